@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// options says what to generate.
+type options struct {
+	types      []string // the types to write methods for
+	trimPrefix string   // removed from each constant's name
+	parse      bool     // also write Parse<Type>
+	json       bool     // also write MarshalJSON and UnmarshalJSON, which need Parse
+	command    string   // the command line, for the header
+}
+
+// enum is a type and its constants, one per value, in value order.
+type enum struct {
+	name     string
+	unsigned bool
+	values   []value
+}
+
+// value is a constant of an enum.
+type value struct {
+	name  string // as declared
+	label string // what String returns
+	val   constant.Value
+	pos   token.Pos
+}
+
+// generate reads the Go package in dir, and returns the source of a file
+// declaring the methods for opts.types.
+func generate(dir string, opts options) ([]byte, error) {
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	pkg, info := check(fset, files)
+
+	var enums []enum
+	for _, name := range opts.types {
+		e, err := findEnum(fset, pkg, info, name, opts.trimPrefix)
+		if err != nil {
+			return nil, err
+		}
+		enums = append(enums, e)
+	}
+	return render(files[0].Name.Name, opts, enums)
+}
+
+// parseDir parses the package's files in dir, leaving out tests and the
+// files enumgen wrote before, which are about to be replaced.
+func parseDir(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if generatedByEnumgen(f) {
+			continue
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// generatedByEnumgen reports whether f starts with enumgen's header.
+func generatedByEnumgen(f *ast.File) bool {
+	return len(f.Comments) > 0 && f.Comments[0].Pos() < f.Package &&
+		strings.HasPrefix(f.Comments[0].Text(), `Code generated by "enumgen`)
+}
+
+// check type-checks files for the values of their constants. Imports
+// are not loaded, which keeps enumgen fast and independent of the
+// build: the errors this causes elsewhere in the package are ignored,
+// since constants of an enum seldom depend on another package.
+func check(fset *token.FileSet, files []*ast.File) (*types.Package, *types.Info) {
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+	conf := types.Config{
+		Importer: noImports{},
+		Error:    func(error) {},
+	}
+	pkg, _ := conf.Check(files[0].Name.Name, fset, files, info)
+	return pkg, info
+}
+
+// noImports fails to import every package.
+type noImports struct{}
+
+func (noImports) Import(path string) (*types.Package, error) {
+	return nil, fmt.Errorf("enumgen does not load imports (%s)", path)
+}
+
+// findEnum returns the type called name in pkg, and its constants.
+func findEnum(fset *token.FileSet, pkg *types.Package, info *types.Info, name, trimPrefix string) (enum, error) {
+	obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return enum{}, fmt.Errorf("no type %s in package %s", name, pkg.Name())
+	}
+	basic, ok := obj.Type().Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsInteger == 0 {
+		return enum{}, fmt.Errorf("type %s is not an integer type", name)
+	}
+	if named, ok := obj.Type().(*types.Named); ok {
+		for i := 0; i < named.NumMethods(); i++ {
+			if m := named.Method(i); m.Name() == "String" {
+				return enum{}, fmt.Errorf("%s: type %s already has a String method; remove it to generate one",
+					fset.Position(m.Pos()), name)
+			}
+		}
+	}
+	e := enum{name: name, unsigned: basic.Info()&types.IsUnsigned != 0}
+
+	for ident, def := range info.Defs {
+		c, ok := def.(*types.Const)
+		if !ok || ident.Name == "_" || !types.Identical(c.Type(), obj.Type()) {
+			continue
+		}
+		e.values = append(e.values, value{
+			name:  c.Name(),
+			label: strings.TrimPrefix(c.Name(), trimPrefix),
+			val:   c.Val(),
+			pos:   c.Pos(),
+		})
+	}
+	if len(e.values) == 0 {
+		return enum{}, fmt.Errorf("no constants of type %s", name)
+	}
+
+	// In value order, keeping the first declared of each value.
+	sort.Slice(e.values, func(i, j int) bool {
+		a, b := e.values[i], e.values[j]
+		if constant.Compare(a.val, token.EQL, b.val) {
+			return a.pos < b.pos
+		}
+		return constant.Compare(a.val, token.LSS, b.val)
+	})
+	unique := e.values[:1]
+	for _, v := range e.values[1:] {
+		if !constant.Compare(v.val, token.EQL, unique[len(unique)-1].val) {
+			unique = append(unique, v)
+		}
+	}
+	e.values = unique
+	return e, nil
+}
+
+// file is the template of the generated file. Its layout need not be
+// tidy: format.Source lays it out as gofmt would.
+var file = template.Must(template.New("file").Parse(`// Code generated by {{printf "%q" .Command}}; DO NOT EDIT.
+
+package {{.Package}}
+
+{{if eq (len .Imports) 1 -}}
+import {{printf "%q" (index .Imports 0)}}
+{{- else -}}
+import (
+{{- range .Imports}}
+	{{printf "%q" .}}
+{{- end}}
+)
+{{- end}}
+{{range .Enums}}{{$e := .}}
+// String returns the name of the {{.Name}} constant equal to v.
+func (v {{.Name}}) String() string {
+	switch v {
+	{{- range .Values}}
+	case {{.Name}}:
+		return {{printf "%q" .Label}}
+	{{- end}}
+	}
+	{{- if .Unsigned}}
+	return "{{.Name}}(" + strconv.FormatUint(uint64(v), 10) + ")"
+	{{- else}}
+	return "{{.Name}}(" + strconv.FormatInt(int64(v), 10) + ")"
+	{{- end}}
+}
+{{if $.Parse}}
+// Parse{{.Name}} returns the {{.Name}} constant whose name, as String
+// returns it, is s in any case.
+func Parse{{.Name}}(s string) ({{.Name}}, error) {
+	switch strings.ToLower(s) {
+	{{- range .Parsed}}
+	case {{printf "%q" .Label}}:
+		return {{.Name}}, nil
+	{{- end}}
+	}
+	return 0, fmt.Errorf("%q is not a valid {{.Name}}", s)
+}
+{{end}}{{if $.JSON}}
+// MarshalJSON encodes v as its name. A value without a constant is an
+// error, since it could not be decoded again.
+func (v {{.Name}}) MarshalJSON() ([]byte, error) {
+	switch v {
+	case {{range $i, $v := .Values}}{{if $i}}, {{end}}{{$v.Name}}{{end}}:
+		return json.Marshal(v.String())
+	}
+	return nil, fmt.Errorf("%v has no name to encode", v)
+}
+
+// UnmarshalJSON decodes a name, as Parse{{.Name}} accepts it.
+func (v *{{.Name}}) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("{{.Name}} should be a string, not %s", data)
+	}
+	parsed, err := Parse{{.Name}}(s)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+{{end}}{{end}}`))
+
+// enumData is an enum as the template sees it.
+type enumData struct {
+	Name     string
+	Unsigned bool
+	Values   []valueData
+	Parsed   []valueData // Label is the lower-case key Parse accepts
+}
+
+// valueData is a constant as the template sees it.
+type valueData struct {
+	Name, Label string
+}
+
+// render returns the generated file, formatted.
+func render(pkgName string, opts options, enums []enum) ([]byte, error) {
+	imports := []string{"strconv"}
+	if opts.parse || opts.json {
+		imports = append(imports, "fmt", "strings")
+	}
+	if opts.json {
+		imports = append(imports, "encoding/json")
+	}
+	sort.Strings(imports)
+
+	var data []enumData
+	for _, e := range enums {
+		d := enumData{Name: e.name, Unsigned: e.unsigned}
+		seen := make(map[string]bool)
+		for _, v := range e.values {
+			d.Values = append(d.Values, valueData{Name: v.name, Label: v.label})
+			// Two labels differing in case only parse as the first.
+			if key := strings.ToLower(v.label); !seen[key] {
+				seen[key] = true
+				d.Parsed = append(d.Parsed, valueData{Name: v.name, Label: key})
+			}
+		}
+		data = append(data, d)
+	}
+
+	var b bytes.Buffer
+	err := file.Execute(&b, map[string]any{
+		"Command": opts.command,
+		"Package": pkgName,
+		"Imports": imports,
+		"Enums":   data,
+		"Parse":   opts.parse || opts.json,
+		"JSON":    opts.json,
+	})
+	if err != nil {
+		return nil, err
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %w", err)
+	}
+	return src, nil
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePackage writes files into a new directory, and returns it.
+func writePackage(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, src := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644))
+	}
+	return dir
+}
+
+const levels = `package logs
+
+import "strings"
+
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	_
+	LevelError
+	LevelFatal = LevelError + 1
+	LevelDefault = LevelInfo
+)
+
+// LevelTrace is untyped, so not a Level.
+const LevelTrace = -1
+
+type Mode uint8
+
+const (
+	ModeRead Mode = 1 << iota
+	ModeWrite
+)
+
+var upper = strings.ToUpper
+`
+
+func TestGenerate(t *testing.T) {
+	dir := writePackage(t, map[string]string{"levels.go": levels})
+	src, err := generate(dir, options{types: []string{"Level", "Mode"}, trimPrefix: "Level", command: "enumgen -type=Level,Mode -trimprefix=Level"})
+	require.NoError(t, err)
+	assert.Equal(t, `// Code generated by "enumgen -type=Level,Mode -trimprefix=Level"; DO NOT EDIT.
+
+package logs
+
+import "strconv"
+
+// String returns the name of the Level constant equal to v.
+func (v Level) String() string {
+	switch v {
+	case LevelDebug:
+		return "Debug"
+	case LevelInfo:
+		return "Info"
+	case LevelWarn:
+		return "Warn"
+	case LevelError:
+		return "Error"
+	case LevelFatal:
+		return "Fatal"
+	}
+	return "Level(" + strconv.FormatInt(int64(v), 10) + ")"
+}
+
+// String returns the name of the Mode constant equal to v.
+func (v Mode) String() string {
+	switch v {
+	case ModeRead:
+		return "ModeRead"
+	case ModeWrite:
+		return "ModeWrite"
+	}
+	return "Mode(" + strconv.FormatUint(uint64(v), 10) + ")"
+}
+`, string(src))
+}
+
+func TestGenerateErrors(t *testing.T) {
+	dir := writePackage(t, map[string]string{
+		"levels.go": levels,
+		"kinds.go": `package logs
+
+type Kind int
+
+const KindCar Kind = 1
+
+func (k Kind) String() string { return "car" }
+
+type Name string
+
+const NameA Name = "a"
+
+type Empty int
+`,
+		"kinds_test.go": "package logs\n\nconst KindTest Kind = 2\n",
+	})
+	tests := map[string]string{
+		"Missing": "no type Missing in package logs",
+		"Name":    "type Name is not an integer type",
+		"Empty":   "no constants of type Empty",
+		"Kind":    "kinds.go:7:15: type Kind already has a String method; remove it to generate one",
+	}
+	for typ, want := range tests {
+		_, err := generate(dir, options{types: []string{typ}})
+		assert.ErrorContains(t, err, want, typ)
+	}
+
+	_, err := generate(t.TempDir(), options{types: []string{"Level"}})
+	assert.ErrorContains(t, err, "no Go files")
+}
+
+func TestRun(t *testing.T) {
+	dir := writePackage(t, map[string]string{"levels.go": levels})
+	require.NoError(t, run([]string{"-type=Level", dir}, io.Discard))
+	first, err := os.ReadFile(filepath.Join(dir, "level_string.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(first), `case LevelDebug:
+		return "LevelDebug"`)
+
+	require.NoError(t, run([]string{"-type=Level", dir}, io.Discard), "the file it wrote before is left out")
+	again, err := os.ReadFile(filepath.Join(dir, "level_string.go"))
+	require.NoError(t, err)
+	assert.Equal(t, string(first), string(again))
+
+	out := filepath.Join(dir, "names.go")
+	require.NoError(t, run([]string{"-type=Mode", "-output", out, dir}, io.Discard))
+	assert.FileExists(t, out)
+
+	assert.Error(t, run(nil, io.Discard), "-type is required")
+	assert.Error(t, run([]string{"-type=Level", dir, dir}, io.Discard))
+}
+
+func TestGenerateParseAndJSON(t *testing.T) {
+	dir := writePackage(t, map[string]string{"levels.go": levels})
+	src, err := generate(dir, options{types: []string{"Mode"}, parse: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(src), `import (
+	"fmt"
+	"strconv"
+	"strings"
+)`)
+	assert.Contains(t, string(src), `func ParseMode(s string) (Mode, error) {
+	switch strings.ToLower(s) {
+	case "moderead":
+		return ModeRead, nil
+	case "modewrite":
+		return ModeWrite, nil
+	}
+	return 0, fmt.Errorf("%q is not a valid Mode", s)
+}`)
+	assert.NotContains(t, string(src), "MarshalJSON")
+
+	src, err = generate(dir, options{types: []string{"Level"}, trimPrefix: "Level", json: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(src), `"encoding/json"`)
+	assert.Contains(t, string(src), "func ParseLevel(s string) (Level, error)", "-json implies -parse")
+	assert.Contains(t, string(src), `case LevelDebug, LevelInfo, LevelWarn, LevelError, LevelFatal:
+		return json.Marshal(v.String())`)
+	assert.Contains(t, string(src), "func (v *Level) UnmarshalJSON(data []byte) error {")
+}
+
+// usesLevels exercises the generated methods of levels, in the package
+// they were generated for.
+const usesLevels = `package logs
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGenerated(t *testing.T) {
+	if got := LevelWarn.String(); got != "Warn" {
+		t.Errorf("LevelWarn.String() = %q", got)
+	}
+	if got := Level(9).String(); got != "Level(9)" {
+		t.Errorf("Level(9).String() = %q", got)
+	}
+	if got, err := ParseLevel("FATAL"); got != LevelFatal || err != nil {
+		t.Errorf("ParseLevel(FATAL) = %v, %v", got, err)
+	}
+	if _, err := ParseLevel("Trace"); err == nil {
+		t.Error("ParseLevel(Trace) succeeded")
+	}
+
+	var config struct{ Level Level }
+	data, err := json.Marshal(struct{ Level Level }{LevelError})
+	if string(data) != ` + "`" + `{"Level":"Error"}` + "`" + ` || err != nil {
+		t.Errorf("Marshal = %s, %v", data, err)
+	}
+	if err := json.Unmarshal(data, &config); config.Level != LevelError || err != nil {
+		t.Errorf("Unmarshal = %v, %v", config.Level, err)
+	}
+	if err := json.Unmarshal([]byte(` + "`" + `{"Level":2}` + "`" + `), &config); err == nil {
+		t.Error("Unmarshal accepted a number")
+	}
+	if _, err := json.Marshal(Level(9)); err == nil {
+		t.Error("Marshal accepted a value without a name")
+	}
+}
+`
+
+// TestGeneratedCode compiles the generated file with the package it was
+// generated for, and runs a test against it.
+func TestGeneratedCode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and tests a package with the go command")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("no go command")
+	}
+	dir := writePackage(t, map[string]string{
+		"go.mod":         "module logs\n\ngo 1.21\n",
+		"levels.go":      levels,
+		"levels_test.go": usesLevels,
+	})
+	require.NoError(t, run([]string{"-type=Level,Mode", "-trimprefix=Level", "-json", dir}, io.Discard))
+
+	cmd := exec.Command(goTool, "test", ".")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	assert.NoError(t, err, "%s", out)
+}
+
+// TestCommittedFilesAreCurrent generates every file enumgen wrote in the
+// course again, from the command in its header, and compares the two: a
+// constant added without running go generate fails here.
+func TestCommittedFilesAreCurrent(t *testing.T) {
+	var found int
+	err := filepath.WalkDir(filepath.Join("..", ".."), func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, "_string.go") {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		header, _ := bufio.NewReader(f).ReadString('\n')
+		f.Close()
+		command, ok := strings.CutPrefix(header, `// Code generated by "enumgen `)
+		if !ok {
+			return nil
+		}
+		found++
+		command, _, _ = strings.Cut(command, `"`)
+
+		out := filepath.Join(t.TempDir(), "out.go")
+		args := append(strings.Fields(command), "-output", out, filepath.Dir(path))
+		if !assert.NoError(t, run(args, io.Discard), path) {
+			return nil
+		}
+		committed, err := os.ReadFile(path)
+		require.NoError(t, err)
+		generated, err := os.ReadFile(out)
+		require.NoError(t, err)
+		// The headers differ in the -output flag.
+		_, want, _ := strings.Cut(string(committed), "\n")
+		_, got, _ := strings.Cut(string(generated), "\n")
+		assert.Equal(t, want, got, "%s is out of date: run go generate in its directory", path)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, found, "no files generated by enumgen")
+}
@@ -0,0 +1,89 @@
+// Command enumgen writes a String method for named integer types, from
+// the constants declared with them, and on request a Parse function and
+// JSON methods that use the same names. It is the course's own, smaller
+// stringer, used by the code generation module and by the enums of the
+// shared packages: put a directive next to the type,
+//
+//	//go:generate go run github.com/TheAnarchoX/LearningGoTheHardWay/cmd/enumgen -type=Level
+//
+// and "go generate" (or "learngo generate <module>") runs it in the
+// directory of that file, where it writes level_string.go.
+//
+// Usage:
+//
+//	enumgen -type T[,U...] [-trimprefix prefix] [-parse] [-json] [-output file] [dir]
+//
+// Only constants of the type itself count: an untyped constant with the
+// right value is not part of the enum. Constants sharing a value print as
+// the first one declared, and values without a constant print as T(n).
+//
+// With -parse, ParseT returns the constant with a given name, ignoring
+// case. With -json, T encodes to JSON as its name and decodes from one,
+// so a config file says "diesel" rather than 2; a value without a name
+// is an error to encode, since it could not be decoded again.
+//
+// The source is meant to be read: it is a whole generator, built from
+// go/parser, go/types, and text/template.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "enumgen: %v\n", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run parses args, and writes the generated file.
+func run(args []string, stderr io.Writer) error {
+	fs := flag.NewFlagSet("enumgen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	typeNames := fs.String("type", "", "comma-separated list of type names (required)")
+	trimPrefix := fs.String("trimprefix", "", "prefix to remove from the constant names")
+	parse := fs.Bool("parse", false, "also write Parse<Type>, which turns a name back into a constant")
+	json := fs.Bool("json", false, "also write MarshalJSON and UnmarshalJSON, which encode names (implies -parse)")
+	output := fs.String("output", "", "output file name (default <type>_string.go, in dir)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: enumgen -type T[,U...] [-trimprefix prefix] [-parse] [-json] [-output file] [dir]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *typeNames == "" || fs.NArg() > 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	dir := "."
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+	types := strings.Split(*typeNames, ",")
+
+	src, err := generate(dir, options{
+		types:      types,
+		trimPrefix: *trimPrefix,
+		parse:      *parse,
+		json:       *json,
+		command:    "enumgen " + strings.Join(args, " "),
+	})
+	if err != nil {
+		return err
+	}
+	name := *output
+	if name == "" {
+		name = filepath.Join(dir, strings.ToLower(types[0])+"_string.go")
+	}
+	return os.WriteFile(name, src, 0o644)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/achievements"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+)
+
+// unlockBadges unlocks the badges the store has earned and announces the
+// new ones. The caller saves the store.
+func (a *app) unlockBadges(c *course.Course, store *progress.Store) {
+	for _, award := range achievements.Update(c, store) {
+		fmt.Fprintf(a.stdout, a.T("\nAchievement unlocked: %s - %s\n"), award.Badge.Name, award.Badge.Description)
+	}
+}
+
+// badges lists every badge, unlocked ones with their date.
+func (a *app) badges(args []string) error {
+	fs := a.flagSet("badges")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errUsage
+	}
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	store, err := a.openStore()
+	if err != nil {
+		return err
+	}
+	// Badges earned before this learngo knew about them are unlocked now.
+	if len(achievements.Update(c, store)) > 0 {
+		if err := store.Save(); err != nil {
+			return err
+		}
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	unlocked := 0
+	for _, b := range achievements.Badges {
+		when := "locked"
+		if at, ok := store.Unlocked(b.ID); ok {
+			when = formatDate(at)
+			unlocked++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", b.Name, when, b.Description)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, a.T("\n%d/%d badges unlocked.\n"), unlocked, len(achievements.Badges))
+	return nil
+}
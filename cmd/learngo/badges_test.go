@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBadges(t *testing.T) {
+	a, stdout, _ := reportCourse(t)
+	require.NoError(t, a.main([]string{"badges"}))
+	out := stdout()
+	assert.Regexp(t, `First Green +2026-03-01 +Make an exercise's tests pass`, out, "unlocked from earlier progress")
+	assert.Regexp(t, `Race Free +locked`, out)
+	assert.Contains(t, out, "/5 badges unlocked.")
+
+	store, err := a.openStore()
+	require.NoError(t, err)
+	_, ok := store.Unlocked("first-green")
+	assert.True(t, ok, "newly found badges are saved")
+
+	assert.ErrorIs(t, a.main([]string{"badges", "extra"}), errUsage)
+}
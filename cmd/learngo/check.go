@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/grader"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/recommend"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vet"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/watch"
+)
+
+// check grades one module and records an attempt for each exercise.
+func (a *app) check(args []string) error {
+	fs := a.flagSet("check")
+	verbose := fs.Bool("v", false, "print the output of failing tests")
+	race := fs.Bool("race", false, "run the tests with the race detector")
+	fuzz := fs.Duration("fuzz", 0, "fuzz each fuzz target of the passing exercises for this long")
+	integration := fs.Bool("integration", false, "run the integration tests too: those that need the network, a database, or other programs")
+	runVet := fs.Bool("vet", true, "run go vet and explain its findings")
+	watchFiles := fs.Bool("watch", false, "check again each time a file in the exercises changes, until interrupted")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	m, err := c.Module(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if len(m.Exercises) == 0 {
+		return fmt.Errorf(a.T("%s has no exercises yet"), m.ID)
+	}
+	store, err := a.openStore()
+	if err != nil {
+		return err
+	}
+	if err := a.checkPrerequisites(c, store, m); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	once := func() error {
+		return a.checkOnce(ctx, c, store, m, checkFlags{verbose: *verbose, race: *race, fuzz: *fuzz, integration: *integration, vet: *runVet})
+	}
+	if *watchFiles {
+		return a.watch(ctx, filepath.Join(m.Dir, "exercises"), once)
+	}
+	return once()
+}
+
+// checkFlags are the flags of check that apply to each run.
+type checkFlags struct {
+	verbose, race, integration, vet bool
+	fuzz                            time.Duration
+}
+
+// checkOnce grades m once, prints the results, and records them in store.
+func (a *app) checkOnce(ctx context.Context, c *course.Course, store *progress.Store, m *course.Module, flags checkFlags) error {
+	if flags.race || m.Race {
+		fmt.Fprintf(a.stdout, a.T("Testing %s with the race detector...\n"), m.ID)
+	} else {
+		fmt.Fprintf(a.stdout, a.T("Testing %s...\n"), m.ID)
+	}
+	opts := grader.Options{Race: flags.race, Env: seedEnv(store), Fuzz: flags.fuzz, Integration: flags.integration}
+	run, err := grader.GradeWith(ctx, m, opts)
+	if err != nil {
+		return err
+	}
+	if m.Race && !run.Race {
+		fmt.Fprintf(a.stderr, "learngo: "+a.T("warning: %s\n"), a.T("the race detector is not available, so data races go unnoticed"))
+	}
+	switch {
+	case run.RaceUnavailable:
+		fmt.Fprintf(a.stdout, a.T("\nThe bugs of %s only show under the race detector, which is not available:\n%s\n\n"), m.ID, run.BuildOutput)
+	case run.BuildOutput != "":
+		fmt.Fprintf(a.stdout, a.T("\nThe exercises do not compile:\n%s\n\n"), run.BuildOutput)
+	}
+
+	summary := recordRun(store, m, run, a.now())
+	skipped := false
+	for _, res := range run.Results {
+		races := res.Races()
+		skipped = skipped || len(res.Skipped()) > 0
+		mark := "FAIL"
+		switch {
+		case res.Passed:
+			mark = "ok  "
+		case res.Blocked != "":
+			mark = "WAIT" // a milestone after one that fails
+		case len(res.Skipped()) > 0 && len(res.Failed()) == 0:
+			mark = "SKIP" // what ran passed, but the skipped tests may not
+		case len(races) > 0:
+			mark = "RACE" // the tests may well have passed otherwise
+		case fuzzFailed(res):
+			mark = "FUZZ" // as are fuzz targets
+		case len(res.Benchmarks) > 0:
+			mark = "SLOW"
+		}
+		name := strings.TrimPrefix(res.Exercise, m.ID+"/")
+		fmt.Fprintf(a.stdout, "%s %s\n", mark, name)
+		if res.Blocked != "" {
+			fmt.Fprintf(a.stdout, "     "+a.T("passes once %s does\n"), strings.TrimPrefix(res.Blocked, m.ID+"/"))
+		}
+		for _, s := range grader.Summaries(races, filepath.Join(m.Dir, "exercises")) {
+			fmt.Fprintf(a.stdout, "     %s\n", s)
+		}
+		for _, f := range res.Fuzz {
+			if f.Input != "" {
+				fmt.Fprintf(a.stdout, "     "+a.T("%s failed on the input in %s\n"), f.Name, f.Input)
+			}
+			if flags.verbose && !f.Passed {
+				fmt.Fprint(a.stdout, indent(f.Output+"\n", "     "))
+			}
+		}
+		for _, b := range res.Benchmarks {
+			fmt.Fprintf(a.stdout, "     %s\n", b)
+		}
+		if flags.verbose && !res.Passed {
+			for _, t := range res.Tests {
+				if !t.Passed && !t.Skipped {
+					fmt.Fprint(a.stdout, indent(t.Output, "     "))
+				}
+			}
+		}
+	}
+	if flags.verbose && run.BenchOutput != "" {
+		fmt.Fprintf(a.stdout, a.T("\nThe benchmarks failed:\n%s\n"), indent(run.BenchOutput, "     "))
+	}
+	fmt.Fprintf(a.stdout, a.T("\n%d/%d exercises pass in %s.\n"), len(summary.Passed), summary.Total(), m.ID)
+	if skipped && !run.Integration {
+		fmt.Fprintf(a.stdout, a.T("Some tests were skipped; run \"learngo check -integration %s\" to run the full suite.\n"), m.ID)
+	}
+	if flags.vet && run.BuildOutput == "" {
+		findings, err := vet.Run(ctx, filepath.Join(m.Dir, "exercises"), vet.Options{})
+		if err != nil {
+			fmt.Fprintf(a.stderr, "learngo: %v\n", err)
+		} else if len(findings) > 0 {
+			fmt.Fprintln(a.stdout)
+			a.printFindings(a.stdout, c, findings)
+		}
+	}
+	a.unlockBadges(c, store)
+	if err := store.Save(); err != nil {
+		return err
+	}
+	a.pushReport(ctx, store, m)
+	return nil
+}
+
+// watchDebounce is how long the exercises must stay unchanged before
+// "check -watch" tests them again: long enough for an editor to finish
+// saving, or "go generate" to finish writing.
+var watchDebounce = 300 * time.Millisecond
+
+// watch calls check, and again each time a file under dir changes, until
+// ctx is done. Hidden files, such as editors' swap files, do not count.
+func (a *app) watch(ctx context.Context, dir string, check func() error) error {
+	w := &watch.Watcher{Paths: []string{dir}, Debounce: watchDebounce, Skip: watch.SkipHidden}
+	if _, err := w.Poll(); err != nil { // before the check, to see changes made during it
+		return err
+	}
+	for {
+		if err := check(); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, a.T("\nWatching %s for changes; press Ctrl+C to stop.\n"), dir)
+		events, err := w.Wait(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		names := make([]string, len(events))
+		for i, e := range events {
+			names[i] = e.Path
+			if rel, err := filepath.Rel(dir, e.Path); err == nil {
+				names[i] = rel
+			}
+		}
+		fmt.Fprintf(a.stdout, a.T("\nChanged: %s\n\n"), strings.Join(names, ", "))
+	}
+}
+
+// fuzzFailed reports whether fuzzing found an input that fails one of the
+// exercise's fuzz targets.
+func fuzzFailed(res *grader.Result) bool {
+	for _, f := range res.Fuzz {
+		if !f.Passed {
+			return true
+		}
+	}
+	return false
+}
+
+// seedEnv passes the learner's seed to exercise tests, so their random
+// inputs and constants differ from everyone else's. A LEARNGO_SEED already
+// in the environment wins, which makes a failure easy to replay.
+func seedEnv(store *progress.Store) []string {
+	if _, ok := seed.Value(); ok {
+		return nil
+	}
+	n, _ := store.LearnerSeed(rand.Int63)
+	return []string{fmt.Sprintf("%s=%d", seed.EnvVar, n)}
+}
+
+// recordRun records an attempt for every exercise in run and stores the
+// run as the module's latest.
+func recordRun(store *progress.Store, m *course.Module, run *grader.Run, at time.Time) progress.Run {
+	summary := progress.Run{At: at, Duration: run.Duration, BuildFailed: run.BuildOutput != "", Race: run.Race}
+	for _, res := range run.Results {
+		store.RecordAttempt(res.Exercise, res.Passed, at)
+		if res.Passed {
+			summary.Passed = append(summary.Passed, res.Exercise)
+		} else {
+			summary.Failed = append(summary.Failed, res.Exercise)
+		}
+	}
+	recommend.RecordMistakes(store, m, run, at)
+	store.RecordRun(run.Module, summary)
+	return summary
+}
+
+// indent prefixes every non-empty line of s.
+func indent(s, prefix string) string {
+	lines := strings.SplitAfter(s, "\n")
+	for i, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			lines[i] = prefix + l
+		}
+	}
+	return strings.Join(lines, "")
+}
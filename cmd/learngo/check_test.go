@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRecordsAttempts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	a, stdout, _ := testApp(t, map[string]string{
+		"modules/01-demo/exercises/add.go":      "package exercises\n\nfunc Add(a, b int) int { return a + b }\n",
+		"modules/01-demo/exercises/add_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {\n\tif Add(1, 2) != 3 {\n\t\tt.Fatal(\"Add(1, 2) is wrong\")\n\t}\n}\n",
+		"modules/01-demo/exercises/sub.go":      "package exercises\n\nfunc Sub(a, b int) int { return a + b }\n",
+		"modules/01-demo/exercises/sub_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestSub(t *testing.T) {\n\tif Sub(3, 1) != 2 {\n\t\tt.Fatal(\"Sub(3, 1) is wrong\")\n\t}\n}\n",
+	})
+
+	require.NoError(t, a.main([]string{"check", "-v", "01"}))
+	out := stdout.String()
+	assert.Contains(t, out, "ok   add")
+	assert.Contains(t, out, "FAIL sub")
+	assert.Contains(t, out, "Sub(3, 1) is wrong")
+	assert.Contains(t, out, "1/2 exercises pass in 01-demo.")
+	assert.Contains(t, out, "Achievement unlocked: First Green")
+
+	store, err := a.openStore()
+	require.NoError(t, err)
+	assert.True(t, store.Get("01-demo/add").Passed)
+	assert.Equal(t, testNow, store.Get("01-demo/add").CompletedAt)
+	assert.Equal(t, 1, store.Get("01-demo/sub").Attempts)
+	assert.False(t, store.Get("01-demo/sub").Passed)
+	run, ok := store.LastRun("01-demo")
+	require.True(t, ok)
+	assert.Equal(t, []string{"01-demo/add"}, run.Passed)
+	assert.Equal(t, []string{"01-demo/sub"}, run.Failed)
+}
+
+func TestCheckWatch(t *testing.T) {
+	a, stdout, _ := testApp(t, map[string]string{})
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runs := 0
+	check := func() error {
+		runs++
+		switch runs {
+		case 1: // saved while the first check runs
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "add.go"), []byte("package exercises"), 0o644))
+		case 2:
+			require.NoError(t, os.WriteFile(filepath.Join(dir, ".add.go.swp"), []byte("x"), 0o644))
+			time.AfterFunc(time.Second, cancel)
+		}
+		return nil
+	}
+	require.NoError(t, a.watch(ctx, dir, check))
+	assert.Equal(t, 2, runs, "an editor's swap file is no change")
+	assert.Contains(t, stdout.String(), "Changed: add.go\n")
+	assert.Contains(t, stdout.String(), "press Ctrl+C to stop")
+}
+
+func TestCheckExplainsVetFindings(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test and go vet")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	a, stdout, _ := testApp(t, map[string]string{
+		"modules/01-basics/README.md":                   "# Module 01: Basics\n",
+		"modules/03-concurrency/README.md":              "# Module 03: Concurrency\n",
+		"modules/03-concurrency/exercises/lock.go":      "package exercises\n\nimport \"sync\"\n\ntype Box struct {\n\tmu sync.Mutex\n\tv  int\n}\n\nfunc (b Box) Get() int { return b.v }\n",
+		"modules/03-concurrency/exercises/lock_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestGet(t *testing.T) {\n\tif (Box{v: 1}).Get() != 1 {\n\t\tt.Fatal(\"wrong\")\n\t}\n}\n",
+	})
+	require.NoError(t, a.main([]string{"check", "03"}))
+	out := stdout.String()
+	assert.Contains(t, out, "go vet found 1 problem:")
+	assert.Contains(t, out, "lock.go:10:9: Get passes lock by value")
+	assert.Contains(t, out, "[copylocks]")
+	assert.Contains(t, out, "    You copied a value that contains a sync.Mutex")
+	assert.NotContains(t, out, "See module", "the course has no 03-concurrency-fundamentals")
+
+	stdout.Reset()
+	require.NoError(t, a.main([]string{"check", "-vet=false", "03"}))
+	assert.NotContains(t, stdout.String(), "go vet")
+}
+
+func TestCheckRace(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test -race")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	a, stdout, stderr := testApp(t, map[string]string{
+		"modules/03-concurrency/module.json":             `{"race": true}`,
+		"modules/03-concurrency/exercises/count.go":      "package exercises\n\nimport \"sync\"\n\nfunc Count(n int) int {\n\ttotal := 0\n\tvar wg sync.WaitGroup\n\tfor i := 0; i < n; i++ {\n\t\twg.Add(1)\n\t\tgo func() {\n\t\t\tdefer wg.Done()\n\t\t\ttotal++\n\t\t}()\n\t}\n\twg.Wait()\n\treturn total\n}\n",
+		"modules/03-concurrency/exercises/count_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestCount(t *testing.T) {\n\tCount(100)\n}\n",
+	})
+	require.NoError(t, a.main([]string{"check", "-vet=false", "03"}))
+	if stderr.Len() > 0 {
+		t.Skip("the race detector is not available")
+	}
+	out := stdout.String()
+	assert.Contains(t, out, "Testing 03-concurrency with the race detector...")
+	assert.Contains(t, out, "RACE count")
+	assert.Regexp(t, `\n     (read|write) in Count\.func1 at count\.go:12 races with `, out)
+}
+
+func TestCheckStaged(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	a, stdout, _ := testApp(t, map[string]string{
+		"modules/54-project/module.json":                  `{"staged": true}`,
+		"modules/54-project/exercises/milestone1.go":      "package exercises\n\nfunc Sub(a, b int) int { return a + b }\n",
+		"modules/54-project/exercises/milestone1_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestSub(t *testing.T) {\n\tif Sub(3, 1) != 2 {\n\t\tt.Fatal(\"Sub(3, 1) is wrong\")\n\t}\n}\n",
+		"modules/54-project/exercises/milestone2.go":      "package exercises\n",
+		"modules/54-project/exercises/milestone2_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestTwo(t *testing.T) {}\n",
+	})
+	require.NoError(t, a.main([]string{"check", "-vet=false", "54"}))
+	out := stdout.String()
+	assert.Contains(t, out, "FAIL milestone1\nWAIT milestone2\n     passes once milestone1 does\n")
+	assert.Contains(t, out, "0/2 exercises pass in 54-project.")
+}
+
+func TestCheckNoExercises(t *testing.T) {
+	a, stdout, _ := testApp(t, map[string]string{
+		"modules/02-types/README.md": "# Module 02: Types\n",
+	})
+	assert.EqualError(t, a.main([]string{"check", "02"}), "02-types has no exercises yet")
+	assert.Empty(t, stdout.String(), "nothing ran")
+}
+
+func TestCheckUsage(t *testing.T) {
+	a, _, stderr := testApp(t, map[string]string{})
+	assert.ErrorIs(t, a.main([]string{"check"}), errUsage)
+	assert.Contains(t, stderr.String(), "Usage: learngo check [-v] [-race] [-fuzz=duration] [-integration] [-vet=false] [-watch] <module>")
+}
+
+func TestSeedEnv(t *testing.T) {
+	t.Setenv(seed.EnvVar, "")
+	store := &progress.Store{Seed: 99}
+	assert.Equal(t, []string{"LEARNGO_SEED=99"}, seedEnv(store))
+
+	t.Setenv(seed.EnvVar, "5")
+	assert.Empty(t, seedEnv(store), "an explicit seed is inherited")
+}
+
+func TestCheckIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	a, stdout, _ := testApp(t, map[string]string{
+		"modules/01-basics/exercises/shell.go":      "package exercises\n",
+		"modules/01-basics/exercises/shell_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestShell(t *testing.T) {\n\tif testing.Short() {\n\t\tt.Skip(\"runs sh\")\n\t}\n}\n",
+		"modules/01-basics/exercises/some.go":       "package exercises\n",
+		"modules/01-basics/exercises/some_test.go":  "package exercises\n\nimport \"testing\"\n\nfunc TestUnit(t *testing.T) {}\n\nfunc TestSomeShell(t *testing.T) {\n\tif testing.Short() {\n\t\tt.Skip(\"runs sh\")\n\t}\n}\n",
+	})
+	require.NoError(t, a.main([]string{"check", "-vet=false", "01"}))
+	assert.Contains(t, stdout.String(), "SKIP shell")
+	assert.Contains(t, stdout.String(), "SKIP some", "a skipped test may be the one that fails")
+	assert.Contains(t, stdout.String(), "0/2 exercises pass")
+	assert.Contains(t, stdout.String(), `run "learngo check -integration 01-basics" to run the full suite`)
+
+	stdout.Reset()
+	require.NoError(t, a.main([]string{"check", "-vet=false", "-integration", "01"}))
+	assert.Contains(t, stdout.String(), "ok   shell")
+	assert.Contains(t, stdout.String(), "ok   some")
+	assert.NotContains(t, stdout.String(), "skipped")
+}
@@ -0,0 +1,414 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/classroom"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/similarity"
+)
+
+// Environment variables that opt a learner into a classroom.
+const (
+	classroomEnv = "LEARNGO_CLASSROOM"       // instructor server URL
+	learnerEnv   = "LEARNGO_LEARNER"         // name shown to the instructor; $USER if unset
+	tokenEnv     = "LEARNGO_CLASSROOM_TOKEN" // shared secret, if the server requires one
+)
+
+// pushReport sends the learner's standing on module m to the classroom
+// server, if they joined one. Failures are only warnings: an unreachable
+// server must not get in the way of doing the exercises.
+func (a *app) pushReport(ctx context.Context, store *progress.Store, m *course.Module) {
+	url := os.Getenv(classroomEnv)
+	if url == "" {
+		return
+	}
+	learner := os.Getenv(learnerEnv)
+	if learner == "" {
+		learner = os.Getenv("USER")
+	}
+	if learner == "" {
+		fmt.Fprintf(a.stderr, "learngo: "+a.T("not reporting to the classroom: set %s to your name\n"), learnerEnv)
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	r := classroomReport(learner, store, m, a.now())
+	if err := classroom.Push(ctx, http.DefaultClient, url, os.Getenv(tokenEnv), r); err != nil {
+		fmt.Fprintf(a.stderr, "learngo: "+a.T("reporting to the classroom: %v\n"), err)
+	}
+}
+
+// classroomReport builds the report for one module from the progress store.
+// Completed exercises carry the learner's file for the similarity report.
+func classroomReport(learner string, store *progress.Store, m *course.Module, at time.Time) classroom.Report {
+	r := classroom.Report{Learner: learner, Module: m.ID, At: at}
+	for _, e := range m.Exercises {
+		p := store.Get(e.ID)
+		ex := classroom.Exercise{
+			ID:         e.ID,
+			Passed:     p.Passed,
+			Completed:  !p.CompletedAt.IsZero(),
+			Attempts:   p.Attempts,
+			FailedRuns: p.FailedRuns,
+			HintsUsed:  p.HintsUsed,
+		}
+		if ex.Completed {
+			if src, err := os.ReadFile(e.File); err == nil {
+				ex.Source = string(src)
+			}
+		}
+		r.Exercises = append(r.Exercises, ex)
+	}
+	return r
+}
+
+// classroom runs the instructor's server.
+func (a *app) classroom(args []string) error {
+	fs := a.flagSet("classroom")
+	addr := fs.String("addr", ":7071", "address to listen on; learners must be able to reach it")
+	data := fs.String("data", "", "file to keep the cohort's results in (default .learngo/classroom.json)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	if *data == "" {
+		*data = filepath.Join(c.Root, ".learngo", "classroom.json")
+	}
+	cohort, err := classroom.Open(*data)
+	if err != nil {
+		return err
+	}
+	s, err := newClassroomServer(a, c, cohort, os.Getenv(tokenEnv))
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(a.stdout, a.T("Classroom running at http://%s (Ctrl-C to stop)\n"), ln.Addr())
+	fmt.Fprintf(a.stdout, a.T("Learners join with: export %s=http://<this host>:%d\n"), classroomEnv, ln.Addr().(*net.TCPAddr).Port)
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// classroomServer serves the cohort dashboard and accepts learners'
+// reports.
+type classroomServer struct {
+	app    *app
+	course *course.Course
+	cohort *classroom.Cohort
+	token  string // required bearer token; none if empty
+	pages  *template.Template
+	mux    *http.ServeMux
+}
+
+func newClassroomServer(a *app, c *course.Course, cohort *classroom.Cohort, token string) (*classroomServer, error) {
+	funcs := template.FuncMap{
+		"date": formatDate,
+		"heat": heatClass,
+		"T":    a.T,
+		"lang": a.msg.Lang,
+	}
+	pages, err := template.New("").Funcs(funcs).ParseFS(templateFS, "templates/serve/layout.html", "templates/classroom/*.html")
+	if err != nil {
+		return nil, err
+	}
+	s := &classroomServer{app: a, course: c, cohort: cohort, token: token, pages: pages, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/", s.handleCohort)
+	s.mux.HandleFunc("/heatmap", s.handleHeatmap)
+	s.mux.HandleFunc("/grades.csv", s.handleGrades)
+	s.mux.HandleFunc("/similarity", s.handleSimilarity)
+	s.mux.HandleFunc(classroom.ReportsPath, s.handleReport)
+	return s, nil
+}
+
+func (s *classroomServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handleReport accepts POST /reports from a learner's learngo.
+func (s *classroomServer) handleReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+	if s.token != "" && r.Header.Get("Authorization") != "Bearer "+s.token {
+		http.Error(w, "wrong or missing classroom token", http.StatusUnauthorized)
+		return
+	}
+	var report classroom.Report
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&report); err != nil {
+		http.Error(w, "bad report: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := report.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := s.course.Module(report.Module); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.cohort.Add(report); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// gradedModules returns the modules that have exercises, in course order.
+func (s *classroomServer) gradedModules() []*course.Module {
+	var modules []*course.Module
+	for _, m := range s.course.Modules {
+		if len(m.Exercises) > 0 {
+			modules = append(modules, m)
+		}
+	}
+	return modules
+}
+
+func exerciseIDs(m *course.Module) []string {
+	ids := make([]string, len(m.Exercises))
+	for i, e := range m.Exercises {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+// learnerRow is one learner on the cohort dashboard and in the grades
+// export.
+type learnerRow struct {
+	Name      string
+	LastSeen  time.Time
+	Modules   []moduleGrade
+	Completed int
+	Total     int
+}
+
+func (r learnerRow) Percent() int { return percent(r.Completed, r.Total) }
+
+// moduleGrade is a learner's completion of one module.
+type moduleGrade struct {
+	Completed int
+	Total     int
+}
+
+func (g moduleGrade) Percent() int { return percent(g.Completed, g.Total) }
+
+func (s *classroomServer) learnerRows() []learnerRow {
+	modules := s.gradedModules()
+	var rows []learnerRow
+	for _, l := range s.cohort.Learners() {
+		row := learnerRow{Name: l.Name, LastSeen: l.LastSeen}
+		for _, m := range modules {
+			g := moduleGrade{Completed: l.Completed(exerciseIDs(m)), Total: len(m.Exercises)}
+			row.Modules = append(row.Modules, g)
+			row.Completed += g.Completed
+			row.Total += g.Total
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (s *classroomServer) handleCohort(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	s.render(w, "cohort.html", map[string]any{
+		"Modules":  s.gradedModules(),
+		"Learners": s.learnerRows(),
+	})
+}
+
+// heatRow is one exercise in the failure heatmap.
+type heatRow struct {
+	Exercise *course.Exercise
+	Cells    []heatCell // one per learner
+	Failed   int        // failed runs summed over the cohort
+}
+
+type heatCell struct {
+	Reported   bool
+	Completed  bool
+	FailedRuns int
+}
+
+// heatClass buckets failed runs into the heatmap's colors.
+func heatClass(failedRuns int) string {
+	switch {
+	case failedRuns == 0:
+		return "heat0"
+	case failedRuns <= 2:
+		return "heat1"
+	case failedRuns <= 5:
+		return "heat2"
+	case failedRuns <= 9:
+		return "heat3"
+	}
+	return "heat4"
+}
+
+// handleHeatmap serves GET /heatmap[?module=ID]: failed runs per exercise
+// and learner, to spot the exercises the whole cohort struggles with.
+func (s *classroomServer) handleHeatmap(w http.ResponseWriter, r *http.Request) {
+	modules := s.gradedModules()
+	if id := r.URL.Query().Get("module"); id != "" {
+		m, err := s.course.Module(id)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		modules = []*course.Module{m}
+	}
+	learners := s.cohort.Learners()
+	var rows []heatRow
+	for _, m := range modules {
+		for _, e := range m.Exercises {
+			row := heatRow{Exercise: e}
+			for _, l := range learners {
+				var cell heatCell
+				if p, ok := l.Exercises[e.ID]; ok {
+					cell = heatCell{Reported: true, Completed: p.Completed, FailedRuns: p.FailedRuns}
+				}
+				row.Failed += cell.FailedRuns
+				row.Cells = append(row.Cells, cell)
+			}
+			rows = append(rows, row)
+		}
+	}
+	s.render(w, "heatmap.html", map[string]any{
+		"Learners": learners,
+		"Rows":     rows,
+	})
+}
+
+// defaultSimilarity is the score from which the similarity report lists a
+// pair of submissions.
+const defaultSimilarity = 0.7
+
+// handleSimilarity serves GET /similarity[?module=ID][&threshold=0.7]: the
+// pairs of learners whose completed exercise files are suspiciously alike.
+// Each exercise's file on the server, which the instructor has not solved,
+// is the starting point left out of the comparison.
+func (s *classroomServer) handleSimilarity(w http.ResponseWriter, r *http.Request) {
+	modules := s.gradedModules()
+	if id := r.URL.Query().Get("module"); id != "" {
+		m, err := s.course.Module(id)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		modules = []*course.Module{m}
+	}
+	threshold := defaultSimilarity
+	if v := r.URL.Query().Get("threshold"); v != "" {
+		t, err := strconv.ParseFloat(v, 64)
+		if err != nil || t < 0 || t > 1 {
+			http.Error(w, "threshold must be a number from 0 to 1", http.StatusBadRequest)
+			return
+		}
+		threshold = t
+	}
+
+	learners := s.cohort.Learners()
+	var subs []similarity.Submission
+	bases := make(map[string][]byte)
+	for _, m := range modules {
+		for _, e := range m.Exercises {
+			if src, err := os.ReadFile(e.File); err == nil {
+				bases[e.ID] = src
+			}
+			for _, l := range learners {
+				if p, ok := l.Exercises[e.ID]; ok && p.Source != "" {
+					subs = append(subs, similarity.Submission{Learner: l.Name, Exercise: e.ID, Source: []byte(p.Source)})
+				}
+			}
+		}
+	}
+	s.render(w, "similarity.html", map[string]any{
+		"Threshold":   threshold,
+		"Submissions": len(subs),
+		"Matches":     similarity.Detect(subs, bases, threshold),
+	})
+}
+
+// handleGrades serves GET /grades.csv: each learner's completion of every
+// module in percent, and overall.
+func (s *classroomServer) handleGrades(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="grades.csv"`)
+	if err := writeGrades(w, s.gradedModules(), s.learnerRows()); err != nil {
+		fmt.Fprintf(s.app.stderr, "learngo classroom: %v\n", err)
+	}
+}
+
+func writeGrades(w io.Writer, modules []*course.Module, rows []learnerRow) error {
+	cw := csv.NewWriter(w)
+	header := []string{"learner", "last_seen"}
+	for _, m := range modules {
+		header = append(header, m.ID)
+	}
+	header = append(header, "completed", "total", "percent")
+	cw.Write(header)
+	for _, row := range rows {
+		record := []string{row.Name, row.LastSeen.UTC().Format(time.RFC3339)}
+		for _, g := range row.Modules {
+			record = append(record, strconv.Itoa(g.Percent()))
+		}
+		record = append(record, strconv.Itoa(row.Completed), strconv.Itoa(row.Total), strconv.Itoa(row.Percent()))
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (s *classroomServer) render(w http.ResponseWriter, name string, data any) {
+	var buf strings.Builder
+	if err := s.pages.ExecuteTemplate(&buf, name, data); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, buf.String())
+}
+
+func (s *classroomServer) serverError(w http.ResponseWriter, err error) {
+	fmt.Fprintf(s.app.stderr, "learngo classroom: %v\n", err)
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/classroom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testClassroom(t *testing.T, token string) (*classroomServer, *app) {
+	t.Helper()
+	a, _, _ := reportCourse(t)
+	c, err := a.loadCourse()
+	require.NoError(t, err)
+	cohort, err := classroom.Open(filepath.Join(t.TempDir(), "classroom.json"))
+	require.NoError(t, err)
+	s, err := newClassroomServer(a, c, cohort, token)
+	require.NoError(t, err)
+	return s, a
+}
+
+func postReport(t *testing.T, h http.Handler, token string, r classroom.Report) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(r)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, classroom.ReportsPath, bytes.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestClassroomReport(t *testing.T) {
+	a, _, _ := reportCourse(t)
+	c, err := a.loadCourse()
+	require.NoError(t, err)
+	store, err := a.openStore()
+	require.NoError(t, err)
+	m, err := c.Module("01")
+	require.NoError(t, err)
+
+	r := classroomReport("ada", store, m, testNow)
+	assert.Equal(t, "01-basics", r.Module)
+	assert.Equal(t, []classroom.Exercise{
+		{ID: "01-basics/exercise1", Passed: true, Completed: true, Attempts: 2, FailedRuns: 1, Source: "package exercises\n"},
+		{ID: "01-basics/exercise2", Attempts: 1, FailedRuns: 1, HintsUsed: 1},
+		{ID: "01-basics/exercise3"},
+	}, r.Exercises)
+}
+
+func TestClassroomServer(t *testing.T) {
+	s, _ := testClassroom(t, "s3cret")
+	ada := classroom.Report{Learner: "ada", Module: "01-basics", At: testNow, Exercises: []classroom.Exercise{
+		{ID: "01-basics/exercise1", Passed: true, Completed: true, Attempts: 4, FailedRuns: 3},
+		{ID: "01-basics/exercise2", Attempts: 7, FailedRuns: 7},
+	}}
+	bo := classroom.Report{Learner: "bo", Module: "02-types", At: testNow, Exercises: []classroom.Exercise{
+		{ID: "02-types/exercise1", Passed: true, Completed: true, Attempts: 1},
+	}}
+
+	assert.Equal(t, http.StatusUnauthorized, postReport(t, s, "", ada).Code)
+	assert.Equal(t, http.StatusUnauthorized, postReport(t, s, "guess", ada).Code)
+	require.Equal(t, http.StatusNoContent, postReport(t, s, "s3cret", ada).Code)
+	require.Equal(t, http.StatusNoContent, postReport(t, s, "s3cret", bo).Code)
+	unknown := classroom.Report{Learner: "ada", Module: "99-nope"}
+	assert.Equal(t, http.StatusBadRequest, postReport(t, s, "s3cret", unknown).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, get(t, s, classroom.ReportsPath).Code)
+
+	body := get(t, s, "/").Body.String()
+	assert.Contains(t, body, "2 learners have reported")
+	assert.Contains(t, body, "<td>ada</td>")
+	assert.Contains(t, body, `<td class="in-progress">1/3</td>`)
+	assert.Contains(t, body, `<td class="done">1/1</td>`)
+
+	body = get(t, s, "/heatmap?module=01").Body.String()
+	assert.Contains(t, body, "<td>01-basics/exercise2</td>")
+	assert.Contains(t, body, `<td class="heat2"><span class="pass">3</span></td>`, "completed after 3 failures")
+	assert.Contains(t, body, `<td class="heat3"><span>7</span></td>`)
+	assert.Contains(t, body, `<td class="heat0"><span class="muted">-</span></td>`, "bo has not reported module 01")
+	assert.NotContains(t, body, "02-types/exercise1")
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/heatmap?module=99").Code)
+
+	rec := get(t, s, "/grades.csv")
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "learner,last_seen,01-basics,02-types,completed,total,percent\n"+
+		"ada,2026-03-01T12:00:00Z,33,0,1,4,25\n"+
+		"bo,2026-03-01T12:00:00Z,0,100,1,4,25\n", rec.Body.String())
+}
+
+// solution is a completed exercise that adds enough code of its own to be
+// compared; copied renames it.
+const (
+	solution = `package exercises
+
+func Reverse(words []string) []string {
+	out := make([]string, 0, len(words))
+	for i := len(words) - 1; i >= 0; i-- {
+		if words[i] == "" {
+			continue
+		}
+		out = append(out, words[i])
+	}
+	for i, w := range out {
+		if len(w) > 10 {
+			out[i] = w[:10] + "..."
+		}
+	}
+	return out
+}
+`
+	copied = `package exercises
+
+// Reverse reverses.
+func Reverse(in []string) []string {
+	res := make([]string, 0, len(in))
+	for n := len(in) - 1; n >= 0; n-- {
+		if in[n] == "" {
+			continue
+		}
+		res = append(res, in[n])
+	}
+	for j, s := range res {
+		if len(s) > 10 {
+			res[j] = s[:10] + "..."
+		}
+	}
+	return res
+}
+`
+)
+
+func TestClassroomSimilarity(t *testing.T) {
+	s, _ := testClassroom(t, "")
+	for _, r := range []classroom.Report{
+		{Learner: "ada", Module: "01-basics", At: testNow, Exercises: []classroom.Exercise{{ID: "01-basics/exercise1", Completed: true, Source: solution}}},
+		{Learner: "bo", Module: "01-basics", At: testNow, Exercises: []classroom.Exercise{{ID: "01-basics/exercise1", Completed: true, Source: copied}}},
+		{Learner: "cy", Module: "01-basics", At: testNow, Exercises: []classroom.Exercise{{ID: "01-basics/exercise1", Completed: true, Source: "package exercises\n"}}},
+	} {
+		require.Equal(t, http.StatusNoContent, postReport(t, s, "", r).Code)
+	}
+
+	body := get(t, s, "/similarity").Body.String()
+	assert.Contains(t, body, "3 submissions compared")
+	assert.Contains(t, body, "<td>01-basics/exercise1</td>\n<td>ada</td>\n<td>bo</td>\n<td>1.00</td>")
+	assert.NotContains(t, body, "<td>cy</td>")
+
+	body = get(t, s, "/similarity?module=02").Body.String()
+	assert.Contains(t, body, "No similar submissions.")
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/similarity?module=99").Code)
+	assert.Equal(t, http.StatusBadRequest, get(t, s, "/similarity?threshold=high").Code)
+}
+
+func TestPushReport(t *testing.T) {
+	s, a := testClassroom(t, "")
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	c, err := a.loadCourse()
+	require.NoError(t, err)
+	m, err := c.Module("01")
+	require.NoError(t, err)
+	store, err := a.openStore()
+	require.NoError(t, err)
+
+	t.Setenv(classroomEnv, "")
+	a.pushReport(context.Background(), store, m)
+	assert.Empty(t, s.cohort.Learners(), "not in a classroom")
+
+	t.Setenv(classroomEnv, srv.URL)
+	t.Setenv(learnerEnv, "ada")
+	t.Setenv(tokenEnv, "")
+	a.pushReport(context.Background(), store, m)
+	learners := s.cohort.Learners()
+	require.Len(t, learners, 1)
+	assert.Equal(t, "ada", learners[0].Name)
+	assert.Equal(t, 1, learners[0].Completed(nil))
+}
+
+func TestPushReportWarns(t *testing.T) {
+	a, _, stderr := reportCourse(t)
+	c, err := a.loadCourse()
+	require.NoError(t, err)
+	m, err := c.Module("01")
+	require.NoError(t, err)
+	store, err := a.openStore()
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "wrong or missing classroom token", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	t.Setenv(classroomEnv, srv.URL)
+	t.Setenv(learnerEnv, "ada")
+	a.pushReport(context.Background(), store, m)
+	assert.Contains(t, stderr(), "reporting to the classroom: classroom server: 401 Unauthorized: wrong or missing classroom token")
+}
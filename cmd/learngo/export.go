@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/cheatsheet"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/quiz"
+)
+
+// flashcard is one exported card.
+type flashcard struct {
+	GUID  string // stable across exports, so re-importing updates the card
+	Front string
+	Code  string // optional snippet shown below Front
+	Back  string
+	Tags  []string
+}
+
+// buildFlashcards collects cards for the modules: what each module sets
+// out to teach, the flashcards in its course.MetaFile, and its quiz
+// questions with their answers.
+func (a *app) buildFlashcards(modules []*course.Module) ([]flashcard, error) {
+	var cards []flashcard
+	for _, m := range modules {
+		if len(m.Objectives) > 0 {
+			back := m.Description
+			for _, o := range m.Objectives {
+				back += "\n- " + o
+			}
+			cards = append(cards, flashcard{
+				GUID:  "learngo/" + m.ID + "/objectives",
+				Front: fmt.Sprintf(a.T("What will you be able to do after %s?"), m.Title),
+				Back:  strings.TrimPrefix(back, "\n"),
+				Tags:  []string{m.ID},
+			})
+		}
+		for _, card := range m.Flashcards {
+			sum := sha256.Sum256([]byte(card.Front))
+			cards = append(cards, flashcard{
+				GUID:  "learngo/" + m.ID + "/card/" + hex.EncodeToString(sum[:6]),
+				Front: card.Front,
+				Back:  card.Back,
+				Tags:  []string{m.ID},
+			})
+		}
+
+		bank, err := quiz.LoadModule(m.Dir)
+		if errors.Is(err, quiz.ErrNoQuiz) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range bank.Questions {
+			back := q.Choices[q.Answer]
+			if q.Explanation != "" {
+				back += "\n\n" + q.Explanation
+			}
+			tags := []string{m.ID}
+			if q.Topic != "" {
+				tags = append(tags, strings.ReplaceAll(q.Topic, " ", "_"))
+			}
+			cards = append(cards, flashcard{
+				GUID:  "learngo/" + m.ID + "/quiz/" + q.ID,
+				Front: q.Question,
+				Code:  q.Code,
+				Back:  back,
+				Tags:  tags,
+			})
+		}
+	}
+	return cards, nil
+}
+
+// writeAnki writes cards as a tab-separated file for Anki's File > Import,
+// with the header lines that tell Anki the note type, deck, and columns.
+// Fields are HTML, so code keeps its layout.
+func writeAnki(w io.Writer, cards []flashcard, deck string) error {
+	header := "#separator:tab\n#html:true\n#notetype:Basic\n#deck:" + deck + "\n#guid column:1\n#tags column:4\n"
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	cw.Comma = '\t'
+	for _, card := range cards {
+		front := ankiHTML(card.Front)
+		if card.Code != "" {
+			front += "<pre><code>" + ankiHTML(card.Code) + "</code></pre>"
+		}
+		if err := cw.Write([]string{card.GUID, front, ankiHTML(card.Back), strings.Join(card.Tags, " ")}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func ankiHTML(s string) string {
+	return strings.ReplaceAll(html.EscapeString(s), "\n", "<br>")
+}
+
+// writeFlashcardCSV writes cards as plain-text CSV with a header row, for
+// flashcard apps other than Anki.
+func writeFlashcardCSV(w io.Writer, cards []flashcard) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"front", "back", "tags"}); err != nil {
+		return err
+	}
+	for _, card := range cards {
+		front := card.Front
+		if card.Code != "" {
+			front += "\n\n" + card.Code
+		}
+		if err := cw.Write([]string{front, card.Back, strings.Join(card.Tags, " ")}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// export writes course material in formats for use outside the course:
+// flashcards of the modules' quizzes and terms, or cheat sheets of their
+// examples.
+func (a *app) export(args []string) error {
+	fs := a.flagSet("export")
+	format := fs.String("format", "", "flashcard format: anki or csv (default: from -o, else anki)")
+	out := fs.String("o", "", "write to this file instead of standard output; for cheat sheets, a directory to write one Markdown file per module to")
+	deck := fs.String("deck", "", "Anki deck to import the cards into (default: the course title)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 || (fs.Arg(0) != "flashcards" && fs.Arg(0) != "cheatsheet") {
+		fs.Usage()
+		return errUsage
+	}
+
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	modules := c.Modules
+	if fs.NArg() > 1 {
+		modules = nil
+		for _, name := range fs.Args()[1:] {
+			m, err := c.Module(name)
+			if err != nil {
+				return err
+			}
+			modules = append(modules, m)
+		}
+	}
+	if fs.Arg(0) == "cheatsheet" {
+		return a.exportCheatsheets(modules, *out)
+	}
+
+	if *format == "" {
+		*format = "anki"
+		if filepath.Ext(*out) == ".csv" {
+			*format = "csv"
+		}
+	}
+	*deck = firstOf(*deck, c.Title, "learngo")
+	var write func(io.Writer, []flashcard) error
+	switch *format {
+	case "anki":
+		write = func(w io.Writer, cards []flashcard) error { return writeAnki(w, cards, *deck) }
+	case "csv":
+		write = writeFlashcardCSV
+	default:
+		return fmt.Errorf("unknown flashcard format %q (want anki or csv)", *format)
+	}
+	cards, err := a.buildFlashcards(modules)
+	if err != nil {
+		return err
+	}
+	if len(cards) == 0 {
+		return errors.New("no flashcards: the modules have no objectives, flashcards, or quiz")
+	}
+
+	if *out == "" {
+		return write(a.stdout, cards)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := write(f, cards); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, a.T("Wrote %d flashcards to %s\n"), len(cards), *out)
+	return nil
+}
+
+// exportCheatsheets writes the cheat sheet of every module with examples,
+// to standard output or as <module ID>.md in dir.
+func (a *app) exportCheatsheets(modules []*course.Module, dir string) error {
+	var sheets []*cheatsheet.Sheet
+	for _, m := range modules {
+		s, err := cheatsheet.Build(m)
+		if errors.Is(err, cheatsheet.ErrNoExamples) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		sheets = append(sheets, s)
+	}
+	if len(sheets) == 0 {
+		return errors.New("no cheat sheets: the modules have no examples")
+	}
+
+	if dir == "" {
+		for i, s := range sheets {
+			if i > 0 {
+				fmt.Fprintln(a.stdout)
+			}
+			if err := s.WriteMarkdown(a.stdout); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, s := range sheets {
+		var buf bytes.Buffer
+		if err := s.WriteMarkdown(&buf); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, s.Module.ID+".md"), buf.Bytes(), 0o644); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(a.stderr, a.T("Wrote %d cheat sheets to %s\n"), len(sheets), dir)
+	return nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportApp(t *testing.T) (*app, func() string, func() string) {
+	t.Helper()
+	a, stdout, stderr := testApp(t, map[string]string{
+		"course.yaml": `title: Test Course
+modules:
+  - id: 01-basics
+    description: The basics.
+    objectives: [Write loops, Use maps]
+  - id: 02-types
+`,
+		"modules/01-basics/README.md":   "# Module 01: Go Basics\n",
+		"modules/01-basics/module.json": `{"flashcards": [{"front": "Zero value of a map?", "back": "nil"}]}`,
+		"modules/02-types/README.md":    "# Module 02: Types\n",
+		"modules/02-types/quiz.json": `{"questions": [
+	{"id": "receiver", "topic": "method sets", "question": "Which compiles?", "code": "var s Shape = c\nvar t Shape = &c", "choices": ["s", "t"], "answer": 1, "explanation": "Only *Circle has Area."}
+]}`,
+	})
+	return a, stdout.String, stderr.String
+}
+
+func TestExportAnki(t *testing.T) {
+	a, stdout, _ := exportApp(t)
+	require.NoError(t, a.main([]string{"export", "flashcards"}))
+	assert.Equal(t, `#separator:tab
+#html:true
+#notetype:Basic
+#deck:Test Course
+#guid column:1
+#tags column:4
+learngo/01-basics/objectives	What will you be able to do after Go Basics?	The basics.<br>- Write loops<br>- Use maps	01-basics
+learngo/01-basics/card/21247f739f5a	Zero value of a map?	nil	01-basics
+learngo/02-types/quiz/receiver	Which compiles?<pre><code>var s Shape = c<br>var t Shape = &amp;c</code></pre>	t<br><br>Only *Circle has Area.	02-types method_sets
+`, stdout())
+}
+
+func TestExportCSV(t *testing.T) {
+	a, _, stderr := exportApp(t)
+	out := filepath.Join(t.TempDir(), "cards.csv")
+	require.NoError(t, a.main([]string{"export", "-o", out, "flashcards", "02"}))
+	assert.Equal(t, "Wrote 1 flashcards to "+out+"\n", stderr())
+
+	f, err := os.Open(out)
+	require.NoError(t, err)
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"front", "back", "tags"},
+		{"Which compiles?\n\nvar s Shape = c\nvar t Shape = &c", "t\n\nOnly *Circle has Area.", "02-types method_sets"},
+	}, records)
+}
+
+func TestExportCheatsheet(t *testing.T) {
+	a, stdout, stderr := exportApp(t)
+	assert.ErrorContains(t, a.main([]string{"export", "cheatsheet"}), "no cheat sheets")
+
+	dir := filepath.Join(a.root, "modules/02-types/examples")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "example1.go"), []byte("package examples\n\n// Area returns the area.\nfunc Area() float64 { return 0 }\n"), 0o644))
+	require.NoError(t, a.main([]string{"export", "cheatsheet"}))
+	assert.Contains(t, stdout(), "# Cheat Sheet: Types\n")
+	assert.Contains(t, stdout(), "- `func Area() float64`: Area returns the area.\n")
+
+	out := filepath.Join(t.TempDir(), "sheets")
+	require.NoError(t, a.main([]string{"export", "-o", out, "cheatsheet", "01", "02"}))
+	assert.Equal(t, "Wrote 1 cheat sheets to "+out+"\n", stderr())
+	data, err := os.ReadFile(filepath.Join(out, "02-types.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "## example1.go")
+	assert.NoFileExists(t, filepath.Join(out, "01-basics.md"), "no examples")
+}
+
+func TestExportErrors(t *testing.T) {
+	a, _, _ := exportApp(t)
+	assert.ErrorIs(t, a.main([]string{"export"}), errUsage)
+	assert.ErrorIs(t, a.main([]string{"export", "slides"}), errUsage)
+	assert.ErrorContains(t, a.main([]string{"export", "-format", "pdf", "flashcards"}), `unknown flashcard format "pdf"`)
+	assert.ErrorContains(t, a.main([]string{"export", "flashcards", "99"}), "99")
+
+	a, _, _ = testApp(t, map[string]string{"modules/01-basics/README.md": "# Go Basics\n"})
+	err := a.main([]string{"export", "flashcards"})
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "no flashcards"))
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/grader"
+)
+
+// flaky reruns a module's exercise tests and reports the ones that pass in
+// some runs and fail in others. It records nothing: a flaky test says more
+// about the test, or about a race in the code, than about progress.
+func (a *app) flaky(args []string) error {
+	fs := a.flagSet("flaky")
+	runs := fs.Int("n", 10, "how many times to run the tests")
+	race := fs.Bool("race", false, "run the tests with the race detector")
+	procsFlag := fs.String("procs", "", "comma-separated GOMAXPROCS values to cycle through, e.g. 1,2,8")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || *runs < 2 {
+		fs.Usage()
+		return errUsage
+	}
+	procs, err := parseProcs(*procsFlag)
+	if err != nil {
+		fmt.Fprintf(a.stderr, "learngo: %v\n", err)
+		fs.Usage()
+		return errUsage
+	}
+
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	m, err := c.Module(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	store, err := a.openStore()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Fprintf(a.stdout, a.T("Running the tests of %s %d times...\n"), m.ID, *runs)
+	report, err := grader.Flaky(ctx, m, grader.FlakyOptions{Runs: *runs, Race: *race, Procs: procs, Env: seedEnv(store)})
+	if err != nil {
+		return err
+	}
+	if (*race || m.Race) && !report.Race {
+		fmt.Fprintf(a.stderr, "learngo: "+a.T("warning: %s\n"), a.T("the race detector is not available, so data races go unnoticed"))
+	}
+	if report.BuildOutput != "" {
+		fmt.Fprintf(a.stdout, a.T("\nThe exercises do not compile:\n%s\n\n"), report.BuildOutput)
+		return nil
+	}
+
+	flaky := report.Flaky()
+	for _, t := range flaky {
+		fmt.Fprintf(a.stdout, "\nFLAKY %s (%s): ", t.Name, strings.TrimPrefix(t.Exercise, m.ID+"/"))
+		fmt.Fprintf(a.stdout, a.T("failed %d of %d runs"), t.Failures, t.Runs)
+		if len(t.FailedProcs) > 0 {
+			fmt.Fprintf(a.stdout, a.T(", with GOMAXPROCS %s"), joinInts(t.FailedProcs))
+		}
+		fmt.Fprintln(a.stdout)
+		for _, msg := range t.Messages {
+			fmt.Fprintf(a.stdout, "     %dx %s\n", msg.Count, strings.TrimSpace(indent(msg.Text, "        ")))
+		}
+	}
+	if len(flaky) == 0 {
+		fmt.Fprintf(a.stdout, a.T("\nNo flaky tests in %d runs of %s.\n"), report.Runs, m.ID)
+	} else {
+		fmt.Fprintf(a.stdout, a.T("\n%d of %d tests in %s are flaky.\n"), len(flaky), len(report.Tests), m.ID)
+	}
+	if failing := report.Failing(); len(failing) > 0 {
+		fmt.Fprintf(a.stdout, a.T("%d tests failed in every run; \"learngo check -v %s\" shows why.\n"), len(failing), m.ID)
+	}
+	return nil
+}
+
+// parseProcs parses a -procs flag such as "1,2,8".
+func parseProcs(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var procs []int
+	for _, f := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid GOMAXPROCS %q in -procs", f)
+		}
+		procs = append(procs, n)
+	}
+	return procs, nil
+}
+
+// joinInts formats ns as "1, 2, 8".
+func joinInts(ns []int) string {
+	s := make([]string, len(ns))
+	for i, n := range ns {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlaky(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	a, stdout, _ := testApp(t, map[string]string{
+		"modules/01-basics/exercises/procs.go": "package exercises\n",
+		"modules/01-basics/exercises/procs_test.go": `package exercises
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestProcs(t *testing.T) {
+	if runtime.GOMAXPROCS(0) > 1 {
+		t.Fatal("lost an update")
+	}
+}
+
+func TestAlways(t *testing.T) { t.Fatal("always") }
+`,
+	})
+	require.NoError(t, a.main([]string{"flaky", "-n", "4", "-procs", "1,2", "01"}))
+	out := stdout.String()
+	assert.Contains(t, out, "Running the tests of 01-basics 4 times")
+	assert.Contains(t, out, "FLAKY TestProcs (procs): failed 2 of 4 runs, with GOMAXPROCS 2")
+	assert.Contains(t, out, "     2x procs_test.go:10: lost an update")
+	assert.NotContains(t, out, "FLAKY TestAlways")
+	assert.Contains(t, out, "1 of 2 tests in 01-basics are flaky.")
+	assert.Contains(t, out, `1 tests failed in every run; "learngo check -v 01-basics" shows why.`)
+
+	stdout.Reset()
+	require.NoError(t, a.main([]string{"flaky", "-n", "2", "01"}))
+	assert.Contains(t, stdout.String(), "No flaky tests in 2 runs of 01-basics.")
+}
+
+func TestParseProcs(t *testing.T) {
+	procs, err := parseProcs("1, 2,8")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 8}, procs)
+	procs, err = parseProcs("")
+	assert.NoError(t, err)
+	assert.Nil(t, procs)
+	_, err = parseProcs("1,0")
+	assert.Error(t, err)
+	_, err = parseProcs("1,,2")
+	assert.Error(t, err)
+}
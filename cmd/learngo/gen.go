@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/readme"
+)
+
+// gen regenerates the parts of the course derived from its code. READMEs
+// are the only kind so far.
+func (a *app) gen(args []string) error {
+	fs := a.flagSet("gen")
+	check := fs.Bool("check", false, "only report out-of-date files, and fail if there are any")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 || fs.Arg(0) != "readme" {
+		fs.Usage()
+		return errUsage
+	}
+
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	modules := c.Modules
+	if fs.NArg() > 1 {
+		modules = nil
+		for _, name := range fs.Args()[1:] {
+			m, err := c.Module(name)
+			if err != nil {
+				return err
+			}
+			modules = append(modules, m)
+		}
+	}
+
+	var stale []string
+	for _, m := range modules {
+		if m.Provider != "" {
+			continue
+		}
+		current, updated, err := readme.Generate(m)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(current, updated) {
+			continue
+		}
+		path := filepath.Join(m.Dir, readme.FileName)
+		rel, err := filepath.Rel(a.root, path)
+		if err != nil {
+			rel = path
+		}
+		stale = append(stale, rel)
+		if *check {
+			continue
+		}
+		if err := os.WriteFile(path, updated, 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, a.T("Updated %s\n"), rel)
+	}
+	if *check && len(stale) > 0 {
+		return fmt.Errorf("out of date, run \"learngo gen readme\": %s", strings.Join(stale, ", "))
+	}
+	return nil
+}
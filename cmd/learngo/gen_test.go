@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenReadme(t *testing.T) {
+	a, stdout, _ := testApp(t, map[string]string{
+		"modules/01-basics/README.md":              "# Go Basics\n\n<!-- learngo:exercises -->\n<!-- /learngo:exercises -->\n",
+		"modules/01-basics/exercises/exercise1.go": "// EXERCISE: Fix the loop.\npackage exercises\n",
+		"modules/02-types/README.md":               "# Types\n\nNo generated sections.\n",
+	})
+	readme := filepath.Join(a.root, "modules/01-basics/README.md")
+
+	err := a.main([]string{"gen", "-check", "readme"})
+	assert.ErrorContains(t, err, "out of date, run \"learngo gen readme\": "+filepath.Join("modules", "01-basics", "README.md"))
+	data, err := os.ReadFile(readme)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "exercise1.go", "-check writes nothing")
+
+	require.NoError(t, a.main([]string{"gen", "readme"}))
+	assert.Equal(t, "Updated "+filepath.Join("modules", "01-basics", "README.md")+"\n", stdout.String())
+	data, err = os.ReadFile(readme)
+	require.NoError(t, err)
+	assert.Equal(t, "# Go Basics\n\n<!-- learngo:exercises -->\n1. **exercise1.go** - Fix the loop.\n<!-- /learngo:exercises -->\n", string(data))
+
+	assert.NoError(t, a.main([]string{"gen", "-check", "readme", "01"}))
+	assert.ErrorIs(t, a.main([]string{"gen", "docs"}), errUsage)
+}
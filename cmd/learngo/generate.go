@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// directive is a //go:generate line, or one that looks meant to be.
+type directive struct {
+	File string
+	Line int
+	Text string
+}
+
+// lookalike matches lines that go generate skips but whose author likely
+// meant as directives: indented, or with a space after the slashes.
+var lookalike = regexp.MustCompile(`^\s*//\s*go:generate\b`)
+
+// directives returns the //go:generate directives in the Go files of dir,
+// and the lines that look like directives but are not.
+func directives(dir string) (found, skipped []directive, err error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		sc := bufio.NewScanner(f)
+		for n := 1; sc.Scan(); n++ {
+			line := sc.Text()
+			d := directive{File: filepath.Base(name), Line: n, Text: strings.TrimSpace(line)}
+			switch {
+			case strings.HasPrefix(line, "//go:generate ") || strings.HasPrefix(line, "//go:generate\t"):
+				found = append(found, d)
+			case lookalike.MatchString(line):
+				skipped = append(skipped, d)
+			}
+		}
+		f.Close()
+		if err := sc.Err(); err != nil {
+			return nil, nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+	}
+	return found, skipped, nil
+}
+
+// generate runs go generate on a module's exercises, showing each
+// command it runs. It points out lines that go generate skips without a
+// word, such as "// go:generate" with a space.
+func (a *app) generate(args []string) error {
+	fs := a.flagSet("generate")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	m, err := c.Module(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(m.Dir, "exercises")
+	found, skipped, err := directives(dir)
+	if err != nil {
+		return err
+	}
+	for _, d := range skipped {
+		fmt.Fprintf(a.stdout, a.T("%s:%d: go generate skips %q: a directive starts the line with \"//go:generate\", without spaces.\n"), d.File, d.Line, d.Text)
+	}
+	if len(found) == 0 {
+		fmt.Fprintf(a.stdout, a.T("No //go:generate directives in %s.\n"), m.ID)
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	cmd := exec.CommandContext(ctx, "go", "generate", "-x", ".")
+	cmd.Dir = dir
+	cmd.Stdout, cmd.Stderr = a.stdout, a.stdout
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("go generate: %w", err)
+	}
+	fmt.Fprintf(a.stdout, a.T("Ran %d directives in %s. Run \"learngo check %s\" to test the result.\n"), len(found), m.ID, m.ID)
+	return nil
+}
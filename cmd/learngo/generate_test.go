@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generateFiles is built line by line: go generate reads test files
+// too, and would run a directive at the start of a line in a raw string.
+var generateFiles = strings.Join([]string{
+	"package exercises",
+	"",
+	"//go:generate go run ./gen one.txt",
+	"// go:generate go run ./gen two.txt",
+	"\t//go:generate go run ./gen three.txt",
+	"",
+	"//go:generate\tgo run ./gen four.txt",
+	"",
+}, "\n")
+
+const generator = `package main
+
+import "os"
+
+func main() {
+	if err := os.WriteFile(os.Args[1], []byte("generated\n"), 0o644); err != nil {
+		panic(err)
+	}
+}
+`
+
+func TestDirectives(t *testing.T) {
+	a, _, _ := testApp(t, map[string]string{"modules/49-codegen/exercises/enum.go": generateFiles})
+	found, skipped, err := directives(filepath.Join(a.root, "modules/49-codegen/exercises"))
+	require.NoError(t, err)
+	assert.Equal(t, []directive{
+		{File: "enum.go", Line: 3, Text: "//go:generate go run ./gen one.txt"},
+		{File: "enum.go", Line: 7, Text: "//go:generate\tgo run ./gen four.txt"},
+	}, found)
+	assert.Equal(t, []directive{
+		{File: "enum.go", Line: 4, Text: "// go:generate go run ./gen two.txt"},
+		{File: "enum.go", Line: 5, Text: "//go:generate go run ./gen three.txt"},
+	}, skipped)
+}
+
+func TestGenerate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go generate")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	a, stdout, _ := testApp(t, map[string]string{
+		"modules/49-codegen/README.md":            "# Module 49: Codegen\n",
+		"modules/49-codegen/exercises/enum.go":    generateFiles,
+		"modules/49-codegen/exercises/gen/gen.go": generator,
+		"modules/01-basics/README.md":             "# Module 01: Basics\n",
+		"modules/01-basics/exercises/basics.go":   "package exercises\n",
+	})
+	require.NoError(t, a.main([]string{"generate", "49"}))
+	out := stdout.String()
+	assert.Contains(t, out, `enum.go:4: go generate skips "// go:generate go run ./gen two.txt"`)
+	assert.Contains(t, out, `enum.go:5: go generate skips`)
+	assert.Contains(t, out, "go run ./gen one.txt", "the commands are shown")
+	assert.Contains(t, out, `Ran 2 directives in 49-codegen. Run "learngo check 49-codegen" to test the result.`)
+	dir := filepath.Join(a.root, "modules/49-codegen/exercises")
+	assert.FileExists(t, filepath.Join(dir, "one.txt"))
+	assert.FileExists(t, filepath.Join(dir, "four.txt"))
+	assert.NoFileExists(t, filepath.Join(dir, "two.txt"))
+
+	stdout.Reset()
+	require.NoError(t, a.main([]string{"generate", "01"}))
+	assert.Contains(t, stdout.String(), "No //go:generate directives in 01-basics.")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gen", "gen.go"), []byte("package main\n\nfunc main() { panic(1) }\n"), 0o644))
+	assert.ErrorContains(t, a.main([]string{"generate", "49"}), "go generate")
+}
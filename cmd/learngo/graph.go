@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/recommend"
+)
+
+// Module states shown by graph.
+const (
+	stateComplete = "complete"
+	stateOpen     = "open"
+	stateLocked   = "locked"
+	stateEmpty    = "no exercises yet"
+)
+
+func moduleState(c *course.Course, store *progress.Store, m *course.Module) string {
+	switch {
+	case len(m.Exercises) == 0 && !recommend.HasQuiz(m):
+		return stateEmpty
+	case recommend.Complete(m, store):
+		return stateComplete
+	case len(recommend.Unmet(c, store, m)) > 0:
+		return stateLocked
+	}
+	return stateOpen
+}
+
+// checkPrerequisites warns when m has incomplete prerequisites, or refuses
+// to go on if LEARNGO_STRICT is set.
+func (a *app) checkPrerequisites(c *course.Course, store *progress.Store, m *course.Module) error {
+	unmet := recommend.Unmet(c, store, m)
+	if len(unmet) == 0 {
+		return nil
+	}
+	ids := make([]string, len(unmet))
+	for i, req := range unmet {
+		ids[i] = req.ID
+	}
+	msg := fmt.Sprintf("%s builds on %s, which you have not completed", m.ID, strings.Join(ids, " and "))
+	if os.Getenv("LEARNGO_STRICT") != "" {
+		return fmt.Errorf("%s (unset LEARNGO_STRICT to go ahead anyway)", msg)
+	}
+	fmt.Fprintf(a.stderr, "learngo: "+a.T("warning: %s\n"), msg)
+	return nil
+}
+
+// graph prints the module prerequisites, in an order that respects them.
+func (a *app) graph(args []string) error {
+	fs := a.flagSet("graph")
+	dot := fs.Bool("dot", false, "print Graphviz dot instead of a table")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errUsage
+	}
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	store, err := a.openStore()
+	if err != nil {
+		return err
+	}
+	order, err := c.Order()
+	if err != nil {
+		return err
+	}
+
+	if *dot {
+		fmt.Fprintln(a.stdout, "digraph modules {")
+		fmt.Fprintln(a.stdout, "\trankdir=LR;")
+		for _, m := range order {
+			style := ""
+			switch moduleState(c, store, m) {
+			case stateComplete:
+				style = ", style=filled, fillcolor=palegreen"
+			case stateLocked, stateEmpty:
+				style = ", color=gray, fontcolor=gray"
+			}
+			fmt.Fprintf(a.stdout, "\t%q [label=%q%s];\n", m.ID, m.ID+"\n"+m.Title, style)
+			for _, req := range m.Requires {
+				fmt.Fprintf(a.stdout, "\t%q -> %q;\n", req, m.ID)
+			}
+		}
+		fmt.Fprintln(a.stdout, "}")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, a.T("MODULE\tSTATE\tREQUIRES"))
+	for _, m := range order {
+		requires := "-"
+		if len(m.Requires) > 0 {
+			requires = strings.Join(m.Requires, ", ")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", m.ID, a.T(moduleState(c, store, m)), requires)
+	}
+	return tw.Flush()
+}
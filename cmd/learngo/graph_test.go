@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func graphApp(t *testing.T) (*app, func() string, func() string) {
+	t.Helper()
+	a, stdout, stderr := testApp(t, map[string]string{
+		"modules/01-basics/README.md":        "# Module 01: Basics\n",
+		"modules/01-basics/exercises/one.go": "package exercises\n",
+		"modules/02-types/README.md":         "# Module 02: Types\n",
+		"modules/02-types/module.json":       `{"requires": ["01"]}`,
+		"modules/02-types/exercises/two.go":  "package exercises\n",
+		"modules/03-later/README.md":         "# Module 03: Later\n",
+		"modules/03-later/module.json":       `{"requires": ["01", "02"]}`,
+	})
+	return a, stdout.String, stderr.String
+}
+
+func TestGraph(t *testing.T) {
+	a, stdout, _ := graphApp(t)
+	store, err := progress.Open(a.progressPath)
+	require.NoError(t, err)
+	store.RecordAttempt("01-basics/one", true, testNow)
+	require.NoError(t, store.Save())
+
+	require.NoError(t, a.main([]string{"graph"}))
+	out := stdout()
+	assert.Regexp(t, `01-basics +complete +-\n`, out)
+	assert.Regexp(t, `02-types +open +01-basics\n`, out)
+	assert.Regexp(t, `03-later +no exercises yet +01-basics, 02-types\n`, out)
+}
+
+func TestGraphDot(t *testing.T) {
+	a, stdout, _ := graphApp(t)
+	require.NoError(t, a.main([]string{"graph", "-dot"}))
+	out := stdout()
+	assert.Contains(t, out, "digraph modules {")
+	assert.Contains(t, out, `"01-basics" -> "03-later";`)
+	assert.Contains(t, out, `"02-types" [label="02-types\nTypes", color=gray, fontcolor=gray];`)
+}
+
+func TestCheckWarnsAboutPrerequisites(t *testing.T) {
+	a, _, stderr := graphApp(t)
+	c, err := a.loadCourse()
+	require.NoError(t, err)
+	store, err := a.openStore()
+	require.NoError(t, err)
+	m, err := c.Module("02")
+	require.NoError(t, err)
+
+	require.NoError(t, a.checkPrerequisites(c, store, m))
+	assert.Contains(t, stderr(), "learngo: warning: 02-types builds on 01-basics, which you have not completed")
+
+	t.Setenv("LEARNGO_STRICT", "1")
+	assert.ErrorContains(t, a.checkPrerequisites(c, store, m), "unset LEARNGO_STRICT")
+
+	store.RecordAttempt("01-basics/one", true, testNow)
+	assert.NoError(t, a.checkPrerequisites(c, store, m))
+}
+
+func TestServeDashboardShowsLockedModules(t *testing.T) {
+	a, _, _ := graphApp(t)
+	c, err := a.loadCourse()
+	require.NoError(t, err)
+	s, err := newServer(a, c)
+	require.NoError(t, err)
+	body := get(t, s, "/").Body.String()
+	assert.Contains(t, body, "Locked: finish 01-basics first")
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/i18n"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslatedOutput(t *testing.T) {
+	a, stdout, stderr := testApp(t, map[string]string{
+		"modules/01-basics/README.md": "# Go Basics\n",
+		"locales/de.yaml": `messages:
+  "Usage: learngo %s %s\n\n%s.": "Aufruf: learngo %s %s\n\n%s."
+  "Run a module's exercise tests and record the results": "Die Tests eines Moduls ausführen"
+  "unknown command %q": "unbekannter Befehl %q"
+`,
+	})
+	t.Setenv(i18n.Env, "de_DE.UTF-8")
+	require.NoError(t, a.main([]string{"help", "check"}))
+	assert.Equal(t, "Aufruf: learngo check [-v] [-race] [-fuzz=duration] [-integration] [-vet=false] [-watch] <module>\n\nDie Tests eines Moduls ausführen.\n", stdout.String())
+	assert.Empty(t, stderr.String())
+
+	assert.ErrorIs(t, a.main([]string{"frobnicate"}), errUsage)
+	assert.True(t, strings.HasPrefix(stderr.String(), "learngo: unbekannter Befehl \"frobnicate\"\n\n"), stderr.String())
+	assert.EqualError(t, a.main([]string{"help", "frobnicate"}), `unbekannter Befehl "frobnicate"`)
+
+	a, _, stderr = testApp(t, map[string]string{"modules/01-basics/README.md": "# Go Basics\n"})
+	t.Setenv(i18n.Env, "fr")
+	require.NoError(t, a.main([]string{"help", "check"}))
+	assert.Contains(t, stderr.String(), `no translation for "fr" in locales`)
+	assert.Contains(t, stderr.String(), "using English")
+}
+
+// TestCatalogs checks the course's translations against the source: every
+// message must still appear in learngo, and every hint and exercise prompt
+// must still have something to translate.
+func TestCatalogs(t *testing.T) {
+	root, err := course.FindRoot(".")
+	require.NoError(t, err)
+	c, err := course.Load(root)
+	require.NoError(t, err)
+	source, calls := sourceStrings(t)
+
+	paths, err := filepath.Glob(filepath.Join(root, i18n.Dir, "*.yaml"))
+	require.NoError(t, err)
+	for _, path := range paths {
+		cat, err := i18n.LoadFile(path)
+		require.NoError(t, err)
+		for msg := range cat.Messages {
+			assert.True(t, source[msg], "%s: message %q no longer appears in learngo", path, msg)
+		}
+		for analyzer := range cat.Hints {
+			f := vet.Finding{Analyzer: analyzer}
+			vet.Explain(&f)
+			assert.NotEmpty(t, f.Explanation, "%s: no analyzer %q to give a hint for", path, analyzer)
+		}
+		for id, text := range cat.Exercises {
+			e, err := c.Exercise(id)
+			if !assert.NoError(t, err, "%s", path) {
+				continue
+			}
+			doc, err := e.Doc()
+			require.NoError(t, err)
+			names := make(map[string]bool)
+			for _, d := range doc.Decls {
+				names[d.Name] = true
+			}
+			for name := range text.Decls {
+				assert.True(t, names[name], "%s: %s declares no %s", path, id, name)
+			}
+		}
+
+		// Run with -v for a translator's to-do list.
+		for _, msg := range calls {
+			if _, ok := cat.Messages[msg]; !ok {
+				t.Logf("%s: not translated: %q", filepath.Base(path), msg)
+			}
+		}
+	}
+}
+
+// sourceStrings returns the string literals in learngo's Go files and
+// templates that may be translated, without leading and trailing newlines,
+// and those passed to T, in order.
+func sourceStrings(t *testing.T) (strs map[string]bool, calls []string) {
+	strs = make(map[string]bool)
+	unquote := func(lit string) string {
+		s, err := strconv.Unquote(lit)
+		require.NoError(t, err)
+		return strings.Trim(s, "\n")
+	}
+	files, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		require.NoError(t, err)
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.BasicLit:
+				if n.Kind == token.STRING {
+					strs[unquote(n.Value)] = true
+				}
+			case *ast.CallExpr:
+				sel, ok := n.Fun.(*ast.SelectorExpr)
+				if !ok || sel.Sel.Name != "T" || len(n.Args) != 1 {
+					break
+				}
+				if lit, ok := n.Args[0].(*ast.BasicLit); ok {
+					calls = append(calls, unquote(lit.Value))
+				}
+			}
+			return true
+		})
+	}
+
+	tmplT := regexp.MustCompile(`\bT ("(?:[^"\\]|\\.)*")`)
+	templates, err := filepath.Glob("templates/*/*.html")
+	require.NoError(t, err)
+	for _, name := range templates {
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		for _, m := range tmplT.FindAllStringSubmatch(string(data), -1) {
+			s := unquote(m[1])
+			strs[s] = true
+			calls = append(calls, s)
+		}
+	}
+	return strs, calls
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	learninggo "github.com/TheAnarchoX/LearningGoTheHardWay"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/update"
+)
+
+// initWorkspace creates a course workspace from the content built into
+// learngo.
+func (a *app) initWorkspace(args []string) error {
+	fs := a.flagSet("init")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return errUsage
+	}
+	dir := "learngo-course"
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+
+	if err := extract(learninggo.Content, dir); err != nil {
+		return err
+	}
+	files, err := update.Files(learninggo.Content)
+	if err != nil {
+		return err
+	}
+	if _, err := update.Create(dir, update.State{Version: learninggo.Version}, files); err != nil {
+		return err
+	}
+	c, err := course.Load(dir)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, a.T("Created a course workspace with %d modules in %s.\n\n"), len(c.Modules), dir)
+	fmt.Fprintf(a.stdout, a.T("Next:\n  cd %s\n  learngo list\n  learngo check 01\n"), dir)
+	return nil
+}
+
+// extract copies content into dir, which must be empty or not exist yet so
+// that no earlier work is overwritten.
+func extract(content fs.FS, dir string) error {
+	entries, err := os.ReadDir(dir)
+	switch {
+	case err == nil && len(entries) > 0:
+		return fmt.Errorf("%s is not empty; choose a new directory", dir)
+	case err != nil && !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+	return fs.WalkDir(content, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		data, err := fs.ReadFile(content, path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0o644)
+	})
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/update"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit(t *testing.T) {
+	a, stdout, _ := testApp(t, map[string]string{})
+	dir := filepath.Join(t.TempDir(), "course")
+	require.NoError(t, a.main([]string{"init", dir}))
+	assert.Contains(t, stdout.String(), "Created a course workspace with ")
+	assert.Contains(t, stdout.String(), "cd "+dir)
+
+	assert.FileExists(t, filepath.Join(dir, "go.mod"))
+	assert.FileExists(t, filepath.Join(dir, "course.yaml"))
+	assert.FileExists(t, filepath.Join(dir, "modules/01-basics/exercises/exercise1_fix_bugs.go"))
+	assert.FileExists(t, filepath.Join(dir, "modules/02-types-interfaces/quiz.json"))
+	assert.FileExists(t, filepath.Join(dir, "pkg/seed/seed.go"))
+	assert.NoDirExists(t, filepath.Join(dir, "modules/01-basics/solutions"), "no solutions in a workspace")
+	assert.FileExists(t, filepath.Join(dir, update.StateDir, "release.json"), "ready for learngo update")
+
+	err := a.main([]string{"init", dir})
+	assert.ErrorContains(t, err, "is not empty")
+}
+
+func TestExtract(t *testing.T) {
+	content := fstest.MapFS{
+		"go.mod":                      {Data: []byte("module example.com/course\n")},
+		"modules/01-basics/README.md": {Data: []byte("# Basics\n")},
+		"modules/01-basics/exercises": {Mode: fs.ModeDir},
+	}
+	dir := t.TempDir()
+	require.NoError(t, extract(content, dir))
+	data, err := os.ReadFile(filepath.Join(dir, "modules/01-basics/README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Basics\n", string(data))
+	assert.DirExists(t, filepath.Join(dir, "modules/01-basics/exercises"))
+}
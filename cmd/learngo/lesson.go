@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/lesson"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/repl"
+)
+
+// lesson lists the lessons of the course or a module, or goes through one,
+// running each cell and showing its output under it.
+func (a *app) lesson(args []string) error {
+	fs := a.flagSet("lesson")
+	step := fs.Bool("step", false, "wait for Enter before running each cell")
+	timeout := fs.Duration("timeout", 10*time.Second, "stop cells that run longer than this (0 for no limit)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return errUsage
+	}
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	ref := fs.Arg(0)
+	if !strings.Contains(ref, "/") {
+		return a.listLessons(c, ref)
+	}
+	l, err := lesson.Find(c, ref)
+	if err != nil {
+		return err
+	}
+
+	s, err := repl.New()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	s.Timeout = *timeout
+	ctx := context.Background()
+	in := bufio.NewScanner(a.stdin)
+	for _, b := range l.Blocks {
+		if b.Kind == lesson.Prose {
+			fmt.Fprintf(a.stdout, "%s\n\n", b.Text)
+			continue
+		}
+		fmt.Fprintf(a.stdout, "```%s\n%s\n```\n", b.Info, b.Text)
+		if b.Kind != lesson.Cell {
+			fmt.Fprintln(a.stdout)
+			continue
+		}
+		if *step {
+			fmt.Fprint(a.stdout, a.T("[Enter runs the cell] "))
+			if !in.Scan() {
+				fmt.Fprintln(a.stdout)
+				return in.Err()
+			}
+		}
+		b.Run(ctx, s)
+		if b.Output != "" {
+			fmt.Fprintf(a.stdout, "%s\n%s\n", a.T("Output:"), indent(strings.TrimRight(b.Output, "\n"), "    "))
+		}
+		if b.Err != "" {
+			fmt.Fprintf(a.stdout, "%s\n%s\n", a.T("Error:"), indent(b.Err, "    "))
+		}
+		fmt.Fprintln(a.stdout)
+	}
+	return nil
+}
+
+// listLessons lists the lessons of one module, or of all if id is empty.
+func (a *app) listLessons(c *course.Course, id string) error {
+	modules := c.Modules
+	if id != "" {
+		m, err := c.Module(id)
+		if err != nil {
+			return err
+		}
+		modules = []*course.Module{m}
+	}
+	var lessons []*lesson.Lesson
+	for _, m := range modules {
+		ls, err := lesson.LoadModule(m)
+		if err != nil {
+			return err
+		}
+		lessons = append(lessons, ls...)
+	}
+	if len(lessons) == 0 {
+		fmt.Fprintln(a.stdout, a.T("No lessons yet."))
+		return nil
+	}
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, a.T("LESSON\tTITLE"))
+	for _, l := range lessons {
+		fmt.Fprintf(tw, "%s\t%s\n", l.ID, l.Title)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"net/http"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var lessonFiles = map[string]string{
+	"modules/01-basics/README.md":         "# Module 01: Go Basics\n",
+	"modules/01-basics/lessons/values.md": "# Zero Values\n\nEvery type has a *zero value*:\n\n```go\nvar n int\nfmt.Println(n)\n```\n\n```go\nn +\n```\n",
+	"modules/02-types/README.md":          "# Module 02: Types\n",
+}
+
+func needGo(t *testing.T) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("runs go build")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+}
+
+func TestLessonList(t *testing.T) {
+	a, stdout, _ := testApp(t, lessonFiles)
+	require.NoError(t, a.main([]string{"lesson"}))
+	assert.Equal(t, "LESSON            TITLE\n01-basics/values  Zero Values\n", stdout.String())
+
+	stdout.Reset()
+	require.NoError(t, a.main([]string{"lesson", "02"}))
+	assert.Equal(t, "No lessons yet.\n", stdout.String())
+
+	assert.Error(t, a.main([]string{"lesson", "01/missing"}))
+}
+
+func TestLessonRun(t *testing.T) {
+	needGo(t)
+	a, stdout, _ := testApp(t, lessonFiles)
+	a.stdin = strings.NewReader("\n")
+	require.NoError(t, a.main([]string{"lesson", "-step", "01/values"}))
+	out := stdout.String()
+	assert.Contains(t, out, "Every type has a *zero value*:\n\n```go\nvar n int\nfmt.Println(n)\n```\n[Enter runs the cell] Output:\n    0\n")
+	assert.True(t, strings.HasSuffix(out, "```go\nn +\n```\n[Enter runs the cell] \n"), "input ended before the second cell:\n%s", out)
+}
+
+func TestServeLesson(t *testing.T) {
+	needGo(t)
+	a, _, _ := testApp(t, lessonFiles)
+	c, err := a.loadCourse()
+	require.NoError(t, err)
+	s, err := newServer(a, c)
+	require.NoError(t, err)
+
+	body := get(t, s, "/modules/01-basics").Body.String()
+	assert.Contains(t, body, `<li><a href="/lessons/01-basics/values">Zero Values</a></li>`)
+
+	rec := get(t, s, "/lessons/01-basics/values")
+	require.Equal(t, http.StatusOK, rec.Code)
+	body = rec.Body.String()
+	assert.Contains(t, body, "<h1>Zero Values</h1>")
+	assert.Contains(t, body, "<p>Every type has a <em>zero value</em>:</p>")
+	assert.Contains(t, body, "<pre><code>var n int\nfmt.Println(n)</code></pre>\n<pre class=\"output\">0\n</pre>")
+	assert.Contains(t, body, `<pre class="output fail">1:4: expected operand`)
+	ran := s.lessons["01-basics/values"]
+	require.Equal(t, http.StatusOK, get(t, s, "/lessons/01-basics/values").Code)
+	assert.Same(t, ran, s.lessons["01-basics/values"], "not run again")
+
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/lessons/01-basics/missing").Code)
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/lessons/01-basics").Code)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+)
+
+// list prints every module, including those from module providers, with
+// how far along the learner is and how long the course manifest expects it
+// to take.
+func (a *app) list(args []string) error {
+	fs := a.flagSet("list")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errUsage
+	}
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	store, err := a.openStore()
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, a.T("MODULE\tTITLE\tDONE\tESTIMATE\tSOURCE"))
+	for _, m := range c.Modules {
+		done := "-"
+		if len(m.Exercises) > 0 {
+			completed := 0
+			for _, e := range m.Exercises {
+				if exerciseStatus(store.Get(e.ID)) == statusDone {
+					completed++
+				}
+			}
+			done = fmt.Sprintf("%d/%d", completed, len(m.Exercises))
+		}
+		source := "course"
+		if m.Provider != "" {
+			source = m.Provider
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", m.ID, m.Title, done, formatDuration(m.Estimate), source)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	extra := filepath.Join(t.TempDir(), "k8s-course")
+	for name, content := range map[string]string{
+		"go.mod":                           "module example.com/extra\n",
+		"modules/50-controllers/README.md": "# Module 50: Writing Controllers\n",
+		"modules/50-controllers/exercises/reconcile.go": "package exercises\n",
+	} {
+		path := filepath.Join(extra, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	t.Setenv(course.ModulesEnv, extra)
+
+	a, stdout, _ := reportCourse(t)
+	require.NoError(t, a.main([]string{"list"}))
+	assert.Equal(t, ""+
+		"MODULE          TITLE                   DONE  ESTIMATE  SOURCE\n"+
+		"01-basics       Go Basics               1/3   -         course\n"+
+		"02-types        Types <and> Interfaces  0/1   -         course\n"+
+		"03-concurrency  Concurrency             -     -         course\n"+
+		"50-controllers  Writing Controllers     0/1   -         k8s-course\n", stdout())
+}
@@ -0,0 +1,228 @@
+// Command learngo is the course companion: it runs exercise tests, tracks
+// progress, quizzes you on each module, and reports on it.
+//
+// Usage:
+//
+//	learngo <command> [flags] [arguments]
+//
+// Run "learngo help" for the list of commands; "learngo init" creates a
+// course workspace from the content built into the binary, and "learngo
+// update" brings it up to date from LEARNGO_UPDATES. learngo finds the
+// course by walking up from the working directory (or LEARNGO_ROOT) and
+// keeps progress in .learngo/progress.json (or LEARNGO_PROGRESS).
+// Checking a module whose prerequisites are incomplete prints a warning,
+// or fails if LEARNGO_STRICT is set. Setting LEARNGO_CLASSROOM to an
+// instructor's "learngo classroom" server reports each test run there;
+// "learngo sync" shares progress between machines through LEARNGO_SYNC.
+// Modules from other repositories join the course through LEARNGO_MODULES
+// or learngo-modules-* executables on PATH; see course.Provider.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/i18n"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+)
+
+// command is one learngo subcommand.
+type command struct {
+	name    string
+	args    string // argument synopsis for help, e.g. "[-format markdown|html] [module...]"
+	summary string
+	run     func(a *app, args []string) error
+}
+
+// commands is filled in init so that the help command can list it.
+var commands []*command
+
+func init() {
+	commands = []*command{
+		{"init", "[dir]", "Create a course workspace to work in, without cloning the repository", (*app).initWorkspace},
+		{"update", "[-channel stable|beta] [-server url] [-check]", "Bring a workspace up to date with the latest course release, keeping your changes", (*app).update},
+		{"list", "", "List the modules, including those from module providers", (*app).list},
+		{"check", "[-v] [-race] [-fuzz=duration] [-integration] [-vet=false] [-watch] <module>", "Run a module's exercise tests and record the results", (*app).check},
+		{"flaky", "[-n runs] [-race] [-procs 1,2,8] <module>", "Rerun a module's tests to find the ones that pass or fail by chance", (*app).flaky},
+		{"generate", "<module>", "Run the go:generate directives of a module's exercises, and point out misspelled ones", (*app).generate},
+		{"vet", "[-staticcheck] <module>", "Run go vet on a module's exercises and explain the findings", (*app).vet},
+		{"report", "[-format markdown|html] [-o file] [module...]", "Summarize progress for sharing with a mentor", (*app).report},
+		{"quiz", "[-n count] [-seed n] <module>", "Answer randomized questions about a module and record the score", (*app).quiz},
+		{"lesson", "[-step] [module[/lesson]]", "List lessons, or go through one, running its code cells as you read", (*app).lesson},
+		{"repl", "[-timeout d]", "Try out Go snippets without a scratch file: declarations are kept, expressions printed", (*app).repl},
+		{"export", "[-format anki|csv] [-o path] [-deck name] flashcards|cheatsheet [module...]", "Export flashcards for Anki or other apps, or cheat sheets of the examples", (*app).export},
+		{"next", "[-why]", "Suggest what to work on next: go on, practice, or review", (*app).next},
+		{"graph", "[-dot]", "Show which modules build on which, and which are locked", (*app).graph},
+		{"stats", "[-n count] [module...]", "Show the hardest exercises and time spent so far", (*app).stats},
+		{"badges", "", "List achievements and the ones you have unlocked", (*app).badges},
+		{"remind", "[-after hh:mm] [-notify] [-init bash|zsh|fish]", "Remind you to practice if you have not today, to keep your streak", (*app).remind},
+		{"serve", "[-addr host:port]", "Start a local web dashboard for progress and test results", (*app).serve},
+		{"sync", "[-server url] [-pull | -push]", "Merge your progress with a sync server, to work on several machines", (*app).sync},
+		{"sync-server", "[-addr host:port] [-data dir]", "Run a server that keeps progress for syncing between machines", (*app).syncServer},
+		{"classroom", "[-addr host:port] [-data file]", "Run an instructor server that collects a cohort's results", (*app).classroom},
+		{"gen", "[-check] readme [module...]", "Regenerate the sections of module READMEs that index examples, exercises, and tests", (*app).gen},
+		{"publish", "-version v [-channel stable|beta] <dir>", "Publish the built-in course content as a release for \"learngo update\"", (*app).publish},
+		{"help", "[command]", "Show help for learngo or one command", (*app).help},
+	}
+}
+
+// errUsage signals a usage mistake; the usage text has already been printed.
+var errUsage = errors.New("usage error")
+
+// app holds what every command needs. Tests build one pointing at a
+// temporary course.
+type app struct {
+	stdin          io.Reader
+	stdout, stderr io.Writer
+	root           string // course root; found from the working directory if empty
+	progressPath   string // progress file; progress.DefaultPath(root) if empty
+	now            func() time.Time
+	msg            *i18n.Catalog                 // translations; nil for English
+	notify         func(title, msg string) error // desktop notifications; none if nil
+}
+
+func main() {
+	a := &app{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		root:   os.Getenv("LEARNGO_ROOT"),
+		now:    time.Now,
+		notify: desktopNotify,
+	}
+	if err := a.main(os.Args[1:]); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintf(os.Stderr, "learngo: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func (a *app) main(args []string) error {
+	a.loadCatalog()
+	if len(args) == 0 {
+		a.usage()
+		return errUsage
+	}
+	cmd := lookup(args[0])
+	if cmd == nil {
+		fmt.Fprintf(a.stderr, "learngo: "+a.T("unknown command %q\n\n"), args[0])
+		a.usage()
+		return errUsage
+	}
+	return cmd.run(a, args[1:])
+}
+
+func lookup(name string) *command {
+	for _, c := range commands {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (a *app) usage() {
+	fmt.Fprintln(a.stderr, a.T("Usage: learngo <command> [arguments]"))
+	fmt.Fprintln(a.stderr)
+	fmt.Fprintln(a.stderr, a.T("Commands:"))
+	for _, c := range commands {
+		fmt.Fprintf(a.stderr, "  %-12s %s\n", c.name, a.T(c.summary))
+	}
+	fmt.Fprintln(a.stderr)
+	fmt.Fprintln(a.stderr, a.T(`Run "learngo help <command>" for details.`))
+}
+
+func (a *app) help(args []string) error {
+	if len(args) == 0 {
+		a.usage()
+		return nil
+	}
+	c := lookup(args[0])
+	if c == nil {
+		return fmt.Errorf(a.T("unknown command %q"), args[0])
+	}
+	fmt.Fprintf(a.stdout, a.T("Usage: learngo %s %s\n\n%s.\n"), c.name, c.args, a.T(c.summary))
+	return nil
+}
+
+// flagSet returns a FlagSet for a command that prints its errors and usage
+// to a.stderr.
+func (a *app) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		c := lookup(name)
+		fmt.Fprintf(a.stderr, a.T("Usage: learngo %s %s\n"), c.name, c.args)
+		fs.VisitAll(func(f *flag.Flag) { f.Usage = a.T(f.Usage) })
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// loadCatalog loads the translation LEARNGO_LANG asks for from the course.
+// Outside a course, or without a translation, learngo speaks English.
+func (a *app) loadCatalog() {
+	lang := i18n.Lang()
+	if lang == "" || a.msg != nil {
+		return
+	}
+	root := a.root
+	if root == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return
+		}
+		if root, err = course.FindRoot(wd); err != nil {
+			return
+		}
+	}
+	c, err := i18n.Load(root, lang)
+	if err != nil {
+		fmt.Fprintf(a.stderr, "learngo: %v; using English\n", err)
+		return
+	}
+	a.msg = c
+}
+
+// T translates a message for the learner; see package i18n.
+func (a *app) T(msg string) string { return a.msg.T(msg) }
+
+// parseFlags parses args, mapping flag errors to errUsage.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+	return nil
+}
+
+func (a *app) loadCourse() (*course.Course, error) {
+	if a.root == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		if a.root, err = course.FindRoot(wd); err != nil {
+			return nil, fmt.Errorf("%w\nRun \"learngo init\" to create a course workspace.", err)
+		}
+	}
+	return course.LoadWith(a.root, course.Discover()...)
+}
+
+// openStore opens the progress store. Call loadCourse first so the root is
+// known.
+func (a *app) openStore() (*progress.Store, error) {
+	path := a.progressPath
+	if path == "" {
+		path = progress.DefaultPath(a.root)
+	}
+	return progress.Open(path)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// testApp returns an app for a temporary course built from files (path ->
+// content) and buffers capturing its output.
+func testApp(t *testing.T, files map[string]string) (*app, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	root := t.TempDir()
+	files["go.mod"] = "module example.com/course\n\ngo 1.21\n"
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	var stdout, stderr bytes.Buffer
+	a := &app{
+		stdout:       &stdout,
+		stderr:       &stderr,
+		root:         root,
+		progressPath: filepath.Join(root, ".learngo", "progress.json"),
+		now:          func() time.Time { return testNow },
+	}
+	return a, &stdout, &stderr
+}
+
+func TestMainUsage(t *testing.T) {
+	a, _, stderr := testApp(t, map[string]string{})
+	assert.ErrorIs(t, a.main(nil), errUsage)
+	assert.Contains(t, stderr.String(), "report")
+
+	stderr.Reset()
+	assert.ErrorIs(t, a.main([]string{"frobnicate"}), errUsage)
+	assert.Contains(t, stderr.String(), `unknown command "frobnicate"`)
+}
+
+func TestHelp(t *testing.T) {
+	a, stdout, _ := testApp(t, map[string]string{})
+	require.NoError(t, a.main([]string{"help", "report"}))
+	assert.Contains(t, stdout.String(), "Usage: learngo report")
+	assert.Error(t, a.main([]string{"help", "nope"}))
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/recommend"
+)
+
+var difficultyNames = map[int]string{
+	course.Easy:   "easy",
+	course.Medium: "medium",
+	course.Hard:   "hard",
+}
+
+// next recommends what to work on.
+func (a *app) next(args []string) error {
+	fs := a.flagSet("next")
+	why := fs.Bool("why", false, "show the mistakes you keep making and what to practice or reread for them")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errUsage
+	}
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	store, err := a.openStore()
+	if err != nil {
+		return err
+	}
+
+	advice := recommend.Next(c, store)
+	switch advice.Action {
+	case recommend.Proceed:
+		fmt.Fprintf(a.stdout, a.T("Next: %s\n"), describe(c, advice.Exercise))
+	case recommend.Practice:
+		fmt.Fprintf(a.stdout, a.T("Practice first: you seem stuck on %s.\n"), advice.Exercise.ID)
+	case recommend.Review:
+		fmt.Fprintf(a.stdout, a.T("Review %s: reread its README and examples.\n"), advice.Module.ID)
+	case recommend.TakeQuiz:
+		fmt.Fprintf(a.stdout, a.T("Next: read %s and take its quiz.\n"), advice.Module.ID)
+	case recommend.Done:
+		fmt.Fprintln(a.stdout, a.T("You have finished every exercise. Well done!"))
+	}
+	if len(advice.Concepts) > 0 {
+		fmt.Fprintf(a.stdout, a.T("Concepts: %s\n"), strings.Join(advice.Concepts, ", "))
+	}
+	if len(advice.Reasons) > 0 {
+		fmt.Fprintln(a.stdout, a.T("\nWhy:"))
+		for _, r := range advice.Reasons {
+			fmt.Fprintf(a.stdout, "  - %s\n", r)
+		}
+	}
+	if len(advice.Practice) > 0 {
+		fmt.Fprintln(a.stdout, a.T("\nTry these first, then go back to it:"))
+		for _, e := range advice.Practice {
+			fmt.Fprintf(a.stdout, "  - %s\n", describe(c, e))
+		}
+	}
+
+	switch advice.Action {
+	case recommend.Proceed:
+		fmt.Fprintf(a.stdout, a.T("\nWhen you think it works: learngo check %s\n"), advice.Module.ID)
+	case recommend.TakeQuiz:
+		fmt.Fprintf(a.stdout, a.T("\nWhen you are ready: learngo quiz %s\n"), advice.Module.ID)
+	case recommend.Practice:
+		fmt.Fprint(a.stdout, a.T("\nCheck them with: learngo check <module>\n"))
+	case recommend.Review:
+		if advice.Exercise != nil {
+			fmt.Fprintf(a.stdout, a.T("\nThen try %s again: learngo check %s\n"), advice.Exercise.Name, advice.Module.ID)
+		} else {
+			fmt.Fprintf(a.stdout, a.T("\nThen retake the quiz: learngo quiz %s\n"), advice.Module.ID)
+		}
+	}
+	patterns := recommend.Patterns(c, store, a.now())
+	switch {
+	case *why:
+		a.printPatterns(c, patterns)
+	case len(patterns) > 0:
+		fmt.Fprint(a.stdout, a.T("\nYou keep making the same mistakes; learngo next -why shows which.\n"))
+	}
+	if line := a.streakLine(store.Streak(a.now())); line != "" {
+		fmt.Fprintf(a.stdout, "\n%s\n", line)
+	}
+	return nil
+}
+
+// printPatterns shows the mistakes the learner keeps making, with
+// exercises to practice and examples to reread for each.
+func (a *app) printPatterns(c *course.Course, patterns []recommend.Pattern) {
+	if len(patterns) == 0 {
+		fmt.Fprintf(a.stdout, a.T("\nNo mistake has come up %d times in the last %d days.\n"),
+			recommend.RepeatedMistake, int(recommend.RecentMistakes.Hours()/24))
+		return
+	}
+	fmt.Fprintln(a.stdout, a.T("\nMistakes you keep making:"))
+	for _, p := range patterns {
+		var where []string
+		for _, e := range p.Exercises {
+			where = append(where, e.ID)
+		}
+		fmt.Fprintf(a.stdout, "\n  "+a.T("%s: %d failed runs, in %s")+"\n", p.Mistake.Name, p.Count, strings.Join(where, ", "))
+		fmt.Fprintf(a.stdout, "    %s\n", p.Mistake.Advice)
+		for _, file := range p.Examples {
+			fmt.Fprintf(a.stdout, "    "+a.T("Reread: %s")+"\n", file)
+		}
+		for _, e := range p.Practice {
+			fmt.Fprintf(a.stdout, "    "+a.T("Practice: %s")+"\n", describe(c, e))
+		}
+	}
+}
+
+// describe names an exercise with its file and difficulty.
+func describe(c *course.Course, e *course.Exercise) string {
+	file, err := filepath.Rel(c.Root, e.File)
+	if err != nil {
+		file = e.File
+	}
+	s := filepath.ToSlash(file)
+	if d, ok := difficultyNames[e.Difficulty]; ok {
+		s += " (" + d + ")"
+	}
+	return s
+}
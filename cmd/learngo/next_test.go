@@ -0,0 +1,84 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/recommend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func nextApp(t *testing.T) (*app, func() string) {
+	t.Helper()
+	a, stdout, _ := testApp(t, map[string]string{
+		"modules/01-basics/README.md":              "# Module 01: Basics\n",
+		"modules/01-basics/module.json":            `{"exercises": {"loops": {"concepts": ["loops"], "difficulty": 1}, "recursion": {"concepts": ["recursion"], "difficulty": 3}, "memo": {"concepts": ["recursion", "maps"], "difficulty": 2}}}`,
+		"modules/01-basics/exercises/loops.go":     "package exercises\n",
+		"modules/01-basics/exercises/memo.go":      "package exercises\n",
+		"modules/01-basics/exercises/recursion.go": "package exercises\n",
+		"modules/02-types/README.md":               "# Module 02: Types\n",
+	})
+	return a, stdout.String
+}
+
+func TestNextProceed(t *testing.T) {
+	a, stdout := nextApp(t)
+	require.NoError(t, a.main([]string{"next"}))
+	out := stdout()
+	assert.Contains(t, out, "Next: modules/01-basics/exercises/loops.go (easy)")
+	assert.Contains(t, out, "Concepts: loops")
+	assert.Contains(t, out, "  - 0/3 exercises done in 01-basics")
+	assert.Contains(t, out, "learngo check 01-basics")
+	assert.NotContains(t, out, "Streak", "nothing practiced yet")
+}
+
+func TestNextPractice(t *testing.T) {
+	a, stdout := nextApp(t)
+	store, err := progress.Open(a.progressPath)
+	require.NoError(t, err)
+	for i := 0; i < recommend.StruggleFailedRuns; i++ {
+		store.RecordAttempt("01-basics/recursion", false, testNow)
+	}
+	require.NoError(t, store.Save())
+
+	require.NoError(t, a.main([]string{"next"}))
+	out := stdout()
+	assert.Contains(t, out, "Practice first: you seem stuck on 01-basics/recursion.")
+	assert.Contains(t, out, "\nStreak: 1 days (longest 1)\n")
+	assert.Contains(t, out, "recursion has failed 5 runs so far")
+	assert.Contains(t, out, "Try these first, then go back to it:\n  - modules/01-basics/exercises/memo.go (medium)")
+}
+
+func TestNextReviewQuiz(t *testing.T) {
+	a, stdout := nextApp(t)
+	store, err := progress.Open(a.progressPath)
+	require.NoError(t, err)
+	store.RecordQuiz("02-types", progress.Score{Correct: 1, Total: 5, At: testNow})
+	require.NoError(t, store.Save())
+
+	require.NoError(t, a.main([]string{"next"}))
+	out := stdout()
+	assert.Contains(t, out, "Review 02-types: reread its README and examples.")
+	assert.Contains(t, out, "retake the quiz: learngo quiz 02-types")
+}
+
+func TestNextWhy(t *testing.T) {
+	a, stdout := nextApp(t)
+	require.NoError(t, a.main([]string{"next", "-why"}))
+	assert.Contains(t, stdout(), "No mistake has come up 2 times in the last 14 days.")
+
+	store, err := progress.Open(a.progressPath)
+	require.NoError(t, err)
+	store.RecordMistake("01-basics/memo", "nil-map", testNow)
+	store.RecordMistake("01-basics/memo", "nil-map", testNow)
+	require.NoError(t, store.Save())
+
+	require.NoError(t, a.main([]string{"next"}))
+	assert.Contains(t, stdout(), "You keep making the same mistakes; learngo next -why shows which.")
+
+	require.NoError(t, a.main([]string{"next", "--why"}))
+	out := stdout()
+	assert.Contains(t, out, "Mistakes you keep making:\n\n  writing to a nil map: 2 failed runs, in 01-basics/memo\n    A map declared")
+	assert.NotContains(t, out, "Practice: modules/01-basics/exercises/memo.go", "not the exercise it came up in")
+}
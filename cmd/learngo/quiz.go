@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/quiz"
+)
+
+// quiz asks randomized questions from a module's question bank and records
+// the score.
+func (a *app) quiz(args []string) error {
+	fs := a.flagSet("quiz")
+	n := fs.Int("n", 5, "number of questions to ask (0 asks them all)")
+	seed := fs.Int64("seed", 0, "shuffle seed, for a repeatable quiz (0 picks one at random)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	m, err := c.Module(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	bank, err := quiz.LoadModule(m.Dir)
+	if errors.Is(err, quiz.ErrNoQuiz) {
+		return fmt.Errorf("%s has no quiz yet", m.ID)
+	}
+	if err != nil {
+		return err
+	}
+	store, err := a.openStore()
+	if err != nil {
+		return err
+	}
+
+	if *seed == 0 {
+		*seed = a.now().UnixNano()
+	}
+	questions := bank.Pick(*n, rand.New(rand.NewSource(*seed)))
+	fmt.Fprintf(a.stdout, a.T("Quiz: %s (%d questions). Answer with a letter.\n"), m.Title, len(questions))
+	res, err := quiz.Run(questions, a.stdin, a.stdout)
+	if err != nil {
+		return err
+	}
+	if res.Total == 0 {
+		fmt.Fprintln(a.stdout, a.T("\nNo answers, nothing recorded."))
+		return nil
+	}
+
+	score := progress.Score{Correct: res.Correct, Total: res.Total, At: a.now()}
+	prev := store.Quiz(m.ID)
+	store.RecordQuiz(m.ID, score)
+	fmt.Fprintf(a.stdout, a.T("\nYou scored %d/%d (%d%%).\n"), score.Correct, score.Total, score.Percent())
+	if len(res.Misses) > 0 {
+		fmt.Fprintln(a.stdout, a.T("\nReview:"))
+		for _, miss := range res.Misses {
+			q := miss.Question
+			fmt.Fprintf(a.stdout, "  - %s\n", q.Question)
+			fmt.Fprintf(a.stdout, "    "+a.T("You said %c) %s; the answer is %c) %s\n"), 'a'+miss.Given, q.Choices[miss.Given], 'a'+q.Answer, q.Choices[q.Answer])
+		}
+	}
+	if prev.Attempts > 0 && score.Percent() > prev.Best.Percent() {
+		fmt.Fprintf(a.stdout, a.T("\nNew best score! (previous best %d%%)\n"), prev.Best.Percent())
+	}
+	a.unlockBadges(c, store)
+	return store.Save()
+}
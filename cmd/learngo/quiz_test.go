@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/quiz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testQuizBank = `{"questions": [
+	{"id": "one", "question": "Pick yes", "choices": ["yes", "no"], "answer": 0, "explanation": "Yes is right."},
+	{"id": "two", "question": "Pick also yes", "choices": ["no", "yes"], "answer": 1}
+]}`
+
+func quizApp(t *testing.T, input string) (*app, *bytes.Buffer) {
+	t.Helper()
+	a, stdout, _ := testApp(t, map[string]string{
+		"modules/02-types/README.md": "# Module 02: Types\n",
+		"modules/02-types/quiz.json": testQuizBank,
+	})
+	a.stdin = strings.NewReader(input)
+	return a, stdout
+}
+
+// answers returns the letters that answer the quiz above correctly or
+// wrongly when it is shuffled with seed, as the quiz command does.
+func answers(t *testing.T, seed int64, correct bool) string {
+	t.Helper()
+	var bank quiz.Bank
+	require.NoError(t, json.Unmarshal([]byte(testQuizBank), &bank))
+	var sb strings.Builder
+	for _, q := range bank.Pick(5, rand.New(rand.NewSource(seed))) {
+		answer := q.Answer
+		if !correct {
+			answer = 1 - answer
+		}
+		fmt.Fprintf(&sb, "%c\n", 'a'+answer)
+	}
+	return sb.String()
+}
+
+func TestQuizRecordsScore(t *testing.T) {
+	a, out := quizApp(t, answers(t, 7, false))
+	require.NoError(t, a.main([]string{"quiz", "-seed", "7", "02"}))
+	text := out.String()
+	assert.Contains(t, text, "Quiz: Types (2 questions)")
+	assert.Contains(t, text, "Yes is right.")
+	assert.Contains(t, text, "You scored 0/2 (0%).")
+	assert.Contains(t, text, "Review:\n  - Pick")
+
+	store, err := a.openStore()
+	require.NoError(t, err)
+	q := store.Quiz("02-types")
+	assert.Equal(t, 1, q.Attempts)
+	assert.Equal(t, 2, q.Last.Total)
+
+	// Same store, better score.
+	out.Reset()
+	a.stdin = strings.NewReader(answers(t, 7, true))
+	require.NoError(t, a.main([]string{"quiz", "-seed", "7", "02"}))
+	assert.Contains(t, out.String(), "You scored 2/2 (100%).")
+	assert.Contains(t, out.String(), "New best score! (previous best 0%)")
+	assert.NotContains(t, out.String(), "Review:")
+
+	store, err = a.openStore()
+	require.NoError(t, err)
+	q = store.Quiz("02-types")
+	assert.Equal(t, 2, q.Attempts)
+	assert.Equal(t, 100, q.Best.Percent())
+}
+
+func TestQuizNoAnswers(t *testing.T) {
+	a, out := quizApp(t, "")
+	require.NoError(t, a.main([]string{"quiz", "02"}))
+	assert.Contains(t, out.String(), "No answers, nothing recorded.")
+	store, err := a.openStore()
+	require.NoError(t, err)
+	assert.Zero(t, store.Quiz("02-types").Attempts)
+}
+
+func TestQuizMissingBank(t *testing.T) {
+	a, _, _ := testApp(t, map[string]string{"modules/01-basics/README.md": "# Module 01: Basics\n"})
+	assert.EqualError(t, a.main([]string{"quiz", "01"}), "01-basics has no quiz yet")
+}
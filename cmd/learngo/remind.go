@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+)
+
+// shellFiles names the startup file each supported shell reads, for the
+// snippet "learngo remind -init" prints.
+var shellFiles = map[string]string{
+	"bash": "~/.bashrc",
+	"zsh":  "~/.zshrc",
+	"fish": "~/.config/fish/config.fish",
+}
+
+// remind nags the learner who has not practiced today, to keep their
+// streak going. It is meant to run from a shell startup file; -init
+// prints the line to put there.
+func (a *app) remind(args []string) error {
+	fs := a.flagSet("remind")
+	after := fs.String("after", "", "stay quiet before this time of day, e.g. 18:00")
+	notify := fs.Bool("notify", false, "show a desktop notification instead of printing")
+	shell := fs.String("init", "", "print the line to add to the startup file of `shell` (bash, zsh, or fish)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errUsage
+	}
+	var from time.Duration
+	if *after != "" {
+		t, err := time.Parse("15:04", *after)
+		if err != nil {
+			return fmt.Errorf(a.T("-after %q: want a time of day like 18:00"), *after)
+		}
+		from = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+
+	if *shell != "" {
+		file, ok := shellFiles[*shell]
+		if !ok {
+			return fmt.Errorf(a.T("unknown shell %q: want bash, zsh, or fish"), *shell)
+		}
+		cmd := "env LEARNGO_ROOT=" + shellQuote(*shell, c.Root) + " learngo remind"
+		if *after != "" {
+			cmd += " -after " + *after
+		}
+		if *notify {
+			cmd += " -notify"
+		}
+		fmt.Fprintf(a.stdout, a.T("# Add this to %s to be reminded to practice:\n"), file)
+		fmt.Fprintf(a.stdout, "%s 2>/dev/null\n", cmd)
+		return nil
+	}
+
+	store, err := a.openStore()
+	if err != nil {
+		return err
+	}
+	now := a.now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	streak := store.Streak(now)
+	if streak.Today || now.Sub(midnight) < from {
+		return nil
+	}
+	msg := a.reminder(streak)
+	if *notify && a.notify != nil {
+		if err := a.notify("learngo", msg); err == nil {
+			return nil
+		}
+	}
+	fmt.Fprintln(a.stdout, msg)
+	return nil
+}
+
+// reminder is the nag for a learner who has not practiced today.
+func (a *app) reminder(streak progress.Streak) string {
+	if streak.Current > 0 {
+		return fmt.Sprintf(a.T("Your %d-day Go streak ends tonight. Keep it going: learngo next"), streak.Current)
+	}
+	return a.T("You have not practiced Go today. Start a streak: learngo next")
+}
+
+// streakLine describes the learner's streak for the terminal, or returns
+// "" if they have none to speak of.
+func (a *app) streakLine(streak progress.Streak) string {
+	switch {
+	case streak.Today:
+		return fmt.Sprintf(a.T("Streak: %d days (longest %d)"), streak.Current, streak.Longest)
+	case streak.Current > 0:
+		return fmt.Sprintf(a.T("Streak: %d days, ends tonight unless you practice today (longest %d)"), streak.Current, streak.Longest)
+	case streak.Longest > 0:
+		return fmt.Sprintf(a.T("No streak going (longest %d days)"), streak.Longest)
+	}
+	return ""
+}
+
+// shellQuote quotes s for shell. Single quotes in POSIX shells take
+// everything literally, so a quote has to end and restart them; fish
+// escapes it instead.
+func shellQuote(shell, s string) string {
+	if shell == "fish" {
+		return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// desktopNotify shows a desktop notification with the tools the system
+// comes with.
+func desktopNotify(title, msg string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", msg, title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "linux", "freebsd", "openbsd", "netbsd":
+		cmd = exec.Command("notify-send", title, msg)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+	return cmd.Run()
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// remindApp returns an app for a learner who practiced on the days before
+// testNow given as offsets, e.g. -1 for yesterday.
+func remindApp(t *testing.T, days ...int) (*app, func() string) {
+	t.Helper()
+	a, stdout, _ := testApp(t, map[string]string{
+		"modules/01-basics/README.md":              "# Module 01: Basics\n",
+		"modules/01-basics/exercises/exercise1.go": "package exercises\n",
+	})
+	store, err := progress.Open(a.progressPath)
+	require.NoError(t, err)
+	for _, d := range days {
+		store.RecordDay(testNow.AddDate(0, 0, d))
+	}
+	require.NoError(t, store.Save())
+	return a, func() string {
+		out := stdout.String()
+		stdout.Reset()
+		return out
+	}
+}
+
+func TestRemind(t *testing.T) {
+	a, stdout := remindApp(t, -3, -2, -1)
+	require.NoError(t, a.main([]string{"remind"}))
+	assert.Equal(t, "Your 3-day Go streak ends tonight. Keep it going: learngo next\n", stdout())
+
+	// testNow is 12:00.
+	require.NoError(t, a.main([]string{"remind", "-after", "18:00"}))
+	assert.Empty(t, stdout(), "too early")
+	require.NoError(t, a.main([]string{"remind", "-after", "9:30"}))
+	assert.NotEmpty(t, stdout())
+
+	a, stdout = remindApp(t, -5)
+	require.NoError(t, a.main([]string{"remind"}))
+	assert.Equal(t, "You have not practiced Go today. Start a streak: learngo next\n", stdout())
+
+	a, stdout = remindApp(t, -1, 0)
+	require.NoError(t, a.main([]string{"remind"}))
+	assert.Empty(t, stdout(), "practiced today")
+
+	assert.ErrorContains(t, a.main([]string{"remind", "-after", "evening"}), "want a time of day")
+	assert.ErrorIs(t, a.main([]string{"remind", "now"}), errUsage)
+}
+
+func TestRemindNotify(t *testing.T) {
+	a, stdout := remindApp(t, -1)
+	var got []string
+	a.notify = func(title, msg string) error {
+		got = append(got, title+": "+msg)
+		return nil
+	}
+	require.NoError(t, a.main([]string{"remind", "-notify"}))
+	assert.Equal(t, []string{"learngo: Your 1-day Go streak ends tonight. Keep it going: learngo next"}, got)
+	assert.Empty(t, stdout())
+
+	a.notify = func(string, string) error { return errors.New("no notify-send") }
+	require.NoError(t, a.main([]string{"remind", "-notify"}))
+	assert.Contains(t, stdout(), "streak ends tonight", "printed when notifications fail")
+}
+
+func TestRemindInit(t *testing.T) {
+	a, stdout := remindApp(t)
+	require.NoError(t, a.main([]string{"remind", "-init", "bash", "-after", "18:00", "-notify"}))
+	assert.Equal(t, "# Add this to ~/.bashrc to be reminded to practice:\n"+
+		"env LEARNGO_ROOT="+shellQuote("bash", a.root)+" learngo remind -after 18:00 -notify 2>/dev/null\n", stdout())
+
+	require.NoError(t, a.main([]string{"remind", "-init", "fish"}))
+	assert.Contains(t, stdout(), "# Add this to ~/.config/fish/config.fish")
+	assert.ErrorContains(t, a.main([]string{"remind", "-init", "tcsh"}), `unknown shell "tcsh"`)
+
+	assert.Equal(t, `'/home/o'\''brien/go course'`, shellQuote("zsh", "/home/o'brien/go course"))
+	assert.Equal(t, `'C:\\go\'s course'`, shellQuote("fish", `C:\go's course`))
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/repl"
+)
+
+// repl evaluates Go snippets as they are typed, for quick experiments
+// without a scratch file.
+func (a *app) repl(args []string) error {
+	fs := a.flagSet("repl")
+	timeout := fs.Duration("timeout", 10*time.Second, "stop inputs that run longer than this (0 for no limit)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	s, err := repl.New()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	s.Timeout = *timeout
+	fmt.Fprintln(a.stdout, a.T("Go REPL: enter declarations, statements, or expressions. :help lists commands, Ctrl-D quits."))
+	return repl.Run(context.Background(), s, a.stdin, a.stdout, a.stderr)
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepl(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go build")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	a, stdout, stderr := testApp(t, map[string]string{})
+	a.stdin = strings.NewReader("s := []string{\"b\", \"a\"}\nslices.Sort(s)\ns\nundefined\n")
+	require.NoError(t, a.main([]string{"repl"}))
+	assert.Contains(t, stdout.String(), "Go REPL")
+	assert.Contains(t, stdout.String(), `>>> []string{"a", "b"}`)
+	assert.Contains(t, stderr.String(), "1:1: undefined: undefined")
+
+	assert.ErrorIs(t, a.main([]string{"repl", "extra"}), errUsage)
+}
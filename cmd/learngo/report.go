@@ -0,0 +1,216 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// Exercise status values shown in reports.
+const (
+	statusDone       = "done"
+	statusInProgress = "in progress"
+	statusNotStarted = "not started"
+)
+
+// exerciseStatus summarizes an exercise's progress as one of the status
+// values.
+func exerciseStatus(p progress.Exercise) string {
+	switch {
+	case !p.CompletedAt.IsZero():
+		return statusDone
+	case p.Attempts > 0 || p.HintsUsed > 0:
+		return statusInProgress
+	}
+	return statusNotStarted
+}
+
+// courseReport is the data behind a progress report.
+type courseReport struct {
+	Generated time.Time
+	Completed int
+	Total     int
+	Modules   []moduleReport
+}
+
+// Percent returns the share of completed exercises, rounded down.
+func (r courseReport) Percent() int { return percent(r.Completed, r.Total) }
+
+type moduleReport struct {
+	ID        string
+	Title     string
+	Completed int
+	Total     int
+	Attempts  int
+	TimeSpent time.Duration
+	HintsUsed int
+	Quiz      progress.Quiz
+	Exercises []exerciseReport
+}
+
+func (m moduleReport) Percent() int { return percent(m.Completed, m.Total) }
+
+type exerciseReport struct {
+	Name        string
+	Status      string
+	Attempts    int
+	TimeSpent   time.Duration
+	HintsUsed   int
+	CompletedAt time.Time
+}
+
+func percent(n, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return n * 100 / total
+}
+
+// buildReport combines the course layout with recorded progress. Modules
+// without exercises are left out; only modules named in only are included
+// when it is non-empty.
+func buildReport(c *course.Course, store *progress.Store, only []*course.Module, now time.Time) courseReport {
+	r := courseReport{Generated: now}
+	modules := c.Modules
+	if len(only) > 0 {
+		modules = only
+	}
+	for _, m := range modules {
+		if len(m.Exercises) == 0 {
+			continue
+		}
+		mr := moduleReport{ID: m.ID, Title: m.Title, Total: len(m.Exercises), Quiz: store.Quiz(m.ID)}
+		for _, e := range m.Exercises {
+			p := store.Get(e.ID)
+			er := exerciseReport{
+				Name:        e.Name,
+				Status:      exerciseStatus(p),
+				Attempts:    p.Attempts,
+				TimeSpent:   p.TimeSpent(),
+				HintsUsed:   p.HintsUsed,
+				CompletedAt: p.CompletedAt,
+			}
+			if er.Status == statusDone {
+				mr.Completed++
+			}
+			mr.Attempts += er.Attempts
+			mr.TimeSpent += er.TimeSpent
+			mr.HintsUsed += er.HintsUsed
+			mr.Exercises = append(mr.Exercises, er)
+		}
+		r.Completed += mr.Completed
+		r.Total += mr.Total
+		r.Modules = append(r.Modules, mr)
+	}
+	return r
+}
+
+// formatDuration renders time spent for humans: "-", "<1m", "45m", "2h5m".
+func formatDuration(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "-"
+	case d < time.Minute:
+		return "<1m"
+	}
+	s := strings.TrimSuffix(d.Round(time.Minute).String(), "0s") // "2h5m0s" -> "2h5m"
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
+
+var templateFuncs = map[string]any{
+	"duration": formatDuration,
+	"date":     formatDate,
+}
+
+// renderReport writes r as "markdown" or "html".
+func renderReport(w io.Writer, r courseReport, format string) error {
+	switch format {
+	case "markdown", "md":
+		t, err := texttemplate.New("report.md.tmpl").Funcs(templateFuncs).ParseFS(templateFS, "templates/report.md.tmpl")
+		if err != nil {
+			return err
+		}
+		return t.Execute(w, r)
+	case "html":
+		t, err := htmltemplate.New("report.html.tmpl").Funcs(templateFuncs).ParseFS(templateFS, "templates/report.html.tmpl")
+		if err != nil {
+			return err
+		}
+		return t.Execute(w, r)
+	default:
+		return fmt.Errorf("unknown report format %q (want markdown or html)", format)
+	}
+}
+
+// report renders the progress store as Markdown or HTML.
+func (a *app) report(args []string) error {
+	fs := a.flagSet("report")
+	format := fs.String("format", "", "output format: markdown or html (default: from -o, else markdown)")
+	out := fs.String("o", "", "write the report to this file instead of standard output")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *format == "" {
+		*format = "markdown"
+		if ext := filepath.Ext(*out); ext == ".html" || ext == ".htm" {
+			*format = "html"
+		}
+	}
+
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	var only []*course.Module
+	for _, name := range fs.Args() {
+		m, err := c.Module(name)
+		if err != nil {
+			return err
+		}
+		only = append(only, m)
+	}
+	store, err := a.openStore()
+	if err != nil {
+		return err
+	}
+	r := buildReport(c, store, only, a.now())
+
+	if *out == "" {
+		return renderReport(a.stdout, r, *format)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := renderReport(f, r, *format); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, a.T("Wrote %s\n"), *out)
+	return nil
+}
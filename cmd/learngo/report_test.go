@@ -0,0 +1,120 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/internal/snapshot"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reportCourse(t *testing.T) (*app, func() string, func() string) {
+	t.Helper()
+	a, stdout, stderr := testApp(t, map[string]string{
+		"modules/01-basics/README.md":              "# Module 01: Go Basics\n",
+		"modules/01-basics/exercises/exercise1.go": "package exercises\n",
+		"modules/01-basics/exercises/exercise2.go": "package exercises\n",
+		"modules/01-basics/exercises/exercise3.go": "package exercises\n",
+		"modules/02-types/README.md":               "# Module 02: Types <and> Interfaces\n",
+		"modules/02-types/exercises/exercise1.go":  "package exercises\n",
+		"modules/03-concurrency/README.md":         "# Module 03: Concurrency\n",
+	})
+	store, err := progress.Open(a.progressPath)
+	require.NoError(t, err)
+	store.RecordAttempt("01-basics/exercise1", false, testNow.Add(-2*time.Hour))
+	store.RecordAttempt("01-basics/exercise1", true, testNow.Add(-85*time.Minute))
+	store.RecordAttempt("01-basics/exercise2", false, testNow.Add(-time.Hour))
+	store.RecordHint("01-basics/exercise2")
+	store.RecordQuiz("02-types", progress.Score{Correct: 4, Total: 5, At: testNow})
+	require.NoError(t, store.Save())
+	return a, stdout.String, stderr.String
+}
+
+func TestBuildReport(t *testing.T) {
+	a, _, _ := reportCourse(t)
+	c, err := a.loadCourse()
+	require.NoError(t, err)
+	store, err := a.openStore()
+	require.NoError(t, err)
+
+	r := buildReport(c, store, nil, testNow)
+	assert.Equal(t, 1, r.Completed)
+	assert.Equal(t, 4, r.Total)
+	assert.Equal(t, 25, r.Percent())
+	require.Len(t, r.Modules, 2, "modules without exercises are skipped")
+
+	basics := r.Modules[0]
+	assert.Equal(t, 33, basics.Percent())
+	assert.Equal(t, 3, basics.Attempts)
+	assert.Equal(t, 35*time.Minute, basics.TimeSpent)
+	assert.Equal(t, 1, basics.HintsUsed)
+	assert.Equal(t, []string{statusDone, statusInProgress, statusNotStarted}, []string{
+		basics.Exercises[0].Status, basics.Exercises[1].Status, basics.Exercises[2].Status,
+	})
+
+	only, err := c.Module("02")
+	require.NoError(t, err)
+	r = buildReport(c, store, []*course.Module{only}, testNow)
+	require.Len(t, r.Modules, 1)
+	assert.Equal(t, "02-types", r.Modules[0].ID)
+}
+
+func TestReportMarkdown(t *testing.T) {
+	a, stdout, _ := reportCourse(t)
+	require.NoError(t, a.main([]string{"report"}))
+	out := stdout()
+	assert.Contains(t, out, "Overall: **1/4 exercises (25%)**")
+	assert.Contains(t, out, "| 01-basics | 1/3 (33%) | 3 | 35m | 1 |")
+	assert.Contains(t, out, "## 02-types: Types <and> Interfaces")
+	assert.Contains(t, out, "| exercise1 | done | 2 | 35m | 0 | 2026-03-01 |")
+	assert.Contains(t, out, "| exercise3 | not started | 0 | - | 0 | - |")
+	assert.Contains(t, out, "Quiz: best 4/5 (80%), last 4/5, 1 attempt(s).")
+	assert.Equal(t, 1, strings.Count(out, "Quiz:"), "modules without a quiz score say nothing")
+	assert.NotContains(t, out, "03-concurrency")
+	snapshot.Match(t, "report.md", out)
+}
+
+func TestReportHTMLFile(t *testing.T) {
+	a, _, stderr := reportCourse(t)
+	path := filepath.Join(t.TempDir(), "report.html")
+	require.NoError(t, a.main([]string{"report", "-o", path, "01", "02"}))
+	assert.Contains(t, stderr(), "Wrote "+path)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	html := string(data)
+	assert.Contains(t, html, "<!DOCTYPE html>")
+	assert.Contains(t, html, `<h2 id="01-basics">`)
+	assert.Contains(t, html, `<td class="in-progress">in progress</td>`)
+	assert.Contains(t, html, "Types &lt;and&gt; Interfaces", "titles are escaped")
+	snapshot.Match(t, "report.html", html)
+}
+
+func TestReportErrors(t *testing.T) {
+	a, _, _ := reportCourse(t)
+	assert.ErrorContains(t, a.main([]string{"report", "-format", "pdf"}), "unknown report format")
+	assert.ErrorContains(t, a.main([]string{"report", "99"}), "not found")
+	assert.ErrorIs(t, a.main([]string{"report", "-bogus"}), errUsage)
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "-"},
+		{20 * time.Second, "<1m"},
+		{45 * time.Minute, "45m"},
+		{2*time.Hour + 5*time.Minute + 20*time.Second, "2h5m"},
+		{3 * time.Hour, "3h"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatDuration(tt.d), tt.d.String())
+	}
+}
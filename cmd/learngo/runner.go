@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/achievements"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/grader"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/recommend"
+)
+
+// The dashboard runs the exercises of modules marked "browser" in the
+// learner's browser: the exercise page edits the file, POST .../build saves
+// it and answers with the module's tests compiled to WebAssembly, the page
+// runs them with the toolchain's wasm_exec.js, and POST .../results grades
+// what they printed and records the attempt. Both requests must carry the
+// ETag of the file they started from in If-Match, so that edits made
+// elsewhere in the meantime are not overwritten or graded by mistake.
+// Custom headers also keep other web sites from posting to the dashboard.
+
+// browserRunner is what the exercise page needs to run an exercise in the
+// browser.
+type browserRunner struct {
+	Source  string
+	Version string            // ETag of Source
+	Args    []string          // for the test binary
+	Env     map[string]string // for the test binary, e.g. the learner's seed
+}
+
+// newBrowserRunner returns the runner for an exercise, or nil if it cannot
+// run in the browser.
+func newBrowserRunner(m *course.Module, e *course.Exercise, store *progress.Store) (*browserRunner, error) {
+	if !m.Browser {
+		return nil, nil
+	}
+	owners, err := grader.TestsByExercise(m)
+	if err != nil {
+		return nil, err
+	}
+	tests := grader.BrowserTests(owners[e.ID])
+	if len(tests) == 0 {
+		return nil, nil
+	}
+	src, err := os.ReadFile(e.File)
+	if err != nil {
+		return nil, err
+	}
+	env := make(map[string]string)
+	for _, kv := range seedEnv(store) {
+		k, v, _ := strings.Cut(kv, "=")
+		env[k] = v
+	}
+	return &browserRunner{
+		Source:  string(src),
+		Version: fileVersion(src),
+		Args:    []string{"-test.v", "-test.run", grader.RunPattern(tests)},
+		Env:     env,
+	}, nil
+}
+
+// fileVersion is the ETag of a file's content.
+func fileVersion(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// checkVersion reports whether the request's If-Match names the current
+// content of the exercise file, answering the request if not.
+func (s *server) checkVersion(w http.ResponseWriter, r *http.Request, e *course.Exercise) ([]byte, bool) {
+	current, err := os.ReadFile(e.File)
+	if err != nil {
+		s.serverError(w, err)
+		return nil, false
+	}
+	if r.Header.Get("If-Match") != fileVersion(current) {
+		http.Error(w, "the exercise file changed since the page was loaded: reload it", http.StatusPreconditionFailed)
+		return nil, false
+	}
+	return current, true
+}
+
+// buildExercise saves the edited exercise and answers with the module's
+// tests compiled to WebAssembly, or with the compiler errors.
+func (s *server) buildExercise(w http.ResponseWriter, r *http.Request, m *course.Module, e *course.Exercise) {
+	if !m.Browser {
+		http.Error(w, m.ID+" does not run in the browser", http.StatusNotFound)
+		return
+	}
+	src, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.checkVersion(w, r, e)
+	if !ok {
+		return
+	}
+	if !bytes.Equal(src, current) {
+		if err := os.WriteFile(e.File, src, 0o644); err != nil {
+			s.serverError(w, err)
+			return
+		}
+	}
+	w.Header().Set("ETag", fileVersion(src))
+
+	wasm, buildOutput, err := s.buildWasm(r.Context(), m)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if buildOutput != "" {
+		// Like "learngo check", a build failure is a failed attempt.
+		if err := s.recordExercise(r.Context(), m, &grader.Result{Exercise: e.ID}, buildOutput); err != nil {
+			s.serverError(w, err)
+			return
+		}
+		http.Error(w, buildOutput, http.StatusUnprocessableEntity)
+		return
+	}
+	w.Header().Set("Content-Type", "application/wasm")
+	w.Write(wasm)
+}
+
+// exerciseResults grades the output of the tests the browser ran and
+// records the attempt.
+func (s *server) exerciseResults(w http.ResponseWriter, r *http.Request, m *course.Module, e *course.Exercise) {
+	out, err := io.ReadAll(io.LimitReader(r.Body, 4<<20))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.checkVersion(w, r, e); !ok {
+		return
+	}
+	run, err := grader.GradeOutput(m, bytes.NewReader(out))
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	var res *grader.Result
+	for _, rr := range run.Results {
+		if rr.Exercise == e.ID {
+			res = rr
+		}
+	}
+	if res == nil {
+		http.Error(w, e.ID+" has no tests", http.StatusNotFound)
+		return
+	}
+	if err := s.recordExercise(r.Context(), m, res, ""); err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	type testJSON struct {
+		Name    string `json:"name"`
+		Passed  bool   `json:"passed"`
+		Skipped bool   `json:"skipped"`
+	}
+	resp := struct {
+		Passed bool       `json:"passed"`
+		Tests  []testJSON `json:"tests"`
+	}{Passed: res.Passed}
+	for _, t := range res.Tests {
+		resp.Tests = append(resp.Tests, testJSON{t.Name, t.Passed, t.Skipped})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// recordExercise records an attempt at one exercise, like check does for
+// a whole module, and shows its tests on the module and exercise pages.
+// buildOutput holds the compiler errors if it did not build. s.mu must be
+// held.
+func (s *server) recordExercise(ctx context.Context, m *course.Module, res *grader.Result, buildOutput string) error {
+	store, err := s.app.openStore()
+	if err != nil {
+		return err
+	}
+	store.RecordAttempt(res.Exercise, res.Passed, s.app.now())
+	run := &grader.Run{Module: m.ID, Results: []*grader.Result{res}, BuildOutput: buildOutput}
+	recommend.RecordMistakes(store, m, run, s.app.now())
+	achievements.Update(s.course, store)
+	if err := store.Save(); err != nil {
+		return err
+	}
+	s.app.pushReport(ctx, store, m)
+	if run := s.runs[m.ID]; run != nil {
+		for i, rr := range run.Results {
+			if rr.Exercise == res.Exercise {
+				run.Results[i] = res
+			}
+		}
+	}
+	return nil
+}
+
+// handleWasmExec serves GET /wasm_exec.js, the support script for running
+// Go WebAssembly, from the toolchain that builds it.
+func (s *server) handleWasmExec(w http.ResponseWriter, r *http.Request) {
+	js, err := s.wasmExec(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+	w.Write(js)
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const runnerAdd = "package exercises\n\nfunc Add(a, b int) int { return 0 }\n"
+
+func runnerServer(t *testing.T) (*server, *app) {
+	t.Helper()
+	a, _, _ := testApp(t, map[string]string{
+		"modules/01-demo/module.json":            `{"browser": true}`,
+		"modules/01-demo/exercises/add.go":       runnerAdd,
+		"modules/01-demo/exercises/add_test.go":  "package exercises\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {}\n",
+		"modules/02-other/exercises/sub.go":      "package exercises\n",
+		"modules/02-other/exercises/sub_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestSub(t *testing.T) {}\n",
+	})
+	c, err := a.loadCourse()
+	require.NoError(t, err)
+	s, err := newServer(a, c)
+	require.NoError(t, err)
+	s.buildWasm = func(context.Context, *course.Module) ([]byte, string, error) {
+		return []byte("\x00asm"), "", nil
+	}
+	s.wasmExec = func(context.Context) ([]byte, error) {
+		return []byte("globalThis.Go = class {};"), nil
+	}
+	return s, a
+}
+
+func postVersion(t *testing.T, h http.Handler, path, version, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("If-Match", version)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServeRunner(t *testing.T) {
+	s, _ := runnerServer(t)
+	body := get(t, s, "/exercises/01-demo/add").Body.String()
+	assert.Contains(t, body, "Save and run tests")
+	assert.Contains(t, body, `<script src="/wasm_exec.js">`)
+	assert.Contains(t, body, "TestAdd")
+	assert.Contains(t, body, "LEARNGO_SEED")
+
+	body = get(t, s, "/exercises/02-other/sub").Body.String()
+	assert.NotContains(t, body, "Save and run tests", "not a browser module")
+	rec := postVersion(t, s, "/exercises/02-other/sub/build", "", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, get(t, s, "/exercises/01-demo/add/build").Code)
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/exercises/01-demo/add/nope").Code)
+
+	rec = get(t, s, "/wasm_exec.js")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/javascript; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "globalThis.Go = class {};", rec.Body.String())
+}
+
+func TestServeRunnerBuild(t *testing.T) {
+	s, a := runnerServer(t)
+	file := filepath.Join(a.root, "modules/01-demo/exercises/add.go")
+	fixed := "package exercises\n\nfunc Add(a, b int) int { return a + b }\n"
+
+	rec := postVersion(t, s, "/exercises/01-demo/add/build", `"stale"`, fixed)
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, runnerAdd, string(data), "not overwritten")
+
+	rec = postVersion(t, s, "/exercises/01-demo/add/build", fileVersion([]byte(runnerAdd)), fixed)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/wasm", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "\x00asm", rec.Body.String())
+	assert.Equal(t, fileVersion([]byte(fixed)), rec.Header().Get("ETag"))
+	data, err = os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, fixed, string(data))
+
+	s.buildWasm = func(context.Context, *course.Module) ([]byte, string, error) {
+		return nil, "./add.go:3:33: syntax error", nil
+	}
+	rec = postVersion(t, s, "/exercises/01-demo/add/build", fileVersion([]byte(fixed)), fixed)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "syntax error")
+	store, err := a.openStore()
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.Get("01-demo/add").Attempts, "a build failure is a failed attempt")
+
+	s.buildWasm = func(context.Context, *course.Module) ([]byte, string, error) {
+		return nil, "", errors.New("go: not found")
+	}
+	rec = postVersion(t, s, "/exercises/01-demo/add/build", fileVersion([]byte(fixed)), fixed)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestServeRunnerResults(t *testing.T) {
+	s, a := runnerServer(t)
+	version := fileVersion([]byte(runnerAdd))
+	out := "=== RUN   TestAdd\n--- PASS: TestAdd (0.00s)\nPASS\n"
+
+	rec := postVersion(t, s, "/exercises/01-demo/add/results", `"stale"`, out)
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+
+	rec = postVersion(t, s, "/exercises/01-demo/add/results", version, out)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"passed": true, "tests": [{"name": "TestAdd", "passed": true, "skipped": false}]}`, rec.Body.String())
+
+	store, err := a.openStore()
+	require.NoError(t, err)
+	ex := store.Get("01-demo/add")
+	assert.Equal(t, 1, ex.Attempts)
+	assert.False(t, ex.CompletedAt.IsZero())
+
+	rec = postVersion(t, s, "/exercises/01-demo/add/results", version, "=== RUN   TestAdd\n")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"passed": false, "tests": [{"name": "TestAdd", "passed": false, "skipped": false}]}`, rec.Body.String(), "crashed")
+}
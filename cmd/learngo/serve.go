@@ -0,0 +1,504 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/doc/comment"
+	"html/template"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/achievements"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/grader"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/lesson"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/recommend"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/repl"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vet"
+)
+
+// serve starts the local dashboard.
+func (a *app) serve(args []string) error {
+	fs := a.flagSet("serve")
+	addr := fs.String("addr", "localhost:7070", "address to listen on")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	s, err := newServer(a, c)
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(a.stdout, a.T("Dashboard running at http://%s (Ctrl-C to stop)\n"), ln.Addr())
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// server is the dashboard's HTTP handler. The progress store is re-read on
+// every request, so results recorded by "learngo check" in a terminal show
+// up on the next page load.
+type server struct {
+	app    *app
+	course *course.Course
+	pages  *template.Template
+	mux    *http.ServeMux
+	grade  func(context.Context, *course.Module, grader.Options) (*grader.Run, error)
+	vet    func(ctx context.Context, dir string, opts vet.Options) ([]vet.Finding, error)
+
+	// For running exercises in the browser; see runner.go.
+	buildWasm func(context.Context, *course.Module) (wasm []byte, buildOutput string, err error)
+	wasmExec  func(context.Context) ([]byte, error)
+	buildApp  func(ctx context.Context, dir string) (wasm []byte, buildOutput string, err error)
+
+	mu       sync.Mutex             // serializes test runs and store writes
+	runs     map[string]*grader.Run // latest detailed run per module, this session only
+	findings map[string][]vet.Finding
+	lessons  map[string]*lesson.Lesson // run lessons by ID, until they change
+}
+
+func newServer(a *app, c *course.Course) (*server, error) {
+	funcs := template.FuncMap{
+		"duration": formatDuration,
+		"date":     formatDate,
+		"doc":      renderDoc,
+		"markdown": func(text string) template.HTML { return template.HTML(lesson.HTML(text)) },
+		"T":        a.T,
+		"lang":     a.msg.Lang,
+		"statusClass": func(status string) string {
+			return strings.ReplaceAll(status, " ", "-")
+		},
+	}
+	pages, err := template.New("").Funcs(funcs).ParseFS(templateFS, "templates/serve/*.html")
+	if err != nil {
+		return nil, err
+	}
+	s := &server{
+		app:       a,
+		course:    c,
+		pages:     pages,
+		mux:       http.NewServeMux(),
+		grade:     grader.GradeWith,
+		vet:       vet.Run,
+		buildWasm: grader.BuildWasm,
+		wasmExec:  grader.WasmExec,
+		buildApp:  grader.BuildWasmProgram,
+		runs:      make(map[string]*grader.Run),
+		findings:  make(map[string][]vet.Finding),
+		lessons:   make(map[string]*lesson.Lesson),
+	}
+	s.mux.HandleFunc("/", s.handleDashboard)
+	s.mux.HandleFunc("/modules/", s.handleModule)
+	s.mux.HandleFunc("/exercises/", s.handleExercise)
+	s.mux.HandleFunc("/lessons/", s.handleLesson)
+	s.mux.HandleFunc("/wasm_exec.js", s.handleWasmExec)
+	s.mux.HandleFunc("/apps/", s.handleApp)
+	return s, nil
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// renderDoc turns a Go doc comment into HTML.
+func renderDoc(text string) template.HTML {
+	var p comment.Parser
+	var pr comment.Printer
+	return template.HTML(pr.HTML(p.Parse(text)))
+}
+
+// moduleView is a module row on the dashboard.
+type moduleView struct {
+	moduleReport
+	LastRun *progress.Run
+	Unmet   []*course.Module // incomplete prerequisites
+}
+
+// UnmetIDs lists the incomplete prerequisites for display.
+func (v moduleView) UnmetIDs() string {
+	ids := make([]string, len(v.Unmet))
+	for i, m := range v.Unmet {
+		ids[i] = m.ID
+	}
+	return strings.Join(ids, ", ")
+}
+
+// badgeView is a badge on the dashboard.
+type badgeView struct {
+	*achievements.Badge
+	Unlocked time.Time // zero while locked
+}
+
+func badgeViews(store *progress.Store) []badgeView {
+	var views []badgeView
+	for _, b := range achievements.Badges {
+		at, _ := store.Unlocked(b.ID)
+		views = append(views, badgeView{Badge: b, Unlocked: at})
+	}
+	return views
+}
+
+func (s *server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	store, err := s.app.openStore()
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	report := buildReport(s.course, store, nil, s.app.now())
+	var modules []moduleView
+	for _, m := range report.Modules {
+		mv := moduleView{moduleReport: m}
+		if run, ok := store.LastRun(m.ID); ok {
+			mv.LastRun = &run
+		}
+		if cm, err := s.course.Module(m.ID); err == nil {
+			mv.Unmet = recommend.Unmet(s.course, store, cm)
+		}
+		modules = append(modules, mv)
+	}
+	s.render(w, "dashboard.html", map[string]any{
+		"Report":  report,
+		"Modules": modules,
+		"Badges":  badgeViews(store),
+		"Streak":  store.Streak(s.app.now()),
+	})
+}
+
+// handleModule serves GET /modules/{id} and POST /modules/{id}/check.
+func (s *server) handleModule(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/modules/")
+	id, action, _ := strings.Cut(rest, "/")
+	m, err := s.course.Module(id)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if m.ID != id && r.Method == http.MethodGet {
+		// Accept the same short names as the CLI: /modules/01 -> /modules/01-basics
+		http.Redirect(w, r, "/modules/"+m.ID, http.StatusFound)
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		s.showModule(w, m)
+	case action == "check" && r.Method == http.MethodPost:
+		if err := s.check(r.Context(), m); err != nil {
+			s.serverError(w, err)
+			return
+		}
+		http.Redirect(w, r, "/modules/"+m.ID, http.StatusSeeOther)
+	case action == "":
+		methodNotAllowed(w, http.MethodGet)
+	case action == "check":
+		methodNotAllowed(w, http.MethodPost)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// findingView is a go vet finding on a module page.
+type findingView struct {
+	vet.Finding
+	See string // module to read, if the course has it
+}
+
+func (s *server) showModule(w http.ResponseWriter, m *course.Module) {
+	store, err := s.app.openStore()
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	mv := moduleView{moduleReport: moduleReport{ID: m.ID, Title: m.Title}}
+	if report := buildReport(s.course, store, []*course.Module{m}, s.app.now()); len(report.Modules) > 0 {
+		mv.moduleReport = report.Modules[0]
+	}
+	if run, ok := store.LastRun(m.ID); ok {
+		mv.LastRun = &run
+	}
+	s.mu.Lock()
+	detail := s.runs[m.ID]
+	findings := s.findings[m.ID]
+	s.mu.Unlock()
+	var vetViews []findingView
+	for _, f := range findings {
+		vetViews = append(vetViews, findingView{Finding: f, See: seeModule(s.course, f)})
+	}
+	lessons, err := lesson.LoadModule(m)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.render(w, "module.html", map[string]any{
+		"Info":    m,
+		"Module":  mv,
+		"Lessons": lessons,
+		"Run":     detail,
+		"Vet":     vetViews,
+	})
+}
+
+// check grades a module and records the run, like "learngo check".
+func (s *server) check(ctx context.Context, m *course.Module) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	store, err := s.app.openStore()
+	if err != nil {
+		return err
+	}
+	run, err := s.grade(ctx, m, grader.Options{Env: seedEnv(store)})
+	if err != nil {
+		return err
+	}
+	recordRun(store, m, run, s.app.now())
+	achievements.Update(s.course, store)
+	if err := store.Save(); err != nil {
+		return err
+	}
+	s.app.pushReport(ctx, store, m)
+	s.runs[m.ID] = run
+	s.findings[m.ID] = nil
+	if run.BuildOutput == "" {
+		findings, err := s.vet(ctx, filepath.Join(m.Dir, "exercises"), vet.Options{})
+		if err != nil {
+			fmt.Fprintf(s.app.stderr, "learngo serve: %v\n", err)
+		}
+		for i, f := range findings {
+			findings[i].Explanation = s.app.msg.Hint(f.Analyzer, f.Explanation)
+		}
+		s.findings[m.ID] = findings
+	}
+	return nil
+}
+
+// handleExercise serves GET /exercises/{module}/{name}, and POST
+// /exercises/{module}/{name}/build and .../results for running it in the
+// browser.
+func (s *server) handleExercise(w http.ResponseWriter, r *http.Request) {
+	id, action := strings.TrimPrefix(r.URL.Path, "/exercises/"), ""
+	if parts := strings.Split(id, "/"); len(parts) == 3 {
+		id, action = parts[0]+"/"+parts[1], parts[2]
+	}
+	e, err := s.course.Exercise(id)
+	if err != nil || e.ID != id {
+		http.NotFound(w, r)
+		return
+	}
+	m, _ := s.course.Module(e.Module)
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		s.showExercise(w, m, e)
+	case action == "build" && r.Method == http.MethodPost:
+		s.buildExercise(w, r, m, e)
+	case action == "results" && r.Method == http.MethodPost:
+		s.exerciseResults(w, r, m, e)
+	case action == "":
+		methodNotAllowed(w, http.MethodGet)
+	case action == "build" || action == "results":
+		methodNotAllowed(w, http.MethodPost)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *server) showExercise(w http.ResponseWriter, m *course.Module, e *course.Exercise) {
+	doc, err := e.Doc()
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	doc.Intro = s.app.msg.Prompt(e.ID, "", doc.Intro)
+	for i, d := range doc.Decls {
+		doc.Decls[i].Doc = s.app.msg.Prompt(e.ID, d.Name, d.Doc)
+	}
+	store, err := s.app.openStore()
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	var status exerciseReport
+	for _, mr := range buildReport(s.course, store, []*course.Module{m}, s.app.now()).Modules {
+		for _, er := range mr.Exercises {
+			if er.Name == e.Name {
+				status = er
+			}
+		}
+	}
+
+	var tests []grader.TestResult
+	s.mu.Lock()
+	if run := s.runs[m.ID]; run != nil {
+		for _, res := range run.Results {
+			if res.Exercise == e.ID {
+				tests = res.Tests
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	runner, err := newBrowserRunner(m, e, store)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	file, err := filepath.Rel(s.course.Root, e.File)
+	if err != nil {
+		file = e.File
+	}
+	s.render(w, "exercise.html", map[string]any{
+		"Exercise": e,
+		"Module":   m,
+		"File":     filepath.ToSlash(file),
+		"Doc":      doc,
+		"Progress": status,
+		"Tests":    tests,
+		"Runner":   runner,
+	})
+}
+
+// handleApp serves GET /apps/{module}/{app}/, the page of one of a
+// module's apps, and .../main.wasm, its program, built on every request
+// so that the page always runs the code as it is on disk.
+func (s *server) handleApp(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+	id, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/apps/"), "/")
+	m, err := s.course.Module(id)
+	if err != nil || m.ID != id {
+		http.NotFound(w, r)
+		return
+	}
+	for _, app := range m.Apps {
+		dir := filepath.Join(m.Dir, filepath.FromSlash(app))
+		switch rest {
+		case app:
+			http.Redirect(w, r, r.URL.Path+"/", http.StatusFound)
+		case app + "/":
+			http.ServeFile(w, r, filepath.Join(dir, course.AppPage))
+		case app + "/main.wasm":
+			wasm, buildOutput, err := s.buildApp(r.Context(), dir)
+			if err != nil {
+				s.serverError(w, err)
+				return
+			}
+			if buildOutput != "" {
+				http.Error(w, buildOutput, http.StatusUnprocessableEntity)
+				return
+			}
+			w.Header().Set("Content-Type", "application/wasm")
+			w.Write(wasm)
+		default:
+			continue
+		}
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// handleLesson serves GET /lessons/{module}/{name}, with the output of
+// every cell. Running the cells takes a while, so the results are kept
+// until the lesson changes.
+func (s *server) handleLesson(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+	l, err := lesson.Find(s.course, strings.TrimPrefix(r.URL.Path, "/lessons/"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	m, _ := s.course.Module(l.Module)
+
+	s.mu.Lock()
+	ran := s.lessons[l.ID]
+	s.mu.Unlock()
+	if ran == nil || !sameText(ran, l) {
+		session, err := repl.New()
+		if err != nil {
+			s.serverError(w, err)
+			return
+		}
+		defer session.Close()
+		session.Timeout = 10 * time.Second
+		l.Run(r.Context(), session)
+		s.mu.Lock()
+		s.lessons[l.ID] = l
+		s.mu.Unlock()
+		ran = l
+	}
+	s.render(w, "lesson.html", map[string]any{
+		"Lesson": ran,
+		"Module": m,
+	})
+}
+
+// sameText reports whether two versions of a lesson read the same.
+func sameText(a, b *lesson.Lesson) bool {
+	if len(a.Blocks) != len(b.Blocks) {
+		return false
+	}
+	for i := range a.Blocks {
+		if a.Blocks[i].Text != b.Blocks[i].Text || a.Blocks[i].Info != b.Blocks[i].Info {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *server) render(w http.ResponseWriter, name string, data any) {
+	var buf strings.Builder
+	if err := s.pages.ExecuteTemplate(&buf, name, data); err != nil {
+		s.serverError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, buf.String())
+}
+
+func methodNotAllowed(w http.ResponseWriter, allow string) {
+	w.Header().Set("Allow", allow)
+	http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+}
+
+func (s *server) serverError(w http.ResponseWriter, err error) {
+	fmt.Fprintf(s.app.stderr, "learngo serve: %v\n", err)
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/grader"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testServer(t *testing.T) (*server, *app) {
+	t.Helper()
+	a, _, _ := reportCourse(t)
+	c, err := a.loadCourse()
+	require.NoError(t, err)
+	s, err := newServer(a, c)
+	require.NoError(t, err)
+	return s, a
+}
+
+func get(t *testing.T, h http.Handler, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestServeDashboard(t *testing.T) {
+	s, _ := testServer(t)
+	rec := get(t, s, "/")
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, "1/4 exercises complete (25%)")
+	assert.Contains(t, body, "🔥 1-day streak")
+	assert.Contains(t, body, `<a href="/modules/01-basics">01-basics</a>`)
+	assert.Contains(t, body, "Types &lt;and&gt; Interfaces")
+	assert.Contains(t, body, "never run")
+	assert.Contains(t, body, `<li class="locked"><strong>Race Free</strong>`)
+	assert.NotContains(t, body, "Locked: finish")
+
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/favicon.ico").Code)
+}
+
+func TestServeModule(t *testing.T) {
+	s, _ := testServer(t)
+	rec := get(t, s, "/modules/01-basics")
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, `<a href="/exercises/01-basics/exercise1">exercise1</a>`)
+	assert.Contains(t, body, `<td class="in-progress">in progress</td>`)
+	assert.Contains(t, body, `action="/modules/01-basics/check"`)
+
+	rec = get(t, s, "/modules/01")
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "/modules/01-basics", rec.Header().Get("Location"))
+
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/modules/99").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, get(t, s, "/modules/01-basics/check").Code)
+}
+
+func TestServeModuleManifest(t *testing.T) {
+	a, _, _ := reportCourse(t)
+	manifest := "modules:\n" +
+		"  - id: 01-basics\n    description: Syntax & types.\n    objectives: [Learn zero values]\n    estimated_time: 2h30m\n" +
+		"  - id: 02-types\n  - id: 03-concurrency\n"
+	require.NoError(t, os.WriteFile(filepath.Join(a.root, course.ManifestFile), []byte(manifest), 0o644))
+	c, err := a.loadCourse()
+	require.NoError(t, err)
+	s, err := newServer(a, c)
+	require.NoError(t, err)
+
+	body := get(t, s, "/modules/01-basics").Body.String()
+	assert.Contains(t, body, "<p>Syntax &amp; types.</p>")
+	assert.Contains(t, body, "<li>Learn zero values</li>")
+	assert.Contains(t, body, "Takes about 2h30m.")
+	assert.NotContains(t, get(t, s, "/modules/02-types").Body.String(), "You will:")
+}
+
+func TestServeExercise(t *testing.T) {
+	s, _ := testServer(t)
+	rec := get(t, s, "/exercises/01-basics/exercise1")
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, "<code>modules/01-basics/exercises/exercise1.go</code>")
+	assert.Contains(t, body, `<span class="done">done</span>`)
+
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/exercises/01-basics/missing").Code)
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/exercises/nope").Code)
+}
+
+func TestServeExerciseDocs(t *testing.T) {
+	a, _, _ := testApp(t, map[string]string{
+		"modules/01-demo/exercises/stack.go": `package exercises
+
+// EXERCISE: Fix the <stack>.
+
+// Push adds v to the stack.
+// BUG: Drops v.
+func Push(v int) {}
+`,
+	})
+	c, err := a.loadCourse()
+	require.NoError(t, err)
+	s, err := newServer(a, c)
+	require.NoError(t, err)
+
+	body := get(t, s, "/exercises/01-demo/stack").Body.String()
+	assert.Contains(t, body, "<p>EXERCISE: Fix the &lt;stack&gt;.")
+	assert.Contains(t, body, "<pre>func Push(v int)</pre>")
+	assert.Contains(t, body, "<p>Push adds v to the stack.\nBUG: Drops v.")
+}
+
+func TestServeCheck(t *testing.T) {
+	s, a := testServer(t)
+	t.Setenv(seed.EnvVar, "")
+	var env []string
+	s.grade = func(_ context.Context, m *course.Module, opts grader.Options) (*grader.Run, error) {
+		env = opts.Env
+		return &grader.Run{Module: m.ID, Results: []*grader.Result{
+			{Exercise: "01-basics/exercise1", Passed: true, Tests: []grader.TestResult{{Name: "TestOne", Passed: true}}},
+			{Exercise: "01-basics/exercise2", Tests: []grader.TestResult{{Name: "TestTwo", Output: "want 3, got <nil>\n"}}},
+			{Exercise: "01-basics/exercise3", Passed: true, Tests: []grader.TestResult{{Name: "TestThree", Passed: true}}},
+		}}, nil
+	}
+	s.vet = func(context.Context, string, vet.Options) ([]vet.Finding, error) {
+		return []vet.Finding{{Analyzer: "printf", File: "exercise2.go", Line: 3, Column: 1, Message: "bad verb",
+			Explanation: "The verbs do not match.", Module: "01-basics"}}, nil
+	}
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/modules/01-basics/check", strings.NewReader(url.Values{}.Encode())))
+	require.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/modules/01-basics", rec.Header().Get("Location"))
+
+	store, err := a.openStore()
+	require.NoError(t, err)
+	run, ok := store.LastRun("01-basics")
+	require.True(t, ok)
+	assert.Equal(t, 3, run.Total())
+	assert.Equal(t, []string{"01-basics/exercise2"}, run.Failed)
+	assert.False(t, store.Get("01-basics/exercise3").CompletedAt.IsZero())
+	require.NotZero(t, store.Seed, "a learner seed is chosen and saved")
+	assert.Equal(t, []string{fmt.Sprintf("LEARNGO_SEED=%d", store.Seed)}, env)
+
+	body := get(t, s, "/modules/01-basics").Body.String()
+	assert.Contains(t, body, "2/3 passing")
+	assert.Contains(t, body, "want 3, got &lt;nil&gt;")
+	assert.Contains(t, body, "<code>exercise2.go:3:1</code>: bad verb")
+	assert.Contains(t, body, `<a href="/modules/01-basics">See module 01-basics.</a>`)
+
+	body = get(t, s, "/exercises/01-basics/exercise2").Body.String()
+	assert.Contains(t, body, `<tr><td>TestTwo</td><td class="fail">fail</td></tr>`)
+	body = get(t, s, "/").Body.String()
+	assert.Contains(t, body, "2/3 passing")
+	assert.Contains(t, body, "<li><strong>First Green</strong>", "checks unlock badges")
+}
+
+func TestServeApp(t *testing.T) {
+	a, _, _ := testApp(t, map[string]string{
+		"modules/01-demo/module.json":                      `{"apps": ["examples/hello"]}`,
+		"modules/01-demo/examples/hello/main.go":           "package main\n",
+		"modules/01-demo/examples/hello/" + course.AppPage: "<title>Hello page</title>\n",
+		"modules/01-demo/exercises/add.go":                 "package exercises\n",
+	})
+	c, err := a.loadCourse()
+	require.NoError(t, err)
+	s, err := newServer(a, c)
+	require.NoError(t, err)
+	var built string
+	s.buildApp = func(_ context.Context, dir string) ([]byte, string, error) {
+		built = dir
+		return []byte("\x00asm"), "", nil
+	}
+
+	body := get(t, s, "/modules/01-demo").Body.String()
+	assert.Contains(t, body, `<a href="/apps/01-demo/examples/hello/">examples/hello</a>`)
+
+	rec := get(t, s, "/apps/01-demo/examples/hello")
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "/apps/01-demo/examples/hello/", rec.Header().Get("Location"))
+	rec = get(t, s, "/apps/01-demo/examples/hello/")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Hello page")
+
+	rec = get(t, s, "/apps/01-demo/examples/hello/main.wasm")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/wasm", rec.Header().Get("Content-Type"))
+	assert.Equal(t, filepath.Join(a.root, "modules/01-demo/examples/hello"), built)
+
+	s.buildApp = func(context.Context, string) ([]byte, string, error) {
+		return nil, "main.go:1: syntax error", nil
+	}
+	rec = get(t, s, "/apps/01-demo/examples/hello/main.wasm")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "syntax error")
+
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/apps/01-demo/examples/hello/main.go").Code)
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/apps/01-demo/exercises/").Code)
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/apps/01/examples/hello/").Code)
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/apps/99-none/examples/hello/").Code)
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/apps/01-demo/examples/hello/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
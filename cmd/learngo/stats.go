@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/stats"
+)
+
+// exerciseStat is the recorded effort on one attempted exercise.
+type exerciseStat struct {
+	ID     string
+	Status string
+	progress.Exercise
+}
+
+// hardest returns the attempted exercises of modules, hardest first: most
+// failed runs, then most active time, then most hints.
+func hardest(modules []*course.Module, store *progress.Store) []exerciseStat {
+	var stats []exerciseStat
+	for _, m := range modules {
+		for _, e := range m.Exercises {
+			p := store.Get(e.ID)
+			if p.Attempts == 0 {
+				continue
+			}
+			stats = append(stats, exerciseStat{ID: e.ID, Status: exerciseStatus(p), Exercise: p})
+		}
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		switch {
+		case a.FailedRuns != b.FailedRuns:
+			return a.FailedRuns > b.FailedRuns
+		case a.ActiveTime != b.ActiveTime:
+			return a.ActiveTime > b.ActiveTime
+		}
+		return a.HintsUsed > b.HintsUsed
+	})
+	return stats
+}
+
+// stats prints the hardest exercises and totals for the effort so far.
+func (a *app) stats(args []string) error {
+	fs := a.flagSet("stats")
+	n := fs.Int("n", 10, "number of exercises to list (0 lists all)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	modules := c.Modules
+	if fs.NArg() > 0 {
+		modules = nil
+		for _, name := range fs.Args() {
+			m, err := c.Module(name)
+			if err != nil {
+				return err
+			}
+			modules = append(modules, m)
+		}
+	}
+	store, err := a.openStore()
+	if err != nil {
+		return err
+	}
+
+	attempted := hardest(modules, store)
+	if len(attempted) == 0 {
+		fmt.Fprintln(a.stdout, a.T(`No attempts recorded yet. Run "learngo check <module>" first.`))
+		return nil
+	}
+
+	var failed, sessions int
+	var active time.Duration
+	var failedDone, activeDone stats.Accumulator
+	for _, s := range attempted {
+		failed += s.FailedRuns
+		sessions += s.Sessions
+		active += s.ActiveTime
+		if s.Status == statusDone {
+			failedDone.Add(float64(s.FailedRuns))
+			activeDone.Add(float64(s.ActiveTime))
+		}
+	}
+	done := failedDone.Count()
+
+	list := attempted
+	if *n > 0 && *n < len(list) {
+		list = list[:*n]
+	}
+	fmt.Fprintln(a.stdout, a.T("Hardest exercises (most failed runs before passing):"))
+	fmt.Fprintln(a.stdout)
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, a.T("EXERCISE\tSTATUS\tFAILED RUNS\tSESSIONS\tACTIVE TIME\tHINTS"))
+	for _, s := range list {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\t%d\n", s.ID, a.T(s.Status), s.FailedRuns, s.Sessions, formatDuration(s.ActiveTime), s.HintsUsed)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, a.T("\n%d exercises attempted, %d done: %d failed runs over %d sessions, %s active.\n"),
+		len(attempted), done, failed, sessions, formatDuration(active))
+	if done > 0 {
+		fmt.Fprintf(a.stdout, a.T("A completed exercise took %.1f failed runs and %s active on average.\n"),
+			failedDone.Mean(), formatDuration(time.Duration(activeDone.Mean())))
+		// A few exercises left open overnight skew the mean; the median
+		// and 90th percentile say what a typical and a hard one took.
+		fmt.Fprintf(a.stdout, a.T("Half took at most %s active, and nine in ten at most %s.\n"),
+			formatDuration(time.Duration(activeDone.Quantile(0.5))), formatDuration(time.Duration(activeDone.Quantile(0.9))))
+	}
+	return nil
+}
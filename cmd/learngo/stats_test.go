@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func statsApp(t *testing.T) (*app, func() string) {
+	t.Helper()
+	a, stdout, _ := testApp(t, map[string]string{
+		"modules/01-basics/README.md":          "# Module 01: Basics\n",
+		"modules/01-basics/exercises/easy.go":  "package exercises\n",
+		"modules/01-basics/exercises/hard.go":  "package exercises\n",
+		"modules/01-basics/exercises/fresh.go": "package exercises\n",
+		"modules/02-types/README.md":           "# Module 02: Types\n",
+		"modules/02-types/exercises/stuck.go":  "package exercises\n",
+	})
+	store, err := progress.Open(a.progressPath)
+	require.NoError(t, err)
+	store.RecordAttempt("01-basics/easy", true, testNow)
+	for i := 0; i < 3; i++ {
+		store.RecordAttempt("01-basics/hard", false, testNow.Add(time.Duration(i)*10*time.Minute))
+	}
+	store.RecordAttempt("01-basics/hard", true, testNow.Add(30*time.Minute))
+	store.RecordAttempt("02-types/stuck", false, testNow)
+	store.RecordAttempt("02-types/stuck", false, testNow.Add(time.Hour))
+	store.RecordHint("02-types/stuck")
+	require.NoError(t, store.Save())
+	return a, stdout.String
+}
+
+func TestHardest(t *testing.T) {
+	a, _ := statsApp(t)
+	c, err := a.loadCourse()
+	require.NoError(t, err)
+	store, err := a.openStore()
+	require.NoError(t, err)
+
+	stats := hardest(c.Modules, store)
+	var ids []string
+	for _, s := range stats {
+		ids = append(ids, s.ID)
+	}
+	assert.Equal(t, []string{"01-basics/hard", "02-types/stuck", "01-basics/easy"}, ids, "unattempted exercises are left out")
+	assert.Equal(t, statusInProgress, stats[1].Status)
+	assert.Equal(t, 2, stats[1].Sessions)
+}
+
+func TestStats(t *testing.T) {
+	a, stdout := statsApp(t)
+	require.NoError(t, a.main([]string{"stats"}))
+	out := stdout()
+	assert.Regexp(t, `01-basics/hard +done +3 +1 +30m +0`, out)
+	assert.Regexp(t, `02-types/stuck +in progress +2 +2 +- +1`, out)
+	assert.Contains(t, out, "3 exercises attempted, 2 done: 5 failed runs over 4 sessions, 30m active.")
+	assert.Contains(t, out, "A completed exercise took 1.5 failed runs and 15m active on average.")
+	assert.Contains(t, out, "Half took at most 15m active, and nine in ten at most 30m.")
+	assert.NotContains(t, out, "fresh")
+}
+
+func TestStatsModuleAndLimit(t *testing.T) {
+	a, stdout := statsApp(t)
+	require.NoError(t, a.main([]string{"stats", "-n", "1", "01"}))
+	out := stdout()
+	assert.Contains(t, out, "01-basics/hard")
+	assert.NotContains(t, out, "01-basics/easy")
+	assert.NotContains(t, out, "02-types")
+	assert.Contains(t, out, "2 exercises attempted, 2 done")
+}
+
+func TestStatsEmpty(t *testing.T) {
+	a, stdout, _ := testApp(t, map[string]string{"modules/01-basics/README.md": "# Module 01: Basics\n"})
+	require.NoError(t, a.main([]string{"stats"}))
+	assert.Contains(t, stdout.String(), "No attempts recorded yet.")
+}
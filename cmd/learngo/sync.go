@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	learnsync "github.com/TheAnarchoX/LearningGoTheHardWay/pkg/sync"
+)
+
+// Environment variables for syncing progress between machines.
+const (
+	syncEnv      = "LEARNGO_SYNC"       // sync server URL
+	syncTokenEnv = "LEARNGO_SYNC_TOKEN" // shared secret, if the server requires one
+)
+
+// sync merges the progress store with the learner's copy on a sync server.
+func (a *app) sync(args []string) error {
+	fs := a.flagSet("sync")
+	server := fs.String("server", os.Getenv(syncEnv), "sync server URL (default $"+syncEnv+")")
+	pull := fs.Bool("pull", false, "only fetch progress from the server")
+	push := fs.Bool("push", false, "only send progress to the server")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 || *pull && *push {
+		fs.Usage()
+		return errUsage
+	}
+	if *server == "" {
+		return fmt.Errorf("no sync server: use -server or set %s", syncEnv)
+	}
+	learner := os.Getenv(learnerEnv)
+	if learner == "" {
+		learner = os.Getenv("USER")
+	}
+	if learner == "" {
+		return fmt.Errorf("set %s to your name on the sync server", learnerEnv)
+	}
+
+	if _, err := a.loadCourse(); err != nil {
+		return err
+	}
+	store, err := a.openStore()
+	if err != nil {
+		return err
+	}
+	client := &learnsync.Client{BaseURL: *server, Learner: learner, Token: os.Getenv(syncTokenEnv)}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	before := len(store.Exercises)
+	switch {
+	case *push:
+		merged, err := client.Push(ctx, store)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, a.T("Pushed progress on %d exercises; the server now has %d.\n"), before, len(merged.Exercises))
+		return nil
+	case *pull:
+		remote, err := client.Pull(ctx)
+		if err != nil {
+			return err
+		}
+		learnsync.Merge(store, remote)
+	default:
+		if err := client.Sync(ctx, store); err != nil {
+			return err
+		}
+	}
+	if err := store.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, a.T("Synced: progress on %d exercises (%d new on this machine).\n"), len(store.Exercises), len(store.Exercises)-before)
+	return nil
+}
+
+// syncServer runs a sync server.
+func (a *app) syncServer(args []string) error {
+	fs := a.flagSet("sync-server")
+	addr := fs.String("addr", ":7072", "address to listen on")
+	data := fs.String("data", "", "directory to keep progress in (default .learngo/sync)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *data == "" {
+		c, err := a.loadCourse()
+		if err != nil {
+			return err
+		}
+		*data = filepath.Join(c.Root, ".learngo", "sync")
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: learnsync.NewServer(*data, os.Getenv(syncTokenEnv)), ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(a.stdout, a.T("Sync server running at http://%s, keeping progress in %s (Ctrl-C to stop)\n"), ln.Addr(), *data)
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
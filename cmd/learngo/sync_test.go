@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	learnsync "github.com/TheAnarchoX/LearningGoTheHardWay/pkg/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSync(t *testing.T) {
+	srv := httptest.NewServer(learnsync.NewServer(t.TempDir(), ""))
+	defer srv.Close()
+	t.Setenv(learnerEnv, "ada")
+	t.Setenv(syncTokenEnv, "")
+
+	// Another machine already pushed progress on module 02.
+	other := &progress.Store{}
+	other.RecordAttempt("02-types/exercise1", true, testNow)
+	_, err := (&learnsync.Client{BaseURL: srv.URL, Learner: "ada"}).Push(context.Background(), other)
+	require.NoError(t, err)
+
+	a, stdout, _ := reportCourse(t)
+	require.NoError(t, a.main([]string{"sync", "-server", srv.URL}))
+	assert.Contains(t, stdout(), "Synced: progress on 3 exercises (1 new on this machine).")
+
+	store, err := a.openStore()
+	require.NoError(t, err)
+	assert.True(t, store.Get("02-types/exercise1").Passed, "pulled from the server")
+
+	remote, err := (&learnsync.Client{BaseURL: srv.URL, Learner: "ada"}).Pull(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, store.IDs(), remote.IDs(), "pushed to the server")
+}
+
+func TestSyncNeedsServer(t *testing.T) {
+	t.Setenv(syncEnv, "")
+	a, _, _ := reportCourse(t)
+	assert.ErrorContains(t, a.main([]string{"sync"}), "no sync server")
+	assert.ErrorIs(t, a.main([]string{"sync", "-pull", "-push", "-server", "http://x"}), errUsage)
+}
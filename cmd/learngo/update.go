@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	learninggo "github.com/TheAnarchoX/LearningGoTheHardWay"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/update"
+)
+
+// updatesEnv is the release endpoint "learngo update" fetches from.
+const updatesEnv = "LEARNGO_UPDATES"
+
+// update brings a workspace created by "learngo init" up to date with the
+// latest release on its channel.
+func (a *app) update(args []string) error {
+	fs := a.flagSet("update")
+	channel := fs.String("channel", "", "release channel to follow: stable or beta (default: the one followed so far, or stable)")
+	server := fs.String("server", "", "release endpoint URL (default $"+updatesEnv+", or the one used last)")
+	check := fs.Bool("check", false, "only report whether an update is available")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	if _, err := a.loadCourse(); err != nil {
+		return err
+	}
+	ws, err := update.Open(a.root)
+	if errors.Is(err, update.ErrNotWorkspace) {
+		return fmt.Errorf("%s was not created by \"learngo init\"; in a clone of the repository, run \"git pull\" instead", a.root)
+	}
+	if err != nil {
+		return err
+	}
+	srv := firstOf(*server, os.Getenv(updatesEnv), ws.State.Server)
+	if srv == "" {
+		return fmt.Errorf("no release server: use -server or set %s", updatesEnv)
+	}
+	ch := firstOf(*channel, ws.State.Channel, "stable")
+
+	client := &update.Client{BaseURL: srv}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	r, err := client.Latest(ctx, ch)
+	if err != nil {
+		return err
+	}
+	if r.Version == ws.State.Version {
+		fmt.Fprintf(a.stdout, a.T("Already up to date: version %s on the %s channel.\n"), r.Version, ch)
+		if *check {
+			return nil
+		}
+		ws.State.Server, ws.State.Channel = srv, ch
+		return ws.Save()
+	}
+	if *check {
+		fmt.Fprintf(a.stdout, a.T("Version %s is available on the %s channel (you have %s).\nRun \"learngo update\" to get it.\n"), r.Version, ch, ws.State.Version)
+		return nil
+	}
+
+	files, err := client.Download(ctx, r)
+	if err != nil {
+		return err
+	}
+	from := ws.State.Version
+	ws.State.Server = srv
+	changes, err := ws.Apply(r, files)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, a.T("Updated from version %s to %s (%s channel).\n"), from, r.Version, ch)
+	var conflicts, kept int
+	for _, c := range changes {
+		fmt.Fprintf(a.stdout, "  %-8s  %s\n", c.Action, c.Path)
+		switch c.Action {
+		case update.Conflicted:
+			conflicts++
+		case update.Kept:
+			kept++
+		}
+	}
+	if kept > 0 {
+		fmt.Fprintln(a.stdout, a.T("\nFiles marked kept are no longer part of the course; they stay because you changed them."))
+	}
+	if conflicts > 0 {
+		fmt.Fprintf(a.stdout, a.T("\n%d files have conflicts between your changes and the release, between <<<<<<< and >>>>>>> lines.\nResolve them before you run \"learngo check\" again.\n"), conflicts)
+	}
+	return nil
+}
+
+// publish writes the course content built into learngo to a release
+// endpoint tree, to be served as static files.
+func (a *app) publish(args []string) error {
+	fs := a.flagSet("publish")
+	version := fs.String("version", "", "version of the release (required)")
+	channel := fs.String("channel", "beta", "channel to publish on: stable or beta")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || *version == "" {
+		fs.Usage()
+		return errUsage
+	}
+	files, err := update.Files(learninggo.Content)
+	if err != nil {
+		return err
+	}
+	r, err := update.Publish(fs.Arg(0), *channel, *version, files)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, a.T("Published version %s (%d files) on the %s channel in %s.\n"), r.Version, len(r.Files), r.Channel, fs.Arg(0))
+	return nil
+}
+
+// firstOf returns the first of values that is not empty.
+func firstOf(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
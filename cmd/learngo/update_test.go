@@ -0,0 +1,76 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	learninggo "github.com/TheAnarchoX/LearningGoTheHardWay"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/update"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdate(t *testing.T) {
+	t.Setenv(updatesEnv, "")
+	releases := t.TempDir()
+	srv := httptest.NewServer(http.FileServer(http.Dir(releases)))
+	defer srv.Close()
+
+	a, stdout, _ := testApp(t, map[string]string{})
+	require.NoError(t, a.main([]string{"publish", "-version", "1.0.0", "-channel", "stable", releases}))
+	assert.Contains(t, stdout.String(), "Published version 1.0.0 (")
+
+	// A beta release fixes module 01's guide and an exercise's doc comment.
+	files, err := update.Files(learninggo.Content)
+	require.NoError(t, err)
+	const guide, exercise = "modules/01-basics/README.md", "modules/01-basics/exercises/exercise1_fix_bugs.go"
+	files[guide] = append(files[guide], "\nA new section.\n"...)
+	files[exercise] = append([]byte("// Updated doc comment.\n"), files[exercise]...)
+	_, err = update.Publish(releases, "beta", "1.1.0", files)
+	require.NoError(t, err)
+
+	dir := filepath.Join(t.TempDir(), "course")
+	require.NoError(t, a.main([]string{"init", dir}))
+	a.root = dir
+	// The learner works on the exercise.
+	path := filepath.Join(dir, filepath.FromSlash(exercise))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, append(data, "\n// My notes.\n"...), 0o644))
+
+	stdout.Reset()
+	require.NoError(t, a.main([]string{"update", "-server", srv.URL}))
+	assert.Contains(t, stdout.String(), "Updated from version dev to 1.0.0 (stable channel).")
+
+	stdout.Reset()
+	require.NoError(t, a.main([]string{"update", "-check", "-channel", "beta"}))
+	assert.Contains(t, stdout.String(), "Version 1.1.0 is available on the beta channel (you have 1.0.0).")
+
+	stdout.Reset()
+	require.NoError(t, a.main([]string{"update", "-channel", "beta"}))
+	assert.Contains(t, stdout.String(), "Updated from version 1.0.0 to 1.1.0 (beta channel).")
+	assert.Contains(t, stdout.String(), "updated   "+guide)
+	assert.Contains(t, stdout.String(), "merged    "+exercise)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "// Updated doc comment.\n")
+	assert.Contains(t, string(data), "// My notes.\n")
+
+	stdout.Reset()
+	require.NoError(t, a.main([]string{"update"}))
+	assert.Contains(t, stdout.String(), "Already up to date: version 1.1.0 on the beta channel.")
+}
+
+func TestUpdateNotWorkspace(t *testing.T) {
+	a, _, _ := reportCourse(t)
+	assert.ErrorContains(t, a.main([]string{"update", "-server", "http://x"}), `run "git pull" instead`)
+
+	dir := filepath.Join(t.TempDir(), "course")
+	require.NoError(t, a.main([]string{"init", dir}))
+	a.root = dir
+	t.Setenv(updatesEnv, "")
+	assert.ErrorContains(t, a.main([]string{"update"}), "no release server")
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vet"
+)
+
+// vet runs the analyzers on a module's exercises and explains what they
+// find.
+func (a *app) vet(args []string) error {
+	fs := a.flagSet("vet")
+	staticcheck := fs.Bool("staticcheck", false, "also run staticcheck (must be installed)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	c, err := a.loadCourse()
+	if err != nil {
+		return err
+	}
+	m, err := c.Module(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	findings, err := vet.Run(ctx, filepath.Join(m.Dir, "exercises"), vet.Options{Staticcheck: *staticcheck})
+	if err != nil {
+		return err
+	}
+	if len(findings) == 0 {
+		fmt.Fprintf(a.stdout, a.T("No problems found in %s.\n"), m.ID)
+		return nil
+	}
+	a.printFindings(a.stdout, c, findings)
+	return nil
+}
+
+// printFindings lists findings with their explanations.
+func (a *app) printFindings(w io.Writer, c *course.Course, findings []vet.Finding) {
+	if len(findings) == 1 {
+		fmt.Fprintln(w, a.T("go vet found 1 problem:"))
+	} else {
+		fmt.Fprintf(w, a.T("go vet found %d problems:\n"), len(findings))
+	}
+	for _, f := range findings {
+		fmt.Fprintf(w, "\n%s:%d:%d: %s [%s]\n", f.File, f.Line, f.Column, f.Message, f.Analyzer)
+		if f.Explanation != "" {
+			fmt.Fprint(w, wrap(a.msg.Hint(f.Analyzer, f.Explanation), "    ", 76))
+		}
+		if see := seeModule(c, f); see != "" {
+			fmt.Fprintf(w, "    "+a.T("See module %s.\n"), see)
+		}
+	}
+}
+
+// seeModule returns the module that covers f, if the course has it.
+func seeModule(c *course.Course, f vet.Finding) string {
+	if f.Module == "" {
+		return ""
+	}
+	m, err := c.Module(f.Module)
+	if err != nil {
+		return ""
+	}
+	return m.ID
+}
+
+// wrap breaks text into lines of at most width runes (words permitting),
+// each starting with prefix.
+func wrap(text, prefix string, width int) string {
+	var b strings.Builder
+	line := prefix
+	for _, word := range strings.Fields(text) {
+		if line != prefix && len(line)+1+len(word) > width {
+			b.WriteString(line + "\n")
+			line = prefix
+		}
+		if line != prefix {
+			line += " "
+		}
+		line += word
+	}
+	if line != prefix {
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintFindings(t *testing.T) {
+	c := &course.Course{Modules: []*course.Module{{ID: "03-concurrency-fundamentals", Number: 3}}}
+	var buf bytes.Buffer
+	(&app{}).printFindings(&buf, c, []vet.Finding{
+		{Analyzer: "copylocks", File: "counter.go", Line: 4, Column: 2, Message: "passes lock by value",
+			Explanation: "You copied a lock.", Module: "03-concurrency-fundamentals"},
+		{Analyzer: "newthing", File: "counter.go", Line: 9, Column: 1, Message: "odd", Module: "99-missing"},
+	})
+	assert.Equal(t, `go vet found 2 problems:
+
+counter.go:4:2: passes lock by value [copylocks]
+    You copied a lock.
+    See module 03-concurrency-fundamentals.
+
+counter.go:9:1: odd [newthing]
+`, buf.String())
+}
+
+func TestWrap(t *testing.T) {
+	text := strings.Repeat("word ", 20)
+	for _, line := range strings.Split(strings.TrimSuffix(wrap(text, "  ", 20), "\n"), "\n") {
+		assert.LessOrEqual(t, len(line), 20)
+		assert.True(t, strings.HasPrefix(line, "  word"))
+	}
+	assert.Equal(t, "", wrap("", "  ", 20))
+}
@@ -0,0 +1,126 @@
+// Command shadow reports variables that shadow another of the same name
+// and type, declared in an enclosing scope and used after the shadowing
+// declaration: most often an err := inside a block, meant to set the err
+// outside it. It runs the check of package pkg/shadow, the course's
+// standard-library version of go vet's shadow analyzer, which vet leaves
+// off by default.
+//
+// Usage:
+//
+//	shadow [dir | dir/...]...
+//
+// Each dir is a package, and dir/... is every package under dir, as for
+// go vet. With no arguments, it checks the package in the current
+// directory. Test files are left out.
+//
+// Each finding is printed in go vet's format,
+//
+//	config.go:31:4: declaration of "err" shadows declaration at line 22
+//
+// and shadow exits with status 1 if there are any, or 2 if a package
+// cannot be checked.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/build"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/shadow"
+)
+
+// errFound is returned by run when it reports findings.
+var errFound = errors.New("found shadowed variables")
+
+func main() {
+	switch err := run(os.Args[1:], os.Stdout, os.Stderr); {
+	case errors.Is(err, errFound):
+		os.Exit(1)
+	case err != nil:
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "shadow: %v\n", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run checks the packages args name, and writes the findings to stdout.
+func run(args []string, stdout, stderr io.Writer) error {
+	fset := flag.NewFlagSet("shadow", flag.ContinueOnError)
+	fset.SetOutput(stderr)
+	fset.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: shadow [dir | dir/...]...")
+	}
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	patterns := fset.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	var dirs []string
+	for _, p := range patterns {
+		expanded, err := expand(p)
+		if err != nil {
+			return err
+		}
+		dirs = append(dirs, expanded...)
+	}
+	found := false
+	for _, dir := range dirs {
+		findings, err := shadow.Dir(dir)
+		if err != nil {
+			return fmt.Errorf("%s: %w", dir, err)
+		}
+		for _, f := range findings {
+			fmt.Fprintln(stdout, f)
+			found = true
+		}
+	}
+	if found {
+		return errFound
+	}
+	return nil
+}
+
+// expand returns the package directories pattern names: the directory
+// itself, or for dir/... every directory under dir with Go files in it,
+// leaving out testdata and names starting with "." or "_", as the go
+// command does.
+func expand(pattern string) ([]string, error) {
+	root, ok := strings.CutSuffix(filepath.ToSlash(pattern), "/...")
+	if !ok {
+		return []string{pattern}, nil
+	}
+	root = filepath.FromSlash(root)
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if path != root && (name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+		if _, err := build.ImportDir(path, 0); err != nil {
+			var noGo *build.NoGoError
+			if errors.As(err, &noGo) {
+				return nil
+			}
+			return err
+		}
+		dirs = append(dirs, path)
+		return nil
+	})
+	return dirs, err
+}
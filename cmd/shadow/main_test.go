@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFile writes src to name under dir, making its directory.
+func writeFile(t *testing.T, dir, name, src string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(src), 0o644))
+}
+
+const sum = `package sum
+
+import "strconv"
+
+func Sum(xs []string) (int, error) {
+	var err error
+	total := 0
+	for _, x := range xs {
+		n, err := strconv.Atoi(x)
+		if err != nil {
+			break
+		}
+		total += n
+	}
+	return total, err
+}
+`
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a/sum.go", sum)
+	writeFile(t, dir, "a/b/fine.go", "package b\n\nfunc Fine() {}\n")
+	writeFile(t, dir, "a/testdata/sum.go", sum)
+	writeFile(t, dir, "a/_old/sum.go", sum)
+	writeFile(t, dir, "a/docs/README.md", "no Go here\n")
+
+	var stdout, stderr bytes.Buffer
+	err := run([]string{filepath.Join(dir, "a") + "/..."}, &stdout, &stderr)
+	assert.ErrorIs(t, err, errFound)
+	assert.Equal(t, filepath.Join(dir, "a", "sum.go")+":9:6: declaration of \"err\" shadows declaration at line 6\n", stdout.String(),
+		"testdata and _old are left out")
+
+	stdout.Reset()
+	assert.NoError(t, run([]string{filepath.Join(dir, "a", "b")}, &stdout, &stderr))
+	assert.Empty(t, stdout.String())
+}
+
+func TestRunErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "bad.go", "package bad\n\nvar x int = \"one\"\n")
+	var stdout, stderr bytes.Buffer
+	assert.ErrorContains(t, run([]string{dir}, &stdout, &stderr), "cannot use")
+	assert.Error(t, run([]string{filepath.Join(dir, "missing")}, &stdout, &stderr))
+	assert.Error(t, run([]string{"-x"}, &stdout, &stderr))
+}
@@ -0,0 +1,35 @@
+// Package learninggo bundles the course content learners work on, so that
+// "learngo init" can create a workspace from the learngo binary alone,
+// without a clone of the repository.
+//
+// It doubles as the course's go:embed example. The directives below are
+// resolved at compile time against this directory, which is why the
+// package lives at the repository root: embed patterns may not climb out
+// of the package directory with "..". A pattern naming a directory embeds
+// everything in it except files starting with "." or "_".
+package learninggo
+
+import "embed"
+
+// Version names the release Content comes from. Release builds set it with
+// -ldflags "-X github.com/TheAnarchoX/LearningGoTheHardWay.Version=<version>",
+// so that "learngo update" knows what a new workspace starts from.
+var Version = "dev"
+
+// Content is a learner's workspace: the module setup, the course manifest
+// and translations, each module's guide, metadata, quiz, lessons, examples,
+// and exercises, the shared packages the exercises import, and the tools
+// their go:generate directives run or that learners run on them. Solutions
+// stay out.
+//
+//go:embed go.mod go.sum course.yaml locales
+//go:embed modules/*/README.md modules/*/module.json modules/*/quiz.json
+//go:embed modules/*/examples modules/*/exercises modules/*/lessons
+//go:embed pkg/cli/cli.go pkg/clone/clone.go pkg/deepdiff/deepdiff.go pkg/geometry/geometry.go pkg/seed/seed.go pkg/stats/stats.go pkg/watch/watch.go
+//go:embed pkg/faker/faker.go pkg/faker/data.go pkg/faker/vehiclekind_string.go pkg/faker/engine_string.go
+//go:embed pkg/headless/headless.go pkg/headless/runner.js
+//go:embed pkg/jsonschema/jsonschema.go pkg/matrix/matrix.go pkg/matrix/affine.go
+//go:embed pkg/proptest/proptest.go pkg/proptest/gen.go pkg/smtptest/smtptest.go pkg/leaktest/leaktest.go pkg/testenv/testenv.go pkg/vec/vec.go pkg/shadow/shadow.go
+//go:embed internal/snapshot/snapshot.go internal/snapshot/diff.go
+//go:embed cmd/enumgen/main.go cmd/enumgen/generate.go cmd/shadow/main.go
+var Content embed.FS
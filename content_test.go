@@ -0,0 +1,60 @@
+package learninggo
+
+import (
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// goRun matches the package a //go:generate directive runs with go run.
+var goRun = regexp.MustCompile(`(?m)^//go:generate\s+go run (\S+)`)
+
+// TestContentIsSelfContained checks that a workspace built from Content
+// compiles: every package of this module that embedded code imports, or
+// runs from a //go:generate directive, must be embedded too.
+func TestContentIsSelfContained(t *testing.T) {
+	const modulePath = "github.com/TheAnarchoX/LearningGoTheHardWay/"
+	fset := token.NewFileSet()
+	err := fs.WalkDir(Content, ".", func(p string, d fs.DirEntry, err error) error {
+		require.NoError(t, err)
+		assert.NotContains(t, p, "/solutions", "solutions must not be embedded")
+		if d.IsDir() || !strings.HasSuffix(p, ".go") {
+			return nil
+		}
+		src, err := fs.ReadFile(Content, p)
+		require.NoError(t, err)
+		f, err := parser.ParseFile(fset, p, src, parser.ImportsOnly)
+		require.NoError(t, err)
+		var deps []string
+		for _, imp := range f.Imports {
+			ip, _ := strconv.Unquote(imp.Path.Value)
+			deps = append(deps, ip)
+		}
+		for _, m := range goRun.FindAllSubmatch(src, -1) {
+			deps = append(deps, string(m[1]))
+		}
+		for _, ip := range deps {
+			dir, ok := strings.CutPrefix(ip, modulePath)
+			if !ok {
+				continue
+			}
+			entries, err := fs.ReadDir(Content, dir)
+			assert.NoError(t, err, "%s needs %s, which is not embedded", p, ip)
+			hasGo := false
+			for _, e := range entries {
+				hasGo = hasGo || path.Ext(e.Name()) == ".go"
+			}
+			assert.True(t, hasGo, "%s needs %s, which has no embedded Go files", p, ip)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
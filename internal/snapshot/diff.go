@@ -0,0 +1,154 @@
+package snapshot
+
+import (
+	"fmt"
+	"strings"
+)
+
+// context is the number of unchanged lines Diff shows around a change.
+const context = 3
+
+// maxCells bounds the table Diff fills in to find the longest common
+// subsequence of lines; beyond it, the lines that differ are shown as
+// removed and then added, all together.
+const maxCells = 4 << 20
+
+// op is one line of a diff: ' ' for a line in both, '-' for one only in
+// want, '+' for one only in got.
+type op struct {
+	kind byte
+	line string
+}
+
+// Diff returns a line diff of want and got, in the style of a unified
+// diff: a "@@ -l +l @@" header for each change, with the line numbers in
+// want and got, and a few lines around it. Lines that differ only in
+// trailing spaces or tabs, which no one can see, are quoted. It returns
+// "" if want and got are equal.
+func Diff(want, got string) string {
+	if want == got {
+		return ""
+	}
+	ops := diffLines(splitLines(want), splitLines(got))
+	var b strings.Builder
+	for start := 0; start < len(ops); {
+		// Find the next change, and the run of changes and short gaps
+		// that follows it.
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		last := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				last = i
+			} else if i-last > 2*context {
+				break
+			}
+		}
+		from, to := max(first-context, start), min(last+context+1, len(ops))
+		wantLine, gotLine := 1, 1
+		for _, o := range ops[:from] {
+			if o.kind != '+' {
+				wantLine++
+			}
+			if o.kind != '-' {
+				gotLine++
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%d +%d @@\n", wantLine, gotLine)
+		for _, o := range ops[from:to] {
+			line := o.line
+			if strings.TrimRight(line, " \t") != line {
+				line = fmt.Sprintf("%q", line)
+			}
+			fmt.Fprintf(&b, "%c%s\n", o.kind, line)
+		}
+		start = to
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// splitLines splits s into lines, without their newlines. A final
+// newline does not start another line, but a missing one is noted.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += " (no newline at end)"
+	return lines
+}
+
+// diffLines returns the ops that turn a into b, keeping the longest
+// common subsequence of lines.
+func diffLines(a, b []string) []op {
+	var head, tail []op
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		head = append(head, op{' ', a[0]})
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		tail = append(tail, op{' ', a[len(a)-1]})
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+	ops := head
+	if (len(a)+1)*(len(b)+1) > maxCells {
+		for _, l := range a {
+			ops = append(ops, op{'-', l})
+		}
+		for _, l := range b {
+			ops = append(ops, op{'+', l})
+		}
+	} else {
+		ops = append(ops, lcs(a, b)...)
+	}
+	for i := len(tail) - 1; i >= 0; i-- {
+		ops = append(ops, tail[i])
+	}
+	return ops
+}
+
+// lcs diffs a and b by dynamic programming: n[i][j] is the length of the
+// longest common subsequence of a[i:] and b[j:].
+func lcs(a, b []string) []op {
+	w := len(b) + 1
+	n := make([]int, (len(a)+1)*w)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				n[i*w+j] = n[(i+1)*w+j+1] + 1
+			} else {
+				n[i*w+j] = max(n[(i+1)*w+j], n[i*w+j+1])
+			}
+		}
+	}
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{' ', a[i]})
+			i, j = i+1, j+1
+		case n[(i+1)*w+j] >= n[i*w+j+1]:
+			ops = append(ops, op{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{'+', b[j]})
+	}
+	return ops
+}
@@ -0,0 +1,106 @@
+// Package snapshot compares what a test produces with a copy kept in the
+// package's testdata, for output too long to spell out in the test:
+// rendered templates, reports, help text, and values dumped as JSON.
+//
+//	snapshot.Match(t, "dashboard.html", out)
+//
+// The first time, and after an intended change, run the tests with -update
+// to write the snapshots, then review them with git diff before
+// committing them:
+//
+//	go test ./... -update
+//
+// Otherwise Match fails the test with a line diff between the snapshot and
+// what the test got. It registers the -update flag, so a test package that
+// imports it must not define a flag of that name.
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the snapshots in testdata/snapshots")
+
+// Dir is where the snapshots are kept, relative to the test's package.
+const Dir = "testdata/snapshots"
+
+// Path returns the file of the snapshot called name.
+func Path(name string) string {
+	return filepath.Join(filepath.FromSlash(Dir), filepath.FromSlash(name))
+}
+
+// Match fails the test if got, serialized, differs from the snapshot
+// called name, or if there is no such snapshot. With -update, it writes
+// got to the snapshot instead. name is a slash-separated path within Dir,
+// such as "report.md" or "pages/dashboard.html"; its extension is only
+// there to help editors and reviewers.
+func Match(tb testing.TB, name string, got any) {
+	tb.Helper()
+	if !filepath.IsLocal(filepath.FromSlash(name)) {
+		tb.Fatalf("snapshot: %q is not a path within %s", name, Dir)
+	}
+	data, err := Serialize(got)
+	if err != nil {
+		tb.Fatalf("snapshot %s: %v", name, err)
+	}
+	path := Path(name)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			tb.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			tb.Fatal(err)
+		}
+		tb.Logf("updated %s", path)
+		return
+	}
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		tb.Errorf("no snapshot %s; run go test -update to write it", path)
+		return
+	}
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if !bytes.Equal(want, data) {
+		tb.Errorf("%s does not match (-snapshot +got):\n%s\nIf the change is intended, run go test -update and review it with git diff.",
+			path, Diff(string(want), string(data)))
+	}
+}
+
+// Serialize returns what Match keeps of v: a string, a []byte, or the
+// String of a fmt.Stringer as it is, and anything else as indented JSON.
+// The result always ends in a newline, so that snapshots are well-formed
+// text files.
+func Serialize(v any) ([]byte, error) {
+	var data []byte
+	switch v := v.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = bytes.Clone(v)
+	case fmt.Stringer:
+		data = []byte(v.String())
+	default:
+		var b bytes.Buffer
+		enc := json.NewEncoder(&b)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(v); err != nil {
+			return nil, err
+		}
+		data = b.Bytes()
+	}
+	if len(data) == 0 || data[len(data)-1] != '\n' {
+		data = append(data, '\n')
+	}
+	return data, nil
+}
@@ -0,0 +1,132 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a testing.TB that records failures instead of failing.
+type recorder struct {
+	testing.TB
+	errors []string
+	logs   []string
+}
+
+func (r *recorder) Helper()                   {}
+func (r *recorder) Logf(f string, a ...any)   { r.logs = append(r.logs, fmt.Sprintf(f, a...)) }
+func (r *recorder) Errorf(f string, a ...any) { r.errors = append(r.errors, fmt.Sprintf(f, a...)) }
+
+// inTempDir runs the test in a directory of its own, since snapshots are
+// read and written relative to the working directory.
+func inTempDir(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(wd)) })
+}
+
+// withUpdate runs f as if the tests were run with -update.
+func withUpdate(f func()) {
+	*update = true
+	defer func() { *update = false }()
+	f()
+}
+
+func TestMatch(t *testing.T) {
+	inTempDir(t)
+	rec := &recorder{TB: t}
+	Match(rec, "page.html", "<p>hello</p>")
+	require.Len(t, rec.errors, 1)
+	assert.Contains(t, rec.errors[0], "no snapshot testdata/snapshots/page.html; run go test -update")
+
+	rec = &recorder{TB: t}
+	withUpdate(func() { Match(rec, "page.html", "<p>hello</p>") })
+	assert.Empty(t, rec.errors)
+	assert.Equal(t, []string{"updated " + filepath.Join("testdata", "snapshots", "page.html")}, rec.logs)
+	data, err := os.ReadFile(filepath.Join("testdata", "snapshots", "page.html"))
+	require.NoError(t, err)
+	assert.Equal(t, "<p>hello</p>\n", string(data), "a newline is added")
+
+	rec = &recorder{TB: t}
+	Match(rec, "page.html", "<p>hello</p>\n")
+	assert.Empty(t, rec.errors)
+
+	Match(rec, "page.html", "<p>goodbye</p>")
+	require.Len(t, rec.errors, 1)
+	assert.Contains(t, rec.errors[0], "does not match (-snapshot +got):\n@@ -1 +1 @@\n-<p>hello</p>\n+<p>goodbye</p>\n")
+}
+
+func TestMatchNestedNames(t *testing.T) {
+	inTempDir(t)
+	rec := &recorder{TB: t}
+	withUpdate(func() { Match(rec, "pages/dashboard.html", "x") })
+	assert.Empty(t, rec.errors)
+	assert.FileExists(t, filepath.Join("testdata", "snapshots", "pages", "dashboard.html"))
+}
+
+type point struct {
+	X, Y int
+	Tag  string `json:",omitempty"`
+}
+
+func (p point) String() string { return fmt.Sprintf("(%d, %d)", p.X, p.Y) }
+
+func TestSerialize(t *testing.T) {
+	for _, tc := range []struct {
+		v    any
+		want string
+	}{
+		{"text", "text\n"},
+		{"", "\n"},
+		{[]byte("bytes\n"), "bytes\n"},
+		{point{1, 2, ""}, "(1, 2)\n"},
+		{[]any{1, "<a>", nil}, "[\n  1,\n  \"<a>\",\n  null\n]\n"},
+		{map[string]int{"b": 2, "a": 1}, "{\n  \"a\": 1,\n  \"b\": 2\n}\n"},
+	} {
+		got, err := Serialize(tc.v)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, string(got), "%#v", tc.v)
+	}
+	_, err := Serialize(func() {})
+	assert.Error(t, err)
+}
+
+func TestDiff(t *testing.T) {
+	assert.Empty(t, Diff("a\nb\n", "a\nb\n"))
+
+	lines := func(from, to int) string {
+		var b strings.Builder
+		for i := from; i <= to; i++ {
+			fmt.Fprintf(&b, "line %d\n", i)
+		}
+		return b.String()
+	}
+	want := lines(1, 20)
+	got := strings.Replace(strings.Replace(want, "line 3\n", "line three\n", 1), "line 18\n", "", 1)
+	assert.Equal(t, `@@ -1 +1 @@
+ line 1
+ line 2
+-line 3
++line three
+ line 4
+ line 5
+ line 6
+@@ -15 +15 @@
+ line 15
+ line 16
+ line 17
+-line 18
+ line 19
+ line 20`, Diff(want, got))
+
+	assert.Equal(t, "@@ -1 +1 @@\n-a\n+\"a \"", Diff("a\n", "a \n"), "trailing spaces are quoted")
+	assert.Equal(t, "@@ -1 +1 @@\n-a\n+a (no newline at end)", Diff("a\n", "a"))
+	assert.Equal(t, "@@ -1 +1 @@\n+a", Diff("", "a\n"))
+}
@@ -198,6 +198,24 @@ func Slices() {
 	// Slices are references
 	subset[0] = "Java"
 	fmt.Printf("Original after modifying subset: %v\n", languages)
+
+	// Appending within capacity writes into the shared backing array
+	base := make([]int, 3, 10)
+	first := append(base, 1)
+	second := append(base, 2) // Same array, same index: first[3] is now 2
+	fmt.Printf("First: %v, Second: %v\n", first, second)
+
+	// A full slice expression [low:high:max] caps the capacity,
+	// so append has to copy to a new array
+	first = append(base[:3:3], 1)
+	second = append(base[:3:3], 2)
+	fmt.Printf("First: %v, Second: %v\n", first, second)
+
+	// copy makes a slice that shares nothing with the original
+	clone := make([]string, len(languages))
+	copy(clone, languages)
+	clone[0] = "C"
+	fmt.Printf("Original: %v, Clone: %v\n", languages, clone)
 }
 
 // Maps demonstrates Go's hash map type.
@@ -0,0 +1,72 @@
+package exercises
+
+// EXERCISE: Turn Fibonacci into a performance lesson.
+// The same function is implemented four ways below. Fix the bugs, then run
+// the benchmarks to see why the choice of algorithm matters more than
+// micro-optimizations:
+//
+//	go test -bench=Fibonacci -benchmem ./exercises/
+
+import "math/big"
+
+// FibonacciRecursive is the textbook definition translated literally.
+// It is correct but runs in O(2^n) time because it recomputes the same
+// subproblems over and over. Use it only as a baseline in benchmarks.
+func FibonacciRecursive(n int) int {
+	if n <= 1 {
+		return n
+	}
+	return FibonacciRecursive(n-1) + FibonacciRecursive(n-2)
+}
+
+// FibonacciMemo computes F(n) recursively, caching every result in memo
+// so each subproblem is solved only once (O(n) time, O(n) space).
+// Callers may pass a nil map; a fresh cache is created in that case.
+// BUG: Results are cached but the cache is never consulted.
+func FibonacciMemo(n int, memo map[int]int) int {
+	if memo == nil {
+		memo = make(map[int]int)
+	}
+	if n <= 1 {
+		return n
+	}
+
+	// BUG: Missing lookup - should return memo[n] if it is already present
+
+	result := FibonacciMemo(n-1, memo) + FibonacciMemo(n-2, memo)
+	memo[n] = result
+	return result
+}
+
+// FibonacciIterative computes F(n) bottom-up with two variables
+// (O(n) time, O(1) space). int overflows after F(92) on 64-bit platforms.
+// BUG: The loop stops one step too early.
+func FibonacciIterative(n int) int {
+	if n <= 1 {
+		return n
+	}
+
+	prev, curr := 0, 1
+	for i := 2; i < n; i++ { // BUG: Should be i <= n
+		prev, curr = curr, prev+curr
+	}
+	return curr
+}
+
+// FibonacciBig computes F(n) with arbitrary precision, so it never overflows.
+// big.Int values are mutable and methods like Add write into the receiver,
+// which makes pointer aliasing an easy mistake.
+// BUG: prev and curr end up pointing at the same big.Int.
+func FibonacciBig(n int) *big.Int {
+	if n <= 1 {
+		return big.NewInt(int64(n))
+	}
+
+	prev, curr := big.NewInt(0), big.NewInt(1)
+	for i := 2; i <= n; i++ {
+		next := curr // BUG: Copies the pointer, not the value - use new(big.Int).Set(curr)
+		curr.Add(prev, curr)
+		prev = next
+	}
+	return curr
+}
@@ -3,7 +3,10 @@ package exercises
 // EXERCISE: Fix the bugs in this file to make the tests pass.
 // Each function has intentional bugs marked with // BUG: comments.
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+)
 
 // CalculateSum should return the sum of two integers.
 // BUG: This function has a logic error.
@@ -114,10 +117,44 @@ func MergeMaps(map1, map2 map[string]int) map[string]int {
 	return result
 }
 
+// Merge copies every key from src into dst. When a key already exists in dst,
+// resolve decides which value to keep; a nil resolve lets src win, which is
+// exactly what MergeMaps does for map[string]int.
+//
+// Merge works for any comparable key and any value type thanks to type
+// parameters - compare Java's <K, V> or C++ templates.
+func Merge[K comparable, V any](dst, src map[K]V, resolve func(existing, incoming V) V) {
+	for k, v := range src {
+		if old, ok := dst[k]; ok && resolve != nil {
+			v = resolve(old, v)
+		}
+		dst[k] = v
+	}
+}
+
+// MergeAll merges any number of maps, left to right, into a new map.
+// Conflicts are settled by resolve exactly as in Merge.
+func MergeAll[K comparable, V any](resolve func(existing, incoming V) V, maps ...map[K]V) map[K]V {
+	result := make(map[K]V)
+	for _, m := range maps {
+		Merge(result, m, resolve)
+	}
+	return result
+}
+
+// KeepLarger is a conflict strategy for Merge that keeps the larger value.
+// TODO: Implement the "keep larger value" strategy.
+// BUG: Always keeps the incoming value, so smaller values overwrite larger ones.
+func KeepLarger[V cmp.Ordered](existing, incoming V) V {
+	return incoming // BUG: Should return whichever of existing/incoming is larger
+}
+
 // TODO: Implement this function
 // Fibonacci should return the nth Fibonacci number.
 // The sequence is: 0, 1, 1, 2, 3, 5, 8, 13, 21, ...
 // F(0) = 0, F(1) = 1, F(n) = F(n-1) + F(n-2)
+// Once it works, exercise1_fibonacci.go compares memoized, iterative, and
+// big.Int variants with benchmarks.
 func Fibonacci(n int) int {
 	// TODO: Implement the Fibonacci function
 	return 0 // Placeholder
@@ -153,5 +190,8 @@ func DemonstrateBugs() {
 	merged := MergeMaps(m1, m2)
 	fmt.Printf("Merged maps: %v (should be map[a:1 b:3 c:4])\n", merged)
 
+	largest := MergeAll(KeepLarger[int], m1, m2, map[string]int{"a": 0})
+	fmt.Printf("Merged keeping larger: %v (should be map[a:1 b:3 c:4])\n", largest)
+
 	fmt.Printf("Fibonacci(6): %d (should be 8)\n", Fibonacci(6))
 }
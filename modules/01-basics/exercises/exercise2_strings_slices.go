@@ -0,0 +1,109 @@
+package exercises
+
+// EXERCISE: Fix the bugs in this file to make the tests pass.
+// These functions practice working with strings, runes, maps, and slices.
+// Remember: a string is a read-only slice of bytes, and ranging over it
+// yields runes (Unicode code points), not bytes.
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// IsPalindrome reports whether s reads the same forwards and backwards,
+// ignoring case, spaces, and punctuation ("A man, a plan, a canal: Panama").
+// BUG: Compares bytes instead of runes and does not ignore case or punctuation.
+func IsPalindrome(s string) bool {
+	// BUG: Should keep only letters and digits (unicode.IsLetter/IsDigit),
+	// lowercased, as a []rune before comparing
+	chars := s
+
+	for i, j := 0, len(chars)-1; i < j; i, j = i+1, j-1 {
+		if chars[i] != chars[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// WordFrequency counts how often each word appears in text.
+// Words are separated by whitespace, compared case-insensitively, and
+// surrounding punctuation is ignored ("Go, go GO!" has "go" three times).
+// BUG: Splits on single spaces only and keeps case and punctuation.
+func WordFrequency(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, word := range strings.Split(text, " ") { // BUG: Use strings.Fields
+		// BUG: Missing strings.ToLower and strings.TrimFunc(word, unicode.IsPunct)
+		counts[word]++
+	}
+	return counts
+}
+
+// AreAnagrams reports whether a and b contain exactly the same letters,
+// ignoring case and spaces ("Dormitory" and "dirty room").
+// BUG: Only checks that both strings have the same set of letters, not
+// the same number of each letter.
+func AreAnagrams(a, b string) bool {
+	seen := make(map[rune]bool)
+	for _, r := range normalizeLetters(a) {
+		seen[r] = true
+	}
+	for _, r := range normalizeLetters(b) {
+		if !seen[r] {
+			return false
+		}
+	}
+	// BUG: Should count occurrences (map[rune]int), increment for a,
+	// decrement for b, and check that every count ends at zero
+	return true
+}
+
+// normalizeLetters lowercases s and drops everything that is not a letter.
+func normalizeLetters(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// SortedRunes returns the runes of s in sorted order. It is an alternative
+// building block for anagram detection: two words are anagrams when their
+// normalized, sorted runes are equal.
+func SortedRunes(s string) []rune {
+	runes := []rune(s)
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	return runes
+}
+
+// Deduplicate returns the elements of items with duplicates removed,
+// keeping the first occurrence and preserving the original order.
+// The input slice must not be modified.
+// BUG: Sorts the input in place, which reorders (and mutates) the caller's data.
+func Deduplicate(items []string) []string {
+	sort.Strings(items) // BUG: Mutates the input and loses the original order
+
+	result := []string{}
+	for i, item := range items {
+		if i == 0 || item != items[i-1] {
+			result = append(result, item)
+		}
+	}
+	// BUG: Should track seen items in a map[string]bool instead of sorting
+	return result
+}
+
+// Truncate shortens s to at most maxRunes characters, appending "…" when
+// anything was cut off. It must never split a multi-byte character, and
+// a negative maxRunes counts as 0.
+// BUG: Slices by bytes, which cuts "héllo" or "日本語" in the middle of a rune.
+// BUG: Panics when maxRunes is negative; FuzzTruncate finds that.
+func Truncate(s string, maxRunes int) string {
+	if len(s) <= maxRunes { // BUG: len counts bytes, not runes (utf8.RuneCountInString)
+		return s
+	}
+	return s[:maxRunes] + "…" // BUG: Should convert to []rune before slicing
+}
@@ -0,0 +1,91 @@
+package exercises
+
+// EXERCISE: Make text processing Unicode-aware.
+// CountVowels in exercise1_fix_bugs.go only knows ASCII: it misses the
+// vowels in "Über" and "naïve". This file goes further. Go strings are
+// UTF-8 bytes, a rune is one code point, and what a reader sees as one
+// character can be several runes ("é" may be U+00E9, or "e" plus U+0301
+// COMBINING ACUTE ACCENT).
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// accentedVowels maps precomposed Latin vowels to their base letter.
+// The standard library has no Unicode normalization; for real
+// applications use golang.org/x/text/unicode/norm to decompose text and
+// drop the combining marks instead of listing characters by hand.
+var accentedVowels = map[rune]rune{
+	'à': 'a', 'á': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a',
+	'è': 'e', 'é': 'e', 'ê': 'e', 'ë': 'e',
+	'ì': 'i', 'í': 'i', 'î': 'i', 'ï': 'i',
+	'ò': 'o', 'ó': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o',
+	'ù': 'u', 'ú': 'u', 'û': 'u', 'ü': 'u',
+}
+
+// isCombiningMark reports whether r is a nonspacing mark such as U+0301
+// COMBINING ACUTE ACCENT, which decorates the rune before it.
+func isCombiningMark(r rune) bool {
+	return unicode.Is(unicode.Mn, r)
+}
+
+// CountVowelsUnicode counts the vowels a, e, i, o, u in s, case-insensitively
+// and including accented forms: "Über café" has 4 vowels. "é" counts once
+// whether it is written as one rune (U+00E9) or as "e" followed by a
+// combining accent.
+// BUG: Accented vowels are never counted.
+func CountVowelsUnicode(s string) int {
+	count := 0
+	for _, r := range s {
+		r = unicode.ToLower(r)
+		// BUG: Missing lookup - replace r with its base letter from accentedVowels
+		if strings.ContainsRune("aeiou", r) {
+			count++
+		}
+	}
+	return count
+}
+
+// Initials returns the uppercased first letter of each word in name:
+// "émile zola" -> "ÉZ".
+// BUG: Takes the first byte of each word, which is half of "é".
+func Initials(name string) string {
+	var sb strings.Builder
+	for _, word := range strings.Fields(name) {
+		first := rune(word[0]) // BUG: Decode the first rune ([]rune(word)[0] or utf8.DecodeRuneInString)
+		sb.WriteRune(unicode.ToUpper(first))
+	}
+	return sb.String()
+}
+
+// SameWord reports whether a and b are the same word ignoring case.
+// BUG: Lowercasing both sides is not the same as case folding. Greek has
+// two lowercase sigmas (σ and final ς) for one uppercase Σ.
+func SameWord(a, b string) bool {
+	return strings.ToLower(a) == strings.ToLower(b) // BUG: Use strings.EqualFold
+}
+
+// VisibleLength returns the number of characters a reader sees in s:
+// runes, minus combining marks that merge into the previous character.
+// BUG: Counts every rune, so a decomposed "é" counts as two characters.
+func VisibleLength(s string) int {
+	return utf8.RuneCountInString(s) // BUG: Skip runes where isCombiningMark is true
+}
+
+// ReverseText reverses s character by character, keeping each combining
+// mark attached to the rune it decorates: "café" -> "éfac".
+// This is an approximation of grapheme clusters; emoji sequences and flags
+// need the full rules (see github.com/rivo/uniseg).
+// BUG: Reverses individual runes, so accents jump onto the wrong letter.
+func ReverseText(s string) string {
+	runes := []rune(s)
+	// BUG: Group each base rune with the combining marks that follow it,
+	// then reverse the groups instead of the runes
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
@@ -0,0 +1,135 @@
+package exercises
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	cafeComposed   = "caf\u00e9"  // é as one rune
+	cafeDecomposed = "cafe\u0301" // e + COMBINING ACUTE ACCENT
+)
+
+func TestCountVowelsUnicode(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"hello", 2},
+		{"AEIOU", 5},
+		{"", 0},
+		{"Über", 2},              // CountVowels finds only the e
+		{"naïve", 3},             // CountVowels finds a and e
+		{cafeComposed, 2},        // CountVowels finds only the a
+		{cafeDecomposed, 2},      // the combining mark is not a vowel
+		{"ÉCOLE", 3},             // uppercase accented letters too
+		{"Smørrebrød", 3},        // ø is a vowel in Danish
+		{"日本語", 0},               // no Latin vowels at all
+		{"Crème brûlée", 5},      // è, e, û, é, e
+		{"rhythm", 0},            // y is not counted
+		{"Ångström", 2},          // Å and ö
+		{"ÀÁÂÃÄÅ àáâãäå", 12},    // every form of a
+		{"ÙÚÛÜ ùúûü", 8},         // every form of u
+		{"ÌÍÎÏ ìíîï ÒÓÔÕÖØ", 14}, // i and o forms
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, CountVowelsUnicode(tt.input), "CountVowelsUnicode(%q)", tt.input)
+	}
+}
+
+// FuzzCountVowelsUnicode checks that a vowel is never a combining mark,
+// and that doubling the text doubles the vowels.
+func FuzzCountVowelsUnicode(f *testing.F) {
+	for _, s := range []string{"Über café", cafeDecomposed, "日本語", "ÀÁÂÃÄÅ", "\u0301"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if !utf8.ValidString(s) {
+			t.Skip("two halves of a rune make a whole one when doubled")
+		}
+		n := CountVowelsUnicode(s)
+		if n < 0 || n > VisibleLength(s) {
+			t.Fatalf("CountVowelsUnicode(%q) = %d, but it has %d characters", s, n, VisibleLength(s))
+		}
+		if got := CountVowelsUnicode(s + s); got != 2*n {
+			t.Errorf("CountVowelsUnicode(%q) = %d, but the text twice has %d", s, n, got)
+		}
+	})
+}
+
+func TestInitials(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"ada lovelace", "AL"},
+		{"émile zola", "ÉZ"},
+		{"  Ørjan   ødegaard ", "ØØ"},
+		{"Šarūnas Jasikevičius", "ŠJ"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Initials(tt.name), "Initials(%q)", tt.name)
+	}
+}
+
+func TestSameWord(t *testing.T) {
+	assert.True(t, SameWord("Go", "GO"))
+	assert.True(t, SameWord("Ärger", "ärger"))
+	assert.True(t, SameWord("ΣΑΣ", "σας"), "final sigma folds to Σ")
+	assert.True(t, SameWord("\u212a", "k"), "KELVIN SIGN folds to k")
+	assert.False(t, SameWord("go", "golang"))
+	// Full case folding (ß -> ss) is beyond the standard library
+	assert.False(t, SameWord("straße", "STRASSE"))
+}
+
+func TestVisibleLength(t *testing.T) {
+	assert.Equal(t, 5, VisibleLength("hello"))
+	assert.Equal(t, 4, VisibleLength(cafeComposed))
+	assert.Equal(t, 4, VisibleLength(cafeDecomposed))
+	assert.Equal(t, 3, VisibleLength("日本語"))
+	assert.Equal(t, 1, VisibleLength("a\u0301\u0302"), "two marks on one letter")
+	assert.Equal(t, 0, VisibleLength(""))
+}
+
+func TestReverseText(t *testing.T) {
+	tests := []struct {
+		input, want string
+	}{
+		{"", ""},
+		{"abc", "cba"},
+		{"日本語", "語本日"},
+		{cafeComposed, "\u00e9fac"},
+		{cafeDecomposed, "e\u0301fac"},
+		{"n\u0303a", "an\u0303"}, // ñ written as n + COMBINING TILDE
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ReverseText(tt.input), "ReverseText(%q)", tt.input)
+	}
+	assert.Equal(t, cafeDecomposed, ReverseText(ReverseText(cafeDecomposed)), "reversing twice restores the input")
+}
+
+// FuzzReverseText checks that reversing keeps every rune and character,
+// and that reversing twice restores the text. A combining mark at the
+// very start decorates nothing, and ends up on the last character.
+func FuzzReverseText(f *testing.F) {
+	for _, s := range []string{"abc", cafeComposed, cafeDecomposed, "n\u0303a", "日本語"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if !utf8.ValidString(s) {
+			t.Skip("ranging over invalid UTF-8 replaces bytes with U+FFFD")
+		}
+		got := ReverseText(s)
+		if utf8.RuneCountInString(got) != utf8.RuneCountInString(s) || VisibleLength(got) != VisibleLength(s) {
+			t.Fatalf("ReverseText(%q) = %q, which has other runes", s, got)
+		}
+		if r, _ := utf8.DecodeRuneInString(s); isCombiningMark(r) {
+			return
+		}
+		if back := ReverseText(got); back != s {
+			t.Errorf("ReverseText(ReverseText(%q)) = %q", s, back)
+		}
+	})
+}
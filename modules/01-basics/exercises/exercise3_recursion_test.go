@@ -0,0 +1,139 @@
+package exercises
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleTree builds:
+//
+//	root (1)
+//	├── docs (2)
+//	│   └── readme.md (10)
+//	└── src (3)
+//	    ├── main.go (20)
+//	    └── pkg (4)
+//	        └── util.go (30)
+func sampleTree() *FileNode {
+	return &FileNode{Name: "root", Size: 1, Children: []*FileNode{
+		{Name: "docs", Size: 2, Children: []*FileNode{
+			{Name: "readme.md", Size: 10},
+		}},
+		{Name: "src", Size: 3, Children: []*FileNode{
+			{Name: "main.go", Size: 20},
+			{Name: "pkg", Size: 4, Children: []*FileNode{
+				{Name: "util.go", Size: 30},
+			}},
+		}},
+	}}
+}
+
+// chain builds a degenerate tree that is depth nodes deep, each of size 1.
+func chain(depth int) *FileNode {
+	root := &FileNode{Name: "n0", Size: 1}
+	curr := root
+	for i := 1; i < depth; i++ {
+		next := &FileNode{Name: "n", Size: 1}
+		curr.Children = []*FileNode{next}
+		curr = next
+	}
+	return root
+}
+
+func TestTotalSize(t *testing.T) {
+	assert.Equal(t, int64(70), TotalSize(sampleTree()))
+	assert.Equal(t, int64(5), TotalSize(&FileNode{Name: "file", Size: 5}))
+	assert.NotPanics(t, func() {
+		assert.Equal(t, int64(0), TotalSize(nil))
+	})
+}
+
+func TestMaxDepth(t *testing.T) {
+	assert.Equal(t, 0, MaxDepth(nil))
+	assert.Equal(t, 1, MaxDepth(&FileNode{Name: "leaf"}))
+	assert.Equal(t, 4, MaxDepth(sampleTree()))
+	assert.Equal(t, 1000, MaxDepth(chain(1000)))
+}
+
+func TestPathsUpTo(t *testing.T) {
+	tree := sampleTree()
+
+	assert.Empty(t, PathsUpTo(tree, 0))
+	assert.Equal(t, []string{"root"}, PathsUpTo(tree, 1))
+	assert.Equal(t, []string{"root", "root/docs", "root/src"}, PathsUpTo(tree, 2))
+	assert.Equal(t, []string{
+		"root",
+		"root/docs", "root/docs/readme.md",
+		"root/src", "root/src/main.go", "root/src/pkg",
+	}, PathsUpTo(tree, 3))
+	assert.Len(t, PathsUpTo(tree, 4), 7)
+	assert.Len(t, PathsUpTo(tree, 100), 7, "limits beyond the tree depth change nothing")
+}
+
+func TestPathsUpToDepthLimit(t *testing.T) {
+	deep := chain(2000)
+	for _, limit := range []int{1, 10, 500, 1999, 2000} {
+		assert.Len(t, PathsUpTo(deep, limit), limit, "limit %d", limit)
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	assert.Equal(t, [][]int{{}}, Permutations([]int{}))
+	assert.Equal(t, [][]int{{1}}, Permutations([]int{1}))
+	assert.ElementsMatch(t, [][]int{{1, 2}, {2, 1}}, Permutations([]int{1, 2}))
+
+	input := []int{1, 2, 3}
+	assert.ElementsMatch(t, [][]int{
+		{1, 2, 3}, {1, 3, 2},
+		{2, 1, 3}, {2, 3, 1},
+		{3, 1, 2}, {3, 2, 1},
+	}, Permutations(input))
+	assert.Equal(t, []int{1, 2, 3}, input, "Permutations must not modify its input")
+
+	assert.Len(t, Permutations([]int{1, 2, 3, 4, 5}), 120)
+}
+
+func TestFloodFill(t *testing.T) {
+	grid := [][]rune{
+		[]rune("..#.."),
+		[]rune(".##.."),
+		[]rune("#...#"),
+		[]rune("..#.."),
+	}
+	var filled int
+	require.NotPanics(t, func() {
+		filled = FloodFill(grid, 2, 2, 'o')
+	})
+	assert.Equal(t, 11, filled)
+	assert.Equal(t, [][]rune{
+		[]rune("..#oo"),
+		[]rune(".##oo"),
+		[]rune("#ooo#"),
+		[]rune("oo#oo"),
+	}, grid)
+
+	// Filling with the same value changes nothing
+	assert.Equal(t, 0, FloodFill(grid, 0, 0, '.'))
+
+	// Out-of-range starting points change nothing
+	assert.NotPanics(t, func() {
+		assert.Equal(t, 0, FloodFill(grid, 4, 0, 'x'))
+		assert.Equal(t, 0, FloodFill(grid, 0, 5, 'x'))
+		assert.Equal(t, 0, FloodFill(grid, -1, 0, 'x'))
+	})
+}
+
+func TestTotalSizeIterative(t *testing.T) {
+	assert.Equal(t, int64(70), TotalSizeIterative(sampleTree()))
+	assert.Equal(t, int64(5), TotalSizeIterative(&FileNode{Name: "file", Size: 5}))
+	assert.Equal(t, int64(0), TotalSizeIterative(nil))
+	assert.Equal(t, TotalSize(sampleTree()), TotalSizeIterative(sampleTree()))
+}
+
+func TestTotalSizeIterativeDeepTree(t *testing.T) {
+	// A million levels would need a million stack frames recursively;
+	// the explicit stack only ever holds a single pending node here.
+	assert.Equal(t, int64(1_000_000), TotalSizeIterative(chain(1_000_000)))
+}
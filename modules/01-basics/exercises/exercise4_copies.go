@@ -0,0 +1,94 @@
+package exercises
+
+// EXERCISE: Fix the copies and the aliases in this file.
+// Assignment in Go copies: a struct copies its fields, an array all its
+// elements, and range hands you a copy of each element. But a slice or a
+// map is a small header pointing at data that the copy shares. Each bug
+// below either changes a copy that was meant to be the original, or
+// shares data that was meant to be copied. Decide which, and use a
+// pointer, an index, or an explicit copy.
+
+// Player is one member of a team.
+type Player struct {
+	Name  string
+	Score int
+	Tags  []string
+}
+
+// Team is a named list of players.
+type Team struct {
+	Name    string
+	Players []Player
+}
+
+// AddBonus adds bonus to the score of every player in players.
+// BUG: The bonus is added to a copy of each player.
+func AddBonus(players []Player, bonus int) {
+	for _, p := range players {
+		p.Score += bonus // BUG: p is range's copy of the element
+	}
+}
+
+// Find returns the team's player called name, so that the caller can
+// change them, or nil if there is none.
+// BUG: Changing the player Find returns does not change the team.
+func (t *Team) Find(name string) *Player {
+	for _, p := range t.Players {
+		if p.Name == name {
+			return &p // BUG: A pointer to the loop variable's copy
+		}
+	}
+	return nil
+}
+
+// Clone returns a copy of p that shares nothing with it: changing the
+// copy's tags leaves p's alone.
+// BUG: Copying a struct copies the slice header in it, not the slice's
+// elements.
+func (p Player) Clone() Player {
+	return p // BUG: The copy's Tags share p's backing array
+}
+
+// Extend returns s with v appended, without changing what any other slice
+// of s's backing array holds, so two calls with the same s do not
+// overwrite each other.
+// BUG: When s has spare capacity, append writes into s's backing array.
+func Extend(s []int, v ...int) []int {
+	return append(s, v...) // BUG: Needs a new array, whatever cap(s) is
+}
+
+// Snapshot returns a copy of stock, which later changes to stock do not
+// affect.
+// BUG: Assigning a map does not copy its entries.
+func Snapshot(stock map[string]int) map[string]int {
+	snap := stock // BUG: The same map, under another name
+	return snap
+}
+
+// Grid is a noughts and crosses board. An array is a value: assigning a
+// Grid, passing it, or appending it copies all nine cells.
+type Grid [3][3]byte
+
+// Move is a mark placed on a Grid.
+type Move struct {
+	Row, Col int
+	Mark     byte
+}
+
+// Set places mark at row, col.
+// BUG: A value receiver is a copy of the Grid, and the mark is lost.
+func (g Grid) Set(row, col int, mark byte) {
+	g[row][col] = mark
+}
+
+// History returns the grid after each of moves, starting from an empty
+// one. Each entry is a copy, so later moves do not change it.
+func History(moves []Move) []Grid {
+	var g Grid
+	history := make([]Grid, 0, len(moves))
+	for _, m := range moves {
+		g.Set(m.Row, m.Col, m.Mark)
+		history = append(history, g)
+	}
+	return history
+}
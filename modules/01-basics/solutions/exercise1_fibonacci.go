@@ -0,0 +1,70 @@
+package solutions
+
+// SOLUTION: Four Fibonacci implementations with very different costs.
+// Run the benchmarks to compare them:
+//
+//	go test -bench=Fibonacci -benchmem ./solutions/
+
+import "math/big"
+
+// FibonacciRecursive is the textbook definition translated literally.
+// It is correct but runs in O(2^n) time because it recomputes the same
+// subproblems over and over. Use it only as a baseline in benchmarks.
+func FibonacciRecursive(n int) int {
+	if n <= 1 {
+		return n
+	}
+	return FibonacciRecursive(n-1) + FibonacciRecursive(n-2)
+}
+
+// FibonacciMemo computes F(n) recursively, caching every result in memo
+// so each subproblem is solved only once (O(n) time, O(n) space).
+// Callers may pass a nil map; a fresh cache is created in that case.
+func FibonacciMemo(n int, memo map[int]int) int {
+	if memo == nil {
+		memo = make(map[int]int)
+	}
+	if n <= 1 {
+		return n
+	}
+
+	// Fixed: Consult the cache before recursing
+	if cached, ok := memo[n]; ok {
+		return cached
+	}
+
+	result := FibonacciMemo(n-1, memo) + FibonacciMemo(n-2, memo)
+	memo[n] = result
+	return result
+}
+
+// FibonacciIterative computes F(n) bottom-up with two variables
+// (O(n) time, O(1) space). int overflows after F(92) on 64-bit platforms.
+func FibonacciIterative(n int) int {
+	if n <= 1 {
+		return n
+	}
+
+	prev, curr := 0, 1
+	for i := 2; i <= n; i++ { // Fixed: Include the nth step
+		prev, curr = curr, prev+curr
+	}
+	return curr
+}
+
+// FibonacciBig computes F(n) with arbitrary precision, so it never overflows.
+// big.Int values are mutable and methods like Add write into the receiver,
+// so we copy the value before overwriting it.
+func FibonacciBig(n int) *big.Int {
+	if n <= 1 {
+		return big.NewInt(int64(n))
+	}
+
+	prev, curr := big.NewInt(0), big.NewInt(1)
+	for i := 2; i <= n; i++ {
+		next := new(big.Int).Set(curr) // Fixed: Copy the value, not the pointer
+		curr.Add(prev, curr)
+		prev = next
+	}
+	return curr
+}
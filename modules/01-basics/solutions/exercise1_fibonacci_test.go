@@ -0,0 +1,137 @@
+package solutions
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fibonacciTable holds known values used by every variant.
+var fibonacciTable = []struct {
+	n    int
+	want int
+}{
+	{0, 0}, {1, 1}, {2, 1}, {3, 2}, {4, 3}, {5, 5}, {6, 8}, {8, 21}, {10, 55}, {20, 6765},
+}
+
+// finishesWithin fails the test if fn does not return before the deadline.
+// An exponential algorithm would otherwise make the test hang.
+func finishesWithin(t *testing.T, d time.Duration, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	select {
+	case <-done:
+	case <-time.After(d):
+		t.Fatalf("did not finish within %v - is the algorithm exponential?", d)
+	}
+}
+
+func TestFibonacciRecursive(t *testing.T) {
+	for _, tc := range fibonacciTable {
+		assert.Equal(t, tc.want, FibonacciRecursive(tc.n), "F(%d)", tc.n)
+	}
+}
+
+func TestFibonacciMemo(t *testing.T) {
+	for _, tc := range fibonacciTable {
+		assert.Equal(t, tc.want, FibonacciMemo(tc.n, nil), "F(%d)", tc.n)
+	}
+
+	memo := make(map[int]int)
+	assert.Equal(t, 55, FibonacciMemo(10, memo))
+	assert.Len(t, memo, 9, "memo should hold F(2) through F(10)")
+}
+
+func TestFibonacciMemoIsFast(t *testing.T) {
+	var got int
+	finishesWithin(t, time.Second, func() {
+		got = FibonacciMemo(90, nil)
+	})
+	assert.Equal(t, 2880067194370816120, got)
+}
+
+func TestFibonacciIterative(t *testing.T) {
+	for _, tc := range fibonacciTable {
+		assert.Equal(t, tc.want, FibonacciIterative(tc.n), "F(%d)", tc.n)
+	}
+	// F(92) is the largest Fibonacci number that fits in an int64
+	assert.Equal(t, 7540113804746346429, FibonacciIterative(92))
+}
+
+func TestFibonacciBig(t *testing.T) {
+	for _, tc := range fibonacciTable {
+		assert.Equal(t, int64(tc.want), FibonacciBig(tc.n).Int64(), "F(%d)", tc.n)
+	}
+
+	// Beyond int64 range: only big.Int gets this right
+	assert.Equal(t, "354224848179261915075", FibonacciBig(100).String())
+
+	// Results must be independent values, not shared state
+	a := FibonacciBig(10)
+	b := FibonacciBig(10)
+	a.SetInt64(0)
+	require.Equal(t, int64(55), b.Int64())
+}
+
+func TestFibonacciVariantsAgree(t *testing.T) {
+	for n := 0; n <= 30; n++ {
+		want := FibonacciRecursive(n)
+		assert.Equal(t, want, FibonacciMemo(n, nil), "memo F(%d)", n)
+		assert.Equal(t, want, FibonacciIterative(n), "iterative F(%d)", n)
+		assert.Equal(t, int64(want), FibonacciBig(n).Int64(), "big F(%d)", n)
+	}
+}
+
+// Compare the variants with:
+//
+//	go test -bench=Fibonacci -benchmem
+//
+// Note how the recursive version explodes between n=10 and n=30 while the
+// others barely notice, and how big.Int pays for allocations.
+
+func BenchmarkFibonacciRecursive(b *testing.B) {
+	for _, n := range []int{10, 20, 30} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				FibonacciRecursive(n)
+			}
+		})
+	}
+}
+
+func BenchmarkFibonacciMemo(b *testing.B) {
+	for _, n := range []int{10, 20, 30, 90} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				FibonacciMemo(n, nil)
+			}
+		})
+	}
+}
+
+func BenchmarkFibonacciIterative(b *testing.B) {
+	for _, n := range []int{10, 20, 30, 90} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				FibonacciIterative(n)
+			}
+		})
+	}
+}
+
+func BenchmarkFibonacciBig(b *testing.B) {
+	for _, n := range []int{10, 20, 30, 90, 1000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				FibonacciBig(n)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package solutions
 // SOLUTION: This file contains the corrected versions of the exercises.
 
 import (
+	"cmp"
 	"fmt"
 	"strings"
 )
@@ -104,6 +105,34 @@ func MergeMaps(map1, map2 map[string]int) map[string]int {
 	return result
 }
 
+// Merge copies every key from src into dst. When a key already exists in dst,
+// resolve decides which value to keep; a nil resolve lets src win, which is
+// exactly what MergeMaps does for map[string]int.
+func Merge[K comparable, V any](dst, src map[K]V, resolve func(existing, incoming V) V) {
+	for k, v := range src {
+		if old, ok := dst[k]; ok && resolve != nil {
+			v = resolve(old, v)
+		}
+		dst[k] = v
+	}
+}
+
+// MergeAll merges any number of maps, left to right, into a new map.
+// Conflicts are settled by resolve exactly as in Merge.
+func MergeAll[K comparable, V any](resolve func(existing, incoming V) V, maps ...map[K]V) map[K]V {
+	result := make(map[K]V)
+	for _, m := range maps {
+		Merge(result, m, resolve)
+	}
+	return result
+}
+
+// KeepLarger is a conflict strategy for Merge that keeps the larger value.
+// The cmp.Ordered constraint allows any type that supports < and >.
+func KeepLarger[V cmp.Ordered](existing, incoming V) V {
+	return max(existing, incoming) // Fixed: Compare instead of blindly overwriting
+}
+
 // Fibonacci returns the nth Fibonacci number.
 // The sequence is: 0, 1, 1, 2, 3, 5, 8, 13, 21, ...
 func Fibonacci(n int) int {
@@ -161,6 +190,9 @@ func DemonstrateSolutions() {
 	merged := MergeMaps(m1, m2)
 	fmt.Printf("Merged maps: %v\n", merged)
 
+	largest := MergeAll(KeepLarger[int], m1, m2, map[string]int{"a": 0})
+	fmt.Printf("Merged keeping larger: %v\n", largest)
+
 	fmt.Printf("Fibonacci(6): %d\n", Fibonacci(6))
 	fmt.Printf("Fibonacci(8): %d\n", Fibonacci(8))
 }
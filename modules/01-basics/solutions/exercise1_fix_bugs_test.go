@@ -1,8 +1,11 @@
 package solutions
 
 import (
+	"strings"
 	"testing"
+	"unicode/utf8"
 
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -40,6 +43,25 @@ func TestGetGrade(t *testing.T) {
 	assert.Equal(t, "F", GetGrade(55))
 }
 
+func TestGetGradeRandomScores(t *testing.T) {
+	r := seed.Rand(t, 1)
+	cutoffs := []struct {
+		min   int
+		grade string
+	}{{90, "A"}, {80, "B"}, {70, "C"}, {60, "D"}, {0, "F"}}
+	for i := 0; i < 50; i++ {
+		score := seed.Between(r, 0, 100)
+		want := "F"
+		for _, c := range cutoffs {
+			if score >= c.min {
+				want = c.grade
+				break
+			}
+		}
+		assert.Equal(t, want, GetGrade(score), "GetGrade(%d)", score)
+	}
+}
+
 func TestFindMax(t *testing.T) {
 	assert.Equal(t, 10, FindMax([]int{1, 5, 10, 3}))
 	assert.Equal(t, -2, FindMax([]int{-5, -2, -10}))
@@ -55,6 +77,38 @@ func TestCountVowels(t *testing.T) {
 	assert.Equal(t, 0, CountVowels(""))
 }
 
+// FuzzCountVowels checks properties that hold for any input: there are
+// never more vowels than runes, doubling the text doubles them, and case
+// does not matter in ASCII text.
+func FuzzCountVowels(f *testing.F) {
+	for _, s := range []string{"hello", "AEIOU", "", "rhythm", "\u0130stanbul", "\xffa"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		n := CountVowels(s)
+		if n < 0 || n > utf8.RuneCountInString(s) {
+			t.Fatalf("CountVowels(%q) = %d, but it has %d runes", s, n, utf8.RuneCountInString(s))
+		}
+		if got := CountVowels(s + s); got != 2*n {
+			t.Errorf("CountVowels(%q) = %d, but the text twice has %d", s, n, got)
+		}
+		if isASCII(s) {
+			if upper := CountVowels(strings.ToUpper(s)); upper != n {
+				t.Errorf("CountVowels(%q) = %d, but in upper case %d", s, n, upper)
+			}
+		}
+	})
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
 func TestReverseSlice(t *testing.T) {
 	assert.Equal(t, []int{5, 4, 3, 2, 1}, ReverseSlice([]int{1, 2, 3, 4, 5}))
 	assert.Equal(t, []int{3, 2, 1}, ReverseSlice([]int{1, 2, 3}))
@@ -79,6 +133,55 @@ func TestMergeMaps(t *testing.T) {
 	assert.Equal(t, 3, len(result))
 }
 
+func TestMerge(t *testing.T) {
+	dst := map[string]int{"a": 1, "b": 2}
+	Merge(dst, map[string]int{"b": 3, "c": 4}, nil)
+	assert.Equal(t, map[string]int{"a": 1, "b": 3, "c": 4}, dst) // nil resolver: src wins
+
+	sum := func(existing, incoming int) int { return existing + incoming }
+	dst = map[string]int{"a": 1, "b": 2}
+	Merge(dst, map[string]int{"b": 3, "c": 4}, sum)
+	assert.Equal(t, map[string]int{"a": 1, "b": 5, "c": 4}, dst)
+
+	keepExisting := func(existing, _ string) string { return existing }
+	names := map[int]string{1: "one"}
+	Merge(names, map[int]string{1: "uno", 2: "dos"}, keepExisting)
+	assert.Equal(t, map[int]string{1: "one", 2: "dos"}, names)
+}
+
+func TestMergeAll(t *testing.T) {
+	concat := func(existing, incoming []string) []string { return append(existing, incoming...) }
+	result := MergeAll(concat,
+		map[string][]string{"go": {"gopher"}},
+		map[string][]string{"go": {"goroutine"}, "rust": {"crab"}},
+		nil,
+		map[string][]string{"go": {"channel"}},
+	)
+	assert.Equal(t, []string{"gopher", "goroutine", "channel"}, result["go"])
+	assert.Equal(t, []string{"crab"}, result["rust"])
+
+	assert.Empty(t, MergeAll[string, int](nil))
+
+	m1 := map[string]int{"a": 1}
+	merged := MergeAll(nil, m1, map[string]int{"a": 2})
+	assert.Equal(t, 2, merged["a"])
+	assert.Equal(t, 1, m1["a"]) // inputs are never modified
+}
+
+func TestKeepLarger(t *testing.T) {
+	assert.Equal(t, 5, KeepLarger(5, 3))
+	assert.Equal(t, 5, KeepLarger(3, 5))
+	assert.Equal(t, -1, KeepLarger(-1, -7))
+	assert.Equal(t, "pear", KeepLarger("apple", "pear"))
+
+	scores := MergeAll(KeepLarger[int],
+		map[string]int{"alice": 90, "bob": 70},
+		map[string]int{"alice": 85, "bob": 95, "carol": 60},
+		map[string]int{"carol": 40},
+	)
+	assert.Equal(t, map[string]int{"alice": 90, "bob": 95, "carol": 60}, scores)
+}
+
 func TestFibonacci(t *testing.T) {
 	assert.Equal(t, 0, Fibonacci(0))
 	assert.Equal(t, 1, Fibonacci(1))
@@ -0,0 +1,112 @@
+package solutions
+
+// SOLUTION: Strings, runes, maps, and slices done idiomatically.
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// IsPalindrome reports whether s reads the same forwards and backwards,
+// ignoring case, spaces, and punctuation ("A man, a plan, a canal: Panama").
+func IsPalindrome(s string) bool {
+	// Fixed: Work on runes and keep only lowercased letters and digits
+	chars := make([]rune, 0, len(s))
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			chars = append(chars, unicode.ToLower(r))
+		}
+	}
+
+	for i, j := 0, len(chars)-1; i < j; i, j = i+1, j-1 {
+		if chars[i] != chars[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// WordFrequency counts how often each word appears in text.
+// Words are separated by whitespace, compared case-insensitively, and
+// surrounding punctuation is ignored ("Go, go GO!" has "go" three times).
+func WordFrequency(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, word := range strings.Fields(text) { // Fixed: Any run of whitespace separates words
+		word = strings.TrimFunc(strings.ToLower(word), unicode.IsPunct)
+		if word == "" {
+			continue // Lone punctuation such as "-" is not a word
+		}
+		counts[word]++
+	}
+	return counts
+}
+
+// AreAnagrams reports whether a and b contain exactly the same letters,
+// ignoring case and spaces ("Dormitory" and "dirty room").
+func AreAnagrams(a, b string) bool {
+	// Fixed: Count occurrences instead of tracking a set
+	counts := make(map[rune]int)
+	for _, r := range normalizeLetters(a) {
+		counts[r]++
+	}
+	for _, r := range normalizeLetters(b) {
+		counts[r]--
+	}
+	for _, c := range counts {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeLetters lowercases s and drops everything that is not a letter.
+func normalizeLetters(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// SortedRunes returns the runes of s in sorted order. It is an alternative
+// building block for anagram detection: two words are anagrams when their
+// normalized, sorted runes are equal.
+func SortedRunes(s string) []rune {
+	runes := []rune(s)
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	return runes
+}
+
+// Deduplicate returns the elements of items with duplicates removed,
+// keeping the first occurrence and preserving the original order.
+// The input slice is not modified.
+func Deduplicate(items []string) []string {
+	// Fixed: A set (map[string]bool) preserves order and leaves the input alone
+	seen := make(map[string]bool, len(items))
+	result := []string{}
+	for _, item := range items {
+		if seen[item] {
+			continue
+		}
+		seen[item] = true
+		result = append(result, item)
+	}
+	return result
+}
+
+// Truncate shortens s to at most maxRunes characters, appending "…" when
+// anything was cut off. It never splits a multi-byte character, and a
+// negative maxRunes counts as 0.
+func Truncate(s string, maxRunes int) string {
+	maxRunes = max(maxRunes, 0)                // Fixed: a negative maxRunes would panic when slicing
+	if utf8.RuneCountInString(s) <= maxRunes { // Fixed: Count runes, not bytes
+		return s
+	}
+	runes := []rune(s) // Fixed: Slice on rune boundaries
+	return string(runes[:maxRunes]) + "…"
+}
@@ -0,0 +1,134 @@
+package solutions
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPalindrome(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"", true},
+		{"a", true},
+		{"racecar", true},
+		{"Racecar", true},
+		{"A man, a plan, a canal: Panama", true},
+		{"never odd or even", true},
+		{"été", true},
+		{"hello", false},
+		{"ab", false},
+		{"No lemon, no melons", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPalindrome(tt.input))
+		})
+	}
+}
+
+func TestWordFrequency(t *testing.T) {
+	assert.Equal(t, map[string]int{"go": 3}, WordFrequency("Go, go GO!"))
+	assert.Equal(t,
+		map[string]int{"the": 2, "quick": 1, "fox": 1, "and": 1, "dog": 1},
+		WordFrequency("the  quick fox\nand\tthe dog."),
+	)
+	assert.Empty(t, WordFrequency(""))
+	assert.Empty(t, WordFrequency("   "))
+}
+
+func TestAreAnagrams(t *testing.T) {
+	assert.True(t, AreAnagrams("listen", "silent"))
+	assert.True(t, AreAnagrams("Dormitory", "dirty room"))
+	assert.True(t, AreAnagrams("", ""))
+	assert.False(t, AreAnagrams("aab", "abb"))  // same letters, different counts
+	assert.False(t, AreAnagrams("abc", "abcd")) // extra letter
+	assert.False(t, AreAnagrams("hello", "world"))
+}
+
+func TestSortedRunes(t *testing.T) {
+	assert.Equal(t, []rune("eilnst"), SortedRunes("listen"))
+	assert.Equal(t, SortedRunes("silent"), SortedRunes("listen"))
+	assert.Empty(t, SortedRunes(""))
+}
+
+func TestDeduplicate(t *testing.T) {
+	input := []string{"go", "rust", "go", "python", "rust", "go"}
+	original := append([]string(nil), input...)
+
+	assert.Equal(t, []string{"go", "rust", "python"}, Deduplicate(input))
+	assert.Equal(t, original, input, "Deduplicate must not modify its input")
+
+	assert.Equal(t, []string{"b", "a"}, Deduplicate([]string{"b", "a", "b"}))
+	assert.Equal(t, []string{}, Deduplicate([]string{}))
+	assert.Equal(t, []string{}, Deduplicate(nil))
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		max   int
+		want  string
+	}{
+		{"short ascii", "hello", 10, "hello"},
+		{"exact ascii", "hello", 5, "hello"},
+		{"long ascii", "hello world", 5, "hello…"},
+		{"accented fits", "héllo", 5, "héllo"},
+		{"accented cut", "héllo wörld", 7, "héllo w…"},
+		{"cjk", "日本語のテキスト", 3, "日本語…"},
+		{"emoji", "🚀🚀🚀", 1, "🚀…"},
+		{"zero", "abc", 0, "…"},
+		{"empty", "", 3, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Truncate(tt.input, tt.max)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, utf8.ValidString(got), "result must be valid UTF-8")
+		})
+	}
+}
+
+// FuzzTruncate checks that Truncate keeps a prefix of at most maxRunes
+// whole runes, for any text and any maxRunes, negative ones included.
+func FuzzTruncate(f *testing.F) {
+	f.Add("héllo wörld", 7)
+	f.Add("🚀🚀🚀", 1)
+	f.Add("", 3)
+	f.Add("abc", 0)
+	f.Fuzz(func(t *testing.T, s string, maxRunes int) {
+		if !utf8.ValidString(s) {
+			t.Skip("invalid UTF-8 has no whole runes to keep")
+		}
+		var got string
+		noPanic(t, func() { got = Truncate(s, maxRunes) })
+		limit := max(maxRunes, 0)
+		if utf8.RuneCountInString(s) <= limit {
+			if got != s {
+				t.Errorf("Truncate(%q, %d) = %q, want it unchanged", s, maxRunes, got)
+			}
+			return
+		}
+		kept, ok := strings.CutSuffix(got, "…")
+		if !ok || !strings.HasPrefix(s, kept) || utf8.RuneCountInString(kept) != limit {
+			t.Errorf("Truncate(%q, %d) = %q, want its first %d runes and \"…\"", s, maxRunes, got, limit)
+		}
+	})
+}
+
+// noPanic calls fn, and fails the test if it panics, rather than letting
+// the panic end the test binary and every other exercise's tests with it.
+func noPanic(t *testing.T, fn func()) {
+	t.Helper()
+	defer func() {
+		if v := recover(); v != nil {
+			t.Fatalf("panic: %v", v)
+		}
+	}()
+	fn()
+}
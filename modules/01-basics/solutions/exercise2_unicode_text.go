@@ -0,0 +1,98 @@
+package solutions
+
+// SOLUTION: Unicode-aware text processing.
+// Each fix is marked with a // Fixed: comment.
+
+import (
+	"strings"
+	"unicode"
+)
+
+// accentedVowels maps precomposed Latin vowels to their base letter.
+// The standard library has no Unicode normalization; for real
+// applications use golang.org/x/text/unicode/norm to decompose text and
+// drop the combining marks instead of listing characters by hand.
+var accentedVowels = map[rune]rune{
+	'à': 'a', 'á': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a',
+	'è': 'e', 'é': 'e', 'ê': 'e', 'ë': 'e',
+	'ì': 'i', 'í': 'i', 'î': 'i', 'ï': 'i',
+	'ò': 'o', 'ó': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o',
+	'ù': 'u', 'ú': 'u', 'û': 'u', 'ü': 'u',
+}
+
+// isCombiningMark reports whether r is a nonspacing mark such as U+0301
+// COMBINING ACUTE ACCENT, which decorates the rune before it.
+func isCombiningMark(r rune) bool {
+	return unicode.Is(unicode.Mn, r)
+}
+
+// CountVowelsUnicode counts the vowels a, e, i, o, u in s, case-insensitively
+// and including accented forms: "Über café" has 4 vowels. "é" counts once
+// whether it is written as one rune (U+00E9) or as "e" followed by a
+// combining accent.
+func CountVowelsUnicode(s string) int {
+	count := 0
+	for _, r := range s {
+		r = unicode.ToLower(r)
+		if base, ok := accentedVowels[r]; ok { // Fixed: map accented vowels to their base letter
+			r = base
+		}
+		if strings.ContainsRune("aeiou", r) {
+			count++
+		}
+	}
+	return count
+}
+
+// Initials returns the uppercased first letter of each word in name:
+// "émile zola" -> "ÉZ".
+func Initials(name string) string {
+	var sb strings.Builder
+	for _, word := range strings.Fields(name) {
+		first := []rune(word)[0] // Fixed: take the first rune, not the first byte
+		sb.WriteRune(unicode.ToUpper(first))
+	}
+	return sb.String()
+}
+
+// SameWord reports whether a and b are the same word ignoring case.
+func SameWord(a, b string) bool {
+	// Fixed: strings.EqualFold compares with Unicode case folding, so all
+	// three Greek sigmas (Σ, σ, final ς) match each other
+	return strings.EqualFold(a, b)
+}
+
+// VisibleLength returns the number of characters a reader sees in s:
+// runes, minus combining marks that merge into the previous character.
+func VisibleLength(s string) int {
+	n := 0
+	for _, r := range s {
+		if !isCombiningMark(r) { // Fixed: combining marks do not add a character
+			n++
+		}
+	}
+	return n
+}
+
+// ReverseText reverses s character by character, keeping each combining
+// mark attached to the rune it decorates: "café" -> "éfac".
+// This is an approximation of grapheme clusters; emoji sequences and flags
+// need the full rules (see github.com/rivo/uniseg).
+func ReverseText(s string) string {
+	// Fixed: group each base rune with its marks, then reverse the groups
+	var clusters [][]rune
+	for _, r := range s {
+		if isCombiningMark(r) && len(clusters) > 0 {
+			last := len(clusters) - 1
+			clusters[last] = append(clusters[last], r)
+			continue
+		}
+		clusters = append(clusters, []rune{r})
+	}
+
+	out := make([]rune, 0, len(s))
+	for i := len(clusters) - 1; i >= 0; i-- {
+		out = append(out, clusters[i]...)
+	}
+	return string(out)
+}
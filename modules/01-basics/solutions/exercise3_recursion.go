@@ -0,0 +1,123 @@
+package solutions
+
+// SOLUTION: Recursion with correct base cases, plus an iterative rewrite
+// using an explicit stack for unbounded depth.
+
+// FileNode is a directory-tree-like structure: directories have children,
+// files have a size.
+type FileNode struct {
+	Name     string
+	Size     int64
+	Children []*FileNode
+}
+
+// TotalSize returns the sum of Size for n and all of its descendants.
+// A nil node has size 0.
+func TotalSize(n *FileNode) int64 {
+	if n == nil {
+		return 0 // Fixed: nil base case
+	}
+	total := n.Size
+	for _, child := range n.Children {
+		total += TotalSize(child)
+	}
+	return total
+}
+
+// MaxDepth returns the number of levels in the tree rooted at n.
+// A nil tree has depth 0 and a single node has depth 1.
+func MaxDepth(n *FileNode) int {
+	if n == nil {
+		return 0
+	}
+
+	// Fixed: No special leaf case needed - a leaf naturally yields 0 + 1
+	deepest := 0
+	for _, child := range n.Children {
+		if d := MaxDepth(child); d > deepest {
+			deepest = d
+		}
+	}
+	return deepest + 1
+}
+
+// PathsUpTo returns the slash-separated paths of every node whose depth is
+// at most maxDepth, in depth-first order. The root is at depth 1, so
+// maxDepth 0 returns no paths and maxDepth 1 returns only the root.
+func PathsUpTo(root *FileNode, maxDepth int) []string {
+	paths := []string{}
+	var walk func(n *FileNode, prefix string, depth int)
+	walk = func(n *FileNode, prefix string, depth int) {
+		if n == nil || depth > maxDepth { // Fixed: Inclusive limit
+			return
+		}
+		path := prefix + n.Name
+		paths = append(paths, path)
+		for _, child := range n.Children {
+			walk(child, path+"/", depth+1)
+		}
+	}
+	walk(root, "", 1)
+	return paths
+}
+
+// Permutations returns every ordering of items. The input slice is not
+// modified. There is exactly one permutation of zero items: the empty one.
+func Permutations(items []int) [][]int {
+	if len(items) == 0 {
+		return [][]int{{}} // Fixed: One empty permutation
+	}
+
+	var result [][]int
+	for i, first := range items {
+		// Fixed: Build rest in a fresh slice so items is never overwritten
+		rest := make([]int, 0, len(items)-1)
+		rest = append(rest, items[:i]...)
+		rest = append(rest, items[i+1:]...)
+		for _, perm := range Permutations(rest) {
+			result = append(result, append([]int{first}, perm...))
+		}
+	}
+	return result
+}
+
+// FloodFill replaces the connected region of cells that share the value at
+// (row, col) with fill, moving up, down, left, and right. It returns how many
+// cells were changed. Coordinates outside the grid change nothing.
+func FloodFill(grid [][]rune, row, col int, fill rune) int {
+	if row < 0 || row >= len(grid) || col < 0 || col >= len(grid[row]) { // Fixed: >= for zero-based indexes
+		return 0
+	}
+	target := grid[row][col]
+	if target == fill {
+		return 0 // Without this, the fill would recurse forever
+	}
+
+	var fillFrom func(r, c int) int
+	fillFrom = func(r, c int) int {
+		if r < 0 || r >= len(grid) || c < 0 || c >= len(grid[r]) || grid[r][c] != target {
+			return 0
+		}
+		grid[r][c] = fill
+		// Fixed: All four directions
+		return 1 + fillFrom(r-1, c) + fillFrom(r+1, c) + fillFrom(r, c-1) + fillFrom(r, c+1)
+	}
+	return fillFrom(row, col)
+}
+
+// TotalSizeIterative computes the same result as TotalSize using an explicit
+// stack instead of the call stack, so arbitrarily deep trees are fine.
+func TotalSizeIterative(root *FileNode) int64 {
+	var total int64
+	stack := []*FileNode{root}
+	for len(stack) > 0 { // Fixed: Process until the stack is empty
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n == nil {
+			continue
+		}
+		total += n.Size
+		stack = append(stack, n.Children...)
+	}
+	return total
+}
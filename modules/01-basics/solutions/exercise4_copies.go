@@ -0,0 +1,93 @@
+package solutions
+
+// SOLUTION: Copies where they are wanted, and pointers or shared values
+// where a change must be seen: index into slices of structs, copy slices
+// and maps explicitly, and give methods that modify an array a pointer
+// receiver.
+
+// Player is one member of a team.
+type Player struct {
+	Name  string
+	Score int
+	Tags  []string
+}
+
+// Team is a named list of players.
+type Team struct {
+	Name    string
+	Players []Player
+}
+
+// AddBonus adds bonus to the score of every player in players.
+func AddBonus(players []Player, bonus int) {
+	for i := range players {
+		players[i].Score += bonus // Fixed: The element itself, not range's copy of it
+	}
+}
+
+// Find returns the team's player called name, so that the caller can
+// change them, or nil if there is none.
+func (t *Team) Find(name string) *Player {
+	for i := range t.Players {
+		if t.Players[i].Name == name {
+			return &t.Players[i] // Fixed: A pointer into the slice, not to a copy
+		}
+	}
+	return nil
+}
+
+// Clone returns a copy of p that shares nothing with it: changing the
+// copy's tags leaves p's alone.
+func (p Player) Clone() Player {
+	// p is already a copy, but its Tags still share a backing array
+	p.Tags = append([]string(nil), p.Tags...) // Fixed: Copy the slice too
+	return p
+}
+
+// Extend returns s with v appended, without changing what any other slice
+// of s's backing array holds, so two calls with the same s do not
+// overwrite each other.
+func Extend(s []int, v ...int) []int {
+	// Fixed: The full slice expression s[low:high:max] caps the capacity at
+	// len(s), so append has no room left and copies to a new array
+	return append(s[:len(s):len(s)], v...)
+}
+
+// Snapshot returns a copy of stock, which later changes to stock do not
+// affect.
+func Snapshot(stock map[string]int) map[string]int {
+	// Fixed: Assigning a map copies a reference to the same map; copy the
+	// entries. maps.Clone does the same since Go 1.21.
+	snap := make(map[string]int, len(stock))
+	for item, count := range stock {
+		snap[item] = count
+	}
+	return snap
+}
+
+// Grid is a noughts and crosses board. An array is a value: assigning a
+// Grid, passing it, or appending it copies all nine cells.
+type Grid [3][3]byte
+
+// Move is a mark placed on a Grid.
+type Move struct {
+	Row, Col int
+	Mark     byte
+}
+
+// Set places mark at row, col.
+func (g *Grid) Set(row, col int, mark byte) { // Fixed: A pointer receiver, to change the caller's Grid
+	g[row][col] = mark
+}
+
+// History returns the grid after each of moves, starting from an empty
+// one. Each entry is a copy, so later moves do not change it.
+func History(moves []Move) []Grid {
+	var g Grid
+	history := make([]Grid, 0, len(moves))
+	for _, m := range moves {
+		g.Set(m.Row, m.Col, m.Mark)
+		history = append(history, g)
+	}
+	return history
+}
@@ -0,0 +1,115 @@
+package solutions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleTeam() *Team {
+	return &Team{Name: "Gophers", Players: []Player{
+		{Name: "Ada", Score: 10, Tags: []string{"captain"}},
+		{Name: "Bo", Score: 7},
+		{Name: "Cy", Score: 12, Tags: []string{"rookie", "keeper"}},
+	}}
+}
+
+func scores(players []Player) []int {
+	var s []int
+	for _, p := range players {
+		s = append(s, p.Score)
+	}
+	return s
+}
+
+func TestAddBonus(t *testing.T) {
+	team := sampleTeam()
+	AddBonus(team.Players, 5)
+	assert.Equal(t, []int{15, 12, 17}, scores(team.Players))
+
+	AddBonus(team.Players[1:], -2)
+	assert.Equal(t, []int{15, 10, 15}, scores(team.Players), "a subslice shares the team's players")
+}
+
+func TestTeamFind(t *testing.T) {
+	team := sampleTeam()
+	bo := team.Find("Bo")
+	require.NotNil(t, bo)
+	assert.Equal(t, "Bo", bo.Name)
+
+	bo.Score = 99
+	assert.Equal(t, 99, team.Players[1].Score, "Find must return the team's player, not a copy")
+
+	assert.Equal(t, "Cy", team.Find("Cy").Name, "each call finds its own player")
+	assert.Nil(t, team.Find("Dee"))
+}
+
+func TestPlayerClone(t *testing.T) {
+	team := sampleTeam()
+	cy := team.Players[2].Clone()
+	assert.Equal(t, team.Players[2], cy)
+
+	cy.Score = 0
+	cy.Tags[0] = "veteran"
+	cy.Tags = append(cy.Tags, "striker")
+	assert.Equal(t, Player{Name: "Cy", Score: 12, Tags: []string{"rookie", "keeper"}}, team.Players[2],
+		"changing the clone must leave the original alone")
+
+	bo := team.Players[1].Clone()
+	assert.Nil(t, bo.Tags)
+}
+
+func TestExtend(t *testing.T) {
+	base := make([]int, 3, 10)
+	copy(base, []int{1, 2, 3})
+
+	a := Extend(base, 4)
+	b := Extend(base, 5, 6)
+	assert.Equal(t, []int{1, 2, 3, 4}, a, "extending base again must not overwrite a")
+	assert.Equal(t, []int{1, 2, 3, 5, 6}, b)
+	assert.Equal(t, []int{1, 2, 3}, base)
+
+	a[0] = 100
+	assert.Equal(t, 1, base[0], "the result must not share base's array")
+
+	assert.Equal(t, []int{7}, Extend(nil, 7))
+}
+
+func TestSnapshot(t *testing.T) {
+	stock := map[string]int{"apples": 4, "pears": 2}
+	snap := Snapshot(stock)
+	assert.Equal(t, stock, snap)
+
+	stock["apples"] = 0
+	delete(stock, "pears")
+	stock["plums"] = 9
+	assert.Equal(t, map[string]int{"apples": 4, "pears": 2}, snap, "changes to stock must not reach the snapshot")
+
+	snap["figs"] = 1
+	assert.NotContains(t, stock, "figs")
+
+	empty := Snapshot(nil)
+	assert.NotPanics(t, func() { empty["kiwis"] = 1 }, "the snapshot of nil is an empty map")
+}
+
+func TestGridSet(t *testing.T) {
+	var g Grid
+	g.Set(1, 1, 'X')
+	g.Set(0, 2, 'O')
+	assert.Equal(t, Grid{{0, 0, 'O'}, {0, 'X', 0}, {0, 0, 0}}, g)
+
+	h := g // An array assignment copies every cell
+	h.Set(2, 0, 'X')
+	assert.Equal(t, byte(0), g[2][0], "h is a copy of g")
+	assert.Equal(t, byte('X'), h[2][0])
+}
+
+func TestHistory(t *testing.T) {
+	history := History([]Move{{1, 1, 'X'}, {0, 0, 'O'}, {2, 2, 'X'}})
+	require.Len(t, history, 3)
+	assert.Equal(t, Grid{{0, 0, 0}, {0, 'X', 0}, {0, 0, 0}}, history[0])
+	assert.Equal(t, Grid{{'O', 0, 0}, {0, 'X', 0}, {0, 0, 0}}, history[1])
+	assert.Equal(t, Grid{{'O', 0, 0}, {0, 'X', 0}, {0, 0, 'X'}}, history[2])
+	assert.Empty(t, History(nil))
+}
@@ -0,0 +1,134 @@
+package exercises
+
+// EXERCISE: Fix three concurrency-safe counters.
+// The same Counter interface is implemented with a mutex, an atomic, and a
+// goroutine that owns the count. Each one has a race: the code looks safe,
+// and often passes a quick test, but loses updates or returns stale values
+// under load. Run the tests with the race detector:
+//
+//	go test -race ./exercises/
+//
+// Then compare the implementations with the benchmarks and pick the
+// cheapest one that is correct for your access pattern:
+//
+//	go test -bench=Counter -cpu=1,4 ./exercises/
+//
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Counter is a concurrency-safe int64 counter.
+type Counter interface {
+	Inc()
+	Add(delta int64)
+	Value() int64
+}
+
+// Compile-time checks that every implementation satisfies Counter.
+var (
+	_ Counter = (*MutexCounter)(nil)
+	_ Counter = (*AtomicCounter)(nil)
+	_ Counter = (*ChannelCounter)(nil)
+)
+
+// MutexCounter guards its value with a sync.RWMutex: any number of readers
+// may hold the lock at once, but a writer needs it exclusively.
+// The zero value is ready to use.
+type MutexCounter struct {
+	mu sync.RWMutex
+	n  int64
+}
+
+// Inc adds one to the counter.
+func (c *MutexCounter) Inc() { c.Add(1) }
+
+// Add adds delta to the counter.
+// BUG: A read lock lets several writers in at once.
+func (c *MutexCounter) Add(delta int64) {
+	c.mu.RLock() // BUG: Use Lock/Unlock for writes
+	defer c.mu.RUnlock()
+	c.n += delta
+}
+
+// Value returns the current count.
+func (c *MutexCounter) Value() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.n
+}
+
+// AtomicCounter uses a single atomic integer and no locks at all.
+// The zero value is ready to use.
+type AtomicCounter struct {
+	n atomic.Int64
+}
+
+// Inc adds one to the counter.
+func (c *AtomicCounter) Inc() { c.Add(1) }
+
+// Add adds delta to the counter.
+// BUG: Load and Store are each atomic, but another goroutine can update the
+// counter between them, and that update is overwritten.
+func (c *AtomicCounter) Add(delta int64) {
+	c.n.Store(c.n.Load() + delta) // BUG: Use c.n.Add(delta)
+}
+
+// Value returns the current count.
+func (c *AtomicCounter) Value() int64 { return c.n.Load() }
+
+// ChannelCounter confines the count to a single goroutine and talks to it
+// over channels: "share memory by communicating". Create one with
+// NewChannelCounter and stop it with Close.
+type ChannelCounter struct {
+	deltas chan int64
+	reads  chan chan int64
+	done   chan struct{}
+	n      int64 // owned by the run goroutine
+}
+
+// NewChannelCounter starts the goroutine that owns the count.
+func NewChannelCounter() *ChannelCounter {
+	c := &ChannelCounter{
+		deltas: make(chan int64, 64),
+		reads:  make(chan chan int64),
+		done:   make(chan struct{}),
+	}
+	go c.run()
+	return c
+}
+
+func (c *ChannelCounter) run() {
+	for {
+		select {
+		case d := <-c.deltas:
+			c.n += d
+		case reply := <-c.reads:
+			// Apply increments that were queued before this read
+			for pending := len(c.deltas); pending > 0; pending-- {
+				c.n += <-c.deltas
+			}
+			reply <- c.n
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// Inc adds one to the counter.
+func (c *ChannelCounter) Inc() { c.Add(1) }
+
+// Add queues delta for the owning goroutine.
+func (c *ChannelCounter) Add(delta int64) { c.deltas <- delta }
+
+// Value asks the owning goroutine for the current count.
+// BUG: Reads c.n directly from the caller's goroutine. That is a data race,
+// and it misses increments still waiting in the deltas buffer.
+func (c *ChannelCounter) Value() int64 {
+	return c.n // BUG: Send a reply channel on c.reads and wait for the answer
+}
+
+// Close stops the owning goroutine. The counter must not be used afterwards.
+func (c *ChannelCounter) Close() { close(c.done) }
@@ -0,0 +1,119 @@
+package solutions
+
+// SOLUTION: Fixed concurrency-safe counters.
+// Each fix is marked with a // Fixed: comment.
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Counter is a concurrency-safe int64 counter.
+type Counter interface {
+	Inc()
+	Add(delta int64)
+	Value() int64
+}
+
+// Compile-time checks that every implementation satisfies Counter.
+var (
+	_ Counter = (*MutexCounter)(nil)
+	_ Counter = (*AtomicCounter)(nil)
+	_ Counter = (*ChannelCounter)(nil)
+)
+
+// MutexCounter guards its value with a sync.RWMutex: any number of readers
+// may hold the lock at once, but a writer needs it exclusively.
+// The zero value is ready to use.
+type MutexCounter struct {
+	mu sync.RWMutex
+	n  int64
+}
+
+// Inc adds one to the counter.
+func (c *MutexCounter) Inc() { c.Add(1) }
+
+// Add adds delta to the counter.
+func (c *MutexCounter) Add(delta int64) {
+	c.mu.Lock() // Fixed: writers need the exclusive lock
+	defer c.mu.Unlock()
+	c.n += delta
+}
+
+// Value returns the current count.
+func (c *MutexCounter) Value() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.n
+}
+
+// AtomicCounter uses a single atomic integer and no locks at all.
+// The zero value is ready to use.
+type AtomicCounter struct {
+	n atomic.Int64
+}
+
+// Inc adds one to the counter.
+func (c *AtomicCounter) Inc() { c.Add(1) }
+
+// Add adds delta to the counter.
+func (c *AtomicCounter) Add(delta int64) {
+	c.n.Add(delta) // Fixed: one atomic read-modify-write
+}
+
+// Value returns the current count.
+func (c *AtomicCounter) Value() int64 { return c.n.Load() }
+
+// ChannelCounter confines the count to a single goroutine and talks to it
+// over channels: "share memory by communicating". Create one with
+// NewChannelCounter and stop it with Close.
+type ChannelCounter struct {
+	deltas chan int64
+	reads  chan chan int64
+	done   chan struct{}
+	n      int64 // owned by the run goroutine
+}
+
+// NewChannelCounter starts the goroutine that owns the count.
+func NewChannelCounter() *ChannelCounter {
+	c := &ChannelCounter{
+		deltas: make(chan int64, 64),
+		reads:  make(chan chan int64),
+		done:   make(chan struct{}),
+	}
+	go c.run()
+	return c
+}
+
+func (c *ChannelCounter) run() {
+	for {
+		select {
+		case d := <-c.deltas:
+			c.n += d
+		case reply := <-c.reads:
+			// Apply increments that were queued before this read
+			for pending := len(c.deltas); pending > 0; pending-- {
+				c.n += <-c.deltas
+			}
+			reply <- c.n
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// Inc adds one to the counter.
+func (c *ChannelCounter) Inc() { c.Add(1) }
+
+// Add queues delta for the owning goroutine.
+func (c *ChannelCounter) Add(delta int64) { c.deltas <- delta }
+
+// Value asks the owning goroutine for the current count.
+func (c *ChannelCounter) Value() int64 {
+	reply := make(chan int64)
+	c.reads <- reply // Fixed: only the owning goroutine touches c.n
+	return <-reply
+}
+
+// Close stops the owning goroutine. The counter must not be used afterwards.
+func (c *ChannelCounter) Close() { close(c.done) }
@@ -0,0 +1,120 @@
+package solutions
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// counterImpls builds a fresh instance of every Counter implementation.
+// The cleanup function stops any background goroutine.
+var counterImpls = []struct {
+	name string
+	new  func() (Counter, func())
+}{
+	{"mutex", func() (Counter, func()) { return &MutexCounter{}, func() {} }},
+	{"atomic", func() (Counter, func()) { return &AtomicCounter{}, func() {} }},
+	{"channel", func() (Counter, func()) {
+		c := NewChannelCounter()
+		return c, c.Close
+	}},
+}
+
+func TestCounterSequential(t *testing.T) {
+	for _, impl := range counterImpls {
+		t.Run(impl.name, func(t *testing.T) {
+			c, cleanup := impl.new()
+			defer cleanup()
+
+			assert.Equal(t, int64(0), c.Value())
+			for i := 0; i < 100; i++ {
+				c.Inc()
+			}
+			assert.Equal(t, int64(100), c.Value())
+			c.Add(-30)
+			assert.Equal(t, int64(70), c.Value())
+		})
+	}
+}
+
+func TestCounterConcurrent(t *testing.T) {
+	// Several Ps let goroutines run truly in parallel, even on one CPU the
+	// OS can preempt a thread between a load and a store.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+
+	const goroutines, perGoroutine = 8, 50_000
+	for _, impl := range counterImpls {
+		t.Run(impl.name, func(t *testing.T) {
+			c, cleanup := impl.new()
+			defer cleanup()
+
+			var wg sync.WaitGroup
+			for g := 0; g < goroutines; g++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < perGoroutine; i++ {
+						c.Inc()
+						if i%1000 == 0 {
+							c.Value() // readers mixed in with writers
+						}
+					}
+				}()
+			}
+			wg.Wait()
+			assert.Equal(t, int64(goroutines*perGoroutine), c.Value(), "updates were lost")
+		})
+	}
+}
+
+func TestMutexCounterWritersWaitForReaders(t *testing.T) {
+	c := &MutexCounter{}
+	c.mu.RLock() // simulate a reader in the middle of Value
+
+	done := make(chan struct{})
+	go func() {
+		c.Add(1)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Error("Add finished while a reader held the lock - writers need exclusive access")
+	case <-time.After(50 * time.Millisecond):
+	}
+	c.mu.RUnlock()
+	<-done
+	assert.Equal(t, int64(1), c.Value())
+}
+
+func BenchmarkCounter(b *testing.B) {
+	for _, impl := range counterImpls {
+		b.Run(impl.name+"/inc", func(b *testing.B) {
+			c, cleanup := impl.new()
+			defer cleanup()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					c.Inc()
+				}
+			})
+		})
+		b.Run(impl.name+"/read-heavy", func(b *testing.B) {
+			c, cleanup := impl.new()
+			defer cleanup()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if i%10 == 0 {
+						c.Inc()
+					} else {
+						c.Value()
+					}
+					i++
+				}
+			})
+		})
+	}
+}
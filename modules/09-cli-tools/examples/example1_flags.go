@@ -0,0 +1,139 @@
+// Package examples demonstrates command-line tools built with the
+// standard flag package.
+//
+// This file shows:
+// - A FlagSet per command instead of the global flag.CommandLine
+// - Typed flags: strings, ints, bools, and durations, with defaults
+// - Positional arguments with fs.Args after the flags
+// - Usage text that names the arguments, not just the flags
+// - Turning parse errors and -h into exit codes without os.Exit
+package examples
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// GrepConfig is what the grep-like example command was asked to do.
+type GrepConfig struct {
+	Pattern    string
+	Files      []string
+	IgnoreCase bool
+	MaxCount   int // 0 means no limit
+	Timeout    time.Duration
+	Color      string
+}
+
+// ParseGrepFlags parses the arguments of "grep [flags] <pattern>
+// [file...]". Errors and usage go to stderr; -h returns flag.ErrHelp.
+func ParseGrepFlags(args []string, stderr io.Writer) (GrepConfig, error) {
+	var cfg GrepConfig
+	// ContinueOnError returns errors instead of calling os.Exit, which is
+	// what makes a command testable. The global flag.Parse uses ExitOnError.
+	fs := flag.NewFlagSet("grep", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.BoolVar(&cfg.IgnoreCase, "i", false, "ignore case")
+	fs.IntVar(&cfg.MaxCount, "m", 0, "stop after `n` matching lines (0 for no limit)")
+	fs.DurationVar(&cfg.Timeout, "timeout", 5*time.Second, "give up after this long")
+	fs.StringVar(&cfg.Color, "color", "auto", "highlight matches: `when` is always, never, or auto")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: grep [flags] <pattern> [file...]")
+		fmt.Fprintln(fs.Output(), "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return GrepConfig{}, err // already reported, with the usage
+	}
+	// Everything after the flags, or after "--", is positional.
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return GrepConfig{}, errors.New("missing pattern")
+	}
+	cfg.Pattern = fs.Arg(0)
+	cfg.Files = fs.Args()[1:]
+	if cfg.MaxCount < 0 {
+		return GrepConfig{}, fmt.Errorf("-m %d: must not be negative", cfg.MaxCount)
+	}
+	switch cfg.Color {
+	case "always", "never", "auto":
+	default:
+		return GrepConfig{}, fmt.Errorf("-color %q: want always, never, or auto", cfg.Color)
+	}
+	return cfg, nil
+}
+
+// Exit codes, following the usual Unix conventions.
+const (
+	ExitOK    = 0
+	ExitError = 1 // the command ran and failed
+	ExitUsage = 2 // the command line was wrong
+)
+
+// RunGrep is the command's main: it returns an exit code instead of
+// calling os.Exit, so tests can run it. main would be
+//
+//	os.Exit(RunGrep(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+func RunGrep(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	cfg, err := ParseGrepFlags(args, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return ExitOK // asking for help is not an error
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "grep:", err)
+		return ExitUsage
+	}
+	if len(cfg.Files) > 0 {
+		fmt.Fprintln(stderr, "grep: this example only reads standard input")
+		return ExitError
+	}
+
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, "grep:", err)
+		return ExitError
+	}
+	pattern := cfg.Pattern
+	if cfg.IgnoreCase {
+		pattern = strings.ToLower(pattern)
+	}
+	matches := 0
+	for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+		candidate := line
+		if cfg.IgnoreCase {
+			candidate = strings.ToLower(line)
+		}
+		if !strings.Contains(candidate, pattern) {
+			continue
+		}
+		fmt.Fprintln(stdout, line)
+		matches++
+		if matches == cfg.MaxCount {
+			break
+		}
+	}
+	if matches == 0 {
+		return ExitError // like grep: no match is a failure scripts can test
+	}
+	return ExitOK
+}
+
+// DemonstrateFlags runs the grep example with a few command lines.
+func DemonstrateFlags() {
+	input := "Go is fun\ngo vet\nRust\nGOPATH\n"
+	for _, args := range [][]string{
+		{"go"},
+		{"-i", "go"},
+		{"-i", "-m", "2", "go"},
+		{"-color", "sometimes", "go"},
+		{"go", "-i"}, // -i comes after the pattern, so it is a file name
+	} {
+		var out, errs strings.Builder
+		code := RunGrep(args, strings.NewReader(input), &out, &errs)
+		fmt.Printf("grep %s -> exit %d\n%s%s", strings.Join(args, " "), code, out.String(), errs.String())
+	}
+}
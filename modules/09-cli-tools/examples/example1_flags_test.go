@@ -0,0 +1,70 @@
+package examples
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateFlags(t *testing.T) {
+	DemonstrateFlags()
+}
+
+func TestParseGrepFlags(t *testing.T) {
+	cfg, err := ParseGrepFlags([]string{"-i", "-m", "3", "-timeout", "1m", "needle", "a.txt", "b.txt"}, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, GrepConfig{
+		Pattern: "needle", Files: []string{"a.txt", "b.txt"},
+		IgnoreCase: true, MaxCount: 3, Timeout: time.Minute, Color: "auto",
+	}, cfg)
+
+	cfg, err = ParseGrepFlags([]string{"--", "-i"}, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, "-i", cfg.Pattern, "-- ends the flags")
+	assert.False(t, cfg.IgnoreCase)
+	assert.Equal(t, 5*time.Second, cfg.Timeout, "default")
+}
+
+func TestParseGrepFlagsErrors(t *testing.T) {
+	var stderr strings.Builder
+	_, err := ParseGrepFlags([]string{"-h"}, &stderr)
+	assert.True(t, errors.Is(err, flag.ErrHelp))
+	assert.Contains(t, stderr.String(), "Usage: grep [flags] <pattern> [file...]")
+	assert.Contains(t, stderr.String(), "-m n")
+
+	for _, args := range [][]string{{}, {"-m", "x", "go"}, {"-m", "-1", "go"}, {"-color", "pink", "go"}, {"-nope", "go"}} {
+		_, err := ParseGrepFlags(args, io.Discard)
+		assert.Error(t, err, "%q", args)
+	}
+}
+
+func TestRunGrep(t *testing.T) {
+	run := func(args ...string) (int, string, string) {
+		var out, errs strings.Builder
+		code := RunGrep(args, strings.NewReader("Go\ngo\nRust\n"), &out, &errs)
+		return code, out.String(), errs.String()
+	}
+	code, out, _ := run("-i", "go")
+	assert.Equal(t, ExitOK, code)
+	assert.Equal(t, "Go\ngo\n", out)
+
+	code, out, _ = run("-i", "-m", "1", "go")
+	assert.Equal(t, ExitOK, code)
+	assert.Equal(t, "Go\n", out)
+
+	code, _, _ = run("python")
+	assert.Equal(t, ExitError, code, "no match")
+
+	code, _, errs := run("-bogus", "go")
+	assert.Equal(t, ExitUsage, code)
+	assert.Contains(t, errs, "flag provided but not defined: -bogus")
+
+	code, _, _ = run("-h")
+	assert.Equal(t, ExitOK, code)
+}
@@ -0,0 +1,123 @@
+package examples
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FlagValueExamples demonstrates flags of your own types.
+//
+// Anything with String and Set methods is a flag.Value and can be
+// registered with fs.Var; Set sees the text of each occurrence, so a type
+// can validate it (the error names the flag) or collect repeated flags.
+// flag.Func does the same for a one-off function, and flag.TextVar takes
+// any type with UnmarshalText.
+
+// StringList is a repeatable flag: -tag a -tag b gives [a b]. Set needs
+// a pointer receiver, because it must change the slice the flag set holds.
+type StringList []string
+
+// String shows the current value, as in the defaults of PrintDefaults.
+func (l *StringList) String() string { return strings.Join(*l, ",") }
+
+// Set is called once per occurrence of the flag.
+func (l *StringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+// Level is a log level that can be parsed from text. Because it implements
+// encoding.TextUnmarshaler and TextMarshaler, flag.TextVar accepts it
+// without a flag.Value of its own, and so do encoding/json and others.
+type Level int
+
+// The levels, from most to least verbose.
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l Level) MarshalText() ([]byte, error) {
+	if l < Debug || l > Error {
+		return nil, fmt.Errorf("level %d out of range", int(l))
+	}
+	return []byte(levelNames[l]), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler; the error ends up in
+// the flag package's "invalid value" message.
+func (l *Level) UnmarshalText(text []byte) error {
+	for i, name := range levelNames {
+		if strings.EqualFold(string(text), name) {
+			*l = Level(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("want one of %s", strings.Join(levelNames, ", "))
+}
+
+// ServerConfig collects the flags of the server example.
+type ServerConfig struct {
+	Tags  StringList
+	Level Level
+	Port  int
+}
+
+// ParseServerFlags parses -tag (repeatable), -level, and -port.
+func ParseServerFlags(args []string, stderr io.Writer) (ServerConfig, error) {
+	cfg := ServerConfig{Port: 8080}
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Var(&cfg.Tags, "tag", "add a tag (repeatable)")
+	fs.TextVar(&cfg.Level, "level", Info, "log level: debug, info, warn, or error")
+	// flag.Func is a flag.Value made from a function: handy when a value
+	// only needs parsing once, like a port with a range check.
+	fs.Func("port", "listen on this port (default 8080)", func(s string) error {
+		var port int
+		if _, err := fmt.Sscan(s, &port); err != nil {
+			return errors.New("not a number")
+		}
+		if port < 1 || port > 65535 {
+			return errors.New("must be between 1 and 65535")
+		}
+		cfg.Port = port
+		return nil
+	})
+	if err := fs.Parse(args); err != nil {
+		return ServerConfig{}, err
+	}
+	if fs.NArg() > 0 {
+		return ServerConfig{}, fmt.Errorf("unexpected arguments: %q", fs.Args())
+	}
+	return cfg, nil
+}
+
+// DemonstrateValues parses a few server command lines.
+func DemonstrateValues() {
+	for _, args := range [][]string{
+		{},
+		{"-tag", "blue", "-tag", "canary", "-level", "debug"},
+		{"-port", "70000"},
+		{"-level", "loud"},
+	} {
+		var errs strings.Builder
+		cfg, err := ParseServerFlags(args, &errs)
+		if err != nil {
+			// The flag package already wrote the error and the usage to
+			// errs; only the first line is shown here.
+			first, _, _ := strings.Cut(errs.String(), "\n")
+			fmt.Printf("%q: %s\n", args, first)
+			continue
+		}
+		level, _ := cfg.Level.MarshalText()
+		fmt.Printf("%q: tags=%v level=%s port=%d\n", args, []string(cfg.Tags), level, cfg.Port)
+	}
+}
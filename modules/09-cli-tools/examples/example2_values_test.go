@@ -0,0 +1,52 @@
+package examples
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateValues(t *testing.T) {
+	DemonstrateValues()
+}
+
+func TestParseServerFlags(t *testing.T) {
+	cfg, err := ParseServerFlags(nil, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, ServerConfig{Level: Info, Port: 8080}, cfg)
+
+	cfg, err = ParseServerFlags([]string{"-tag", "a", "-tag", "b", "-level", "WARN", "-port", "9000"}, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, StringList{"a", "b"}, cfg.Tags)
+	assert.Equal(t, Warn, cfg.Level)
+	assert.Equal(t, 9000, cfg.Port)
+}
+
+func TestParseServerFlagsErrors(t *testing.T) {
+	for args, want := range map[string]string{
+		"-level loud":  `invalid value "loud" for flag -level: want one of debug, info, warn, error`,
+		"-port 0":      `invalid value "0" for flag -port: must be between 1 and 65535`,
+		"-port eighty": `invalid value "eighty" for flag -port: not a number`,
+		"extra":        `unexpected arguments: ["extra"]`,
+	} {
+		var stderr strings.Builder
+		_, err := ParseServerFlags(strings.Fields(args), &stderr)
+		require.Error(t, err, args)
+		assert.Contains(t, err.Error()+stderr.String(), want, args)
+	}
+}
+
+func TestLevelText(t *testing.T) {
+	for l := Debug; l <= Error; l++ {
+		text, err := l.MarshalText()
+		require.NoError(t, err)
+		var back Level
+		require.NoError(t, back.UnmarshalText(text))
+		assert.Equal(t, l, back)
+	}
+	_, err := Level(7).MarshalText()
+	assert.Error(t, err)
+}
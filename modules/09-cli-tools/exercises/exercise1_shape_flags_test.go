@@ -0,0 +1,108 @@
+package exercises
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArgsDefaults(t *testing.T) {
+	o, err := ParseArgs([]string{"circle", "2"}, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, Options{Shape: "circle", Sizes: []float64{2}, Precision: 2, Unit: "cm"}, o)
+}
+
+func TestParseArgsFlagsFirst(t *testing.T) {
+	o, err := ParseArgs([]string{"-precision", "3", "-unit", "m", "-perimeter", "rectangle", "3", "4.5"}, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, Options{Shape: "rectangle", Sizes: []float64{3, 4.5}, Precision: 3, Unit: "m", Perimeter: true}, o)
+}
+
+func TestParseArgsFlagsAfterArguments(t *testing.T) {
+	o, err := ParseArgs([]string{"rectangle", "3", "-unit", "in", "4", "-perimeter"}, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, Options{Shape: "rectangle", Sizes: []float64{3, 4}, Precision: 2, Unit: "in", Perimeter: true}, o)
+
+	o, err = ParseArgs([]string{"-precision", "0", "circle", "1", "-perimeter"}, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, 0, o.Precision, "an explicit 0 is not the default")
+	assert.True(t, o.Perimeter)
+}
+
+func TestParseArgsDoubleDash(t *testing.T) {
+	o, err := ParseArgs([]string{"square", "--", "3"}, io.Discard)
+	require.NoError(t, err, `"--" only ends the flags`)
+	assert.Equal(t, []float64{3}, o.Sizes)
+
+	_, err = ParseArgs([]string{"square", "--", "-perimeter"}, io.Discard)
+	assert.ErrorContains(t, err, `size "-perimeter"`, `after "--", flags are arguments`)
+}
+
+func TestParseArgsErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"-precision"},
+		{"-unknown", "circle", "1"},
+		{"circle", "-unknown", "1"},
+		{"circle", "x"},
+		{"circle", "0"},
+		{"-precision", "-1", "circle", "1"},
+	} {
+		_, err := ParseArgs(args, io.Discard)
+		assert.Error(t, err, "%q", args)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	var stdout, stderr strings.Builder
+	code := Run([]string{"-h"}, &stdout, &stderr)
+	assert.Equal(t, 0, code, "-h is not an error")
+	usage := stderr.String()
+	assert.True(t, strings.HasPrefix(usage, Usage+"\n"), "the usage starts with the Usage line, got:\n%s", usage)
+	assert.Contains(t, usage, "-precision n")
+	assert.Contains(t, usage, "(default 2)")
+	assert.Contains(t, usage, `(default "cm")`)
+	assert.NotContains(t, usage, "shapes: ", "asking for help is not an error")
+
+	_, err := ParseArgs([]string{"-help"}, io.Discard)
+	assert.True(t, errors.Is(err, flag.ErrHelp))
+}
+
+func TestRun(t *testing.T) {
+	r := seed.Rand(t, 9)
+	radius := seed.Between(r, 1, 20)
+	var stdout, stderr strings.Builder
+	code := Run([]string{"circle", fmt.Sprint(radius), "-perimeter"}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+	area, perimeter := math.Pi*float64(radius*radius), 2*math.Pi*float64(radius)
+	assert.Equal(t, fmt.Sprintf("area: %.2f cm²\nperimeter: %.2f cm\n", area, perimeter), stdout.String())
+
+	w, h := seed.Between(r, 1, 50), seed.Between(r, 1, 50)
+	stdout.Reset()
+	code = Run([]string{"rectangle", "-unit", "m", fmt.Sprint(w), fmt.Sprint(h), "-precision", "1"}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+	assert.Equal(t, fmt.Sprintf("area: %.1f m²\n", float64(w*h)), stdout.String())
+}
+
+func TestRunErrors(t *testing.T) {
+	for args, want := range map[string]string{
+		"hexagon 2":   `unknown shape "hexagon"`,
+		"rectangle 2": "rectangle takes 2 sizes, got 1",
+		"circle 1 2":  "circle takes 1 sizes, got 2",
+		"":            "missing shape",
+	} {
+		var stdout, stderr strings.Builder
+		code := Run(strings.Fields(args), &stdout, &stderr)
+		assert.Equal(t, 2, code, args)
+		assert.Contains(t, stderr.String(), want, args)
+		assert.Empty(t, stdout.String(), args)
+	}
+}
@@ -0,0 +1,119 @@
+package exercises
+
+// EXERCISE: Fix the custom flag types of "shapes draw".
+// Any type with String and Set methods is a flag.Value, and fs.Var turns
+// it into a flag: Set gets the text of every occurrence, so it can check
+// the value or collect repeated flags. The draw command uses three:
+//
+//	shapes draw -unit mm -size 3,4 -size 5 -label color=red -label owner=you
+//
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Unit is a length unit: mm, cm, m, or in.
+type Unit string
+
+// centimeters is the length of each unit in centimeters.
+var centimeters = map[Unit]float64{"mm": 0.1, "cm": 1, "m": 100, "in": 2.54}
+
+// String implements flag.Value.
+func (u *Unit) String() string { return string(*u) }
+
+// Set implements flag.Value.
+// BUG: Any text is accepted, so -unit furlong goes through. Return an
+// error unless the unit is in centimeters; the flag package adds the
+// flag's name to it.
+func (u *Unit) Set(s string) error {
+	*u = Unit(s)
+	return nil
+}
+
+// ToCentimeters converts a length in u to centimeters.
+func (u Unit) ToCentimeters(length float64) float64 {
+	return length * centimeters[u]
+}
+
+// Sizes collects the -size flags: "-size 3,4 -size 5" gives [3 4 5].
+type Sizes []float64
+
+// String implements flag.Value.
+func (s Sizes) String() string {
+	parts := make([]string, len(s))
+	for i, size := range s {
+		parts[i] = strconv.FormatFloat(size, 'g', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Set implements flag.Value.
+// BUG: s is a copy of the slice the flag belongs to, so the appended sizes
+// are lost when Set returns. Set must change the caller's Sizes.
+func (s Sizes) Set(v string) error {
+	for _, part := range strings.Split(v, ",") {
+		size, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", part)
+		}
+		if size <= 0 {
+			return fmt.Errorf("%q is not positive", part)
+		}
+		s = append(s, size)
+	}
+	return nil
+}
+
+// Labels collects the -label flags: "-label color=red" sets color to red.
+type Labels map[string]string
+
+// String implements flag.Value; the labels are sorted by key.
+func (l *Labels) String() string {
+	keys := make([]string, 0, len(*l))
+	for k := range *l {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		keys[i] = k + "=" + (*l)[k]
+	}
+	return strings.Join(keys, ",")
+}
+
+// Set implements flag.Value.
+// BUG: A Labels nobody has set yet is a nil map, and writing to a nil map
+// panics.
+func (l *Labels) Set(v string) error {
+	key, value, ok := strings.Cut(v, "=")
+	if !ok || key == "" {
+		return errors.New("want key=value")
+	}
+	(*l)[key] = value
+	return nil
+}
+
+// DrawConfig holds the flags of "shapes draw".
+type DrawConfig struct {
+	Unit   Unit
+	Sizes  Sizes
+	Labels Labels
+}
+
+// DrawFlags defines the draw flags on fs and returns the config they fill
+// in when fs is parsed. The unit is cm unless -unit says otherwise.
+func DrawFlags(fs *flag.FlagSet) *DrawConfig {
+	cfg := &DrawConfig{}
+	fs.Var(&cfg.Unit, "unit", "length unit: mm, cm, m, or in")
+	fs.Var(&cfg.Sizes, "size", "add comma-separated sizes (repeatable)")
+	fs.Var(&cfg.Labels, "label", "add a `key=value` label (repeatable)")
+	// BUG: fs.Var records a flag's default, for the usage message, when the
+	// flag is defined. Set the default unit before defining -unit.
+	cfg.Unit = "cm"
+	return cfg
+}
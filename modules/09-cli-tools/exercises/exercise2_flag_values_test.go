@@ -0,0 +1,82 @@
+package exercises
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseDraw parses args with the draw flags.
+func parseDraw(args ...string) (*DrawConfig, error) {
+	fs := flag.NewFlagSet("draw", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	cfg := DrawFlags(fs)
+	return cfg, fs.Parse(args)
+}
+
+func TestUnitFlag(t *testing.T) {
+	cfg, err := parseDraw()
+	require.NoError(t, err)
+	assert.Equal(t, Unit("cm"), cfg.Unit, "default")
+
+	for _, u := range []string{"mm", "cm", "m", "in"} {
+		cfg, err := parseDraw("-unit", u)
+		require.NoError(t, err, u)
+		assert.Equal(t, Unit(u), cfg.Unit)
+	}
+
+	_, err = parseDraw("-unit", "furlong")
+	assert.ErrorContains(t, err, `invalid value "furlong" for flag -unit`)
+}
+
+func TestUnitToCentimeters(t *testing.T) {
+	assert.InDelta(t, 2.54, Unit("in").ToCentimeters(1), 1e-9)
+	assert.InDelta(t, 150.0, Unit("m").ToCentimeters(1.5), 1e-9)
+	assert.InDelta(t, 0.5, Unit("mm").ToCentimeters(5), 1e-9)
+}
+
+func TestSizesFlag(t *testing.T) {
+	r := seed.Rand(t, 3)
+	a, b, c := seed.Between(r, 1, 99), seed.Between(r, 1, 99), seed.Between(r, 1, 99)
+	cfg, err := parseDraw("-size", fmt.Sprintf("%d, %d", a, b), "-size", fmt.Sprint(c))
+	require.NoError(t, err)
+	assert.Equal(t, Sizes{float64(a), float64(b), float64(c)}, cfg.Sizes, "every -size adds to the list")
+	assert.Equal(t, fmt.Sprintf("%d,%d,%d", a, b, c), cfg.Sizes.String())
+
+	_, err = parseDraw("-size", "3,x")
+	assert.ErrorContains(t, err, `invalid value "3,x" for flag -size`)
+	_, err = parseDraw("-size", "-2")
+	assert.ErrorContains(t, err, "not positive")
+}
+
+func TestLabelsFlag(t *testing.T) {
+	cfg, err := parseDraw("-label", "color=red", "-label", "owner=you", "-label", "color=blue")
+	require.NoError(t, err)
+	assert.Equal(t, Labels{"color": "blue", "owner": "you"}, cfg.Labels, "a later label wins")
+	assert.Equal(t, "color=blue,owner=you", cfg.Labels.String())
+
+	cfg, err = parseDraw("-label", "empty=")
+	require.NoError(t, err)
+	assert.Equal(t, Labels{"empty": ""}, cfg.Labels)
+
+	_, err = parseDraw("-label", "novalue")
+	assert.ErrorContains(t, err, "want key=value")
+	_, err = parseDraw("-label", "=x")
+	assert.Error(t, err)
+}
+
+func TestDrawUsageShowsDefaults(t *testing.T) {
+	var usage strings.Builder
+	fs := flag.NewFlagSet("draw", flag.ContinueOnError)
+	fs.SetOutput(&usage)
+	DrawFlags(fs)
+	fs.PrintDefaults()
+	assert.Contains(t, usage.String(), "(default cm)", "got:\n%s", usage.String())
+	assert.Contains(t, usage.String(), "-label key=value")
+}
@@ -0,0 +1,131 @@
+package solutions
+
+// SOLUTION: The fixed command line of the shapes tool.
+// shapes prints the area of a shape described on its command line:
+//
+//	shapes [-precision n] [-unit u] [-perimeter] <circle|square|rectangle> <size>...
+//
+// Flags may come before or after the shape and sizes, as in
+// "shapes circle 2 -perimeter", like most tools people are used to.
+// Each fix is marked with a // Fixed: comment.
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// Usage is the first line of the shapes usage message.
+const Usage = "Usage: shapes [flags] <circle|square|rectangle> <size>..."
+
+// Options is a parsed shapes command line.
+type Options struct {
+	Shape     string
+	Sizes     []float64
+	Precision int    // digits after the decimal point
+	Unit      string // length unit of the sizes
+	Perimeter bool   // also print the perimeter
+}
+
+// ParseArgs parses the arguments after the program name. Errors and the
+// usage message go to stderr; -h returns flag.ErrHelp.
+func ParseArgs(args []string, stderr io.Writer) (Options, error) {
+	var o Options
+	fs := flag.NewFlagSet("shapes", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.IntVar(&o.Precision, "precision", 2, "print `n` digits after the decimal point") // Fixed: default 2
+	fs.StringVar(&o.Unit, "unit", "cm", "length `unit` of the sizes")                   // Fixed: default cm
+	fs.BoolVar(&o.Perimeter, "perimeter", false, "also print the perimeter")
+	// Fixed: the usage names the arguments, not just the flags
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), Usage)
+		fmt.Fprintln(fs.Output(), "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	// Fixed: Parse stops at the first positional argument, so take it and
+	// parse the rest again, until nothing is left or "--" ended the flags.
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return Options{}, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		if flags := len(args) - fs.NArg(); flags > 0 && args[flags-1] == "--" {
+			positional = append(positional, fs.Args()...)
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(positional) == 0 {
+		return Options{}, errors.New("missing shape")
+	}
+	o.Shape = positional[0]
+	for _, s := range positional[1:] {
+		size, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return Options{}, fmt.Errorf("size %q: not a number", s)
+		}
+		if size <= 0 {
+			return Options{}, fmt.Errorf("size %q: must be positive", s)
+		}
+		o.Sizes = append(o.Sizes, size)
+	}
+	if o.Precision < 0 {
+		return Options{}, fmt.Errorf("-precision %d: must not be negative", o.Precision)
+	}
+	return o, nil
+}
+
+// sizesPerShape is how many sizes each shape takes.
+var sizesPerShape = map[string]int{"circle": 1, "square": 1, "rectangle": 2}
+
+// Build returns the shape o describes.
+func (o Options) Build() (geometry.Shape, error) {
+	want, ok := sizesPerShape[o.Shape]
+	if !ok {
+		return nil, fmt.Errorf("unknown shape %q: want circle, square, or rectangle", o.Shape)
+	}
+	if len(o.Sizes) != want {
+		return nil, fmt.Errorf("%s takes %d sizes, got %d", o.Shape, want, len(o.Sizes))
+	}
+	switch o.Shape {
+	case "circle":
+		return geometry.Circle{Radius: o.Sizes[0]}, nil
+	case "square":
+		return geometry.Rectangle{Width: o.Sizes[0], Height: o.Sizes[0]}, nil
+	default:
+		return geometry.Rectangle{Width: o.Sizes[0], Height: o.Sizes[1]}, nil
+	}
+}
+
+// Run is the shapes command. It returns the exit code: 0 on success or
+// for -h, and 2 for a command line it cannot use.
+func Run(args []string, stdout, stderr io.Writer) int {
+	o, err := ParseArgs(args, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0 // Fixed: asking for help is not a mistake
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "shapes:", err)
+		return 2
+	}
+	s, err := o.Build()
+	if err != nil {
+		fmt.Fprintln(stderr, "shapes:", err)
+		return 2
+	}
+	fmt.Fprintf(stdout, "area: %.*f %s²\n", o.Precision, s.Area(), o.Unit)
+	if o.Perimeter {
+		fmt.Fprintf(stdout, "perimeter: %.*f %s\n", o.Precision, s.Perimeter(), o.Unit)
+	}
+	return 0
+}
@@ -0,0 +1,117 @@
+package solutions
+
+// SOLUTION: The fixed custom flag types of "shapes draw".
+// Any type with String and Set methods is a flag.Value, and fs.Var turns
+// it into a flag: Set gets the text of every occurrence, so it can check
+// the value or collect repeated flags. The draw command uses three:
+//
+//	shapes draw -unit mm -size 3,4 -size 5 -label color=red -label owner=you
+//
+// Each fix is marked with a // Fixed: comment.
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Unit is a length unit: mm, cm, m, or in.
+type Unit string
+
+// centimeters is the length of each unit in centimeters.
+var centimeters = map[Unit]float64{"mm": 0.1, "cm": 1, "m": 100, "in": 2.54}
+
+// String implements flag.Value.
+func (u *Unit) String() string { return string(*u) }
+
+// Set implements flag.Value.
+func (u *Unit) Set(s string) error {
+	if _, ok := centimeters[Unit(s)]; !ok {
+		return errors.New("want mm, cm, m, or in") // Fixed: only known units
+	}
+	*u = Unit(s)
+	return nil
+}
+
+// ToCentimeters converts a length in u to centimeters.
+func (u Unit) ToCentimeters(length float64) float64 {
+	return length * centimeters[u]
+}
+
+// Sizes collects the -size flags: "-size 3,4 -size 5" gives [3 4 5].
+type Sizes []float64
+
+// String implements flag.Value.
+func (s Sizes) String() string {
+	parts := make([]string, len(s))
+	for i, size := range s {
+		parts[i] = strconv.FormatFloat(size, 'g', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Set implements flag.Value. Fixed: the pointer receiver lets Set append
+// to the caller's Sizes instead of a copy.
+func (s *Sizes) Set(v string) error {
+	for _, part := range strings.Split(v, ",") {
+		size, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", part)
+		}
+		if size <= 0 {
+			return fmt.Errorf("%q is not positive", part)
+		}
+		*s = append(*s, size)
+	}
+	return nil
+}
+
+// Labels collects the -label flags: "-label color=red" sets color to red.
+type Labels map[string]string
+
+// String implements flag.Value; the labels are sorted by key.
+func (l *Labels) String() string {
+	keys := make([]string, 0, len(*l))
+	for k := range *l {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		keys[i] = k + "=" + (*l)[k]
+	}
+	return strings.Join(keys, ",")
+}
+
+// Set implements flag.Value.
+func (l *Labels) Set(v string) error {
+	key, value, ok := strings.Cut(v, "=")
+	if !ok || key == "" {
+		return errors.New("want key=value")
+	}
+	if *l == nil {
+		*l = make(Labels) // Fixed: a nil map cannot be written to
+	}
+	(*l)[key] = value
+	return nil
+}
+
+// DrawConfig holds the flags of "shapes draw".
+type DrawConfig struct {
+	Unit   Unit
+	Sizes  Sizes
+	Labels Labels
+}
+
+// DrawFlags defines the draw flags on fs and returns the config they fill
+// in when fs is parsed. The unit is cm unless -unit says otherwise.
+func DrawFlags(fs *flag.FlagSet) *DrawConfig {
+	// Fixed: the default is set before fs.Var records it for the usage
+	cfg := &DrawConfig{Unit: "cm"}
+	fs.Var(&cfg.Unit, "unit", "length unit: mm, cm, m, or in")
+	fs.Var(&cfg.Sizes, "size", "add comma-separated sizes (repeatable)")
+	fs.Var(&cfg.Labels, "label", "add a `key=value` label (repeatable)")
+	return cfg
+}
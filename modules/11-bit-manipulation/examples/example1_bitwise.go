@@ -0,0 +1,99 @@
+// Package examples demonstrates bit manipulation in Go.
+//
+// This file shows:
+// - The bitwise operators &, |, ^, &^, <<, and >>
+// - Setting, clearing, toggling, and testing individual bits
+// - Masks for extracting bit fields
+// - Counting bits by hand and with math/bits
+package examples
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// BitwiseOperators demonstrates every bitwise operator Go provides.
+func BitwiseOperators() {
+	a, b := uint8(0b1100), uint8(0b1010)
+
+	fmt.Printf("a      = %04b\n", a)
+	fmt.Printf("b      = %04b\n", b)
+	fmt.Printf("a & b  = %04b (AND: bits set in both)\n", a&b)
+	fmt.Printf("a | b  = %04b (OR: bits set in either)\n", a|b)
+	fmt.Printf("a ^ b  = %04b (XOR: bits set in exactly one)\n", a^b)
+	fmt.Printf("a &^ b = %04b (AND NOT: bits of a not set in b)\n", a&^b)
+	fmt.Printf("^a     = %08b (unary ^ is bitwise NOT, unlike ~ in C)\n", ^a)
+
+	// Shifts multiply or divide by powers of two
+	fmt.Printf("1 << 4 = %d\n", 1<<4)
+	fmt.Printf("96 >> 3 = %d\n", 96>>3)
+
+	// Right shifts of signed values keep the sign (arithmetic shift)
+	fmt.Printf("-16 >> 2 = %d\n", int8(-16)>>2)
+}
+
+// SetBit returns n with bit pos set to 1.
+func SetBit(n uint64, pos uint) uint64 {
+	return n | 1<<pos
+}
+
+// ClearBit returns n with bit pos set to 0.
+func ClearBit(n uint64, pos uint) uint64 {
+	return n &^ (1 << pos)
+}
+
+// ToggleBit returns n with bit pos flipped.
+func ToggleBit(n uint64, pos uint) uint64 {
+	return n ^ 1<<pos
+}
+
+// HasBit reports whether bit pos of n is set.
+func HasBit(n uint64, pos uint) bool {
+	return n&(1<<pos) != 0
+}
+
+// IsPowerOfTwo reports whether n has exactly one bit set.
+// n & (n-1) clears the lowest set bit, so it is zero only for powers of two.
+func IsPowerOfTwo(n uint64) bool {
+	return n != 0 && n&(n-1) == 0
+}
+
+// ExtractBits returns the width-bit field of value starting at offset.
+// A mask of width ones is built with (1 << width) - 1.
+func ExtractBits(value uint32, offset, width uint) uint32 {
+	mask := uint32(1)<<width - 1
+	return (value >> offset) & mask
+}
+
+// CountBits counts set bits with Brian Kernighan's trick: each iteration
+// clears the lowest set bit, so the loop runs once per set bit.
+func CountBits(n uint64) int {
+	count := 0
+	for n != 0 {
+		n &= n - 1
+		count++
+	}
+	return count
+}
+
+// DemonstrateBitHelpers shows the helper functions next to their math/bits
+// equivalents, which compile down to single CPU instructions where possible.
+func DemonstrateBitHelpers() {
+	n := uint64(0b1011_0000)
+
+	fmt.Printf("n            = %08b\n", n)
+	fmt.Printf("SetBit(n, 0) = %08b\n", SetBit(n, 0))
+	fmt.Printf("ClearBit(n,4)= %08b\n", ClearBit(n, 4))
+	fmt.Printf("ToggleBit(n,7)=%08b\n", ToggleBit(n, 7))
+	fmt.Printf("HasBit(n, 5) = %v\n", HasBit(n, 5))
+
+	fmt.Printf("CountBits(n) = %d, bits.OnesCount64(n) = %d\n", CountBits(n), bits.OnesCount64(n))
+	fmt.Printf("Lowest set bit index: %d\n", bits.TrailingZeros64(n))
+	fmt.Printf("Bit length: %d\n", bits.Len64(n))
+	fmt.Printf("IsPowerOfTwo(64) = %v, IsPowerOfTwo(96) = %v\n", IsPowerOfTwo(64), IsPowerOfTwo(96))
+
+	// An RGB565 pixel packs red (5 bits), green (6 bits), and blue (5 bits)
+	pixel := uint32(0b10110_011011_00101)
+	fmt.Printf("RGB565 %016b -> r=%d g=%d b=%d\n", pixel,
+		ExtractBits(pixel, 11, 5), ExtractBits(pixel, 5, 6), ExtractBits(pixel, 0, 5))
+}
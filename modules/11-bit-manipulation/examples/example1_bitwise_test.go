@@ -0,0 +1,50 @@
+package examples
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitwiseOperators(t *testing.T) {
+	BitwiseOperators()
+}
+
+func TestDemonstrateBitHelpers(t *testing.T) {
+	DemonstrateBitHelpers()
+}
+
+func TestSingleBitHelpers(t *testing.T) {
+	assert.Equal(t, uint64(0b1001), SetBit(0b1000, 0))
+	assert.Equal(t, uint64(0b1000), SetBit(0b1000, 3), "setting a set bit is a no-op")
+	assert.Equal(t, uint64(0b0001), ClearBit(0b1001, 3))
+	assert.Equal(t, uint64(0b1001), ClearBit(0b1001, 2), "clearing a clear bit is a no-op")
+	assert.Equal(t, uint64(0b0001), ToggleBit(0b1001, 3))
+	assert.Equal(t, uint64(0b1101), ToggleBit(0b1001, 2))
+	assert.True(t, HasBit(0b1001, 0))
+	assert.False(t, HasBit(0b1001, 1))
+	assert.True(t, HasBit(1<<63, 63))
+}
+
+func TestIsPowerOfTwo(t *testing.T) {
+	for i := uint(0); i < 64; i++ {
+		assert.True(t, IsPowerOfTwo(1<<i), "1<<%d", i)
+	}
+	for _, n := range []uint64{0, 3, 6, 12, 100, 1<<10 + 1} {
+		assert.False(t, IsPowerOfTwo(n), "%d", n)
+	}
+}
+
+func TestExtractBits(t *testing.T) {
+	pixel := uint32(0b10110_011011_00101)
+	assert.Equal(t, uint32(0b10110), ExtractBits(pixel, 11, 5))
+	assert.Equal(t, uint32(0b011011), ExtractBits(pixel, 5, 6))
+	assert.Equal(t, uint32(0b00101), ExtractBits(pixel, 0, 5))
+}
+
+func TestCountBits(t *testing.T) {
+	for _, n := range []uint64{0, 1, 0b1011, 0xFF, 1 << 63, ^uint64(0)} {
+		assert.Equal(t, bits.OnesCount64(n), CountBits(n), "%b", n)
+	}
+}
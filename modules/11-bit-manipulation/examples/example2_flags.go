@@ -0,0 +1,78 @@
+package examples
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FlagEnumExamples demonstrates flag enums built with iota.
+//
+// Plain iota gives 0, 1, 2, ... which is right for mutually exclusive
+// values (a weekday). 1 << iota gives 1, 2, 4, ... which is right for
+// values that combine (a set of options), because each one owns a bit.
+
+// Feature is a set of optional features, one bit each.
+type Feature uint8
+
+const (
+	FeatureLogging Feature = 1 << iota // 0b0001
+	FeatureMetrics                     // 0b0010
+	FeatureTracing                     // 0b0100
+	FeatureCaching                     // 0b1000
+
+	// FeatureNone is the empty set and FeatureAll combines every feature.
+	FeatureNone Feature = 0
+	FeatureAll          = FeatureLogging | FeatureMetrics | FeatureTracing | FeatureCaching
+)
+
+var featureNames = []struct {
+	flag Feature
+	name string
+}{
+	{FeatureLogging, "logging"},
+	{FeatureMetrics, "metrics"},
+	{FeatureTracing, "tracing"},
+	{FeatureCaching, "caching"},
+}
+
+// Has reports whether every feature in other is enabled in f.
+func (f Feature) Has(other Feature) bool {
+	return f&other == other
+}
+
+// String lists enabled features separated by "|", e.g. "logging|tracing".
+// Implementing fmt.Stringer makes %v print names instead of numbers.
+func (f Feature) String() string {
+	if f == FeatureNone {
+		return "none"
+	}
+	var names []string
+	for _, fn := range featureNames {
+		if f.Has(fn.flag) {
+			names = append(names, fn.name)
+		}
+	}
+	if unknown := f &^ FeatureAll; unknown != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint8(unknown)))
+	}
+	return strings.Join(names, "|")
+}
+
+// DemonstrateFlags shows combining, testing, and removing flags.
+func DemonstrateFlags() {
+	enabled := FeatureLogging | FeatureTracing
+	fmt.Printf("Enabled: %v (%04b)\n", enabled, uint8(enabled))
+
+	enabled |= FeatureMetrics // add
+	fmt.Printf("After adding metrics: %v\n", enabled)
+
+	enabled &^= FeatureLogging // remove
+	fmt.Printf("After removing logging: %v\n", enabled)
+
+	enabled ^= FeatureCaching // toggle
+	fmt.Printf("After toggling caching: %v\n", enabled)
+
+	fmt.Printf("Has tracing? %v\n", enabled.Has(FeatureTracing))
+	fmt.Printf("Has logging and metrics? %v\n", enabled.Has(FeatureLogging|FeatureMetrics))
+	fmt.Printf("All: %v\n", FeatureAll)
+}
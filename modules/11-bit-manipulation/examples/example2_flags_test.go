@@ -0,0 +1,34 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateFlags(t *testing.T) {
+	DemonstrateFlags()
+}
+
+func TestFeatureValues(t *testing.T) {
+	assert.Equal(t, Feature(1), FeatureLogging)
+	assert.Equal(t, Feature(2), FeatureMetrics)
+	assert.Equal(t, Feature(4), FeatureTracing)
+	assert.Equal(t, Feature(8), FeatureCaching)
+	assert.Equal(t, Feature(15), FeatureAll)
+}
+
+func TestFeatureHas(t *testing.T) {
+	f := FeatureLogging | FeatureTracing
+	assert.True(t, f.Has(FeatureLogging))
+	assert.True(t, f.Has(FeatureLogging|FeatureTracing))
+	assert.False(t, f.Has(FeatureLogging|FeatureMetrics), "Has requires every flag")
+	assert.True(t, f.Has(FeatureNone))
+}
+
+func TestFeatureString(t *testing.T) {
+	assert.Equal(t, "none", FeatureNone.String())
+	assert.Equal(t, "logging|tracing", (FeatureLogging | FeatureTracing).String())
+	assert.Equal(t, "logging|metrics|tracing|caching", FeatureAll.String())
+	assert.Equal(t, "metrics|0x10", (FeatureMetrics | 1<<4).String())
+}
@@ -0,0 +1,132 @@
+package exercises
+
+// EXERCISE: Implement a Unix-style permission flags type.
+// Each permission owns one bit, so permissions can be combined with |,
+// tested with &, and removed with &^. The FileHandler below relies on the
+// flags being correct. Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Permission is a set of access rights on a file.
+type Permission uint8
+
+// The bit layout matches Unix octal modes: r=4, w=2, x=1, so "rwx" is 7
+// and "r-x" is 5.
+// BUG: Plain iota yields 0, 1, 2 - Execute would be zero and could never be set.
+const (
+	Execute Permission = iota // BUG: Should be 1 << iota
+	Write
+	Read
+
+	// None grants nothing and All grants everything.
+	None Permission = 0
+	All             = Read | Write | Execute
+)
+
+// Has reports whether p grants every permission in other.
+// BUG: Returns true if p grants any one of the permissions in other.
+func (p Permission) Has(other Permission) bool {
+	return p&other != 0 // BUG: Should be p&other == other
+}
+
+// Add returns p with the permissions in other granted.
+// BUG: XOR toggles bits, so adding an existing permission removes it.
+func (p Permission) Add(other Permission) Permission {
+	return p ^ other // BUG: Should be p | other
+}
+
+// Remove returns p with the permissions in other revoked.
+// BUG: AND keeps only the bits being removed.
+func (p Permission) Remove(other Permission) Permission {
+	return p & other // BUG: Should be p &^ other
+}
+
+// String renders p in ls -l style, e.g. "rw-" or "r-x".
+func (p Permission) String() string {
+	s := []byte("---")
+	if p.Has(Read) {
+		s[0] = 'r'
+	}
+	if p.Has(Write) {
+		s[1] = 'w'
+	}
+	if p.Has(Execute) {
+		s[2] = 'x'
+	}
+	return string(s)
+}
+
+// ParsePermission parses the ls -l style form produced by String.
+func ParsePermission(s string) (Permission, error) {
+	if len(s) != 3 {
+		return None, fmt.Errorf("invalid permission %q: want 3 characters like \"rw-\"", s)
+	}
+	var p Permission
+	for i, want := range []struct {
+		char byte
+		perm Permission
+	}{{'r', Read}, {'w', Write}, {'x', Execute}} {
+		switch s[i] {
+		case want.char:
+			p = p.Add(want.perm)
+		case '-':
+		default:
+			return None, fmt.Errorf("invalid permission %q: unexpected %q at position %d", s, s[i], i)
+		}
+	}
+	return p, nil
+}
+
+// ErrPermissionDenied is returned when a FileHandler operation is not allowed.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// FileHandler is an in-memory file guarded by a Permission set.
+type FileHandler struct {
+	Name string
+	Perm Permission
+	data []byte
+}
+
+// NewFileHandler returns a FileHandler for name with the given permissions.
+func NewFileHandler(name string, perm Permission) *FileHandler {
+	return &FileHandler{Name: name, Perm: perm}
+}
+
+// Read returns a copy of the file contents. Requires Read.
+func (f *FileHandler) Read() ([]byte, error) {
+	if !f.Perm.Has(Read) {
+		return nil, fmt.Errorf("read %s: %w", f.Name, ErrPermissionDenied)
+	}
+	return append([]byte(nil), f.data...), nil
+}
+
+// Write replaces the file contents. Requires Write.
+func (f *FileHandler) Write(data []byte) error {
+	if !f.Perm.Has(Write) {
+		return fmt.Errorf("write %s: %w", f.Name, ErrPermissionDenied)
+	}
+	f.data = append([]byte(nil), data...)
+	return nil
+}
+
+// Execute "runs" the file by returning its contents as a command line.
+// Running a file requires both Read and Execute, just like a shell script.
+func (f *FileHandler) Execute() (string, error) {
+	if !f.Perm.Has(Read | Execute) {
+		return "", fmt.Errorf("execute %s: %w", f.Name, ErrPermissionDenied)
+	}
+	return string(f.data), nil
+}
+
+// Chmod grants the permissions in add and then revokes those in remove.
+func (f *FileHandler) Chmod(add, remove Permission) {
+	f.Perm = f.Perm.Add(add).Remove(remove)
+}
+
+// Mode returns the permissions as a single octal digit, e.g. 6 for "rw-".
+func (f *FileHandler) Mode() uint8 {
+	return uint8(f.Perm & All)
+}
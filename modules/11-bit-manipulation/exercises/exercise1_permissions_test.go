@@ -0,0 +1,113 @@
+package exercises
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionValues(t *testing.T) {
+	assert.Equal(t, Permission(1), Execute)
+	assert.Equal(t, Permission(2), Write)
+	assert.Equal(t, Permission(4), Read)
+	assert.Equal(t, Permission(7), All)
+	assert.Equal(t, Permission(0), None)
+}
+
+func TestPermissionHas(t *testing.T) {
+	rw := Read | Write
+	assert.True(t, rw.Has(Read))
+	assert.True(t, rw.Has(Write))
+	assert.True(t, rw.Has(Read|Write))
+	assert.False(t, rw.Has(Execute))
+	assert.False(t, rw.Has(Read|Execute), "Has must require every permission")
+	assert.True(t, None.Has(None))
+	assert.True(t, All.Has(Read|Write|Execute))
+}
+
+func TestPermissionAdd(t *testing.T) {
+	assert.Equal(t, Read|Write, Read.Add(Write))
+	assert.Equal(t, Read|Write, (Read | Write).Add(Write), "adding twice is a no-op")
+	assert.Equal(t, All, None.Add(Read).Add(Write).Add(Execute))
+}
+
+func TestPermissionRemove(t *testing.T) {
+	assert.Equal(t, Read, (Read | Write).Remove(Write))
+	assert.Equal(t, Read, Read.Remove(Write), "removing a missing permission is a no-op")
+	assert.Equal(t, Execute, All.Remove(Read|Write))
+	assert.Equal(t, None, All.Remove(All))
+}
+
+func TestPermissionString(t *testing.T) {
+	assert.Equal(t, "---", None.String())
+	assert.Equal(t, "r--", Read.String())
+	assert.Equal(t, "rw-", (Read | Write).String())
+	assert.Equal(t, "r-x", (Read | Execute).String())
+	assert.Equal(t, "rwx", All.String())
+}
+
+func TestParsePermission(t *testing.T) {
+	for _, s := range []string{"---", "r--", "-w-", "--x", "rw-", "r-x", "-wx", "rwx"} {
+		p, err := ParsePermission(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, s, p.String(), "round trip %q", s)
+	}
+
+	for _, bad := range []string{"", "rw", "rwxr", "wrx", "r?x"} {
+		_, err := ParsePermission(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestFileHandlerReadWrite(t *testing.T) {
+	f := NewFileHandler("notes.txt", Read|Write)
+	require.NoError(t, f.Write([]byte("hello")))
+
+	data, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	data[0] = 'J'
+	again, _ := f.Read()
+	assert.Equal(t, "hello", string(again), "Read must return a copy")
+
+	_, err = f.Execute()
+	assert.True(t, errors.Is(err, ErrPermissionDenied))
+}
+
+func TestFileHandlerPermissionDenied(t *testing.T) {
+	readOnly := NewFileHandler("config.yaml", Read)
+	err := readOnly.Write([]byte("x"))
+	assert.True(t, errors.Is(err, ErrPermissionDenied))
+	assert.Contains(t, err.Error(), "config.yaml")
+
+	writeOnly := NewFileHandler("log.txt", Write)
+	_, err = writeOnly.Read()
+	assert.True(t, errors.Is(err, ErrPermissionDenied))
+
+	execOnly := NewFileHandler("run.sh", Execute)
+	_, err = execOnly.Execute()
+	assert.True(t, errors.Is(err, ErrPermissionDenied), "executing needs read and execute")
+}
+
+func TestFileHandlerChmod(t *testing.T) {
+	f := NewFileHandler("run.sh", Read|Write)
+	require.NoError(t, f.Write([]byte("echo hi")))
+
+	f.Chmod(Execute, Write) // chmod +x -w
+	assert.Equal(t, "r-x", f.Perm.String())
+	assert.Equal(t, uint8(5), f.Mode())
+
+	cmd, err := f.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, "echo hi", cmd)
+	assert.True(t, errors.Is(f.Write([]byte("x")), ErrPermissionDenied))
+
+	f.Chmod(Execute, None) // chmod +x again changes nothing
+	assert.Equal(t, uint8(5), f.Mode())
+
+	f.Chmod(All, None)
+	assert.Equal(t, uint8(7), f.Mode())
+}
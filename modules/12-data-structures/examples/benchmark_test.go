@@ -0,0 +1,95 @@
+package examples
+
+import "testing"
+
+// Compare slice-backed and container/list-backed structures with:
+//
+//	go test -bench=. -benchmem ./examples/
+//
+// Expect the slice versions to win by a wide margin and to allocate far
+// less: list elements are individual heap objects and values are boxed
+// into interfaces.
+
+const benchOps = 1000
+
+func BenchmarkStackSlice(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var s Stack[int]
+		for j := 0; j < benchOps; j++ {
+			s.Push(j)
+		}
+		for s.Len() > 0 {
+			s.Pop()
+		}
+	}
+}
+
+func BenchmarkStackList(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var s ListStack[int]
+		for j := 0; j < benchOps; j++ {
+			s.Push(j)
+		}
+		for s.Len() > 0 {
+			s.Pop()
+		}
+	}
+}
+
+func BenchmarkQueueSlice(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var q Queue[int]
+		for j := 0; j < benchOps; j++ {
+			q.Enqueue(j)
+		}
+		for q.Len() > 0 {
+			q.Dequeue()
+		}
+	}
+}
+
+func BenchmarkQueueList(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var q ListQueue[int]
+		for j := 0; j < benchOps; j++ {
+			q.Enqueue(j)
+		}
+		for q.Len() > 0 {
+			q.Dequeue()
+		}
+	}
+}
+
+func BenchmarkDequeSlice(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var d Deque[int]
+		for j := 0; j < benchOps; j++ {
+			d.PushFront(j)
+			d.PushBack(j)
+		}
+		for d.Len() > 0 {
+			d.PopFront()
+			d.PopBack()
+		}
+	}
+}
+
+func BenchmarkDequeList(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var d ListDeque[int]
+		for j := 0; j < benchOps; j++ {
+			d.PushFront(j)
+			d.PushBack(j)
+		}
+		for d.Len() > 0 {
+			d.PopFront()
+			d.PopBack()
+		}
+	}
+}
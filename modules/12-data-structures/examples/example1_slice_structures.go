@@ -0,0 +1,180 @@
+// Package examples demonstrates classic data structures in Go.
+//
+// This file shows slice-backed implementations:
+// - Stack (LIFO) on top of append and reslicing
+// - Queue (FIFO) with a moving head index
+// - Deque (double-ended queue) on a growable circular buffer
+//
+// All three use type parameters so one implementation serves every element type.
+package examples
+
+import "fmt"
+
+// Stack is a last-in, first-out collection backed by a slice.
+// The zero value is an empty stack ready to use.
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds v to the top of the stack.
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+
+// Pop removes and returns the top element. ok is false if the stack is empty.
+func (s *Stack[T]) Pop() (v T, ok bool) {
+	if len(s.items) == 0 {
+		return v, false
+	}
+	last := len(s.items) - 1
+	v = s.items[last]
+	var zero T
+	s.items[last] = zero // let the garbage collector reclaim pointers
+	s.items = s.items[:last]
+	return v, true
+}
+
+// Peek returns the top element without removing it.
+func (s *Stack[T]) Peek() (v T, ok bool) {
+	if len(s.items) == 0 {
+		return v, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Len returns the number of elements on the stack.
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// Queue is a first-in, first-out collection backed by a slice.
+// Dequeue advances a head index instead of shifting every element, and the
+// slice is compacted once more than half of it is dead space.
+type Queue[T any] struct {
+	items []T
+	head  int
+}
+
+// Enqueue adds v to the back of the queue.
+func (q *Queue[T]) Enqueue(v T) {
+	q.items = append(q.items, v)
+}
+
+// Dequeue removes and returns the front element. ok is false if the queue is empty.
+func (q *Queue[T]) Dequeue() (v T, ok bool) {
+	if q.head == len(q.items) {
+		return v, false
+	}
+	v = q.items[q.head]
+	var zero T
+	q.items[q.head] = zero
+	q.head++
+
+	if q.head > len(q.items)/2 {
+		q.items = append(q.items[:0], q.items[q.head:]...)
+		q.head = 0
+	}
+	return v, true
+}
+
+// Len returns the number of elements in the queue.
+func (q *Queue[T]) Len() int {
+	return len(q.items) - q.head
+}
+
+// Deque is a double-ended queue on a circular buffer that doubles in size
+// when full. All four operations are amortized O(1).
+type Deque[T any] struct {
+	buf   []T
+	head  int // index of the front element
+	count int
+}
+
+// PushFront adds v to the front.
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = v
+	d.count++
+}
+
+// PushBack adds v to the back.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[(d.head+d.count)%len(d.buf)] = v
+	d.count++
+}
+
+// PopFront removes and returns the front element.
+func (d *Deque[T]) PopFront() (v T, ok bool) {
+	if d.count == 0 {
+		return v, false
+	}
+	var zero T
+	v, d.buf[d.head] = d.buf[d.head], zero
+	d.head = (d.head + 1) % len(d.buf)
+	d.count--
+	return v, true
+}
+
+// PopBack removes and returns the back element.
+func (d *Deque[T]) PopBack() (v T, ok bool) {
+	if d.count == 0 {
+		return v, false
+	}
+	var zero T
+	tail := (d.head + d.count - 1) % len(d.buf)
+	v, d.buf[tail] = d.buf[tail], zero
+	d.count--
+	return v, true
+}
+
+// Len returns the number of elements in the deque.
+func (d *Deque[T]) Len() int {
+	return d.count
+}
+
+// grow doubles the buffer when it is full, unrolling the ring so the
+// front element lands at index 0.
+func (d *Deque[T]) grow() {
+	if d.count < len(d.buf) {
+		return
+	}
+	size := max(2*len(d.buf), 8)
+	buf := make([]T, size)
+	for i := 0; i < d.count; i++ {
+		buf[i] = d.buf[(d.head+i)%len(d.buf)]
+	}
+	d.buf, d.head = buf, 0
+}
+
+// DemonstrateSliceStructures shows the three slice-backed structures.
+func DemonstrateSliceStructures() {
+	var s Stack[string]
+	for _, w := range []string{"first", "second", "third"} {
+		s.Push(w)
+	}
+	top, _ := s.Peek()
+	fmt.Printf("Stack top: %s, len: %d\n", top, s.Len())
+	for s.Len() > 0 {
+		v, _ := s.Pop()
+		fmt.Printf("Popped: %s\n", v)
+	}
+
+	var q Queue[int]
+	for i := 1; i <= 3; i++ {
+		q.Enqueue(i * 10)
+	}
+	for q.Len() > 0 {
+		v, _ := q.Dequeue()
+		fmt.Printf("Dequeued: %d\n", v)
+	}
+
+	var d Deque[rune]
+	d.PushBack('b')
+	d.PushFront('a')
+	d.PushBack('c')
+	front, _ := d.PopFront()
+	back, _ := d.PopBack()
+	fmt.Printf("Deque front: %c, back: %c, remaining: %d\n", front, back, d.Len())
+}
@@ -0,0 +1,83 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateSliceStructures(t *testing.T) {
+	DemonstrateSliceStructures()
+}
+
+func TestStack(t *testing.T) {
+	var s Stack[int]
+	_, ok := s.Pop()
+	assert.False(t, ok, "pop on empty stack")
+	_, ok = s.Peek()
+	assert.False(t, ok, "peek on empty stack")
+
+	for i := 1; i <= 3; i++ {
+		s.Push(i)
+	}
+	top, ok := s.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 3, top)
+	assert.Equal(t, 3, s.Len())
+
+	for want := 3; want >= 1; want-- {
+		v, ok := s.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, want, v)
+	}
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestQueue(t *testing.T) {
+	var q Queue[int]
+	_, ok := q.Dequeue()
+	assert.False(t, ok)
+
+	// Interleave operations so compaction kicks in mid-stream
+	next := 0
+	for i := 0; i < 100; i++ {
+		q.Enqueue(i)
+		if i%3 == 0 {
+			v, ok := q.Dequeue()
+			assert.True(t, ok)
+			assert.Equal(t, next, v)
+			next++
+		}
+	}
+	for q.Len() > 0 {
+		v, _ := q.Dequeue()
+		assert.Equal(t, next, v)
+		next++
+	}
+	assert.Equal(t, 100, next)
+}
+
+func TestDeque(t *testing.T) {
+	var d Deque[int]
+	_, ok := d.PopFront()
+	assert.False(t, ok)
+	_, ok = d.PopBack()
+	assert.False(t, ok)
+
+	// Push enough from both ends to force several grow() calls across the wrap point
+	for i := 1; i <= 20; i++ {
+		d.PushBack(i)
+		d.PushFront(-i)
+	}
+	assert.Equal(t, 40, d.Len())
+
+	for i := 20; i >= 1; i-- {
+		v, _ := d.PopFront()
+		assert.Equal(t, -i, v)
+	}
+	for i := 20; i >= 1; i-- {
+		v, _ := d.PopBack()
+		assert.Equal(t, i, v)
+	}
+	assert.Equal(t, 0, d.Len())
+}
@@ -0,0 +1,125 @@
+package examples
+
+import (
+	"container/list"
+	"container/ring"
+	"fmt"
+)
+
+// ContainerExamples demonstrates the standard library's container packages.
+//
+// container/list is a doubly linked list and container/ring is a circular
+// list. Neither is generic (elements are stored as any), every element is a
+// separate heap allocation, and traversals chase pointers instead of
+// walking contiguous memory. They shine when you need O(1) removal from the
+// middle given an element handle (LRU caches) - otherwise prefer slices.
+
+// ListDeque is a double-ended queue built on container/list.
+type ListDeque[T any] struct {
+	l list.List // the zero value of list.List is an empty list
+}
+
+// PushFront adds v to the front.
+func (d *ListDeque[T]) PushFront(v T) { d.l.PushFront(v) }
+
+// PushBack adds v to the back.
+func (d *ListDeque[T]) PushBack(v T) { d.l.PushBack(v) }
+
+// PopFront removes and returns the front element.
+func (d *ListDeque[T]) PopFront() (v T, ok bool) {
+	e := d.l.Front()
+	if e == nil {
+		return v, false
+	}
+	return d.l.Remove(e).(T), true // type assertion: list stores any
+}
+
+// PopBack removes and returns the back element.
+func (d *ListDeque[T]) PopBack() (v T, ok bool) {
+	e := d.l.Back()
+	if e == nil {
+		return v, false
+	}
+	return d.l.Remove(e).(T), true
+}
+
+// Len returns the number of elements.
+func (d *ListDeque[T]) Len() int { return d.l.Len() }
+
+// ListStack is a stack built on ListDeque, using only the back.
+type ListStack[T any] struct{ d ListDeque[T] }
+
+// Push adds v to the top of the stack.
+func (s *ListStack[T]) Push(v T) { s.d.PushBack(v) }
+
+// Pop removes and returns the top element.
+func (s *ListStack[T]) Pop() (T, bool) { return s.d.PopBack() }
+
+// Len returns the number of elements.
+func (s *ListStack[T]) Len() int { return s.d.Len() }
+
+// ListQueue is a queue built on ListDeque: push at the back, pop at the front.
+type ListQueue[T any] struct{ d ListDeque[T] }
+
+// Enqueue adds v to the back of the queue.
+func (q *ListQueue[T]) Enqueue(v T) { q.d.PushBack(v) }
+
+// Dequeue removes and returns the front element.
+func (q *ListQueue[T]) Dequeue() (T, bool) { return q.d.PopFront() }
+
+// Len returns the number of elements.
+func (q *ListQueue[T]) Len() int { return q.d.Len() }
+
+// History remembers the last n values using container/ring. Adding a value
+// overwrites the oldest one once the ring is full.
+type History[T any] struct {
+	r     *ring.Ring // points at the slot the next value will be written to
+	count int
+}
+
+// NewHistory returns a History that keeps the last n values. n must be positive.
+func NewHistory[T any](n int) *History[T] {
+	return &History[T]{r: ring.New(n)}
+}
+
+// Add records v, overwriting the oldest value when full.
+func (h *History[T]) Add(v T) {
+	h.r.Value = v
+	h.r = h.r.Next()
+	if h.count < h.r.Len() {
+		h.count++
+	}
+}
+
+// Values returns the remembered values from oldest to newest.
+func (h *History[T]) Values() []T {
+	out := make([]T, 0, h.count)
+	// Once full, the next write slot holds the oldest value; before that,
+	// the oldest value sits count slots behind the write slot.
+	start := h.r.Move(-h.count)
+	for i, r := 0, start; i < h.count; i, r = i+1, r.Next() {
+		out = append(out, r.Value.(T))
+	}
+	return out
+}
+
+// DemonstrateContainerStructures shows container/list and container/ring in use.
+func DemonstrateContainerStructures() {
+	var q ListQueue[string]
+	q.Enqueue("alpha")
+	q.Enqueue("beta")
+	v, _ := q.Dequeue()
+	fmt.Printf("ListQueue dequeued: %s, remaining: %d\n", v, q.Len())
+
+	var s ListStack[int]
+	s.Push(1)
+	s.Push(2)
+	top, _ := s.Pop()
+	fmt.Printf("ListStack popped: %d\n", top)
+
+	h := NewHistory[string](3)
+	for _, cmd := range []string{"ls", "cd /tmp", "make", "go test"} {
+		h.Add(cmd)
+	}
+	fmt.Printf("Last 3 commands: %q\n", h.Values())
+}
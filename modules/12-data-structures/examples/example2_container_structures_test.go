@@ -0,0 +1,64 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateContainerStructures(t *testing.T) {
+	DemonstrateContainerStructures()
+}
+
+func TestListDeque(t *testing.T) {
+	var d ListDeque[string]
+	_, ok := d.PopFront()
+	assert.False(t, ok)
+
+	d.PushBack("b")
+	d.PushFront("a")
+	d.PushBack("c")
+	assert.Equal(t, 3, d.Len())
+
+	v, _ := d.PopFront()
+	assert.Equal(t, "a", v)
+	v, _ = d.PopBack()
+	assert.Equal(t, "c", v)
+	v, _ = d.PopBack()
+	assert.Equal(t, "b", v)
+	_, ok = d.PopBack()
+	assert.False(t, ok)
+}
+
+func TestListStackAndQueue(t *testing.T) {
+	var s ListStack[int]
+	var q ListQueue[int]
+	for i := 1; i <= 3; i++ {
+		s.Push(i)
+		q.Enqueue(i)
+	}
+	for i := 1; i <= 3; i++ {
+		top, _ := s.Pop()
+		front, _ := q.Dequeue()
+		assert.Equal(t, 4-i, top)
+		assert.Equal(t, i, front)
+	}
+	assert.Equal(t, 0, s.Len())
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestHistory(t *testing.T) {
+	h := NewHistory[int](3)
+	assert.Empty(t, h.Values())
+
+	h.Add(1)
+	h.Add(2)
+	assert.Equal(t, []int{1, 2}, h.Values())
+
+	h.Add(3)
+	assert.Equal(t, []int{1, 2, 3}, h.Values())
+
+	h.Add(4)
+	h.Add(5)
+	assert.Equal(t, []int{3, 4, 5}, h.Values())
+}
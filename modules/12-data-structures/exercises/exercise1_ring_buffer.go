@@ -0,0 +1,86 @@
+package exercises
+
+// EXERCISE: Fix the fixed-capacity ring buffer.
+// A ring buffer stores up to N elements in a slice of length N and wraps
+// its indexes around with the modulo operator. Every bug below is an
+// off-by-one in that wraparound logic. Fix the bugs marked with // BUG:
+// comments, then compare it with a slice queue using the benchmarks.
+
+// RingBuffer is a FIFO with a fixed capacity. When full, Push overwrites
+// the oldest element, which makes it ideal for "last N events" logs.
+type RingBuffer[T any] struct {
+	buf   []T
+	head  int // index of the oldest element
+	count int
+}
+
+// NewRingBuffer returns an empty RingBuffer holding at most capacity elements.
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &RingBuffer[T]{buf: make([]T, capacity)}
+}
+
+// Push appends v, overwriting the oldest element if the buffer is full.
+// It reports whether an element was overwritten.
+// BUG: The write index wraps one slot too early.
+func (r *RingBuffer[T]) Push(v T) (overwrote bool) {
+	tail := (r.head + r.count) % (len(r.buf) - 1) // BUG: Should be % len(r.buf)
+	r.buf[tail] = v
+	if r.count == len(r.buf) {
+		r.head = (r.head + 1) % len(r.buf)
+		return true
+	}
+	r.count++
+	return false
+}
+
+// Pop removes and returns the oldest element. ok is false if the buffer is empty.
+// BUG: head is advanced without wrapping around.
+func (r *RingBuffer[T]) Pop() (v T, ok bool) {
+	if r.count == 0 {
+		return v, false
+	}
+	var zero T
+	v, r.buf[r.head] = r.buf[r.head], zero
+	r.head++ // BUG: Should wrap: (r.head + 1) % len(r.buf)
+	r.count--
+	return v, true
+}
+
+// Peek returns the oldest element without removing it.
+func (r *RingBuffer[T]) Peek() (v T, ok bool) {
+	if r.count == 0 {
+		return v, false
+	}
+	return r.buf[r.head], true
+}
+
+// Newest returns the most recently pushed element.
+// BUG: Reads the slot after the newest element instead of the newest one.
+func (r *RingBuffer[T]) Newest() (v T, ok bool) {
+	if r.count == 0 {
+		return v, false
+	}
+	return r.buf[(r.head+r.count)%len(r.buf)], true // BUG: Should be r.head+r.count-1
+}
+
+// Items returns the elements from oldest to newest.
+// BUG: Indexes past the end of buf once the contents wrap around.
+func (r *RingBuffer[T]) Items() []T {
+	out := make([]T, 0, r.count)
+	for i := 0; i < r.count; i++ {
+		out = append(out, r.buf[r.head+i]) // BUG: Missing % len(r.buf)
+	}
+	return out
+}
+
+// Len returns the number of stored elements.
+func (r *RingBuffer[T]) Len() int { return r.count }
+
+// Cap returns the maximum number of elements.
+func (r *RingBuffer[T]) Cap() int { return len(r.buf) }
+
+// Full reports whether the next Push will overwrite an element.
+func (r *RingBuffer[T]) Full() bool { return r.count == len(r.buf) }
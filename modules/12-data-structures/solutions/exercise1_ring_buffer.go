@@ -0,0 +1,81 @@
+package solutions
+
+// SOLUTION: A fixed-capacity ring buffer.
+// A ring buffer stores up to N elements in a slice of length N and wraps
+// its indexes around with the modulo operator. Every index is computed as
+// (start + offset) % len(buf), where offset ranges over [0, count).
+
+// RingBuffer is a FIFO with a fixed capacity. When full, Push overwrites
+// the oldest element, which makes it ideal for "last N events" logs.
+type RingBuffer[T any] struct {
+	buf   []T
+	head  int // index of the oldest element
+	count int
+}
+
+// NewRingBuffer returns an empty RingBuffer holding at most capacity elements.
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &RingBuffer[T]{buf: make([]T, capacity)}
+}
+
+// Push appends v, overwriting the oldest element if the buffer is full.
+// It reports whether an element was overwritten.
+func (r *RingBuffer[T]) Push(v T) (overwrote bool) {
+	tail := (r.head + r.count) % len(r.buf) // Fixed: Wrap at the buffer length
+	r.buf[tail] = v
+	if r.count == len(r.buf) {
+		r.head = (r.head + 1) % len(r.buf)
+		return true
+	}
+	r.count++
+	return false
+}
+
+// Pop removes and returns the oldest element. ok is false if the buffer is empty.
+func (r *RingBuffer[T]) Pop() (v T, ok bool) {
+	if r.count == 0 {
+		return v, false
+	}
+	var zero T
+	v, r.buf[r.head] = r.buf[r.head], zero
+	r.head = (r.head + 1) % len(r.buf) // Fixed: Wrap around
+	r.count--
+	return v, true
+}
+
+// Peek returns the oldest element without removing it.
+func (r *RingBuffer[T]) Peek() (v T, ok bool) {
+	if r.count == 0 {
+		return v, false
+	}
+	return r.buf[r.head], true
+}
+
+// Newest returns the most recently pushed element.
+func (r *RingBuffer[T]) Newest() (v T, ok bool) {
+	if r.count == 0 {
+		return v, false
+	}
+	return r.buf[(r.head+r.count-1)%len(r.buf)], true // Fixed: Last offset is count-1
+}
+
+// Items returns the elements from oldest to newest.
+func (r *RingBuffer[T]) Items() []T {
+	out := make([]T, 0, r.count)
+	for i := 0; i < r.count; i++ {
+		out = append(out, r.buf[(r.head+i)%len(r.buf)]) // Fixed: Wrap around
+	}
+	return out
+}
+
+// Len returns the number of stored elements.
+func (r *RingBuffer[T]) Len() int { return r.count }
+
+// Cap returns the maximum number of elements.
+func (r *RingBuffer[T]) Cap() int { return len(r.buf) }
+
+// Full reports whether the next Push will overwrite an element.
+func (r *RingBuffer[T]) Full() bool { return r.count == len(r.buf) }
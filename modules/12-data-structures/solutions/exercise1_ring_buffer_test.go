@@ -0,0 +1,120 @@
+package solutions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRingBufferBasics(t *testing.T) {
+	r := NewRingBuffer[int](3)
+	assert.Equal(t, 3, r.Cap())
+	assert.Equal(t, 0, r.Len())
+	_, ok := r.Pop()
+	assert.False(t, ok)
+	_, ok = r.Newest()
+	assert.False(t, ok)
+
+	require.NotPanics(t, func() {
+		assert.False(t, r.Push(1))
+		assert.False(t, r.Push(2))
+	})
+	newest, ok := r.Newest()
+	assert.True(t, ok)
+	assert.Equal(t, 2, newest)
+	oldest, _ := r.Peek()
+	assert.Equal(t, 1, oldest)
+}
+
+func TestRingBufferFillToCapacity(t *testing.T) {
+	r := NewRingBuffer[int](3)
+	require.NotPanics(t, func() {
+		r.Push(1)
+		r.Push(2)
+		r.Push(3)
+	})
+	assert.True(t, r.Full())
+	assert.Equal(t, []int{1, 2, 3}, r.Items(), "the last slot must be usable")
+}
+
+func TestRingBufferOverwrite(t *testing.T) {
+	r := NewRingBuffer[string](3)
+	require.NotPanics(t, func() {
+		for _, s := range []string{"a", "b", "c"} {
+			assert.False(t, r.Push(s))
+		}
+		assert.True(t, r.Push("d"), "pushing into a full buffer overwrites")
+		assert.True(t, r.Push("e"))
+	})
+	assert.Equal(t, 3, r.Len())
+
+	var items []string
+	require.NotPanics(t, func() { items = r.Items() })
+	assert.Equal(t, []string{"c", "d", "e"}, items)
+
+	newest, _ := r.Newest()
+	assert.Equal(t, "e", newest)
+	oldest, _ := r.Peek()
+	assert.Equal(t, "c", oldest)
+}
+
+func TestRingBufferWrapAroundManyTimes(t *testing.T) {
+	r := NewRingBuffer[int](4)
+	require.NotPanics(t, func() {
+		// Keep three elements in flight so head travels around the ring repeatedly
+		next := 0
+		for i := 0; i < 50; i++ {
+			r.Push(i)
+			if r.Len() == 3 {
+				v, ok := r.Pop()
+				require.True(t, ok)
+				require.Equal(t, next, v)
+				next++
+			}
+		}
+		assert.Equal(t, []int{48, 49}, r.Items())
+		for r.Len() > 0 {
+			v, _ := r.Pop()
+			assert.Equal(t, next, v)
+			next++
+		}
+		assert.Equal(t, 50, next)
+	})
+}
+
+func TestRingBufferCapacityOne(t *testing.T) {
+	r := NewRingBuffer[int](0) // clamped to 1
+	assert.Equal(t, 1, r.Cap())
+	require.NotPanics(t, func() {
+		r.Push(1)
+		assert.True(t, r.Push(2))
+	})
+	assert.Equal(t, []int{2}, r.Items())
+}
+
+// BenchmarkRingBuffer measures steady-state push/pop. Unlike a growing slice
+// queue, a ring buffer never reallocates, so it reports zero allocations.
+func BenchmarkRingBuffer(b *testing.B) {
+	r := NewRingBuffer[int](1024)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r.Push(i)
+		if r.Full() {
+			r.Pop()
+		}
+	}
+}
+
+// BenchmarkSliceQueue is the naive alternative: append at the back and
+// reslice at the front, which keeps reallocating as the window slides.
+func BenchmarkSliceQueue(b *testing.B) {
+	var q []int
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		q = append(q, i)
+		if len(q) == 1024 {
+			q = q[1:]
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Package examples demonstrates trees and graphs in Go.
+//
+// This file shows a generic binary search tree:
+// - Type parameters with the cmp.Ordered constraint for keys
+// - Recursive insertion, lookup, and deletion
+// - In-order traversal with an early-exit visitor
+package examples
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// BST is a binary search tree mapping ordered keys to arbitrary payloads.
+// The zero value is an empty tree ready to use. It is not balanced, so
+// inserting sorted keys degrades it to a linked list.
+type BST[K cmp.Ordered, V any] struct {
+	root *bstNode[K, V]
+	size int
+}
+
+type bstNode[K cmp.Ordered, V any] struct {
+	key         K
+	value       V
+	left, right *bstNode[K, V]
+}
+
+// Put inserts key with value, replacing the value if key already exists.
+func (t *BST[K, V]) Put(key K, value V) {
+	t.root = t.put(t.root, key, value)
+}
+
+func (t *BST[K, V]) put(n *bstNode[K, V], key K, value V) *bstNode[K, V] {
+	if n == nil {
+		t.size++
+		return &bstNode[K, V]{key: key, value: value}
+	}
+	switch c := cmp.Compare(key, n.key); {
+	case c < 0:
+		n.left = t.put(n.left, key, value)
+	case c > 0:
+		n.right = t.put(n.right, key, value)
+	default:
+		n.value = value
+	}
+	return n
+}
+
+// Get returns the value stored for key.
+func (t *BST[K, V]) Get(key K) (V, bool) {
+	n := t.root
+	for n != nil {
+		switch c := cmp.Compare(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes key and reports whether it was present.
+func (t *BST[K, V]) Delete(key K) bool {
+	var deleted bool
+	t.root, deleted = t.delete(t.root, key)
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+func (t *BST[K, V]) delete(n *bstNode[K, V], key K) (*bstNode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var deleted bool
+	switch c := cmp.Compare(key, n.key); {
+	case c < 0:
+		n.left, deleted = t.delete(n.left, key)
+		return n, deleted
+	case c > 0:
+		n.right, deleted = t.delete(n.right, key)
+		return n, deleted
+	}
+
+	// Found it. Zero or one child: splice the node out.
+	if n.left == nil {
+		return n.right, true
+	}
+	if n.right == nil {
+		return n.left, true
+	}
+	// Two children: replace with the smallest key of the right subtree.
+	successor := n.right
+	for successor.left != nil {
+		successor = successor.left
+	}
+	n.key, n.value = successor.key, successor.value
+	n.right, _ = t.delete(n.right, successor.key)
+	return n, true
+}
+
+// Len returns the number of keys in the tree.
+func (t *BST[K, V]) Len() int { return t.size }
+
+// Height returns the number of levels; an empty tree has height 0.
+func (t *BST[K, V]) Height() int {
+	var height func(n *bstNode[K, V]) int
+	height = func(n *bstNode[K, V]) int {
+		if n == nil {
+			return 0
+		}
+		return 1 + max(height(n.left), height(n.right))
+	}
+	return height(t.root)
+}
+
+// InOrder calls visit for every key in ascending order. Returning false
+// from visit stops the traversal early.
+func (t *BST[K, V]) InOrder(visit func(key K, value V) bool) {
+	var walk func(n *bstNode[K, V]) bool
+	walk = func(n *bstNode[K, V]) bool {
+		if n == nil {
+			return true
+		}
+		return walk(n.left) && visit(n.key, n.value) && walk(n.right)
+	}
+	walk(t.root)
+}
+
+// Keys returns every key in ascending order.
+func (t *BST[K, V]) Keys() []K {
+	keys := make([]K, 0, t.size)
+	t.InOrder(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// DemonstrateBST builds a small tree of words to definitions.
+func DemonstrateBST() {
+	var glossary BST[string, string]
+	glossary.Put("goroutine", "a lightweight thread managed by the Go runtime")
+	glossary.Put("channel", "a typed conduit between goroutines")
+	glossary.Put("slice", "a view onto an underlying array")
+	glossary.Put("interface", "a set of method signatures")
+
+	fmt.Printf("Keys in order: %v\n", glossary.Keys())
+	if def, ok := glossary.Get("channel"); ok {
+		fmt.Printf("channel: %s\n", def)
+	}
+
+	glossary.Delete("slice")
+	fmt.Printf("After delete: %v (len %d, height %d)\n", glossary.Keys(), glossary.Len(), glossary.Height())
+
+	// Stop early: print only the first two keys
+	count := 0
+	glossary.InOrder(func(k, _ string) bool {
+		fmt.Printf("First keys: %s\n", k)
+		count++
+		return count < 2
+	})
+}
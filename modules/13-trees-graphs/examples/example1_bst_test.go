@@ -0,0 +1,88 @@
+package examples
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateBST(t *testing.T) {
+	DemonstrateBST()
+}
+
+func TestBSTPutGet(t *testing.T) {
+	var tree BST[int, string]
+	_, ok := tree.Get(1)
+	assert.False(t, ok)
+
+	tree.Put(2, "two")
+	tree.Put(1, "one")
+	tree.Put(3, "three")
+	tree.Put(2, "TWO")
+
+	v, ok := tree.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, "TWO", v)
+	assert.Equal(t, 3, tree.Len())
+	assert.Equal(t, []int{1, 2, 3}, tree.Keys())
+	assert.Equal(t, 2, tree.Height())
+}
+
+func TestBSTDelete(t *testing.T) {
+	var tree BST[int, int]
+	for _, k := range []int{50, 30, 70, 20, 40, 60, 80, 35} {
+		tree.Put(k, k*10)
+	}
+	assert.False(t, tree.Delete(99))
+	assert.True(t, tree.Delete(20)) // leaf
+	assert.True(t, tree.Delete(40)) // one child
+	assert.True(t, tree.Delete(50)) // two children (root)
+	assert.Equal(t, []int{30, 35, 60, 70, 80}, tree.Keys())
+	assert.Equal(t, 5, tree.Len())
+
+	v, ok := tree.Get(60)
+	assert.True(t, ok)
+	assert.Equal(t, 600, v)
+}
+
+func TestBSTInOrderStopsEarly(t *testing.T) {
+	var tree BST[int, struct{}]
+	for _, k := range []int{5, 3, 8, 1, 4} {
+		tree.Put(k, struct{}{})
+	}
+	var seen []int
+	tree.InOrder(func(k int, _ struct{}) bool {
+		seen = append(seen, k)
+		return k < 4
+	})
+	assert.Equal(t, []int{1, 3, 4}, seen)
+}
+
+// TestBSTProperties checks invariants against random inputs with testing/quick:
+// the keys come out sorted and unique, and every inserted key can be found.
+func TestBSTProperties(t *testing.T) {
+	property := func(keys []int16) bool {
+		var tree BST[int16, int]
+		for i, k := range keys {
+			tree.Put(k, i)
+		}
+		want := slices.Clone(keys)
+		slices.Sort(want)
+		want = slices.Compact(want)
+
+		if !slices.Equal(want, tree.Keys()) || tree.Len() != len(want) {
+			return false
+		}
+		for _, k := range keys {
+			if _, ok := tree.Get(k); !ok {
+				return false
+			}
+		}
+		return true
+	}
+	cfg := &quick.Config{MaxCount: 200, Rand: rand.New(rand.NewSource(1))}
+	assert.NoError(t, quick.Check(property, cfg))
+}
@@ -0,0 +1,182 @@
+package examples
+
+import (
+	"errors"
+	"fmt"
+)
+
+// GraphExamples demonstrates an adjacency-list graph with BFS, DFS, and
+// topological sorting. Nodes can be any comparable type: strings, ints,
+// or small structs.
+
+// ErrCycle is returned by TopologicalSort when the graph has a cycle.
+var ErrCycle = errors.New("graph has a cycle")
+
+// Graph is a directed graph stored as adjacency lists. Insertion order of
+// nodes and edges is preserved so traversals are deterministic.
+type Graph[T comparable] struct {
+	edges map[T][]T
+	order []T // nodes in insertion order
+}
+
+// NewGraph returns an empty directed graph.
+func NewGraph[T comparable]() *Graph[T] {
+	return &Graph[T]{edges: make(map[T][]T)}
+}
+
+// AddNode adds n if it is not already present.
+func (g *Graph[T]) AddNode(n T) {
+	if _, ok := g.edges[n]; !ok {
+		g.edges[n] = nil
+		g.order = append(g.order, n)
+	}
+}
+
+// AddEdge adds a directed edge from -> to, adding both nodes if needed.
+func (g *Graph[T]) AddEdge(from, to T) {
+	g.AddNode(from)
+	g.AddNode(to)
+	g.edges[from] = append(g.edges[from], to)
+}
+
+// Nodes returns every node in insertion order.
+func (g *Graph[T]) Nodes() []T {
+	return append([]T(nil), g.order...)
+}
+
+// Neighbors returns the nodes reachable from n by one edge.
+func (g *Graph[T]) Neighbors(n T) []T {
+	return g.edges[n]
+}
+
+// BFS visits nodes reachable from start in breadth-first order (closest
+// first). Nodes are marked visited when enqueued so each is seen once.
+func (g *Graph[T]) BFS(start T, visit func(T)) {
+	if _, ok := g.edges[start]; !ok {
+		return
+	}
+	visited := map[T]bool{start: true}
+	queue := []T{start}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		visit(n)
+		for _, next := range g.edges[n] {
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+}
+
+// DFS visits nodes reachable from start in depth-first pre-order.
+func (g *Graph[T]) DFS(start T, visit func(T)) {
+	if _, ok := g.edges[start]; !ok {
+		return
+	}
+	visited := make(map[T]bool)
+	var walk func(n T)
+	walk = func(n T) {
+		visited[n] = true
+		visit(n)
+		for _, next := range g.edges[n] {
+			if !visited[next] {
+				walk(next)
+			}
+		}
+	}
+	walk(start)
+}
+
+// ShortestPath returns the path with the fewest edges from -> to, or nil
+// if to is unreachable. BFS finds it because it explores by distance.
+func (g *Graph[T]) ShortestPath(from, to T) []T {
+	if _, ok := g.edges[from]; !ok {
+		return nil
+	}
+	parent := map[T]T{}
+	visited := map[T]bool{from: true}
+	queue := []T{from}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if n == to {
+			path := []T{to}
+			for path[0] != from {
+				path = append([]T{parent[path[0]]}, path...)
+			}
+			return path
+		}
+		for _, next := range g.edges[n] {
+			if !visited[next] {
+				visited[next] = true
+				parent[next] = n
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
+
+// TopologicalSort orders nodes so every edge points forward, using
+// Kahn's algorithm: repeatedly remove nodes with no incoming edges.
+// It returns ErrCycle if no such order exists.
+func (g *Graph[T]) TopologicalSort() ([]T, error) {
+	inDegree := make(map[T]int, len(g.order))
+	for _, n := range g.order {
+		for _, next := range g.edges[n] {
+			inDegree[next]++
+		}
+	}
+
+	var ready []T
+	for _, n := range g.order {
+		if inDegree[n] == 0 {
+			ready = append(ready, n)
+		}
+	}
+
+	sorted := make([]T, 0, len(g.order))
+	for len(ready) > 0 {
+		n := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, n)
+		for _, next := range g.edges[n] {
+			inDegree[next]--
+			if inDegree[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+
+	if len(sorted) != len(g.order) {
+		return nil, ErrCycle
+	}
+	return sorted, nil
+}
+
+// DemonstrateGraph models module prerequisites and sorts them.
+func DemonstrateGraph() {
+	g := NewGraph[string]()
+	g.AddEdge("basics", "types")
+	g.AddEdge("types", "concurrency")
+	g.AddEdge("basics", "errors")
+	g.AddEdge("errors", "testing")
+	g.AddEdge("concurrency", "advanced-concurrency")
+	g.AddEdge("testing", "advanced-concurrency")
+
+	var bfs, dfs []string
+	g.BFS("basics", func(n string) { bfs = append(bfs, n) })
+	g.DFS("basics", func(n string) { dfs = append(dfs, n) })
+	fmt.Printf("BFS: %v\n", bfs)
+	fmt.Printf("DFS: %v\n", dfs)
+	fmt.Printf("Shortest path: %v\n", g.ShortestPath("basics", "advanced-concurrency"))
+
+	order, err := g.TopologicalSort()
+	fmt.Printf("Study order: %v (err: %v)\n", order, err)
+
+	g.AddEdge("advanced-concurrency", "basics")
+	_, err = g.TopologicalSort()
+	fmt.Printf("With a cycle: %v\n", err)
+}
@@ -0,0 +1,70 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateGraph(t *testing.T) {
+	DemonstrateGraph()
+}
+
+// diamond builds a -> b, a -> c, b -> d, c -> d.
+func diamond() *Graph[string] {
+	g := NewGraph[string]()
+	g.AddEdge("a", "b")
+	g.AddEdge("a", "c")
+	g.AddEdge("b", "d")
+	g.AddEdge("c", "d")
+	return g
+}
+
+func TestGraphBFS(t *testing.T) {
+	var order []string
+	diamond().BFS("a", func(n string) { order = append(order, n) })
+	assert.Equal(t, []string{"a", "b", "c", "d"}, order)
+
+	order = nil
+	diamond().BFS("missing", func(n string) { order = append(order, n) })
+	assert.Empty(t, order)
+}
+
+func TestGraphDFS(t *testing.T) {
+	var order []string
+	diamond().DFS("a", func(n string) { order = append(order, n) })
+	assert.Equal(t, []string{"a", "b", "d", "c"}, order)
+}
+
+func TestGraphTraversalsHandleCycles(t *testing.T) {
+	g := NewGraph[int]()
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 1)
+
+	var bfs, dfs []int
+	g.BFS(1, func(n int) { bfs = append(bfs, n) })
+	g.DFS(1, func(n int) { dfs = append(dfs, n) })
+	assert.Equal(t, []int{1, 2, 3}, bfs)
+	assert.Equal(t, []int{1, 2, 3}, dfs)
+}
+
+func TestGraphShortestPath(t *testing.T) {
+	g := diamond()
+	g.AddEdge("a", "d")
+	assert.Equal(t, []string{"a", "d"}, g.ShortestPath("a", "d"))
+	assert.Equal(t, []string{"a"}, g.ShortestPath("a", "a"))
+	assert.Nil(t, g.ShortestPath("d", "a"))
+}
+
+func TestGraphTopologicalSort(t *testing.T) {
+	order, err := diamond().TopologicalSort()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, order)
+
+	g := diamond()
+	g.AddEdge("d", "a")
+	_, err = g.TopologicalSort()
+	assert.ErrorIs(t, err, ErrCycle)
+}
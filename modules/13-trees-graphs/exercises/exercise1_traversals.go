@@ -0,0 +1,212 @@
+package exercises
+
+// EXERCISE: Fix the tree and graph traversals.
+// Each traversal below has a subtle bug that small hand-written tests can
+// miss. The tests check properties over hundreds of random inputs instead
+// (in-order output is sorted, BFS never repeats a node, every edge points
+// forward in a topological order). Fix the bugs marked with // BUG: comments.
+
+import (
+	"cmp"
+	"errors"
+)
+
+// Tree is a binary search tree of unique ordered values.
+type Tree[T cmp.Ordered] struct {
+	root *treeNode[T]
+	size int
+}
+
+type treeNode[T cmp.Ordered] struct {
+	value       T
+	left, right *treeNode[T]
+}
+
+// Insert adds v to the tree. Inserting a value that is already present
+// does nothing and returns false.
+// BUG: Equal values are sent to the right subtree, creating duplicates.
+func (t *Tree[T]) Insert(v T) bool {
+	link := &t.root
+	for *link != nil {
+		n := *link
+		if v < n.value {
+			link = &n.left
+		} else { // BUG: Missing the v == n.value case, which should return false
+			link = &n.right
+		}
+	}
+	*link = &treeNode[T]{value: v}
+	t.size++
+	return true
+}
+
+// Len returns the number of values in the tree.
+func (t *Tree[T]) Len() int { return t.size }
+
+// InOrder returns the values in ascending order: left, node, right.
+// BUG: Visits the node before its left subtree.
+func (t *Tree[T]) InOrder() []T {
+	var out []T
+	var walk func(n *treeNode[T])
+	walk = func(n *treeNode[T]) {
+		if n == nil {
+			return
+		}
+		out = append(out, n.value) // BUG: Should come after walk(n.left)
+		walk(n.left)
+		walk(n.right)
+	}
+	walk(t.root)
+	return out
+}
+
+// PreOrder returns the values node first: node, left, right.
+// Re-inserting a pre-order listing into an empty tree rebuilds the same shape.
+func (t *Tree[T]) PreOrder() []T {
+	var out []T
+	var walk func(n *treeNode[T])
+	walk = func(n *treeNode[T]) {
+		if n == nil {
+			return
+		}
+		out = append(out, n.value)
+		walk(n.left)
+		walk(n.right)
+	}
+	walk(t.root)
+	return out
+}
+
+// PostOrder returns the values children first: left, right, node.
+// The root is always last.
+// BUG: Visits the node between its subtrees.
+func (t *Tree[T]) PostOrder() []T {
+	var out []T
+	var walk func(n *treeNode[T])
+	walk = func(n *treeNode[T]) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		out = append(out, n.value) // BUG: Should come after walk(n.right)
+		walk(n.right)
+	}
+	walk(t.root)
+	return out
+}
+
+// LevelOrder returns the values level by level, left to right.
+// BUG: Uses the slice as a stack (LIFO) instead of a queue (FIFO).
+func (t *Tree[T]) LevelOrder() []T {
+	var out []T
+	if t.root == nil {
+		return out
+	}
+	pending := []*treeNode[T]{t.root}
+	for len(pending) > 0 {
+		n := pending[len(pending)-1]       // BUG: Should take pending[0] ...
+		pending = pending[:len(pending)-1] // ... and reslice with pending[1:]
+		out = append(out, n.value)
+		if n.left != nil {
+			pending = append(pending, n.left)
+		}
+		if n.right != nil {
+			pending = append(pending, n.right)
+		}
+	}
+	return out
+}
+
+// ErrCycle is returned by TopologicalSort when the graph has a cycle.
+var ErrCycle = errors.New("graph has a cycle")
+
+// Graph is a directed graph stored as adjacency lists.
+type Graph[T comparable] struct {
+	edges map[T][]T
+	nodes []T // insertion order, for deterministic traversals
+}
+
+// NewGraph returns an empty directed graph.
+func NewGraph[T comparable]() *Graph[T] {
+	return &Graph[T]{edges: make(map[T][]T)}
+}
+
+// AddEdge adds a directed edge from -> to, adding both nodes if needed.
+func (g *Graph[T]) AddEdge(from, to T) {
+	g.AddNode(from)
+	g.AddNode(to)
+	g.edges[from] = append(g.edges[from], to)
+}
+
+// AddNode adds n if it is not already present.
+func (g *Graph[T]) AddNode(n T) {
+	if _, ok := g.edges[n]; !ok {
+		g.edges[n] = nil
+		g.nodes = append(g.nodes, n)
+	}
+}
+
+// BFS returns every node reachable from start, each exactly once, in
+// breadth-first order.
+// BUG: Nodes are marked visited when dequeued, so a node reachable along
+// several paths is enqueued (and returned) several times.
+func (g *Graph[T]) BFS(start T) []T {
+	if _, ok := g.edges[start]; !ok {
+		return nil
+	}
+	var out []T
+	visited := make(map[T]bool)
+	queue := []T{start}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		visited[n] = true // BUG: Mark start before the loop and neighbors when enqueued
+		out = append(out, n)
+		for _, next := range g.edges[n] {
+			if !visited[next] {
+				queue = append(queue, next)
+			}
+		}
+	}
+	return out
+}
+
+// TopologicalSort orders the nodes so that every edge points forward,
+// using depth-first search: a node is finished after all of its
+// descendants, so reversing the finish order yields a valid ordering.
+// It returns ErrCycle if the graph has a cycle.
+// BUG: Cycles are not detected, and the finish order is never reversed.
+func (g *Graph[T]) TopologicalSort() ([]T, error) {
+	const (
+		unvisited = iota
+		visiting  // on the current DFS path
+		done
+	)
+	state := make(map[T]int, len(g.nodes))
+	finished := make([]T, 0, len(g.nodes))
+
+	var visit func(n T) error
+	visit = func(n T) error {
+		switch state[n] {
+		case done, visiting: // BUG: Reaching a "visiting" node means we found a cycle
+			return nil
+		}
+		state[n] = visiting
+		for _, next := range g.edges[n] {
+			if err := visit(next); err != nil {
+				return err
+			}
+		}
+		state[n] = done
+		finished = append(finished, n)
+		return nil
+	}
+
+	for _, n := range g.nodes {
+		if err := visit(n); err != nil {
+			return nil, err
+		}
+	}
+	// BUG: Missing: reverse finished before returning it
+	return finished, nil
+}
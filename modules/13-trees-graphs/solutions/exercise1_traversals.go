@@ -0,0 +1,209 @@
+package solutions
+
+// SOLUTION: Tree and graph traversals that satisfy their properties:
+// in-order output is sorted, BFS never repeats a node, and every edge
+// points forward in a topological order.
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+)
+
+// Tree is a binary search tree of unique ordered values.
+type Tree[T cmp.Ordered] struct {
+	root *treeNode[T]
+	size int
+}
+
+type treeNode[T cmp.Ordered] struct {
+	value       T
+	left, right *treeNode[T]
+}
+
+// Insert adds v to the tree. Inserting a value that is already present
+// does nothing and returns false.
+func (t *Tree[T]) Insert(v T) bool {
+	link := &t.root
+	for *link != nil {
+		n := *link
+		switch {
+		case v < n.value:
+			link = &n.left
+		case v > n.value:
+			link = &n.right
+		default:
+			return false // Fixed: Duplicates are ignored
+		}
+	}
+	*link = &treeNode[T]{value: v}
+	t.size++
+	return true
+}
+
+// Len returns the number of values in the tree.
+func (t *Tree[T]) Len() int { return t.size }
+
+// InOrder returns the values in ascending order: left, node, right.
+func (t *Tree[T]) InOrder() []T {
+	var out []T
+	var walk func(n *treeNode[T])
+	walk = func(n *treeNode[T]) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		out = append(out, n.value) // Fixed: Between the subtrees
+		walk(n.right)
+	}
+	walk(t.root)
+	return out
+}
+
+// PreOrder returns the values node first: node, left, right.
+// Re-inserting a pre-order listing into an empty tree rebuilds the same shape.
+func (t *Tree[T]) PreOrder() []T {
+	var out []T
+	var walk func(n *treeNode[T])
+	walk = func(n *treeNode[T]) {
+		if n == nil {
+			return
+		}
+		out = append(out, n.value)
+		walk(n.left)
+		walk(n.right)
+	}
+	walk(t.root)
+	return out
+}
+
+// PostOrder returns the values children first: left, right, node.
+// The root is always last.
+func (t *Tree[T]) PostOrder() []T {
+	var out []T
+	var walk func(n *treeNode[T])
+	walk = func(n *treeNode[T]) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		walk(n.right)
+		out = append(out, n.value) // Fixed: After both subtrees
+	}
+	walk(t.root)
+	return out
+}
+
+// LevelOrder returns the values level by level, left to right.
+func (t *Tree[T]) LevelOrder() []T {
+	var out []T
+	if t.root == nil {
+		return out
+	}
+	pending := []*treeNode[T]{t.root}
+	for len(pending) > 0 {
+		n := pending[0] // Fixed: FIFO - oldest first
+		pending = pending[1:]
+		out = append(out, n.value)
+		if n.left != nil {
+			pending = append(pending, n.left)
+		}
+		if n.right != nil {
+			pending = append(pending, n.right)
+		}
+	}
+	return out
+}
+
+// ErrCycle is returned by TopologicalSort when the graph has a cycle.
+var ErrCycle = errors.New("graph has a cycle")
+
+// Graph is a directed graph stored as adjacency lists.
+type Graph[T comparable] struct {
+	edges map[T][]T
+	nodes []T // insertion order, for deterministic traversals
+}
+
+// NewGraph returns an empty directed graph.
+func NewGraph[T comparable]() *Graph[T] {
+	return &Graph[T]{edges: make(map[T][]T)}
+}
+
+// AddEdge adds a directed edge from -> to, adding both nodes if needed.
+func (g *Graph[T]) AddEdge(from, to T) {
+	g.AddNode(from)
+	g.AddNode(to)
+	g.edges[from] = append(g.edges[from], to)
+}
+
+// AddNode adds n if it is not already present.
+func (g *Graph[T]) AddNode(n T) {
+	if _, ok := g.edges[n]; !ok {
+		g.edges[n] = nil
+		g.nodes = append(g.nodes, n)
+	}
+}
+
+// BFS returns every node reachable from start, each exactly once, in
+// breadth-first order.
+func (g *Graph[T]) BFS(start T) []T {
+	if _, ok := g.edges[start]; !ok {
+		return nil
+	}
+	var out []T
+	visited := map[T]bool{start: true}
+	queue := []T{start}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		out = append(out, n)
+		for _, next := range g.edges[n] {
+			if !visited[next] {
+				visited[next] = true // Fixed: Mark when enqueued
+				queue = append(queue, next)
+			}
+		}
+	}
+	return out
+}
+
+// TopologicalSort orders the nodes so that every edge points forward,
+// using depth-first search: a node is finished after all of its
+// descendants, so reversing the finish order yields a valid ordering.
+// It returns ErrCycle if the graph has a cycle.
+func (g *Graph[T]) TopologicalSort() ([]T, error) {
+	const (
+		unvisited = iota
+		visiting  // on the current DFS path
+		done
+	)
+	state := make(map[T]int, len(g.nodes))
+	finished := make([]T, 0, len(g.nodes))
+
+	var visit func(n T) error
+	visit = func(n T) error {
+		switch state[n] {
+		case done:
+			return nil
+		case visiting:
+			return ErrCycle // Fixed: A back edge to the current path is a cycle
+		}
+		state[n] = visiting
+		for _, next := range g.edges[n] {
+			if err := visit(next); err != nil {
+				return err
+			}
+		}
+		state[n] = done
+		finished = append(finished, n)
+		return nil
+	}
+
+	for _, n := range g.nodes {
+		if err := visit(n); err != nil {
+			return nil, err
+		}
+	}
+	slices.Reverse(finished) // Fixed: Reverse finish order
+	return finished, nil
+}
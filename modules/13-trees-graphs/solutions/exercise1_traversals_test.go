@@ -0,0 +1,226 @@
+package solutions
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+	"testing/quick"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// quickConfig runs each property against 300 random inputs. They depend
+// only on your learner seed, so failures are reproducible.
+func quickConfig(t *testing.T) *quick.Config {
+	return &quick.Config{MaxCount: 300, Rand: seed.Rand(t, 42)}
+}
+
+func buildTree(values []int8) *Tree[int8] {
+	var tree Tree[int8]
+	for _, v := range values {
+		tree.Insert(v)
+	}
+	return &tree
+}
+
+func TestTreeExample(t *testing.T) {
+	//        4
+	//      /   \
+	//     2     6
+	//    / \   / \
+	//   1   3 5   7
+	tree := buildTree([]int8{4, 2, 6, 1, 3, 5, 7})
+	assert.Equal(t, []int8{1, 2, 3, 4, 5, 6, 7}, tree.InOrder())
+	assert.Equal(t, []int8{4, 2, 1, 3, 6, 5, 7}, tree.PreOrder())
+	assert.Equal(t, []int8{1, 3, 2, 5, 7, 6, 4}, tree.PostOrder())
+	assert.Equal(t, []int8{4, 2, 6, 1, 3, 5, 7}, tree.LevelOrder())
+	assert.False(t, tree.Insert(4), "duplicates are rejected")
+	assert.Equal(t, 7, tree.Len())
+}
+
+func TestTreeEmpty(t *testing.T) {
+	var tree Tree[string]
+	assert.Empty(t, tree.InOrder())
+	assert.Empty(t, tree.PreOrder())
+	assert.Empty(t, tree.PostOrder())
+	assert.Empty(t, tree.LevelOrder())
+}
+
+func TestPropertyInOrderIsSortedAndUnique(t *testing.T) {
+	property := func(values []int8) bool {
+		tree := buildTree(values)
+		want := slices.Clone(values)
+		slices.Sort(want)
+		want = slices.Compact(want)
+		return slices.Equal(want, tree.InOrder()) && tree.Len() == len(want)
+	}
+	assert.NoError(t, quick.Check(property, quickConfig(t)))
+}
+
+func TestPropertyPreOrderRebuildsSameTree(t *testing.T) {
+	property := func(values []int8) bool {
+		tree := buildTree(values)
+		rebuilt := buildTree(tree.PreOrder())
+		return slices.Equal(tree.PreOrder(), rebuilt.PreOrder())
+	}
+	assert.NoError(t, quick.Check(property, quickConfig(t)))
+}
+
+func TestPropertyPostOrderEndsWithRoot(t *testing.T) {
+	property := func(values []int8) bool {
+		if len(values) == 0 {
+			return true
+		}
+		tree := buildTree(values)
+		post := tree.PostOrder()
+		return len(post) == tree.Len() && post[len(post)-1] == values[0]
+	}
+	assert.NoError(t, quick.Check(property, quickConfig(t)))
+}
+
+func TestPropertyLevelOrderIsByDepth(t *testing.T) {
+	depthOf := func(tree *Tree[int8], v int8) int {
+		depth := 0
+		for n := tree.root; n.value != v; depth++ {
+			if v < n.value {
+				n = n.left
+			} else {
+				n = n.right
+			}
+		}
+		return depth
+	}
+	property := func(values []int8) bool {
+		tree := buildTree(values)
+		level := tree.LevelOrder()
+		if len(level) != tree.Len() {
+			return false
+		}
+		for i := 1; i < len(level); i++ {
+			if depthOf(tree, level[i-1]) > depthOf(tree, level[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	assert.NoError(t, quick.Check(property, quickConfig(t)))
+}
+
+// randomDAG returns a graph over nodes 0..n-1 whose edges only go from a
+// lower to a higher number, so it cannot contain a cycle. Nodes are added
+// in a shuffled order so the insertion order gives nothing away.
+func randomDAG(r *rand.Rand, n int) (*Graph[int], [][2]int) {
+	g := NewGraph[int]()
+	for _, node := range r.Perm(n) {
+		g.AddNode(node)
+	}
+	var edges [][2]int
+	for from := 0; from < n; from++ {
+		for to := from + 1; to < n; to++ {
+			if r.Intn(3) == 0 {
+				g.AddEdge(from, to)
+				edges = append(edges, [2]int{from, to})
+			}
+		}
+	}
+	return g, edges
+}
+
+func TestBFSExample(t *testing.T) {
+	g := NewGraph[string]()
+	g.AddEdge("a", "b")
+	g.AddEdge("a", "c")
+	g.AddEdge("b", "d")
+	g.AddEdge("c", "d")
+	g.AddEdge("d", "a")
+	assert.Equal(t, []string{"a", "b", "c", "d"}, g.BFS("a"))
+	assert.Nil(t, g.BFS("missing"))
+}
+
+func TestPropertyBFSVisitsReachableNodesOnce(t *testing.T) {
+	r := seed.Rand(t, 7)
+	for i := 0; i < 200; i++ {
+		g, _ := randomDAG(r, 2+r.Intn(12))
+		order := g.BFS(0)
+
+		seen := make(map[int]bool)
+		for _, n := range order {
+			require.False(t, seen[n], "node %d visited twice in %v", n, order)
+			seen[n] = true
+		}
+
+		// Everything reachable must be present
+		reachable := map[int]bool{0: true}
+		stack := []int{0}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, next := range g.edges[n] {
+				if !reachable[next] {
+					reachable[next] = true
+					stack = append(stack, next)
+				}
+			}
+		}
+		require.Equal(t, len(reachable), len(order))
+	}
+}
+
+func TestTopologicalSortExample(t *testing.T) {
+	g := NewGraph[string]()
+	g.AddEdge("shirt", "tie")
+	g.AddEdge("tie", "jacket")
+	g.AddEdge("trousers", "shoes")
+	g.AddEdge("trousers", "belt")
+	g.AddEdge("belt", "jacket")
+
+	order, err := g.TopologicalSort()
+	require.NoError(t, err)
+	pos := make(map[string]int)
+	for i, n := range order {
+		pos[n] = i
+	}
+	assert.Len(t, order, 6)
+	assert.Less(t, pos["shirt"], pos["tie"])
+	assert.Less(t, pos["tie"], pos["jacket"])
+	assert.Less(t, pos["belt"], pos["jacket"])
+	assert.Less(t, pos["trousers"], pos["shoes"])
+}
+
+func TestPropertyTopologicalSortRespectsEdges(t *testing.T) {
+	r := seed.Rand(t, 99)
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(15)
+		g, edges := randomDAG(r, n)
+
+		order, err := g.TopologicalSort()
+		require.NoError(t, err)
+		require.Len(t, order, n)
+
+		pos := make(map[int]int, n)
+		for i, node := range order {
+			pos[node] = i
+		}
+		for _, e := range edges {
+			require.Less(t, pos[e[0]], pos[e[1]], "edge %d -> %d points backwards in %v", e[0], e[1], order)
+		}
+	}
+}
+
+func TestPropertyTopologicalSortDetectsCycles(t *testing.T) {
+	r := seed.Rand(t, 5)
+	for i := 0; i < 200; i++ {
+		n := 2 + r.Intn(10)
+		g, _ := randomDAG(r, n)
+		// Close a cycle through a path that is guaranteed to exist
+		for k := 0; k < n-1; k++ {
+			g.AddEdge(k, k+1)
+		}
+		g.AddEdge(n-1, 0)
+
+		_, err := g.TopologicalSort()
+		require.ErrorIs(t, err, ErrCycle)
+	}
+}
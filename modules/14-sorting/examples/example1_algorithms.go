@@ -0,0 +1,89 @@
+// Package examples demonstrates sorting in Go.
+//
+// This file shows three classic algorithms written once with type
+// parameters and a less function:
+// - Insertion sort: simple, stable, fast for tiny or nearly sorted input
+// - Merge sort: O(n log n), stable, needs O(n) extra space
+// - Quicksort: O(n log n) on average, in place, not stable
+package examples
+
+import "fmt"
+
+// InsertionSort sorts s in place. It is stable: equal elements keep their
+// relative order because an element only moves past strictly greater ones.
+func InsertionSort[T any](s []T, less func(a, b T) bool) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && less(s[j], s[j-1]); j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+		}
+	}
+}
+
+// MergeSort returns a sorted copy of s. It is stable because merge takes
+// from the left half when elements compare equal.
+func MergeSort[T any](s []T, less func(a, b T) bool) []T {
+	if len(s) <= 1 {
+		return append(make([]T, 0, len(s)), s...)
+	}
+	mid := len(s) / 2
+	left := MergeSort(s[:mid], less)
+	right := MergeSort(s[mid:], less)
+
+	merged := make([]T, 0, len(s))
+	i, j := 0, 0
+	for i < len(left) && j < len(right) {
+		if less(right[j], left[i]) {
+			merged = append(merged, right[j])
+			j++
+		} else {
+			merged = append(merged, left[i]) // ties go left: stability
+			i++
+		}
+	}
+	merged = append(merged, left[i:]...)
+	return append(merged, right[j:]...)
+}
+
+// QuickSort sorts s in place using Lomuto partitioning around the middle
+// element. Swapping elements across the array makes it unstable.
+func QuickSort[T any](s []T, less func(a, b T) bool) {
+	if len(s) <= 1 {
+		return
+	}
+	mid := len(s) / 2
+	s[mid], s[len(s)-1] = s[len(s)-1], s[mid] // middle pivot avoids O(n²) on sorted input
+	pivot := s[len(s)-1]
+
+	store := 0
+	for i := 0; i < len(s)-1; i++ {
+		if less(s[i], pivot) {
+			s[i], s[store] = s[store], s[i]
+			store++
+		}
+	}
+	s[store], s[len(s)-1] = s[len(s)-1], s[store]
+
+	QuickSort(s[:store], less)
+	QuickSort(s[store+1:], less)
+}
+
+// DemonstrateAlgorithms sorts the same data with each algorithm.
+func DemonstrateAlgorithms() {
+	less := func(a, b int) bool { return a < b }
+
+	data := []int{5, 2, 9, 1, 5, 6}
+	insertion := append([]int(nil), data...)
+	InsertionSort(insertion, less)
+	fmt.Printf("Insertion sort: %v\n", insertion)
+
+	fmt.Printf("Merge sort:     %v (original untouched: %v)\n", MergeSort(data, less), data)
+
+	quick := append([]int(nil), data...)
+	QuickSort(quick, less)
+	fmt.Printf("Quicksort:      %v\n", quick)
+
+	// The same functions sort anything - here, strings by length
+	words := []string{"banana", "fig", "apple", "kiwi"}
+	InsertionSort(words, func(a, b string) bool { return len(a) < len(b) })
+	fmt.Printf("Words by length: %v\n", words)
+}
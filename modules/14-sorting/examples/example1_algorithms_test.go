@@ -0,0 +1,59 @@
+package examples
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateAlgorithms(t *testing.T) {
+	DemonstrateAlgorithms()
+}
+
+func intLess(a, b int) bool { return a < b }
+
+func TestAlgorithmsSortRandomInput(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 60; n++ {
+		data := make([]int, n)
+		for i := range data {
+			data[i] = r.Intn(20) // small range forces duplicates
+		}
+		want := slices.Clone(data)
+		slices.Sort(want)
+
+		insertion := slices.Clone(data)
+		InsertionSort(insertion, intLess)
+		assert.Equal(t, want, insertion, "insertion n=%d", n)
+
+		merged := MergeSort(data, intLess)
+		assert.Equal(t, want, merged, "merge n=%d", n)
+
+		quick := slices.Clone(data)
+		QuickSort(quick, intLess)
+		assert.Equal(t, want, quick, "quick n=%d", n)
+	}
+}
+
+func TestMergeSortDoesNotModifyInput(t *testing.T) {
+	data := []int{3, 1, 2}
+	MergeSort(data, intLess)
+	assert.Equal(t, []int{3, 1, 2}, data)
+}
+
+type tagged struct {
+	key, seq int
+}
+
+func TestStableAlgorithmsKeepEqualElementsInOrder(t *testing.T) {
+	data := []tagged{{2, 0}, {1, 1}, {2, 2}, {1, 3}, {2, 4}, {0, 5}}
+	byKey := func(a, b tagged) bool { return a.key < b.key }
+	want := []tagged{{0, 5}, {1, 1}, {1, 3}, {2, 0}, {2, 2}, {2, 4}}
+
+	insertion := slices.Clone(data)
+	InsertionSort(insertion, byKey)
+	assert.Equal(t, want, insertion)
+	assert.Equal(t, want, MergeSort(data, byKey))
+}
@@ -0,0 +1,94 @@
+package examples
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// StdlibSortingExamples contrasts the two standard library approaches.
+//
+// sort.Interface (since Go 1.0) asks a type for Len, Less, and Swap.
+// slices.SortFunc (since Go 1.21) takes a slice and a three-way compare
+// function returning negative, zero, or positive - usually built with
+// cmp.Compare. Both have a Stable variant; the plain versions are not stable.
+
+// Employee is a record sorted by several keys in the examples.
+type Employee struct {
+	Name       string
+	Department string
+	Salary     int
+}
+
+// BySalary implements sort.Interface for []Employee, ordering by salary.
+type BySalary []Employee
+
+func (s BySalary) Len() int           { return len(s) }
+func (s BySalary) Less(i, j int) bool { return s[i].Salary < s[j].Salary }
+func (s BySalary) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// SampleEmployees returns data with duplicate departments and salaries,
+// listed alphabetically by name, for stability demonstrations.
+func SampleEmployees() []Employee {
+	return []Employee{
+		{"Alice", "Engineering", 120},
+		{"Bob", "Sales", 90},
+		{"Carol", "Engineering", 110},
+		{"Dave", "Sales", 90},
+		{"Eve", "Support", 80},
+		{"Frank", "Engineering", 120},
+		{"Grace", "Support", 80},
+	}
+}
+
+// SortBySalarySortInterface sorts with sort.Stable and the BySalary type.
+func SortBySalarySortInterface(staff []Employee) {
+	sort.Stable(BySalary(staff))
+}
+
+// SortBySalarySlices sorts with slices.SortStableFunc and cmp.Compare.
+func SortBySalarySlices(staff []Employee) {
+	slices.SortStableFunc(staff, func(a, b Employee) int {
+		return cmp.Compare(a.Salary, b.Salary)
+	})
+}
+
+// SortByDepartmentThenSalaryDesc shows a multi-key comparison: each key is
+// consulted only when all previous keys compare equal.
+func SortByDepartmentThenSalaryDesc(staff []Employee) {
+	slices.SortFunc(staff, func(a, b Employee) int {
+		if c := strings.Compare(a.Department, b.Department); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Salary, a.Salary); c != 0 { // b before a: descending
+			return c
+		}
+		return strings.Compare(a.Name, b.Name) // total order: no ties left
+	})
+}
+
+// DemonstrateStability shows why Stable matters: sorting by department
+// after sorting by name keeps names alphabetical within each department.
+func DemonstrateStability() {
+	staff := SampleEmployees() // already sorted by name
+
+	slices.SortStableFunc(staff, func(a, b Employee) int {
+		return strings.Compare(a.Department, b.Department)
+	})
+	fmt.Println("Stable sort by department (names stay alphabetical):")
+	for _, e := range staff {
+		fmt.Printf("  %-12s %s\n", e.Department, e.Name)
+	}
+
+	fmt.Println("sort.Interface vs slices.SortFunc give the same stable order:")
+	a, b := SampleEmployees(), SampleEmployees()
+	SortBySalarySortInterface(a)
+	SortBySalarySlices(b)
+	fmt.Printf("  equal: %v\n", slices.Equal(a, b))
+
+	fmt.Printf("Is sorted by salary? %v\n", slices.IsSortedFunc(b, func(x, y Employee) int {
+		return cmp.Compare(x.Salary, y.Salary)
+	}))
+}
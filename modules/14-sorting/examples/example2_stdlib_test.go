@@ -0,0 +1,34 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateStability(t *testing.T) {
+	DemonstrateStability()
+}
+
+func names(staff []Employee) []string {
+	out := make([]string, len(staff))
+	for i, e := range staff {
+		out[i] = e.Name
+	}
+	return out
+}
+
+func TestSortBySalaryVariantsAgree(t *testing.T) {
+	a, b := SampleEmployees(), SampleEmployees()
+	SortBySalarySortInterface(a)
+	SortBySalarySlices(b)
+	assert.Equal(t, a, b)
+	// Stable: ties keep alphabetical input order
+	assert.Equal(t, []string{"Eve", "Grace", "Bob", "Dave", "Carol", "Alice", "Frank"}, names(a))
+}
+
+func TestSortByDepartmentThenSalaryDesc(t *testing.T) {
+	staff := SampleEmployees()
+	SortByDepartmentThenSalaryDesc(staff)
+	assert.Equal(t, []string{"Alice", "Frank", "Carol", "Bob", "Dave", "Eve", "Grace"}, names(staff))
+}
@@ -0,0 +1,67 @@
+package exercises
+
+// EXERCISE: Fix sorts that must be stable but are not.
+// A stable sort keeps equal elements in their original order. That matters
+// whenever the input order carries meaning (submission time) or when you
+// sort in several passes (by name, then by team). The standard library's
+// sort.Slice, sort.Sort, and slices.SortFunc are NOT stable; small inputs
+// often look fine by accident, so the tests use hundreds of ties.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Submission is one leaderboard entry. Submissions arrive in chronological
+// order, so a slice of them is already sorted by time.
+type Submission struct {
+	Player string
+	Score  int
+}
+
+// RankSubmissions orders subs by score, highest first. Equal scores are
+// ranked by who submitted first, i.e. they keep their input order.
+// BUG: sort.Slice is not stable, so tied players can swap places.
+func RankSubmissions(subs []Submission) {
+	sort.Slice(subs, func(i, j int) bool { // BUG: Use sort.SliceStable
+		return subs[i].Score > subs[j].Score
+	})
+}
+
+// ByScoreDesc implements sort.Interface, ordering by score, highest first.
+type ByScoreDesc []Submission
+
+func (s ByScoreDesc) Len() int      { return len(s) }
+func (s ByScoreDesc) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// Less must be a strict ordering: it returns false for equal elements.
+// BUG: >= reports equal scores as "less", so even a stable sort reorders ties.
+func (s ByScoreDesc) Less(i, j int) bool {
+	return s[i].Score >= s[j].Score // BUG: Should be >
+}
+
+// RankWithInterface does the same as RankSubmissions via sort.Stable.
+func RankWithInterface(subs []Submission) {
+	sort.Stable(ByScoreDesc(subs))
+}
+
+// Player belongs to a team.
+type Player struct {
+	Name string
+	Team string
+}
+
+// GroupByTeam orders players by team and alphabetically within each team,
+// using two passes: first by name, then by team. The second pass only
+// preserves the first pass's order if it is stable.
+// BUG: The second pass uses an unstable sort.
+func GroupByTeam(players []Player) {
+	slices.SortFunc(players, func(a, b Player) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	slices.SortFunc(players, func(a, b Player) int { // BUG: Use slices.SortStableFunc
+		return strings.Compare(a.Team, b.Team)
+	})
+}
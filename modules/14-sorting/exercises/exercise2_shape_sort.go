@@ -0,0 +1,70 @@
+package exercises
+
+// EXERCISE: Sort shapes by area and perimeter with sort.Interface and slices.SortFunc alike.
+// The same ordering can be written twice: as a sort.Interface type whose
+// Less reports whether one element goes before another, and as a
+// three-way compare function for slices.SortFunc, built with cmp.Compare.
+// Callers may use either, so the two must agree on every pair of shapes,
+// ties included: shapes of equal size keep their order with both.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// ByArea implements sort.Interface for shapes, smallest area first.
+type ByArea []geometry.Shape
+
+func (s ByArea) Len() int           { return len(s) }
+func (s ByArea) Less(i, j int) bool { return s[i].Area() < s[j].Area() }
+func (s ByArea) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// ByPerimeter implements sort.Interface for shapes, shortest perimeter
+// first.
+type ByPerimeter []geometry.Shape
+
+func (s ByPerimeter) Len() int { return len(s) }
+func (s ByPerimeter) Less(i, j int) bool {
+	return s[i].Perimeter() < s[j].Area() // BUG: Compares a perimeter with an area
+}
+func (s ByPerimeter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// CompareArea is ByArea's order as a three-way compare function for
+// slices.SortFunc: negative if a is smaller, zero if equal, positive if
+// larger.
+func CompareArea(a, b geometry.Shape) int {
+	// BUG: Converting the difference to int rounds anything under 1 to 0, so
+	// shapes whose areas differ by less than 1 compare as equal.
+	return int(a.Area() - b.Area())
+}
+
+// ComparePerimeter is ByPerimeter's order as a compare function.
+func ComparePerimeter(a, b geometry.Shape) int {
+	return cmp.Compare(b.Perimeter(), a.Perimeter()) // BUG: Descending, unlike ByPerimeter
+}
+
+// SortByArea sorts shapes by area with sort.Stable; shapes of equal area
+// keep their order.
+func SortByArea(shapes []geometry.Shape) {
+	sort.Stable(ByArea(shapes))
+}
+
+// SortByAreaFunc does the same as SortByArea with slices.SortStableFunc.
+func SortByAreaFunc(shapes []geometry.Shape) {
+	slices.SortStableFunc(shapes, CompareArea)
+}
+
+// SortByPerimeter sorts shapes by perimeter with sort.Stable.
+func SortByPerimeter(shapes []geometry.Shape) {
+	sort.Stable(ByPerimeter(shapes))
+}
+
+// SortByPerimeterFunc does the same as SortByPerimeter with
+// slices.SortStableFunc.
+func SortByPerimeterFunc(shapes []geometry.Shape) {
+	slices.SortFunc(shapes, ComparePerimeter) // BUG: Not stable, unlike sort.Stable above
+}
@@ -0,0 +1,60 @@
+package solutions
+
+// SOLUTION: Stable sorts wherever input order carries meaning.
+// sort.SliceStable, sort.Stable, and slices.SortStableFunc keep equal
+// elements in their original order; their unstable counterparts do not.
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Submission is one leaderboard entry. Submissions arrive in chronological
+// order, so a slice of them is already sorted by time.
+type Submission struct {
+	Player string
+	Score  int
+}
+
+// RankSubmissions orders subs by score, highest first. Equal scores are
+// ranked by who submitted first, i.e. they keep their input order.
+func RankSubmissions(subs []Submission) {
+	sort.SliceStable(subs, func(i, j int) bool { // Fixed: Stable keeps ties in submission order
+		return subs[i].Score > subs[j].Score
+	})
+}
+
+// ByScoreDesc implements sort.Interface, ordering by score, highest first.
+type ByScoreDesc []Submission
+
+func (s ByScoreDesc) Len() int      { return len(s) }
+func (s ByScoreDesc) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// Less must be a strict ordering: it returns false for equal elements.
+func (s ByScoreDesc) Less(i, j int) bool {
+	return s[i].Score > s[j].Score // Fixed: Strict comparison
+}
+
+// RankWithInterface does the same as RankSubmissions via sort.Stable.
+func RankWithInterface(subs []Submission) {
+	sort.Stable(ByScoreDesc(subs))
+}
+
+// Player belongs to a team.
+type Player struct {
+	Name string
+	Team string
+}
+
+// GroupByTeam orders players by team and alphabetically within each team,
+// using two passes: first by name, then by team. The second pass only
+// preserves the first pass's order if it is stable.
+func GroupByTeam(players []Player) {
+	slices.SortFunc(players, func(a, b Player) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	slices.SortStableFunc(players, func(a, b Player) int { // Fixed: Keep the name order within a team
+		return strings.Compare(a.Team, b.Team)
+	})
+}
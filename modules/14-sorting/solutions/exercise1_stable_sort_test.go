@@ -0,0 +1,83 @@
+package solutions
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+)
+
+// manySubmissions returns n submissions with only a handful of distinct
+// scores, so most entries tie with many others. Player names encode the
+// submission order so mistakes are easy to read in failure output.
+func manySubmissions(t *testing.T, n int) []Submission {
+	r := seed.Rand(t, 3)
+	subs := make([]Submission, n)
+	for i := range subs {
+		subs[i] = Submission{Player: fmt.Sprintf("p%03d", i), Score: r.Intn(5) * 10}
+	}
+	return subs
+}
+
+// expectedRanking computes the correct order with an obviously stable
+// algorithm: bucket by score, highest bucket first.
+func expectedRanking(subs []Submission) []Submission {
+	var out []Submission
+	for score := 40; score >= 0; score -= 10 {
+		for _, s := range subs {
+			if s.Score == score {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+func TestRankSubmissionsSmall(t *testing.T) {
+	subs := []Submission{{"ann", 50}, {"ben", 70}, {"cat", 50}, {"dan", 90}}
+	RankSubmissions(subs)
+	assert.Equal(t, []Submission{{"dan", 90}, {"ben", 70}, {"ann", 50}, {"cat", 50}}, subs)
+}
+
+func TestRankSubmissionsKeepsTiesInSubmissionOrder(t *testing.T) {
+	subs := manySubmissions(t, 300)
+	want := expectedRanking(subs)
+	RankSubmissions(subs)
+	assert.Equal(t, want, subs)
+}
+
+func TestRankWithInterfaceKeepsTiesInSubmissionOrder(t *testing.T) {
+	subs := manySubmissions(t, 300)
+	want := expectedRanking(subs)
+	RankWithInterface(subs)
+	assert.Equal(t, want, subs)
+}
+
+func TestByScoreDescLessIsStrict(t *testing.T) {
+	s := ByScoreDesc{{"a", 10}, {"b", 10}}
+	assert.False(t, s.Less(0, 1), "equal elements are never less than each other")
+	assert.False(t, s.Less(1, 0))
+}
+
+func TestGroupByTeam(t *testing.T) {
+	r := seed.Rand(t, 11)
+	teams := []string{"blue", "green", "red"}
+	players := make([]Player, 200)
+	for i := range players {
+		players[i] = Player{Name: fmt.Sprintf("player-%03d", r.Intn(1000)), Team: teams[r.Intn(len(teams))]}
+	}
+
+	want := slices.Clone(players)
+	slices.SortStableFunc(want, func(a, b Player) int {
+		if c := cmp.Compare(a.Team, b.Team); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	GroupByTeam(players)
+	assert.Equal(t, want, players)
+}
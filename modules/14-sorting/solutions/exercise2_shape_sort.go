@@ -0,0 +1,65 @@
+package solutions
+
+// SOLUTION: One ordering, two spellings.
+// A sort.Interface type and a cmp-style compare function must agree: Less
+// is "compare < 0", both read the same key, and both go through a stable
+// sort when ties must keep their order.
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// ByArea implements sort.Interface for shapes, smallest area first.
+type ByArea []geometry.Shape
+
+func (s ByArea) Len() int           { return len(s) }
+func (s ByArea) Less(i, j int) bool { return s[i].Area() < s[j].Area() }
+func (s ByArea) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// ByPerimeter implements sort.Interface for shapes, shortest perimeter
+// first.
+type ByPerimeter []geometry.Shape
+
+func (s ByPerimeter) Len() int { return len(s) }
+func (s ByPerimeter) Less(i, j int) bool {
+	return s[i].Perimeter() < s[j].Perimeter() // Fixed: Perimeter on both sides
+}
+func (s ByPerimeter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// CompareArea is ByArea's order as a three-way compare function for
+// slices.SortFunc: negative if a is smaller, zero if equal, positive if
+// larger.
+func CompareArea(a, b geometry.Shape) int {
+	return cmp.Compare(a.Area(), b.Area()) // Fixed: No truncation of fractional differences
+}
+
+// ComparePerimeter is ByPerimeter's order as a compare function.
+func ComparePerimeter(a, b geometry.Shape) int {
+	return cmp.Compare(a.Perimeter(), b.Perimeter()) // Fixed: a before b, ascending like ByPerimeter
+}
+
+// SortByArea sorts shapes by area with sort.Stable; shapes of equal area
+// keep their order.
+func SortByArea(shapes []geometry.Shape) {
+	sort.Stable(ByArea(shapes))
+}
+
+// SortByAreaFunc does the same as SortByArea with slices.SortStableFunc.
+func SortByAreaFunc(shapes []geometry.Shape) {
+	slices.SortStableFunc(shapes, CompareArea)
+}
+
+// SortByPerimeter sorts shapes by perimeter with sort.Stable.
+func SortByPerimeter(shapes []geometry.Shape) {
+	sort.Stable(ByPerimeter(shapes))
+}
+
+// SortByPerimeterFunc does the same as SortByPerimeter with
+// slices.SortStableFunc.
+func SortByPerimeterFunc(shapes []geometry.Shape) {
+	slices.SortStableFunc(shapes, ComparePerimeter) // Fixed: Stable, like sort.Stable above
+}
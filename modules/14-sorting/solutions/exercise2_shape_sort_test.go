@@ -0,0 +1,134 @@
+package solutions
+
+import (
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+)
+
+// manyShapes returns n shapes with few distinct sizes, so that most of
+// them tie on area or perimeter. Each shape's Origin.X or Center.X is its
+// index, which shows in failure output where a shape started.
+func manyShapes(t *testing.T, n int) []geometry.Shape {
+	r := seed.Rand(t, 14)
+	shapes := make([]geometry.Shape, n)
+	for i := range shapes {
+		at := geometry.Point{X: float64(i)}
+		if r.Intn(4) == 0 {
+			shapes[i] = geometry.Circle{Center: at, Radius: []float64{0.5, 1}[r.Intn(2)]}
+			continue
+		}
+		shapes[i] = geometry.Rectangle{Origin: at, Width: float64(1 + r.Intn(4)), Height: float64(1 + r.Intn(4))}
+	}
+	return shapes
+}
+
+// sortedBy sorts a copy of shapes by key with sort.SliceStable, which
+// the code under test does not use.
+func sortedBy(shapes []geometry.Shape, key func(geometry.Shape) float64) []geometry.Shape {
+	out := slices.Clone(shapes)
+	sort.SliceStable(out, func(i, j int) bool { return key(out[i]) < key(out[j]) })
+	return out
+}
+
+func area(s geometry.Shape) float64      { return s.Area() }
+func perimeter(s geometry.Shape) float64 { return s.Perimeter() }
+
+func TestSortByAreaSmall(t *testing.T) {
+	shapes := []geometry.Shape{
+		geometry.Rectangle{Width: 2, Height: 2},
+		geometry.Circle{Radius: 0.5},
+		geometry.Rectangle{Width: 1, Height: 1},
+		geometry.Circle{Radius: 1},
+	}
+	want := []geometry.Shape{shapes[1], shapes[2], shapes[3], shapes[0]}
+	for name, sortFn := range map[string]func([]geometry.Shape){"SortByArea": SortByArea, "SortByAreaFunc": SortByAreaFunc} {
+		got := slices.Clone(shapes)
+		sortFn(got)
+		assert.Equal(t, want, got, name)
+	}
+}
+
+func TestLessAgreesWithCompare(t *testing.T) {
+	long := geometry.Rectangle{Width: 1, Height: 10}  // area 10, perimeter 22
+	square := geometry.Rectangle{Width: 4, Height: 4} // area 16, perimeter 16
+	small := geometry.Circle{Radius: 0.5}             // area 0.79
+	unit := geometry.Rectangle{Width: 1, Height: 1}   // area 1
+
+	assert.True(t, ByPerimeter{long, square}.Less(1, 0), "square's perimeter is shorter")
+	assert.False(t, ByPerimeter{long, square}.Less(0, 1))
+	assert.Negative(t, ComparePerimeter(square, long))
+	assert.Positive(t, ComparePerimeter(long, square))
+	assert.Zero(t, ComparePerimeter(long, long))
+
+	assert.True(t, ByArea{unit, small}.Less(1, 0))
+	assert.Negative(t, CompareArea(small, unit), "0.79 < 1 even though both round down to 0")
+	assert.Positive(t, CompareArea(unit, small))
+	assert.Zero(t, CompareArea(small, geometry.Circle{Radius: 0.5}))
+}
+
+func TestSortByAreaBothWays(t *testing.T) {
+	shapes := manyShapes(t, 300)
+	want := sortedBy(shapes, area)
+
+	viaInterface := slices.Clone(shapes)
+	SortByArea(viaInterface)
+	viaFunc := slices.Clone(shapes)
+	SortByAreaFunc(viaFunc)
+
+	assert.Equal(t, want, viaInterface, "sort.Interface")
+	assert.Equal(t, want, viaFunc, "slices.SortStableFunc")
+}
+
+func TestSortByPerimeterBothWays(t *testing.T) {
+	shapes := manyShapes(t, 300)
+	want := sortedBy(shapes, perimeter)
+
+	viaInterface := slices.Clone(shapes)
+	SortByPerimeter(viaInterface)
+	viaFunc := slices.Clone(shapes)
+	SortByPerimeterFunc(viaFunc)
+
+	assert.Equal(t, want, viaInterface, "sort.Interface")
+	assert.Equal(t, want, viaFunc, "slices.SortStableFunc")
+}
+
+func TestSortsAreStable(t *testing.T) {
+	sorts := []struct {
+		name string
+		sort func([]geometry.Shape)
+		key  func(geometry.Shape) float64
+	}{
+		{"SortByArea", SortByArea, area},
+		{"SortByAreaFunc", SortByAreaFunc, area},
+		{"SortByPerimeter", SortByPerimeter, perimeter},
+		{"SortByPerimeterFunc", SortByPerimeterFunc, perimeter},
+	}
+	for _, s := range sorts {
+		shapes := manyShapes(t, 300)
+		s.sort(shapes)
+		for i := 1; i < len(shapes); i++ {
+			if s.key(shapes[i-1]) != s.key(shapes[i]) {
+				continue
+			}
+			if !assert.Less(t, index(shapes[i-1]), index(shapes[i]), "%s: shapes that tie at %d and %d swapped places", s.name, i-1, i) {
+				break
+			}
+		}
+	}
+}
+
+// index is where manyShapes put s.
+func index(s geometry.Shape) float64 {
+	switch s := s.(type) {
+	case geometry.Circle:
+		return s.Center.X
+	case geometry.Rectangle:
+		return s.Origin.X
+	}
+	return -1
+}
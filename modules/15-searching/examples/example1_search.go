@@ -0,0 +1,105 @@
+// Package examples demonstrates searching algorithms in Go.
+//
+// This file shows:
+//   - Linear search: works on any slice, O(n)
+//   - Binary search: needs sorted input, O(log n)
+//   - Lower bound: the first position where a value could be inserted
+//   - Interpolation search: guesses the position from the value, O(log log n)
+//     on uniformly distributed data
+package examples
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// LinearSearch returns the index of the first element equal to target, or -1.
+func LinearSearch[T comparable](s []T, target T) int {
+	for i, v := range s {
+		if v == target {
+			return i
+		}
+	}
+	return -1
+}
+
+// BinarySearch returns the index of target in the sorted slice s and
+// whether it was found. The half-open interval [lo, hi) always contains
+// the answer if there is one, which keeps the loop bounds simple.
+func BinarySearch[T cmp.Ordered](s []T, target T) (int, bool) {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := lo + (hi-lo)/2 // never overflows, unlike (lo+hi)/2
+		switch {
+		case s[mid] < target:
+			lo = mid + 1
+		case s[mid] > target:
+			hi = mid
+		default:
+			return mid, true
+		}
+	}
+	return lo, false
+}
+
+// LowerBound returns the index of the first element >= target in the sorted
+// slice s, or len(s) if every element is smaller. With duplicates it finds
+// the leftmost one.
+func LowerBound[T cmp.Ordered](s []T, target T) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if s[mid] < target {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// InterpolationSearch finds target in a sorted slice of ints by estimating
+// where it should be from the values at the ends of the range, like looking
+// up a name in a phone book. Returns -1 if not found.
+func InterpolationSearch(s []int, target int) int {
+	lo, hi := 0, len(s)-1
+	for lo <= hi && target >= s[lo] && target <= s[hi] {
+		if s[hi] == s[lo] {
+			if s[lo] == target {
+				return lo
+			}
+			return -1
+		}
+		// Position estimate, computed in float64 to avoid overflowing the product
+		frac := float64(target-s[lo]) / float64(s[hi]-s[lo])
+		pos := lo + int(frac*float64(hi-lo))
+		switch {
+		case s[pos] < target:
+			lo = pos + 1
+		case s[pos] > target:
+			hi = pos - 1
+		default:
+			return pos
+		}
+	}
+	return -1
+}
+
+// DemonstrateSearches runs each algorithm on the same data.
+func DemonstrateSearches() {
+	unsorted := []string{"gopher", "crab", "python", "duke"}
+	fmt.Printf("LinearSearch(duke): %d\n", LinearSearch(unsorted, "duke"))
+
+	sorted := []int{2, 3, 5, 7, 7, 7, 11, 13, 17, 19}
+	i, found := BinarySearch(sorted, 11)
+	fmt.Printf("BinarySearch(11): index %d, found %v\n", i, found)
+	i, found = BinarySearch(sorted, 8)
+	fmt.Printf("BinarySearch(8): insert at %d, found %v\n", i, found)
+	fmt.Printf("LowerBound(7): %d (leftmost of the duplicates)\n", LowerBound(sorted, 7))
+
+	evens := make([]int, 1000)
+	for i := range evens {
+		evens[i] = i * 2
+	}
+	fmt.Printf("InterpolationSearch(1234): %d\n", InterpolationSearch(evens, 1234))
+}
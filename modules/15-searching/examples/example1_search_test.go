@@ -0,0 +1,48 @@
+package examples
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateSearches(t *testing.T) {
+	DemonstrateSearches()
+}
+
+func TestLinearSearch(t *testing.T) {
+	assert.Equal(t, 1, LinearSearch([]string{"a", "b", "b"}, "b"))
+	assert.Equal(t, -1, LinearSearch([]int{1, 2, 3}, 4))
+	assert.Equal(t, -1, LinearSearch(nil, 0))
+}
+
+func TestBinarySearchMatchesStdlib(t *testing.T) {
+	sorted := []int{1, 3, 3, 5, 8, 13, 21}
+	for target := 0; target <= 22; target++ {
+		wantIdx, wantFound := slices.BinarySearch(sorted, target)
+		idx, found := BinarySearch(sorted, target)
+		assert.Equal(t, wantFound, found, "target %d", target)
+		if found {
+			assert.Equal(t, target, sorted[idx])
+		} else {
+			assert.Equal(t, wantIdx, idx, "insertion point for %d", target)
+		}
+		assert.Equal(t, wantIdx, LowerBound(sorted, target), "lower bound of %d", target)
+	}
+}
+
+func TestInterpolationSearch(t *testing.T) {
+	squares := make([]int, 100)
+	for i := range squares {
+		squares[i] = i * i
+	}
+	for i, v := range squares {
+		assert.Equal(t, i, InterpolationSearch(squares, v))
+	}
+	assert.Equal(t, -1, InterpolationSearch(squares, 2))
+	assert.Equal(t, -1, InterpolationSearch(squares, -1))
+	assert.Equal(t, -1, InterpolationSearch(squares, 10_000))
+	assert.Equal(t, -1, InterpolationSearch(nil, 1))
+	assert.Equal(t, 0, InterpolationSearch([]int{4, 4, 4}, 4))
+}
@@ -0,0 +1,92 @@
+package examples
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// StdlibSearchExamples shows the standard library's binary searches on
+// course data.
+//
+// sort.Search(n, pred) returns the smallest index i in [0, n) for which
+// pred(i) is true, assuming pred is false and then true ("monotonic").
+// slices.BinarySearchFunc(s, target, cmp) finds target in s using a
+// three-way compare of an element and the target, and also reports
+// whether it was found.
+
+// Account is a bank account record keyed by ID.
+type Account struct {
+	ID      string
+	Owner   string
+	Balance int64 // in cents
+}
+
+// SampleAccounts returns accounts sorted by ID.
+func SampleAccounts() []Account {
+	return []Account{
+		{"ACC-1001", "Alice", 120_00},
+		{"ACC-1007", "Bob", 5_50},
+		{"ACC-1013", "Carol", 980_25},
+		{"ACC-1042", "Dave", 0},
+		{"ACC-1100", "Eve", 42_00},
+	}
+}
+
+// FindAccount looks up an account by ID with slices.BinarySearchFunc.
+// The compare function receives (element, target) in that order.
+func FindAccount(accounts []Account, id string) (Account, bool) {
+	i, found := slices.BinarySearchFunc(accounts, id, func(a Account, id string) int {
+		return strings.Compare(a.ID, id)
+	})
+	if !found {
+		return Account{}, false
+	}
+	return accounts[i], true
+}
+
+// SampleShapes returns shapes sorted by area, smallest first.
+func SampleShapes() []geometry.Shape {
+	shapes := []geometry.Shape{
+		geometry.Rectangle{Width: 10, Height: 10},
+		geometry.Circle{Radius: 1},
+		geometry.Triangle{A: geometry.Point{}, B: geometry.Point{X: 4}, C: geometry.Point{Y: 3}},
+		geometry.Rectangle{Width: 2, Height: 3},
+		geometry.Circle{Radius: 5},
+	}
+	slices.SortFunc(shapes, func(a, b geometry.Shape) int {
+		return cmp.Compare(a.Area(), b.Area())
+	})
+	return shapes
+}
+
+// FirstShapeWithAreaAtLeast returns the index of the first shape whose area
+// is >= minArea in shapes sorted by area, or len(shapes) if none is.
+func FirstShapeWithAreaAtLeast(shapes []geometry.Shape, minArea float64) int {
+	return sort.Search(len(shapes), func(i int) bool {
+		return shapes[i].Area() >= minArea
+	})
+}
+
+// DemonstrateStdlibSearch runs the standard library searches.
+func DemonstrateStdlibSearch() {
+	accounts := SampleAccounts()
+	if acc, ok := FindAccount(accounts, "ACC-1013"); ok {
+		fmt.Printf("Found %s owned by %s\n", acc.ID, acc.Owner)
+	}
+	_, ok := FindAccount(accounts, "ACC-9999")
+	fmt.Printf("ACC-9999 found? %v\n", ok)
+
+	shapes := SampleShapes()
+	i := FirstShapeWithAreaAtLeast(shapes, 6)
+	fmt.Printf("Shapes with area >= 6: %v\n", shapes[i:])
+
+	scores := []int{10, 20, 30, 40}
+	fmt.Printf("sort.SearchInts(scores, 25) = %d\n", sort.SearchInts(scores, 25))
+	idx, found := slices.BinarySearch(scores, 30)
+	fmt.Printf("slices.BinarySearch(scores, 30) = %d, %v\n", idx, found)
+}
@@ -0,0 +1,31 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateStdlibSearch(t *testing.T) {
+	DemonstrateStdlibSearch()
+}
+
+func TestFindAccount(t *testing.T) {
+	accounts := SampleAccounts()
+	for _, want := range accounts {
+		got, ok := FindAccount(accounts, want.ID)
+		assert.True(t, ok, want.ID)
+		assert.Equal(t, want, got)
+	}
+	_, ok := FindAccount(accounts, "ACC-0000")
+	assert.False(t, ok)
+}
+
+func TestFirstShapeWithAreaAtLeast(t *testing.T) {
+	shapes := SampleShapes()
+	assert.Equal(t, 0, FirstShapeWithAreaAtLeast(shapes, 0))
+	// Areas: 3.14, 6, 6, 78.5, 100 - the first 6 is at index 1
+	assert.Equal(t, 1, FirstShapeWithAreaAtLeast(shapes, 6))
+	assert.Equal(t, 3, FirstShapeWithAreaAtLeast(shapes, 6.1))
+	assert.Equal(t, len(shapes), FirstShapeWithAreaAtLeast(shapes, 1000))
+}
@@ -0,0 +1,103 @@
+package exercises
+
+// EXERCISE: Fix the binary searches.
+// Binary search is famously easy to get almost right. Jon Bentley found that
+// most professional programmers got it wrong, and the JDK's own version
+// carried an overflow bug for nearly a decade. Each function below has one
+// of the classic mistakes: a wrong loop condition, an overflowing midpoint,
+// an off-by-one comparison, or a misused standard library API.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// BinarySearch returns the index of target in the sorted slice s, or -1 if
+// it is not present.
+// BUG: The loop stops one step early and misses targets at the edges.
+func BinarySearch[T cmp.Ordered](s []T, target T) int {
+	lo, hi := 0, len(s)-1 // closed interval [lo, hi]
+	for lo < hi {         // BUG: A closed interval is still non-empty when lo == hi; use <=
+		mid := lo + (hi-lo)/2
+		switch {
+		case s[mid] < target:
+			lo = mid + 1
+		case s[mid] > target:
+			hi = mid - 1
+		default:
+			return mid
+		}
+	}
+	return -1
+}
+
+// FirstTrue returns the smallest i in [lo, hi) for which pred(i) is true,
+// or hi if there is none. pred must be false for a prefix of the range and
+// true for the rest. The range may be anywhere in int, e.g. IDs near
+// math.MaxInt, and pred is never called outside it.
+// BUG: The midpoint overflows when lo and hi are both large. It also
+// misbehaves for negative ranges: Go's integer division truncates toward
+// zero, so (lo+hi)/2 can round up to hi and the loop never shrinks.
+func FirstTrue(lo, hi int, pred func(int) bool) int {
+	for lo < hi {
+		mid := (lo + hi) / 2 // BUG: lo+hi can overflow; use lo + (hi-lo)/2
+		if pred(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// LowerBound returns the index of the first element >= target in the
+// sorted slice s, or len(s) if every element is smaller. With duplicates it
+// must return the leftmost one.
+// BUG: Returns the index just past the last duplicate (the upper bound).
+func LowerBound[T cmp.Ordered](s []T, target T) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if s[mid] <= target { // BUG: Only move right past elements strictly smaller than target
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// FirstShapeWithAreaAtLeast returns the index of the first shape whose area
+// is >= minArea in shapes sorted by area, or len(shapes) if none is.
+// BUG: sort.Search needs a predicate that is false, then true. This one is
+// true, then false, so the result is meaningless.
+func FirstShapeWithAreaAtLeast(shapes []geometry.Shape, minArea float64) int {
+	return sort.Search(len(shapes), func(i int) bool {
+		return shapes[i].Area() < minArea // BUG: Should be >=
+	})
+}
+
+// Account is a bank account record keyed by ID.
+type Account struct {
+	ID      string
+	Owner   string
+	Balance int64 // in cents
+}
+
+// FindAccount looks up an account by ID in accounts sorted by ID.
+// BUG: slices.BinarySearchFunc calls cmp(element, target). Comparing
+// (target, element) flips the sign and sends the search the wrong way.
+func FindAccount(accounts []Account, id string) (Account, bool) {
+	i, found := slices.BinarySearchFunc(accounts, id, func(a Account, id string) int {
+		return strings.Compare(id, a.ID) // BUG: Arguments are reversed
+	})
+	if !found {
+		return Account{}, false
+	}
+	return accounts[i], true
+}
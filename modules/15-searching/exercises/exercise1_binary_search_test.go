@@ -0,0 +1,184 @@
+package exercises
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/faker"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinarySearch(t *testing.T) {
+	s := []int{2, 3, 5, 7, 11, 13, 17}
+	for i, v := range s {
+		assert.Equal(t, i, BinarySearch(s, v), "search for %d", v)
+	}
+	for _, missing := range []int{0, 4, 12, 18} {
+		assert.Equal(t, -1, BinarySearch(s, missing), "search for %d", missing)
+	}
+	assert.Equal(t, 0, BinarySearch([]string{"go"}, "go"))
+	assert.Equal(t, -1, BinarySearch([]int{}, 1))
+}
+
+func TestFirstTrue(t *testing.T) {
+	tests := []struct {
+		name           string
+		lo, hi, target int
+	}{
+		{"small range", 0, 100, 37},
+		{"none true", 0, 100, 100},
+		{"all true", 0, 100, 0},
+		{"empty range", 5, 5, 5},
+		{"negative range", -1000, -10, -500},
+		{"near MaxInt", math.MaxInt - 1000, math.MaxInt, math.MaxInt - 3},
+		{"whole upper half", math.MaxInt / 2, math.MaxInt, math.MaxInt - 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var outOfRange []int
+			calls := 0
+			pred := func(i int) bool {
+				calls++
+				if i < tt.lo || i >= tt.hi {
+					outOfRange = append(outOfRange, i)
+				}
+				// A correct search needs at most 64 probes over an int range.
+				// Stop a broken midpoint here instead of letting it spin.
+				if calls > 128 {
+					t.Fatalf("FirstTrue(%d, %d) made %d probes without converging", tt.lo, tt.hi, calls)
+				}
+				return i >= tt.target
+			}
+			got := FirstTrue(tt.lo, tt.hi, pred)
+			assert.Empty(t, outOfRange, "pred called outside [lo, hi) - does the midpoint overflow?")
+			assert.LessOrEqual(t, calls, 64, "too many probes")
+			assert.Equal(t, tt.target, got)
+		})
+	}
+}
+
+func TestLowerBound(t *testing.T) {
+	s := []int{1, 3, 3, 3, 5, 8}
+	tests := []struct {
+		target, want int
+	}{
+		{0, 0},
+		{1, 0},
+		{2, 1},
+		{3, 1}, // leftmost of the duplicates
+		{4, 4},
+		{8, 5},
+		{9, 6},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, LowerBound(s, tt.target), "LowerBound(%d)", tt.target)
+	}
+	assert.Equal(t, 0, LowerBound([]string{}, "x"))
+}
+
+func TestLowerBoundMatchesStdlib(t *testing.T) {
+	s := []int{-4, -4, 0, 2, 2, 2, 2, 9, 10, 10}
+	for target := -5; target <= 11; target++ {
+		want, _ := slices.BinarySearch(s, target)
+		assert.Equal(t, want, LowerBound(s, target), "LowerBound(%d)", target)
+	}
+}
+
+func sortedShapes() []geometry.Shape {
+	shapes := []geometry.Shape{
+		geometry.Rectangle{Width: 10, Height: 10}, // 100
+		geometry.Circle{Radius: 1},                // ~3.14
+		geometry.Triangle{A: geometry.Point{}, B: geometry.Point{X: 4}, C: geometry.Point{Y: 3}}, // 6
+		geometry.Rectangle{Width: 2, Height: 3},                                                  // 6
+		geometry.Circle{Radius: 5},                                                               // ~78.5
+	}
+	slices.SortFunc(shapes, func(a, b geometry.Shape) int {
+		return cmp.Compare(a.Area(), b.Area())
+	})
+	return shapes
+}
+
+func TestFirstShapeWithAreaAtLeast(t *testing.T) {
+	shapes := sortedShapes()
+	tests := []struct {
+		minArea float64
+		want    int
+	}{
+		{0, 0},
+		{3, 0},
+		{6, 1},
+		{6.1, 3},
+		{100, 4},
+		{1000, 5},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.minArea), func(t *testing.T) {
+			assert.Equal(t, tt.want, FirstShapeWithAreaAtLeast(shapes, tt.minArea))
+		})
+	}
+}
+
+func TestFirstShapeWithAreaAtLeastRandomShapes(t *testing.T) {
+	r := seed.Rand(t, 15)
+	for i := 0; i < 100; i++ {
+		shapes := make([]geometry.Shape, seed.Between(r, 0, 12))
+		for j := range shapes {
+			if r.Intn(2) == 0 {
+				shapes[j] = geometry.Rectangle{Width: float64(seed.Between(r, 1, 20)), Height: float64(seed.Between(r, 1, 20))}
+			} else {
+				shapes[j] = geometry.Circle{Radius: float64(seed.Between(r, 1, 10))}
+			}
+		}
+		slices.SortFunc(shapes, func(a, b geometry.Shape) int {
+			return cmp.Compare(a.Area(), b.Area())
+		})
+		minArea := float64(seed.Between(r, 0, 400))
+
+		want := len(shapes)
+		for j, s := range shapes {
+			if s.Area() >= minArea {
+				want = j
+				break
+			}
+		}
+		require.Equal(t, want, FirstShapeWithAreaAtLeast(shapes, minArea), "minArea %v in %v", minArea, shapes)
+	}
+}
+
+func TestFindAccount(t *testing.T) {
+	var accounts []Account
+	for _, a := range faker.For(t, 3).Accounts(50) {
+		accounts = append(accounts, Account{a.ID, a.Owner.Name, a.Balance})
+	}
+	require.True(t, slices.IsSortedFunc(accounts, func(a, b Account) int {
+		return cmp.Compare(a.ID, b.ID)
+	}))
+
+	for _, want := range accounts {
+		got, ok := FindAccount(accounts, want.ID)
+		assert.True(t, ok, "account %s should be found", want.ID)
+		assert.Equal(t, want, got)
+	}
+	// An ID with a digit more sorts between its account and the next.
+	for _, missing := range []string{"ACC-0", accounts[0].ID + "5", accounts[24].ID + "5", "ACC-999999"} {
+		_, ok := FindAccount(accounts, missing)
+		assert.False(t, ok, "account %s should not be found", missing)
+	}
+}
+
+func BenchmarkBinarySearch(b *testing.B) {
+	s := make([]int, 1<<20)
+	for i := range s {
+		s[i] = i * 2
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BinarySearch(s, (i%len(s))*2)
+	}
+}
@@ -0,0 +1,95 @@
+package solutions
+
+// SOLUTION: Fixed binary searches.
+// Binary search is famously easy to get almost right. Jon Bentley found that
+// most professional programmers got it wrong, and the JDK's own version
+// carried an overflow bug for nearly a decade. Each function below has one
+// of the classic mistakes: a wrong loop condition, an overflowing midpoint,
+// an off-by-one comparison, or a misused standard library API.
+// Each fix is marked with a // Fixed: comment.
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// BinarySearch returns the index of target in the sorted slice s, or -1 if
+// it is not present.
+func BinarySearch[T cmp.Ordered](s []T, target T) int {
+	lo, hi := 0, len(s)-1 // closed interval [lo, hi]
+	for lo <= hi {        // Fixed: [lo, hi] still holds one element when lo == hi
+		mid := lo + (hi-lo)/2
+		switch {
+		case s[mid] < target:
+			lo = mid + 1
+		case s[mid] > target:
+			hi = mid - 1
+		default:
+			return mid
+		}
+	}
+	return -1
+}
+
+// FirstTrue returns the smallest i in [lo, hi) for which pred(i) is true,
+// or hi if there is none. pred must be false for a prefix of the range and
+// true for the rest. The range may be anywhere in int, e.g. IDs near
+// math.MaxInt, and pred is never called outside it.
+func FirstTrue(lo, hi int, pred func(int) bool) int {
+	for lo < hi {
+		mid := lo + (hi-lo)/2 // Fixed: hi-lo cannot overflow when lo <= hi
+		if pred(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// LowerBound returns the index of the first element >= target in the
+// sorted slice s, or len(s) if every element is smaller. With duplicates it
+// must return the leftmost one.
+func LowerBound[T cmp.Ordered](s []T, target T) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if s[mid] < target { // Fixed: elements equal to target stay in the range
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// FirstShapeWithAreaAtLeast returns the index of the first shape whose area
+// is >= minArea in shapes sorted by area, or len(shapes) if none is.
+func FirstShapeWithAreaAtLeast(shapes []geometry.Shape, minArea float64) int {
+	return sort.Search(len(shapes), func(i int) bool {
+		return shapes[i].Area() >= minArea // Fixed: false for small shapes, then true
+	})
+}
+
+// Account is a bank account record keyed by ID.
+type Account struct {
+	ID      string
+	Owner   string
+	Balance int64 // in cents
+}
+
+// FindAccount looks up an account by ID in accounts sorted by ID.
+// slices.BinarySearchFunc calls cmp(element, target).
+func FindAccount(accounts []Account, id string) (Account, bool) {
+	i, found := slices.BinarySearchFunc(accounts, id, func(a Account, id string) int {
+		return strings.Compare(a.ID, id) // Fixed: element first, then target
+	})
+	if !found {
+		return Account{}, false
+	}
+	return accounts[i], true
+}
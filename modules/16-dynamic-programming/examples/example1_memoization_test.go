@@ -0,0 +1,42 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateMemoization(t *testing.T) {
+	DemonstrateMemoization()
+}
+
+func TestGridPathsAgree(t *testing.T) {
+	for rows := 0; rows <= 8; rows++ {
+		for cols := 0; cols <= 8; cols++ {
+			want := GridPathsNaive(rows, cols)
+			assert.Equal(t, want, GridPathsMemo(rows, cols), "memo %dx%d", rows, cols)
+			assert.Equal(t, want, GridPathsTable(rows, cols), "table %dx%d", rows, cols)
+		}
+	}
+	// C(64, 32): the largest central binomial coefficient that fits in int64
+	assert.Equal(t, 1832624140942590534, GridPathsMemo(33, 33))
+	assert.Equal(t, 1832624140942590534, GridPathsTable(33, 33))
+}
+
+func BenchmarkGridPaths(b *testing.B) {
+	b.Run("naive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			GridPathsNaive(12, 12)
+		}
+	})
+	b.Run("memo", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			GridPathsMemo(12, 12)
+		}
+	})
+	b.Run("table", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			GridPathsTable(12, 12)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package exercises
+
+// EXERCISE: Fix coin change, the classic dynamic programming problem.
+// Given coin denominations and an amount, find the fewest coins that make
+// the amount, and count the ways to make it. The naive recursion is
+// provided and correct; the memoized and bottom-up versions are not.
+// Run the benchmarks to see the difference once they pass:
+//
+//	go test -bench=MinCoins -benchmem ./exercises/
+//
+// Fix the bugs marked with // BUG: comments.
+
+// MinCoinsNaive returns the fewest coins that sum to amount, or -1 if no
+// combination does. It tries every coin at every step: correct, but
+// exponential in amount. It is here as a reference and a benchmark baseline.
+func MinCoinsNaive(coins []int, amount int) int {
+	if amount == 0 {
+		return 0
+	}
+	best := -1
+	for _, c := range coins {
+		if c <= 0 || c > amount {
+			continue
+		}
+		if sub := MinCoinsNaive(coins, amount-c); sub >= 0 && (best < 0 || sub+1 < best) {
+			best = sub + 1
+		}
+	}
+	return best
+}
+
+// MinCoinsMemo is MinCoinsNaive with a cache, so each amount is solved once.
+// Unreachable amounts are cached as -1.
+// BUG: Cached -1 results are ignored, so every unreachable amount is solved
+// again from scratch. With coins {4, 6} and an odd amount, nothing is
+// reachable and the "memoized" version is as slow as the naive one.
+func MinCoinsMemo(coins []int, amount int) int {
+	memo := make(map[int]int)
+	var solve func(amount int) int
+	solve = func(amount int) int {
+		if amount == 0 {
+			return 0
+		}
+		if v, ok := memo[amount]; ok && v >= 0 { // BUG: -1 is a cached answer too
+			return v
+		}
+		best := -1
+		for _, c := range coins {
+			if c <= 0 || c > amount {
+				continue
+			}
+			if sub := solve(amount - c); sub >= 0 && (best < 0 || sub+1 < best) {
+				best = sub + 1
+			}
+		}
+		memo[amount] = best
+		return best
+	}
+	return solve(amount)
+}
+
+// MinCoins solves the same problem bottom-up: dp[a] is the fewest coins
+// summing to a. It runs in O(amount * len(coins)) time.
+// BUG: The "unreachable" sentinel collides with a real answer.
+func MinCoins(coins []int, amount int) int {
+	if amount < 0 {
+		return -1
+	}
+	// No answer can use more than amount coins (all 1s), so anything larger
+	// means "unreachable".
+	unreachable := amount // BUG: amount coins is a real answer (all 1s); use amount+1
+	dp := make([]int, amount+1)
+	for a := 1; a <= amount; a++ {
+		dp[a] = unreachable
+		for _, c := range coins {
+			if c > 0 && c <= a && dp[a-c]+1 < dp[a] {
+				dp[a] = dp[a-c] + 1
+			}
+		}
+	}
+	if dp[amount] >= unreachable {
+		return -1
+	}
+	return dp[amount]
+}
+
+// CountWays returns how many distinct combinations of coins sum to amount.
+// Order does not matter: 1+2 and 2+1 are the same combination.
+// BUG: The loops are nested the wrong way round, so this counts ordered
+// sequences (permutations) instead of combinations.
+func CountWays(coins []int, amount int) int {
+	if amount < 0 {
+		return 0
+	}
+	ways := make([]int, amount+1)
+	ways[0] = 1
+	for a := 1; a <= amount; a++ { // BUG: Loop over coins outside, amounts inside
+		for _, c := range coins {
+			if c > 0 && c <= a {
+				ways[a] += ways[a-c]
+			}
+		}
+	}
+	return ways[amount]
+}
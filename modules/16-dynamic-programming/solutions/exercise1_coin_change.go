@@ -0,0 +1,95 @@
+package solutions
+
+// SOLUTION: Fixed coin change.
+// Each fix is marked with a // Fixed: comment.
+
+// MinCoinsNaive returns the fewest coins that sum to amount, or -1 if no
+// combination does. It tries every coin at every step: correct, but
+// exponential in amount. It is here as a reference and a benchmark baseline.
+func MinCoinsNaive(coins []int, amount int) int {
+	if amount == 0 {
+		return 0
+	}
+	best := -1
+	for _, c := range coins {
+		if c <= 0 || c > amount {
+			continue
+		}
+		if sub := MinCoinsNaive(coins, amount-c); sub >= 0 && (best < 0 || sub+1 < best) {
+			best = sub + 1
+		}
+	}
+	return best
+}
+
+// MinCoinsMemo is MinCoinsNaive with a cache, so each amount is solved once.
+// Unreachable amounts are cached as -1.
+func MinCoinsMemo(coins []int, amount int) int {
+	memo := make(map[int]int)
+	var solve func(amount int) int
+	solve = func(amount int) int {
+		if amount == 0 {
+			return 0
+		}
+		if v, ok := memo[amount]; ok { // Fixed: a cached -1 is a valid answer too
+			return v
+		}
+		best := -1
+		for _, c := range coins {
+			if c <= 0 || c > amount {
+				continue
+			}
+			if sub := solve(amount - c); sub >= 0 && (best < 0 || sub+1 < best) {
+				best = sub + 1
+			}
+		}
+		memo[amount] = best
+		return best
+	}
+	return solve(amount)
+}
+
+// MinCoins solves the same problem bottom-up: dp[a] is the fewest coins
+// summing to a. It runs in O(amount * len(coins)) time.
+func MinCoins(coins []int, amount int) int {
+	if amount < 0 {
+		return -1
+	}
+	// No answer can use more than amount coins (all 1s), so amount+1 means
+	// "unreachable".
+	unreachable := amount + 1 // Fixed: amount itself is a real answer when coins include 1
+	dp := make([]int, amount+1)
+	for a := 1; a <= amount; a++ {
+		dp[a] = unreachable
+		for _, c := range coins {
+			if c > 0 && c <= a && dp[a-c]+1 < dp[a] {
+				dp[a] = dp[a-c] + 1
+			}
+		}
+	}
+	if dp[amount] >= unreachable {
+		return -1
+	}
+	return dp[amount]
+}
+
+// CountWays returns how many distinct combinations of coins sum to amount.
+// Order does not matter: 1+2 and 2+1 are the same combination.
+func CountWays(coins []int, amount int) int {
+	if amount < 0 {
+		return 0
+	}
+	ways := make([]int, amount+1)
+	ways[0] = 1
+	// Fixed: coins in the outer loop, so each combination is built in one
+	// fixed coin order and counted once
+	for _, c := range coins {
+		if c <= 0 {
+			continue
+		}
+		for a := c; a <= amount; a++ {
+			ways[a] += ways[a-c]
+		}
+	}
+	return ways[amount]
+}
@@ -0,0 +1,123 @@
+package solutions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// finishesWithin fails the test if fn does not return before the deadline.
+// The naive algorithms in this package are exponential, so a DP version
+// that silently falls back to naive behavior would otherwise hang.
+func finishesWithin(t *testing.T, d time.Duration, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	select {
+	case <-done:
+	case <-time.After(d):
+		t.Fatalf("did not finish within %v - is the algorithm exponential?", d)
+	}
+}
+
+var minCoinsTable = []struct {
+	name   string
+	coins  []int
+	amount int
+	want   int
+}{
+	{"zero amount", []int{1, 2, 5}, 0, 0},
+	{"greedy works", []int{1, 2, 5}, 11, 3},
+	{"greedy fails", []int{1, 3, 4}, 6, 2}, // 3+3, not 4+1+1
+	{"only ones", []int{1}, 7, 7},
+	{"ones and large", []int{1, 50}, 49, 49},
+	{"unreachable", []int{2}, 3, -1},
+	{"unreachable even coins", []int{4, 6}, 9, -1},
+	{"no coins", nil, 5, -1},
+	{"single coin exact", []int{7}, 21, 3},
+}
+
+func TestMinCoinsNaive(t *testing.T) {
+	for _, tt := range minCoinsTable {
+		assert.Equal(t, tt.want, MinCoinsNaive(tt.coins, tt.amount), tt.name)
+	}
+}
+
+func TestMinCoinsMemo(t *testing.T) {
+	for _, tt := range minCoinsTable {
+		assert.Equal(t, tt.want, MinCoinsMemo(tt.coins, tt.amount), tt.name)
+	}
+}
+
+func TestMinCoins(t *testing.T) {
+	for _, tt := range minCoinsTable {
+		assert.Equal(t, tt.want, MinCoins(tt.coins, tt.amount), tt.name)
+	}
+}
+
+func TestMinCoinsLargeAmounts(t *testing.T) {
+	tests := []struct {
+		name   string
+		coins  []int
+		amount int
+		want   int
+	}{
+		{"reachable", []int{1, 7, 23, 50}, 5_000, 100},
+		// Every amount is unreachable: a cache that forgets failures is exponential
+		{"unreachable", []int{4, 6}, 999, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var memo, table int
+			finishesWithin(t, 2*time.Second, func() {
+				memo = MinCoinsMemo(tt.coins, tt.amount)
+			})
+			finishesWithin(t, 2*time.Second, func() {
+				table = MinCoins(tt.coins, tt.amount)
+			})
+			assert.Equal(t, tt.want, memo, "MinCoinsMemo")
+			assert.Equal(t, tt.want, table, "MinCoins")
+		})
+	}
+}
+
+func TestCountWays(t *testing.T) {
+	tests := []struct {
+		coins  []int
+		amount int
+		want   int
+	}{
+		{[]int{1, 2, 5}, 0, 1}, // the empty combination
+		{[]int{1, 2}, 3, 2},    // 1+1+1, 1+2
+		{[]int{1, 2, 5}, 5, 4}, // 5, 2+2+1, 2+1+1+1, 1*5
+		{[]int{2}, 3, 0},
+		{[]int{1, 5, 10, 25, 50}, 100, 292},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, CountWays(tt.coins, tt.amount), "coins %v amount %d", tt.coins, tt.amount)
+	}
+}
+
+func BenchmarkMinCoins(b *testing.B) {
+	coins := []int{1, 3, 4}
+	const amount = 25
+	b.Run("naive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			MinCoinsNaive(coins, amount)
+		}
+	})
+	b.Run("memo", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			MinCoinsMemo(coins, amount)
+		}
+	})
+	b.Run("table", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			MinCoins(coins, amount)
+		}
+	})
+}
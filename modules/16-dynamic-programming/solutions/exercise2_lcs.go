@@ -0,0 +1,70 @@
+package solutions
+
+// LCSLengthNaive returns the length of the longest common subsequence of a
+// and b. A subsequence keeps order but may skip characters: "ace" is a
+// subsequence of "abcde". Exponential; used as a reference.
+func LCSLengthNaive(a, b string) int {
+	return lcsNaive([]rune(a), []rune(b))
+}
+
+func lcsNaive(a, b []rune) int {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if a[0] == b[0] {
+		return 1 + lcsNaive(a[1:], b[1:])
+	}
+	return max(lcsNaive(a[1:], b), lcsNaive(a, b[1:]))
+}
+
+// lcsTable returns dp where dp[i][j] is the LCS length of a[:i] and b[:j].
+func lcsTable(a, b []rune) [][]int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				dp[i][j] = dp[i-1][j-1] + 1 // Fixed: extend the LCS of both prefixes
+			} else {
+				dp[i][j] = max(dp[i-1][j], dp[i][j-1])
+			}
+		}
+	}
+	return dp
+}
+
+// LCSLength returns the length of the longest common subsequence of a and b
+// in O(len(a) * len(b)) time.
+func LCSLength(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	return lcsTable(ra, rb)[len(ra)][len(rb)]
+}
+
+// LCS returns one longest common subsequence of a and b by walking the
+// table back from the bottom-right corner.
+func LCS(a, b string) string {
+	ra, rb := []rune(a), []rune(b)
+	dp := lcsTable(ra, rb)
+
+	out := make([]rune, 0, dp[len(ra)][len(rb)])
+	i, j := len(ra), len(rb)
+	for i > 0 && j > 0 {
+		switch {
+		case ra[i-1] == rb[j-1]:
+			out = append(out, ra[i-1])
+			i--
+			j--
+		case dp[i-1][j] >= dp[i][j-1]:
+			i--
+		default:
+			j--
+		}
+	}
+	// Fixed: the walk runs from the end, so reverse the collected runes
+	for l, r := 0, len(out)-1; l < r; l, r = l+1, r-1 {
+		out[l], out[r] = out[r], out[l]
+	}
+	return string(out)
+}
@@ -0,0 +1,100 @@
+package solutions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+)
+
+// isSubsequence reports whether sub can be formed by deleting runes from s.
+func isSubsequence(sub, s string) bool {
+	rs := []rune(s)
+	i := 0
+	for _, r := range sub {
+		for i < len(rs) && rs[i] != r {
+			i++
+		}
+		if i == len(rs) {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+var lcsTableTests = []struct {
+	a, b string
+	want int
+}{
+	{"", "", 0},
+	{"abc", "", 0},
+	{"abcde", "ace", 3},
+	{"abc", "abc", 3},
+	{"abc", "def", 0},
+	{"aa", "a", 1},
+	{"AGGTAB", "GXTXAYB", 4}, // GTAB
+	{"ABCBDAB", "BDCABA", 4},
+	{"héllo wörld", "hello world", 9},
+}
+
+func TestLCSLengthNaive(t *testing.T) {
+	for _, tt := range lcsTableTests {
+		assert.Equal(t, tt.want, LCSLengthNaive(tt.a, tt.b), "%q vs %q", tt.a, tt.b)
+	}
+}
+
+func TestLCSLength(t *testing.T) {
+	for _, tt := range lcsTableTests {
+		assert.Equal(t, tt.want, LCSLength(tt.a, tt.b), "%q vs %q", tt.a, tt.b)
+	}
+}
+
+func TestLCS(t *testing.T) {
+	for _, tt := range lcsTableTests {
+		got := LCS(tt.a, tt.b)
+		assert.Equal(t, tt.want, len([]rune(got)), "LCS(%q, %q) = %q has the wrong length", tt.a, tt.b, got)
+		assert.True(t, isSubsequence(got, tt.a), "%q is not a subsequence of %q", got, tt.a)
+		assert.True(t, isSubsequence(got, tt.b), "%q is not a subsequence of %q", got, tt.b)
+	}
+}
+
+func TestLCSLargeInput(t *testing.T) {
+	r := seed.Rand(t, 1)
+	randomDNA := func(n int) string {
+		var sb strings.Builder
+		for i := 0; i < n; i++ {
+			sb.WriteByte("ACGT"[r.Intn(4)])
+		}
+		return sb.String()
+	}
+	a, b := randomDNA(2000), randomDNA(2000)
+
+	var length int
+	var seq string
+	finishesWithin(t, 2*time.Second, func() {
+		length = LCSLength(a, b)
+		seq = LCS(a, b)
+	})
+	assert.Equal(t, length, len(seq))
+	assert.True(t, isSubsequence(seq, a))
+	assert.True(t, isSubsequence(seq, b))
+	// Random DNA strings share roughly 65% of their length
+	assert.InDelta(t, 1300, length, 100)
+}
+
+func BenchmarkLCSLength(b *testing.B) {
+	x, y := "ACCGGTCGAGTGCGCGG", "GTCGTTCGGAATGCCGT"
+	b.Run("naive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			LCSLengthNaive(x, y)
+		}
+	})
+	b.Run("table", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			LCSLength(x, y)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package solutions
+
+// EditDistanceNaive returns the Levenshtein distance between a and b: the
+// fewest single-rune insertions, deletions, and substitutions that turn a
+// into b. Exponential; used as a reference.
+func EditDistanceNaive(a, b string) int {
+	return editNaive([]rune(a), []rune(b))
+}
+
+func editNaive(a, b []rune) int {
+	if len(a) == 0 {
+		return len(b)
+	}
+	if len(b) == 0 {
+		return len(a)
+	}
+	if a[0] == b[0] {
+		return editNaive(a[1:], b[1:])
+	}
+	return 1 + min(
+		editNaive(a[1:], b),     // delete a[0]
+		editNaive(a, b[1:]),     // insert b[0]
+		editNaive(a[1:], b[1:]), // substitute
+	)
+}
+
+// EditDistanceMemo caches the distance between every pair of suffixes
+// a[i:] and b[j:], so there are only len(a)*len(b) subproblems.
+func EditDistanceMemo(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	memo := make(map[[2]int]int)
+	var dist func(i, j int) int
+	dist = func(i, j int) int {
+		if i == len(ra) {
+			return len(rb) - j
+		}
+		if j == len(rb) {
+			return len(ra) - i
+		}
+		key := [2]int{i, j} // Fixed: one key per (i, j) pair
+		if v, ok := memo[key]; ok {
+			return v
+		}
+		var v int
+		if ra[i] == rb[j] {
+			v = dist(i+1, j+1)
+		} else {
+			v = 1 + min(dist(i+1, j), dist(i, j+1), dist(i+1, j+1))
+		}
+		memo[key] = v
+		return v
+	}
+	return dist(0, 0)
+}
+
+// EditDistance fills the table bottom-up, keeping only the previous row:
+// prev[j] is the distance between a[:i-1] and b[:j].
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j // Fixed: turning "" into b[:j] takes j insertions
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			if ra[i-1] == rb[j-1] {
+				curr[j] = prev[j-1]
+			} else {
+				curr[j] = 1 + min(prev[j], curr[j-1], prev[j-1])
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,88 @@
+package solutions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var editDistanceTable = []struct {
+	a, b string
+	want int
+}{
+	{"", "", 0},
+	{"", "abc", 3},
+	{"abc", "", 3},
+	{"kitten", "sitting", 3},
+	{"flaw", "lawn", 2},
+	{"intention", "execution", 5},
+	{"gopher", "gopher", 0},
+	{"ab", "ba", 2},
+	{"abc", "bca", 2},
+	{"café", "cafe", 1}, // one rune, even though é is two bytes
+}
+
+func TestEditDistanceNaive(t *testing.T) {
+	for _, tt := range editDistanceTable {
+		assert.Equal(t, tt.want, EditDistanceNaive(tt.a, tt.b), "%q -> %q", tt.a, tt.b)
+	}
+}
+
+func TestEditDistanceMemo(t *testing.T) {
+	for _, tt := range editDistanceTable {
+		assert.Equal(t, tt.want, EditDistanceMemo(tt.a, tt.b), "%q -> %q", tt.a, tt.b)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, tt := range editDistanceTable {
+		assert.Equal(t, tt.want, EditDistance(tt.a, tt.b), "%q -> %q", tt.a, tt.b)
+	}
+}
+
+func TestEditDistanceSymmetric(t *testing.T) {
+	words := []string{"", "go", "gopher", "golang", "google", "rust", "gust"}
+	for _, a := range words {
+		for _, b := range words {
+			assert.Equal(t, EditDistance(a, b), EditDistance(b, a), "%q <-> %q", a, b)
+			assert.Equal(t, EditDistanceMemo(a, b), EditDistanceMemo(b, a), "%q <-> %q", a, b)
+		}
+	}
+}
+
+func TestEditDistanceLargeInput(t *testing.T) {
+	a := strings.Repeat("abcdefghij", 100)
+	b := strings.Repeat("abcdxfghij", 100) // one substitution per 10 runes
+	c := a[:500]
+
+	var memo, table, prefix int
+	finishesWithin(t, 2*time.Second, func() {
+		memo = EditDistanceMemo(a, b)
+		table = EditDistance(a, b)
+		prefix = EditDistance(a, c)
+	})
+	assert.Equal(t, 100, memo, "EditDistanceMemo")
+	assert.Equal(t, 100, table, "EditDistance")
+	assert.Equal(t, 500, prefix, "deleting the second half")
+}
+
+func BenchmarkEditDistance(b *testing.B) {
+	x, y := "intention", "execution"
+	b.Run("naive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			EditDistanceNaive(x, y)
+		}
+	})
+	b.Run("memo", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			EditDistanceMemo(x, y)
+		}
+	})
+	b.Run("table", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			EditDistance(x, y)
+		}
+	})
+}
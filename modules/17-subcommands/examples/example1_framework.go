@@ -0,0 +1,197 @@
+// Package examples demonstrates command-line tools with nested
+// subcommands, like "git remote add" or "kubectl config use-context".
+//
+// This file shows:
+// - A tree of commands with the course's small framework, pkg/cli
+// - Persistent flags that every command below the one defining them accepts
+// - Flags of a single command, before or after its arguments
+// - Aliases, argument checks, and help generated from the tree
+// - Errors as exit codes: 1 when a command fails, 2 when it was misused
+package examples
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/cli"
+)
+
+// Todo is an item of a TodoList.
+type Todo struct {
+	Title    string
+	Priority int
+	Tags     []string
+	Done     bool
+}
+
+// TodoList is what the todo tool works on. A real tool would load and
+// save it; the example keeps it in memory so the tests can look at it.
+type TodoList struct {
+	Items []Todo
+}
+
+// item returns the todo numbered arg, counting from 1.
+func (l *TodoList) item(arg string) (*Todo, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 || n > len(l.Items) {
+		// A number that is not on the list is a usage error: exit code 2.
+		return nil, cli.Usagef("no todo %q; the list has %d", arg, len(l.Items))
+	}
+	return &l.Items[n-1], nil
+}
+
+// NewTodoCommand returns the todo tool:
+//
+//	todo [-q] add [-priority n] <title>...
+//	todo [-q] list [-all]
+//	todo [-q] done <n>
+//	todo [-q] tag add <n> <tag>...
+//	todo [-q] tag rm <n> <tag>
+//
+// The tree is built fresh for every run, because parsing a flag sets the
+// variable it was defined with.
+func NewTodoCommand(list *TodoList) *cli.Command {
+	root := &cli.Command{
+		Use:   "todo",
+		Short: "Keep a list of things to do",
+	}
+	// A persistent flag of the root is a global flag: every subcommand,
+	// however deep, accepts it, before or after its own name.
+	quiet := root.PersistentFlags().Bool("q", false, "print nothing on success")
+	say := func(ctx *cli.Context, format string, args ...any) {
+		if !*quiet {
+			fmt.Fprintf(ctx.Stdout, format+"\n", args...)
+		}
+	}
+
+	add := &cli.Command{
+		Use:   "add <title>...",
+		Short: "Add a todo",
+		Args:  cli.MinimumArgs(1),
+	}
+	// A flag of add alone; "todo list -priority 3" is an error.
+	priority := add.Flags().Int("priority", 2, "priority: 1 (high), 2, or 3 (low)")
+	// Run gets the positional arguments with the flags taken out.
+	add.Run = func(ctx *cli.Context) error {
+		if *priority < 1 || *priority > 3 {
+			return cli.Usagef("-priority %d: want 1, 2, or 3", *priority)
+		}
+		list.Items = append(list.Items, Todo{Title: strings.Join(ctx.Args, " "), Priority: *priority})
+		say(ctx, "added #%d", len(list.Items))
+		return nil
+	}
+
+	ls := &cli.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the todos",
+		Args:    cli.NoArgs,
+	}
+	all := ls.Flags().Bool("all", false, "include the done ones")
+	ls.Run = func(ctx *cli.Context) error {
+		for i, t := range list.Items {
+			if t.Done && !*all {
+				continue
+			}
+			mark := " "
+			if t.Done {
+				mark = "x"
+			}
+			fmt.Fprintf(ctx.Stdout, "%d [%s] p%d %s", i+1, mark, t.Priority, t.Title)
+			if len(t.Tags) > 0 {
+				fmt.Fprintf(ctx.Stdout, " #%s", strings.Join(t.Tags, " #"))
+			}
+			fmt.Fprintln(ctx.Stdout)
+		}
+		return nil
+	}
+
+	done := &cli.Command{
+		Use:   "done <n>",
+		Short: "Mark a todo as done",
+		Args:  cli.ExactArgs(1),
+		Run: func(ctx *cli.Context) error {
+			t, err := list.item(ctx.Args[0])
+			if err != nil {
+				return err
+			}
+			if t.Done {
+				// The command line was fine; doing it failed: exit code 1.
+				return fmt.Errorf("#%s is already done", ctx.Args[0])
+			}
+			t.Done = true
+			say(ctx, "done: %s", t.Title)
+			return nil
+		},
+	}
+
+	// tag has no Run: it only groups its subcommands, and prints its help
+	// when run on its own.
+	tag := &cli.Command{Use: "tag", Short: "Add or remove tags"}
+	tag.AddCommand(
+		&cli.Command{
+			Use:   "add <n> <tag>...",
+			Short: "Tag a todo",
+			Args:  cli.MinimumArgs(2),
+			Run: func(ctx *cli.Context) error {
+				t, err := list.item(ctx.Args[0])
+				if err != nil {
+					return err
+				}
+				t.Tags = append(t.Tags, ctx.Args[1:]...)
+				say(ctx, "tagged #%s", ctx.Args[0])
+				return nil
+			},
+		},
+		&cli.Command{
+			Use:     "rm <n> <tag>",
+			Aliases: []string{"remove"},
+			Short:   "Remove a tag",
+			Args:    cli.ExactArgs(2),
+			Run: func(ctx *cli.Context) error {
+				t, err := list.item(ctx.Args[0])
+				if err != nil {
+					return err
+				}
+				for i, name := range t.Tags {
+					if name == ctx.Args[1] {
+						t.Tags = append(t.Tags[:i], t.Tags[i+1:]...)
+						say(ctx, "untagged #%s", ctx.Args[0])
+						return nil
+					}
+				}
+				return fmt.Errorf("#%s has no tag %q", ctx.Args[0], ctx.Args[1])
+			},
+		},
+	)
+
+	root.AddCommand(add, ls, done, tag)
+	return root
+}
+
+// RunTodo runs one todo command line on list and returns the exit code.
+func RunTodo(list *TodoList, args []string, stdout, stderr io.Writer) int {
+	return NewTodoCommand(list).Execute(args, stdout, stderr)
+}
+
+// DemonstrateFramework runs a few todo command lines.
+func DemonstrateFramework() {
+	list := &TodoList{}
+	for _, line := range []string{
+		"add Buy milk",
+		"add -priority 1 Fix the build",
+		"tag add 2 work urgent",
+		"-q done 1",
+		"ls -all",
+		"tag",
+		"lst",
+		"done 7",
+		"help tag rm",
+	} {
+		var out, errs strings.Builder
+		code := RunTodo(list, strings.Fields(line), &out, &errs)
+		fmt.Printf("$ todo %s  (exit %d)\n%s%s\n", line, code, out.String(), errs.String())
+	}
+}
@@ -0,0 +1,76 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateFramework(t *testing.T) {
+	DemonstrateFramework()
+}
+
+// todo runs a todo command line on list.
+func todo(list *TodoList, line string) (int, string, string) {
+	var out, errs strings.Builder
+	code := RunTodo(list, strings.Fields(line), &out, &errs)
+	return code, out.String(), errs.String()
+}
+
+func TestTodo(t *testing.T) {
+	list := &TodoList{}
+	code, out, _ := todo(list, "add Buy milk")
+	assert.Equal(t, ExitOK, code)
+	assert.Equal(t, "added #1\n", out)
+
+	code, out, _ = todo(list, "add Fix the build -priority 1 -q")
+	assert.Equal(t, ExitOK, code)
+	assert.Empty(t, out, "-q is global, and flags may follow the arguments")
+	assert.Equal(t, Todo{Title: "Fix the build", Priority: 1}, list.Items[1])
+
+	code, _, _ = todo(list, "-q tag add 2 work urgent")
+	assert.Equal(t, ExitOK, code)
+	code, _, _ = todo(list, "tag remove 2 urgent")
+	assert.Equal(t, ExitOK, code)
+	code, _, _ = todo(list, "done 1")
+	assert.Equal(t, ExitOK, code)
+
+	_, out, _ = todo(list, "ls")
+	assert.Equal(t, "2 [ ] p1 Fix the build #work\n", out)
+	_, out, _ = todo(list, "list -all")
+	assert.Equal(t, "1 [x] p2 Buy milk\n2 [ ] p1 Fix the build #work\n", out)
+}
+
+func TestTodoErrors(t *testing.T) {
+	list := &TodoList{Items: []Todo{{Title: "a", Priority: 2, Done: true}}}
+	for _, tt := range []struct {
+		line   string
+		code   int
+		stderr string
+	}{
+		{"done 1", ExitError, "todo done: #1 is already done\n"},
+		{"done 2", ExitUsage, `todo done: no todo "2"; the list has 1`},
+		{"add", ExitUsage, "want at least 1 arguments, got 0"},
+		{"add -priority 9 x", ExitUsage, "-priority 9: want 1, 2, or 3"},
+		{"list -priority 1", ExitUsage, "flag provided but not defined: -priority"},
+		{"tag rm 1 nope", ExitError, `#1 has no tag "nope"`},
+		{"tag ad 1 x", ExitUsage, `todo tag: unknown command "ad"; did you mean add?`},
+	} {
+		code, _, stderr := todo(list, tt.line)
+		assert.Equal(t, tt.code, code, tt.line)
+		assert.Contains(t, stderr, tt.stderr, tt.line)
+	}
+	assert.Len(t, list.Items, 1)
+}
+
+func TestTodoHelp(t *testing.T) {
+	code, out, _ := todo(&TodoList{}, "-h")
+	assert.Equal(t, ExitOK, code)
+	assert.Contains(t, out, "Commands:\n  add   Add a todo\n  done  Mark a todo as done\n  list  List the todos\n  tag   Add or remove tags\n")
+
+	_, out, _ = todo(&TodoList{}, "add -h")
+	assert.Contains(t, out, "Usage:\n  todo add [flags] <title>...\n")
+	assert.Contains(t, out, "-priority int")
+	assert.Contains(t, out, "Global flags:\n  -q\tprint nothing on success\n")
+}
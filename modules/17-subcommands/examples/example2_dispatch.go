@@ -0,0 +1,147 @@
+package examples
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DispatchExamples demonstrates subcommands without a framework.
+//
+// A switch on the first argument picks the subcommand, and each subcommand
+// parses the rest with a FlagSet of its own. Global flags are parsed first
+// by the root's FlagSet, which stops at the subcommand name. That is all
+// there is to it for two levels; past that, a tree of commands saves
+// repeating the switch, the help, and the error handling at every level.
+
+// Exit codes of RunRemote; pkg/cli uses the same ones.
+const (
+	ExitOK    = 0
+	ExitError = 1 // the command ran and failed
+	ExitUsage = 2 // the command line was wrong
+)
+
+// RemoteSet is what the remote tool manages: remote names to URLs.
+type RemoteSet map[string]string
+
+// errUsage marks a mistake in the command line.
+var errUsage = errors.New("usage")
+
+// RunRemote is a git-remote-like tool with the dispatch written out:
+//
+//	remote [-v] list
+//	remote [-v] add [-f] <name> <url>
+//	remote [-v] rm <name>
+func RunRemote(remotes RemoteSet, args []string, stdout, stderr io.Writer) int {
+	global := flag.NewFlagSet("remote", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	verbose := global.Bool("v", false, "show URLs")
+	global.Usage = func() {
+		fmt.Fprintln(global.Output(), "Usage: remote [-v] <list|add|rm> [args]")
+		global.PrintDefaults()
+	}
+	if err := global.Parse(args); err != nil {
+		return parseExit(err)
+	}
+	if global.NArg() == 0 {
+		global.Usage()
+		return ExitUsage
+	}
+
+	// The subcommand's FlagSet starts after its name, so "-v" before the
+	// name is global and "-f" after it belongs to add.
+	name, rest := global.Arg(0), global.Args()[1:]
+	fs := flag.NewFlagSet("remote "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var err error
+	switch name {
+	case "list":
+		if err := fs.Parse(rest); err != nil {
+			return parseExit(err)
+		}
+		names := make([]string, 0, len(remotes))
+		for n := range remotes {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			if *verbose {
+				fmt.Fprintf(stdout, "%s\t%s\n", n, remotes[n])
+			} else {
+				fmt.Fprintln(stdout, n)
+			}
+		}
+	case "add":
+		force := fs.Bool("f", false, "replace an existing remote")
+		if err := fs.Parse(rest); err != nil {
+			return parseExit(err)
+		}
+		err = addRemote(remotes, fs.Args(), *force)
+	case "rm":
+		if err := fs.Parse(rest); err != nil {
+			return parseExit(err)
+		}
+		if fs.NArg() != 1 {
+			err = fmt.Errorf("%w: rm takes a name", errUsage)
+		} else if _, ok := remotes[fs.Arg(0)]; !ok {
+			err = fmt.Errorf("no remote %q", fs.Arg(0))
+		} else {
+			delete(remotes, fs.Arg(0))
+		}
+	default:
+		err = fmt.Errorf("%w: unknown command %q", errUsage, name)
+	}
+
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, errUsage):
+		fmt.Fprintln(stderr, "remote:", strings.TrimPrefix(err.Error(), "usage: "))
+		return ExitUsage
+	default:
+		fmt.Fprintln(stderr, "remote:", err)
+		return ExitError
+	}
+}
+
+// parseExit is the exit code for an error of fs.Parse, which has already
+// printed it with the usage.
+func parseExit(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+	return ExitUsage
+}
+
+// addRemote adds the remote that args name.
+func addRemote(remotes RemoteSet, args []string, force bool) error {
+	if len(args) != 2 {
+		return fmt.Errorf("%w: add takes a name and a URL", errUsage)
+	}
+	if _, ok := remotes[args[0]]; ok && !force {
+		return fmt.Errorf("remote %q exists; use -f to replace it", args[0])
+	}
+	remotes[args[0]] = args[1]
+	return nil
+}
+
+// DemonstrateDispatch runs a few remote command lines.
+func DemonstrateDispatch() {
+	remotes := RemoteSet{}
+	for _, line := range []string{
+		"add origin https://example.com/you/repo",
+		"add upstream https://example.com/them/repo",
+		"add origin https://example.com/other",
+		"-v list",
+		"list -v", // -v after "list" is for list, which has no such flag
+		"rm fork",
+		"push",
+	} {
+		var out, errs strings.Builder
+		code := RunRemote(remotes, strings.Fields(line), &out, &errs)
+		fmt.Printf("$ remote %s  (exit %d)\n%s%s\n", line, code, out.String(), errs.String())
+	}
+}
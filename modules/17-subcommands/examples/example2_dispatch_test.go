@@ -0,0 +1,48 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateDispatch(t *testing.T) {
+	DemonstrateDispatch()
+}
+
+func TestRunRemote(t *testing.T) {
+	remotes := RemoteSet{}
+	run := func(line string) (int, string, string) {
+		var out, errs strings.Builder
+		code := RunRemote(remotes, strings.Fields(line), &out, &errs)
+		return code, out.String(), errs.String()
+	}
+
+	code, _, _ := run("add origin u1")
+	assert.Equal(t, ExitOK, code)
+	code, _, errs := run("add origin u2")
+	assert.Equal(t, ExitError, code)
+	assert.Contains(t, errs, "use -f")
+	code, _, _ = run("add -f origin u2")
+	assert.Equal(t, ExitOK, code)
+	run("add backup u3")
+
+	_, out, _ := run("list")
+	assert.Equal(t, "backup\norigin\n", out)
+	_, out, _ = run("-v list")
+	assert.Equal(t, "backup\tu3\norigin\tu2\n", out)
+
+	code, _, _ = run("list -v")
+	assert.Equal(t, ExitUsage, code, "-v after list is not global")
+	code, _, _ = run("rm backup")
+	assert.Equal(t, ExitOK, code)
+	assert.Equal(t, RemoteSet{"origin": "u2"}, remotes)
+
+	for _, line := range []string{"", "push", "rm", "add x", "-x list"} {
+		code, _, _ := run(line)
+		assert.Equal(t, ExitUsage, code, "%q", line)
+	}
+	code, _, _ = run("-h")
+	assert.Equal(t, ExitOK, code)
+}
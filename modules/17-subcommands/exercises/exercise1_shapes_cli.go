@@ -0,0 +1,175 @@
+package exercises
+
+// EXERCISE: Wire up the subcommands of the shapes tool.
+// Module 09's shapes tool did one thing. This one has subcommands, built
+// as a tree of pkg/cli Commands:
+//
+//	shapes [-precision n] [-unit u] area <shape> <size>...
+//	shapes [-precision n] [-unit u] perimeter <shape> <size>...
+//	shapes [-precision n] units list
+//	shapes [-precision n] units convert <length> <from> <to>
+//
+// -precision and -unit are global: every command accepts them, before or
+// after its name. The tests run whole command lines through Main, the way
+// a user would. Fix the bugs marked with // BUG: comments.
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/cli"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// centimeters is the length of each unit in centimeters.
+var centimeters = map[string]float64{"mm": 0.1, "cm": 1, "m": 100, "in": 2.54}
+
+// sizesPerShape is how many sizes each shape takes.
+var sizesPerShape = map[string]int{"circle": 1, "square": 1, "rectangle": 2}
+
+// parseShape returns the shape that args describe: a shape name and its
+// sizes.
+func parseShape(args []string) (geometry.Shape, error) {
+	want, ok := sizesPerShape[args[0]]
+	if !ok {
+		// BUG: Naming a shape that does not exist is a mistake in the
+		// command line, which exits with 2 and points to the help. Errors
+		// made with cli.Usagef do; other errors exit with 1.
+		return nil, fmt.Errorf("unknown shape %q: want circle, square, or rectangle", args[0])
+	}
+	if len(args)-1 != want {
+		return nil, cli.Usagef("%s takes %d sizes, got %d", args[0], want, len(args)-1)
+	}
+	sizes := make([]float64, want)
+	for i, s := range args[1:] {
+		size, err := strconv.ParseFloat(s, 64)
+		if err != nil || size <= 0 {
+			return nil, cli.Usagef("size %q: want a positive number", s)
+		}
+		sizes[i] = size
+	}
+	switch args[0] {
+	case "circle":
+		return geometry.Circle{Radius: sizes[0]}, nil
+	case "square":
+		return geometry.Rectangle{Width: sizes[0], Height: sizes[0]}, nil
+	default:
+		return geometry.Rectangle{Width: sizes[0], Height: sizes[1]}, nil
+	}
+}
+
+// checkUnit returns an error unless unit is a known length unit.
+func checkUnit(unit string) error {
+	if _, ok := centimeters[unit]; !ok {
+		return cli.Usagef("unknown unit %q: want mm, cm, m, or in", unit)
+	}
+	return nil
+}
+
+// NewShapesCommand returns the shapes tool.
+func NewShapesCommand() *cli.Command {
+	root := &cli.Command{
+		Use:   "shapes",
+		Short: "Measure shapes and convert lengths",
+	}
+	unit := root.PersistentFlags().String("unit", "cm", "length `unit` of the sizes: mm, cm, m, or in")
+
+	area := &cli.Command{
+		Use:   "area <circle|square|rectangle> <size>...",
+		Short: "Print the area of a shape",
+		Args:  cli.MinimumArgs(1),
+	}
+	// BUG: -precision is a flag of area alone, so "shapes perimeter
+	// -precision 3 ..." and "shapes -precision 3 area ..." are rejected.
+	// Make it a persistent flag of the root, like -unit.
+	precision := area.Flags().Int("precision", 2, "print `n` digits after the decimal point")
+	area.Run = func(ctx *cli.Context) error {
+		if err := checkUnit(*unit); err != nil {
+			return err
+		}
+		s, err := parseShape(ctx.Args)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(ctx.Stdout, "%.*f %s²\n", *precision, s.Area(), *unit)
+		return nil
+	}
+
+	// BUG: The first word of Use is the command's name, and it is spelled
+	// wrong: "shapes perimeter" is an unknown command.
+	perimeter := &cli.Command{
+		Use:   "perimiter <circle|square|rectangle> <size>...",
+		Short: "Print the perimeter of a shape",
+		Args:  cli.MinimumArgs(1),
+		Run: func(ctx *cli.Context) error {
+			if err := checkUnit(*unit); err != nil {
+				return err
+			}
+			s, err := parseShape(ctx.Args)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(ctx.Stdout, "%.*f %s\n", *precision, s.Perimeter(), *unit)
+			return nil
+		},
+	}
+
+	// BUG: units has no Short, so the help of shapes lists it without a
+	// description. Describe it as "Work with length units".
+	units := &cli.Command{
+		Use: "units",
+	}
+	list := &cli.Command{
+		Use:   "list",
+		Short: "List the length units",
+		Args:  cli.NoArgs,
+		Run: func(ctx *cli.Context) error {
+			names := make([]string, 0, len(centimeters))
+			for name := range centimeters {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				fmt.Fprintf(ctx.Stdout, "%s\t%g cm\n", name, centimeters[name])
+			}
+			return nil
+		},
+	}
+	convert := &cli.Command{
+		Use:   "convert <length> <from> <to>",
+		Short: "Convert a length to another unit",
+		Args:  cli.ExactArgs(3),
+		Run: func(ctx *cli.Context) error {
+			length, err := strconv.ParseFloat(ctx.Args[0], 64)
+			if err != nil {
+				return cli.Usagef("length %q: not a number", ctx.Args[0])
+			}
+			from, to := ctx.Args[1], ctx.Args[2]
+			if err := checkUnit(from); err != nil {
+				return err
+			}
+			if err := checkUnit(to); err != nil {
+				return err
+			}
+			fmt.Fprintf(ctx.Stdout, "%.*f %s\n", *precision, length*centimeters[from]/centimeters[to], to)
+			return nil
+		},
+	}
+	units.AddCommand(list)
+	// BUG: convert belongs under units ("shapes units convert"), not
+	// directly under the root.
+	root.AddCommand(convert)
+
+	root.AddCommand(area, perimeter, units)
+	return root
+}
+
+// Main runs the shapes tool with the arguments after the program name and
+// returns the exit code. The real main would be
+//
+//	os.Exit(Main(os.Args[1:], os.Stdout, os.Stderr))
+func Main(args []string, stdout, stderr io.Writer) int {
+	return NewShapesCommand().Execute(args, stdout, stderr)
+}
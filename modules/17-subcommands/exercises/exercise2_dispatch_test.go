@@ -0,0 +1,153 @@
+package exercises
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tree returns a shapes tool whose commands record their arguments in
+// calls.
+func tree(calls *[]string) *Node {
+	record := func(name string) func([]string, io.Writer) error {
+		return func(args []string, stdout io.Writer) error {
+			*calls = append(*calls, fmt.Sprintf("%s %q", name, args))
+			if len(args) > 0 && args[0] == "fail" {
+				return errors.New("failed")
+			}
+			fmt.Fprintln(stdout, name, "ok")
+			return nil
+		}
+	}
+	units := &Node{Name: "units", Summary: "Work with length units", Subs: []*Node{
+		{Name: "list", Summary: "List the length units", Run: record("list")},
+		{Name: "convert", Args: "<length> <from> <to>", Summary: "Convert a length", Run: record("convert")},
+	}}
+	return &Node{Name: "shapes", Summary: "Measure shapes", Subs: []*Node{
+		{Name: "perimeter", Args: "<shape> <size>...", Summary: "Print the perimeter", Run: record("perimeter")},
+		units,
+		{Name: "area", Args: "<shape> <size>...", Summary: "Print the area", Run: record("area")},
+	}}
+}
+
+// dispatch runs Dispatch, failing the test instead of the test binary if
+// it panics.
+func dispatch(t *testing.T, root *Node, args ...string) (code int, stdout, stderr string) {
+	var out, errs strings.Builder
+	require.NotPanics(t, func() { code = Dispatch(root, args, &out, &errs) }, "%q", args)
+	return code, out.String(), errs.String()
+}
+
+// names returns the names of the nodes of path.
+func names(path []*Node) []string {
+	var out []string
+	for _, n := range path {
+		out = append(out, n.Name)
+	}
+	return out
+}
+
+func TestResolve(t *testing.T) {
+	root := tree(new([]string))
+	path, rest := Resolve(root, []string{"area", "circle", "2"})
+	assert.Equal(t, []string{"shapes", "area"}, names(path))
+	assert.Equal(t, []string{"circle", "2"}, rest)
+
+	path, rest = Resolve(root, []string{"units", "convert", "1", "in", "cm"})
+	assert.Equal(t, []string{"shapes", "units", "convert"}, names(path), "names are looked up below the last node")
+	assert.Equal(t, []string{"1", "in", "cm"}, rest)
+
+	path, rest = Resolve(root, []string{"units", "area"})
+	assert.Equal(t, []string{"shapes", "units"}, names(path), "area is not below units")
+	assert.Equal(t, []string{"area"}, rest)
+
+	path, rest = Resolve(root, nil)
+	assert.Equal(t, []string{"shapes"}, names(path))
+	assert.Empty(t, rest)
+}
+
+func TestHelpUsage(t *testing.T) {
+	root := tree(new([]string))
+	var out strings.Builder
+	path, _ := Resolve(root, []string{"units", "convert"})
+	Help(path, &out)
+	assert.Equal(t, "Convert a length\n\nUsage: shapes units convert <length> <from> <to>\n", out.String())
+
+	out.Reset()
+	path, _ = Resolve(root, []string{"units"})
+	Help(path, &out)
+	assert.Equal(t, `Work with length units
+
+Usage: shapes units <command>
+
+Commands:
+  convert  Convert a length
+  list     List the length units
+`, out.String())
+}
+
+func TestHelpSorted(t *testing.T) {
+	root := tree(new([]string))
+	var out strings.Builder
+	Help([]*Node{root}, &out)
+	assert.Equal(t, `Measure shapes
+
+Usage: shapes <command>
+
+Commands:
+  area       Print the area
+  perimeter  Print the perimeter
+  units      Work with length units
+`, out.String())
+	assert.Equal(t, []string{"perimeter", "units", "area"}, names(root.Subs), "the tree is unchanged")
+}
+
+func TestDispatch(t *testing.T) {
+	var calls []string
+	root := tree(&calls)
+	run := func(args ...string) (int, string, string) { return dispatch(t, root, args...) }
+
+	r := seed.Rand(t, 1)
+	size := fmt.Sprint(seed.Between(r, 1, 100))
+	code, out, _ := run("area", "circle", size)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "area ok\n", out)
+	code, _, _ = run("units", "convert", "1", "in", "cm")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, []string{fmt.Sprintf(`area ["circle" %q]`, size), `convert ["1" "in" "cm"]`}, calls)
+
+	code, _, errs := run("units", "list", "fail")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "shapes units list: failed\n", errs)
+
+	code, out, _ = run("units", "-h")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out, "Commands:")
+}
+
+func TestDispatchGroups(t *testing.T) {
+	var calls []string
+	root := tree(&calls)
+	run := func(args ...string) (int, string, string) { return dispatch(t, root, args...) }
+
+	code, out, errs := run("units")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out, "Usage: shapes units <command>\n", "a group prints its help")
+	assert.Empty(t, errs)
+
+	code, out, errs = run("units", "frobnicate", "2")
+	assert.Equal(t, 2, code)
+	assert.Empty(t, out)
+	assert.Equal(t, "shapes units: unknown command \"frobnicate\"\n", errs)
+
+	code, _, errs = run("volume")
+	assert.Equal(t, 2, code)
+	assert.Equal(t, "shapes: unknown command \"volume\"\n", errs)
+	assert.Empty(t, calls)
+}
@@ -0,0 +1,122 @@
+package solutions
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/cli"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+)
+
+// shapes runs a shapes command line.
+func shapes(line string) (code int, stdout, stderr string) {
+	var out, errs strings.Builder
+	code = Main(strings.Fields(line), &out, &errs)
+	return code, out.String(), errs.String()
+}
+
+func TestArea(t *testing.T) {
+	r := seed.Rand(t, 2)
+	w, h := seed.Between(r, 1, 50), seed.Between(r, 1, 50)
+	for line, want := range map[string]string{
+		"area circle 1":                           "3.14 cm²\n",
+		"area -precision 4 circle 1":              "3.1416 cm²\n",
+		"area square 3 -unit in":                  "9.00 in²\n",
+		fmt.Sprintf("area rectangle %d %d", w, h): fmt.Sprintf("%d.00 cm²\n", w*h),
+	} {
+		code, out, errs := shapes(line)
+		assert.Equal(t, cli.ExitOK, code, "%s: %s", line, errs)
+		assert.Equal(t, want, out, line)
+	}
+}
+
+func TestPerimeter(t *testing.T) {
+	code, out, errs := shapes("perimeter square 2")
+	assert.Equal(t, cli.ExitOK, code, errs)
+	assert.Equal(t, "8.00 cm\n", out)
+
+	code, out, _ = shapes("perimeter circle 1 -unit m -precision 3")
+	assert.Equal(t, cli.ExitOK, code)
+	assert.Equal(t, fmt.Sprintf("%.3f m\n", 2*math.Pi), out)
+}
+
+func TestGlobalFlags(t *testing.T) {
+	for _, line := range []string{
+		"-precision 1 area circle 1",
+		"area -precision 1 circle 1",
+		"area circle 1 -precision 1",
+		"-precision=1 area circle 1",
+	} {
+		code, out, errs := shapes(line)
+		assert.Equal(t, cli.ExitOK, code, "%s: %s", line, errs)
+		assert.Equal(t, "3.1 cm²\n", out, line)
+	}
+
+	code, out, errs := shapes("-precision 0 perimeter rectangle 2 3")
+	assert.Equal(t, cli.ExitOK, code, errs)
+	assert.Equal(t, "10 cm\n", out)
+}
+
+func TestUnits(t *testing.T) {
+	code, out, errs := shapes("units list")
+	assert.Equal(t, cli.ExitOK, code, errs)
+	assert.Equal(t, "cm\t1 cm\nin\t2.54 cm\nm\t100 cm\nmm\t0.1 cm\n", out)
+
+	r := seed.Rand(t, 1)
+	n := seed.Between(r, 1, 100)
+	code, out, errs = shapes(fmt.Sprintf("units convert %d m cm", n))
+	assert.Equal(t, cli.ExitOK, code, errs)
+	assert.Equal(t, fmt.Sprintf("%d.00 cm\n", 100*n), out)
+
+	code, out, errs = shapes("-precision 3 units convert 1 in mm")
+	assert.Equal(t, cli.ExitOK, code, errs)
+	assert.Equal(t, "25.400 mm\n", out)
+
+	code, _, _ = shapes("convert 1 in mm")
+	assert.Equal(t, cli.ExitUsage, code, "convert is a subcommand of units")
+}
+
+func TestUsageErrors(t *testing.T) {
+	for _, tt := range []struct {
+		line, stderr string
+	}{
+		{"area", "shapes area: want at least 1 arguments, got 0"},
+		{"area hexagon 2", `shapes area: unknown shape "hexagon"`},
+		{"perimeter rectangle 2", "shapes perimeter: rectangle takes 2 sizes, got 1"},
+		{"area circle 0", `size "0": want a positive number`},
+		{"area -- circle -2", `size "-2": want a positive number`},
+		{"area circle 1 -unit ft", `unknown unit "ft"`},
+		{"units convert 1 cm", "shapes units convert: want 3 arguments, got 2"},
+		{"units convert 1 cm parsec", `unknown unit "parsec"`},
+		{"volume cube 2", `shapes: unknown command "volume"`},
+		{"area circle 1 -radius 2", "flag provided but not defined: -radius"},
+	} {
+		code, out, errs := shapes(tt.line)
+		assert.Equal(t, cli.ExitUsage, code, "%s: %s", tt.line, errs)
+		assert.Contains(t, errs, tt.stderr, tt.line)
+		assert.Contains(t, errs, "-h' for usage.", tt.line)
+		assert.Empty(t, out, tt.line)
+	}
+}
+
+func TestHelp(t *testing.T) {
+	code, out, _ := shapes("-h")
+	assert.Equal(t, cli.ExitOK, code)
+	assert.Contains(t, out, `Commands:
+  area       Print the area of a shape
+  perimeter  Print the perimeter of a shape
+  units      Work with length units
+`)
+	assert.Contains(t, out, "-precision n\n")
+	assert.Contains(t, out, "-unit unit\n")
+
+	_, out, _ = shapes("help units")
+	assert.Contains(t, out, "Commands:\n  convert  Convert a length to another unit\n  list     List the length units\n")
+
+	_, out, _ = shapes("units convert -h")
+	assert.Contains(t, out, "Usage:\n  shapes units convert [flags] <length> <from> <to>\n")
+	assert.Contains(t, out, "Global flags:\n  -precision n")
+}
@@ -0,0 +1,126 @@
+package solutions
+
+// SOLUTION: The fixed hand-written subcommand dispatcher.
+// pkg/cli is one way to build a tree of commands; this is the core of it
+// in a few functions. A Node is a command, Resolve finds the command that
+// the arguments name, Help describes it, and Dispatch runs it:
+//
+//	Dispatch(root, []string{"units", "convert", "1", "in", "cm"}, ...)
+//
+// walks root -> units -> convert and calls convert's Run with
+// ["1" "in" "cm"]. Each fix is marked with a // Fixed: comment.
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Node is a command of a tool, with the subcommands below it.
+type Node struct {
+	Name    string
+	Args    string // synopsis of the positional arguments, e.g. "<from> <to>"
+	Summary string
+	Subs    []*Node
+	// Run does the command's work; nil for a node that only groups
+	// subcommands.
+	Run func(args []string, stdout io.Writer) error
+}
+
+// sub returns the subcommand of n called name, or nil.
+func (n *Node) sub(name string) *Node {
+	for _, s := range n.Subs {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// Resolve follows the subcommand names at the start of args down from
+// root. It returns the nodes on the way, root first and the command to run
+// last, and the arguments left for that command.
+func Resolve(root *Node, args []string) (path []*Node, rest []string) {
+	path = []*Node{root}
+	for len(args) > 0 {
+		next := path[len(path)-1].sub(args[0]) // Fixed: look below the node found last
+		if next == nil {
+			break
+		}
+		path = append(path, next)
+		args = args[1:]
+	}
+	return path, args
+}
+
+// Help writes the help of the last node of path: its summary, how to call
+// it, and its subcommands sorted by name.
+func Help(path []*Node, w io.Writer) {
+	n := path[len(path)-1]
+	if n.Summary != "" {
+		fmt.Fprintf(w, "%s\n\n", n.Summary)
+	}
+	// Fixed: the usage starts with the names of the parents
+	names := make([]string, len(path))
+	for i, p := range path {
+		names[i] = p.Name
+	}
+	usage := strings.Join(names, " ")
+	if n.Run != nil {
+		fmt.Fprintf(w, "Usage: %s\n", strings.TrimSpace(usage+" "+n.Args))
+	} else {
+		fmt.Fprintf(w, "Usage: %s <command>\n", usage)
+	}
+	if len(n.Subs) == 0 {
+		return
+	}
+
+	// Fixed: sort a copy, leaving the tree as it was
+	subs := append([]*Node(nil), n.Subs...)
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Name < subs[j].Name })
+	width := 0
+	for _, s := range subs {
+		if len(s.Name) > width {
+			width = len(s.Name)
+		}
+	}
+	fmt.Fprintln(w, "\nCommands:")
+	for _, s := range subs {
+		fmt.Fprintf(w, "  %-*s  %s\n", width, s.Name, s.Summary)
+	}
+}
+
+// Dispatch runs the command that args name below root and returns the
+// exit code: 0 on success or for -h, 1 when the command fails, and 2 for
+// an unknown command. Errors go to stderr, prefixed with the path of the
+// command.
+func Dispatch(root *Node, args []string, stdout, stderr io.Writer) int {
+	path, rest := Resolve(root, args)
+	n := path[len(path)-1]
+	names := make([]string, len(path))
+	for i, p := range path {
+		names[i] = p.Name
+	}
+	prefix := strings.Join(names, " ")
+
+	for _, arg := range rest {
+		if arg == "-h" || arg == "-help" || arg == "--help" {
+			Help(path, stdout)
+			return 0
+		}
+	}
+	if n.Run == nil { // Fixed: a group has nothing to run
+		if len(rest) == 0 {
+			Help(path, stdout)
+			return 0
+		}
+		fmt.Fprintf(stderr, "%s: unknown command %q\n", prefix, rest[0])
+		return 2
+	}
+	if err := n.Run(rest, stdout); err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", prefix, err)
+		return 1
+	}
+	return 0
+}
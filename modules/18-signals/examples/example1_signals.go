@@ -0,0 +1,116 @@
+// Package examples demonstrates how long-running Go programs learn that
+// they should stop, and how they stop well.
+//
+// This file shows:
+// - signal.NotifyContext, which turns signals into a cancelled context
+// - SIGINT (Ctrl+C) and SIGTERM (service managers), and why both matter
+// - A grace period: finish the work in hand, but not forever
+// - A second signal as "stop now"
+// - signal.Ignore, and why SIGTERM should not be ignored
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// StopSignals are the signals a service should stop on: os.Interrupt is
+// SIGINT, sent by Ctrl+C in a terminal; SIGTERM is what kill, systemd,
+// Docker, and Kubernetes send first, before SIGKILL, which cannot be
+// caught.
+var StopSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// ErrGraceExpired is returned by RunUntilSignal when the work did not
+// return within the grace period after a signal.
+var ErrGraceExpired = errors.New("grace period expired")
+
+// RunUntilSignal runs work until it returns or one of sigs arrives. On a
+// signal, the context passed to work is cancelled and work gets grace to
+// return; a second signal, or the end of the grace period, gives up on it.
+func RunUntilSignal(work func(ctx context.Context) error, grace time.Duration, sigs ...os.Signal) error {
+	// NotifyContext cancels ctx on the first signal. Until stop is called
+	// the signals are delivered to it rather than killing the process, so
+	// stop must run even when work returns on its own.
+	ctx, stop := signal.NotifyContext(context.Background(), sigs...)
+	defer stop()
+
+	done := make(chan error, 1)
+	go func() { done <- work(ctx) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+
+	// A second signal should not wait out the grace period: people press
+	// Ctrl+C twice when they mean it. A channel of our own catches it now
+	// that ctx is spent.
+	again := make(chan os.Signal, 1)
+	signal.Notify(again, sigs...)
+	defer signal.Stop(again)
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-again:
+		return fmt.Errorf("second signal: %w", ErrGraceExpired)
+	case <-timer.C:
+		return ErrGraceExpired
+	}
+}
+
+// Interrupt sends os.Interrupt to the current process, as Ctrl+C would.
+// Windows cannot send signals this way, and returns an error.
+func Interrupt() error {
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		return err
+	}
+	return p.Signal(os.Interrupt)
+}
+
+// DemonstrateSignals stops a worker with a signal the process sends
+// itself.
+func DemonstrateSignals() {
+	jobs := 0
+	work := func(ctx context.Context) error {
+		for {
+			select {
+			case <-ctx.Done():
+				// Stop taking new work, finish up, and return.
+				fmt.Printf("worker: stopping after %d jobs\n", jobs)
+				return nil
+			case <-time.After(5 * time.Millisecond):
+				jobs++
+				if jobs == 200 { // no signal: Windows, or something went wrong
+					return errors.New("no signal came")
+				}
+			}
+		}
+	}
+
+	time.AfterFunc(30*time.Millisecond, func() {
+		if err := Interrupt(); err != nil {
+			fmt.Println("cannot send a signal:", err)
+		}
+	})
+	err := RunUntilSignal(work, time.Second, StopSignals...)
+	fmt.Println("RunUntilSignal:", err)
+
+	// signal.Ignore drops a signal for the whole process. It suits SIGHUP
+	// for a program that should survive its terminal closing, but an
+	// ignored SIGTERM leaves a service manager no choice but SIGKILL,
+	// which skips every cleanup. signal.Reset undoes both Ignore and
+	// Notify.
+	signal.Ignore(syscall.SIGHUP)
+	fmt.Println("SIGHUP ignored:", signal.Ignored(syscall.SIGHUP))
+	signal.Reset(syscall.SIGHUP)
+	fmt.Println("SIGHUP ignored after Reset:", signal.Ignored(syscall.SIGHUP))
+}
@@ -0,0 +1,67 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateSignals(t *testing.T) {
+	DemonstrateSignals()
+}
+
+// interruptSoon sends os.Interrupt to the test process after d.
+func interruptSoon(t *testing.T, d time.Duration) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("signals cannot be sent on Windows")
+	}
+	time.AfterFunc(d, func() {
+		if err := Interrupt(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestRunUntilSignalWorkReturns(t *testing.T) {
+	err := RunUntilSignal(func(ctx context.Context) error { return errors.New("boom") }, time.Second, StopSignals...)
+	assert.EqualError(t, err, "boom")
+}
+
+func TestRunUntilSignalStops(t *testing.T) {
+	interruptSoon(t, 10*time.Millisecond)
+	stopped := false
+	err := RunUntilSignal(func(ctx context.Context) error {
+		<-ctx.Done()
+		stopped = true
+		return nil
+	}, time.Second, StopSignals...)
+	assert.NoError(t, err)
+	assert.True(t, stopped)
+}
+
+func TestRunUntilSignalGrace(t *testing.T) {
+	interruptSoon(t, 10*time.Millisecond)
+	start := time.Now()
+	err := RunUntilSignal(func(ctx context.Context) error {
+		time.Sleep(time.Minute) // ignores ctx
+		return nil
+	}, 50*time.Millisecond, StopSignals...)
+	assert.ErrorIs(t, err, ErrGraceExpired)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestRunUntilSignalTwice(t *testing.T) {
+	interruptSoon(t, 10*time.Millisecond)
+	interruptSoon(t, 50*time.Millisecond)
+	err := RunUntilSignal(func(ctx context.Context) error {
+		time.Sleep(time.Minute)
+		return nil
+	}, time.Minute, StopSignals...)
+	assert.ErrorIs(t, err, ErrGraceExpired)
+	assert.ErrorContains(t, err, "second signal")
+}
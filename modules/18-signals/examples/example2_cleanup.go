@@ -0,0 +1,125 @@
+package examples
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// CleanupExamples demonstrates cleaning up in the right order on the way
+// out.
+//
+// Deferred calls run last in, first out, so the cleanups for a resource
+// run before those of the resources it was built on: a buffer is flushed
+// before its file is closed, and the file is closed before the PID file
+// saying the process runs goes away. os.Exit skips deferred calls, which
+// is why main is usually the one line os.Exit(run()) and run does the
+// deferring.
+
+// PIDFile records the process ID of a running program in a file, so that
+// a second copy can refuse to start and scripts can find the process.
+type PIDFile struct {
+	Path string
+}
+
+// ErrRunning is returned by Acquire when the PID file exists.
+var ErrRunning = errors.New("already running")
+
+// Acquire creates the PID file with the current process ID. O_EXCL makes
+// creating and checking one step, so two copies starting at once cannot
+// both succeed.
+func (p PIDFile) Acquire() error {
+	f, err := os.OpenFile(p.Path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		data, _ := os.ReadFile(p.Path)
+		return fmt.Errorf("%w as pid %s (remove %s if it is not)", ErrRunning, strings.TrimSpace(string(data)), p.Path)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, os.Getpid()); err != nil {
+		f.Close()
+		os.Remove(p.Path)
+		return err
+	}
+	return f.Close()
+}
+
+// Release removes the PID file.
+func (p PIDFile) Release() error {
+	return os.Remove(p.Path)
+}
+
+// PID returns the process ID in the PID file.
+func (p PIDFile) PID() (int, error) {
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// WriteReport writes lines to path through a buffer, with the cleanups
+// deferred in the order that makes them run right. It logs each step to
+// log, so the order can be seen.
+func WriteReport(path string, lines []string, log func(string)) (err error) {
+	pid := PIDFile{Path: path + ".pid"}
+	if err := pid.Acquire(); err != nil {
+		return err
+	}
+	defer func() { pid.Release(); log("released PID file") }() // runs last
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// A failed Close can lose data that Write accepted; report it
+		// unless there is an earlier error.
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		log("closed file")
+	}()
+
+	w := bufio.NewWriter(f)
+	defer func() {
+		// Deferred after the Close above, so it runs before it.
+		if ferr := w.Flush(); err == nil {
+			err = ferr
+		}
+		log("flushed buffer")
+	}() // runs first
+
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+	log("wrote lines")
+	return nil
+}
+
+// DemonstrateCleanup writes a report and shows the order of the cleanups.
+func DemonstrateCleanup() {
+	dir, err := os.MkdirTemp("", "cleanup")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "report.txt")
+	err = WriteReport(path, []string{"jobs: 3", "failed: 0"}, func(step string) { fmt.Println(" ", step) })
+	data, _ := os.ReadFile(path)
+	fmt.Printf("err=%v, report=%q\n", err, data)
+
+	// A PID file that is already there stops a second copy.
+	pid := PIDFile{Path: filepath.Join(dir, "worker.pid")}
+	fmt.Println("first:", pid.Acquire())
+	fmt.Println("second:", pid.Acquire())
+	pid.Release()
+}
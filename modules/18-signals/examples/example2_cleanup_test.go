@@ -0,0 +1,44 @@
+package examples
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateCleanup(t *testing.T) {
+	DemonstrateCleanup()
+}
+
+func TestPIDFile(t *testing.T) {
+	pid := PIDFile{Path: filepath.Join(t.TempDir(), "x.pid")}
+	require.NoError(t, pid.Acquire())
+	n, err := pid.PID()
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), n)
+
+	err = pid.Acquire()
+	assert.ErrorIs(t, err, ErrRunning)
+	assert.ErrorContains(t, err, "remove "+pid.Path)
+
+	require.NoError(t, pid.Release())
+	assert.NoFileExists(t, pid.Path)
+	assert.NoError(t, pid.Acquire(), "free again")
+}
+
+func TestWriteReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "r.txt")
+	var steps []string
+	require.NoError(t, WriteReport(path, []string{"a", "b"}, func(s string) { steps = append(steps, s) }))
+	assert.Equal(t, []string{"wrote lines", "flushed buffer", "closed file", "released PID file"}, steps)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb\n", string(data))
+	assert.NoFileExists(t, path+".pid")
+
+	require.NoError(t, os.WriteFile(path+".pid", []byte("1\n"), 0o644))
+	assert.ErrorIs(t, WriteReport(path, nil, func(string) {}), ErrRunning)
+}
@@ -0,0 +1,113 @@
+package exercises
+
+// EXERCISE: Make the worker stop cleanly when it is told to.
+// The worker does jobs until it is stopped, writing a line per finished
+// job to a results file:
+//
+//	worker -pidfile worker.pid -out results.txt [-job 20ms]
+//
+// Ctrl+C sends it SIGINT; kill, systemd, Docker, and Kubernetes send
+// SIGTERM, wait a few seconds, then send SIGKILL, which cannot be caught.
+// On either signal the worker should finish the job in hand, flush its
+// results, remove its PID file, and exit with 0. The tests start it as a
+// separate process, signal it, and give it two seconds.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrRunning is returned by WritePIDFile when the PID file exists.
+var ErrRunning = errors.New("already running")
+
+// WritePIDFile creates path with the current process ID in it. It fails
+// with ErrRunning if the file exists: another worker holds it.
+func WritePIDFile(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		data, _ := os.ReadFile(path)
+		return fmt.Errorf("%w as pid %s", ErrRunning, strings.TrimSpace(string(data)))
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, os.Getpid()); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
+
+// Work does a job every jobTime, writing "job N done" to w for each, until
+// ctx is cancelled. It returns the number of jobs done.
+func Work(ctx context.Context, w io.Writer, jobTime time.Duration) int {
+	done := 0
+	ticker := time.NewTicker(jobTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			done++
+			fmt.Fprintf(w, "job %d done\n", done)
+			// BUG: Nothing here watches ctx, so the loop never ends, signal
+			// or not. Add a case that returns done once ctx is cancelled.
+		}
+	}
+}
+
+// Worker is the worker's main: it takes the arguments after the program
+// name and returns the exit code. It prints "ready" to stdout once it
+// handles signals, and how many jobs it did when it stops.
+func Worker(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("worker", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	pidFile := flags.String("pidfile", "worker.pid", "write the process ID to `path`")
+	out := flags.String("out", "results.txt", "write results to `path`")
+	jobTime := flags.Duration("job", 20*time.Millisecond, "how long a job takes")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if err := WritePIDFile(*pidFile); err != nil {
+		fmt.Fprintln(stderr, "worker:", err)
+		return 1
+	}
+	// BUG: The PID file stays behind when the worker exits, so the next
+	// worker refuses to start. Remove it on the way out.
+
+	f, err := os.Create(*out)
+	if err != nil {
+		fmt.Fprintln(stderr, "worker:", err)
+		return 1
+	}
+	w := bufio.NewWriter(f)
+	// BUG: Deferred calls run last in, first out: as written, the file is
+	// closed first, and the flush that follows cannot write to it. The
+	// results still in the buffer are lost.
+	defer w.Flush()
+	defer f.Close()
+
+	// BUG: Ignoring SIGHUP keeps the worker running when its terminal
+	// closes, but ignoring SIGTERM leaves a service manager nothing but
+	// SIGKILL, and no cleanup runs at all. Handle SIGTERM like SIGINT.
+	signal.Ignore(syscall.SIGHUP, syscall.SIGTERM)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Fprintln(stdout, "ready")
+	n := Work(ctx, w, *jobTime)
+	fmt.Fprintf(stdout, "stopped after %d jobs\n", n)
+	return 0
+}
@@ -0,0 +1,163 @@
+package exercises
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// workerEnv, when set, makes the test binary run Worker with the
+// arguments it holds, one per line, instead of the tests. That is how the
+// tests start the worker as a process of its own, which they can signal.
+const workerEnv = "LEARNGO_WORKER_ARGS"
+
+func TestMain(m *testing.M) {
+	if args, ok := os.LookupEnv(workerEnv); ok {
+		os.Exit(Worker(strings.Split(args, "\n"), os.Stdout, os.Stderr))
+	}
+	os.Exit(m.Run())
+}
+
+// deadline is how long the worker gets to stop after a signal.
+const deadline = 2 * time.Second
+
+// worker is a worker running as a separate process.
+type worker struct {
+	cmd     *exec.Cmd
+	stdout  *bufio.Scanner
+	pidFile string
+	out     string
+}
+
+// startWorker starts a worker process and waits until it is ready for
+// signals.
+func startWorker(t *testing.T) *worker {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows cannot send SIGINT or SIGTERM to a process")
+	}
+	dir := t.TempDir()
+	w := &worker{pidFile: filepath.Join(dir, "worker.pid"), out: filepath.Join(dir, "results.txt")}
+	args := []string{"-pidfile", w.pidFile, "-out", w.out, "-job", "5ms"}
+	w.cmd = exec.Command(os.Args[0])
+	w.cmd.Env = append(os.Environ(), workerEnv+"="+strings.Join(args, "\n"))
+	w.cmd.Stderr = os.Stderr
+	stdout, err := w.cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, w.cmd.Start())
+	t.Cleanup(func() { w.cmd.Process.Kill() })
+
+	w.stdout = bufio.NewScanner(stdout)
+	require.True(t, w.stdout.Scan(), "the worker exited before it was ready")
+	require.Equal(t, "ready", w.stdout.Text())
+	return w
+}
+
+// stop sends sig to the worker after it has done some jobs, and returns
+// its remaining output. The test fails if the worker does not exit with 0
+// within the deadline.
+func (w *worker) stop(t *testing.T, sig os.Signal) string {
+	t.Helper()
+	r := seed.Rand(t, 1)
+	time.Sleep(time.Duration(seed.Between(r, 30, 80)) * time.Millisecond)
+	require.NoError(t, w.cmd.Process.Signal(sig))
+
+	exited := make(chan error, 1)
+	var rest strings.Builder
+	go func() {
+		for w.stdout.Scan() {
+			rest.WriteString(w.stdout.Text() + "\n")
+		}
+		exited <- w.cmd.Wait()
+	}()
+	select {
+	case err := <-exited:
+		require.NoError(t, err, "the worker should exit with 0 after %v", sig)
+	case <-time.After(deadline):
+		w.cmd.Process.Kill()
+		t.Fatalf("the worker did not exit within %v after %v", deadline, sig)
+	}
+	return rest.String()
+}
+
+// checkCleanedUp checks that the worker removed its PID file and wrote a
+// line for every job it reported.
+func (w *worker) checkCleanedUp(t *testing.T, output string) {
+	t.Helper()
+	var jobs int
+	_, err := fmt.Sscanf(output, "stopped after %d jobs\n", &jobs)
+	require.NoError(t, err, "output: %q", output)
+	assert.Greater(t, jobs, 0)
+
+	assert.NoFileExists(t, w.pidFile, "the PID file is removed on the way out")
+	data, err := os.ReadFile(w.out)
+	require.NoError(t, err)
+	var want strings.Builder
+	for i := 1; i <= jobs; i++ {
+		fmt.Fprintf(&want, "job %d done\n", i)
+	}
+	assert.Equal(t, want.String(), string(data), "every finished job is in the results file")
+}
+
+func TestWorkerStopsOnSIGTERM(t *testing.T) {
+	w := startWorker(t)
+	output := w.stop(t, syscall.SIGTERM)
+	w.checkCleanedUp(t, output)
+}
+
+func TestWorkerStopsOnSIGINT(t *testing.T) {
+	w := startWorker(t)
+	output := w.stop(t, os.Interrupt)
+	w.checkCleanedUp(t, output)
+}
+
+func TestWorkStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var out strings.Builder
+	done := make(chan int, 1)
+	go func() { done <- Work(ctx, &out, time.Millisecond) }()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	select {
+	case n := <-done:
+		assert.Greater(t, n, 0)
+		assert.Equal(t, n, strings.Count(out.String(), "done\n"))
+	case <-time.After(deadline):
+		t.Fatal("Work did not return after its context was cancelled")
+	}
+}
+
+func TestWorkerRefusesSecondCopy(t *testing.T) {
+	dir := t.TempDir()
+	pidFile := filepath.Join(dir, "worker.pid")
+	require.NoError(t, os.WriteFile(pidFile, []byte("12345\n"), 0o644))
+
+	var stderr strings.Builder
+	code := Worker([]string{"-pidfile", pidFile, "-out", filepath.Join(dir, "out.txt")}, io.Discard, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "worker: already running as pid 12345\n", stderr.String())
+	assert.FileExists(t, pidFile, "another worker's PID file is left alone")
+	assert.NoFileExists(t, filepath.Join(dir, "out.txt"))
+}
+
+func TestWritePIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "w.pid")
+	require.NoError(t, WritePIDFile(path))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintln(os.Getpid()), string(data))
+	assert.ErrorIs(t, WritePIDFile(path), ErrRunning)
+}
@@ -0,0 +1,110 @@
+package solutions
+
+// SOLUTION: The worker, stopping cleanly when it is told to.
+// The worker does jobs until it is stopped, writing a line per finished
+// job to a results file:
+//
+//	worker -pidfile worker.pid -out results.txt [-job 20ms]
+//
+// Ctrl+C sends it SIGINT; kill, systemd, Docker, and Kubernetes send
+// SIGTERM, wait a few seconds, then send SIGKILL, which cannot be caught.
+// On either signal the worker should finish the job in hand, flush its
+// results, remove its PID file, and exit with 0. The tests start it as a
+// separate process, signal it, and give it two seconds.
+// Each fix is marked with a // Fixed: comment.
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrRunning is returned by WritePIDFile when the PID file exists.
+var ErrRunning = errors.New("already running")
+
+// WritePIDFile creates path with the current process ID in it. It fails
+// with ErrRunning if the file exists: another worker holds it.
+func WritePIDFile(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		data, _ := os.ReadFile(path)
+		return fmt.Errorf("%w as pid %s", ErrRunning, strings.TrimSpace(string(data)))
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, os.Getpid()); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
+
+// Work does a job every jobTime, writing "job N done" to w for each, until
+// ctx is cancelled. It returns the number of jobs done.
+func Work(ctx context.Context, w io.Writer, jobTime time.Duration) int {
+	done := 0
+	ticker := time.NewTicker(jobTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			done++
+			fmt.Fprintf(w, "job %d done\n", done)
+		case <-ctx.Done(): // Fixed: stop between jobs once ctx is cancelled
+			return done
+		}
+	}
+}
+
+// Worker is the worker's main: it takes the arguments after the program
+// name and returns the exit code. It prints "ready" to stdout once it
+// handles signals, and how many jobs it did when it stops.
+func Worker(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("worker", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	pidFile := flags.String("pidfile", "worker.pid", "write the process ID to `path`")
+	out := flags.String("out", "results.txt", "write results to `path`")
+	jobTime := flags.Duration("job", 20*time.Millisecond, "how long a job takes")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if err := WritePIDFile(*pidFile); err != nil {
+		fmt.Fprintln(stderr, "worker:", err)
+		return 1
+	}
+	defer os.Remove(*pidFile) // Fixed: deferred first, so removed last
+
+	f, err := os.Create(*out)
+	if err != nil {
+		fmt.Fprintln(stderr, "worker:", err)
+		return 1
+	}
+	w := bufio.NewWriter(f)
+	// Fixed: deferred calls run last in, first out, so defer Close before
+	// Flush to flush first.
+	defer f.Close()
+	defer w.Flush()
+
+	// Fixed: SIGHUP alone is ignored, and SIGTERM stops the worker like
+	// SIGINT does.
+	signal.Ignore(syscall.SIGHUP)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Fprintln(stdout, "ready")
+	n := Work(ctx, w, *jobTime)
+	fmt.Fprintf(stdout, "stopped after %d jobs\n", n)
+	return 0
+}
@@ -0,0 +1,139 @@
+// Package examples demonstrates running other programs with os/exec.
+//
+// The examples run the go command and gofmt, which every Go installation
+// has, so they work the same on every operating system.
+//
+// This file shows:
+// - exec.Command runs a program directly, with no shell in between
+// - Output and CombinedOutput, and separate stdout and stderr buffers
+// - exec.CommandContext and WaitDelay to put a limit on a command
+// - Exit codes with *exec.ExitError, and telling them from failures to start
+// - The environment and working directory of a child process
+package examples
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Result is what a finished command printed and how it exited.
+type Result struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// RunCommand runs name with args and waits for it, giving up when ctx is
+// done. A command that ran and exited with a non-zero code is not an
+// error: the code is in the result. The error is for commands that could
+// not run, or were stopped.
+func RunCommand(ctx context.Context, name string, args ...string) (Result, error) {
+	// No shell is involved: args go to the program as they are, so spaces
+	// and quotes in them are safe, and "|" or "*" mean nothing special.
+	cmd := exec.CommandContext(ctx, name, args...)
+	// CommandContext kills the process when ctx is done. WaitDelay bounds
+	// the wait after that for the output pipes to close, in case the
+	// process started children that keep them open.
+	cmd.WaitDelay = time.Second
+
+	// exec copies each stream into its buffer in a goroutine of its own,
+	// so a child writing a lot to stderr cannot block on a full pipe.
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	res := Result{Stdout: stdout.String(), Stderr: stderr.String()}
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		// Killed because of ctx; the exit status says "signal: killed".
+		return res, fmt.Errorf("%s: %w", name, ctx.Err())
+	case errors.As(err, &exitErr):
+		res.ExitCode = exitErr.ExitCode()
+		return res, nil
+	case err != nil:
+		// Not found, not executable, ...: the command never ran.
+		return res, err
+	}
+	return res, nil
+}
+
+// GoEnv returns the values of Go environment variables, as "go env"
+// prints them. dir is the working directory for the command, and extra
+// adds to the environment, as in "GOOS=windows go env GOOS".
+func GoEnv(dir string, extra []string, names ...string) (map[string]string, error) {
+	cmd := exec.Command("go", append([]string{"env"}, names...)...)
+	cmd.Dir = dir
+	// A nil Env means "the same as mine". To change a variable, start from
+	// os.Environ: an Env with only the new variables would drop PATH,
+	// HOME, and everything else the child needs. Later entries win.
+	if len(extra) > 0 {
+		cmd.Env = append(os.Environ(), extra...)
+	}
+	// Output captures stdout; on a non-zero exit, the *exec.ExitError it
+	// returns holds the stderr too.
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("go env: %s", bytes.TrimSpace(exitErr.Stderr))
+	}
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	values := make(map[string]string, len(names))
+	for i, name := range names {
+		if i < len(lines) {
+			values[name] = lines[i]
+		}
+	}
+	return values, nil
+}
+
+// DemonstrateExec runs the go command a few ways.
+func DemonstrateExec() {
+	ctx := context.Background()
+
+	res, err := RunCommand(ctx, "go", "version")
+	fmt.Printf("go version: %q, exit %d, err %v\n", strings.TrimSpace(res.Stdout), res.ExitCode, err)
+
+	// A command that runs and fails: exit code 2, message on stderr.
+	res, err = RunCommand(ctx, "go", "help", "no-such-topic")
+	fmt.Printf("go help no-such-topic: exit %d, stderr %q, err %v\n", res.ExitCode, firstLine(res.Stderr), err)
+
+	// A command that cannot run at all.
+	_, err = RunCommand(ctx, "no-such-program-learngo")
+	fmt.Println("missing program:", err, "- exec.ErrNotFound:", errors.Is(err, exec.ErrNotFound))
+
+	// A deadline that has passed before the command starts.
+	expired, cancel := context.WithTimeout(ctx, time.Nanosecond)
+	defer cancel()
+	time.Sleep(time.Millisecond)
+	_, err = RunCommand(expired, "go", "version")
+	fmt.Println("expired context:", err)
+
+	env, err := GoEnv(os.TempDir(), []string{"GOOS=windows", "GOARCH=arm64"}, "GOOS", "GOARCH")
+	fmt.Println("go env with GOOS=windows GOARCH=arm64:", env, err)
+
+	// CombinedOutput interleaves stdout and stderr as the child wrote them.
+	out, err := exec.Command("go", "env", "-w").CombinedOutput()
+	fmt.Printf("go env -w: %q, err %v\n", firstLine(string(out)), err)
+
+	if path, err := exec.LookPath("gofmt"); err == nil {
+		fmt.Println("gofmt is", filepath.Base(path))
+	}
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -0,0 +1,50 @@
+package examples
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateExec(t *testing.T) {
+	DemonstrateExec()
+}
+
+func TestRunCommand(t *testing.T) {
+	res, err := RunCommand(context.Background(), "go", "env", "GOOS")
+	require.NoError(t, err)
+	assert.Equal(t, Result{Stdout: runtime.GOOS + "\n"}, res)
+
+	res, err = RunCommand(context.Background(), "go", "help", "no-such-topic")
+	require.NoError(t, err, "a non-zero exit is a result, not an error")
+	assert.Equal(t, 2, res.ExitCode)
+	assert.Contains(t, res.Stderr, "unknown help topic")
+	assert.Empty(t, res.Stdout)
+
+	_, err = RunCommand(context.Background(), "no-such-program-learngo")
+	assert.ErrorIs(t, err, exec.ErrNotFound)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	_, err = RunCommand(ctx, "go", "version")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestGoEnv(t *testing.T) {
+	env, err := GoEnv(t.TempDir(), []string{"GOOS=plan9", "GOARCH=386"}, "GOOS", "GOARCH")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"GOOS": "plan9", "GOARCH": "386"}, env)
+
+	env, err = GoEnv(t.TempDir(), nil, "GOOS")
+	require.NoError(t, err)
+	assert.Equal(t, runtime.GOOS, env["GOOS"], "a nil Env inherits the environment")
+
+	_, err = GoEnv(t.TempDir(), nil, "-bogus")
+	assert.ErrorContains(t, err, "go env: flag provided but not defined: -bogus")
+}
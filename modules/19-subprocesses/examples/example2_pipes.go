@@ -0,0 +1,136 @@
+package examples
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// PipeExamples demonstrates connecting processes, and reading output
+// while the process runs.
+//
+// A pipe holds a few dozen kilobytes. A child that fills it blocks until
+// someone reads, so every pipe must be read while the process runs, not
+// after Wait; and every started process must be waited for, or it stays
+// in the process table as a zombie, with its pipes open.
+
+// Pipeline runs cmds connected like a shell pipeline: input goes to the
+// first, each command's stdout is the next one's stdin, and the last
+// one's stdout is returned. Each command's stderr is collected, and
+// reported for the first command that fails.
+func Pipeline(input string, cmds ...*exec.Cmd) (string, error) {
+	if len(cmds) == 0 {
+		return input, nil
+	}
+	cmds[0].Stdin = strings.NewReader(input)
+	stderrs := make([]bytes.Buffer, len(cmds))
+	for i, cmd := range cmds {
+		cmd.Stderr = &stderrs[i]
+		if i > 0 {
+			// An *os.File from StdoutPipe given as Stdin is handed to the
+			// child as it is: the two processes talk directly.
+			out, err := cmds[i-1].StdoutPipe()
+			if err != nil {
+				return "", err
+			}
+			cmd.Stdin = out
+		}
+	}
+	var out bytes.Buffer
+	cmds[len(cmds)-1].Stdout = &out
+
+	// Start every command before waiting for any: the first one may not
+	// finish until the second reads what it wrote.
+	for i, cmd := range cmds {
+		if err := cmd.Start(); err != nil {
+			// The ones already started must still be stopped and waited for.
+			for _, started := range cmds[:i] {
+				started.Process.Kill()
+				started.Wait()
+			}
+			return "", err
+		}
+	}
+	var firstErr error
+	for i, cmd := range cmds {
+		if err := cmd.Wait(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w: %s", cmd.Args[0], err, strings.TrimSpace(stderrs[i].String()))
+		}
+	}
+	return out.String(), firstErr
+}
+
+// ErrStop, returned by the function given to StreamLines, stops reading
+// without being an error.
+var ErrStop = errors.New("stop")
+
+// StreamLines starts cmd and calls fn for each line of its stdout as it
+// comes. If fn returns an error, the process is killed. Either way it is
+// waited for before StreamLines returns. Make cmd with exec.CommandContext
+// to limit how long it may run.
+func StreamLines(cmd *exec.Cmd, fn func(line string) error) error {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr // drained by exec while we read stdout
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	sc := bufio.NewScanner(stdout)
+	var fnErr error
+	for sc.Scan() {
+		if fnErr = fn(sc.Text()); fnErr != nil {
+			break
+		}
+	}
+	if fnErr != nil {
+		// Stop the child, then drain what is left in the pipe so that it
+		// is not blocked writing while it dies.
+		cmd.Process.Kill()
+		io.Copy(io.Discard, stdout)
+	}
+	// Wait after the reads are done: it closes stdout. It also reaps the
+	// process, which would otherwise stay a zombie.
+	waitErr := cmd.Wait()
+	switch {
+	case errors.Is(fnErr, ErrStop):
+		return nil
+	case fnErr != nil:
+		return fnErr
+	case waitErr != nil:
+		return fmt.Errorf("%w: %s", waitErr, strings.TrimSpace(stderr.String()))
+	}
+	return sc.Err()
+}
+
+// DemonstratePipes pipes Go source through two gofmt rewrites, and reads
+// the first lines "go list std" prints.
+func DemonstratePipes() {
+	src := "package p\nfunc f(items []int) { for _, item := range items { print(item) } }\n"
+	// In a gofmt rule, one-letter lower-case names match any expression.
+	out, err := Pipeline(src,
+		exec.Command("gofmt", "-r", "items -> values"),
+		exec.Command("gofmt", "-r", "print(x) -> println(x)"),
+	)
+	fmt.Printf("gofmt | gofmt:\n%s(err %v)\n", out, err)
+
+	_, err = Pipeline(src, exec.Command("gofmt", "-r", "not a rule ->"))
+	fmt.Println("bad rule:", err)
+
+	var first []string
+	err = StreamLines(exec.Command("go", "list", "std"), func(line string) error {
+		first = append(first, line)
+		if len(first) == 3 {
+			return ErrStop // kill go list; there is no need to read the rest
+		}
+		return nil
+	})
+	fmt.Println("first packages of go list std:", first, err)
+}
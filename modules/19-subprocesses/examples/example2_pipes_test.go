@@ -0,0 +1,56 @@
+package examples
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstratePipes(t *testing.T) {
+	DemonstratePipes()
+}
+
+func TestPipeline(t *testing.T) {
+	out, err := Pipeline("package p\nvar a = old\n",
+		exec.Command("gofmt", "-r", "old -> middle"),
+		exec.Command("gofmt", "-r", "middle -> new"),
+		exec.Command("gofmt"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "package p\n\nvar a = new\n", out)
+
+	out, err = Pipeline("unchanged")
+	require.NoError(t, err)
+	assert.Equal(t, "unchanged", out)
+
+	_, err = Pipeline("package p\n", exec.Command("gofmt"), exec.Command("no-such-program-learngo"))
+	assert.ErrorIs(t, err, exec.ErrNotFound)
+
+	_, err = Pipeline("not go", exec.Command("gofmt"), exec.Command("gofmt"))
+	assert.ErrorContains(t, err, "gofmt: exit status 2: <standard input>")
+}
+
+func TestStreamLines(t *testing.T) {
+	cmd := exec.Command("go", "list", "std")
+	var lines []string
+	err := StreamLines(cmd, func(line string) error {
+		lines = append(lines, line)
+		if len(lines) == 2 {
+			return ErrStop
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"archive/tar", "archive/zip"}, lines)
+	assert.NotNil(t, cmd.ProcessState, "the process was waited for")
+
+	boom := errors.New("boom")
+	err = StreamLines(exec.Command("go", "list", "std"), func(string) error { return boom })
+	assert.ErrorIs(t, err, boom)
+
+	err = StreamLines(exec.Command("go", "list", "no/such/package/learngo"), func(string) error { return nil })
+	assert.ErrorContains(t, err, "exit status 1")
+}
@@ -0,0 +1,70 @@
+package exercises
+
+// EXERCISE: Fix a helper that runs a program and reports what happened.
+// Run is meant for tools that call other tools: it runs a program with
+// some input and extra environment variables, captures stdout and stderr
+// separately, and tells a program that failed (a non-zero exit code)
+// apart from one that could not run or ran out of time (an error).
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Result is what a finished program printed and how it exited.
+type Result struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// Options says how to run a program.
+type Options struct {
+	Dir     string        // working directory; the current one if empty
+	Env     []string      // "KEY=value" entries added to the current environment
+	Stdin   string        // the program's input
+	Timeout time.Duration // kill the program after this long; 0 for no limit
+}
+
+// Run runs name with args as opts says and waits for it to finish.
+//
+// A program that ran and exited with a non-zero code is not an error: its
+// code is in Result.ExitCode. The error is for programs that could not
+// start, and for programs killed because ctx was done or the timeout
+// passed, in which case it wraps ctx.Err().
+func Run(ctx context.Context, opts Options, name string, args ...string) (Result, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	// BUG: exec.Command knows nothing about ctx, so the timeout never
+	// stops the program. exec.CommandContext kills it when ctx is done.
+	cmd := exec.Command(name, args...)
+	cmd.Dir = opts.Dir
+	cmd.Stdin = strings.NewReader(opts.Stdin)
+	// BUG: A non-nil Env is the child's whole environment, so PATH, HOME,
+	// and the rest are gone. Add opts.Env to os.Environ() instead.
+	cmd.Env = opts.Env
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	// BUG: Errors end up mixed into the output. Stderr has a buffer of its
+	// own.
+	cmd.Stderr = &stdout
+	err := cmd.Run()
+
+	res := Result{Stdout: stdout.String(), Stderr: stderr.String()}
+	// BUG: Every non-zero exit comes back as an error. Check ctx.Err()
+	// first: a killed program has a non-zero exit too, but the error for
+	// it must wrap ctx.Err(). Then use errors.As to find an
+	// *exec.ExitError, and put its ExitCode() in the result instead.
+	if err != nil {
+		return res, err
+	}
+	return res, nil
+}
@@ -0,0 +1,91 @@
+package exercises
+
+// EXERCISE: Fix the deadlocks and zombies in two pipe helpers.
+// A pipe between processes holds only a few dozen kilobytes. A program
+// that fills it stops until someone reads from the other end, so a parent
+// that waits for the program before reading waits forever. And a started
+// program must always be waited for: until then it stays in the process
+// table as a zombie, holding its pipes open.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// Pipe runs first and second like the shell pipeline "first | second":
+// input goes to first, first's stdout is second's stdin, and second's
+// stdout is returned.
+func Pipe(input string, first, second *exec.Cmd) (string, error) {
+	first.Stdin = strings.NewReader(input)
+	pipe, err := first.StdoutPipe()
+	if err != nil {
+		return "", err
+	}
+	second.Stdin = pipe
+	var out bytes.Buffer
+	second.Stdout = &out
+
+	// BUG: Run waits for first to finish before second starts reading.
+	// Once first has written more than the pipe holds, it waits for a
+	// reader that never comes. Start second before running first.
+	if err := first.Run(); err != nil {
+		return "", fmt.Errorf("first: %w", err)
+	}
+	if err := second.Start(); err != nil {
+		return "", err
+	}
+	if err := second.Wait(); err != nil {
+		return "", fmt.Errorf("second: %w", err)
+	}
+	return out.String(), nil
+}
+
+// ErrStop, returned by the function given to StreamLines, stops reading
+// without being an error.
+var ErrStop = errors.New("stop")
+
+// StreamLines starts cmd and calls fn for each line of its stdout as it
+// comes. If fn returns an error, StreamLines kills the process. Either way
+// the process has been waited for when StreamLines returns. If the
+// process fails, the error includes what it wrote to stderr.
+func StreamLines(cmd *exec.Cmd, fn func(line string) error) error {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	// BUG: stderr is only read after stdout is done. A program that writes
+	// a lot to stderr first fills that pipe and stops, before it writes a
+	// line to stdout. Set cmd.Stderr to a bytes.Buffer instead: exec then
+	// copies stderr in the background.
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	sc := bufio.NewScanner(stdout)
+	for sc.Scan() {
+		if err := fn(sc.Text()); err != nil {
+			// BUG: Returning here leaves the process running, blocked on a
+			// pipe nobody reads, and never waited for. Kill it, wait for
+			// it, and then return nil for ErrStop or the error otherwise.
+			if errors.Is(err, ErrStop) {
+				return nil
+			}
+			return err
+		}
+	}
+	errOutput, _ := io.ReadAll(stderr)
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("%w: %s", err, bytes.TrimSpace(errOutput))
+	}
+	return sc.Err()
+}
@@ -0,0 +1,94 @@
+package exercises
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The child program and the helpers are in exercise1_run_command_test.go.
+
+func TestPipeSmall(t *testing.T) {
+	out, err := Pipe("hello\n", childCmd(t, "cat"), childCmd(t, "cat"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", out)
+}
+
+func TestPipeLargeOutput(t *testing.T) {
+	r := seed.Rand(t, 1)
+	size := seed.Between(r, 1<<20, 2<<20) // far more than a pipe holds
+	var out string
+	var err error
+	within(t, 5*time.Second, "Pipe with "+strconv.Itoa(size)+" bytes", func() {
+		out, err = Pipe("", childCmd(t, "spam", strconv.Itoa(size)), childCmd(t, "wc"))
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintln(size), out)
+}
+
+func TestPipeErrors(t *testing.T) {
+	_, err := Pipe("", childCmd(t, "fail", "3", "no"), childCmd(t, "cat"))
+	assert.ErrorContains(t, err, "first: exit status 3")
+	_, err = Pipe("", childCmd(t, "echo", "hi"), childCmd(t, "fail", "4", "no"))
+	assert.ErrorContains(t, err, "second: exit status 4")
+}
+
+func TestStreamLines(t *testing.T) {
+	var lines []string
+	err := StreamLines(childCmd(t, "spam", "130"), func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, lines, 3)
+
+	err = StreamLines(childCmd(t, "fail", "1", "it broke"), func(string) error { return nil })
+	assert.ErrorContains(t, err, "exit status 1: it broke")
+}
+
+func TestStreamLinesLargeStderr(t *testing.T) {
+	var lines []string
+	var err error
+	within(t, 5*time.Second, "StreamLines with 1MB on stderr", func() {
+		err = StreamLines(childCmd(t, "spam-stderr", strconv.Itoa(1<<20)), func(line string) error {
+			lines = append(lines, line)
+			return nil
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"done"}, lines)
+}
+
+func TestStreamLinesStopsEarly(t *testing.T) {
+	r := seed.Rand(t, 1)
+	stopAt := seed.Between(r, 1, 50)
+	cmd := childCmd(t, "count")
+	var lines []string
+	var err error
+	within(t, 5*time.Second, "StreamLines stopping early", func() {
+		err = StreamLines(cmd, func(line string) error {
+			lines = append(lines, line)
+			if len(lines) == stopAt {
+				return ErrStop
+			}
+			return nil
+		})
+	})
+	require.NoError(t, err)
+	assert.Len(t, lines, stopAt)
+	assert.Equal(t, strconv.Itoa(stopAt), lines[len(lines)-1])
+	require.NotNil(t, cmd.ProcessState, "the process must be waited for, or it is left a zombie")
+	assert.False(t, cmd.ProcessState.Success(), "count never ends on its own: it was killed")
+
+	boom := errors.New("boom")
+	cmd = childCmd(t, "count")
+	err = StreamLines(cmd, func(string) error { return boom })
+	assert.ErrorIs(t, err, boom)
+	assert.NotNil(t, cmd.ProcessState, "waited for after an error too")
+}
@@ -0,0 +1,72 @@
+package solutions
+
+// SOLUTION: The fixed helper that runs a program and reports what happened.
+// Run is meant for tools that call other tools: it runs a program with
+// some input and extra environment variables, captures stdout and stderr
+// separately, and tells a program that failed (a non-zero exit code)
+// apart from one that could not run or ran out of time (an error).
+// Each fix is marked with a // Fixed: comment.
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Result is what a finished program printed and how it exited.
+type Result struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// Options says how to run a program.
+type Options struct {
+	Dir     string        // working directory; the current one if empty
+	Env     []string      // "KEY=value" entries added to the current environment
+	Stdin   string        // the program's input
+	Timeout time.Duration // kill the program after this long; 0 for no limit
+}
+
+// Run runs name with args as opts says and waits for it to finish.
+//
+// A program that ran and exited with a non-zero code is not an error: its
+// code is in Result.ExitCode. The error is for programs that could not
+// start, and for programs killed because ctx was done or the timeout
+// passed, in which case it wraps ctx.Err().
+func Run(ctx context.Context, opts Options, name string, args ...string) (Result, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	cmd := exec.CommandContext(ctx, name, args...) // Fixed: killed when ctx is done
+	cmd.Dir = opts.Dir
+	cmd.Stdin = strings.NewReader(opts.Stdin)
+	if len(opts.Env) > 0 {
+		cmd.Env = append(os.Environ(), opts.Env...) // Fixed: added to the environment
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr // Fixed: a buffer of its own
+	err := cmd.Run()
+
+	res := Result{Stdout: stdout.String(), Stderr: stderr.String()}
+	// Fixed: a killed program reports why it was killed, and a non-zero
+	// exit is a result, not an error
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		return res, fmt.Errorf("%s: %w", name, ctx.Err())
+	case errors.As(err, &exitErr):
+		res.ExitCode = exitErr.ExitCode()
+		return res, nil
+	}
+	return res, err
+}
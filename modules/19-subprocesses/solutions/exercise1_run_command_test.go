@@ -0,0 +1,203 @@
+package solutions
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// childArg, as the first argument, makes the test binary act as a small
+// program instead of running the tests, so the tests have programs to run
+// that behave the same on every operating system. It is an argument and
+// not an environment variable because Run may get the environment wrong.
+const childArg = "learngo-child"
+
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == childArg {
+		os.Exit(child(os.Args[2:]))
+	}
+	os.Exit(m.Run())
+}
+
+// child is the program the tests run. Its first argument is a command:
+//
+//	echo <word>...       print the words
+//	env <name>           print the value of an environment variable
+//	pwd                  print the working directory
+//	cat                  copy stdin to stdout
+//	wc                   print the number of bytes on stdin
+//	fail <code> <msg>    print msg to stderr and exit with code
+//	sleep <duration>     sleep, then print "woke up"
+//	spam <bytes>         print that many bytes, in lines of 64
+//	spam-stderr <bytes>  print that many bytes to stderr, then "done"
+//	count                print 1, 2, 3, ... forever
+func child(args []string) int {
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	switch args[0] {
+	case "echo":
+		fmt.Fprintln(out, strings.Join(args[1:], " "))
+	case "env":
+		fmt.Fprintln(out, os.Getenv(args[1]))
+	case "pwd":
+		dir, _ := os.Getwd()
+		fmt.Fprintln(out, dir)
+	case "cat":
+		io.Copy(out, os.Stdin)
+	case "wc":
+		n, _ := io.Copy(io.Discard, os.Stdin)
+		fmt.Fprintln(out, n)
+	case "fail":
+		code, _ := strconv.Atoi(args[1])
+		fmt.Fprintln(os.Stderr, args[2])
+		return code
+	case "sleep":
+		d, _ := time.ParseDuration(args[1])
+		time.Sleep(d)
+		fmt.Fprintln(out, "woke up")
+	case "spam", "spam-stderr":
+		n, _ := strconv.Atoi(args[1])
+		w := io.Writer(out)
+		if args[0] == "spam-stderr" {
+			w = os.Stderr
+		}
+		line := strings.Repeat("x", 63) + "\n"
+		for ; n >= len(line); n -= len(line) {
+			io.WriteString(w, line)
+		}
+		io.WriteString(w, line[len(line)-n:])
+		if args[0] == "spam-stderr" {
+			fmt.Fprintln(out, "done")
+		}
+	case "count":
+		for i := 1; ; i++ {
+			if _, err := fmt.Fprintln(os.Stdout, i); err != nil {
+				return 1
+			}
+		}
+	default:
+		fmt.Fprintln(os.Stderr, "unknown child command", args[0])
+		return 2
+	}
+	return 0
+}
+
+// program returns the name and arguments that run child with args.
+func program(args ...string) (string, []string) {
+	return os.Args[0], append([]string{childArg}, args...)
+}
+
+// childCmd returns a command that runs child with args. It is killed when
+// the test ends, in case the test did not get that far.
+func childCmd(t *testing.T, args ...string) *exec.Cmd {
+	name, args := program(args...)
+	cmd := exec.Command(name, args...)
+	t.Cleanup(func() {
+		if cmd.Process != nil && cmd.ProcessState == nil {
+			cmd.Process.Kill()
+		}
+	})
+	return cmd
+}
+
+// within fails the test if fn does not return within d.
+func within(t *testing.T, d time.Duration, what string, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	select {
+	case <-done:
+	case <-time.After(d):
+		t.Fatalf("%s did not return within %v", what, d)
+	}
+}
+
+func TestRunOutput(t *testing.T) {
+	r := seed.Rand(t, 1)
+	word := fmt.Sprintf("word-%d", seed.Between(r, 1, 1000))
+	name, args := program("echo", "hello,", word)
+	res, err := Run(context.Background(), Options{}, name, args...)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Stdout: "hello, " + word + "\n"}, res)
+
+	name, args = program("cat")
+	res, err = Run(context.Background(), Options{Stdin: "line 1\nline 2\n"}, name, args...)
+	require.NoError(t, err)
+	assert.Equal(t, "line 1\nline 2\n", res.Stdout)
+}
+
+func TestRunSeparatesStderr(t *testing.T) {
+	name, args := program("spam-stderr", "10")
+	res, err := Run(context.Background(), Options{}, name, args...)
+	require.NoError(t, err)
+	assert.Equal(t, "done\n", res.Stdout)
+	assert.Equal(t, "xxxxxxxxx\n", res.Stderr)
+}
+
+func TestRunExitCode(t *testing.T) {
+	r := seed.Rand(t, 1)
+	code := seed.Between(r, 1, 100)
+	name, args := program("fail", strconv.Itoa(code), "it broke")
+	res, err := Run(context.Background(), Options{}, name, args...)
+	require.NoError(t, err, "a program that ran and failed is not an error")
+	assert.Equal(t, Result{Stderr: "it broke\n", ExitCode: code}, res)
+
+	_, err = Run(context.Background(), Options{}, filepath.Join(t.TempDir(), "no-such-program"))
+	assert.Error(t, err, "a program that cannot start is an error")
+}
+
+func TestRunEnvironment(t *testing.T) {
+	t.Setenv("LEARNGO_PARENT", "inherited")
+	name, args := program("env", "LEARNGO_PARENT")
+	res, err := Run(context.Background(), Options{Env: []string{"LEARNGO_EXTRA=added"}}, name, args...)
+	require.NoError(t, err)
+	assert.Equal(t, "inherited\n", res.Stdout, "Env adds to the environment")
+
+	name, args = program("env", "LEARNGO_EXTRA")
+	res, err = Run(context.Background(), Options{Env: []string{"LEARNGO_EXTRA=added"}}, name, args...)
+	require.NoError(t, err)
+	assert.Equal(t, "added\n", res.Stdout)
+}
+
+func TestRunDir(t *testing.T) {
+	dir := t.TempDir()
+	name, args := program("pwd")
+	res, err := Run(context.Background(), Options{Dir: dir}, name, args...)
+	require.NoError(t, err)
+	want, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	got, err := filepath.EvalSymlinks(strings.TrimSpace(res.Stdout))
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestRunTimeout(t *testing.T) {
+	name, args := program("sleep", "10s")
+	var err error
+	within(t, 5*time.Second, "Run with a 100ms timeout", func() {
+		_, err = Run(context.Background(), Options{Timeout: 100 * time.Millisecond}, name, args...)
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	within(t, 5*time.Second, "Run with a cancelled context", func() {
+		_, err = Run(ctx, Options{}, name, args...)
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
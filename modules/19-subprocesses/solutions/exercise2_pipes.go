@@ -0,0 +1,83 @@
+package solutions
+
+// SOLUTION: The two pipe helpers, without deadlocks or zombies.
+// A pipe between processes holds only a few dozen kilobytes. A program
+// that fills it stops until someone reads from the other end, so a parent
+// that waits for the program before reading waits forever. And a started
+// program must always be waited for: until then it stays in the process
+// table as a zombie, holding its pipes open.
+// Each fix is marked with a // Fixed: comment.
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Pipe runs first and second like the shell pipeline "first | second":
+// input goes to first, first's stdout is second's stdin, and second's
+// stdout is returned.
+func Pipe(input string, first, second *exec.Cmd) (string, error) {
+	first.Stdin = strings.NewReader(input)
+	pipe, err := first.StdoutPipe()
+	if err != nil {
+		return "", err
+	}
+	second.Stdin = pipe
+	var out bytes.Buffer
+	second.Stdout = &out
+
+	// Fixed: second is reading before first starts writing
+	if err := second.Start(); err != nil {
+		return "", err
+	}
+	if err := first.Run(); err != nil {
+		second.Process.Kill()
+		second.Wait()
+		return "", fmt.Errorf("first: %w", err)
+	}
+	if err := second.Wait(); err != nil {
+		return "", fmt.Errorf("second: %w", err)
+	}
+	return out.String(), nil
+}
+
+// ErrStop, returned by the function given to StreamLines, stops reading
+// without being an error.
+var ErrStop = errors.New("stop")
+
+// StreamLines starts cmd and calls fn for each line of its stdout as it
+// comes. If fn returns an error, StreamLines kills the process. Either way
+// the process has been waited for when StreamLines returns. If the
+// process fails, the error includes what it wrote to stderr.
+func StreamLines(cmd *exec.Cmd, fn func(line string) error) error {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr // Fixed: exec copies stderr while we read stdout
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	sc := bufio.NewScanner(stdout)
+	for sc.Scan() {
+		if err := fn(sc.Text()); err != nil {
+			// Fixed: kill the process and reap it before returning
+			cmd.Process.Kill()
+			cmd.Wait()
+			if errors.Is(err, ErrStop) {
+				return nil
+			}
+			return err
+		}
+	}
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("%w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return sc.Err()
+}
@@ -0,0 +1,82 @@
+// Package examples demonstrates code that is built differently for each
+// operating system and architecture.
+//
+// This file shows:
+// - runtime.GOOS and runtime.GOARCH, the platform a program was built for
+// - File name suffixes: a _windows.go file is only built for Windows
+// - //go:build lines for groups of platforms, such as unix
+// - A fallback file for every platform the others leave out
+// - One API in a common file, over a small function per platform
+package examples
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Platform describes the system a program was built for.
+type Platform struct {
+	OS, Arch      string
+	PathSeparator string // between directories in a path
+	ListSeparator string // between paths in PATH
+	ExeSuffix     string // at the end of a program's file name
+}
+
+// Current returns the platform this program was built for. Unlike an
+// environment variable, runtime.GOOS is a constant, fixed when the program
+// is compiled: a Windows binary says "windows" wherever it is copied.
+func Current() Platform {
+	return Platform{
+		OS:            runtime.GOOS,
+		Arch:          runtime.GOARCH,
+		PathSeparator: string(os.PathSeparator),
+		ListSeparator: string(os.PathListSeparator),
+		ExeSuffix:     exeSuffix, // from the file for this platform
+	}
+}
+
+// IsHidden reports whether a file is hidden from directory listings. Unix
+// hides names starting with a dot; Windows has an attribute for it, read
+// with a system call that does not exist anywhere else. That is what
+// separate files are for: code that would not compile on every platform.
+func IsHidden(path string) (bool, error) {
+	return isHidden(path)
+}
+
+// ProgramFile returns the path of the program called name in dir.
+func ProgramFile(dir, name string) string {
+	// Code that compiles everywhere needs no file of its own: checking
+	// runtime.GOOS == "windows" would do here, and the compiler drops the
+	// branches that cannot run.
+	if strings.HasSuffix(name, exeSuffix) {
+		return filepath.Join(dir, name)
+	}
+	return filepath.Join(dir, name+exeSuffix)
+}
+
+// DemonstratePlatform prints what this build knows about its platform.
+func DemonstratePlatform() {
+	p := Current()
+	fmt.Printf("built for %s/%s\n", p.OS, p.Arch)
+	fmt.Printf("paths: %q between directories, %q between entries of PATH\n", p.PathSeparator, p.ListSeparator)
+	fmt.Println("the go command is", ProgramFile(filepath.Join(runtime.GOROOT(), "bin"), "go"))
+
+	dir, err := os.MkdirTemp("", "learngo-hidden")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"visible.txt", ".hidden.txt"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			fmt.Println(err)
+			return
+		}
+		hidden, err := IsHidden(path)
+		fmt.Printf("%s hidden: %v (err %v)\n", name, hidden, err)
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !unix && !windows
+
+package examples
+
+// Every platform the other files leave out, such as js/wasm and plan9,
+// gets this file. Without it, the package would not build there at all.
+
+const exeSuffix = ""
+
+func isHidden(path string) (bool, error) {
+	return false, nil
+}
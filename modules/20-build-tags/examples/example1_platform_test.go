@@ -0,0 +1,49 @@
+package examples
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstratePlatform(t *testing.T) {
+	DemonstratePlatform()
+}
+
+func TestCurrent(t *testing.T) {
+	p := Current()
+	assert.Equal(t, runtime.GOOS, p.OS)
+	assert.Equal(t, runtime.GOARCH, p.Arch)
+	// The tests run everywhere, so they check the platform at run time.
+	if runtime.GOOS == "windows" {
+		assert.Equal(t, Platform{OS: "windows", Arch: runtime.GOARCH, PathSeparator: `\`, ListSeparator: ";", ExeSuffix: ".exe"}, p)
+	} else {
+		assert.Equal(t, Platform{OS: runtime.GOOS, Arch: runtime.GOARCH, PathSeparator: "/", ListSeparator: ":"}, p)
+	}
+}
+
+func TestProgramFile(t *testing.T) {
+	dir := t.TempDir()
+	want := filepath.Join(dir, "tool"+Current().ExeSuffix)
+	assert.Equal(t, want, ProgramFile(dir, "tool"))
+	assert.Equal(t, want, ProgramFile(dir, filepath.Base(want)), "no second suffix")
+}
+
+func TestIsHidden(t *testing.T) {
+	dir := t.TempDir()
+	dotfile := filepath.Join(dir, ".profile")
+	require.NoError(t, os.WriteFile(dotfile, nil, 0o644))
+	hidden, err := IsHidden(dotfile)
+	require.NoError(t, err)
+	assert.Equal(t, runtime.GOOS != "windows", hidden, "a leading dot hides a file on Unix only")
+
+	plain := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(plain, nil, 0o644))
+	hidden, err = IsHidden(plain)
+	require.NoError(t, err)
+	assert.False(t, hidden)
+}
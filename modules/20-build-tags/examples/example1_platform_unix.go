@@ -0,0 +1,17 @@
+//go:build unix
+
+package examples
+
+import "path/filepath"
+
+// "_unix" is not a suffix the go command knows, unlike "_linux" or
+// "_windows": without the //go:build line above, this file would be built
+// for Windows too. The unix constraint covers Linux, macOS, the BSDs,
+// and the other Unix-like systems.
+
+const exeSuffix = ""
+
+func isHidden(path string) (bool, error) {
+	name := filepath.Base(path)
+	return len(name) > 1 && name[0] == '.' && name != "..", nil
+}
@@ -0,0 +1,20 @@
+package examples
+
+import "syscall"
+
+// The _windows suffix alone limits this file to Windows: no //go:build
+// line is needed, though one would do no harm.
+
+const exeSuffix = ".exe"
+
+func isHidden(path string) (bool, error) {
+	p, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return false, err
+	}
+	attrs, err := syscall.GetFileAttributes(p)
+	if err != nil {
+		return false, err
+	}
+	return attrs&syscall.FILE_ATTRIBUTE_HIDDEN != 0, nil
+}
@@ -0,0 +1,133 @@
+package examples
+
+import (
+	"context"
+	"fmt"
+	"go/build"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// CrossExamples demonstrates choosing files with build constraints, and
+// building for a platform other than the one the go command runs on.
+//
+// GOOS and GOARCH say which platform to build for. The go command drops
+// the files whose names or //go:build lines rule that platform out, and
+// compiles the rest with the standard library for the target. With cgo
+// disabled, nothing else is needed: any platform can build for any other.
+
+// Files returns the Go files in dir that are built for goos/goarch with
+// the given build tags, and the ones that are not. Test files are left
+// out. The go command makes the same choice, with go/build's rules.
+func Files(dir, goos, goarch string, tags ...string) (built, ignored []string, err error) {
+	ctxt := build.Default
+	ctxt.GOOS = goos
+	ctxt.GOARCH = goarch
+	ctxt.BuildTags = tags
+	ctxt.CgoEnabled = false
+	pkg, err := ctxt.ImportDir(dir, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(pkg.IgnoredGoFiles)
+	return pkg.GoFiles, pkg.IgnoredGoFiles, nil
+}
+
+// CrossBuild builds the main package in dir for goos/goarch and returns
+// the path of the program, in dir. It is "GOOS=goos GOARCH=goarch go
+// build", with cgo turned off: a C compiler for another platform is rarely
+// at hand.
+func CrossBuild(ctx context.Context, dir, goos, goarch string) (string, error) {
+	out := filepath.Join(dir, "main-"+goos+"-"+goarch)
+	if goos == "windows" {
+		out += ".exe"
+	}
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", out, ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOOS="+goos, "GOARCH="+goarch, "CGO_ENABLED=0")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("GOOS=%s GOARCH=%s go build: %w\n%s", goos, goarch, err, output)
+	}
+	return out, nil
+}
+
+// Executable formats, told apart by the first bytes of the file.
+var magics = []struct {
+	format string
+	magic  string
+}{
+	{"ELF (Linux, BSD)", "\x7fELF"},
+	{"PE (Windows)", "MZ"},
+	{"Mach-O (macOS)", "\xcf\xfa\xed\xfe"},
+	{"WebAssembly", "\x00asm"},
+}
+
+// ExecutableFormat says what kind of executable the file at path is.
+func ExecutableFormat(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	head := make([]byte, 4)
+	n, _ := f.Read(head)
+	for _, m := range magics {
+		if strings.HasPrefix(string(head[:n]), m.magic) {
+			return m.format, nil
+		}
+	}
+	return "", fmt.Errorf("%s: not an executable", path)
+}
+
+// DemonstrateCross lists the files of this package that each platform
+// builds, then builds a small program for Windows.
+func DemonstrateCross() {
+	platforms := [][2]string{{"linux", "amd64"}, {"darwin", "arm64"}, {"windows", "amd64"}, {"js", "wasm"}}
+	for _, p := range platforms {
+		built, _, err := Files(".", p[0], p[1])
+		fmt.Printf("%s/%s: %s (err %v)\n", p[0], p[1], platformFiles(built), err)
+	}
+	fmt.Println("debug build:", Debug)
+	built, _, _ := Files(".", runtime.GOOS, runtime.GOARCH, "learngo_debug")
+	fmt.Println("with -tags learngo_debug:", strings.Join(built, ", "))
+
+	dir, err := os.MkdirTemp("", "learngo-cross")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"go.mod":  "module hello\n",
+		"main.go": "package main\n\nfunc main() { println(\"hello\") }\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+	exe, err := CrossBuild(context.Background(), dir, "windows", "amd64")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	format, err := ExecutableFormat(exe)
+	fmt.Printf("%s: %s (err %v)\n", filepath.Base(exe), format, err)
+}
+
+// platformFiles returns the names in files that are specific to a
+// platform or tag, the ones worth showing.
+func platformFiles(files []string) string {
+	var picked []string
+	for _, f := range files {
+		if strings.HasPrefix(f, "example1_platform_") || strings.HasPrefix(f, "example2_debug_") {
+			picked = append(picked, f)
+		}
+	}
+	return strings.Join(picked, ", ")
+}
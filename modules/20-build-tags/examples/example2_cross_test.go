@@ -0,0 +1,81 @@
+package examples
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateCross(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go build")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	DemonstrateCross()
+}
+
+func TestFiles(t *testing.T) {
+	tests := []struct {
+		goos, goarch string
+		tags         []string
+		want         []string
+	}{
+		{"linux", "amd64", nil, []string{"example1_platform_unix.go", "example2_debug_off.go"}},
+		{"darwin", "arm64", nil, []string{"example1_platform_unix.go", "example2_debug_off.go"}},
+		{"windows", "amd64", nil, []string{"example1_platform_windows.go", "example2_debug_off.go"}},
+		{"js", "wasm", nil, []string{"example1_platform_other.go", "example2_debug_off.go"}},
+		{"linux", "arm64", []string{"learngo_debug"}, []string{"example1_platform_unix.go", "example2_debug_on.go"}},
+	}
+	for _, tt := range tests {
+		built, ignored, err := Files(".", tt.goos, tt.goarch, tt.tags...)
+		require.NoError(t, err)
+		assert.Equal(t, append([]string{"example1_platform.go"}, tt.want...), filterOut(built, "example2_cross.go"), tt.goos)
+		assert.Len(t, ignored, 3, "two platform files and one debug file: %v", ignored)
+		assert.Contains(t, built, "example2_cross.go", "no constraint: built everywhere")
+	}
+}
+
+func TestCrossBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go build")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module hello\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644))
+
+	for goos, want := range map[string]string{"linux": "ELF (Linux, BSD)", "windows": "PE (Windows)", "darwin": "Mach-O (macOS)"} {
+		exe, err := CrossBuild(context.Background(), dir, goos, "amd64")
+		require.NoError(t, err)
+		format, err := ExecutableFormat(exe)
+		require.NoError(t, err)
+		assert.Equal(t, want, format, goos)
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() { undefined() }\n"), 0o644))
+	_, err := CrossBuild(context.Background(), dir, "linux", "amd64")
+	assert.ErrorContains(t, err, "undefined: undefined")
+
+	_, err = ExecutableFormat(filepath.Join(dir, "go.mod"))
+	assert.ErrorContains(t, err, "not an executable")
+}
+
+// filterOut returns files without name.
+func filterOut(files []string, name string) []string {
+	var kept []string
+	for _, f := range files {
+		if f != name {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
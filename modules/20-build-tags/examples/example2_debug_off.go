@@ -0,0 +1,8 @@
+//go:build !learngo_debug
+
+package examples
+
+// Debug is true in builds made with "go build -tags learngo_debug". The
+// two files have opposite constraints: exactly one of them is always
+// built, so Debug is always declared, and never twice.
+const Debug = false
@@ -0,0 +1,8 @@
+//go:build learngo_debug
+
+package examples
+
+// Debug is true in builds made with "go build -tags learngo_debug". A
+// custom tag is any name that is not a platform; prefix it with the
+// project's name, so that it cannot clash with someone else's.
+const Debug = true
@@ -0,0 +1,156 @@
+package exercises
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/testenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The tests run on one platform at a time, so they check runtime.GOOS to
+// know what to expect. TestBuildsEverywhere is the one test that looks at
+// the other platforms.
+
+func TestBuildsEverywhere(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+	platforms := []struct{ goos, goarch string }{
+		{"linux", "amd64"},
+		{"darwin", "arm64"},
+		{"windows", "amd64"},
+	}
+	for _, p := range platforms {
+		t.Run(p.goos, func(t *testing.T) {
+			// go vet type-checks the package and its tests as the compiler
+			// would for that platform, without building a program.
+			cmd := exec.Command("go", "vet", ".")
+			cmd.Env = append(os.Environ(), "GOOS="+p.goos, "GOARCH="+p.goarch, "CGO_ENABLED=0")
+			out, err := cmd.CombinedOutput()
+			assert.NoError(t, err, "GOOS=%s GOARCH=%s go vet:\n%s", p.goos, p.goarch, out)
+		})
+	}
+}
+
+func TestExecutableName(t *testing.T) {
+	r := seed.Rand(t, 1)
+	name := fmt.Sprintf("tool%d", seed.Between(r, 1, 1000))
+	if runtime.GOOS == "windows" {
+		assert.Equal(t, name+".exe", ExecutableName(name))
+	} else {
+		assert.Equal(t, name, ExecutableName(name))
+	}
+}
+
+// fileInfo is an fs.FileInfo for a file that need not exist.
+type fileInfo struct {
+	name string
+	mode fs.FileMode
+}
+
+func (fi fileInfo) Name() string       { return fi.name }
+func (fi fileInfo) Size() int64        { return 0 }
+func (fi fileInfo) Mode() fs.FileMode  { return fi.mode }
+func (fi fileInfo) ModTime() time.Time { return time.Time{} }
+func (fi fileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi fileInfo) Sys() any           { return nil }
+
+func TestIsExecutable(t *testing.T) {
+	tests := []struct {
+		info          fileInfo
+		unix, windows bool
+	}{
+		{fileInfo{"tool", 0o755}, true, false},
+		{fileInfo{"tool", 0o700}, true, false},
+		{fileInfo{"tool", 0o654}, true, false},
+		{fileInfo{"tool", 0o645}, true, false},
+		{fileInfo{"notes.txt", 0o644}, false, false},
+		{fileInfo{"bin", fs.ModeDir | 0o755}, false, false},
+		{fileInfo{"link", fs.ModeSymlink | 0o777}, false, false},
+		{fileInfo{"setup.exe", 0o666}, false, true},
+		{fileInfo{"SETUP.EXE", 0o666}, false, true},
+		{fileInfo{"build.Bat", 0o666}, false, true},
+		{fileInfo{"make.cmd", 0o666}, false, true},
+		{fileInfo{"tool.exe", 0o755}, true, true},
+		{fileInfo{"apps.exe", fs.ModeDir | 0o777}, false, false},
+		{fileInfo{"exe", 0o666}, false, false},
+	}
+	for _, tt := range tests {
+		want := tt.unix
+		if runtime.GOOS == "windows" {
+			want = tt.windows
+		}
+		assert.Equal(t, want, IsExecutable(tt.info), "%s %v", tt.info.name, tt.info.mode)
+	}
+}
+
+func TestMakePrivate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("secret"), 0o644))
+	require.NoError(t, os.Chmod(path, 0o444))
+	require.NoError(t, MakePrivate(path))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	if runtime.GOOS == "windows" {
+		assert.NotZero(t, info.Mode().Perm()&0o200, "the owner can write to it")
+	} else {
+		assert.Equal(t, fs.FileMode(0o600), info.Mode().Perm())
+	}
+
+	assert.ErrorIs(t, MakePrivate(filepath.Join(t.TempDir(), "missing")), fs.ErrNotExist)
+}
+
+// cacheDirCase is an environment and the cache directory it gives, or ""
+// for an error.
+type cacheDirCase struct {
+	env  map[string]string
+	want string
+}
+
+// cacheDirCases are the cases of TestCacheDir for each platform, with
+// "app" for the name of the program.
+var cacheDirCases = map[string][]cacheDirCase{
+	"windows": {
+		{map[string]string{"LocalAppData": `C:\Users\gopher\AppData\Local`, "AppData": `C:\Users\gopher\AppData\Roaming`}, `C:\Users\gopher\AppData\Local\app`},
+		{map[string]string{"AppData": `C:\Users\gopher\AppData\Roaming`}, ""},
+	},
+	"darwin": {
+		{map[string]string{"HOME": "/Users/gopher", "XDG_CACHE_HOME": "/tmp/cache"}, "/Users/gopher/Library/Caches/app"},
+		{map[string]string{}, ""},
+	},
+	"unix": {
+		{map[string]string{"HOME": "/home/gopher"}, "/home/gopher/.cache/app"},
+		{map[string]string{"HOME": "/home/gopher", "XDG_CACHE_HOME": "/var/cache/gopher"}, "/var/cache/gopher/app"},
+		{map[string]string{"XDG_CACHE_HOME": "/var/cache/gopher"}, "/var/cache/gopher/app"},
+		{map[string]string{"HOME": "/home/gopher", "XDG_CACHE_HOME": "relative/cache"}, "/home/gopher/.cache/app"},
+		{map[string]string{}, ""},
+	},
+}
+
+func TestCacheDir(t *testing.T) {
+	r := seed.Rand(t, 1)
+	app := fmt.Sprintf("app%d", seed.Between(r, 1, 1000))
+	cases, ok := cacheDirCases[runtime.GOOS]
+	if !ok {
+		cases = cacheDirCases["unix"]
+	}
+	for _, tt := range cases {
+		getenv := func(key string) string { return tt.env[key] }
+		got, err := CacheDir(getenv, app)
+		if tt.want == "" {
+			assert.Error(t, err, "%v", tt.env)
+			continue
+		}
+		if assert.NoError(t, err, "%v", tt.env) {
+			assert.Equal(t, strings.TrimSuffix(tt.want, "app")+app, got, "%v", tt.env)
+		}
+	}
+}
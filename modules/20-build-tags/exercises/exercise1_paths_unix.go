@@ -0,0 +1,48 @@
+// BUG: macOS and the BSDs are Unix too, and they get neither this file
+// nor the Windows one, so the package does not build there. The unix
+// constraint takes in all of them. (A "_unix" file name suffix does
+// nothing by itself: only the //go:build line limits this file.)
+//go:build linux
+
+package exercises
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+const exeSuffix = ""
+
+func isExecutable(info fs.FileInfo) bool {
+	// BUG: A directory has execute bits too: they let you enter it. And a
+	// file that only its group may run is still a program. Check that the
+	// file is regular, and look at all three bits, 0o111.
+	return info.Mode()&0o100 != 0
+}
+
+func makePrivate(path string) error {
+	return os.Chmod(path, 0o600)
+}
+
+func cacheBase(getenv func(string) string) (string, error) {
+	// macOS differs from the other Unix systems in a way that needs no
+	// code of its own, so checking runtime.GOOS is enough.
+	if runtime.GOOS == "darwin" {
+		home := getenv("HOME")
+		if home == "" {
+			return "", errors.New("$HOME is not set")
+		}
+		return filepath.Join(home, "Library", "Caches"), nil
+	}
+	// BUG: $XDG_CACHE_HOME, when set, says where caches go. Use it if it
+	// is an absolute path; the XDG specification says to ignore it if it
+	// is relative. Fall back to $HOME/.cache only when it is not usable.
+	home := getenv("HOME")
+	if home == "" {
+		return "", errors.New("neither $XDG_CACHE_HOME nor $HOME is set")
+	}
+	return filepath.Join(home, ".cache"), nil
+}
@@ -0,0 +1,43 @@
+package exercises
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const exeSuffix = ".exe"
+
+func isExecutable(info fs.FileInfo) bool {
+	if !info.Mode().IsRegular() {
+		return false
+	}
+	// BUG: Windows file names are not case-sensitive: SETUP.EXE runs as
+	// well as setup.exe. Compare the extension in lower case.
+	switch filepath.Ext(info.Name()) {
+	case ".exe", ".bat", ".cmd":
+		return true
+	}
+	return false
+}
+
+// BUG: MakePrivate returns what makePrivate returns, but this one returns
+// nothing, so the package does not build for Windows. Return the error
+// from os.Chmod.
+func makePrivate(path string) {
+	// On Windows, os.Chmod only looks at the owner's write bit, and sets
+	// or clears the read-only attribute.
+	os.Chmod(path, 0o600)
+}
+
+func cacheBase(getenv func(string) string) (string, error) {
+	// BUG: %AppData% is the roaming profile, copied from machine to machine
+	// when the user logs on. A cache does not belong there; use
+	// %LocalAppData%.
+	dir := getenv("AppData")
+	if dir == "" {
+		return "", errors.New("%AppData% is not set")
+	}
+	return dir, nil
+}
@@ -0,0 +1,54 @@
+package solutions
+
+// SOLUTION: A package for files and paths that builds on every platform.
+// It compiles, and passes its tests, on Linux, macOS, and Windows.
+// The functions below are the same on every platform. What they do is
+// not, and that part lives in exercise1_paths_unix.go and
+// exercise1_paths_windows.go. The compiler on your machine only ever
+// reads one of the two, so a mistake in the other goes unnoticed until
+// someone builds on that platform. TestBuildsEverywhere does that for
+// you: it runs go vet with GOOS set to each platform in turn.
+// Each fix is marked with a // Fixed: comment.
+
+import (
+	"io/fs"
+	"path/filepath"
+)
+
+// ExecutableName returns the file name of the program called name: name
+// itself on Unix, and name.exe on Windows.
+func ExecutableName(name string) string {
+	return name + exeSuffix
+}
+
+// IsExecutable reports whether info describes a program that can be run.
+// On Unix, that is a regular file with any of its execute bits set. On
+// Windows, which has no execute bits, it is a regular file whose name
+// ends in .exe, .bat, or .cmd, in upper or lower case.
+func IsExecutable(info fs.FileInfo) bool {
+	return isExecutable(info)
+}
+
+// MakePrivate keeps other users from reading the file at path. On Unix,
+// it sets the mode to 0600. Windows controls access with ACLs, which
+// os.Chmod does not touch: there, MakePrivate only makes sure that the
+// owner can write to the file.
+func MakePrivate(path string) error {
+	return makePrivate(path)
+}
+
+// CacheDir returns the directory for app's cache files. getenv looks up
+// environment variables; pass os.Getenv.
+//
+//	Linux and other Unix:  $XDG_CACHE_HOME/app, or else $HOME/.cache/app
+//	macOS:                 $HOME/Library/Caches/app
+//	Windows:               %LocalAppData%\app
+//
+// It returns an error if the variables it needs are not set.
+func CacheDir(getenv func(string) string, app string) (string, error) {
+	base, err := cacheBase(getenv)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, app), nil // Fixed: the platform's separator
+}
@@ -0,0 +1,43 @@
+// Fixed: unix takes in Linux, macOS, and the BSDs
+//go:build unix
+
+package solutions
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+const exeSuffix = ""
+
+func isExecutable(info fs.FileInfo) bool {
+	return info.Mode().IsRegular() && info.Mode()&0o111 != 0 // Fixed: regular files, any execute bit
+}
+
+func makePrivate(path string) error {
+	return os.Chmod(path, 0o600)
+}
+
+func cacheBase(getenv func(string) string) (string, error) {
+	// macOS differs from the other Unix systems in a way that needs no
+	// code of its own, so checking runtime.GOOS is enough.
+	if runtime.GOOS == "darwin" {
+		home := getenv("HOME")
+		if home == "" {
+			return "", errors.New("$HOME is not set")
+		}
+		return filepath.Join(home, "Library", "Caches"), nil
+	}
+	// Fixed: an absolute $XDG_CACHE_HOME comes first
+	if dir := getenv("XDG_CACHE_HOME"); filepath.IsAbs(dir) {
+		return dir, nil
+	}
+	home := getenv("HOME")
+	if home == "" {
+		return "", errors.New("neither $XDG_CACHE_HOME nor $HOME is set")
+	}
+	return filepath.Join(home, ".cache"), nil
+}
@@ -0,0 +1,36 @@
+package solutions
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const exeSuffix = ".exe"
+
+func isExecutable(info fs.FileInfo) bool {
+	if !info.Mode().IsRegular() {
+		return false
+	}
+	switch strings.ToLower(filepath.Ext(info.Name())) { // Fixed: any case
+	case ".exe", ".bat", ".cmd":
+		return true
+	}
+	return false
+}
+
+func makePrivate(path string) error { // Fixed: returns the error
+	// On Windows, os.Chmod only looks at the owner's write bit, and sets
+	// or clears the read-only attribute.
+	return os.Chmod(path, 0o600)
+}
+
+func cacheBase(getenv func(string) string) (string, error) {
+	dir := getenv("LocalAppData") // Fixed: the local profile, not the roaming one
+	if dir == "" {
+		return "", errors.New("%LocalAppData% is not set")
+	}
+	return dir, nil
+}
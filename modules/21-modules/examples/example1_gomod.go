@@ -0,0 +1,143 @@
+// Package examples demonstrates Go modules: go.mod files, versions, and
+// workspaces.
+//
+// The examples run the go command on small modules they write to a
+// temporary directory, with the module proxy turned off, so they never
+// need the network.
+//
+// This file shows:
+// - What a go.mod file holds, read with "go mod edit -json"
+// - Semantic import versioning: from v2 on, the major version is part of
+// the module path
+// - The module versions compiled into a program, from debug.ReadBuildInfo
+package examples
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// GoMod is a go.mod file, as "go mod edit -json" prints it.
+type GoMod struct {
+	Module  ModuleVersion
+	Go      string
+	Require []Require
+	Replace []Replace
+}
+
+// ModuleVersion is a module path and, where there is one, a version.
+type ModuleVersion struct {
+	Path    string
+	Version string
+}
+
+// Require is a require directive: the least version of a module that the
+// main module needs.
+type Require struct {
+	Path     string
+	Version  string
+	Indirect bool // needed by a dependency, not imported by the module itself
+}
+
+// Replace is a replace directive. New has no Version when it is a
+// directory.
+type Replace struct {
+	Old, New ModuleVersion
+}
+
+// ReadGoMod parses the go.mod file at path. The go command's own parser
+// does the work: go.mod is simple to read by eye, but has quoting,
+// comments, and blocks that a hand-written parser gets wrong.
+func ReadGoMod(ctx context.Context, path string) (*GoMod, error) {
+	out, err := Go(ctx, filepath.Dir(path), nil, "mod", "edit", "-json", filepath.Base(path))
+	if err != nil {
+		return nil, err
+	}
+	var m GoMod
+	if err := json.Unmarshal([]byte(out), &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// SplitMajor splits a module path into its prefix and its major version:
+// "example.com/m/v3" is ("example.com/m", 3). A path without a version
+// suffix is major version 0 or 1, reported as 1. gopkg.in paths put the
+// version after a dot: "gopkg.in/yaml.v3".
+func SplitMajor(path string) (prefix string, major int) {
+	if strings.HasPrefix(path, "gopkg.in/") {
+		// gopkg.in spells out v0 and v1 too.
+		if i := strings.LastIndex(path, ".v"); i >= 0 {
+			if n, err := strconv.Atoi(path[i+2:]); err == nil {
+				return path[:i], n
+			}
+		}
+		return path, 1
+	}
+	i := strings.LastIndex(path, "/v")
+	if i < 0 {
+		return path, 1
+	}
+	// "/v1", "/v02", and "/vendor" are not version suffixes.
+	suffix := path[i+2:]
+	n, err := strconv.Atoi(suffix)
+	if err != nil || n < 2 || suffix[0] < '1' || suffix[0] > '9' {
+		return path, 1
+	}
+	return path[:i], n
+}
+
+// PathForMajor returns the module path of major version major of the
+// module whose path without a suffix is prefix. Versions 0 and 1 share a
+// path; each later one is a different module, and code can import v1 and
+// v2 side by side.
+func PathForMajor(prefix string, major int) string {
+	if major < 2 {
+		return prefix
+	}
+	return fmt.Sprintf("%s/v%d", prefix, major)
+}
+
+// Dependencies returns the modules compiled into the running program, as
+// "path version" lines, the way "go version -m" prints them for a
+// binary.
+func Dependencies() []string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	deps := []string{info.Main.Path + " " + info.Main.Version}
+	for _, d := range info.Deps {
+		line := d.Path + " " + d.Version
+		if d.Replace != nil {
+			line += " => " + d.Replace.Path + " " + d.Replace.Version
+		}
+		deps = append(deps, line)
+	}
+	return deps
+}
+
+// DemonstrateGoMod reads this course's go.mod, and shows major versions
+// in module paths.
+func DemonstrateGoMod() {
+	m, err := ReadGoMod(context.Background(), filepath.Join("..", "..", "..", "go.mod"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("module %s, go %s\n", m.Module.Path, m.Go)
+	for _, r := range m.Require {
+		prefix, major := SplitMajor(r.Path)
+		fmt.Printf("  requires %s %s (indirect %v): major version %d of %s\n", r.Path, r.Version, r.Indirect, major, prefix)
+	}
+
+	for _, major := range []int{0, 1, 2, 3} {
+		fmt.Printf("v%d.x.y of example.com/shapes is imported as %s\n", major, PathForMajor("example.com/shapes", major))
+	}
+	fmt.Println("compiled in:", strings.Join(Dependencies(), "; "))
+}
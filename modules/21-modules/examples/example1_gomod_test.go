@@ -0,0 +1,89 @@
+package examples
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateGoMod(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	DemonstrateGoMod()
+}
+
+func TestReadGoMod(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	dir := t.TempDir()
+	require.NoError(t, WriteFiles(dir, map[string]string{
+		"go.mod": "// The module of the test.\nmodule example.com/m/v2\n\ngo 1.21\n\nrequire (\n\texample.com/a v1.2.3\n\texample.com/b/v3 v3.0.1 // indirect\n)\n\nreplace example.com/a => ../a\n",
+	}))
+	m, err := ReadGoMod(context.Background(), filepath.Join(dir, "go.mod"))
+	require.NoError(t, err)
+	assert.Equal(t, &GoMod{
+		Module: ModuleVersion{Path: "example.com/m/v2"},
+		Go:     "1.21",
+		Require: []Require{
+			{Path: "example.com/a", Version: "v1.2.3"},
+			{Path: "example.com/b/v3", Version: "v3.0.1", Indirect: true},
+		},
+		Replace: []Replace{{Old: ModuleVersion{Path: "example.com/a"}, New: ModuleVersion{Path: "../a"}}},
+	}, m)
+
+	_, err = ReadGoMod(context.Background(), filepath.Join(dir, "missing.mod"))
+	assert.Error(t, err)
+}
+
+func TestSplitMajor(t *testing.T) {
+	tests := []struct {
+		path   string
+		prefix string
+		major  int
+	}{
+		{"example.com/m", "example.com/m", 1},
+		{"example.com/m/v2", "example.com/m", 2},
+		{"example.com/m/v12", "example.com/m", 12},
+		{"example.com/m/v1", "example.com/m/v1", 1},
+		{"example.com/m/v02", "example.com/m/v02", 1},
+		{"example.com/m/vendor", "example.com/m/vendor", 1},
+		{"example.com/v3/m", "example.com/v3/m", 1},
+		{"gopkg.in/yaml.v3", "gopkg.in/yaml", 3},
+		{"gopkg.in/check.v1", "gopkg.in/check", 1},
+	}
+	for _, tt := range tests {
+		prefix, major := SplitMajor(tt.path)
+		assert.Equal(t, tt.prefix, prefix, tt.path)
+		assert.Equal(t, tt.major, major, tt.path)
+	}
+}
+
+func TestPathForMajor(t *testing.T) {
+	assert.Equal(t, "example.com/m", PathForMajor("example.com/m", 0))
+	assert.Equal(t, "example.com/m", PathForMajor("example.com/m", 1))
+	assert.Equal(t, "example.com/m/v2", PathForMajor("example.com/m", 2))
+	prefix, major := SplitMajor(PathForMajor("example.com/m", 7))
+	assert.Equal(t, "example.com/m", prefix)
+	assert.Equal(t, 7, major)
+}
+
+func TestDependencies(t *testing.T) {
+	deps := Dependencies()
+	require.NotEmpty(t, deps)
+	assert.True(t, strings.HasPrefix(deps[0], "github.com/TheAnarchoX/LearningGoTheHardWay "), deps[0])
+	assert.Condition(t, func() bool {
+		for _, d := range deps {
+			if strings.HasPrefix(d, "github.com/stretchr/testify v1.") {
+				return true
+			}
+		}
+		return false
+	}, "the test binary has testify compiled in: %v", deps)
+}
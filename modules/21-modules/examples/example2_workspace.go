@@ -0,0 +1,121 @@
+package examples
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// WorkspaceExamples demonstrates working on two modules at once.
+//
+// An application that requires a library finds it through the module
+// proxy, at the version in its go.mod. To try a change to the library
+// before it is published, the application needs to see the library's
+// directory instead. A replace directive in the application's go.mod
+// does that, and is easy to commit by mistake; a go.work file next to
+// both modules does it without touching either go.mod, and is usually
+// left out of version control.
+
+// Go runs the go command in dir and returns what it printed. extra adds
+// to the environment. The module proxy is turned off, so nothing is
+// downloaded, and GOFLAGS and GOWORK are cleared, so that settings in the
+// caller's environment cannot change what the command does.
+func Go(ctx context.Context, dir string, extra []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOPROXY=off", "GOFLAGS=", "GOWORK=")
+	cmd.Env = append(cmd.Env, extra...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("go %s: %w\n%s", strings.Join(args, " "), err, out)
+	}
+	return string(out), nil
+}
+
+// WriteFiles creates each file under dir, with the directories it needs.
+func WriteFiles(dir string, files map[string]string) error {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// greetFiles is an application, and a library it requires at a version
+// that was never published.
+var greetFiles = map[string]string{
+	"greet/go.mod":   "module example.com/greet\n\ngo 1.21\n",
+	"greet/greet.go": "package greet\n\nfunc Hello(name string) string { return \"Hello, \" + name }\n",
+	"app/go.mod":     "module example.com/app\n\ngo 1.21\n\nrequire example.com/greet v1.0.0\n",
+	"app/main.go":    "package main\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/greet\"\n)\n\nfunc main() { fmt.Println(greet.Hello(\"workspace\")) }\n",
+}
+
+// DemonstrateWorkspace runs an application against a local copy of its
+// library, first with a replace directive and then with a workspace.
+func DemonstrateWorkspace() {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "learngo-workspace")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	if err := WriteFiles(dir, greetFiles); err != nil {
+		fmt.Println(err)
+		return
+	}
+	app := filepath.Join(dir, "app")
+
+	// With the proxy off and no go.sum entry, example.com/greet v1.0.0
+	// cannot be found.
+	_, err = Go(ctx, app, nil, "run", ".")
+	fmt.Println("without help:", firstLine(err))
+
+	// A directory on the right of a replace starts with ./ or ../;
+	// otherwise it is read as a module path.
+	if _, err := Go(ctx, app, nil, "mod", "edit", "-replace", "example.com/greet=../greet"); err != nil {
+		fmt.Println(err)
+		return
+	}
+	out, err := Go(ctx, app, nil, "run", ".")
+	fmt.Printf("with a replace: %q, err %v\n", out, err)
+	if _, err := Go(ctx, app, nil, "mod", "edit", "-dropreplace", "example.com/greet"); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// go work init writes go.work in the current directory, listing the
+	// module directories it is given.
+	if _, err := Go(ctx, dir, nil, "work", "init", "./app", "./greet"); err != nil {
+		fmt.Println(err)
+		return
+	}
+	work, _ := os.ReadFile(filepath.Join(dir, "go.work"))
+	fmt.Printf("go.work:\n%s", work)
+	out, err = Go(ctx, app, nil, "run", ".")
+	fmt.Printf("in the workspace: %q, err %v\n", out, err)
+
+	// GOWORK=off builds the module on its own, as anyone without the
+	// go.work file would.
+	_, err = Go(ctx, app, []string{"GOWORK=off"}, "run", ".")
+	fmt.Println("with GOWORK=off:", firstLine(err))
+}
+
+// firstLine returns the first line of err's message after the one
+// saying which command failed.
+func firstLine(err error) string {
+	if err == nil {
+		return "no error"
+	}
+	_, rest, _ := strings.Cut(err.Error(), "\n")
+	line, _, _ := strings.Cut(rest, "\n")
+	return line
+}
@@ -0,0 +1,61 @@
+package examples
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateWorkspace(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go run")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	DemonstrateWorkspace()
+}
+
+func TestWorkspace(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go build")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+	require.NoError(t, WriteFiles(dir, greetFiles))
+	app := filepath.Join(dir, "app")
+
+	_, err := Go(ctx, app, nil, "build", "./...")
+	assert.ErrorContains(t, err, "example.com/greet", "v1.0.0 was never published")
+
+	_, err = Go(ctx, dir, nil, "work", "init", "./app", "./greet")
+	require.NoError(t, err)
+	out, err := Go(ctx, app, nil, "run", ".")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, workspace\n", out)
+
+	gomod, err := os.ReadFile(filepath.Join(app, "go.mod"))
+	require.NoError(t, err)
+	assert.Equal(t, greetFiles["app/go.mod"], string(gomod), "the workspace leaves go.mod alone")
+
+	_, err = Go(ctx, app, []string{"GOWORK=off"}, "build", "./...")
+	assert.Error(t, err)
+}
+
+func TestGoClearsGOFLAGS(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	t.Setenv("GOFLAGS", "-mod=mod")
+	out, err := Go(context.Background(), t.TempDir(), nil, "env", "GOFLAGS", "GOPROXY")
+	require.NoError(t, err)
+	assert.Equal(t, "\noff\n", out)
+}
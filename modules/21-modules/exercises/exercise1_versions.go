@@ -0,0 +1,132 @@
+package exercises
+
+// EXERCISE: Fix the version comparison and minimal version selection.
+// The go command picks the version of each dependency with minimal
+// version selection (MVS): it follows every requirement of every module
+// it reaches, and for each module uses the highest version that anything
+// requires. Not the newest release, and not the first version it saw:
+// the builds stay the same until someone changes a go.mod.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version, vMAJOR.MINOR.PATCH with an optional
+// pre-release part after a dash, as in v1.2.3 and v2.0.0-rc.1.
+type Version struct {
+	Major, Minor, Patch int
+	Pre                 string // "rc.1" in v2.0.0-rc.1; empty for a release
+}
+
+// ParseVersion parses a version such as "v1.2.3" or "v2.0.0-rc.1".
+func ParseVersion(s string) (Version, error) {
+	rest, ok := strings.CutPrefix(s, "v")
+	if !ok {
+		return Version{}, fmt.Errorf("version %q does not start with v", s)
+	}
+	rest, pre, _ := strings.Cut(rest, "-")
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("version %q is not vMAJOR.MINOR.PATCH", s)
+	}
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		// Leading zeros and signs are not allowed: v1.02.0 is no version.
+		if err != nil || p[0] < '0' || p[0] > '9' || (len(p) > 1 && p[0] == '0') {
+			return Version{}, fmt.Errorf("version %q: bad number %q", s, p)
+		}
+		nums[i] = n
+	}
+	return Version{Major: nums[0], Minor: nums[1], Patch: nums[2], Pre: pre}, nil
+}
+
+// String returns the version as ParseVersion reads it.
+func (v Version) String() string {
+	s := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// Compare returns -1 if v is older than w, 1 if it is newer, and 0 if
+// they are the same. The numbers are compared in order, as numbers. A
+// pre-release comes before the release it leads up to: v1.0.0-rc.1 is
+// older than v1.0.0. (Pre-releases of the same version are compared as
+// strings here, which is simpler than the full rules.)
+func Compare(v, w Version) int {
+	for _, d := range []int{v.Major - w.Major, v.Minor - w.Minor, v.Patch - w.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+	// BUG: An empty Pre is a release, the newest of them all, yet an empty
+	// string sorts before every other. Handle a missing Pre on either side
+	// first.
+	return strings.Compare(v.Pre, w.Pre)
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// Module is one version of one module, as in a require line.
+type Module struct {
+	Path    string
+	Version string
+}
+
+func (m Module) String() string { return m.Path + "@" + m.Version }
+
+// Graph maps each module version to the requirements in its go.mod.
+type Graph map[Module][]Module
+
+// BuildList returns the modules that a build of main uses, with the
+// version MVS selects for each, sorted by path. main itself is not in the
+// list. An error is returned for a version that does not parse.
+func BuildList(g Graph, main Module) ([]Module, error) {
+	selected := make(map[string]Version) // by path
+	seen := make(map[Module]bool)
+	queue := append([]Module(nil), g[main]...)
+	for len(queue) > 0 {
+		m := queue[0]
+		queue = queue[1:]
+		// The main module is the one being built, whatever version of
+		// it anything else requires.
+		if seen[m] || m.Path == main.Path {
+			continue
+		}
+		seen[m] = true
+		v, err := ParseVersion(m.Version)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m, err)
+		}
+		// BUG: The first version reached is kept, and a higher one
+		// required elsewhere is skipped together with its requirements.
+		// Every module version reached counts: keep the highest version
+		// of each path, and follow the requirements of every version.
+		if _, ok := selected[m.Path]; ok {
+			continue
+		}
+		selected[m.Path] = v
+		queue = append(queue, g[m]...)
+	}
+
+	list := make([]Module, 0, len(selected))
+	for path, v := range selected {
+		list = append(list, Module{path, v.String()})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Path < list[j].Path })
+	return list, nil
+}
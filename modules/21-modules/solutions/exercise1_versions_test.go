@@ -0,0 +1,188 @@
+package solutions
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	v, err := ParseVersion("v1.22.3")
+	require.NoError(t, err)
+	assert.Equal(t, Version{Major: 1, Minor: 22, Patch: 3}, v)
+	assert.Equal(t, "v1.22.3", v.String())
+
+	v, err = ParseVersion("v2.0.0-rc.1")
+	require.NoError(t, err)
+	assert.Equal(t, Version{Major: 2, Pre: "rc.1"}, v)
+	assert.Equal(t, "v2.0.0-rc.1", v.String())
+
+	for _, bad := range []string{"1.2.3", "v1.2", "v1.2.3.4", "v1.02.3", "v1.-2.3", "v1.+2.3", "vx.y.z", ""} {
+		_, err := ParseVersion(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// In order, oldest first.
+	ordered := []string{
+		"v0.1.0", "v0.9.0", "v0.10.0", "v1.0.0-alpha", "v1.0.0-beta", "v1.0.0-rc.1",
+		"v1.0.0", "v1.0.1", "v1.2.0", "v1.10.0", "v2.0.0-rc.1", "v2.0.0", "v10.0.0",
+	}
+	versions := make([]Version, len(ordered))
+	for i, s := range ordered {
+		v, err := ParseVersion(s)
+		require.NoError(t, err)
+		versions[i] = v
+	}
+	for i, v := range versions {
+		for j, w := range versions {
+			assert.Equal(t, sign(i-j), Compare(v, w), "Compare(%s, %s)", v, w)
+		}
+	}
+
+	r := seed.Rand(t, 1)
+	shuffled := append([]Version(nil), versions...)
+	r.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	sort.Slice(shuffled, func(i, j int) bool { return Compare(shuffled[i], shuffled[j]) < 0 })
+	assert.Equal(t, versions, shuffled)
+}
+
+// mod returns a Module from "path@version".
+func mod(s string) Module {
+	path, version, _ := strings.Cut(s, "@")
+	return Module{path, version}
+}
+
+// graph builds a Graph from "path@version" strings.
+func graph(reqs map[string][]string) Graph {
+	g := make(Graph)
+	for m, deps := range reqs {
+		var list []Module
+		for _, d := range deps {
+			list = append(list, mod(d))
+		}
+		g[mod(m)] = list
+	}
+	return g
+}
+
+func TestBuildList(t *testing.T) {
+	// The example from Russ Cox's "Minimal Version Selection": A needs B
+	// 1.2 and C 1.2; they need D 1.3 and D 1.4. D 1.4 needs E 1.2, which
+	// D 1.3 does not. Newer versions of everything exist, and are not
+	// used.
+	g := graph(map[string][]string{
+		"A@v1.0.0": {"B@v1.2.0", "C@v1.2.0"},
+		"B@v1.1.0": {"D@v1.1.0"},
+		"B@v1.2.0": {"D@v1.3.0"},
+		"C@v1.2.0": {"D@v1.4.0"},
+		"C@v1.3.0": {"F@v1.1.0"},
+		"D@v1.3.0": {"E@v1.1.0"},
+		"D@v1.4.0": {"E@v1.2.0"},
+		"D@v1.5.0": {},
+		"E@v1.1.0": {},
+		"E@v1.2.0": {},
+		"E@v1.3.0": {},
+		"F@v1.1.0": {},
+	})
+	list, err := BuildList(g, mod("A@v1.0.0"))
+	require.NoError(t, err)
+	assert.Equal(t, []Module{mod("B@v1.2.0"), mod("C@v1.2.0"), mod("D@v1.4.0"), mod("E@v1.2.0")}, list)
+}
+
+func TestBuildListPreReleases(t *testing.T) {
+	g := graph(map[string][]string{
+		"app@v0.0.0":  {"lib@v1.0.0-rc.2", "tool@v1.0.0"},
+		"tool@v1.0.0": {"lib@v1.0.0"},
+		"lib@v1.0.0":  {},
+	})
+	list, err := BuildList(g, mod("app@v0.0.0"))
+	require.NoError(t, err)
+	assert.Equal(t, []Module{mod("lib@v1.0.0"), mod("tool@v1.0.0")}, list, "a release beats its release candidate")
+}
+
+func TestBuildListCycles(t *testing.T) {
+	g := graph(map[string][]string{
+		"app@v1.0.0": {"a@v1.0.0"},
+		"a@v1.0.0":   {"b@v1.0.0"},
+		"b@v1.0.0":   {"a@v1.1.0", "app@v0.9.0"},
+		"a@v1.1.0":   {"b@v1.0.0"},
+	})
+	list, err := BuildList(g, mod("app@v1.0.0"))
+	require.NoError(t, err)
+	assert.Equal(t, []Module{mod("a@v1.1.0"), mod("b@v1.0.0")}, list, "the main module is not in the list")
+
+	g[mod("b@v1.0.0")] = append(g[mod("b@v1.0.0")], mod("c@latest"))
+	_, err = BuildList(g, mod("app@v1.0.0"))
+	assert.ErrorContains(t, err, "c@latest")
+}
+
+// TestBuildListRandom checks BuildList against its definition on a
+// random graph: a module's version is the highest one reachable.
+func TestBuildListRandom(t *testing.T) {
+	r := seed.Rand(t, 2)
+	g, main := randomGraph(r)
+	list, err := BuildList(g, main)
+	require.NoError(t, err)
+
+	reachable := map[Module]bool{}
+	var walk func(m Module)
+	walk = func(m Module) {
+		if reachable[m] {
+			return
+		}
+		reachable[m] = true
+		for _, d := range g[m] {
+			walk(d)
+		}
+	}
+	walk(main)
+	want := map[string]string{}
+	for m := range reachable {
+		if m.Path == main.Path {
+			continue
+		}
+		if cur, ok := want[m.Path]; !ok || m.Version > cur { // one-digit minor versions compare as strings
+			want[m.Path] = m.Version
+		}
+	}
+	got := map[string]string{}
+	for _, m := range list {
+		got[m.Path] = m.Version
+	}
+	assert.Equal(t, want, got)
+}
+
+// randomGraph returns a graph of modules m0 to m7, each with versions
+// v1.0.0 to v1.4.0 requiring a few random modules with higher numbers,
+// and the main module that requires some of them.
+func randomGraph(r *rand.Rand) (Graph, Module) {
+	g := make(Graph)
+	const n, versions = 8, 5
+	name := func(i, v int) Module { return Module{fmt.Sprintf("m%d", i), fmt.Sprintf("v1.%d.0", v)} }
+	for i := 0; i < n; i++ {
+		for v := 0; v < versions; v++ {
+			m := name(i, v)
+			g[m] = nil
+			for j := i + 1; j < n; j++ {
+				if r.Intn(3) == 0 {
+					g[m] = append(g[m], name(j, r.Intn(versions)))
+				}
+			}
+		}
+	}
+	main := Module{"main", "v0.0.0"}
+	for i := 0; i < n; i++ {
+		if r.Intn(2) == 0 {
+			g[main] = append(g[main], name(i, seed.Between(r, 0, versions-1)))
+		}
+	}
+	return g, main
+}
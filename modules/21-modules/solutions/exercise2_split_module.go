@@ -0,0 +1,142 @@
+package solutions
+
+// SOLUTION: The course's pkg/geometry, split into a module of its own.
+// SplitModule does what you would do by hand to publish one package of a
+// repository on its own: give it a go.mod, point the code that imports it
+// at its new path, and add a go.work file so that the repository builds
+// against the local copy before any version of the new module exists.
+// The tests run it on a copy of pkg/geometry and some code that uses it,
+// then build the result.
+// Each fix is marked with a // Fixed: comment.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SplitModule turns the package in root/dir into a module whose path is
+// modPath, and makes root a workspace in which the code keeps using the
+// local copy. Afterwards:
+//
+//   - root/dir/go.mod declares modPath, with the go version of root/go.mod
+//   - every Go file under root imports modPath instead of the package's
+//     old path, and modPath/x instead of the old path's package x
+//   - root/go.work uses both the root module and root/dir
+//
+// dir is relative to root. SplitModule runs the go command to edit go.mod
+// and go.work files.
+func SplitModule(ctx context.Context, root, dir, modPath string) error {
+	rootMod, err := readGoMod(ctx, root)
+	if err != nil {
+		return err
+	}
+	oldPath := path.Join(rootMod.Module.Path, filepath.ToSlash(dir))
+
+	if _, err := goCommand(ctx, filepath.Join(root, dir), "mod", "init", modPath); err != nil {
+		return err
+	}
+	// Fixed: no newer Go than the rest of the repository needs
+	if _, err := goCommand(ctx, filepath.Join(root, dir), "mod", "edit", "-go="+rootMod.Go); err != nil {
+		return err
+	}
+
+	if err := rewriteImports(root, oldPath, modPath); err != nil {
+		return err
+	}
+
+	_, err = goCommand(ctx, root, "work", "init", ".", "./"+filepath.ToSlash(dir)) // Fixed: both modules
+	return err
+}
+
+// goMod is the part of "go mod edit -json" that SplitModule needs.
+type goMod struct {
+	Module struct{ Path string }
+	Go     string
+}
+
+// readGoMod reads dir/go.mod.
+func readGoMod(ctx context.Context, dir string) (*goMod, error) {
+	out, err := goCommand(ctx, dir, "mod", "edit", "-json")
+	if err != nil {
+		return nil, err
+	}
+	var m goMod
+	if err := json.Unmarshal(out, &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Join(dir, "go.mod"), err)
+	}
+	return &m, nil
+}
+
+// goCommand runs the go command in dir and returns its standard output.
+// GOFLAGS and GOWORK are cleared, so that the caller's environment cannot
+// change what the command does: GOFLAGS=-mod=mod, for one, is an error in
+// a workspace.
+func goCommand(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=", "GOWORK=")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go %s: %w: %s", strings.Join(args, " "), err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return out, nil
+}
+
+// rewriteImports changes the imports of the packages at oldPath, and
+// below it, to newPath in every Go file under root.
+func rewriteImports(root, oldPath, newPath string) error {
+	return filepath.WalkDir(root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(name, ".go") {
+			return err
+		}
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		changed := false
+		for _, imp := range f.Imports {
+			importPath, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				return err
+			}
+			if moved, ok := movedPath(importPath, oldPath, newPath); ok {
+				imp.Path.Value = strconv.Quote(moved)
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		// format.Node prints the file as gofmt would, imports sorted.
+		var buf bytes.Buffer
+		if err := format.Node(&buf, fset, f); err != nil {
+			return err
+		}
+		return os.WriteFile(name, buf.Bytes(), 0o644)
+	})
+}
+
+// movedPath returns the new import path of importPath when the packages
+// at oldPath and below move to newPath, and whether it moves at all.
+func movedPath(importPath, oldPath, newPath string) (string, bool) {
+	rest, ok := strings.CutPrefix(importPath, oldPath)
+	if !ok || (rest != "" && rest[0] != '/') { // Fixed: geometry3d is not below geometry
+		return "", false
+	}
+	return newPath + rest, true
+}
@@ -0,0 +1,160 @@
+package solutions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/testenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// geometrySource is the course's own pkg/geometry, which the tests split
+// off a small copy of the repository.
+var geometrySource = filepath.Join("..", "..", "..", "pkg", "geometry", "geometry.go")
+
+// courseFiles returns the copy: pkg/geometry, a package below it that
+// moves along, a package with a similar name that stays, and a program
+// that uses all three.
+func courseFiles(t *testing.T) map[string]string {
+	t.Helper()
+	src, err := os.ReadFile(geometrySource)
+	require.NoError(t, err)
+	return map[string]string{
+		"go.mod":                   "module example.com/course\n\ngo 1.21\n",
+		"pkg/geometry/geometry.go": string(src),
+		"pkg/geometry/scale/scale.go": `package scale
+
+import "example.com/course/pkg/geometry"
+
+// Rectangle returns r with both sides multiplied by k.
+func Rectangle(r geometry.Rectangle, k float64) geometry.Rectangle {
+	return geometry.Rectangle{Width: r.Width * k, Height: r.Height * k}
+}
+`,
+		"pkg/geometry3d/cube.go": `package geometry3d
+
+// Cube is a cube with sides of the given length.
+type Cube struct{ Side float64 }
+
+// Volume returns the cube's volume.
+func (c Cube) Volume() float64 { return c.Side * c.Side * c.Side }
+`,
+		"cmd/area/main.go": `package main
+
+import (
+	"fmt"
+
+	"example.com/course/pkg/geometry"
+	"example.com/course/pkg/geometry/scale"
+	"example.com/course/pkg/geometry3d"
+)
+
+func main() {
+	r := scale.Rectangle(geometry.Rectangle{Width: 2, Height: 3}, 2)
+	fmt.Println(r, r.Area(), geometry3d.Cube{Side: 2}.Volume())
+}
+`,
+	}
+}
+
+// writeFiles creates each file under dir, with the directories it needs.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+// runGo runs the go command in dir, with the module proxy off, and
+// returns what it printed.
+func runGo(t *testing.T, dir string, args ...string) (string, error) {
+	t.Helper()
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=", "GOWORK=", "GOPROXY=off")
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+// splitCourse writes the copy of the course to a new directory, splits
+// pkg/geometry off it as modPath, and returns the directory.
+func splitCourse(t *testing.T, modPath string) string {
+	t.Helper()
+	testenv.MustHaveGoBuild(t)
+	root := t.TempDir()
+	writeFiles(t, root, courseFiles(t))
+	require.NoError(t, SplitModule(context.Background(), root, filepath.Join("pkg", "geometry"), modPath))
+	return root
+}
+
+func learnerModule(t *testing.T) string {
+	r := seed.Rand(t, 1)
+	return fmt.Sprintf("github.com/learner%d/geometry", seed.Between(r, 1, 1000))
+}
+
+func TestSplitModuleGoMod(t *testing.T) {
+	modPath := learnerModule(t)
+	root := splitCourse(t, modPath)
+	out, err := runGo(t, filepath.Join(root, "pkg", "geometry"), "mod", "edit", "-json")
+	require.NoError(t, err, out)
+	var m struct {
+		Module struct{ Path string }
+		Go     string
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &m))
+	assert.Equal(t, modPath, m.Module.Path)
+	assert.Equal(t, "1.21", m.Go, "the go version of the repository's go.mod")
+
+	rootMod, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	require.NoError(t, err)
+	assert.Equal(t, "module example.com/course\n\ngo 1.21\n", string(rootMod), "the root go.mod is left alone")
+}
+
+func TestSplitModuleImports(t *testing.T) {
+	modPath := learnerModule(t)
+	root := splitCourse(t, modPath)
+	main, err := os.ReadFile(filepath.Join(root, "cmd", "area", "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(main), fmt.Sprintf("\t%q\n", modPath))
+	assert.Contains(t, string(main), fmt.Sprintf("\t%q\n", modPath+"/scale"))
+	assert.Contains(t, string(main), "\t\"example.com/course/pkg/geometry3d\"\n", "geometry3d is another package, and stays")
+	assert.NotContains(t, string(main), "\"example.com/course/pkg/geometry\"")
+
+	scale, err := os.ReadFile(filepath.Join(root, "pkg", "geometry", "scale", "scale.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(scale), fmt.Sprintf("import %q\n", modPath))
+}
+
+func TestSplitModuleWorkspace(t *testing.T) {
+	root := splitCourse(t, learnerModule(t))
+	out, err := runGo(t, root, "work", "edit", "-json")
+	require.NoError(t, err, out)
+	var work struct{ Use []struct{ DiskPath string } }
+	require.NoError(t, json.Unmarshal([]byte(out), &work))
+	var dirs []string
+	for _, u := range work.Use {
+		dirs = append(dirs, u.DiskPath)
+	}
+	assert.ElementsMatch(t, []string{".", "./pkg/geometry"}, dirs)
+}
+
+func TestSplitModuleBuilds(t *testing.T) {
+	root := splitCourse(t, learnerModule(t))
+	out, err := runGo(t, root, "vet", "./...")
+	assert.NoError(t, err, "go vet ./... in the repository:\n%s", out)
+	out, err = runGo(t, root, "run", "./cmd/area")
+	if assert.NoError(t, err, "go run ./cmd/area:\n%s", out) {
+		assert.Equal(t, "Rectangle(4x6) 24 8\n", out)
+	}
+	out, err = runGo(t, filepath.Join(root, "pkg", "geometry"), "vet", "./...")
+	assert.NoError(t, err, "go vet ./... in the new module:\n%s", out)
+}
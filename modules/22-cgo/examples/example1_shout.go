@@ -0,0 +1,38 @@
+// Package examples demonstrates calling C from Go with cgo.
+//
+// The C code is in shout.c, next to the Go files: the go command compiles
+// every .c file in a package that imports "C". Building it needs a C
+// compiler. Without one, or with CGO_ENABLED=0, the go command leaves
+// out the files that import "C" and the .c files, and builds the pure Go
+// versions instead.
+//
+// This file shows:
+// - An API that is the same with and without cgo, over two backends
+// - //go:build cgo and //go:build !cgo to choose the backend
+// - C strings: C.CString copies into C memory, C.GoString copies back
+// - Who frees what: C.CString's copy is ours, and so is what shout returns
+package examples
+
+import "fmt"
+
+// Backend says which implementation this build uses: "cgo" or "go".
+const Backend = backend
+
+// CountVowels returns the number of ASCII vowels in s.
+func CountVowels(s string) int {
+	return countVowels(s)
+}
+
+// Shout returns s in upper case, with an exclamation mark.
+func Shout(s string) string {
+	return shout(s)
+}
+
+// DemonstrateCgo calls the C functions, or their Go versions.
+func DemonstrateCgo() {
+	fmt.Println("backend:", Backend)
+	fmt.Println(`vowels in "cgo is not Go":`, CountVowels("cgo is not Go"))
+	fmt.Println(Shout("hello from C"))
+	// A C string ends at the first NUL byte; the rest is lost.
+	fmt.Printf("%q\n", Shout("cut\x00off"))
+}
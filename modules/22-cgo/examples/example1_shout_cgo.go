@@ -0,0 +1,38 @@
+//go:build cgo
+
+package examples
+
+// The comment right above import "C" is the preamble: C code that cgo
+// compiles with the package. Here it includes the header of shout.c and
+// stdlib.h, for free.
+
+/*
+#include <stdlib.h>
+#include "shout.h"
+*/
+import "C"
+
+import "unsafe"
+
+const backend = "cgo"
+
+func countVowels(s string) int {
+	// C.CString copies s into memory from C's malloc. The Go garbage
+	// collector does not know about that memory: it is ours to free.
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return int(C.count_vowels(cs))
+}
+
+func shout(s string) string {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	out := C.shout(cs)
+	if out == nil {
+		panic("shout: out of memory")
+	}
+	// shout's result is ours too. C.GoString copies it into Go memory,
+	// after which the C copy can go.
+	defer C.free(unsafe.Pointer(out))
+	return C.GoString(out)
+}
@@ -0,0 +1,38 @@
+//go:build !cgo
+
+package examples
+
+import "strings"
+
+// The same functions in Go, for builds without cgo. They must behave
+// exactly like the C ones, down to the strings cut off at a NUL byte.
+
+const backend = "go"
+
+func countVowels(s string) int {
+	s = beforeNUL(s)
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte("aeiouAEIOU", s[i]) >= 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func shout(s string) string {
+	s = beforeNUL(s)
+	upper := []byte(s)
+	for i, c := range upper {
+		if 'a' <= c && c <= 'z' {
+			upper[i] = c - 'a' + 'A'
+		}
+	}
+	return string(upper) + "!"
+}
+
+// beforeNUL returns s up to its first NUL byte, where a C string ends.
+func beforeNUL(s string) string {
+	s, _, _ = strings.Cut(s, "\x00")
+	return s
+}
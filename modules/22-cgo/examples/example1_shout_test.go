@@ -0,0 +1,43 @@
+package examples
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateCgo(t *testing.T) {
+	DemonstrateCgo()
+}
+
+func TestCountVowels(t *testing.T) {
+	assert.Equal(t, 0, CountVowels(""))
+	assert.Equal(t, 4, CountVowels("cgo is not Go"))
+	assert.Equal(t, 2, CountVowels("AEzz\x00aeiou"), "a C string ends at NUL")
+}
+
+func TestShout(t *testing.T) {
+	assert.Equal(t, "!", Shout(""))
+	assert.Equal(t, "HELLO, 世界!", Shout("hello, 世界"), "bytes above ASCII are left alone")
+	assert.Equal(t, "CUT!", Shout("cut\x00off"))
+}
+
+// TestPureGo runs the tests above again, built without cgo, so that both
+// backends are checked wherever a C compiler is at hand.
+func TestPureGo(t *testing.T) {
+	if Backend == "go" {
+		t.Skip("already the pure Go build")
+	}
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	cmd := exec.Command("go", "test", "-run", "^Test(CountVowels|Shout)$", ".")
+	cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
+	out, err := cmd.CombinedOutput()
+	assert.NoError(t, err, "CGO_ENABLED=0 go test:\n%s", out)
+}
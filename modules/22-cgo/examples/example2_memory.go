@@ -0,0 +1,107 @@
+//go:build cgo
+
+package examples
+
+/*
+#include <stdlib.h>
+#include "shout.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// MemoryExamples demonstrates who owns memory on each side of a cgo
+// call.
+//
+// Go memory may be passed to C for the length of a call, as long as it
+// holds no Go pointers, and C must not keep it afterwards: the garbage
+// collector is free to move or free it once the call returns. C memory
+// is never freed by Go, and may be kept as long as someone remembers to
+// free it. The checks GODEBUG=cgocheck=1 (the default) turns on catch
+// some mistakes; the rest show up as crashes, or as slow leaks.
+
+// Checksum returns the Fletcher-16 checksum of data, computed in C. data
+// is passed without a copy: C reads the Go slice in place during the call.
+func Checksum(data []byte) uint16 {
+	if len(data) == 0 {
+		return 0
+	}
+	return uint16(C.checksum((*C.uchar)(unsafe.Pointer(&data[0])), C.size_t(len(data))))
+}
+
+// CBuffer is a buffer in C memory, which can outlive any single call: C
+// code may keep pointers to it. Free it when done; nothing else will.
+type CBuffer struct {
+	ptr unsafe.Pointer
+	n   int
+}
+
+// ErrFreed is returned by the methods of a CBuffer that was freed.
+var ErrFreed = errors.New("buffer already freed")
+
+// NewCBuffer allocates n zeroed bytes with C's calloc.
+func NewCBuffer(n int) (*CBuffer, error) {
+	p := C.calloc(C.size_t(n), 1)
+	if p == nil {
+		return nil, fmt.Errorf("calloc(%d): out of memory", n)
+	}
+	return &CBuffer{ptr: p, n: n}, nil
+}
+
+// Bytes returns the buffer as a Go slice, without a copy. The slice
+// points into C memory: it is only valid until Free.
+func (b *CBuffer) Bytes() ([]byte, error) {
+	if b.ptr == nil {
+		return nil, ErrFreed
+	}
+	return unsafe.Slice((*byte)(b.ptr), b.n), nil
+}
+
+// Checksum returns the checksum of the buffer, computed in C.
+func (b *CBuffer) Checksum() (uint16, error) {
+	if b.ptr == nil {
+		return 0, ErrFreed
+	}
+	return uint16(C.checksum((*C.uchar)(b.ptr), C.size_t(b.n))), nil
+}
+
+// Copy returns a copy of the buffer in Go memory, which stays valid after
+// Free.
+func (b *CBuffer) Copy() ([]byte, error) {
+	if b.ptr == nil {
+		return nil, ErrFreed
+	}
+	return C.GoBytes(b.ptr, C.int(b.n)), nil
+}
+
+// Free releases the buffer's C memory. Freeing twice is a crash in C;
+// here the second call does nothing.
+func (b *CBuffer) Free() {
+	C.free(b.ptr)
+	b.ptr = nil
+}
+
+// DemonstrateMemory passes Go memory to C, and C memory to Go.
+func DemonstrateMemory() {
+	data := []byte("Go memory, read by C in place")
+	fmt.Printf("checksum of %q: %#04x\n", data, Checksum(data))
+
+	buf, err := NewCBuffer(len(data))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	view, _ := buf.Bytes()
+	copy(view, data) // writes into C memory through the Go slice
+	sum, _ := buf.Checksum()
+	kept, _ := buf.Copy()
+	buf.Free()
+	fmt.Printf("same bytes in C memory: %#04x\n", sum)
+	fmt.Printf("copy after Free: %q\n", kept)
+	_, err = buf.Checksum()
+	fmt.Println("after Free:", err)
+}
@@ -0,0 +1,45 @@
+//go:build cgo
+
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test files cannot import "C" themselves, but can be limited to cgo
+// builds like any other file.
+
+func TestDemonstrateMemory(t *testing.T) {
+	DemonstrateMemory()
+}
+
+func TestChecksum(t *testing.T) {
+	assert.Equal(t, uint16(0), Checksum(nil))
+	assert.Equal(t, uint16(0xC8F0), Checksum([]byte("abcde")))
+	assert.Equal(t, uint16(0x2057), Checksum([]byte("abcdef")))
+}
+
+func TestCBuffer(t *testing.T) {
+	buf, err := NewCBuffer(6)
+	require.NoError(t, err)
+	view, err := buf.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, make([]byte, 6), view, "calloc zeroes the memory")
+	copy(view, "abcdef")
+	sum, err := buf.Checksum()
+	require.NoError(t, err)
+	assert.Equal(t, Checksum([]byte("abcdef")), sum)
+
+	kept, err := buf.Copy()
+	require.NoError(t, err)
+	buf.Free()
+	assert.Equal(t, []byte("abcdef"), kept)
+	_, err = buf.Bytes()
+	assert.ErrorIs(t, err, ErrFreed)
+	_, err = buf.Checksum()
+	assert.ErrorIs(t, err, ErrFreed)
+	buf.Free() // does nothing
+}
@@ -0,0 +1,38 @@
+#include <ctype.h>
+#include <stdlib.h>
+#include <string.h>
+
+#include "shout.h"
+
+size_t count_vowels(const char *s) {
+	size_t n = 0;
+	for (; *s; s++) {
+		if (strchr("aeiouAEIOU", *s) != NULL) {
+			n++;
+		}
+	}
+	return n;
+}
+
+char *shout(const char *s) {
+	size_t len = strlen(s);
+	char *out = malloc(len + 2);
+	if (out == NULL) {
+		return NULL;
+	}
+	for (size_t i = 0; i < len; i++) {
+		out[i] = (char)toupper((unsigned char)s[i]);
+	}
+	out[len] = '!';
+	out[len + 1] = '\0';
+	return out;
+}
+
+unsigned int checksum(const unsigned char *buf, size_t n) {
+	unsigned int a = 0, b = 0;
+	for (size_t i = 0; i < n; i++) {
+		a = (a + buf[i]) % 255;
+		b = (b + a) % 255;
+	}
+	return (b << 8) | a;
+}
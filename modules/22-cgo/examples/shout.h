@@ -0,0 +1,16 @@
+#ifndef SHOUT_H
+#define SHOUT_H
+
+#include <stddef.h>
+
+/* count_vowels returns the number of ASCII vowels in s. It only reads s. */
+size_t count_vowels(const char *s);
+
+/* shout returns s in upper case with "!" added, in memory from malloc
+   that the caller must free, or NULL if there is no memory. */
+char *shout(const char *s);
+
+/* checksum returns the Fletcher-16 checksum of the n bytes at buf. */
+unsigned int checksum(const unsigned char *buf, size_t n);
+
+#endif
@@ -0,0 +1,44 @@
+package exercises
+
+// EXERCISE: Fix the memory leaks in a cgo binding of a small C library.
+// words.c joins words and finds the first word of a string. The Go
+// functions below call it through exercise1_cstrings_cgo.go, which leaks
+// C memory on every call, and returns too much from FirstWord. Builds
+// without cgo use exercise1_cstrings_purego.go instead, which must behave
+// exactly the same; TestPureGo runs the tests with CGO_ENABLED=0 to check.
+// The leak tests measure the C heap before and after many calls, on
+// Linux and macOS.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNUL is returned for strings that contain a NUL byte: C would take
+// them to end there, and quietly lose the rest.
+var ErrNUL = errors.New("string contains a NUL byte")
+
+// Backend says which implementation this build uses: "cgo" or "go".
+const Backend = backend
+
+// Join returns words joined with sep between them, like strings.Join.
+func Join(words []string, sep string) (string, error) {
+	return join(words, sep)
+}
+
+// FirstWord returns the first word of s: the first run of bytes that are
+// not spaces, tabs, or newlines. It returns "" if s has no word.
+func FirstWord(s string) (string, error) {
+	return firstWord(s)
+}
+
+// checkNUL returns ErrNUL if any of strs contains a NUL byte.
+func checkNUL(strs ...string) error {
+	for _, s := range strs {
+		if strings.IndexByte(s, 0) >= 0 {
+			return ErrNUL
+		}
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+#ifndef HEAP_H
+#define HEAP_H
+
+/* heap_in_use returns the number of bytes from malloc in use, or -1 where
+   that cannot be measured. It is for the tests, which use it to find C
+   memory that was never freed. */
+long long heap_in_use(void);
+
+#endif
@@ -0,0 +1,39 @@
+package solutions
+
+// SOLUTION: Fix the memory leaks in a cgo binding of a small C library.
+// Every C.CString is freed once C is done with it, and so is the string
+// join_words returns; FirstWord copies exactly the word with C.GoStringN.
+// The pure Go version refuses a NUL byte, like the cgo one.
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNUL is returned for strings that contain a NUL byte: C would take
+// them to end there, and quietly lose the rest.
+var ErrNUL = errors.New("string contains a NUL byte")
+
+// Backend says which implementation this build uses: "cgo" or "go".
+const Backend = backend
+
+// Join returns words joined with sep between them, like strings.Join.
+func Join(words []string, sep string) (string, error) {
+	return join(words, sep)
+}
+
+// FirstWord returns the first word of s: the first run of bytes that are
+// not spaces, tabs, or newlines. It returns "" if s has no word.
+func FirstWord(s string) (string, error) {
+	return firstWord(s)
+}
+
+// checkNUL returns ErrNUL if any of strs contains a NUL byte.
+func checkNUL(strs ...string) error {
+	for _, s := range strs {
+		if strings.IndexByte(s, 0) >= 0 {
+			return ErrNUL
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+//go:build cgo
+
+package solutions
+
+/*
+#include <stdlib.h>
+#include "heap.h"
+#include "words.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+const backend = "cgo"
+
+var errOutOfMemory = errors.New("C: out of memory")
+
+func join(words []string, sep string) (string, error) {
+	if err := checkNUL(append([]string{sep}, words...)...); err != nil {
+		return "", err
+	}
+	if len(words) == 0 {
+		return "", nil
+	}
+	// A Go slice may be passed to C for the length of a call as long as it
+	// holds no Go pointers. These pointers are to C memory.
+	cwords := make([]*C.char, len(words))
+	for i, w := range words {
+		cwords[i] = C.CString(w)
+		// Fixed: Free every word once join_words has returned.
+		defer C.free(unsafe.Pointer(cwords[i]))
+	}
+	csep := C.CString(sep)
+	defer C.free(unsafe.Pointer(csep))
+
+	out := C.join_words(&cwords[0], C.size_t(len(cwords)), csep)
+	if out == nil {
+		return "", errOutOfMemory
+	}
+	// Fixed: The result belongs to us; free it after copying it.
+	defer C.free(unsafe.Pointer(out))
+	return C.GoString(out), nil
+}
+
+func firstWord(s string) (string, error) {
+	if err := checkNUL(s); err != nil {
+		return "", err
+	}
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+
+	var n C.size_t
+	p := C.first_word(cs, &n)
+	if p == nil {
+		return "", nil
+	}
+	// p points into cs, which is freed above, and must not be freed
+	// again. (Copy from it before that happens, as here.)
+	// Fixed: Copy exactly the n bytes of the word.
+	return C.GoStringN(p, C.int(n)), nil
+}
+
+// heapInUse returns the bytes of C memory in use, and whether it could
+// be measured. It is for the tests.
+func heapInUse() (int64, bool) {
+	n := C.heap_in_use()
+	return int64(n), n >= 0
+}
@@ -0,0 +1,33 @@
+//go:build !cgo
+
+package solutions
+
+import "strings"
+
+const backend = "go"
+
+func join(words []string, sep string) (string, error) {
+	// Fixed: Refuse a NUL byte, like the cgo version.
+	if err := checkNUL(append([]string{sep}, words...)...); err != nil {
+		return "", err
+	}
+	return strings.Join(words, sep), nil
+}
+
+func firstWord(s string) (string, error) {
+	if err := checkNUL(s); err != nil {
+		return "", err
+	}
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n'
+	})
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return fields[0], nil
+}
+
+// heapInUse reports that there is no C memory to measure.
+func heapInUse() (int64, bool) {
+	return 0, false
+}
@@ -0,0 +1,113 @@
+package solutions
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/testenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		words []string
+		sep   string
+		want  string
+	}{
+		{nil, ", ", ""},
+		{[]string{"one"}, ", ", "one"},
+		{[]string{"one", "two", "three"}, ", ", "one, two, three"},
+		{[]string{"", "b", ""}, "-", "-b-"},
+		{[]string{"héllo", "世界"}, "", "héllo世界"},
+	}
+	for _, tt := range tests {
+		got, err := Join(tt.words, tt.sep)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "Join(%q, %q)", tt.words, tt.sep)
+	}
+
+	r := seed.Rand(t, 1)
+	words := make([]string, seed.Between(r, 50, 100))
+	for i := range words {
+		words[i] = strings.Repeat(string(rune('a'+i%26)), seed.Between(r, 1, 30))
+	}
+	got, err := Join(words, " ")
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join(words, " "), got)
+}
+
+func TestJoinNUL(t *testing.T) {
+	_, err := Join([]string{"a", "b\x00c"}, " ")
+	assert.ErrorIs(t, err, ErrNUL)
+	_, err = Join([]string{"a", "b"}, "\x00")
+	assert.ErrorIs(t, err, ErrNUL)
+}
+
+func TestFirstWord(t *testing.T) {
+	tests := map[string]string{
+		"":                   "",
+		" \t\n":              "",
+		"hello":              "hello",
+		"hello world":        "hello",
+		"\t  cgo\nis not Go": "cgo",
+		"  ünïcode  rest":    "ünïcode",
+	}
+	for s, want := range tests {
+		got, err := FirstWord(s)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "FirstWord(%q)", s)
+	}
+
+	_, err := FirstWord("a\x00b")
+	assert.ErrorIs(t, err, ErrNUL)
+}
+
+// assertNoLeak calls f many times and checks that the C heap has not
+// grown by much more than one call could use.
+func assertNoLeak(t *testing.T, f func()) {
+	t.Helper()
+	if _, ok := heapInUse(); !ok {
+		t.Skipf("cannot measure the C heap on %s with the %s backend", runtime.GOOS, Backend)
+	}
+	f() // the first call may allocate for good, in C or in the runtime
+	before, _ := heapInUse()
+	for i := 0; i < 2000; i++ {
+		f()
+	}
+	after, _ := heapInUse()
+	assert.Less(t, after-before, int64(256<<10),
+		"the C heap grew by %d bytes over 2000 calls", after-before)
+}
+
+func TestJoinDoesNotLeak(t *testing.T) {
+	words := strings.Fields("the quick brown fox jumps over the lazy dog")
+	assertNoLeak(t, func() {
+		_, _ = Join(words, ", ")
+	})
+}
+
+func TestFirstWordDoesNotLeak(t *testing.T) {
+	s := fmt.Sprintf("  first %s", strings.Repeat("word ", 20))
+	assertNoLeak(t, func() {
+		_, _ = FirstWord(s)
+	})
+}
+
+// TestPureGo runs the tests above again, built without cgo, so that both
+// backends are checked wherever a C compiler is at hand.
+func TestPureGo(t *testing.T) {
+	if Backend == "go" {
+		t.Skip("already the pure Go build")
+	}
+	testenv.MustHaveGoBuild(t)
+	cmd := exec.Command("go", "test", "-run", "^Test(Join|JoinNUL|FirstWord)$", ".")
+	cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
+	out, err := cmd.CombinedOutput()
+	assert.NoError(t, err, "CGO_ENABLED=0 go test:\n%s", out)
+}
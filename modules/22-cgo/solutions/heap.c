@@ -0,0 +1,35 @@
+#include <stdlib.h> /* defines __GLIBC__ on glibc */
+
+#include "heap.h"
+
+#if defined(__GLIBC__) && (__GLIBC__ > 2 || __GLIBC_MINOR__ >= 33)
+
+#include <malloc.h>
+
+/* mallinfo2 only counts the main arena. With one arena for all threads,
+   that is every allocation, whichever thread a cgo call runs on. */
+__attribute__((constructor)) static void one_arena(void) {
+	mallopt(M_ARENA_MAX, 1);
+}
+
+long long heap_in_use(void) {
+	return (long long)mallinfo2().uordblks;
+}
+
+#elif defined(__APPLE__)
+
+#include <malloc/malloc.h>
+
+long long heap_in_use(void) {
+	malloc_statistics_t stats;
+	malloc_zone_statistics(NULL, &stats);
+	return (long long)stats.size_in_use;
+}
+
+#else
+
+long long heap_in_use(void) {
+	return -1;
+}
+
+#endif
@@ -0,0 +1,46 @@
+#include <stdlib.h>
+#include <string.h>
+
+#include "words.h"
+
+char *join_words(char *const *words, size_t n, const char *sep) {
+	size_t seplen = strlen(sep), total = 1;
+	for (size_t i = 0; i < n; i++) {
+		total += strlen(words[i]) + (i > 0 ? seplen : 0);
+	}
+	char *out = malloc(total), *p = out;
+	if (out == NULL) {
+		return NULL;
+	}
+	for (size_t i = 0; i < n; i++) {
+		if (i > 0) {
+			memcpy(p, sep, seplen);
+			p += seplen;
+		}
+		size_t len = strlen(words[i]);
+		memcpy(p, words[i], len);
+		p += len;
+	}
+	*p = '\0';
+	return out;
+}
+
+static int is_space(char c) {
+	return c == ' ' || c == '\t' || c == '\n';
+}
+
+const char *first_word(const char *s, size_t *len) {
+	while (is_space(*s)) {
+		s++;
+	}
+	if (*s == '\0') {
+		*len = 0;
+		return NULL;
+	}
+	const char *end = s;
+	while (*end != '\0' && !is_space(*end)) {
+		end++;
+	}
+	*len = (size_t)(end - s);
+	return s;
+}
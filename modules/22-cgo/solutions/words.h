@@ -0,0 +1,16 @@
+#ifndef WORDS_H
+#define WORDS_H
+
+#include <stddef.h>
+
+/* join_words returns the n words joined with sep between them, in memory
+   from malloc that the caller must free, or NULL if there is no memory. */
+char *join_words(char *const *words, size_t n, const char *sep);
+
+/* first_word finds the first word of s: the first run of characters that
+   are not spaces, tabs, or newlines. It returns a pointer into s, where
+   the word starts, and puts the word's length in *len. It allocates
+   nothing. If s has no word, it returns NULL. */
+const char *first_word(const char *s, size_t *len);
+
+#endif
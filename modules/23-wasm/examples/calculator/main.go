@@ -0,0 +1,16 @@
+//go:build js && wasm
+
+// Command calculator is the program of the calculator page, index.html.
+// Build it with
+//
+//	GOOS=js GOARCH=wasm go build -o main.wasm
+//
+// and serve it next to the page and wasm_exec.js, or let learngo serve do
+// that.
+package main
+
+import "github.com/TheAnarchoX/LearningGoTheHardWay/modules/23-wasm/examples"
+
+func main() {
+	examples.Run()
+}
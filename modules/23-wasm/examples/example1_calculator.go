@@ -0,0 +1,98 @@
+// Package examples demonstrates Go compiled to WebAssembly for a web page.
+//
+// With GOOS=js GOARCH=wasm, the go command builds a .wasm file that a
+// browser runs next to wasm_exec.js, the support script from the Go
+// toolchain. The program reaches the page through syscall/js: it finds
+// elements, reads and writes their properties, and registers Go functions
+// as event listeners.
+//
+// The calculator here is split the way module 20 splits platform code:
+// this file computes areas, and builds everywhere, so it is tested with a
+// plain go test; example1_calculator_js.go is the DOM side, built only for
+// js. The page is calculator/index.html, with calculator/main.go as its
+// program. learngo serve shows it at /apps/23-wasm/examples/calculator/.
+//
+// This file shows:
+// - Keeping the logic in ordinary Go, apart from syscall/js
+// - What the page asks for: a shape, and one or two lengths
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// Shapes are the kinds of shape the calculator knows, in the order of
+// the page's select.
+var Shapes = []string{"circle", "rectangle", "triangle"}
+
+// ErrUnknownShape is returned for a kind not in Shapes.
+var ErrUnknownShape = errors.New("unknown shape")
+
+// Dimensions returns the labels of the lengths a shape needs: one for a
+// circle, two for the others.
+func Dimensions(kind string) ([]string, error) {
+	switch kind {
+	case "circle":
+		return []string{"Radius"}, nil
+	case "rectangle":
+		return []string{"Width", "Height"}, nil
+	case "triangle":
+		return []string{"Base", "Height"}, nil
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownShape, kind)
+}
+
+// NewShape returns a shape of the kind, from lengths as typed into the
+// page. A triangle is a right triangle with the given base and height.
+func NewShape(kind string, lengths ...string) (geometry.Shape, error) {
+	labels, err := Dimensions(kind)
+	if err != nil {
+		return nil, err
+	}
+	if len(lengths) < len(labels) {
+		return nil, fmt.Errorf("a %s needs %d lengths", kind, len(labels))
+	}
+	dims := make([]float64, len(labels))
+	for i, label := range labels {
+		s := strings.TrimSpace(lengths[i])
+		if s == "" {
+			return nil, fmt.Errorf("%s is missing", label)
+		}
+		d, err := strconv.ParseFloat(s, 64)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("%s must be a number, 0 or more", label)
+		}
+		dims[i] = d
+	}
+	switch kind {
+	case "circle":
+		return geometry.Circle{Radius: dims[0]}, nil
+	case "rectangle":
+		return geometry.Rectangle{Width: dims[0], Height: dims[1]}, nil
+	default:
+		return geometry.Triangle{B: geometry.Point{X: dims[0]}, C: geometry.Point{Y: dims[1]}}, nil
+	}
+}
+
+// Describe returns what the page shows for a shape.
+func Describe(s geometry.Shape) string {
+	return fmt.Sprintf("Area: %.2f, perimeter: %.2f", s.Area(), s.Perimeter())
+}
+
+// DemonstrateCalculator computes what the page would show, without the
+// page.
+func DemonstrateCalculator() {
+	for _, in := range [][]string{{"circle", "2"}, {"rectangle", "3", "4.5"}, {"triangle", "3", "4"}, {"circle", "-1"}} {
+		s, err := NewShape(in[0], in[1:]...)
+		if err != nil {
+			fmt.Printf("%v: %v\n", in, err)
+			continue
+		}
+		fmt.Printf("%v: %s\n", s, Describe(s))
+	}
+}
@@ -0,0 +1,140 @@
+package examples
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// The _js suffix builds this file only with GOOS=js. It shows:
+// - js.Global, Get, Set, and Call to reach the page
+// - js.FuncOf to turn a Go function into an event listener
+// - Releasing those functions, and keeping main running for them
+
+// Calculator is the calculator bound to a page.
+type Calculator struct {
+	shape, a, b, bField, aLabel, bLabel, result, errorText js.Value
+
+	listeners []listener
+}
+
+// listener is an event listener added to an element, kept so that it can
+// be removed and released.
+type listener struct {
+	target js.Value
+	event  string
+	fn     js.Func
+}
+
+// Bind finds the calculator's elements in doc, the page's document, and
+// adds its event listeners.
+func Bind(doc js.Value) (*Calculator, error) {
+	c := &Calculator{}
+	elements := map[string]*js.Value{
+		"shape": &c.shape, "a": &c.a, "b": &c.b, "b-field": &c.bField,
+		"a-label": &c.aLabel, "b-label": &c.bLabel, "result": &c.result, "error": &c.errorText,
+	}
+	for id, v := range elements {
+		*v = doc.Call("getElementById", id)
+		if v.IsNull() {
+			return nil, fmt.Errorf("the page has no #%s", id)
+		}
+	}
+	form, clear := doc.Call("getElementById", "calculator"), doc.Call("getElementById", "clear")
+	if form.IsNull() || clear.IsNull() {
+		return nil, fmt.Errorf("the page has no #calculator form or #clear button")
+	}
+
+	// Listeners run in Go while JavaScript waits for them to return, so
+	// they must not block. Each js.Func holds on to its Go function until
+	// it is released.
+	c.listen(c.shape, "change", func(js.Value) { c.showFields(); c.update() })
+	c.listen(c.a, "input", func(js.Value) { c.update() })
+	c.listen(c.b, "input", func(js.Value) { c.update() })
+	c.listen(form, "submit", func(event js.Value) {
+		// Without this, the browser submits the form and loads a new page,
+		// and the program with it.
+		event.Call("preventDefault")
+		c.update()
+	})
+	c.listen(clear, "click", func(js.Value) { c.clear() })
+	c.showFields()
+	return c, nil
+}
+
+func (c *Calculator) listen(target js.Value, event string, handle func(event js.Value)) {
+	fn := js.FuncOf(func(this js.Value, args []js.Value) any {
+		handle(args[0])
+		return nil
+	})
+	target.Call("addEventListener", event, fn)
+	c.listeners = append(c.listeners, listener{target, event, fn})
+}
+
+// Release removes the calculator's listeners from the page and frees the
+// Go functions behind them.
+func (c *Calculator) Release() {
+	for _, l := range c.listeners {
+		l.target.Call("removeEventListener", l.event, l.fn)
+		l.fn.Release()
+	}
+	c.listeners = nil
+}
+
+// showFields labels the inputs for the selected shape, and hides the
+// second one if the shape needs only one length.
+func (c *Calculator) showFields() {
+	labels, err := Dimensions(c.shape.Get("value").String())
+	if err != nil {
+		c.showError(err)
+		return
+	}
+	c.aLabel.Set("textContent", labels[0])
+	c.bField.Set("hidden", len(labels) < 2)
+	if len(labels) > 1 {
+		c.bLabel.Set("textContent", labels[1])
+	}
+}
+
+// update shows the area of the shape typed in so far, or why there is
+// none. Until the first length is typed, it shows nothing.
+func (c *Calculator) update() {
+	a, b := c.a.Get("value").String(), c.b.Get("value").String()
+	if a == "" {
+		c.result.Set("textContent", "")
+		c.errorText.Set("hidden", true)
+		return
+	}
+	s, err := NewShape(c.shape.Get("value").String(), a, b)
+	if err != nil {
+		c.showError(err)
+		return
+	}
+	c.result.Set("textContent", Describe(s))
+	c.errorText.Set("hidden", true)
+}
+
+func (c *Calculator) showError(err error) {
+	c.result.Set("textContent", "")
+	c.errorText.Set("textContent", err.Error())
+	c.errorText.Set("hidden", false)
+}
+
+func (c *Calculator) clear() {
+	c.a.Set("value", "")
+	c.b.Set("value", "")
+	c.update()
+}
+
+// Run binds the calculator to the page and keeps the program running, as
+// the main function of a page's program must: once main returns, every
+// listener fails with "Go program has already exited".
+func Run() {
+	doc := js.Global().Get("document")
+	status := doc.Call("getElementById", "status")
+	if _, err := Bind(doc); err != nil {
+		status.Set("textContent", err.Error())
+		return
+	}
+	status.Set("textContent", "Ready")
+	select {}
+}
@@ -0,0 +1,70 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/headless"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateCalculator(t *testing.T) {
+	DemonstrateCalculator()
+}
+
+func TestNewShape(t *testing.T) {
+	s, err := NewShape("rectangle", "3", " 4.5 ")
+	require.NoError(t, err)
+	assert.Equal(t, "Area: 13.50, perimeter: 15.00", Describe(s))
+
+	s, err = NewShape("triangle", "3", "4")
+	require.NoError(t, err)
+	assert.Equal(t, "Area: 6.00, perimeter: 12.00", Describe(s))
+
+	_, err = NewShape("hexagon", "1")
+	assert.ErrorIs(t, err, ErrUnknownShape)
+	_, err = NewShape("circle", "-1")
+	assert.EqualError(t, err, "Radius must be a number, 0 or more")
+	_, err = NewShape("rectangle", "1", "")
+	assert.EqualError(t, err, "Height is missing")
+}
+
+// TestCalculatorPage builds calculator/ for the browser and uses its page
+// in Node.js, with the headless package.
+func TestCalculatorPage(t *testing.T) {
+	page := headless.Load(t, "calculator")
+	text := func(id string) string {
+		t.Helper()
+		e, err := page.Element(id)
+		require.NoError(t, err)
+		return e.Text
+	}
+	hidden := func(id string) bool {
+		t.Helper()
+		e, err := page.Element(id)
+		require.NoError(t, err)
+		return e.Hidden
+	}
+
+	assert.Equal(t, "Ready", text("status"))
+	assert.True(t, hidden("b-field"), "a circle has one length")
+
+	require.NoError(t, page.Set("a", "2"))
+	assert.Equal(t, "Area: 12.57, perimeter: 12.57", text("result"))
+
+	require.NoError(t, page.Set("shape", "rectangle"))
+	assert.False(t, hidden("b-field"))
+	assert.Equal(t, "Width", text("a-label"))
+	assert.Equal(t, "Height is missing", text("error"))
+	require.NoError(t, page.Set("b", "x"))
+	assert.False(t, hidden("error"))
+	assert.Equal(t, "Height must be a number, 0 or more", text("error"))
+	require.NoError(t, page.Set("b", "3"))
+	assert.Equal(t, "Area: 6.00, perimeter: 10.00", text("result"))
+	assert.True(t, hidden("error"))
+
+	require.NoError(t, page.Submit("calculator"))
+	require.NoError(t, page.Click("clear"))
+	assert.Empty(t, text("result"))
+	assert.Empty(t, page.Exited())
+}
@@ -0,0 +1,96 @@
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"syscall/js"
+)
+
+// ValuesExamples demonstrates moving values between Go and JavaScript.
+//
+// js.ValueOf turns Go values into JavaScript ones: numbers, strings,
+// bools, nil, and []any and map[string]any of those. Coming back, a
+// js.Value has Int, Float, String, Bool, and Get, and Type says which of
+// them makes sense; there is no conversion to arbitrary Go types. Bytes
+// are copied in bulk with js.CopyBytesToGo and js.CopyBytesToJS. A
+// JavaScript Promise is awaited from Go by blocking a goroutine on a
+// channel, never the goroutine of an event listener: JavaScript waits for
+// the listener, and nothing else runs until it returns.
+
+// ToJSON returns the JSON JavaScript makes of a Go value passed to it.
+func ToJSON(v any) string {
+	return js.Global().Get("JSON").Call("stringify", js.ValueOf(v)).String()
+}
+
+// Bytes copies the bytes of a JavaScript Uint8Array into Go.
+func Bytes(array js.Value) []byte {
+	b := make([]byte, array.Get("length").Int())
+	js.CopyBytesToGo(b, array)
+	return b
+}
+
+// Await waits for a Promise and returns its value, or its rejection as
+// an error. It blocks the calling goroutine, which must not be one that
+// JavaScript is waiting for, such as an event listener's.
+func Await(promise js.Value) (js.Value, error) {
+	type settled struct {
+		value js.Value
+		err   error
+	}
+	done := make(chan settled, 1)
+	resolve := js.FuncOf(func(this js.Value, args []js.Value) any {
+		done <- settled{value: args[0]}
+		return nil
+	})
+	reject := js.FuncOf(func(this js.Value, args []js.Value) any {
+		done <- settled{err: errors.New(js.Global().Get("String").Invoke(args[0]).String())}
+		return nil
+	})
+	defer resolve.Release()
+	defer reject.Release()
+	promise.Call("then", resolve, reject)
+	s := <-done
+	return s.value, s.err
+}
+
+// NewPromise returns a Promise that runs work in a new goroutine and
+// settles with its result, so JavaScript can wait for Go without blocking.
+func NewPromise(work func() (any, error)) js.Value {
+	var executor js.Func
+	executor = js.FuncOf(func(this js.Value, args []js.Value) any {
+		resolve, reject := args[0], args[1]
+		go func() {
+			defer executor.Release()
+			v, err := work()
+			if err != nil {
+				reject.Invoke(js.Global().Get("Error").New(err.Error()))
+				return
+			}
+			resolve.Invoke(js.ValueOf(v))
+		}()
+		return nil
+	})
+	return js.Global().Get("Promise").New(executor)
+}
+
+// DemonstrateValues converts values both ways and waits for Promises in
+// both directions.
+func DemonstrateValues() {
+	fmt.Println(ToJSON(map[string]any{"shape": "circle", "radius": 2, "tags": []any{"round", true, nil}}))
+	v := js.Global().Get("Math").Call("max", 3, 7.5)
+	fmt.Println(v.Type(), v.Float())
+
+	array := js.Global().Get("Uint8Array").New(js.ValueOf([]any{1, 2, 255}))
+	fmt.Println(Bytes(array))
+
+	area, err := Await(NewPromise(func() (any, error) {
+		s, err := NewShape("circle", "1")
+		if err != nil {
+			return nil, err
+		}
+		return s.Area(), nil
+	}))
+	fmt.Printf("%.5f %v\n", area.Float(), err)
+	_, err = Await(NewPromise(func() (any, error) { return NewShape("hexagon") }))
+	fmt.Println(err)
+}
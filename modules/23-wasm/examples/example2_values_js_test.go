@@ -0,0 +1,27 @@
+package examples
+
+import (
+	"syscall/js"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateValues(t *testing.T) {
+	DemonstrateValues()
+}
+
+func TestToJSON(t *testing.T) {
+	assert.Equal(t, `{"a":[1,"two",false,null]}`, ToJSON(map[string]any{"a": []any{1, "two", false, nil}}))
+	assert.Equal(t, `1.5`, ToJSON(1.5))
+}
+
+func TestAwait(t *testing.T) {
+	v, err := Await(js.Global().Get("Promise").Call("resolve", 42))
+	require.NoError(t, err)
+	assert.Equal(t, 42, v.Int())
+
+	_, err = Await(js.Global().Get("Promise").Call("reject", js.Global().Get("Error").New("no")))
+	assert.EqualError(t, err, "Error: no")
+}
@@ -0,0 +1,41 @@
+//go:build !js
+
+package examples
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValuesInNode runs the tests of example2_values_js.go, which only
+// build for js, in Node.js. The go command runs a js/wasm test binary with
+// go_js_wasm_exec, from the toolchain's lib/wasm directory.
+func TestValuesInNode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	for _, tool := range []string{"go", "node"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s not in PATH", tool)
+		}
+	}
+	out, err := exec.Command("go", "env", "GOROOT").Output()
+	require.NoError(t, err)
+	goroot := strings.TrimSpace(string(out))
+	// lib/wasm since Go 1.24, misc/wasm before.
+	execJS := filepath.Join(goroot, "lib", "wasm", "go_js_wasm_exec")
+	if _, err := os.Stat(execJS); err != nil {
+		execJS = filepath.Join(goroot, "misc", "wasm", "go_js_wasm_exec")
+	}
+
+	cmd := exec.Command("go", "test", "-exec", execJS, "-run", "^Test(DemonstrateValues|ToJSON|Await)$", ".")
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	out, err = cmd.CombinedOutput()
+	assert.NoError(t, err, "GOOS=js go test:\n%s", out)
+}
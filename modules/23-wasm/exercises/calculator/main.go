@@ -0,0 +1,10 @@
+//go:build js && wasm
+
+// Command calculator is the program of the calculator page, index.html.
+package main
+
+import "github.com/TheAnarchoX/LearningGoTheHardWay/modules/23-wasm/exercises"
+
+func main() {
+	exercises.Run()
+}
@@ -0,0 +1,75 @@
+package exercises
+
+// EXERCISE: Wire up the event listeners of a shape-area calculator page.
+// The calculator computes areas correctly: the functions in this file are
+// fine. Its page, calculator/index.html, barely works, because
+// exercise1_calculator_js.go, which binds it to the page with syscall/js,
+// is missing event listeners and attaches others in the wrong place. That
+// file only builds with GOOS=js, so your editor may not check it; the
+// tests build calculator/ for the browser and use the page in Node.js, as
+// a person would in a browser. See it for yourself with learngo serve, at
+// /apps/23-wasm/exercises/calculator/.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// ErrUnknownShape is returned for a shape the calculator does not know.
+var ErrUnknownShape = errors.New("unknown shape")
+
+// Dimensions returns the labels of the lengths a shape needs: one for a
+// circle, two for a rectangle or a triangle.
+func Dimensions(kind string) ([]string, error) {
+	switch kind {
+	case "circle":
+		return []string{"Radius"}, nil
+	case "rectangle":
+		return []string{"Width", "Height"}, nil
+	case "triangle":
+		return []string{"Base", "Height"}, nil
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownShape, kind)
+}
+
+// NewShape returns a shape of the kind, from lengths as typed into the
+// page. A triangle is a right triangle with the given base and height.
+func NewShape(kind string, lengths ...string) (geometry.Shape, error) {
+	labels, err := Dimensions(kind)
+	if err != nil {
+		return nil, err
+	}
+	if len(lengths) < len(labels) {
+		return nil, fmt.Errorf("a %s needs %d lengths", kind, len(labels))
+	}
+	dims := make([]float64, len(labels))
+	for i, label := range labels {
+		s := strings.TrimSpace(lengths[i])
+		if s == "" {
+			return nil, fmt.Errorf("%s is missing", label)
+		}
+		d, err := strconv.ParseFloat(s, 64)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("%s must be a number, 0 or more", label)
+		}
+		dims[i] = d
+	}
+	switch kind {
+	case "circle":
+		return geometry.Circle{Radius: dims[0]}, nil
+	case "rectangle":
+		return geometry.Rectangle{Width: dims[0], Height: dims[1]}, nil
+	default:
+		return geometry.Triangle{B: geometry.Point{X: dims[0]}, C: geometry.Point{Y: dims[1]}}, nil
+	}
+}
+
+// Describe returns what the page shows for a shape.
+func Describe(s geometry.Shape) string {
+	return fmt.Sprintf("Area: %.2f, perimeter: %.2f", s.Area(), s.Perimeter())
+}
@@ -0,0 +1,117 @@
+package exercises
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// Calculator is the calculator bound to a page.
+type Calculator struct {
+	shape, a, b, bField, aLabel, bLabel, result, errorText js.Value
+}
+
+// Bind finds the calculator's elements in doc, the page's document, and
+// adds its event listeners.
+func Bind(doc js.Value) (*Calculator, error) {
+	c := &Calculator{}
+	elements := map[string]*js.Value{
+		"shape": &c.shape, "a": &c.a, "b": &c.b, "b-field": &c.bField,
+		"a-label": &c.aLabel, "b-label": &c.bLabel, "result": &c.result, "error": &c.errorText,
+	}
+	for id, v := range elements {
+		*v = doc.Call("getElementById", id)
+		if v.IsNull() {
+			return nil, fmt.Errorf("the page has no #%s", id)
+		}
+	}
+	form, clear := doc.Call("getElementById", "calculator"), doc.Call("getElementById", "clear")
+	if form.IsNull() || clear.IsNull() {
+		return nil, fmt.Errorf("the page has no #calculator form or #clear button")
+	}
+
+	// BUG: Nothing listens for the shape select's "change" event, so
+	// picking another shape keeps the circle's fields. Call showFields and
+	// update when it changes.
+	listen(c.a, "input", func(js.Value) { c.update() })
+	// BUG: Only the first length updates the result as it is typed. The
+	// second input needs an "input" listener too.
+	listen(form, "submit", func(event js.Value) {
+		// BUG: Pressing Enter or Calculate submits the form, and the
+		// browser leaves the page, and the program with it. Call
+		// preventDefault on the event.
+		c.update()
+	})
+	// BUG: Clear is wired to the wrong element: nobody clicks #result.
+	listen(c.result, "click", func(js.Value) { c.clear() })
+	c.showFields()
+	return c, nil
+}
+
+// listen adds a Go function as a listener for an event. The page keeps it
+// for as long as the program runs, so it is never released.
+func listen(target js.Value, event string, handle func(event js.Value)) {
+	target.Call("addEventListener", event, js.FuncOf(func(this js.Value, args []js.Value) any {
+		handle(args[0])
+		return nil
+	}))
+}
+
+// showFields labels the inputs for the selected shape, and hides the
+// second one if the shape needs only one length.
+func (c *Calculator) showFields() {
+	labels, err := Dimensions(c.shape.Get("value").String())
+	if err != nil {
+		c.showError(err)
+		return
+	}
+	c.aLabel.Set("textContent", labels[0])
+	c.bField.Set("hidden", len(labels) < 2)
+	if len(labels) > 1 {
+		c.bLabel.Set("textContent", labels[1])
+	}
+}
+
+// update shows the area of the shape typed in so far, or why there is
+// none. Until the first length is typed, it shows nothing.
+func (c *Calculator) update() {
+	a, b := c.a.Get("value").String(), c.b.Get("value").String()
+	if a == "" {
+		c.result.Set("textContent", "")
+		c.errorText.Set("hidden", true)
+		return
+	}
+	s, err := NewShape(c.shape.Get("value").String(), a, b)
+	if err != nil {
+		c.showError(err)
+		return
+	}
+	c.result.Set("textContent", Describe(s))
+	c.errorText.Set("hidden", true)
+}
+
+func (c *Calculator) showError(err error) {
+	c.result.Set("textContent", "")
+	c.errorText.Set("textContent", err.Error())
+	c.errorText.Set("hidden", false)
+}
+
+func (c *Calculator) clear() {
+	c.a.Set("value", "")
+	c.b.Set("value", "")
+	c.update()
+}
+
+// Run binds the calculator to the page, shows "Ready" in #status, and
+// keeps the program running for the listeners.
+func Run() {
+	doc := js.Global().Get("document")
+	status := doc.Call("getElementById", "status")
+	if _, err := Bind(doc); err != nil {
+		status.Set("textContent", err.Error())
+		return
+	}
+	status.Set("textContent", "Ready")
+	// BUG: When main returns, the program ends, and every listener fails
+	// with "Go program has already exited". Block forever with an empty
+	// select.
+}
@@ -0,0 +1,135 @@
+package exercises
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/headless"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewShape(t *testing.T) {
+	// The bugs are in the page, which only the page tests check. Skipping
+	// with them keeps this test from passing the exercise on its own.
+	if testing.Short() {
+		t.Skip("builds and runs WebAssembly")
+	}
+	s, err := NewShape("circle", "1")
+	require.NoError(t, err)
+	assert.Equal(t, "Area: 3.14, perimeter: 6.28", Describe(s))
+	s, err = NewShape("triangle", "6", "8")
+	require.NoError(t, err)
+	assert.Equal(t, "Area: 24.00, perimeter: 24.00", Describe(s))
+
+	_, err = NewShape("square", "1")
+	assert.ErrorIs(t, err, ErrUnknownShape)
+	_, err = NewShape("rectangle", "2", "-3")
+	assert.EqualError(t, err, "Height must be a number, 0 or more")
+}
+
+// calculator is the calculator page, with helpers that fail the test
+// instead of returning errors.
+type calculator struct {
+	*headless.Page
+	t *testing.T
+}
+
+func loadCalculator(t *testing.T) calculator {
+	c := calculator{headless.Load(t, "calculator"), t}
+	require.Equal(t, "Ready", c.element("status").Text, "the page did not start")
+	return c
+}
+
+func (c calculator) element(id string) headless.Element {
+	c.t.Helper()
+	e, err := c.Element(id)
+	require.NoError(c.t, err)
+	return e
+}
+
+func (c calculator) set(id, value string) {
+	c.t.Helper()
+	require.NoError(c.t, c.Set(id, value), "setting #%s to %q", id, value)
+}
+
+func TestPageKeepsRunning(t *testing.T) {
+	c := loadCalculator(t)
+	assert.Empty(t, c.Exited(), "the page's program must keep running")
+	assert.NoError(t, c.Set("a", "1"), "the listeners must work after main has set up the page")
+}
+
+func TestPageShapes(t *testing.T) {
+	c := loadCalculator(t)
+	assert.True(t, c.element("shape").Listens("change"), "#shape has no change listener")
+	assert.Equal(t, "Radius", c.element("a-label").Text)
+	assert.True(t, c.element("b-field").Hidden)
+
+	for _, shape := range []string{"rectangle", "triangle", "circle", "triangle"} {
+		labels, err := Dimensions(shape)
+		require.NoError(t, err)
+		c.set("shape", shape)
+		assert.Equal(t, labels[0], c.element("a-label").Text, "first label for a %s", shape)
+		assert.Equal(t, len(labels) < 2, c.element("b-field").Hidden, "second field hidden for a %s", shape)
+		if len(labels) > 1 {
+			assert.Equal(t, labels[1], c.element("b-label").Text, "second label for a %s", shape)
+		}
+	}
+}
+
+func TestPageUpdatesAsYouType(t *testing.T) {
+	c := loadCalculator(t)
+	r := seed.Rand(t, 1)
+	a, b := fmt.Sprint(seed.Between(r, 2, 40)), fmt.Sprint(seed.Between(r, 2, 40))
+	want := func(shape string, lengths ...string) string {
+		s, err := NewShape(shape, lengths...)
+		require.NoError(t, err)
+		return Describe(s)
+	}
+
+	c.set("a", a)
+	assert.Equal(t, want("circle", a), c.element("result").Text)
+	c.set("shape", "rectangle")
+	c.set("b", b)
+	assert.Equal(t, want("rectangle", a, b), c.element("result").Text, "after typing the height")
+	c.set("shape", "triangle")
+	assert.Equal(t, want("triangle", a, b), c.element("result").Text, "after picking another shape")
+	c.set("b", a)
+	assert.Equal(t, want("triangle", a, a), c.element("result").Text, "after changing the height")
+}
+
+func TestPageErrors(t *testing.T) {
+	c := loadCalculator(t)
+	c.set("a", "abc")
+	assert.False(t, c.element("error").Hidden)
+	assert.Equal(t, "Radius must be a number, 0 or more", c.element("error").Text)
+	assert.Empty(t, c.element("result").Text)
+	c.set("a", "3")
+	assert.True(t, c.element("error").Hidden, "a fixed length clears the error")
+
+	c.set("shape", "rectangle")
+	c.set("b", "-1")
+	assert.Equal(t, "Height must be a number, 0 or more", c.element("error").Text)
+	assert.False(t, c.element("error").Hidden)
+}
+
+func TestPageSubmit(t *testing.T) {
+	c := loadCalculator(t)
+	c.set("a", "2")
+	require.NoError(t, c.Submit("calculator"), "pressing Enter or Calculate")
+	assert.Empty(t, c.Exited())
+	assert.Equal(t, "Area: 12.57, perimeter: 12.57", c.element("result").Text)
+}
+
+func TestPageClear(t *testing.T) {
+	c := loadCalculator(t)
+	c.set("shape", "rectangle")
+	c.set("a", "2")
+	c.set("b", "5")
+	require.NoError(t, c.Click("clear"))
+	assert.Empty(t, c.element("a").Value)
+	assert.Empty(t, c.element("b").Value)
+	assert.Empty(t, c.element("result").Text)
+	assert.True(t, c.element("error").Hidden)
+}
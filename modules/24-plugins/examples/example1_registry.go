@@ -0,0 +1,122 @@
+// Package examples demonstrates three ways to let others add to a Go
+// program: registration, the plugin package, and plugins in processes of
+// their own.
+//
+// A Go binary is linked once, so the usual extension point is an
+// interface and a registry that packages add themselves to from init,
+// the way database/sql drivers and image formats do. The new code is
+// compiled in: nothing is loaded at run time. When that is not enough,
+// plugin.Open loads a shared object built with -buildmode=plugin into the
+// running program, on Linux, macOS, and FreeBSD, with cgo, and only from
+// the exact same build of every package the two share. The third way
+// runs the extension as a separate program and talks to it over a pipe,
+// as Terraform and HashiCorp's go-plugin do: slower, but it works
+// everywhere, with any Go version or even any language, and a crash stays
+// in its own process.
+//
+// This file shows:
+// - A registry of named constructors, safe to use from many goroutines
+// - Registering from init, and panicking on a duplicate like sql.Register
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// Factory makes a shape from its dimensions.
+type Factory func(dims ...float64) (geometry.Shape, error)
+
+// Errors returned by a Registry.
+var (
+	ErrDuplicate    = errors.New("shape already registered")
+	ErrUnknownShape = errors.New("unknown shape")
+)
+
+// Registry maps shape names to their factories.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]Factory)}
+}
+
+// Register adds a factory under name, which must not be taken yet.
+func (r *Registry) Register(name string, f Factory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.factories[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicate, name)
+	}
+	r.factories[name] = f
+	return nil
+}
+
+// New makes a shape with the factory registered under name.
+func (r *Registry) New(name string, dims ...float64) (geometry.Shape, error) {
+	r.mu.RLock()
+	f, ok := r.factories[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownShape, name)
+	}
+	return f(dims...)
+}
+
+// Names returns the registered names, sorted.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Default is the registry that Register adds to.
+var Default = NewRegistry()
+
+// Register adds a factory to Default. It is meant for init functions, so
+// it panics on a duplicate name rather than returning an error that no
+// one could handle.
+func Register(name string, f Factory) {
+	if err := Default.Register(name, f); err != nil {
+		panic(err)
+	}
+}
+
+func init() {
+	Register("circle", func(dims ...float64) (geometry.Shape, error) {
+		if len(dims) != 1 {
+			return nil, fmt.Errorf("a circle takes a radius, got %d numbers", len(dims))
+		}
+		return geometry.Circle{Radius: dims[0]}, nil
+	})
+	Register("rectangle", func(dims ...float64) (geometry.Shape, error) {
+		if len(dims) != 2 {
+			return nil, fmt.Errorf("a rectangle takes a width and a height, got %d numbers", len(dims))
+		}
+		return geometry.Rectangle{Width: dims[0], Height: dims[1]}, nil
+	})
+}
+
+// DemonstrateRegistry makes shapes by name, and shows what a second
+// registration of a name does.
+func DemonstrateRegistry() {
+	fmt.Println("registered:", Default.Names())
+	for _, name := range []string{"circle", "rectangle", "hexagon"} {
+		s, err := Default.New(name, 2, 3)
+		fmt.Println(name, s, err)
+	}
+	defer func() { fmt.Println("recovered:", recover()) }()
+	Register("circle", nil)
+}
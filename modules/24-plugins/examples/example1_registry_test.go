@@ -0,0 +1,41 @@
+package examples
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateRegistry(t *testing.T) {
+	DemonstrateRegistry()
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register("square", func(dims ...float64) (geometry.Shape, error) {
+		return geometry.Rectangle{Width: dims[0], Height: dims[0]}, nil
+	}))
+	assert.ErrorIs(t, r.Register("square", nil), ErrDuplicate)
+
+	s, err := r.New("square", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 9.0, s.Area())
+	_, err = r.New("circle", 1)
+	assert.ErrorIs(t, err, ErrUnknownShape, "only Default has the built-in shapes")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.Names()
+			r.New("square", 1)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, []string{"circle", "rectangle"}, Default.Names())
+	assert.Panics(t, func() { Register("rectangle", nil) })
+}
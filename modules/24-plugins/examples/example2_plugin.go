@@ -0,0 +1,118 @@
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"sync"
+)
+
+// PluginExamples demonstrates loading a shape into the running program
+// with the plugin package.
+//
+// plugins/hexagon is a main package built with go build
+// -buildmode=plugin. Opening it runs its init functions, and Lookup finds
+// its exported variables and functions by name: a variable comes back as
+// a pointer to it. The plugin and the program must be built by the same
+// Go version, with the same flags (-race included), from the same source
+// of every package they share. That is why the plugin's Shapes map uses
+// an unnamed function type and geometry.Shape, and not this package's
+// Factory: a test binary compiles this package with its tests, so the
+// plugin's copy of it would never match. A plugin is also opened only
+// once per process, and never unloaded: opening it again from the same
+// path returns it again, and from another path fails with "plugin already
+// loaded".
+//
+// PluginsSupported is false where the plugin package does not work: on
+// Windows, without cgo, and on most other platforms. The files
+// example2_plugin_dlopen.go and example2_plugin_other.go provide the two
+// versions of openPlugin.
+
+// PluginsSupported reports whether this build can load plugins.
+const PluginsSupported = pluginsSupported
+
+// ErrPluginsUnsupported is returned by LoadPlugin where PluginsSupported
+// is false.
+var ErrPluginsUnsupported = errors.New("plugins are not supported on this platform")
+
+// PluginSymbol is the name of the variable a shape plugin exports: a
+// map[string]func(dims ...float64) (geometry.Shape, error).
+const PluginSymbol = "Shapes"
+
+// LoadPlugin opens the plugin at path and registers its shapes in r. It
+// returns the names it added.
+func LoadPlugin(path string, r *Registry) ([]string, error) {
+	shapes, err := openPlugin(path)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for name, f := range shapes {
+		if err := r.Register(name, f); err != nil {
+			return names, fmt.Errorf("plugin %s: %w", path, err)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// BuildPlugin builds the plugin in dir into out with go build
+// -buildmode=plugin, and with -race if the running program has it.
+func BuildPlugin(dir, out string) error {
+	args := []string{"build", "-buildmode=plugin", "-o", out}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			if s.Key == "-race" && s.Value == "true" {
+				args = append(args, "-race")
+			}
+		}
+	}
+	cmd := exec.Command("go", append(args, ".")...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("go build -buildmode=plugin: %w\n%s", err, output)
+	}
+	return nil
+}
+
+// hexagon builds plugins/hexagon and loads it into a new registry the
+// first time it is called, and returns the same registry after that. The
+// file can go once it is open: the program keeps it mapped.
+var hexagon = sync.OnceValues(func() (*Registry, error) {
+	if !PluginsSupported {
+		return nil, ErrPluginsUnsupported
+	}
+	tmp, err := os.MkdirTemp("", "learngo-plugin-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	so := filepath.Join(tmp, "hexagon.so")
+	if err := BuildPlugin(filepath.Join("plugins", "hexagon"), so); err != nil {
+		return nil, err
+	}
+	r := NewRegistry()
+	if _, err := LoadPlugin(so, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+})
+
+// DemonstratePlugin builds plugins/hexagon and uses the shape it adds
+// while the program runs.
+func DemonstratePlugin() {
+	r, err := hexagon()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("loaded:", r.Names())
+	if s, err := r.New("hexagon", 2); err == nil {
+		fmt.Printf("%v: area %.2f, perimeter %.2f\n", s, s.Area(), s.Perimeter())
+	}
+}
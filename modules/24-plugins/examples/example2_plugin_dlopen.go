@@ -0,0 +1,28 @@
+//go:build cgo && (linux || darwin || freebsd)
+
+package examples
+
+import (
+	"fmt"
+	"plugin"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+const pluginsSupported = true
+
+func openPlugin(path string) (map[string]func(dims ...float64) (geometry.Shape, error), error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	sym, err := p.Lookup(PluginSymbol)
+	if err != nil {
+		return nil, err
+	}
+	shapes, ok := sym.(*map[string]func(dims ...float64) (geometry.Shape, error))
+	if !ok {
+		return nil, fmt.Errorf("plugin %s: %s is a %T, not a map of shape factories", path, PluginSymbol, sym)
+	}
+	return *shapes, nil
+}
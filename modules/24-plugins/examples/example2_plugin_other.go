@@ -0,0 +1,11 @@
+//go:build !cgo || !(linux || darwin || freebsd)
+
+package examples
+
+import "github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+
+const pluginsSupported = false
+
+func openPlugin(string) (map[string]func(dims ...float64) (geometry.Shape, error), error) {
+	return nil, ErrPluginsUnsupported
+}
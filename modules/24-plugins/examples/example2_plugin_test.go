@@ -0,0 +1,43 @@
+package examples
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstratePlugin(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go build")
+	}
+	DemonstratePlugin()
+}
+
+func TestLoadPlugin(t *testing.T) {
+	_, err := LoadPlugin(filepath.Join(t.TempDir(), "missing.so"), NewRegistry())
+	assert.Error(t, err)
+	if !PluginsSupported {
+		assert.ErrorIs(t, err, ErrPluginsUnsupported)
+		return
+	}
+	if testing.Short() {
+		t.Skip("runs go build")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+
+	r, err := hexagon()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hexagon"}, r.Names())
+	s, err := r.New("hexagon", 2)
+	require.NoError(t, err)
+	assert.InDelta(t, 10.392, s.Area(), 0.001)
+	assert.Equal(t, 12.0, s.Perimeter())
+	assert.Equal(t, "Hexagon(2)", s.(interface{ String() string }).String())
+	_, err = r.New("hexagon")
+	assert.EqualError(t, err, "a hexagon takes a side, got 0 numbers")
+}
@@ -0,0 +1,170 @@
+package examples
+
+import (
+	"fmt"
+	"io"
+	"net/rpc"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// RPCExamples demonstrates a plugin that is a program of its own.
+//
+// The program starts the plugin with os/exec and speaks net/rpc with it
+// over the plugin's stdin and stdout, leaving stderr for its logs. The
+// plugin can be built by any Go version, for any platform the program
+// runs on, and a panic in it ends only its own process. The price is a
+// copy of every argument and result, a process per plugin, and values
+// that cannot cross the pipe: the program gets the numbers of a shape,
+// not the plugin's type. A real protocol would also start with a
+// handshake, as exercise 2 adds.
+
+// NewArgs are the arguments of the Shapes.New call.
+type NewArgs struct {
+	Name string
+	Dims []float64
+}
+
+// Measurement is what a plugin sends back for a shape.
+type Measurement struct {
+	Desc            string // fmt.Sprint of the plugin's shape
+	Area, Perimeter float64
+}
+
+// Measured is a shape known only by its Measurement.
+type Measured struct {
+	m Measurement
+}
+
+// Area implements geometry.Shape.
+func (s Measured) Area() float64 { return s.m.Area }
+
+// Perimeter implements geometry.Shape.
+func (s Measured) Perimeter() float64 { return s.m.Perimeter }
+
+// String implements fmt.Stringer.
+func (s Measured) String() string { return s.m.Desc }
+
+// ShapeService is the plugin's side: the methods of a Registry, in the
+// form net/rpc serves.
+type ShapeService struct {
+	r *Registry
+}
+
+// Names returns the names of the plugin's shapes.
+func (s *ShapeService) Names(_ struct{}, names *[]string) error {
+	*names = s.r.Names()
+	return nil
+}
+
+// New makes a shape and measures it.
+func (s *ShapeService) New(args NewArgs, m *Measurement) error {
+	shape, err := s.r.New(args.Name, args.Dims...)
+	if err != nil {
+		return err
+	}
+	*m = Measurement{Desc: fmt.Sprint(shape), Area: shape.Area(), Perimeter: shape.Perimeter()}
+	return nil
+}
+
+// stdio joins a plugin's two pipes into the connection net/rpc needs.
+type stdio struct {
+	io.Reader
+	io.WriteCloser
+}
+
+// ServeRPC serves r's shapes as "Shapes" on in and out, until in ends.
+// A plugin's main calls it with os.Stdin and os.Stdout.
+func ServeRPC(r *Registry, in io.Reader, out io.WriteCloser) error {
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("Shapes", &ShapeService{r}); err != nil {
+		return err
+	}
+	srv.ServeConn(stdio{in, out})
+	return nil
+}
+
+// RPCPlugin is a running plugin program.
+type RPCPlugin struct {
+	cmd    *exec.Cmd
+	client *rpc.Client
+}
+
+// StartRPC starts cmd, which must call ServeRPC, and connects to it. The
+// plugin's stderr is the program's, unless cmd.Stderr says otherwise.
+func StartRPC(cmd *exec.Cmd) (*RPCPlugin, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &RPCPlugin{cmd: cmd, client: rpc.NewClient(stdio{stdout, stdin})}, nil
+}
+
+// Names returns the names of the plugin's shapes.
+func (p *RPCPlugin) Names() ([]string, error) {
+	var names []string
+	err := p.client.Call("Shapes.Names", struct{}{}, &names)
+	return names, err
+}
+
+// New asks the plugin for a shape.
+func (p *RPCPlugin) New(name string, dims ...float64) (geometry.Shape, error) {
+	var m Measurement
+	if err := p.client.Call("Shapes.New", NewArgs{name, dims}, &m); err != nil {
+		return nil, err
+	}
+	return Measured{m}, nil
+}
+
+// Close closes the plugin's stdin, which ends ServeRPC, and waits for the
+// plugin to exit.
+func (p *RPCPlugin) Close() error {
+	p.client.Close()
+	return p.cmd.Wait()
+}
+
+// DemonstrateRPC builds plugins/octagon and uses its shape from another
+// process.
+func DemonstrateRPC() {
+	tmp, err := os.MkdirTemp("", "learngo-rpc-")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(tmp)
+	bin := filepath.Join(tmp, "octagon")
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = filepath.Join("plugins", "octagon")
+	if out, err := build.CombinedOutput(); err != nil {
+		fmt.Printf("go build: %v\n%s", err, out)
+		return
+	}
+
+	p, err := StartRPC(exec.Command(bin))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	names, err := p.Names()
+	fmt.Println("the plugin has:", names, err)
+	s, err := p.New("octagon", 1)
+	if err == nil {
+		fmt.Printf("%v: area %.2f, perimeter %.2f\n", s, s.Area(), s.Perimeter())
+	}
+	_, err = p.New("octagon")
+	fmt.Println("error from the plugin:", err)
+	fmt.Println("closed:", p.Close())
+}
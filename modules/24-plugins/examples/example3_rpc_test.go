@@ -0,0 +1,52 @@
+package examples
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateRPC(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go build")
+	}
+	DemonstrateRPC()
+}
+
+func TestRPCPlugin(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go build")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	bin := filepath.Join(t.TempDir(), "octagon")
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = filepath.Join("plugins", "octagon")
+	out, err := build.CombinedOutput()
+	require.NoError(t, err, "%s", out)
+
+	var logs bytes.Buffer
+	cmd := exec.Command(bin)
+	cmd.Stderr = &logs
+	p, err := StartRPC(cmd)
+	require.NoError(t, err)
+	names, err := p.Names()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"octagon"}, names)
+
+	s, err := p.New("octagon", 1)
+	require.NoError(t, err)
+	assert.InDelta(t, 4.828, s.Area(), 0.001)
+	assert.Equal(t, 8.0, s.Perimeter())
+	assert.Equal(t, "Octagon(1)", s.(Measured).String())
+
+	_, err = p.New("circle", 1)
+	assert.EqualError(t, err, "unknown shape: circle", "errors come back as text")
+	assert.NoError(t, p.Close())
+	assert.True(t, cmd.ProcessState.Success())
+}
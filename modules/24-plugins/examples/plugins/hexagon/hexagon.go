@@ -0,0 +1,40 @@
+// Command hexagon is a plugin that adds a regular hexagon to the shapes of
+// a program, for example2_plugin.go. Build it with
+//
+//	go build -buildmode=plugin -o hexagon.so
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// Hexagon is a regular hexagon.
+type Hexagon struct {
+	Side float64
+}
+
+// Area returns (3√3/2)s².
+func (h Hexagon) Area() float64 { return 3 * math.Sqrt(3) / 2 * h.Side * h.Side }
+
+// Perimeter returns 6s.
+func (h Hexagon) Perimeter() float64 { return 6 * h.Side }
+
+// String implements fmt.Stringer.
+func (h Hexagon) String() string { return fmt.Sprintf("Hexagon(%g)", h.Side) }
+
+// Shapes is what the program looks up after opening the plugin.
+var Shapes = map[string]func(dims ...float64) (geometry.Shape, error){
+	"hexagon": func(dims ...float64) (geometry.Shape, error) {
+		if len(dims) != 1 {
+			return nil, fmt.Errorf("a hexagon takes a side, got %d numbers", len(dims))
+		}
+		return Hexagon{Side: dims[0]}, nil
+	},
+}
+
+// main never runs: opening a plugin only runs its init functions. The go
+// command builds plugins from main packages alone.
+func main() {}
@@ -0,0 +1,42 @@
+// Command octagon is a plugin program that serves a regular octagon, for
+// example3_rpc.go. It logs to stderr, and speaks net/rpc on stdin and
+// stdout.
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/24-plugins/examples"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// Octagon is a regular octagon.
+type Octagon struct {
+	Side float64
+}
+
+// Area returns 2(1+√2)s².
+func (o Octagon) Area() float64 { return 2 * (1 + math.Sqrt2) * o.Side * o.Side }
+
+// Perimeter returns 8s.
+func (o Octagon) Perimeter() float64 { return 8 * o.Side }
+
+// String implements fmt.Stringer.
+func (o Octagon) String() string { return fmt.Sprintf("Octagon(%g)", o.Side) }
+
+func main() {
+	log.SetPrefix("octagon: ")
+	r := examples.NewRegistry()
+	r.Register("octagon", func(dims ...float64) (geometry.Shape, error) {
+		if len(dims) != 1 {
+			return nil, fmt.Errorf("an octagon takes a side, got %d numbers", len(dims))
+		}
+		return Octagon{Side: dims[0]}, nil
+	})
+	if err := examples.ServeRPC(r, os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,92 @@
+package exercises
+
+// EXERCISE: Fix a registry that lets packages add shapes to a program.
+// This is the registration model of the module: packages register
+// factories by name, usually from init, and the program makes shapes by
+// name without knowing where they came from, like database/sql and its
+// drivers. The registry loses registrations, cannot find names written
+// another way, hides which errors mean an unknown name, and locks up when
+// a factory builds its shape from another registered one.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// Factory makes a shape from its dimensions.
+type Factory func(dims ...float64) (geometry.Shape, error)
+
+// Errors returned by a Registry.
+var (
+	ErrInvalid      = errors.New("invalid registration")
+	ErrDuplicate    = errors.New("shape already registered")
+	ErrUnknownShape = errors.New("unknown shape")
+)
+
+// Registry maps shape names to their factories. It is safe for use by
+// many goroutines, and factories may use the registry themselves.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]Factory)}
+}
+
+// key is how a registry stores a name: shape names ignore case and
+// surrounding spaces, so " Circle" and "circle" are the same shape.
+func key(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Register adds a factory under name. An empty name or a nil factory is
+// ErrInvalid, and a name already taken is ErrDuplicate.
+func (r *Registry) Register(name string, f Factory) error {
+	k := key(name)
+	// BUG: An empty name or a nil factory is accepted, and fails much
+	// later. Return ErrInvalid for them.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// BUG: A second registration of a name replaces the first without a
+	// word. Return ErrDuplicate instead.
+	r.factories[k] = f
+	return nil
+}
+
+// New makes a shape with the factory registered under name. An unknown
+// name is ErrUnknownShape.
+func (r *Registry) New(name string, dims ...float64) (geometry.Shape, error) {
+	// BUG: The name is looked up as given, but Register stored it with
+	// key.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, ok := r.factories[name]
+	if !ok {
+		// BUG: errors.Is cannot tell this error from a factory's. Wrap
+		// ErrUnknownShape.
+		return nil, fmt.Errorf("no shape called %s", name)
+	}
+	// BUG: The factory runs with the lock held, so a factory that calls
+	// New itself waits for the lock forever. Only hold the lock to read
+	// the map.
+	return f(dims...)
+}
+
+// Names returns the registered names, sorted.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	// BUG: Map order is random. Sort the names.
+	return names
+}
@@ -0,0 +1,122 @@
+package exercises
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func circle(dims ...float64) (geometry.Shape, error) {
+	if len(dims) != 1 {
+		return nil, fmt.Errorf("a circle takes a radius, got %d numbers", len(dims))
+	}
+	return geometry.Circle{Radius: dims[0]}, nil
+}
+
+func rectangle(dims ...float64) (geometry.Shape, error) {
+	if len(dims) != 2 {
+		return nil, fmt.Errorf("a rectangle takes a width and a height, got %d numbers", len(dims))
+	}
+	return geometry.Rectangle{Width: dims[0], Height: dims[1]}, nil
+}
+
+func TestRegister(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register("circle", circle))
+	require.NoError(t, r.Register("rectangle", rectangle))
+
+	err := r.Register("circle", rectangle)
+	assert.ErrorIs(t, err, ErrDuplicate)
+	assert.ErrorIs(t, r.Register(" CIRCLE ", rectangle), ErrDuplicate, "names ignore case and spaces")
+
+	radius := seed.Between(seed.Rand(t, 1), 1, 20)
+	s, err := r.New("circle", float64(radius))
+	require.NoError(t, err)
+	assert.Equal(t, geometry.Circle{Radius: float64(radius)}, s, "the first registration stays")
+}
+
+func TestRegisterInvalid(t *testing.T) {
+	r := NewRegistry()
+	assert.ErrorIs(t, r.Register("", circle), ErrInvalid)
+	assert.ErrorIs(t, r.Register("  ", circle), ErrInvalid)
+	assert.ErrorIs(t, r.Register("circle", nil), ErrInvalid)
+	assert.Empty(t, r.Names())
+}
+
+func TestNew(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register("Rectangle", rectangle))
+	w, h := seed.Between(seed.Rand(t, 2), 1, 20), seed.Between(seed.Rand(t, 3), 1, 20)
+
+	for _, name := range []string{"rectangle", "Rectangle", " RECTANGLE\t"} {
+		s, err := r.New(name, float64(w), float64(h))
+		if assert.NoError(t, err, "New(%q)", name) {
+			assert.Equal(t, float64(w*h), s.Area())
+		}
+	}
+	_, err := r.New("rectangle", 1)
+	assert.EqualError(t, err, "a rectangle takes a width and a height, got 1 numbers", "factory errors come through")
+}
+
+func TestNewUnknown(t *testing.T) {
+	r := NewRegistry()
+	s, err := r.New("hexagon", 1)
+	assert.Nil(t, s)
+	assert.ErrorIs(t, err, ErrUnknownShape)
+	assert.ErrorContains(t, err, "hexagon")
+}
+
+func TestFactoryUsesRegistry(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register("rectangle", rectangle))
+	require.NoError(t, r.Register("square", func(dims ...float64) (geometry.Shape, error) {
+		if len(dims) != 1 {
+			return nil, fmt.Errorf("a square takes a side, got %d numbers", len(dims))
+		}
+		return r.New("rectangle", dims[0], dims[0])
+	}))
+
+	done := make(chan geometry.Shape, 1)
+	go func() {
+		s, _ := r.New("square", 3)
+		done <- s
+	}()
+	select {
+	case s := <-done:
+		require.NotNil(t, s)
+		assert.Equal(t, 9.0, s.Area())
+	case <-time.After(5 * time.Second):
+		t.Fatal("New deadlocked when the factory called New")
+	}
+}
+
+func TestNames(t *testing.T) {
+	r := NewRegistry()
+	names := []string{"triangle", "circle", "rectangle", "hexagon", "ellipse", "square", "octagon"}
+	for _, name := range names {
+		require.NoError(t, r.Register(name, circle))
+	}
+	assert.Equal(t, []string{"circle", "ellipse", "hexagon", "octagon", "rectangle", "square", "triangle"}, r.Names())
+}
+
+func TestConcurrentUse(t *testing.T) {
+	r := NewRegistry()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r.Register(fmt.Sprintf("circle%d", i), circle)
+			r.New(fmt.Sprintf("circle%d", i), 1)
+			r.Names()
+		}(i)
+	}
+	wg.Wait()
+	assert.Len(t, r.Names(), 8)
+}
@@ -0,0 +1,230 @@
+package exercises
+
+// EXERCISE: Fix the host of plugins that run as programs of their own.
+// This is the subprocess model of the module, the one HashiCorp's
+// go-plugin uses: the host starts the plugin, the plugin writes a
+// handshake line on stdout, and from then on the two speak net/rpc over
+// the plugin's stdin and stdout. Serve, the plugin's side, works. The
+// host loses the plugin's logs, talks to plugins of any version, leaves
+// plugins running when their handshake fails, cannot say why a call
+// failed when the plugin died, and does not wait for the plugin it closes.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/rpc"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// Handshake is the first thing a plugin writes on stdout, before any RPC:
+// the protocol name and version, on one line.
+const (
+	Handshake       = "LEARNGO-PLUGIN"
+	ProtocolVersion = 1
+)
+
+// HandshakeTimeout is how long Start waits for a plugin's handshake.
+var HandshakeTimeout = 5 * time.Second
+
+// Errors returned by Start and by the methods of Plugin.
+var (
+	ErrHandshake    = errors.New("plugin handshake failed")
+	ErrIncompatible = errors.New("plugin speaks another protocol version")
+	ErrExited       = errors.New("plugin exited")
+)
+
+// NewArgs asks a plugin for a shape.
+type NewArgs struct {
+	Name string
+	Dims []float64
+}
+
+// Measurement is what the host gets back for a shape: the shape itself
+// stays in the plugin.
+type Measurement struct {
+	Desc      string
+	Area      float64
+	Perimeter float64
+}
+
+// shapeService is the RPC service a plugin serves, as "Shapes".
+type shapeService struct {
+	shapes map[string]func(dims ...float64) (geometry.Shape, error)
+}
+
+func (s *shapeService) Names(_ struct{}, names *[]string) error {
+	for name := range s.shapes {
+		*names = append(*names, name)
+	}
+	return nil
+}
+
+func (s *shapeService) New(args NewArgs, m *Measurement) error {
+	f, ok := s.shapes[args.Name]
+	if !ok {
+		return fmt.Errorf("unknown shape %q", args.Name)
+	}
+	shape, err := f(args.Dims...)
+	if err != nil {
+		return err
+	}
+	*m = Measurement{Desc: fmt.Sprint(shape), Area: shape.Area(), Perimeter: shape.Perimeter()}
+	return nil
+}
+
+// stdio joins a program's two ends of a pipe into the connection net/rpc
+// expects.
+type stdio struct {
+	io.Reader
+	io.WriteCloser
+}
+
+// Serve is the plugin's side: it writes the handshake to out and then
+// serves shapes over net/rpc until in ends.
+func Serve(shapes map[string]func(dims ...float64) (geometry.Shape, error), in io.Reader, out io.WriteCloser) error {
+	if _, err := fmt.Fprintf(out, "%s|%d\n", Handshake, ProtocolVersion); err != nil {
+		return err
+	}
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("Shapes", &shapeService{shapes}); err != nil {
+		return err
+	}
+	srv.ServeConn(stdio{in, out})
+	return nil
+}
+
+// Plugin is a running plugin program.
+type Plugin struct {
+	cmd    *exec.Cmd
+	client *rpc.Client
+	stdout *os.File
+	done   chan struct{} // closed once the plugin has exited
+	err    error         // what cmd.Wait returned, once done is closed
+}
+
+// Start starts cmd as a plugin, with its stderr going to logs, and checks
+// its handshake. When the handshake fails, the plugin is killed and waited
+// for before Start returns.
+func Start(cmd *exec.Cmd, logs io.Writer) (*Plugin, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	// Not StdoutPipe: Wait closes that, and a plugin that exits may still
+	// have written something the host wants to read.
+	stdout, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stdout = w
+	// BUG: The plugin's stderr is discarded. Send it to logs.
+	err = cmd.Start()
+	w.Close()
+	if err != nil {
+		stdout.Close()
+		return nil, err
+	}
+
+	p := &Plugin{cmd: cmd, stdout: stdout, done: make(chan struct{})}
+	go func() {
+		p.err = cmd.Wait()
+		close(p.done)
+	}()
+	r := bufio.NewReader(stdout)
+	if err := p.handshake(r); err != nil {
+		// BUG: The plugin keeps running, and nobody waits for it. Kill
+		// it, and wait until p.done is closed.
+		stdout.Close()
+		return nil, err
+	}
+	p.client = rpc.NewClient(stdio{r, stdin})
+	return p, nil
+}
+
+// handshake reads the handshake line from r, for at most HandshakeTimeout.
+func (p *Plugin) handshake(r *bufio.Reader) error {
+	type result struct {
+		line string
+		err  error
+	}
+	read := make(chan result, 1)
+	go func() {
+		line, err := r.ReadString('\n')
+		read <- result{line, err}
+	}()
+	var res result
+	select {
+	case res = <-read:
+	case <-time.After(HandshakeTimeout):
+		return fmt.Errorf("%w: nothing after %v", ErrHandshake, HandshakeTimeout)
+	}
+	if res.err != nil {
+		return fmt.Errorf("%w: %v", ErrHandshake, res.err)
+	}
+	name, version, ok := strings.Cut(strings.TrimSpace(res.line), "|")
+	if !ok || name != Handshake {
+		return fmt.Errorf("%w: got %q", ErrHandshake, res.line)
+	}
+	// BUG: Any version is accepted, and a plugin of another version
+	// would misread every call. Return ErrIncompatible unless version is
+	// ProtocolVersion.
+	if version == "" {
+		return fmt.Errorf("%w: no version in %q", ErrHandshake, res.line)
+	}
+	return nil
+}
+
+// Names returns the names of the plugin's shapes.
+func (p *Plugin) Names() ([]string, error) {
+	var names []string
+	err := p.client.Call("Shapes.Names", struct{}{}, &names)
+	return names, p.callError(err)
+}
+
+// New asks the plugin for a shape, and returns its measurements.
+func (p *Plugin) New(name string, dims ...float64) (Measurement, error) {
+	var m Measurement
+	err := p.client.Call("Shapes.New", NewArgs{Name: name, Dims: dims}, &m)
+	return m, p.callError(err)
+}
+
+// callError returns err, unless the call failed because the plugin
+// exited: then it returns ErrExited, with the plugin's exit status.
+func (p *Plugin) callError(err error) error {
+	if err == nil {
+		return nil
+	}
+	// An error from the plugin's code: the plugin is fine.
+	if _, ok := err.(rpc.ServerError); ok {
+		return err
+	}
+	// BUG: The caller gets "connection is shut down" or "unexpected EOF"
+	// when the plugin died. Wait up to a second for p.done, since the
+	// connection can break a little before Wait returns, and if it closes,
+	// return exitError instead.
+	return err
+}
+
+func (p *Plugin) exitError() error {
+	if p.err == nil {
+		return ErrExited
+	}
+	return fmt.Errorf("%w: %v", ErrExited, p.err)
+}
+
+// Close closes the plugin's stdin, which ends its Serve, waits for it to
+// exit, and returns its exit error, if any.
+func (p *Plugin) Close() error {
+	p.client.Close()
+	// BUG: The plugin is left a zombie, and its exit status is lost.
+	// Wait until p.done is closed, and return p.err.
+	return p.stdout.Close()
+}
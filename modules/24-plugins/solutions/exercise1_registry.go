@@ -0,0 +1,91 @@
+package solutions
+
+// SOLUTION: Fix a registry that lets packages add shapes to a program.
+// Register rejects empty names, nil factories, and names already taken.
+// New finds names however they are written, wraps ErrUnknownShape, and
+// calls the factory without holding the lock. Names are sorted.
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// Factory makes a shape from its dimensions.
+type Factory func(dims ...float64) (geometry.Shape, error)
+
+// Errors returned by a Registry.
+var (
+	ErrInvalid      = errors.New("invalid registration")
+	ErrDuplicate    = errors.New("shape already registered")
+	ErrUnknownShape = errors.New("unknown shape")
+)
+
+// Registry maps shape names to their factories. It is safe for use by
+// many goroutines, and factories may use the registry themselves.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]Factory)}
+}
+
+// key is how a registry stores a name: shape names ignore case and
+// surrounding spaces, so " Circle" and "circle" are the same shape.
+func key(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Register adds a factory under name. An empty name or a nil factory is
+// ErrInvalid, and a name already taken is ErrDuplicate.
+func (r *Registry) Register(name string, f Factory) error {
+	k := key(name)
+	// Fixed: Reject registrations that could never work.
+	if k == "" || f == nil {
+		return fmt.Errorf("%w: name %q, factory %v", ErrInvalid, name, f != nil)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Fixed: Keep the first registration of a name.
+	if _, ok := r.factories[k]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicate, k)
+	}
+	r.factories[k] = f
+	return nil
+}
+
+// New makes a shape with the factory registered under name. An unknown
+// name is ErrUnknownShape.
+func (r *Registry) New(name string, dims ...float64) (geometry.Shape, error) {
+	// Fixed: Look the name up the way Register stored it.
+	k := key(name)
+	r.mu.RLock()
+	f, ok := r.factories[k]
+	r.mu.RUnlock()
+	// Fixed: Wrap ErrUnknownShape, so errors.Is finds it.
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownShape, k)
+	}
+	// Fixed: Call the factory without the lock; it may use the registry.
+	return f(dims...)
+}
+
+// Names returns the registered names, sorted.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	// Fixed: Map order is random; sort.
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,203 @@
+package solutions
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pluginArg, as the first argument, makes the test binary act as a plugin
+// instead of running the tests, so the tests have plugins to start that
+// behave the same on every operating system.
+const pluginArg = "learngo-plugin"
+
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == pluginArg {
+		os.Exit(plugin(os.Args[2]))
+	}
+	os.Exit(m.Run())
+}
+
+// Octagon is a regular octagon, the shape the test plugin adds.
+type Octagon struct{ Side float64 }
+
+func (o Octagon) Area() float64      { return 2 * (1 + math.Sqrt2) * o.Side * o.Side }
+func (o Octagon) Perimeter() float64 { return 8 * o.Side }
+func (o Octagon) String() string     { return fmt.Sprintf("Octagon(side=%g)", o.Side) }
+
+// plugin is the plugin the tests start. Its argument says how it behaves:
+//
+//	ok        serve octagons; "boom" makes it exit with status 3
+//	exit4     serve, then exit with status 4 when stdin ends
+//	version2  write the handshake of protocol version 2
+//	garbage   write something that is not a handshake
+//	silent    write nothing
+//
+// Plugins that do not serve wait for stdin to end, or thirty seconds.
+func plugin(mode string) int {
+	fmt.Fprintln(os.Stderr, "plugin starting:", mode)
+	shapes := map[string]func(dims ...float64) (geometry.Shape, error){
+		"octagon": func(dims ...float64) (geometry.Shape, error) {
+			if len(dims) != 1 || dims[0] <= 0 {
+				return nil, fmt.Errorf("an octagon takes one positive side, got %v", dims)
+			}
+			return Octagon{Side: dims[0]}, nil
+		},
+		"boom": func(dims ...float64) (geometry.Shape, error) {
+			os.Exit(3)
+			return nil, nil
+		},
+	}
+	switch mode {
+	case "ok", "exit4":
+		Serve(shapes, os.Stdin, os.Stdout)
+		if mode == "exit4" {
+			return 4
+		}
+		return 0
+	case "version2":
+		fmt.Printf("%s|2\n", Handshake)
+	case "garbage":
+		fmt.Println("hello, I am not a plugin")
+	}
+	ended := make(chan struct{})
+	go func() {
+		os.Stdin.Read(make([]byte, 1))
+		close(ended)
+	}()
+	select {
+	case <-ended:
+	case <-time.After(30 * time.Second):
+	}
+	return 0
+}
+
+// pluginCmd returns a command that runs plugin in mode. It is killed when
+// the test ends, in case the test did not get that far.
+func pluginCmd(t *testing.T, mode string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], pluginArg, mode)
+	t.Cleanup(func() {
+		if cmd.Process != nil {
+			cmd.Process.Kill()
+		}
+	})
+	return cmd
+}
+
+// lockedBuffer is a bytes.Buffer that the plugin's stderr can be copied
+// into while the test reads it.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestStart(t *testing.T) {
+	cmd := pluginCmd(t, "ok")
+	p, err := Start(cmd, &lockedBuffer{})
+	require.NoError(t, err)
+
+	names, err := p.Names()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"octagon", "boom"}, names)
+
+	side := float64(seed.Between(seed.Rand(t, 1), 1, 20))
+	m, err := p.New("octagon", side)
+	require.NoError(t, err)
+	want := Octagon{Side: side}
+	assert.Equal(t, Measurement{Desc: want.String(), Area: want.Area(), Perimeter: want.Perimeter()}, m)
+
+	assert.NoError(t, p.Close())
+}
+
+func TestPluginErrors(t *testing.T) {
+	p, err := Start(pluginCmd(t, "ok"), &lockedBuffer{})
+	require.NoError(t, err)
+	defer p.Close()
+
+	_, err = p.New("octagon", -1)
+	assert.EqualError(t, err, "an octagon takes one positive side, got [-1]")
+	assert.NotErrorIs(t, err, ErrExited)
+	_, err = p.New("circle", 1)
+	assert.EqualError(t, err, `unknown shape "circle"`)
+
+	_, err = p.New("octagon", 2)
+	assert.NoError(t, err, "the plugin still works")
+}
+
+func TestPluginLogs(t *testing.T) {
+	var logs lockedBuffer
+	p, err := Start(pluginCmd(t, "ok"), &logs)
+	require.NoError(t, err)
+	require.NoError(t, p.Close())
+	assert.Equal(t, "plugin starting: ok\n", logs.String())
+}
+
+func TestHandshakeVersion(t *testing.T) {
+	cmd := pluginCmd(t, "version2")
+	p, err := Start(cmd, &lockedBuffer{})
+	assert.Nil(t, p)
+	assert.ErrorIs(t, err, ErrIncompatible)
+	assert.ErrorContains(t, err, "version 2")
+}
+
+func TestHandshakeFailure(t *testing.T) {
+	for _, mode := range []string{"garbage", "silent"} {
+		t.Run(mode, func(t *testing.T) {
+			defer func(d time.Duration) { HandshakeTimeout = d }(HandshakeTimeout)
+			HandshakeTimeout = 500 * time.Millisecond
+
+			cmd := pluginCmd(t, mode)
+			p, err := Start(cmd, &lockedBuffer{})
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, ErrHandshake)
+			assert.NotNil(t, cmd.ProcessState, "Start returned without waiting for the plugin")
+		})
+	}
+}
+
+func TestPluginCrash(t *testing.T) {
+	p, err := Start(pluginCmd(t, "ok"), &lockedBuffer{})
+	require.NoError(t, err)
+
+	_, err = p.New("boom")
+	assert.ErrorIs(t, err, ErrExited)
+	assert.ErrorContains(t, err, "exit status 3")
+
+	_, err = p.Names()
+	assert.ErrorIs(t, err, ErrExited, "every call after the crash")
+	p.Close()
+}
+
+func TestCloseWaits(t *testing.T) {
+	cmd := pluginCmd(t, "exit4")
+	p, err := Start(cmd, &lockedBuffer{})
+	require.NoError(t, err)
+
+	err = p.Close()
+	assert.ErrorContains(t, err, "exit status 4")
+	if assert.NotNil(t, cmd.ProcessState, "Close returned without waiting for the plugin") {
+		assert.Equal(t, 4, cmd.ProcessState.ExitCode())
+	}
+}
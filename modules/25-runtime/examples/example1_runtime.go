@@ -0,0 +1,102 @@
+// Package examples demonstrates what a Go program can learn about itself
+// while it runs, from the runtime and runtime/debug packages.
+//
+// This file shows:
+// - runtime.NumGoroutine, and polling it to find goroutines that never end
+// - GOMAXPROCS and NumCPU, and reading GOMAXPROCS without changing it
+// - runtime.GOOS, GOARCH, and Version
+// - runtime.ReadMemStats and runtime.GC
+package examples
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Stats is a snapshot of the running program.
+type Stats struct {
+	GoVersion  string
+	GOOS       string
+	GOARCH     string
+	NumCPU     int
+	GOMAXPROCS int
+	Goroutines int
+	HeapAlloc  uint64 // bytes of live heap objects, and garbage not yet collected
+	NumGC      uint32 // garbage collections so far
+}
+
+// ReadStats returns a snapshot of the running program.
+func ReadStats() Stats {
+	var mem runtime.MemStats
+	// ReadMemStats stops the world for a moment: fine in a debug page, too
+	// slow to call on every request.
+	runtime.ReadMemStats(&mem)
+	return Stats{
+		GoVersion: runtime.Version(),
+		GOOS:      runtime.GOOS,
+		GOARCH:    runtime.GOARCH,
+		NumCPU:    runtime.NumCPU(),
+		// An argument below 1 reads the setting without changing it.
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		Goroutines: runtime.NumGoroutine(),
+		HeapAlloc:  mem.HeapAlloc,
+		NumGC:      mem.NumGC,
+	}
+}
+
+// WaitForGoroutines waits until at most n goroutines are running, or
+// timeout has passed, and returns the last count. A goroutine takes a
+// moment to end after it is told to, so a leak check polls rather than
+// counting once.
+func WaitForGoroutines(n int, timeout time.Duration) int {
+	deadline := time.Now().Add(timeout)
+	for {
+		count := runtime.NumGoroutine()
+		if count <= n || time.Now().After(deadline) {
+			return count
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// DemonstrateRuntime prints the runtime's view of the program, and counts
+// goroutines as they start and end.
+func DemonstrateRuntime() {
+	s := ReadStats()
+	fmt.Printf("%s on %s/%s, %d CPUs, GOMAXPROCS %d\n", s.GoVersion, s.GOOS, s.GOARCH, s.NumCPU, s.GOMAXPROCS)
+
+	before := runtime.NumGoroutine()
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-stop
+		}()
+	}
+	fmt.Printf("goroutines: %d before, %d with 10 blocked\n", before, runtime.NumGoroutine())
+	close(stop)
+	wg.Wait()
+	// wg.Wait returns when Done has been called, which is a little before
+	// the goroutines are gone.
+	fmt.Printf("goroutines after they end: %d\n", WaitForGoroutines(before, time.Second))
+
+	// GOMAXPROCS returns the old setting, so it can be put back.
+	old := runtime.GOMAXPROCS(1)
+	fmt.Printf("GOMAXPROCS set to %d, was %d\n", runtime.GOMAXPROCS(0), old)
+	runtime.GOMAXPROCS(old)
+
+	garbage := make([][]byte, 0, 100)
+	for i := 0; i < 100; i++ {
+		garbage = append(garbage, make([]byte, 64<<10))
+	}
+	fmt.Printf("heap with 6.4MB allocated: %.1fMB\n", float64(ReadStats().HeapAlloc)/(1<<20))
+	runtime.KeepAlive(garbage)
+	garbage = nil
+	runtime.GC()
+	s = ReadStats()
+	fmt.Printf("heap after runtime.GC: %.1fMB, %d collections so far\n", float64(s.HeapAlloc)/(1<<20), s.NumGC)
+}
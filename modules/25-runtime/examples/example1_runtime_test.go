@@ -0,0 +1,35 @@
+package examples
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateRuntime(t *testing.T) {
+	DemonstrateRuntime()
+}
+
+func TestReadStats(t *testing.T) {
+	s := ReadStats()
+	assert.Equal(t, runtime.Version(), s.GoVersion)
+	assert.Equal(t, runtime.GOOS, s.GOOS)
+	assert.Equal(t, runtime.GOARCH, s.GOARCH)
+	assert.Positive(t, s.NumCPU)
+	assert.Equal(t, runtime.GOMAXPROCS(0), s.GOMAXPROCS, "reading GOMAXPROCS does not change it")
+	assert.GreaterOrEqual(t, s.Goroutines, 1)
+	assert.Positive(t, s.HeapAlloc)
+}
+
+func TestWaitForGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+	stop := make(chan struct{})
+	for i := 0; i < 5; i++ {
+		go func() { <-stop }()
+	}
+	assert.Equal(t, before+5, WaitForGoroutines(before, 50*time.Millisecond), "it gives up after the timeout")
+	close(stop)
+	assert.Equal(t, before, WaitForGoroutines(before, 5*time.Second))
+}
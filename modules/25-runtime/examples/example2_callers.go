@@ -0,0 +1,106 @@
+package examples
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// CallerExamples demonstrates finding out who called a function.
+//
+// runtime.Caller(skip) returns the program counter, file, and line of one
+// frame: 0 is the function calling Caller, 1 its caller, and so on.
+// runtime.Callers fills a slice of program counters for the whole stack,
+// and runtime.CallersFrames turns them into frames, expanding the calls
+// the compiler inlined; runtime.FuncForPC does not, so prefer
+// CallersFrames. debug.Stack formats the stack of the calling goroutine
+// as a panic would print it, and runtime.Stack can include every
+// goroutine.
+
+// Frame is one call on a stack.
+type Frame struct {
+	Function string // with its package path, e.g. "main.run" or "net/http.(*Server).Serve"
+	File     string
+	Line     int
+}
+
+// String returns the function and its place, with only the file's base
+// name.
+func (f Frame) String() string {
+	return fmt.Sprintf("%s (%s:%d)", f.Function, filepath.Base(f.File), f.Line)
+}
+
+// Here returns the frame skip calls above the caller of Here: Here(0) is
+// where Here was called, and Here(1) is where that function was called,
+// which is what a logging helper wants, like testing.T.Helper.
+func Here(skip int) Frame {
+	// Callers, not Caller: Caller's skip can land on the wrong frame when
+	// the function above was inlined.
+	pcs := make([]uintptr, 1)
+	if runtime.Callers(skip+2, pcs) == 0 {
+		return Frame{Function: "unknown"}
+	}
+	f, _ := runtime.CallersFrames(pcs).Next()
+	return Frame{Function: f.Function, File: f.File, Line: f.Line}
+}
+
+// Stack returns the frames of the calling goroutine, from skip calls above
+// the caller of Stack up to the goroutine's first function.
+func Stack(skip int) []Frame {
+	pcs := make([]uintptr, 64)
+	for {
+		n := runtime.Callers(skip+2, pcs)
+		if n < len(pcs) {
+			pcs = pcs[:n]
+			break
+		}
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+	var stack []Frame
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		stack = append(stack, Frame{Function: f.Function, File: f.File, Line: f.Line})
+		if !more {
+			return stack
+		}
+	}
+}
+
+// CountGoroutines counts the goroutines in a dump of every goroutine's
+// stack, the way a debug page would print it.
+func CountGoroutines() int {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return bytes.Count(buf[:n], []byte("\ngoroutine ")) + 1
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// logf prints a message with the place it was called from, not the place
+// of logf itself.
+func logf(format string, args ...any) {
+	fmt.Printf("%s: %s\n", Here(1), fmt.Sprintf(format, args...))
+}
+
+// DemonstrateCallers prints frames and stacks of the running program.
+func DemonstrateCallers() {
+	fmt.Println("here:", Here(0))
+	logf("logf reports its caller, %d frames up the stack", 1)
+
+	for i, f := range Stack(0) {
+		fmt.Printf("stack[%d]: %s\n", i, f)
+	}
+
+	// debug.Stack is what a panic prints: the goroutine's header, then a
+	// function line and a file line for each frame.
+	lines := strings.Split(string(debug.Stack()), "\n")
+	fmt.Printf("debug.Stack: %d lines, starting %q\n", len(lines), lines[0])
+	fmt.Println("goroutines in runtime.Stack(buf, true):", CountGoroutines())
+}
@@ -0,0 +1,48 @@
+package examples
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateCallers(t *testing.T) {
+	DemonstrateCallers()
+}
+
+func TestHere(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	f := Here(0)
+	assert.Equal(t, Frame{Function: pkgPath + ".TestHere", File: file, Line: line + 1}, f)
+	assert.True(t, strings.HasSuffix(f.String(), ".TestHere (example2_callers_test.go:"+strconv.Itoa(line+1)+")"), f.String())
+
+	inner := func() Frame { return Here(1) }
+	_, _, line, _ = runtime.Caller(0)
+	f = inner()
+	assert.Equal(t, line+1, f.Line, "Here(1) is the caller's caller")
+	assert.Equal(t, pkgPath+".TestHere", f.Function)
+}
+
+func TestStack(t *testing.T) {
+	stack := Stack(0)
+	require.NotEmpty(t, stack)
+	assert.Equal(t, pkgPath+".TestStack", stack[0].Function)
+	assert.Equal(t, "testing.tRunner", stack[1].Function)
+	assert.Equal(t, stack[1:], Stack(1)[0:len(stack)-1], "skip drops frames from the top")
+}
+
+func TestCountGoroutines(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	for i := 0; i < 3; i++ {
+		go func() { <-stop }()
+	}
+	assert.GreaterOrEqual(t, CountGoroutines(), 4, "this one and the three blocked")
+}
+
+// pkgPath is the import path of this package, as function names start.
+const pkgPath = "github.com/TheAnarchoX/LearningGoTheHardWay/modules/25-runtime/examples"
@@ -0,0 +1,128 @@
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// BuildInfoExamples demonstrates reading how the program was built.
+//
+// The go command records the main module, every dependency and its
+// version, and the build settings (flags, GOOS and GOARCH, cgo, and the
+// commit of the repository, when the build ran in one) in each binary.
+// debug.ReadBuildInfo returns them from inside the program, and
+// `go version -m <binary>` prints them from outside, in the format of
+// BuildInfo.String, which debug.ParseBuildInfo reads back without the Go
+// version. A version command built from this needs no
+// -ldflags "-X main.version=..." in the build.
+
+// ErrNoBuildInfo is returned by ReadBuild in a binary built without module
+// support.
+var ErrNoBuildInfo = errors.New("no build information in this binary")
+
+// Build is what a binary says about how it was built.
+type Build struct {
+	GoVersion string
+	Path      string            // the main package
+	Module    string            // the main module
+	Version   string            // of the main module: "(devel)" when built from a checkout
+	Revision  string            // the commit, when built in a repository with VCS stamping on
+	Modified  bool              // whether the checkout had uncommitted changes
+	Settings  map[string]string // -race, -tags, CGO_ENABLED, GOOS, GOARCH, ...
+	Deps      []string          // path@version of every module built in
+}
+
+// ReadBuild returns the build information of the running program.
+func ReadBuild() (Build, error) {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return Build{}, ErrNoBuildInfo
+	}
+	return FromBuildInfo(bi), nil
+}
+
+// FromBuildInfo converts what debug.ReadBuildInfo or debug.ParseBuildInfo
+// returned.
+func FromBuildInfo(bi *debug.BuildInfo) Build {
+	b := Build{
+		GoVersion: bi.GoVersion,
+		Path:      bi.Path,
+		Module:    bi.Main.Path,
+		Version:   bi.Main.Version,
+		Settings:  make(map[string]string),
+	}
+	for _, s := range bi.Settings {
+		b.Settings[s.Key] = s.Value
+	}
+	b.Revision = b.Settings["vcs.revision"]
+	b.Modified = b.Settings["vcs.modified"] == "true"
+	for _, dep := range bi.Deps {
+		// A replaced module reports the replacement.
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		b.Deps = append(b.Deps, dep.Path+"@"+dep.Version)
+	}
+	return b
+}
+
+// VersionLine returns a line for a version command, such as
+// "shapes v1.2.0 (3f2a9c1d0b7e, modified) go1.22.0".
+func (b Build) VersionLine() string {
+	name := b.Path[strings.LastIndex(b.Path, "/")+1:]
+	line := name + " " + b.Version
+	if b.Revision != "" {
+		rev := b.Revision
+		if len(rev) > 12 {
+			rev = rev[:12]
+		}
+		if b.Modified {
+			rev += ", modified"
+		}
+		line += " (" + rev + ")"
+	}
+	if b.GoVersion != "" {
+		line += " " + b.GoVersion
+	}
+	return line
+}
+
+// shapesBuild is what BuildInfo.String returned for a release of a shapes
+// tool, with tabs between the fields.
+const shapesBuild = `path	example.com/shapes/cmd/shapes
+mod	example.com/shapes	v1.2.0	h1:Hcp7NHvMUN2Vj6wz8bk9vhX2hM2gU8bPFLBhjWR/ZGs=
+dep	github.com/TheAnarchoX/LearningGoTheHardWay	v0.3.0	h1:Qn9aoqZ1VNKKiVgbnITvIlpjcO8VIgJq1NUl3kPk8eo=
+build	-trimpath=true
+build	CGO_ENABLED=0
+build	GOOS=linux
+build	GOARCH=amd64
+build	vcs=git
+build	vcs.revision=3f2a9c1d0b7e44e1a2b1c0d9e8f7a6b5c4d3e2f1
+build	vcs.modified=false
+`
+
+// DemonstrateBuildInfo prints the build information of the running
+// program, and of a release read back from `go version -m`.
+func DemonstrateBuildInfo() {
+	b, err := ReadBuild()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("this program: %s, module %s %s, %d dependencies\n", b.Path, b.Module, b.Version, len(b.Deps))
+	fmt.Printf("built for %s/%s, CGO_ENABLED=%s, -race=%q\n",
+		b.Settings["GOOS"], b.Settings["GOARCH"], b.Settings["CGO_ENABLED"], b.Settings["-race"])
+
+	bi, err := debug.ParseBuildInfo(shapesBuild)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	release := FromBuildInfo(bi)
+	// go version -m prints the Go version on a line of its own, above.
+	release.GoVersion = "go1.22.0"
+	fmt.Println("a release:", release.VersionLine())
+	fmt.Println("its dependencies:", release.Deps)
+}
@@ -0,0 +1,41 @@
+package examples
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateBuildInfo(t *testing.T) {
+	DemonstrateBuildInfo()
+}
+
+func TestReadBuild(t *testing.T) {
+	b, err := ReadBuild()
+	require.NoError(t, err)
+	assert.Equal(t, runtime.Version(), b.GoVersion)
+	assert.Equal(t, "github.com/TheAnarchoX/LearningGoTheHardWay", b.Module)
+	assert.Contains(t, b.Deps, "github.com/stretchr/testify@v1.11.1")
+	assert.Equal(t, runtime.GOOS, b.Settings["GOOS"])
+}
+
+func TestFromBuildInfo(t *testing.T) {
+	bi, err := debug.ParseBuildInfo(shapesBuild)
+	require.NoError(t, err)
+	b := FromBuildInfo(bi)
+	assert.Equal(t, "example.com/shapes/cmd/shapes", b.Path)
+	assert.Equal(t, "v1.2.0", b.Version)
+	assert.Equal(t, []string{"github.com/TheAnarchoX/LearningGoTheHardWay@v0.3.0"}, b.Deps)
+	assert.Equal(t, "shapes v1.2.0 (3f2a9c1d0b7e)", b.VersionLine())
+
+	b.GoVersion = "go1.22.0"
+	assert.Equal(t, "shapes v1.2.0 (3f2a9c1d0b7e) go1.22.0", b.VersionLine())
+
+	b.Modified = true
+	assert.Equal(t, "shapes v1.2.0 (3f2a9c1d0b7e, modified) go1.22.0", b.VersionLine())
+	b.Revision = ""
+	assert.Equal(t, "shapes v1.2.0 go1.22.0", b.VersionLine())
+}
@@ -0,0 +1,150 @@
+package exercises
+
+// EXERCISE: Fix a panic handler that logs panics with their stack.
+// A server cannot let one bad request end the whole program, so it
+// recovers panics at the edge of each piece of work and logs them, with
+// the stack that shows how the program got there. This handler lets
+// panics through, blames itself for them, hides the errors they carry,
+// loses the errors it makes from them, and hangs in Wait after a panic.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// PanicError is a panic that was recovered: its value, where it happened,
+// and the stack of the goroutine at that moment.
+type PanicError struct {
+	Value any
+	Site  string // "file.go:line" of the code that panicked
+	Stack []byte // as debug.Stack returns it
+}
+
+// Error returns the panic value, as a panic prints it.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v [recovered at %s]", e.Value, e.Site)
+}
+
+// Unwrap returns the panic value if it is an error, so errors.Is and
+// errors.As see through the panic.
+func (e *PanicError) Unwrap() error {
+	// BUG: errors.Is(err, io.EOF) is false even for panic(io.EOF).
+	// Return the value when it is an error.
+	return nil
+}
+
+// Handler stops panics before they end the program, and logs each one,
+// with its stack, as an error through Logger.
+type Handler struct {
+	Logger *slog.Logger
+
+	mu     sync.Mutex
+	panics int
+	wg     sync.WaitGroup
+}
+
+// NewHandler returns a Handler that logs through logger.
+func NewHandler(logger *slog.Logger) *Handler {
+	return &Handler{Logger: logger}
+}
+
+// Recover stops a panic in the goroutine that defers it, and logs it. name
+// says what was running. It must be deferred itself, as in
+//
+//	defer h.Recover("worker")
+//
+// since recover only stops a panic when the deferred function calls it.
+func (h *Handler) Recover(name string) {
+	// BUG: recover only works when called by the deferred function
+	// itself. Called from recovered, it returns nil, and the panic goes
+	// on.
+	if v := h.recovered(); v != nil {
+		h.handle(name, v)
+	}
+}
+
+func (h *Handler) recovered() any {
+	return recover()
+}
+
+// Do calls f, and returns its error, or a *PanicError if it panicked.
+func (h *Handler) Do(name string, f func() error) error {
+	// BUG: Once the function panics, it returns nil: the deferred function
+	// sets a local variable, not the result. Name the result instead.
+	var err error
+	defer func() {
+		if v := recover(); v != nil {
+			err = h.handle(name, v)
+		}
+	}()
+	err = f()
+	return err
+}
+
+// Go runs f in a new goroutine that logs a panic instead of ending the
+// program. Wait waits for every goroutine started by Go.
+func (h *Handler) Go(name string, f func()) {
+	h.wg.Add(1)
+	go func() {
+		defer h.Recover(name)
+		f()
+		// BUG: When f panics, this line never runs, and Wait waits
+		// forever. Defer it.
+		h.wg.Done()
+	}()
+}
+
+// Wait waits for the goroutines started by Go.
+func (h *Handler) Wait() {
+	h.wg.Wait()
+}
+
+// Panics returns the number of panics recovered so far.
+func (h *Handler) Panics() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.panics
+}
+
+// handle logs a recovered panic and counts it. It must be called from the
+// deferred function, while the panicking frames are still on the stack.
+func (h *Handler) handle(name string, v any) *PanicError {
+	pe := &PanicError{Value: v, Site: Site(), Stack: debug.Stack()}
+	h.mu.Lock()
+	h.panics++
+	h.mu.Unlock()
+	h.Logger.Error("recovered panic",
+		slog.String("name", name),
+		slog.Any("panic", v),
+		slog.String("site", pe.Site),
+		slog.Int("goroutines", runtime.NumGoroutine()),
+		slog.String("stack", string(pe.Stack)))
+	return pe
+}
+
+// Site returns "file.go:line" of the code that panicked, when called from
+// a deferred function during a panic, and "unknown" otherwise. The stack
+// then reads, from the top: Site, its callers in the handler, the deferred
+// function, runtime.gopanic, maybe more of the runtime (for a nil pointer
+// or an index out of range), and then the code that panicked.
+func Site() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+	// BUG: The first frame that is not the runtime's is Site itself,
+	// in the handler. Skip every frame up to runtime.gopanic, then the
+	// runtime's frames after it.
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, "runtime.") {
+			return fmt.Sprintf("%s:%d", f.File[strings.LastIndex(f.File, "/")+1:], f.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
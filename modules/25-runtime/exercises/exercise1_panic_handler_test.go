@@ -0,0 +1,203 @@
+package exercises
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lockedBuffer is a bytes.Buffer that goroutines can log into at once.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// records decodes the JSON lines logged so far.
+func (b *lockedBuffer) records(t *testing.T) []map[string]any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var records []map[string]any
+	dec := json.NewDecoder(bytes.NewReader(b.buf.Bytes()))
+	for {
+		var r map[string]any
+		if err := dec.Decode(&r); err == io.EOF {
+			return records
+		} else {
+			require.NoError(t, err)
+		}
+		records = append(records, r)
+	}
+}
+
+func newHandler() (*Handler, *lockedBuffer) {
+	var logs lockedBuffer
+	return NewHandler(slog.New(slog.NewJSONHandler(&logs, nil))), &logs
+}
+
+// here returns "file.go:line" of the line after the one calling it.
+func here() string {
+	_, file, line, _ := runtime.Caller(1)
+	return file[strings.LastIndex(file, "/")+1:] + ":" + strconv.Itoa(line+1)
+}
+
+// noPanic calls f, and fails the test if a panic gets out of it, rather
+// than letting it end the test binary.
+func noPanic(t *testing.T, f func()) {
+	t.Helper()
+	defer func() {
+		if v := recover(); v != nil {
+			t.Errorf("the panic got through: %v", v)
+		}
+	}()
+	f()
+}
+
+func TestRecover(t *testing.T) {
+	h, logs := newHandler()
+	var site string
+	noPanic(t, func() {
+		defer h.Recover("job")
+		site = here()
+		panic("something broke")
+	})
+	assert.Equal(t, 1, h.Panics())
+
+	records := logs.records(t)
+	require.Len(t, records, 1)
+	r := records[0]
+	assert.Equal(t, "ERROR", r["level"])
+	assert.Equal(t, "recovered panic", r["msg"])
+	assert.Equal(t, "job", r["name"])
+	assert.Equal(t, "something broke", r["panic"])
+	assert.Equal(t, site, r["site"])
+	assert.Contains(t, r["stack"], "TestRecover", "the stack shows how the program got to the panic")
+	assert.Contains(t, r["stack"], "panic(")
+}
+
+func TestRecoverNoPanic(t *testing.T) {
+	h, logs := newHandler()
+	func() {
+		defer h.Recover("job")
+	}()
+	assert.Zero(t, h.Panics())
+	assert.Empty(t, logs.records(t))
+}
+
+func TestSite(t *testing.T) {
+	h, logs := newHandler()
+	var sites []string
+
+	var m map[string]int
+	_ = h.Do("nil map", func() error {
+		sites = append(sites, here())
+		m["x"] = 1
+		return nil
+	})
+
+	var p *struct{ n int }
+	_ = h.Do("nil pointer", func() error {
+		sites = append(sites, here())
+		return fmt.Errorf("%d", p.n)
+	})
+
+	i := seed.Between(seed.Rand(t, 1), 3, 10)
+	_ = h.Do("index", func() error {
+		s := make([]int, 2)
+		sites = append(sites, here())
+		s[i] = 1
+		return nil
+	})
+
+	records := logs.records(t)
+	require.Len(t, records, 3)
+	for n, r := range records {
+		assert.Equal(t, sites[n], r["site"], "panic in %s", r["name"])
+	}
+	assert.Equal(t, "unknown", Site(), "Site outside a panic")
+}
+
+func TestDo(t *testing.T) {
+	h, _ := newHandler()
+	assert.NoError(t, h.Do("ok", func() error { return nil }))
+	assert.EqualError(t, h.Do("error", func() error { return errors.New("plain error") }), "plain error")
+	assert.Zero(t, h.Panics())
+
+	var site string
+	err := h.Do("panic", func() error {
+		site = here()
+		panic("boom")
+	})
+	var pe *PanicError
+	require.ErrorAs(t, err, &pe, "a panic becomes a *PanicError")
+	assert.Equal(t, "boom", pe.Value)
+	assert.Equal(t, site, pe.Site)
+	assert.Equal(t, "panic: boom [recovered at "+site+"]", err.Error())
+	assert.Contains(t, string(pe.Stack), "TestDo")
+	assert.Equal(t, 1, h.Panics())
+}
+
+func TestPanicErrorUnwrap(t *testing.T) {
+	h, _ := newHandler()
+	err := h.Do("eof", func() error { panic(io.ErrUnexpectedEOF) })
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	err = h.Do("runtime", func() error {
+		var s []int
+		return fmt.Errorf("%d", s[1])
+	})
+	var re runtime.Error
+	assert.ErrorAs(t, err, &re, "runtime panics are runtime.Error values")
+
+	err = h.Do("string", func() error { panic("not an error") })
+	assert.Nil(t, errors.Unwrap(err))
+}
+
+func TestGo(t *testing.T) {
+	h, logs := newHandler()
+	n := seed.Between(seed.Rand(t, 2), 5, 20)
+	for i := 0; i < n; i++ {
+		i := i
+		h.Go("worker "+strconv.Itoa(i), func() {
+			if i%2 == 0 {
+				panic(fmt.Sprintf("worker %d failed", i))
+			}
+		})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		h.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait still waiting after five seconds")
+	}
+
+	assert.Equal(t, (n+1)/2, h.Panics())
+	records := logs.records(t)
+	require.Len(t, records, (n+1)/2)
+	for _, r := range records {
+		assert.Equal(t, r["name"].(string)+" failed", r["panic"])
+	}
+}
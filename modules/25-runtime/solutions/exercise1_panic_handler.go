@@ -0,0 +1,141 @@
+package solutions
+
+// SOLUTION: Fix a panic handler that logs panics with their stack.
+// Recover calls recover itself, Site walks past the runtime's frames to
+// the code that panicked, PanicError unwraps error values, Do names its
+// result so the deferred function can set it, and Go marks its goroutine
+// done even when it panics.
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// PanicError is a panic that was recovered: its value, where it happened,
+// and the stack of the goroutine at that moment.
+type PanicError struct {
+	Value any
+	Site  string // "file.go:line" of the code that panicked
+	Stack []byte // as debug.Stack returns it
+}
+
+// Error returns the panic value, as a panic prints it.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v [recovered at %s]", e.Value, e.Site)
+}
+
+// Unwrap returns the panic value if it is an error, so errors.Is and
+// errors.As see through the panic.
+func (e *PanicError) Unwrap() error {
+	// Fixed: Return the value when it is an error.
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Handler stops panics before they end the program, and logs each one,
+// with its stack, as an error through Logger.
+type Handler struct {
+	Logger *slog.Logger
+
+	mu     sync.Mutex
+	panics int
+	wg     sync.WaitGroup
+}
+
+// NewHandler returns a Handler that logs through logger.
+func NewHandler(logger *slog.Logger) *Handler {
+	return &Handler{Logger: logger}
+}
+
+// Recover stops a panic in the goroutine that defers it, and logs it. name
+// says what was running. It must be deferred itself, as in
+//
+//	defer h.Recover("worker")
+//
+// since recover only stops a panic when the deferred function calls it.
+func (h *Handler) Recover(name string) {
+	// Fixed: Call recover here, not in a function that Recover calls.
+	if v := recover(); v != nil {
+		h.handle(name, v)
+	}
+}
+
+// Do calls f, and returns its error, or a *PanicError if it panicked.
+func (h *Handler) Do(name string, f func() error) (err error) {
+	// Fixed: Name the result, so the deferred function can set it.
+	defer func() {
+		if v := recover(); v != nil {
+			err = h.handle(name, v)
+		}
+	}()
+	return f()
+}
+
+// Go runs f in a new goroutine that logs a panic instead of ending the
+// program. Wait waits for every goroutine started by Go.
+func (h *Handler) Go(name string, f func()) {
+	h.wg.Add(1)
+	go func() {
+		// Fixed: Deferred, so Done is called when f panics too. Deferred
+		// calls run last in, first out: Recover, then Done.
+		defer h.wg.Done()
+		defer h.Recover(name)
+		f()
+	}()
+}
+
+// Wait waits for the goroutines started by Go.
+func (h *Handler) Wait() {
+	h.wg.Wait()
+}
+
+// Panics returns the number of panics recovered so far.
+func (h *Handler) Panics() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.panics
+}
+
+// handle logs a recovered panic and counts it. It must be called from the
+// deferred function, while the panicking frames are still on the stack.
+func (h *Handler) handle(name string, v any) *PanicError {
+	pe := &PanicError{Value: v, Site: Site(), Stack: debug.Stack()}
+	h.mu.Lock()
+	h.panics++
+	h.mu.Unlock()
+	h.Logger.Error("recovered panic",
+		slog.String("name", name),
+		slog.Any("panic", v),
+		slog.String("site", pe.Site),
+		slog.Int("goroutines", runtime.NumGoroutine()),
+		slog.String("stack", string(pe.Stack)))
+	return pe
+}
+
+// Site returns "file.go:line" of the code that panicked, when called from
+// a deferred function during a panic, and "unknown" otherwise. The stack
+// then reads, from the top: Site, its callers in the handler, the deferred
+// function, runtime.gopanic, maybe more of the runtime (for a nil pointer
+// or an index out of range), and then the code that panicked.
+func Site() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+	// Fixed: Find runtime.gopanic, then skip the runtime's frames.
+	panicking := false
+	for {
+		f, more := frames.Next()
+		switch {
+		case f.Function == "runtime.gopanic":
+			panicking = true
+		case panicking && !strings.HasPrefix(f.Function, "runtime."):
+			return fmt.Sprintf("%s:%d", f.File[strings.LastIndex(f.File, "/")+1:], f.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Package examples demonstrates hash functions: cryptographic ones for
+// checksums that must hold up against an attacker, fast ones for hash
+// tables and sharding, and HMAC for messages that must come from someone
+// holding a key.
+//
+// Every hash in the standard library implements hash.Hash: an io.Writer
+// that data is written to, in as many pieces as you like, and Sum, which
+// appends the digest to a slice. The digest is bytes; hex or base64 is
+// only how it is printed.
+//
+// This file shows:
+// - sha256.Sum256 for one slice, and sha256.New for a hash.Hash
+// - Hex-encoding a digest, and why each byte needs two characters
+// - hash/fnv and hash/crc32: fast, and easy to collide on purpose
+// - Spreading keys over shards with a non-cryptographic hash
+package examples
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"hash/fnv"
+	"strconv"
+)
+
+// SHA256Hex returns the SHA-256 digest of data as 64 hex characters.
+func SHA256Hex(data []byte) string {
+	sum := sha256.Sum256(data) // a [32]byte, not a slice
+	return hex.EncodeToString(sum[:])
+}
+
+// Digest writes each part to h and returns the digest: the same as
+// hashing the parts joined together.
+func Digest(h hash.Hash, parts ...[]byte) []byte {
+	for _, p := range parts {
+		// A hash.Hash's Write never returns an error.
+		h.Write(p)
+	}
+	// Sum appends to its argument and does not change the hash state, so
+	// more can be written afterwards.
+	return h.Sum(nil)
+}
+
+// Shard picks one of n shards for key with FNV-1a: fast and well spread,
+// but anyone can find keys that land on the same shard. Use it where the
+// keys are not chosen by an attacker, or where that only costs speed.
+func Shard(key string, n int) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(n))
+}
+
+// DemonstrateHashes prints digests of the same data with different hashes.
+func DemonstrateHashes() {
+	data := []byte("The quick brown fox jumps over the lazy dog")
+
+	fmt.Println("sha256:", SHA256Hex(data))
+	fmt.Printf("sha256 in two writes: %x\n", Digest(sha256.New(), data[:10], data[10:]))
+	fmt.Printf("fnv-1a 64: %x\n", Digest(fnv.New64a(), data))
+	fmt.Printf("crc32 (IEEE): %08x\n", crc32.ChecksumIEEE(data))
+
+	// One changed bit changes about half of a cryptographic digest.
+	changed := append([]byte(nil), data...)
+	changed[0] ^= 1
+	fmt.Println("one bit flipped:", SHA256Hex(changed))
+
+	// %x and hex.EncodeToString write two characters for every byte; a
+	// byte below 0x10 starts with a 0 that is easy to lose.
+	fmt.Printf("the byte 0x0a: %%x of a slice %x, %%02x %02x, but strconv.FormatUint %s\n",
+		[]byte{0x0a}, 0x0a, strconv.FormatUint(0x0a, 16))
+
+	counts := make([]int, 4)
+	for i := 0; i < 1000; i++ {
+		counts[Shard(fmt.Sprintf("user-%d", i), len(counts))]++
+	}
+	fmt.Println("1000 keys over 4 shards:", counts)
+}
@@ -0,0 +1,36 @@
+package examples
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateHashes(t *testing.T) {
+	DemonstrateHashes()
+}
+
+func TestSHA256Hex(t *testing.T) {
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", SHA256Hex([]byte("abc")))
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", SHA256Hex(nil))
+}
+
+func TestDigest(t *testing.T) {
+	assert.Equal(t, SHA256Hex([]byte("abc")), hex.EncodeToString(Digest(sha256.New(), []byte("a"), []byte("bc"))))
+	assert.Equal(t, "e71fa2190541574b", hex.EncodeToString(Digest(fnv.New64a(), []byte("abc"))))
+}
+
+func TestShard(t *testing.T) {
+	counts := make([]int, 8)
+	for i := 0; i < 8000; i++ {
+		counts[Shard(fmt.Sprintf("key-%d", i), 8)]++
+	}
+	for _, c := range counts {
+		assert.InDelta(t, 1000, c, 250)
+	}
+	assert.Equal(t, Shard("key", 8), Shard("key", 8))
+}
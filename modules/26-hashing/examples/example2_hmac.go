@@ -0,0 +1,87 @@
+package examples
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// HMACExamples demonstrates message authentication with crypto/hmac.
+//
+// A digest proves that data did not change since someone computed it,
+// but anyone can compute one. An HMAC mixes in a secret key, so only
+// someone holding the key can produce it: a server can hand out a value
+// and know it is the one it signed when it comes back. sha256(key + data)
+// looks similar and is broken: anyone can append to the data and extend
+// the digest without the key. Compare MACs with hmac.Equal, which takes
+// the same time wherever the first difference is, so an attacker cannot
+// guess a valid MAC byte by byte from the response times.
+
+// ErrBadToken is returned by Token.Open for a token that was not signed
+// with the key, or was changed since.
+var ErrBadToken = errors.New("bad token")
+
+// Token signs values and checks them, as "value.signature" strings, the
+// way a session cookie or a download link is signed.
+type Token struct {
+	key []byte
+}
+
+// NewToken returns a Token that signs with key, which should be 32 random
+// bytes kept on the server.
+func NewToken(key []byte) *Token {
+	return &Token{key: key}
+}
+
+func (t *Token) mac(value string) []byte {
+	m := hmac.New(sha256.New, t.key)
+	m.Write([]byte(value))
+	return m.Sum(nil)
+}
+
+// Sign returns value with its signature appended.
+func (t *Token) Sign(value string) string {
+	return value + "." + base64.RawURLEncoding.EncodeToString(t.mac(value))
+}
+
+// Open checks a token made by Sign and returns its value.
+func (t *Token) Open(token string) (string, error) {
+	i := strings.LastIndexByte(token, '.')
+	if i < 0 {
+		return "", ErrBadToken
+	}
+	value, sig := token[:i], token[i+1:]
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return "", ErrBadToken
+	}
+	// hmac.Equal also fails for a MAC of another length, so a truncated
+	// signature does not pass.
+	if !hmac.Equal(got, t.mac(value)) {
+		return "", ErrBadToken
+	}
+	return value, nil
+}
+
+// DemonstrateHMAC signs a value, and shows that changes to it are caught.
+func DemonstrateHMAC() {
+	tokens := NewToken([]byte("a key of 32 bytes, kept secret!!"))
+	token := tokens.Sign("user=ada")
+	fmt.Println("token:", token)
+
+	value, err := tokens.Open(token)
+	fmt.Printf("opened: %q, %v\n", value, err)
+
+	forged := strings.Replace(token, "ada", "bob", 1)
+	_, err = tokens.Open(forged)
+	fmt.Println("with the value changed:", err)
+
+	_, err = tokens.Open(token[:len(token)-10])
+	fmt.Println("with the signature cut short:", err)
+
+	_, err = NewToken([]byte("another key")).Open(token)
+	fmt.Println("checked with another key:", err)
+}
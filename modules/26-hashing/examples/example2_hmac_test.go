@@ -0,0 +1,45 @@
+package examples
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateHMAC(t *testing.T) {
+	DemonstrateHMAC()
+}
+
+func TestHMACVector(t *testing.T) {
+	// RFC 4231, test case 2.
+	m := hmac.New(sha256.New, []byte("Jefe"))
+	m.Write([]byte("what do ya want for nothing?"))
+	assert.Equal(t, "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", hex.EncodeToString(m.Sum(nil)))
+}
+
+func TestToken(t *testing.T) {
+	tokens := NewToken([]byte("test key"))
+	token := tokens.Sign("id=42.7")
+	value, err := tokens.Open(token)
+	require.NoError(t, err)
+	assert.Equal(t, "id=42.7", value, "the value may contain dots")
+
+	for _, bad := range []string{
+		"",
+		"id=42.7",
+		"id=43.7" + token[len("id=42.7"):],
+		token[:len(token)-1],
+		token + "A",
+		"id=42.7.",
+		"id=42.7.!!!",
+	} {
+		_, err := tokens.Open(bad)
+		assert.ErrorIs(t, err, ErrBadToken, "%q", bad)
+	}
+	_, err = NewToken([]byte("other key")).Open(token)
+	assert.ErrorIs(t, err, ErrBadToken)
+}
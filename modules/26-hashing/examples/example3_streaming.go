@@ -0,0 +1,100 @@
+package examples
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// StreamingExamples demonstrates hashing data as it goes by, without
+// holding all of it in memory.
+//
+// A hash.Hash is an io.Writer, so io.Copy hashes a file or a download of
+// any size through a small buffer. io.MultiWriter hashes data while it is
+// written somewhere else, and io.TeeReader while it is read by someone
+// else; either way the data is read only once.
+
+// HashReader returns the SHA-256 of everything r holds, and its length.
+func HashReader(r io.Reader) (string, int64, error) {
+	h := sha256.New()
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return "", n, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// HashFile returns the SHA-256 of a file, as `sha256sum` prints it.
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	sum, _, err := HashReader(f)
+	return sum, err
+}
+
+// CopyAndHash copies src to dst and returns the SHA-256 and CRC-32 of what
+// it copied, computed on the way.
+func CopyAndHash(dst io.Writer, src io.Reader) (sha string, crc uint32, err error) {
+	sh, cr := sha256.New(), crc32.NewIEEE()
+	if _, err := io.Copy(io.MultiWriter(dst, sh, cr), src); err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(sh.Sum(nil)), cr.Sum32(), nil
+}
+
+// DemonstrateStreaming hashes data while copying it, and a file.
+func DemonstrateStreaming() {
+	// 10MB that never exist in memory at once.
+	big := io.LimitReader(repeat("learngo "), 10<<20)
+	sum, n, err := HashReader(big)
+	fmt.Printf("%d bytes: %s %v\n", n, sum, err)
+
+	var out strings.Builder
+	sha, crc, err := CopyAndHash(&out, strings.NewReader("copied and hashed in one pass"))
+	fmt.Printf("copied %q: sha256 %s..., crc32 %08x, %v\n", out.String(), sha[:16], crc, err)
+
+	// TeeReader: whoever reads body also feeds the hash.
+	h := sha256.New()
+	body := io.TeeReader(strings.NewReader("read by someone else"), h)
+	io.ReadAll(body)
+	fmt.Printf("tee: %x\n", h.Sum(nil))
+
+	dir, err := os.MkdirTemp("", "learngo-hash")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "hello.txt")
+	os.WriteFile(path, []byte("hello\n"), 0o644)
+	sum, err = HashFile(path)
+	fmt.Printf("%s  %s %v\n", sum, filepath.Base(path), err)
+}
+
+// repeat returns a reader that yields s forever.
+func repeat(s string) io.Reader {
+	return &repeater{s: s}
+}
+
+type repeater struct {
+	s   string
+	off int
+}
+
+func (r *repeater) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		c := copy(p[n:], r.s[r.off:])
+		n += c
+		r.off = (r.off + c) % len(r.s)
+	}
+	return n, nil
+}
@@ -0,0 +1,57 @@
+package examples
+
+import (
+	"bytes"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateStreaming(t *testing.T) {
+	DemonstrateStreaming()
+}
+
+func TestHashReader(t *testing.T) {
+	data := make([]byte, seed.Between(seed.Rand(t, 1), 1, 1<<20))
+	seed.Rand(t, 2).Read(data)
+	sum, n, err := HashReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), n)
+	assert.Equal(t, SHA256Hex(data), sum)
+
+	boom := errors.New("boom")
+	_, _, err = HashReader(io.MultiReader(strings.NewReader("abc"), errReader{boom}))
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestHashFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hello.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hello\n"), 0o644))
+	sum, err := HashFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03", sum)
+
+	_, err = HashFile(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestCopyAndHash(t *testing.T) {
+	var out bytes.Buffer
+	sha, crc, err := CopyAndHash(&out, strings.NewReader("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, "abc", out.String())
+	assert.Equal(t, SHA256Hex([]byte("abc")), sha)
+	assert.Equal(t, crc32.ChecksumIEEE([]byte("abc")), crc)
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
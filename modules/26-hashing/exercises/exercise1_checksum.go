@@ -0,0 +1,96 @@
+package exercises
+
+// EXERCISE: Fix a checksum package that verifies downloads.
+// A release page lists the SHA-256 of each file, and a downloader checks
+// what it got against it. This package prints digests that are too short,
+// accepts checksums that are too short, hashes bytes it never read, hands
+// out part of the data instead of its digest, and passes a download
+// whenever the checksum is a prefix of the real one, the empty string
+// included.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Errors returned by ParseDigest and Verify.
+var (
+	ErrDigestLength = errors.New("a SHA-256 digest is 64 hex characters")
+	ErrMismatch     = errors.New("checksum mismatch")
+)
+
+// Digest is a SHA-256 digest.
+type Digest [sha256.Size]byte
+
+// String returns the digest as 64 lowercase hex characters, as sha256sum
+// prints it.
+func (d Digest) String() string {
+	var s string
+	for _, b := range d {
+		// BUG: A byte below 0x10 becomes one character: 0x0a is "a", not
+		// "0a". Use hex.EncodeToString.
+		s += strconv.FormatUint(uint64(b), 16)
+	}
+	return s
+}
+
+// ParseDigest parses 64 hex characters, in either case.
+func ParseDigest(s string) (Digest, error) {
+	var d Digest
+	// BUG: Any length is accepted: copy takes what fits, so a short
+	// string leaves zeros at the end and a long one is cut. Return
+	// ErrDigestLength unless s is 64 characters, before decoding it.
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return d, fmt.Errorf("digest %q: %w", s, err)
+	}
+	copy(d[:], b)
+	return d, nil
+}
+
+// Sum reads r to the end and returns the digest of what it read, and how
+// many bytes that was.
+func Sum(r io.Reader) (Digest, int64, error) {
+	var d Digest
+	h := sha256.New()
+	buf := make([]byte, 32<<10)
+	var n int64
+	for {
+		m, err := r.Read(buf)
+		// BUG: The whole buffer is hashed, with whatever an earlier read
+		// left after the m new bytes.
+		h.Write(buf)
+		n += int64(m)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return d, n, err
+		}
+	}
+	// BUG: Sum appends the digest to its argument, so this copies the
+	// start of buf, the data. Pass nil.
+	copy(d[:], h.Sum(buf))
+	return d, n, nil
+}
+
+// Verify reads r to the end and checks that its digest is want, 64 hex
+// characters. It returns ErrMismatch, with both digests, when it is not.
+func Verify(r io.Reader, want string) error {
+	got, _, err := Sum(r)
+	if err != nil {
+		return err
+	}
+	// BUG: A checksum cut short, or empty, passes. Parse want with
+	// ParseDigest, and compare the two Digests.
+	if !strings.HasPrefix(got.String(), strings.ToLower(want)) {
+		return fmt.Errorf("%w: got %s, want %s", ErrMismatch, got, want)
+	}
+	return nil
+}
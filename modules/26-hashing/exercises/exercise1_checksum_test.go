@@ -0,0 +1,87 @@
+package exercises
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// abc is the SHA-256 of "abc". Its sixth byte is 0x01.
+const abc = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+
+func TestDigestString(t *testing.T) {
+	assert.Equal(t, abc, Digest(sha256.Sum256([]byte("abc"))).String())
+	assert.Equal(t, strings.Repeat("00", 32), Digest{}.String())
+
+	data := make([]byte, 100)
+	seed.Rand(t, 1).Read(data)
+	sum := sha256.Sum256(data)
+	assert.Equal(t, hex.EncodeToString(sum[:]), Digest(sum).String())
+}
+
+func TestParseDigest(t *testing.T) {
+	d, err := ParseDigest(abc)
+	require.NoError(t, err)
+	assert.Equal(t, Digest(sha256.Sum256([]byte("abc"))), d)
+
+	d, err = ParseDigest(strings.ToUpper(abc))
+	require.NoError(t, err)
+	assert.Equal(t, abc, d.String())
+
+	for _, s := range []string{"", abc[:62], abc[:8], abc + "00"} {
+		_, err := ParseDigest(s)
+		assert.ErrorIs(t, err, ErrDigestLength, "%q", s)
+	}
+	_, err = ParseDigest(strings.Replace(abc, "b", "g", 1))
+	assert.Error(t, err)
+}
+
+func TestSum(t *testing.T) {
+	r := seed.Rand(t, 2)
+	for _, size := range []int{0, 1, 100, 32 << 10, 32<<10 + 1, seed.Between(r, 40<<10, 200<<10)} {
+		data := make([]byte, size)
+		r.Read(data)
+		want := sha256.Sum256(data)
+
+		d, n, err := Sum(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, int64(size), n)
+		assert.Equal(t, Digest(want), d, "%d bytes", size)
+
+		d, _, err = Sum(iotest.HalfReader(bytes.NewReader(data)))
+		require.NoError(t, err)
+		assert.Equal(t, Digest(want), d, "%d bytes, read in small pieces", size)
+	}
+}
+
+func TestSumError(t *testing.T) {
+	boom := errors.New("boom")
+	_, n, err := Sum(io.MultiReader(strings.NewReader("abc"), iotest.ErrReader(boom)))
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, int64(3), n)
+}
+
+func TestVerify(t *testing.T) {
+	assert.NoError(t, Verify(strings.NewReader("abc"), abc))
+	assert.NoError(t, Verify(strings.NewReader("abc"), strings.ToUpper(abc)))
+
+	err := Verify(strings.NewReader("abd"), abc)
+	assert.ErrorIs(t, err, ErrMismatch)
+	assert.ErrorContains(t, err, "want "+abc)
+}
+
+func TestVerifyTruncated(t *testing.T) {
+	for _, want := range []string{"", abc[:8], abc[:63], abc + "0"} {
+		err := Verify(strings.NewReader("abc"), want)
+		assert.ErrorIs(t, err, ErrDigestLength, "%q", want)
+	}
+}
@@ -0,0 +1,90 @@
+package exercises
+
+// EXERCISE: Fix the signature check of a webhook receiver.
+// A service that calls your webhook signs each request body with a secret
+// you share, and you check the signature before trusting the body. This
+// receiver signs with a construction anyone can extend, accepts
+// signatures that are cut short, reads bodies of any size, and leaves the
+// handler an empty body.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// SignatureHeader carries a request's signature: "sha256=" and the hex
+// HMAC-SHA256 of the body, the way GitHub signs its webhooks.
+const SignatureHeader = "X-Signature-256"
+
+const prefix = "sha256="
+
+// MaxBodySize is the largest body VerifyRequest reads.
+const MaxBodySize = 1 << 20
+
+// Errors returned by VerifyBody and VerifyRequest.
+var (
+	ErrNoSignature  = errors.New("no signature")
+	ErrBadSignature = errors.New("bad signature")
+	ErrTooLarge     = errors.New("body too large")
+)
+
+// SignBody returns the signature header value for body.
+func SignBody(secret, body []byte) string {
+	// BUG: Anyone who has one signature can append to the body and
+	// compute the signature of the result, without the secret: that is a
+	// length extension. Use hmac.New(sha256.New, secret).
+	h := sha256.New()
+	h.Write(secret)
+	h.Write(body)
+	return prefix + hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyBody checks a signature header value made by SignBody.
+func VerifyBody(secret, body []byte, signature string) error {
+	if signature == "" {
+		return ErrNoSignature
+	}
+	hexMAC, ok := strings.CutPrefix(signature, prefix)
+	if !ok {
+		return fmt.Errorf("%w: want %s...", ErrBadSignature, prefix)
+	}
+	got, err := hex.DecodeString(hexMAC)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBadSignature, err)
+	}
+	want, _ := hex.DecodeString(strings.TrimPrefix(SignBody(secret, body), prefix))
+	// BUG: Only as many bytes as the signature has are compared, so one
+	// byte of signature takes at most 256 guesses, and an empty one none.
+	// bytes.Equal would also take longer the more bytes match: use
+	// hmac.Equal.
+	if !bytes.Equal(got, want[:min(len(got), len(want))]) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// VerifyRequest reads r's body, up to MaxBodySize, and checks its
+// signature. On success it returns the body and also puts it back in
+// r.Body, so the handler can read it again.
+func VerifyRequest(secret []byte, r *http.Request) ([]byte, error) {
+	// BUG: A sender can make the server read gigabytes before the check
+	// fails. Read at most MaxBodySize+1 bytes with io.LimitReader, and
+	// return ErrTooLarge if there were more than MaxBodySize.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyBody(secret, body, r.Header.Get(SignatureHeader)); err != nil {
+		return nil, err
+	}
+	// BUG: r.Body is used up: a handler that reads it gets nothing. Put
+	// the body back with io.NopCloser(bytes.NewReader(body)).
+	return body, nil
+}
@@ -0,0 +1,88 @@
+package solutions
+
+// SOLUTION: Fix a checksum package that verifies downloads.
+// String writes two hex characters for every byte, ParseDigest rejects
+// strings of the wrong length, Sum hashes only the bytes it read and
+// returns the digest alone, and Verify compares whole digests.
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Errors returned by ParseDigest and Verify.
+var (
+	ErrDigestLength = errors.New("a SHA-256 digest is 64 hex characters")
+	ErrMismatch     = errors.New("checksum mismatch")
+)
+
+// Digest is a SHA-256 digest.
+type Digest [sha256.Size]byte
+
+// String returns the digest as 64 lowercase hex characters, as sha256sum
+// prints it.
+func (d Digest) String() string {
+	// Fixed: Two characters for every byte, leading zeros included.
+	return hex.EncodeToString(d[:])
+}
+
+// ParseDigest parses 64 hex characters, in either case.
+func ParseDigest(s string) (Digest, error) {
+	var d Digest
+	// Fixed: A digest that is too short, or too long, is not one.
+	if len(s) != hex.EncodedLen(len(d)) {
+		return d, fmt.Errorf("%w: got %d", ErrDigestLength, len(s))
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return d, fmt.Errorf("digest %q: %w", s, err)
+	}
+	copy(d[:], b)
+	return d, nil
+}
+
+// Sum reads r to the end and returns the digest of what it read, and how
+// many bytes that was.
+func Sum(r io.Reader) (Digest, int64, error) {
+	var d Digest
+	h := sha256.New()
+	buf := make([]byte, 32<<10)
+	var n int64
+	for {
+		m, err := r.Read(buf)
+		// Fixed: Only the m bytes read this time.
+		h.Write(buf[:m])
+		n += int64(m)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return d, n, err
+		}
+	}
+	// Fixed: Sum appends the digest to its argument; start from nothing.
+	copy(d[:], h.Sum(nil))
+	return d, n, nil
+}
+
+// Verify reads r to the end and checks that its digest is want, 64 hex
+// characters. It returns ErrMismatch, with both digests, when it is not.
+func Verify(r io.Reader, want string) error {
+	// Fixed: Parse want, so a short or empty checksum is an error rather
+	// than a prefix of every digest.
+	wantDigest, err := ParseDigest(want)
+	if err != nil {
+		return err
+	}
+	got, _, err := Sum(r)
+	if err != nil {
+		return err
+	}
+	if got != wantDigest {
+		return fmt.Errorf("%w: got %s, want %s", ErrMismatch, got, wantDigest)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package solutions
+
+// SOLUTION: Fix the signature check of a webhook receiver.
+// SignBody uses HMAC instead of hashing the secret with the body,
+// VerifyBody compares whole MACs with hmac.Equal, and VerifyRequest limits
+// the body and puts it back for the handler.
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// SignatureHeader carries a request's signature: "sha256=" and the hex
+// HMAC-SHA256 of the body, the way GitHub signs its webhooks.
+const SignatureHeader = "X-Signature-256"
+
+const prefix = "sha256="
+
+// MaxBodySize is the largest body VerifyRequest reads.
+const MaxBodySize = 1 << 20
+
+// Errors returned by VerifyBody and VerifyRequest.
+var (
+	ErrNoSignature  = errors.New("no signature")
+	ErrBadSignature = errors.New("bad signature")
+	ErrTooLarge     = errors.New("body too large")
+)
+
+// SignBody returns the signature header value for body.
+func SignBody(secret, body []byte) string {
+	// Fixed: An HMAC, not sha256(secret + body), which anyone can extend
+	// with more body and a matching signature.
+	m := hmac.New(sha256.New, secret)
+	m.Write(body)
+	return prefix + hex.EncodeToString(m.Sum(nil))
+}
+
+// VerifyBody checks a signature header value made by SignBody.
+func VerifyBody(secret, body []byte, signature string) error {
+	if signature == "" {
+		return ErrNoSignature
+	}
+	hexMAC, ok := strings.CutPrefix(signature, prefix)
+	if !ok {
+		return fmt.Errorf("%w: want %s...", ErrBadSignature, prefix)
+	}
+	got, err := hex.DecodeString(hexMAC)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBadSignature, err)
+	}
+	want, _ := hex.DecodeString(strings.TrimPrefix(SignBody(secret, body), prefix))
+	// Fixed: Compare whole MACs, in constant time: hmac.Equal fails for
+	// one of another length.
+	if !hmac.Equal(got, want) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// VerifyRequest reads r's body, up to MaxBodySize, and checks its
+// signature. On success it returns the body and also puts it back in
+// r.Body, so the handler can read it again.
+func VerifyRequest(secret []byte, r *http.Request) ([]byte, error) {
+	// Fixed: Read at most one byte more than allowed, to know it was more.
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > MaxBodySize {
+		return nil, ErrTooLarge
+	}
+	if err := VerifyBody(secret, body, r.Header.Get(SignatureHeader)); err != nil {
+		return nil, err
+	}
+	// Fixed: The handler reads the body after us.
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
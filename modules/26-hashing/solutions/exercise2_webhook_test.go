@@ -0,0 +1,104 @@
+package solutions
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignBody(t *testing.T) {
+	// RFC 4231, test case 2.
+	assert.Equal(t, "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		SignBody([]byte("Jefe"), []byte("what do ya want for nothing?")))
+}
+
+func TestVerifyBody(t *testing.T) {
+	secret := []byte("webhook secret")
+	body := make([]byte, seed.Between(seed.Rand(t, 1), 10, 1000))
+	seed.Rand(t, 2).Read(body)
+	sig := SignBody(secret, body)
+
+	assert.NoError(t, VerifyBody(secret, body, sig))
+	assert.NoError(t, VerifyBody(secret, body, "sha256="+strings.ToUpper(strings.TrimPrefix(sig, "sha256="))))
+
+	assert.ErrorIs(t, VerifyBody(secret, body, ""), ErrNoSignature)
+	assert.ErrorIs(t, VerifyBody([]byte("another secret"), body, sig), ErrBadSignature)
+	assert.ErrorIs(t, VerifyBody(secret, append(body, '!'), sig), ErrBadSignature)
+	assert.ErrorIs(t, VerifyBody(secret, body, strings.TrimPrefix(sig, "sha256=")), ErrBadSignature)
+	assert.ErrorIs(t, VerifyBody(secret, body, "sha256=not hex"), ErrBadSignature)
+}
+
+func TestVerifyBodyTruncated(t *testing.T) {
+	secret, body := []byte("webhook secret"), []byte(`{"action":"opened"}`)
+	sig := SignBody(secret, body)
+	for _, bad := range []string{"sha256=", sig[:len("sha256=")+2], sig[:len(sig)-2], sig + "00"} {
+		assert.ErrorIs(t, VerifyBody(secret, body, bad), ErrBadSignature, "%q", bad)
+	}
+}
+
+// receiver is a webhook handler that checks the signature, then echoes
+// the body it reads itself.
+func receiver(secret []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := VerifyRequest(secret, r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		io.Copy(w, r.Body)
+	})
+}
+
+func TestVerifyRequest(t *testing.T) {
+	secret, body := []byte("webhook secret"), `{"action":"opened","number":42}`
+
+	req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(body))
+	req.Header.Set(SignatureHeader, SignBody(secret, []byte(body)))
+	rec := httptest.NewRecorder()
+	receiver(secret).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, body, rec.Body.String(), "the handler reads the whole body after VerifyRequest")
+
+	req = httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(body))
+	req.Header.Set(SignatureHeader, SignBody([]byte("guess"), []byte(body)))
+	rec = httptest.NewRecorder()
+	receiver(secret).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "bad signature\n", rec.Body.String())
+}
+
+// countingReader counts the bytes read from it.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestVerifyRequestSize(t *testing.T) {
+	secret := []byte("webhook secret")
+
+	body := bytes.Repeat([]byte("a"), MaxBodySize)
+	req := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewReader(body))
+	req.Header.Set(SignatureHeader, SignBody(secret, body))
+	got, err := VerifyRequest(secret, req)
+	require.NoError(t, err, "MaxBodySize bytes is allowed")
+	assert.Len(t, got, MaxBodySize)
+
+	huge := &countingReader{r: bytes.NewReader(make([]byte, 16*MaxBodySize))}
+	req = httptest.NewRequest(http.MethodPost, "/hook", huge)
+	req.Header.Set(SignatureHeader, SignBody(secret, nil))
+	_, err = VerifyRequest(secret, req)
+	assert.ErrorIs(t, err, ErrTooLarge)
+	assert.LessOrEqual(t, huge.n, MaxBodySize+1, "bytes read from a body that is too large")
+}
@@ -0,0 +1,117 @@
+// Package examples demonstrates symmetric encryption with AES-GCM, and
+// turning a passphrase into a key.
+//
+// AES-GCM is authenticated encryption: Seal encrypts and appends a tag
+// computed with the key, and Open refuses anything that was changed, cut,
+// or encrypted under another key. It needs a nonce, 12 bytes that must
+// never be used twice with the same key; a random nonce, sent along with
+// the ciphertext, is the usual choice.
+//
+// This file shows:
+// - aes.NewCipher and cipher.NewGCM, with a 16, 24, or 32-byte key
+// - Prepending a random nonce to the ciphertext, and splitting it off
+// - Additional data: bound to the ciphertext, but not encrypted
+// - What a reused nonce gives away
+package examples
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// ErrDecrypt is returned by Open for a ciphertext that was not sealed with
+// the key and additional data, or was changed since. It says nothing more,
+// on purpose.
+var ErrDecrypt = errors.New("message authentication failed")
+
+// NewKey returns a random 32-byte key, for AES-256.
+func NewKey() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key) // fails unless the key is 16, 24, or 32 bytes
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Seal encrypts plaintext with key, binding it to additional data, and
+// returns the nonce followed by the ciphertext and its tag.
+func Seal(key, plaintext, additional []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize(), gcm.NonceSize()+len(plaintext)+gcm.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	// Seal appends to its first argument: the nonce, so the result is
+	// nonce, ciphertext, tag, in one allocation.
+	return gcm.Seal(nonce, nonce, plaintext, additional), nil
+}
+
+// Open decrypts what Seal returned, with the same key and additional data.
+func Open(key, sealed, additional []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, ErrDecrypt
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, additional)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// DemonstrateAESGCM encrypts a message, and shows what Open refuses.
+func DemonstrateAESGCM() {
+	key := NewKey()
+	sealed, err := Seal(key, []byte("meet at noon"), []byte("to: ada"))
+	fmt.Printf("sealed: %d bytes for 12 of plaintext (12 nonce, 16 tag), %v\n", len(sealed), err)
+
+	again, _ := Seal(key, []byte("meet at noon"), []byte("to: ada"))
+	fmt.Println("the same message sealed twice looks different:", !bytes.Equal(sealed, again))
+
+	plain, err := Open(key, sealed, []byte("to: ada"))
+	fmt.Printf("opened: %q %v\n", plain, err)
+
+	tampered := append([]byte(nil), sealed...)
+	tampered[15] ^= 1
+	_, err = Open(key, tampered, []byte("to: ada"))
+	fmt.Println("one bit changed:", err)
+	_, err = Open(key, sealed, []byte("to: bob"))
+	fmt.Println("other additional data:", err)
+	_, err = Open(NewKey(), sealed, []byte("to: ada"))
+	fmt.Println("another key:", err)
+	_, err = Seal([]byte("short key"), []byte("x"), nil)
+	fmt.Println("a 9-byte key:", err)
+
+	// Reuse a nonce, and the XOR of two ciphertexts is the XOR of the two
+	// plaintexts: know one message, and you can read the other. GCM's
+	// authentication key leaks too, so messages can be forged.
+	gcm, _ := newGCM(key)
+	nonce := make([]byte, gcm.NonceSize())
+	a := gcm.Seal(nil, nonce, []byte("attack at dawn"), nil)
+	b := gcm.Seal(nil, nonce, []byte("secret plans!!"), nil)
+	known := []byte("attack at dawn")
+	recovered := make([]byte, len(known))
+	for i := range known {
+		recovered[i] = a[i] ^ b[i] ^ known[i]
+	}
+	fmt.Printf("with a reused nonce, the second message is %q\n", recovered)
+}
@@ -0,0 +1,49 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateAESGCM(t *testing.T) {
+	DemonstrateAESGCM()
+}
+
+func TestSealOpen(t *testing.T) {
+	key := NewKey()
+	plaintext := make([]byte, seed.Between(seed.Rand(t, 1), 0, 1000))
+	seed.Rand(t, 2).Read(plaintext)
+
+	sealed, err := Seal(key, plaintext, []byte("context"))
+	require.NoError(t, err)
+	assert.Len(t, sealed, 12+len(plaintext)+16)
+
+	got, err := Open(key, sealed, []byte("context"))
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, got[:len(plaintext)])
+
+	for i := range sealed {
+		tampered := append([]byte(nil), sealed...)
+		tampered[i] ^= 0x80
+		_, err := Open(key, tampered, []byte("context"))
+		assert.ErrorIs(t, err, ErrDecrypt, "byte %d changed", i)
+	}
+	_, err = Open(key, sealed[:len(sealed)-1], []byte("context"))
+	assert.ErrorIs(t, err, ErrDecrypt)
+	_, err = Open(key, sealed[:10], []byte("context"))
+	assert.ErrorIs(t, err, ErrDecrypt)
+	_, err = Open(key, sealed, nil)
+	assert.ErrorIs(t, err, ErrDecrypt)
+}
+
+func TestKeySizes(t *testing.T) {
+	for _, size := range []int{16, 24, 32} {
+		_, err := Seal(make([]byte, size), []byte("x"), nil)
+		assert.NoError(t, err, "%d-byte key", size)
+	}
+	_, err := Seal(make([]byte, 20), []byte("x"), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+package examples
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"time"
+)
+
+// PassphraseExamples demonstrates deriving a key from a passphrase.
+//
+// A passphrase is not a key: it is short, and people choose it. A key
+// derivation function stretches it into a key and makes every guess
+// expensive, with a random salt so that the same passphrase gives
+// different keys in different files, and one precomputed table cannot
+// attack them all. PBKDF2 is written out here, since the course supports
+// Go versions without crypto/pbkdf2 (Go 1.24). For new designs, scrypt or
+// Argon2 from golang.org/x/crypto make guesses cost memory as well as
+// time.
+
+// Iterations is how many times PBKDF2 runs HMAC-SHA256 for each block:
+// what OWASP recommends, and about a fifth of a second per guess on a
+// laptop.
+const Iterations = 600_000
+
+// SaltSize is the size of the random salt stored with each file.
+const SaltSize = 16
+
+// PBKDF2 derives a key of keyLen bytes from password and salt, as RFC 8018
+// defines it. Each block of the output is the XOR of iter chained PRF
+// outputs, the first of them over the salt and the block's number.
+func PBKDF2(h func() hash.Hash, password, salt []byte, iter, keyLen int) []byte {
+	prf := hmac.New(h, password)
+	var key []byte
+	u := make([]byte, 0, prf.Size())
+	for block := uint32(1); len(key) < keyLen; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(binary.BigEndian.AppendUint32(nil, block))
+		u = prf.Sum(u[:0])
+		t := append([]byte(nil), u...)
+		for i := 1; i < iter; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen]
+}
+
+// ErrFormat is returned by DecryptWithPassphrase for data too short to be
+// something EncryptWithPassphrase wrote.
+var ErrFormat = errors.New("not encrypted with a passphrase")
+
+// EncryptWithPassphrase encrypts plaintext with a key derived from
+// passphrase, and returns the salt followed by what Seal returned.
+func EncryptWithPassphrase(passphrase string, plaintext []byte) ([]byte, error) {
+	salt := make([]byte, SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	key := PBKDF2(sha256.New, []byte(passphrase), salt, Iterations, 32)
+	sealed, err := Seal(key, plaintext, salt)
+	if err != nil {
+		return nil, err
+	}
+	return append(salt, sealed...), nil
+}
+
+// DecryptWithPassphrase decrypts what EncryptWithPassphrase returned. A
+// wrong passphrase is ErrDecrypt, like any other change.
+func DecryptWithPassphrase(passphrase string, data []byte) ([]byte, error) {
+	if len(data) < SaltSize {
+		return nil, ErrFormat
+	}
+	salt := data[:SaltSize]
+	key := PBKDF2(sha256.New, []byte(passphrase), salt, Iterations, 32)
+	// The salt is also the additional data: it is not secret, but nobody
+	// may swap it.
+	return Open(key, data[SaltSize:], salt)
+}
+
+// DemonstratePassphrase derives keys, and encrypts with a passphrase.
+func DemonstratePassphrase() {
+	salt := []byte("a salt of 16 b..")
+	fmt.Printf("PBKDF2, 1 iteration:    %x\n", PBKDF2(sha256.New, []byte("hunter2"), salt, 1, 16))
+	start := time.Now()
+	fmt.Printf("PBKDF2, %d: %x\n", Iterations, PBKDF2(sha256.New, []byte("hunter2"), salt, Iterations, 16))
+	fmt.Println("one guess costs about", time.Since(start).Round(10*time.Millisecond))
+
+	data, err := EncryptWithPassphrase("correct horse battery staple", []byte("my notes"))
+	fmt.Printf("encrypted: %d bytes, %v\n", len(data), err)
+	plain, err := DecryptWithPassphrase("correct horse battery staple", data)
+	fmt.Printf("decrypted: %q %v\n", plain, err)
+	_, err = DecryptWithPassphrase("Tr0ub4dor&3", data)
+	fmt.Println("wrong passphrase:", err)
+}
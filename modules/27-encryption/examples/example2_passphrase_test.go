@@ -0,0 +1,52 @@
+package examples
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstratePassphrase(t *testing.T) {
+	DemonstratePassphrase()
+}
+
+func TestPBKDF2(t *testing.T) {
+	// PBKDF2-HMAC-SHA256 test vectors, in the style of RFC 6070.
+	tests := []struct {
+		password, salt string
+		iter, keyLen   int
+		want           string
+	}{
+		{"password", "salt", 1, 32, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{"password", "salt", 4096, 32, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+		{"passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, 40,
+			"348c89dbcbd32b2f32d814b8116e84cf2b17347ebc1800181c4e2a1fb8dd53e1c635518c7dac47e9"},
+	}
+	for _, tt := range tests {
+		got := PBKDF2(sha256.New, []byte(tt.password), []byte(tt.salt), tt.iter, tt.keyLen)
+		assert.Equal(t, tt.want, hex.EncodeToString(got), "%q %q %d", tt.password, tt.salt, tt.iter)
+	}
+}
+
+func TestEncryptWithPassphrase(t *testing.T) {
+	if testing.Short() {
+		t.Skip("derives keys with 600,000 iterations")
+	}
+	a, err := EncryptWithPassphrase("pass", []byte("secret"))
+	require.NoError(t, err)
+	b, err := EncryptWithPassphrase("pass", []byte("secret"))
+	require.NoError(t, err)
+	assert.NotEqual(t, a[:SaltSize], b[:SaltSize], "every file gets its own salt")
+
+	plain, err := DecryptWithPassphrase("pass", a)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(plain))
+
+	_, err = DecryptWithPassphrase("Pass", a)
+	assert.ErrorIs(t, err, ErrDecrypt)
+	_, err = DecryptWithPassphrase("pass", a[:5])
+	assert.ErrorIs(t, err, ErrFormat)
+}
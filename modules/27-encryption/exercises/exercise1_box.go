@@ -0,0 +1,80 @@
+package exercises
+
+// EXERCISE: Fix a Box that encrypts records with AES-GCM.
+// A service keeps secrets in a database, each encrypted under one key.
+// This Box accepts keys of any size, reuses one nonce for every record,
+// lets a ciphertext be moved to another record, and hands back nothing,
+// without an error, for a ciphertext someone changed.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+)
+
+// KeySize is the size of a Box's key: AES-256.
+const KeySize = 32
+
+// Errors returned by NewBox and Open.
+var (
+	ErrKeySize = errors.New("key must be 32 bytes")
+	ErrDecrypt = errors.New("message authentication failed")
+)
+
+// Box encrypts the records of a database, each under the same key. The
+// ID of a record is bound to its ciphertext, so a value copied into
+// another record does not open there.
+type Box struct {
+	gcm   cipher.AEAD
+	nonce []byte
+}
+
+// NewBox returns a Box that encrypts with key, which must be KeySize
+// random bytes.
+func NewBox(key []byte) (*Box, error) {
+	// BUG: A short key is padded with zeros, so a 1-byte key "works".
+	// Return ErrKeySize unless len(key) is KeySize.
+	padded := make([]byte, KeySize)
+	copy(padded, key)
+	block, err := aes.NewCipher(padded)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return &Box{gcm: gcm, nonce: nonce}, nil
+}
+
+// Seal encrypts the value of the record id, and returns a nonce followed
+// by the ciphertext.
+func (b *Box) Seal(id string, value []byte) ([]byte, error) {
+	// BUG: The nonce is random, but the same for every message. A nonce
+	// used twice with the same key gives away the XOR of the two values,
+	// and the key GCM uses to authenticate. Make a new one for each call.
+	nonce := b.nonce
+	// BUG: Nothing ties the ciphertext to id. Pass []byte(id) as the
+	// additional data, here and in Open.
+	return b.gcm.Seal(append([]byte(nil), nonce...), nonce, value, nil), nil
+}
+
+// Open decrypts what Seal returned for the record id. Anything else, or
+// anything changed, is ErrDecrypt.
+func (b *Box) Open(id string, sealed []byte) ([]byte, error) {
+	n := b.gcm.NonceSize()
+	if len(sealed) < n+b.gcm.Overhead() {
+		return nil, ErrDecrypt
+	}
+	// BUG: The error says the ciphertext was changed, or is not for this
+	// key, and it is dropped: the caller gets a nil value and no error.
+	// Return ErrDecrypt.
+	value, _ := b.gcm.Open(nil, sealed[:n], sealed[n:], nil)
+	return value, nil
+}
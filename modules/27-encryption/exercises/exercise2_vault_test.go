@@ -0,0 +1,94 @@
+package exercises
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastIterations lowers Iterations for the rest of the test.
+func fastIterations(t *testing.T) {
+	old := Iterations
+	Iterations = 1000
+	t.Cleanup(func() { Iterations = old })
+}
+
+func TestPBKDF2(t *testing.T) {
+	// PBKDF2-HMAC-SHA256 test vectors, in the style of RFC 6070.
+	tests := []struct {
+		password, salt string
+		iter, keyLen   int
+		want           string
+	}{
+		{"password", "salt", 1, 32, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{"password", "salt", 2, 32, "ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43"},
+		{"password", "salt", 4096, 32, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+		{"passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, 40,
+			"348c89dbcbd32b2f32d814b8116e84cf2b17347ebc1800181c4e2a1fb8dd53e1c635518c7dac47e9"},
+	}
+	for _, tt := range tests {
+		got := PBKDF2([]byte(tt.password), []byte(tt.salt), tt.iter, tt.keyLen)
+		assert.Equal(t, tt.want, hex.EncodeToString(got), "%q %q %d", tt.password, tt.salt, tt.iter)
+	}
+}
+
+func TestVault(t *testing.T) {
+	fastIterations(t)
+	plaintext := make([]byte, seed.Between(seed.Rand(t, 1), 0, 2000))
+	seed.Rand(t, 2).Read(plaintext)
+
+	data, err := EncryptFile("correct horse", plaintext)
+	require.NoError(t, err)
+	assert.Equal(t, byte(Version), data[0])
+	assert.Len(t, data, 1+SaltSize+12+len(plaintext)+16)
+
+	got, err := DecryptFile("correct horse", data)
+	require.NoError(t, err)
+	assert.Equal(t, string(plaintext), string(got))
+
+	_, err = DecryptFile("Correct horse", data)
+	assert.ErrorIs(t, err, ErrDecrypt)
+}
+
+func TestVaultSalt(t *testing.T) {
+	fastIterations(t)
+	a, err := EncryptFile("pass", []byte("secret"))
+	require.NoError(t, err)
+	b, err := EncryptFile("pass", []byte("secret"))
+	require.NoError(t, err)
+
+	saltA, saltB := a[1:1+SaltSize], b[1:1+SaltSize]
+	assert.NotEqual(t, make([]byte, SaltSize), saltA, "the salt is all zeros")
+	assert.NotEqual(t, saltA, saltB, "two files share a salt")
+}
+
+func TestVaultTampered(t *testing.T) {
+	fastIterations(t)
+	data, err := EncryptFile("pass", []byte("secret notes"))
+	require.NoError(t, err)
+	for i := 1; i < len(data); i++ {
+		tampered := append([]byte(nil), data...)
+		tampered[i] ^= 1
+		_, err := DecryptFile("pass", tampered)
+		assert.ErrorIs(t, err, ErrDecrypt, "byte %d changed", i)
+	}
+}
+
+func TestVaultFormat(t *testing.T) {
+	fastIterations(t)
+	data, err := EncryptFile("pass", []byte("secret"))
+	require.NoError(t, err)
+
+	for _, short := range [][]byte{nil, data[:1], data[:1+SaltSize+11]} {
+		_, err := DecryptFile("pass", short)
+		assert.ErrorIs(t, err, ErrFormat, "%d bytes", len(short))
+	}
+	for _, version := range []byte{0, 2, 255} {
+		other := append([]byte{version}, data[1:]...)
+		_, err := DecryptFile("pass", other)
+		assert.ErrorIs(t, err, ErrFormat, "version %d", version)
+	}
+}
@@ -0,0 +1,78 @@
+package solutions
+
+// SOLUTION: Fix a Box that encrypts records with AES-GCM.
+// NewBox rejects keys of the wrong size, Seal uses a new random nonce for
+// every message, both methods pass the record's ID as additional data,
+// and Open returns ErrDecrypt when authentication fails.
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// KeySize is the size of a Box's key: AES-256.
+const KeySize = 32
+
+// Errors returned by NewBox and Open.
+var (
+	ErrKeySize = errors.New("key must be 32 bytes")
+	ErrDecrypt = errors.New("message authentication failed")
+)
+
+// Box encrypts the records of a database, each under the same key. The
+// ID of a record is bound to its ciphertext, so a value copied into
+// another record does not open there.
+type Box struct {
+	gcm cipher.AEAD
+}
+
+// NewBox returns a Box that encrypts with key, which must be KeySize
+// random bytes.
+func NewBox(key []byte) (*Box, error) {
+	// Fixed: A key of the wrong size is an error, not padded with zeros.
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%w, got %d", ErrKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Box{gcm: gcm}, nil
+}
+
+// Seal encrypts the value of the record id, and returns a nonce followed
+// by the ciphertext.
+func (b *Box) Seal(id string, value []byte) ([]byte, error) {
+	// Fixed: A new nonce for every message. One used twice with the same
+	// key gives away the XOR of the two values, and the key GCM uses to
+	// authenticate.
+	nonce := make([]byte, b.gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	// Fixed: Bind the ID to the ciphertext.
+	return b.gcm.Seal(nonce, nonce, value, []byte(id)), nil
+}
+
+// Open decrypts what Seal returned for the record id. Anything else, or
+// anything changed, is ErrDecrypt.
+func (b *Box) Open(id string, sealed []byte) ([]byte, error) {
+	n := b.gcm.NonceSize()
+	if len(sealed) < n+b.gcm.Overhead() {
+		return nil, ErrDecrypt
+	}
+	// Fixed: The same additional data as Seal.
+	value, err := b.gcm.Open(nil, sealed[:n], sealed[n:], []byte(id))
+	// Fixed: A value that fails authentication is no value at all.
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return value, nil
+}
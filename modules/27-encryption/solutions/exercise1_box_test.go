@@ -0,0 +1,83 @@
+package solutions
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBox(t *testing.T) *Box {
+	t.Helper()
+	key := make([]byte, KeySize)
+	rand.Read(key)
+	b, err := NewBox(key)
+	require.NoError(t, err)
+	return b
+}
+
+func TestNewBoxKeySize(t *testing.T) {
+	for _, size := range []int{0, 1, 16, 24, 31, 33, 64} {
+		_, err := NewBox(make([]byte, size))
+		assert.ErrorIs(t, err, ErrKeySize, "%d-byte key", size)
+	}
+}
+
+func TestBoxSealOpen(t *testing.T) {
+	b := newTestBox(t)
+	r := seed.Rand(t, 1)
+	for i := 0; i < 10; i++ {
+		value := make([]byte, seed.Between(r, 1, 500))
+		r.Read(value)
+		id := fmt.Sprintf("user/%d", i)
+
+		sealed, err := b.Seal(id, value)
+		require.NoError(t, err)
+		got, err := b.Open(id, sealed)
+		require.NoError(t, err)
+		assert.Equal(t, value, got)
+	}
+}
+
+func TestBoxNonces(t *testing.T) {
+	b := newTestBox(t)
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		sealed, err := b.Seal("user/1", []byte("the same value"))
+		require.NoError(t, err)
+		nonce := string(sealed[:12])
+		require.False(t, seen[nonce], "nonce reused after %d messages", i)
+		seen[nonce] = true
+	}
+}
+
+func TestBoxTampered(t *testing.T) {
+	b := newTestBox(t)
+	sealed, err := b.Seal("user/1", []byte("api key 123"))
+	require.NoError(t, err)
+
+	for i := range sealed {
+		tampered := append([]byte(nil), sealed...)
+		tampered[i] ^= 1
+		value, err := b.Open("user/1", tampered)
+		assert.Nil(t, value)
+		assert.ErrorIs(t, err, ErrDecrypt, "byte %d changed", i)
+	}
+	for _, short := range [][]byte{nil, sealed[:12], sealed[:len(sealed)-1]} {
+		_, err := b.Open("user/1", short)
+		assert.ErrorIs(t, err, ErrDecrypt, "%d bytes", len(short))
+	}
+	_, err = newTestBox(t).Open("user/1", sealed)
+	assert.ErrorIs(t, err, ErrDecrypt, "another key")
+}
+
+func TestBoxOtherRecord(t *testing.T) {
+	b := newTestBox(t)
+	sealed, err := b.Seal("user/1", []byte("api key 123"))
+	require.NoError(t, err)
+	_, err = b.Open("user/2", sealed)
+	assert.ErrorIs(t, err, ErrDecrypt, "a value copied into another record")
+}
@@ -0,0 +1,116 @@
+package solutions
+
+// SOLUTION: Fix a vault that encrypts files with a passphrase.
+// PBKDF2 numbers its blocks from 1 and XORs every round into the block,
+// EncryptFile fills the salt with random bytes, and DecryptFile checks the
+// version before reading the rest.
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// A vault file is a version byte, a salt, and then a nonce and the
+// ciphertext as AES-256-GCM produces them. The key is derived from the
+// passphrase and the salt with PBKDF2-HMAC-SHA256.
+const (
+	Version   = 1
+	SaltSize  = 16
+	nonceSize = 12
+	headerLen = 1 + SaltSize
+)
+
+// Iterations is the PBKDF2 iteration count. Tests lower it, to run fast.
+var Iterations = 600_000
+
+// ErrFormat is returned by DecryptFile for data that is not a vault file
+// of this version.
+var ErrFormat = errors.New("not a vault file")
+
+// PBKDF2 derives a key of keyLen bytes from password and salt with
+// HMAC-SHA256, as RFC 8018 defines it: block i of the key is
+// U1 ^ U2 ^ ... ^ Uiter, where U1 = HMAC(password, salt || i as four
+// big-endian bytes) and each following U is the HMAC of the one before.
+func PBKDF2(password, salt []byte, iter, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	var key []byte
+	// Fixed: Blocks are numbered from 1.
+	for block := uint32(1); len(key) < keyLen; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(binary.BigEndian.AppendUint32(nil, block))
+		u := prf.Sum(nil)
+		t := append([]byte(nil), u...)
+		for i := 1; i < iter; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			// Fixed: XOR every round into t.
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen]
+}
+
+func vaultGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key := PBKDF2([]byte(passphrase), salt, Iterations, 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptFile encrypts plaintext with passphrase, and returns the vault
+// file.
+func EncryptFile(passphrase string, plaintext []byte) ([]byte, error) {
+	out := make([]byte, headerLen+nonceSize, headerLen+nonceSize+len(plaintext)+16)
+	out[0] = Version
+	salt, nonce := out[1:headerLen], out[headerLen:]
+	// Fixed: A random salt, so that one passphrase gives a different key
+	// in every file.
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	gcm, err := vaultGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	// The header is the additional data: nobody can change the version or
+	// the salt without Open noticing.
+	return gcm.Seal(out, nonce, plaintext, out[:headerLen]), nil
+}
+
+// DecryptFile decrypts a vault file. A wrong passphrase and a changed file
+// both fail with ErrDecrypt.
+func DecryptFile(passphrase string, data []byte) ([]byte, error) {
+	if len(data) < headerLen+nonceSize {
+		return nil, ErrFormat
+	}
+	// Fixed: Check the version before trusting the rest of the format.
+	if data[0] != Version {
+		return nil, fmt.Errorf("%w: version %d", ErrFormat, data[0])
+	}
+	gcm, err := vaultGCM(passphrase, data[1:headerLen])
+	if err != nil {
+		return nil, err
+	}
+	nonce, ciphertext := data[headerLen:headerLen+nonceSize], data[headerLen+nonceSize:]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, data[:headerLen])
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
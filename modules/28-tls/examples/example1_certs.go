@@ -0,0 +1,155 @@
+// Package examples demonstrates TLS in Go: making certificates, serving
+// HTTPS, trusting a private certificate authority, and mutual TLS, where
+// the client proves who it is too.
+//
+// Everything runs over loopback with certificates made on the spot by a
+// certificate authority (CA) of our own, the way tests and internal
+// networks do it. Public servers get their certificates from a public CA
+// such as Let's Encrypt instead, and clients trust those through the
+// system's root pool.
+//
+// This file shows:
+// - Making a CA, and server and client certificates signed by it
+// - Subject alternative names: the names a server certificate is valid for
+// - PEM encoding, the format of .crt and .key files
+// - Verifying a certificate chain with x509.Certificate.Verify
+package examples
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+)
+
+// CA is a certificate authority that signs certificates for tests and
+// private networks.
+type CA struct {
+	Cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// NewCA makes a CA called name, valid for a day.
+func NewCA(name string) (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: name},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	cert, err := sign(template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	return &CA{Cert: cert, key: key}, nil
+}
+
+// sign fills in what every certificate needs and signs template with
+// the parent's key.
+func sign(template, parent *x509.Certificate, pub *ecdsa.PublicKey, parentKey *ecdsa.PrivateKey) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template.SerialNumber = serial
+	// A little in the past, for clocks that are behind.
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(24 * time.Hour)
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, parentKey)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+// issue makes a key pair and a certificate for it signed by the CA.
+func (ca *CA) issue(template *x509.Certificate) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	cert, err := sign(template, ca.Cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{cert.Raw}, PrivateKey: key, Leaf: cert}, nil
+}
+
+// IssueServer returns a server certificate for hosts, each a DNS name or
+// an IP address.
+func (ca *CA) IssueServer(hosts ...string) (tls.Certificate, error) {
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: hosts[0]},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	// Clients check these, not the common name.
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+	return ca.issue(template)
+}
+
+// IssueClient returns a client certificate for mutual TLS, with name as
+// its common name.
+func (ca *CA) IssueClient(name string) (tls.Certificate, error) {
+	return ca.issue(&x509.Certificate{
+		Subject:     pkix.Name{CommonName: name},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+}
+
+// Pool returns a pool that trusts the CA, and nothing else.
+func (ca *CA) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Cert)
+	return pool
+}
+
+// PEM returns the CA's certificate as PEM, the format of a .crt file.
+func (ca *CA) PEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Cert.Raw})
+}
+
+// DemonstrateCerts makes a CA and a server certificate, and verifies it.
+func DemonstrateCerts() {
+	ca, err := NewCA("learngo test CA")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	cert, err := ca.IssueServer("shapes.internal", "127.0.0.1")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	leaf := cert.Leaf
+	fmt.Printf("issued by %q for %v and %v, until %s\n",
+		leaf.Issuer.CommonName, leaf.DNSNames, leaf.IPAddresses, leaf.NotAfter.Format(time.DateOnly))
+
+	_, err = leaf.Verify(x509.VerifyOptions{DNSName: "shapes.internal", Roots: ca.Pool()})
+	fmt.Println("verify for shapes.internal:", err)
+	_, err = leaf.Verify(x509.VerifyOptions{DNSName: "example.com", Roots: ca.Pool()})
+	fmt.Println("verify for example.com:", err)
+	other, _ := NewCA("someone else's CA")
+	_, err = leaf.Verify(x509.VerifyOptions{DNSName: "shapes.internal", Roots: other.Pool()})
+	fmt.Println("verify with another CA:", err)
+
+	pemCert := ca.PEM()
+	fmt.Printf("the CA as PEM, %d bytes: %s...\n", len(pemCert), pemCert[:27])
+}
@@ -0,0 +1,61 @@
+package examples
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateCerts(t *testing.T) {
+	DemonstrateCerts()
+}
+
+func TestIssueServer(t *testing.T) {
+	ca, err := NewCA("test CA")
+	require.NoError(t, err)
+	cert, err := ca.IssueServer("shapes.internal", "localhost", "127.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shapes.internal", "localhost"}, cert.Leaf.DNSNames)
+	assert.True(t, cert.Leaf.IPAddresses[0].Equal(net.IPv4(127, 0, 0, 1)))
+
+	for _, name := range []string{"shapes.internal", "localhost", "127.0.0.1"} {
+		_, err := cert.Leaf.Verify(x509.VerifyOptions{DNSName: name, Roots: ca.Pool()})
+		assert.NoError(t, err, name)
+	}
+	_, err = cert.Leaf.Verify(x509.VerifyOptions{DNSName: "other.internal", Roots: ca.Pool()})
+	assert.Error(t, err)
+
+	_, err = cert.Leaf.Verify(x509.VerifyOptions{DNSName: "shapes.internal", Roots: x509.NewCertPool()})
+	var unknown x509.UnknownAuthorityError
+	assert.ErrorAs(t, err, &unknown)
+}
+
+func TestIssueClient(t *testing.T) {
+	ca, err := NewCA("test CA")
+	require.NoError(t, err)
+	cert, err := ca.IssueClient("worker-7")
+	require.NoError(t, err)
+	assert.Equal(t, "worker-7", cert.Leaf.Subject.CommonName)
+
+	_, err = cert.Leaf.Verify(x509.VerifyOptions{Roots: ca.Pool(), KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	assert.NoError(t, err)
+	_, err = cert.Leaf.Verify(x509.VerifyOptions{Roots: ca.Pool()})
+	assert.Error(t, err, "a client certificate is not for servers")
+}
+
+func TestPEM(t *testing.T) {
+	ca, err := NewCA("test CA")
+	require.NoError(t, err)
+	block, rest := pem.Decode(ca.PEM())
+	require.NotNil(t, block)
+	assert.Empty(t, rest)
+	assert.Equal(t, "CERTIFICATE", block.Type)
+	assert.Equal(t, ca.Cert.Raw, block.Bytes)
+
+	pool := x509.NewCertPool()
+	assert.True(t, pool.AppendCertsFromPEM(ca.PEM()))
+}
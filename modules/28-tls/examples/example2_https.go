@@ -0,0 +1,99 @@
+package examples
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"time"
+)
+
+// HTTPSExamples demonstrates serving HTTPS and calling it.
+//
+// The server needs a certificate and its key; the client needs to trust
+// the CA that signed it, and checks that the certificate is for the name
+// it asked for. That name is sent in the handshake too, as SNI (server
+// name indication), so one address can serve several names. A client
+// normally takes it from the URL; tls.Config.ServerName overrides it, for
+// when the address you dial is not the name on the certificate.
+
+// ServeTLS serves h over TLS with config on a random loopback port. It
+// returns the server's URL, and a function that shuts the server down.
+func ServeTLS(config *tls.Config, h http.Handler) (string, func(), error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+	srv := &http.Server{
+		Handler:           h,
+		TLSConfig:         config,
+		ReadHeaderTimeout: 5 * time.Second,
+		// Failed handshakes are the point of the demonstrations; do not
+		// log them.
+		ErrorLog: log.New(io.Discard, "", 0),
+	}
+	// The certificates are in TLSConfig, so no file names are needed.
+	go srv.ServeTLS(ln, "", "")
+	return "https://" + ln.Addr().String(), func() { srv.Close() }, nil
+}
+
+// ServerConfig returns a server config with cert, and TLS 1.2 at least.
+func ServerConfig(cert tls.Certificate) *tls.Config {
+	return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+}
+
+// NewClient returns a client that trusts roots, and nothing else. When
+// serverName is not empty, it is the name the client asks for and checks
+// the certificate against, instead of the host in the URL.
+func NewClient(roots *x509.CertPool, serverName string) *http.Client {
+	// Clone the default transport for its timeouts and proxy settings;
+	// changing http.DefaultTransport itself would change every client in
+	// the program.
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = &tls.Config{RootCAs: roots, ServerName: serverName, MinVersion: tls.VersionTLS12}
+	return &http.Client{Transport: t, Timeout: 10 * time.Second}
+}
+
+// hello reports the TLS connection the request came over.
+func hello(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, "hello over %s to %q", tls.VersionName(r.TLS.Version), r.TLS.ServerName)
+}
+
+// get fetches url with c, and returns the body or the error.
+func get(c *http.Client, url string) string {
+	resp, err := c.Get(url)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+// DemonstrateHTTPS serves HTTPS with a certificate for a name, and calls
+// it with clients that get it right and wrong.
+func DemonstrateHTTPS() {
+	ca, err := NewCA("learngo test CA")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	cert, err := ca.IssueServer("shapes.internal")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	url, stop, err := ServeTLS(ServerConfig(cert), http.HandlerFunc(hello))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer stop()
+
+	fmt.Println("default client:", get(http.DefaultClient, url))
+	fmt.Println("trusting the CA, checking 127.0.0.1:", get(NewClient(ca.Pool(), ""), url))
+	fmt.Println("trusting the CA, checking shapes.internal:", get(NewClient(ca.Pool(), "shapes.internal"), url))
+}
@@ -0,0 +1,41 @@
+package examples
+
+import (
+	"crypto/tls"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateHTTPS(t *testing.T) {
+	DemonstrateHTTPS()
+}
+
+func TestServeTLS(t *testing.T) {
+	ca, err := NewCA("test CA")
+	require.NoError(t, err)
+	cert, err := ca.IssueServer("shapes.internal")
+	require.NoError(t, err)
+	url, stop, err := ServeTLS(ServerConfig(cert), http.HandlerFunc(hello))
+	require.NoError(t, err)
+	defer stop()
+	assert.True(t, strings.HasPrefix(url, "https://127.0.0.1:"))
+
+	assert.Equal(t, `hello over TLS 1.3 to "shapes.internal"`, get(NewClient(ca.Pool(), "shapes.internal"), url))
+	assert.Contains(t, get(NewClient(ca.Pool(), ""), url), "cannot validate certificate for 127.0.0.1")
+	assert.Contains(t, get(NewClient(ca.Pool(), "other.internal"), url), "not other.internal")
+
+	other, err := NewCA("other CA")
+	require.NoError(t, err)
+	assert.Contains(t, get(NewClient(other.Pool(), "shapes.internal"), url), "certificate signed by unknown authority")
+
+	old := NewClient(ca.Pool(), "shapes.internal")
+	old.Transport.(*http.Transport).TLSClientConfig.MaxVersion = tls.VersionTLS12
+	assert.Equal(t, `hello over TLS 1.2 to "shapes.internal"`, get(old, url))
+	if config := http.DefaultTransport.(*http.Transport).TLSClientConfig; config != nil {
+		assert.Nil(t, config.RootCAs, "NewClient leaves the default transport alone")
+	}
+}
@@ -0,0 +1,44 @@
+package examples
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateMutualTLS(t *testing.T) {
+	DemonstrateMutualTLS()
+}
+
+func TestMutualTLS(t *testing.T) {
+	serverCA, err := NewCA("server CA")
+	require.NoError(t, err)
+	clientCA, err := NewCA("client CA")
+	require.NoError(t, err)
+	serverCert, err := serverCA.IssueServer("shapes.internal")
+	require.NoError(t, err)
+	url, stop, err := ServeTLS(MutualTLSConfig(serverCert, clientCA.Pool()),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, ClientName(r))
+		}))
+	require.NoError(t, err)
+	defer stop()
+
+	worker, err := clientCA.IssueClient("worker-7")
+	require.NoError(t, err)
+	assert.Equal(t, "worker-7", get(NewMutualClient(serverCA.Pool(), "shapes.internal", worker), url))
+
+	assert.Contains(t, get(NewClient(serverCA.Pool(), "shapes.internal"), url), "certificate required")
+	rogue, err := serverCA.IssueClient("admin")
+	require.NoError(t, err)
+	assert.Contains(t, get(NewMutualClient(serverCA.Pool(), "shapes.internal", rogue), url), "certificate required")
+}
+
+func TestClientNameWithoutTLS(t *testing.T) {
+	r, err := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+	require.NoError(t, err)
+	assert.Empty(t, ClientName(r))
+}
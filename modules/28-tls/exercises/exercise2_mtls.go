@@ -0,0 +1,74 @@
+package exercises
+
+// EXERCISE: Fix a server that authenticates its clients with mutual TLS.
+// Each client of an internal service has a certificate from the clients'
+// CA, and the service decides what a client may do by the name in it.
+// This server accepts certificates nobody signed, crashes on plain HTTP,
+// reads the name from the wrong certificate, lets in clients whose name
+// merely starts with an allowed one, and runs the handler after refusing
+// the request.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrNoClientCert is returned by Identity for a request that did not come
+// with a verified client certificate.
+var ErrNoClientCert = errors.New("no verified client certificate")
+
+// ServerConfig returns the TLS config of a server that presents cert and
+// accepts only clients with a certificate signed by one of clientCAs.
+func ServerConfig(cert tls.Certificate, clientCAs *x509.CertPool) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		// BUG: The server asks for a certificate, but takes none, or one
+		// signed by anyone. Use tls.RequireAndVerifyClientCert, and set
+		// ClientCAs.
+		ClientAuth: tls.RequestClientCert,
+	}
+}
+
+// Identity returns the common name of the client certificate the server
+// verified for r.
+func Identity(r *http.Request) (string, error) {
+	// BUG: r.TLS is nil for a request over plain HTTP, and this panics.
+	// Return ErrNoClientCert when r.TLS is nil or has no VerifiedChains.
+	certs := r.TLS.PeerCertificates
+	if len(certs) == 0 {
+		return "", ErrNoClientCert
+	}
+	// BUG: A client may send its CA's certificate after its own, and the
+	// last one is then the CA. PeerCertificates is also what the client
+	// sent, checked or not: take r.TLS.VerifiedChains[0][0], the leaf of
+	// the chain the server verified.
+	return certs[len(certs)-1].Subject.CommonName, nil
+}
+
+// Authorize lets requests from clients named in allowed through to next.
+// Others get 401 Unauthorized without a certificate, and 403 Forbidden
+// with one.
+func Authorize(allowed []string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, err := Identity(r)
+		if err != nil {
+			// BUG: The client gets a 401, and then next runs anyway.
+			// Return after the error.
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		}
+		for _, a := range allowed {
+			// BUG: "worker-77" and "worker-7.evil" pass for "worker-7".
+			// Compare the whole name.
+			if strings.HasPrefix(name, a) {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		http.Error(w, name+" may not do this", http.StatusForbidden)
+	})
+}
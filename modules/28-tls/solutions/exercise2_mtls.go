@@ -0,0 +1,64 @@
+package solutions
+
+// SOLUTION: Fix a server that authenticates its clients with mutual TLS.
+// ServerConfig requires and verifies client certificates against
+// clientCAs, Identity checks for a TLS connection and reads the name from
+// the verified chain's leaf, and Authorize compares whole names and stops
+// after refusing a request.
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+)
+
+// ErrNoClientCert is returned by Identity for a request that did not come
+// with a verified client certificate.
+var ErrNoClientCert = errors.New("no verified client certificate")
+
+// ServerConfig returns the TLS config of a server that presents cert and
+// accepts only clients with a certificate signed by one of clientCAs.
+func ServerConfig(cert tls.Certificate, clientCAs *x509.CertPool) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		// Fixed: Require a certificate, and verify it against clientCAs.
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+}
+
+// Identity returns the common name of the client certificate the server
+// verified for r.
+func Identity(r *http.Request) (string, error) {
+	// Fixed: A request over plain HTTP has no TLS state.
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		return "", ErrNoClientCert
+	}
+	// Fixed: The leaf of the verified chain: PeerCertificates is what the
+	// client sent, in any order and unchecked.
+	return r.TLS.VerifiedChains[0][0].Subject.CommonName, nil
+}
+
+// Authorize lets requests from clients named in allowed through to next.
+// Others get 401 Unauthorized without a certificate, and 403 Forbidden
+// with one.
+func Authorize(allowed []string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, err := Identity(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			// Fixed: Stop here.
+			return
+		}
+		for _, a := range allowed {
+			// Fixed: The whole name.
+			if name == a {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		http.Error(w, name+" may not do this", http.StatusForbidden)
+	})
+}
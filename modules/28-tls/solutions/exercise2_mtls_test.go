@@ -0,0 +1,119 @@
+package solutions
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// whoami answers with the client's name.
+var whoami = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	name, err := Identity(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	io.WriteString(w, name)
+})
+
+// mutualClient returns a client that trusts ca and presents certs, if any.
+func mutualClient(ca *testCA, certs ...tls.Certificate) *http.Client {
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs:      ca.pool(),
+		Certificates: certs,
+	}}}
+}
+
+func TestServerConfigRequiresCert(t *testing.T) {
+	servers, clients := newTestCA(t, "server CA"), newTestCA(t, "client CA")
+	rogue := newTestCA(t, "rogue CA")
+	url := serveTLS(t, ServerConfig(servers.server(t, "shapes.internal"), clients.pool()), whoami)
+
+	body, err := getBody(t, mutualClient(servers, clients.client(t, "worker-7")), url)
+	require.NoError(t, err)
+	assert.Equal(t, "worker-7", body)
+
+	_, err = getBody(t, mutualClient(servers), url)
+	assert.Error(t, err, "a client without a certificate must be refused")
+
+	// The server does not name the rogue CA, but a client may send a
+	// certificate anyway.
+	cert := rogue.client(t, "worker-7")
+	c := mutualClient(servers)
+	c.Transport.(*http.Transport).TLSClientConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		return &cert, nil
+	}
+	_, err = getBody(t, c, url)
+	assert.Error(t, err, "a certificate from another CA must be refused")
+}
+
+func TestIdentityVerifiedLeaf(t *testing.T) {
+	servers, clients := newTestCA(t, "server CA"), newTestCA(t, "client CA")
+	url := serveTLS(t, ServerConfig(servers.server(t, "shapes.internal"), clients.pool()), whoami)
+
+	// Clients often send the certificates that lead to the root too.
+	cert := clients.client(t, "worker-7")
+	cert.Certificate = append(cert.Certificate, clients.cert.Raw)
+	body, err := getBody(t, mutualClient(servers, cert), url)
+	require.NoError(t, err)
+	assert.Equal(t, "worker-7", body)
+}
+
+func TestIdentityWithoutTLS(t *testing.T) {
+	var err error
+	assert.NotPanics(t, func() {
+		_, err = Identity(httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	assert.ErrorIs(t, err, ErrNoClientCert)
+
+	// A TLS connection, but no certificate.
+	r := httptest.NewRequest(http.MethodGet, "https://shapes.internal/", nil)
+	require.NotNil(t, r.TLS)
+	assert.NotPanics(t, func() { _, err = Identity(r) })
+	assert.ErrorIs(t, err, ErrNoClientCert)
+}
+
+func TestAuthorize(t *testing.T) {
+	servers, clients := newTestCA(t, "server CA"), newTestCA(t, "client CA")
+	var calls int
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		io.WriteString(w, "ok")
+	})
+	url := serveTLS(t, ServerConfig(servers.server(t, "shapes.internal"), clients.pool()),
+		Authorize([]string{"worker-7", "admin"}, next))
+
+	for name, want := range map[string]int{
+		"worker-7":      http.StatusOK,
+		"admin":         http.StatusOK,
+		"worker-77":     http.StatusForbidden,
+		"worker-7.evil": http.StatusForbidden,
+		"worker":        http.StatusForbidden,
+		"":              http.StatusForbidden,
+	} {
+		calls = 0
+		resp, err := mutualClient(servers, clients.client(t, name)).Get(url)
+		require.NoError(t, err, name)
+		resp.Body.Close()
+		assert.Equal(t, want, resp.StatusCode, "%q", name)
+		if want == http.StatusOK {
+			assert.Equal(t, 1, calls, "%q", name)
+		} else {
+			assert.Zero(t, calls, "%q must not reach the handler", name)
+		}
+	}
+
+	// Over plain HTTP, there is no certificate at all.
+	calls = 0
+	w := httptest.NewRecorder()
+	assert.NotPanics(t, func() {
+		Authorize([]string{"worker-7"}, next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Zero(t, calls, "a request without a certificate must not reach the handler")
+}
@@ -0,0 +1,79 @@
+// Package examples demonstrates handling secrets in Go: making tokens
+// nobody can guess, comparing them without leaking their contents through
+// timing, keeping them out of logs and error messages, and reading them
+// from the environment or from files.
+//
+// This file shows:
+// - Random tokens from crypto/rand, and why math/rand will not do
+// - Encoding tokens for URLs and headers with base64.RawURLEncoding
+// - Random numbers in a range without modulo bias, with rand.Int
+// - Comparing secrets in constant time with crypto/subtle
+package examples
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	mrand "math/rand"
+)
+
+// TokenBytes is the size of a token: 256 random bits, which nobody will
+// ever guess.
+const TokenBytes = 32
+
+// NewToken returns a random token that is safe in a URL, a header, or a
+// file name.
+func NewToken() (string, error) {
+	b := make([]byte, TokenBytes)
+	// crypto/rand reads the operating system's generator, which is
+	// unpredictable even to someone who has seen every earlier value.
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// NewCode returns a random code of digits, such as a one-time code sent
+// by text message.
+func NewCode(digits int) (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	// rand.Int is uniform: a random number modulo 10^digits would make
+	// the small codes a little more likely.
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
+
+// Equal reports whether a and b are the same secret, in time that depends
+// on their lengths but not on their contents.
+func Equal(a, b string) bool {
+	// a == b stops at the first byte that differs, and how long it takes
+	// tells an attacker how many bytes of a guess were right.
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// DemonstrateTokens makes tokens and codes, and compares them.
+func DemonstrateTokens() {
+	token, err := NewToken()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("token: %d characters, %d random bits\n", len(token), TokenBytes*8)
+	code, _ := NewCode(6)
+	fmt.Println("six-digit code:", len(code), "digits")
+	fmt.Println("equal to itself:", Equal(token, token))
+	fmt.Println("equal to another token:", Equal(token, token[:len(token)-1]+"A"))
+
+	// math/rand with the same seed makes the same "random" tokens, and a
+	// seed from the clock is easy to guess.
+	a := make([]byte, 8)
+	b := make([]byte, 8)
+	mrand.New(mrand.NewSource(1700000000)).Read(a)
+	mrand.New(mrand.NewSource(1700000000)).Read(b)
+	fmt.Printf("math/rand, seeded with the same second: %x and %x\n", a, b)
+}
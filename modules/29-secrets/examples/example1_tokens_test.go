@@ -0,0 +1,51 @@
+package examples
+
+import (
+	"encoding/base64"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateTokens(t *testing.T) {
+	DemonstrateTokens()
+}
+
+func TestNewToken(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		token, err := NewToken()
+		require.NoError(t, err)
+		b, err := base64.RawURLEncoding.DecodeString(token)
+		require.NoError(t, err)
+		assert.Len(t, b, TokenBytes)
+		assert.False(t, seen[token], "the same token twice")
+		seen[token] = true
+	}
+}
+
+func TestNewCode(t *testing.T) {
+	counts := make([]int, 10)
+	for i := 0; i < 1000; i++ {
+		code, err := NewCode(6)
+		require.NoError(t, err)
+		require.Len(t, code, 6)
+		_, err = strconv.Atoi(code)
+		require.NoError(t, err)
+		counts[code[0]-'0']++
+	}
+	// Leading zeros are kept, and every first digit turns up.
+	for d, n := range counts {
+		assert.Positive(t, n, "first digit %d", d)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	assert.True(t, Equal("s3cret", "s3cret"))
+	assert.True(t, Equal("", ""))
+	assert.False(t, Equal("s3cret", "s3creT"))
+	assert.False(t, Equal("s3cret", "s3cre"))
+	assert.False(t, Equal("s3cret", ""))
+}
@@ -0,0 +1,87 @@
+package examples
+
+// This file shows:
+// - A Secret type that prints as [REDACTED] with fmt, encoding/json, and
+//   log/slog, so it cannot leak by accident
+// - Redacting attributes by name in a slog handler with ReplaceAttr
+// - Keeping secrets out of error messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Redacted is what a secret looks like in logs and output.
+const Redacted = "[REDACTED]"
+
+// Secret is a string that is never printed, logged, or encoded. Reveal
+// returns the value, for the one place that needs it.
+type Secret string
+
+// Reveal returns the secret itself.
+func (s Secret) Reveal() string { return string(s) }
+
+// String is used by %v and %s, and by Println.
+func (s Secret) String() string { return Redacted }
+
+// GoString is used by %#v.
+func (s Secret) GoString() string { return `"` + Redacted + `"` }
+
+// MarshalJSON hides the secret from encoding/json, and so from the JSON
+// handler of slog.
+func (s Secret) MarshalJSON() ([]byte, error) { return json.Marshal(Redacted) }
+
+// LogValue hides the secret from every slog handler.
+func (s Secret) LogValue() slog.Value { return slog.StringValue(Redacted) }
+
+// SensitiveKeys are the attribute names NewLogger redacts, whatever their
+// values, in any case.
+var SensitiveKeys = []string{"password", "token", "secret", "api_key", "authorization"}
+
+// NewLogger returns a logger writing text to w, that redacts the values of
+// attributes named in SensitiveKeys.
+func NewLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{
+		// ReplaceAttr sees every attribute, inside groups too, before it
+		// is written.
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			for _, k := range SensitiveKeys {
+				if strings.EqualFold(a.Key, k) {
+					return slog.String(a.Key, Redacted)
+				}
+			}
+			return a
+		},
+	}))
+}
+
+// DemonstrateRedaction prints and logs a secret in every way it could leak.
+func DemonstrateRedaction() {
+	type config struct {
+		User     string
+		Password Secret
+	}
+	cfg := config{User: "admin", Password: "hunter2"}
+	fmt.Printf("%%v: %v\n", cfg)
+	fmt.Printf("%%+v: %+v\n", cfg)
+	fmt.Printf("%%#v: %#v\n", cfg)
+	b, _ := json.Marshal(cfg)
+	fmt.Println("json:", string(b))
+
+	var buf bytes.Buffer
+	logger := NewLogger(&buf)
+	logger.Info("connecting", "config", cfg)
+	// A plain string under a sensitive name is redacted by the handler.
+	logger.Info("login", slog.Group("request", "user", "admin", "Password", "hunter2"))
+	fmt.Print(buf.String())
+
+	// An error message is read by whoever reads the logs. Say which
+	// setting is wrong, not what it is.
+	err := fmt.Errorf("DATABASE_PASSWORD: must be at least 12 characters")
+	fmt.Println("error:", err)
+	fmt.Println("revealed for the database driver only:", len(cfg.Password.Reveal()), "characters")
+}
@@ -0,0 +1,49 @@
+package examples
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateRedaction(t *testing.T) {
+	DemonstrateRedaction()
+}
+
+func TestSecretNeverPrinted(t *testing.T) {
+	s := Secret("hunter2")
+	v := struct{ Password Secret }{s}
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q"} {
+		out := fmt.Sprintf(verb, v)
+		assert.NotContains(t, out, "hunter2", verb)
+		assert.Contains(t, out, Redacted, verb)
+	}
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Password":"[REDACTED]"}`, string(b))
+	assert.Equal(t, "hunter2", s.Reveal())
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("x", "p", s, "v", v)
+	assert.NotContains(t, buf.String(), "hunter2")
+}
+
+func TestNewLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf)
+	logger.Info("x", "password", "hunter2", "user", "admin")
+	logger.Info("x", "API_KEY", "k-123", slog.Group("req", "Authorization", "Bearer abc"))
+	logger.With("token", "t-456").Info("x")
+
+	out := buf.String()
+	for _, secret := range []string{"hunter2", "k-123", "Bearer abc", "t-456"} {
+		assert.NotContains(t, out, secret)
+	}
+	assert.Contains(t, out, "user=admin")
+	assert.Contains(t, out, "req.Authorization="+Redacted)
+}
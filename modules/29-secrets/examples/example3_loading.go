@@ -0,0 +1,89 @@
+package examples
+
+// This file shows:
+// - Reading a secret from NAME, or from the file named by NAME_FILE, the
+//   convention of Docker and Kubernetes secrets
+// - Refusing a secret file that other users can read
+// - Trimming the newline editors add, and nothing else
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ErrNoSecret is returned by LoadSecret when neither NAME nor NAME_FILE
+// is set.
+var ErrNoSecret = errors.New("secret not set")
+
+// ErrPermissions is returned by LoadSecret for a secret file that users
+// other than its owner can read or write.
+var ErrPermissions = errors.New("secret file is accessible to other users")
+
+// LoadSecret returns the secret called name. When name+"_FILE" is set, it
+// is the path of a file holding the secret; otherwise the secret is in
+// the environment variable name.
+func LoadSecret(name string) (Secret, error) {
+	if path, ok := os.LookupEnv(name + "_FILE"); ok {
+		return readSecretFile(path)
+	}
+	if v, ok := os.LookupEnv(name); ok && v != "" {
+		return Secret(v), nil
+	}
+	// The name of the secret is fine in an error; its value never is.
+	return "", fmt.Errorf("%s: %w", name, ErrNoSecret)
+}
+
+// readSecretFile reads a secret from path, which only its owner may read.
+func readSecretFile(path string) (Secret, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	// Windows has no mode bits for other users; it has ACLs instead.
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		return "", fmt.Errorf("%s has mode %v: %w", path, info.Mode().Perm(), ErrPermissions)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	// One trailing newline is the editor's; spaces may be the secret's.
+	s := strings.TrimSuffix(strings.TrimSuffix(string(b), "\n"), "\r")
+	if s == "" {
+		return "", fmt.Errorf("%s: %w", path, ErrNoSecret)
+	}
+	return Secret(s), nil
+}
+
+// DemonstrateLoading loads secrets from the environment and from files.
+func DemonstrateLoading() {
+	dir, err := os.MkdirTemp("", "learngo-secrets")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	os.Setenv("LEARNGO_API_KEY", "k-123")
+	defer os.Unsetenv("LEARNGO_API_KEY")
+	s, err := LoadSecret("LEARNGO_API_KEY")
+	fmt.Println("from the environment:", s, err)
+
+	path := filepath.Join(dir, "db_password")
+	os.WriteFile(path, []byte("hunter2\n"), 0o600)
+	os.Setenv("LEARNGO_DB_PASSWORD_FILE", path)
+	defer os.Unsetenv("LEARNGO_DB_PASSWORD_FILE")
+	s, err = LoadSecret("LEARNGO_DB_PASSWORD")
+	fmt.Printf("from a file: %v, %d characters, %v\n", s, len(s.Reveal()), err)
+
+	os.Chmod(path, 0o644)
+	_, err = LoadSecret("LEARNGO_DB_PASSWORD")
+	fmt.Println("from a file anyone can read:", errors.Is(err, ErrPermissions) || runtime.GOOS == "windows")
+
+	_, err = LoadSecret("LEARNGO_MISSING")
+	fmt.Println("not set:", err)
+}
@@ -0,0 +1,51 @@
+package examples
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateLoading(t *testing.T) {
+	DemonstrateLoading()
+}
+
+func TestLoadSecretEnv(t *testing.T) {
+	t.Setenv("LEARNGO_TEST_SECRET", " k-123 ")
+	s, err := LoadSecret("LEARNGO_TEST_SECRET")
+	require.NoError(t, err)
+	assert.Equal(t, " k-123 ", s.Reveal())
+
+	t.Setenv("LEARNGO_TEST_SECRET", "")
+	_, err = LoadSecret("LEARNGO_TEST_SECRET")
+	assert.ErrorIs(t, err, ErrNoSecret)
+	_, err = LoadSecret("LEARNGO_TEST_UNSET")
+	assert.ErrorIs(t, err, ErrNoSecret)
+	assert.Contains(t, err.Error(), "LEARNGO_TEST_UNSET")
+}
+
+func TestLoadSecretFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte("hunter2 \n"), 0o600))
+	t.Setenv("LEARNGO_TEST_SECRET", "from the environment")
+	t.Setenv("LEARNGO_TEST_SECRET_FILE", path)
+
+	s, err := LoadSecret("LEARNGO_TEST_SECRET")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2 ", s.Reveal(), "the file wins, without its newline")
+
+	if runtime.GOOS != "windows" {
+		require.NoError(t, os.Chmod(path, 0o640))
+		_, err = LoadSecret("LEARNGO_TEST_SECRET")
+		assert.ErrorIs(t, err, ErrPermissions)
+		assert.NotContains(t, err.Error(), "hunter2")
+	}
+
+	t.Setenv("LEARNGO_TEST_SECRET_FILE", filepath.Join(t.TempDir(), "missing"))
+	_, err = LoadSecret("LEARNGO_TEST_SECRET")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,80 @@
+package exercises
+
+// EXERCISE: Fix a login service that leaks passwords and session tokens.
+// Sessions logs users in and hands out session tokens. It was written to
+// be easy to debug: it logs the password of every failed login, the token
+// of every successful one, and its errors say what was typed. Its tokens
+// come from math/rand, seeded with the time.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// TokenBytes is the number of random bytes in a session token.
+const TokenBytes = 32
+
+// ErrLogin is returned by Login for a wrong user name or password.
+var ErrLogin = errors.New("wrong user name or password")
+
+// NewToken returns a random session token, base64-encoded for URLs.
+func NewToken() (string, error) {
+	b := make([]byte, TokenBytes)
+	// BUG: math/rand seeded with the time in seconds: two tokens made in
+	// the same second are the same, and anyone who knows roughly when a
+	// user logged in can make theirs. Use crypto/rand.
+	r := rand.New(rand.NewSource(time.Now().Unix()))
+	if _, err := r.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Sessions logs users in, and remembers them by session token.
+type Sessions struct {
+	// Logger receives a line for every login, successful or not.
+	Logger *slog.Logger
+	// Check reports whether password is the password of user.
+	Check func(user, password string) bool
+
+	mu    sync.Mutex
+	users map[string]string // session token -> user
+}
+
+// Login checks the password of user, and returns a new session token.
+func (s *Sessions) Login(user, password string) (string, error) {
+	if !s.Check(user, password) {
+		// BUG: A failed login is often the right password for another
+		// account, or a typo of this one's. Log the user name only.
+		s.Logger.Warn("login failed", "user", user, "password", password)
+		// BUG: Errors end up in logs too. Return ErrLogin.
+		return "", fmt.Errorf("%w: %s/%s", ErrLogin, user, password)
+	}
+	token, err := NewToken()
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	if s.users == nil {
+		s.users = make(map[string]string)
+	}
+	s.users[token] = user
+	s.mu.Unlock()
+	// BUG: Whoever reads the logs can use the token. Leave it out.
+	s.Logger.Info("logged in", "user", user, "token", token)
+	return token, nil
+}
+
+// User returns the user a session token belongs to.
+func (s *Sessions) User(token string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.users[token]
+	return user, ok
+}
@@ -0,0 +1,119 @@
+package exercises
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSessions returns Sessions with one user, and the buffer it logs
+// to.
+func newTestSessions(user, password string) (*Sessions, *bytes.Buffer) {
+	var buf bytes.Buffer
+	return &Sessions{
+		Logger: slog.New(slog.NewTextHandler(&buf, nil)),
+		Check: func(u, p string) bool {
+			return u == user && p == password
+		},
+	}, &buf
+}
+
+// randomPassword returns a password nothing else in a log could contain.
+func randomPassword(t *testing.T, base int64) string {
+	r := seed.Rand(t, base)
+	return fmt.Sprintf("pw-%x", r.Int63())
+}
+
+func TestNewToken(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		token, err := NewToken()
+		require.NoError(t, err)
+		b, err := base64.RawURLEncoding.DecodeString(token)
+		require.NoError(t, err)
+		assert.Len(t, b, TokenBytes)
+		require.False(t, seen[token], "the same token twice, after %d tokens", i)
+		seen[token] = true
+	}
+}
+
+func TestLogin(t *testing.T) {
+	password := randomPassword(t, 1)
+	s, _ := newTestSessions("ada", password)
+
+	token, err := s.Login("ada", password)
+	require.NoError(t, err)
+	user, ok := s.User(token)
+	assert.True(t, ok)
+	assert.Equal(t, "ada", user)
+
+	other, err := s.Login("ada", password)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other, "every login gets a token of its own")
+
+	_, ok = s.User("")
+	assert.False(t, ok)
+	_, ok = s.User(token + "x")
+	assert.False(t, ok)
+}
+
+func TestLoginWrongPassword(t *testing.T) {
+	password := randomPassword(t, 2)
+	s, _ := newTestSessions("ada", password)
+
+	for _, attempt := range []string{password + "1", "", randomPassword(t, 3)} {
+		token, err := s.Login("ada", attempt)
+		assert.ErrorIs(t, err, ErrLogin)
+		assert.Empty(t, token)
+		if attempt != "" {
+			assert.NotContains(t, err.Error(), attempt, "the error must not contain the password")
+		}
+	}
+	_, err := s.Login("grace", password)
+	assert.ErrorIs(t, err, ErrLogin)
+	assert.NotContains(t, err.Error(), password)
+}
+
+func TestLoginLogsNoSecrets(t *testing.T) {
+	password := randomPassword(t, 4)
+	s, logs := newTestSessions("ada", password)
+
+	_, err := s.Login("ada", password+"!")
+	require.Error(t, err)
+	token, err := s.Login("ada", password)
+	require.NoError(t, err)
+
+	out := logs.String()
+	assert.Contains(t, out, "user=ada", "logins are logged")
+	assert.Equal(t, 2, bytes.Count(logs.Bytes(), []byte("\n")), "one line per login")
+	assert.NotContains(t, out, password, "the log must not contain a password")
+	assert.NotContains(t, out, token, "the log must not contain a token")
+}
+
+func TestLoginConcurrent(t *testing.T) {
+	password := randomPassword(t, 5)
+	s, _ := newTestSessions("ada", password)
+
+	tokens := make([]string, 20)
+	var wg sync.WaitGroup
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens[i], _ = s.Login("ada", password)
+		}(i)
+	}
+	wg.Wait()
+	for _, token := range tokens {
+		user, ok := s.User(token)
+		assert.True(t, ok)
+		assert.Equal(t, "ada", user)
+	}
+}
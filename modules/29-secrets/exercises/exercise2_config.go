@@ -0,0 +1,123 @@
+package exercises
+
+// EXERCISE: Fix a configuration loader that lets its secrets leak.
+// Config holds a database URL with a password in it, and an API key, as
+// Secrets that print as [REDACTED]. They still show up with %#v and in
+// JSON, a secret file anyone can read is accepted, a typo in the URL puts
+// the password in the error, and the logger only redacts lower-case keys.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Redacted is what a Secret looks like in output and logs.
+const Redacted = "[REDACTED]"
+
+var (
+	// ErrNoSecret is returned for a secret that is not set.
+	ErrNoSecret = errors.New("secret not set")
+	// ErrPermissions is returned for a secret file other users can
+	// read or write.
+	ErrPermissions = errors.New("secret file is accessible to other users")
+	// ErrInvalid is returned by LoadConfig for a setting that is set, but
+	// wrong.
+	ErrInvalid = errors.New("invalid setting")
+)
+
+// Secret is a string that is never printed, logged, or encoded.
+type Secret string
+
+// Reveal returns the secret itself.
+func (s Secret) Reveal() string { return string(s) }
+
+// String is used by %v, %s, and %q.
+func (s Secret) String() string { return Redacted }
+
+// BUG: %#v uses GoString, not String, and prints the secret. Add a
+// GoString method that returns "[REDACTED]", quoted.
+
+// MarshalJSON hides the secret from encoding/json.
+//
+// BUG: A method on *Secret is not called for a Secret in a struct that was
+// passed to json.Marshal by value. Use a value receiver.
+func (s *Secret) MarshalJSON() ([]byte, error) { return json.Marshal(Redacted) }
+
+// LoadSecret returns the secret called name, from the file named by
+// name+"_FILE" when that is set, and from the environment variable name
+// otherwise.
+func LoadSecret(name string) (Secret, error) {
+	if path, ok := os.LookupEnv(name + "_FILE"); ok {
+		// BUG: Another user who can read the file knows the secret. Stat
+		// it, and return ErrPermissions when any of the group and other
+		// bits of its mode (0o077) are set, except on Windows.
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		if s := strings.TrimSuffix(string(b), "\n"); s != "" {
+			return Secret(s), nil
+		}
+		return "", fmt.Errorf("%s: %w", path, ErrNoSecret)
+	}
+	if v := os.Getenv(name); v != "" {
+		return Secret(v), nil
+	}
+	return "", fmt.Errorf("%s: %w", name, ErrNoSecret)
+}
+
+// Config is the configuration of a service.
+type Config struct {
+	Addr        string
+	DatabaseURL Secret
+	APIKey      Secret
+}
+
+// LoadConfig reads the configuration from LEARNGO_ADDR (":8080" by
+// default), and the secrets LEARNGO_DATABASE_URL and LEARNGO_API_KEY.
+func LoadConfig() (Config, error) {
+	cfg := Config{Addr: os.Getenv("LEARNGO_ADDR")}
+	if cfg.Addr == "" {
+		cfg.Addr = ":8080"
+	}
+	var err error
+	if cfg.DatabaseURL, err = LoadSecret("LEARNGO_DATABASE_URL"); err != nil {
+		return Config{}, err
+	}
+	// BUG: url.Parse's error quotes the whole URL, password and all.
+	// Return ErrInvalid, with the name of the setting instead.
+	if _, err := url.Parse(cfg.DatabaseURL.Reveal()); err != nil {
+		return Config{}, fmt.Errorf("LEARNGO_DATABASE_URL: %w", err)
+	}
+	if cfg.APIKey, err = LoadSecret("LEARNGO_API_KEY"); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// SensitiveKeys are the attribute names NewLogger redacts, in any case.
+var SensitiveKeys = []string{"password", "token", "secret", "api_key", "authorization"}
+
+// NewLogger returns a logger writing text to w, that redacts the values of
+// attributes named in SensitiveKeys.
+func NewLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			for _, k := range SensitiveKeys {
+				// BUG: "Password" and "API_KEY" get through. Compare
+				// without case.
+				if a.Key == k {
+					return slog.String(a.Key, Redacted)
+				}
+			}
+			return a
+		},
+	}))
+}
@@ -0,0 +1,75 @@
+package solutions
+
+// SOLUTION: Fix a login service that leaks passwords and session tokens.
+// NewToken reads crypto/rand instead of math/rand seeded with the clock,
+// and Login logs the user name but never the password or the token, and
+// returns errors without the password in them.
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"sync"
+)
+
+// TokenBytes is the number of random bytes in a session token.
+const TokenBytes = 32
+
+// ErrLogin is returned by Login for a wrong user name or password.
+var ErrLogin = errors.New("wrong user name or password")
+
+// NewToken returns a random session token, base64-encoded for URLs.
+func NewToken() (string, error) {
+	b := make([]byte, TokenBytes)
+	// Fixed: crypto/rand. Tokens from math/rand seeded with the time
+	// repeat within a second, and anyone who knows the time can make them.
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Sessions logs users in, and remembers them by session token.
+type Sessions struct {
+	// Logger receives a line for every login, successful or not.
+	Logger *slog.Logger
+	// Check reports whether password is the password of user.
+	Check func(user, password string) bool
+
+	mu    sync.Mutex
+	users map[string]string // session token -> user
+}
+
+// Login checks the password of user, and returns a new session token.
+func (s *Sessions) Login(user, password string) (string, error) {
+	if !s.Check(user, password) {
+		// Fixed: The user name, but not the password: a failed login is
+		// often the right password for another account, or a typo of
+		// this one's.
+		s.Logger.Warn("login failed", "user", user)
+		// Fixed: Errors end up in logs too.
+		return "", ErrLogin
+	}
+	token, err := NewToken()
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	if s.users == nil {
+		s.users = make(map[string]string)
+	}
+	s.users[token] = user
+	s.mu.Unlock()
+	// Fixed: Whoever reads the logs could use the token.
+	s.Logger.Info("logged in", "user", user)
+	return token, nil
+}
+
+// User returns the user a session token belongs to.
+func (s *Sessions) User(token string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.users[token]
+	return user, ok
+}
@@ -0,0 +1,121 @@
+package solutions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setConfig sets the environment for LoadConfig, with a password and an
+// API key made for this test.
+func setConfig(t *testing.T) (password, apiKey string) {
+	password, apiKey = randomPassword(t, 10), randomPassword(t, 11)
+	t.Setenv("LEARNGO_ADDR", "")
+	t.Setenv("LEARNGO_DATABASE_URL", "postgres://app:"+password+"@db:5432/shapes")
+	t.Setenv("LEARNGO_API_KEY", apiKey)
+	// Setenv first, so the variables are restored after the test.
+	for _, name := range []string{"LEARNGO_DATABASE_URL_FILE", "LEARNGO_API_KEY_FILE"} {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+	return password, apiKey
+}
+
+func TestLoadConfig(t *testing.T) {
+	password, apiKey := setConfig(t)
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, ":8080", cfg.Addr)
+	assert.Equal(t, "postgres://app:"+password+"@db:5432/shapes", cfg.DatabaseURL.Reveal())
+	assert.Equal(t, apiKey, cfg.APIKey.Reveal())
+
+	t.Setenv("LEARNGO_API_KEY", "")
+	_, err = LoadConfig()
+	assert.ErrorIs(t, err, ErrNoSecret)
+	assert.Contains(t, err.Error(), "LEARNGO_API_KEY")
+}
+
+func TestLoadConfigInvalidURL(t *testing.T) {
+	password, _ := setConfig(t)
+	// %zz is not an escape: a typo that should not cost the password.
+	t.Setenv("LEARNGO_DATABASE_URL", "postgres://app:"+password+"@db:5432/shapes%zz")
+	_, err := LoadConfig()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalid)
+	assert.Contains(t, err.Error(), "LEARNGO_DATABASE_URL")
+	assert.NotContains(t, err.Error(), password, "the error must not contain the password")
+}
+
+func TestSecretFormatting(t *testing.T) {
+	password, apiKey := setConfig(t)
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q"} {
+		for _, v := range []any{cfg, &cfg, cfg.APIKey} {
+			out := fmt.Sprintf(verb, v)
+			assert.NotContains(t, out, password, "%s of %T", verb, v)
+			assert.NotContains(t, out, apiKey, "%s of %T", verb, v)
+			assert.Contains(t, out, Redacted, "%s of %T", verb, v)
+		}
+	}
+	for _, v := range []any{cfg, &cfg, []Config{cfg}, map[string]Secret{"k": cfg.APIKey}} {
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+		assert.NotContains(t, string(b), password, "JSON of %T", v)
+		assert.NotContains(t, string(b), apiKey, "JSON of %T", v)
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("starting", "config", cfg)
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("starting", "config", cfg)
+	assert.NotContains(t, buf.String(), password)
+	assert.NotContains(t, buf.String(), apiKey)
+}
+
+func TestLoadSecretFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_key")
+	require.NoError(t, os.WriteFile(path, []byte("k-123\n"), 0o600))
+	t.Setenv("LEARNGO_TEST_KEY", "")
+	t.Setenv("LEARNGO_TEST_KEY_FILE", path)
+
+	s, err := LoadSecret("LEARNGO_TEST_KEY")
+	require.NoError(t, err)
+	assert.Equal(t, "k-123", s.Reveal())
+
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows has no mode bits for other users")
+	}
+	for _, mode := range []os.FileMode{0o640, 0o604, 0o660, 0o644, 0o666} {
+		require.NoError(t, os.Chmod(path, mode))
+		_, err := LoadSecret("LEARNGO_TEST_KEY")
+		assert.ErrorIs(t, err, ErrPermissions, "mode %v", mode)
+	}
+	require.NoError(t, os.Chmod(path, 0o400))
+	_, err = LoadSecret("LEARNGO_TEST_KEY")
+	assert.NoError(t, err, "mode 0400")
+}
+
+func TestNewLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(&buf)
+	logger.Info("x", "password", "s-1", "user", "ada")
+	logger.Info("x", "Password", "s-2", "API_KEY", "s-3")
+	logger.Info("x", slog.Group("request", "Authorization", "s-4"))
+	logger.With("Token", "s-5").Info("x")
+
+	out := buf.String()
+	for i := 1; i <= 5; i++ {
+		assert.NotContains(t, out, fmt.Sprintf("s-%d", i))
+	}
+	assert.Contains(t, out, "user=ada")
+	assert.Contains(t, out, "request.Authorization="+Redacted)
+}
@@ -0,0 +1,188 @@
+// Package examples demonstrates composing email in Go: headers, encoded
+// words for non-ASCII subjects, multipart messages with attachments, and
+// sending them with net/smtp.
+//
+// The examples send to an SMTP server that runs inside the process, from
+// pkg/smtptest, so nothing leaves the machine.
+//
+// This file shows:
+// - The shape of a message: header lines, a blank line, and the body
+// - Checking addresses with net/mail, and encoding subjects with mime
+// - multipart/mixed messages with mime/multipart
+// - Quoted-printable text and base64 attachments in lines of 76 characters
+package examples
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Attachment is a file attached to a message.
+type Attachment struct {
+	Name        string
+	ContentType string // application/octet-stream when empty
+	Data        []byte
+}
+
+// Message is an email with a plain text body and attachments.
+type Message struct {
+	From        string
+	To          []string
+	Subject     string
+	Text        string
+	Attachments []Attachment
+}
+
+// Bytes returns the message in the format SMTP sends, RFC 5322 with MIME.
+func (m Message) Bytes() ([]byte, error) {
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return nil, fmt.Errorf("from: %w", err)
+	}
+	// ParseAddressList takes "a@b, c@d" and "Ada <ada@b>", and refuses
+	// anything with a line break in it.
+	to, err := mail.ParseAddressList(strings.Join(m.To, ", "))
+	if err != nil {
+		return nil, fmt.Errorf("to: %w", err)
+	}
+
+	var buf bytes.Buffer
+	header := func(key, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+	}
+	header("From", from.String())
+	addrs := make([]string, len(to))
+	for i, a := range to {
+		addrs[i] = a.String()
+	}
+	header("To", strings.Join(addrs, ", "))
+	// An encoded word, =?utf-8?q?...?=, for anything beyond plain ASCII,
+	// line breaks included.
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+
+	if len(m.Attachments) == 0 {
+		header("Content-Type", "text/plain; charset=utf-8")
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeText(&buf, m.Text); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	w := multipart.NewWriter(&buf)
+	header("Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": w.Boundary()}))
+	buf.WriteString("\r\n")
+
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := writeText(part, m.Text); err != nil {
+		return nil, err
+	}
+	for _, a := range m.Attachments {
+		contentType := a.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {contentType},
+			// FormatMediaType quotes the name, and encodes it when it
+			// is not plain ASCII.
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Name})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeBase64(part, a.Data); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeText writes text as quoted-printable, which keeps ASCII readable
+// and lines short.
+func writeText(w io.Writer, text string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := io.WriteString(qp, text); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// base64Line is the length of a line of base64 in a message, from RFC 2045.
+const base64Line = 76
+
+// writeBase64 writes data as base64 in lines of 76 characters.
+func writeBase64(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 0 {
+		n := min(base64Line, len(encoded))
+		if _, err := io.WriteString(w, encoded[:n]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[n:]
+	}
+	return nil
+}
+
+// DemonstrateMIME composes a message with an attachment, and reads it back.
+func DemonstrateMIME() {
+	msg, err := Message{
+		From:    "Shapes <shapes@example.com>",
+		To:      []string{"ada@example.com", "Grace Hopper <grace@example.com>"},
+		Subject: "Größen der Formen",
+		Text:    "The report is attached.\n",
+		Attachments: []Attachment{
+			{Name: "areas.csv", ContentType: "text/csv", Data: []byte("shape,area\ncircle,3.14\n")},
+		},
+	}.Bytes()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	head, _, _ := bytes.Cut(msg, []byte("\r\n\r\n"))
+	fmt.Printf("%s\n\n", head)
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	fmt.Println("subject, decoded:", subject)
+	_, params, _ := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	r := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := r.NextPart()
+		if err != nil {
+			break
+		}
+		// NextPart decodes quoted-printable, but not base64.
+		body, _ := io.ReadAll(part)
+		fmt.Printf("part %s %q: %d bytes\n", part.Header.Get("Content-Type"), part.FileName(), len(body))
+	}
+
+	_, err = Message{From: "shapes@example.com", To: []string{"ada@example.com\r\nBcc: eve@example.com"}}.Bytes()
+	fmt.Println("an address with a line break:", err)
+}
@@ -0,0 +1,101 @@
+package examples
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/smtptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateMIME(t *testing.T) {
+	DemonstrateMIME()
+}
+
+func TestMessageText(t *testing.T) {
+	msg, err := Message{
+		From:    "shapes@example.com",
+		To:      []string{"ada@example.com"},
+		Subject: "Hi\r\nBcc: eve@example.com",
+		Text:    "Größe: 3\n",
+	}.Bytes()
+	require.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg))
+	require.NoError(t, err)
+	assert.Empty(t, parsed.Header.Get("Bcc"))
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Hi\r\nBcc: eve@example.com", subject)
+	body, err := io.ReadAll(parsed.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "Gr=C3=B6=C3=9Fe: 3\r\n", string(body), "quoted-printable, with CRLF")
+}
+
+func TestMessageAttachments(t *testing.T) {
+	data := make([]byte, seed.Between(seed.Rand(t, 1), 1000, 5000))
+	seed.Rand(t, 2).Read(data)
+	msg, err := Message{
+		From:        "shapes@example.com",
+		To:          []string{"ada@example.com"},
+		Text:        "See attached.",
+		Attachments: []Attachment{{Name: "Größe \"1\".bin", Data: data}},
+	}.Bytes()
+	require.NoError(t, err)
+
+	for _, line := range bytes.Split(msg, []byte("\r\n")) {
+		assert.LessOrEqual(t, len(line), smtptest.MaxLineLength)
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg))
+	require.NoError(t, err)
+	_, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	r := multipart.NewReader(parsed.Body, params["boundary"])
+	_, err = r.NextPart()
+	require.NoError(t, err)
+	part, err := r.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "Größe \"1\".bin", part.FileName())
+	assert.Equal(t, "application/octet-stream", part.Header.Get("Content-Type"))
+	encoded, err := io.ReadAll(part)
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSuffix(encoded, []byte("\r\n")), []byte("\r\n"))
+	for _, line := range lines[:len(lines)-1] {
+		assert.Len(t, line, 76)
+	}
+	got, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, bytes.NewReader(encoded)))
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+}
+
+func TestMessageAddresses(t *testing.T) {
+	for _, to := range []string{"", "ada", "ada@example.com\r\nBcc: eve@example.com", "ada@example.com>"} {
+		_, err := Message{From: "shapes@example.com", To: []string{to}}.Bytes()
+		assert.Error(t, err, "%q", to)
+	}
+	_, err := Message{From: "shapes", To: []string{"ada@example.com"}}.Bytes()
+	assert.Error(t, err)
+}
+
+// The server takes the message as composed.
+func TestMessageAccepted(t *testing.T) {
+	srv := smtptest.Start(t, nil)
+	msg, err := Message{
+		From:        "shapes@example.com",
+		To:          []string{"ada@example.com"},
+		Text:        string(bytes.Repeat([]byte("long line "), 500)),
+		Attachments: []Attachment{{Name: "a.bin", Data: make([]byte, 10000)}},
+	}.Bytes()
+	require.NoError(t, err)
+	require.NoError(t, Send(srv.Addr, nil, "shapes@example.com", []string{"ada@example.com"}, msg))
+	require.Len(t, srv.Messages(), 1)
+	assert.Equal(t, msg, srv.Messages()[0].Data)
+}
@@ -0,0 +1,89 @@
+package examples
+
+// This file shows:
+// - Sending a message with smtp.SendMail, and with the steps of a
+//   smtp.Client one at a time
+// - The envelope: MAIL FROM and RCPT TO decide who gets a message, not the
+//   To and Cc headers, which is how Bcc works
+// - Logging in with smtp.PlainAuth, which sends a password over TLS, or
+//   to localhost
+
+import (
+	"fmt"
+	"net/smtp"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/smtptest"
+)
+
+// Send sends msg to the SMTP server at addr, from from to every address in
+// to. auth may be nil, for a server that does not need a password.
+func Send(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+	// SendMail says hello, upgrades to TLS when the server offers it,
+	// logs in, and sends: the steps below, in one call.
+	return smtp.SendMail(addr, auth, from, to, msg)
+}
+
+// sendSteps does what SendMail does, one command at a time.
+func sendSteps(addr, from string, to []string, msg []byte) error {
+	c, err := smtp.Dial(addr)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if err := c.Hello("shapes.example.com"); err != nil {
+		return err
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	// The server answers the message when it is closed.
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// DemonstrateSMTP sends messages to an SMTP server in the process.
+func DemonstrateSMTP() {
+	srv, err := smtptest.NewServer(map[string]string{"shapes": "s3cret"})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer srv.Close()
+
+	msg, _ := Message{
+		From:    "shapes@example.com",
+		To:      []string{"ada@example.com"},
+		Subject: "Report",
+		Text:    "Done.\n",
+	}.Bytes()
+	// Grace is in the envelope but not in the headers: a Bcc.
+	to := []string{"ada@example.com", "grace@example.com"}
+
+	err = Send(srv.Addr, nil, "shapes@example.com", to, msg)
+	fmt.Println("without a password:", err)
+	auth := smtp.PlainAuth("", "shapes", "s3cret", "127.0.0.1")
+	err = Send(srv.Addr, auth, "shapes@example.com", to, msg)
+	fmt.Println("with a password:", err)
+	// PlainAuth sends the password only to the host it was made for, and
+	// without TLS only to localhost.
+	err = Send(srv.Addr, smtp.PlainAuth("", "shapes", "s3cret", "mail.example.com"), "shapes@example.com", to, msg)
+	fmt.Println("to a host it was not made for:", err)
+
+	for _, m := range srv.Messages() {
+		fmt.Printf("received from %s for %v, as %s, %d bytes\n", m.From, m.To, m.User, len(m.Data))
+	}
+}
@@ -0,0 +1,40 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/smtptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateSMTP(t *testing.T) {
+	DemonstrateSMTP()
+}
+
+func TestSendSteps(t *testing.T) {
+	srv := smtptest.Start(t, nil)
+	msg, err := Message{From: "shapes@example.com", To: []string{"ada@example.com"}, Text: ".\n"}.Bytes()
+	require.NoError(t, err)
+	to := []string{"ada@example.com", "grace@example.com"}
+	require.NoError(t, sendSteps(srv.Addr, "shapes@example.com", to, msg))
+	require.NoError(t, Send(srv.Addr, nil, "shapes@example.com", to, msg))
+
+	got := srv.Messages()
+	require.Len(t, got, 2)
+	for _, m := range got {
+		assert.Equal(t, to, m.To)
+		assert.Equal(t, msg, m.Data)
+		assert.False(t, strings.Contains(string(m.Data), "grace"), "a Bcc is not in the headers")
+	}
+}
+
+func TestSendRefused(t *testing.T) {
+	srv := smtptest.Start(t, nil)
+	err := Send(srv.Addr, nil, "shapes@example.com", []string{"ada@example.com"},
+		[]byte("Subject: x\r\n\r\n"+strings.Repeat("x", 1000)+"\r\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line longer than")
+	assert.Empty(t, srv.Messages())
+}
@@ -0,0 +1,54 @@
+package exercises
+
+// EXERCISE: Fix a function that composes plain text email.
+// Compose writes headers and text the way they look in a mail program.
+// But a subject or an address with a line break in it adds headers of
+// its own choosing, readers guess the charset of anything beyond ASCII,
+// and servers refuse a paragraph longer than 998 characters.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrAddress is returned by Compose for an address it cannot use.
+var ErrAddress = errors.New("invalid address")
+
+// Email is a plain text email.
+type Email struct {
+	From    string
+	To      []string
+	Subject string
+	Text    string
+}
+
+// Compose returns e as a message for SMTP: RFC 5322 headers, a blank line,
+// and the text.
+func Compose(e Email) ([]byte, error) {
+	if len(e.To) == 0 {
+		return nil, fmt.Errorf("%w: no recipients", ErrAddress)
+	}
+	var buf bytes.Buffer
+	// BUG: A line break in an address ends the header and starts another,
+	// such as Bcc, and "ada" is not an address at all. Parse each with
+	// mail.ParseAddress, return ErrAddress, wrapped, when it fails, and
+	// write what the *mail.Address's String method returns.
+	fmt.Fprintf(&buf, "From: %s\r\n", e.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(e.To, ", "))
+	// BUG: The same goes for the subject, and headers must be ASCII.
+	// Encode it with mime.QEncoding, as utf-8.
+	fmt.Fprintf(&buf, "Subject: %s\r\n", e.Subject)
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	// BUG: Without a charset, readers guess. Add "; charset=utf-8".
+	buf.WriteString("Content-Type: text/plain\r\n")
+	// BUG: Servers refuse lines of more than 998 characters, and one
+	// paragraph is a line. Use quoted-printable, with a
+	// quotedprintable.Writer.
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(e.Text)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,149 @@
+package exercises
+
+// EXERCISE: Fix a mailer that sends attachments and blind copies.
+// Mailer sends text with attachments through an SMTP server. Any
+// attachment bigger than a few hundred bytes is refused by the server,
+// a quote in a file name breaks the message, the Bcc recipients are
+// listed for everyone to see but never get the mail, and a sender with a
+// name confuses the server.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+)
+
+// Attachment is a file attached to a Mail.
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Mail is a message to send with a Mailer.
+type Mail struct {
+	To          []string
+	Bcc         []string // get the message, without the others knowing
+	Subject     string
+	Text        string
+	Attachments []Attachment
+}
+
+// Mailer sends mail through an SMTP server.
+type Mailer struct {
+	Addr string    // host:port of the server
+	Auth smtp.Auth // nil for a server that needs no password
+	From string    // the sender, such as "Shapes <shapes@example.com>"
+}
+
+// Send sends mail to everyone in its To and Bcc.
+func (m *Mailer) Send(ml Mail) error {
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return fmt.Errorf("from: %w", err)
+	}
+	to, err := addresses(ml.To)
+	if err != nil {
+		return fmt.Errorf("to: %w", err)
+	}
+	bcc, err := addresses(ml.Bcc)
+	if err != nil {
+		return fmt.Errorf("bcc: %w", err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(formatAll(to), ", "))
+	// BUG: Every recipient can read this header. Leave it out.
+	if len(bcc) > 0 {
+		fmt.Fprintf(&buf, "Bcc: %s\r\n", strings.Join(formatAll(bcc), ", "))
+	}
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", ml.Subject))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	w := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", w.Boundary())
+
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeBase64(part, []byte(ml.Text)); err != nil {
+		return err
+	}
+	for _, a := range ml.Attachments {
+		contentType := a.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {contentType},
+			// BUG: A quote or a line break in the name breaks the
+			// header, and headers must be ASCII. mime.FormatMediaType
+			// quotes, escapes, and encodes the name as needed.
+			"Content-Disposition":       {`attachment; filename="` + a.Name + `"`},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return err
+		}
+		if err := writeBase64(part, a.Data); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	// BUG: The server sends the message to the addresses it is given
+	// here, the envelope, and never reads the headers. Add the Bcc
+	// addresses.
+	var rcpt []string
+	for _, a := range to {
+		rcpt = append(rcpt, a.Address)
+	}
+	// BUG: MAIL FROM takes the address alone, not "Name <address>". Use
+	// from.Address.
+	return smtp.SendMail(m.Addr, m.Auth, m.From, rcpt, buf.Bytes())
+}
+
+// addresses parses each of addrs.
+func addresses(addrs []string) ([]*mail.Address, error) {
+	parsed := make([]*mail.Address, len(addrs))
+	for i, s := range addrs {
+		a, err := mail.ParseAddress(s)
+		if err != nil {
+			return nil, err
+		}
+		parsed[i] = a
+	}
+	return parsed, nil
+}
+
+// formatAll formats each address for a header.
+func formatAll(addrs []*mail.Address) []string {
+	s := make([]string, len(addrs))
+	for i, a := range addrs {
+		s[i] = a.String()
+	}
+	return s
+}
+
+// writeBase64 writes data to w in base64.
+func writeBase64(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	// BUG: One line, however long. Servers refuse lines of more than 998
+	// characters; RFC 2045 asks for base64 in lines of 76.
+	_, err := io.WriteString(w, encoded+"\r\n")
+	return err
+}
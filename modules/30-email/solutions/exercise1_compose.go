@@ -0,0 +1,71 @@
+package solutions
+
+// SOLUTION: Fix a function that composes plain text email.
+// Compose checks every address with net/mail, encodes the subject as a
+// MIME encoded word so a line break cannot start a new header, and writes
+// the text as UTF-8 in quoted-printable, whose lines are never too long.
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+)
+
+// ErrAddress is returned by Compose for an address it cannot use.
+var ErrAddress = errors.New("invalid address")
+
+// Email is a plain text email.
+type Email struct {
+	From    string
+	To      []string
+	Subject string
+	Text    string
+}
+
+// Compose returns e as a message for SMTP: RFC 5322 headers, a blank line,
+// and the text.
+func Compose(e Email) ([]byte, error) {
+	if len(e.To) == 0 {
+		return nil, fmt.Errorf("%w: no recipients", ErrAddress)
+	}
+	// Fixed: Parse every address. A line break in one would end the
+	// header and start another, such as Bcc.
+	from, err := mail.ParseAddress(e.From)
+	if err != nil {
+		return nil, fmt.Errorf("%w: from: %v", ErrAddress, err)
+	}
+	to := make([]string, len(e.To))
+	for i, addr := range e.To {
+		a, err := mail.ParseAddress(addr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: to: %v", ErrAddress, err)
+		}
+		to[i] = a.String()
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+	// Fixed: An encoded word, which has no line breaks, and carries any
+	// character.
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", e.Subject))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	// Fixed: The charset, without which readers guess.
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	// Fixed: Quoted-printable: servers refuse lines of more than 998
+	// characters.
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buf.WriteString("\r\n")
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(e.Text)); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,134 @@
+package solutions
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/smtptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readComposed parses a message from Compose, and returns its headers and
+// its text, decoded.
+func readComposed(t *testing.T, msg []byte) (mail.Header, string) {
+	t.Helper()
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg))
+	require.NoError(t, err)
+	text, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	require.NoError(t, err)
+	return parsed.Header, string(text)
+}
+
+func TestComposeHeaders(t *testing.T) {
+	msg, err := Compose(Email{
+		From:    "Shapes <shapes@example.com>",
+		To:      []string{"ada@example.com", "Grace Hopper <grace@example.com>"},
+		Subject: "Areas",
+		Text:    "Done.\n",
+	})
+	require.NoError(t, err)
+	header, _ := readComposed(t, msg)
+
+	from, err := header.AddressList("From")
+	require.NoError(t, err)
+	assert.Equal(t, []*mail.Address{{Name: "Shapes", Address: "shapes@example.com"}}, from)
+	to, err := header.AddressList("To")
+	require.NoError(t, err)
+	assert.Equal(t, []*mail.Address{
+		{Address: "ada@example.com"},
+		{Name: "Grace Hopper", Address: "grace@example.com"},
+	}, to)
+	assert.Equal(t, "Areas", header.Get("Subject"))
+	assert.Equal(t, "1.0", header.Get("MIME-Version"))
+}
+
+func TestComposeSubject(t *testing.T) {
+	dec := new(mime.WordDecoder)
+	for _, subject := range []string{
+		"Größen der Formen",
+		"円の面積",
+		"Hi\r\nBcc: eve@example.com",
+		"Hi\nX-Priority: 1",
+	} {
+		msg, err := Compose(Email{From: "shapes@example.com", To: []string{"ada@example.com"}, Subject: subject})
+		require.NoError(t, err, "%q", subject)
+		header, _ := readComposed(t, msg)
+		assert.Empty(t, header.Get("Bcc"), "%q added a header", subject)
+		assert.Empty(t, header.Get("X-Priority"), "%q added a header", subject)
+		got, err := dec.DecodeHeader(header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, subject, got)
+		for _, b := range []byte(header.Get("Subject")) {
+			require.Less(t, b, byte(0x80), "headers are ASCII: %q", header.Get("Subject"))
+		}
+	}
+}
+
+func TestComposeAddresses(t *testing.T) {
+	valid := Email{From: "shapes@example.com", To: []string{"ada@example.com"}}
+	for _, to := range []string{
+		"",
+		"ada",
+		"ada@example.com\r\nBcc: eve@example.com",
+		"ada@example.com, eve@example.com",
+		"<ada@example.com",
+	} {
+		e := valid
+		e.To = []string{"grace@example.com", to}
+		msg, err := Compose(e)
+		assert.ErrorIs(t, err, ErrAddress, "to %q", to)
+		assert.Nil(t, msg)
+
+		e = valid
+		e.From = to
+		_, err = Compose(e)
+		assert.ErrorIs(t, err, ErrAddress, "from %q", to)
+	}
+	e := valid
+	e.To = nil
+	_, err := Compose(e)
+	assert.ErrorIs(t, err, ErrAddress, "nobody to send to")
+}
+
+func TestComposeText(t *testing.T) {
+	r := seed.Rand(t, 1)
+	words := []string{"circle", "Größe", "面積", "=", "π", "\n", "  ", "."}
+	var b strings.Builder
+	for i := 0; i < 2000; i++ {
+		b.WriteString(words[r.Intn(len(words))])
+		b.WriteString(" ")
+	}
+	text := b.String()
+
+	msg, err := Compose(Email{From: "shapes@example.com", To: []string{"ada@example.com"}, Text: text})
+	require.NoError(t, err)
+	header, got := readComposed(t, msg)
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain", mediaType)
+	assert.Equal(t, "utf-8", strings.ToLower(params["charset"]))
+	assert.Equal(t, "quoted-printable", header.Get("Content-Transfer-Encoding"))
+	// Quoted-printable text ends lines with CRLF.
+	assert.Equal(t, strings.ReplaceAll(text, "\n", "\r\n"), got)
+}
+
+func TestComposeLongLines(t *testing.T) {
+	srv := smtptest.Start(t, nil)
+	text := strings.Repeat("A paragraph that goes on and on. ", 100) + "\n"
+	msg, err := Compose(Email{From: "shapes@example.com", To: []string{"ada@example.com"}, Text: text})
+	require.NoError(t, err)
+
+	err = smtp.SendMail(srv.Addr, nil, "shapes@example.com", []string{"ada@example.com"}, msg)
+	require.NoError(t, err, "the server refused the message")
+	require.Len(t, srv.Messages(), 1)
+	_, got := readComposed(t, srv.Messages()[0].Data)
+	assert.Equal(t, strings.ReplaceAll(text, "\n", "\r\n"), got)
+}
@@ -0,0 +1,145 @@
+package solutions
+
+// SOLUTION: Fix a mailer that sends attachments and blind copies.
+// Send wraps base64 at 76 characters, writes attachment names with
+// mime.FormatMediaType, keeps Bcc out of the headers but in the envelope,
+// and gives MAIL FROM the bare address.
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+)
+
+// Attachment is a file attached to a Mail.
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Mail is a message to send with a Mailer.
+type Mail struct {
+	To          []string
+	Bcc         []string // get the message, without the others knowing
+	Subject     string
+	Text        string
+	Attachments []Attachment
+}
+
+// Mailer sends mail through an SMTP server.
+type Mailer struct {
+	Addr string    // host:port of the server
+	Auth smtp.Auth // nil for a server that needs no password
+	From string    // the sender, such as "Shapes <shapes@example.com>"
+}
+
+// Send sends mail to everyone in its To and Bcc.
+func (m *Mailer) Send(ml Mail) error {
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return fmt.Errorf("from: %w", err)
+	}
+	to, err := addresses(ml.To)
+	if err != nil {
+		return fmt.Errorf("to: %w", err)
+	}
+	bcc, err := addresses(ml.Bcc)
+	if err != nil {
+		return fmt.Errorf("bcc: %w", err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(formatAll(to), ", "))
+	// Fixed: No Bcc header: every recipient could read it.
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", ml.Subject))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	w := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", w.Boundary())
+
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeBase64(part, []byte(ml.Text)); err != nil {
+		return err
+	}
+	for _, a := range ml.Attachments {
+		contentType := a.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {contentType},
+			// Fixed: FormatMediaType quotes and escapes the name, and
+			// encodes it when it is not plain ASCII.
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Name})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return err
+		}
+		if err := writeBase64(part, a.Data); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	// Fixed: The envelope decides who gets the message, Bcc included.
+	var rcpt []string
+	for _, a := range append(to, bcc...) {
+		rcpt = append(rcpt, a.Address)
+	}
+	// Fixed: MAIL FROM takes the address alone, not "Name <address>".
+	return smtp.SendMail(m.Addr, m.Auth, from.Address, rcpt, buf.Bytes())
+}
+
+// addresses parses each of addrs.
+func addresses(addrs []string) ([]*mail.Address, error) {
+	parsed := make([]*mail.Address, len(addrs))
+	for i, s := range addrs {
+		a, err := mail.ParseAddress(s)
+		if err != nil {
+			return nil, err
+		}
+		parsed[i] = a
+	}
+	return parsed, nil
+}
+
+// formatAll formats each address for a header.
+func formatAll(addrs []*mail.Address) []string {
+	s := make([]string, len(addrs))
+	for i, a := range addrs {
+		s[i] = a.String()
+	}
+	return s
+}
+
+// writeBase64 writes data to w in base64.
+func writeBase64(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	// Fixed: Lines of 76 characters, as RFC 2045 asks. Servers refuse
+	// lines of more than 998.
+	for len(encoded) > 76 {
+		if _, err := io.WriteString(w, encoded[:76]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := io.WriteString(w, encoded+"\r\n")
+	return err
+}
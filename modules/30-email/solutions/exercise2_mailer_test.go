@@ -0,0 +1,170 @@
+package solutions
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/faker"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/smtptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sentPart is a part of a message a Mailer sent, decoded.
+type sentPart struct {
+	ContentType string
+	FileName    string
+	Data        []byte
+}
+
+// readSent parses a message a Mailer sent.
+func readSent(t *testing.T, data []byte) (mail.Header, []sentPart) {
+	t.Helper()
+	for _, line := range bytes.Split(data, []byte("\r\n")) {
+		require.LessOrEqual(t, len(line), smtptest.MaxLineLength)
+	}
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/mixed", mediaType)
+
+	var parts []sentPart
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := r.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.Equal(t, "base64", p.Header.Get("Content-Transfer-Encoding"))
+		b, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, p))
+		require.NoError(t, err)
+		parts = append(parts, sentPart{ContentType: p.Header.Get("Content-Type"), FileName: p.FileName(), Data: b})
+	}
+	return msg.Header, parts
+}
+
+func newTestMailer(t *testing.T) (*Mailer, *smtptest.Server) {
+	srv := smtptest.Start(t, map[string]string{"shapes": "s3cret"})
+	return &Mailer{
+		Addr: srv.Addr,
+		// net/smtp sends a password without TLS only to localhost.
+		Auth: smtp.PlainAuth("", "shapes", "s3cret", "127.0.0.1"),
+		From: "Shapes <shapes@example.com>",
+	}, srv
+}
+
+func TestMailerSend(t *testing.T) {
+	m, srv := newTestMailer(t)
+	require.NoError(t, m.Send(Mail{
+		To:          []string{"ada@example.com"},
+		Subject:     "Größen",
+		Text:        "See attached.\n",
+		Attachments: []Attachment{{Name: "areas.csv", ContentType: "text/csv", Data: []byte("circle,3.14\n")}},
+	}))
+
+	got := srv.Messages()
+	require.Len(t, got, 1)
+	assert.Equal(t, "shapes", got[0].User)
+	assert.Equal(t, "shapes@example.com", got[0].From, "MAIL FROM takes the bare address")
+	assert.Equal(t, []string{"ada@example.com"}, got[0].To)
+
+	header, parts := readSent(t, got[0].Data)
+	subject, err := new(mime.WordDecoder).DecodeHeader(header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Größen", subject)
+	assert.Equal(t, `"Shapes" <shapes@example.com>`, header.Get("From"))
+	require.Len(t, parts, 2)
+	assert.Equal(t, "See attached.\n", string(parts[0].Data))
+	assert.Equal(t, sentPart{ContentType: "text/csv", FileName: "areas.csv", Data: []byte("circle,3.14\n")}, parts[1])
+}
+
+func TestMailerLargeAttachments(t *testing.T) {
+	m, srv := newTestMailer(t)
+	r := seed.Rand(t, 1)
+	var attachments []Attachment
+	for _, size := range []int{0, 57, 700, 750, seed.Between(r, 1000, 100000)} {
+		data := make([]byte, size)
+		r.Read(data)
+		attachments = append(attachments, Attachment{Name: "data.bin", Data: data})
+	}
+	require.NoError(t, m.Send(Mail{To: []string{"ada@example.com"}, Attachments: attachments}))
+
+	require.Len(t, srv.Messages(), 1)
+	_, parts := readSent(t, srv.Messages()[0].Data)
+	require.Len(t, parts, 1+len(attachments))
+	for i, a := range attachments {
+		assert.Equal(t, "application/octet-stream", parts[i+1].ContentType)
+		assert.Equal(t, a.Data, parts[i+1].Data, "attachment of %d bytes", len(a.Data))
+	}
+}
+
+func TestMailerAttachmentNames(t *testing.T) {
+	m, srv := newTestMailer(t)
+	names := []string{
+		"report.pdf",
+		`the "final" report.pdf`,
+		`back\slash.txt`,
+		"Größen.txt",
+		"a.txt\r\nContent-Type: image-png",
+	}
+	var attachments []Attachment
+	for _, name := range names {
+		attachments = append(attachments, Attachment{Name: name, ContentType: "text/plain", Data: []byte(name)})
+	}
+	require.NoError(t, m.Send(Mail{To: []string{"ada@example.com"}, Attachments: attachments}))
+
+	require.Len(t, srv.Messages(), 1)
+	_, parts := readSent(t, srv.Messages()[0].Data)
+	require.Len(t, parts, 1+len(names))
+	for i, name := range names {
+		assert.Equal(t, name, parts[i+1].FileName)
+		assert.Equal(t, "text/plain", parts[i+1].ContentType, "%q changed the content type", name)
+	}
+}
+
+func TestMailerBcc(t *testing.T) {
+	m, srv := newTestMailer(t)
+	people := faker.For(t, 3).People(4)
+	to, bcc := people[:2], people[2:]
+	require.NoError(t, m.Send(Mail{
+		To:   []string{to[0].Email, to[1].Address()},
+		Bcc:  []string{bcc[0].Email, bcc[1].Address()},
+		Text: "Hello.",
+	}))
+
+	require.Len(t, srv.Messages(), 1)
+	got := srv.Messages()[0]
+	var everyone []string
+	for _, p := range people {
+		everyone = append(everyone, p.Email)
+	}
+	assert.ElementsMatch(t, everyone, got.To, "everyone gets the message")
+	header, _ := readSent(t, got.Data)
+	assert.Empty(t, header.Get("Bcc"))
+	for _, p := range bcc {
+		assert.False(t, strings.Contains(string(got.Data), p.Email), "nobody sees who else got it")
+	}
+}
+
+func TestMailerAddresses(t *testing.T) {
+	m, srv := newTestMailer(t)
+	for _, ml := range []Mail{
+		{To: []string{"ada@example.com\r\nBcc: eve@example.com"}},
+		{To: []string{"ada@example.com"}, Bcc: []string{"eve"}},
+	} {
+		assert.Error(t, m.Send(ml))
+	}
+	m.From = "shapes"
+	assert.Error(t, m.Send(Mail{To: []string{"ada@example.com"}}))
+	assert.Empty(t, srv.Messages())
+}
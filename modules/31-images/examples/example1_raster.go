@@ -0,0 +1,120 @@
+// Package examples demonstrates images in Go: drawing the course's shapes
+// into an image.RGBA pixel by pixel, compositing layers with image/draw,
+// and encoding PNG and JPEG files.
+//
+// This file shows:
+// - image.Rectangle: Min is inside, Max is not, and Min need not be 0,0
+// - Rasterizing a shape by testing the center of each pixel
+// - Clipping to the image's bounds with Intersect
+// - image.RGBA's Pix, Stride, and PixOffset
+package examples
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vec"
+)
+
+// Contains reports whether p is inside s, or on its edge. Rectangles
+// include their minimum edges and exclude their maximum ones, like
+// image.Rectangle.
+func Contains(s geometry.Shape, p geometry.Point) bool {
+	switch s := s.(type) {
+	case geometry.Circle:
+		return p.Distance(s.Center) <= s.Radius
+	case geometry.Rectangle:
+		return p.X >= s.Origin.X && p.X < s.Origin.X+s.Width &&
+			p.Y >= s.Origin.Y && p.Y < s.Origin.Y+s.Height
+	case geometry.Triangle:
+		// p is inside when it is on the same side of all three edges.
+		d1 := cross(s.A, s.B, p)
+		d2 := cross(s.B, s.C, p)
+		d3 := cross(s.C, s.A, p)
+		neg := d1 < 0 || d2 < 0 || d3 < 0
+		pos := d1 > 0 || d2 > 0 || d3 > 0
+		return !(neg && pos)
+	}
+	return false
+}
+
+// cross is the z component of (b-a) × (p-a): positive when p is to the left
+// of the line from a to b.
+func cross(a, b, p geometry.Point) float64 {
+	return vec.Cross(vec.Sub(b, a), vec.Sub(p, a))
+}
+
+// Bounds returns the smallest image.Rectangle of whole pixels that covers s.
+func Bounds(s geometry.Shape) image.Rectangle {
+	var minX, minY, maxX, maxY float64
+	switch s := s.(type) {
+	case geometry.Circle:
+		minX, minY = s.Center.X-s.Radius, s.Center.Y-s.Radius
+		maxX, maxY = s.Center.X+s.Radius, s.Center.Y+s.Radius
+	case geometry.Rectangle:
+		minX, minY = s.Origin.X, s.Origin.Y
+		maxX, maxY = s.Origin.X+s.Width, s.Origin.Y+s.Height
+	case geometry.Triangle:
+		minX, maxX = math.Min(s.A.X, math.Min(s.B.X, s.C.X)), math.Max(s.A.X, math.Max(s.B.X, s.C.X))
+		minY, maxY = math.Min(s.A.Y, math.Min(s.B.Y, s.C.Y)), math.Max(s.A.Y, math.Max(s.B.Y, s.C.Y))
+	}
+	// Floor and Ceil, not int(): int truncates toward zero, and drops
+	// the last partly covered pixel.
+	return image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY)))
+}
+
+// Fill paints every pixel of dst whose center is inside s with c. Pixel
+// x, y covers x to x+1 and y to y+1, with y growing downwards.
+func Fill(dst *image.RGBA, s geometry.Shape, c color.Color) {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	// Only the pixels that are both in the shape's box and in the image.
+	r := Bounds(s).Intersect(dst.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if Contains(s, geometry.Point{X: float64(x) + 0.5, Y: float64(y) + 0.5}) {
+				dst.SetRGBA(x, y, rgba)
+			}
+		}
+	}
+}
+
+// ASCII draws img as text, one character per pixel: '#' for a pixel that
+// is not the background color at Min.
+func ASCII(img image.Image) string {
+	b := img.Bounds()
+	bg := img.At(b.Min.X, b.Min.Y)
+	var sb strings.Builder
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if img.At(x, y) == bg {
+				sb.WriteByte('.')
+			} else {
+				sb.WriteByte('#')
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// DemonstrateRaster draws shapes into a small image.
+func DemonstrateRaster() {
+	img := image.NewRGBA(image.Rect(0, 0, 24, 10))
+	Fill(img, geometry.Rectangle{Origin: geometry.Point{X: 1, Y: 1}, Width: 6, Height: 4}, color.White)
+	Fill(img, geometry.Circle{Center: geometry.Point{X: 12, Y: 5}, Radius: 4}, color.White)
+	Fill(img, geometry.Triangle{A: geometry.Point{X: 17, Y: 9}, B: geometry.Point{X: 20, Y: 1}, C: geometry.Point{X: 23, Y: 9}}, color.White)
+	fmt.Print(ASCII(img))
+
+	// Pixel x, y starts at Pix[PixOffset(x, y)]: R, G, B, A.
+	i := img.PixOffset(12, 5)
+	fmt.Printf("stride %d bytes, pixel 12,5 at Pix[%d:%d] = %v\n", img.Stride, i, i+4, img.Pix[i:i+4])
+
+	// A SubImage shares the pixels, and keeps their coordinates.
+	sub := img.SubImage(image.Rect(8, 1, 16, 9)).(*image.RGBA)
+	fmt.Println("sub-image bounds:", sub.Bounds(), "pixel 12,5:", sub.At(12, 5))
+	fmt.Print(ASCII(sub))
+}
@@ -0,0 +1,95 @@
+package examples
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden images in testdata")
+
+// checkGolden compares img with testdata/name.png, pixel by pixel. With
+// -update, it writes img there instead.
+func checkGolden(t *testing.T, name string, img image.Image) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".png")
+	if *update {
+		f, err := os.Create(path)
+		require.NoError(t, err)
+		require.NoError(t, png.Encode(f, img))
+		require.NoError(t, f.Close())
+	}
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	want, err := png.Decode(f)
+	require.NoError(t, err)
+
+	require.Equal(t, want.Bounds(), img.Bounds(), "bounds")
+	b := img.Bounds()
+	diffs := 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			w := color.RGBA64Model.Convert(want.At(x, y))
+			g := color.RGBA64Model.Convert(img.At(x, y))
+			if w != g {
+				if diffs == 0 {
+					t.Errorf("pixel %d,%d is %v, want %v", x, y, g, w)
+				}
+				diffs++
+			}
+		}
+	}
+	assert.Zero(t, diffs, "pixels that differ from %s", path)
+}
+
+func TestDemonstrateRaster(t *testing.T) {
+	DemonstrateRaster()
+}
+
+func TestFillArea(t *testing.T) {
+	for _, s := range []geometry.Shape{
+		geometry.Circle{Center: geometry.Point{X: 50, Y: 50}, Radius: 30},
+		geometry.Rectangle{Origin: geometry.Point{X: 10, Y: 20}, Width: 37, Height: 11},
+		geometry.Triangle{A: geometry.Point{X: 5, Y: 90}, B: geometry.Point{X: 50, Y: 5}, C: geometry.Point{X: 95, Y: 90}},
+	} {
+		img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+		Fill(img, s, color.White)
+		n := 0
+		for i := 0; i < len(img.Pix); i += 4 {
+			if img.Pix[i] == 0xff {
+				n++
+			}
+		}
+		assert.InDelta(t, s.Area(), float64(n), math.Max(2, s.Area()*0.01), "%v", s)
+	}
+}
+
+func TestFillClipsAndOffsets(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 40))
+	sub := img.SubImage(image.Rect(20, 20, 40, 40)).(*image.RGBA)
+	// Half of the circle is outside sub.
+	Fill(sub, geometry.Circle{Center: geometry.Point{X: 20, Y: 30}, Radius: 5}, color.White)
+	assert.Equal(t, color.RGBA{0xff, 0xff, 0xff, 0xff}, img.RGBAAt(22, 30))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(18, 30), "outside the sub-image")
+	// Nothing happens for a shape entirely outside.
+	Fill(img, geometry.Circle{Center: geometry.Point{X: -50, Y: -50}, Radius: 5}, color.White)
+}
+
+func TestBounds(t *testing.T) {
+	assert.Equal(t, image.Rect(4, 4, 16, 16), Bounds(geometry.Circle{Center: geometry.Point{X: 10, Y: 10}, Radius: 5.5}))
+	assert.Equal(t, image.Rect(-2, 0, 3, 1), Bounds(geometry.Rectangle{Origin: geometry.Point{X: -1.5, Y: 0}, Width: 4, Height: 1}))
+}
+
+func TestSceneGolden(t *testing.T) {
+	checkGolden(t, "scene", Scene())
+}
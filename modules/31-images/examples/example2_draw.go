@@ -0,0 +1,82 @@
+package examples
+
+// This file shows:
+// - draw.Draw with draw.Src, which replaces, and draw.Over, which blends
+// - image.Uniform, an image of one color and infinite size
+// - Drawing a translucent shape through an image.Alpha mask with
+//   draw.DrawMask
+// - color.RGBA is premultiplied by alpha, and color.NRGBA is not
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// Layer is a shape in a color, which may be translucent.
+type Layer struct {
+	Shape geometry.Shape
+	Color color.NRGBA
+}
+
+// Mask returns an image.Alpha over r that is opaque where s is.
+func Mask(s geometry.Shape, r image.Rectangle) *image.Alpha {
+	mask := image.NewAlpha(r)
+	b := Bounds(s).Intersect(r)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if Contains(s, geometry.Point{X: float64(x) + 0.5, Y: float64(y) + 0.5}) {
+				mask.SetAlpha(x, y, color.Alpha{A: 0xff})
+			}
+		}
+	}
+	return mask
+}
+
+// Composite draws layers over a background, the first one at the bottom.
+func Composite(r image.Rectangle, background color.Color, layers []Layer) *image.RGBA {
+	dst := image.NewRGBA(r)
+	// Src: the background replaces whatever dst held.
+	draw.Draw(dst, r, image.NewUniform(background), image.Point{}, draw.Src)
+	for _, l := range layers {
+		// Over: the layer's color blends with what is below, by its
+		// alpha, where the mask lets it through.
+		draw.DrawMask(dst, r, image.NewUniform(l.Color), image.Point{}, Mask(l.Shape, r), r.Min, draw.Over)
+	}
+	return dst
+}
+
+// Scene is the picture the golden test checks: a circle, a rectangle, and
+// a triangle, half transparent where they overlap.
+func Scene() *image.RGBA {
+	return Composite(image.Rect(0, 0, 64, 48), color.White, []Layer{
+		{geometry.Rectangle{Origin: geometry.Point{X: 4, Y: 4}, Width: 36, Height: 24}, color.NRGBA{0x1e, 0x88, 0xe5, 0xff}},
+		{geometry.Circle{Center: geometry.Point{X: 36, Y: 24}, Radius: 16}, color.NRGBA{0xe5, 0x39, 0x35, 0x80}},
+		{geometry.Triangle{A: geometry.Point{X: 8, Y: 44}, B: geometry.Point{X: 24, Y: 16}, C: geometry.Point{X: 40, Y: 44}}, color.NRGBA{0x43, 0xa0, 0x47, 0xc0}},
+	})
+}
+
+// DemonstrateDraw composites translucent layers.
+func DemonstrateDraw() {
+	img := Scene()
+	fmt.Println("white background:", img.RGBAAt(60, 2))
+	fmt.Println("blue rectangle:", img.RGBAAt(6, 6))
+	fmt.Println("half-transparent red over blue:", img.RGBAAt(30, 20))
+	fmt.Println("half-transparent red over white:", img.RGBAAt(48, 24))
+
+	// The same color, in both models: RGBA stores R*A/255.
+	n := color.NRGBA{0xe5, 0x39, 0x35, 0x80}
+	fmt.Println("NRGBA", n, "is RGBA", color.RGBAModel.Convert(n))
+	// Converting the struct type does not convert the color: R > A is
+	// not a valid premultiplied color, and draws too bright.
+	fmt.Println("color.RGBA(n) is", color.RGBA(n), "which is wrong")
+
+	// Src copies the translucent color, alpha and all.
+	dst := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(n), image.Point{}, draw.Src)
+	fmt.Println("Src over white:", dst.RGBAAt(0, 0))
+}
@@ -0,0 +1,35 @@
+package examples
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateDraw(t *testing.T) {
+	DemonstrateDraw()
+}
+
+func TestComposite(t *testing.T) {
+	r := image.Rect(0, 0, 10, 10)
+	img := Composite(r, color.White, []Layer{
+		{geometry.Rectangle{Width: 10, Height: 5}, color.NRGBA{0, 0, 0xff, 0xff}},
+		{geometry.Rectangle{Width: 5, Height: 10}, color.NRGBA{0xff, 0, 0, 0x80}},
+	})
+	assert.Equal(t, color.RGBA{0xff, 0xff, 0xff, 0xff}, img.RGBAAt(9, 9))
+	assert.Equal(t, color.RGBA{0, 0, 0xff, 0xff}, img.RGBAAt(9, 0))
+	// Half red over blue, and half red over white.
+	assert.Equal(t, color.RGBA{0x80, 0, 0x7f, 0xff}, img.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{0xff, 0x7f, 0x7f, 0xff}, img.RGBAAt(0, 9))
+}
+
+func TestMask(t *testing.T) {
+	r := image.Rect(10, 10, 20, 20)
+	m := Mask(geometry.Circle{Center: geometry.Point{X: 15, Y: 15}, Radius: 3}, r)
+	assert.Equal(t, r, m.Bounds())
+	assert.Equal(t, color.Alpha{0xff}, m.AlphaAt(15, 15))
+	assert.Equal(t, color.Alpha{}, m.AlphaAt(10, 10))
+}
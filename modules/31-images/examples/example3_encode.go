@@ -0,0 +1,64 @@
+package examples
+
+// This file shows:
+// - Encoding PNG, which is lossless, and JPEG, which is not, at a quality
+//   you choose
+// - image.Decode, which recognizes any format whose package is imported
+// - Converting between color models: to grayscale, and out of
+//   premultiplied alpha
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// Encode writes img as PNG, or as JPEG at the given quality (1 to 100)
+// when quality is not 0.
+func Encode(w io.Writer, img image.Image, quality int) error {
+	if quality == 0 {
+		return png.Encode(w, img)
+	}
+	// JPEG has no alpha: translucent pixels come out over black.
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+}
+
+// Gray returns img in shades of gray, with the same bounds.
+func Gray(img image.Image) *image.Gray {
+	gray := image.NewGray(img.Bounds())
+	// Draw converts every pixel through gray's color model,
+	// color.GrayModel, which weighs green the most, like the eye.
+	draw.Draw(gray, gray.Bounds(), img, img.Bounds().Min, draw.Src)
+	return gray
+}
+
+// DemonstrateEncode encodes the scene in both formats and reads it back.
+func DemonstrateEncode() {
+	img := Scene()
+	for _, quality := range []int{0, 90, 10} {
+		var buf bytes.Buffer
+		if err := Encode(&buf, img, quality); err != nil {
+			fmt.Println(err)
+			return
+		}
+		// image.Decode works out the format from the first bytes.
+		decoded, format, err := image.Decode(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("%s, quality %d: %d bytes, pixel 6,6 is %v\n",
+			format, quality, buf.Len(), color.RGBAModel.Convert(decoded.At(6, 6)))
+	}
+
+	gray := Gray(img)
+	fmt.Println("blue as gray:", gray.GrayAt(6, 6))
+	// The 16-bit values from RGBA() need >> 8 to fit a uint8.
+	r, g, b, a := color.NRGBA{0xe5, 0x39, 0x35, 0x80}.RGBA()
+	fmt.Printf("RGBA() of a translucent red: %#04x %#04x %#04x %#04x (premultiplied, 16-bit)\n", r, g, b, a)
+}
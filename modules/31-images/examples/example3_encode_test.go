@@ -0,0 +1,51 @@
+package examples
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateEncode(t *testing.T) {
+	DemonstrateEncode()
+}
+
+func TestEncodePNGLossless(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, Scene(), 0))
+	img, format, err := image.Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, "png", format)
+	checkGolden(t, "scene", img)
+}
+
+func TestEncodeJPEG(t *testing.T) {
+	sizes := map[int]int{}
+	for _, q := range []int{10, 90} {
+		var buf bytes.Buffer
+		require.NoError(t, Encode(&buf, Scene(), q))
+		sizes[q] = buf.Len()
+		img, format, err := image.Decode(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, Scene().Bounds(), img.Bounds())
+	}
+	assert.Less(t, sizes[10], sizes[90])
+}
+
+func TestGray(t *testing.T) {
+	src := image.NewRGBA(image.Rect(5, 5, 8, 6))
+	src.SetRGBA(5, 5, color.RGBA{0xff, 0, 0, 0xff})
+	src.SetRGBA(6, 5, color.RGBA{0, 0xff, 0, 0xff})
+	src.SetRGBA(7, 5, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	gray := Gray(src)
+	assert.Equal(t, src.Bounds(), gray.Bounds())
+	for x := 5; x < 8; x++ {
+		assert.Equal(t, color.GrayModel.Convert(src.At(x, 5)), gray.GrayAt(x, 5))
+	}
+	assert.Less(t, gray.GrayAt(5, 5).Y, gray.GrayAt(6, 5).Y, "green is brighter than red")
+}
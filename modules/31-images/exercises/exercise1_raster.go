@@ -0,0 +1,81 @@
+package exercises
+
+// EXERCISE: Fix a rasterizer that draws the course's shapes into images.
+// Draw paints every pixel whose center is inside a shape. Its pictures
+// are almost right: circles lose a column and lean, rectangles come out
+// the wrong size, triangles drawn clockwise vanish, and nothing shows up
+// in a sub-image.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vec"
+)
+
+// Inside reports whether p is inside s, or on its edge. Rectangles
+// include their minimum edges and exclude their maximum ones.
+func Inside(s geometry.Shape, p geometry.Point) bool {
+	switch s := s.(type) {
+	case geometry.Circle:
+		return p.Distance(s.Center) <= s.Radius
+	case geometry.Rectangle:
+		return p.X >= s.Origin.X && p.X < s.Origin.X+s.Width &&
+			p.Y >= s.Origin.Y && p.Y < s.Origin.Y+s.Height
+	case geometry.Triangle:
+		d1 := side(s.A, s.B, p)
+		d2 := side(s.B, s.C, p)
+		d3 := side(s.C, s.A, p)
+		// BUG: This only works for vertices in one order. p is inside
+		// when no two of d1, d2, and d3 have opposite signs.
+		return d1 >= 0 && d2 >= 0 && d3 >= 0
+	}
+	return false
+}
+
+// side is positive when p is on one side of the line from a to b, negative
+// on the other, and 0 on it.
+func side(a, b, p geometry.Point) float64 {
+	return vec.Cross(vec.Sub(b, a), vec.Sub(p, a))
+}
+
+// PixelBounds returns the smallest rectangle of whole pixels covering s.
+func PixelBounds(s geometry.Shape) image.Rectangle {
+	var minX, minY, maxX, maxY float64
+	switch s := s.(type) {
+	case geometry.Circle:
+		minX, minY = s.Center.X-s.Radius, s.Center.Y-s.Radius
+		maxX, maxY = s.Center.X+s.Radius, s.Center.Y+s.Radius
+	case geometry.Rectangle:
+		// BUG: image.Rect takes two corners, not a corner and a size.
+		return image.Rect(int(s.Origin.X), int(s.Origin.Y), int(s.Width), int(s.Height))
+	case geometry.Triangle:
+		minX, maxX = math.Min(s.A.X, math.Min(s.B.X, s.C.X)), math.Max(s.A.X, math.Max(s.B.X, s.C.X))
+		minY, maxY = math.Min(s.A.Y, math.Min(s.B.Y, s.C.Y)), math.Max(s.A.Y, math.Max(s.B.Y, s.C.Y))
+	}
+	// BUG: int() rounds toward zero, which drops a partly covered last
+	// pixel, and moves negative minimums right. Round outwards, with
+	// math.Floor and math.Ceil.
+	return image.Rect(int(minX), int(minY), int(maxX), int(maxY))
+}
+
+// Draw paints the pixels of dst whose centers are inside s with c. Pixel
+// x, y is the square from x, y to x+1, y+1.
+func Draw(dst *image.RGBA, s geometry.Shape, c color.RGBA) {
+	// BUG: A sub-image's pixels keep the coordinates they have in the
+	// image it came from, so Min is not always 0, 0. Use dst.Bounds().
+	r := PixelBounds(s).Intersect(image.Rect(0, 0, dst.Bounds().Dx(), dst.Bounds().Dy()))
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			// BUG: This is the pixel's top left corner, which shifts
+			// every shape half a pixel up and to the left. Test its
+			// center.
+			if Inside(s, geometry.Point{X: float64(x), Y: float64(y)}) {
+				dst.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
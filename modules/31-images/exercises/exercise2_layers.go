@@ -0,0 +1,103 @@
+package exercises
+
+// EXERCISE: Fix the color model conversions of a layered renderer.
+// Render draws translucent shapes over each other, and ToGray and ToNRGBA
+// convert the result for other programs. But translucent layers glow
+// and hide what is below them, gray images come out as noise, sub-images
+// come out shifted, and translucent pixels turn dark in ToNRGBA.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vec"
+)
+
+// Layer is a shape in a color. The color is not premultiplied: an A below
+// 0xff makes the layer translucent, whatever R, G, and B are.
+type Layer struct {
+	Shape geometry.Shape
+	Color color.NRGBA
+}
+
+// covers reports whether the center of pixel x, y is inside s.
+func covers(s geometry.Shape, x, y int) bool {
+	p := geometry.Point{X: float64(x) + 0.5, Y: float64(y) + 0.5}
+	switch s := s.(type) {
+	case geometry.Circle:
+		return p.Distance(s.Center) <= s.Radius
+	case geometry.Rectangle:
+		return p.X >= s.Origin.X && p.X < s.Origin.X+s.Width &&
+			p.Y >= s.Origin.Y && p.Y < s.Origin.Y+s.Height
+	case geometry.Triangle:
+		side := func(a, b geometry.Point) float64 {
+			return vec.Cross(vec.Sub(b, a), vec.Sub(p, a))
+		}
+		d1, d2, d3 := side(s.A, s.B), side(s.B, s.C), side(s.C, s.A)
+		return !((d1 < 0 || d2 < 0 || d3 < 0) && (d1 > 0 || d2 > 0 || d3 > 0))
+	}
+	return false
+}
+
+// mask returns an alpha mask over r, opaque where s covers a pixel.
+func mask(s geometry.Shape, r image.Rectangle) *image.Alpha {
+	m := image.NewAlpha(r)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if covers(s, x, y) {
+				m.SetAlpha(x, y, color.Alpha{A: 0xff})
+			}
+		}
+	}
+	return m
+}
+
+// Render draws layers over an opaque background, the first layer at the
+// bottom.
+func Render(r image.Rectangle, background color.Color, layers []Layer) *image.RGBA {
+	dst := image.NewRGBA(r)
+	draw.Draw(dst, r, image.NewUniform(background), image.Point{}, draw.Src)
+	for _, l := range layers {
+		// BUG: color.RGBA(l.Color) relabels the bytes without converting
+		// them: color.RGBA is premultiplied, so R, G, and B must be at
+		// most A. Use l.Color itself, whose RGBA method premultiplies.
+		src := image.NewUniform(color.RGBA(l.Color))
+		// BUG: Src replaces what is below, alpha and all, so the
+		// background shows through a translucent layer as black. Over
+		// blends.
+		draw.DrawMask(dst, r, src, image.Point{}, mask(l.Shape, r), r.Min, draw.Src)
+	}
+	return dst
+}
+
+// ToGray returns img in shades of gray, with the same bounds.
+func ToGray(img image.Image) *image.Gray {
+	b := img.Bounds()
+	gray := image.NewGray(b)
+	// BUG: An image's pixels need not start at 0, 0. Loop from b.Min to
+	// b.Max.
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			// BUG: RGBA() returns 16-bit values, and uint8 keeps the low
+			// byte, which is noise. The eye does not weigh the channels
+			// equally either. Use color.GrayModel.Convert.
+			r, g, bl, _ := img.At(x, y).RGBA()
+			gray.SetGray(x, y, color.Gray{Y: uint8((r + g + bl) / 3)})
+		}
+	}
+	return gray
+}
+
+// ToNRGBA returns a copy of img with colors that are not premultiplied,
+// as image formats and most other programs expect.
+func ToNRGBA(img *image.RGBA) *image.NRGBA {
+	dst := image.NewNRGBA(img.Bounds())
+	// BUG: image.RGBA's bytes are premultiplied, and NRGBA's are not:
+	// translucent pixels come out dark. draw.Draw converts every pixel
+	// through dst's color model.
+	copy(dst.Pix, img.Pix)
+	return dst
+}
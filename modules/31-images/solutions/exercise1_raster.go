@@ -0,0 +1,79 @@
+package solutions
+
+// SOLUTION: Fix a rasterizer that draws the course's shapes into images.
+// PixelBounds rounds outwards and treats a rectangle's size as a size,
+// Inside accepts triangles in either vertex order, and Draw samples the
+// center of each pixel and clips to the image's real bounds.
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vec"
+)
+
+// Inside reports whether p is inside s, or on its edge. Rectangles
+// include their minimum edges and exclude their maximum ones.
+func Inside(s geometry.Shape, p geometry.Point) bool {
+	switch s := s.(type) {
+	case geometry.Circle:
+		return p.Distance(s.Center) <= s.Radius
+	case geometry.Rectangle:
+		return p.X >= s.Origin.X && p.X < s.Origin.X+s.Width &&
+			p.Y >= s.Origin.Y && p.Y < s.Origin.Y+s.Height
+	case geometry.Triangle:
+		d1 := side(s.A, s.B, p)
+		d2 := side(s.B, s.C, p)
+		d3 := side(s.C, s.A, p)
+		// Fixed: All on one side, whichever side that is: the vertices
+		// may go either way round.
+		neg := d1 < 0 || d2 < 0 || d3 < 0
+		pos := d1 > 0 || d2 > 0 || d3 > 0
+		return !(neg && pos)
+	}
+	return false
+}
+
+// side is positive when p is on one side of the line from a to b, negative
+// on the other, and 0 on it.
+func side(a, b, p geometry.Point) float64 {
+	return vec.Cross(vec.Sub(b, a), vec.Sub(p, a))
+}
+
+// PixelBounds returns the smallest rectangle of whole pixels covering s.
+func PixelBounds(s geometry.Shape) image.Rectangle {
+	var minX, minY, maxX, maxY float64
+	switch s := s.(type) {
+	case geometry.Circle:
+		minX, minY = s.Center.X-s.Radius, s.Center.Y-s.Radius
+		maxX, maxY = s.Center.X+s.Radius, s.Center.Y+s.Radius
+	case geometry.Rectangle:
+		// Fixed: image.Rect takes two corners, not a corner and a size.
+		minX, minY = s.Origin.X, s.Origin.Y
+		maxX, maxY = s.Origin.X+s.Width, s.Origin.Y+s.Height
+	case geometry.Triangle:
+		minX, maxX = math.Min(s.A.X, math.Min(s.B.X, s.C.X)), math.Max(s.A.X, math.Max(s.B.X, s.C.X))
+		minY, maxY = math.Min(s.A.Y, math.Min(s.B.Y, s.C.Y)), math.Max(s.A.Y, math.Max(s.B.Y, s.C.Y))
+	}
+	// Fixed: Round outwards. int() rounds toward zero, which drops a
+	// partly covered last pixel, and moves negative minimums right.
+	return image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY)))
+}
+
+// Draw paints the pixels of dst whose centers are inside s with c. Pixel
+// x, y is the square from x, y to x+1, y+1.
+func Draw(dst *image.RGBA, s geometry.Shape, c color.RGBA) {
+	// Fixed: dst.Bounds(). A sub-image's pixels keep the coordinates
+	// they have in the image it came from, so Min is not always 0, 0.
+	r := PixelBounds(s).Intersect(dst.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			// Fixed: The center of the pixel, not its corner.
+			if Inside(s, geometry.Point{X: float64(x) + 0.5, Y: float64(y) + 0.5}) {
+				dst.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package solutions
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The golden images come from the solutions: never run -update on your
+// own exercises.
+var update = flag.Bool("update", false, "rewrite the golden images in testdata")
+
+// checkGolden compares img with testdata/name.png, pixel by pixel. With
+// -update, it writes img there instead.
+func checkGolden(t *testing.T, name string, img image.Image) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".png")
+	if *update {
+		f, err := os.Create(path)
+		require.NoError(t, err)
+		require.NoError(t, png.Encode(f, img))
+		require.NoError(t, f.Close())
+	}
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	want, err := png.Decode(f)
+	require.NoError(t, err)
+
+	require.Equal(t, want.Bounds(), img.Bounds(), "bounds")
+	b := img.Bounds()
+	diffs := 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			w := color.RGBA64Model.Convert(want.At(x, y))
+			g := color.RGBA64Model.Convert(img.At(x, y))
+			if w != g {
+				if diffs == 0 {
+					t.Errorf("pixel %d,%d is %v, want %v", x, y, g, w)
+				}
+				diffs++
+			}
+		}
+	}
+	assert.Zero(t, diffs, "pixels that differ from %s; write the image out and look at it", path)
+}
+
+var white = color.RGBA{0xff, 0xff, 0xff, 0xff}
+
+// painted returns the pixels of img that are not transparent.
+func painted(img *image.RGBA) map[image.Point]bool {
+	p := make(map[image.Point]bool)
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if img.RGBAAt(x, y).A != 0 {
+				p[image.Point{x, y}] = true
+			}
+		}
+	}
+	return p
+}
+
+func TestPixelBounds(t *testing.T) {
+	for _, tc := range []struct {
+		shape geometry.Shape
+		want  image.Rectangle
+	}{
+		{geometry.Circle{Center: geometry.Point{X: 10, Y: 10}, Radius: 5}, image.Rect(5, 5, 15, 15)},
+		{geometry.Circle{Center: geometry.Point{X: 10, Y: 10}, Radius: 5.5}, image.Rect(4, 4, 16, 16)},
+		{geometry.Rectangle{Origin: geometry.Point{X: 5, Y: 8}, Width: 10, Height: 4}, image.Rect(5, 8, 15, 12)},
+		{geometry.Rectangle{Origin: geometry.Point{X: -1.5, Y: 0.5}, Width: 3, Height: 1}, image.Rect(-2, 0, 2, 2)},
+		{geometry.Triangle{A: geometry.Point{X: 1, Y: 9.5}, B: geometry.Point{X: 4.2, Y: -3}, C: geometry.Point{X: 8, Y: 2}}, image.Rect(1, -3, 8, 10)},
+	} {
+		assert.Equal(t, tc.want, PixelBounds(tc.shape), "%v", tc.shape)
+	}
+}
+
+func TestDrawRectangle(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 30, 20))
+	Draw(img, geometry.Rectangle{Origin: geometry.Point{X: 5, Y: 8}, Width: 10, Height: 4}, white)
+	got := painted(img)
+	assert.Len(t, got, 40)
+	for y := 8; y < 12; y++ {
+		for x := 5; x < 15; x++ {
+			assert.True(t, got[image.Point{x, y}], "pixel %d,%d", x, y)
+		}
+	}
+}
+
+func TestDrawCircleSymmetric(t *testing.T) {
+	r := seed.Rand(t, 1)
+	for i := 0; i < 10; i++ {
+		cx, cy := seed.Between(r, 10, 30), seed.Between(r, 10, 30)
+		radius := float64(seed.Between(r, 2, 10)) + 0.5*float64(r.Intn(2))
+		c := geometry.Circle{Center: geometry.Point{X: float64(cx), Y: float64(cy)}, Radius: radius}
+		img := image.NewRGBA(image.Rect(0, 0, 40, 40))
+		Draw(img, c, white)
+		got := painted(img)
+
+		assert.InDelta(t, c.Area(), float64(len(got)), c.Area()*0.15, "%v", c)
+		// Pixel x covers x to x+1, so its mirror in x = cx is 2cx-1-x.
+		for p := range got {
+			mirror := image.Point{2*cx - 1 - p.X, 2*cy - 1 - p.Y}
+			if !got[mirror] {
+				t.Fatalf("%v at %d,%d: pixel %v is painted, but not %v", c, cx, cy, p, mirror)
+			}
+		}
+	}
+}
+
+func TestDrawTriangleEitherWay(t *testing.T) {
+	a, b, c := geometry.Point{X: 2, Y: 2}, geometry.Point{X: 28, Y: 6}, geometry.Point{X: 10, Y: 26}
+	counts := make([]int, 0, 2)
+	for _, tri := range []geometry.Triangle{{A: a, B: b, C: c}, {A: a, B: c, C: b}} {
+		img := image.NewRGBA(image.Rect(0, 0, 30, 30))
+		Draw(img, tri, white)
+		counts = append(counts, len(painted(img)))
+		assert.InDelta(t, tri.Area(), float64(counts[len(counts)-1]), tri.Area()*0.1, "%v", tri)
+	}
+	assert.Equal(t, counts[0], counts[1])
+}
+
+func TestDrawSubImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 40))
+	sub := img.SubImage(image.Rect(20, 20, 40, 40)).(*image.RGBA)
+	Draw(sub, geometry.Circle{Center: geometry.Point{X: 30, Y: 30}, Radius: 4}, white)
+	assert.Equal(t, white, img.RGBAAt(30, 30), "drawn into the image the sub-image shares")
+	assert.Equal(t, painted(sub), painted(img))
+
+	// A shape half outside is clipped, not drawn outside sub.
+	Draw(sub, geometry.Circle{Center: geometry.Point{X: 20, Y: 20}, Radius: 6}, white)
+	for p := range painted(img) {
+		assert.True(t, p.In(sub.Bounds()), "pixel %v is outside the sub-image", p)
+	}
+	// Negative coordinates and shapes far outside are fine.
+	Draw(img, geometry.Rectangle{Origin: geometry.Point{X: -100, Y: -100}, Width: 10, Height: 10}, white)
+	Draw(img, geometry.Circle{Center: geometry.Point{X: -1, Y: 10}, Radius: 2}, white)
+	assert.Equal(t, white, img.RGBAAt(0, 10))
+}
+
+func TestDrawGolden(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	for _, s := range []struct {
+		shape geometry.Shape
+		color color.RGBA
+	}{
+		{geometry.Rectangle{Origin: geometry.Point{X: 2, Y: 3}, Width: 27, Height: 17}, color.RGBA{0x1e, 0x88, 0xe5, 0xff}},
+		{geometry.Circle{Center: geometry.Point{X: 44, Y: 14}, Radius: 10.5}, color.RGBA{0xe5, 0x39, 0x35, 0xff}},
+		{geometry.Triangle{A: geometry.Point{X: 4, Y: 44}, B: geometry.Point{X: 30, Y: 24}, C: geometry.Point{X: 34, Y: 46}}, color.RGBA{0x43, 0xa0, 0x47, 0xff}},
+		{geometry.Triangle{A: geometry.Point{X: 40, Y: 30}, B: geometry.Point{X: 62, Y: 30}, C: geometry.Point{X: 51, Y: 46}}, color.RGBA{0xfb, 0xc0, 0x2d, 0xff}},
+		{geometry.Circle{Center: geometry.Point{X: 20, Y: 20}, Radius: 5.7}, color.RGBA{0x8e, 0x24, 0xaa, 0xff}},
+	} {
+		Draw(img, s.shape, s.color)
+	}
+	checkGolden(t, "shapes", img)
+}
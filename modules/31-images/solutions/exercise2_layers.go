@@ -0,0 +1,95 @@
+package solutions
+
+// SOLUTION: Fix the color model conversions of a layered renderer.
+// Render keeps a layer's color non-premultiplied and blends it with
+// draw.Over, ToGray converts through color.GrayModel at the image's own
+// coordinates, and ToNRGBA un-premultiplies instead of copying bytes.
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vec"
+)
+
+// Layer is a shape in a color. The color is not premultiplied: an A below
+// 0xff makes the layer translucent, whatever R, G, and B are.
+type Layer struct {
+	Shape geometry.Shape
+	Color color.NRGBA
+}
+
+// covers reports whether the center of pixel x, y is inside s.
+func covers(s geometry.Shape, x, y int) bool {
+	p := geometry.Point{X: float64(x) + 0.5, Y: float64(y) + 0.5}
+	switch s := s.(type) {
+	case geometry.Circle:
+		return p.Distance(s.Center) <= s.Radius
+	case geometry.Rectangle:
+		return p.X >= s.Origin.X && p.X < s.Origin.X+s.Width &&
+			p.Y >= s.Origin.Y && p.Y < s.Origin.Y+s.Height
+	case geometry.Triangle:
+		side := func(a, b geometry.Point) float64 {
+			return vec.Cross(vec.Sub(b, a), vec.Sub(p, a))
+		}
+		d1, d2, d3 := side(s.A, s.B), side(s.B, s.C), side(s.C, s.A)
+		return !((d1 < 0 || d2 < 0 || d3 < 0) && (d1 > 0 || d2 > 0 || d3 > 0))
+	}
+	return false
+}
+
+// mask returns an alpha mask over r, opaque where s covers a pixel.
+func mask(s geometry.Shape, r image.Rectangle) *image.Alpha {
+	m := image.NewAlpha(r)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if covers(s, x, y) {
+				m.SetAlpha(x, y, color.Alpha{A: 0xff})
+			}
+		}
+	}
+	return m
+}
+
+// Render draws layers over an opaque background, the first layer at the
+// bottom.
+func Render(r image.Rectangle, background color.Color, layers []Layer) *image.RGBA {
+	dst := image.NewRGBA(r)
+	draw.Draw(dst, r, image.NewUniform(background), image.Point{}, draw.Src)
+	for _, l := range layers {
+		// Fixed: The NRGBA color itself; its RGBA method premultiplies.
+		// color.RGBA(l.Color) only relabels the bytes.
+		src := image.NewUniform(l.Color)
+		// Fixed: Over blends with what is below; Src replaces it.
+		draw.DrawMask(dst, r, src, image.Point{}, mask(l.Shape, r), r.Min, draw.Over)
+	}
+	return dst
+}
+
+// ToGray returns img in shades of gray, with the same bounds.
+func ToGray(img image.Image) *image.Gray {
+	b := img.Bounds()
+	gray := image.NewGray(b)
+	// Fixed: From Min to Max: an image's pixels need not start at 0, 0.
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			// Fixed: GrayModel weighs the channels as the eye does, and
+			// scales the 16-bit values of RGBA() down to 8 bits.
+			gray.Set(x, y, color.GrayModel.Convert(img.At(x, y)))
+		}
+	}
+	return gray
+}
+
+// ToNRGBA returns a copy of img with colors that are not premultiplied,
+// as image formats and most other programs expect.
+func ToNRGBA(img *image.RGBA) *image.NRGBA {
+	dst := image.NewNRGBA(img.Bounds())
+	// Fixed: Draw converts every pixel through dst's color model, which
+	// divides R, G, and B by alpha. Copying Pix would keep them
+	// premultiplied, and translucent pixels would come out dark.
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Src)
+	return dst
+}
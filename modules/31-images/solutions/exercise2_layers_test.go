@@ -0,0 +1,110 @@
+package solutions
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderOpaque(t *testing.T) {
+	r := image.Rect(0, 0, 10, 10)
+	img := Render(r, color.White, []Layer{
+		{geometry.Rectangle{Width: 10, Height: 5}, color.NRGBA{0, 0, 0xff, 0xff}},
+	})
+	assert.Equal(t, r, img.Bounds())
+	assert.Equal(t, color.RGBA{0, 0, 0xff, 0xff}, img.RGBAAt(3, 2))
+	assert.Equal(t, color.RGBA{0xff, 0xff, 0xff, 0xff}, img.RGBAAt(3, 7))
+}
+
+func TestRenderTranslucent(t *testing.T) {
+	img := Render(image.Rect(0, 0, 10, 10), color.White, []Layer{
+		{geometry.Rectangle{Width: 10, Height: 5}, color.NRGBA{0, 0, 0xff, 0xff}},
+		{geometry.Rectangle{Width: 5, Height: 10}, color.NRGBA{0xff, 0, 0, 0x80}},
+		{geometry.Rectangle{Width: 10, Height: 10}, color.NRGBA{0xff, 0xff, 0xff, 0}},
+	})
+	// Half red over blue, and half red over white. A transparent layer
+	// changes nothing.
+	assert.Equal(t, color.RGBA{0x80, 0, 0x7f, 0xff}, img.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{0xff, 0x7f, 0x7f, 0xff}, img.RGBAAt(0, 9))
+	assert.Equal(t, color.RGBA{0, 0, 0xff, 0xff}, img.RGBAAt(9, 0))
+	assert.Equal(t, color.RGBA{0xff, 0xff, 0xff, 0xff}, img.RGBAAt(9, 9))
+	for i := 0; i < len(img.Pix); i += 4 {
+		require.Equal(t, uint8(0xff), img.Pix[i+3], "an opaque background stays opaque")
+	}
+}
+
+func TestRenderGolden(t *testing.T) {
+	img := Render(image.Rect(0, 0, 64, 48), color.NRGBA{0xf5, 0xf5, 0xdc, 0xff}, []Layer{
+		{geometry.Rectangle{Origin: geometry.Point{X: 4, Y: 4}, Width: 36, Height: 24}, color.NRGBA{0x1e, 0x88, 0xe5, 0xff}},
+		{geometry.Circle{Center: geometry.Point{X: 36, Y: 24}, Radius: 16}, color.NRGBA{0xe5, 0x39, 0x35, 0x80}},
+		{geometry.Triangle{A: geometry.Point{X: 8, Y: 44}, B: geometry.Point{X: 40, Y: 44}, C: geometry.Point{X: 24, Y: 16}}, color.NRGBA{0x43, 0xa0, 0x47, 0xc0}},
+		{geometry.Circle{Center: geometry.Point{X: 52, Y: 36}, Radius: 9.5}, color.NRGBA{0xfb, 0xc0, 0x2d, 0x40}},
+	})
+	checkGolden(t, "layers", img)
+}
+
+func TestToGray(t *testing.T) {
+	r := seed.Rand(t, 1)
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 16))
+	r.Read(img.Pix)
+	gray := ToGray(img)
+	require.Equal(t, img.Bounds(), gray.Bounds())
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			require.Equal(t, color.GrayModel.Convert(img.At(x, y)), gray.GrayAt(x, y), "pixel %d,%d", x, y)
+		}
+	}
+	// Green looks brighter than blue of the same value.
+	rgb := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	rgb.SetRGBA(0, 0, color.RGBA{0, 0xc0, 0, 0xff})
+	rgb.SetRGBA(1, 0, color.RGBA{0, 0, 0xc0, 0xff})
+	gray = ToGray(rgb)
+	assert.Greater(t, gray.GrayAt(0, 0).Y, gray.GrayAt(1, 0).Y)
+}
+
+func TestToGraySubImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	img.SetRGBA(15, 15, color.RGBA{0, 0, 0, 0xff})
+	sub := img.SubImage(image.Rect(10, 10, 20, 20))
+	gray := ToGray(sub)
+	assert.Equal(t, image.Rect(10, 10, 20, 20), gray.Bounds())
+	assert.Equal(t, color.Gray{0}, gray.GrayAt(15, 15))
+	assert.Equal(t, color.Gray{0xff}, gray.GrayAt(10, 10))
+	assert.Equal(t, color.Gray{0xff}, gray.GrayAt(19, 19))
+}
+
+func TestToNRGBA(t *testing.T) {
+	img := image.NewRGBA(image.Rect(3, 3, 6, 4))
+	img.SetRGBA(3, 3, color.RGBA{0x80, 0, 0, 0x80}) // half-transparent red
+	img.SetRGBA(4, 3, color.RGBA{0x12, 0x34, 0x56, 0xff})
+	n := ToNRGBA(img)
+	assert.Equal(t, img.Bounds(), n.Bounds())
+	assert.Equal(t, color.NRGBA{0xff, 0, 0, 0x80}, n.NRGBAAt(3, 3))
+	assert.Equal(t, color.NRGBA{0x12, 0x34, 0x56, 0xff}, n.NRGBAAt(4, 3))
+	assert.Equal(t, color.NRGBA{}, n.NRGBAAt(5, 3))
+
+	// The colors are the same, in another model.
+	layers := Render(image.Rect(0, 0, 64, 48), color.Transparent, []Layer{
+		{geometry.Circle{Center: geometry.Point{X: 32, Y: 24}, Radius: 16}, color.NRGBA{0xe5, 0x39, 0x35, 0x80}},
+	})
+	n = ToNRGBA(layers)
+	for y := 0; y < 48; y++ {
+		for x := 0; x < 64; x++ {
+			require.Equal(t, color.NRGBAModel.Convert(layers.At(x, y)), n.At(x, y), "pixel %d,%d", x, y)
+		}
+	}
+	// Premultiplied 8-bit channels lose a little precision, not more.
+	got := n.NRGBAAt(32, 24)
+	assert.Equal(t, uint8(0x80), got.A)
+	for i, c := range []uint8{got.R, got.G, got.B} {
+		assert.InDelta(t, []uint8{0xe5, 0x39, 0x35}[i], c, 3)
+	}
+}
@@ -0,0 +1,62 @@
+// Package examples demonstrates rendering HTML in Go: what html/template
+// escapes that text/template does not, how it escapes a value for where
+// it lands in the page, and pages that share one layout.
+//
+// This file shows:
+// - text/template and html/template share their syntax and their API
+// - text/template writes values as they are, which is right for text
+// - html/template escapes every value for where it lands in the page
+// - Switching between them is a one-line change to the imports
+package examples
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Card is what a learner shows about themselves on the dashboard.
+type Card struct {
+	Name    string
+	Website string
+}
+
+// cardTemplate is parsed by both packages. Nothing in it says which.
+const cardTemplate = `<div class="card"><a href="{{.Website}}">{{.Name}}</a></div>`
+
+var (
+	textCard = texttemplate.Must(texttemplate.New("card").Parse(cardTemplate))
+	htmlCard = htmltemplate.Must(htmltemplate.New("card").Parse(cardTemplate))
+)
+
+// RenderText renders c with text/template, which does not escape.
+func RenderText(c Card) (string, error) {
+	var b strings.Builder
+	err := textCard.Execute(&b, c)
+	return b.String(), err
+}
+
+// RenderHTML renders c with html/template, which escapes.
+func RenderHTML(c Card) (string, error) {
+	var b strings.Builder
+	err := htmlCard.Execute(&b, c)
+	return b.String(), err
+}
+
+// DemonstrateEscaping renders the same card with both packages.
+func DemonstrateEscaping() {
+	fmt.Println("=== text/template vs html/template ===")
+
+	cards := []Card{
+		{Name: "Ada", Website: "https://example.com/ada"},
+		{Name: `<script>alert("hi")</script>`, Website: "javascript:alert(1)"},
+	}
+	for _, c := range cards {
+		text, _ := RenderText(c)
+		html, _ := RenderHTML(c)
+		fmt.Printf("text/template: %s\n", text)
+		fmt.Printf("html/template: %s\n\n", html)
+	}
+	fmt.Println("text/template runs the script and the javascript: link; html/template shows the name and drops the link")
+}
@@ -0,0 +1,36 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateEscaping(t *testing.T) {
+	DemonstrateEscaping()
+}
+
+func TestRenderSameForSafeInput(t *testing.T) {
+	c := Card{Name: "Ada", Website: "https://example.com/ada"}
+	text, err := RenderText(c)
+	require.NoError(t, err)
+	html, err := RenderHTML(c)
+	require.NoError(t, err)
+	assert.Equal(t, text, html)
+}
+
+func TestRenderTextDoesNotEscape(t *testing.T) {
+	out, err := RenderText(Card{Name: "<b>Ada</b>", Website: "javascript:alert(1)"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "<b>Ada</b>")
+	assert.Contains(t, out, `href="javascript:alert(1)"`)
+}
+
+func TestRenderHTMLEscapes(t *testing.T) {
+	out, err := RenderHTML(Card{Name: "<b>Ada</b>", Website: "javascript:alert(1)"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "&lt;b&gt;Ada&lt;/b&gt;")
+	assert.Contains(t, out, `href="#ZgotmplZ"`)
+	assert.NotContains(t, out, "<b>")
+}
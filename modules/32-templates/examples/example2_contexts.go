@@ -0,0 +1,91 @@
+package examples
+
+// This file shows:
+// - html/template picks an escaper for each action from its context:
+//   element text, attribute, URL, query parameter, JavaScript, or CSS
+// - Unsafe URLs and CSS become ZgotmplZ instead of running
+// - Values in a <script> become JavaScript literals, structs become
+//   objects
+// - template.HTML, template.URL, and template.JS skip the escaping, so
+//   only build them from text you have escaped yourself
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Progress is a learner's progress through one module.
+type Progress struct {
+	Module string
+	Done   int
+	Total  int
+}
+
+// contextsTemplate puts the same value into every context html/template
+// knows about.
+const contextsTemplate = `text:  <p>{{.}}</p>
+attr:  <p title="{{.}}"></p>
+url:   <a href="{{.}}"></a>
+query: <a href="/search?q={{.}}"></a>
+js:    <script>const v = {{.}};</script>
+css:   <p style="color: {{.}}"></p>
+`
+
+var contexts = template.Must(template.New("contexts").Parse(contextsTemplate))
+
+// Contexts renders v in every context.
+func Contexts(v any) (string, error) {
+	var b strings.Builder
+	err := contexts.Execute(&b, v)
+	return b.String(), err
+}
+
+// Paragraphs turns text with blank lines between paragraphs into HTML
+// paragraphs. It escapes the text before adding markup, which is what
+// makes returning template.HTML safe.
+func Paragraphs(text string) template.HTML {
+	var b strings.Builder
+	for _, p := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			b.WriteString("<p>" + template.HTMLEscapeString(p) + "</p>")
+		}
+	}
+	return template.HTML(b.String())
+}
+
+// bioTemplate shows a learner's bio with Paragraphs.
+var bioTemplate = template.Must(template.New("bio").
+	Funcs(template.FuncMap{"paragraphs": Paragraphs}).
+	Parse(`<section>{{paragraphs .}}</section>`))
+
+// Bio renders text as paragraphs.
+func Bio(text string) (string, error) {
+	var b strings.Builder
+	err := bioTemplate.Execute(&b, text)
+	return b.String(), err
+}
+
+// DemonstrateContexts renders a few values in every context, then shows
+// what the trusted types do.
+func DemonstrateContexts() {
+	fmt.Println("=== Escaping by context ===")
+	for _, v := range []any{
+		`O'Brien & "Co"`,
+		"javascript:alert(1)",
+		"teal",
+		Progress{Module: "32-templates", Done: 1, Total: 2},
+	} {
+		out, _ := Contexts(v)
+		fmt.Printf("%#v\n%s\n", v, out)
+	}
+
+	fmt.Println("=== Trusted types ===")
+	// template.URL says "this URL is safe"; here it is not.
+	out, _ := Contexts(template.URL("javascript:alert(1)"))
+	fmt.Printf("template.URL(\"javascript:alert(1)\") keeps the link:\n%s\n", out)
+
+	// template.HTML built from escaped text is safe.
+	bio, _ := Bio("I like <b>Go</b>.\n\nAnd tea.")
+	fmt.Printf("Paragraphs: %s\n", bio)
+}
@@ -0,0 +1,62 @@
+package examples
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateContexts(t *testing.T) {
+	DemonstrateContexts()
+}
+
+// line returns the line of out that starts with prefix.
+func line(t *testing.T, out, prefix string) string {
+	t.Helper()
+	for _, l := range strings.Split(out, "\n") {
+		if strings.HasPrefix(l, prefix) {
+			return l
+		}
+	}
+	t.Fatalf("no line starting with %q in:\n%s", prefix, out)
+	return ""
+}
+
+func TestContextsEscapeEach(t *testing.T) {
+	out, err := Contexts(`<a&"b'>`)
+	require.NoError(t, err)
+
+	assert.Equal(t, `text:  <p>&lt;a&amp;&#34;b&#39;&gt;</p>`, line(t, out, "text:"))
+	assert.Equal(t, `attr:  <p title="&lt;a&amp;&#34;b&#39;&gt;"></p>`, line(t, out, "attr:"))
+	assert.Equal(t, `query: <a href="/search?q=%3ca%26%22b%27%3e"></a>`, line(t, out, "query:"))
+	assert.Equal(t, `js:    <script>const v = "\u003ca\u0026\"b'\u003e";</script>`, line(t, out, "js:"))
+	assert.Equal(t, `css:   <p style="color: ZgotmplZ"></p>`, line(t, out, "css:"))
+}
+
+func TestContextsFilterURLs(t *testing.T) {
+	out, err := Contexts("javascript:alert(1)")
+	require.NoError(t, err)
+	assert.Equal(t, `url:   <a href="#ZgotmplZ"></a>`, line(t, out, "url:"))
+
+	// The parentheses are percent-encoded, and the browser decodes them
+	// before it runs the script.
+	out, err = Contexts(template.URL("javascript:alert(1)"))
+	require.NoError(t, err)
+	assert.Equal(t, `url:   <a href="javascript:alert%281%29"></a>`, line(t, out, "url:"),
+		"template.URL turns the filter off")
+}
+
+func TestContextsStructInScript(t *testing.T) {
+	out, err := Contexts(Progress{Module: "32-templates", Done: 1, Total: 2})
+	require.NoError(t, err)
+	assert.Equal(t, `js:    <script>const v = {"Module":"32-templates","Done":1,"Total":2};</script>`, line(t, out, "js:"))
+}
+
+func TestBio(t *testing.T) {
+	out, err := Bio("I like <b>Go</b>.\r\n\r\n\n\nAnd tea.\n")
+	require.NoError(t, err)
+	assert.Equal(t, "<section><p>I like &lt;b&gt;Go&lt;/b&gt;.</p><p>And tea.</p></section>", out)
+}
@@ -0,0 +1,111 @@
+package examples
+
+// This file shows:
+// - A layout with {{block}}s that pages fill in with {{define}}
+// - One template set per page, cloned from the layout, so that pages do
+//   not overwrite each other's blocks
+// - Parsing templates embedded with go:embed, like the learngo dashboard
+// - Executing into a buffer, so that an error does not send half a page
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// Module is one row of the dashboard.
+type Module struct {
+	ID    string
+	Title string
+	Done  int
+	Total int
+}
+
+// Page is the data every page gets. Pages use the fields they need.
+type Page struct {
+	Learner string
+	Modules []Module
+	Module  Module
+}
+
+// Pages holds one template per page, each a copy of the layout with the
+// page's blocks defined.
+type Pages map[string]*template.Template
+
+// ParsePages parses layout.html from fsys, then every other .html file as
+// a page named after the file.
+func ParsePages(fsys fs.FS) (Pages, error) {
+	layout, err := template.ParseFS(fsys, "layout.html")
+	if err != nil {
+		return nil, err
+	}
+	files, err := fs.Glob(fsys, "*.html")
+	if err != nil {
+		return nil, err
+	}
+	pages := Pages{}
+	for _, file := range files {
+		if file == "layout.html" {
+			continue
+		}
+		// Parsing the page into the layout itself would redefine the
+		// blocks for every page. Each page gets its own copy.
+		t, err := layout.Clone()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := t.ParseFS(fsys, file); err != nil {
+			return nil, err
+		}
+		pages[strings.TrimSuffix(path.Base(file), ".html")] = t
+	}
+	return pages, nil
+}
+
+// Render executes page with data and writes it to w. Nothing is written
+// if the page fails.
+func (p Pages) Render(w io.Writer, page string, data Page) error {
+	t, ok := p[page]
+	if !ok {
+		return fmt.Errorf("no page %q", page)
+	}
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, "layout.html", data); err != nil {
+		return err
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// DemonstratePages renders each page of a small dashboard.
+func DemonstratePages() {
+	fmt.Println("=== Layouts and pages ===")
+
+	sub, _ := fs.Sub(templates, "templates")
+	pages, err := ParsePages(sub)
+	if err != nil {
+		fmt.Println("parse:", err)
+		return
+	}
+	modules := []Module{
+		{ID: "31-images", Title: "Image Processing", Done: 2, Total: 2},
+		{ID: "32-templates", Title: "HTML Templates", Done: 0, Total: 2},
+	}
+	data := Page{Learner: "Ada <ada@example.com>", Modules: modules, Module: modules[1]}
+	for _, name := range []string{"dashboard", "module", "about"} {
+		var b strings.Builder
+		if err := pages.Render(&b, name, data); err != nil {
+			fmt.Println(name+":", err)
+			continue
+		}
+		fmt.Printf("--- %s ---\n%s\n", name, b.String())
+	}
+}
@@ -0,0 +1,81 @@
+package examples
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstratePages(t *testing.T) {
+	DemonstratePages()
+}
+
+func parsePages(t *testing.T) Pages {
+	t.Helper()
+	sub, err := fs.Sub(templates, "templates")
+	require.NoError(t, err)
+	pages, err := ParsePages(sub)
+	require.NoError(t, err)
+	return pages
+}
+
+func TestPagesKeepTheirOwnBlocks(t *testing.T) {
+	pages := parsePages(t)
+	assert.Len(t, pages, 3)
+
+	m := Module{ID: "32-templates", Title: "HTML Templates", Done: 1, Total: 2}
+	data := Page{Learner: "Ada", Modules: []Module{m}, Module: m}
+
+	var b strings.Builder
+	require.NoError(t, pages.Render(&b, "dashboard", data))
+	assert.Contains(t, b.String(), "<title>Dashboard · Learn Go the Hard Way</title>")
+	assert.Contains(t, b.String(), `<li><a href="/modules/32-templates">HTML Templates</a> 1/2</li>`)
+	assert.NotContains(t, b.String(), "<progress")
+	snapshot.Match(t, "dashboard.html", b.String())
+
+	b.Reset()
+	require.NoError(t, pages.Render(&b, "module", data))
+	assert.Contains(t, b.String(), "<title>HTML Templates · Learn Go the Hard Way</title>")
+	assert.Contains(t, b.String(), `<script>const progress = {"ID":"32-templates","Title":"HTML Templates","Done":1,"Total":2};</script>`)
+	assert.NotContains(t, b.String(), "<h1>Dashboard</h1>")
+	snapshot.Match(t, "module.html", b.String())
+
+	b.Reset()
+	require.NoError(t, pages.Render(&b, "about", data))
+	assert.Contains(t, b.String(), "<title>Learn Go the Hard Way</title>")
+	assert.Contains(t, b.String(), "<p>Nothing here yet.</p>")
+	snapshot.Match(t, "about.html", b.String())
+}
+
+func TestPagesEscapeLayout(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, parsePages(t).Render(&b, "about", Page{Learner: "<script>"}))
+	assert.Contains(t, b.String(), "· &lt;script&gt;</nav>")
+}
+
+type failingWriter struct{ wrote bool }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.wrote = true
+	return 0, errors.New("unreachable")
+}
+
+func TestRenderWritesNothingOnError(t *testing.T) {
+	pages, err := ParsePages(fstest.MapFS{
+		"layout.html": {Data: []byte(`<p>{{block "content" .}}{{end}}</p>`)},
+		"broken.html": {Data: []byte(`{{define "content"}}{{.Module.Title}} {{index .Modules 5}}{{end}}`)},
+	})
+	require.NoError(t, err)
+
+	w := &failingWriter{}
+	assert.Error(t, pages.Render(w, "broken", Page{}))
+	assert.False(t, w.wrote)
+
+	assert.ErrorContains(t, pages.Render(w, "missing", Page{}), `no page "missing"`)
+}
@@ -0,0 +1,62 @@
+package exercises
+
+// EXERCISE: Fix a profile card that stopped escaping when its import changed.
+// RenderProfile shows a learner's card on the dashboard. When the file's
+// imports were tidied, the card started rendering with text/template,
+// and nothing noticed: names run as scripts, javascript: links work, and
+// a bio can add any markup it likes. Switching back is not the whole fix;
+// some of the template was written for text/template.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"strings"
+	// BUG: text/template escapes nothing; this card is HTML.
+	"text/template"
+)
+
+// Profile is what a learner shows about themselves on the dashboard. Every
+// field is typed in by the learner.
+type Profile struct {
+	Name    string
+	Bio     string
+	Website string
+	Color   string
+}
+
+// paragraphs turns text with blank lines between paragraphs into HTML
+// paragraphs.
+func paragraphs(text string) string {
+	var b strings.Builder
+	for _, p := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			// BUG: the bio goes into the page as markup, unescaped.
+			b.WriteString("<p>" + p + "</p>")
+		}
+	}
+	return b.String()
+}
+
+// profileCard is a learner's card on the dashboard.
+//
+// BUG: "| html" escapes the name in the <script> for HTML, not for
+// JavaScript.
+const profileCard = `<div class="profile" style="border-color: {{.Color}}">
+  <h2><a href="{{.Website}}">{{.Name | html}}</a></h2>
+  {{paragraphs .Bio}}
+  <a href="/learners?name={{.Name | urlquery}}">More from this learner</a>
+  <script>const learner = "{{.Name | html}}";</script>
+</div>
+`
+
+var profile = template.Must(template.New("profile").
+	Funcs(template.FuncMap{"paragraphs": paragraphs}).
+	Parse(profileCard))
+
+// RenderProfile renders p's card.
+func RenderProfile(p Profile) (string, error) {
+	var b strings.Builder
+	if err := profile.Execute(&b, p); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
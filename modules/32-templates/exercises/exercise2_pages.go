@@ -0,0 +1,102 @@
+package exercises
+
+// EXERCISE: Fix the layout inheritance of a course site's pages.
+// A Site renders a dashboard and a page per module inside one layout,
+// which the pages fill in with {{define}}. But every page shows the same
+// content, a page that fails is half sent, module titles can inject
+// markup, and the script on the module page gets a string where it
+// expects an object.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+)
+
+// Module is a module's progress, shown on the dashboard and its own page.
+type Module struct {
+	ID    string
+	Title string
+	Done  int
+	Total int
+}
+
+// Page is the data every page gets. Module is nil on the dashboard.
+type Page struct {
+	Learner string
+	Modules []Module
+	Module  *Module
+}
+
+// layout is the frame around every page. Pages fill in "title" and
+// "content".
+const layout = `<!DOCTYPE html>
+<title>{{block "title" .}}Learn Go the Hard Way{{end}}</title>
+<nav><a href="/">Dashboard</a> · {{.Learner}}</nav>
+<main>{{block "content" .}}{{end}}</main>
+`
+
+// pages are the site's pages, by name.
+var pages = map[string]string{
+	"dashboard": `{{define "title"}}Dashboard · Learn Go the Hard Way{{end}}
+{{define "content"}}<h1>Dashboard</h1>
+<ul>{{range .Modules}}<li>{{moduleLink .}} {{.Done}}/{{.Total}}</li>{{end}}</ul>{{end}}`,
+
+	"module": `{{define "title"}}{{.Module.Title}} · Learn Go the Hard Way{{end}}
+{{define "content"}}<h1>{{.Module.Title}}</h1>
+<script>const progress = {{json .Module}};</script>{{end}}`,
+}
+
+// moduleLink links to m's page.
+func moduleLink(m Module) template.HTML {
+	// BUG: template.HTML tells html/template not to escape, and nothing
+	// else escapes m.ID or m.Title.
+	return template.HTML(`<a href="/modules/` + m.ID + `">` + m.Title + `</a>`)
+}
+
+// toJSON encodes v as JSON for the module page's script.
+//
+// BUG: in a <script>, html/template writes a string as a JavaScript string
+// literal, so the script gets the JSON text instead of an object.
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// Site renders the course site's pages.
+type Site struct {
+	pages map[string]*template.Template
+}
+
+// NewSite parses the layout and every page.
+func NewSite() (*Site, error) {
+	base, err := template.New("layout").
+		Funcs(template.FuncMap{"moduleLink": moduleLink, "json": toJSON}).
+		Parse(layout)
+	if err != nil {
+		return nil, err
+	}
+	s := &Site{pages: map[string]*template.Template{}}
+	for name, src := range pages {
+		// BUG: every page defines "title" and "content" in the same
+		// template set, so the page parsed last wins for all of them.
+		if _, err := base.Parse(src); err != nil {
+			return nil, fmt.Errorf("page %s: %w", name, err)
+		}
+		s.pages[name] = base
+	}
+	return s, nil
+}
+
+// Render writes page, rendered with data, to w. It writes nothing if
+// rendering fails.
+func (s *Site) Render(w io.Writer, page string, data Page) error {
+	t, ok := s.pages[page]
+	if !ok {
+		return fmt.Errorf("no page %q", page)
+	}
+	// BUG: a page that fails halfway has already written its first half.
+	return t.Execute(w, data)
+}
@@ -0,0 +1,56 @@
+package solutions
+
+// SOLUTION: Fix a profile card that stopped escaping when its import changed.
+// Fixed: html/template instead of text/template, so every value is escaped for where it lands
+// Fixed: no "| html" in the <script>, where html/template escapes for JavaScript
+// Fixed: paragraphs escapes the bio before it adds markup, and returns template.HTML
+
+import (
+	"html/template"
+	"strings"
+)
+
+// Profile is what a learner shows about themselves on the dashboard. Every
+// field is typed in by the learner.
+type Profile struct {
+	Name    string
+	Bio     string
+	Website string
+	Color   string
+}
+
+// paragraphs turns text with blank lines between paragraphs into HTML
+// paragraphs.
+func paragraphs(text string) template.HTML {
+	var b strings.Builder
+	for _, p := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			b.WriteString("<p>" + template.HTMLEscapeString(p) + "</p>")
+		}
+	}
+	return template.HTML(b.String())
+}
+
+// profileCard is a learner's card on the dashboard. The "| html" on the
+// heading and the "| urlquery" on the link are left from text/template;
+// html/template accepts them where they match its own escaping.
+const profileCard = `<div class="profile" style="border-color: {{.Color}}">
+  <h2><a href="{{.Website}}">{{.Name | html}}</a></h2>
+  {{paragraphs .Bio}}
+  <a href="/learners?name={{.Name | urlquery}}">More from this learner</a>
+  <script>const learner = "{{.Name}}";</script>
+</div>
+`
+
+var profile = template.Must(template.New("profile").
+	Funcs(template.FuncMap{"paragraphs": paragraphs}).
+	Parse(profileCard))
+
+// RenderProfile renders p's card.
+func RenderProfile(p Profile) (string, error) {
+	var b strings.Builder
+	if err := profile.Execute(&b, p); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,58 @@
+package solutions
+
+import (
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderProfile(t *testing.T) {
+	out, err := RenderProfile(Profile{
+		Name:    "Ada",
+		Bio:     "Likes Go.\n\nAnd tea.",
+		Website: "https://example.com/ada",
+		Color:   "teal",
+	})
+	require.NoError(t, err)
+	snapshot.Match(t, "profile.html", out)
+}
+
+func TestRenderProfileEscapesName(t *testing.T) {
+	out, err := RenderProfile(Profile{Name: "<script>alert(1)</script>"})
+	require.NoError(t, err)
+	assert.Contains(t, out, `<h2><a href="">&lt;script&gt;alert(1)&lt;/script&gt;</a></h2>`)
+	assert.Contains(t, out, `<a href="/learners?name=%3Cscript%3Ealert%281%29%3C%2Fscript%3E">`)
+	assert.NotContains(t, out, "<script>alert")
+}
+
+func TestRenderProfileScript(t *testing.T) {
+	out, err := RenderProfile(Profile{Name: `O'Brien & "Co"`})
+	require.NoError(t, err)
+	assert.Contains(t, out, `<script>const learner = "O\u0027Brien \u0026 \u0022Co\u0022";</script>`)
+}
+
+func TestRenderProfileWebsite(t *testing.T) {
+	for website, href := range map[string]string{
+		"https://example.com/a?b=c&d=e": `href="https://example.com/a?b=c&amp;d=e"`,
+		"javascript:alert(1)":           `href="#ZgotmplZ"`,
+		`https://x" onclick="alert(1)`:  `href="https://x%22%20onclick=%22alert%281%29"`,
+	} {
+		out, err := RenderProfile(Profile{Name: "Ada", Website: website})
+		require.NoError(t, err)
+		assert.Contains(t, out, href, website)
+	}
+}
+
+func TestRenderProfileColor(t *testing.T) {
+	out, err := RenderProfile(Profile{Color: `red"><script>alert(1)</script>`})
+	require.NoError(t, err)
+	assert.Contains(t, out, `style="border-color: ZgotmplZ"`)
+}
+
+func TestRenderProfileBio(t *testing.T) {
+	out, err := RenderProfile(Profile{Bio: "<b>Hi</b>\r\n\r\n\n\n<img src=x onerror=alert(1)>\n"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "\n  <p>&lt;b&gt;Hi&lt;/b&gt;</p><p>&lt;img src=x onerror=alert(1)&gt;</p>\n")
+}
@@ -0,0 +1,97 @@
+package solutions
+
+// SOLUTION: Fix the layout inheritance of a course site's pages.
+// Fixed: each page is parsed into its own clone of the layout, so pages keep their own blocks
+// Fixed: Render executes into a buffer, so a failing page writes nothing
+// Fixed: moduleLink escapes the ID and the title before it builds template.HTML
+// Fixed: the module page passes the Module itself to the <script>, which html/template writes as a JavaScript object
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+)
+
+// Module is a module's progress, shown on the dashboard and its own page.
+type Module struct {
+	ID    string
+	Title string
+	Done  int
+	Total int
+}
+
+// Page is the data every page gets. Module is nil on the dashboard.
+type Page struct {
+	Learner string
+	Modules []Module
+	Module  *Module
+}
+
+// layout is the frame around every page. Pages fill in "title" and
+// "content".
+const layout = `<!DOCTYPE html>
+<title>{{block "title" .}}Learn Go the Hard Way{{end}}</title>
+<nav><a href="/">Dashboard</a> · {{.Learner}}</nav>
+<main>{{block "content" .}}{{end}}</main>
+`
+
+// pages are the site's pages, by name.
+var pages = map[string]string{
+	"dashboard": `{{define "title"}}Dashboard · Learn Go the Hard Way{{end}}
+{{define "content"}}<h1>Dashboard</h1>
+<ul>{{range .Modules}}<li>{{moduleLink .}} {{.Done}}/{{.Total}}</li>{{end}}</ul>{{end}}`,
+
+	"module": `{{define "title"}}{{.Module.Title}} · Learn Go the Hard Way{{end}}
+{{define "content"}}<h1>{{.Module.Title}}</h1>
+<script>const progress = {{.Module}};</script>{{end}}`,
+}
+
+// moduleLink links to m's page.
+func moduleLink(m Module) template.HTML {
+	return template.HTML(fmt.Sprintf(`<a href="/modules/%s">%s</a>`,
+		template.HTMLEscapeString(url.PathEscape(m.ID)), template.HTMLEscapeString(m.Title)))
+}
+
+// Site renders the course site's pages.
+type Site struct {
+	pages map[string]*template.Template
+}
+
+// NewSite parses the layout and every page.
+func NewSite() (*Site, error) {
+	base, err := template.New("layout").
+		Funcs(template.FuncMap{"moduleLink": moduleLink}).
+		Parse(layout)
+	if err != nil {
+		return nil, err
+	}
+	s := &Site{pages: map[string]*template.Template{}}
+	for name, src := range pages {
+		t, err := base.Clone()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := t.Parse(src); err != nil {
+			return nil, fmt.Errorf("page %s: %w", name, err)
+		}
+		s.pages[name] = t
+	}
+	return s, nil
+}
+
+// Render writes page, rendered with data, to w. It writes nothing if
+// rendering fails.
+func (s *Site) Render(w io.Writer, page string, data Page) error {
+	t, ok := s.pages[page]
+	if !ok {
+		return fmt.Errorf("no page %q", page)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return err
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
@@ -0,0 +1,72 @@
+package solutions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSite(t *testing.T) *Site {
+	t.Helper()
+	s, err := NewSite()
+	require.NoError(t, err)
+	return s
+}
+
+func render(t *testing.T, s *Site, page string, data Page) string {
+	t.Helper()
+	var b strings.Builder
+	require.NoError(t, s.Render(&b, page, data))
+	return b.String()
+}
+
+func TestSiteDashboard(t *testing.T) {
+	out := render(t, newSite(t), "dashboard", Page{
+		Learner: "Ada",
+		Modules: []Module{{ID: "31-images", Title: "Image Processing", Done: 2, Total: 2}},
+	})
+	snapshot.Match(t, "dashboard.html", out)
+}
+
+func TestSitePagesKeepTheirOwnBlocks(t *testing.T) {
+	s := newSite(t)
+	m := Module{ID: "32-templates", Title: "HTML Templates", Done: 1, Total: 2}
+	data := Page{Learner: "Ada", Modules: []Module{m}, Module: &m}
+
+	out := render(t, s, "dashboard", data)
+	assert.Contains(t, out, "<title>Dashboard · Learn Go the Hard Way</title>")
+	assert.Contains(t, out, "<h1>Dashboard</h1>")
+	assert.NotContains(t, out, "<script>")
+
+	out = render(t, s, "module", data)
+	assert.Contains(t, out, "<title>HTML Templates · Learn Go the Hard Way</title>")
+	assert.Contains(t, out, "<h1>HTML Templates</h1>")
+	assert.NotContains(t, out, "Dashboard ·")
+}
+
+func TestSiteModuleLinksEscaped(t *testing.T) {
+	out := render(t, newSite(t), "dashboard", Page{
+		Modules: []Module{{ID: `x" onclick="alert(1)`, Title: "<img src=x onerror=alert(1)>"}},
+	})
+	assert.Contains(t, out, `<li><a href="/modules/x%22%20onclick=%22alert%281%29">&lt;img src=x onerror=alert(1)&gt;</a> 0/0</li>`)
+	assert.NotContains(t, out, "<img")
+}
+
+func TestSiteModuleProgress(t *testing.T) {
+	m := Module{ID: "32-templates", Title: "</script><script>alert(1)", Done: 1, Total: 2}
+	out := render(t, newSite(t), "module", Page{Module: &m})
+	assert.Contains(t, out,
+		`<script>const progress = {"ID":"32-templates","Title":"\u003c/script\u003e\u003cscript\u003ealert(1)","Done":1,"Total":2};</script>`)
+}
+
+func TestSiteRenderWritesNothingOnError(t *testing.T) {
+	var b strings.Builder
+	err := newSite(t).Render(&b, "module", Page{Learner: "Ada"})
+	assert.Error(t, err, "the module page needs a Module")
+	assert.Empty(t, b.String())
+
+	assert.ErrorContains(t, newSite(t).Render(&b, "about", Page{}), `no page "about"`)
+}
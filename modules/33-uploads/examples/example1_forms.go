@@ -0,0 +1,158 @@
+// Package examples demonstrates file uploads in Go: multipart forms, limits
+// on how much a client may send, streaming files to disk instead of
+// memory, checking what a file really is, and answering with JSON errors.
+//
+// This file shows:
+// - Building a multipart/form-data request with mime/multipart, as a browser does for a file input
+// - http.MaxBytesReader, which caps the request body
+// - r.ParseMultipartForm, which keeps small parts in memory and writes the rest to temporary files
+// - r.FormValue and r.FormFile, and the file name and type the client claims
+// - Writing JSON responses with the Content-Type set before the status
+package examples
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+)
+
+// MaxMemory is how much of a form ParseMultipartForm keeps in memory.
+// Files that do not fit go to temporary files.
+const MaxMemory = 32 << 10
+
+// File is a file to upload.
+type File struct {
+	Field   string
+	Name    string
+	Content io.Reader
+}
+
+// NewUploadRequest returns a POST request to url with fields and files in
+// a multipart/form-data body.
+func NewUploadRequest(url string, fields map[string]string, files ...File) (*http.Request, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for name, value := range fields {
+		if err := mw.WriteField(name, value); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range files {
+		// CreateFormFile says application/octet-stream whatever the
+		// file is; browsers guess from the extension. Either way, the
+		// server cannot trust it.
+		w, err := mw.CreateFormFile(f.Field, f.Name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(w, f.Content); err != nil {
+			return nil, err
+		}
+	}
+	// Close writes the final boundary. Without it the server sees a
+	// truncated body.
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req, nil
+}
+
+// WriteJSON writes v as JSON with status.
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+	// Headers set after WriteHeader are ignored.
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// WriteError writes {"error": message} with status.
+func WriteError(w http.ResponseWriter, status int, message string) {
+	WriteJSON(w, status, map[string]string{"error": message})
+}
+
+// FileSummary describes an uploaded file.
+type FileSummary struct {
+	Field       string `json:"field"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	OnDisk      bool   `json:"onDisk"`
+}
+
+// FormSummary describes an uploaded form.
+type FormSummary struct {
+	Fields map[string]string `json:"fields"`
+	Files  []FileSummary     `json:"files"`
+}
+
+// FormHandler answers a multipart form of at most maxBytes with a
+// FormSummary.
+func FormHandler(maxBytes int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Without this, a client can send as much as it likes, and
+		// ParseMultipartForm writes all of it to disk.
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+		if err := r.ParseMultipartForm(MaxMemory); err != nil {
+			WriteError(w, StatusOf(err), err.Error())
+			return
+		}
+		// The temporary files stay until they are removed.
+		defer r.MultipartForm.RemoveAll()
+
+		summary := FormSummary{Fields: map[string]string{}}
+		for name := range r.MultipartForm.Value {
+			summary.Fields[name] = r.FormValue(name)
+		}
+		for field, headers := range r.MultipartForm.File {
+			for _, h := range headers {
+				f, err := h.Open()
+				if err != nil {
+					WriteError(w, http.StatusInternalServerError, err.Error())
+					return
+				}
+				_, onDisk := f.(*os.File)
+				f.Close()
+				summary.Files = append(summary.Files, FileSummary{
+					Field:       field,
+					Filename:    h.Filename,
+					ContentType: h.Header.Get("Content-Type"),
+					Size:        h.Size,
+					OnDisk:      onDisk,
+				})
+			}
+		}
+		sort.Slice(summary.Files, func(i, j int) bool { return summary.Files[i].Field < summary.Files[j].Field })
+		WriteJSON(w, http.StatusOK, summary)
+	})
+}
+
+// DemonstrateForms uploads a small and a large file, then too much.
+func DemonstrateForms() {
+	fmt.Println("=== Multipart forms ===")
+	h := FormHandler(1 << 20)
+
+	req, _ := NewUploadRequest("/upload", map[string]string{"title": "Holiday"},
+		File{Field: "small", Name: "note.txt", Content: bytes.NewReader([]byte("hello"))},
+		File{Field: "large", Name: "photo.png", Content: bytes.NewReader(make([]byte, 100<<10))},
+	)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	fmt.Printf("%d %s", rec.Code, rec.Body)
+
+	req, _ = NewUploadRequest("/upload", nil,
+		File{Field: "huge", Name: "huge.bin", Content: bytes.NewReader(make([]byte, 2<<20))})
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	fmt.Printf("%d %s", rec.Code, rec.Body)
+}
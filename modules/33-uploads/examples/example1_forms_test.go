@@ -0,0 +1,68 @@
+package examples
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateForms(t *testing.T) {
+	DemonstrateForms()
+}
+
+func TestNewUploadRequest(t *testing.T) {
+	req, err := NewUploadRequest("/upload", map[string]string{"title": "Holiday"},
+		File{Field: "photo", Name: "photo.png", Content: strings.NewReader("pixels")})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPost, req.Method)
+
+	require.NoError(t, req.ParseMultipartForm(MaxMemory))
+	assert.Equal(t, "Holiday", req.FormValue("title"))
+	f, h, err := req.FormFile("photo")
+	require.NoError(t, err)
+	defer f.Close()
+	assert.Equal(t, "photo.png", h.Filename)
+	assert.Equal(t, int64(6), h.Size)
+}
+
+func TestFormHandlerSpillsToDisk(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	req, err := NewUploadRequest("/upload", nil,
+		File{Field: "small", Name: "a.txt", Content: strings.NewReader("hello")},
+		File{Field: "large", Name: "b.bin", Content: bytes.NewReader(make([]byte, 2*MaxMemory))})
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	FormHandler(1<<20).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var got FormSummary
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Len(t, got.Files, 2)
+	assert.Equal(t, "large", got.Files[0].Field)
+	assert.True(t, got.Files[0].OnDisk)
+	assert.False(t, got.Files[1].OnDisk)
+
+	entries, err := os.ReadDir(tmp)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "RemoveAll deletes the temporary files")
+}
+
+func TestFormHandlerLimit(t *testing.T) {
+	req, err := NewUploadRequest("/upload", nil,
+		File{Field: "f", Name: "f.bin", Content: bytes.NewReader(make([]byte, 2<<10))})
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	FormHandler(1<<10).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.JSONEq(t, `{"error":"http: request body too large"}`, rec.Body.String())
+}
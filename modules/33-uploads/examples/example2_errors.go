@@ -0,0 +1,78 @@
+package examples
+
+// This file shows:
+// - Handlers that return an error, and one place that turns it into JSON
+// - errors.As with *http.MaxBytesError, for 413 Request Entity Too Large
+// - Sentinel errors for the other statuses a handler means
+// - Keeping internal details out of 500 responses
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+var (
+	// ErrBadRequest is for requests that are not the form expected.
+	ErrBadRequest = errors.New("bad request")
+	// ErrUnsupportedType is for files of a type that is not accepted.
+	ErrUnsupportedType = errors.New("unsupported file type")
+	// ErrFileTooLarge is for a file over its own limit, in a body that
+	// is within the body's.
+	ErrFileTooLarge = errors.New("file too large")
+)
+
+// StatusOf returns the HTTP status for err.
+func StatusOf(err error) int {
+	var tooLarge *http.MaxBytesError
+	switch {
+	// MaxBytesReader's error comes back wrapped by whatever read the
+	// body: errors.As finds it, a type assertion does not.
+	case errors.As(err, &tooLarge), errors.Is(err, ErrFileTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrUnsupportedType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, ErrBadRequest), errors.Is(err, http.ErrNotMultipart),
+		errors.Is(err, http.ErrMissingFile):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// HandlerFunc is a handler that returns an error instead of writing one.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP calls f, and writes its error, if any, as JSON.
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	err := f(w, r)
+	if err == nil {
+		return
+	}
+	status := StatusOf(err)
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		// Paths and system errors are for the log, not the client.
+		slog.Error("upload failed", "path", r.URL.Path, "err", err)
+		message = http.StatusText(status)
+	}
+	WriteError(w, status, message)
+}
+
+// DemonstrateErrors shows the JSON each kind of error becomes.
+func DemonstrateErrors() {
+	fmt.Println("=== JSON errors ===")
+	for _, err := range []error{
+		fmt.Errorf("%w: no file in the form", ErrBadRequest),
+		fmt.Errorf("photo.exe: %w: application/x-msdownload", ErrUnsupportedType),
+		fmt.Errorf("reading photo.png: %w", &http.MaxBytesError{Limit: 1 << 20}),
+		errors.New("open /srv/uploads/x: permission denied"),
+	} {
+		h := HandlerFunc(func(http.ResponseWriter, *http.Request) error { return err })
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/upload", nil))
+		fmt.Printf("%d %s: %s\n", rec.Code, rec.Header().Get("Content-Type"), strings.TrimSpace(rec.Body.String()))
+	}
+}
@@ -0,0 +1,51 @@
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateErrors(t *testing.T) {
+	DemonstrateErrors()
+}
+
+func TestStatusOf(t *testing.T) {
+	for err, want := range map[error]int{
+		&http.MaxBytesError{Limit: 1}:                       http.StatusRequestEntityTooLarge,
+		fmt.Errorf("a: %w", &http.MaxBytesError{}):          http.StatusRequestEntityTooLarge,
+		fmt.Errorf("a: %w", ErrFileTooLarge):                http.StatusRequestEntityTooLarge,
+		fmt.Errorf("a: %w: text/plain", ErrUnsupportedType): http.StatusUnsupportedMediaType,
+		fmt.Errorf("%w: no files", ErrBadRequest):           http.StatusBadRequest,
+		http.ErrNotMultipart:                                http.StatusBadRequest,
+		errors.New("disk full"):                             http.StatusInternalServerError,
+	} {
+		assert.Equal(t, want, StatusOf(err), err.Error())
+	}
+}
+
+func TestHandlerFuncHidesInternalErrors(t *testing.T) {
+	h := HandlerFunc(func(http.ResponseWriter, *http.Request) error {
+		return errors.New("open /srv/uploads: permission denied")
+	})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/upload", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"error":"Internal Server Error"}`, rec.Body.String())
+}
+
+func TestHandlerFuncSuccess(t *testing.T) {
+	h := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		WriteJSON(w, http.StatusCreated, []string{"ok"})
+		return nil
+	})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/upload", nil))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `["ok"]`, rec.Body.String())
+}
@@ -0,0 +1,161 @@
+package examples
+
+// This file shows:
+// - r.MultipartReader, which reads a form part by part as it arrives
+// - Copying each file to disk through an io.LimitReader one byte past the
+//   limit, to tell a file at the limit from one over it
+// - http.DetectContentType on the first 512 bytes, put back in front of
+//   the rest with io.MultiReader
+// - Naming saved files on the server instead of trusting the client's name
+// - Removing what was saved when the request fails halfway
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SniffLen is how many bytes http.DetectContentType looks at.
+const SniffLen = 512
+
+// ImageTypes maps the image types accepted to the extensions saved.
+var ImageTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+}
+
+// Saved describes a saved file.
+type Saved struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Size int64  `json:"size"`
+}
+
+// Sniff returns the type of what r reads, and a reader that reads all of
+// it, the sniffed bytes included.
+func Sniff(r io.Reader) (string, io.Reader, error) {
+	head := make([]byte, SniffLen)
+	// One Read may return fewer bytes than are coming. ReadFull keeps
+	// reading, and a shorter file is not an error.
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", nil, err
+	}
+	head = head[:n]
+	return http.DetectContentType(head), io.MultiReader(bytes.NewReader(head), r), nil
+}
+
+// SaveFile copies r into a new file in dir if it is of one of types and
+// at most maxSize bytes long.
+func SaveFile(dir string, r io.Reader, maxSize int64, types map[string]string) (Saved, error) {
+	typ, r, err := Sniff(r)
+	if err != nil {
+		return Saved{}, err
+	}
+	ext, ok := types[typ]
+	if !ok {
+		return Saved{}, fmt.Errorf("%w: %s", ErrUnsupportedType, typ)
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return Saved{}, err
+	}
+	name := hex.EncodeToString(b) + ext
+	path := filepath.Join(dir, name)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return Saved{}, err
+	}
+	n, err := io.Copy(f, io.LimitReader(r, maxSize+1))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && n > maxSize {
+		err = fmt.Errorf("%w: more than %d bytes", ErrFileTooLarge, maxSize)
+	}
+	if err != nil {
+		os.Remove(path)
+		return Saved{}, err
+	}
+	return Saved{Name: name, Type: typ, Size: n}, nil
+}
+
+// StreamHandler saves the images in a multipart form to dir, one part at
+// a time, and answers with what it saved. Requests are at most maxBody
+// bytes, and each image at most maxFile.
+func StreamHandler(dir string, maxBody, maxFile int64) http.Handler {
+	return HandlerFunc(func(w http.ResponseWriter, r *http.Request) (err error) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBody)
+		mr, err := r.MultipartReader()
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrBadRequest, err)
+		}
+
+		var saved []Saved
+		defer func() {
+			if err != nil {
+				for _, s := range saved {
+					os.Remove(filepath.Join(dir, s.Name))
+				}
+			}
+		}()
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if part.FileName() == "" {
+				continue // a plain field
+			}
+			s, err := SaveFile(dir, part, maxFile, ImageTypes)
+			if err != nil {
+				return fmt.Errorf("%s: %w", part.FileName(), err)
+			}
+			saved = append(saved, s)
+		}
+		if len(saved) == 0 {
+			return fmt.Errorf("%w: no files in the form", ErrBadRequest)
+		}
+		WriteJSON(w, http.StatusCreated, saved)
+		return nil
+	})
+}
+
+// DemonstrateStreaming uploads an image, a text file, and too big an
+// image.
+func DemonstrateStreaming() {
+	fmt.Println("=== Streaming to disk ===")
+	dir, err := os.MkdirTemp("", "uploads-")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	h := StreamHandler(dir, 1<<20, 64<<10)
+
+	png := "\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 1000)
+	for _, f := range []File{
+		{Field: "photo", Name: "photo.png", Content: strings.NewReader(png)},
+		{Field: "photo", Name: "photo.png", Content: strings.NewReader("not a picture")},
+		{Field: "photo", Name: "big.png", Content: strings.NewReader(png + strings.Repeat("\x00", 64<<10))},
+	} {
+		req, _ := NewUploadRequest("/photos", nil, f)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		fmt.Printf("%s: %d %s", f.Name, rec.Code, rec.Body)
+	}
+	entries, _ := os.ReadDir(dir)
+	fmt.Printf("%d file(s) saved\n", len(entries))
+}
@@ -0,0 +1,90 @@
+package examples
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateStreaming(t *testing.T) {
+	DemonstrateStreaming()
+}
+
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+func TestSniffKeepsEveryByte(t *testing.T) {
+	content := pngHeader + strings.Repeat("x", 2*SniffLen)
+	// OneByteReader returns one byte per Read, like a slow network.
+	typ, r, err := Sniff(iotest.OneByteReader(strings.NewReader(content)))
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", typ)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(got))
+
+	typ, r, err = Sniff(strings.NewReader("hi"))
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", typ)
+	got, _ = io.ReadAll(r)
+	assert.Equal(t, "hi", string(got))
+}
+
+func TestSaveFileLimit(t *testing.T) {
+	dir := t.TempDir()
+	content := pngHeader + strings.Repeat("\x00", 92)
+
+	s, err := SaveFile(dir, strings.NewReader(content), 100, ImageTypes)
+	require.NoError(t, err, "exactly at the limit")
+	assert.Equal(t, int64(100), s.Size)
+	assert.Equal(t, ".png", filepath.Ext(s.Name))
+	data, err := os.ReadFile(filepath.Join(dir, s.Name))
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+
+	_, err = SaveFile(dir, strings.NewReader(content+"!"), 100, ImageTypes)
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 1, "the partial file is removed")
+}
+
+func TestStreamHandler(t *testing.T) {
+	dir := t.TempDir()
+	h := StreamHandler(dir, 1<<20, 1<<10)
+	upload := func(files ...File) *httptest.ResponseRecorder {
+		req, err := NewUploadRequest("/photos", map[string]string{"album": "x"}, files...)
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := upload(
+		File{Field: "photo", Name: "a.png", Content: strings.NewReader(pngHeader)},
+		File{Field: "photo", Name: "../../b.gif", Content: strings.NewReader("GIF89a")})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var saved []Saved
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &saved))
+	require.Len(t, saved, 2)
+	assert.Equal(t, "image/gif", saved[1].Type)
+	assert.FileExists(t, filepath.Join(dir, saved[1].Name))
+
+	rec = upload(
+		File{Field: "photo", Name: "c.png", Content: strings.NewReader(pngHeader)},
+		File{Field: "photo", Name: "d.png", Content: strings.NewReader(pngHeader + strings.Repeat("\x00", 1<<10))})
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.JSONEq(t, `{"error":"d.png: file too large: more than 1024 bytes"}`, rec.Body.String())
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 2, "c.png is removed with d.png")
+
+	rec = upload()
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,125 @@
+package exercises
+
+// EXERCISE: Fix an avatar upload that holds whole requests in memory.
+// Avatars saves the picture a learner uploads for their profile, and
+// answers with JSON. It works for the small pictures it was tested with,
+// but it takes uploads of any size, keeps every upload in memory, some of
+// them twice, and once it stops doing that, leaves temporary files behind.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// maxMemory is how much of a form is kept in memory.
+const maxMemory = 32 << 10
+
+// avatarTypes maps the image types accepted to the extensions saved.
+var avatarTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+}
+
+// Avatar describes a saved avatar.
+type Avatar struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Size int64  `json:"size"`
+}
+
+// Avatars saves the images uploaded as the "avatar" field of a multipart
+// form into Dir.
+type Avatars struct {
+	Dir      string
+	MaxBytes int64 // the largest request body accepted
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// ServeHTTP saves the avatar and answers with an Avatar, or with
+// {"error": "..."}.
+func (a *Avatars) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	// BUG: nothing limits how much the client sends; MaxBytes is only
+	// used below.
+	// BUG: ParseMultipartForm keeps up to its argument in memory, which
+	// here is the whole upload.
+	if err := r.ParseMultipartForm(a.MaxBytes); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge,
+				fmt.Sprintf("the upload is over %d bytes", a.MaxBytes))
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	// BUG: the form's temporary files are never removed.
+
+	file, _, err := r.FormFile("avatar")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "no avatar in the form")
+		return
+	}
+	defer file.Close()
+
+	// BUG: io.ReadAll holds the whole avatar in memory, a second time.
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(data) == 0 {
+		writeError(w, http.StatusBadRequest, "empty avatar")
+		return
+	}
+	typ := http.DetectContentType(data)
+	ext, ok := avatarTypes[typ]
+	if !ok {
+		writeError(w, http.StatusUnsupportedMediaType, typ+" is not an image")
+		return
+	}
+
+	avatar, err := a.save(data, typ, ext)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeJSON(w, http.StatusCreated, avatar)
+}
+
+// save writes data into a new file in a.Dir.
+func (a *Avatars) save(data []byte, typ, ext string) (Avatar, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return Avatar{}, err
+	}
+	name := hex.EncodeToString(b) + ext
+	path := filepath.Join(a.Dir, name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		os.Remove(path)
+		return Avatar{}, err
+	}
+	return Avatar{Name: name, Type: typ, Size: int64(len(data))}, nil
+}
@@ -0,0 +1,120 @@
+package exercises
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+// uploadRequest returns a request with content as the file of field.
+func uploadRequest(t *testing.T, field, filename string, content []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	require.NoError(t, mw.WriteField("user", "ada"))
+	fw, err := mw.CreateFormFile(field, filename)
+	require.NoError(t, err)
+	_, err = fw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+	req := httptest.NewRequest(http.MethodPost, "/avatar", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+// serve runs a on req, with the form's temporary files in a directory of
+// their own, and returns the response and that directory.
+func serve(t *testing.T, a *Avatars, req *http.Request) (*httptest.ResponseRecorder, string) {
+	t.Helper()
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	rec := httptest.NewRecorder()
+	a.ServeHTTP(rec, req)
+	return rec, tmp
+}
+
+func TestAvatarSaved(t *testing.T) {
+	a := &Avatars{Dir: t.TempDir(), MaxBytes: 1 << 20}
+	content := []byte(pngHeader + strings.Repeat("\x00", 100<<10))
+	rec, _ := serve(t, a, uploadRequest(t, "avatar", "me.png", content))
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var got Avatar
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, "image/png", got.Type)
+	assert.Equal(t, int64(len(content)), got.Size)
+	data, err := os.ReadFile(filepath.Join(a.Dir, got.Name))
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+}
+
+func TestAvatarBodyLimit(t *testing.T) {
+	a := &Avatars{Dir: t.TempDir(), MaxBytes: 64 << 10}
+	content := []byte(pngHeader + strings.Repeat("\x00", 64<<10))
+	rec, _ := serve(t, a, uploadRequest(t, "avatar", "me.png", content))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.JSONEq(t, `{"error":"the upload is over 65536 bytes"}`, rec.Body.String())
+	entries, _ := os.ReadDir(a.Dir)
+	assert.Empty(t, entries)
+}
+
+func TestAvatarStreamsToDisk(t *testing.T) {
+	a := &Avatars{Dir: t.TempDir(), MaxBytes: 32 << 20}
+	const size = 8 << 20
+	req := uploadRequest(t, "avatar", "me.png", []byte(pngHeader+strings.Repeat("\x00", size)))
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	rec, _ := serve(t, a, req)
+	runtime.ReadMemStats(&after)
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	allocated := after.TotalAlloc - before.TotalAlloc
+	assert.Less(t, allocated, uint64(size/4),
+		"an 8 MiB avatar allocated %d KiB: it was held in memory", allocated>>10)
+}
+
+func TestAvatarRemovesTemporaryFiles(t *testing.T) {
+	a := &Avatars{Dir: t.TempDir(), MaxBytes: 1 << 20}
+	content := []byte(pngHeader + strings.Repeat("\x00", 100<<10))
+	rec, tmp := serve(t, a, uploadRequest(t, "avatar", "me.png", content))
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	entries, err := os.ReadDir(tmp)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the form's temporary files are left behind")
+}
+
+func TestAvatarRejected(t *testing.T) {
+	a := &Avatars{Dir: t.TempDir(), MaxBytes: 1 << 20}
+
+	rec, _ := serve(t, a, uploadRequest(t, "avatar", "me.png", []byte("<html>not a picture")))
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	assert.JSONEq(t, `{"error":"text/html; charset=utf-8 is not an image"}`, rec.Body.String())
+
+	rec, _ = serve(t, a, uploadRequest(t, "picture", "me.png", []byte(pngHeader)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"error":"no avatar in the form"}`, rec.Body.String())
+
+	rec, _ = serve(t, a, httptest.NewRequest(http.MethodGet, "/avatar", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "POST", rec.Header().Get("Allow"))
+
+	entries, _ := os.ReadDir(a.Dir)
+	assert.Empty(t, entries)
+}
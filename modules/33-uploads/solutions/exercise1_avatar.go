@@ -0,0 +1,129 @@
+package solutions
+
+// SOLUTION: Fix an avatar upload that holds whole requests in memory.
+// Fixed: http.MaxBytesReader caps the body, and a body over the cap is answered with 413
+// Fixed: ParseMultipartForm keeps at most maxMemory bytes in memory and writes the rest to a temporary file
+// Fixed: the avatar is copied to disk with io.Copy instead of read into memory with io.ReadAll
+// Fixed: RemoveAll deletes the form's temporary files
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// maxMemory is how much of a form is kept in memory.
+const maxMemory = 32 << 10
+
+// avatarTypes maps the image types accepted to the extensions saved.
+var avatarTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+}
+
+// Avatar describes a saved avatar.
+type Avatar struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Size int64  `json:"size"`
+}
+
+// Avatars saves the images uploaded as the "avatar" field of a multipart
+// form into Dir.
+type Avatars struct {
+	Dir      string
+	MaxBytes int64 // the largest request body accepted
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// ServeHTTP saves the avatar and answers with an Avatar, or with
+// {"error": "..."}.
+func (a *Avatars) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, a.MaxBytes)
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge,
+				fmt.Sprintf("the upload is over %d bytes", a.MaxBytes))
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, _, err := r.FormFile("avatar")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "no avatar in the form")
+		return
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		writeError(w, http.StatusBadRequest, "empty avatar")
+		return
+	}
+	typ := http.DetectContentType(head[:n])
+	ext, ok := avatarTypes[typ]
+	if !ok {
+		writeError(w, http.StatusUnsupportedMediaType, typ+" is not an image")
+		return
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	avatar, err := a.save(file, typ, ext)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeJSON(w, http.StatusCreated, avatar)
+}
+
+// save copies r into a new file in a.Dir.
+func (a *Avatars) save(r io.Reader, typ, ext string) (Avatar, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return Avatar{}, err
+	}
+	name := hex.EncodeToString(b) + ext
+	path := filepath.Join(a.Dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		return Avatar{}, err
+	}
+	n, err := io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return Avatar{}, err
+	}
+	return Avatar{Name: name, Type: typ, Size: n}, nil
+}
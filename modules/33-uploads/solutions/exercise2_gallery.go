@@ -0,0 +1,175 @@
+package solutions
+
+// SOLUTION: Fix a streaming photo upload that loses bytes and misreports its errors.
+// Fixed: Sniff reads with io.ReadFull, and puts the sniffed bytes back in front with io.MultiReader
+// Fixed: the copy reads one byte past MaxPhoto, so a photo over the limit is an error instead of cut short
+// Fixed: statusOf finds a wrapped *http.MaxBytesError with errors.As
+// Fixed: respond sets Content-Type before WriteHeader
+// Fixed: a failed upload removes the photos it already saved
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+var (
+	// ErrBadRequest is for requests that are not a form with photos.
+	ErrBadRequest = errors.New("bad request")
+	// ErrUnsupportedType is for files that are not photos.
+	ErrUnsupportedType = errors.New("unsupported file type")
+	// ErrFileTooLarge is for a photo over MaxPhoto bytes.
+	ErrFileTooLarge = errors.New("file too large")
+)
+
+// sniffLen is how many bytes http.DetectContentType looks at.
+const sniffLen = 512
+
+// photoTypes maps the photo types accepted to the extensions saved.
+var photoTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+}
+
+// Photo describes a saved photo.
+type Photo struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Size int64  `json:"size"`
+}
+
+// Gallery saves every photo in a multipart form into Dir, streaming each
+// one to disk as it arrives.
+type Gallery struct {
+	Dir      string
+	MaxBody  int64 // the largest request body accepted
+	MaxPhoto int64 // the largest photo accepted
+}
+
+// Sniff returns the type of what r reads, and a reader that reads all of
+// it, the sniffed bytes included.
+func Sniff(r io.Reader) (string, io.Reader, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", nil, err
+	}
+	head = head[:n]
+	return http.DetectContentType(head), io.MultiReader(bytes.NewReader(head), r), nil
+}
+
+// statusOf returns the HTTP status for err.
+func statusOf(err error) int {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge), errors.Is(err, ErrFileTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrUnsupportedType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, ErrBadRequest):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// respond writes v as JSON with status.
+func respond(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// ServeHTTP saves the photos and answers with a list of Photos, or with
+// {"error": "..."}.
+func (g *Gallery) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	photos, err := g.upload(w, r)
+	if err != nil {
+		status := statusOf(err)
+		message := err.Error()
+		if status == http.StatusInternalServerError {
+			message = http.StatusText(status)
+		}
+		respond(w, status, map[string]string{"error": message})
+		return
+	}
+	respond(w, http.StatusCreated, photos)
+}
+
+// upload saves the photos in r's form, or none of them.
+func (g *Gallery) upload(w http.ResponseWriter, r *http.Request) (photos []Photo, err error) {
+	r.Body = http.MaxBytesReader(w, r.Body, g.MaxBody)
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	defer func() {
+		if err != nil {
+			for _, p := range photos {
+				os.Remove(filepath.Join(g.Dir, p.Name))
+			}
+		}
+	}()
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return photos, err
+		}
+		if part.FileName() == "" {
+			continue
+		}
+		p, err := g.save(part)
+		if err != nil {
+			return photos, fmt.Errorf("%s: %w", part.FileName(), err)
+		}
+		photos = append(photos, p)
+	}
+	if len(photos) == 0 {
+		return nil, fmt.Errorf("%w: no photos in the form", ErrBadRequest)
+	}
+	return photos, nil
+}
+
+// save copies a photo from r into a new file in g.Dir.
+func (g *Gallery) save(r io.Reader) (Photo, error) {
+	typ, r, err := Sniff(r)
+	if err != nil {
+		return Photo{}, err
+	}
+	ext, ok := photoTypes[typ]
+	if !ok {
+		return Photo{}, fmt.Errorf("%w: %s", ErrUnsupportedType, typ)
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return Photo{}, err
+	}
+	name := hex.EncodeToString(b) + ext
+	path := filepath.Join(g.Dir, name)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return Photo{}, err
+	}
+	n, err := io.Copy(f, io.LimitReader(r, g.MaxPhoto+1))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && n > g.MaxPhoto {
+		err = fmt.Errorf("%w: more than %d bytes", ErrFileTooLarge, g.MaxPhoto)
+	}
+	if err != nil {
+		os.Remove(path)
+		return Photo{}, err
+	}
+	return Photo{Name: name, Type: typ, Size: n}, nil
+}
@@ -0,0 +1,121 @@
+package solutions
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// photo returns size bytes that start like a PNG, padded with fill.
+func photo(size int, fill byte) []byte {
+	return append([]byte(pngHeader), bytes.Repeat([]byte{fill}, size-len(pngHeader))...)
+}
+
+// postPhotos posts files, by name, to g in one form.
+func postPhotos(t *testing.T, g *Gallery, names []string, files ...[]byte) *http.Response {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	require.NoError(t, mw.WriteField("album", "holiday"))
+	for i, f := range files {
+		fw, err := mw.CreateFormFile("photo", names[i])
+		require.NoError(t, err)
+		_, err = fw.Write(f)
+		require.NoError(t, err)
+	}
+	require.NoError(t, mw.Close())
+	req := httptest.NewRequest(http.MethodPost, "/photos", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+	return rec.Result()
+}
+
+func decode(t *testing.T, resp *http.Response, v any) {
+	t.Helper()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+}
+
+func TestSniff(t *testing.T) {
+	content := string(photo(2*sniffLen, 'x'))
+	// OneByteReader returns one byte per Read, as a slow network might.
+	typ, r, err := Sniff(iotest.OneByteReader(strings.NewReader(content)))
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", typ)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(got))
+}
+
+func TestGallerySavesEveryByte(t *testing.T) {
+	g := &Gallery{Dir: t.TempDir(), MaxBody: 1 << 20, MaxPhoto: 64 << 10}
+	files := [][]byte{photo(100, 1), photo(64<<10, 2)}
+	resp := postPhotos(t, g, []string{"a.png", "b.png"}, files...)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var got []Photo
+	decode(t, resp, &got)
+	require.Len(t, got, 2)
+	for i, p := range got {
+		assert.Equal(t, "image/png", p.Type)
+		assert.Equal(t, int64(len(files[i])), p.Size)
+		data, err := os.ReadFile(filepath.Join(g.Dir, p.Name))
+		require.NoError(t, err)
+		assert.Equal(t, files[i], data, "photo %d", i)
+	}
+}
+
+func TestGalleryPhotoLimit(t *testing.T) {
+	g := &Gallery{Dir: t.TempDir(), MaxBody: 1 << 20, MaxPhoto: 64 << 10}
+	resp := postPhotos(t, g, []string{"a.png", "big.png"}, photo(100, 1), photo(64<<10+1, 2))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+	var got map[string]string
+	decode(t, resp, &got)
+	assert.Equal(t, "big.png: file too large: more than 65536 bytes", got["error"])
+	entries, _ := os.ReadDir(g.Dir)
+	assert.Empty(t, entries, "a failed upload saves nothing")
+}
+
+func TestGalleryBodyLimit(t *testing.T) {
+	g := &Gallery{Dir: t.TempDir(), MaxBody: 64 << 10, MaxPhoto: 1 << 20}
+	resp := postPhotos(t, g, []string{"a.png", "b.png"}, photo(32<<10, 1), photo(32<<10, 2))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+	var got map[string]string
+	decode(t, resp, &got)
+	assert.Equal(t, "b.png: http: request body too large", got["error"])
+	entries, _ := os.ReadDir(g.Dir)
+	assert.Empty(t, entries, "a failed upload saves nothing")
+}
+
+func TestGalleryRejects(t *testing.T) {
+	g := &Gallery{Dir: t.TempDir(), MaxBody: 1 << 20, MaxPhoto: 64 << 10}
+
+	resp := postPhotos(t, g, []string{"notes.png"}, []byte("just text"))
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	var got map[string]string
+	decode(t, resp, &got)
+	assert.Equal(t, "notes.png: unsupported file type: text/plain; charset=utf-8", got["error"])
+
+	resp = postPhotos(t, g, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	decode(t, resp, &got)
+	assert.Equal(t, "bad request: no photos in the form", got["error"])
+
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/photos", strings.NewReader("x=1")))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
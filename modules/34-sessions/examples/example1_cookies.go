@@ -0,0 +1,113 @@
+// Package examples demonstrates sessions in Go: cookies and the
+// attributes that keep them away from scripts and other sites, signing a
+// cookie's value, stores that remember sessions in memory or in files,
+// and a session manager with expiry, CSRF tokens, and a login flow.
+//
+// This file shows:
+// - The attributes of http.Cookie: HttpOnly, Secure, SameSite, Path, and MaxAge
+// - Deleting a cookie with MaxAge -1 and the same Path
+// - Signing a cookie's value with HMAC, so that the client cannot change it
+// - Reading cookies from a request with r.Cookie
+package examples
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+)
+
+// ErrBadSignature is returned by Verify for a value that was not signed
+// with the key, or was changed after it was.
+var ErrBadSignature = errors.New("bad cookie signature")
+
+// NewCookie returns a cookie that only the server sees, only over HTTPS,
+// and that other sites' pages do not send along, for ttl.
+func NewCookie(name, value string, ttl time.Duration) *http.Cookie {
+	return &http.Cookie{
+		Name:  name,
+		Value: value,
+		// Every path on the site. A cookie without a Path belongs to
+		// the directory of the page that set it.
+		Path: "/",
+		// Seconds, not a time.Duration. MaxAge is relative, so the
+		// client's clock does not matter, unlike Expires.
+		MaxAge: int(ttl / time.Second),
+		// Invisible to document.cookie, so an injected script cannot
+		// steal the session.
+		HttpOnly: true,
+		// HTTPS only. Browsers make an exception for localhost.
+		Secure: true,
+		// Not sent with requests that other sites start, except top-level
+		// navigation: the first defense against CSRF.
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// ClearCookie returns a cookie that deletes the cookie name, which
+// NewCookie set.
+func ClearCookie(name string) *http.Cookie {
+	c := NewCookie(name, "", 0)
+	// MaxAge 0 leaves Max-Age out, which makes a cookie that lasts until
+	// the browser closes. A negative MaxAge is Max-Age=0: delete now.
+	c.MaxAge = -1
+	return c
+}
+
+// Sign returns value with an HMAC of it, made with key, appended.
+func Sign(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return value + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns the value that Sign signed with key.
+func Verify(key []byte, signed string) (string, error) {
+	i := strings.LastIndexByte(signed, '.')
+	if i < 0 {
+		return "", ErrBadSignature
+	}
+	value, sig := signed[:i], signed[i+1:]
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return "", ErrBadSignature
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	// hmac.Equal takes the same time wherever the first difference is.
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return "", ErrBadSignature
+	}
+	return value, nil
+}
+
+// DemonstrateCookies sets, reads, and deletes a signed cookie.
+func DemonstrateCookies() {
+	fmt.Println("=== Cookies ===")
+	key := []byte("a key of 32 bytes, from a secret")
+
+	rec := httptest.NewRecorder()
+	http.SetCookie(rec, NewCookie("theme", Sign(key, "dark"), 30*24*time.Hour))
+	fmt.Println("Set-Cookie:", rec.Header().Get("Set-Cookie"))
+
+	// The browser sends back only the name and value.
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, c := range rec.Result().Cookies() {
+		req.AddCookie(c)
+	}
+	c, _ := req.Cookie("theme")
+	fmt.Println("Cookie:", req.Header.Get("Cookie"))
+	value, err := Verify(key, c.Value)
+	fmt.Printf("verified: %q, %v\n", value, err)
+	_, err = Verify(key, strings.Replace(c.Value, "dark", "light", 1))
+	fmt.Println("changed by the client:", err)
+
+	rec = httptest.NewRecorder()
+	http.SetCookie(rec, ClearCookie("theme"))
+	fmt.Println("Set-Cookie:", rec.Header().Get("Set-Cookie"))
+}
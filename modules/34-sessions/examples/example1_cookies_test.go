@@ -0,0 +1,50 @@
+package examples
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateCookies(t *testing.T) {
+	DemonstrateCookies()
+}
+
+func TestNewCookie(t *testing.T) {
+	c := NewCookie("session", "abc", time.Hour)
+	assert.Equal(t, "session=abc; Path=/; Max-Age=3600; HttpOnly; Secure; SameSite=Lax", c.String())
+	require.NoError(t, c.Valid())
+}
+
+func TestClearCookie(t *testing.T) {
+	rec := httptest.NewRecorder()
+	http.SetCookie(rec, ClearCookie("session"))
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "/", cookies[0].Path)
+	assert.Equal(t, -1, cookies[0].MaxAge, "Max-Age=0 parses as -1: delete now")
+}
+
+func TestSignVerify(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	signed := Sign(key, "user.42")
+	value, err := Verify(key, signed)
+	require.NoError(t, err)
+	assert.Equal(t, "user.42", value)
+
+	for _, bad := range []string{
+		"user.43" + signed[len("user.42"):],
+		"user.42",
+		"nodot",
+		signed + "x",
+	} {
+		_, err := Verify(key, bad)
+		assert.ErrorIs(t, err, ErrBadSignature, bad)
+	}
+	_, err = Verify([]byte("another key"), signed)
+	assert.ErrorIs(t, err, ErrBadSignature)
+}
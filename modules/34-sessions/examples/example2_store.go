@@ -0,0 +1,257 @@
+package examples
+
+// This file shows:
+// - A Store interface with an implementation in memory and one in files
+// - Session IDs from crypto/rand, checked before they become file names
+// - Copying a session in and out of MemoryStore, so that callers do not
+//   share its maps
+// - Writing a file atomically with os.CreateTemp and os.Rename
+// - Sweeping expired sessions
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned by a Store for an ID it has no session for.
+var ErrNotFound = errors.New("session not found")
+
+// Session is what the server remembers about a client between requests.
+// The client only has its ID.
+type Session struct {
+	ID      string            `json:"id"`
+	Values  map[string]string `json:"values"`
+	CSRF    string            `json:"csrf"`
+	Expires time.Time         `json:"expires"`
+}
+
+// Store keeps sessions by ID.
+type Store interface {
+	// Load returns the session with id, or ErrNotFound.
+	Load(id string) (*Session, error)
+	// Save adds s, or replaces the session with its ID.
+	Save(s *Session) error
+	// Delete removes the session with id, if there is one.
+	Delete(id string) error
+	// Sweep removes the sessions that expired before now, and returns
+	// how many it removed.
+	Sweep(now time.Time) (int, error)
+}
+
+// NewID returns a random session ID: 256 bits, base64-encoded for URLs.
+func NewID() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// validID reports whether id could have come from NewID.
+func validID(id string) bool {
+	if len(id) != base64.RawURLEncoding.EncodedLen(32) {
+		return false
+	}
+	_, err := base64.RawURLEncoding.DecodeString(id)
+	return err == nil
+}
+
+// clone returns a copy of s that shares nothing with it.
+func clone(s *Session) *Session {
+	c := *s
+	c.Values = maps.Clone(s.Values)
+	return &c
+}
+
+// MemoryStore is a Store in memory: fast, and gone when the process
+// exits. Its zero value is ready to use.
+type MemoryStore struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// Load implements Store.
+func (m *MemoryStore) Load(id string) (*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return clone(s), nil
+}
+
+// Save implements Store.
+func (m *MemoryStore) Save(s *Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sessions == nil {
+		m.sessions = make(map[string]*Session)
+	}
+	m.sessions[s.ID] = clone(s)
+	return nil
+}
+
+// Delete implements Store.
+func (m *MemoryStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, id)
+	return nil
+}
+
+// Sweep implements Store.
+func (m *MemoryStore) Sweep(now time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for id, s := range m.sessions {
+		if now.After(s.Expires) {
+			delete(m.sessions, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// FileStore is a Store with a JSON file per session in Dir, which
+// survives restarts.
+type FileStore struct {
+	Dir string
+}
+
+// path returns the file of the session with id. The ID comes from a
+// cookie, which the client can set to "../../etc/passwd", so it is checked
+// first.
+func (f *FileStore) path(id string) (string, error) {
+	if !validID(id) {
+		return "", ErrNotFound
+	}
+	return filepath.Join(f.Dir, id+".json"), nil
+}
+
+// Load implements Store.
+func (f *FileStore) Load(id string) (*Session, error) {
+	path, err := f.path(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("session %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Save implements Store.
+func (f *FileStore) Save(s *Session) error {
+	path, err := f.path(s.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	// A reader never sees half a file: write another file, then rename
+	// it over the old one. CreateTemp makes it readable by us alone.
+	tmp, err := os.CreateTemp(f.Dir, ".session-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Delete implements Store.
+func (f *FileStore) Delete(id string) error {
+	path, err := f.path(id)
+	if err != nil {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Sweep implements Store.
+func (f *FileStore) Sweep(now time.Time) (int, error) {
+	entries, err := os.ReadDir(f.Dir)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, e := range entries {
+		id, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok {
+			continue
+		}
+		s, err := f.Load(id)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return n, err
+		}
+		if now.After(s.Expires) {
+			if err := f.Delete(id); err != nil {
+				return n, err
+			}
+			n++
+		}
+	}
+	return n, nil
+}
+
+// DemonstrateStores saves, loads, and sweeps sessions in both stores.
+func DemonstrateStores() {
+	fmt.Println("=== Session stores ===")
+	dir, err := os.MkdirTemp("", "sessions-")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, store := range []Store{&MemoryStore{}, &FileStore{Dir: dir}} {
+		id, _ := NewID()
+		s := &Session{ID: id, Values: map[string]string{"user": "ada"}, Expires: now.Add(time.Hour)}
+		if err := store.Save(s); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		loaded, err := store.Load(id)
+		fmt.Printf("%T: loaded user %q, %v\n", store, loaded.Values["user"], err)
+		_, err = store.Load("../../etc/passwd")
+		fmt.Printf("%T: a made-up ID: %v\n", store, err)
+		n, _ := store.Sweep(now.Add(30 * time.Minute))
+		fmt.Printf("%T: swept %d after 30 minutes\n", store, n)
+		n, _ = store.Sweep(now.Add(2 * time.Hour))
+		fmt.Printf("%T: swept %d after 2 hours\n", store, n)
+	}
+}
@@ -0,0 +1,84 @@
+package examples
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateStores(t *testing.T) {
+	DemonstrateStores()
+}
+
+func testStore(t *testing.T, store Store) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	id, err := NewID()
+	require.NoError(t, err)
+
+	_, err = store.Load(id)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	s := &Session{ID: id, Values: map[string]string{"user": "ada"}, CSRF: "c", Expires: now.Add(time.Hour)}
+	require.NoError(t, store.Save(s))
+	s.Values["user"] = "mallory"
+
+	got, err := store.Load(id)
+	require.NoError(t, err)
+	assert.Equal(t, "ada", got.Values["user"], "the store keeps a copy")
+	assert.True(t, got.Expires.Equal(s.Expires))
+
+	n, err := store.Sweep(now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	n, err = store.Sweep(now.Add(time.Hour + time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	_, err = store.Load(id)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Save(s))
+	require.NoError(t, store.Delete(id))
+	_, err = store.Load(id)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, store.Delete(id), "deleting twice")
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, &MemoryStore{})
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	testStore(t, &FileStore{Dir: dir})
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "no temporary files left")
+}
+
+func TestFileStoreRejectsPaths(t *testing.T) {
+	dir := t.TempDir()
+	outside := filepath.Join(filepath.Dir(dir), "secret.json")
+	require.NoError(t, os.WriteFile(outside, []byte(`{"values":{"user":"root"}}`), 0o600))
+	t.Cleanup(func() { os.Remove(outside) })
+
+	store := &FileStore{Dir: dir}
+	_, err := store.Load("../secret")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Error(t, store.Save(&Session{ID: "../secret"}))
+	assert.FileExists(t, outside)
+}
+
+func TestFileStorePermissions(t *testing.T) {
+	store := &FileStore{Dir: t.TempDir()}
+	id, err := NewID()
+	require.NoError(t, err)
+	require.NoError(t, store.Save(&Session{ID: id}))
+	info, err := os.Stat(filepath.Join(store.Dir, id+".json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
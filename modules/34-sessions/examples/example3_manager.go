@@ -0,0 +1,272 @@
+package examples
+
+// This file shows:
+// - A session manager that ties a Store to a cookie
+// - Expiry on the server, whatever the cookie's Max-Age, sliding with use
+// - A new session ID at login, so that an ID planted before it is useless
+// - CSRF tokens: a random value per session that forms and scripts send
+//   back, which another site cannot read
+// - Deleting the session from the store at logout, not only the cookie
+// - A login flow of JSON endpoints: /login, /logout, and /me
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+)
+
+// ErrCSRF is returned by CheckCSRF for a request without the session's
+// CSRF token.
+var ErrCSRF = errors.New("missing or wrong CSRF token")
+
+// CSRFHeader is the header scripts send the CSRF token in. Forms send it
+// as the csrf_token field.
+const CSRFHeader = "X-CSRF-Token"
+
+// Manager keeps sessions in Store, and their IDs in a cookie.
+type Manager struct {
+	Store Store
+	// Cookie is the cookie's name.
+	Cookie string
+	// TTL is how long a session lasts after the request that last
+	// saved it.
+	TTL time.Duration
+	// Now returns the current time. Tests set it; nil is time.Now.
+	Now func() time.Time
+}
+
+func (m *Manager) now() time.Time {
+	if m.Now != nil {
+		return m.Now()
+	}
+	return time.Now()
+}
+
+// Get returns the session of r's cookie, or ErrNotFound if there is none
+// or it has expired.
+func (m *Manager) Get(r *http.Request) (*Session, error) {
+	c, err := r.Cookie(m.Cookie)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	s, err := m.Store.Load(c.Value)
+	if err != nil {
+		return nil, err
+	}
+	// The cookie's Max-Age is a request to the browser. The server
+	// decides when a session ends.
+	if m.now().After(s.Expires) {
+		m.Store.Delete(s.ID)
+		return nil, ErrNotFound
+	}
+	return s, nil
+}
+
+// New returns a new, empty session. Save stores it.
+func (m *Manager) New() (*Session, error) {
+	id, err := NewID()
+	if err != nil {
+		return nil, err
+	}
+	csrf, err := NewID()
+	if err != nil {
+		return nil, err
+	}
+	return &Session{ID: id, Values: map[string]string{}, CSRF: csrf}, nil
+}
+
+// Save stores s for another TTL, and sets its cookie on w.
+func (m *Manager) Save(w http.ResponseWriter, s *Session) error {
+	s.Expires = m.now().Add(m.TTL)
+	if err := m.Store.Save(s); err != nil {
+		return err
+	}
+	http.SetCookie(w, NewCookie(m.Cookie, s.ID, m.TTL))
+	return nil
+}
+
+// Renew moves s to a new ID and CSRF token, and deletes the old one. Call
+// it whenever the session's privileges change, and above all at login.
+func (m *Manager) Renew(s *Session) error {
+	if err := m.Store.Delete(s.ID); err != nil {
+		return err
+	}
+	n, err := m.New()
+	if err != nil {
+		return err
+	}
+	s.ID, s.CSRF = n.ID, n.CSRF
+	return nil
+}
+
+// Destroy deletes r's session and its cookie.
+func (m *Manager) Destroy(w http.ResponseWriter, r *http.Request) error {
+	http.SetCookie(w, ClearCookie(m.Cookie))
+	c, err := r.Cookie(m.Cookie)
+	if err != nil {
+		return nil
+	}
+	// Deleting the cookie alone would leave the session usable by
+	// anyone who has a copy of it.
+	return m.Store.Delete(c.Value)
+}
+
+// CheckCSRF returns ErrCSRF unless r carries s's CSRF token.
+func CheckCSRF(r *http.Request, s *Session) error {
+	token := r.Header.Get(CSRFHeader)
+	if token == "" {
+		token = r.PostFormValue("csrf_token")
+	}
+	if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.CSRF)) != 1 {
+		return ErrCSRF
+	}
+	return nil
+}
+
+// Auth logs users in and out with sessions from Sessions.
+type Auth struct {
+	Sessions *Manager
+	// Check reports whether password is user's.
+	Check func(user, password string) bool
+}
+
+// LoginResponse is what /login and /me answer with.
+type LoginResponse struct {
+	User string `json:"user"`
+	CSRF string `json:"csrf"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// Handler returns the handler of /login, /logout, and /me.
+func (a *Auth) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", a.login)
+	mux.HandleFunc("/logout", a.logout)
+	mux.HandleFunc("/me", a.me)
+	return mux
+}
+
+// login takes {"user": "...", "password": "..."}.
+func (a *Auth) login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var req struct{ User, Password string }
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<10)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad request")
+		return
+	}
+	if !a.Check(req.User, req.Password) {
+		writeError(w, http.StatusUnauthorized, "wrong user name or password")
+		return
+	}
+	s, err := a.Sessions.Get(r)
+	if errors.Is(err, ErrNotFound) {
+		s, err = a.Sessions.New()
+	}
+	if err == nil {
+		// An attacker who planted their session ID in the victim's
+		// browser would be logged in too, without Renew.
+		err = a.Sessions.Renew(s)
+	}
+	if err == nil {
+		s.Values["user"] = req.User
+		err = a.Sessions.Save(w, s)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeJSON(w, http.StatusOK, LoginResponse{User: req.User, CSRF: s.CSRF})
+}
+
+// logout needs the CSRF token: a logout that any site can trigger is a
+// nuisance, and the same check guards every request that changes things.
+func (a *Auth) logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	s, err := a.Sessions.Get(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "not logged in")
+		return
+	}
+	if err := CheckCSRF(r, s); err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err := a.Sessions.Destroy(w, r); err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// me answers with the user logged in, and refreshes the session.
+func (a *Auth) me(w http.ResponseWriter, r *http.Request) {
+	s, err := a.Sessions.Get(r)
+	if err != nil || s.Values["user"] == "" {
+		writeError(w, http.StatusUnauthorized, "not logged in")
+		return
+	}
+	if err := a.Sessions.Save(w, s); err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeJSON(w, http.StatusOK, LoginResponse{User: s.Values["user"], CSRF: s.CSRF})
+}
+
+// DemonstrateLogin logs in, looks, and logs out, as a browser would.
+func DemonstrateLogin() {
+	fmt.Println("=== Login flow ===")
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	auth := &Auth{
+		Sessions: &Manager{Store: &MemoryStore{}, Cookie: "session", TTL: time.Hour, Now: func() time.Time { return now }},
+		Check:    func(user, password string) bool { return user == "ada" && password == "lovelace" },
+	}
+	h := auth.Handler()
+	var cookies []*http.Cookie
+	do := func(method, path, body, csrf string) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		if csrf != "" {
+			req.Header.Set(CSRFHeader, csrf)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if set := rec.Result().Cookies(); len(set) > 0 {
+			cookies = set
+		}
+		fmt.Printf("%s %s: %d %s", method, path, rec.Code, rec.Body)
+		if rec.Body.Len() == 0 {
+			fmt.Println()
+		}
+	}
+
+	do("GET", "/me", "", "")
+	do("POST", "/login", `{"user":"ada","password":"wrong"}`, "")
+	do("POST", "/login", `{"user":"ada","password":"lovelace"}`, "")
+	do("GET", "/me", "", "")
+	s, _ := auth.Sessions.Store.Load(cookies[0].Value)
+	do("POST", "/logout", "", "")
+	do("POST", "/logout", "", s.CSRF)
+	do("GET", "/me", "", "")
+}
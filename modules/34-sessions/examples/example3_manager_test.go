@@ -0,0 +1,129 @@
+package examples
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateLogin(t *testing.T) {
+	DemonstrateLogin()
+}
+
+// browser keeps cookies between requests to h.
+type browser struct {
+	h       http.Handler
+	cookies map[string]*http.Cookie
+}
+
+func (b *browser) do(method, path, body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	for k, v := range header {
+		req.Header.Set(k, v[0])
+	}
+	for _, c := range b.cookies {
+		req.AddCookie(c)
+	}
+	rec := httptest.NewRecorder()
+	b.h.ServeHTTP(rec, req)
+	for _, c := range rec.Result().Cookies() {
+		if c.MaxAge < 0 {
+			delete(b.cookies, c.Name)
+		} else {
+			b.cookies[c.Name] = c
+		}
+	}
+	return rec
+}
+
+func newAuth(now *time.Time) *Auth {
+	return &Auth{
+		Sessions: &Manager{Store: &MemoryStore{}, Cookie: "session", TTL: time.Hour, Now: func() time.Time { return *now }},
+		Check:    func(user, password string) bool { return user == "ada" && password == "lovelace" },
+	}
+}
+
+func TestLoginFlow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := &browser{h: newAuth(&now).Handler(), cookies: map[string]*http.Cookie{}}
+
+	assert.Equal(t, http.StatusUnauthorized, b.do("GET", "/me", "", nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, b.do("POST", "/login", `{"user":"ada","password":"x"}`, nil).Code)
+
+	rec := b.do("POST", "/login", `{"user":"ada","password":"lovelace"}`, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Header().Get("Set-Cookie"), "HttpOnly; Secure; SameSite=Lax")
+
+	rec = b.do("GET", "/me", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"user":"ada"`)
+
+	rec = b.do("POST", "/logout", "", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code, "no CSRF token")
+
+	rec = b.do("POST", "/logout", "csrf_token=wrong", http.Header{"Content-Type": {"application/x-www-form-urlencoded"}})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	var me LoginResponse
+	require.NoError(t, json.Unmarshal(b.do("GET", "/me", "", nil).Body.Bytes(), &me))
+	rec = b.do("POST", "/logout", "", http.Header{CSRFHeader: {me.CSRF}})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, http.StatusUnauthorized, b.do("GET", "/me", "", nil).Code)
+}
+
+func TestLogoutDeletesSession(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	auth := newAuth(&now)
+	b := &browser{h: auth.Handler(), cookies: map[string]*http.Cookie{}}
+	require.Equal(t, http.StatusOK, b.do("POST", "/login", `{"user":"ada","password":"lovelace"}`, nil).Code)
+	stolen := b.cookies["session"]
+	s, err := auth.Sessions.Store.Load(stolen.Value)
+	require.NoError(t, err)
+
+	form := url.Values{"csrf_token": {s.CSRF}}.Encode()
+	rec := b.do("POST", "/logout", form, http.Header{"Content-Type": {"application/x-www-form-urlencoded"}})
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, b.cookies)
+
+	thief := &browser{h: auth.Handler(), cookies: map[string]*http.Cookie{"session": stolen}}
+	assert.Equal(t, http.StatusUnauthorized, thief.do("GET", "/me", "", nil).Code)
+}
+
+func TestLoginRenewsPlantedSession(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	auth := newAuth(&now)
+	planted, err := auth.Sessions.New()
+	require.NoError(t, err)
+	require.NoError(t, auth.Sessions.Save(httptest.NewRecorder(), planted))
+
+	b := &browser{h: auth.Handler(), cookies: map[string]*http.Cookie{
+		"session": {Name: "session", Value: planted.ID},
+	}}
+	require.Equal(t, http.StatusOK, b.do("POST", "/login", `{"user":"ada","password":"lovelace"}`, nil).Code)
+	assert.NotEqual(t, planted.ID, b.cookies["session"].Value)
+
+	attacker := &browser{h: auth.Handler(), cookies: map[string]*http.Cookie{
+		"session": {Name: "session", Value: planted.ID},
+	}}
+	assert.Equal(t, http.StatusUnauthorized, attacker.do("GET", "/me", "", nil).Code)
+}
+
+func TestSessionExpires(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := &browser{h: newAuth(&now).Handler(), cookies: map[string]*http.Cookie{}}
+	require.Equal(t, http.StatusOK, b.do("POST", "/login", `{"user":"ada","password":"lovelace"}`, nil).Code)
+
+	now = now.Add(50 * time.Minute)
+	require.Equal(t, http.StatusOK, b.do("GET", "/me", "", nil).Code, "within the hour")
+	now = now.Add(50 * time.Minute)
+	require.Equal(t, http.StatusOK, b.do("GET", "/me", "", nil).Code, "the last request renewed it")
+	now = now.Add(61 * time.Minute)
+	assert.Equal(t, http.StatusUnauthorized, b.do("GET", "/me", "", nil).Code)
+}
@@ -0,0 +1,54 @@
+package exercises
+
+// EXERCISE: Fix a session cookie that scripts can read and other sites can send.
+// SessionCookie gives a client its session ID, and ExpiredCookie takes it
+// away at logout. Logging in works, but the cookie is readable from
+// JavaScript, travels over plain HTTP, comes along with other sites'
+// requests, lasts far longer than it should, and survives logout.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// CookieName is the name of the session cookie.
+const CookieName = "session"
+
+// ErrNoSession is returned by SessionID for a request without a session
+// cookie.
+var ErrNoSession = errors.New("no session cookie")
+
+// SessionCookie returns the cookie that gives a client session id for
+// ttl.
+func SessionCookie(id string, ttl time.Duration) *http.Cookie {
+	// BUG: without HttpOnly, any script on the page can read the cookie.
+	// BUG: without Secure, the cookie is sent over plain HTTP too.
+	return &http.Cookie{
+		Name:  CookieName,
+		Value: id,
+		Path:  "/",
+		// BUG: MaxAge is in seconds.
+		MaxAge: int(ttl),
+		// BUG: SameSite None sends the cookie with requests that other
+		// sites start, such as a form posted to this one.
+		SameSite: http.SameSiteNoneMode,
+	}
+}
+
+// ExpiredCookie returns the cookie that deletes the session cookie.
+func ExpiredCookie() *http.Cookie {
+	// BUG: MaxAge 0 leaves Max-Age out, and without the session cookie's
+	// Path this is another cookie.
+	return &http.Cookie{Name: CookieName, MaxAge: 0}
+}
+
+// SessionID returns the session ID in r's session cookie.
+func SessionID(r *http.Request) (string, error) {
+	c, err := r.Cookie(CookieName)
+	if err != nil || c.Value == "" {
+		return "", ErrNoSession
+	}
+	return c.Value, nil
+}
@@ -0,0 +1,67 @@
+package exercises
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setCookie returns c as the client receives it in a response.
+func setCookie(t *testing.T, c *http.Cookie) *http.Cookie {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	http.SetCookie(rec, c)
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1, "Set-Cookie: %s", rec.Header().Get("Set-Cookie"))
+	return cookies[0]
+}
+
+func TestSessionCookieHttpOnly(t *testing.T) {
+	c := setCookie(t, SessionCookie("abc", time.Hour))
+	assert.True(t, c.HttpOnly, "scripts can read the session ID")
+}
+
+func TestSessionCookieSecure(t *testing.T) {
+	c := setCookie(t, SessionCookie("abc", time.Hour))
+	assert.True(t, c.Secure, "the session ID is sent over plain HTTP")
+}
+
+func TestSessionCookieSameSite(t *testing.T) {
+	c := setCookie(t, SessionCookie("abc", time.Hour))
+	assert.Equal(t, http.SameSiteLaxMode, c.SameSite, "other sites' forms send the session cookie")
+}
+
+func TestSessionCookieLifetime(t *testing.T) {
+	c := setCookie(t, SessionCookie("abc", 90*time.Minute))
+	assert.Equal(t, 5400, c.MaxAge)
+	assert.Equal(t, "/", c.Path)
+	assert.Equal(t, "abc", c.Value)
+}
+
+func TestSessionCookieHeader(t *testing.T) {
+	assert.Equal(t, "session=abc; Path=/; Max-Age=3600; HttpOnly; Secure; SameSite=Lax",
+		SessionCookie("abc", time.Hour).String())
+}
+
+func TestExpiredCookie(t *testing.T) {
+	c := setCookie(t, ExpiredCookie())
+	assert.Equal(t, CookieName, c.Name)
+	assert.Equal(t, "/", c.Path, "a cookie with another path is another cookie")
+	assert.Equal(t, -1, c.MaxAge, "the cookie is not deleted")
+	assert.Equal(t, "session=; Path=/; Max-Age=0; HttpOnly; Secure; SameSite=Lax", ExpiredCookie().String())
+}
+
+func TestSessionID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	_, err := SessionID(req)
+	assert.ErrorIs(t, err, ErrNoSession)
+
+	req.AddCookie(&http.Cookie{Name: CookieName, Value: "abc"})
+	id, err := SessionID(req)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", id)
+}
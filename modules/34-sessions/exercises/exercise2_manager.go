@@ -0,0 +1,172 @@
+package exercises
+
+// EXERCISE: Fix a session manager that keeps sessions alive after they should end.
+// Manager logs users in, keeps their sessions in memory, and guards
+// handlers with Protect, which also checks CSRF tokens. Sessions never
+// expire, except the ones in use, a logged-out cookie still works, an ID
+// planted before login carries the login, and requests without a CSRF
+// token get through.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCSRF is returned for an unsafe request without the session's CSRF
+// token.
+var ErrCSRF = errors.New("missing or wrong CSRF token")
+
+// CSRFHeader is the header the CSRF token comes in.
+const CSRFHeader = "X-CSRF-Token"
+
+// Session is what the server remembers about a logged-in client.
+type Session struct {
+	ID      string
+	User    string
+	CSRF    string
+	Expires time.Time
+}
+
+// Manager keeps sessions in memory, and their IDs in a cookie named
+// "sid".
+type Manager struct {
+	TTL time.Duration
+	// Now returns the current time. Tests set it; nil is time.Now.
+	Now func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]Session
+}
+
+func (m *Manager) now() time.Time {
+	if m.Now != nil {
+		return m.Now()
+	}
+	return time.Now()
+}
+
+func randomID() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (m *Manager) setCookie(w http.ResponseWriter, value string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name: "sid", Value: value, Path: "/", MaxAge: maxAge,
+		HttpOnly: true, Secure: true, SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// save stores s until TTL from now, and sets its cookie.
+func (m *Manager) save(w http.ResponseWriter, s Session) Session {
+	s.Expires = m.now().Add(m.TTL)
+	m.mu.Lock()
+	if m.sessions == nil {
+		m.sessions = make(map[string]Session)
+	}
+	m.sessions[s.ID] = s
+	m.mu.Unlock()
+	m.setCookie(w, s.ID, int(m.TTL/time.Second))
+	return s
+}
+
+// Session returns r's session, or ErrNoSession.
+func (m *Manager) Session(r *http.Request) (Session, error) {
+	c, err := r.Cookie("sid")
+	if err != nil {
+		return Session{}, ErrNoSession
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[c.Value]
+	if !ok {
+		return Session{}, ErrNoSession
+	}
+	// BUG: the session is returned whatever its Expires says.
+	return s, nil
+}
+
+// Login starts a session for user, whose password has been checked, and
+// returns it.
+func (m *Manager) Login(w http.ResponseWriter, r *http.Request, user string) (Session, error) {
+	id, err := randomID()
+	if err != nil {
+		return Session{}, err
+	}
+	csrf, err := randomID()
+	if err != nil {
+		return Session{}, err
+	}
+	// BUG: keeping the session ID the client already had lets whoever
+	// put it there share the new login.
+	if old, err := m.Session(r); err == nil {
+		id, csrf = old.ID, old.CSRF
+	}
+	return m.save(w, Session{ID: id, User: user, CSRF: csrf}), nil
+}
+
+// Logout ends r's session.
+func (m *Manager) Logout(w http.ResponseWriter, r *http.Request) {
+	// BUG: deleting the cookie does not delete the session, and any copy
+	// of the cookie still works.
+	m.setCookie(w, "", -1)
+}
+
+// checkCSRF returns ErrCSRF unless r carries s's CSRF token.
+func checkCSRF(r *http.Request, s Session) error {
+	token := r.Header.Get(CSRFHeader)
+	// BUG: a request without a token is let through, and != stops at
+	// the first byte that differs, which an attacker can time.
+	if token != "" && token != s.CSRF {
+		return ErrCSRF
+	}
+	return nil
+}
+
+type sessionKey struct{}
+
+// Protect lets requests with a session through to next, and answers the
+// rest with 401. Requests that may change things also need the session's
+// CSRF token, or get 403.
+func (m *Manager) Protect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s, err := m.Session(r)
+		if err != nil {
+			respondError(w, http.StatusUnauthorized, "not logged in")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			if err := checkCSRF(r, s); err != nil {
+				respondError(w, http.StatusForbidden, err.Error())
+				return
+			}
+		}
+		// BUG: the session's Expires is never moved, so a session in use
+		// ends TTL after login.
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), sessionKey{}, s)))
+	})
+}
+
+// FromContext returns the session that Protect put in ctx.
+func FromContext(ctx context.Context) (Session, bool) {
+	s, ok := ctx.Value(sessionKey{}).(Session)
+	return s, ok
+}
+
+func respondError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
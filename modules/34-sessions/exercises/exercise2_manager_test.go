@@ -0,0 +1,182 @@
+package exercises
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var passwords = map[string]string{"ada": "lovelace", "mallory": "hunter2"}
+
+// newApp returns a small app behind m: /login, and /me, /notes, and
+// /logout, which need a session.
+func newApp(m *Manager) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		user := r.PostFormValue("user")
+		if p, ok := passwords[user]; !ok || p != r.PostFormValue("password") {
+			respondError(w, http.StatusUnauthorized, "wrong user name or password")
+			return
+		}
+		s, err := m.Login(w, r, user)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"csrf": s.CSRF})
+	})
+	mux.Handle("/me", m.Protect(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s, _ := FromContext(r.Context())
+		json.NewEncoder(w).Encode(map[string]string{"user": s.User})
+	})))
+	mux.Handle("/notes", m.Protect(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})))
+	mux.Handle("/logout", m.Protect(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.Logout(w, r)
+		w.WriteHeader(http.StatusNoContent)
+	})))
+	return mux
+}
+
+// client is a browser: it keeps the cookies it is given, and its CSRF
+// token.
+type client struct {
+	t       *testing.T
+	app     http.Handler
+	cookies map[string]*http.Cookie
+	csrf    string
+}
+
+func newClient(t *testing.T, app http.Handler) *client {
+	return &client{t: t, app: app, cookies: map[string]*http.Cookie{}}
+}
+
+func (c *client) do(method, path string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if c.csrf != "" {
+		req.Header.Set(CSRFHeader, c.csrf)
+	}
+	for _, cookie := range c.cookies {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	c.app.ServeHTTP(rec, req)
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.MaxAge < 0 {
+			delete(c.cookies, cookie.Name)
+		} else {
+			c.cookies[cookie.Name] = cookie
+		}
+	}
+	return rec
+}
+
+func (c *client) login(user string) {
+	c.t.Helper()
+	rec := c.do(http.MethodPost, "/login", url.Values{"user": {user}, "password": {passwords[user]}})
+	require.Equal(c.t, http.StatusOK, rec.Code, rec.Body.String())
+	var got map[string]string
+	require.NoError(c.t, json.Unmarshal(rec.Body.Bytes(), &got))
+	c.csrf = got["csrf"]
+}
+
+// me returns the user /me answers with, or "" for a 401.
+func (c *client) me() string {
+	c.t.Helper()
+	rec := c.do(http.MethodGet, "/me", nil)
+	if rec.Code == http.StatusUnauthorized {
+		return ""
+	}
+	require.Equal(c.t, http.StatusOK, rec.Code, rec.Body.String())
+	var got map[string]string
+	require.NoError(c.t, json.Unmarshal(rec.Body.Bytes(), &got))
+	return got["user"]
+}
+
+func newManager(now *time.Time) *Manager {
+	return &Manager{TTL: 30 * time.Minute, Now: func() time.Time { return *now }}
+}
+
+func TestLogin(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := newClient(t, newApp(newManager(&now)))
+	assert.Equal(t, "", c.me())
+	c.login("ada")
+	assert.Equal(t, "ada", c.me())
+}
+
+func TestSessionExpires(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := newClient(t, newApp(newManager(&now)))
+	c.login("ada")
+
+	now = now.Add(31 * time.Minute)
+	assert.Equal(t, "", c.me(), "the session outlived its TTL")
+}
+
+func TestSessionSlides(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := newClient(t, newApp(newManager(&now)))
+	c.login("ada")
+
+	for i := 0; i < 4; i++ {
+		now = now.Add(20 * time.Minute)
+		assert.Equal(t, "ada", c.me(), "a session in use ended after %v", time.Duration(i+1)*20*time.Minute)
+	}
+}
+
+func TestLoginRenewsSessionID(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	app := newApp(newManager(&now))
+	mallory := newClient(t, app)
+	mallory.login("mallory")
+
+	// Mallory plants their session cookie in Ada's browser, and Ada logs in.
+	ada := newClient(t, app)
+	ada.cookies["sid"] = mallory.cookies["sid"]
+	ada.login("ada")
+	assert.Equal(t, "ada", ada.me())
+	assert.NotEqual(t, mallory.cookies["sid"].Value, ada.cookies["sid"].Value)
+	assert.NotEqual(t, "ada", mallory.me(), "Mallory is logged in as Ada")
+}
+
+func TestLogoutEndsSession(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	app := newApp(newManager(&now))
+	c := newClient(t, app)
+	c.login("ada")
+	stolen := *c.cookies["sid"]
+
+	require.Equal(t, http.StatusNoContent, c.do(http.MethodPost, "/logout", nil).Code)
+	assert.Empty(t, c.cookies, "the cookie is deleted")
+
+	thief := newClient(t, app)
+	thief.cookies["sid"] = &stolen
+	assert.Equal(t, "", thief.me(), "a copy of the cookie still works after logout")
+}
+
+func TestCSRF(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := newClient(t, newApp(newManager(&now)))
+	c.login("ada")
+	token := c.csrf
+
+	c.csrf = ""
+	assert.Equal(t, http.StatusForbidden, c.do(http.MethodPost, "/notes", nil).Code, "no token")
+	c.csrf = "wrong"
+	assert.Equal(t, http.StatusForbidden, c.do(http.MethodPost, "/notes", nil).Code, "wrong token")
+	c.csrf = token
+	assert.Equal(t, http.StatusCreated, c.do(http.MethodPost, "/notes", nil).Code)
+
+	c.csrf = ""
+	assert.Equal(t, "ada", c.me(), "GET needs no token")
+}
@@ -0,0 +1,51 @@
+package solutions
+
+// SOLUTION: Fix a session cookie that scripts can read and other sites can send.
+// Fixed: HttpOnly keeps the cookie from document.cookie
+// Fixed: Secure sends it over HTTPS only
+// Fixed: SameSite Lax keeps it off requests that other sites start
+// Fixed: MaxAge is in seconds, not nanoseconds
+// Fixed: ExpiredCookie has the session cookie's Path, and a negative MaxAge, which is Max-Age=0
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// CookieName is the name of the session cookie.
+const CookieName = "session"
+
+// ErrNoSession is returned by SessionID for a request without a session
+// cookie.
+var ErrNoSession = errors.New("no session cookie")
+
+// SessionCookie returns the cookie that gives a client session id for
+// ttl.
+func SessionCookie(id string, ttl time.Duration) *http.Cookie {
+	return &http.Cookie{
+		Name:     CookieName,
+		Value:    id,
+		Path:     "/",
+		MaxAge:   int(ttl / time.Second),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// ExpiredCookie returns the cookie that deletes the session cookie.
+func ExpiredCookie() *http.Cookie {
+	c := SessionCookie("", 0)
+	c.MaxAge = -1
+	return c
+}
+
+// SessionID returns the session ID in r's session cookie.
+func SessionID(r *http.Request) (string, error) {
+	c, err := r.Cookie(CookieName)
+	if err != nil || c.Value == "" {
+		return "", ErrNoSession
+	}
+	return c.Value, nil
+}
@@ -0,0 +1,224 @@
+// Package examples demonstrates the client side of OAuth 2.0 in Go: the
+// authorization-code flow that logs users in with another site's
+// account, the state parameter that ties the callback to the browser that
+// started it, refreshing access tokens, and storing them.
+//
+// The examples log in against the fake provider in examples/provider,
+// which runs in the same process. Real apps use golang.org/x/oauth2,
+// whose Config, Token, and TokenSource these mirror.
+//
+// This file shows:
+// - The authorization request: the URL the user is sent to, with client_id, redirect_uri, scope, and state
+// - A random state, kept in a short-lived cookie, and checked in the callback
+// - Exchanging the code for tokens at the token endpoint, with the client's secret
+// - Errors from the token endpoint, as in RFC 6749
+package examples
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/35-oauth2/examples/provider"
+)
+
+// Config is an app's registration with a provider.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	// RedirectURL is the app's callback, where the provider sends the
+	// user back with a code.
+	RedirectURL string
+	Scopes      []string
+}
+
+// Token is what the token endpoint issues.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// TokenError is an error response from the token endpoint.
+type TokenError struct {
+	Status int
+	Code   string // such as invalid_grant
+}
+
+func (e *TokenError) Error() string {
+	return fmt.Sprintf("token endpoint: %d %s", e.Status, e.Code)
+}
+
+// AuthCodeURL returns the URL to send the user to, with state in it.
+func (c *Config) AuthCodeURL(state string) string {
+	q := url.Values{
+		"response_type": {"code"},
+		"client_id":     {c.ClientID},
+		"redirect_uri":  {c.RedirectURL},
+		"state":         {state},
+	}
+	if len(c.Scopes) > 0 {
+		q.Set("scope", strings.Join(c.Scopes, " "))
+	}
+	return c.AuthURL + "?" + q.Encode()
+}
+
+// Exchange trades a code from the callback for a token.
+func (c *Config) Exchange(ctx context.Context, code string) (*Token, error) {
+	return c.retrieve(ctx, url.Values{
+		"grant_type": {"authorization_code"},
+		"code":       {code},
+		// The same redirect URI again, so that a code sent to another
+		// URI cannot be exchanged.
+		"redirect_uri": {c.RedirectURL},
+	})
+}
+
+// retrieve posts form to the token endpoint.
+func (c *Config) retrieve(ctx context.Context, form url.Values) (*Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+		Error        string `json:"error"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("token endpoint: %d: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || body.AccessToken == "" {
+		return nil, &TokenError{Status: resp.StatusCode, Code: body.Error}
+	}
+	tok := &Token{AccessToken: body.AccessToken, TokenType: body.TokenType, RefreshToken: body.RefreshToken}
+	if body.ExpiresIn > 0 {
+		// expires_in is in seconds, from when the response was sent.
+		tok.Expiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	return tok, nil
+}
+
+// NewState returns a random state parameter.
+func NewState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// StateCookie is the cookie the state waits in during a login.
+const StateCookie = "oauth_state"
+
+// Login is the handler that starts a login: it remembers a new state in
+// the browser, and sends the user to the provider.
+func (c *Config) Login(w http.ResponseWriter, r *http.Request) {
+	state, err := NewState()
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name: StateCookie, Value: state, Path: "/", MaxAge: 600,
+		// Lax, not Strict: the provider's redirect back is a top-level
+		// navigation from another site, and must bring the cookie.
+		HttpOnly: true, Secure: true, SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, c.AuthCodeURL(state), http.StatusFound)
+}
+
+// ErrState is returned by Callback when the state is not the one Login
+// gave this browser.
+var ErrState = errors.New("state does not match")
+
+// Callback checks the provider's redirect to the callback, and exchanges
+// its code for a token. The state cookie is deleted either way: a state
+// is good for one login.
+func (c *Config) Callback(w http.ResponseWriter, r *http.Request) (*Token, error) {
+	http.SetCookie(w, &http.Cookie{Name: StateCookie, Path: "/", MaxAge: -1, HttpOnly: true, Secure: true, SameSite: http.SameSiteLaxMode})
+	q := r.URL.Query()
+	// Without this check, an attacker can send the victim to the
+	// callback with the attacker's own code, and the victim ends up
+	// logged in to the attacker's account.
+	cookie, err := r.Cookie(StateCookie)
+	if err != nil || q.Get("state") == "" ||
+		subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(q.Get("state"))) != 1 {
+		return nil, ErrState
+	}
+	if e := q.Get("error"); e != "" {
+		return nil, fmt.Errorf("authorization failed: %s", e)
+	}
+	return c.Exchange(r.Context(), q.Get("code"))
+}
+
+// DemonstrateFlow logs in against the fake provider, following the
+// redirects by hand the way a browser would.
+func DemonstrateFlow() {
+	fmt.Println("=== Authorization-code flow ===")
+	p := provider.New("demo-app", "demo-secret")
+	defer p.Close()
+	c := &Config{
+		ClientID: "demo-app", ClientSecret: "demo-secret",
+		AuthURL: p.AuthURL(), TokenURL: p.TokenURL(),
+		RedirectURL: "https://app.example/callback", Scopes: []string{"profile"},
+	}
+
+	// 1. The app's login handler redirects to the provider.
+	rec := httptest.NewRecorder()
+	c.Login(rec, httptest.NewRequest(http.MethodGet, "/login", nil))
+	fmt.Println("login redirects to", strings.SplitN(rec.Header().Get("Location"), "?", 2)[0])
+	stateCookie := rec.Result().Cookies()[0]
+
+	// 2. The provider approves, and redirects back with a code.
+	noRedirects := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := noRedirects.Get(rec.Header().Get("Location"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	resp.Body.Close()
+	callback := resp.Header.Get("Location")
+	fmt.Println("provider redirects to", strings.SplitN(callback, "?", 2)[0])
+
+	// 3. The callback checks the state, and exchanges the code.
+	req := httptest.NewRequest(http.MethodGet, callback, nil)
+	req.AddCookie(stateCookie)
+	tok, err := c.Callback(httptest.NewRecorder(), req)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("token: %s, refresh token: %t, expires in %v\n",
+		tok.TokenType, tok.RefreshToken != "", time.Until(tok.Expiry).Round(time.Minute))
+
+	// The same callback from another browser, without the cookie.
+	_, err = c.Callback(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, callback, nil))
+	fmt.Println("without the state cookie:", err)
+	// The same code again.
+	_, err = c.Exchange(context.Background(), req.URL.Query().Get("code"))
+	fmt.Println("the code again:", err)
+}
@@ -0,0 +1,102 @@
+package examples
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/35-oauth2/examples/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateFlow(t *testing.T) {
+	DemonstrateFlow()
+}
+
+const redirectURL = "https://app.example/callback"
+
+func newConfig(p *provider.Provider) *Config {
+	return &Config{
+		ClientID: "app", ClientSecret: "s3cret",
+		AuthURL: p.AuthURL(), TokenURL: p.TokenURL(),
+		RedirectURL: redirectURL,
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	c := &Config{ClientID: "app", AuthURL: "https://id.example/authorize", RedirectURL: redirectURL, Scopes: []string{"profile", "email"}}
+	u, err := url.Parse(c.AuthCodeURL("xyz"))
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"response_type": {"code"}, "client_id": {"app"}, "redirect_uri": {redirectURL},
+		"state": {"xyz"}, "scope": {"profile email"},
+	}, u.Query())
+}
+
+func TestExchange(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	c := newConfig(p)
+
+	tok, err := c.Exchange(context.Background(), p.Code("ada", redirectURL))
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer", tok.TokenType)
+	assert.NotEmpty(t, tok.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), tok.Expiry, time.Minute)
+
+	_, err = c.Exchange(context.Background(), p.Code("ada", "https://evil.example/callback"))
+	var te *TokenError
+	require.ErrorAs(t, err, &te)
+	assert.Equal(t, "invalid_grant", te.Code)
+
+	c.ClientSecret = "wrong"
+	_, err = c.Exchange(context.Background(), p.Code("ada", redirectURL))
+	require.ErrorAs(t, err, &te)
+	assert.Equal(t, http.StatusUnauthorized, te.Status)
+}
+
+func TestCallbackChecksState(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	c := newConfig(p)
+
+	callback := func(state string, cookie *http.Cookie) (*httptest.ResponseRecorder, error) {
+		q := url.Values{"code": {p.Code("mallory", redirectURL)}, "state": {state}}
+		req := httptest.NewRequest(http.MethodGet, "/callback?"+q.Encode(), nil)
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		rec := httptest.NewRecorder()
+		_, err := c.Callback(rec, req)
+		return rec, err
+	}
+
+	_, err := callback("abc", nil)
+	assert.ErrorIs(t, err, ErrState)
+	_, err = callback("abc", &http.Cookie{Name: StateCookie, Value: "xyz"})
+	assert.ErrorIs(t, err, ErrState)
+	_, err = callback("", &http.Cookie{Name: StateCookie, Value: ""})
+	assert.ErrorIs(t, err, ErrState)
+
+	rec, err := callback("xyz", &http.Cookie{Name: StateCookie, Value: "xyz"})
+	require.NoError(t, err)
+	assert.Equal(t, -1, rec.Result().Cookies()[0].MaxAge, "the state cookie is deleted")
+}
+
+func TestLoginSetsState(t *testing.T) {
+	c := &Config{ClientID: "app", AuthURL: "https://id.example/authorize", RedirectURL: redirectURL}
+	rec := httptest.NewRecorder()
+	c.Login(rec, httptest.NewRequest(http.MethodGet, "/login", nil))
+	require.Equal(t, http.StatusFound, rec.Code)
+
+	cookie := rec.Result().Cookies()[0]
+	assert.Equal(t, StateCookie, cookie.Name)
+	assert.Len(t, cookie.Value, 43)
+	assert.True(t, cookie.HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+	loc, err := url.Parse(rec.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, cookie.Value, loc.Query().Get("state"))
+}
@@ -0,0 +1,170 @@
+package examples
+
+// This file shows:
+// - When a token counts as expired: a little before its Expiry, so that
+//   it does not expire on the way to the server
+// - Refreshing with the refresh_token grant, and keeping the old refresh
+//   token when the response has none
+// - A TokenSource that refreshes once for all the goroutines that need a
+//   token
+// - An http.RoundTripper that adds the Authorization header to a clone
+//   of each request
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/35-oauth2/examples/provider"
+)
+
+// ExpiryDelta is how long before its Expiry a token is treated as
+// expired.
+const ExpiryDelta = 10 * time.Second
+
+// Valid reports whether t can still be used. A token without an Expiry
+// does not expire.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(ExpiryDelta).Before(t.Expiry)
+}
+
+// Refresh trades t's refresh token for a new token.
+func (c *Config) Refresh(ctx context.Context, t *Token) (*Token, error) {
+	if t.RefreshToken == "" {
+		return nil, fmt.Errorf("token expired and has no refresh token")
+	}
+	n, err := c.retrieve(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {t.RefreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Providers that do not rotate refresh tokens leave refresh_token
+	// out. The old one is still good.
+	if n.RefreshToken == "" {
+		n.RefreshToken = t.RefreshToken
+	}
+	return n, nil
+}
+
+// Source hands out valid tokens.
+type Source interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenSource hands out a valid token, refreshing it when it expires. It
+// is safe for concurrent use.
+type TokenSource struct {
+	Config *Config
+
+	mu  sync.Mutex
+	tok *Token
+}
+
+// NewTokenSource returns a source that starts from t.
+func NewTokenSource(c *Config, t *Token) *TokenSource {
+	return &TokenSource{Config: c, tok: t}
+}
+
+// Token returns a valid token.
+func (s *TokenSource) Token(ctx context.Context) (*Token, error) {
+	// Holding the lock through the refresh makes the other goroutines
+	// wait for its token, instead of refreshing too. With rotating
+	// refresh tokens, only the first refresh would succeed.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tok.Valid() {
+		return s.tok, nil
+	}
+	t, err := s.Config.Refresh(ctx, s.tok)
+	if err != nil {
+		return nil, err
+	}
+	s.tok = t
+	return t, nil
+}
+
+// Transport adds a token from Source to every request.
+type Transport struct {
+	Source Source
+	// Base sends the requests; nil is http.DefaultTransport.
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tok, err := t.Source.Token(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	// A RoundTripper must not change the request it is given: the
+	// caller may reuse or log it.
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+tok.AccessToken)
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+// NewClient returns an HTTP client that authenticates with tokens from s.
+func NewClient(s Source) *http.Client {
+	return &http.Client{Transport: &Transport{Source: s}}
+}
+
+// DemonstrateRefresh calls an API with an access token, lets it expire,
+// and calls again.
+func DemonstrateRefresh() {
+	fmt.Println("=== Refreshing tokens ===")
+	p := provider.New("demo-app", "demo-secret")
+	defer p.Close()
+	p.RotateRefresh = true
+	c := &Config{ClientID: "demo-app", ClientSecret: "demo-secret", AuthURL: p.AuthURL(), TokenURL: p.TokenURL(), RedirectURL: "https://app.example/callback"}
+
+	tok, err := c.Exchange(context.Background(), p.Code("ada", c.RedirectURL))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	ts := NewTokenSource(c, tok)
+	client := NewClient(ts)
+	call := func() {
+		resp, err := client.Get(p.UserInfoURL())
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		fmt.Printf("%d %s", resp.StatusCode, body)
+	}
+
+	call()
+	// The provider expires the token early, which Valid cannot know.
+	p.Expire()
+	call()
+	// Once the token's own Expiry passes, the source refreshes it.
+	tok.Expiry = time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			call()
+		}()
+	}
+	wg.Wait()
+	fmt.Println("refreshes:", p.Refreshes())
+}
@@ -0,0 +1,79 @@
+package examples
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/35-oauth2/examples/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateRefresh(t *testing.T) {
+	DemonstrateRefresh()
+}
+
+func TestValid(t *testing.T) {
+	var nilToken *Token
+	assert.False(t, nilToken.Valid())
+	assert.False(t, (&Token{}).Valid())
+	assert.True(t, (&Token{AccessToken: "a"}).Valid(), "no expiry")
+	assert.True(t, (&Token{AccessToken: "a", Expiry: time.Now().Add(time.Minute)}).Valid())
+	assert.False(t, (&Token{AccessToken: "a", Expiry: time.Now().Add(5 * time.Second)}).Valid(), "about to expire")
+}
+
+func TestTokenSourceRefreshesOnce(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	p.RotateRefresh = true
+	c := newConfig(p)
+	tok, err := c.Exchange(context.Background(), p.Code("ada", redirectURL))
+	require.NoError(t, err)
+	tok.Expiry = time.Now()
+
+	ts := NewTokenSource(c, tok)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := ts.Token(context.Background())
+			assert.NoError(t, err)
+			assert.True(t, got.Valid())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, p.Refreshes())
+}
+
+func TestRefreshKeepsRefreshToken(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	c := newConfig(p)
+	tok, err := c.Exchange(context.Background(), p.Code("ada", redirectURL))
+	require.NoError(t, err)
+
+	n, err := c.Refresh(context.Background(), tok)
+	require.NoError(t, err)
+	assert.Equal(t, tok.RefreshToken, n.RefreshToken)
+	assert.NotEqual(t, tok.AccessToken, n.AccessToken)
+}
+
+func TestTransportDoesNotChangeRequest(t *testing.T) {
+	var got string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+	}))
+	defer api.Close()
+
+	ts := NewTokenSource(&Config{}, &Token{AccessToken: "abc"})
+	req, err := http.NewRequest(http.MethodGet, api.URL, nil)
+	require.NoError(t, err)
+	resp, err := NewClient(ts).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "Bearer abc", got)
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
@@ -0,0 +1,188 @@
+package examples
+
+// This file shows:
+// - Tokens are passwords: a file only the user can read, written atomically
+// - Encrypting tokens at rest with AES-GCM, with the user as additional
+//   data, so that one user's file does not decrypt as another's
+// - Saving the token again after every refresh: a rotated refresh token
+//   replaces the old one, which stops working
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/35-oauth2/examples/provider"
+)
+
+// ErrNoToken is returned by FileStore.Load for a user without a token.
+var ErrNoToken = errors.New("no token stored")
+
+// FileStore keeps a token per user in Dir, encrypted with Key.
+type FileStore struct {
+	Dir string
+	// Key is a 32-byte AES-256 key, from a secret the process is given,
+	// not from the same disk.
+	Key []byte
+}
+
+// path returns the file of user's token. The name is the user in hex, so
+// that no user name can climb out of Dir.
+func (f *FileStore) path(user string) string {
+	return filepath.Join(f.Dir, hex.EncodeToString([]byte(user))+".token")
+}
+
+func (f *FileStore) aead() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(f.Key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Save stores t as user's token.
+func (f *FileStore) Save(user string, t *Token) error {
+	plain, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	aead, err := f.aead()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	sealed := aead.Seal(nonce, nonce, plain, []byte(user))
+
+	if err := os.MkdirAll(f.Dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(f.Dir, ".token-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(sealed); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path(user))
+}
+
+// Load returns user's token, or ErrNoToken.
+func (f *FileStore) Load(user string) (*Token, error) {
+	sealed, err := os.ReadFile(f.path(user))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	aead, err := f.aead()
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("token of %q: too short", user)
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(user))
+	if err != nil {
+		return nil, fmt.Errorf("token of %q: %w", user, err)
+	}
+	var t Token
+	if err := json.Unmarshal(plain, &t); err != nil {
+		return nil, fmt.Errorf("token of %q: %w", user, err)
+	}
+	return &t, nil
+}
+
+// SavingSource saves every new token that Source hands out as User's.
+type SavingSource struct {
+	Source Source
+	Store  *FileStore
+	User   string
+
+	mu    sync.Mutex
+	saved string // the access token saved last
+}
+
+// Token implements Source.
+func (s *SavingSource) Token(ctx context.Context) (*Token, error) {
+	t, err := s.Source.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t.AccessToken != s.saved {
+		if err := s.Store.Save(s.User, t); err != nil {
+			return nil, fmt.Errorf("saving the refreshed token: %w", err)
+		}
+		s.saved = t.AccessToken
+	}
+	return t, nil
+}
+
+// DemonstrateStorage logs in, saves the token, and starts again from the
+// file, the way a program would after a restart.
+func DemonstrateStorage() {
+	fmt.Println("=== Storing tokens ===")
+	dir, err := os.MkdirTemp("", "tokens-")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	key := make([]byte, 32)
+	rand.Read(key)
+	store := &FileStore{Dir: dir, Key: key}
+
+	p := provider.New("demo-app", "demo-secret")
+	defer p.Close()
+	p.RotateRefresh = true
+	c := &Config{ClientID: "demo-app", ClientSecret: "demo-secret", AuthURL: p.AuthURL(), TokenURL: p.TokenURL(), RedirectURL: "https://app.example/callback"}
+
+	tok, err := c.Exchange(context.Background(), p.Code("ada", c.RedirectURL))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := store.Save("ada", tok); err != nil {
+		fmt.Println(err)
+		return
+	}
+	entries, _ := os.ReadDir(dir)
+	info, _ := entries[0].Info()
+	fmt.Printf("saved %s, mode %v\n", entries[0].Name(), info.Mode().Perm())
+
+	for run := 1; run <= 3; run++ {
+		// A new run: load the token, and expire it to force a refresh.
+		tok, err := store.Load("ada")
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		tok.Expiry = time.Now()
+		src := &SavingSource{Source: NewTokenSource(c, tok), Store: store, User: "ada"}
+		_, err = src.Token(context.Background())
+		fmt.Printf("run %d: refresh: %v\n", run, err)
+	}
+
+	other := &FileStore{Dir: dir, Key: make([]byte, 32)}
+	_, err = other.Load("ada")
+	fmt.Println("with another key:", err)
+}
@@ -0,0 +1,78 @@
+package examples
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/35-oauth2/examples/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateStorage(t *testing.T) {
+	DemonstrateStorage()
+}
+
+func newStore(t *testing.T) *FileStore {
+	return &FileStore{Dir: filepath.Join(t.TempDir(), "tokens"), Key: []byte("0123456789abcdef0123456789abcdef")}
+}
+
+func TestFileStore(t *testing.T) {
+	store := newStore(t)
+	_, err := store.Load("ada")
+	assert.ErrorIs(t, err, ErrNoToken)
+
+	want := &Token{AccessToken: "a", TokenType: "Bearer", RefreshToken: "r", Expiry: time.Now().Round(0)}
+	require.NoError(t, store.Save("ada", want))
+	got, err := store.Load("ada")
+	require.NoError(t, err)
+	assert.Equal(t, want.AccessToken, got.AccessToken)
+	assert.Equal(t, want.RefreshToken, got.RefreshToken)
+	assert.True(t, want.Expiry.Equal(got.Expiry))
+
+	entries, err := os.ReadDir(store.Dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	info, err := entries[0].Info()
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	data, err := os.ReadFile(filepath.Join(store.Dir, entries[0].Name()))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"r"`, "the token is encrypted")
+}
+
+func TestFileStoreTiesTokenToUser(t *testing.T) {
+	store := newStore(t)
+	require.NoError(t, store.Save("ada", &Token{AccessToken: "a"}))
+	data, err := os.ReadFile(store.path("ada"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(store.path("mallory"), data, 0o600))
+	_, err = store.Load("mallory")
+	assert.Error(t, err, "ada's token copied to mallory's file")
+
+	_, err = store.Load("../../etc/passwd")
+	assert.ErrorIs(t, err, ErrNoToken)
+}
+
+func TestSavingSourceKeepsRotatedToken(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	p.RotateRefresh = true
+	c := newConfig(p)
+	store := newStore(t)
+	tok, err := c.Exchange(context.Background(), p.Code("ada", redirectURL))
+	require.NoError(t, err)
+	require.NoError(t, store.Save("ada", tok))
+
+	for run := 0; run < 3; run++ {
+		tok, err := store.Load("ada")
+		require.NoError(t, err)
+		tok.Expiry = time.Now()
+		src := &SavingSource{Source: NewTokenSource(c, tok), Store: store, User: "ada"}
+		_, err = src.Token(context.Background())
+		require.NoError(t, err, "run %d", run)
+	}
+	assert.Equal(t, 3, p.Refreshes())
+}
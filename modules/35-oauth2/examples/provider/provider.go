@@ -0,0 +1,275 @@
+// Package provider is a fake OAuth 2.0 authorization server, for the
+// examples and exercises of module 35 to log in against without leaving
+// the test process. It implements the authorization-code grant and
+// refresh tokens of RFC 6749 for one client, and a user info endpoint
+// that takes the access tokens it issues:
+//
+//	p := provider.Start(t, "app", "s3cret")
+//	// send the user to p.AuthURL(); they come back to the redirect URI
+//	// with a code, which the app posts to p.TokenURL()
+//
+// There is no login page: every authorization request is approved as
+// User, or denied when Deny is set.
+package provider
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Provider is an authorization server listening on loopback. Set its
+// fields before the first request.
+type Provider struct {
+	// URL is the server's base URL, such as http://127.0.0.1:1234.
+	URL string
+
+	ClientID     string
+	ClientSecret string
+	// User is who approves every authorization request.
+	User string
+	// Deny makes every authorization request come back with
+	// error=access_denied, as if the user had said no.
+	Deny bool
+	// TokenTTL is how long an access token lasts.
+	TokenTTL time.Duration
+	// RotateRefresh makes every refresh return a new refresh token and
+	// revoke the old one. Without it, refresh responses have no
+	// refresh_token, and the old one stays valid.
+	RotateRefresh bool
+
+	srv *httptest.Server
+
+	mu        sync.Mutex
+	codes     map[string]grant
+	access    map[string]grant
+	refresh   map[string]grant
+	refreshes int
+}
+
+// grant is what a code or a token stands for.
+type grant struct {
+	user        string
+	scope       string
+	redirectURI string
+	expires     time.Time
+}
+
+// New starts a provider for the client clientID, which authenticates with
+// clientSecret. Its users are all called "ada", and its access tokens
+// last an hour.
+func New(clientID, clientSecret string) *Provider {
+	p := &Provider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		User:         "ada",
+		TokenTTL:     time.Hour,
+		codes:        map[string]grant{},
+		access:       map[string]grant{},
+		refresh:      map[string]grant{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	mux.HandleFunc("/userinfo", p.userinfo)
+	p.srv = httptest.NewServer(mux)
+	p.URL = p.srv.URL
+	return p
+}
+
+// Start starts a provider for a test, and closes it when the test ends.
+func Start(t testing.TB, clientID, clientSecret string) *Provider {
+	t.Helper()
+	p := New(clientID, clientSecret)
+	t.Cleanup(p.Close)
+	return p
+}
+
+// Close stops the server.
+func (p *Provider) Close() { p.srv.Close() }
+
+// AuthURL is the authorization endpoint, where apps send users.
+func (p *Provider) AuthURL() string { return p.URL + "/authorize" }
+
+// TokenURL is the token endpoint, where apps exchange codes and refresh
+// tokens.
+func (p *Provider) TokenURL() string { return p.URL + "/token" }
+
+// UserInfoURL is an API that answers {"sub": user} for a valid access
+// token.
+func (p *Provider) UserInfoURL() string { return p.URL + "/userinfo" }
+
+// Code returns a code for user, as if they had approved a request with
+// redirectURI. Tests use it to play an attacker with an account of their
+// own.
+func (p *Provider) Code(user, redirectURI string) string {
+	code := random()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.codes[code] = grant{user: user, redirectURI: redirectURI, expires: time.Now().Add(time.Minute)}
+	return code
+}
+
+// Expire makes every access token issued so far expire, as if TokenTTL
+// had passed.
+func (p *Provider) Expire() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for at, g := range p.access {
+		g.expires = time.Now().Add(-time.Second)
+		p.access[at] = g
+	}
+}
+
+// Refreshes returns how many refresh requests succeeded.
+func (p *Provider) Refreshes() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.refreshes
+}
+
+func random() string {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// authorize approves a request at once, and redirects back with a code.
+func (p *Provider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || !redirect.IsAbs() {
+		// Never redirect to a URL that is not the client's.
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	if q.Get("client_id") != p.ClientID {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+	back := redirect.Query()
+	if s := q.Get("state"); s != "" {
+		back.Set("state", s)
+	}
+	switch {
+	case q.Get("response_type") != "code":
+		back.Set("error", "unsupported_response_type")
+	case p.Deny:
+		back.Set("error", "access_denied")
+	default:
+		code := p.Code(p.User, redirect.String())
+		p.mu.Lock()
+		g := p.codes[code]
+		g.scope = q.Get("scope")
+		p.codes[code] = g
+		p.mu.Unlock()
+		back.Set("code", code)
+	}
+	redirect.RawQuery = back.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// tokenError writes an error response of RFC 6749, section 5.2.
+func tokenError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
+
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// token exchanges a code or a refresh token for tokens.
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		tokenError(w, http.StatusMethodNotAllowed, "invalid_request")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+	id, secret, ok := r.BasicAuth()
+	if !ok {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if !equal(id, p.ClientID) || !equal(secret, p.ClientSecret) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="token"`)
+		tokenError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	resp := map[string]any{"token_type": "Bearer", "expires_in": int(p.TokenTTL / time.Second)}
+	var g grant
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		code := r.PostForm.Get("code")
+		g, ok = p.codes[code]
+		// A code is good once, for a minute, with the redirect URI it
+		// was issued for.
+		delete(p.codes, code)
+		if !ok || time.Now().After(g.expires) || g.redirectURI != r.PostForm.Get("redirect_uri") {
+			tokenError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+		rt := random()
+		p.refresh[rt] = g
+		resp["refresh_token"] = rt
+	case "refresh_token":
+		rt := r.PostForm.Get("refresh_token")
+		g, ok = p.refresh[rt]
+		if !ok {
+			tokenError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+		if p.RotateRefresh {
+			delete(p.refresh, rt)
+			rt = random()
+			p.refresh[rt] = g
+			resp["refresh_token"] = rt
+		}
+		p.refreshes++
+	default:
+		tokenError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+	at := random()
+	g.expires = time.Now().Add(p.TokenTTL)
+	p.access[at] = g
+	resp["access_token"] = at
+	if g.scope != "" {
+		resp["scope"] = g.scope
+	}
+	w.Header().Set("Content-Type", "application/json")
+	// Tokens must not end up in a cache.
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// userinfo answers who an access token belongs to.
+func (p *Provider) userinfo(w http.ResponseWriter, r *http.Request) {
+	at, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	p.mu.Lock()
+	g, found := p.access[at]
+	p.mu.Unlock()
+	if !ok || !found || time.Now().After(g.expires) {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"sub": g.user})
+}
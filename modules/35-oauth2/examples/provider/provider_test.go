@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURI = "http://app.test/callback"
+
+// noRedirects is a client that returns redirects instead of following
+// them.
+var noRedirects = &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}}
+
+func authorize(t *testing.T, p *Provider, state string) url.Values {
+	t.Helper()
+	q := url.Values{"response_type": {"code"}, "client_id": {"app"}, "redirect_uri": {redirectURI}, "state": {state}}
+	resp, err := noRedirects.Get(p.AuthURL() + "?" + q.Encode())
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, redirectURI, "http://"+loc.Host+loc.Path)
+	return loc.Query()
+}
+
+func postToken(t *testing.T, p *Provider, form url.Values, secret string) (int, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, p.TokenURL(), strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth("app", secret)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func userinfo(t *testing.T, p *Provider, token string) int {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, p.UserInfoURL(), nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	p := Start(t, "app", "s3cret")
+	back := authorize(t, p, "xyz")
+	assert.Equal(t, "xyz", back.Get("state"))
+	code := back.Get("code")
+	require.NotEmpty(t, code)
+
+	form := url.Values{"grant_type": {"authorization_code"}, "code": {code}, "redirect_uri": {redirectURI}}
+	status, body := postToken(t, p, form, "wrong")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "invalid_client", body["error"])
+
+	status, body = postToken(t, p, form, "s3cret")
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Equal(t, "Bearer", body["token_type"])
+	assert.Equal(t, float64(3600), body["expires_in"])
+	assert.NotEmpty(t, body["refresh_token"])
+	assert.Equal(t, http.StatusOK, userinfo(t, p, body["access_token"].(string)))
+
+	status, body = postToken(t, p, form, "s3cret")
+	assert.Equal(t, http.StatusBadRequest, status, "a code works once")
+	assert.Equal(t, "invalid_grant", body["error"])
+}
+
+func TestDeny(t *testing.T) {
+	p := Start(t, "app", "s3cret")
+	p.Deny = true
+	back := authorize(t, p, "xyz")
+	assert.Equal(t, "access_denied", back.Get("error"))
+	assert.Equal(t, "xyz", back.Get("state"))
+	assert.Empty(t, back.Get("code"))
+}
+
+func TestRefresh(t *testing.T) {
+	for _, rotate := range []bool{false, true} {
+		p := Start(t, "app", "s3cret")
+		p.RotateRefresh = rotate
+		form := url.Values{"grant_type": {"authorization_code"}, "code": {p.Code("ada", redirectURI)}, "redirect_uri": {redirectURI}}
+		_, body := postToken(t, p, form, "s3cret")
+		first := body["access_token"].(string)
+		rt := body["refresh_token"].(string)
+
+		p.Expire()
+		assert.Equal(t, http.StatusUnauthorized, userinfo(t, p, first))
+
+		refresh := url.Values{"grant_type": {"refresh_token"}, "refresh_token": {rt}}
+		status, body := postToken(t, p, refresh, "s3cret")
+		require.Equal(t, http.StatusOK, status, body)
+		assert.Equal(t, http.StatusOK, userinfo(t, p, body["access_token"].(string)))
+		assert.Equal(t, 1, p.Refreshes())
+
+		status, _ = postToken(t, p, refresh, "s3cret")
+		if rotate {
+			assert.NotEqual(t, rt, body["refresh_token"])
+			assert.Equal(t, http.StatusBadRequest, status, "a rotated refresh token is revoked")
+		} else {
+			assert.Nil(t, body["refresh_token"])
+			assert.Equal(t, http.StatusOK, status)
+		}
+	}
+}
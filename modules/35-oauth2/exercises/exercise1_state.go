@@ -0,0 +1,116 @@
+package exercises
+
+// EXERCISE: Fix an OAuth2 login whose callback accepts any state.
+// Start sends the user to the provider with a state, and Finish checks
+// it when they come back, so that only the browser that started a login
+// can finish it. But Finish lets through a callback with no state cookie
+// at all, states are easy to guess and can be used twice, the cookie
+// does not survive the trip to the provider, and a denied login is
+// exchanged anyway.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// loginCookie is the cookie the state waits in during a login.
+const loginCookie = "login_state"
+
+var (
+	// ErrState is returned by Finish when the state is not the one Start
+	// gave this browser.
+	ErrState = errors.New("state does not match")
+	// ErrAuthorization is returned by Finish when the provider sends the
+	// user back with an error, such as access_denied.
+	ErrAuthorization = errors.New("authorization failed")
+)
+
+// Login logs users in with a provider's authorization-code flow.
+type Login struct {
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	RedirectURL  string
+}
+
+// NewState returns a state parameter that nobody can guess.
+func NewState() (string, error) {
+	// BUG: Anyone who knows roughly when a login started can guess this.
+	return strconv.FormatInt(time.Now().UnixNano(), 36), nil
+}
+
+// Start remembers a new state in the browser, and redirects to the
+// provider.
+func (l *Login) Start(w http.ResponseWriter, r *http.Request) {
+	state, err := NewState()
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name: loginCookie, Value: state, Path: "/", MaxAge: 600, HttpOnly: true, Secure: true,
+		// BUG: Browsers do not send Strict cookies on the redirect back
+		// from the provider, which is a navigation from another site.
+		SameSite: http.SameSiteStrictMode,
+	})
+	q := url.Values{
+		"response_type": {"code"},
+		"client_id":     {l.ClientID},
+		"redirect_uri":  {l.RedirectURL},
+		"state":         {state},
+	}
+	http.Redirect(w, r, l.AuthURL+"?"+q.Encode(), http.StatusFound)
+}
+
+// Finish handles the redirect back to RedirectURL, and returns the access
+// token the code is exchanged for.
+func (l *Login) Finish(w http.ResponseWriter, r *http.Request) (string, error) {
+	// BUG: The state cookie is never deleted, so its state can be used
+	// for another login.
+	q := r.URL.Query()
+	// BUG: A request without the cookie skips the check.
+	if c, err := r.Cookie(loginCookie); err == nil &&
+		subtle.ConstantTimeCompare([]byte(c.Value), []byte(q.Get("state"))) != 1 {
+		return "", ErrState
+	}
+	// BUG: When the user says no, the provider sends error=access_denied
+	// and no code, and the empty code is exchanged anyway.
+	return l.exchange(r.Context(), q.Get("code"))
+}
+
+// exchange trades code for an access token.
+func (l *Login) exchange(ctx context.Context, code string) (string, error) {
+	form := url.Values{"grant_type": {"authorization_code"}, "code": {code}, "redirect_uri": {l.RedirectURL}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(l.ClientID), url.QueryEscape(l.ClientSecret))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var body struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint: %d %s", resp.StatusCode, body.Error)
+	}
+	return body.AccessToken, nil
+}
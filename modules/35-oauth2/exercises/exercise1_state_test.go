@@ -0,0 +1,121 @@
+package exercises
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/35-oauth2/examples/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const callbackURL = "https://app.example/callback"
+
+func newLogin(p *provider.Provider) *Login {
+	return &Login{ClientID: "app", ClientSecret: "s3cret", AuthURL: p.AuthURL(), TokenURL: p.TokenURL(), RedirectURL: callbackURL}
+}
+
+// start runs Start, and returns the state cookie and the provider's
+// redirect back to the callback.
+func start(t *testing.T, l *Login) (*http.Cookie, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	l.Start(rec, httptest.NewRequest(http.MethodGet, "/login", nil))
+	require.Equal(t, http.StatusFound, rec.Code)
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+
+	noRedirects := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := noRedirects.Get(rec.Header().Get("Location"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	return cookies[0], resp.Header.Get("Location")
+}
+
+func finish(l *Login, callback string, cookie *http.Cookie) (*httptest.ResponseRecorder, string, error) {
+	req := httptest.NewRequest(http.MethodGet, callback, nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	tok, err := l.Finish(rec, req)
+	return rec, tok, err
+}
+
+func TestLogin(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	l := newLogin(p)
+	cookie, callback := start(t, l)
+	_, tok, err := finish(l, callback, cookie)
+	require.NoError(t, err)
+	assert.NotEmpty(t, tok)
+}
+
+func TestStateCookie(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	cookie, _ := start(t, newLogin(p))
+	assert.True(t, cookie.HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite,
+		"a Strict cookie is not sent on the redirect back from the provider")
+}
+
+func TestNewStateIsRandom(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		s, err := NewState()
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(s), 43, "state %q is short enough to guess", s)
+		require.False(t, seen[s], "state %q repeated", s)
+		seen[s] = true
+	}
+}
+
+func TestFinishRejectsForgedCallback(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	l := newLogin(p)
+	// Mallory gets a code for their own account, and sends the victim,
+	// who has no login in progress, to the callback with it.
+	code := p.Code("mallory", callbackURL)
+	_, _, err := finish(l, callbackURL+"?code="+code+"&state=x", nil)
+	assert.ErrorIs(t, err, ErrState, "callback without a state cookie")
+
+	// The victim has a login in progress, but the state is Mallory's.
+	cookie, _ := start(t, l)
+	_, _, err = finish(l, callbackURL+"?code="+p.Code("mallory", callbackURL)+"&state=x", cookie)
+	assert.ErrorIs(t, err, ErrState, "callback with another state")
+
+	_, _, err = finish(l, callbackURL+"?code="+p.Code("mallory", callbackURL), &http.Cookie{Name: cookie.Name, Value: ""})
+	assert.ErrorIs(t, err, ErrState, "empty state and cookie")
+}
+
+func TestFinishDeletesState(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	l := newLogin(p)
+	cookie, callback := start(t, l)
+	rec, _, err := finish(l, callback, cookie)
+	require.NoError(t, err)
+	cleared := rec.Result().Cookies()
+	require.Len(t, cleared, 1, "the state cookie is not deleted")
+	assert.Equal(t, cookie.Name, cleared[0].Name)
+	assert.Less(t, cleared[0].MaxAge, 0)
+
+	rec, _, err = finish(l, callbackURL+"?state=x", &http.Cookie{Name: cookie.Name, Value: "y"})
+	assert.ErrorIs(t, err, ErrState)
+	assert.Len(t, rec.Result().Cookies(), 1, "a failed callback leaves the state cookie")
+}
+
+func TestFinishReportsDenial(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	p.Deny = true
+	l := newLogin(p)
+	cookie, callback := start(t, l)
+	u, err := url.Parse(callback)
+	require.NoError(t, err)
+	require.Equal(t, "access_denied", u.Query().Get("error"))
+
+	_, _, err = finish(l, callback, cookie)
+	assert.ErrorIs(t, err, ErrAuthorization)
+	assert.ErrorContains(t, err, "access_denied")
+}
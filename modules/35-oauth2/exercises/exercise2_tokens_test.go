@@ -0,0 +1,146 @@
+package exercises
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/35-oauth2/examples/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memStore is a TokenStore that remembers the tokens it is given.
+type memStore struct {
+	mu     sync.Mutex
+	tokens map[string]Token
+}
+
+func (s *memStore) Save(user string, t Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tokens == nil {
+		s.tokens = map[string]Token{}
+	}
+	s.tokens[user] = t
+	return nil
+}
+
+func (s *memStore) get(user string) Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens[user]
+}
+
+// clock is a time that tests move forward.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// expire moves c past the expiry of every token p has issued, on both
+// sides.
+func (c *clock) expire(p *provider.Provider) {
+	c.mu.Lock()
+	c.now = c.now.Add(2 * time.Hour)
+	c.mu.Unlock()
+	p.Expire()
+}
+
+// linked returns an account of ada's, linked with a code from p.
+func linked(t *testing.T, p *provider.Provider) (*Account, *memStore, *clock) {
+	t.Helper()
+	store := &memStore{}
+	c := &clock{now: time.Now()}
+	a := &Account{ClientID: "app", ClientSecret: "s3cret", TokenURL: p.TokenURL(), User: "ada", Store: store, Now: c.Now}
+	require.NoError(t, a.Link(context.Background(), p.Code("ada", callbackURL), callbackURL))
+	return a, store, c
+}
+
+func TestLinkSavesToken(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	_, store, _ := linked(t, p)
+	tok := store.get("ada")
+	assert.NotEmpty(t, tok.Access)
+	assert.NotEmpty(t, tok.Refresh)
+}
+
+func TestExpiry(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	a, store, _ := linked(t, p)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), store.get("ada").Expiry, time.Minute,
+		"expires_in is in seconds")
+	_, err := a.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, p.Refreshes(), "a fresh token is refreshed")
+}
+
+func TestRefreshWithoutNewRefreshToken(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	a, _, clock := linked(t, p)
+	for i := 0; i < 3; i++ {
+		clock.expire(p)
+		_, err := a.Token(context.Background())
+		require.NoError(t, err, "refresh %d", i+1)
+	}
+	assert.Equal(t, 3, p.Refreshes())
+}
+
+func TestRotatedTokenIsSaved(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	p.RotateRefresh = true
+	a, store, clock := linked(t, p)
+	first := store.get("ada")
+	clock.expire(p)
+	access, err := a.Token(context.Background())
+	require.NoError(t, err)
+	saved := store.get("ada")
+	assert.Equal(t, access, saved.Access, "the refreshed token is not saved")
+	assert.NotEqual(t, first.Refresh, saved.Refresh, "the saved refresh token is revoked")
+}
+
+func TestConcurrentRefresh(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	p.RotateRefresh = true
+	a, _, clock := linked(t, p)
+	clock.expire(p)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = a.Token(context.Background())
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, p.Refreshes(), "one expired token, one refresh")
+}
+
+func TestClient(t *testing.T) {
+	p := provider.Start(t, "app", "s3cret")
+	a, _, _ := linked(t, p)
+	req, err := http.NewRequest(http.MethodGet, p.UserInfoURL(), nil)
+	require.NoError(t, err)
+	resp, err := a.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var info struct{ Sub string }
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&info))
+	assert.Equal(t, "ada", info.Sub)
+	assert.Empty(t, req.Header.Get("Authorization"), "the transport changed the caller's request")
+}
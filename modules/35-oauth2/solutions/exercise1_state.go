@@ -0,0 +1,121 @@
+package solutions
+
+// SOLUTION: Fix an OAuth2 login whose callback accepts any state.
+// The state comes from crypto/rand, and waits in a Lax cookie that the
+// callback always deletes. The callback rejects a missing cookie or an
+// empty state, and reports the provider's error instead of exchanging a
+// code that is not there.
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// loginCookie is the cookie the state waits in during a login.
+const loginCookie = "login_state"
+
+var (
+	// ErrState is returned by Finish when the state is not the one Start
+	// gave this browser.
+	ErrState = errors.New("state does not match")
+	// ErrAuthorization is returned by Finish when the provider sends the
+	// user back with an error, such as access_denied.
+	ErrAuthorization = errors.New("authorization failed")
+)
+
+// Login logs users in with a provider's authorization-code flow.
+type Login struct {
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	RedirectURL  string
+}
+
+// NewState returns a state parameter that nobody can guess.
+func NewState() (string, error) {
+	b := make([]byte, 32)
+	// Fixed: crypto/rand. A state from the clock can be guessed, and a
+	// guessed state lets an attacker forge the callback.
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Start remembers a new state in the browser, and redirects to the
+// provider.
+func (l *Login) Start(w http.ResponseWriter, r *http.Request) {
+	state, err := NewState()
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name: loginCookie, Value: state, Path: "/", MaxAge: 600, HttpOnly: true, Secure: true,
+		// Fixed: Lax. The redirect back from the provider comes from
+		// another site, and a Strict cookie is left behind.
+		SameSite: http.SameSiteLaxMode,
+	})
+	q := url.Values{
+		"response_type": {"code"},
+		"client_id":     {l.ClientID},
+		"redirect_uri":  {l.RedirectURL},
+		"state":         {state},
+	}
+	http.Redirect(w, r, l.AuthURL+"?"+q.Encode(), http.StatusFound)
+}
+
+// Finish handles the redirect back to RedirectURL, and returns the access
+// token the code is exchanged for.
+func (l *Login) Finish(w http.ResponseWriter, r *http.Request) (string, error) {
+	// Fixed: Always delete the cookie, so that a state is used once.
+	http.SetCookie(w, &http.Cookie{Name: loginCookie, Path: "/", MaxAge: -1, HttpOnly: true, Secure: true, SameSite: http.SameSiteLaxMode})
+	q := r.URL.Query()
+	// Fixed: No cookie, or no state, is not a match.
+	c, err := r.Cookie(loginCookie)
+	if err != nil || q.Get("state") == "" ||
+		subtle.ConstantTimeCompare([]byte(c.Value), []byte(q.Get("state"))) != 1 {
+		return "", ErrState
+	}
+	// Fixed: A denied request has an error and no code.
+	if e := q.Get("error"); e != "" {
+		return "", fmt.Errorf("%w: %s", ErrAuthorization, e)
+	}
+	return l.exchange(r.Context(), q.Get("code"))
+}
+
+// exchange trades code for an access token.
+func (l *Login) exchange(ctx context.Context, code string) (string, error) {
+	form := url.Values{"grant_type": {"authorization_code"}, "code": {code}, "redirect_uri": {l.RedirectURL}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(l.ClientID), url.QueryEscape(l.ClientSecret))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var body struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint: %d %s", resp.StatusCode, body.Error)
+	}
+	return body.AccessToken, nil
+}
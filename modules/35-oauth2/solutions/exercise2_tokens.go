@@ -0,0 +1,148 @@
+package solutions
+
+// SOLUTION: Fix the token handling of an app that calls an API for its users.
+// expires_in is read as seconds, a refresh that returns no refresh token
+// keeps the old one, every new token is saved, refreshes happen one at a
+// time under a mutex, and the transport sets the Authorization header on a
+// clone of the request.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Token is a user's access and refresh token.
+type Token struct {
+	Access  string    `json:"access"`
+	Refresh string    `json:"refresh"`
+	Expiry  time.Time `json:"expiry"`
+}
+
+// valid reports whether t can be used for a while yet at now.
+func (t Token) valid(now time.Time) bool {
+	return t.Access != "" && now.Add(10*time.Second).Before(t.Expiry)
+}
+
+// TokenStore keeps tokens between runs.
+type TokenStore interface {
+	Save(user string, t Token) error
+}
+
+// Account is a user's account at a provider, linked to the app.
+type Account struct {
+	ClientID     string
+	ClientSecret string
+	TokenURL     string
+	User         string
+	Store        TokenStore
+	// Now returns the current time. Nil means time.Now.
+	Now func() time.Time
+
+	mu  sync.Mutex // guards tok
+	tok Token
+}
+
+func (a *Account) now() time.Time {
+	if a.Now == nil {
+		return time.Now()
+	}
+	return a.Now()
+}
+
+// Link exchanges a code from the authorization callback for the first
+// token, and saves it.
+func (a *Account) Link(ctx context.Context, code, redirectURI string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.fetch(ctx, url.Values{"grant_type": {"authorization_code"}, "code": {code}, "redirect_uri": {redirectURI}})
+}
+
+// Token returns a valid access token, refreshing it first if it has
+// expired.
+func (a *Account) Token(ctx context.Context) (string, error) {
+	// Fixed: One refresh at a time. With rotation, a second refresh with
+	// the same refresh token fails; the others wait, and find the new
+	// token valid.
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.tok.valid(a.now()) {
+		return a.tok.Access, nil
+	}
+	if err := a.fetch(ctx, url.Values{"grant_type": {"refresh_token"}, "refresh_token": {a.tok.Refresh}}); err != nil {
+		return "", err
+	}
+	return a.tok.Access, nil
+}
+
+// fetch posts form to the token endpoint, and saves the token it returns.
+// a.mu must be held.
+func (a *Account) fetch(ctx context.Context, form url.Values) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(a.ClientID), url.QueryEscape(a.ClientSecret))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+		Error        string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token endpoint: %d %s", resp.StatusCode, body.Error)
+	}
+	tok := Token{
+		Access: body.AccessToken,
+		// Fixed: expires_in is in seconds; a Duration is in nanoseconds.
+		Expiry: a.now().Add(time.Duration(body.ExpiresIn) * time.Second),
+		// Fixed: No refresh_token in the response means the old one
+		// still works.
+		Refresh: a.tok.Refresh,
+	}
+	if body.RefreshToken != "" {
+		tok.Refresh = body.RefreshToken
+	}
+	a.tok = tok
+	// Fixed: Save every new token, not only the first. After a rotation,
+	// the saved refresh token is revoked.
+	return a.Store.Save(a.User, tok)
+}
+
+// Client returns an HTTP client that calls APIs as the account's user.
+func (a *Account) Client() *http.Client {
+	return &http.Client{Transport: &bearer{account: a}}
+}
+
+// bearer adds the account's access token to every request.
+type bearer struct {
+	account *Account
+}
+
+func (b *bearer) RoundTrip(req *http.Request) (*http.Response, error) {
+	tok, err := b.account.Token(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	// Fixed: A RoundTripper must not change the request it is given.
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+tok)
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,146 @@
+// Package examples demonstrates rate limiting in Go: a token bucket with
+// an injectable clock, a sliding window counter, a limiter per client
+// that forgets idle clients, and HTTP middleware that answers 429.
+//
+// golang.org/x/time/rate is the token bucket real programs use; Bucket
+// here is the same idea in fifty lines, so that you can see the arithmetic.
+//
+// This file shows:
+// - A token bucket: Burst tokens at most, refilled at Rate per second, one token per request
+// - Refilling lazily, from the time since the last call, instead of with a ticker
+// - Injecting the clock, so that tests step through time instead of sleeping
+// - How long until the next token, for a Retry-After header
+package examples
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Bucket is a token bucket. It is safe for concurrent use.
+type Bucket struct {
+	// Rate is how many tokens are added per second.
+	Rate float64
+	// Burst is how many tokens the bucket holds, and so the most
+	// requests it allows at once.
+	Burst int
+	// Now returns the current time. Tests set it; nil is time.Now.
+	Now func() time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time // when tokens was last brought up to date
+}
+
+// NewBucket returns a full bucket.
+func NewBucket(rate float64, burst int) *Bucket {
+	return &Bucket{Rate: rate, Burst: burst}
+}
+
+func (b *Bucket) now() time.Time {
+	if b.Now != nil {
+		return b.Now()
+	}
+	return time.Now()
+}
+
+// refill adds the tokens earned since the last call, up to Burst.
+// b.mu must be held.
+func (b *Bucket) refill(now time.Time) {
+	if b.last.IsZero() {
+		// A new bucket starts full.
+		b.tokens = float64(b.Burst)
+	} else if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.Rate
+		if b.tokens > float64(b.Burst) {
+			b.tokens = float64(b.Burst)
+		}
+	}
+	b.last = now
+}
+
+// Allow reports whether a request may go ahead now, and takes a token
+// if it may.
+func (b *Bucket) Allow() bool {
+	return b.AllowN(1)
+}
+
+// AllowN reports whether n tokens are available now, and takes them if
+// they are. It takes none otherwise.
+func (b *Bucket) AllowN(n int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(b.now())
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+// Delay returns how long until the next token, or 0 if there is one now.
+func (b *Bucket) Delay() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(b.now())
+	if b.tokens >= 1 || b.Rate <= 0 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.Rate * float64(time.Second))
+}
+
+// FakeClock is a clock for tests, which only moves when told to.
+type FakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+// NewFakeClock returns a clock stopped at t.
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{t: t}
+}
+
+// Now returns the clock's time. Pass c.Now as a Now field.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+// DemonstrateBucket spends a bucket's burst, and watches it refill on a
+// fake clock.
+func DemonstrateBucket() {
+	fmt.Println("=== Token bucket ===")
+	clock := NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	b := NewBucket(2, 5) // 2 per second, 5 at once
+	b.Now = clock.Now
+
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		if b.Allow() {
+			allowed++
+		}
+	}
+	fmt.Printf("10 requests at once: %d allowed\n", allowed)
+	fmt.Println("next token in", b.Delay())
+
+	clock.Advance(1500 * time.Millisecond)
+	allowed = 0
+	for i := 0; i < 10; i++ {
+		if b.Allow() {
+			allowed++
+		}
+	}
+	fmt.Printf("after 1.5s: %d allowed\n", allowed)
+
+	clock.Advance(time.Hour)
+	fmt.Println("after an hour, 6 at once:", b.AllowN(6), "5 at once:", b.AllowN(5))
+}
@@ -0,0 +1,63 @@
+package examples
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateBucket(t *testing.T) {
+	DemonstrateBucket()
+}
+
+func newTestBucket(rate float64, burst int) (*Bucket, *FakeClock) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	b := NewBucket(rate, burst)
+	b.Now = clock.Now
+	return b, clock
+}
+
+func TestBucketRefills(t *testing.T) {
+	b, clock := newTestBucket(4, 1)
+	assert.True(t, b.Allow())
+	assert.False(t, b.Allow())
+	assert.Equal(t, 250*time.Millisecond, b.Delay())
+
+	// Half tokens add up across calls.
+	clock.Advance(125 * time.Millisecond)
+	assert.False(t, b.Allow())
+	assert.Equal(t, 125*time.Millisecond, b.Delay())
+	clock.Advance(125 * time.Millisecond)
+	assert.Equal(t, time.Duration(0), b.Delay())
+	assert.True(t, b.Allow())
+}
+
+func TestBucketCapsAtBurst(t *testing.T) {
+	b, clock := newTestBucket(1, 3)
+	clock.Advance(time.Hour)
+	assert.False(t, b.AllowN(4))
+	assert.True(t, b.AllowN(3))
+	assert.False(t, b.Allow())
+}
+
+func TestBucketConcurrent(t *testing.T) {
+	b, _ := newTestBucket(1, 50)
+	var mu sync.Mutex
+	allowed := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if b.Allow() {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 50, allowed)
+}
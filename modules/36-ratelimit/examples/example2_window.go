@@ -0,0 +1,120 @@
+package examples
+
+// This file shows:
+// - A fixed window counter, and the burst of twice the limit it allows
+//   around a window boundary
+// - A sliding window counter, which weighs the previous window by how
+//   much of it is still inside the last Size
+// - The same clock injection as Bucket
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FixedWindow allows Limit requests in each window of Size, counted from
+// the zero time. It is safe for concurrent use.
+type FixedWindow struct {
+	Limit int
+	Size  time.Duration
+	// Now returns the current time. Tests set it; nil is time.Now.
+	Now func() time.Time
+
+	mu    sync.Mutex
+	start time.Time // start of the current window
+	count int
+}
+
+// Allow reports whether a request may go ahead now, and counts it if it
+// may.
+func (w *FixedWindow) Allow() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := time.Now()
+	if w.Now != nil {
+		now = w.Now()
+	}
+	if start := now.Truncate(w.Size); !start.Equal(w.start) {
+		w.start, w.count = start, 0
+	}
+	if w.count >= w.Limit {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// SlidingWindow allows about Limit requests in any span of Size. It
+// keeps two counters, not a timestamp per request: the estimate for the
+// last Size is the current window's count, plus the previous window's
+// count weighted by how much of it overlaps. It is safe for concurrent
+// use.
+type SlidingWindow struct {
+	Limit int
+	Size  time.Duration
+	// Now returns the current time. Tests set it; nil is time.Now.
+	Now func() time.Time
+
+	mu    sync.Mutex
+	start time.Time // start of the current window
+	prev  int       // count of the window before it
+	count int       // count of the current window
+}
+
+// Allow reports whether a request may go ahead now, and counts it if it
+// may.
+func (w *SlidingWindow) Allow() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := time.Now()
+	if w.Now != nil {
+		now = w.Now()
+	}
+	start := now.Truncate(w.Size)
+	switch {
+	case start.Equal(w.start):
+	case start.Sub(w.start) == w.Size:
+		// The next window: the current one becomes the previous one.
+		w.start, w.prev, w.count = start, w.count, 0
+	default:
+		// A window or more went by without requests.
+		w.start, w.prev, w.count = start, 0, 0
+	}
+	// The fraction of the previous window still inside the last Size.
+	overlap := 1 - float64(now.Sub(start))/float64(w.Size)
+	if float64(w.prev)*overlap+float64(w.count) >= float64(w.Limit) {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// DemonstrateWindows sends 10 requests just before a window boundary and
+// 10 just after it, with a limit of 10 a minute.
+func DemonstrateWindows() {
+	fmt.Println("=== Fixed and sliding windows ===")
+	clock := NewFakeClock(time.Date(2024, 1, 1, 12, 0, 50, 0, time.UTC))
+	fixed := &FixedWindow{Limit: 10, Size: time.Minute, Now: clock.Now}
+	sliding := &SlidingWindow{Limit: 10, Size: time.Minute, Now: clock.Now}
+
+	send := func() (f, s int) {
+		for i := 0; i < 10; i++ {
+			if fixed.Allow() {
+				f++
+			}
+			if sliding.Allow() {
+				s++
+			}
+		}
+		return f, s
+	}
+	f, s := send()
+	fmt.Printf("12:00:50  fixed %2d, sliding %2d\n", f, s)
+	clock.Advance(15 * time.Second)
+	f, s = send()
+	fmt.Printf("12:01:05  fixed %2d, sliding %2d\n", f, s)
+	clock.Advance(30 * time.Second)
+	f, s = send()
+	fmt.Printf("12:01:35  fixed %2d, sliding %2d\n", f, s)
+}
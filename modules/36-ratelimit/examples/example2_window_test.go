@@ -0,0 +1,50 @@
+package examples
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateWindows(t *testing.T) {
+	DemonstrateWindows()
+}
+
+// count returns how many of n calls to allow succeed.
+func count(n int, allow func() bool) int {
+	allowed := 0
+	for i := 0; i < n; i++ {
+		if allow() {
+			allowed++
+		}
+	}
+	return allowed
+}
+
+func TestFixedWindowBoundary(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 12, 0, 59, 0, time.UTC))
+	w := &FixedWindow{Limit: 10, Size: time.Minute, Now: clock.Now}
+	assert.Equal(t, 10, count(20, w.Allow))
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, 10, count(20, w.Allow), "a new window starts from zero")
+}
+
+func TestSlidingWindow(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 12, 0, 59, 0, time.UTC))
+	w := &SlidingWindow{Limit: 10, Size: time.Minute, Now: clock.Now}
+	assert.Equal(t, 10, count(20, w.Allow))
+
+	// 12:01:01: 59/60 of the previous window still counts, 9.8
+	// requests, which leaves room for one.
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, 1, count(20, w.Allow))
+
+	// 12:01:30: half of it does, 5 requests, and 1 in this window.
+	clock.Advance(29 * time.Second)
+	assert.Equal(t, 4, count(20, w.Allow))
+
+	// Two windows later, nothing counts.
+	clock.Advance(2 * time.Minute)
+	assert.Equal(t, 10, count(20, w.Allow))
+}
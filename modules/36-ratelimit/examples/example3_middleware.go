@@ -0,0 +1,171 @@
+package examples
+
+// This file shows:
+// - A bucket per client, in a map behind a mutex
+// - Forgetting clients that have been idle for a while, from a goroutine
+//   that stops with its context, so that the map does not grow forever
+// - Keying by the client's IP, without the port, which changes with every
+//   connection
+// - Middleware that answers 429 Too Many Requests, with Retry-After and a
+//   JSON error
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limiters keeps a Bucket per key, such as a client's IP. It is safe for
+// concurrent use.
+type Limiters struct {
+	Rate  float64
+	Burst int
+	// Idle is how long a key goes unused before Sweep forgets it. Make
+	// it at least Burst/Rate seconds: a forgotten key comes back with a
+	// full bucket, and by then it would have had one anyway.
+	Idle time.Duration
+	// Now returns the current time. Tests set it; nil is time.Now.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*entry
+}
+
+// entry is a key's bucket, and when it was last used.
+type entry struct {
+	bucket *Bucket
+	seen   time.Time
+}
+
+func (l *Limiters) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+	return time.Now()
+}
+
+// Get returns key's bucket, creating a full one for a new key.
+func (l *Limiters) Get(key string) *Bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.buckets == nil {
+		l.buckets = make(map[string]*entry)
+	}
+	e, ok := l.buckets[key]
+	if !ok {
+		b := NewBucket(l.Rate, l.Burst)
+		b.Now = l.Now
+		e = &entry{bucket: b}
+		l.buckets[key] = e
+	}
+	e.seen = l.now()
+	return e.bucket
+}
+
+// Len returns the number of keys remembered.
+func (l *Limiters) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+// Sweep forgets the keys that have not been used for Idle.
+func (l *Limiters) Sweep() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	cutoff := l.now().Add(-l.Idle)
+	for key, e := range l.buckets {
+		if e.seen.Before(cutoff) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Run calls Sweep every interval until ctx is done.
+func (l *Limiters) Run(ctx context.Context, every time.Duration) {
+	t := time.NewTicker(every)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			l.Sweep()
+		}
+	}
+}
+
+// ClientIP returns the IP address of r's client. Behind a proxy that is
+// the proxy's; read X-Forwarded-For only from proxies you trust.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Middleware allows requests through to next while the bucket of their
+// key, as key returns it, has tokens, and answers 429 otherwise.
+func (l *Limiters) Middleware(key func(*http.Request) string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b := l.Get(key(r))
+		if !b.Allow() {
+			// Whole seconds, rounded up: Retry-After: 0 invites a retry
+			// that fails again.
+			retry := int(math.Ceil(b.Delay().Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(max(retry, 1)))
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeError writes {"error": msg} with status.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
+
+// DemonstrateMiddleware sends requests from two clients through the
+// middleware, and sweeps the idle one.
+func DemonstrateMiddleware() {
+	fmt.Println("=== Rate limiting middleware ===")
+	clock := NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	l := &Limiters{Rate: 1, Burst: 3, Idle: 10 * time.Minute, Now: clock.Now}
+	h := l.Middleware(ClientIP, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "hello")
+	}))
+
+	get := func(addr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = addr
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	for i, port := range []string{"50001", "50002", "50003", "50004"} {
+		rec := get("203.0.113.7:" + port)
+		fmt.Printf("203.0.113.7 request %d: %d %s", i+1, rec.Code, rec.Body)
+		if rec.Code == http.StatusTooManyRequests {
+			fmt.Println("  Retry-After:", rec.Header().Get("Retry-After"))
+		}
+	}
+	fmt.Printf("198.51.100.2 request 1: %d\n", get("198.51.100.2:40000").Code)
+	fmt.Println("clients:", l.Len())
+
+	clock.Advance(6 * time.Minute)
+	get("198.51.100.2:40001")
+	clock.Advance(6 * time.Minute)
+	l.Sweep()
+	fmt.Println("clients after 12 minutes, one idle:", l.Len())
+}
@@ -0,0 +1,68 @@
+package examples
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateMiddleware(t *testing.T) {
+	DemonstrateMiddleware()
+}
+
+func TestMiddleware(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	l := &Limiters{Rate: 0.5, Burst: 1, Idle: time.Minute, Now: clock.Now}
+	h := l.Middleware(ClientIP, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	get := func(addr string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = addr
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+
+	assert.Equal(t, http.StatusOK, get("192.0.2.1:1000").StatusCode)
+	resp := get("192.0.2.1:1001")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "a new port is the same client")
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, http.StatusOK, get("192.0.2.2:1000").StatusCode, "another client has its own bucket")
+
+	clock.Advance(1500 * time.Millisecond)
+	assert.Equal(t, "1", get("192.0.2.1:1002").Header.Get("Retry-After"), "rounded up, not to 0")
+}
+
+func TestSweep(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	l := &Limiters{Rate: 1, Burst: 1, Idle: time.Minute, Now: clock.Now}
+	l.Get("a")
+	l.Get("b")
+	clock.Advance(45 * time.Second)
+	l.Get("b")
+	clock.Advance(30 * time.Second)
+	l.Sweep()
+	assert.Equal(t, 1, l.Len())
+}
+
+func TestRunStops(t *testing.T) {
+	l := &Limiters{Rate: 1, Burst: 1, Idle: time.Nanosecond}
+	l.Get("a")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		l.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool { return l.Len() == 0 }, time.Second, time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after its context was canceled")
+	}
+}
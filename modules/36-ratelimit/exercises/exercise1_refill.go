@@ -0,0 +1,58 @@
+package exercises
+
+// EXERCISE: Fix a token bucket that runs dry and never refills.
+// A TokenBucket allows Burst requests at once, and Rate per second after
+// that. But a new bucket rejects its first request, a bucket that runs
+// out stays empty, steady traffic earns nothing, and an idle bucket
+// saves up more than Burst.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"sync"
+	"time"
+)
+
+// TokenBucket allows Burst requests at once, and Rate per second after
+// that. It is safe for concurrent use.
+type TokenBucket struct {
+	Rate  float64
+	Burst int
+	// Now returns the current time. Nil means time.Now.
+	Now func() time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket returns a bucket that allows burst requests at once,
+// and rate per second after that.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	// BUG: A new bucket starts empty.
+	return &TokenBucket{Rate: rate, Burst: burst}
+}
+
+// Allow reports whether a request may go ahead now, and takes a token if
+// it may.
+func (b *TokenBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	if b.Now != nil {
+		now = b.Now()
+	}
+	// BUG: last moves before the time since it is measured.
+	b.last = now
+	if !b.last.IsZero() && now.After(b.last) {
+		elapsed := now.Sub(b.last)
+		// BUG: Whole tokens only. A call that earns half a token
+		// throws it away.
+		b.tokens += float64(int(elapsed.Seconds() * b.Rate))
+		// BUG: Nothing stops an idle bucket at Burst.
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,116 @@
+package exercises
+
+// EXERCISE: Fix a per-client rate limiter whose map grows forever.
+// Clients keeps a token bucket per client IP, forgets idle clients in
+// Sweep, and its Handler answers 429 with Retry-After. But every
+// connection counts as a new client, Sweep forgets the wrong clients,
+// Cleanup never stops, and Retry-After never arrives.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Clients limits each client to Burst requests at once, and Rate per
+// second after that. It is safe for concurrent use.
+type Clients struct {
+	Rate  float64
+	Burst int
+	// Idle is how long a client goes without requests before Sweep
+	// forgets it.
+	Idle time.Duration
+	// Now returns the current time. Nil means time.Now.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]*client
+}
+
+// client is a client's token bucket.
+type client struct {
+	tokens float64
+	last   time.Time // the client's last request
+}
+
+func (c *Clients) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// Allow reports whether key may make a request now. If not, it returns
+// how long until it may.
+func (c *Clients) Allow(key string) (bool, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if c.clients == nil {
+		c.clients = make(map[string]*client)
+	}
+	cl, ok := c.clients[key]
+	if !ok {
+		cl = &client{tokens: float64(c.Burst), last: now}
+		c.clients[key] = cl
+	}
+	cl.tokens = min(cl.tokens+now.Sub(cl.last).Seconds()*c.Rate, float64(c.Burst))
+	cl.last = now
+	if cl.tokens < 1 {
+		return false, time.Duration((1 - cl.tokens) / c.Rate * float64(time.Second))
+	}
+	cl.tokens--
+	return true, 0
+}
+
+// Len returns the number of clients remembered.
+func (c *Clients) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.clients)
+}
+
+// Sweep forgets the clients that have made no request for Idle.
+func (c *Clients) Sweep() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cutoff := c.now().Add(-c.Idle)
+	for key, cl := range c.clients {
+		// BUG: These are the clients that made a request since cutoff.
+		if cl.last.After(cutoff) {
+			delete(c.clients, key)
+		}
+	}
+}
+
+// Cleanup calls Sweep every interval until ctx is done.
+func (c *Clients) Cleanup(ctx context.Context, every time.Duration) {
+	// BUG: This never returns, whatever ctx says.
+	for range time.Tick(every) {
+		c.Sweep()
+	}
+}
+
+// Handler passes requests on to next while their client is within its
+// limit, and answers 429 Too Many Requests otherwise.
+func (c *Clients) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// BUG: RemoteAddr is IP:port, and the port is new for every
+		// connection.
+		ok, retry := c.Allow(r.RemoteAddr)
+		if !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			// BUG: Headers set after WriteHeader are not sent.
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retry.Seconds()))))
+			json.NewEncoder(w).Encode(map[string]string{"error": "rate limit exceeded"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
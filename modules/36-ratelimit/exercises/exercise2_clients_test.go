@@ -0,0 +1,95 @@
+package exercises
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newClients() (*Clients, *clock) {
+	c := &clock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	return &Clients{Rate: 1, Burst: 2, Idle: time.Minute, Now: c.Now}, c
+}
+
+// get sends a request from addr through h.
+func get(h http.Handler, addr string) *http.Response {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = addr
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Result()
+}
+
+var noop = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+func TestHandlerLimits(t *testing.T) {
+	c, _ := newClients()
+	h := c.Handler(noop)
+	assert.Equal(t, http.StatusOK, get(h, "192.0.2.1:1000").StatusCode)
+	assert.Equal(t, http.StatusOK, get(h, "192.0.2.1:1000").StatusCode)
+	resp := get(h, "192.0.2.1:1000")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+}
+
+func TestHandlerKeysByIP(t *testing.T) {
+	c, _ := newClients()
+	h := c.Handler(noop)
+	codes := []int{}
+	for port := 1000; port < 1010; port++ {
+		codes = append(codes, get(h, "192.0.2.1:"+strconv.Itoa(port)).StatusCode)
+	}
+	assert.Equal(t, []int{200, 200, 429, 429, 429, 429, 429, 429, 429, 429}, codes,
+		"a new connection is the same client")
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestSweepForgetsIdleClients(t *testing.T) {
+	c, clock := newClients()
+	for _, ip := range []string{"a", "b", "c"} {
+		c.Allow(ip)
+	}
+	clock.Advance(50 * time.Second)
+	c.Allow("c")
+	clock.Advance(20 * time.Second)
+	c.Sweep()
+	assert.Equal(t, 1, c.Len(), "a and b have been idle for over a minute")
+}
+
+func TestSweepKeepsActiveClients(t *testing.T) {
+	c, clock := newClients()
+	for i := 0; i < 10; i++ {
+		c.Allow("busy")
+	}
+	clock.Advance(time.Second)
+	c.Sweep()
+	allowed, _ := c.Allow("busy")
+	assert.True(t, allowed)
+	allowed, _ = c.Allow("busy")
+	assert.False(t, allowed, "the sweep gave an active client a full bucket")
+}
+
+func TestCleanupStops(t *testing.T) {
+	c := &Clients{Rate: 1, Burst: 1, Idle: time.Nanosecond}
+	c.Allow("a")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.Cleanup(ctx, time.Millisecond)
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return c.Len() == 0 }, time.Second, time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Cleanup did not return after its context was canceled")
+	}
+}
@@ -0,0 +1,59 @@
+package solutions
+
+// SOLUTION: Fix a token bucket that runs dry and never refills.
+// Allow measures the time since the last call before it moves last
+// forward, keeps fractions of a token, caps the tokens at Burst, and a
+// new bucket starts full.
+
+import (
+	"sync"
+	"time"
+)
+
+// TokenBucket allows Burst requests at once, and Rate per second after
+// that. It is safe for concurrent use.
+type TokenBucket struct {
+	Rate  float64
+	Burst int
+	// Now returns the current time. Nil means time.Now.
+	Now func() time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket returns a bucket that allows burst requests at once,
+// and rate per second after that.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	// Fixed: A new bucket starts full, so that the first requests are
+	// allowed.
+	return &TokenBucket{Rate: rate, Burst: burst, tokens: float64(burst)}
+}
+
+// Allow reports whether a request may go ahead now, and takes a token if
+// it may.
+func (b *TokenBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	if b.Now != nil {
+		now = b.Now()
+	}
+	if !b.last.IsZero() && now.After(b.last) {
+		// Fixed: The time since the last call, measured before last
+		// moves. Measured after, it is always zero.
+		elapsed := now.Sub(b.last)
+		// Fixed: Keep fractions. Calls closer together than a token
+		// each would otherwise never earn one.
+		b.tokens += elapsed.Seconds() * b.Rate
+		// Fixed: An idle bucket fills up to Burst, not beyond.
+		b.tokens = min(b.tokens, float64(b.Burst))
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
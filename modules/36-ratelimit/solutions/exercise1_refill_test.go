@@ -0,0 +1,66 @@
+package solutions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// clock is a time that tests move forward.
+type clock struct{ t time.Time }
+
+func (c *clock) Now() time.Time          { return c.t }
+func (c *clock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newBucket(rate float64, burst int) (*TokenBucket, *clock) {
+	c := &clock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b := NewTokenBucket(rate, burst)
+	b.Now = c.Now
+	return b, c
+}
+
+// allowed returns how many of n requests b allows.
+func allowed(b *TokenBucket, n int) int {
+	count := 0
+	for i := 0; i < n; i++ {
+		if b.Allow() {
+			count++
+		}
+	}
+	return count
+}
+
+func TestStartsFull(t *testing.T) {
+	b, _ := newBucket(1, 5)
+	assert.Equal(t, 5, allowed(b, 10))
+}
+
+func TestRefills(t *testing.T) {
+	b, c := newBucket(2, 2)
+	assert.Equal(t, 2, allowed(b, 5))
+	c.Advance(time.Second)
+	assert.Equal(t, 2, allowed(b, 5), "two tokens a second")
+	c.Advance(500 * time.Millisecond)
+	assert.Equal(t, 1, allowed(b, 5))
+}
+
+func TestRefillsUnderSteadyTraffic(t *testing.T) {
+	// A request every 100ms, at 5 a second: every other one is allowed.
+	b, c := newBucket(5, 1)
+	count := 0
+	for i := 0; i < 20; i++ {
+		c.Advance(100 * time.Millisecond)
+		if b.Allow() {
+			count++
+		}
+	}
+	assert.Equal(t, 10, count)
+}
+
+func TestCapsAtBurst(t *testing.T) {
+	b, c := newBucket(10, 3)
+	allowed(b, 3)
+	c.Advance(time.Hour)
+	assert.Equal(t, 3, allowed(b, 100), "an idle bucket holds Burst tokens")
+}
@@ -0,0 +1,87 @@
+// Package examples demonstrates retrying failed operations in Go:
+// exponential backoff with a cap and jitter, a Retry function that
+// stops on errors that will not go away and when its context is done,
+// and retrying HTTP requests with Retry-After.
+//
+// This file shows:
+// - Exponential backoff: each delay Multiplier times the one before, up to Max
+// - Jitter: a random part of each delay, so that clients that failed together do not retry together
+// - Making the randomness injectable, so that tests get the same delays every run
+package examples
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Policy says how often and how long to wait between attempts.
+type Policy struct {
+	// MaxAttempts is the most calls to make, the first one included.
+	MaxAttempts int
+	// Initial is the delay after the first failure.
+	Initial time.Duration
+	// Max caps every delay.
+	Max time.Duration
+	// Multiplier grows the delay after every failure. 2 doubles it.
+	Multiplier float64
+	// Jitter is the fraction of each delay that is random: 0 is none,
+	// and 1 is any delay from zero up to the full one.
+	Jitter float64
+
+	// Rand returns a number in [0, 1). Tests set it; nil is math/rand.
+	Rand func() float64
+	// Sleep waits for d, or until ctx is done. Tests set it; nil waits
+	// on a timer. See Retry.
+	Sleep SleepFunc
+}
+
+// DefaultPolicy makes 5 attempts over about 3 seconds.
+var DefaultPolicy = Policy{
+	MaxAttempts: 5,
+	Initial:     200 * time.Millisecond,
+	Max:         5 * time.Second,
+	Multiplier:  2,
+	Jitter:      0.5,
+}
+
+func (p Policy) rand() float64 {
+	if p.Rand != nil {
+		return p.Rand()
+	}
+	return rand.Float64()
+}
+
+// Delay returns how long to wait after the failure of attempt n, where
+// the first attempt is 1.
+func (p Policy) Delay(n int) time.Duration {
+	d := float64(p.Initial) * math.Pow(p.Multiplier, float64(n-1))
+	// Cap before converting: float64 to Duration overflows in about 50
+	// doublings of a second.
+	d = math.Min(d, float64(p.Max))
+	d -= d * p.Jitter * p.rand()
+	return time.Duration(d)
+}
+
+// DemonstrateBackoff prints the delays of a policy, without jitter and
+// with it.
+func DemonstrateBackoff() {
+	fmt.Println("=== Exponential backoff ===")
+	p := Policy{MaxAttempts: 8, Initial: 100 * time.Millisecond, Max: 2 * time.Second, Multiplier: 2}
+	for n := 1; n < p.MaxAttempts; n++ {
+		fmt.Printf("after attempt %d: %v\n", n, p.Delay(n))
+	}
+
+	// Three clients that failed at the same moment, with full jitter.
+	p.Jitter = 1
+	for client := 1; client <= 3; client++ {
+		r := rand.New(rand.NewSource(int64(client)))
+		p.Rand = r.Float64
+		fmt.Printf("client %d:", client)
+		for n := 1; n <= 4; n++ {
+			fmt.Printf(" %v", p.Delay(n).Round(time.Millisecond))
+		}
+		fmt.Println()
+	}
+}
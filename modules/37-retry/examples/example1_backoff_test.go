@@ -0,0 +1,32 @@
+package examples
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateBackoff(t *testing.T) {
+	DemonstrateBackoff()
+}
+
+func TestDelayGrowsAndCaps(t *testing.T) {
+	p := Policy{Initial: time.Second, Max: 10 * time.Second, Multiplier: 3}
+	var got []time.Duration
+	for n := 1; n <= 5; n++ {
+		got = append(got, p.Delay(n))
+	}
+	assert.Equal(t, []time.Duration{time.Second, 3 * time.Second, 9 * time.Second, 10 * time.Second, 10 * time.Second}, got)
+	assert.Equal(t, 10*time.Second, p.Delay(1000), "no overflow")
+}
+
+func TestDelayJitter(t *testing.T) {
+	p := Policy{Initial: time.Second, Max: time.Minute, Multiplier: 2, Jitter: 0.5}
+	p.Rand = func() float64 { return 0 }
+	assert.Equal(t, 4*time.Second, p.Delay(3))
+	p.Rand = func() float64 { return 0.5 }
+	assert.Equal(t, 3*time.Second, p.Delay(3))
+	p.Rand = func() float64 { return 0.999 }
+	assert.InDelta(t, 2*time.Second, p.Delay(3), float64(10*time.Millisecond))
+}
@@ -0,0 +1,132 @@
+package examples
+
+// This file shows:
+// - Retry: call fn until it succeeds, fails for good, runs out of
+//   attempts, or the context is done
+// - Classifying errors with errors.As, so that wrapped errors keep their
+//   class: only errors that say they are retryable are retried
+// - Never retrying context errors: the caller has given up
+// - Sleeping with a timer and a select, so that cancellation ends the
+//   wait, and not sleeping past the context's deadline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SleepFunc waits for d, or until ctx is done, and returns ctx.Err() in
+// that case.
+type SleepFunc func(ctx context.Context, d time.Duration) error
+
+// sleep is the SleepFunc of a Policy without one.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// IsRetryable reports whether err, or any error it wraps, has a
+// Retryable method that returns true. Context errors never are.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var r interface{ Retryable() bool }
+	return errors.As(err, &r) && r.Retryable()
+}
+
+// Temporary marks err as retryable.
+func Temporary(err error) error {
+	return &temporary{err}
+}
+
+type temporary struct{ err error }
+
+func (t *temporary) Error() string   { return t.err.Error() }
+func (t *temporary) Unwrap() error   { return t.err }
+func (t *temporary) Retryable() bool { return true }
+
+// Retry calls fn until it returns nil, or an error that IsRetryable says
+// no to, or p.MaxAttempts calls have failed, waiting p.Delay between
+// them. It returns the last error. An error with a RetryAfter method
+// asks for a longer wait, as a 429 or 503 does.
+func Retry(ctx context.Context, p Policy, fn func(ctx context.Context) error) error {
+	sleepFn := p.Sleep
+	if sleepFn == nil {
+		sleepFn = sleep
+	}
+	for n := 1; ; n++ {
+		err := fn(ctx)
+		if err == nil || !IsRetryable(err) {
+			return err
+		}
+		if n >= p.MaxAttempts {
+			return fmt.Errorf("gave up after %d attempts: %w", n, err)
+		}
+		d := p.Delay(n)
+		var ra interface{ RetryAfter() time.Duration }
+		if errors.As(err, &ra) && ra.RetryAfter() > d {
+			d = ra.RetryAfter()
+		}
+		// A wait that ends after the deadline is a wait for nothing.
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+			return fmt.Errorf("%w before attempt %d: %w", context.DeadlineExceeded, n+1, err)
+		}
+		if serr := sleepFn(ctx, d); serr != nil {
+			return fmt.Errorf("%w before attempt %d: %w", serr, n+1, err)
+		}
+	}
+}
+
+// errFlaky is what the flaky operation of DemonstrateRetry fails with.
+var errFlaky = errors.New("connection reset")
+
+// DemonstrateRetry retries an operation that fails twice, one that
+// fails for good, and one that is canceled while waiting.
+func DemonstrateRetry() {
+	fmt.Println("=== Retry ===")
+	p := DefaultPolicy
+	p.Rand = func() float64 { return 0.5 }
+	p.Sleep = func(ctx context.Context, d time.Duration) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		fmt.Println("  waiting", d)
+		return nil
+	}
+
+	calls := 0
+	err := Retry(context.Background(), p, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return Temporary(fmt.Errorf("read: %w", errFlaky))
+		}
+		return nil
+	})
+	fmt.Printf("flaky: %d calls, err %v\n", calls, err)
+
+	calls = 0
+	err = Retry(context.Background(), p, func(context.Context) error {
+		calls++
+		return errors.New("invalid request")
+	})
+	fmt.Printf("not retryable: %d call, err %v\n", calls, err)
+
+	calls = 0
+	ctx, cancel := context.WithCancel(context.Background())
+	err = Retry(ctx, p, func(context.Context) error {
+		calls++
+		if calls == 2 {
+			cancel()
+		}
+		return Temporary(errFlaky)
+	})
+	fmt.Printf("canceled: %d calls, err %v, is errFlaky: %t\n", calls, err, errors.Is(err, errFlaky))
+}
@@ -0,0 +1,102 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateRetry(t *testing.T) {
+	DemonstrateRetry()
+}
+
+// fakeClock is a clock whose Sleep returns at once, and only records how
+// long it was asked to sleep.
+type fakeClock struct {
+	mu    sync.Mutex
+	slept []time.Duration
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.slept = append(c.slept, d)
+	return nil
+}
+
+// testPolicy returns a policy without jitter that sleeps on a fake
+// clock.
+func testPolicy() (Policy, *fakeClock) {
+	c := &fakeClock{}
+	return Policy{MaxAttempts: 4, Initial: time.Second, Max: 3 * time.Second, Multiplier: 2, Sleep: c.Sleep}, c
+}
+
+func TestRetryUntilSuccess(t *testing.T) {
+	p, clock := testPolicy()
+	calls := 0
+	err := Retry(context.Background(), p, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return Temporary(errors.New("busy"))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, clock.slept)
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	p, clock := testPolicy()
+	busy := errors.New("busy")
+	calls := 0
+	err := Retry(context.Background(), p, func(context.Context) error {
+		calls++
+		return Temporary(busy)
+	})
+	assert.ErrorIs(t, err, busy)
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}, clock.slept)
+}
+
+func TestRetryStopsOnPermanentErrors(t *testing.T) {
+	p, clock := testPolicy()
+	bad := errors.New("bad request")
+	calls := 0
+	err := Retry(context.Background(), p, func(context.Context) error {
+		calls++
+		return fmt.Errorf("wrapped: %w", bad)
+	})
+	assert.ErrorIs(t, err, bad)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, clock.slept)
+
+	assert.True(t, IsRetryable(fmt.Errorf("wrapped: %w", Temporary(bad))), "errors.As sees through wrapping")
+	assert.False(t, IsRetryable(Temporary(context.Canceled)))
+}
+
+func TestRetryStopsWhenCanceled(t *testing.T) {
+	p := DefaultPolicy
+	p.Initial, p.Max = time.Hour, time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	start := time.Now()
+	err := Retry(ctx, p, func(context.Context) error { return Temporary(errFlaky) })
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, errFlaky)
+	assert.Less(t, time.Since(start), time.Second)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	err = Retry(ctx, p, func(context.Context) error { return Temporary(errFlaky) })
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "an hour's wait does not fit in a minute")
+}
@@ -0,0 +1,119 @@
+package examples
+
+// This file shows:
+// - Which HTTP failures are worth retrying: 429, 502, 503, 504, and
+//   timeouts, not 400 or 404
+// - Honoring Retry-After, in seconds or as an HTTP date
+// - A new request for every attempt, since a body can be read once
+// - Reading and closing the body of a failed response, so that the
+//   connection can be used again
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// StatusError is an HTTP response with a status other than 2xx.
+type StatusError struct {
+	Code int
+	// After is the response's Retry-After, or 0.
+	After time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("HTTP %d %s", e.Code, http.StatusText(e.Code))
+}
+
+// Retryable reports whether the same request may succeed later: the
+// server is overloaded, down for a moment, or behind a proxy that timed
+// out.
+func (e *StatusError) Retryable() bool {
+	switch e.Code {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// RetryAfter is how long the server asked for.
+func (e *StatusError) RetryAfter() time.Duration { return e.After }
+
+// ParseRetryAfter reads a Retry-After header, which is a number of
+// seconds or an HTTP date. It returns 0 for an empty or invalid one.
+func ParseRetryAfter(h string, now time.Time) time.Duration {
+	if secs, err := strconv.Atoi(h); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(h); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// Get fetches url with client, retrying as p says. GET is idempotent:
+// retrying a POST can do what it does twice.
+func Get(ctx context.Context, client *http.Client, p Policy, url string) ([]byte, error) {
+	var body []byte
+	err := Retry(ctx, p, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			var nerr net.Error
+			if errors.As(err, &nerr) && nerr.Timeout() && ctx.Err() == nil {
+				return Temporary(err)
+			}
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+			return &StatusError{Code: resp.StatusCode, After: ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+		}
+		body, err = io.ReadAll(resp.Body)
+		return err
+	})
+	return body, err
+}
+
+// DemonstrateHTTP fetches from a server that is unavailable twice, and
+// from one that answers 404.
+func DemonstrateHTTP() {
+	fmt.Println("=== Retrying HTTP requests ===")
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path != "/":
+			http.NotFound(w, r)
+		case hits.Add(1) <= 2:
+			w.Header().Set("Retry-After", "3")
+			http.Error(w, "maintenance", http.StatusServiceUnavailable)
+		default:
+			fmt.Fprint(w, "hello")
+		}
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy
+	p.Sleep = func(ctx context.Context, d time.Duration) error {
+		fmt.Println("  waiting", d)
+		return ctx.Err()
+	}
+	body, err := Get(context.Background(), srv.Client(), p, srv.URL)
+	fmt.Printf("%d requests: %q %v\n", hits.Load(), body, err)
+
+	_, err = Get(context.Background(), srv.Client(), p, srv.URL+"/missing")
+	var se *StatusError
+	fmt.Println("missing:", err, errors.As(err, &se) && !se.Retryable())
+}
@@ -0,0 +1,63 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateHTTP(t *testing.T) {
+	DemonstrateHTTP()
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, 120*time.Second, ParseRetryAfter("120", now))
+	assert.Equal(t, 90*time.Second, ParseRetryAfter("Mon, 01 Jan 2024 12:01:30 GMT", now))
+	assert.Zero(t, ParseRetryAfter("Mon, 01 Jan 2024 11:00:00 GMT", now))
+	assert.Zero(t, ParseRetryAfter("", now))
+	assert.Zero(t, ParseRetryAfter("soon", now))
+}
+
+func TestGet(t *testing.T) {
+	statuses := []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusOK}
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[hits.Add(1)-1]
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "30")
+		}
+		w.WriteHeader(status)
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	p, clock := testPolicy()
+	body, err := Get(context.Background(), srv.Client(), p, srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, []time.Duration{30 * time.Second, 2 * time.Second}, clock.slept, "Retry-After, then the backoff")
+}
+
+func TestGetDoesNotRetryClientErrors(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		http.Error(w, "no", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	p, _ := testPolicy()
+	_, err := Get(context.Background(), srv.Client(), p, srv.URL)
+	var se *StatusError
+	require.True(t, errors.As(err, &se))
+	assert.Equal(t, http.StatusBadRequest, se.Code)
+	assert.Equal(t, int32(1), hits.Load())
+}
@@ -0,0 +1,78 @@
+package exercises
+
+// EXERCISE: Fix a retry loop that retries requests that can never succeed.
+// Backoff.Do retries calls that fail with a temporary APIError, such as
+// 503, with exponential backoff and jitter. But it retries every other
+// error too, including 400s wrapped with fmt.Errorf, makes one call too
+// many, never backs off further, and sends every client back at the same
+// moment.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// APIError is an error response from the API.
+type APIError struct {
+	Status int
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: %d %s", e.Status, http.StatusText(e.Status))
+}
+
+// Temporary reports whether the request may succeed if it is sent again.
+func (e *APIError) Temporary() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= 500
+}
+
+// Backoff retries a call with exponential backoff.
+type Backoff struct {
+	// Attempts is the most calls Do makes.
+	Attempts int
+	// Base is the delay after the first failure, and every delay after
+	// that doubles it, up to Cap.
+	Base time.Duration
+	Cap  time.Duration
+	// Rand returns a number in [0, 1). Nil means math/rand.
+	Rand func() float64
+	// Sleep waits. Nil means time.Sleep.
+	Sleep func(time.Duration)
+}
+
+// Do calls fn until it succeeds, returns an error that is not a
+// temporary APIError, or has been called Attempts times. It returns fn's
+// last error.
+func (b *Backoff) Do(fn func() error) error {
+	sleep, random := b.Sleep, b.Rand
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	if random == nil {
+		random = rand.Float64
+	}
+	// BUG: Attempts+1 calls.
+	for n := 0; ; n++ {
+		// BUG: Every delay starts over from Base.
+		delay := b.Base
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		// BUG: Everything but an unwrapped permanent APIError is retried,
+		// including errors that no retry will fix.
+		if apiErr, ok := err.(*APIError); ok && !apiErr.Temporary() {
+			return err
+		}
+		if n >= b.Attempts {
+			return err
+		}
+		// BUG: No jitter: clients that failed together retry together.
+		sleep(delay)
+		// BUG: Doubled, but not capped, and thrown away.
+		delay *= 2
+	}
+}
@@ -0,0 +1,83 @@
+package exercises
+
+// EXERCISE: Fix a retry helper that ignores its context and the server's Retry-After.
+// Retrier.Do retries calls that fail with a ServerError, doubling the
+// delay each time. But canceling its context does not stop it, it comes
+// back sooner than the server asked, it cannot read Retry-After dates,
+// and when it gives up, the error it returns says nothing about why.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ServerError is a response from an overloaded or unavailable server.
+type ServerError struct {
+	Status int
+	// RetryAfter is how long the server asked clients to wait, or 0.
+	RetryAfter time.Duration
+}
+
+func (e *ServerError) Error() string {
+	return fmt.Sprintf("server error: %d %s", e.Status, http.StatusText(e.Status))
+}
+
+// ParseRetryAfter reads a Retry-After header: a number of seconds, or an
+// HTTP date. It returns 0 for anything else, and for dates before now.
+func ParseRetryAfter(h string, now time.Time) time.Duration {
+	if secs, err := strconv.Atoi(h); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	// BUG: Retry-After can also be an HTTP date, such as
+	// "Wed, 21 Oct 2015 07:28:00 GMT".
+	return 0
+}
+
+// Retrier retries calls that fail with a ServerError.
+type Retrier struct {
+	// Attempts is the most calls Do makes.
+	Attempts int
+	// Delay is the delay after the first failure, doubled after each.
+	Delay time.Duration
+	// Sleep waits for d, or until ctx is done, and returns ctx.Err() in
+	// that case. Nil means a timer.
+	Sleep func(ctx context.Context, d time.Duration) error
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	// BUG: Nothing stops time.Sleep.
+	time.Sleep(d)
+	return nil
+}
+
+// Do calls fn until it succeeds, returns an error other than a
+// ServerError, has been called Attempts times, or ctx is done.
+func (r *Retrier) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	wait := r.Sleep
+	if wait == nil {
+		wait = sleep
+	}
+	delay := r.Delay
+	for n := 1; ; n++ {
+		err := fn(ctx)
+		var serr *ServerError
+		if err == nil || !errors.As(err, &serr) {
+			return err
+		}
+		if n >= r.Attempts {
+			// BUG: The last error is dropped.
+			return fmt.Errorf("giving up after %d attempts", n)
+		}
+		// BUG: serr.RetryAfter is how long the server asked for.
+		d := delay
+		// BUG: Whatever wait returns, the retries go on.
+		wait(ctx, d)
+		delay *= 2
+	}
+}
@@ -0,0 +1,100 @@
+package exercises
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2015, 10, 21, 7, 0, 0, 0, time.UTC)
+	assert.Equal(t, 120*time.Second, ParseRetryAfter("120", now))
+	assert.Equal(t, 28*time.Minute, ParseRetryAfter("Wed, 21 Oct 2015 07:28:00 GMT", now))
+	assert.Zero(t, ParseRetryAfter("Wed, 21 Oct 2015 06:00:00 GMT", now))
+	assert.Zero(t, ParseRetryAfter("-5", now))
+	assert.Zero(t, ParseRetryAfter("later", now))
+}
+
+// recorder returns a Sleep that records its delays and returns at once.
+func recorder() (func(context.Context, time.Duration) error, *[]time.Duration) {
+	var slept []time.Duration
+	return func(ctx context.Context, d time.Duration) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		slept = append(slept, d)
+		return nil
+	}, &slept
+}
+
+func TestDoRetryAfter(t *testing.T) {
+	sleep, slept := recorder()
+	r := &Retrier{Attempts: 4, Delay: time.Second, Sleep: sleep}
+	errs := []error{
+		&ServerError{Status: http.StatusTooManyRequests, RetryAfter: 10 * time.Second},
+		&ServerError{Status: http.StatusServiceUnavailable},
+		&ServerError{Status: http.StatusServiceUnavailable, RetryAfter: time.Second},
+		nil,
+	}
+	calls := 0
+	err := r.Do(context.Background(), func(context.Context) error {
+		calls++
+		return errs[calls-1]
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{10 * time.Second, 2 * time.Second, 4 * time.Second}, *slept,
+		"the longer of the backoff and Retry-After")
+}
+
+func TestDoKeepsLastError(t *testing.T) {
+	sleep, _ := recorder()
+	r := &Retrier{Attempts: 3, Delay: time.Second, Sleep: sleep}
+	err := r.Do(context.Background(), func(context.Context) error {
+		return &ServerError{Status: http.StatusBadGateway}
+	})
+	var serr *ServerError
+	require.True(t, errors.As(err, &serr), "the ServerError is lost: %v", err)
+	assert.Equal(t, http.StatusBadGateway, serr.Status)
+}
+
+func TestDoStopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sleep, slept := recorder()
+	r := &Retrier{Attempts: 10, Delay: time.Second, Sleep: sleep}
+	calls := 0
+	err := r.Do(ctx, func(context.Context) error {
+		calls++
+		if calls == 2 {
+			cancel()
+		}
+		return &ServerError{Status: http.StatusServiceUnavailable}
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, calls, "calls after the context was canceled")
+	assert.Len(t, *slept, 1)
+}
+
+func TestDefaultSleepIsCancelable(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	r := &Retrier{Attempts: 3, Delay: time.Hour}
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Do(ctx, func(context.Context) error {
+			return &ServerError{Status: http.StatusServiceUnavailable}
+		})
+	}()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Do slept through its context's deadline")
+	}
+}
@@ -0,0 +1,80 @@
+package solutions
+
+// SOLUTION: Fix a retry loop that retries requests that can never succeed.
+// Do retries only errors that errors.As finds a temporary APIError in,
+// makes Attempts calls and no more, doubles the delay up to Cap, and
+// randomizes half of each delay.
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// APIError is an error response from the API.
+type APIError struct {
+	Status int
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: %d %s", e.Status, http.StatusText(e.Status))
+}
+
+// Temporary reports whether the request may succeed if it is sent again.
+func (e *APIError) Temporary() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= 500
+}
+
+// Backoff retries a call with exponential backoff.
+type Backoff struct {
+	// Attempts is the most calls Do makes.
+	Attempts int
+	// Base is the delay after the first failure, and every delay after
+	// that doubles it, up to Cap.
+	Base time.Duration
+	Cap  time.Duration
+	// Rand returns a number in [0, 1). Nil means math/rand.
+	Rand func() float64
+	// Sleep waits. Nil means time.Sleep.
+	Sleep func(time.Duration)
+}
+
+// Do calls fn until it succeeds, returns an error that is not a
+// temporary APIError, or has been called Attempts times. It returns fn's
+// last error.
+func (b *Backoff) Do(fn func() error) error {
+	sleep, random := b.Sleep, b.Rand
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	if random == nil {
+		random = rand.Float64
+	}
+	// Fixed: The delay lives outside the loop, so that it grows.
+	delay := b.Base
+	// Fixed: Attempts calls, not Attempts+1.
+	for n := 1; ; n++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		// Fixed: Retry only what is known to be temporary, and find it
+		// with errors.As, which sees through fmt.Errorf's %w. A type
+		// assertion misses wrapped errors, and retrying every error that
+		// is not an APIError retries bugs and bad input forever.
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || !apiErr.Temporary() {
+			return err
+		}
+		if n >= b.Attempts {
+			return err
+		}
+		// Fixed: Half of the delay is random, so that clients that failed
+		// together spread out.
+		sleep(delay/2 + time.Duration(random()*float64(delay/2)))
+		// Fixed: Double the delay, up to Cap.
+		delay = min(delay*2, b.Cap)
+	}
+}
@@ -0,0 +1,95 @@
+package solutions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newBackoff returns a backoff whose Rand always returns r, and the
+// delays it sleeps for.
+func newBackoff(r float64) (*Backoff, *[]time.Duration) {
+	var slept []time.Duration
+	return &Backoff{
+		Attempts: 5,
+		Base:     time.Second,
+		Cap:      4 * time.Second,
+		Rand:     func() float64 { return r },
+		Sleep:    func(d time.Duration) { slept = append(slept, d) },
+	}, &slept
+}
+
+// failing returns a function that fails with err, and counts its calls.
+func failing(err error) (func() error, *int) {
+	calls := 0
+	return func() error {
+		calls++
+		return err
+	}, &calls
+}
+
+func TestDoSucceeds(t *testing.T) {
+	b, _ := newBackoff(0)
+	calls := 0
+	err := b.Do(func() error {
+		calls++
+		if calls < 3 {
+			return &APIError{Status: http.StatusServiceUnavailable}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestDoAttempts(t *testing.T) {
+	b, _ := newBackoff(0)
+	fn, calls := failing(&APIError{Status: http.StatusServiceUnavailable})
+	err := b.Do(fn)
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 5, *calls)
+}
+
+func TestDoDoesNotRetryPermanentErrors(t *testing.T) {
+	for _, err := range []error{
+		&APIError{Status: http.StatusBadRequest},
+		fmt.Errorf("creating user: %w", &APIError{Status: http.StatusConflict}),
+		errors.New("invalid email address"),
+	} {
+		b, slept := newBackoff(0)
+		fn, calls := failing(err)
+		assert.Equal(t, err, b.Do(fn))
+		assert.Equal(t, 1, *calls, "%v retried", err)
+		assert.Empty(t, *slept)
+	}
+}
+
+func TestDoRetriesWrappedTemporaryErrors(t *testing.T) {
+	b, _ := newBackoff(0)
+	fn, calls := failing(fmt.Errorf("listing users: %w", &APIError{Status: http.StatusTooManyRequests}))
+	b.Do(fn)
+	assert.Equal(t, 5, *calls)
+}
+
+func TestDoBacksOff(t *testing.T) {
+	b, slept := newBackoff(0.999999)
+	fn, _ := failing(&APIError{Status: http.StatusBadGateway})
+	b.Do(fn)
+	assert.Len(t, *slept, 4)
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		assert.InDelta(t, want, (*slept)[i], float64(time.Millisecond), "delay %d", i+1)
+	}
+}
+
+func TestDoJitter(t *testing.T) {
+	b, slept := newBackoff(0)
+	fn, _ := failing(&APIError{Status: http.StatusBadGateway})
+	b.Do(fn)
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second, 2 * time.Second}, *slept,
+		"every client that fails at once waits exactly as long")
+}
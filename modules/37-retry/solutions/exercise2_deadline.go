@@ -0,0 +1,95 @@
+package solutions
+
+// SOLUTION: Fix a retry helper that ignores its context and the server's Retry-After.
+// The default sleep waits on a timer or the context, whichever is first,
+// Do stops when sleeping fails, waits at least as long as the server
+// asked, and wraps the last error when it gives up. ParseRetryAfter reads
+// HTTP dates as well as seconds.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ServerError is a response from an overloaded or unavailable server.
+type ServerError struct {
+	Status int
+	// RetryAfter is how long the server asked clients to wait, or 0.
+	RetryAfter time.Duration
+}
+
+func (e *ServerError) Error() string {
+	return fmt.Sprintf("server error: %d %s", e.Status, http.StatusText(e.Status))
+}
+
+// ParseRetryAfter reads a Retry-After header: a number of seconds, or an
+// HTTP date. It returns 0 for anything else, and for dates before now.
+func ParseRetryAfter(h string, now time.Time) time.Duration {
+	if secs, err := strconv.Atoi(h); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	// Fixed: The HTTP-date form, such as
+	// "Wed, 21 Oct 2015 07:28:00 GMT".
+	if t, err := http.ParseTime(h); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// Retrier retries calls that fail with a ServerError.
+type Retrier struct {
+	// Attempts is the most calls Do makes.
+	Attempts int
+	// Delay is the delay after the first failure, doubled after each.
+	Delay time.Duration
+	// Sleep waits for d, or until ctx is done, and returns ctx.Err() in
+	// that case. Nil means a timer.
+	Sleep func(ctx context.Context, d time.Duration) error
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	// Fixed: time.Sleep cannot be canceled. A timer in a select can.
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// Do calls fn until it succeeds, returns an error other than a
+// ServerError, has been called Attempts times, or ctx is done.
+func (r *Retrier) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	wait := r.Sleep
+	if wait == nil {
+		wait = sleep
+	}
+	delay := r.Delay
+	for n := 1; ; n++ {
+		err := fn(ctx)
+		var serr *ServerError
+		if err == nil || !errors.As(err, &serr) {
+			return err
+		}
+		if n >= r.Attempts {
+			// Fixed: Keep the last error, so that callers can see what
+			// went wrong.
+			return fmt.Errorf("giving up after %d attempts: %w", n, err)
+		}
+		// Fixed: Wait at least as long as the server asked. Coming back
+		// sooner makes an overloaded server busier.
+		d := max(delay, serr.RetryAfter)
+		// Fixed: A canceled wait ends the retries.
+		if err := wait(ctx, d); err != nil {
+			return err
+		}
+		delay *= 2
+	}
+}
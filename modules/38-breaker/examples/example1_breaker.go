@@ -0,0 +1,190 @@
+// Package examples demonstrates circuit breakers in Go: a breaker with
+// closed, open, and half-open states, failure thresholds and probe
+// requests, and an http.RoundTripper that puts one in front of a
+// server.
+//
+// This file shows:
+// - The three states: closed lets calls through, open fails them at once, half-open lets a probe through
+// - Opening after Threshold failures in a row, and trying again after Cooldown
+// - One probe at a time in half-open, closing after Probes successes and reopening on a failure
+// - An OnChange hook, for logs and metrics
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// State is a breaker's state.
+type State int
+
+const (
+	// Closed lets calls through, and counts failures.
+	Closed State = iota
+	// Open fails calls at once, until Cooldown has passed.
+	Open
+	// HalfOpen lets one probe call through at a time, to see whether
+	// the other side has recovered.
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// ErrOpen is returned by Allow when the breaker is not letting calls
+// through.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// Breaker is a circuit breaker. It is safe for concurrent use. Call
+// Allow before each call, and Done with its result, or Cancel, after it.
+type Breaker struct {
+	// Threshold is how many failures in a row open the breaker.
+	Threshold int
+	// Cooldown is how long the breaker stays open before it lets a
+	// probe through.
+	Cooldown time.Duration
+	// Probes is how many probes in a row must succeed to close it.
+	Probes int
+	// OnChange, if set, is called on every change of state, with the
+	// breaker's lock held.
+	OnChange func(from, to State)
+	// Now returns the current time. Tests set it; nil is time.Now.
+	Now func() time.Time
+
+	mu        sync.Mutex
+	state     State
+	failures  int       // failures in a row, when closed
+	successes int       // successful probes in a row, when half-open
+	probing   bool      // a probe is in flight
+	openedAt  time.Time // when the breaker last opened
+}
+
+func (b *Breaker) now() time.Time {
+	if b.Now != nil {
+		return b.Now()
+	}
+	return time.Now()
+}
+
+// set moves the breaker to state to. b.mu must be held.
+func (b *Breaker) set(to State) {
+	from := b.state
+	b.state = to
+	b.failures, b.successes, b.probing = 0, 0, false
+	if to == Open {
+		b.openedAt = b.now()
+	}
+	if b.OnChange != nil && from != to {
+		b.OnChange(from, to)
+	}
+}
+
+// State returns the breaker's state.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Allow returns nil if a call may go ahead, and ErrOpen if not. Every
+// call Allow lets through must be followed by Done or Cancel.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case Open:
+		if b.now().Sub(b.openedAt) < b.Cooldown {
+			return ErrOpen
+		}
+		b.set(HalfOpen)
+		fallthrough
+	case HalfOpen:
+		if b.probing {
+			return ErrOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Done records the result of a call that Allow let through.
+func (b *Breaker) Done(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case Closed:
+		if err == nil {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.Threshold {
+			b.set(Open)
+		}
+	case HalfOpen:
+		b.probing = false
+		if err != nil {
+			b.set(Open)
+			return
+		}
+		b.successes++
+		if b.successes >= b.Probes {
+			b.set(Closed)
+		}
+	}
+}
+
+// Cancel records that a call Allow let through ended without telling
+// anything about the other side, such as when the caller gave up. It
+// counts as neither a success nor a failure, but frees the probe.
+func (b *Breaker) Cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// Do calls fn if the breaker allows it, and records its result.
+func (b *Breaker) Do(fn func() error) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+	err := fn()
+	b.Done(err)
+	return err
+}
+
+// DemonstrateBreaker fails a breaker's calls until it opens, and watches
+// it probe and close again on a fake clock.
+func DemonstrateBreaker() {
+	fmt.Println("=== Circuit breaker ===")
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := &Breaker{
+		Threshold: 3, Cooldown: 10 * time.Second, Probes: 2,
+		Now:      func() time.Time { return now },
+		OnChange: func(from, to State) { fmt.Printf("  %v -> %v\n", from, to) },
+	}
+	down := errors.New("connection refused")
+	fail := func() error { return down }
+	ok := func() error { return nil }
+
+	for i := 1; i <= 5; i++ {
+		fmt.Printf("call %d: %v\n", i, b.Do(fail))
+	}
+	now = now.Add(10 * time.Second)
+	fmt.Println("probe:", b.Do(fail))
+	now = now.Add(10 * time.Second)
+	fmt.Println("probe:", b.Do(ok))
+	fmt.Println("probe:", b.Do(ok))
+	fmt.Println("call:", b.Do(ok), b.State())
+}
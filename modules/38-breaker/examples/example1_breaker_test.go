@@ -0,0 +1,120 @@
+package examples
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateBreaker(t *testing.T) {
+	DemonstrateBreaker()
+}
+
+var errDown = errors.New("down")
+
+// step is one thing that happens to a breaker in a transition test.
+type step struct {
+	wait  time.Duration // time to pass first
+	call  error         // the call's result, if Allow lets it through
+	allow bool          // whether Allow lets it through
+	state State         // the state after the call
+}
+
+func TestBreakerTransitions(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"successes keep it closed", []step{
+			{call: nil, allow: true, state: Closed},
+			{call: nil, allow: true, state: Closed},
+		}},
+		{"threshold failures in a row open it", []step{
+			{call: errDown, allow: true, state: Closed},
+			{call: errDown, allow: true, state: Closed},
+			{call: errDown, allow: true, state: Open},
+			{call: nil, allow: false, state: Open},
+		}},
+		{"a success resets the count", []step{
+			{call: errDown, allow: true, state: Closed},
+			{call: errDown, allow: true, state: Closed},
+			{call: nil, allow: true, state: Closed},
+			{call: errDown, allow: true, state: Closed},
+			{call: errDown, allow: true, state: Closed},
+		}},
+		{"open until the cooldown", []step{
+			{call: errDown, allow: true}, {call: errDown, allow: true}, {call: errDown, allow: true, state: Open},
+			{wait: 9 * time.Second, allow: false, state: Open},
+			{wait: time.Second, call: nil, allow: true, state: HalfOpen},
+		}},
+		{"probes close it", []step{
+			{call: errDown, allow: true}, {call: errDown, allow: true}, {call: errDown, allow: true, state: Open},
+			{wait: 10 * time.Second, call: nil, allow: true, state: HalfOpen},
+			{call: nil, allow: true, state: Closed},
+			{call: errDown, allow: true, state: Closed},
+		}},
+		{"a failed probe reopens it for another cooldown", []step{
+			{call: errDown, allow: true}, {call: errDown, allow: true}, {call: errDown, allow: true, state: Open},
+			{wait: 10 * time.Second, call: nil, allow: true, state: HalfOpen},
+			{call: errDown, allow: true, state: Open},
+			{wait: 9 * time.Second, allow: false, state: Open},
+			{wait: time.Second, call: nil, allow: true, state: HalfOpen},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			b := &Breaker{Threshold: 3, Cooldown: 10 * time.Second, Probes: 2, Now: func() time.Time { return now }}
+			for i, s := range tt.steps {
+				now = now.Add(s.wait)
+				err := b.Do(func() error { return s.call })
+				if s.allow {
+					assert.NotErrorIs(t, err, ErrOpen, "step %d", i+1)
+				} else {
+					assert.ErrorIs(t, err, ErrOpen, "step %d", i+1)
+				}
+				assert.Equal(t, s.state, b.State(), "step %d", i+1)
+			}
+		})
+	}
+}
+
+func TestBreakerOneProbeAtATime(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &Breaker{Threshold: 1, Cooldown: time.Second, Probes: 1, Now: func() time.Time { return now }}
+	b.Do(func() error { return errDown })
+	now = now.Add(time.Second)
+
+	assert.NoError(t, b.Allow())
+	assert.ErrorIs(t, b.Allow(), ErrOpen, "a second probe while the first is in flight")
+	b.Done(nil)
+	assert.Equal(t, Closed, b.State())
+	assert.NoError(t, b.Allow())
+}
+
+func TestCancelFreesProbe(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &Breaker{Threshold: 1, Cooldown: time.Second, Probes: 1, Now: func() time.Time { return now }}
+	b.Do(func() error { return errDown })
+	now = now.Add(time.Second)
+
+	assert.NoError(t, b.Allow())
+	b.Cancel()
+	assert.Equal(t, HalfOpen, b.State(), "a canceled probe is not a success")
+	assert.NoError(t, b.Allow(), "the canceled probe still holds the slot")
+}
+
+func TestOnChange(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var changes []string
+	b := &Breaker{
+		Threshold: 1, Cooldown: time.Second, Probes: 1, Now: func() time.Time { return now },
+		OnChange: func(from, to State) { changes = append(changes, from.String()+">"+to.String()) },
+	}
+	b.Do(func() error { return errDown })
+	now = now.Add(time.Second)
+	b.Do(func() error { return nil })
+	assert.Equal(t, []string{"closed>open", "open>half-open", "half-open>closed"}, changes)
+}
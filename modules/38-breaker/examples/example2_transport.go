@@ -0,0 +1,128 @@
+package examples
+
+// This file shows:
+// - A breaker in front of a server, as an http.RoundTripper, so that any
+//   http.Client can use it
+// - What counts as a failure: network errors and 5xx, which say the
+//   server is in trouble, not 4xx, which say the request was
+// - Not blaming the server for the caller's own cancellation
+// - A breaker per host, so that one server that is down does not stop
+//   calls to the others
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Transport sends requests through Base while their host's breaker
+// allows them.
+type Transport struct {
+	// New returns the breaker of a host the Transport has not seen
+	// before.
+	New func(host string) *Breaker
+	// Base sends the requests. Nil means http.DefaultTransport.
+	Base http.RoundTripper
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+// Breaker returns host's breaker.
+func (t *Transport) Breaker(host string) *Breaker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.breakers == nil {
+		t.breakers = make(map[string]*Breaker)
+	}
+	b, ok := t.breakers[host]
+	if !ok {
+		b = t.New(host)
+		t.breakers[host] = b
+	}
+	return b
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	b := t.Breaker(req.URL.Host)
+	if err := b.Allow(); err != nil {
+		// A RoundTripper must close the body, even of a request it does
+		// not send.
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf("%s: %w", req.URL.Host, err)
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		// The caller gave up; the server may be fine.
+		b.Cancel()
+	case err != nil:
+		b.Done(err)
+	case resp.StatusCode >= 500:
+		b.Done(fmt.Errorf("HTTP %d", resp.StatusCode))
+	default:
+		b.Done(nil)
+	}
+	return resp, err
+}
+
+// DemonstrateTransport calls a server that goes down and comes back,
+// through a client with a breaker.
+func DemonstrateTransport() {
+	fmt.Println("=== Circuit breaker transport ===")
+	var down atomic.Bool
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if down.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tr := &Transport{Base: srv.Client().Transport, New: func(host string) *Breaker {
+		return &Breaker{Threshold: 3, Cooldown: 30 * time.Second, Probes: 1, Now: func() time.Time { return now }}
+	}}
+	client := &http.Client{Transport: tr}
+	get := func() string {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			if errors.Is(err, ErrOpen) {
+				return "rejected by the breaker"
+			}
+			return err.Error()
+		}
+		resp.Body.Close()
+		return resp.Status
+	}
+
+	down.Store(true)
+	for i := 0; i < 6; i++ {
+		fmt.Println(get())
+	}
+	fmt.Printf("the server saw %d of 6 requests\n", hits.Load())
+
+	down.Store(false)
+	now = now.Add(30 * time.Second)
+	fmt.Println("after the cooldown:", get())
+	fmt.Println("breaker:", tr.Breaker(srv.Listener.Addr().String()).State())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	_, err := client.Do(req)
+	fmt.Println("canceled:", errors.Is(err, context.Canceled), "breaker:", tr.Breaker(srv.Listener.Addr().String()).State())
+}
@@ -0,0 +1,89 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateTransport(t *testing.T) {
+	DemonstrateTransport()
+}
+
+// statusServer answers every request with the status in *status.
+func statusServer(t *testing.T, status *int) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(*status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTransport(srv *httptest.Server) *Transport {
+	return &Transport{Base: srv.Client().Transport, New: func(string) *Breaker {
+		return &Breaker{Threshold: 2, Cooldown: time.Hour, Probes: 1}
+	}}
+}
+
+func get(t *testing.T, client *http.Client, url string) error {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err == nil {
+		resp.Body.Close()
+	}
+	return err
+}
+
+func TestTransportOpensOnServerErrors(t *testing.T) {
+	status := http.StatusInternalServerError
+	srv := statusServer(t, &status)
+	client := &http.Client{Transport: newTransport(srv)}
+	require.NoError(t, get(t, client, srv.URL))
+	require.NoError(t, get(t, client, srv.URL))
+	assert.ErrorIs(t, get(t, client, srv.URL), ErrOpen)
+}
+
+func TestTransportIgnoresClientErrors(t *testing.T) {
+	status := http.StatusNotFound
+	srv := statusServer(t, &status)
+	tr := newTransport(srv)
+	client := &http.Client{Transport: tr}
+	for i := 0; i < 5; i++ {
+		require.NoError(t, get(t, client, srv.URL))
+	}
+	assert.Equal(t, Closed, tr.Breaker(srv.Listener.Addr().String()).State())
+}
+
+func TestTransportIgnoresCancellation(t *testing.T) {
+	status := http.StatusOK
+	srv := statusServer(t, &status)
+	tr := newTransport(srv)
+	client := &http.Client{Transport: tr}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 5; i++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+		_, err = client.Do(req)
+		require.True(t, errors.Is(err, context.Canceled), "%v", err)
+	}
+	assert.Equal(t, Closed, tr.Breaker(srv.Listener.Addr().String()).State())
+}
+
+func TestTransportBreakerPerHost(t *testing.T) {
+	bad, good := http.StatusBadGateway, http.StatusOK
+	down, up := statusServer(t, &bad), statusServer(t, &good)
+	tr := newTransport(down)
+	client := &http.Client{Transport: tr}
+	for i := 0; i < 3; i++ {
+		get(t, client, down.URL)
+	}
+	assert.ErrorIs(t, get(t, client, down.URL), ErrOpen)
+	assert.NoError(t, get(t, client, up.URL))
+}
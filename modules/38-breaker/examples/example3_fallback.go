@@ -0,0 +1,103 @@
+package examples
+
+// This file shows:
+// - A fallback for when the breaker is open: the last good value, marked
+//   as stale, instead of an error
+// - Why retries and breakers go together: a retry loop that stops on
+//   ErrOpen sends no more than the breaker allows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Cached fetches a value through a breaker, and falls back to the last
+// value it fetched when the fetch fails.
+type Cached[T any] struct {
+	Breaker *Breaker
+	Fetch   func(ctx context.Context) (T, error)
+
+	mu   sync.Mutex
+	last T
+	have bool // whether last is a fetched value
+}
+
+// Get returns a fresh value, or the last good one, marked stale. It
+// fails only if there has never been a good one.
+func (c *Cached[T]) Get(ctx context.Context) (v T, stale bool, err error) {
+	err = c.Breaker.Allow()
+	if err == nil {
+		v, err = c.Fetch(ctx)
+		if ctx.Err() != nil {
+			c.Breaker.Cancel()
+		} else {
+			c.Breaker.Done(err)
+		}
+		if err == nil {
+			c.mu.Lock()
+			c.last, c.have = v, true
+			c.mu.Unlock()
+			return v, false, nil
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.have {
+		var zero T
+		return zero, false, err
+	}
+	return c.last, true, nil
+}
+
+// RetryBreaker calls fn through b up to attempts times, and stops as
+// soon as the breaker opens: retrying against an open breaker only
+// waits to fail.
+func RetryBreaker(ctx context.Context, b *Breaker, attempts int, delay time.Duration, fn func(ctx context.Context) error) error {
+	var err error
+	for n := 0; n < attempts; n++ {
+		if n > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+		if err = b.Do(func() error { return fn(ctx) }); err == nil || errors.Is(err, ErrOpen) {
+			return err
+		}
+	}
+	return err
+}
+
+// DemonstrateFallback serves exchange rates from a source that fails.
+func DemonstrateFallback() {
+	fmt.Println("=== Fallbacks ===")
+	fail := false
+	c := &Cached[float64]{
+		Breaker: &Breaker{Threshold: 2, Cooldown: time.Minute, Probes: 1},
+		Fetch: func(context.Context) (float64, error) {
+			if fail {
+				return 0, errors.New("rates service unavailable")
+			}
+			return 1.08, nil
+		},
+	}
+	for i := 0; i < 4; i++ {
+		if i == 1 {
+			fail = true
+		}
+		v, stale, err := c.Get(context.Background())
+		fmt.Printf("rate %.2f, stale %t, err %v, breaker %v\n", v, stale, err, c.Breaker.State())
+	}
+
+	calls := 0
+	err := RetryBreaker(context.Background(), &Breaker{Threshold: 2, Cooldown: time.Minute, Probes: 1}, 10, time.Millisecond,
+		func(context.Context) error {
+			calls++
+			return errors.New("timeout")
+		})
+	fmt.Printf("retries: %d calls of 10, then %v\n", calls, err)
+}
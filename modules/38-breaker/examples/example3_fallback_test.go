@@ -0,0 +1,53 @@
+package examples
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateFallback(t *testing.T) {
+	DemonstrateFallback()
+}
+
+func TestCached(t *testing.T) {
+	var err error
+	c := &Cached[string]{
+		Breaker: &Breaker{Threshold: 1, Cooldown: time.Hour, Probes: 1},
+		Fetch: func(context.Context) (string, error) {
+			return "fresh", err
+		},
+	}
+	err = errDown
+	_, _, gerr := c.Get(context.Background())
+	assert.ErrorIs(t, gerr, errDown, "nothing to fall back to")
+
+	c.Breaker = &Breaker{Threshold: 1, Cooldown: time.Hour, Probes: 1}
+	err = nil
+	v, stale, gerr := c.Get(context.Background())
+	assert.NoError(t, gerr)
+	assert.Equal(t, "fresh", v)
+	assert.False(t, stale)
+
+	err = errDown
+	for i := 0; i < 2; i++ {
+		v, stale, gerr = c.Get(context.Background())
+		assert.NoError(t, gerr)
+		assert.Equal(t, "fresh", v)
+		assert.True(t, stale)
+	}
+	assert.Equal(t, Open, c.Breaker.State())
+}
+
+func TestRetryBreakerStopsWhenOpen(t *testing.T) {
+	b := &Breaker{Threshold: 3, Cooldown: time.Hour, Probes: 1}
+	calls := 0
+	err := RetryBreaker(context.Background(), b, 10, 0, func(context.Context) error {
+		calls++
+		return errDown
+	})
+	assert.ErrorIs(t, err, ErrOpen)
+	assert.Equal(t, 3, calls)
+}
@@ -0,0 +1,97 @@
+package exercises
+
+// EXERCISE: Fix a circuit breaker that never leaves the open state.
+// A CircuitBreaker opens after MaxFailures failures in a row, and should
+// let a probe call through once ResetTimeout has passed: a success
+// closes it, and a failure opens it again. But once this one opens, it
+// stays open, and the fixes for that uncover more: when it opened is
+// never recorded, a failed probe leaves it stuck, and the failures from
+// before it opened still count after it closes.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// State is a CircuitBreaker's state.
+type State int
+
+const (
+	// StateClosed calls fn, and counts failures.
+	StateClosed State = iota
+	// StateOpen returns ErrCircuitOpen without calling fn.
+	StateOpen
+	// StateHalfOpen is a probe call in flight.
+	StateHalfOpen
+)
+
+// ErrCircuitOpen is returned by Call without calling fn.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// CircuitBreaker stops calling a function that keeps failing, and tries
+// it again after a while.
+type CircuitBreaker struct {
+	// MaxFailures is how many failures in a row open the breaker.
+	MaxFailures int
+	// ResetTimeout is how long it stays open before the next call is
+	// let through as a probe.
+	ResetTimeout time.Duration
+	// Now returns the current time. Nil means time.Now.
+	Now func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+}
+
+func (cb *CircuitBreaker) now() time.Time {
+	if cb.Now == nil {
+		return time.Now()
+	}
+	return cb.Now()
+}
+
+// State returns the breaker's state.
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// Call calls fn, unless the breaker is open, or half-open with a probe
+// already in flight.
+func (cb *CircuitBreaker) Call(fn func() error) error {
+	cb.mu.Lock()
+	switch cb.state {
+	case StateOpen:
+		// BUG: Open, whatever the time.
+		cb.mu.Unlock()
+		return ErrCircuitOpen
+	case StateHalfOpen:
+		cb.mu.Unlock()
+		return ErrCircuitOpen
+	}
+	cb.mu.Unlock()
+
+	err := fn()
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if err != nil {
+		cb.failures++
+		// BUG: A failed probe is one failure, which is short of
+		// MaxFailures, and leaves the breaker half-open.
+		if cb.failures >= cb.MaxFailures {
+			// BUG: openedAt is never set, so ResetTimeout counts from the
+			// zero time.
+			cb.state, cb.failures = StateOpen, 0
+		}
+		return err
+	}
+	// BUG: The failures before this success still count.
+	cb.state = StateClosed
+	return nil
+}
@@ -0,0 +1,154 @@
+package exercises
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitTransitions(t *testing.T) {
+	down := errors.New("down")
+	tests := []struct {
+		name   string
+		wait   time.Duration // after tripping the circuit
+		probe  error
+		after  time.Duration // after the probe
+		allow  bool          // whether Allow lets a request through then
+		states [2]string     // after the probe, and after Allow
+	}{
+		{"open before the cooldown", 59 * time.Second, nil, 0, false, [2]string{"open", "open"}},
+		{"successful probe closes", time.Minute, nil, 0, true, [2]string{"closed", "closed"}},
+		{"failed probe reopens", time.Minute, down, 0, false, [2]string{"open", "open"}},
+		{"failed probe restarts the cooldown", time.Minute, down, 59 * time.Second, false, [2]string{"open", "open"}},
+		{"probe again after another cooldown", time.Minute, down, time.Minute, true, [2]string{"open", "half-open"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			c := &Circuit{Threshold: 2, Cooldown: time.Minute, Now: func() time.Time { return now }}
+			for i := 0; i < 2; i++ {
+				require.NoError(t, c.Allow())
+				c.Report(down)
+			}
+			require.Equal(t, "open", c.State())
+
+			now = now.Add(tt.wait)
+			if err := c.Allow(); err == nil {
+				c.Report(tt.probe)
+			}
+			assert.Equal(t, tt.states[0], c.State())
+			now = now.Add(tt.after)
+			assert.Equal(t, tt.allow, c.Allow() == nil)
+			assert.Equal(t, tt.states[1], c.State())
+		})
+	}
+}
+
+func TestCircuitOneProbeAtATime(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := &Circuit{Threshold: 1, Cooldown: time.Minute, Now: func() time.Time { return now }}
+	c.Allow()
+	c.Report(errors.New("down"))
+	now = now.Add(time.Minute)
+
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if c.Allow() == nil {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), allowed.Load(), "requests let through to a server that may still be down")
+}
+
+// guardedServer returns a client through a Circuit, to a server that
+// answers with *status.
+func guardedServer(t *testing.T, status *atomic.Int32) (*http.Client, *Circuit, string) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	t.Cleanup(srv.Close)
+	c := &Circuit{Threshold: 2, Cooldown: time.Hour}
+	return &http.Client{Transport: &Guarded{Circuit: c, Base: srv.Client().Transport}}, c, srv.URL
+}
+
+func TestGuardedServerErrors(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	client, c, url := guardedServer(t, &status)
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(url)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	_, err := client.Get(url)
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, "open", c.State())
+}
+
+func TestGuardedClientErrors(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusNotFound)
+	client, c, url := guardedServer(t, &status)
+	for i := 0; i < 5; i++ {
+		resp, err := client.Get(url)
+		require.NoError(t, err, "a 404 opened the circuit")
+		resp.Body.Close()
+	}
+	assert.Equal(t, "closed", c.State())
+}
+
+func TestGuardedCancellation(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	client, c, url := guardedServer(t, &status)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		require.NoError(t, err)
+		_, err = client.Do(req)
+		require.ErrorIs(t, err, context.Canceled)
+	}
+	assert.Equal(t, "closed", c.State(), "canceled requests opened the circuit")
+}
+
+func TestGuardedCanceledProbe(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusBadGateway)
+	client, c, url := guardedServer(t, &status)
+	now := time.Now()
+	c.Now = func() time.Time { return now }
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(url)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	now = now.Add(time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	require.ErrorIs(t, err, context.Canceled)
+
+	status.Store(http.StatusOK)
+	resp, err := client.Get(url)
+	require.NoError(t, err, "a canceled probe kept the circuit from probing again")
+	resp.Body.Close()
+	assert.Equal(t, "closed", c.State())
+}
@@ -0,0 +1,94 @@
+package solutions
+
+// SOLUTION: Fix a circuit breaker that never leaves the open state.
+// Call lets a probe through once ResetTimeout has passed since the
+// breaker opened, which it records. A failed probe reopens the breaker,
+// and a success closes it and clears the failure count.
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// State is a CircuitBreaker's state.
+type State int
+
+const (
+	// StateClosed calls fn, and counts failures.
+	StateClosed State = iota
+	// StateOpen returns ErrCircuitOpen without calling fn.
+	StateOpen
+	// StateHalfOpen is a probe call in flight.
+	StateHalfOpen
+)
+
+// ErrCircuitOpen is returned by Call without calling fn.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// CircuitBreaker stops calling a function that keeps failing, and tries
+// it again after a while.
+type CircuitBreaker struct {
+	// MaxFailures is how many failures in a row open the breaker.
+	MaxFailures int
+	// ResetTimeout is how long it stays open before the next call is
+	// let through as a probe.
+	ResetTimeout time.Duration
+	// Now returns the current time. Nil means time.Now.
+	Now func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+}
+
+func (cb *CircuitBreaker) now() time.Time {
+	if cb.Now == nil {
+		return time.Now()
+	}
+	return cb.Now()
+}
+
+// State returns the breaker's state.
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// Call calls fn, unless the breaker is open, or half-open with a probe
+// already in flight.
+func (cb *CircuitBreaker) Call(fn func() error) error {
+	cb.mu.Lock()
+	switch cb.state {
+	case StateOpen:
+		// Fixed: After ResetTimeout, let a probe through.
+		if cb.now().Sub(cb.openedAt) < cb.ResetTimeout {
+			cb.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		cb.state = StateHalfOpen
+	case StateHalfOpen:
+		cb.mu.Unlock()
+		return ErrCircuitOpen
+	}
+	cb.mu.Unlock()
+
+	err := fn()
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if err != nil {
+		cb.failures++
+		// Fixed: A failed probe reopens the breaker, whatever the count.
+		if cb.state == StateHalfOpen || cb.failures >= cb.MaxFailures {
+			// Fixed: Record when, for the timeout to count from.
+			cb.state, cb.failures, cb.openedAt = StateOpen, 0, cb.now()
+		}
+		return err
+	}
+	// Fixed: A success ends the run of failures.
+	cb.state, cb.failures = StateClosed, 0
+	return nil
+}
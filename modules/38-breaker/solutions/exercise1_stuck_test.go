@@ -0,0 +1,84 @@
+package solutions
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errFail = errors.New("fail")
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	type step struct {
+		wait    time.Duration
+		fail    bool
+		want    error
+		state   State
+		comment string
+	}
+	trip := []step{
+		{fail: true, want: errFail, state: StateClosed},
+		{fail: true, want: errFail, state: StateClosed},
+		{fail: true, want: errFail, state: StateOpen},
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"opens after MaxFailures", append(trip[:3:3],
+			step{want: ErrCircuitOpen, state: StateOpen},
+		)},
+		{"stays open until the timeout", append(trip[:3:3],
+			step{wait: 59 * time.Second, want: ErrCircuitOpen, state: StateOpen, comment: "opened a minute ago"},
+		)},
+		{"closes after a successful probe", append(trip[:3:3],
+			step{wait: time.Minute, state: StateClosed, comment: "the probe succeeded"},
+			step{state: StateClosed},
+		)},
+		{"reopens after a failed probe", append(trip[:3:3],
+			step{wait: time.Minute, fail: true, want: errFail, state: StateOpen, comment: "the probe failed"},
+			step{wait: 30 * time.Second, want: ErrCircuitOpen, state: StateOpen},
+			step{wait: 30 * time.Second, state: StateClosed},
+		)},
+		{"counts failures in a row", append(trip[:2:2],
+			step{state: StateClosed},
+			step{fail: true, want: errFail, state: StateClosed},
+			step{fail: true, want: errFail, state: StateClosed},
+		)},
+		{"starts counting again after closing", append(trip[:3:3],
+			step{wait: time.Minute, state: StateClosed},
+			step{fail: true, want: errFail, state: StateClosed, comment: "one failure after recovering"},
+		)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			cb := &CircuitBreaker{MaxFailures: 3, ResetTimeout: time.Minute, Now: func() time.Time { return now }}
+			for i, s := range tt.steps {
+				now = now.Add(s.wait)
+				err := cb.Call(func() error {
+					if s.fail {
+						return errFail
+					}
+					return nil
+				})
+				assert.Equal(t, s.want, err, "step %d %s", i+1, s.comment)
+				assert.Equal(t, s.state, cb.State(), "step %d %s", i+1, s.comment)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerOneProbe(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cb := &CircuitBreaker{MaxFailures: 1, ResetTimeout: time.Minute, Now: func() time.Time { return now }}
+	cb.Call(func() error { return errFail })
+	now = now.Add(time.Minute)
+	cb.Call(func() error {
+		assert.Equal(t, ErrCircuitOpen, cb.Call(func() error { return nil }), "a second probe")
+		return nil
+	})
+	assert.Equal(t, StateClosed, cb.State())
+}
@@ -0,0 +1,144 @@
+package solutions
+
+// SOLUTION: Fix an HTTP client's circuit breaker that trips on the wrong errors and floods a recovering server.
+// Allow lets one probe through at a time in half-open, a failed probe
+// restarts the cooldown, and the transport counts only network errors
+// and 5xx as failures, releasing the probe when the caller cancels.
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrUnavailable is returned for requests the circuit does not let
+// through.
+var ErrUnavailable = errors.New("service unavailable: circuit open")
+
+type phase int
+
+const (
+	closed phase = iota
+	open
+	halfOpen
+)
+
+// Circuit is a circuit breaker for one server. It is safe for
+// concurrent use.
+type Circuit struct {
+	// Threshold is how many failures in a row open the circuit.
+	Threshold int
+	// Cooldown is how long it stays open before a probe.
+	Cooldown time.Duration
+	// Now returns the current time. Nil means time.Now.
+	Now func() time.Time
+
+	mu       sync.Mutex
+	phase    phase
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func (c *Circuit) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// State returns "closed", "open", or "half-open".
+func (c *Circuit) State() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return [...]string{"closed", "open", "half-open"}[c.phase]
+}
+
+// Allow returns nil if a request may go ahead, and ErrUnavailable if
+// not. A request that Allow lets through must be followed by Report or
+// Release.
+func (c *Circuit) Allow() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.phase == open {
+		if c.now().Sub(c.openedAt) < c.Cooldown {
+			return ErrUnavailable
+		}
+		c.phase = halfOpen
+	}
+	if c.phase == halfOpen {
+		// Fixed: One probe at a time. A server that has just come back
+		// is the one least able to take every waiting request at once.
+		if c.probing {
+			return ErrUnavailable
+		}
+		c.probing = true
+	}
+	return nil
+}
+
+// Report records whether a request failed.
+func (c *Circuit) Report(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.phase == halfOpen {
+		c.probing = false
+		if err != nil {
+			// Fixed: A failed probe starts a new cooldown.
+			c.phase, c.openedAt = open, c.now()
+		} else {
+			c.phase, c.failures = closed, 0
+		}
+		return
+	}
+	if err == nil {
+		c.failures = 0
+		return
+	}
+	c.failures++
+	if c.failures >= c.Threshold {
+		c.phase, c.openedAt = open, c.now()
+	}
+}
+
+// Release records that a request ended without a result, such as when
+// the caller canceled it.
+func (c *Circuit) Release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.probing = false
+}
+
+// Guarded is an http.RoundTripper that sends requests through Base
+// while Circuit allows them.
+type Guarded struct {
+	Circuit *Circuit
+	Base    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (g *Guarded) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := g.Circuit.Allow(); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	resp, err := g.Base.RoundTrip(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		// Fixed: The caller canceled; that says nothing about the
+		// server.
+		g.Circuit.Release()
+	case err != nil:
+		g.Circuit.Report(err)
+	// Fixed: Only 5xx. A 404 or 400 is the server working.
+	case resp.StatusCode >= 500:
+		g.Circuit.Report(fmt.Errorf("HTTP %d", resp.StatusCode))
+	default:
+		g.Circuit.Report(nil)
+	}
+	return resp, err
+}
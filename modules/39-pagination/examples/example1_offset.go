@@ -0,0 +1,146 @@
+// Package examples demonstrates pagination in Go: offset pages, keyset
+// pages with opaque cursors, and iterators that fetch pages as they are
+// needed, all over a generic in-memory Repository that stands in for a
+// database table.
+//
+// This file shows:
+// - A generic Repository, ordered by ID, as a database table would be by its primary key
+// - Offset pagination: LIMIT and OFFSET, page numbers, and a total count
+// - How offset pages repeat and skip rows when rows are inserted or deleted between requests
+package examples
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Repository holds rows of type T, ordered by the IDs that id returns.
+// It is safe for concurrent use.
+type Repository[T any] struct {
+	id func(T) int64
+
+	mu   sync.RWMutex
+	rows []T // sorted by id
+}
+
+// NewRepository returns an empty repository of rows whose IDs id returns.
+func NewRepository[T any](id func(T) int64) *Repository[T] {
+	return &Repository[T]{id: id}
+}
+
+// find returns where the row with ID id is, or would be.
+func (r *Repository[T]) find(id int64) (int, bool) {
+	i := sort.Search(len(r.rows), func(i int) bool { return r.id(r.rows[i]) >= id })
+	return i, i < len(r.rows) && r.id(r.rows[i]) == id
+}
+
+// Put inserts row, or replaces the row with its ID.
+func (r *Repository[T]) Put(row T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i, ok := r.find(r.id(row))
+	if ok {
+		r.rows[i] = row
+		return
+	}
+	r.rows = slices.Insert(r.rows, i, row)
+}
+
+// Delete deletes the row with ID id, if there is one.
+func (r *Repository[T]) Delete(id int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i, ok := r.find(id); ok {
+		r.rows = slices.Delete(r.rows, i, i+1)
+	}
+}
+
+// Count returns the number of rows.
+func (r *Repository[T]) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.rows)
+}
+
+// Offset returns up to limit rows, skipping the first offset: SELECT ...
+// ORDER BY id LIMIT limit OFFSET offset. A database still reads the rows
+// it skips, so deep pages get slower.
+func (r *Repository[T]) Offset(offset, limit int) []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if offset < 0 || offset >= len(r.rows) || limit <= 0 {
+		return nil
+	}
+	end := min(offset+limit, len(r.rows))
+	return slices.Clone(r.rows[offset:end])
+}
+
+// OffsetPage is a page of rows by number, as an API would return it.
+type OffsetPage[T any] struct {
+	Items []T `json:"items"`
+	// Page is the page number, from 1.
+	Page int `json:"page"`
+	// Pages is the number of pages, counting a last partial one.
+	Pages int `json:"pages"`
+	Total int `json:"total"`
+}
+
+// GetPage returns page number page, of size rows, from 1.
+func GetPage[T any](r *Repository[T], page, size int) OffsetPage[T] {
+	total := r.Count()
+	return OffsetPage[T]{
+		Items: r.Offset((page-1)*size, size),
+		Page:  page,
+		Pages: (total + size - 1) / size,
+		Total: total,
+	}
+}
+
+// Article is the row type of the examples.
+type Article struct {
+	ID    int64
+	Title string
+}
+
+func articleID(a Article) int64 { return a.ID }
+
+// newArticles returns a repository of n articles, with IDs 1 to n.
+func newArticles(n int) *Repository[Article] {
+	r := NewRepository(articleID)
+	for i := 1; i <= n; i++ {
+		r.Put(Article{ID: int64(i), Title: fmt.Sprintf("Article %d", i)})
+	}
+	return r
+}
+
+func ids(as []Article) []int64 {
+	out := make([]int64, len(as))
+	for i, a := range as {
+		out[i] = a.ID
+	}
+	return out
+}
+
+// DemonstrateOffset pages through a repository while rows are deleted
+// and inserted.
+func DemonstrateOffset() {
+	fmt.Println("=== Offset pagination ===")
+	r := newArticles(10)
+	p := GetPage(r, 1, 4)
+	fmt.Printf("page %d of %d: %v (total %d)\n", p.Page, p.Pages, ids(p.Items), p.Total)
+
+	// Article 2 is deleted before page 2 is fetched: everything after it
+	// moves up by one, and article 5 is never seen.
+	r.Delete(2)
+	p = GetPage(r, 2, 4)
+	fmt.Printf("page %d after a delete: %v\n", p.Page, ids(p.Items))
+
+	// Two new rows sort before the page, and everything moves down by
+	// two: articles 8 and 9 are seen twice.
+	r.Put(Article{ID: 0, Title: "Pinned"})
+	r.Put(Article{ID: -1, Title: "Pinned too"})
+	p = GetPage(r, 3, 4)
+	fmt.Printf("page %d after two inserts: %v\n", p.Page, ids(p.Items))
+}
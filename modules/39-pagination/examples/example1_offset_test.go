@@ -0,0 +1,45 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateOffset(t *testing.T) {
+	DemonstrateOffset()
+}
+
+func TestRepository(t *testing.T) {
+	r := NewRepository(articleID)
+	for _, id := range []int64{5, 1, 3} {
+		r.Put(Article{ID: id})
+	}
+	r.Put(Article{ID: 3, Title: "replaced"})
+	assert.Equal(t, []int64{1, 3, 5}, ids(r.Offset(0, 10)))
+	assert.Equal(t, "replaced", r.Offset(1, 1)[0].Title)
+	r.Delete(1)
+	r.Delete(42)
+	assert.Equal(t, 2, r.Count())
+}
+
+func TestGetPage(t *testing.T) {
+	r := newArticles(10)
+	tests := []struct {
+		page  int
+		want  []int64
+		pages int
+	}{
+		{1, []int64{1, 2, 3, 4}, 3},
+		{2, []int64{5, 6, 7, 8}, 3},
+		{3, []int64{9, 10}, 3},
+		{4, []int64{}, 3},
+		{0, []int64{}, 3},
+	}
+	for _, tt := range tests {
+		p := GetPage(r, tt.page, 4)
+		assert.Equal(t, tt.want, ids(p.Items), "page %d", tt.page)
+		assert.Equal(t, tt.pages, p.Pages)
+		assert.Equal(t, 10, p.Total)
+	}
+}
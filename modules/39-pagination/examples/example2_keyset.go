@@ -0,0 +1,111 @@
+package examples
+
+// This file shows:
+// - Keyset pagination: WHERE id > last ORDER BY id LIMIT n, which
+//   neither repeats nor skips rows when others are inserted or deleted
+// - Fetching one row more than the page, to know whether there is a
+//   next page without counting
+// - Opaque cursors: base64-encoded JSON that clients pass back
+//   unchanged, and that the server can change the shape of later
+// - Rejecting a cursor that does not decode, instead of starting over
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// After returns up to limit rows with IDs greater than after: SELECT ...
+// WHERE id > after ORDER BY id LIMIT limit. A database finds the first
+// row with the primary key's index, however deep the page.
+func (r *Repository[T]) After(after int64, limit int) []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i, ok := r.find(after)
+	if ok {
+		i++
+	}
+	end := min(i+max(limit, 0), len(r.rows))
+	return slices.Clone(r.rows[i:end])
+}
+
+// ErrBadCursor is returned for a cursor that this server did not make.
+var ErrBadCursor = errors.New("invalid cursor")
+
+// Cursor is where a page ends.
+type Cursor struct {
+	After int64 `json:"after"`
+}
+
+// Encode returns c as an opaque string.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor decodes a cursor from Encode. The empty string is the
+// start.
+func DecodeCursor(s string) (Cursor, error) {
+	if s == "" {
+		return Cursor{After: math.MinInt64}, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrBadCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return Cursor{}, ErrBadCursor
+	}
+	return c, nil
+}
+
+// KeysetPage is a page of rows and the cursor of the next one, as an API
+// would return it.
+type KeysetPage[T any] struct {
+	Items []T `json:"items"`
+	// Next is the cursor of the next page, or empty on the last one.
+	Next string `json:"next,omitempty"`
+}
+
+// GetKeyset returns up to limit rows after cursor.
+func GetKeyset[T any](r *Repository[T], cursor string, limit int) (KeysetPage[T], error) {
+	c, err := DecodeCursor(cursor)
+	if err != nil {
+		return KeysetPage[T]{}, err
+	}
+	// One more than the page: if it is there, so is a next page.
+	rows := r.After(c.After, limit+1)
+	p := KeysetPage[T]{Items: rows}
+	if len(rows) > limit {
+		p.Items = rows[:limit]
+		p.Next = Cursor{After: r.id(rows[limit-1])}.Encode()
+	}
+	return p, nil
+}
+
+// DemonstrateKeyset pages through the same changes as DemonstrateOffset,
+// with cursors.
+func DemonstrateKeyset() {
+	fmt.Println("=== Keyset pagination ===")
+	r := newArticles(10)
+	p, _ := GetKeyset(r, "", 4)
+	fmt.Printf("first page: %v, next %s\n", ids(p.Items), p.Next)
+
+	r.Delete(2)
+	r.Put(Article{ID: 0, Title: "Pinned"})
+	for p.Next != "" {
+		var err error
+		if p, err = GetKeyset(r, p.Next, 4); err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("next page: %v\n", ids(p.Items))
+	}
+
+	_, err := GetKeyset(r, "not-a-cursor", 4)
+	fmt.Println("bad cursor:", err)
+}
@@ -0,0 +1,58 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateKeyset(t *testing.T) {
+	DemonstrateKeyset()
+}
+
+// collect pages through r with GetKeyset, calling between after every
+// page, and returns every ID seen and the number of pages.
+func collect(t *testing.T, r *Repository[Article], limit int, between func()) ([]int64, int) {
+	t.Helper()
+	var seen []int64
+	cursor, pages := "", 0
+	for {
+		p, err := GetKeyset(r, cursor, limit)
+		require.NoError(t, err)
+		pages++
+		seen = append(seen, ids(p.Items)...)
+		if p.Next == "" {
+			return seen, pages
+		}
+		cursor = p.Next
+		between()
+	}
+}
+
+func TestKeysetPages(t *testing.T) {
+	seen, pages := collect(t, newArticles(8), 4, func() {})
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7, 8}, seen)
+	assert.Equal(t, 2, pages, "no empty page after a full last one")
+}
+
+func TestKeysetWithChanges(t *testing.T) {
+	r := newArticles(10)
+	n := 0
+	seen, _ := collect(t, r, 3, func() {
+		n++
+		r.Delete(int64(n))            // already seen
+		r.Put(Article{ID: -int64(n)}) // sorts before everything
+	})
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, seen, "every row once")
+}
+
+func TestDecodeCursor(t *testing.T) {
+	c, err := DecodeCursor(Cursor{After: 42}.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), c.After)
+	for _, bad := range []string{"!!", "bm90IGpzb24"} {
+		_, err := DecodeCursor(bad)
+		assert.ErrorIs(t, err, ErrBadCursor, bad)
+	}
+}
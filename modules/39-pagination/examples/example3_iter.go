@@ -0,0 +1,107 @@
+//go:build go1.23
+
+package examples
+
+// This file shows:
+// - iter.Seq: a results API that the caller ranges over, with the pages
+//   fetched inside it as the loop needs them
+// - Stopping when yield returns false, so that a loop that breaks early
+//   fetches no more pages
+// - iter.Seq2[T, error] for a source that can fail, such as an API with
+//   cursors
+//
+// Range-over-func needs Go 1.23. The //go:build line above sets this
+// file's language version, so the rest of the module builds with the
+// course's go 1.21.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+)
+
+// All returns every row of r in ID order, read a page of size rows at a
+// time. Rows inserted or deleted during the loop are seen or not as
+// with keyset pages: none twice, and none skipped that existed
+// throughout.
+func (r *Repository[T]) All(size int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		after := int64(math.MinInt64)
+		for {
+			rows := r.After(after, size)
+			for _, row := range rows {
+				if !yield(row) {
+					return
+				}
+			}
+			if len(rows) < size {
+				return
+			}
+			after = r.id(rows[len(rows)-1])
+		}
+	}
+}
+
+// Paginate returns the items of every page that fetch returns, starting
+// from the empty cursor and following Next. An error ends the sequence,
+// as its last pair.
+func Paginate[T any](ctx context.Context, fetch func(ctx context.Context, cursor string) (KeysetPage[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		cursor := ""
+		for {
+			if err := ctx.Err(); err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			p, err := fetch(ctx, cursor)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range p.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if p.Next == "" {
+				return
+			}
+			cursor = p.Next
+		}
+	}
+}
+
+// DemonstrateIterators ranges over a repository, breaking early, and
+// over an API that fails on its third page.
+func DemonstrateIterators() {
+	fmt.Println("=== Iterators ===")
+	r := newArticles(100)
+	for a := range r.All(10) {
+		if a.ID > 3 {
+			break
+		}
+		fmt.Println(a.Title)
+	}
+
+	fetches := 0
+	fetch := func(ctx context.Context, cursor string) (KeysetPage[Article], error) {
+		fetches++
+		if fetches == 3 {
+			return KeysetPage[Article]{}, errors.New("HTTP 503")
+		}
+		return GetKeyset(r, cursor, 10)
+	}
+	n := 0
+	for a, err := range Paginate(context.Background(), fetch) {
+		if err != nil {
+			fmt.Printf("after %d articles, up to %d: %v\n", n, n, err)
+			break
+		}
+		n = int(a.ID)
+	}
+	fmt.Println("fetches:", fetches)
+}
@@ -0,0 +1,73 @@
+//go:build go1.23
+
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateIterators(t *testing.T) {
+	DemonstrateIterators()
+}
+
+func TestAll(t *testing.T) {
+	r := newArticles(25)
+	var seen []int64
+	for a := range r.All(10) {
+		seen = append(seen, a.ID)
+	}
+	assert.Len(t, seen, 25)
+	assert.Equal(t, int64(25), seen[24])
+
+	seen = nil
+	for a := range newArticles(20).All(10) {
+		seen = append(seen, a.ID)
+	}
+	assert.Len(t, seen, 20, "an exact number of pages")
+}
+
+func TestPaginateStopsFetching(t *testing.T) {
+	r := newArticles(100)
+	fetches := 0
+	fetch := func(ctx context.Context, cursor string) (KeysetPage[Article], error) {
+		fetches++
+		return GetKeyset(r, cursor, 10)
+	}
+	for a, err := range Paginate(context.Background(), fetch) {
+		assert.NoError(t, err)
+		if a.ID == 15 {
+			break
+		}
+	}
+	assert.Equal(t, 2, fetches)
+}
+
+func TestPaginateError(t *testing.T) {
+	boom := errors.New("boom")
+	fetch := func(ctx context.Context, cursor string) (KeysetPage[Article], error) {
+		if cursor != "" {
+			return KeysetPage[Article]{}, boom
+		}
+		return KeysetPage[Article]{Items: []Article{{ID: 1}}, Next: "x"}, nil
+	}
+	var errs []error
+	n := 0
+	for _, err := range Paginate(context.Background(), fetch) {
+		n++
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []error{boom}, errs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range Paginate(ctx, fetch) {
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
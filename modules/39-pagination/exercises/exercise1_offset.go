@@ -0,0 +1,46 @@
+package exercises
+
+// EXERCISE: Fix an offset paginator that repeats and drops rows at page boundaries.
+// Paginate splits a blog's posts into numbered pages. But the first page
+// is never shown, the last post of each page is the first of the next,
+// the last partial page is missing, a page past the end panics, and
+// appending to a page changes the next one.
+// Fix the bugs marked with // BUG: comments.
+
+// Post is a row of a blog's posts.
+type Post struct {
+	ID    int
+	Title string
+}
+
+// Bounds returns the half-open range [start, end) of page, counted from
+// 1, in a list of total rows with size rows per page. Pages out of range
+// are empty.
+func Bounds(page, size, total int) (start, end int) {
+	if page < 1 || size < 1 {
+		return 0, 0
+	}
+	// BUG: pages count from 1, so page 1 starts at row size.
+	start = page * size
+	// BUG: end is already exclusive, and a page past the end starts past
+	// total.
+	end = min(start+size+1, total)
+	return start, end
+}
+
+// TotalPages returns how many pages of size rows total rows fill.
+func TotalPages(total, size int) int {
+	if size < 1 {
+		return 0
+	}
+	// BUG: integer division rounds down, and the last partial page is
+	// not counted.
+	return total / size
+}
+
+// Paginate returns page of posts, with size posts per page.
+func Paginate(posts []Post, page, size int) []Post {
+	start, end := Bounds(page, size, len(posts))
+	// BUG: the page's capacity runs on into the next page.
+	return posts[start:end]
+}
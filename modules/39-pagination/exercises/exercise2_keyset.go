@@ -0,0 +1,120 @@
+//go:build go1.23
+
+package exercises
+
+// EXERCISE: Fix a leaderboard's keyset pages, which skip players with the same score.
+// Page returns the entries after a cursor of the last entry's score and
+// ID, and All ranges over every page. But players with the same score as
+// the end of a page never appear, the last page is followed by an empty
+// one, a bad cursor starts over from the top, and All keeps going after
+// the loop that ranges over it stops.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"iter"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Entry is a player's score.
+type Entry struct {
+	ID     int64
+	Player string
+	Score  int
+}
+
+// ErrCursor is returned for a cursor that does not decode.
+var ErrCursor = errors.New("invalid cursor")
+
+// cursor is the last entry of a page.
+type cursor struct {
+	Score int   `json:"s"`
+	ID    int64 `json:"i"`
+}
+
+// before reports whether a comes before b on the leaderboard.
+func before(a, b Entry) bool {
+	// BUG: entries with the same score are in no order, and the entries
+	// after a cursor skip every one with its score.
+	return a.Score > b.Score
+}
+
+// Leaderboard is a game's entries, highest score first. It is safe for
+// concurrent use.
+type Leaderboard struct {
+	mu      sync.RWMutex
+	entries []Entry
+}
+
+// Add adds e to the leaderboard.
+func (l *Leaderboard) Add(e Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	i := sort.Search(len(l.entries), func(i int) bool { return before(e, l.entries[i]) })
+	l.entries = slices.Insert(l.entries, i, e)
+}
+
+// Page returns up to limit entries after the cursor, and the cursor of
+// the page after, or "" on the last page. The empty cursor is the top
+// of the leaderboard.
+func (l *Leaderboard) Page(after string, limit int) ([]Entry, string, error) {
+	var last *Entry
+	if after != "" {
+		b, err := base64.RawURLEncoding.DecodeString(after)
+		var c cursor
+		if err == nil {
+			err = json.Unmarshal(b, &c)
+		}
+		if err != nil {
+			// BUG: a client with a broken cursor is sent back to the top,
+			// and sees the same entries again.
+			return l.Page("", limit)
+		}
+		last = &Entry{ID: c.ID, Score: c.Score}
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	start := 0
+	if last != nil {
+		start = sort.Search(len(l.entries), func(i int) bool { return before(*last, l.entries[i]) })
+	}
+	end := min(start+max(limit, 0), len(l.entries))
+	page := slices.Clone(l.entries[start:end])
+	// BUG: the last page has a next cursor too, to an empty page.
+	if len(page) == 0 {
+		return page, "", nil
+	}
+	e := page[len(page)-1]
+	b, _ := json.Marshal(cursor{Score: e.Score, ID: e.ID})
+	return page, base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// All returns every entry, read size entries at a time.
+func (l *Leaderboard) All(size int) iter.Seq2[Entry, error] {
+	return func(yield func(Entry, error) bool) {
+		next := ""
+		for {
+			page, cur, err := l.Page(next, size)
+			if err != nil {
+				yield(Entry{}, err)
+				return
+			}
+			for _, e := range page {
+				if !yield(e, nil) {
+					// BUG: break ends this page, and the next is fetched
+					// and yielded to a loop that has stopped.
+					break
+				}
+			}
+			if cur == "" {
+				return
+			}
+			next = cur
+		}
+	}
+}
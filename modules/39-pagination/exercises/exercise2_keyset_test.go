@@ -0,0 +1,101 @@
+//go:build go1.23
+
+package exercises
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// board returns a leaderboard of n entries with only three different
+// scores, added in an order that is neither by score nor by ID.
+func board(n int) *Leaderboard {
+	l := &Leaderboard{}
+	for i := range n {
+		id := int64((i*7)%n + 1)
+		l.Add(Entry{ID: id, Player: "player", Score: int(id%3) * 100})
+	}
+	return l
+}
+
+func pages(t *testing.T, l *Leaderboard, size int) (ids []int64, n int) {
+	t.Helper()
+	next := ""
+	for {
+		page, cur, err := l.Page(next, size)
+		require.NoError(t, err)
+		n++
+		for _, e := range page {
+			ids = append(ids, e.ID)
+		}
+		if cur == "" || n > 100 {
+			return ids, n
+		}
+		next = cur
+	}
+}
+
+func TestOrder(t *testing.T) {
+	l := board(10)
+	page, _, err := l.Page("", 10)
+	require.NoError(t, err)
+	var ids []int64
+	for _, e := range page {
+		ids = append(ids, e.ID)
+	}
+	assert.Equal(t, []int64{2, 5, 8, 1, 4, 7, 10, 3, 6, 9}, ids)
+}
+
+func TestPagesWithTies(t *testing.T) {
+	for _, size := range []int{1, 2, 3, 4, 5, 10} {
+		ids, _ := pages(t, board(30), size)
+		assert.Len(t, ids, 30, "page size %d", size)
+		seen := map[int64]bool{}
+		for _, id := range ids {
+			assert.False(t, seen[id], "page size %d: %d twice", size, id)
+			seen[id] = true
+		}
+	}
+}
+
+func TestLastPage(t *testing.T) {
+	_, n := pages(t, board(20), 10)
+	assert.Equal(t, 2, n, "a full last page has no next cursor")
+
+	_, n = pages(t, board(25), 10)
+	assert.Equal(t, 3, n)
+}
+
+func TestBadCursor(t *testing.T) {
+	l := board(10)
+	for _, c := range []string{"not a cursor!", "bm90IGpzb24"} {
+		page, next, err := l.Page(c, 5)
+		assert.ErrorIs(t, err, ErrCursor, c)
+		assert.Empty(t, page)
+		assert.Empty(t, next)
+	}
+}
+
+func TestAll(t *testing.T) {
+	l := board(25)
+	n := 0
+	for _, err := range l.All(10) {
+		require.NoError(t, err)
+		n++
+	}
+	assert.Equal(t, 25, n)
+}
+
+func TestAllStops(t *testing.T) {
+	// Calling the sequence directly, rather than with range, shows a
+	// yield after the loop stopped as a count instead of a panic.
+	l := board(25)
+	calls := 0
+	l.All(10)(func(Entry, error) bool {
+		calls++
+		return false
+	})
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,44 @@
+package solutions
+
+// SOLUTION: Fix an offset paginator that repeats and drops rows at page boundaries.
+// Pages count from 1, so page p starts at (p-1)*size and ends size rows
+// later, where the next begins. The page count rounds up to include a
+// last partial page, pages past the end are empty, and a page is capped
+// so that appending to it cannot write into the next one.
+
+// Post is a row of a blog's posts.
+type Post struct {
+	ID    int
+	Title string
+}
+
+// Bounds returns the half-open range [start, end) of page, counted from
+// 1, in a list of total rows with size rows per page. Pages out of range
+// are empty.
+func Bounds(page, size, total int) (start, end int) {
+	if page < 1 || size < 1 {
+		return 0, 0
+	}
+	// Fixed: page 1 starts at row 0.
+	// Fixed: a page past the end starts at the end.
+	start = min((page-1)*size, total)
+	// Fixed: end is exclusive, so it is the next page's start.
+	end = min(start+size, total)
+	return start, end
+}
+
+// TotalPages returns how many pages of size rows total rows fill.
+func TotalPages(total, size int) int {
+	if size < 1 {
+		return 0
+	}
+	// Fixed: rounds up, for the last partial page.
+	return (total + size - 1) / size
+}
+
+// Paginate returns page of posts, with size posts per page.
+func Paginate(posts []Post, page, size int) []Post {
+	start, end := Bounds(page, size, len(posts))
+	// Fixed: the capacity ends with the page.
+	return posts[start:end:end]
+}
@@ -0,0 +1,71 @@
+package solutions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func posts(n int) []Post {
+	ps := make([]Post, n)
+	for i := range ps {
+		ps[i] = Post{ID: i + 1, Title: fmt.Sprintf("Post %d", i+1)}
+	}
+	return ps
+}
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		page, size, total int
+		start, end        int
+	}{
+		{1, 10, 25, 0, 10},
+		{2, 10, 25, 10, 20},
+		{3, 10, 25, 20, 25},
+		{4, 10, 25, 25, 25},
+		{100, 10, 25, 25, 25},
+		{0, 10, 25, 0, 0},
+		{1, 0, 25, 0, 0},
+		{1, 10, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		start, end := Bounds(tt.page, tt.size, tt.total)
+		assert.Equal(t, []int{tt.start, tt.end}, []int{start, end},
+			"Bounds(%d, %d, %d)", tt.page, tt.size, tt.total)
+	}
+}
+
+func TestTotalPages(t *testing.T) {
+	assert.Equal(t, 3, TotalPages(25, 10))
+	assert.Equal(t, 2, TotalPages(20, 10))
+	assert.Equal(t, 1, TotalPages(1, 10))
+	assert.Equal(t, 0, TotalPages(0, 10))
+	assert.Equal(t, 0, TotalPages(25, 0))
+}
+
+func TestEveryRowOnce(t *testing.T) {
+	for _, n := range []int{0, 1, 9, 10, 11, 25, 30} {
+		all := posts(n)
+		var seen []Post
+		for p := 1; p <= TotalPages(n, 10); p++ {
+			seen = append(seen, Paginate(all, p, 10)...)
+		}
+		assert.Equal(t, all, append([]Post{}, seen...), "%d posts", n)
+	}
+}
+
+func TestPaginateOutOfRange(t *testing.T) {
+	all := posts(25)
+	assert.NotPanics(t, func() {
+		assert.Empty(t, Paginate(all, 4, 10))
+		assert.Empty(t, Paginate(all, 0, 10))
+	})
+}
+
+func TestAppendToPage(t *testing.T) {
+	all := posts(25)
+	first := Paginate(all, 1, 10)
+	_ = append(first, Post{ID: 99, Title: "Pinned"})
+	assert.Equal(t, 11, Paginate(all, 2, 10)[0].ID, "appending to page 1 must not change page 2")
+}
@@ -0,0 +1,118 @@
+//go:build go1.23
+
+package solutions
+
+// SOLUTION: Fix a leaderboard's keyset pages, which skip players with the same score.
+// Entries are ordered by score, highest first, and then by ID, so that
+// no two compare equal and a cursor of (score, ID) names one place in
+// the order. The last page has no next cursor, a cursor that does not
+// decode is an error, and All stops when its loop does.
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"iter"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Entry is a player's score.
+type Entry struct {
+	ID     int64
+	Player string
+	Score  int
+}
+
+// ErrCursor is returned for a cursor that does not decode.
+var ErrCursor = errors.New("invalid cursor")
+
+// cursor is the last entry of a page.
+type cursor struct {
+	Score int   `json:"s"`
+	ID    int64 `json:"i"`
+}
+
+// before reports whether a comes before b on the leaderboard.
+func before(a, b Entry) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	// Fixed: ties are broken by ID, so the order is total.
+	return a.ID < b.ID
+}
+
+// Leaderboard is a game's entries, highest score first. It is safe for
+// concurrent use.
+type Leaderboard struct {
+	mu      sync.RWMutex
+	entries []Entry
+}
+
+// Add adds e to the leaderboard.
+func (l *Leaderboard) Add(e Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	i := sort.Search(len(l.entries), func(i int) bool { return before(e, l.entries[i]) })
+	l.entries = slices.Insert(l.entries, i, e)
+}
+
+// Page returns up to limit entries after the cursor, and the cursor of
+// the page after, or "" on the last page. The empty cursor is the top
+// of the leaderboard.
+func (l *Leaderboard) Page(after string, limit int) ([]Entry, string, error) {
+	var last *Entry
+	if after != "" {
+		b, err := base64.RawURLEncoding.DecodeString(after)
+		var c cursor
+		if err == nil {
+			err = json.Unmarshal(b, &c)
+		}
+		if err != nil {
+			// Fixed: a bad cursor is an error, not the first page.
+			return nil, "", ErrCursor
+		}
+		last = &Entry{ID: c.ID, Score: c.Score}
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	start := 0
+	if last != nil {
+		start = sort.Search(len(l.entries), func(i int) bool { return before(*last, l.entries[i]) })
+	}
+	end := min(start+max(limit, 0), len(l.entries))
+	page := slices.Clone(l.entries[start:end])
+	// Fixed: only a page with entries after it has a next cursor.
+	if end == len(l.entries) || len(page) == 0 {
+		return page, "", nil
+	}
+	e := page[len(page)-1]
+	b, _ := json.Marshal(cursor{Score: e.Score, ID: e.ID})
+	return page, base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// All returns every entry, read size entries at a time.
+func (l *Leaderboard) All(size int) iter.Seq2[Entry, error] {
+	return func(yield func(Entry, error) bool) {
+		next := ""
+		for {
+			page, cur, err := l.Page(next, size)
+			if err != nil {
+				yield(Entry{}, err)
+				return
+			}
+			for _, e := range page {
+				if !yield(e, nil) {
+					// Fixed: returns, instead of ending only this page.
+					return
+				}
+			}
+			if cur == "" {
+				return
+			}
+			next = cur
+		}
+	}
+}
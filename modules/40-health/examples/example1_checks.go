@@ -0,0 +1,186 @@
+// Package examples demonstrates health checks for Go services: liveness
+// and readiness endpoints, checkers for the dependencies a service
+// needs, and running them concurrently with timeouts, so that one slow
+// dependency cannot hang a probe.
+//
+// This file shows:
+// - A Checker interface with one method, and CheckFunc to make one from a function, as http.HandlerFunc makes a Handler
+// - Checkers for a database connection, a cache that must be warm before it takes traffic, and a downstream service's health endpoint
+// - Checks that take a context, and give up when it is done
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// Checker checks one thing a service needs. Check returns nil if it is
+// healthy, and gives up when ctx is done.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckFunc makes a Checker from a function.
+type CheckFunc func(ctx context.Context) error
+
+// Check returns f(ctx).
+func (f CheckFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// ErrNotConnected is returned by Database.Ping before Connect and after
+// Disconnect.
+var ErrNotConnected = errors.New("database not connected")
+
+// Database stands in for a connection pool, such as *sql.DB. Ping takes
+// Latency to answer.
+type Database struct {
+	Latency time.Duration
+
+	mu        sync.Mutex
+	connected bool
+}
+
+// Connect connects db.
+func (db *Database) Connect() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.connected = true
+}
+
+// Disconnect disconnects db, as a database restart would.
+func (db *Database) Disconnect() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.connected = false
+}
+
+// Connected reports whether db is connected.
+func (db *Database) Connected() bool {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.connected
+}
+
+// Ping makes a round trip to the database.
+func (db *Database) Ping(ctx context.Context) error {
+	t := time.NewTimer(db.Latency)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if !db.Connected() {
+		return ErrNotConnected
+	}
+	return nil
+}
+
+// DatabaseCheck checks that db is connected and answers. A pool that
+// thinks it is connected may not be: only a round trip shows it.
+func DatabaseCheck(db *Database) Checker {
+	return CheckFunc(func(ctx context.Context) error {
+		if !db.Connected() {
+			return ErrNotConnected
+		}
+		return db.Ping(ctx)
+	})
+}
+
+// Cache is an in-memory cache that is loaded at startup. Until it holds
+// Warm entries, requests would all miss it and go to the database.
+type Cache struct {
+	Warm int
+
+	mu    sync.Mutex
+	items map[string]string
+}
+
+// Set stores value under key.
+func (c *Cache) Set(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.items == nil {
+		c.items = map[string]string{}
+	}
+	c.items[key] = value
+}
+
+// Len returns the number of entries in c.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// CacheCheck checks that c is warm.
+func CacheCheck(c *Cache) Checker {
+	return CheckFunc(func(ctx context.Context) error {
+		if n := c.Len(); n < c.Warm {
+			return fmt.Errorf("cache cold: %d of %d entries", n, c.Warm)
+		}
+		return nil
+	})
+}
+
+// PingCheck checks that GET url answers 2xx. It is for a downstream
+// service's liveness endpoint: its readiness endpoint would fail this
+// service whenever one of that service's dependencies failed.
+func PingCheck(client *http.Client, url string) Checker {
+	return CheckFunc(func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		io.Copy(io.Discard, resp.Body)
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("GET %s: %s", url, resp.Status)
+		}
+		return nil
+	})
+}
+
+// DemonstrateCheckers checks a database, a cache, and a downstream
+// service, before and after they are ready.
+func DemonstrateCheckers() {
+	fmt.Println("=== Checkers ===")
+	ctx := context.Background()
+
+	db := &Database{Latency: time.Millisecond}
+	fmt.Println("database:", DatabaseCheck(db).Check(ctx))
+	db.Connect()
+	fmt.Println("database:", DatabaseCheck(db).Check(ctx))
+
+	cache := &Cache{Warm: 2}
+	cache.Set("module:01", "Basics")
+	fmt.Println("cache:", CacheCheck(cache).Check(ctx))
+	cache.Set("module:02", "Types and Interfaces")
+	fmt.Println("cache:", CacheCheck(cache).Check(ctx))
+
+	status := http.StatusServiceUnavailable
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+	fmt.Println("downstream:", PingCheck(srv.Client(), srv.URL+"/healthz").Check(ctx) != nil)
+	status = http.StatusOK
+	fmt.Println("downstream:", PingCheck(srv.Client(), srv.URL+"/healthz").Check(ctx))
+
+	slow := &Database{Latency: time.Hour}
+	slow.Connect()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	fmt.Println("slow database:", DatabaseCheck(slow).Check(ctx))
+}
@@ -0,0 +1,53 @@
+package examples
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateCheckers(t *testing.T) {
+	DemonstrateCheckers()
+}
+
+func TestDatabaseCheck(t *testing.T) {
+	ctx := context.Background()
+	db := &Database{}
+	assert.ErrorIs(t, DatabaseCheck(db).Check(ctx), ErrNotConnected)
+	db.Connect()
+	assert.NoError(t, DatabaseCheck(db).Check(ctx))
+	db.Disconnect()
+	assert.ErrorIs(t, DatabaseCheck(db).Check(ctx), ErrNotConnected)
+
+	db = &Database{Latency: time.Hour}
+	db.Connect()
+	ctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, DatabaseCheck(db).Check(ctx), context.DeadlineExceeded)
+}
+
+func TestCacheCheck(t *testing.T) {
+	c := &Cache{Warm: 1}
+	assert.EqualError(t, CacheCheck(c).Check(context.Background()), "cache cold: 0 of 1 entries")
+	c.Set("k", "v")
+	assert.NoError(t, CacheCheck(c).Check(context.Background()))
+}
+
+func TestPingCheck(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+	check := PingCheck(srv.Client(), srv.URL)
+	assert.NoError(t, check.Check(context.Background()))
+	status = http.StatusInternalServerError
+	assert.ErrorContains(t, check.Check(context.Background()), "500")
+
+	srv.Close()
+	assert.Error(t, check.Check(context.Background()))
+}
@@ -0,0 +1,135 @@
+package examples
+
+// This file shows:
+// - Running every check at once, so that a probe takes as long as the
+//   slowest check, not all of them added up
+// - A timeout per check, and a check that ignores its context: the
+//   result is taken when the timeout ends, whether the check has
+//   returned or not
+// - Writing results by index from each goroutine, without a mutex
+// - Optional checks, whose failure makes a service degraded, not down
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Statuses of a check and of a Report.
+const (
+	StatusUp       = "up"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+// DefaultTimeout is the timeout of a Check without one.
+const DefaultTimeout = time.Second
+
+// Check is a named Checker.
+type Check struct {
+	Name    string
+	Checker Checker
+	// Timeout is how long the check may take. Zero means DefaultTimeout.
+	Timeout time.Duration
+	// Optional checks are for dependencies the service can work without,
+	// more slowly or with less. Their failure makes it degraded.
+	Optional bool
+}
+
+// Result is the outcome of a Check.
+type Result struct {
+	Name   string        `json:"name"`
+	Status string        `json:"status"`
+	Error  string        `json:"error,omitempty"`
+	Took   time.Duration `json:"-"`
+}
+
+// Report is the outcome of a set of checks. Its Status is down if a
+// required check failed, degraded if an optional one did, and up
+// otherwise.
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks,omitempty"`
+}
+
+// RunChecks runs checks concurrently, and returns when every one of them
+// has returned or timed out.
+func RunChecks(ctx context.Context, checks []Check) Report {
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c Check) {
+			defer wg.Done()
+			results[i] = run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	rep := Report{Status: StatusUp, Checks: results}
+	for i, r := range results {
+		if r.Status == StatusUp {
+			continue
+		}
+		if !checks[i].Optional {
+			rep.Status = StatusDown
+		} else if rep.Status == StatusUp {
+			rep.Status = StatusDegraded
+		}
+	}
+	return rep
+}
+
+// run runs c with its timeout. The check runs in a goroutine of its own,
+// so that one that ignores ctx delays nothing but itself; the channel
+// has room for its result, so that it can finish after run has gone.
+func run(ctx context.Context, c Check) Result {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- c.Checker.Check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %v: %w", timeout, ctx.Err())
+	}
+	r := Result{Name: c.Name, Status: StatusUp, Took: time.Since(start)}
+	if err != nil {
+		r.Status = StatusDown
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// DemonstrateAggregate runs a fast check, a cold cache, and a check that
+// hangs, and prints how long they took together.
+func DemonstrateAggregate() {
+	fmt.Println("=== Running checks ===")
+	db := &Database{Latency: 5 * time.Millisecond}
+	db.Connect()
+	hang := CheckFunc(func(ctx context.Context) error {
+		time.Sleep(time.Second) // ignores ctx
+		return nil
+	})
+	checks := []Check{
+		{Name: "database", Checker: DatabaseCheck(db)},
+		{Name: "cache", Checker: CacheCheck(&Cache{Warm: 1}), Optional: true},
+		{Name: "search", Checker: hang, Timeout: 20 * time.Millisecond},
+	}
+
+	start := time.Now()
+	rep := RunChecks(context.Background(), checks)
+	fmt.Printf("%s in under 100ms: %v\n", rep.Status, time.Since(start) < 100*time.Millisecond)
+	for _, r := range rep.Checks {
+		fmt.Printf("  %-8s %-4s %s\n", r.Name, r.Status, r.Error)
+	}
+}
@@ -0,0 +1,71 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateAggregate(t *testing.T) {
+	DemonstrateAggregate()
+}
+
+func check(err error) Checker {
+	return CheckFunc(func(context.Context) error { return err })
+}
+
+func TestRunChecksStatus(t *testing.T) {
+	fail := errors.New("fail")
+	tests := []struct {
+		name   string
+		checks []Check
+		want   string
+	}{
+		{"none", nil, StatusUp},
+		{"all up", []Check{{Name: "a", Checker: check(nil)}, {Name: "b", Checker: check(nil), Optional: true}}, StatusUp},
+		{"optional down", []Check{{Name: "a", Checker: check(nil)}, {Name: "b", Checker: check(fail), Optional: true}}, StatusDegraded},
+		{"required down", []Check{{Name: "a", Checker: check(fail)}, {Name: "b", Checker: check(nil), Optional: true}}, StatusDown},
+		{"both down", []Check{{Name: "b", Checker: check(fail), Optional: true}, {Name: "a", Checker: check(fail)}}, StatusDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rep := RunChecks(context.Background(), tt.checks)
+			assert.Equal(t, tt.want, rep.Status)
+			assert.Len(t, rep.Checks, len(tt.checks))
+		})
+	}
+}
+
+func TestRunChecksConcurrently(t *testing.T) {
+	sleep := CheckFunc(func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+	checks := []Check{{Name: "a", Checker: sleep}, {Name: "b", Checker: sleep}, {Name: "c", Checker: sleep}}
+	start := time.Now()
+	rep := RunChecks(context.Background(), checks)
+	assert.Equal(t, StatusUp, rep.Status)
+	assert.Less(t, time.Since(start), 140*time.Millisecond)
+}
+
+func TestRunChecksTimeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	hang := CheckFunc(func(context.Context) error {
+		<-block
+		return nil
+	})
+	start := time.Now()
+	rep := RunChecks(context.Background(), []Check{
+		{Name: "hang", Checker: hang, Timeout: 10 * time.Millisecond},
+		{Name: "ok", Checker: check(nil)},
+	})
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, StatusDown, rep.Status)
+	assert.Equal(t, "hang", rep.Checks[0].Name)
+	assert.Contains(t, rep.Checks[0].Error, "timed out after 10ms")
+	assert.Equal(t, StatusUp, rep.Checks[1].Status)
+}
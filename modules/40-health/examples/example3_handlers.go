@@ -0,0 +1,144 @@
+package examples
+
+// This file shows:
+// - /healthz for liveness, which checks only the process itself: a
+//   restart does not fix a database that is down
+// - /readyz for readiness, which runs the checks and answers 503 when
+//   the service should get no traffic
+// - Keeping a report for a moment, so that probes from every load
+//   balancer do not each ping the database
+// - Draining: failing readiness before shutdown, so that traffic moves
+//   away while requests in flight finish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Health serves a service's liveness and readiness endpoints.
+type Health struct {
+	Checks []Check
+	// TTL is how long a report is reused for. Zero runs the checks on
+	// every request.
+	TTL time.Duration
+	Now func() time.Time
+
+	draining atomic.Bool
+
+	mu     sync.Mutex
+	report Report
+	at     time.Time
+}
+
+func (h *Health) now() time.Time {
+	if h.Now != nil {
+		return h.Now()
+	}
+	return time.Now()
+}
+
+// Drain makes readiness fail from now on. Call it when shutdown starts,
+// and give load balancers a few probes to notice before the server
+// stops.
+func (h *Health) Drain() {
+	h.draining.Store(true)
+}
+
+// Report runs the checks, or returns the report of a run less than TTL
+// ago. Requests that arrive during a run wait for it, instead of
+// starting their own.
+func (h *Health) Report(ctx context.Context) Report {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.now()
+	if !h.at.IsZero() && now.Sub(h.at) < h.TTL {
+		return h.report
+	}
+	h.report = RunChecks(ctx, h.Checks)
+	h.at = now
+	return h.report
+}
+
+// Live answers 200 while the process can serve HTTP at all.
+func (h *Health) Live(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, Report{Status: StatusUp})
+}
+
+// Ready answers 200 when the service can take traffic, up or degraded,
+// and 503 when it is down or draining. ?verbose lists the checks.
+func (h *Health) Ready(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, Report{Status: "draining"})
+		return
+	}
+	rep := h.Report(r.Context())
+	if !r.URL.Query().Has("verbose") {
+		rep.Checks = nil
+	}
+	status := http.StatusOK
+	if rep.Status == StatusDown {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, rep)
+}
+
+// Register adds /healthz and /readyz to mux.
+func (h *Health) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", h.Live)
+	mux.HandleFunc("/readyz", h.Ready)
+}
+
+// writeJSON writes v as a JSON response with status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// DemonstrateHandlers probes a service as it starts, loses its database,
+// and drains.
+func DemonstrateHandlers() {
+	fmt.Println("=== Endpoints ===")
+	db := &Database{}
+	cache := &Cache{Warm: 1}
+	h := &Health{Checks: []Check{
+		{Name: "database", Checker: DatabaseCheck(db)},
+		{Name: "cache", Checker: CacheCheck(cache), Optional: true},
+	}}
+	mux := http.NewServeMux()
+	h.Register(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	probe := func(step string) {
+		fmt.Printf("%s:\n", step)
+		for _, path := range []string{"/healthz", "/readyz?verbose"} {
+			resp, err := srv.Client().Get(srv.URL + path)
+			if err != nil {
+				fmt.Println("  error:", err)
+				return
+			}
+			var rep Report
+			json.NewDecoder(resp.Body).Decode(&rep)
+			resp.Body.Close()
+			fmt.Printf("  %-15s %d %s\n", path, resp.StatusCode, rep.Status)
+		}
+	}
+
+	probe("starting")
+	db.Connect()
+	probe("connected, cache cold")
+	cache.Set("module:01", "Basics")
+	probe("warm")
+	db.Disconnect()
+	probe("database down")
+	db.Connect()
+	h.Drain()
+	probe("draining")
+}
@@ -0,0 +1,78 @@
+package examples
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateHandlers(t *testing.T) {
+	DemonstrateHandlers()
+}
+
+func serve(h *Health, path string) (int, Report) {
+	mux := http.NewServeMux()
+	h.Register(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	var rep Report
+	json.NewDecoder(rec.Body).Decode(&rep)
+	return rec.Code, rep
+}
+
+func TestReady(t *testing.T) {
+	fail := errors.New("fail")
+	h := &Health{Checks: []Check{{Name: "db", Checker: check(fail)}}}
+	code, rep := serve(h, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusDown, rep.Status)
+	assert.Empty(t, rep.Checks)
+
+	code, _ = serve(h, "/healthz")
+	assert.Equal(t, http.StatusOK, code, "liveness does not depend on the database")
+
+	h = &Health{Checks: []Check{{Name: "cache", Checker: check(fail), Optional: true}}}
+	code, rep = serve(h, "/readyz?verbose")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, StatusDegraded, rep.Status)
+	require.Len(t, rep.Checks, 1)
+	assert.Equal(t, "fail", rep.Checks[0].Error)
+}
+
+func TestDrain(t *testing.T) {
+	h := &Health{}
+	code, _ := serve(h, "/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	h.Drain()
+	code, rep := serve(h, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "draining", rep.Status)
+	code, _ = serve(h, "/healthz")
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestReportTTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	runs := 0
+	h := &Health{
+		Checks: []Check{{Name: "count", Checker: CheckFunc(func(context.Context) error {
+			runs++
+			return nil
+		})}},
+		TTL: time.Second,
+		Now: func() time.Time { return now },
+	}
+	h.Report(context.Background())
+	h.Report(context.Background())
+	assert.Equal(t, 1, runs)
+	now = now.Add(time.Second)
+	h.Report(context.Background())
+	assert.Equal(t, 2, runs)
+}
@@ -0,0 +1,91 @@
+package exercises
+
+// EXERCISE: Fix a readiness check that blocks forever on one slow dependency.
+// Readiness runs a service's dependency checks for /readyz. But a check
+// that never returns hangs every probe, the checks run one after
+// another, and they keep running after the prober has hung up.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrTimeout is returned for a check that did not return in time.
+var ErrTimeout = errors.New("check timed out")
+
+// Check is a dependency's check.
+type Check struct {
+	Name string
+	Fn   func(ctx context.Context) error
+}
+
+// Readiness is a /readyz handler.
+type Readiness struct {
+	Checks  []Check
+	Timeout time.Duration
+}
+
+// Run runs the checks, and returns the errors of those that failed, by
+// name.
+func (r *Readiness) Run(ctx context.Context) map[string]error {
+	// BUG: Timeout is never applied, so a check that waits for ctx
+	// waits as long as the caller's context lasts.
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed = map[string]error{}
+	)
+	for _, c := range r.Checks {
+		// BUG: the checks run one after another, so a probe takes as long
+		// as all of them added up.
+		if err := check(ctx, c); err != nil {
+			mu.Lock()
+			failed[c.Name] = err
+			mu.Unlock()
+		}
+	}
+	wg.Wait()
+	return failed
+}
+
+// check runs c, and gives up on it when ctx is done.
+func check(ctx context.Context, c Check) error {
+	// BUG: a check that ignores ctx is waited for however long it takes,
+	// and ErrTimeout is never returned.
+	if err := c.Fn(ctx); err != nil {
+		return fmt.Errorf("%s: %w", c.Name, err)
+	}
+	return nil
+}
+
+// status is the body of a /readyz response.
+type status struct {
+	Status string            `json:"status"`
+	Failed map[string]string `json:"failed,omitempty"`
+}
+
+// ServeHTTP answers 200 if every check passed, and 503 with the errors
+// if not.
+func (r *Readiness) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// BUG: the checks go on after the prober has hung up.
+	failed := r.Run(context.Background())
+	body := status{Status: "ready"}
+	code := http.StatusOK
+	if len(failed) > 0 {
+		body = status{Status: "not ready", Failed: map[string]string{}}
+		for name, err := range failed {
+			body.Failed[name] = err.Error()
+		}
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
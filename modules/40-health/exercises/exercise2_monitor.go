@@ -0,0 +1,120 @@
+package exercises
+
+// EXERCISE: Fix a health monitor that gets its service restarted whenever a dependency fails.
+// Monitor serves /healthz, which the orchestrator restarts the service
+// on, and /readyz, which the load balancer sends traffic on. But a cold
+// cache takes the service out of rotation, a database outage restarts
+// it, every probe checks every dependency, one prober that hangs up
+// marks the service down for everyone, and a draining service still
+// gets traffic.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Dependency is something a service needs, with its check.
+type Dependency struct {
+	Name string
+	// Optional dependencies are ones the service can work without.
+	Optional bool
+	Check    func(ctx context.Context) error
+}
+
+// Status is the outcome of a Monitor's checks: "up", "degraded" if an
+// optional dependency failed, or "down" if a required one did.
+type Status struct {
+	Status string   `json:"status"`
+	Failed []string `json:"failed,omitempty"`
+}
+
+// Monitor serves /healthz and /readyz for a service's dependencies.
+type Monitor struct {
+	Deps []Dependency
+	// Timeout is how long the checks may take.
+	Timeout time.Duration
+	// TTL is how long Status reuses a result for.
+	TTL time.Duration
+	Now func() time.Time
+
+	draining atomic.Bool
+
+	mu      sync.Mutex
+	last    Status
+	checked time.Time
+}
+
+func (m *Monitor) now() time.Time {
+	if m.Now != nil {
+		return m.Now()
+	}
+	return time.Now()
+}
+
+// Status checks the dependencies, or returns the result of a check less
+// than TTL ago.
+func (m *Monitor) Status(ctx context.Context) Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	if !m.checked.IsZero() && now.Sub(m.checked) < m.TTL {
+		return m.last
+	}
+
+	// BUG: the result is shared by every prober for TTL, but the checks
+	// are canceled with the request that happened to run them.
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+	st := Status{Status: "up"}
+	for _, d := range m.Deps {
+		if err := d.Check(ctx); err != nil {
+			st.Failed = append(st.Failed, d.Name)
+			// BUG: an optional dependency takes the service down too.
+			st.Status = "down"
+		}
+	}
+	// BUG: checked is never set, so every call runs the checks.
+	m.last = st
+	return st
+}
+
+// Drain makes Readyz fail from now on.
+func (m *Monitor) Drain() {
+	m.draining.Store(true)
+}
+
+// Healthz answers 200 while the process can serve requests.
+func (m *Monitor) Healthz(w http.ResponseWriter, r *http.Request) {
+	// BUG: a dependency that is down fails liveness, and restarting the
+	// service does not bring it back.
+	st := m.Status(r.Context())
+	code := http.StatusOK
+	if st.Status == "down" {
+		code = http.StatusServiceUnavailable
+	}
+	writeStatus(w, code, st)
+}
+
+// Readyz answers 200 when the service can take traffic, and 503 when a
+// required dependency is down or the service is draining.
+func (m *Monitor) Readyz(w http.ResponseWriter, r *http.Request) {
+	// BUG: draining is never checked.
+	st := m.Status(r.Context())
+	code := http.StatusOK
+	if st.Status == "down" {
+		code = http.StatusServiceUnavailable
+	}
+	writeStatus(w, code, st)
+}
+
+// writeStatus writes st as a JSON response with code.
+func writeStatus(w http.ResponseWriter, code int, st Status) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(st)
+}
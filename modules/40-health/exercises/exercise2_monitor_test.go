@@ -0,0 +1,120 @@
+package exercises
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errDown = errors.New("down")
+
+// fakeDep is a dependency whose check counts its calls, and fails while
+// err is set.
+type fakeDep struct {
+	calls int
+	err   error
+}
+
+func (d *fakeDep) check(ctx context.Context) error {
+	d.calls++
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return d.err
+}
+
+func probe(ctx context.Context, h http.HandlerFunc) (int, Status) {
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+	var st Status
+	json.NewDecoder(rec.Body).Decode(&st)
+	return rec.Code, st
+}
+
+func newMonitor(db, cache *fakeDep) *Monitor {
+	return &Monitor{
+		Timeout: time.Second,
+		Deps: []Dependency{
+			{Name: "database", Check: db.check},
+			{Name: "cache", Optional: true, Check: cache.check},
+		},
+	}
+}
+
+func TestHealthzRunsNoChecks(t *testing.T) {
+	db := &fakeDep{err: errDown}
+	m := newMonitor(db, &fakeDep{})
+	code, st := probe(context.Background(), m.Healthz)
+	assert.Equal(t, http.StatusOK, code, "a restart does not bring the database back")
+	assert.Equal(t, "up", st.Status)
+	assert.Zero(t, db.calls)
+}
+
+func TestReadyz(t *testing.T) {
+	tests := []struct {
+		name      string
+		db, cache error
+		code      int
+		status    string
+		failed    []string
+	}{
+		{"all up", nil, nil, http.StatusOK, "up", nil},
+		{"cache down", nil, errDown, http.StatusOK, "degraded", []string{"cache"}},
+		{"database down", errDown, nil, http.StatusServiceUnavailable, "down", []string{"database"}},
+		{"both down", errDown, errDown, http.StatusServiceUnavailable, "down", []string{"database", "cache"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMonitor(&fakeDep{err: tt.db}, &fakeDep{err: tt.cache})
+			code, st := probe(context.Background(), m.Readyz)
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, Status{Status: tt.status, Failed: tt.failed}, st)
+		})
+	}
+}
+
+func TestStatusTTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	db := &fakeDep{}
+	m := newMonitor(db, &fakeDep{})
+	m.TTL = 5 * time.Second
+	m.Now = func() time.Time { return now }
+
+	for i := 0; i < 10; i++ {
+		m.Status(context.Background())
+		now = now.Add(time.Second)
+	}
+	assert.Equal(t, 2, db.calls, "10 probes a second apart, with a 5s TTL")
+}
+
+func TestProberHangsUp(t *testing.T) {
+	m := newMonitor(&fakeDep{}, &fakeDep{})
+	m.TTL = time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	probe(ctx, m.Readyz)
+
+	code, st := probe(context.Background(), m.Readyz)
+	assert.Equal(t, http.StatusOK, code, "one prober's hang-up is not an outage for the others")
+	assert.Equal(t, "up", st.Status)
+}
+
+func TestDrain(t *testing.T) {
+	m := newMonitor(&fakeDep{}, &fakeDep{})
+	m.TTL = time.Minute
+	code, _ := probe(context.Background(), m.Readyz)
+	assert.Equal(t, http.StatusOK, code)
+
+	m.Drain()
+	code, st := probe(context.Background(), m.Readyz)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "draining", st.Status)
+	code, _ = probe(context.Background(), m.Healthz)
+	assert.Equal(t, http.StatusOK, code)
+}
@@ -0,0 +1,102 @@
+package solutions
+
+// SOLUTION: Fix a readiness check that blocks forever on one slow dependency.
+// Run gives the checks a context that ends after Timeout, runs them all
+// at once, and takes each result when the check returns or the context
+// ends, whichever is first. ServeHTTP runs the checks with the request's
+// context, so that they stop when the prober hangs up.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrTimeout is returned for a check that did not return in time.
+var ErrTimeout = errors.New("check timed out")
+
+// Check is a dependency's check.
+type Check struct {
+	Name string
+	Fn   func(ctx context.Context) error
+}
+
+// Readiness is a /readyz handler.
+type Readiness struct {
+	Checks  []Check
+	Timeout time.Duration
+}
+
+// Run runs the checks, and returns the errors of those that failed, by
+// name.
+func (r *Readiness) Run(ctx context.Context) map[string]error {
+	// Fixed: the checks have Timeout to finish.
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed = map[string]error{}
+	)
+	for _, c := range r.Checks {
+		wg.Add(1)
+		// Fixed: the checks run at once.
+		go func(c Check) {
+			defer wg.Done()
+			if err := check(ctx, c); err != nil {
+				mu.Lock()
+				failed[c.Name] = err
+				mu.Unlock()
+			}
+		}(c)
+	}
+	wg.Wait()
+	return failed
+}
+
+// check runs c, and gives up on it when ctx is done.
+func check(ctx context.Context, c Check) error {
+	// Fixed: a check that ignores ctx is left to finish in its own
+	// goroutine, with room in the channel for its result.
+	done := make(chan error, 1)
+	go func() { done <- c.Fn(ctx) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("%s: %w", c.Name, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%s: %w: %w", c.Name, ErrTimeout, ctx.Err())
+	}
+}
+
+// status is the body of a /readyz response.
+type status struct {
+	Status string            `json:"status"`
+	Failed map[string]string `json:"failed,omitempty"`
+}
+
+// ServeHTTP answers 200 if every check passed, and 503 with the errors
+// if not.
+func (r *Readiness) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// Fixed: the checks stop when the request's context does.
+	failed := r.Run(req.Context())
+	body := status{Status: "ready"}
+	code := http.StatusOK
+	if len(failed) > 0 {
+		body = status{Status: "not ready", Failed: map[string]string{}}
+		for name, err := range failed {
+			body.Failed[name] = err.Error()
+		}
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,127 @@
+package solutions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// within fails t if f does not return within d. A check that blocks
+// forever fails the test instead of hanging it.
+func within(t *testing.T, d time.Duration, f func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	select {
+	case <-done:
+	case <-time.After(d):
+		t.Fatalf("did not return within %v", d)
+	}
+}
+
+func ok(context.Context) error { return nil }
+
+// hang returns a check that ignores its context, and blocks until the
+// test ends.
+func hang(t *testing.T) func(context.Context) error {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	return func(context.Context) error {
+		<-release
+		return nil
+	}
+}
+
+// slow returns a check that takes d, or until its context ends.
+func slow(d time.Duration) func(context.Context) error {
+	return func(ctx context.Context) error {
+		select {
+		case <-time.After(d):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func TestRunReportsFailures(t *testing.T) {
+	down := errors.New("connection refused")
+	r := &Readiness{Timeout: time.Second, Checks: []Check{
+		{Name: "database", Fn: ok},
+		{Name: "search", Fn: func(context.Context) error { return down }},
+	}}
+	var failed map[string]error
+	within(t, time.Second, func() { failed = r.Run(context.Background()) })
+	assert.Len(t, failed, 1)
+	assert.ErrorIs(t, failed["search"], down)
+}
+
+func TestRunTimesOutSlowCheck(t *testing.T) {
+	r := &Readiness{Timeout: 20 * time.Millisecond, Checks: []Check{
+		{Name: "database", Fn: ok},
+		{Name: "search", Fn: slow(time.Hour)},
+	}}
+	var failed map[string]error
+	within(t, time.Second, func() { failed = r.Run(context.Background()) })
+	assert.Len(t, failed, 1)
+	assert.ErrorIs(t, failed["search"], context.DeadlineExceeded)
+}
+
+func TestRunTimesOutCheckThatIgnoresContext(t *testing.T) {
+	r := &Readiness{Timeout: 20 * time.Millisecond, Checks: []Check{
+		{Name: "database", Fn: ok},
+		{Name: "cache", Fn: hang(t)},
+	}}
+	var failed map[string]error
+	within(t, time.Second, func() { failed = r.Run(context.Background()) })
+	assert.Len(t, failed, 1)
+	assert.ErrorIs(t, failed["cache"], ErrTimeout)
+	assert.ErrorIs(t, failed["cache"], context.DeadlineExceeded)
+}
+
+func TestRunConcurrently(t *testing.T) {
+	r := &Readiness{Timeout: time.Second, Checks: []Check{
+		{Name: "a", Fn: slow(50 * time.Millisecond)},
+		{Name: "b", Fn: slow(50 * time.Millisecond)},
+		{Name: "c", Fn: slow(50 * time.Millisecond)},
+	}}
+	start := time.Now()
+	within(t, time.Second, func() { assert.Empty(t, r.Run(context.Background())) })
+	assert.Less(t, time.Since(start), 140*time.Millisecond, "three 50ms checks at once")
+}
+
+func TestServeHTTP(t *testing.T) {
+	r := &Readiness{Timeout: 20 * time.Millisecond, Checks: []Check{{Name: "database", Fn: ok}}}
+	rec := httptest.NewRecorder()
+	within(t, time.Second, func() { r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil)) })
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status": "ready"}`, rec.Body.String())
+
+	r.Checks = append(r.Checks, Check{Name: "cache", Fn: hang(t)})
+	rec = httptest.NewRecorder()
+	within(t, time.Second, func() { r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil)) })
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var body struct{ Failed map[string]string }
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Contains(t, body.Failed["cache"], "timed out")
+}
+
+func TestServeHTTPStopsWhenProberHangsUp(t *testing.T) {
+	r := &Readiness{Timeout: time.Hour, Checks: []Check{{Name: "database", Fn: slow(time.Hour)}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	within(t, time.Second, func() { r.ServeHTTP(rec, req) })
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
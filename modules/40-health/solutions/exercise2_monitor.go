@@ -0,0 +1,122 @@
+package solutions
+
+// SOLUTION: Fix a health monitor that gets its service restarted whenever a dependency fails.
+// Healthz answers without running any checks, since a restart fixes no
+// dependency. An optional dependency's failure leaves the service
+// degraded but ready, Status keeps its result for TTL, the checks run
+// on a context of their own that a prober cannot cancel, and Readyz
+// fails as soon as Drain is called.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Dependency is something a service needs, with its check.
+type Dependency struct {
+	Name string
+	// Optional dependencies are ones the service can work without.
+	Optional bool
+	Check    func(ctx context.Context) error
+}
+
+// Status is the outcome of a Monitor's checks: "up", "degraded" if an
+// optional dependency failed, or "down" if a required one did.
+type Status struct {
+	Status string   `json:"status"`
+	Failed []string `json:"failed,omitempty"`
+}
+
+// Monitor serves /healthz and /readyz for a service's dependencies.
+type Monitor struct {
+	Deps []Dependency
+	// Timeout is how long the checks may take.
+	Timeout time.Duration
+	// TTL is how long Status reuses a result for.
+	TTL time.Duration
+	Now func() time.Time
+
+	draining atomic.Bool
+
+	mu      sync.Mutex
+	last    Status
+	checked time.Time
+}
+
+func (m *Monitor) now() time.Time {
+	if m.Now != nil {
+		return m.Now()
+	}
+	return time.Now()
+}
+
+// Status checks the dependencies, or returns the result of a check less
+// than TTL ago.
+func (m *Monitor) Status(ctx context.Context) Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	if !m.checked.IsZero() && now.Sub(m.checked) < m.TTL {
+		return m.last
+	}
+
+	// Fixed: the result is shared by every prober for TTL, so the
+	// checks are not canceled with the request that happened to run
+	// them.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), m.Timeout)
+	defer cancel()
+	st := Status{Status: "up"}
+	for _, d := range m.Deps {
+		if err := d.Check(ctx); err != nil {
+			st.Failed = append(st.Failed, d.Name)
+			// Fixed: an optional dependency makes the service degraded.
+			if !d.Optional {
+				st.Status = "down"
+			} else if st.Status == "up" {
+				st.Status = "degraded"
+			}
+		}
+	}
+	m.last = st
+	// Fixed: the time of the check is kept, for the next call.
+	m.checked = now
+	return st
+}
+
+// Drain makes Readyz fail from now on.
+func (m *Monitor) Drain() {
+	m.draining.Store(true)
+}
+
+// Healthz answers 200 while the process can serve requests.
+func (m *Monitor) Healthz(w http.ResponseWriter, r *http.Request) {
+	// Fixed: liveness runs no checks.
+	writeStatus(w, http.StatusOK, Status{Status: "up"})
+}
+
+// Readyz answers 200 when the service can take traffic, and 503 when a
+// required dependency is down or the service is draining.
+func (m *Monitor) Readyz(w http.ResponseWriter, r *http.Request) {
+	// Fixed: draining is checked first.
+	if m.draining.Load() {
+		writeStatus(w, http.StatusServiceUnavailable, Status{Status: "draining"})
+		return
+	}
+	st := m.Status(r.Context())
+	code := http.StatusOK
+	if st.Status == "down" {
+		code = http.StatusServiceUnavailable
+	}
+	writeStatus(w, code, st)
+}
+
+// writeStatus writes st as a JSON response with code.
+func writeStatus(w http.ResponseWriter, code int, st Status) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(st)
+}
@@ -0,0 +1,140 @@
+// Package examples demonstrates feature flags: flags that are on or off,
+// flags rolled out to a percentage of users by hashing their IDs,
+// overrides for each environment, and flags loaded from a file that can
+// change while the service runs.
+//
+// This file shows:
+// - A Flag that is on, off, or on for a percentage of users
+// - Bucketing users by hashing the flag's name with their ID, so that a user gets the same answer every time, and each flag picks different users
+// - Raising a rollout from 10% to 20% without turning the flag off for any of the first 10%
+// - Weighted variants for an experiment, from a hash of their own
+package examples
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// Buckets is how many buckets users are hashed into: one per percent.
+const Buckets = 100
+
+// Flag is a feature flag's setting.
+type Flag struct {
+	// Enabled turns the flag on. Off, it is off for everyone.
+	Enabled bool `json:"enabled"`
+	// Rollout limits an enabled flag to a percentage of users, from 0
+	// to 100. Nil means everyone.
+	Rollout *int `json:"rollout,omitempty"`
+}
+
+// Percent returns a pointer to p, for Flag.Rollout.
+func Percent(p int) *int {
+	return &p
+}
+
+// hashN hashes key and user into [0, n). FNV-1a is fast and spreads
+// similar IDs evenly, and gives the same answer in every process, on
+// every machine, every time.
+func hashN(key, user string, n int) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(user))
+	return int(h.Sum32() % uint32(n))
+}
+
+// Bucket returns user's bucket for the flag called name, from 0 to
+// Buckets-1. The name is hashed too: with the user's ID alone, the same
+// users would be first in every rollout.
+func Bucket(name, user string) int {
+	return hashN(name, user, Buckets)
+}
+
+// On reports whether the flag called name is on for user. A user is in a
+// rollout of p% if their bucket is below p, so raising p only adds users.
+func (f Flag) On(name, user string) bool {
+	if !f.Enabled {
+		return false
+	}
+	if f.Rollout == nil {
+		return true
+	}
+	return Bucket(name, user) < *f.Rollout
+}
+
+// Variant is one arm of an experiment.
+type Variant struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+}
+
+// Pick returns the variant of the experiment called name that user is
+// in, with each variant getting its weight's share of users. Variants
+// are a slice, not a map, so that the order, and with it every user's
+// variant, is the same every time. Pick returns "" if no variant has
+// any weight.
+func Pick(name, user string, variants []Variant) string {
+	total := 0
+	for _, v := range variants {
+		total += max(v.Weight, 0)
+	}
+	if total == 0 {
+		return ""
+	}
+	// A hash of its own, so that the users in a rollout are not all in
+	// the first variant.
+	n := hashN(name+"/variant", user, total)
+	for _, v := range variants {
+		n -= max(v.Weight, 0)
+		if n < 0 {
+			return v.Name
+		}
+	}
+	return ""
+}
+
+// DemonstrateRollout rolls a flag out to 10% and then 20% of a thousand
+// users, and splits them between the variants of an experiment.
+func DemonstrateRollout() {
+	fmt.Println("=== Rollout ===")
+	users := make([]string, 1000)
+	for i := range users {
+		users[i] = fmt.Sprintf("user-%d", i)
+	}
+	count := func(f Flag, name string) (n int, in map[string]bool) {
+		in = map[string]bool{}
+		for _, u := range users {
+			if f.On(name, u) {
+				n++
+				in[u] = true
+			}
+		}
+		return n, in
+	}
+
+	n10, in10 := count(Flag{Enabled: true, Rollout: Percent(10)}, "new-editor")
+	n20, in20 := count(Flag{Enabled: true, Rollout: Percent(20)}, "new-editor")
+	kept := 0
+	for u := range in10 {
+		if in20[u] {
+			kept++
+		}
+	}
+	fmt.Printf("10%%: %d users, 20%%: %d users, %d of the first %d still in\n", n10, n20, kept, n10)
+
+	_, other := count(Flag{Enabled: true, Rollout: Percent(10)}, "dark-mode")
+	both := 0
+	for u := range in10 {
+		if other[u] {
+			both++
+		}
+	}
+	fmt.Printf("in both 10%% rollouts: %d users, about 1%%\n", both)
+
+	variants := []Variant{{"control", 50}, {"blue", 25}, {"green", 25}}
+	split := map[string]int{}
+	for _, u := range users {
+		split[Pick("checkout-button", u, variants)]++
+	}
+	fmt.Printf("control %d, blue %d, green %d\n", split["control"], split["blue"], split["green"])
+}
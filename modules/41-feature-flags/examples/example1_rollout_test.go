@@ -0,0 +1,65 @@
+package examples
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateRollout(t *testing.T) {
+	DemonstrateRollout()
+}
+
+func TestBucketIsStable(t *testing.T) {
+	assert.Equal(t, Bucket("new-editor", "user-1"), Bucket("new-editor", "user-1"))
+	for i := 0; i < 1000; i++ {
+		b := Bucket("new-editor", fmt.Sprint(i))
+		assert.True(t, b >= 0 && b < Buckets, b)
+	}
+}
+
+func TestOn(t *testing.T) {
+	assert.False(t, Flag{}.On("f", "u"))
+	assert.False(t, Flag{Rollout: Percent(100)}.On("f", "u"))
+	assert.True(t, Flag{Enabled: true}.On("f", "u"))
+	assert.True(t, Flag{Enabled: true, Rollout: Percent(100)}.On("f", "u"))
+	assert.False(t, Flag{Enabled: true, Rollout: Percent(0)}.On("f", "u"))
+}
+
+func TestRolloutShare(t *testing.T) {
+	f := Flag{Enabled: true, Rollout: Percent(30)}
+	on := 0
+	for i := 0; i < 10000; i++ {
+		if f.On("share", fmt.Sprintf("user-%d", i)) {
+			on++
+		}
+	}
+	assert.InDelta(t, 3000, on, 300)
+}
+
+func TestRolloutOnlyAdds(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		u := fmt.Sprintf("user-%d", i)
+		for p := 0; p < 100; p += 10 {
+			if (Flag{Enabled: true, Rollout: Percent(p)}).On("grow", u) {
+				assert.True(t, Flag{Enabled: true, Rollout: Percent(p + 10)}.On("grow", u))
+			}
+		}
+	}
+}
+
+func TestPick(t *testing.T) {
+	variants := []Variant{{"a", 3}, {"b", 1}, {"off", 0}}
+	counts := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		u := fmt.Sprintf("user-%d", i)
+		v := Pick("exp", u, variants)
+		assert.Equal(t, v, Pick("exp", u, variants))
+		counts[v]++
+	}
+	assert.InDelta(t, 3000, counts["a"], 200)
+	assert.InDelta(t, 1000, counts["b"], 200)
+	assert.Zero(t, counts["off"])
+	assert.Equal(t, "", Pick("exp", "u", nil))
+}
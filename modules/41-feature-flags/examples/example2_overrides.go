@@ -0,0 +1,91 @@
+package examples
+
+// This file shows:
+// - Overrides per environment, with pointer fields for "not set", so
+//   that an override of the rollout alone leaves the flag enabled
+// - Working out a flag for an environment on a copy, leaving the
+//   definition as it was for the others
+// - An Evaluator for one environment, to which unknown flags are off
+
+import (
+	"fmt"
+)
+
+// Override changes a flag in one environment. Nil fields leave the
+// flag's own value.
+type Override struct {
+	Enabled *bool `json:"enabled,omitempty"`
+	Rollout *int  `json:"rollout,omitempty"`
+}
+
+// Definition is a flag and its overrides, by environment.
+type Definition struct {
+	Flag
+	Environments map[string]Override `json:"environments,omitempty"`
+}
+
+// In returns the flag as it is in env.
+func (d Definition) In(env string) Flag {
+	f := d.Flag // a copy: d's own Flag is the same for every environment
+	o, ok := d.Environments[env]
+	if !ok {
+		return f
+	}
+	if o.Enabled != nil {
+		f.Enabled = *o.Enabled
+	}
+	if o.Rollout != nil {
+		f.Rollout = o.Rollout
+	}
+	return f
+}
+
+// Set is a service's flags, by name.
+type Set map[string]Definition
+
+// Evaluator answers whether flags are on in one environment.
+type Evaluator struct {
+	Env string
+	Set Set
+}
+
+// Enabled reports whether the flag called name is on for user. A flag
+// that is not in the set is off, so that code can ship before its flag.
+func (e Evaluator) Enabled(name, user string) bool {
+	d, ok := e.Set[name]
+	if !ok {
+		return false
+	}
+	return d.In(e.Env).On(name, user)
+}
+
+// Bool returns a pointer to b, for Override.Enabled.
+func Bool(b bool) *bool {
+	return &b
+}
+
+// DemonstrateOverrides evaluates a flag that is on in staging, rolling
+// out in production, and off on a developer's machine.
+func DemonstrateOverrides() {
+	fmt.Println("=== Overrides ===")
+	set := Set{
+		"new-editor": {
+			Flag: Flag{Enabled: true, Rollout: Percent(10)},
+			Environments: map[string]Override{
+				"staging": {Rollout: Percent(100)},
+				"dev":     {Enabled: Bool(false)},
+			},
+		},
+	}
+	for _, env := range []string{"production", "staging", "dev"} {
+		e := Evaluator{Env: env, Set: set}
+		on := 0
+		for i := 0; i < 1000; i++ {
+			if e.Enabled("new-editor", fmt.Sprintf("user-%d", i)) {
+				on++
+			}
+		}
+		fmt.Printf("%-10s %4d of 1000\n", env, on)
+	}
+	fmt.Println("unknown flag:", Evaluator{Set: set}.Enabled("not-yet", "user-1"))
+}
@@ -0,0 +1,39 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateOverrides(t *testing.T) {
+	DemonstrateOverrides()
+}
+
+func TestIn(t *testing.T) {
+	d := Definition{
+		Flag: Flag{Enabled: true, Rollout: Percent(10)},
+		Environments: map[string]Override{
+			"staging": {Rollout: Percent(100)},
+			"dev":     {Enabled: Bool(false)},
+			"qa":      {},
+		},
+	}
+	assert.Equal(t, Flag{Enabled: true, Rollout: Percent(10)}, d.In("production"))
+	assert.Equal(t, Flag{Enabled: true, Rollout: Percent(100)}, d.In("staging"))
+	assert.Equal(t, Flag{Enabled: false, Rollout: Percent(10)}, d.In("dev"))
+	assert.Equal(t, Flag{Enabled: true, Rollout: Percent(10)}, d.In("qa"))
+	assert.Equal(t, 10, *d.Rollout, "In leaves the definition alone")
+}
+
+func TestEvaluator(t *testing.T) {
+	set := Set{
+		"on":  {Flag: Flag{Enabled: true}},
+		"off": {Flag: Flag{Enabled: true}, Environments: map[string]Override{"prod": {Enabled: Bool(false)}}},
+	}
+	e := Evaluator{Env: "prod", Set: set}
+	assert.True(t, e.Enabled("on", "u"))
+	assert.False(t, e.Enabled("off", "u"))
+	assert.False(t, e.Enabled("missing", "u"))
+	assert.True(t, Evaluator{Env: "dev", Set: set}.Enabled("off", "u"))
+}
@@ -0,0 +1,180 @@
+package examples
+
+// This file shows:
+// - Loading flags from a JSON file, rejecting unknown fields and
+//   rollouts outside 0 to 100 instead of guessing
+// - Swapping the whole set at once with atomic.Pointer, so that a
+//   request never sees half of an old file and half of a new one
+// - Reloading when the file changes, and keeping the flags that work
+//   when the new file does not
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrInvalid is returned for a flags file that parses, but makes no
+// sense.
+var ErrInvalid = errors.New("invalid flags")
+
+// flagsFile is the layout of a flags file:
+//
+//	{"flags": {"new-editor": {"enabled": true, "rollout": 10,
+//	  "environments": {"staging": {"rollout": 100}}}}}
+type flagsFile struct {
+	Flags Set `json:"flags"`
+}
+
+// Parse reads a flags file from r.
+func Parse(r io.Reader) (Set, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields() // a misspelt "rollot" is a mistake, not an unset field
+	var f flagsFile
+	if err := dec.Decode(&f); err != nil {
+		return nil, err
+	}
+	for name, d := range f.Flags {
+		if name == "" {
+			return nil, fmt.Errorf("%w: flag with no name", ErrInvalid)
+		}
+		if err := checkRollout(d.Rollout); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		for env, o := range d.Environments {
+			if err := checkRollout(o.Rollout); err != nil {
+				return nil, fmt.Errorf("%s in %s: %w", name, env, err)
+			}
+		}
+	}
+	if f.Flags == nil {
+		f.Flags = Set{}
+	}
+	return f.Flags, nil
+}
+
+func checkRollout(p *int) error {
+	if p != nil && (*p < 0 || *p > 100) {
+		return fmt.Errorf("%w: rollout %d%% is not from 0 to 100", ErrInvalid, *p)
+	}
+	return nil
+}
+
+// LoadFile reads a flags file.
+func LoadFile(path string) (Set, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	set, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return set, nil
+}
+
+// Store holds the flags from a file, for one environment, and reloads
+// them when the file changes. It is safe for concurrent use.
+type Store struct {
+	path string
+	env  string
+	set  atomic.Pointer[Set]
+
+	mu      sync.Mutex // serializes Reload
+	modTime time.Time
+}
+
+// NewStore loads the flags in path. A service that cannot read its flags
+// at startup should not start.
+func NewStore(path, env string) (*Store, error) {
+	s := &Store{path: path, env: env}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Enabled reports whether the flag called name is on for user.
+func (s *Store) Enabled(name, user string) bool {
+	return Evaluator{Env: s.env, Set: *s.set.Load()}.Enabled(name, user)
+}
+
+// Reload reads the file again if it has changed. If the new file is
+// invalid, s keeps the flags it has.
+func (s *Store) Reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return err
+	}
+	if s.set.Load() != nil && info.ModTime().Equal(s.modTime) {
+		return nil
+	}
+	set, err := LoadFile(s.path)
+	if err != nil {
+		return err
+	}
+	s.set.Store(&set)
+	s.modTime = info.ModTime()
+	return nil
+}
+
+// Watch calls Reload every interval until ctx is done, and passes its
+// errors to onError.
+func (s *Store) Watch(ctx context.Context, every time.Duration, onError func(error)) {
+	t := time.NewTicker(every)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := s.Reload(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// DemonstrateLoading loads a flags file, replaces it with a broken one,
+// and then with one that turns the flag on for everyone.
+func DemonstrateLoading() {
+	fmt.Println("=== Loading ===")
+	dir, err := os.MkdirTemp("", "flags")
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "flags.json")
+	write := func(s string, mod time.Time) {
+		os.WriteFile(path, []byte(s), 0o644)
+		os.Chtimes(path, mod, mod)
+	}
+	start := time.Now().Add(-time.Hour)
+
+	write(`{"flags": {"new-editor": {"enabled": true, "rollout": 0}}}`, start)
+	s, err := NewStore(path, "production")
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Println("new-editor:", s.Enabled("new-editor", "user-1"))
+
+	write(`{"flags": {"new-editor": {"enabled": true, "rollout": 150}}}`, start.Add(time.Minute))
+	fmt.Println("invalid:", errors.Is(s.Reload(), ErrInvalid))
+	fmt.Println("new-editor:", s.Enabled("new-editor", "user-1"))
+
+	write(`{"flags": {"new-editor": {"enabled": true}}}`, start.Add(2*time.Minute))
+	fmt.Println("reload:", s.Reload())
+	fmt.Println("new-editor:", s.Enabled("new-editor", "user-1"))
+}
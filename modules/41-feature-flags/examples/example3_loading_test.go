@@ -0,0 +1,90 @@
+package examples
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateLoading(t *testing.T) {
+	DemonstrateLoading()
+}
+
+func TestParse(t *testing.T) {
+	set, err := Parse(strings.NewReader(`{"flags": {"a": {"enabled": true, "rollout": 5,
+		"environments": {"dev": {"enabled": false}}}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, Set{"a": {
+		Flag:         Flag{Enabled: true, Rollout: Percent(5)},
+		Environments: map[string]Override{"dev": {Enabled: Bool(false)}},
+	}}, set)
+
+	set, err = Parse(strings.NewReader(`{}`))
+	require.NoError(t, err)
+	assert.Empty(t, set)
+
+	for _, bad := range []string{
+		`{"flags": {"a": {"enabled": true, "rollot": 5}}}`,
+		`{"flags": {"a": {"rollout": 101}}}`,
+		`{"flags": {"a": {"environments": {"dev": {"rollout": -1}}}}}`,
+		`{"flags": {"": {}}}`,
+		`{"flags": `,
+	} {
+		_, err := Parse(strings.NewReader(bad))
+		assert.Error(t, err, bad)
+	}
+	_, err = Parse(strings.NewReader(`{"flags": {"a": {"rollout": 101}}}`))
+	assert.ErrorIs(t, err, ErrInvalid)
+}
+
+func TestStoreReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.json")
+	mod := time.Now().Add(-time.Hour)
+	write := func(s string) {
+		require.NoError(t, os.WriteFile(path, []byte(s), 0o644))
+		mod = mod.Add(time.Second)
+		require.NoError(t, os.Chtimes(path, mod, mod))
+	}
+
+	_, err := NewStore(path, "prod")
+	assert.Error(t, err)
+
+	write(`{"flags": {"a": {"enabled": true}}}`)
+	s, err := NewStore(path, "prod")
+	require.NoError(t, err)
+	assert.True(t, s.Enabled("a", "u"))
+
+	write(`not json`)
+	assert.Error(t, s.Reload())
+	assert.True(t, s.Enabled("a", "u"), "a bad file keeps the flags")
+
+	write(`{"flags": {"a": {"enabled": true, "environments": {"prod": {"enabled": false}}}}}`)
+	require.NoError(t, s.Reload())
+	assert.False(t, s.Enabled("a", "u"))
+}
+
+func TestStoreWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.json")
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.WriteFile(path, []byte(`{"flags": {}}`), 0o644))
+	require.NoError(t, os.Chtimes(path, old, old))
+	s, err := NewStore(path, "prod")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Watch(ctx, time.Millisecond, nil)
+	}()
+	require.NoError(t, os.WriteFile(path, []byte(`{"flags": {"a": {"enabled": true}}}`), 0o644))
+	assert.Eventually(t, func() bool { return s.Enabled("a", "u") }, time.Second, time.Millisecond)
+	cancel()
+	<-done
+}
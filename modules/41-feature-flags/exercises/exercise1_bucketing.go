@@ -0,0 +1,67 @@
+package exercises
+
+// EXERCISE: Fix a percentage rollout that gives users a different answer on every request.
+// Bucket puts each user in one of 100 buckets per flag, and a rollout of
+// p% is on for the users in p of them. But each replica buckets users
+// differently, every rollout picks the same users, 0% is not off, and a
+// user's variant in an experiment changes from one call to the next.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"hash/maphash"
+)
+
+// seed is the seed of every bucket hash.
+var seed = maphash.MakeSeed()
+
+// Bucket returns user's bucket for flag, from 0 to 99: the FNV-1a 32-bit
+// hash of flag, a zero byte, and user, modulo 100.
+func Bucket(flag, user string) int {
+	// BUG: seed is random, and new in every process, so each replica and
+	// each restart puts the user in a different bucket.
+	// BUG: flag is not hashed, so a user in the first 10% of one rollout
+	// is in the first 10% of all of them.
+	return int(maphash.String(seed, user) % 100)
+}
+
+// Rollout is a flag that is on for Percent percent of users.
+type Rollout struct {
+	Flag    string
+	Percent int
+}
+
+// On reports whether r is on for user.
+func (r Rollout) On(user string) bool {
+	// BUG: Percent+1 buckets are at or below Percent, so 0% is on for a
+	// hundredth of users.
+	return Bucket(r.Flag, user) <= r.Percent
+}
+
+// Experiment splits users between variants, each getting its weight's
+// share.
+type Experiment struct {
+	Name    string
+	Weights map[string]int
+}
+
+// Variant returns the variant user is in, or "" if no variant has any
+// weight.
+func (e Experiment) Variant(user string) string {
+	total := 0
+	for _, w := range e.Weights {
+		total += max(w, 0)
+	}
+	if total == 0 {
+		return ""
+	}
+	n := Bucket(e.Name, user) * total / 100
+	// BUG: a range over a map visits the keys in a different order each
+	// time, so the same n lands on a different variant.
+	for name, w := range e.Weights {
+		n -= max(w, 0)
+		if n < 0 {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package exercises
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const flagsJSON = `{
+	"new-editor": {
+		"enabled": true,
+		"rollout": 10,
+		"environments": {
+			"staging": {"rollout": 100},
+			"dev": {"enabled": false}
+		}
+	},
+	"dark-mode": {"enabled": false, "environments": {"staging": {"enabled": true}}}
+}`
+
+// share returns how many of 1000 users name is on for.
+func share(f *Flags, name string) int {
+	n := 0
+	for i := 0; i < 1000; i++ {
+		if f.Enabled(name, fmt.Sprintf("user-%d", i)) {
+			n++
+		}
+	}
+	return n
+}
+
+func load(t *testing.T, env string) *Flags {
+	t.Helper()
+	f := &Flags{Env: env}
+	require.NoError(t, f.Load(strings.NewReader(flagsJSON)))
+	return f
+}
+
+func TestEnvironments(t *testing.T) {
+	assert.InDelta(t, 100, share(load(t, "production"), "new-editor"), 40)
+	assert.Equal(t, 1000, share(load(t, "staging"), "new-editor"), "a rollout override keeps the flag enabled")
+	assert.Zero(t, share(load(t, "dev"), "new-editor"))
+
+	assert.Zero(t, share(load(t, "production"), "dark-mode"))
+	assert.Equal(t, 1000, share(load(t, "staging"), "dark-mode"))
+	assert.Zero(t, share(load(t, "production"), "missing"))
+}
+
+func TestResolveLeavesDefinitionAlone(t *testing.T) {
+	staging := load(t, "staging")
+	production := &Flags{Env: "production"}
+	production.defs.Store(staging.defs.Load())
+
+	assert.Equal(t, 1000, share(staging, "new-editor"))
+	assert.InDelta(t, 100, share(production, "new-editor"), 40, "staging's override must not change production")
+}
+
+func TestLoadRejectsBadRollouts(t *testing.T) {
+	for _, bad := range []string{
+		`{"a": {"enabled": true, "rollout": 101}}`,
+		`{"a": {"enabled": true, "environments": {"dev": {"rollout": 150}}}}`,
+		`{"a": {"enabled": true, "environments": {"dev": {"rollout": -5}}}}`,
+	} {
+		f := &Flags{Env: "dev"}
+		assert.ErrorIs(t, f.Load(strings.NewReader(bad)), ErrInvalid, bad)
+	}
+	f := &Flags{}
+	assert.Error(t, f.Load(strings.NewReader(`{"a": {"enabeld": true}}`)))
+}
+
+func TestLoadKeepsFlagsOnError(t *testing.T) {
+	f := load(t, "staging")
+	assert.Error(t, f.Load(strings.NewReader(`{"new-editor": `)))
+	assert.Error(t, f.Load(strings.NewReader(`{"new-editor": {"rollout": 500}}`)))
+	assert.Equal(t, 1000, share(f, "new-editor"))
+}
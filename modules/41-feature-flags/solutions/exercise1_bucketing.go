@@ -0,0 +1,73 @@
+package solutions
+
+// SOLUTION: Fix a percentage rollout that gives users a different answer on every request.
+// Bucket hashes the flag's name and the user's ID with FNV-1a, which
+// gives the same bucket in every process, rather than with maphash,
+// whose seed is new in each one. A user is in a rollout if their bucket
+// is below Percent, so 0% is no one, and Variant walks the weights in
+// sorted order, not map order.
+
+import (
+	"hash/fnv"
+	"sort"
+)
+
+// Bucket returns user's bucket for flag, from 0 to 99: the FNV-1a 32-bit
+// hash of flag, a zero byte, and user, modulo 100.
+func Bucket(flag, user string) int {
+	// Fixed: FNV-1a has no seed, so every replica and every restart
+	// agrees.
+	h := fnv.New32a()
+	// Fixed: the flag is hashed too, so each flag picks different users.
+	h.Write([]byte(flag))
+	h.Write([]byte{0})
+	h.Write([]byte(user))
+	return int(h.Sum32() % 100)
+}
+
+// Rollout is a flag that is on for Percent percent of users.
+type Rollout struct {
+	Flag    string
+	Percent int
+}
+
+// On reports whether r is on for user.
+func (r Rollout) On(user string) bool {
+	// Fixed: buckets run from 0 to 99, so Percent of them are below
+	// Percent.
+	return Bucket(r.Flag, user) < r.Percent
+}
+
+// Experiment splits users between variants, each getting its weight's
+// share.
+type Experiment struct {
+	Name    string
+	Weights map[string]int
+}
+
+// Variant returns the variant user is in, or "" if no variant has any
+// weight.
+func (e Experiment) Variant(user string) string {
+	// Fixed: names in sorted order, the same every time, where ranging
+	// over the map gives a new order on every call.
+	names := make([]string, 0, len(e.Weights))
+	total := 0
+	for name, w := range e.Weights {
+		if w > 0 {
+			names = append(names, name)
+			total += w
+		}
+	}
+	if total == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	n := Bucket(e.Name, user) * total / 100
+	for _, name := range names {
+		n -= e.Weights[name]
+		if n < 0 {
+			return name
+		}
+	}
+	return ""
+}
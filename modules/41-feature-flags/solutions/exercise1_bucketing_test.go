@@ -0,0 +1,80 @@
+package solutions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketKnownValues(t *testing.T) {
+	// FNV-1a of "new-editor\x00user-1" is 0x726bb46e, which is 66
+	// modulo 100: the buckets every replica must agree on.
+	tests := []struct {
+		flag, user string
+		want       int
+	}{
+		{"new-editor", "user-1", 66},
+		{"new-editor", "user-2", 47},
+		{"dark-mode", "user-1", 84},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Bucket(tt.flag, tt.user), "%s %s", tt.flag, tt.user)
+	}
+}
+
+func TestBucketRange(t *testing.T) {
+	seen := map[int]bool{}
+	for i := 0; i < 10000; i++ {
+		b := Bucket("range", fmt.Sprint(i))
+		assert.True(t, b >= 0 && b < 100, b)
+		seen[b] = true
+	}
+	assert.Len(t, seen, 100)
+}
+
+func TestRolloutsAreIndependent(t *testing.T) {
+	a := Rollout{Flag: "new-editor", Percent: 10}
+	b := Rollout{Flag: "dark-mode", Percent: 10}
+	both := 0
+	for i := 0; i < 10000; i++ {
+		u := fmt.Sprintf("user-%d", i)
+		if a.On(u) && b.On(u) {
+			both++
+		}
+	}
+	assert.InDelta(t, 100, both, 60, "about 1%% of users should be in both 10%% rollouts")
+}
+
+func TestRolloutEdges(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		u := fmt.Sprintf("user-%d", i)
+		assert.False(t, Rollout{Flag: "f", Percent: 0}.On(u), u)
+		assert.True(t, Rollout{Flag: "f", Percent: 100}.On(u), u)
+	}
+}
+
+func TestVariantIsStable(t *testing.T) {
+	e := Experiment{Name: "checkout", Weights: map[string]int{
+		"control": 25, "blue": 25, "green": 25, "red": 25,
+	}}
+	for i := 0; i < 100; i++ {
+		u := fmt.Sprintf("user-%d", i)
+		first := e.Variant(u)
+		for j := 0; j < 20; j++ {
+			assert.Equal(t, first, e.Variant(u), u)
+		}
+	}
+}
+
+func TestVariantShares(t *testing.T) {
+	e := Experiment{Name: "checkout", Weights: map[string]int{"control": 3, "new": 1, "off": 0}}
+	counts := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		counts[e.Variant(fmt.Sprintf("user-%d", i))]++
+	}
+	assert.InDelta(t, 3000, counts["control"], 200)
+	assert.InDelta(t, 1000, counts["new"], 200)
+	assert.Zero(t, counts["off"])
+	assert.Equal(t, "", Experiment{Name: "none"}.Variant("user-1"))
+}
@@ -0,0 +1,126 @@
+package solutions
+
+// SOLUTION: Fix a flags file loader whose environment overrides leak into each other.
+// Override.Enabled is a pointer, so that an override that leaves it out
+// keeps the flag's own value. Resolve replaces the Rollout pointer
+// instead of writing through it, since the copy of the Flag shares it
+// with the definition. Load checks the rollouts in overrides too, and
+// keeps the flags it has when the new file is invalid.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sync/atomic"
+)
+
+// ErrInvalid is returned by Load for a rollout outside 0 to 100.
+var ErrInvalid = errors.New("invalid flags")
+
+// Flag is a feature flag: off, on, or on for Rollout percent of users.
+type Flag struct {
+	Enabled bool `json:"enabled"`
+	Rollout *int `json:"rollout,omitempty"`
+}
+
+// Override changes a flag in one environment. Fields that are not set
+// leave the flag's own value.
+type Override struct {
+	// Fixed: a pointer, so that "not set" is not false.
+	Enabled *bool `json:"enabled,omitempty"`
+	Rollout *int  `json:"rollout,omitempty"`
+}
+
+// Definition is a flag and its overrides, by environment.
+type Definition struct {
+	Flag
+	Environments map[string]Override `json:"environments,omitempty"`
+}
+
+// Resolve returns the flag as it is in env.
+func (d *Definition) Resolve(env string) Flag {
+	f := d.Flag
+	o, ok := d.Environments[env]
+	if !ok {
+		return f
+	}
+	if o.Enabled != nil {
+		f.Enabled = *o.Enabled
+	}
+	if o.Rollout != nil {
+		// Fixed: f.Rollout points at d's rollout; replace the pointer,
+		// not what it points at.
+		f.Rollout = o.Rollout
+	}
+	return f
+}
+
+// bucket returns user's bucket for flag, from 0 to 99.
+func bucket(flag, user string) int {
+	h := fnv.New32a()
+	h.Write([]byte(flag))
+	h.Write([]byte{0})
+	h.Write([]byte(user))
+	return int(h.Sum32() % 100)
+}
+
+// Flags is a service's flags in one environment. It is safe for
+// concurrent use.
+type Flags struct {
+	Env string
+
+	defs atomic.Pointer[map[string]*Definition]
+}
+
+// Load replaces the flags with the ones in r, a JSON object of
+// Definitions by name. If r is invalid, f keeps the flags it has.
+func (f *Flags) Load(r io.Reader) error {
+	var defs map[string]*Definition
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&defs); err != nil {
+		return err
+	}
+	for name, d := range defs {
+		if err := checkRollout(d.Rollout); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		// Fixed: overrides are checked too.
+		for env, o := range d.Environments {
+			if err := checkRollout(o.Rollout); err != nil {
+				return fmt.Errorf("%s in %s: %w", name, env, err)
+			}
+		}
+	}
+	// Fixed: the new flags are stored only once they are known to be
+	// valid.
+	f.defs.Store(&defs)
+	return nil
+}
+
+func checkRollout(p *int) error {
+	if p != nil && (*p < 0 || *p > 100) {
+		return fmt.Errorf("%w: rollout %d%%", ErrInvalid, *p)
+	}
+	return nil
+}
+
+// Enabled reports whether the flag called name is on for user. Flags
+// that are not defined are off.
+func (f *Flags) Enabled(name, user string) bool {
+	defs := f.defs.Load()
+	if defs == nil {
+		return false
+	}
+	d, ok := (*defs)[name]
+	if !ok {
+		return false
+	}
+	flag := d.Resolve(f.Env)
+	if !flag.Enabled {
+		return false
+	}
+	return flag.Rollout == nil || bucket(name, user) < *flag.Rollout
+}
@@ -0,0 +1,146 @@
+// Package examples demonstrates configuration that changes while a
+// service runs: immutable snapshots swapped with atomic.Pointer, a
+// watcher that reloads the file when it changes, and subscribers told
+// about each new snapshot over channels.
+//
+// This file shows:
+// - A Config that nobody changes after it is built: a new file makes a new Config
+// - A Duration type that implements json.Unmarshaler, for timeouts written as "5s"
+// - Decoding into a fresh value, and validating it, before anyone sees it
+// - Copy-on-write: a changed Config is a copy, with its map cloned
+// - Holding one snapshot for a whole request, so that the request sees one config, not parts of two
+package examples
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sync/atomic"
+	"time"
+)
+
+// ErrInvalid is returned for a config that decodes, but makes no sense.
+var ErrInvalid = errors.New("invalid config")
+
+// Duration is a time.Duration written in JSON as a string such as "5s".
+type Duration time.Duration
+
+// UnmarshalJSON parses a string such as "1m30s".
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalJSON writes d as a string such as "1m30s".
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Config is a service's configuration. A *Config is a snapshot: nothing
+// changes it once Parse has returned it, so any number of goroutines
+// can read it without a lock.
+type Config struct {
+	Version int            `json:"version"`
+	Addr    string         `json:"addr"`
+	Timeout Duration       `json:"timeout"`
+	Limits  map[string]int `json:"limits,omitempty"`
+}
+
+// Parse decodes and validates a config. It decodes into a new Config,
+// so that nothing from an earlier one survives in it.
+func Parse(data []byte) (*Config, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var c Config
+	if err := dec.Decode(&c); err != nil {
+		return nil, err
+	}
+	if c.Addr == "" {
+		return nil, fmt.Errorf("%w: no addr", ErrInvalid)
+	}
+	if c.Timeout <= 0 {
+		return nil, fmt.Errorf("%w: timeout %v", ErrInvalid, time.Duration(c.Timeout))
+	}
+	for name, n := range c.Limits {
+		if n < 0 {
+			return nil, fmt.Errorf("%w: limit %s is %d", ErrInvalid, name, n)
+		}
+	}
+	return &c, nil
+}
+
+// Limit returns the limit called name.
+func (c *Config) Limit(name string) (int, bool) {
+	n, ok := c.Limits[name]
+	return n, ok
+}
+
+// WithLimit returns a copy of c with the limit called name set to n. A
+// copy of the struct would share c's map; the map is cloned too.
+func (c *Config) WithLimit(name string, n int) *Config {
+	cp := *c
+	cp.Limits = maps.Clone(c.Limits)
+	if cp.Limits == nil {
+		cp.Limits = map[string]int{}
+	}
+	cp.Limits[name] = n
+	return &cp
+}
+
+// Holder holds the current Config. Load and Swap are single atomic
+// operations on a pointer, so a reader gets the old snapshot or the new
+// one, and never a mixture.
+type Holder struct {
+	p atomic.Pointer[Config]
+}
+
+// NewHolder returns a Holder of c.
+func NewHolder(c *Config) *Holder {
+	h := &Holder{}
+	h.p.Store(c)
+	return h
+}
+
+// Load returns the current Config.
+func (h *Holder) Load() *Config {
+	return h.p.Load()
+}
+
+// Swap makes c the current Config, and returns the one it replaces.
+func (h *Holder) Swap(c *Config) *Config {
+	return h.p.Swap(c)
+}
+
+// DemonstrateSnapshot swaps the config in the middle of a "request"
+// that holds its own snapshot.
+func DemonstrateSnapshot() {
+	fmt.Println("=== Snapshots ===")
+	v1, err := Parse([]byte(`{"version": 1, "addr": ":8080", "timeout": "5s", "limits": {"upload": 10}}`))
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	h := NewHolder(v1)
+
+	cfg := h.Load() // one snapshot for the whole request
+	h.Swap(cfg.WithLimit("upload", 20))
+	n, _ := cfg.Limit("upload")
+	fmt.Printf("request: version %d, upload limit %d\n", cfg.Version, n)
+	n, _ = h.Load().Limit("upload")
+	fmt.Printf("next request: upload limit %d\n", n)
+
+	_, err = Parse([]byte(`{"version": 2, "addr": ":8080", "timeout": "soon"}`))
+	fmt.Println("bad timeout:", err)
+	_, err = Parse([]byte(`{"version": 2, "addr": ":8080", "timeout": "0s"}`))
+	fmt.Println("zero timeout:", errors.Is(err, ErrInvalid))
+}
@@ -0,0 +1,76 @@
+package examples
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateSnapshot(t *testing.T) {
+	DemonstrateSnapshot()
+}
+
+func TestParse(t *testing.T) {
+	c, err := Parse([]byte(`{"version": 3, "addr": ":80", "timeout": "1m30s", "limits": {"a": 1}}`))
+	require.NoError(t, err)
+	assert.Equal(t, &Config{Version: 3, Addr: ":80", Timeout: Duration(90 * time.Second), Limits: map[string]int{"a": 1}}, c)
+
+	b, err := json.Marshal(c)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"timeout":"1m30s"`)
+
+	for _, bad := range []string{
+		`{"addr": ":80"}`,
+		`{"timeout": "1s"}`,
+		`{"addr": ":80", "timeout": "1s", "limits": {"a": -1}}`,
+		`{"addr": ":80", "timeout": "1s", "timout": "2s"}`,
+		`{"addr": ":80", "timeout": 5}`,
+	} {
+		_, err := Parse([]byte(bad))
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestWithLimit(t *testing.T) {
+	c := &Config{Limits: map[string]int{"a": 1}}
+	d := c.WithLimit("a", 2)
+	n, _ := c.Limit("a")
+	assert.Equal(t, 1, n, "the original is unchanged")
+	n, _ = d.Limit("a")
+	assert.Equal(t, 2, n)
+
+	n, ok := (&Config{}).WithLimit("b", 5).Limit("b")
+	assert.True(t, ok)
+	assert.Equal(t, 5, n)
+}
+
+func TestHolderConcurrent(t *testing.T) {
+	h := NewHolder(&Config{Version: 0, Limits: map[string]int{"v": 0}})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 1000; i++ {
+			c := h.Load().WithLimit("v", i)
+			c.Version = i
+			h.Swap(c)
+		}
+	}()
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				c := h.Load()
+				n, _ := c.Limit("v")
+				assert.Equal(t, c.Version, n)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1000, h.Load().Version)
+}
@@ -0,0 +1,96 @@
+package examples
+
+// This file shows:
+// - Telling subscribers about a new Config over channels, after it is
+//   current, so that a subscriber that calls Current sees it too
+// - Channels with room for one value, where a new Config replaces one
+//   that has not been received yet: a slow subscriber never blocks a
+//   reload, and always gets the latest Config
+// - Unsubscribing, which closes the channel, under the same lock as the
+//   sends, so that nothing sends on a closed channel
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Store holds the current Config, and tells its subscribers when it
+// changes.
+type Store struct {
+	cur atomic.Pointer[Config]
+
+	mu   sync.Mutex
+	subs map[chan *Config]struct{}
+}
+
+// NewStore returns a Store of c.
+func NewStore(c *Config) *Store {
+	s := &Store{subs: map[chan *Config]struct{}{}}
+	s.cur.Store(c)
+	return s
+}
+
+// Current returns the current Config.
+func (s *Store) Current() *Config {
+	return s.cur.Load()
+}
+
+// Set makes c the current Config, and sends it to every subscriber.
+func (s *Store) Set(c *Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Store first: a subscriber woken by the send may call Current.
+	s.cur.Store(c)
+	for ch := range s.subs {
+		// Take back a Config the subscriber has not received yet, then
+		// send the new one. The channel has room for one, and only Set
+		// sends on it, under s.mu, so the send never blocks.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- c
+	}
+}
+
+// Subscribe returns a channel that receives each new Config, and a
+// function that unsubscribes and closes the channel. A subscriber that
+// falls behind skips to the latest Config.
+func (s *Store) Subscribe() (<-chan *Config, func()) {
+	ch := make(chan *Config, 1)
+	s.mu.Lock()
+	s.subs[ch] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			delete(s.subs, ch)
+			close(ch)
+		})
+	}
+}
+
+// DemonstrateSubscribe sets three configs while one subscriber keeps up
+// and another is busy.
+func DemonstrateSubscribe() {
+	fmt.Println("=== Subscribers ===")
+	s := NewStore(&Config{Version: 1, Addr: ":8080"})
+	fast, unsubscribeFast := s.Subscribe()
+	slow, unsubscribeSlow := s.Subscribe()
+	defer unsubscribeSlow()
+
+	for v := 2; v <= 4; v++ {
+		s.Set(&Config{Version: v, Addr: ":8080"})
+		fmt.Println("fast got version", (<-fast).Version)
+	}
+	fmt.Println("slow got version", (<-slow).Version, "and skipped the rest")
+
+	unsubscribeFast()
+	s.Set(&Config{Version: 5, Addr: ":8080"})
+	_, ok := <-fast
+	fmt.Println("fast after unsubscribing: open", ok)
+}
@@ -0,0 +1,78 @@
+package examples
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateSubscribe(t *testing.T) {
+	DemonstrateSubscribe()
+}
+
+func TestSetDoesNotBlock(t *testing.T) {
+	s := NewStore(&Config{Version: 0})
+	ch, unsubscribe := s.Subscribe()
+	defer unsubscribe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for v := 1; v <= 10; v++ {
+			s.Set(&Config{Version: v})
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Set blocked on a subscriber that is not receiving")
+	}
+	assert.Equal(t, 10, (<-ch).Version, "the latest")
+	select {
+	case c := <-ch:
+		t.Fatalf("got version %d as well", c.Version)
+	default:
+	}
+}
+
+func TestSubscriberSeesCurrent(t *testing.T) {
+	s := NewStore(&Config{Version: 0})
+	ch, unsubscribe := s.Subscribe()
+	defer unsubscribe()
+	got := make(chan int)
+	go func() {
+		for c := range ch {
+			assert.Equal(t, c, s.Current())
+			got <- c.Version
+		}
+	}()
+	s.Set(&Config{Version: 1})
+	assert.Equal(t, 1, <-got)
+}
+
+func TestUnsubscribeConcurrent(t *testing.T) {
+	s := NewStore(&Config{})
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		ch, unsubscribe := s.Subscribe()
+		go func() {
+			defer wg.Done()
+			for range ch {
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			unsubscribe()
+			unsubscribe()
+		}()
+	}
+	for v := 0; v < 100; v++ {
+		s.Set(&Config{Version: v})
+	}
+	wg.Wait()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	assert.Empty(t, s.subs)
+}
@@ -0,0 +1,145 @@
+package examples
+
+// This file shows:
+// - Polling a config file, and reloading only when its contents change
+// - Keeping the current Config when the new file is invalid, and
+//   reporting why once, not on every tick
+// - Writing a config file atomically, to a temporary file renamed over
+//   the old one, so that the watcher never reads half of it
+// - A watch loop on package watch's polling, which stops with its
+//   context and keeps going after an error
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/watch"
+)
+
+// Watcher reloads a Store's Config from a file.
+type Watcher struct {
+	Path  string
+	Store *Store
+	// OnError is called with each failed reload. Nil ignores them.
+	OnError func(error)
+
+	last []byte
+}
+
+// Reload reads the file, and sets a new Config if its contents have
+// changed since the last reload. It reports whether it did. Contents
+// that are invalid are remembered too, and reported only the first
+// time.
+func (w *Watcher) Reload() (bool, error) {
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		return false, err
+	}
+	if w.last != nil && bytes.Equal(data, w.last) {
+		return false, nil
+	}
+	w.last = data
+	c, err := Parse(data)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", w.Path, err)
+	}
+	w.Store.Set(c)
+	return true, nil
+}
+
+// Run reloads the file each time it changes, looking every interval,
+// until ctx is done. Package watch notices a new size or modification
+// time; Reload still compares the contents, so a file saved unchanged is
+// not set again.
+func (w *Watcher) Run(ctx context.Context, every time.Duration) {
+	files := &watch.Watcher{Paths: []string{w.Path}, Interval: every}
+	files.Poll()
+	// A change since the caller's last Reload came before the first
+	// listing, and Wait would not see it.
+	w.reload()
+	for {
+		if _, err := files.Wait(ctx); ctx.Err() != nil {
+			return
+		} else if err != nil {
+			w.report(err)
+			continue
+		}
+		w.reload()
+	}
+}
+
+// reload calls Reload and reports its error.
+func (w *Watcher) reload() {
+	if _, err := w.Reload(); err != nil {
+		w.report(err)
+	}
+}
+
+// report passes err to OnError, if there is one.
+func (w *Watcher) report(err error) {
+	if w.OnError != nil {
+		w.OnError(err)
+	}
+}
+
+// WriteFile replaces the file at path with data, atomically: readers see
+// the old file or the new one, never a mixture.
+func WriteFile(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".config-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) // fails harmlessly after the rename
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// DemonstrateWatch watches a file while it is changed, broken, and
+// fixed.
+func DemonstrateWatch() {
+	fmt.Println("=== Watching ===")
+	dir, err := os.MkdirTemp("", "config")
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+	WriteFile(path, []byte(`{"version": 1, "addr": ":8080", "timeout": "5s"}`))
+
+	s := NewStore(&Config{})
+	errs := make(chan error, 1)
+	w := &Watcher{Path: path, Store: s, OnError: func(err error) { errs <- err }}
+	if _, err := w.Reload(); err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	updates, unsubscribe := s.Subscribe()
+	defer unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx, 5*time.Millisecond)
+
+	WriteFile(path, []byte(`{"version": 2, "addr": ":8080", "timeout": "10s"}`))
+	u := <-updates
+	fmt.Printf("version %d, timeout %v\n", u.Version, time.Duration(u.Timeout))
+
+	WriteFile(path, []byte(`{"version": 3, "addr": ""`))
+	<-errs
+	fmt.Println("broken file, still version", s.Current().Version)
+
+	WriteFile(path, []byte(`{"version": 4, "addr": ":9090", "timeout": "10s"}`))
+	u = <-updates
+	fmt.Printf("version %d, addr %s\n", u.Version, u.Addr)
+}
@@ -0,0 +1,86 @@
+package examples
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateWatch(t *testing.T) {
+	DemonstrateWatch()
+}
+
+const configV1 = `{"version": 1, "addr": ":8080", "timeout": "5s"}`
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, WriteFile(path, []byte(configV1)))
+	s := NewStore(&Config{})
+	w := &Watcher{Path: path, Store: s}
+
+	changed, err := w.Reload()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, 1, s.Current().Version)
+
+	changed, err = w.Reload()
+	require.NoError(t, err)
+	assert.False(t, changed, "the same contents")
+
+	require.NoError(t, WriteFile(path, []byte(`{"version": 2}`)))
+	_, err = w.Reload()
+	assert.ErrorIs(t, err, ErrInvalid)
+	assert.Equal(t, 1, s.Current().Version)
+	changed, err = w.Reload()
+	assert.NoError(t, err, "reported once")
+	assert.False(t, changed)
+
+	require.NoError(t, os.Remove(path))
+	_, err = w.Reload()
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.Equal(t, 1, s.Current().Version)
+}
+
+func TestRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, WriteFile(path, []byte(`{"version": 0}`)))
+	s := NewStore(&Config{})
+	updates, unsubscribe := s.Subscribe()
+	defer unsubscribe()
+	errs := make(chan error, 1)
+	w := &Watcher{Path: path, Store: s, OnError: func(err error) { errs <- err }}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.Run(ctx, time.Millisecond)
+	}()
+	<-errs
+	require.NoError(t, WriteFile(path, []byte(configV1)))
+	select {
+	case c := <-updates:
+		assert.Equal(t, 1, c.Version)
+	case <-time.After(time.Second):
+		t.Fatal("no update")
+	}
+	cancel()
+	<-done
+}
+
+func TestWriteFileLeavesNoTemporaryFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	require.NoError(t, WriteFile(path, []byte("a")))
+	require.NoError(t, WriteFile(path, []byte("b")))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+	b, _ := os.ReadFile(path)
+	assert.Equal(t, "b", string(b))
+}
@@ -0,0 +1,81 @@
+package exercises
+
+// EXERCISE: Fix a config reloader whose readers see half-updated settings.
+// Reloader holds a service's settings, which handlers read with Current
+// while files are reloaded and limits changed. But a handler's settings
+// change under it in the middle of a request, a reload keeps values the
+// new file left out, an invalid file is half applied, and SetLimit
+// writes to a map that every handler is reading.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrInvalid is returned for settings without a DSN.
+var ErrInvalid = errors.New("invalid settings")
+
+// Settings is a snapshot of a service's configuration. Readers may keep
+// one for as long as they like; it never changes.
+type Settings struct {
+	Version int            `json:"version"`
+	DSN     string         `json:"dsn"`
+	Replica string         `json:"replica,omitempty"`
+	Limits  map[string]int `json:"limits,omitempty"`
+}
+
+// Reloader holds the current Settings.
+type Reloader struct {
+	mu  sync.Mutex // serializes writers; readers take no lock
+	cur atomic.Pointer[Settings]
+}
+
+// NewReloader returns a Reloader of the settings in data.
+func NewReloader(data []byte) (*Reloader, error) {
+	r := &Reloader{}
+	r.cur.Store(&Settings{})
+	if err := r.Reload(data); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Current returns the current Settings.
+func (r *Reloader) Current() *Settings {
+	return r.cur.Load()
+}
+
+// Reload replaces the settings with the ones in data. If data is
+// invalid, the settings are unchanged.
+func (r *Reloader) Reload(data []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// BUG: this decodes into the Settings that readers already hold,
+	// one field at a time. Fields the new file leaves out keep their old
+	// values, and its limits are added to the old map.
+	s := r.cur.Load()
+	if err := json.Unmarshal(data, s); err != nil {
+		return err
+	}
+	// BUG: by the time this is checked, readers have the invalid
+	// settings.
+	if s.DSN == "" {
+		return ErrInvalid
+	}
+	return nil
+}
+
+// SetLimit sets the limit called name to n.
+func (r *Reloader) SetLimit(name string, n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// BUG: this writes to the map of the Settings that readers hold.
+	s := r.cur.Load()
+	if s.Limits == nil {
+		s.Limits = map[string]int{}
+	}
+	s.Limits[name] = n
+}
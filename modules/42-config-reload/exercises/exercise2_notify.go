@@ -0,0 +1,122 @@
+package exercises
+
+// EXERCISE: Fix a config hub that blocks on slow subscribers and panics after an unsubscribe.
+// Hub sends each new Snapshot to its subscribers, and FileWatcher
+// publishes the snapshot in a file when it changes. But one subscriber
+// that is busy stops every reload, a Publish after an unsubscribe
+// panics, every tick publishes the same file again, and one bad file
+// stops the watcher for good.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Snapshot is a version of a service's configuration.
+type Snapshot struct {
+	Version int               `json:"version"`
+	Values  map[string]string `json:"values"`
+}
+
+// Hub holds the current Snapshot, and sends each new one to its
+// subscribers.
+type Hub struct {
+	cur atomic.Pointer[Snapshot]
+
+	mu   sync.Mutex
+	subs map[chan *Snapshot]bool
+}
+
+// NewHub returns a Hub of s.
+func NewHub(s *Snapshot) *Hub {
+	h := &Hub{subs: map[chan *Snapshot]bool{}}
+	h.cur.Store(s)
+	return h
+}
+
+// Current returns the current Snapshot.
+func (h *Hub) Current() *Snapshot {
+	return h.cur.Load()
+}
+
+// Publish makes s current, and sends it to every subscriber. A
+// subscriber that has not received the last Snapshot gets s instead.
+func (h *Hub) Publish(s *Snapshot) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cur.Store(s) // before anyone is told
+	for ch := range h.subs {
+		// BUG: this waits until the subscriber receives, however long
+		// that takes.
+		ch <- s
+	}
+}
+
+// Subscribe returns a channel of new Snapshots, and a function that
+// closes it.
+func (h *Hub) Subscribe() (<-chan *Snapshot, func()) {
+	ch := make(chan *Snapshot)
+	h.mu.Lock()
+	h.subs[ch] = true
+	h.mu.Unlock()
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		// BUG: the channel stays in subs, so the next Publish sends on a
+		// closed channel, and a second call closes it again.
+		close(ch)
+	}
+}
+
+// FileWatcher publishes the Snapshot in a JSON file to a Hub when the
+// file changes.
+type FileWatcher struct {
+	Path    string
+	Hub     *Hub
+	OnError func(error)
+
+	last []byte
+}
+
+// Check reads the file, and publishes it if it has changed. It reports
+// whether it did.
+func (w *FileWatcher) Check() (bool, error) {
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		return false, err
+	}
+	// BUG: the same contents are published on every call.
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return false, err
+	}
+	w.last = data
+	w.Hub.Publish(&s)
+	return true, nil
+}
+
+// Run calls Check every interval until ctx is done.
+func (w *FileWatcher) Run(ctx context.Context, every time.Duration) {
+	t := time.NewTicker(every)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if _, err := w.Check(); err != nil {
+				// BUG: one bad file, such as one caught half-written,
+				// stops the watcher.
+				if w.OnError != nil {
+					w.OnError(err)
+				}
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package solutions
+
+// SOLUTION: Fix a config reloader whose readers see half-updated settings.
+// Reload decodes into a new Settings, validates it, and swaps the
+// pointer, so that a reader's snapshot never changes under it and
+// nothing from the old file survives in the new one. SetLimit is
+// copy-on-write: it clones the map into a new Settings.
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrInvalid is returned for settings without a DSN.
+var ErrInvalid = errors.New("invalid settings")
+
+// Settings is a snapshot of a service's configuration. Readers may keep
+// one for as long as they like; it never changes.
+type Settings struct {
+	Version int            `json:"version"`
+	DSN     string         `json:"dsn"`
+	Replica string         `json:"replica,omitempty"`
+	Limits  map[string]int `json:"limits,omitempty"`
+}
+
+// Reloader holds the current Settings.
+type Reloader struct {
+	mu  sync.Mutex // serializes writers; readers take no lock
+	cur atomic.Pointer[Settings]
+}
+
+// NewReloader returns a Reloader of the settings in data.
+func NewReloader(data []byte) (*Reloader, error) {
+	r := &Reloader{}
+	if err := r.Reload(data); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Current returns the current Settings.
+func (r *Reloader) Current() *Settings {
+	return r.cur.Load()
+}
+
+// Reload replaces the settings with the ones in data. If data is
+// invalid, the settings are unchanged.
+func (r *Reloader) Reload(data []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Fixed: a new Settings, which no reader has yet, and which starts
+	// empty.
+	var s Settings
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	// Fixed: validated before anyone can see it.
+	if s.DSN == "" {
+		return ErrInvalid
+	}
+	r.cur.Store(&s)
+	return nil
+}
+
+// SetLimit sets the limit called name to n.
+func (r *Reloader) SetLimit(name string, n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Fixed: a copy, with its own map.
+	s := *r.cur.Load()
+	s.Limits = maps.Clone(s.Limits)
+	if s.Limits == nil {
+		s.Limits = map[string]int{}
+	}
+	s.Limits[name] = n
+	r.cur.Store(&s)
+}
@@ -0,0 +1,94 @@
+package solutions
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	settingsV1 = `{"version": 1, "dsn": "db-1", "replica": "replica-1", "limits": {"upload": 10, "search": 100}}`
+	settingsV2 = `{"version": 2, "dsn": "db-2", "limits": {"upload": 20}}`
+)
+
+func TestReloadKeepsSnapshots(t *testing.T) {
+	r, err := NewReloader([]byte(settingsV1))
+	require.NoError(t, err)
+	s1 := r.Current()
+	require.NoError(t, r.Reload([]byte(settingsV2)))
+
+	assert.Equal(t, 1, s1.Version, "a snapshot taken before Reload")
+	assert.Equal(t, "db-1", s1.DSN)
+	assert.Equal(t, 10, s1.Limits["upload"])
+	assert.Equal(t, 2, r.Current().Version)
+}
+
+func TestReloadReplacesEverything(t *testing.T) {
+	r, err := NewReloader([]byte(settingsV1))
+	require.NoError(t, err)
+	require.NoError(t, r.Reload([]byte(settingsV2)))
+	assert.Equal(t, &Settings{Version: 2, DSN: "db-2", Limits: map[string]int{"upload": 20}}, r.Current(),
+		"nothing from version 1 is left")
+}
+
+func TestReloadInvalid(t *testing.T) {
+	r, err := NewReloader([]byte(settingsV1))
+	require.NoError(t, err)
+	s1 := r.Current()
+
+	assert.ErrorIs(t, r.Reload([]byte(`{"version": 2, "dsn": ""}`)), ErrInvalid)
+	assert.Error(t, r.Reload([]byte(`{"version": 3, "dsn": 5}`)))
+	assert.Same(t, s1, r.Current())
+	assert.Equal(t, 1, s1.Version)
+	assert.Equal(t, "db-1", s1.DSN)
+
+	_, err = NewReloader([]byte(`{}`))
+	assert.ErrorIs(t, err, ErrInvalid)
+}
+
+func TestSetLimit(t *testing.T) {
+	r, err := NewReloader([]byte(settingsV1))
+	require.NoError(t, err)
+	s1 := r.Current()
+	r.SetLimit("upload", 50)
+	assert.Equal(t, 10, s1.Limits["upload"], "a snapshot taken before SetLimit")
+	assert.Equal(t, map[string]int{"upload": 50, "search": 100}, r.Current().Limits)
+	assert.Equal(t, "db-1", r.Current().DSN)
+
+	r, err = NewReloader([]byte(`{"dsn": "db"}`))
+	require.NoError(t, err)
+	r.SetLimit("upload", 1)
+	assert.Equal(t, 1, r.Current().Limits["upload"])
+}
+
+// Run with -race to see the readers race the writer in the exercise.
+func TestConcurrentReads(t *testing.T) {
+	r, err := NewReloader([]byte(`{"version": 0, "dsn": "db-0"}`))
+	require.NoError(t, err)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for v := 1; v <= 500; v++ {
+			r.Reload([]byte(fmt.Sprintf(`{"version": %d, "dsn": "db-%d"}`, v, v)))
+		}
+	}()
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				s := r.Current()
+				v, dsn := s.Version, s.DSN
+				if dsn != fmt.Sprintf("db-%d", v) {
+					t.Errorf("torn read: version %d with %s", v, dsn)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
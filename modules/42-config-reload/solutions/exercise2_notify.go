@@ -0,0 +1,127 @@
+package solutions
+
+// SOLUTION: Fix a config hub that blocks on slow subscribers and panics after an unsubscribe.
+// Publish replaces a Snapshot that a subscriber has not received yet,
+// instead of waiting for it. An unsubscribed channel is removed before
+// it is closed, under the lock Publish holds. The watcher publishes only
+// files that changed, and keeps watching after an error.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Snapshot is a version of a service's configuration.
+type Snapshot struct {
+	Version int               `json:"version"`
+	Values  map[string]string `json:"values"`
+}
+
+// Hub holds the current Snapshot, and sends each new one to its
+// subscribers.
+type Hub struct {
+	cur atomic.Pointer[Snapshot]
+
+	mu   sync.Mutex
+	subs map[chan *Snapshot]bool
+}
+
+// NewHub returns a Hub of s.
+func NewHub(s *Snapshot) *Hub {
+	h := &Hub{subs: map[chan *Snapshot]bool{}}
+	h.cur.Store(s)
+	return h
+}
+
+// Current returns the current Snapshot.
+func (h *Hub) Current() *Snapshot {
+	return h.cur.Load()
+}
+
+// Publish makes s current, and sends it to every subscriber. A
+// subscriber that has not received the last Snapshot gets s instead.
+func (h *Hub) Publish(s *Snapshot) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cur.Store(s) // before anyone is told
+	for ch := range h.subs {
+		// Fixed: the channel has room for one; an unreceived Snapshot
+		// is taken back, and the send cannot block.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- s
+	}
+}
+
+// Subscribe returns a channel of new Snapshots, and a function that
+// closes it.
+func (h *Hub) Subscribe() (<-chan *Snapshot, func()) {
+	ch := make(chan *Snapshot, 1)
+	h.mu.Lock()
+	h.subs[ch] = true
+	h.mu.Unlock()
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		// Fixed: removed, so that Publish no longer sends on it, and
+		// closed only once.
+		if h.subs[ch] {
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// FileWatcher publishes the Snapshot in a JSON file to a Hub when the
+// file changes.
+type FileWatcher struct {
+	Path    string
+	Hub     *Hub
+	OnError func(error)
+
+	last []byte
+}
+
+// Check reads the file, and publishes it if it has changed. It reports
+// whether it did.
+func (w *FileWatcher) Check() (bool, error) {
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		return false, err
+	}
+	// Fixed: unchanged contents are not published again.
+	if w.last != nil && bytes.Equal(data, w.last) {
+		return false, nil
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return false, err
+	}
+	w.last = data
+	w.Hub.Publish(&s)
+	return true, nil
+}
+
+// Run calls Check every interval until ctx is done.
+func (w *FileWatcher) Run(ctx context.Context, every time.Duration) {
+	t := time.NewTicker(every)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			// Fixed: an error is reported, and the next tick tries again.
+			if _, err := w.Check(); err != nil && w.OnError != nil {
+				w.OnError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package solutions
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// within fails t if f does not return within a second.
+func within(t *testing.T, f func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("did not return within a second")
+	}
+}
+
+func TestPublishDoesNotBlock(t *testing.T) {
+	h := NewHub(&Snapshot{Version: 0})
+	ch, _ := h.Subscribe()
+	within(t, func() {
+		for v := 1; v <= 5; v++ {
+			h.Publish(&Snapshot{Version: v})
+		}
+	})
+	select {
+	case s := <-ch:
+		assert.Equal(t, 5, s.Version, "a subscriber that falls behind gets the latest")
+	default:
+		t.Fatal("nothing received")
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	h := NewHub(&Snapshot{})
+	ch, unsubscribe := h.Subscribe()
+	other, unsubscribeOther := h.Subscribe()
+	defer unsubscribeOther()
+
+	unsubscribe()
+	assert.NotPanics(t, unsubscribe, "a second call")
+	_, ok := <-ch
+	assert.False(t, ok, "closed")
+
+	got := make(chan int, 1)
+	go func() {
+		for s := range other {
+			got <- s.Version
+		}
+	}()
+	assert.NotPanics(t, func() { h.Publish(&Snapshot{Version: 1}) }, "a send on the closed channel")
+	select {
+	case v := <-got:
+		assert.Equal(t, 1, v)
+	case <-time.After(time.Second):
+		t.Fatal("the other subscriber got nothing")
+	}
+}
+
+func writeSnapshot(t *testing.T, path, data string) {
+	t.Helper()
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(data), 0o644))
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+func TestCheckOnlyPublishesChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeSnapshot(t, path, `{"version": 1, "values": {"a": "b"}}`)
+	h := NewHub(&Snapshot{})
+	w := &FileWatcher{Path: path, Hub: h}
+
+	changed, err := w.Check()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	first := h.Current()
+	assert.Equal(t, &Snapshot{Version: 1, Values: map[string]string{"a": "b"}}, first)
+
+	changed, err = w.Check()
+	require.NoError(t, err)
+	assert.False(t, changed, "the same file twice")
+	assert.Same(t, first, h.Current())
+
+	writeSnapshot(t, path, `{"version": 2}`)
+	changed, err = w.Check()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, 2, h.Current().Version)
+}
+
+func TestRunSurvivesBadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeSnapshot(t, path, `{"version": `)
+	h := NewHub(&Snapshot{})
+	errs := make(chan error, 1)
+	w := &FileWatcher{Path: path, Hub: h, OnError: func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx, time.Millisecond)
+	<-errs
+	writeSnapshot(t, path, `{"version": 7}`)
+	assert.Eventually(t, func() bool { return h.Current().Version == 7 }, time.Second, time.Millisecond,
+		"the watcher stopped after the bad file")
+}
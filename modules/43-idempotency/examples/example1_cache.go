@@ -0,0 +1,120 @@
+// Package examples demonstrates idempotency keys: a client sends the
+// same key with each retry of a request, and the server runs it once and
+// answers every retry with the first response. The responses wait in a
+// cache that forgets them after a while, and concurrent duplicates share
+// one run of the handler.
+//
+// This file shows:
+// - A generic Cache whose entries expire after a TTL, with the clock injected for tests
+// - Expired entries that Get ignores, and Sweep deletes
+// - A Response type that keeps everything needed to send a response again: status, headers, and body
+package examples
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Cache holds values by key for TTL after they are set. It is safe for
+// concurrent use.
+type Cache[V any] struct {
+	TTL time.Duration
+	Now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]entry[V]
+}
+
+type entry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+func (c *Cache[V]) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+// Get returns the value stored under key, if it has not expired.
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || !c.now().Before(e.expires) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set stores v under key for TTL.
+func (c *Cache[V]) Set(key string, v V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = map[string]entry[V]{}
+	}
+	c.entries[key] = entry[V]{value: v, expires: c.now().Add(c.TTL)}
+}
+
+// Sweep deletes expired entries, and returns how many it deleted. Get
+// ignores them already; Sweep gives their memory back.
+func (c *Cache[V]) Sweep() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	n := 0
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+			n++
+		}
+	}
+	return n
+}
+
+// Len returns the number of entries, expired or not.
+func (c *Cache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Response is a response that can be sent again.
+type Response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// WriteTo sends r to w.
+func (r *Response) WriteTo(w http.ResponseWriter) {
+	for k, v := range r.Header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(r.Status)
+	w.Write(r.Body)
+}
+
+// DemonstrateCache stores a response, and reads it before and after it
+// expires.
+func DemonstrateCache() {
+	fmt.Println("=== Cache ===")
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := &Cache[*Response]{TTL: 24 * time.Hour, Now: func() time.Time { return now }}
+	c.Set("key-1", &Response{Status: http.StatusCreated, Body: []byte(`{"id": 1}`)})
+
+	r, ok := c.Get("key-1")
+	fmt.Println("after 0h:", ok, r.Status, string(r.Body))
+	now = now.Add(23 * time.Hour)
+	_, ok = c.Get("key-1")
+	fmt.Println("after 23h:", ok)
+	now = now.Add(time.Hour)
+	_, ok = c.Get("key-1")
+	fmt.Println("after 24h:", ok, "entries:", c.Len())
+	fmt.Println("swept:", c.Sweep(), "entries:", c.Len())
+}
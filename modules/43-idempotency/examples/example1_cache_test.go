@@ -0,0 +1,46 @@
+package examples
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateCache(t *testing.T) {
+	DemonstrateCache()
+}
+
+func TestCacheExpiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := &Cache[int]{TTL: time.Minute, Now: func() time.Time { return now }}
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	c.Set("a", 1)
+	now = now.Add(30 * time.Second)
+	c.Set("b", 2)
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	now = now.Add(30 * time.Second)
+	_, ok = c.Get("a")
+	assert.False(t, ok, "expired at exactly TTL")
+	_, ok = c.Get("b")
+	assert.True(t, ok)
+
+	assert.Equal(t, 1, c.Sweep())
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestResponseWriteTo(t *testing.T) {
+	r := &Response{Status: http.StatusCreated, Header: http.Header{"Location": {"/orders/1"}}, Body: []byte("ok")}
+	rec := httptest.NewRecorder()
+	r.WriteTo(rec)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "/orders/1", rec.Header().Get("Location"))
+	assert.Equal(t, "ok", rec.Body.String())
+}
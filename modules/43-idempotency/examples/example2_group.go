@@ -0,0 +1,98 @@
+package examples
+
+// This file shows:
+// - Singleflight: concurrent calls with the same key share one run of
+//   the function, and all get its result
+// - A call in flight as a struct with a channel that is closed when it
+//   is done, which any number of waiters can receive from
+// - Forgetting the call when it returns, so that the next call after it
+//   runs the function again
+//
+// golang.org/x/sync/singleflight is the same idea, with more options.
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// call is a run of a function that others may be waiting for.
+type call[V any] struct {
+	done  chan struct{}
+	dups  int // callers waiting for it, guarded by the Group's mu
+	value V
+	err   error
+}
+
+// Group runs functions with the same key one at a time, and shares each
+// result with the calls that arrived while it ran. The zero Group is
+// ready to use.
+type Group[V any] struct {
+	mu    sync.Mutex
+	calls map[string]*call[V]
+}
+
+// Do runs fn, unless a call with the same key is running already, in
+// which case it waits for that call and returns its result. shared
+// reports whether the result went to more than one caller.
+func (g *Group[V]) Do(key string, fn func() (V, error)) (v V, err error, shared bool) {
+	g.mu.Lock()
+	if c, ok := g.calls[key]; ok {
+		c.dups++
+		g.mu.Unlock()
+		<-c.done
+		return c.value, c.err, true
+	}
+	c := &call[V]{done: make(chan struct{})}
+	if g.calls == nil {
+		g.calls = map[string]*call[V]{}
+	}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.value, c.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	shared = c.dups > 0
+	g.mu.Unlock()
+	close(c.done)
+	return c.value, c.err, shared
+}
+
+// DemonstrateGroup sends ten concurrent calls with one key and two with
+// another.
+func DemonstrateGroup() {
+	fmt.Println("=== Singleflight ===")
+	var g Group[string]
+	var runs atomic.Int32
+	slow := func(key string) func() (string, error) {
+		return func() (string, error) {
+			runs.Add(1)
+			time.Sleep(20 * time.Millisecond)
+			return "result for " + key, nil
+		}
+	}
+
+	var wg sync.WaitGroup
+	var shared atomic.Int32
+	for i := 0; i < 12; i++ {
+		key := "a"
+		if i >= 10 {
+			key = "b"
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, s := g.Do(key, slow(key)); s {
+				shared.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("12 calls, %d runs, %d shared results\n", runs.Load(), shared.Load())
+
+	v, _, _ := g.Do("a", slow("a"))
+	fmt.Printf("a again: %q, runs %d\n", v, runs.Load())
+}
@@ -0,0 +1,65 @@
+package examples
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateGroup(t *testing.T) {
+	DemonstrateGroup()
+}
+
+// waiting returns the number of callers waiting for the call with key.
+func waiting[V any](g *Group[V], key string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if c, ok := g.calls[key]; ok {
+		return c.dups
+	}
+	return 0
+}
+
+func TestGroupShares(t *testing.T) {
+	var g Group[int]
+	var runs atomic.Int32
+	release := make(chan struct{})
+	fn := func() (int, error) {
+		runs.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	shared := make([]bool, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _, shared[i] = g.Do("k", fn)
+		}(i)
+	}
+	assert.Eventually(t, func() bool { return waiting(&g, "k") == 4 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), runs.Load())
+	assert.Equal(t, []int{42, 42, 42, 42, 42}, results)
+	assert.Equal(t, []bool{true, true, true, true, true}, shared)
+}
+
+func TestGroupForgets(t *testing.T) {
+	var g Group[int]
+	boom := errors.New("boom")
+	_, err, shared := g.Do("k", func() (int, error) { return 0, boom })
+	assert.ErrorIs(t, err, boom)
+	assert.False(t, shared)
+
+	v, err, _ := g.Do("k", func() (int, error) { return 1, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v, "a failed call is not remembered")
+}
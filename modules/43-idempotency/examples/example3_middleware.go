@@ -0,0 +1,177 @@
+package examples
+
+// This file shows:
+// - Middleware that runs a request with an Idempotency-Key once, and
+//   replays its response to every retry
+// - Recording a response with a ResponseWriter of our own, to store it
+//   and send it to the client at the same time
+// - Keys scoped to the client, so that two clients' keys never meet
+// - A hash of the request, to refuse a key reused for a different one
+// - Concurrent duplicates waiting for the first, through a Group
+// - Not storing 5xx responses, so that a retry after a failure runs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// KeyHeader is the request header that carries idempotency keys.
+const KeyHeader = "Idempotency-Key"
+
+// ReplayedHeader is set on responses sent from the cache.
+const ReplayedHeader = "Idempotent-Replayed"
+
+// MaxBody is the largest request body the middleware reads.
+const MaxBody = 1 << 20
+
+// stored is a response, and the hash of the request it answered.
+type stored struct {
+	hash string
+	resp *Response
+}
+
+// Idempotency runs requests with the same key once.
+type Idempotency struct {
+	Cache *Cache[stored]
+	// Client returns who sent r, such as the user of its session. Keys
+	// are unique per client.
+	Client func(r *http.Request) string
+
+	group Group[stored]
+}
+
+// NewIdempotency returns middleware that remembers responses for ttl.
+func NewIdempotency(ttl time.Duration, client func(r *http.Request) string) *Idempotency {
+	return &Idempotency{Cache: &Cache[stored]{TTL: ttl}, Client: client}
+}
+
+// Middleware returns next with idempotency keys. Requests without a key
+// go straight to next.
+func (m *Idempotency) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(KeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBody))
+		if err != nil {
+			writeError(w, http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		hash := requestHash(r, body)
+		key = m.Client(r) + "\x00" + key
+
+		if s, ok := m.Cache.Get(key); ok {
+			m.replay(w, s, hash)
+			return
+		}
+		ran := false
+		s, _, _ := m.group.Do(key, func() (stored, error) {
+			// Another request may have finished between Get and Do.
+			if s, ok := m.Cache.Get(key); ok {
+				return s, nil
+			}
+			ran = true
+			rec := &recorder{header: http.Header{}, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			s := stored{hash: hash, resp: &Response{Status: rec.status, Header: rec.header, Body: rec.body.Bytes()}}
+			if rec.status < 500 {
+				m.Cache.Set(key, s)
+			}
+			return s, nil
+		})
+		if !ran {
+			m.replay(w, s, hash)
+			return
+		}
+		s.resp.WriteTo(w)
+	})
+}
+
+// replay sends s again, unless it answered a different request.
+func (m *Idempotency) replay(w http.ResponseWriter, s stored, hash string) {
+	if s.hash != hash {
+		writeError(w, http.StatusUnprocessableEntity, "idempotency key reused for a different request")
+		return
+	}
+	w.Header().Set(ReplayedHeader, "true")
+	s.resp.WriteTo(w)
+}
+
+// requestHash identifies a request by its method, path, and body.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", r.Method, r.URL.Path)
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recorder is a ResponseWriter that keeps the response.
+type recorder struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *recorder) Header() http.Header { return r.header }
+
+func (r *recorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.body.Write(b)
+}
+
+// writeError writes a JSON error response.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
+
+// DemonstrateMiddleware creates an order, retries it, and reuses the key
+// for another order.
+func DemonstrateMiddleware() {
+	fmt.Println("=== Middleware ===")
+	var orders atomic.Int32
+	create := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := orders.Add(1)
+		w.Header().Set("Location", fmt.Sprintf("/orders/%d", id))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id": %d}`, id)
+	})
+	m := NewIdempotency(24*time.Hour, func(r *http.Request) string { return r.Header.Get("X-User") })
+	h := m.Middleware(create)
+
+	send := func(key, body string) {
+		req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewBufferString(body))
+		req.Header.Set("X-User", "ada")
+		req.Header.Set(KeyHeader, key)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		fmt.Printf("%d %s replayed=%q %s\n", rec.Code, rec.Header().Get("Location"),
+			rec.Header().Get(ReplayedHeader), strings.TrimSpace(rec.Body.String()))
+	}
+	send("k1", `{"item": "book"}`)
+	send("k1", `{"item": "book"}`)
+	send("k1", `{"item": "lamp"}`)
+	send("k2", `{"item": "lamp"}`)
+	fmt.Println("orders created:", orders.Load())
+}
@@ -0,0 +1,99 @@
+package examples
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateMiddleware(t *testing.T) {
+	DemonstrateMiddleware()
+}
+
+func post(h http.Handler, user, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set("X-User", user)
+	if key != "" {
+		req.Header.Set(KeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func newMiddleware() *Idempotency {
+	return NewIdempotency(time.Hour, func(r *http.Request) string { return r.Header.Get("X-User") })
+}
+
+func TestMiddlewareReplays(t *testing.T) {
+	var runs atomic.Int32
+	h := newMiddleware().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runs.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Order", "1")
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	}))
+
+	first := post(h, "ada", "k", "book")
+	again := post(h, "ada", "k", "book")
+	assert.Equal(t, int32(1), runs.Load())
+	assert.Equal(t, http.StatusCreated, again.Code)
+	assert.Equal(t, "1", again.Header().Get("X-Order"))
+	assert.Equal(t, "book", again.Body.String(), "the handler read the body")
+	assert.Equal(t, "", first.Header().Get(ReplayedHeader))
+	assert.Equal(t, "true", again.Header().Get(ReplayedHeader))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, post(h, "ada", "k", "lamp").Code)
+	post(h, "grace", "k", "book")
+	post(h, "ada", "", "book")
+	post(h, "ada", "", "book")
+	assert.Equal(t, int32(4), runs.Load(), "another client's key, and no key")
+}
+
+func TestMiddlewareConcurrentDuplicates(t *testing.T) {
+	var runs atomic.Int32
+	h := newMiddleware().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runs.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	var wg sync.WaitGroup
+	var replayed atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := post(h, "ada", "k", "book")
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			if rec.Header().Get(ReplayedHeader) == "true" {
+				replayed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), runs.Load())
+	assert.Equal(t, int32(9), replayed.Load())
+}
+
+func TestMiddlewareDoesNotStoreServerErrors(t *testing.T) {
+	var runs atomic.Int32
+	h := newMiddleware().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if runs.Add(1) == 1 {
+			http.Error(w, "database down", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	assert.Equal(t, http.StatusServiceUnavailable, post(h, "ada", "k", "book").Code)
+	assert.Equal(t, http.StatusCreated, post(h, "ada", "k", "book").Code)
+	assert.Equal(t, http.StatusCreated, post(h, "ada", "k", "book").Code)
+	assert.Equal(t, int32(2), runs.Load())
+}
@@ -0,0 +1,91 @@
+package exercises
+
+// EXERCISE: Fix a payment service that charges twice when a request is retried.
+// Ledger charges an account once per idempotency key, so that a client
+// whose connection dropped can safely send the payment again. But ten
+// retries at once charge ten times, one account's key returns another
+// account's receipt, a declined card can never be retried, keys are
+// remembered forever, and Sweep forgets the wrong payments.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"sync"
+	"time"
+)
+
+// Receipt is a successful charge.
+type Receipt struct {
+	ID    string
+	Cents int
+}
+
+// Ledger charges accounts at most once per idempotency key.
+type Ledger struct {
+	// Charge charges an account. It has side effects that must not
+	// happen twice.
+	Charge func(account string, cents int) (Receipt, error)
+	// TTL is how long a payment's key is remembered.
+	TTL time.Duration
+	Now func() time.Time
+
+	mu   sync.Mutex
+	done map[string]payment
+}
+
+// payment is a finished charge.
+type payment struct {
+	receipt Receipt
+	err     error
+	expires time.Time
+}
+
+func (l *Ledger) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+	return time.Now()
+}
+
+// Pay charges account cents, unless a payment with the same key has
+// succeeded, in which case it returns that payment's receipt.
+func (l *Ledger) Pay(account, key string, cents int) (Receipt, error) {
+	// BUG: the key is shared by every account.
+	k := key
+
+	l.mu.Lock()
+	// BUG: a payment is replayed however long ago it was made.
+	if p, ok := l.done[k]; ok {
+		l.mu.Unlock()
+		return p.receipt, p.err
+	}
+	if l.done == nil {
+		l.done = map[string]payment{}
+	}
+	// BUG: nothing records that this payment is running, so a duplicate
+	// that arrives during the charge charges too.
+	l.mu.Unlock()
+
+	receipt, err := l.Charge(account, cents)
+
+	l.mu.Lock()
+	// BUG: a failed charge is remembered, so a retry never charges.
+	l.done[k] = payment{receipt: receipt, err: err, expires: l.now().Add(l.TTL)}
+	l.mu.Unlock()
+	return receipt, err
+}
+
+// Sweep forgets expired payments, and returns how many it forgot.
+func (l *Ledger) Sweep() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	n := 0
+	for k, p := range l.done {
+		// BUG: deletes the payments that have not expired.
+		if now.Before(p.expires) {
+			delete(l.done, k)
+			n++
+		}
+	}
+	return n
+}
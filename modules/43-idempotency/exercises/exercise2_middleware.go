@@ -0,0 +1,116 @@
+package exercises
+
+// EXERCISE: Fix idempotency middleware that runs retries twice and replays them wrong.
+// Dedup saves the response to each request with an Idempotency-Key, and
+// sends it again when the client retries, so that an order is created
+// once however many times it is sent. But the handler sees an empty
+// body, retries sent at once all run, a key reused for another request
+// replays the wrong response, a 503 is replayed forever, and a replay
+// loses its status and headers.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// KeyHeader is the request header that carries idempotency keys.
+const KeyHeader = "Idempotency-Key"
+
+// Saved is a response, and the hash of the request it answered.
+type Saved struct {
+	Hash   string
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// Dedup is middleware that runs each idempotency key's request once.
+type Dedup struct {
+	// TTL is how long a response is saved.
+	TTL time.Duration
+	Now func() time.Time
+
+	mu    sync.Mutex
+	saved map[string]entry
+}
+
+type entry struct {
+	saved   Saved
+	expires time.Time
+}
+
+func (d *Dedup) now() time.Time {
+	if d.Now != nil {
+		return d.Now()
+	}
+	return time.Now()
+}
+
+// Wrap returns next with idempotency keys. Requests without a key go
+// straight to next.
+func (d *Dedup) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(KeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "cannot read body", http.StatusBadRequest)
+			return
+		}
+		// BUG: the handler reads a body that has already been read.
+		hash := hashRequest(r, body)
+
+		d.mu.Lock()
+		if e, ok := d.saved[key]; ok && d.now().Before(e.expires) {
+			d.mu.Unlock()
+			replay(w, e.saved, hash)
+			return
+		}
+		if d.saved == nil {
+			d.saved = map[string]entry{}
+		}
+		// BUG: a duplicate that arrives while this request runs finds
+		// nothing saved, and runs too.
+		d.mu.Unlock()
+
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+		saved := Saved{Hash: hash, Status: rec.Code, Header: rec.Header(), Body: rec.Body.Bytes()}
+
+		d.mu.Lock()
+		// BUG: server errors are saved, so a retry never runs.
+		d.saved[key] = entry{saved: saved, expires: d.now().Add(d.TTL)}
+		d.mu.Unlock()
+		write(w, saved)
+	})
+}
+
+// replay sends s again, unless it answered a different request.
+func replay(w http.ResponseWriter, s Saved, hash string) {
+	// BUG: a key reused for a different request replays its response.
+	w.Header().Set("Idempotent-Replayed", "true")
+	write(w, s)
+}
+
+// write sends s.
+func write(w http.ResponseWriter, s Saved) {
+	// BUG: only the body is sent, with a 200 OK.
+	w.Write(s.Body)
+}
+
+// hashRequest identifies a request by its method, path, and body.
+func hashRequest(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
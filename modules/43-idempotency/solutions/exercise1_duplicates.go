@@ -0,0 +1,107 @@
+package solutions
+
+// SOLUTION: Fix a payment service that charges twice when a request is retried.
+// Pay keys payments by account and key, so that two accounts' keys never
+// meet. A payment in flight is waited for instead of started again, only
+// a successful charge is remembered, a remembered payment expires after
+// TTL, and Sweep deletes the expired payments, not the fresh ones.
+
+import (
+	"sync"
+	"time"
+)
+
+// Receipt is a successful charge.
+type Receipt struct {
+	ID    string
+	Cents int
+}
+
+// Ledger charges accounts at most once per idempotency key.
+type Ledger struct {
+	// Charge charges an account. It has side effects that must not
+	// happen twice.
+	Charge func(account string, cents int) (Receipt, error)
+	// TTL is how long a payment's key is remembered.
+	TTL time.Duration
+	Now func() time.Time
+
+	mu      sync.Mutex
+	done    map[string]payment
+	running map[string]*pending
+}
+
+// payment is a charge that succeeded.
+type payment struct {
+	receipt Receipt
+	expires time.Time
+}
+
+// pending is a charge in flight. done is closed when it has finished.
+type pending struct {
+	done    chan struct{}
+	receipt Receipt
+	err     error
+}
+
+func (l *Ledger) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+	return time.Now()
+}
+
+// Pay charges account cents, unless a payment with the same key has
+// succeeded, in which case it returns that payment's receipt.
+func (l *Ledger) Pay(account, key string, cents int) (Receipt, error) {
+	// Fixed: keys are per account.
+	k := account + "\x00" + key
+
+	l.mu.Lock()
+	// Fixed: an expired payment is not replayed.
+	if p, ok := l.done[k]; ok && l.now().Before(p.expires) {
+		l.mu.Unlock()
+		return p.receipt, nil
+	}
+	// Fixed: a payment in flight is waited for, not started again.
+	if p, ok := l.running[k]; ok {
+		l.mu.Unlock()
+		<-p.done
+		return p.receipt, p.err
+	}
+	if l.done == nil {
+		l.done = map[string]payment{}
+		l.running = map[string]*pending{}
+	}
+	p := &pending{done: make(chan struct{})}
+	l.running[k] = p
+	l.mu.Unlock()
+
+	p.receipt, p.err = l.Charge(account, cents)
+
+	l.mu.Lock()
+	delete(l.running, k)
+	// Fixed: a failed charge is not remembered, so a retry charges.
+	if p.err == nil {
+		l.done[k] = payment{receipt: p.receipt, expires: l.now().Add(l.TTL)}
+	}
+	l.mu.Unlock()
+	close(p.done)
+	return p.receipt, p.err
+}
+
+// Sweep forgets expired payments, and returns how many it forgot.
+func (l *Ledger) Sweep() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	n := 0
+	for k, p := range l.done {
+		// Fixed: deletes the payments that have expired.
+		if !now.Before(p.expires) {
+			delete(l.done, k)
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,126 @@
+package solutions
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bank counts charges, and fails the ones in fail.
+type bank struct {
+	mu      sync.Mutex
+	charges map[string]int
+	fail    int
+	delay   time.Duration
+}
+
+func (b *bank) charge(account string, cents int) (Receipt, error) {
+	time.Sleep(b.delay)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.fail > 0 {
+		b.fail--
+		return Receipt{}, errors.New("card declined")
+	}
+	if b.charges == nil {
+		b.charges = map[string]int{}
+	}
+	b.charges[account]++
+	return Receipt{ID: fmt.Sprintf("%s-%d", account, b.charges[account]), Cents: cents}, nil
+}
+
+func (b *bank) count(account string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.charges[account]
+}
+
+func TestPayOnce(t *testing.T) {
+	b := &bank{}
+	l := &Ledger{Charge: b.charge, TTL: time.Hour}
+	r1, err := l.Pay("ada", "k1", 500)
+	require.NoError(t, err)
+	r2, err := l.Pay("ada", "k1", 500)
+	require.NoError(t, err)
+	assert.Equal(t, r1, r2)
+	assert.Equal(t, 1, b.count("ada"))
+
+	_, err = l.Pay("ada", "k2", 500)
+	require.NoError(t, err)
+	assert.Equal(t, 2, b.count("ada"), "a new key is a new payment")
+}
+
+func TestPayKeysPerAccount(t *testing.T) {
+	b := &bank{}
+	l := &Ledger{Charge: b.charge, TTL: time.Hour}
+	ada, err := l.Pay("ada", "order-1", 500)
+	require.NoError(t, err)
+	grace, err := l.Pay("grace", "order-1", 700)
+	require.NoError(t, err)
+	assert.Equal(t, 1, b.count("grace"), "grace's payment, not ada's receipt")
+	assert.NotEqual(t, ada, grace)
+	assert.Equal(t, 700, grace.Cents)
+}
+
+func TestPayConcurrentDuplicates(t *testing.T) {
+	b := &bank{delay: 20 * time.Millisecond}
+	l := &Ledger{Charge: b.charge, TTL: time.Hour}
+	var wg sync.WaitGroup
+	receipts := make([]Receipt, 10)
+	for i := range receipts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r, err := l.Pay("ada", "k", 500)
+			assert.NoError(t, err)
+			receipts[i] = r
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 1, b.count("ada"), "ten retries at once, one charge")
+	for _, r := range receipts {
+		assert.Equal(t, receipts[0], r)
+	}
+}
+
+func TestPayRetriesFailures(t *testing.T) {
+	b := &bank{fail: 1}
+	l := &Ledger{Charge: b.charge, TTL: time.Hour}
+	_, err := l.Pay("ada", "k", 500)
+	assert.Error(t, err)
+	r, err := l.Pay("ada", "k", 500)
+	require.NoError(t, err, "a retry after a decline charges again")
+	assert.Equal(t, 500, r.Cents)
+	assert.Equal(t, 1, b.count("ada"))
+}
+
+func TestPayExpiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &bank{}
+	l := &Ledger{Charge: b.charge, TTL: time.Hour, Now: func() time.Time { return now }}
+	_, err := l.Pay("ada", "k", 500)
+	require.NoError(t, err)
+	now = now.Add(time.Hour)
+	_, err = l.Pay("ada", "k", 500)
+	require.NoError(t, err)
+	assert.Equal(t, 2, b.count("ada"), "an expired key is forgotten")
+}
+
+func TestSweep(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &bank{}
+	l := &Ledger{Charge: b.charge, TTL: time.Hour, Now: func() time.Time { return now }}
+	l.Pay("ada", "old", 100)
+	now = now.Add(30 * time.Minute)
+	l.Pay("ada", "new", 100)
+	now = now.Add(30 * time.Minute)
+
+	assert.Equal(t, 1, l.Sweep())
+	l.Pay("ada", "new", 100)
+	assert.Equal(t, 2, b.count("ada"), "the fresh payment is still remembered")
+}
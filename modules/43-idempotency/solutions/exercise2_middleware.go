@@ -0,0 +1,143 @@
+package solutions
+
+// SOLUTION: Fix idempotency middleware that runs retries twice and replays them wrong.
+// The handler gets back the body that Wrap read to hash the request. A
+// duplicate of a request in flight waits for its response instead of
+// running too, a key reused for a different request is refused, 5xx
+// responses are not saved, and a replay sends the status and headers
+// along with the body.
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// KeyHeader is the request header that carries idempotency keys.
+const KeyHeader = "Idempotency-Key"
+
+// Saved is a response, and the hash of the request it answered.
+type Saved struct {
+	Hash   string
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// Dedup is middleware that runs each idempotency key's request once.
+type Dedup struct {
+	// TTL is how long a response is saved.
+	TTL time.Duration
+	Now func() time.Time
+
+	mu       sync.Mutex
+	saved    map[string]entry
+	inflight map[string]*flight
+}
+
+type entry struct {
+	saved   Saved
+	expires time.Time
+}
+
+// flight is a request being run. done is closed when saved is set.
+type flight struct {
+	done  chan struct{}
+	saved Saved
+}
+
+func (d *Dedup) now() time.Time {
+	if d.Now != nil {
+		return d.Now()
+	}
+	return time.Now()
+}
+
+// Wrap returns next with idempotency keys. Requests without a key go
+// straight to next.
+func (d *Dedup) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(KeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "cannot read body", http.StatusBadRequest)
+			return
+		}
+		// Fixed: the handler gets the body back.
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		hash := hashRequest(r, body)
+
+		d.mu.Lock()
+		if e, ok := d.saved[key]; ok && d.now().Before(e.expires) {
+			d.mu.Unlock()
+			replay(w, e.saved, hash)
+			return
+		}
+		// Fixed: a duplicate of a request in flight waits for its response.
+		if f, ok := d.inflight[key]; ok {
+			d.mu.Unlock()
+			<-f.done
+			replay(w, f.saved, hash)
+			return
+		}
+		if d.saved == nil {
+			d.saved = map[string]entry{}
+			d.inflight = map[string]*flight{}
+		}
+		f := &flight{done: make(chan struct{})}
+		d.inflight[key] = f
+		d.mu.Unlock()
+
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+		f.saved = Saved{Hash: hash, Status: rec.Code, Header: rec.Header(), Body: rec.Body.Bytes()}
+
+		d.mu.Lock()
+		delete(d.inflight, key)
+		// Fixed: server errors are not saved, so a retry runs again.
+		if rec.Code < 500 {
+			d.saved[key] = entry{saved: f.saved, expires: d.now().Add(d.TTL)}
+		}
+		d.mu.Unlock()
+		close(f.done)
+		write(w, f.saved)
+	})
+}
+
+// replay sends s again, unless it answered a different request.
+func replay(w http.ResponseWriter, s Saved, hash string) {
+	// Fixed: a key reused for a different request is refused.
+	if s.Hash != hash {
+		http.Error(w, "idempotency key reused for a different request", http.StatusUnprocessableEntity)
+		return
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	write(w, s)
+}
+
+// write sends s.
+func write(w http.ResponseWriter, s Saved) {
+	// Fixed: the status and headers are sent along with the body.
+	for k, v := range s.Header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(s.Status)
+	w.Write(s.Body)
+}
+
+// hashRequest identifies a request by its method, path, and body.
+func hashRequest(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,115 @@
+package solutions
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func send(h http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(KeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// orders is a handler that creates an order for each request it runs.
+type orders struct {
+	runs  atomic.Int32
+	delay time.Duration
+}
+
+func (o *orders) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	time.Sleep(o.delay)
+	n := o.runs.Add(1)
+	body, _ := io.ReadAll(r.Body)
+	w.Header().Set("Location", fmt.Sprintf("/orders/%d", n))
+	w.WriteHeader(http.StatusCreated)
+	w.Write(body)
+}
+
+func TestHandlerReadsBody(t *testing.T) {
+	h := (&Dedup{TTL: time.Hour}).Wrap(&orders{})
+	rec := send(h, "k", "book")
+	assert.Equal(t, "book", rec.Body.String())
+}
+
+func TestReplay(t *testing.T) {
+	o := &orders{}
+	h := (&Dedup{TTL: time.Hour}).Wrap(o)
+	send(h, "k", "book")
+	rec := send(h, "k", "book")
+	assert.Equal(t, int32(1), o.runs.Load())
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "/orders/1", rec.Header().Get("Location"))
+	assert.Equal(t, "true", rec.Header().Get("Idempotent-Replayed"))
+}
+
+func TestNoKey(t *testing.T) {
+	o := &orders{}
+	h := (&Dedup{TTL: time.Hour}).Wrap(o)
+	send(h, "", "book")
+	send(h, "", "book")
+	assert.Equal(t, int32(2), o.runs.Load())
+}
+
+func TestConcurrentDuplicates(t *testing.T) {
+	o := &orders{delay: 20 * time.Millisecond}
+	h := (&Dedup{TTL: time.Hour}).Wrap(o)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := send(h, "k", "book")
+			assert.Equal(t, "/orders/1", rec.Header().Get("Location"))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), o.runs.Load(), "ten retries at once, one order")
+}
+
+func TestKeyReused(t *testing.T) {
+	o := &orders{}
+	h := (&Dedup{TTL: time.Hour}).Wrap(o)
+	send(h, "k", "book")
+	rec := send(h, "k", "lamp")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, int32(1), o.runs.Load())
+}
+
+func TestServerErrorsRun(t *testing.T) {
+	var runs atomic.Int32
+	h := (&Dedup{TTL: time.Hour}).Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if runs.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	assert.Equal(t, http.StatusServiceUnavailable, send(h, "k", "book").Code)
+	assert.Equal(t, http.StatusCreated, send(h, "k", "book").Code, "the retry runs")
+	assert.Equal(t, http.StatusCreated, send(h, "k", "book").Code)
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+func TestExpiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	o := &orders{}
+	h := (&Dedup{TTL: time.Hour, Now: func() time.Time { return now }}).Wrap(o)
+	send(h, "k", "book")
+	now = now.Add(time.Hour)
+	send(h, "k", "book")
+	assert.Equal(t, int32(2), o.runs.Load())
+}
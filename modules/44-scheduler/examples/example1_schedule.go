@@ -0,0 +1,184 @@
+// Package examples demonstrates a job scheduler built on time.Ticker:
+// fixed intervals and cron-like specs, one goroutine per run with its
+// panics recovered, policies for runs that were missed, a graceful stop,
+// and a fake clock that tests a simulated hour in microseconds.
+//
+// This file shows:
+// - A Schedule as one method, Next, so that intervals and cron specs are interchangeable
+// - Parsing a five-field cron spec into bit sets, one bit per value
+// - Finding the next matching minute by skipping whole months, days, and hours
+// - Building times with time.Date, so that skipping a day starts it at midnight
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule says when a job runs.
+type Schedule interface {
+	// Next returns the first run after t, or the zero Time if there is
+	// none.
+	Next(t time.Time) time.Time
+}
+
+// Every runs a job at a fixed interval.
+type Every time.Duration
+
+// Next returns t plus the interval.
+func (e Every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+// ErrSpec is returned for a cron spec that cannot be parsed.
+var ErrSpec = errors.New("invalid cron spec")
+
+// Cron is a parsed cron spec. Each field is a bit set of the values it
+// matches.
+type Cron struct {
+	minute, hour, dom, month, dow uint64
+}
+
+// bounds are the values a field may hold.
+type bounds struct {
+	name     string
+	min, max int
+}
+
+var fields = [5]bounds{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+// descriptors are shorthands for common specs.
+var descriptors = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+	"@yearly":  "0 0 1 1 *",
+}
+
+// ParseCron parses a spec of five fields: minute, hour, day of month,
+// month, and day of week, with Sunday as 0. A field is *, a number, a
+// range such as 1-5, a step such as */15 or 0-30/10, or a list of these
+// separated by commas. Unlike cron, a spec that restricts both days
+// matches only the days that are both.
+func ParseCron(spec string) (*Cron, error) {
+	if d, ok := descriptors[spec]; ok {
+		spec = d
+	}
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("%w: %q has %d fields, want 5", ErrSpec, spec, len(parts))
+	}
+	var sets [5]uint64
+	for i, s := range parts {
+		set, err := parseField(s, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+	return &Cron{minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4]}, nil
+}
+
+// parseField parses one field into a bit set.
+func parseField(s string, b bounds) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(s, ",") {
+		rng, stepText, hasStep := strings.Cut(part, "/")
+		lo, hi := b.min, b.max
+		if rng != "*" {
+			from, to, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(from); err != nil {
+				return 0, fmt.Errorf("%w: %s %q", ErrSpec, b.name, part)
+			}
+			switch {
+			case isRange:
+				if hi, err = strconv.Atoi(to); err != nil {
+					return 0, fmt.Errorf("%w: %s %q", ErrSpec, b.name, part)
+				}
+			case !hasStep:
+				hi = lo
+			}
+		}
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("%w: %s step %q", ErrSpec, b.name, part)
+			}
+			step = n
+		}
+		if lo < b.min || hi > b.max || lo > hi {
+			return 0, fmt.Errorf("%w: %s %q is outside %d-%d", ErrSpec, b.name, part, b.min, b.max)
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<v) != 0
+}
+
+// Next returns the first minute after t that c matches, in t's location,
+// or the zero Time if there is none within five years, as for the 30th
+// of February.
+func (c *Cron) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	end := t.AddDate(5, 0, 0)
+	for t.Before(end) {
+		switch {
+		case !has(c.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !has(c.dom, t.Day()) || !has(c.dow, int(t.Weekday())):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !has(c.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !has(c.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// DemonstrateSchedule prints the next runs of an interval and of a few
+// cron specs.
+func DemonstrateSchedule() {
+	fmt.Println("=== Schedules ===")
+	from := time.Date(2024, 3, 1, 16, 50, 0, 0, time.UTC) // a Friday
+	fmt.Println("from:", from.Format("Mon Jan 2 15:04"))
+
+	fmt.Println("every 45m:", Every(45*time.Minute).Next(from).Format("Mon 15:04"))
+	for _, spec := range []string{"*/15 9-17 * * 1-5", "0 9 * * 1", "@monthly"} {
+		c, err := ParseCron(spec)
+		if err != nil {
+			fmt.Println("error:", err)
+			return
+		}
+		var runs []string
+		t := from
+		for i := 0; i < 3; i++ {
+			t = c.Next(t)
+			runs = append(runs, t.Format("Mon Jan 2 15:04"))
+		}
+		fmt.Printf("%-18s %s\n", spec+":", strings.Join(runs, ", "))
+	}
+
+	_, err := ParseCron("60 * * * *")
+	fmt.Println("bad spec:", err)
+}
@@ -0,0 +1,52 @@
+package examples
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateSchedule(t *testing.T) {
+	DemonstrateSchedule()
+}
+
+func TestParseCron(t *testing.T) {
+	for _, spec := range []string{"* * * * *", "*/5 0-6,22-23 1 */3 1-5", "5/15 * * * *", "@daily"} {
+		_, err := ParseCron(spec)
+		assert.NoError(t, err, spec)
+	}
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * * 7", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		_, err := ParseCron(spec)
+		assert.ErrorIs(t, err, ErrSpec, spec)
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2024, month, day, hour, min, 0, 0, time.UTC)
+	}
+	from := at(3, 1, 16, 50) // a Friday
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", at(3, 1, 16, 51)},
+		{"50 16 * * *", at(3, 2, 16, 50)},
+		{"*/15 9-17 * * 1-5", at(3, 1, 17, 0)},
+		{"0 9 * * 1", at(3, 4, 9, 0)},
+		{"30 8 * * *", at(3, 2, 8, 30)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", at(4, 1, 0, 0)},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.spec)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, c.Next(from), tt.spec)
+	}
+
+	c, err := ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, c.Next(from).IsZero(), "February 30th never comes")
+}
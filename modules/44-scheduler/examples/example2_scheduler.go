@@ -0,0 +1,267 @@
+package examples
+
+// This file shows:
+// - A scheduler that ticks on a time.Ticker and starts the jobs that are due
+// - A Clock interface, so that tests can tick the scheduler by hand
+// - Each run in its own goroutine, with a panic recovered and reported as an error
+// - A job that is still running when it is due again is not started twice
+// - Policies for the runs a job missed: run once, skip them, or catch up
+// - Stopping gracefully: no new runs, and a deadline for the running ones
+// - Waiting for the ticks received so far by asking the ticking goroutine itself
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock is the time a Scheduler runs on.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker is the part of a time.Ticker that a Scheduler uses.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// SystemClock is the real clock.
+type SystemClock struct{}
+
+// Now returns time.Now().
+func (SystemClock) Now() time.Time { return time.Now() }
+
+// NewTicker returns a time.Ticker.
+func (SystemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTicker struct{ *time.Ticker }
+
+func (t systemTicker) C() <-chan time.Time { return t.Ticker.C }
+
+// Missed says what a job does about runs that were due while it could
+// not run: because it was still running, or the process was asleep.
+type Missed int
+
+const (
+	// RunOnce runs a job once for all the runs it missed.
+	RunOnce Missed = iota
+	// Skip drops the runs a job missed. It runs a job only when it is on
+	// time, within one tick of when it was due.
+	Skip
+	// CatchUp runs a job once for each run it missed, up to MaxCatchUp.
+	CatchUp
+)
+
+// MaxCatchUp is the most runs that CatchUp makes at once.
+const MaxCatchUp = 100
+
+// ErrPanic is wrapped by the errors of jobs that panicked.
+var ErrPanic = errors.New("job panicked")
+
+// Job is work that a Scheduler runs on a Schedule.
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context) error
+	Missed   Missed
+}
+
+// Scheduler runs jobs on their schedules. Add jobs, then Start it, and
+// Stop it when the service shuts down.
+type Scheduler struct {
+	// Clock is SystemClock if nil.
+	Clock Clock
+	// Resolution is how often the scheduler ticks: one second if zero.
+	Resolution time.Duration
+	// OnError is called with the errors that jobs return, and their
+	// panics.
+	OnError func(job string, err error)
+
+	mu     sync.Mutex
+	jobs   []*entry
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+	stop   chan struct{}
+	done   chan struct{}
+	waits  chan chan struct{}
+}
+
+// entry is a scheduled job. next is when it is due.
+type entry struct {
+	job     Job
+	next    time.Time
+	running bool
+}
+
+func (s *Scheduler) clock() Clock {
+	if s.Clock == nil {
+		return SystemClock{}
+	}
+	return s.Clock
+}
+
+func (s *Scheduler) resolution() time.Duration {
+	if s.Resolution <= 0 {
+		return time.Second
+	}
+	return s.Resolution
+}
+
+// Add schedules job, with its first run after now.
+func (s *Scheduler) Add(job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, &entry{job: job, next: job.Schedule.Next(s.clock().Now())})
+}
+
+// Start starts ticking in a goroutine of its own.
+func (s *Scheduler) Start() {
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	s.waits = make(chan chan struct{})
+	t := s.clock().NewTicker(s.resolution())
+	go func() {
+		defer close(s.done)
+		defer t.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case now := <-t.C():
+				s.tick(now)
+			case ack := <-s.waits:
+				close(ack)
+			}
+		}
+	}()
+}
+
+// tick starts the jobs that are due at now.
+func (s *Scheduler) tick(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.jobs {
+		// A running job stays due, and its missed runs are counted when
+		// it has finished.
+		if e.running || e.next.IsZero() || e.next.After(now) {
+			continue
+		}
+		runs, last := 0, e.next
+		for !e.next.IsZero() && !e.next.After(now) {
+			runs++
+			last = e.next
+			e.next = e.job.Schedule.Next(e.next)
+		}
+		switch e.job.Missed {
+		case Skip:
+			if now.Sub(last) > s.resolution() {
+				continue
+			}
+			runs = 1
+		case CatchUp:
+			runs = min(runs, MaxCatchUp)
+		default:
+			runs = 1
+		}
+		e.running = true
+		s.wg.Add(1)
+		go s.run(e, runs)
+	}
+}
+
+// run runs e's job runs times, unless the scheduler is stopped first.
+func (s *Scheduler) run(e *entry, runs int) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		e.running = false
+		s.mu.Unlock()
+	}()
+	for i := 0; i < runs && s.ctx.Err() == nil; i++ {
+		if err := s.call(e.job); err != nil && s.OnError != nil {
+			s.OnError(e.job.Name, err)
+		}
+	}
+}
+
+// call runs job, and turns a panic into an error, so that one job's
+// panic does not crash the process.
+func (s *Scheduler) call(job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrPanic, r)
+		}
+	}()
+	return job.Run(s.ctx)
+}
+
+// Wait waits until the scheduler has handled the ticks it has received,
+// and the runs they started have finished. Tests call it after advancing
+// a FakeClock, and before looking at what the jobs did.
+func (s *Scheduler) Wait() {
+	ack := make(chan struct{})
+	select {
+	case s.waits <- ack:
+		<-ack
+	case <-s.done:
+	}
+	s.wg.Wait()
+}
+
+// Stop stops a started Scheduler: it starts no more runs, and waits for
+// the running ones until ctx is done. Then it cancels their context, and
+// returns ctx.Err() if they had not finished.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	close(s.stop)
+	<-s.done
+	finished := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(finished)
+	}()
+	defer s.cancel()
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// DemonstrateScheduler runs a job every 20ms on the real clock, next to
+// a job that panics, and stops them both.
+func DemonstrateScheduler() {
+	fmt.Println("=== Scheduler ===")
+	var runs atomic.Int32
+	var once sync.Once
+	s := &Scheduler{
+		Resolution: 5 * time.Millisecond,
+		OnError: func(job string, err error) {
+			once.Do(func() { fmt.Printf("%s: %v\n", job, err) })
+		},
+	}
+	s.Add(Job{Name: "heartbeat", Schedule: Every(20 * time.Millisecond), Run: func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}})
+	s.Add(Job{Name: "broken", Schedule: Every(20 * time.Millisecond), Run: func(context.Context) error {
+		var m map[string]int
+		m["boom"]++
+		return nil
+	}})
+	s.Start()
+	time.Sleep(110 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	fmt.Println("stopped:", s.Stop(ctx))
+	fmt.Println("heartbeat ran despite the panics:", runs.Load() >= 3)
+}
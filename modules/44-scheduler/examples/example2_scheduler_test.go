@@ -0,0 +1,123 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateScheduler(t *testing.T) {
+	DemonstrateScheduler()
+}
+
+var start = time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+// stop stops s, and fails the test if its jobs do not finish in a second.
+func stop(t *testing.T, s *Scheduler) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, s.Stop(ctx))
+}
+
+func TestSchedulerRuns(t *testing.T) {
+	clock := NewFakeClock(start)
+	s := &Scheduler{Clock: clock}
+	var runs atomic.Int32
+	s.Add(Job{Name: "count", Schedule: Every(time.Minute), Run: func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}})
+	s.Start()
+	advance(clock, s, 5*time.Minute)
+	assert.Equal(t, int32(5), runs.Load())
+	advance(clock, s, 5*time.Minute)
+	stop(t, s)
+	assert.Equal(t, int32(10), runs.Load())
+}
+
+func TestSchedulerRecoversPanics(t *testing.T) {
+	clock := NewFakeClock(start)
+	var mu sync.Mutex
+	var errs []error
+	s := &Scheduler{Clock: clock, OnError: func(job string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}}
+	var runs atomic.Int32
+	s.Add(Job{Name: "panics", Schedule: Every(time.Minute), Run: func(context.Context) error {
+		panic("nil map")
+	}})
+	s.Add(Job{Name: "count", Schedule: Every(time.Minute), Run: func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}})
+	s.Start()
+	advance(clock, s, 3*time.Minute)
+	stop(t, s)
+
+	assert.Equal(t, int32(3), runs.Load())
+	require.Len(t, errs, 3, "the panicking job runs again")
+	assert.ErrorIs(t, errs[0], ErrPanic)
+	assert.ErrorContains(t, errs[0], "nil map")
+}
+
+func TestSchedulerMissedRuns(t *testing.T) {
+	tests := []struct {
+		missed Missed
+		want   int32
+	}{
+		{RunOnce, 2},
+		{Skip, 1},
+		{CatchUp, 6},
+	}
+	for _, tt := range tests {
+		clock := NewFakeClock(start)
+		s := &Scheduler{Clock: clock}
+		release := make(chan struct{})
+		var runs atomic.Int32
+		s.Add(Job{Name: "slow", Schedule: Every(time.Minute), Missed: tt.missed, Run: func(context.Context) error {
+			if runs.Add(1) == 1 {
+				<-release
+			}
+			return nil
+		}})
+		s.Start()
+
+		clock.Advance(time.Minute) // the first run starts, and blocks
+		clock.Advance(5*time.Minute + 30*time.Second)
+		close(release)
+		s.Wait()
+		clock.Advance(20 * time.Second) // the 6:00 run is late, and 7:00 is not due
+		stop(t, s)
+		assert.Equal(t, tt.want, runs.Load(), "policy %d", tt.missed)
+	}
+}
+
+func TestSchedulerStop(t *testing.T) {
+	clock := NewFakeClock(start)
+	s := &Scheduler{Clock: clock}
+	var cancelled atomic.Bool
+	started := make(chan struct{})
+	s.Add(Job{Name: "stuck", Schedule: Every(time.Minute), Run: func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		cancelled.Store(true)
+		return ctx.Err()
+	}})
+	s.Start()
+	clock.Advance(time.Minute)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.True(t, errors.Is(s.Stop(ctx), context.DeadlineExceeded))
+	assert.Eventually(t, cancelled.Load, time.Second, time.Millisecond, "the job's context is cancelled")
+}
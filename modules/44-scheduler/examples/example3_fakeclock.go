@@ -0,0 +1,221 @@
+package examples
+
+// This file shows:
+// - A fake Clock whose tickers tick only when a test advances it
+// - Handing each tick over on an unbuffered channel, so that a scheduler sees every tick in order
+// - Running a simulated hour of a scheduler in microseconds
+// - Advancing a step at a time, and waiting between steps, because runs
+//   happen in goroutines that lag behind the clock
+// - Jobs that keep a service tidy: a session janitor and an audit log flusher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock that moves only when Advance is called.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+// NewFakeClock returns a FakeClock that reads now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker returns a Ticker that ticks every d of fake time.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTicker{
+		clock: c,
+		c:     make(chan time.Time),
+		stop:  make(chan struct{}),
+		every: d,
+		next:  c.now.Add(d),
+	}
+	c.tickers = append(c.tickers, t)
+	return t
+}
+
+// Advance moves the clock forward by d, one tick at a time. Each tick
+// waits until its ticker's reader receives it, or the ticker is stopped.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	c.mu.Unlock()
+	for {
+		c.mu.Lock()
+		var t *fakeTicker
+		for _, ft := range c.tickers {
+			if !ft.stopped && !ft.next.After(end) && (t == nil || ft.next.Before(t.next)) {
+				t = ft
+			}
+		}
+		if t == nil {
+			c.now = end
+			c.mu.Unlock()
+			return
+		}
+		at := t.next
+		c.now = at
+		t.next = at.Add(t.every)
+		c.mu.Unlock()
+
+		select {
+		case t.c <- at:
+		case <-t.stop:
+		}
+	}
+}
+
+// fakeTicker is a Ticker of a FakeClock. stopped is guarded by the
+// clock's mutex.
+type fakeTicker struct {
+	clock   *FakeClock
+	c       chan time.Time
+	stop    chan struct{}
+	every   time.Duration
+	next    time.Time
+	stopped bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.c }
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	if !t.stopped {
+		t.stopped = true
+		close(t.stop)
+	}
+}
+
+// Expiring is a set of keys that expire, such as sessions or cached
+// responses, which a janitor job sweeps.
+type Expiring struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+}
+
+// Set adds key until expires.
+func (e *Expiring) Set(key string, expires time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.expires == nil {
+		e.expires = map[string]time.Time{}
+	}
+	e.expires[key] = expires
+}
+
+// Sweep deletes the keys that have expired at now, and returns them in
+// order.
+func (e *Expiring) Sweep(now time.Time) []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var gone []string
+	for k, exp := range e.expires {
+		if !now.Before(exp) {
+			delete(e.expires, k)
+			gone = append(gone, k)
+		}
+	}
+	sort.Strings(gone)
+	return gone
+}
+
+// AuditLog buffers audit entries, and writes them to Out when flushed.
+type AuditLog struct {
+	Out io.Writer
+
+	mu      sync.Mutex
+	pending []string
+}
+
+// Record buffers an entry.
+func (l *AuditLog) Record(entry string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pending = append(l.pending, entry)
+}
+
+// Flush writes the buffered entries. Entries it fails to write stay
+// buffered for the next flush.
+func (l *AuditLog) Flush(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for len(l.pending) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(l.Out, l.pending[0]); err != nil {
+			return err
+		}
+		l.pending = l.pending[1:]
+	}
+	return nil
+}
+
+// advance moves clock forward by d a minute at a time, and waits for the
+// runs that each minute starts, so that a job reads the time it ran at.
+func advance(clock *FakeClock, s *Scheduler, d time.Duration) {
+	for ; d > 0; d -= time.Minute {
+		clock.Advance(min(d, time.Minute))
+		s.Wait()
+	}
+}
+
+// DemonstrateJanitor runs a session janitor and an audit log flusher
+// through a simulated half hour on a fake clock.
+func DemonstrateJanitor() {
+	fmt.Println("=== Janitor and flusher ===")
+	clock := NewFakeClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	sessions := &Expiring{}
+	audit := &AuditLog{Out: os.Stdout}
+	flushEvery, err := ParseCron("*/15 * * * *")
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+
+	s := &Scheduler{Clock: clock, Resolution: time.Second}
+	s.Add(Job{Name: "janitor", Schedule: Every(10 * time.Minute), Run: func(context.Context) error {
+		if gone := sessions.Sweep(clock.Now()); len(gone) > 0 {
+			fmt.Println("janitor: expired", gone)
+		}
+		return nil
+	}})
+	s.Add(Job{Name: "audit", Schedule: flushEvery, Run: audit.Flush})
+	s.Start()
+
+	start := clock.Now()
+	sessions.Set("ada", start.Add(5*time.Minute))
+	sessions.Set("grace", start.Add(18*time.Minute))
+	audit.Record("09:02 ada logged in")
+	audit.Record("09:07 ada changed their password")
+	advance(clock, s, 20*time.Minute)
+	audit.Record("09:21 grace logged in")
+	advance(clock, s, 9*time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	fmt.Println("stopped:", s.Stop(ctx))
+	// Entries recorded since the last flush are written on the way out.
+	if err := audit.Flush(ctx); err != nil {
+		fmt.Println("error:", err)
+	}
+}
@@ -0,0 +1,59 @@
+package examples
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateJanitor(t *testing.T) {
+	DemonstrateJanitor()
+}
+
+func TestFakeClockAdvance(t *testing.T) {
+	clock := NewFakeClock(start)
+	ticker := clock.NewTicker(time.Second)
+	var got []time.Time
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 3; i++ {
+			got = append(got, <-ticker.C())
+		}
+	}()
+	clock.Advance(3500 * time.Millisecond)
+	<-done
+	assert.Equal(t, []time.Time{start.Add(time.Second), start.Add(2 * time.Second), start.Add(3 * time.Second)}, got)
+	assert.Equal(t, start.Add(3500*time.Millisecond), clock.Now())
+
+	ticker.Stop()
+	clock.Advance(time.Hour) // a stopped ticker does not block Advance
+}
+
+func TestJanitorAndFlusher(t *testing.T) {
+	clock := NewFakeClock(start)
+	sessions := &Expiring{}
+	var out bytes.Buffer
+	audit := &AuditLog{Out: &out}
+	s := &Scheduler{Clock: clock}
+	s.Add(Job{Name: "janitor", Schedule: Every(time.Minute), Run: func(context.Context) error {
+		sessions.Sweep(clock.Now())
+		return nil
+	}})
+	s.Add(Job{Name: "audit", Schedule: Every(time.Hour), Run: audit.Flush})
+	s.Start()
+
+	sessions.Set("ada", start.Add(30*time.Minute))
+	sessions.Set("grace", start.Add(2*time.Hour))
+	audit.Record("login ada")
+	advance(clock, s, time.Hour)
+	stop(t, s)
+
+	assert.Equal(t, "login ada\n", out.String())
+	require.Len(t, sessions.expires, 1)
+	assert.Contains(t, sessions.expires, "grace")
+}
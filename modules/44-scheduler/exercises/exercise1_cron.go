@@ -0,0 +1,116 @@
+package exercises
+
+// EXERCISE: Fix a cron spec parser whose jobs run at the wrong times.
+// Parse reads specs such as "*/15 9-17 * * 1-5", and Next finds the
+// next minute a spec matches, for a scheduler to run its jobs at. But
+// */2 of days runs on the even days, a spec for Sunday written as 7 is
+// refused, day 0 is accepted and never comes, a job due at 09:00 is
+// found to be due again at 09:00, and a daily job skips a day.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrSpec is returned for a spec that cannot be parsed.
+var ErrSpec = errors.New("invalid cron spec")
+
+// Spec is a parsed cron spec. Each field is indexed by value, and is true
+// for the values the spec matches.
+type Spec struct {
+	Minute, Hour, Day, Month, Weekday []bool
+}
+
+// field is the range of values a field may hold.
+type field struct {
+	min, max int
+}
+
+// BUG: 7, which cron also accepts for Sunday, is out of range.
+var specFields = [5]field{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+
+// Parse parses five fields: minute, hour, day of month, month, and day
+// of week. A field is *, a number, a range such as 1-5, a step such as
+// */15, or a list of these separated by commas.
+func Parse(spec string) (*Spec, error) {
+	parts := strings.Fields(spec)
+	if len(parts) != len(specFields) {
+		return nil, fmt.Errorf("%w: %q has %d fields, want 5", ErrSpec, spec, len(parts))
+	}
+	var sets [5][]bool
+	for i, p := range parts {
+		set, err := parseList(p, specFields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+	return &Spec{Minute: sets[0], Hour: sets[1], Day: sets[2], Month: sets[3], Weekday: sets[4]}, nil
+}
+
+// parseList parses one field.
+func parseList(s string, f field) ([]bool, error) {
+	set := make([]bool, f.max+1)
+	for _, part := range strings.Split(s, ",") {
+		rng, stepText, hasStep := strings.Cut(part, "/")
+		// BUG: * starts at 0, even for days and months.
+		lo, hi := 0, f.max
+		if rng != "*" {
+			from, to, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(from); err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrSpec, part)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(to); err != nil {
+					return nil, fmt.Errorf("%w: %q", ErrSpec, part)
+				}
+			}
+		}
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: step %q", ErrSpec, part)
+			}
+			step = n
+		}
+		// BUG: values below the minimum are accepted.
+		if hi > f.max || lo > hi {
+			return nil, fmt.Errorf("%w: %q is outside %d-%d", ErrSpec, part, f.min, f.max)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+// Next returns the first minute after t that s matches, or the zero Time
+// if there is none within five years.
+func (s *Spec) Next(t time.Time) time.Time {
+	loc := t.Location()
+	// BUG: starts at t's own minute.
+	t = t.Truncate(time.Minute)
+	for end := t.AddDate(5, 0, 0); t.Before(end); {
+		switch {
+		case !s.Month[t.Month()]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.Day[t.Day()] || !s.Weekday[t.Weekday()]:
+			// BUG: the next day starts at the time of day t had.
+			t = t.AddDate(0, 0, 1)
+		case !s.Hour[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !s.Minute[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,133 @@
+package exercises
+
+// EXERCISE: Fix a job runner that stops running a job after it panics.
+// Runner runs a service's background jobs at fixed intervals, each in a
+// goroutine of its own, and recovers their panics. But a job that
+// panicked once never runs again, a job that fell behind runs on every
+// tick until it catches up, and Stop returns while jobs are still
+// running, without telling them to stop.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrPanic is wrapped by the errors of jobs that panicked.
+var ErrPanic = errors.New("job panicked")
+
+// Runner runs jobs at fixed intervals. Tick starts the jobs that are due,
+// and Start calls it on a time.Ticker.
+type Runner struct {
+	// OnError is called with the errors that jobs return, and their
+	// panics.
+	OnError func(job string, err error)
+
+	mu     sync.Mutex
+	jobs   []*job
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+type job struct {
+	name    string
+	every   time.Duration
+	fn      func(ctx context.Context) error
+	next    time.Time
+	running bool
+}
+
+// Add runs fn every interval, from first.
+func (r *Runner) Add(name string, every time.Duration, first time.Time, fn func(ctx context.Context) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ctx == nil {
+		r.ctx, r.cancel = context.WithCancel(context.Background())
+	}
+	r.jobs = append(r.jobs, &job{name: name, every: every, fn: fn, next: first})
+}
+
+// Tick starts the jobs that are due at now, each in a goroutine of its
+// own. A job that is still running is not started again.
+func (r *Runner) Tick(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, j := range r.jobs {
+		if j.running || j.next.After(now) {
+			continue
+		}
+		// BUG: the next run is the next one the job missed, which may
+		// already be due.
+		j.next = j.next.Add(j.every)
+		j.running = true
+		r.wg.Add(1)
+		go r.run(j)
+	}
+}
+
+func (r *Runner) run(j *job) {
+	defer r.wg.Done()
+	defer func() {
+		if v := recover(); v != nil {
+			r.report(j.name, fmt.Errorf("%w: %v", ErrPanic, v))
+		}
+	}()
+	// BUG: the job's context is never cancelled.
+	err := j.fn(context.Background())
+	// BUG: a panic skips this, and the job stays running forever.
+	r.mu.Lock()
+	j.running = false
+	r.mu.Unlock()
+	if err != nil {
+		r.report(j.name, err)
+	}
+}
+
+func (r *Runner) report(name string, err error) {
+	if r.OnError != nil {
+		r.OnError(name, err)
+	}
+}
+
+// Wait waits for the running jobs to return.
+func (r *Runner) Wait() {
+	r.wg.Wait()
+}
+
+// Start calls Tick every resolution, until Stop.
+func (r *Runner) Start(resolution time.Duration) {
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		t := time.NewTicker(resolution)
+		defer t.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case now := <-t.C:
+				r.Tick(now)
+			}
+		}
+	}()
+}
+
+// Stop stops ticking, cancels the running jobs' context, and waits for
+// them to return.
+func (r *Runner) Stop() {
+	if r.stop != nil {
+		close(r.stop)
+		<-r.done
+	}
+	if r.cancel != nil {
+		r.cancel()
+	}
+	// BUG: returns without waiting for the running jobs.
+}
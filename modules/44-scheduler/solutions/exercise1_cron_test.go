@@ -0,0 +1,60 @@
+package solutions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// from is Friday the 1st of March 2024, at 16:50.
+var from = time.Date(2024, 3, 1, 16, 50, 0, 0, time.UTC)
+
+func next(t *testing.T, spec string, after time.Time) time.Time {
+	t.Helper()
+	s, err := Parse(spec)
+	require.NoError(t, err, spec)
+	return s.Next(after)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{"* * * *", "60 * * * *", "* * 0 * *", "* * * 0 *", "* * * * 8", "*/0 * * * *", "9-5 * * * *"} {
+		_, err := Parse(spec)
+		assert.ErrorIs(t, err, ErrSpec, spec)
+	}
+}
+
+func TestSteps(t *testing.T) {
+	s, err := Parse("0 0 */2 * *")
+	require.NoError(t, err)
+	assert.True(t, s.Day[1])
+	assert.False(t, s.Day[2])
+	assert.Equal(t, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC), s.Next(from), "the odd days")
+}
+
+func TestSunday(t *testing.T) {
+	sunday := time.Date(2024, 3, 3, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, sunday, next(t, "0 9 * * 0", from))
+	assert.Equal(t, sunday, next(t, "0 9 * * 7", from))
+	assert.Equal(t, sunday, next(t, "0 9 * * 6-7", time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)))
+}
+
+func TestNextIsAfter(t *testing.T) {
+	at := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, at.AddDate(0, 0, 1), next(t, "0 9 * * *", at), "not the minute it is already")
+	assert.Equal(t, at.AddDate(0, 0, 1), next(t, "0 9 * * *", at.Add(30*time.Second)))
+}
+
+func TestNextDay(t *testing.T) {
+	assert.Equal(t, time.Date(2024, 3, 2, 8, 30, 0, 0, time.UTC), next(t, "30 8 * * *", from))
+	assert.Equal(t, time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), next(t, "0 9 * * 1-5", from))
+	saturday := time.Date(2024, 3, 2, 10, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), next(t, "0 9 * * 1", saturday))
+}
+
+func TestNextSameDay(t *testing.T) {
+	assert.Equal(t, time.Date(2024, 3, 1, 17, 0, 0, 0, time.UTC), next(t, "*/15 9-17 * * *", from))
+	assert.Equal(t, time.Date(2024, 3, 1, 16, 51, 0, 0, time.UTC), next(t, "* * * * *", from))
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), next(t, "0 0 1 * *", from))
+}
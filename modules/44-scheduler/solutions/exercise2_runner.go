@@ -0,0 +1,136 @@
+package solutions
+
+// SOLUTION: Fix a job runner that stops running a job after it panics.
+// run clears a job's running flag in a deferred function, so that a panic
+// clears it too. A job that fell behind is next due at the first run
+// after now, not at the next one it missed. Jobs get a context that Stop
+// cancels, and Stop waits for them to return.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrPanic is wrapped by the errors of jobs that panicked.
+var ErrPanic = errors.New("job panicked")
+
+// Runner runs jobs at fixed intervals. Tick starts the jobs that are due,
+// and Start calls it on a time.Ticker.
+type Runner struct {
+	// OnError is called with the errors that jobs return, and their
+	// panics.
+	OnError func(job string, err error)
+
+	mu     sync.Mutex
+	jobs   []*job
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+type job struct {
+	name    string
+	every   time.Duration
+	fn      func(ctx context.Context) error
+	next    time.Time
+	running bool
+}
+
+// Add runs fn every interval, from first.
+func (r *Runner) Add(name string, every time.Duration, first time.Time, fn func(ctx context.Context) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ctx == nil {
+		r.ctx, r.cancel = context.WithCancel(context.Background())
+	}
+	r.jobs = append(r.jobs, &job{name: name, every: every, fn: fn, next: first})
+}
+
+// Tick starts the jobs that are due at now, each in a goroutine of its
+// own. A job that is still running is not started again.
+func (r *Runner) Tick(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, j := range r.jobs {
+		if j.running || j.next.After(now) {
+			continue
+		}
+		// Fixed: the next run is the first one after now, so that a job
+		// that fell behind runs once, not on every tick until it catches up.
+		for !j.next.After(now) {
+			j.next = j.next.Add(j.every)
+		}
+		j.running = true
+		r.wg.Add(1)
+		go r.run(j)
+	}
+}
+
+func (r *Runner) run(j *job) {
+	defer r.wg.Done()
+	// Fixed: running is cleared in a deferred function, which a panic runs
+	// too.
+	defer func() {
+		r.mu.Lock()
+		j.running = false
+		r.mu.Unlock()
+	}()
+	defer func() {
+		if v := recover(); v != nil {
+			r.report(j.name, fmt.Errorf("%w: %v", ErrPanic, v))
+		}
+	}()
+	// Fixed: the job gets the context that Stop cancels.
+	if err := j.fn(r.ctx); err != nil {
+		r.report(j.name, err)
+	}
+}
+
+func (r *Runner) report(name string, err error) {
+	if r.OnError != nil {
+		r.OnError(name, err)
+	}
+}
+
+// Wait waits for the running jobs to return.
+func (r *Runner) Wait() {
+	r.wg.Wait()
+}
+
+// Start calls Tick every resolution, until Stop.
+func (r *Runner) Start(resolution time.Duration) {
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		t := time.NewTicker(resolution)
+		defer t.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case now := <-t.C:
+				r.Tick(now)
+			}
+		}
+	}()
+}
+
+// Stop stops ticking, cancels the running jobs' context, and waits for
+// them to return.
+func (r *Runner) Stop() {
+	if r.stop != nil {
+		close(r.stop)
+		<-r.done
+	}
+	if r.cancel != nil {
+		r.cancel()
+	}
+	// Fixed: waits for the running jobs.
+	r.wg.Wait()
+}
@@ -0,0 +1,146 @@
+package solutions
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var t0 = time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+// within fails the test if fn has not returned in a second.
+func within(t *testing.T, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out")
+	}
+}
+
+func counter(n *atomic.Int32) func(context.Context) error {
+	return func(context.Context) error {
+		n.Add(1)
+		return nil
+	}
+}
+
+func TestTick(t *testing.T) {
+	var r Runner
+	var a, b atomic.Int32
+	r.Add("a", time.Minute, t0, counter(&a))
+	r.Add("b", time.Minute, t0.Add(time.Minute), counter(&b))
+	r.Tick(t0)
+	r.Wait()
+	r.Tick(t0.Add(time.Minute))
+	r.Wait()
+	assert.Equal(t, int32(2), a.Load())
+	assert.Equal(t, int32(1), b.Load())
+}
+
+func TestPanicRecovered(t *testing.T) {
+	var mu sync.Mutex
+	var errs []error
+	r := Runner{OnError: func(job string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}}
+	var runs atomic.Int32
+	r.Add("panics", time.Minute, t0, func(context.Context) error {
+		runs.Add(1)
+		panic("nil map")
+	})
+	for i := 0; i < 3; i++ {
+		r.Tick(t0.Add(time.Duration(i) * time.Minute))
+		r.Wait()
+	}
+	assert.Equal(t, int32(3), runs.Load(), "a job that panicked runs again")
+	require.Len(t, errs, 3)
+	assert.ErrorIs(t, errs[0], ErrPanic)
+}
+
+func TestFallingBehind(t *testing.T) {
+	var r Runner
+	var runs atomic.Int32
+	r.Add("count", time.Minute, t0, counter(&runs))
+	r.Tick(t0)
+	r.Wait()
+	r.Tick(t0.Add(10 * time.Minute)) // the process was asleep
+	r.Wait()
+	r.Tick(t0.Add(10*time.Minute + time.Second))
+	r.Wait()
+	assert.Equal(t, int32(2), runs.Load(), "the missed runs are run once")
+	r.Tick(t0.Add(11 * time.Minute))
+	r.Wait()
+	assert.Equal(t, int32(3), runs.Load())
+}
+
+func TestNoOverlap(t *testing.T) {
+	var r Runner
+	var runs atomic.Int32
+	release := make(chan struct{})
+	r.Add("slow", time.Minute, t0, func(context.Context) error {
+		runs.Add(1)
+		<-release
+		return nil
+	})
+	r.Tick(t0)
+	r.Tick(t0.Add(time.Minute))
+	r.Tick(t0.Add(2 * time.Minute))
+	close(release)
+	r.Wait()
+	assert.Equal(t, int32(1), runs.Load())
+}
+
+func TestStopWaits(t *testing.T) {
+	var r Runner
+	var finished atomic.Bool
+	r.Add("slow", time.Minute, t0, func(context.Context) error {
+		time.Sleep(30 * time.Millisecond)
+		finished.Store(true)
+		return nil
+	})
+	r.Tick(t0)
+	within(t, r.Stop)
+	assert.True(t, finished.Load(), "Stop returned before the job")
+}
+
+func TestStopCancels(t *testing.T) {
+	var r Runner
+	started := make(chan struct{})
+	var cancelled atomic.Bool
+	r.Add("waits", time.Minute, t0, func(ctx context.Context) error {
+		close(started)
+		select {
+		case <-ctx.Done():
+			cancelled.Store(true)
+		case <-time.After(2 * time.Second):
+		}
+		return nil
+	})
+	r.Tick(t0)
+	<-started
+	within(t, r.Stop)
+	assert.Eventually(t, cancelled.Load, time.Second, time.Millisecond)
+}
+
+func TestStart(t *testing.T) {
+	var r Runner
+	var runs atomic.Int32
+	r.Add("count", 10*time.Millisecond, time.Now(), counter(&runs))
+	r.Start(2 * time.Millisecond)
+	time.Sleep(60 * time.Millisecond)
+	within(t, r.Stop)
+	assert.GreaterOrEqual(t, runs.Load(), int32(2))
+}
@@ -0,0 +1,149 @@
+// Package examples demonstrates property-based testing with the course's
+// proptest library: properties that must hold for every input rather than
+// for a few chosen ones, generators for ints, slices, and shapes, and
+// shrinking a failing input down to the simplest one that still fails.
+//
+// This file shows:
+// - A property as a func that returns true when it holds for one input
+// - Round trips: reversing twice, and quoting then unquoting
+// - Comparing with an oracle, a simpler implementation known to be right
+// - Identities and laws: scaling by 1 changes nothing, scaling by k scales the perimeter by k
+// - Copying the input first when the function under test modifies it in place
+// - Comparing floats with a tolerance, since a property meets every rounding error
+// - Find, and the shrunk counterexample it reports for a buggy function
+package examples
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strconv"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/proptest"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vec"
+)
+
+// ReverseSlice reverses a slice in place and returns it, as in module 01.
+func ReverseSlice(numbers []int) []int {
+	for i := 0; i < len(numbers)/2; i++ {
+		j := len(numbers) - 1 - i
+		numbers[i], numbers[j] = numbers[j], numbers[i]
+	}
+	return numbers
+}
+
+// SortInts returns a sorted copy of xs, by insertion sort.
+func SortInts(xs []int) []int {
+	out := make([]int, 0, len(xs))
+	for _, x := range xs {
+		i := len(out)
+		for i > 0 && out[i-1] > x {
+			i--
+		}
+		out = slices.Insert(out, i, x)
+	}
+	return out
+}
+
+// ScaleShape returns s scaled by k about the origin: every point and
+// every length is multiplied by k. k must be positive.
+func ScaleShape(s geometry.Shape, k float64) geometry.Shape {
+	switch s := s.(type) {
+	case geometry.Circle:
+		return geometry.Circle{Center: vec.Scale(s.Center, k), Radius: s.Radius * k}
+	case geometry.Rectangle:
+		return geometry.Rectangle{Origin: vec.Scale(s.Origin, k), Width: s.Width * k, Height: s.Height * k}
+	case geometry.Triangle:
+		return geometry.Triangle{A: vec.Scale(s.A, k), B: vec.Scale(s.B, k), C: vec.Scale(s.C, k)}
+	}
+	panic(fmt.Sprintf("ScaleShape: unknown shape %T", s))
+}
+
+// ReverseTwice is the property that reversing xs twice gives xs back.
+// ReverseSlice works in place, so comparing its result with xs itself
+// would compare xs with xs: the property keeps a copy to compare with.
+func ReverseTwice(xs []int) bool {
+	want := slices.Clone(xs)
+	return slices.Equal(ReverseSlice(ReverseSlice(xs)), want)
+}
+
+// QuoteRoundTrip is the property that unquoting a quoted string gives
+// the string back.
+func QuoteRoundTrip(s string) bool {
+	got, err := strconv.Unquote(strconv.Quote(s))
+	return err == nil && got == s
+}
+
+// SortMatchesOracle is the property that SortInts agrees with
+// slices.Sort, which is trusted to be right.
+func SortMatchesOracle(xs []int) bool {
+	want := slices.Clone(xs)
+	slices.Sort(want)
+	return slices.Equal(SortInts(xs), want)
+}
+
+// ScaleByOne is the property that scaling by 1 gives the same shape.
+// Multiplying by 1 is exact, so == is safe here.
+func ScaleByOne(s geometry.Shape) bool {
+	return ScaleShape(s, 1) == s
+}
+
+// ScaledPerimeter is the property that scaling by k multiplies the
+// perimeter by k. The two sides are computed in different orders, so they
+// may differ in the last bits; == would fail on the first input it meets.
+func ScaledPerimeter(s geometry.Shape, k float64) bool {
+	return approxEqual(ScaleShape(s, k).Perimeter(), k*s.Perimeter())
+}
+
+// approxEqual reports whether a and b are within a relative tolerance of
+// each other, or an absolute one near zero.
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+// dedupSort is a buggy sort that drops repeated elements.
+func dedupSort(xs []int) []int {
+	return slices.Compact(SortInts(xs))
+}
+
+// DemonstrateProperties checks a few properties, then finds and shrinks
+// a counterexample to a buggy sort.
+func DemonstrateProperties() {
+	fmt.Println("=== Properties ===")
+	r := rand.New(rand.NewSource(1))
+	ints := proptest.SliceOf(proptest.Int(-100, 100))
+
+	_, failed := proptest.Find(r, proptest.Config{}, ints, ReverseTwice)
+	fmt.Println("reversing twice gives xs back:", !failed)
+	_, failed = proptest.Find(r, proptest.Config{}, proptest.String(), QuoteRoundTrip)
+	fmt.Println("unquoting a quoted string gives it back:", !failed)
+	_, failed = proptest.Find(r, proptest.Config{}, ints, SortMatchesOracle)
+	fmt.Println("SortInts agrees with slices.Sort:", !failed)
+	_, failed = proptest.Find(r, proptest.Config{}, Shapes(), ScaleByOne)
+	fmt.Println("scaling by 1 changes nothing:", !failed)
+	scaled := proptest.Map2(Shapes(), proptest.Float64(0.1, 10), func(s geometry.Shape, k float64) scaling {
+		return scaling{s, k}
+	})
+	_, failed = proptest.Find(r, proptest.Config{}, scaled, func(sc scaling) bool {
+		return ScaledPerimeter(sc.Shape, sc.K)
+	})
+	fmt.Println("scaling by k scales the perimeter by k:", !failed)
+
+	f, failed := proptest.Find(r, proptest.Config{}, ints, func(xs []int) bool {
+		want := slices.Clone(xs)
+		slices.Sort(want)
+		return slices.Equal(dedupSort(xs), want)
+	})
+	if failed {
+		fmt.Printf("dedupSort fails on run %d for %v, shrunk %d times from %d elements\n",
+			f.Run, f.Value, f.Shrinks, len(f.Original))
+	}
+}
+
+// scaling is a shape and a factor to scale it by, generated together.
+type scaling struct {
+	Shape geometry.Shape
+	K     float64
+}
@@ -0,0 +1,41 @@
+package examples
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/proptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateProperties(t *testing.T) {
+	DemonstrateProperties()
+}
+
+func TestProperties(t *testing.T) {
+	ints := proptest.SliceOf(proptest.Int(-100, 100))
+	proptest.Check(t, ints, ReverseTwice)
+	proptest.Check(t, ints, SortMatchesOracle)
+	proptest.Check(t, proptest.String(), QuoteRoundTrip)
+	proptest.Check(t, Shapes(), ScaleByOne)
+	proptest.Check(t, Shapes(), func(s geometry.Shape) bool { return ScaledPerimeter(s, 2.5) })
+}
+
+func TestScaleShape(t *testing.T) {
+	r := geometry.Rectangle{Origin: geometry.Point{X: 1, Y: -2}, Width: 3, Height: 4}
+	assert.Equal(t, geometry.Rectangle{Origin: geometry.Point{X: 2, Y: -4}, Width: 6, Height: 8}, ScaleShape(r, 2))
+	c := geometry.Circle{Radius: 2}
+	assert.InDelta(t, 9*c.Area(), ScaleShape(c, 3).Area(), 1e-9)
+	assert.Panics(t, func() { ScaleShape(nil, 2) })
+}
+
+func TestFindsDedupSort(t *testing.T) {
+	f, failed := proptest.Find(rand.New(rand.NewSource(1)), proptest.Config{}, proptest.SliceOf(proptest.Int(-100, 100)),
+		func(xs []int) bool { return slices.Equal(dedupSort(xs), SortInts(xs)) })
+	require.True(t, failed)
+	require.Len(t, f.Value, 2, "two equal elements, and nothing else")
+	assert.Equal(t, f.Value[0], f.Value[1])
+}
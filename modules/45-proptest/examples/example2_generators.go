@@ -0,0 +1,103 @@
+package examples
+
+// This file shows:
+// - A generator is a function of a random source and a size, which grows over a check
+// - Building struct generators from field generators with Map2 and Map3
+// - Whole-number coordinates, which keep counterexamples readable
+// - Generating only valid values directly, such as positive radii, instead of filtering
+// - Filter for what is hard to generate directly: a triangle that is not flat
+// - A generator that picks one of several others, for an interface type
+// - Sampling a generator to see what it makes
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/proptest"
+)
+
+// coord generates whole-number coordinates in [-100, 100].
+func coord() proptest.Gen[float64] {
+	return proptest.Map(proptest.Int(-100, 100), func(n int) float64 { return float64(n) })
+}
+
+// length generates whole-number lengths in [1, 100]; Int(1, 100) never
+// makes 0, so no filtering is needed.
+func length() proptest.Gen[float64] {
+	return proptest.Map(proptest.Int(1, 100), func(n int) float64 { return float64(n) })
+}
+
+// Points generates points, shrinking towards the origin.
+func Points() proptest.Gen[geometry.Point] {
+	return proptest.Map2(coord(), coord(), func(x, y float64) geometry.Point {
+		return geometry.Point{X: x, Y: y}
+	})
+}
+
+// Circles generates circles with a positive radius.
+func Circles() proptest.Gen[geometry.Circle] {
+	return proptest.Map2(Points(), length(), func(c geometry.Point, r float64) geometry.Circle {
+		return geometry.Circle{Center: c, Radius: r}
+	})
+}
+
+// Rectangles generates rectangles with positive sides.
+func Rectangles() proptest.Gen[geometry.Rectangle] {
+	return proptest.Map3(Points(), length(), length(), func(o geometry.Point, w, h float64) geometry.Rectangle {
+		return geometry.Rectangle{Origin: o, Width: w, Height: h}
+	})
+}
+
+// Triangles generates triangles with a positive area. Three random points
+// are rarely in a line, so Filter seldom has to try again; but shrinking
+// moves points towards the origin, and would end at a flat triangle of
+// three equal points if Filter did not refuse it.
+func Triangles() proptest.Gen[geometry.Triangle] {
+	g := proptest.Map3(Points(), Points(), Points(), func(a, b, c geometry.Point) geometry.Triangle {
+		return geometry.Triangle{A: a, B: b, C: c}
+	})
+	return proptest.Filter(g, func(t geometry.Triangle) bool { return t.Area() > 0 })
+}
+
+// asShape converts a concrete shape to the interface, for Map.
+func asShape[S geometry.Shape](s S) geometry.Shape { return s }
+
+// Shapes generates circles, rectangles, and triangles, equally often.
+func Shapes() proptest.Gen[geometry.Shape] {
+	gens := []proptest.Gen[geometry.Shape]{
+		proptest.Map(Circles(), asShape[geometry.Circle]),
+		proptest.Map(Rectangles(), asShape[geometry.Rectangle]),
+		proptest.Map(Triangles(), asShape[geometry.Triangle]),
+	}
+	return func(r *rand.Rand, size int) proptest.Tree[geometry.Shape] {
+		return gens[r.Intn(len(gens))](r, size)
+	}
+}
+
+// Sample returns n values from g, with the size growing from 1 to n as it
+// does over the runs of a check.
+func Sample[T any](g proptest.Gen[T], r *rand.Rand, n int) []T {
+	out := make([]T, n)
+	for i := range out {
+		out[i] = g(r, i+1).Value
+	}
+	return out
+}
+
+// DemonstrateGenerators prints samples from a few generators.
+func DemonstrateGenerators() {
+	fmt.Println("=== Generators ===")
+	r := rand.New(rand.NewSource(1))
+	fmt.Println("ints:", Sample(proptest.Int(-1000, 1000), r, 8))
+	fmt.Println("ints in [5, 10]:", Sample(proptest.Int(5, 10), r, 8))
+	for _, xs := range Sample(proptest.SliceOf(proptest.Int(0, 9)), r, 5) {
+		fmt.Println("slice:", xs)
+	}
+	for _, s := range Sample(proptest.String(), r, 12)[8:] {
+		fmt.Printf("string: %q\n", s)
+	}
+	for _, s := range Sample(Shapes(), r, 30)[24:] {
+		fmt.Printf("shape: %v, area %g\n", s, s.Area())
+	}
+}
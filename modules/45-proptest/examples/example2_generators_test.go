@@ -0,0 +1,58 @@
+package examples
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/proptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateGenerators(t *testing.T) {
+	DemonstrateGenerators()
+}
+
+func TestShapesAreValid(t *testing.T) {
+	proptest.Check(t, Shapes(), func(s geometry.Shape) bool {
+		switch s := s.(type) {
+		case geometry.Circle:
+			return s.Radius >= 1
+		case geometry.Rectangle:
+			return s.Width >= 1 && s.Height >= 1
+		case geometry.Triangle:
+			return s.Area() > 0
+		}
+		return false
+	})
+}
+
+func TestTrianglesShrinkToValid(t *testing.T) {
+	f, failed := proptest.Find(rand.New(rand.NewSource(1)), proptest.Config{}, Triangles(),
+		func(geometry.Triangle) bool { return false })
+	require.True(t, failed)
+	assert.Positive(t, f.Value.Area(), "Filter refuses flat triangles while shrinking too")
+	assert.Positive(t, f.Shrinks)
+}
+
+func TestSample(t *testing.T) {
+	xs := Sample(proptest.Int(-1000, 1000), rand.New(rand.NewSource(1)), 50)
+	require.Len(t, xs, 50)
+	for i, x := range xs {
+		assert.LessOrEqual(t, x, i+1, "size bounds the magnitude")
+		assert.GreaterOrEqual(t, x, -(i + 1))
+	}
+	kinds := map[string]bool{}
+	for _, s := range Sample(Shapes(), rand.New(rand.NewSource(1)), 50) {
+		switch s.(type) {
+		case geometry.Circle:
+			kinds["circle"] = true
+		case geometry.Rectangle:
+			kinds["rectangle"] = true
+		case geometry.Triangle:
+			kinds["triangle"] = true
+		}
+	}
+	assert.Len(t, kinds, 3)
+}
@@ -0,0 +1,93 @@
+package examples
+
+// This file shows:
+// - A Tree: a generated value and, computed when asked for, the simpler values it shrinks to
+// - How ints shrink towards 0, and slices towards shorter slices of smaller elements
+// - Shrinking as a walk down the tree, to the first child that still fails, until none does
+// - A custom shrinker through NewTree, for values not built from other generators
+// - Why a shrinker must never return its own argument
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/proptest"
+)
+
+// ShrinkPath shrinks a failing value the way Find does, and returns each
+// value on the way down, starting with the tree's own. It stops after max
+// steps, in case a shrinker never ends.
+func ShrinkPath[T any](t proptest.Tree[T], fails func(T) bool, max int) []T {
+	path := []T{t.Value}
+	for len(path) <= max {
+		next, found := t, false
+		for _, child := range t.Shrinks() {
+			if fails(child.Value) {
+				next, found = child, true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+		t = next
+		path = append(path, t.Value)
+	}
+	return path
+}
+
+// ShrinkBits shrinks a bit mask by clearing one of its set bits, the
+// highest first: a mask with fewer bits set is simpler. It never returns
+// v itself, since a shrinker that does can be walked down forever.
+func ShrinkBits(v uint8) []uint8 {
+	var out []uint8
+	for i := 7; i >= 0; i-- {
+		if bit := uint8(1) << i; v&bit != 0 {
+			out = append(out, v&^bit)
+		}
+	}
+	return out
+}
+
+// Masks generates bit masks, shrinking them with ShrinkBits.
+func Masks() proptest.Gen[uint8] {
+	return func(r *rand.Rand, _ int) proptest.Tree[uint8] {
+		return proptest.NewTree(uint8(r.Intn(256)), ShrinkBits)
+	}
+}
+
+// onesCount7 is a buggy bit count that overlooks the top bit.
+func onesCount7(v uint8) int {
+	return bits.OnesCount8(v & 0x7f)
+}
+
+// DemonstrateShrinking prints how ints, slices, and masks shrink.
+func DemonstrateShrinking() {
+	fmt.Println("=== Shrinking ===")
+	fmt.Println("10 shrinks to:", proptest.ShrinkInt(10, 0))
+	tree := proptest.NewTree(73, func(v int) []int { return proptest.ShrinkInt(v, 0) })
+	fmt.Println("shrinking 73 while n >= 10:", ShrinkPath(tree, func(n int) bool { return n >= 10 }, 100))
+
+	r := rand.New(rand.NewSource(3))
+	slice := proptest.SliceOf(proptest.Int(0, 50))(r, 10)
+	fmt.Println("a slice:", slice.Value)
+	for _, s := range slice.Shrinks()[:4] {
+		fmt.Println("  shrinks to", s.Value)
+	}
+	sum := func(xs []int) int {
+		total := 0
+		for _, x := range xs {
+			total += x
+		}
+		return total
+	}
+	fmt.Println("shrinking while the sum is over 10:", ShrinkPath(slice, func(xs []int) bool { return sum(xs) > 10 }, 100))
+
+	f, failed := proptest.Find(r, proptest.Config{}, Masks(), func(v uint8) bool {
+		return onesCount7(v) == bits.OnesCount8(v)
+	})
+	if failed {
+		fmt.Printf("onesCount7 is wrong for %08b, shrunk from %08b\n", f.Value, f.Original)
+	}
+}
@@ -0,0 +1,42 @@
+package examples
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/proptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateShrinking(t *testing.T) {
+	DemonstrateShrinking()
+}
+
+func TestShrinkPath(t *testing.T) {
+	tree := proptest.NewTree(73, func(v int) []int { return proptest.ShrinkInt(v, 0) })
+	assert.Equal(t, []int{73, 37, 19, 10}, ShrinkPath(tree, func(n int) bool { return n >= 10 }, 100))
+	assert.Equal(t, []int{73}, ShrinkPath(tree, func(n int) bool { return n == 73 }, 100))
+
+	forever := proptest.NewTree(1, func(v int) []int { return []int{v} })
+	assert.Len(t, ShrinkPath(forever, func(int) bool { return true }, 10), 11, "stops after max steps")
+}
+
+func TestShrinkBits(t *testing.T) {
+	assert.Equal(t, []uint8{0b0101, 0b1001, 0b1100}, ShrinkBits(0b1101))
+	assert.Empty(t, ShrinkBits(0))
+	for v := 1; v < 256; v++ {
+		for _, s := range ShrinkBits(uint8(v)) {
+			assert.Equal(t, bits.OnesCount8(uint8(v))-1, bits.OnesCount8(s))
+		}
+	}
+}
+
+func TestMasksFindTopBit(t *testing.T) {
+	f, failed := proptest.Find(rand.New(rand.NewSource(1)), proptest.Config{}, Masks(), func(v uint8) bool {
+		return onesCount7(v) == bits.OnesCount8(v)
+	})
+	require.True(t, failed)
+	assert.Equal(t, uint8(0x80), f.Value)
+}
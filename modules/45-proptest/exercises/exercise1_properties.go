@@ -0,0 +1,99 @@
+package exercises
+
+// EXERCISE: Fix the properties that let bugs through, or fail on correct code.
+// A property is only as good as the bugs it catches. Each function here
+// states something that must hold for ReverseSlice, SortInts, or
+// ScaleShape for every input, and the tests check it with random inputs:
+// it must hold for the correct functions, and fail for broken ones. But
+// ReverseMirrors fails for ReverseSlice itself, SortPermutation passes a
+// sort that drops elements, ScaleIdentity passes a scale that turns
+// rectangles on their side, and ScaleArea fails now and then for no
+// reason at all.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vec"
+)
+
+// ReverseSlice reverses a slice in place and returns it, as in module 01.
+func ReverseSlice(numbers []int) []int {
+	for i := 0; i < len(numbers)/2; i++ {
+		j := len(numbers) - 1 - i
+		numbers[i], numbers[j] = numbers[j], numbers[i]
+	}
+	return numbers
+}
+
+// SortInts returns a sorted copy of xs, by insertion sort.
+func SortInts(xs []int) []int {
+	out := make([]int, 0, len(xs))
+	for _, x := range xs {
+		i := len(out)
+		for i > 0 && out[i-1] > x {
+			i--
+		}
+		out = slices.Insert(out, i, x)
+	}
+	return out
+}
+
+// ScaleShape returns s scaled by k about the origin: every point and
+// every length is multiplied by k. k must be positive.
+func ScaleShape(s geometry.Shape, k float64) geometry.Shape {
+	switch s := s.(type) {
+	case geometry.Circle:
+		return geometry.Circle{Center: vec.Scale(s.Center, k), Radius: s.Radius * k}
+	case geometry.Rectangle:
+		return geometry.Rectangle{Origin: vec.Scale(s.Origin, k), Width: s.Width * k, Height: s.Height * k}
+	case geometry.Triangle:
+		return geometry.Triangle{A: vec.Scale(s.A, k), B: vec.Scale(s.B, k), C: vec.Scale(s.C, k)}
+	}
+	panic(fmt.Sprintf("ScaleShape: unknown shape %T", s))
+}
+
+// ReverseMirrors is the property that reverse puts each element of xs at
+// the mirrored index: the first last, the second second to last, and so
+// on. reverse may work in place.
+func ReverseMirrors(reverse func([]int) []int, xs []int) bool {
+	// BUG: reverse may reverse xs in place, and then this compares its
+	// result with itself
+	got := reverse(xs)
+	if len(got) != len(xs) {
+		return false
+	}
+	for i := range got {
+		if got[i] != xs[len(xs)-1-i] {
+			return false
+		}
+	}
+	return true
+}
+
+// SortPermutation is the property that sort returns the elements of xs,
+// each as many times as xs holds it, in any order.
+func SortPermutation(sort func([]int) []int, xs []int) bool {
+	got := sort(slices.Clone(xs))
+	// BUG: Every element of the result is in xs, but some of xs may be
+	// missing from it, or there twice
+	for _, g := range got {
+		if !slices.Contains(xs, g) {
+			return false
+		}
+	}
+	return true
+}
+
+// ScaleIdentity is the property that scaling s by 1 gives s back.
+func ScaleIdentity(scale func(geometry.Shape, float64) geometry.Shape, s geometry.Shape) bool {
+	return scale(s, 1).Area() == s.Area() // BUG: Different shapes can have the same area
+}
+
+// ScaleArea is the property that scaling s by k multiplies its area by k².
+func ScaleArea(scale func(geometry.Shape, float64) geometry.Shape, s geometry.Shape, k float64) bool {
+	got, want := scale(s, k).Area(), k*k*s.Area()
+	return got == want // BUG: The two sides multiply in different orders, and round differently
+}
@@ -0,0 +1,81 @@
+package exercises
+
+// EXERCISE: Fix generators that make invalid values, or shrink to them.
+// A property test is only as good as its inputs. Rectangles should have
+// positive sides, Triangles should never be flat, Distinct should make
+// ascending slices without repeats, and ShrinkEven should shrink an even
+// number to smaller even numbers. But rectangles come out with negative
+// sides, triangles shrink to three equal points, Distinct keeps repeats
+// that are not next to each other, and ShrinkEven returns odd numbers and
+// v itself, so that shrinking never ends on its own.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"math/rand"
+	"slices"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/proptest"
+)
+
+// coord generates whole-number coordinates in [-100, 100].
+func coord() proptest.Gen[float64] {
+	return proptest.Map(proptest.Int(-100, 100), func(n int) float64 { return float64(n) })
+}
+
+// Points generates points, shrinking towards the origin.
+func Points() proptest.Gen[geometry.Point] {
+	return proptest.Map2(coord(), coord(), func(x, y float64) geometry.Point {
+		return geometry.Point{X: x, Y: y}
+	})
+}
+
+// Rectangles generates rectangles whose sides are whole numbers from 1
+// to 100.
+func Rectangles() proptest.Gen[geometry.Rectangle] {
+	side := proptest.Map(proptest.Int(-100, 100), func(n int) float64 { return float64(n) }) // BUG: Sides can be 0 or negative
+	return proptest.Map3(Points(), side, side, func(o geometry.Point, w, h float64) geometry.Rectangle {
+		return geometry.Rectangle{Origin: o, Width: w, Height: h}
+	})
+}
+
+// Triangles generates triangles with a positive area, and shrinks them
+// to triangles with a positive area.
+func Triangles() proptest.Gen[geometry.Triangle] {
+	g := proptest.Map3(Points(), Points(), Points(), func(a, b, c geometry.Point) geometry.Triangle {
+		return geometry.Triangle{A: a, B: b, C: c}
+	})
+	return proptest.Filter(g, func(t geometry.Triangle) bool {
+		return t.Area() >= 0 // BUG: Keeps flat triangles, whose area is 0
+	})
+}
+
+// Distinct generates slices of ints with no repeats, in ascending order.
+func Distinct() proptest.Gen[[]int] {
+	return proptest.Map(proptest.SliceOf(proptest.Int(-100, 100)), func(xs []int) []int {
+		// BUG: Compact only removes repeats next to each other, and the
+		// result should be in ascending order anyway
+		return slices.Compact(slices.Clone(xs))
+	})
+}
+
+// ShrinkEven returns the even numbers between 0 and the even number v that
+// v shrinks to, closest to 0 first. It never returns v.
+func ShrinkEven(v int) []int {
+	var out []int
+	// BUG: Starts with v itself, and halving an even number can make an
+	// odd one
+	for n := v; n != 0; n /= 2 {
+		out = append(out, n)
+	}
+	return out
+}
+
+// Evens generates even numbers no further from 0 than twice the size, and
+// shrinks them with ShrinkEven.
+func Evens() proptest.Gen[int] {
+	return func(r *rand.Rand, size int) proptest.Tree[int] {
+		v := 2 * (r.Intn(2*size+1) - size)
+		return proptest.NewTree(v, ShrinkEven)
+	}
+}
@@ -0,0 +1,102 @@
+package solutions
+
+// SOLUTION: Fix the properties that let bugs through, or fail on correct code.
+// ReverseMirrors keeps a copy of the input, since ReverseSlice reverses in
+// place; SortPermutation counts each element rather than only looking for
+// it, so a sort that drops or repeats elements fails; ScaleIdentity
+// compares the shapes themselves rather than their areas; and ScaleArea
+// compares with a tolerance.
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vec"
+)
+
+// ReverseSlice reverses a slice in place and returns it, as in module 01.
+func ReverseSlice(numbers []int) []int {
+	for i := 0; i < len(numbers)/2; i++ {
+		j := len(numbers) - 1 - i
+		numbers[i], numbers[j] = numbers[j], numbers[i]
+	}
+	return numbers
+}
+
+// SortInts returns a sorted copy of xs, by insertion sort.
+func SortInts(xs []int) []int {
+	out := make([]int, 0, len(xs))
+	for _, x := range xs {
+		i := len(out)
+		for i > 0 && out[i-1] > x {
+			i--
+		}
+		out = slices.Insert(out, i, x)
+	}
+	return out
+}
+
+// ScaleShape returns s scaled by k about the origin: every point and
+// every length is multiplied by k. k must be positive.
+func ScaleShape(s geometry.Shape, k float64) geometry.Shape {
+	switch s := s.(type) {
+	case geometry.Circle:
+		return geometry.Circle{Center: vec.Scale(s.Center, k), Radius: s.Radius * k}
+	case geometry.Rectangle:
+		return geometry.Rectangle{Origin: vec.Scale(s.Origin, k), Width: s.Width * k, Height: s.Height * k}
+	case geometry.Triangle:
+		return geometry.Triangle{A: vec.Scale(s.A, k), B: vec.Scale(s.B, k), C: vec.Scale(s.C, k)}
+	}
+	panic(fmt.Sprintf("ScaleShape: unknown shape %T", s))
+}
+
+// ReverseMirrors is the property that reverse puts each element of xs at
+// the mirrored index: the first last, the second second to last, and so
+// on. reverse may work in place.
+func ReverseMirrors(reverse func([]int) []int, xs []int) bool {
+	orig := slices.Clone(xs) // Fixed: reverse may change xs, so compare with a copy
+	got := reverse(xs)
+	if len(got) != len(orig) {
+		return false
+	}
+	for i := range got {
+		if got[i] != orig[len(orig)-1-i] {
+			return false
+		}
+	}
+	return true
+}
+
+// SortPermutation is the property that sort returns the elements of xs,
+// each as many times as xs holds it, in any order.
+func SortPermutation(sort func([]int) []int, xs []int) bool {
+	got := sort(slices.Clone(xs))
+	// Fixed: Count the elements, so that a missing or repeated one shows
+	counts := make(map[int]int)
+	for _, x := range xs {
+		counts[x]++
+	}
+	for _, g := range got {
+		counts[g]--
+	}
+	for _, n := range counts {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ScaleIdentity is the property that scaling s by 1 gives s back.
+func ScaleIdentity(scale func(geometry.Shape, float64) geometry.Shape, s geometry.Shape) bool {
+	return scale(s, 1) == s // Fixed: Compare the shapes; different shapes can share an area
+}
+
+// ScaleArea is the property that scaling s by k multiplies its area by k².
+func ScaleArea(scale func(geometry.Shape, float64) geometry.Shape, s geometry.Shape, k float64) bool {
+	got, want := scale(s, k).Area(), k*k*s.Area()
+	// Fixed: Allow for rounding, since the two sides multiply in different orders
+	return math.Abs(got-want) <= 1e-9*math.Max(1, math.Abs(want))
+}
@@ -0,0 +1,140 @@
+package solutions
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/proptest"
+	"github.com/stretchr/testify/assert"
+)
+
+var ints = proptest.SliceOf(proptest.Int(-100, 100))
+
+// number generates whole numbers in [lo, hi] as floats.
+func number(lo, hi int) proptest.Gen[float64] {
+	return proptest.Map(proptest.Int(lo, hi), func(n int) float64 { return float64(n) })
+}
+
+// shapes generates circles, rectangles, and triangles with whole-number
+// coordinates.
+func shapes() proptest.Gen[geometry.Shape] {
+	point := proptest.Map2(number(-100, 100), number(-100, 100), func(x, y float64) geometry.Point {
+		return geometry.Point{X: x, Y: y}
+	})
+	gens := []proptest.Gen[geometry.Shape]{
+		proptest.Map2(point, number(1, 100), func(c geometry.Point, r float64) geometry.Shape {
+			return geometry.Circle{Center: c, Radius: r}
+		}),
+		proptest.Map3(point, number(1, 100), number(1, 100), func(o geometry.Point, w, h float64) geometry.Shape {
+			return geometry.Rectangle{Origin: o, Width: w, Height: h}
+		}),
+		proptest.Map3(point, point, point, func(a, b, c geometry.Point) geometry.Shape {
+			return geometry.Triangle{A: a, B: b, C: c}
+		}),
+	}
+	return func(r *rand.Rand, size int) proptest.Tree[geometry.Shape] {
+		return gens[r.Intn(len(gens))](r, size)
+	}
+}
+
+// scaling is a shape and a factor, generated together.
+type scaling struct {
+	s geometry.Shape
+	k float64
+}
+
+func scalings() proptest.Gen[scaling] {
+	return proptest.Map2(shapes(), proptest.Float64(0.1, 10), func(s geometry.Shape, k float64) scaling {
+		return scaling{s, k}
+	})
+}
+
+// catches reports whether prop fails for some value from g.
+func catches[T any](g proptest.Gen[T], prop func(T) bool) bool {
+	_, failed := proptest.Find(rand.New(rand.NewSource(1)), proptest.Config{Runs: 300}, g, prop)
+	return failed
+}
+
+func TestReverseMirrors(t *testing.T) {
+	proptest.Check(t, ints, func(xs []int) bool { return ReverseMirrors(ReverseSlice, xs) })
+	proptest.Check(t, ints, func(xs []int) bool { return ReverseMirrors(slices.Clone[[]int], slices.Clone(xs)) == isPalindrome(xs) })
+
+	halfReverse := func(xs []int) []int {
+		out := slices.Clone(xs)
+		for i := 0; i < len(out)/2; i++ {
+			out[i] = xs[len(xs)-1-i]
+		}
+		return out
+	}
+	assert.True(t, catches(ints, func(xs []int) bool { return ReverseMirrors(halfReverse, xs) }),
+		"a reverse that only fills in the first half")
+}
+
+func isPalindrome(xs []int) bool {
+	for i := range xs {
+		if xs[i] != xs[len(xs)-1-i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSortPermutation(t *testing.T) {
+	proptest.Check(t, ints, func(xs []int) bool { return SortPermutation(SortInts, xs) })
+
+	dedup := func(xs []int) []int { return slices.Compact(SortInts(xs)) }
+	dropLast := func(xs []int) []int {
+		out := SortInts(xs)
+		return out[:max(len(out)-1, 0)]
+	}
+	repeatFirst := func(xs []int) []int {
+		out := SortInts(xs)
+		for i := range out {
+			out[i] = out[0]
+		}
+		return out
+	}
+	assert.True(t, catches(ints, func(xs []int) bool { return SortPermutation(dedup, xs) }), "a sort that drops repeats")
+	assert.True(t, catches(ints, func(xs []int) bool { return SortPermutation(dropLast, xs) }), "a sort that drops the largest")
+	assert.True(t, catches(ints, func(xs []int) bool { return SortPermutation(repeatFirst, xs) }), "a sort that repeats the smallest")
+}
+
+func TestScaleIdentity(t *testing.T) {
+	proptest.Check(t, shapes(), func(s geometry.Shape) bool { return ScaleIdentity(ScaleShape, s) })
+
+	turn := func(s geometry.Shape, k float64) geometry.Shape {
+		if r, ok := s.(geometry.Rectangle); ok {
+			r.Width, r.Height = r.Height, r.Width
+			s = r
+		}
+		return ScaleShape(s, k)
+	}
+	moveTo0 := func(s geometry.Shape, k float64) geometry.Shape {
+		if c, ok := s.(geometry.Circle); ok {
+			c.Center = geometry.Point{}
+			s = c
+		}
+		return ScaleShape(s, k)
+	}
+	assert.True(t, catches(shapes(), func(s geometry.Shape) bool { return ScaleIdentity(turn, s) }), "a scale that turns rectangles")
+	assert.True(t, catches(shapes(), func(s geometry.Shape) bool { return ScaleIdentity(moveTo0, s) }), "a scale that centers circles")
+}
+
+func TestScaleArea(t *testing.T) {
+	proptest.Check(t, scalings(), func(sc scaling) bool { return ScaleArea(ScaleShape, sc.s, sc.k) })
+
+	linear := func(s geometry.Shape, k float64) geometry.Shape {
+		if r, ok := s.(geometry.Rectangle); ok {
+			r.Width *= k
+			return r
+		}
+		return ScaleShape(s, k)
+	}
+	assert.True(t, catches(scalings(), func(sc scaling) bool { return ScaleArea(linear, sc.s, sc.k) }),
+		"a scale that only stretches the width")
+	off := func(s geometry.Shape, k float64) geometry.Shape { return ScaleShape(s, k*1.0001) }
+	assert.True(t, catches(scalings(), func(sc scaling) bool { return ScaleArea(off, sc.s, sc.k) }),
+		"a scale that is a little off, which a loose tolerance would let through")
+}
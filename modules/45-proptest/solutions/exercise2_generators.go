@@ -0,0 +1,77 @@
+package solutions
+
+// SOLUTION: Fix generators that make invalid values, or shrink to them.
+// Rectangles draws its sides from [1, 100] rather than [-100, 100],
+// Triangles keeps only triangles with a positive area, Distinct sorts
+// before compacting so that every repeat is next to its twin, and
+// ShrinkEven halves v before shrinking it, so that it returns only even
+// numbers, and never v itself.
+
+import (
+	"math/rand"
+	"slices"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/proptest"
+)
+
+// coord generates whole-number coordinates in [-100, 100].
+func coord() proptest.Gen[float64] {
+	return proptest.Map(proptest.Int(-100, 100), func(n int) float64 { return float64(n) })
+}
+
+// Points generates points, shrinking towards the origin.
+func Points() proptest.Gen[geometry.Point] {
+	return proptest.Map2(coord(), coord(), func(x, y float64) geometry.Point {
+		return geometry.Point{X: x, Y: y}
+	})
+}
+
+// Rectangles generates rectangles whose sides are whole numbers from 1
+// to 100.
+func Rectangles() proptest.Gen[geometry.Rectangle] {
+	side := proptest.Map(proptest.Int(1, 100), func(n int) float64 { return float64(n) }) // Fixed: Sides start at 1
+	return proptest.Map3(Points(), side, side, func(o geometry.Point, w, h float64) geometry.Rectangle {
+		return geometry.Rectangle{Origin: o, Width: w, Height: h}
+	})
+}
+
+// Triangles generates triangles with a positive area, and shrinks them
+// to triangles with a positive area.
+func Triangles() proptest.Gen[geometry.Triangle] {
+	g := proptest.Map3(Points(), Points(), Points(), func(a, b, c geometry.Point) geometry.Triangle {
+		return geometry.Triangle{A: a, B: b, C: c}
+	})
+	return proptest.Filter(g, func(t geometry.Triangle) bool {
+		return t.Area() > 0 // Fixed: A flat triangle has an area of 0
+	})
+}
+
+// Distinct generates slices of ints with no repeats, in ascending order.
+func Distinct() proptest.Gen[[]int] {
+	return proptest.Map(proptest.SliceOf(proptest.Int(-100, 100)), func(xs []int) []int {
+		xs = slices.Clone(xs)
+		slices.Sort(xs) // Fixed: Compact only removes repeats next to each other
+		return slices.Compact(xs)
+	})
+}
+
+// ShrinkEven returns the even numbers between 0 and the even number v that
+// v shrinks to, closest to 0 first. It never returns v.
+func ShrinkEven(v int) []int {
+	var out []int
+	// Fixed: Shrink half of v and double the results, which are even and never v
+	for _, n := range proptest.ShrinkInt(v/2, 0) {
+		out = append(out, 2*n)
+	}
+	return out
+}
+
+// Evens generates even numbers no further from 0 than twice the size, and
+// shrinks them with ShrinkEven.
+func Evens() proptest.Gen[int] {
+	return func(r *rand.Rand, size int) proptest.Tree[int] {
+		v := 2 * (r.Intn(2*size+1) - size)
+		return proptest.NewTree(v, ShrinkEven)
+	}
+}
@@ -0,0 +1,82 @@
+package solutions
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/proptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smallest shrinks a value from g as far as it goes: the property fails
+// for everything, so Find keeps the first child of each tree.
+func smallest[T any](t *testing.T, g proptest.Gen[T]) proptest.Failure[T] {
+	t.Helper()
+	f, failed := proptest.Find(rand.New(rand.NewSource(1)), proptest.Config{}, g, func(T) bool { return false })
+	require.True(t, failed)
+	return f
+}
+
+func TestRectangles(t *testing.T) {
+	proptest.Check(t, Rectangles(), func(r geometry.Rectangle) bool {
+		return r.Width >= 1 && r.Height >= 1 && r.Width <= 100 && r.Height <= 100
+	})
+	f := smallest(t, Rectangles())
+	assert.Equal(t, geometry.Rectangle{Width: 1, Height: 1}, f.Value, "shrinks to the smallest valid rectangle")
+}
+
+func TestTriangles(t *testing.T) {
+	proptest.Check(t, Triangles(), func(tr geometry.Triangle) bool { return tr.Area() > 0 })
+	f := smallest(t, Triangles())
+	assert.Positive(t, f.Value.Area(), "shrinks to a triangle that is not flat, %v", f.Value)
+}
+
+func TestDistinct(t *testing.T) {
+	proptest.Check(t, Distinct(), func(xs []int) bool {
+		for i := 1; i < len(xs); i++ {
+			if xs[i-1] >= xs[i] {
+				return false
+			}
+		}
+		return true
+	})
+	var longest int
+	for _, xs := range sample(Distinct(), 100) {
+		longest = max(longest, len(xs))
+	}
+	assert.Greater(t, longest, 20, "repeats are removed, not whole slices")
+}
+
+func sample[T any](g proptest.Gen[T], n int) []T {
+	r := rand.New(rand.NewSource(1))
+	out := make([]T, n)
+	for i := range out {
+		out[i] = g(r, i+1).Value
+	}
+	return out
+}
+
+func TestShrinkEven(t *testing.T) {
+	assert.Equal(t, []int{0, 6, 8}, ShrinkEven(10))
+	assert.Equal(t, []int{0, -4}, ShrinkEven(-6))
+	assert.Empty(t, ShrinkEven(0))
+	proptest.Check(t, Evens(), func(v int) bool {
+		for _, s := range ShrinkEven(v) {
+			if s%2 != 0 || s == v {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestEvens(t *testing.T) {
+	proptest.Check(t, Evens(), func(v int) bool { return v%2 == 0 })
+
+	f, failed := proptest.Find(rand.New(rand.NewSource(1)), proptest.Config{}, Evens(), func(v int) bool { return v < 10 })
+	require.True(t, failed)
+	assert.Equal(t, 10, f.Value, "the smallest even counterexample")
+	assert.Less(t, f.Shrinks, 20, "shrinking ends on its own")
+}
@@ -0,0 +1,130 @@
+// Package examples demonstrates tests that run in parallel without
+// tripping over each other: state moved out of package variables into
+// values that each test makes for itself, temporary files in directories
+// of their own, and the go test flags that shake out tests that depend on
+// one another.
+//
+// This file shows:
+// - Package-level state, which every test in the package shares
+// - Moving the state into a struct, so that each test makes its own
+// - Passing dependencies such as the clock in, instead of reaching for globals
+// - Returning a copy of an internal slice, so that callers cannot change it
+// - A default instance for programs that need only one, as a thin wrapper
+package examples
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// The counts of CountGlobal are package-level state: every caller shares
+// them, including every test in the package.
+var (
+	globalMu   sync.Mutex
+	globalHits = make(map[string]int)
+)
+
+// CountGlobal records a hit on path in the package's counts, and returns
+// how many there have been.
+func CountGlobal(path string) int {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	globalHits[path]++
+	return globalHits[path]
+}
+
+// Counter counts the hits on each path within a sliding window. All of
+// its state is in its fields, so two Counters know nothing of each other.
+type Counter struct {
+	mu     sync.Mutex
+	window time.Duration
+	now    func() time.Time // time.Now, or a fake clock in tests
+	hits   map[string][]time.Time
+}
+
+// NewCounter returns a Counter that counts the hits of the last window,
+// reading the time from now.
+func NewCounter(window time.Duration, now func() time.Time) *Counter {
+	return &Counter{window: window, now: now, hits: make(map[string][]time.Time)}
+}
+
+// Hit records a hit on path, and returns the number of hits on it within
+// the window.
+func (c *Counter) Hit(path string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	recent := c.hits[path][:0]
+	for _, t := range c.hits[path] {
+		if now.Sub(t) < c.window {
+			recent = append(recent, t)
+		}
+	}
+	c.hits[path] = append(recent, now)
+	return len(c.hits[path])
+}
+
+// Paths returns the paths hit so far, sorted. The slice is the caller's
+// own: changing it does not change the Counter.
+func (c *Counter) Paths() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	paths := make([]string, 0, len(c.hits))
+	for p := range c.hits {
+		paths = append(paths, p)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// Default is the Counter that Hit uses, for programs that need only one.
+// Tests make Counters of their own instead.
+var Default = NewCounter(time.Minute, time.Now)
+
+// Hit records a hit on path in Default.
+func Hit(path string) int { return Default.Hit(path) }
+
+// FakeClock is a clock that moves only when told to, safe to share
+// between goroutines.
+type FakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+// Now returns the clock's time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+// Advance moves the clock on by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+// DemonstrateState shows two callers sharing package-level counts, and
+// two Counters that do not.
+func DemonstrateState() {
+	fmt.Println("=== Package State vs Values ===")
+	// Two "tests" that each expect to see their first hit.
+	fmt.Println("first test, global:", CountGlobal("/home"))
+	fmt.Println("second test, global:", CountGlobal("/home"), "(sees the first test's hit)")
+
+	clock := &FakeClock{t: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	a := NewCounter(time.Minute, clock.Now)
+	b := NewCounter(time.Minute, clock.Now)
+	fmt.Println("first test, own counter:", a.Hit("/home"))
+	fmt.Println("second test, own counter:", b.Hit("/home"))
+
+	a.Hit("/about")
+	clock.Advance(90 * time.Second)
+	fmt.Println("after 90s, within the minute:", a.Hit("/home"))
+	paths := a.Paths()
+	paths[0] = "/changed"
+	fmt.Println("paths:", a.Paths(), "(a caller's change did not stick)")
+}
@@ -0,0 +1,51 @@
+package examples
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateState(t *testing.T) {
+	DemonstrateState()
+}
+
+// Each test makes its own Counter and clock, so the tests can run in
+// parallel, and in any order.
+
+func TestCounterWindow(t *testing.T) {
+	t.Parallel()
+	clock := &FakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c := NewCounter(time.Minute, clock.Now)
+	assert.Equal(t, 1, c.Hit("/"))
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, 2, c.Hit("/"))
+	clock.Advance(45 * time.Second)
+	assert.Equal(t, 2, c.Hit("/"), "the first hit is over a minute old")
+}
+
+func TestCounterPaths(t *testing.T) {
+	t.Parallel()
+	c := NewCounter(time.Minute, time.Now)
+	c.Hit("/b")
+	c.Hit("/a")
+	paths := c.Paths()
+	assert.Equal(t, []string{"/a", "/b"}, paths)
+	paths[0] = "/x"
+	assert.Equal(t, []string{"/a", "/b"}, c.Paths())
+}
+
+func TestCountersAreIndependent(t *testing.T) {
+	t.Parallel()
+	for _, path := range []string{"/a", "/b", "/c"} {
+		path := path
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			c := NewCounter(time.Minute, time.Now)
+			for i := 1; i <= 10; i++ {
+				assert.Equal(t, i, c.Hit(path))
+			}
+		})
+	}
+}
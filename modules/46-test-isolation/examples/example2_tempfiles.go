@@ -0,0 +1,118 @@
+package examples
+
+// This file shows:
+// - A file cache that is given its directory, rather than choosing one
+// - Writing a file atomically: a temporary file in the same directory, then os.Rename
+// - os.CreateTemp and os.MkdirTemp, which pick names that no one else is using
+// - Why a fixed path under os.TempDir collides between tests, and between runs
+// - Keys checked before they become file names, so that "../x" stays in the directory
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrKey is returned for a key that cannot be a file name.
+var ErrKey = errors.New("invalid cache key")
+
+// FileCache keeps one file per key in a directory.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache returns a cache in dir, creating it if need be.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+func (c *FileCache) path(key string) (string, error) {
+	if key == "" || key != filepath.Base(key) || strings.HasPrefix(key, ".") {
+		return "", fmt.Errorf("%w: %q", ErrKey, key)
+	}
+	return filepath.Join(c.dir, key), nil
+}
+
+// Put stores data under key. It writes a temporary file next to the final
+// one and renames it into place, so that a reader sees the old data or
+// the new, never half of it. The temporary file's name is unique, so
+// concurrent Puts never write to the same file; and it is in the same
+// directory, since a rename cannot move a file between file systems.
+func (c *FileCache) Put(key string, data []byte) error {
+	path, err := c.path(key)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(c.dir, "."+key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) // fails harmlessly once renamed
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Get returns the data stored under key, and false if there is none.
+func (c *FileCache) Get(key string) ([]byte, bool, error) {
+	path, err := c.path(key)
+	if err != nil {
+		return nil, false, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	return data, err == nil, err
+}
+
+// DemonstrateTempFiles writes to two caches concurrently, each in a
+// directory of its own.
+func DemonstrateTempFiles() {
+	fmt.Println("=== Temporary Files ===")
+	root, err := os.MkdirTemp("", "cache-demo-*")
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	defer os.RemoveAll(root)
+	fmt.Println("a fixed path, shared by every run:", filepath.Join(os.TempDir(), "cache"))
+	fmt.Println("os.MkdirTemp picks a new one:", strings.HasPrefix(filepath.Base(root), "cache-demo-"))
+
+	var wg sync.WaitGroup
+	for _, name := range []string{"a", "b"} {
+		c, err := NewFileCache(filepath.Join(root, name))
+		if err != nil {
+			fmt.Println("error:", err)
+			return
+		}
+		greeting := []byte("hello from " + name)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = c.Put("greeting", greeting)
+			}()
+		}
+	}
+	wg.Wait()
+	for _, name := range []string{"a", "b"} {
+		c, _ := NewFileCache(filepath.Join(root, name))
+		data, _, _ := c.Get("greeting")
+		entries, _ := os.ReadDir(filepath.Join(root, name))
+		fmt.Printf("cache %s: %q, %d file(s), no temporary files left\n", name, data, len(entries))
+	}
+	_, err = (&FileCache{dir: root}).path("../escape")
+	fmt.Println("bad key:", err)
+}
@@ -0,0 +1,65 @@
+package examples
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateTempFiles(t *testing.T) {
+	DemonstrateTempFiles()
+}
+
+// newCache is a fixture: a cache in a directory of the test's own, which
+// the testing package removes when the test ends.
+func newCache(t *testing.T) *FileCache {
+	t.Helper()
+	c, err := NewFileCache(t.TempDir())
+	require.NoError(t, err)
+	return c
+}
+
+func TestFileCachePutGet(t *testing.T) {
+	t.Parallel()
+	c := newCache(t)
+	_, ok, err := c.Get("k")
+	require.NoError(t, err)
+	assert.False(t, ok, "a new cache is empty")
+	require.NoError(t, c.Put("k", []byte("v1")))
+	require.NoError(t, c.Put("k", []byte("v2")))
+	data, ok, err := c.Get("k")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "v2", string(data))
+}
+
+func TestFileCacheConcurrentPuts(t *testing.T) {
+	t.Parallel()
+	c := newCache(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, c.Put("k", []byte(fmt.Sprint(i))))
+		}(i)
+	}
+	wg.Wait()
+	entries, err := os.ReadDir(c.dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "no temporary files left behind")
+	assert.Equal(t, "k", entries[0].Name())
+}
+
+func TestFileCacheKeys(t *testing.T) {
+	t.Parallel()
+	c := newCache(t)
+	for _, key := range []string{"", ".", "..", "../x", "a/b", filepath.Join("a", "b")} {
+		assert.ErrorIs(t, c.Put(key, nil), ErrKey, key)
+	}
+}
@@ -0,0 +1,112 @@
+package examples
+
+// This file shows:
+// - How go test -shuffle=on runs tests in a random order, and prints the seed to replay it
+// - Running a suite in many orders, to find the tests that depend on one another
+// - How -parallel caps the parallel tests running at once, however many call t.Parallel
+// - Why a test that passes alone but fails in a suite is sharing something
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+)
+
+// Case is one test of a suite.
+type Case struct {
+	Name string
+	Run  func() error
+}
+
+// Shuffled runs the cases in the order that seed shuffles them into, as
+// go test -shuffle=seed does, and returns that order and the names of the
+// cases that failed.
+func Shuffled(cases []Case, seed int64) (order, failed []string) {
+	shuffled := append([]Case(nil), cases...)
+	rand.New(rand.NewSource(seed)).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	for _, c := range shuffled {
+		order = append(order, c.Name)
+		if err := c.Run(); err != nil {
+			failed = append(failed, c.Name)
+		}
+	}
+	return order, failed
+}
+
+// FindOrderDependence runs the suite made by newSuite in up to tries
+// shuffled orders, each with a fresh suite, and returns the first seed
+// for which a case fails, and false if none does.
+func FindOrderDependence(newSuite func() []Case, tries int) (int64, bool) {
+	for seed := int64(1); seed <= int64(tries); seed++ {
+		if _, failed := Shuffled(newSuite(), seed); len(failed) > 0 {
+			return seed, true
+		}
+	}
+	return 0, false
+}
+
+// RunLimited runs fs concurrently with at most n at once, as go test
+// -parallel=n runs parallel tests, and returns the most that ran at once.
+func RunLimited(fs []func(), n int) int {
+	sem := make(chan struct{}, n)
+	var running, most atomic.Int32
+	var wg sync.WaitGroup
+	for _, f := range fs {
+		wg.Add(1)
+		go func(f func()) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			now := running.Add(1)
+			for {
+				m := most.Load()
+				if now <= m || most.CompareAndSwap(m, now) {
+					break
+				}
+			}
+			f()
+			running.Add(-1)
+		}(f)
+	}
+	wg.Wait()
+	return int(most.Load())
+}
+
+// leakySuite is two cases that share a map: the second passes only if
+// the first has not run yet.
+func leakySuite() []Case {
+	users := map[string]bool{}
+	return []Case{
+		{"TestCreate", func() error {
+			users["ada"] = true
+			return nil
+		}},
+		{"TestEmpty", func() error {
+			if len(users) != 0 {
+				return fmt.Errorf("%d users left over", len(users))
+			}
+			return nil
+		}},
+	}
+}
+
+// DemonstrateOrder finds the seed that makes a leaky suite fail.
+func DemonstrateOrder() {
+	fmt.Println("=== Test Order ===")
+	for _, seed := range []int64{1, 2, 3} {
+		order, failed := Shuffled(leakySuite(), seed)
+		fmt.Printf("-shuffle=%d: %v, failed: %v\n", seed, order, failed)
+	}
+	if seed, ok := FindOrderDependence(leakySuite, 10); ok {
+		fmt.Printf("replay the failure with go test -shuffle=%d\n", seed)
+	}
+
+	fs := make([]func(), 20)
+	for i := range fs {
+		fs[i] = func() {}
+	}
+	fmt.Println("at most 4 at once with -parallel=4:", RunLimited(fs, 4) <= 4)
+}
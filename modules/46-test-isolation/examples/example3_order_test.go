@@ -0,0 +1,60 @@
+package examples
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateOrder(t *testing.T) {
+	DemonstrateOrder()
+}
+
+func TestShuffled(t *testing.T) {
+	t.Parallel()
+	var ran []string
+	cases := []Case{
+		{"A", func() error { ran = append(ran, "A"); return nil }},
+		{"B", func() error { ran = append(ran, "B"); return errors.New("fails") }},
+		{"C", func() error { ran = append(ran, "C"); return nil }},
+	}
+	order, failed := Shuffled(cases, 7)
+	assert.Equal(t, ran, order)
+	assert.ElementsMatch(t, []string{"A", "B", "C"}, order)
+	assert.Equal(t, []string{"B"}, failed)
+	again, _ := Shuffled(cases, 7)
+	assert.Equal(t, order, again, "the same seed gives the same order")
+}
+
+func TestFindOrderDependence(t *testing.T) {
+	t.Parallel()
+	seed, ok := FindOrderDependence(leakySuite, 10)
+	assert.True(t, ok)
+	_, failed := Shuffled(leakySuite(), seed)
+	assert.Equal(t, []string{"TestEmpty"}, failed)
+
+	independent := func() []Case {
+		return []Case{{"A", func() error { return nil }}, {"B", func() error { return nil }}}
+	}
+	_, ok = FindOrderDependence(independent, 10)
+	assert.False(t, ok)
+}
+
+func TestRunLimited(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	fs := make([]func(), 12)
+	for i := range fs {
+		fs[i] = func() {
+			calls.Add(1)
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	most := RunLimited(fs, 3)
+	assert.Equal(t, int32(12), calls.Load())
+	assert.LessOrEqual(t, most, 3)
+	assert.GreaterOrEqual(t, most, 1)
+}
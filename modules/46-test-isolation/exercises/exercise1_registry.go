@@ -0,0 +1,92 @@
+package exercises
+
+// EXERCISE: Fix a plugin registry whose tests see each other's plugins.
+// Each test makes a NewRegistry and expects it to be empty, and to count
+// job IDs from 1. But the plugins and the last ID are package variables,
+// so every Registry shares them: a test fails when another registered the
+// same name first, or took ID 1, and which ones fail depends on the order
+// the tests run in. Names hands out the slice it keeps, too, so a caller
+// that changes it changes the registry. learngo runs these tests in
+// parallel and shuffled, as module.json asks, until each has state of its
+// own.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+var (
+	// ErrDuplicate is returned when a name is registered twice.
+	ErrDuplicate = errors.New("plugin already registered")
+	// ErrUnknown is returned for a name that is not registered.
+	ErrUnknown = errors.New("unknown plugin")
+)
+
+// Plugin transforms its input.
+type Plugin func(input string) string
+
+// BUG: Every Registry shares these, so no NewRegistry starts empty
+var (
+	mu      sync.Mutex
+	plugins = make(map[string]Plugin)
+	names   []string
+	lastID  int
+)
+
+// Registry holds plugins by name, and gives out IDs for the jobs that run
+// them.
+type Registry struct{}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds p under name, or returns ErrDuplicate if name is taken.
+func (r *Registry) Register(name string, p Plugin) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := plugins[name]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicate, name)
+	}
+	plugins[name] = p
+	names = append(names, name)
+	slices.Sort(names)
+	return nil
+}
+
+// Lookup returns the plugin registered under name.
+func (r *Registry) Lookup(name string) (Plugin, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	p, ok := plugins[name]
+	return p, ok
+}
+
+// Names returns the registered names, sorted. The slice is the caller's to
+// keep and change.
+func (r *Registry) Names() []string {
+	mu.Lock()
+	defer mu.Unlock()
+	return names // BUG: The registry's own slice, which the caller can change
+}
+
+// NextID returns the ID of the next job: 1, then 2, and so on.
+func (r *Registry) NextID() int {
+	mu.Lock()
+	defer mu.Unlock()
+	lastID++
+	return lastID
+}
+
+// Run runs the plugin registered under name on input, as job NextID.
+func (r *Registry) Run(name, input string) (id int, output string, err error) {
+	p, ok := r.Lookup(name)
+	if !ok {
+		return 0, "", fmt.Errorf("%w: %q", ErrUnknown, name)
+	}
+	return r.NextID(), p(input), nil
+}
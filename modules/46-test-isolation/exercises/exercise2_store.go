@@ -0,0 +1,104 @@
+package exercises
+
+// EXERCISE: Fix a file store whose tests trip over each other's files.
+// Each test gets a Store from NewTestStore and expects it to be empty. But
+// NewTestStore opens every store in the same directory, which outlives the
+// test and the run, so a test finds the keys another one set, in this run
+// or the last. And save writes every store's data to one temporary file
+// under os.TempDir before renaming it into place, so two stores saving at
+// once can each rename the other's data, and a rename fails outright when
+// the temporary directory is on another file system, or missing. Give each
+// test, and each save, a file of its own.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// FileName is the name of the file a Store keeps its data in.
+const FileName = "store.json"
+
+// Store keeps string values by key in a JSON file, and rewrites the file
+// on every Set.
+type Store struct {
+	mu   sync.Mutex
+	dir  string
+	data map[string]string
+}
+
+// Open opens the store in dir, reading the data saved there before, if
+// any.
+func Open(dir string) (*Store, error) {
+	s := &Store{dir: dir, data: make(map[string]string)}
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Dir returns the directory the store is in.
+func (s *Store) Dir() string { return s.dir }
+
+// Get returns the value of key.
+func (s *Store) Get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.data[key]
+	return v, ok
+}
+
+// Len returns the number of keys.
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.data)
+}
+
+// Set sets key to value, and saves the store.
+func (s *Store) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = value
+	return s.save()
+}
+
+// save writes the data to a temporary file and renames it over the
+// store's file, so that a crash leaves the old file or the new one.
+func (s *Store) save() error {
+	data, err := json.Marshal(s.data)
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(os.TempDir(), FileName+".tmp") // BUG: One path for every store, maybe on another file system
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(s.dir, FileName))
+}
+
+// NewTestStore is a test fixture: it returns an empty Store in a directory
+// that belongs to the test alone, and is removed when it ends.
+func NewTestStore(tb testing.TB) *Store {
+	tb.Helper()
+	dir := filepath.Join(os.TempDir(), "learngo-store-test") // BUG: Every test, and every run, shares this directory
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		tb.Fatal(err)
+	}
+	s, err := Open(dir)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return s
+}
@@ -0,0 +1,86 @@
+package solutions
+
+// SOLUTION: Fix a plugin registry whose tests see each other's plugins.
+// The plugins and the last ID move from package variables into the
+// Registry, so that every NewRegistry starts empty and counts IDs from 1,
+// and Names builds a new slice for each caller instead of handing out the
+// one it keeps.
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+var (
+	// ErrDuplicate is returned when a name is registered twice.
+	ErrDuplicate = errors.New("plugin already registered")
+	// ErrUnknown is returned for a name that is not registered.
+	ErrUnknown = errors.New("unknown plugin")
+)
+
+// Plugin transforms its input.
+type Plugin func(input string) string
+
+// Registry holds plugins by name, and gives out IDs for the jobs that run
+// them.
+type Registry struct {
+	mu      sync.Mutex
+	plugins map[string]Plugin // Fixed: Each Registry has plugins of its own
+	lastID  int               // Fixed: and IDs of its own
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{plugins: make(map[string]Plugin)}
+}
+
+// Register adds p under name, or returns ErrDuplicate if name is taken.
+func (r *Registry) Register(name string, p Plugin) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.plugins[name]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicate, name)
+	}
+	r.plugins[name] = p
+	return nil
+}
+
+// Lookup returns the plugin registered under name.
+func (r *Registry) Lookup(name string) (Plugin, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.plugins[name]
+	return p, ok
+}
+
+// Names returns the registered names, sorted. The slice is the caller's to
+// keep and change.
+func (r *Registry) Names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, 0, len(r.plugins)) // Fixed: A new slice for every call
+	for name := range r.plugins {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// NextID returns the ID of the next job: 1, then 2, and so on.
+func (r *Registry) NextID() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastID++
+	return r.lastID
+}
+
+// Run runs the plugin registered under name on input, as job NextID.
+func (r *Registry) Run(name, input string) (id int, output string, err error) {
+	p, ok := r.Lookup(name)
+	if !ok {
+		return 0, "", fmt.Errorf("%w: %q", ErrUnknown, name)
+	}
+	return r.NextID(), p(input), nil
+}
@@ -0,0 +1,88 @@
+package solutions
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Every test here calls t.Parallel, and learngo runs them eight at a time
+// and in a random order: each must make the state it needs for itself.
+
+func upper(s string) string { return strings.ToUpper(s) }
+
+func TestRegisterLookup(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	require.NoError(t, r.Register("upper", upper))
+	p, ok := r.Lookup("upper")
+	require.True(t, ok)
+	assert.Equal(t, "GO", p("go"))
+	_, ok = r.Lookup("lower")
+	assert.False(t, ok)
+}
+
+func TestNewRegistryIsEmpty(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	assert.Empty(t, r.Names())
+	require.NoError(t, r.Register("upper", upper), "no other test's plugins")
+	assert.Equal(t, []string{"upper"}, r.Names())
+}
+
+func TestDuplicate(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	require.NoError(t, r.Register("trim", strings.TrimSpace))
+	assert.ErrorIs(t, r.Register("trim", strings.TrimSpace), ErrDuplicate)
+	assert.NoError(t, NewRegistry().Register("trim", strings.TrimSpace), "another registry")
+}
+
+func TestNextID(t *testing.T) {
+	t.Parallel()
+	a, b := NewRegistry(), NewRegistry()
+	assert.Equal(t, 1, a.NextID())
+	assert.Equal(t, 2, a.NextID())
+	assert.Equal(t, 1, b.NextID(), "IDs count per registry")
+
+	require.NoError(t, b.Register("upper", upper))
+	id, out, err := b.Run("upper", "id")
+	require.NoError(t, err)
+	assert.Equal(t, 2, id)
+	assert.Equal(t, "ID", out)
+	_, _, err = b.Run("lower", "id")
+	assert.ErrorIs(t, err, ErrUnknown)
+}
+
+func TestNamesCopy(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	require.NoError(t, r.Register("b", upper))
+	require.NoError(t, r.Register("a", upper))
+	names := r.Names()
+	require.Equal(t, []string{"a", "b"}, names)
+	names[0] = "changed"
+	assert.Equal(t, []string{"a", "b"}, r.Names(), "a caller's change does not stick")
+}
+
+func TestConcurrentRegistries(t *testing.T) {
+	t.Parallel()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r := NewRegistry()
+			for j := 0; j < 20; j++ {
+				assert.NoError(t, r.Register(fmt.Sprintf("plugin%d", j), upper), "registry %d", i)
+			}
+			assert.Len(t, r.Names(), 20)
+			assert.Equal(t, 1, r.NextID())
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,105 @@
+package solutions
+
+// SOLUTION: Fix a file store whose tests trip over each other's files.
+// save creates its temporary file with os.CreateTemp in the store's own
+// directory, rather than at one fixed path under os.TempDir that every
+// store shares, and NewTestStore opens the store in t.TempDir(), which is
+// the test's own and is removed after it, rather than in a directory that
+// every test, and every run, shares.
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// FileName is the name of the file a Store keeps its data in.
+const FileName = "store.json"
+
+// Store keeps string values by key in a JSON file, and rewrites the file
+// on every Set.
+type Store struct {
+	mu   sync.Mutex
+	dir  string
+	data map[string]string
+}
+
+// Open opens the store in dir, reading the data saved there before, if
+// any.
+func Open(dir string) (*Store, error) {
+	s := &Store{dir: dir, data: make(map[string]string)}
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Dir returns the directory the store is in.
+func (s *Store) Dir() string { return s.dir }
+
+// Get returns the value of key.
+func (s *Store) Get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.data[key]
+	return v, ok
+}
+
+// Len returns the number of keys.
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.data)
+}
+
+// Set sets key to value, and saves the store.
+func (s *Store) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = value
+	return s.save()
+}
+
+// save writes the data to a temporary file and renames it over the
+// store's file, so that a crash leaves the old file or the new one.
+func (s *Store) save() error {
+	data, err := json.Marshal(s.data)
+	if err != nil {
+		return err
+	}
+	// Fixed: A file of its own, next to the store's, where a rename can reach
+	f, err := os.CreateTemp(s.dir, FileName+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(s.dir, FileName))
+}
+
+// NewTestStore is a test fixture: it returns an empty Store in a directory
+// that belongs to the test alone, and is removed when it ends.
+func NewTestStore(tb testing.TB) *Store {
+	tb.Helper()
+	s, err := Open(tb.TempDir()) // Fixed: The test's own directory, removed after it
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return s
+}
@@ -0,0 +1,93 @@
+package solutions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTestStoreIsEmpty(t *testing.T) {
+	t.Parallel()
+	s := NewTestStore(t)
+	assert.Zero(t, s.Len(), "no keys from other tests, or earlier runs")
+	require.NoError(t, s.Set("greeting", "hello"))
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestNewTestStoreOwnDir(t *testing.T) {
+	t.Parallel()
+	a, b := NewTestStore(t), NewTestStore(t)
+	assert.NotEqual(t, a.Dir(), b.Dir())
+	require.NoError(t, a.Set("k", "a"))
+	_, ok := b.Get("k")
+	assert.False(t, ok)
+}
+
+func TestSetPersists(t *testing.T) {
+	t.Parallel()
+	r := seed.Rand(t, 1)
+	s := NewTestStore(t)
+	want := make(map[string]string)
+	for i := 0; i < 5; i++ {
+		k, v := fmt.Sprintf("key%d", r.Intn(100)), fmt.Sprint(r.Int())
+		want[k] = v
+		require.NoError(t, s.Set(k, v))
+	}
+	reopened, err := Open(s.Dir())
+	require.NoError(t, err)
+	assert.Equal(t, len(want), reopened.Len())
+	for k, v := range want {
+		got, _ := reopened.Get(k)
+		assert.Equal(t, v, got, k)
+	}
+	entries, err := os.ReadDir(s.Dir())
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "only %s, with no temporary files left", FileName)
+}
+
+// TestSetWithoutTempDir is the one test that is not parallel: t.Setenv
+// changes the whole process's environment, so it refuses to run in a
+// parallel test. The temporary directory is not always on the same file
+// system as the store, which os.Rename cannot cross, and here it is not
+// there at all.
+func TestSetWithoutTempDir(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(t.TempDir(), "missing")
+	for _, key := range []string{"TMPDIR", "TMP", "TEMP"} {
+		t.Setenv(key, missing)
+	}
+	s, err := Open(dir)
+	require.NoError(t, err)
+	assert.NoError(t, s.Set("k", "v"))
+}
+
+func TestConcurrentStores(t *testing.T) {
+	t.Parallel()
+	stores := make([]*Store, 8)
+	for i := range stores {
+		stores[i] = NewTestStore(t)
+	}
+	var wg sync.WaitGroup
+	for i, s := range stores {
+		wg.Add(1)
+		go func(i int, s *Store) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				assert.NoError(t, s.Set(fmt.Sprint(j), fmt.Sprint(i)))
+			}
+		}(i, s)
+	}
+	wg.Wait()
+	for i, s := range stores {
+		reopened, err := Open(s.Dir())
+		require.NoError(t, err)
+		v, _ := reopened.Get("19")
+		assert.Equal(t, fmt.Sprint(i), v, "store %d holds its own data", i)
+	}
+}
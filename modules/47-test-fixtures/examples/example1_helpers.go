@@ -0,0 +1,195 @@
+// Package examples demonstrates the setup and teardown of tests: helpers
+// that report failures at the line that called them, fixtures that build
+// what a test needs and clean it up afterwards with t.Cleanup and
+// t.TempDir, and tables of cases that every test can share.
+//
+// This file shows:
+// - A Scene of named shapes, the structs and Shape interface of module 02, to test
+// - Assertion helpers that call t.Helper, so failures point at the caller
+// - A fixture that builds a Scene and stops the test with Fatal if it cannot
+// - Error for checks a test can go on after, Fatal for setup it cannot
+// - Recorder, a testing.TB that records what helpers report instead of failing
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+var (
+	// ErrInvalidShape is returned for a shape with no area.
+	ErrInvalidShape = errors.New("invalid shape")
+	// ErrDuplicate is returned when a name is added twice.
+	ErrDuplicate = errors.New("duplicate shape name")
+)
+
+// Scene is a set of named shapes.
+type Scene struct {
+	names  []string
+	shapes map[string]geometry.Shape
+}
+
+// NewScene returns an empty Scene.
+func NewScene() *Scene {
+	return &Scene{shapes: make(map[string]geometry.Shape)}
+}
+
+// Add adds sh under name. It returns ErrInvalidShape if sh has no area,
+// and ErrDuplicate if name is taken.
+func (s *Scene) Add(name string, sh geometry.Shape) error {
+	if sh == nil || !(sh.Area() > 0) {
+		return fmt.Errorf("%w: %s %v", ErrInvalidShape, name, sh)
+	}
+	if _, ok := s.shapes[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicate, name)
+	}
+	s.names = append(s.names, name)
+	s.shapes[name] = sh
+	return nil
+}
+
+// Shape returns the shape added under name.
+func (s *Scene) Shape(name string) (geometry.Shape, bool) {
+	sh, ok := s.shapes[name]
+	return sh, ok
+}
+
+// Names returns the names in the order they were added.
+func (s *Scene) Names() []string {
+	return append([]string(nil), s.names...)
+}
+
+// TotalArea returns the sum of the shapes' areas.
+func (s *Scene) TotalArea() float64 {
+	total := 0.0
+	for _, sh := range s.shapes {
+		total += sh.Area()
+	}
+	return total
+}
+
+// AssertShape checks that the shape has the given area and perimeter, to
+// within 1e-9. It calls tb.Helper, so that a failure is reported at the
+// line of the test that called it, not at the line in here; and it uses
+// Errorf, so that the test goes on to report the perimeter too.
+func AssertShape(tb testing.TB, sh geometry.Shape, area, perimeter float64) {
+	tb.Helper()
+	if got := sh.Area(); math.Abs(got-area) > 1e-9 {
+		tb.Errorf("%v: area = %g, want %g", sh, got, area)
+	}
+	if got := sh.Perimeter(); math.Abs(got-perimeter) > 1e-9 {
+		tb.Errorf("%v: perimeter = %g, want %g", sh, got, perimeter)
+	}
+}
+
+// NamedShape is a shape and the name to add it to a Scene under.
+type NamedShape struct {
+	Name  string
+	Shape geometry.Shape
+}
+
+// NewTestScene is a fixture: a Scene with the given shapes. A test cannot
+// go on without its fixture, so a shape it cannot add stops the test with
+// Fatalf, instead of leaving the test to fail later for a reason that is
+// hard to see.
+func NewTestScene(tb testing.TB, shapes ...NamedShape) *Scene {
+	tb.Helper()
+	s := NewScene()
+	for _, ns := range shapes {
+		if err := s.Add(ns.Name, ns.Shape); err != nil {
+			tb.Fatalf("NewTestScene: %v", err)
+		}
+	}
+	return s
+}
+
+// Recorder is a testing.TB for showing what helpers and fixtures do
+// without failing a real test: it records their messages, and their
+// cleanups, which it runs when Run ends. Methods it does not define panic.
+type Recorder struct {
+	testing.TB
+	Helpers  int      // the calls to Helper
+	Messages []string // what Error, Errorf, Fatal, and Fatalf reported
+	failed   bool
+	cleanups []func()
+}
+
+// Failed reports whether anything failed.
+func (r *Recorder) Failed() bool { return r.failed }
+
+// Helper counts the call.
+func (r *Recorder) Helper() { r.Helpers++ }
+
+// Error records a failure.
+func (r *Recorder) Error(args ...any) {
+	r.failed = true
+	r.Messages = append(r.Messages, fmt.Sprint(args...))
+}
+
+// Errorf records a failure.
+func (r *Recorder) Errorf(format string, args ...any) { r.Error(fmt.Sprintf(format, args...)) }
+
+// Fatal records a failure, and ends the function passed to Run.
+func (r *Recorder) Fatal(args ...any) {
+	r.Error(args...)
+	runtime.Goexit()
+}
+
+// Fatalf records a failure, and ends the function passed to Run.
+func (r *Recorder) Fatalf(format string, args ...any) { r.Fatal(fmt.Sprintf(format, args...)) }
+
+// Cleanup registers f to run when Run ends, before the functions
+// registered earlier.
+func (r *Recorder) Cleanup(f func()) { r.cleanups = append(r.cleanups, f) }
+
+// Run calls f with r in a goroutine of its own, as the testing package
+// does, so that Fatal can end it; then runs the cleanups, last first.
+func (r *Recorder) Run(f func(tb testing.TB)) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f(r)
+	}()
+	<-done
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+	r.cleanups = nil
+}
+
+// DemonstrateHelpers runs a helper and a fixture against a Recorder, and
+// prints what they report.
+func DemonstrateHelpers() {
+	fmt.Println("=== Helpers ===")
+	r := &Recorder{}
+	r.Run(func(tb testing.TB) {
+		AssertShape(tb, geometry.Rectangle{Width: 2, Height: 3}, 6, 10)
+		AssertShape(tb, geometry.Rectangle{Width: 2, Height: 3}, 5, 12)
+	})
+	fmt.Printf("Helper called %d times; failures:\n", r.Helpers)
+	for _, m := range r.Messages {
+		fmt.Println(" ", m)
+	}
+
+	r = &Recorder{}
+	var reached bool
+	r.Run(func(tb testing.TB) {
+		NewTestScene(tb,
+			NamedShape{"unit", geometry.Circle{Radius: 1}},
+			NamedShape{"flat", geometry.Rectangle{Width: 2}},
+		)
+		reached = true
+	})
+	fmt.Println("fixture failed:", r.Messages)
+	fmt.Println("the test went on after Fatal:", reached)
+
+	s := NewScene()
+	_ = s.Add("b", geometry.Rectangle{Width: 1, Height: 1})
+	_ = s.Add("a", geometry.Rectangle{Width: 2, Height: 2})
+	fmt.Printf("scene %v, total area %g\n", s.Names(), s.TotalArea())
+}
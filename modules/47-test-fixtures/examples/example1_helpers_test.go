@@ -0,0 +1,49 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateHelpers(t *testing.T) {
+	DemonstrateHelpers()
+}
+
+func TestSceneAdd(t *testing.T) {
+	s := NewScene()
+	require.NoError(t, s.Add("square", geometry.Rectangle{Width: 2, Height: 2}))
+	assert.ErrorIs(t, s.Add("square", geometry.Circle{Radius: 1}), ErrDuplicate)
+	assert.ErrorIs(t, s.Add("flat", geometry.Rectangle{Width: 2}), ErrInvalidShape)
+	assert.ErrorIs(t, s.Add("nil", nil), ErrInvalidShape)
+	assert.Equal(t, []string{"square"}, s.Names())
+	assert.Equal(t, 4.0, s.TotalArea())
+}
+
+func TestAssertShapeReportsAtCaller(t *testing.T) {
+	r := &Recorder{}
+	r.Run(func(tb testing.TB) {
+		AssertShape(tb, geometry.Rectangle{Width: 1, Height: 1}, 2, 5)
+	})
+	assert.Equal(t, 1, r.Helpers)
+	assert.True(t, r.Failed())
+	assert.Len(t, r.Messages, 2, "Errorf lets the perimeter be checked as well")
+}
+
+func TestNewTestSceneStops(t *testing.T) {
+	r := &Recorder{}
+	reached := false
+	r.Run(func(tb testing.TB) {
+		NewTestScene(tb, NamedShape{"flat", geometry.Rectangle{Width: 1}})
+		reached = true
+	})
+	assert.True(t, r.Failed())
+	assert.False(t, reached, "Fatalf ends the test")
+	require.Len(t, r.Messages, 1)
+	assert.Contains(t, r.Messages[0], "flat")
+
+	s := NewTestScene(t, NamedShape{"unit", geometry.Circle{Radius: 1}})
+	assert.Equal(t, []string{"unit"}, s.Names())
+}
@@ -0,0 +1,155 @@
+package examples
+
+// This file shows:
+// - A Journal that writes lines to a file, for a fixture to open and close
+// - t.Cleanup, which runs after the test, and not when the helper returns as defer does
+// - Cleanups running last first, so that what was set up last is torn down first
+// - t.TempDir, whose directory is removed after the cleanups registered later
+// - Reporting an error from a cleanup, which a deferred Close would lose
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Journal appends lines to a file.
+type Journal struct {
+	path string
+	f    *os.File
+	w    *bufio.Writer
+}
+
+// OpenJournal opens the journal at path, creating the file if need be.
+func OpenJournal(path string) (*Journal, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{path: path, f: f, w: bufio.NewWriter(f)}, nil
+}
+
+// Path returns the journal's file.
+func (j *Journal) Path() string { return j.path }
+
+// Record appends a line. It is buffered until Close, or Flush.
+func (j *Journal) Record(format string, args ...any) error {
+	if j.f == nil {
+		return os.ErrClosed
+	}
+	_, err := fmt.Fprintf(j.w, format+"\n", args...)
+	return err
+}
+
+// Flush writes the buffered lines to the file.
+func (j *Journal) Flush() error {
+	if j.f == nil {
+		return os.ErrClosed
+	}
+	return j.w.Flush()
+}
+
+// Close flushes and closes the journal.
+func (j *Journal) Close() error {
+	if j.f == nil {
+		return os.ErrClosed
+	}
+	err := j.w.Flush()
+	if cerr := j.f.Close(); err == nil {
+		err = cerr
+	}
+	j.f = nil
+	return err
+}
+
+// ReadJournal returns the lines of the journal at path.
+func ReadJournal(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimSuffix(string(data), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
+
+// NewTestJournal is a fixture: a Journal in a directory of the test's own.
+// It is closed when the test ends, and a failure to close, which would
+// lose the buffered lines, fails the test. A defer j.Close() here would
+// close it when NewTestJournal returns, before the test could use it.
+func NewTestJournal(tb testing.TB) *Journal {
+	tb.Helper()
+	j, err := OpenJournal(filepath.Join(tb.TempDir(), "journal.log"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() {
+		if err := j.Close(); err != nil {
+			tb.Errorf("closing the journal: %v", err)
+		}
+	})
+	return j
+}
+
+// Env is the whole fixture of a test: a scene and a journal of what was
+// done to it, built from the smaller fixtures.
+type Env struct {
+	Scene   *Scene
+	Journal *Journal
+}
+
+// NewEnv returns an Env whose parts clean up after themselves.
+func NewEnv(tb testing.TB, shapes ...NamedShape) *Env {
+	tb.Helper()
+	return &Env{Scene: NewTestScene(tb, shapes...), Journal: NewTestJournal(tb)}
+}
+
+// tempDirRecorder is a Recorder whose TempDir removes the directory in a
+// cleanup, as the testing package's does.
+type tempDirRecorder struct {
+	*Recorder
+	log *[]string
+}
+
+func (r tempDirRecorder) TempDir() string {
+	dir, err := os.MkdirTemp("", "journal-demo-*")
+	if err != nil {
+		r.Fatal(err)
+	}
+	r.Cleanup(func() {
+		lines, _ := ReadJournal(filepath.Join(dir, "journal.log"))
+		*r.log = append(*r.log, fmt.Sprintf("remove the directory, once the journal is closed: %d lines", len(lines)))
+		os.RemoveAll(dir)
+	})
+	return dir
+}
+
+// DemonstrateCleanup builds a journal fixture and shows the order its
+// cleanups run in.
+func DemonstrateCleanup() {
+	fmt.Println("=== Cleanup ===")
+	var log []string
+	r := tempDirRecorder{Recorder: &Recorder{}, log: &log}
+	var path string
+	r.Run(func(tb testing.TB) {
+		tb.Cleanup(func() { log = append(log, "registered first, runs last") })
+		j := NewTestJournal(r)
+		path = j.Path()
+		tb.Cleanup(func() {
+			lines, _ := ReadJournal(path)
+			log = append(log, fmt.Sprintf("registered last, runs first: %d lines written, the rest buffered", len(lines)))
+		})
+		_ = j.Record("add %s", "circle")
+		_ = j.Record("add %s", "square")
+	})
+	for i, step := range log {
+		fmt.Printf("%d. %s\n", i+1, step)
+	}
+	_, err := os.Stat(path)
+	fmt.Println("the directory is gone:", os.IsNotExist(err))
+}
@@ -0,0 +1,49 @@
+package examples
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateCleanup(t *testing.T) {
+	DemonstrateCleanup()
+}
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "j.log")
+	j, err := OpenJournal(path)
+	require.NoError(t, err)
+	require.NoError(t, j.Record("add %s", "circle"))
+	lines, err := ReadJournal(path)
+	require.NoError(t, err)
+	assert.Empty(t, lines, "buffered")
+	require.NoError(t, j.Close())
+	lines, err = ReadJournal(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"add circle"}, lines)
+	assert.ErrorIs(t, j.Record("late"), os.ErrClosed)
+	assert.ErrorIs(t, j.Close(), os.ErrClosed)
+}
+
+func TestNewTestJournalCleansUp(t *testing.T) {
+	var j *Journal
+	t.Run("fixture", func(t *testing.T) {
+		j = NewTestJournal(t)
+		require.NoError(t, j.Record("open"), "still open after the fixture returns")
+		require.NoError(t, j.Flush())
+	})
+	assert.ErrorIs(t, j.Record("late"), os.ErrClosed, "closed when the subtest ended")
+	_, err := os.Stat(filepath.Dir(j.Path()))
+	assert.True(t, os.IsNotExist(err), "and its directory removed")
+}
+
+func TestNewEnv(t *testing.T) {
+	env := NewEnv(t, NamedShape{"square", geometry.Rectangle{Width: 1, Height: 1}})
+	assert.Equal(t, []string{"square"}, env.Scene.Names())
+	assert.NoError(t, env.Journal.Record("checked"))
+}
@@ -0,0 +1,105 @@
+package examples
+
+// This file shows:
+// - A table of cases returned by a function, so that each test gets a fresh copy
+// - One table shared by the tests of Area, Perimeter, and a Scene's total
+// - Running the cases as parallel subtests, with the loop variable copied first
+// - A fixture with options, so that each test says only what differs from the default
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// ShapeCase is a shape and the area and perimeter it must have.
+type ShapeCase struct {
+	Name            string
+	Shape           geometry.Shape
+	Area, Perimeter float64
+}
+
+// ShapeCases returns the shapes that the tests of this package share. It
+// builds a new slice on every call: a test that changes its cases does not
+// change anyone else's, as it would with a package-level slice.
+func ShapeCases() []ShapeCase {
+	return []ShapeCase{
+		{"unit circle", geometry.Circle{Radius: 1}, math.Pi, 2 * math.Pi},
+		{"square", geometry.Rectangle{Width: 2, Height: 2}, 4, 8},
+		{"wide rectangle", geometry.Rectangle{Origin: geometry.Point{X: -1, Y: 5}, Width: 10, Height: 0.5}, 5, 21},
+		{"right triangle", geometry.Triangle{C: geometry.Point{X: 3}, B: geometry.Point{Y: 4}}, 6, 12},
+	}
+}
+
+// RunShapeCases runs check on each case in a parallel subtest named after
+// it. The case is copied before the closure captures it: up to Go 1.21,
+// which this module's go.mod names, the loop variable is one variable for
+// the whole loop, and parallel subtests run only after the loop has ended,
+// so they would all see the last case.
+func RunShapeCases(t *testing.T, cases []ShapeCase, check func(t *testing.T, c ShapeCase)) {
+	t.Helper()
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+			check(t, c)
+		})
+	}
+}
+
+// SceneOption changes the Scene that BuildScene builds.
+type SceneOption func(cases []ShapeCase) []ShapeCase
+
+// Without leaves out the named case.
+func Without(name string) SceneOption {
+	return func(cases []ShapeCase) []ShapeCase {
+		var out []ShapeCase
+		for _, c := range cases {
+			if c.Name != name {
+				out = append(out, c)
+			}
+		}
+		return out
+	}
+}
+
+// With adds a case.
+func With(c ShapeCase) SceneOption {
+	return func(cases []ShapeCase) []ShapeCase { return append(cases, c) }
+}
+
+// BuildScene is a fixture: a Scene of ShapeCases, changed by opts. A test
+// that needs a scene with one more shape says With, and nothing else.
+func BuildScene(tb testing.TB, opts ...SceneOption) *Scene {
+	tb.Helper()
+	cases := ShapeCases()
+	for _, opt := range opts {
+		cases = opt(cases)
+	}
+	shapes := make([]NamedShape, len(cases))
+	for i, c := range cases {
+		shapes[i] = NamedShape{c.Name, c.Shape}
+	}
+	return NewTestScene(tb, shapes...)
+}
+
+// DemonstrateTables prints the shared cases, and scenes built from them.
+func DemonstrateTables() {
+	fmt.Println("=== Tables ===")
+	for _, c := range ShapeCases() {
+		fmt.Printf("%-15s area %6.3f, perimeter %6.3f\n", c.Name, c.Area, c.Perimeter)
+	}
+	cases := ShapeCases()
+	cases[0].Name = "changed"
+	fmt.Println("a changed copy leaves the next one alone:", ShapeCases()[0].Name)
+
+	r := &Recorder{}
+	r.Run(func(tb testing.TB) {
+		s := BuildScene(tb)
+		fmt.Printf("default scene: %d shapes, total area %.3f\n", len(s.Names()), s.TotalArea())
+		s = BuildScene(tb, Without("unit circle"), With(ShapeCase{Name: "tiny", Shape: geometry.Rectangle{Width: 0.5, Height: 0.5}}))
+		fmt.Printf("with options: %v\n", s.Names())
+	})
+}
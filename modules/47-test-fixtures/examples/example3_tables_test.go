@@ -0,0 +1,52 @@
+package examples
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateTables(t *testing.T) {
+	DemonstrateTables()
+}
+
+// The same table checks Area and Perimeter here, and the Scene fixture
+// below.
+
+func TestShapeCases(t *testing.T) {
+	RunShapeCases(t, ShapeCases(), func(t *testing.T, c ShapeCase) {
+		AssertShape(t, c.Shape, c.Area, c.Perimeter)
+	})
+}
+
+func TestRunShapeCasesRunsEach(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+	t.Run("all", func(t *testing.T) {
+		RunShapeCases(t, ShapeCases(), func(t *testing.T, c ShapeCase) {
+			mu.Lock()
+			defer mu.Unlock()
+			seen = append(seen, c.Name)
+		})
+	})
+	var want []string
+	for _, c := range ShapeCases() {
+		want = append(want, c.Name)
+	}
+	assert.ElementsMatch(t, want, seen)
+}
+
+func TestBuildScene(t *testing.T) {
+	total := 0.0
+	for _, c := range ShapeCases() {
+		total += c.Area
+	}
+	assert.InDelta(t, total, BuildScene(t).TotalArea(), 1e-9)
+
+	s := BuildScene(t, Without("square"), With(ShapeCase{Name: "big", Shape: geometry.Circle{Radius: 10}}))
+	_, ok := s.Shape("square")
+	assert.False(t, ok)
+	assert.Equal(t, "big", s.Names()[len(s.Names())-1])
+}
@@ -0,0 +1,107 @@
+package exercises
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a testing.TB that records what a helper reports instead of
+// failing the test.
+type recorder struct {
+	testing.TB
+	helpers  int
+	messages []string
+	fatal    bool
+	panicked any
+}
+
+func (r *recorder) Helper() { r.helpers++ }
+func (r *recorder) Errorf(format string, args ...any) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+func (r *recorder) Error(args ...any) { r.messages = append(r.messages, fmt.Sprint(args...)) }
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	r.fatal = true
+	runtime.Goexit()
+}
+func (r *recorder) Fatal(args ...any) {
+	r.Error(args...)
+	r.fatal = true
+	runtime.Goexit()
+}
+
+// run calls f with a new recorder in a goroutine of its own, so that
+// Fatal can end it as it ends a test.
+func run(f func(tb testing.TB)) *recorder {
+	r := &recorder{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() { r.panicked = recover() }()
+		f(r)
+	}()
+	<-done
+	return r
+}
+
+func sampleScene(t *testing.T) *Scene {
+	return NewTestScene(t,
+		NamedShape{"unit", geometry.Circle{Radius: 1}},
+		NamedShape{"square", geometry.Rectangle{Width: 2, Height: 2}},
+	)
+}
+
+func TestAssertTotalArea(t *testing.T) {
+	s := sampleScene(t)
+	AssertTotalArea(t, s, math.Pi+4)
+
+	r := run(func(tb testing.TB) { AssertTotalArea(tb, s, 1) })
+	require.Len(t, r.messages, 1)
+	assert.Contains(t, r.messages[0], "want 1")
+	assert.Positive(t, r.helpers, "a failure must be reported at the caller's line: call tb.Helper")
+}
+
+func TestAssertShape(t *testing.T) {
+	s := sampleScene(t)
+	AssertShape(t, s, "square", 4, 8)
+
+	r := run(func(tb testing.TB) { AssertShape(tb, s, "square", 5, 8) })
+	assert.Equal(t, []string{"square: area = 4, want 5"}, r.messages)
+	assert.False(t, r.fatal, "a wrong area still lets the perimeter be checked")
+
+	r = run(func(tb testing.TB) { AssertShape(tb, s, "triangle", 6, 12) })
+	assert.Nil(t, r.panicked, "a missing shape must not crash the test")
+	assert.True(t, r.fatal, "a missing shape leaves nothing to check: use Fatalf")
+	require.Len(t, r.messages, 1)
+	assert.Contains(t, r.messages[0], "triangle")
+}
+
+func TestNewTestScene(t *testing.T) {
+	s := sampleScene(t)
+	assert.Equal(t, []string{"unit", "square"}, s.Names())
+
+	reached := false
+	r := run(func(tb testing.TB) {
+		NewTestScene(tb,
+			NamedShape{"square", geometry.Rectangle{Width: 1, Height: 1}},
+			NamedShape{"flat", geometry.Rectangle{Width: 1}},
+		)
+		reached = true
+	})
+	assert.True(t, r.fatal, "a fixture that cannot be built must stop the test")
+	assert.False(t, reached)
+	require.Len(t, r.messages, 1)
+	assert.Contains(t, r.messages[0], "flat")
+
+	r = run(func(tb testing.TB) {
+		NewTestScene(tb, NamedShape{"a", geometry.Circle{Radius: 1}}, NamedShape{"a", geometry.Circle{Radius: 2}})
+	})
+	assert.True(t, r.fatal, "duplicate names too")
+}
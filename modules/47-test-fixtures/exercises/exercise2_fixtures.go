@@ -0,0 +1,119 @@
+package exercises
+
+// EXERCISE: Fix fixtures that clean up too early, or never.
+// NewTestJournal closes the journal it returns before the test can write
+// to it; SceneFile leaves a directory behind in the temporary directory
+// on every run; and RunCases, which runs a table of cases as parallel
+// subtests, checks the last case in every one of them, in a way that go
+// vet does not spot. Use what the testing package provides, t.Cleanup
+// and t.TempDir, and remember that this module's go.mod names Go 1.21.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// Journal appends lines to a file.
+type Journal struct {
+	path string
+	f    *os.File
+	w    *bufio.Writer
+}
+
+// OpenJournal opens the journal at path, creating the file if need be.
+func OpenJournal(path string) (*Journal, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{path: path, f: f, w: bufio.NewWriter(f)}, nil
+}
+
+// Path returns the journal's file.
+func (j *Journal) Path() string { return j.path }
+
+// Record appends a line. It is buffered until Close.
+func (j *Journal) Record(format string, args ...any) error {
+	if j.f == nil {
+		return os.ErrClosed
+	}
+	_, err := fmt.Fprintf(j.w, format+"\n", args...)
+	return err
+}
+
+// Close flushes and closes the journal.
+func (j *Journal) Close() error {
+	if j.f == nil {
+		return os.ErrClosed
+	}
+	err := j.w.Flush()
+	if cerr := j.f.Close(); err == nil {
+		err = cerr
+	}
+	j.f = nil
+	return err
+}
+
+// NewTestJournal is a fixture: a Journal in a directory of the test's own,
+// closed when the test ends.
+func NewTestJournal(tb testing.TB) *Journal {
+	tb.Helper()
+	j, err := OpenJournal(filepath.Join(tb.TempDir(), "journal.log"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer j.Close() // BUG: Closed when NewTestJournal returns, before the test can use it
+	return j
+}
+
+// WriteScene writes one line for each of the scene's shapes to path: its
+// name and area.
+func WriteScene(path string, s *Scene) error {
+	var b strings.Builder
+	for _, name := range s.Names() {
+		sh, _ := s.Shape(name)
+		fmt.Fprintf(&b, "%s\t%g\n", name, sh.Area())
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// SceneFile is a fixture: the path of a file that WriteScene wrote s to,
+// in a directory that is removed when the test ends.
+func SceneFile(tb testing.TB, s *Scene) string {
+	tb.Helper()
+	dir, err := os.MkdirTemp("", "scene-*") // BUG: Never removed
+	if err != nil {
+		tb.Fatal(err)
+	}
+	path := filepath.Join(dir, "scene.txt")
+	if err := WriteScene(path, s); err != nil {
+		tb.Fatal(err)
+	}
+	return path
+}
+
+// ShapeCase is a shape and the area and perimeter it must have.
+type ShapeCase struct {
+	Name            string
+	Shape           geometry.Shape
+	Area, Perimeter float64
+}
+
+// RunCases runs check on each case in a parallel subtest named after it.
+func RunCases(t *testing.T, cases []ShapeCase, check func(t *testing.T, c ShapeCase)) {
+	t.Helper()
+	for _, c := range cases {
+		subtest := func(t *testing.T) {
+			t.Parallel()
+			check(t, c) // BUG: Every subtest runs after the loop, and sees its last case
+		}
+		t.Run(c.Name, subtest)
+	}
+}
@@ -0,0 +1,119 @@
+package solutions
+
+// SOLUTION: Fix fixtures that clean up too early, or never. NewTestJournal
+// closes the journal in a t.Cleanup, which runs when the test ends, where
+// a defer ran when the fixture returned; SceneFile writes to t.TempDir(),
+// which the testing package removes, where os.MkdirTemp left a directory
+// behind on every run; and RunCases copies the loop variable before the
+// parallel subtests capture it.
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// Journal appends lines to a file.
+type Journal struct {
+	path string
+	f    *os.File
+	w    *bufio.Writer
+}
+
+// OpenJournal opens the journal at path, creating the file if need be.
+func OpenJournal(path string) (*Journal, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{path: path, f: f, w: bufio.NewWriter(f)}, nil
+}
+
+// Path returns the journal's file.
+func (j *Journal) Path() string { return j.path }
+
+// Record appends a line. It is buffered until Close.
+func (j *Journal) Record(format string, args ...any) error {
+	if j.f == nil {
+		return os.ErrClosed
+	}
+	_, err := fmt.Fprintf(j.w, format+"\n", args...)
+	return err
+}
+
+// Close flushes and closes the journal.
+func (j *Journal) Close() error {
+	if j.f == nil {
+		return os.ErrClosed
+	}
+	err := j.w.Flush()
+	if cerr := j.f.Close(); err == nil {
+		err = cerr
+	}
+	j.f = nil
+	return err
+}
+
+// NewTestJournal is a fixture: a Journal in a directory of the test's own,
+// closed when the test ends.
+func NewTestJournal(tb testing.TB) *Journal {
+	tb.Helper()
+	j, err := OpenJournal(filepath.Join(tb.TempDir(), "journal.log"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	// Fixed: Closed when the test ends, and not when NewTestJournal returns
+	tb.Cleanup(func() {
+		if err := j.Close(); err != nil {
+			tb.Errorf("closing the journal: %v", err)
+		}
+	})
+	return j
+}
+
+// WriteScene writes one line for each of the scene's shapes to path: its
+// name and area.
+func WriteScene(path string, s *Scene) error {
+	var b strings.Builder
+	for _, name := range s.Names() {
+		sh, _ := s.Shape(name)
+		fmt.Fprintf(&b, "%s\t%g\n", name, sh.Area())
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// SceneFile is a fixture: the path of a file that WriteScene wrote s to,
+// in a directory that is removed when the test ends.
+func SceneFile(tb testing.TB, s *Scene) string {
+	tb.Helper()
+	path := filepath.Join(tb.TempDir(), "scene.txt") // Fixed: Removed when the test ends
+	if err := WriteScene(path, s); err != nil {
+		tb.Fatal(err)
+	}
+	return path
+}
+
+// ShapeCase is a shape and the area and perimeter it must have.
+type ShapeCase struct {
+	Name            string
+	Shape           geometry.Shape
+	Area, Perimeter float64
+}
+
+// RunCases runs check on each case in a parallel subtest named after it.
+func RunCases(t *testing.T, cases []ShapeCase, check func(t *testing.T, c ShapeCase)) {
+	t.Helper()
+	for _, c := range cases {
+		c := c // Fixed: Each subtest has a case of its own
+		subtest := func(t *testing.T) {
+			t.Parallel()
+			check(t, c)
+		}
+		t.Run(c.Name, subtest)
+	}
+}
@@ -0,0 +1,61 @@
+package solutions
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTestJournal(t *testing.T) {
+	var j *Journal
+	t.Run("fixture", func(t *testing.T) {
+		j = NewTestJournal(t)
+		assert.NoError(t, j.Record("add %s", "square"), "the journal must still be open when the fixture returns")
+	})
+	assert.ErrorIs(t, j.Record("late"), os.ErrClosed, "the journal must be closed when the test ends")
+	_, err := os.Stat(filepath.Dir(j.Path()))
+	assert.True(t, os.IsNotExist(err), "and its directory removed")
+}
+
+func TestSceneFile(t *testing.T) {
+	var path string
+	t.Run("fixture", func(t *testing.T) {
+		path = SceneFile(t, sampleScene(t))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "unit\t3.141592653589793\nsquare\t4\n", string(data))
+	})
+	_, err := os.Stat(filepath.Dir(path))
+	assert.True(t, os.IsNotExist(err), "the directory must be removed when the test ends: %s", filepath.Dir(path))
+}
+
+func TestRunCases(t *testing.T) {
+	cases := []ShapeCase{
+		{"unit circle", geometry.Circle{Radius: 1}, math.Pi, 2 * math.Pi},
+		{"square", geometry.Rectangle{Width: 2, Height: 2}, 4, 8},
+		{"right triangle", geometry.Triangle{B: geometry.Point{Y: 4}, C: geometry.Point{X: 3}}, 6, 12},
+	}
+	var mu sync.Mutex
+	seen := make(map[string]string)
+	t.Run("cases", func(t *testing.T) {
+		RunCases(t, cases, func(t *testing.T, c ShapeCase) {
+			mu.Lock()
+			seen[t.Name()] = c.Name
+			mu.Unlock()
+			assert.InDelta(t, c.Area, c.Shape.Area(), 1e-9)
+			assert.InDelta(t, c.Perimeter, c.Shape.Perimeter(), 1e-9)
+		})
+	})
+	require.Len(t, seen, len(cases))
+	for _, c := range cases {
+		got := seen["TestRunCases/cases/"+strings.ReplaceAll(c.Name, " ", "_")]
+		assert.Equal(t, c.Name, got, "each subtest must check its own case")
+	}
+}
@@ -0,0 +1,87 @@
+// Package examples demonstrates generic numeric code and the linear
+// algebra of the course's pkg/matrix: type constraints for numbers,
+// matrices that report mismatched dimensions as errors, and the 3×3
+// affine matrices that move, rotate, and scale shapes.
+//
+// This file shows:
+// - A constraint as an interface listing the types it allows
+// - ~float64, which also allows types defined on float64, such as Meters
+// - Generic Sum and Dot that work for ints and floats alike
+// - Returning an error for slices of different lengths instead of indexing past one
+// - float32 rounding sooner than float64, with the same code
+package examples
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/matrix"
+)
+
+// Number allows the types that support +, -, *, and / and convert to and
+// from float64. The ~ allows defined types too: without it, Meters would
+// not satisfy Number, though its underlying type is float64.
+type Number interface {
+	~int | ~int32 | ~int64 | ~float32 | ~float64
+}
+
+// Meters is a distance; its underlying type is float64.
+type Meters float64
+
+// ErrLength is returned, wrapped, for slices that should be the same
+// length and are not.
+var ErrLength = errors.New("length mismatch")
+
+// Sum returns the sum of xs.
+func Sum[T Number](xs []T) T {
+	var total T
+	for _, x := range xs {
+		total += x
+	}
+	return total
+}
+
+// Dot returns the dot product of a and b, the sum of their products. It
+// returns an error, rather than panicking on b[i] or ignoring the rest of
+// a, if they have different lengths.
+func Dot[T Number](a, b []T) (T, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("%w: dot product of %d and %d elements", ErrLength, len(a), len(b))
+	}
+	var d T
+	for i := range a {
+		d += a[i] * b[i]
+	}
+	return d, nil
+}
+
+// Mean returns the mean of xs, or 0 for none. Integers would divide with
+// truncation, so it only allows floats: matrix.Float is ~float32 | ~float64.
+func Mean[T matrix.Float](xs []T) T {
+	if len(xs) == 0 {
+		return 0
+	}
+	return Sum(xs) / T(len(xs))
+}
+
+// DemonstrateConstraints calls the same generic functions with ints,
+// float64s, float32s, and Meters.
+func DemonstrateConstraints() {
+	fmt.Println("=== Constraints ===")
+	fmt.Println("Sum of ints:", Sum([]int{1, 2, 3}))
+	fmt.Println("Sum of Meters:", Sum([]Meters{1.5, 2.25}))
+	fmt.Println("Mean of float64s:", Mean([]float64{1, 2, 4}))
+
+	d, err := Dot([]int{1, 2, 3}, []int{4, 5, 6})
+	fmt.Println("Dot:", d, err)
+	_, err = Dot([]int{1, 2, 3}, []int{4, 5})
+	fmt.Println("Dot of mismatched slices:", err, "- ErrLength:", errors.Is(err, ErrLength))
+
+	// 0.1 is not exact in binary; float32 shows it after fewer additions.
+	tenths32 := make([]float32, 10)
+	tenths64 := make([]float64, 10)
+	for i := range tenths32 {
+		tenths32[i], tenths64[i] = 0.1, 0.1
+	}
+	fmt.Printf("Ten tenths: float32 %.10f, float64 %.17f\n", Sum(tenths32), Sum(tenths64))
+}
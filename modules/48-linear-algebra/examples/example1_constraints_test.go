@@ -0,0 +1,35 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateConstraints(t *testing.T) {
+	DemonstrateConstraints()
+}
+
+func TestSum(t *testing.T) {
+	assert.Equal(t, 6, Sum([]int{1, 2, 3}))
+	assert.Equal(t, Meters(3.75), Sum([]Meters{1.5, 2.25}))
+	assert.Equal(t, int64(0), Sum[int64](nil))
+}
+
+func TestDot(t *testing.T) {
+	d, err := Dot([]float64{1, 2, 3}, []float64{4, 5, 6})
+	require.NoError(t, err)
+	assert.Equal(t, 32.0, d)
+
+	_, err = Dot([]int{1, 2, 3}, []int{4, 5})
+	assert.ErrorIs(t, err, ErrLength)
+	_, err = Dot([]int{1}, []int{4, 5})
+	assert.ErrorIs(t, err, ErrLength, "a shorter a is an error too, not a partial sum")
+}
+
+func TestMean(t *testing.T) {
+	assert.InDelta(t, 7.0/3, Mean([]float64{1, 2, 4}), 1e-12)
+	assert.Equal(t, float32(1.5), Mean([]float32{1, 2}))
+	assert.Zero(t, Mean[float64](nil))
+}
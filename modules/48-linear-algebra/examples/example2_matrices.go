@@ -0,0 +1,96 @@
+package examples
+
+// This file shows:
+// - Building matrices from rows with matrix.FromRows, which rejects ragged rows
+// - Multiplying, which needs as many columns on the left as rows on the right
+// - Transposing, and the identity matrix that multiplication leaves unchanged
+// - Checking for matrix.ErrShape with errors.Is, and adding context with %w
+// - Parsing a matrix from text, whose dimensions come from the input
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/matrix"
+)
+
+// ParseMatrix reads a matrix from text with rows separated by semicolons
+// and elements by spaces, as in "1 2; 3 4". A ragged matrix is an error
+// wrapping matrix.ErrShape, since the text comes from the user.
+func ParseMatrix(s string) (matrix.Matrix[float64], error) {
+	var rows [][]float64
+	for _, line := range strings.Split(s, ";") {
+		var row []float64
+		for _, f := range strings.Fields(line) {
+			v, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				return matrix.Matrix[float64]{}, fmt.Errorf("parsing matrix: %w", err)
+			}
+			row = append(row, v)
+		}
+		rows = append(rows, row)
+	}
+	m, err := matrix.FromRows(rows...)
+	if err != nil {
+		return matrix.Matrix[float64]{}, fmt.Errorf("parsing matrix %q: %w", s, err)
+	}
+	return m, nil
+}
+
+// MulAll returns the product of ms, left to right, or an error naming the
+// first pair whose dimensions do not fit.
+func MulAll[T matrix.Float](ms ...matrix.Matrix[T]) (matrix.Matrix[T], error) {
+	if len(ms) == 0 {
+		return matrix.Matrix[T]{}, errors.New("MulAll: no matrices")
+	}
+	p := ms[0]
+	for i, m := range ms[1:] {
+		var err error
+		if p, err = p.Mul(m); err != nil {
+			return matrix.Matrix[T]{}, fmt.Errorf("MulAll: matrix %d: %w", i+1, err)
+		}
+	}
+	return p, nil
+}
+
+// Gram returns mᵀ × m, which is square and symmetric for any m: its
+// dimensions always fit, so the error cannot happen.
+func Gram[T matrix.Float](m matrix.Matrix[T]) matrix.Matrix[T] {
+	g, err := m.Transpose().Mul(m)
+	if err != nil {
+		panic(err) // a bug in Gram or in Mul, not in the caller's input
+	}
+	return g
+}
+
+// DemonstrateMatrices multiplies, transposes, and parses matrices, and
+// shows the errors for dimensions that do not fit.
+func DemonstrateMatrices() {
+	fmt.Println("=== Matrices ===")
+	a, _ := ParseMatrix("1 2 3; 4 5 6")
+	b, _ := ParseMatrix("1 0; 0 1; 1 1")
+	fmt.Printf("A (%dx%d):\n%v\n", a.Rows(), a.Cols(), a)
+
+	ab := must(MulAll(a, b))
+	fmt.Printf("A × B:\n%v\n", ab)
+	fmt.Printf("A × B × I:\n%v\n", must(MulAll(ab, matrix.Identity[float64](2))))
+	fmt.Printf("Aᵀ:\n%v\n", a.Transpose())
+	fmt.Printf("Gram(A) = Aᵀ × A:\n%v\n", Gram(a))
+
+	_, err := MulAll(a, b, a, a)
+	fmt.Println("A × B × A × A:", err)
+	fmt.Println("  is ErrShape:", errors.Is(err, matrix.ErrShape))
+	_, err = ParseMatrix("1 2; 3")
+	fmt.Println("Ragged:", err)
+	_, err = ParseMatrix("1 x")
+	fmt.Println("Not a number:", err)
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,46 @@
+package examples
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/matrix"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateMatrices(t *testing.T) {
+	DemonstrateMatrices()
+}
+
+func TestParseMatrix(t *testing.T) {
+	m, err := ParseMatrix("1 2 3; 4 5 6")
+	require.NoError(t, err)
+	assert.Equal(t, "[1 2 3]\n[4 5 6]", m.String())
+
+	_, err = ParseMatrix("1 2; 3")
+	assert.ErrorIs(t, err, matrix.ErrShape)
+	_, err = ParseMatrix("1 x")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+}
+
+func TestMulAll(t *testing.T) {
+	a, _ := ParseMatrix("1 2; 3 4")
+	col, _ := ParseMatrix("1; 1")
+	p, err := MulAll(a, a, col)
+	require.NoError(t, err)
+	assert.Equal(t, "[17]\n[37]", p.String())
+
+	_, err = MulAll(a, col, a)
+	assert.ErrorIs(t, err, matrix.ErrShape)
+	assert.ErrorContains(t, err, "matrix 2")
+	_, err = MulAll[float64]()
+	assert.Error(t, err)
+}
+
+func TestGram(t *testing.T) {
+	m, _ := ParseMatrix("1 2 3; 4 5 6")
+	g := Gram(m)
+	assert.Equal(t, 3, g.Rows())
+	assert.Equal(t, g, g.Transpose(), "symmetric")
+}
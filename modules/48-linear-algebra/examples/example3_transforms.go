@@ -0,0 +1,76 @@
+package examples
+
+// This file shows:
+// - Affine transforms as 3×3 matrices: Translation, Scaling, and Rotation
+// - Composing transforms, where the order changes the result
+// - Rotating and scaling about a point other than the origin
+// - matrix.Transform on shapes, and ErrNotPreserved when a shape cannot stay its kind
+// - Transforming many shapes and joining the errors with errors.Join
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/matrix"
+)
+
+// About returns the transform that applies t about center rather than
+// about the origin: move center to the origin, apply t, and move back.
+func About(center geometry.Point, t matrix.Matrix[float64]) (matrix.Matrix[float64], error) {
+	return matrix.Compose(matrix.Translation(-center.X, -center.Y), t, matrix.Translation(center.X, center.Y))
+}
+
+// TransformAll transforms each of shapes by m. Shapes that cannot be
+// transformed are left out, and their errors returned together.
+func TransformAll(shapes []geometry.Shape, m matrix.Matrix[float64]) ([]geometry.Shape, error) {
+	var out []geometry.Shape
+	var errs []error
+	for i, s := range shapes {
+		t, err := matrix.Transform(s, m)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("shape %d (%v): %w", i, s, err))
+			continue
+		}
+		out = append(out, t)
+	}
+	return out, errors.Join(errs...)
+}
+
+// DemonstrateTransforms moves, rotates, and scales points and shapes.
+func DemonstrateTransforms() {
+	fmt.Println("=== Transforms ===")
+	p := geometry.Point{X: 1, Y: 0}
+	show := func(name string, m matrix.Matrix[float64]) {
+		q, _ := matrix.Apply(m, p)
+		fmt.Printf("%-20s %v -> {%g %g}\n", name, p, round(q.X), round(q.Y))
+	}
+	show("Translation(2, 1)", matrix.Translation(2, 1))
+	show("Rotation(90°)", matrix.Rotation(math.Pi/2))
+	show("Scaling(3, 3)", matrix.Scaling(3, 3))
+
+	moveThenTurn, _ := matrix.Compose(matrix.Translation(2, 0), matrix.Rotation(math.Pi/2))
+	turnThenMove, _ := matrix.Compose(matrix.Rotation(math.Pi/2), matrix.Translation(2, 0))
+	show("move, then turn", moveThenTurn)
+	show("turn, then move", turnThenMove)
+	aboutCenter, _ := About(geometry.Point{X: 2, Y: 0}, matrix.Rotation(math.Pi/2))
+	show("turn about (2, 0)", aboutCenter)
+
+	shapes := []geometry.Shape{
+		geometry.Triangle{B: geometry.Point{X: 2}, C: geometry.Point{Y: 1}},
+		geometry.Circle{Radius: 1},
+		geometry.Rectangle{Width: 2, Height: 1},
+	}
+	stretch := matrix.Scaling(2, 1)
+	out, err := TransformAll(shapes, stretch)
+	fmt.Println("Stretched:", out)
+	fmt.Println("Errors:", err)
+	fmt.Println("  not preserved:", errors.Is(err, matrix.ErrNotPreserved))
+}
+
+// round rounds v to three decimals, so that cos(π/2) ≈ 6e-17 prints as 0.
+// Adding 0 turns -0 into 0.
+func round(v float64) float64 {
+	return math.Round(v*1000)/1000 + 0
+}
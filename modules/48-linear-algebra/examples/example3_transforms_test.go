@@ -0,0 +1,47 @@
+package examples
+
+import (
+	"math"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/matrix"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateTransforms(t *testing.T) {
+	DemonstrateTransforms()
+}
+
+func TestAbout(t *testing.T) {
+	center := geometry.Point{X: 2, Y: 3}
+	m, err := About(center, matrix.Scaling(2, 2))
+	require.NoError(t, err)
+	p, err := matrix.Apply(m, center)
+	require.NoError(t, err)
+	assert.Equal(t, center, p, "the center stays put")
+
+	m, err = About(center, matrix.Rotation(1))
+	require.NoError(t, err)
+	p, err = matrix.Apply(m, geometry.Point{X: 5, Y: -1})
+	require.NoError(t, err)
+	assert.True(t, vec.Near(vec.RotateAbout(geometry.Point{X: 5, Y: -1}, center, 1), p, 1e-9))
+}
+
+func TestTransformAll(t *testing.T) {
+	shapes := []geometry.Shape{
+		geometry.Circle{Radius: 1},
+		geometry.Rectangle{Width: 2, Height: 1},
+		geometry.Circle{Radius: 2},
+	}
+	out, err := TransformAll(shapes, matrix.Rotation(math.Pi/4))
+	assert.ErrorIs(t, err, matrix.ErrNotPreserved)
+	assert.ErrorContains(t, err, "shape 1")
+	assert.Len(t, out, 2, "the circles")
+
+	out, err = TransformAll(shapes, matrix.Scaling(2, 2))
+	require.NoError(t, err)
+	assert.Equal(t, geometry.Circle{Radius: 4}, out[2])
+}
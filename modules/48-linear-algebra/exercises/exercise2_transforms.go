@@ -0,0 +1,63 @@
+package exercises
+
+// EXERCISE: Fix shape transforms that apply steps in the wrong order or hide their errors.
+// The transforms here are built from pkg/matrix's Translation, Rotation,
+// and Scaling with matrix.Compose, which applies them first to last.
+// Rotating about a point means moving it to the origin, rotating, and
+// moving it back, in that order. A transform that cannot be built must
+// return an error rather than a matrix of infinities, and transforming
+// many shapes must report every one that failed.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/matrix"
+)
+
+// ErrEmpty is returned, wrapped, for a rectangle without width or height,
+// which no transform can stretch to fill another.
+var ErrEmpty = errors.New("empty rectangle")
+
+// RotateAbout returns the transform that rotates by theta radians
+// counterclockwise about center.
+func RotateAbout(center geometry.Point, theta float64) (matrix.Matrix[float64], error) {
+	return matrix.Compose(
+		// BUG: The translations are swapped: this moves center away from
+		// the origin, rotates about the origin, and moves back.
+		matrix.Translation(center.X, center.Y),
+		matrix.Rotation(theta),
+		matrix.Translation(-center.X, -center.Y),
+	)
+}
+
+// Fit returns the transform that maps the rectangle from onto the
+// rectangle to: corner onto corner, stretched to to's size.
+func Fit(from, to geometry.Rectangle) (matrix.Matrix[float64], error) {
+	// BUG: A from without width or height must return an error wrapping
+	// ErrEmpty; dividing by its 0 gives a matrix of infinities.
+	return matrix.Compose(
+		matrix.Translation(-from.Origin.X, -from.Origin.Y),
+		matrix.Scaling(to.Width/from.Width, to.Height/from.Height),
+		matrix.Translation(to.Origin.X, to.Origin.Y),
+	)
+}
+
+// TransformAll transforms each of shapes by m. The shapes that cannot be
+// transformed are left out, and the error names each of them.
+func TransformAll(shapes []geometry.Shape, m matrix.Matrix[float64]) ([]geometry.Shape, error) {
+	var out []geometry.Shape
+	var errs []error
+	for i, s := range shapes {
+		t, err := matrix.Transform(s, m)
+		if err != nil {
+			// BUG: %v hides err from errors.Is, and returning here leaves the
+			// remaining shapes out. Wrap with %w, add it to errs, and continue.
+			return out, fmt.Errorf("shape %d: %v", i, err)
+		}
+		out = append(out, t)
+	}
+	return out, errors.Join(errs...)
+}
@@ -0,0 +1,96 @@
+package solutions
+
+// SOLUTION: Check every dimension before indexing.
+// Each operation compares the dimensions it relies on first, and returns
+// an error wrapping ErrShape when they do not fit; only then does it
+// index, so no input can make it panic.
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Float allows float32, float64, and types defined on them.
+type Float interface {
+	~float32 | ~float64
+}
+
+// ErrShape is returned, wrapped, when dimensions do not fit together.
+var ErrShape = errors.New("dimension mismatch")
+
+// Matrix is a rows×cols matrix of T, stored row by row.
+type Matrix[T Float] struct {
+	rows, cols int
+	data       []T
+}
+
+// FromRows returns the matrix with the given rows, which must all be the
+// same length.
+func FromRows[T Float](rows ...[]T) (Matrix[T], error) {
+	if len(rows) == 0 {
+		return Matrix[T]{}, nil
+	}
+	m := Matrix[T]{rows: len(rows), cols: len(rows[0])}
+	for i, row := range rows {
+		if len(row) != m.cols { // Fixed: A ragged row is an error, not a matrix
+			return Matrix[T]{}, fmt.Errorf("%w: row %d has %d elements, row 0 has %d", ErrShape, i, len(row), m.cols)
+		}
+		m.data = append(m.data, row...)
+	}
+	return m, nil
+}
+
+// Rows returns the number of rows.
+func (m Matrix[T]) Rows() int { return m.rows }
+
+// Cols returns the number of columns.
+func (m Matrix[T]) Cols() int { return m.cols }
+
+// At returns the element in row i and column j.
+func (m Matrix[T]) At(i, j int) T { return m.data[i*m.cols+j] }
+
+// Add returns a + b, which must have the same dimensions.
+func Add[T Float](a, b Matrix[T]) (Matrix[T], error) {
+	if a.rows != b.rows || a.cols != b.cols { // Fixed: Columns must match as well as rows
+		return Matrix[T]{}, fmt.Errorf("%w: cannot add %dx%d and %dx%d", ErrShape, a.rows, a.cols, b.rows, b.cols)
+	}
+	sum := Matrix[T]{rows: a.rows, cols: a.cols, data: make([]T, len(a.data))}
+	for i := range a.data {
+		sum.data[i] = a.data[i] + b.data[i]
+	}
+	return sum, nil
+}
+
+// Mul returns a × b. a must have as many columns as b has rows.
+func Mul[T Float](a, b Matrix[T]) (Matrix[T], error) {
+	if a.cols != b.rows { // Fixed: The inner dimensions must match
+		return Matrix[T]{}, fmt.Errorf("%w: cannot multiply %dx%d by %dx%d", ErrShape, a.rows, a.cols, b.rows, b.cols)
+	}
+	p := Matrix[T]{rows: a.rows, cols: b.cols, data: make([]T, a.rows*b.cols)}
+	for i := 0; i < a.rows; i++ {
+		for j := 0; j < b.cols; j++ {
+			var sum T
+			for k := 0; k < a.cols; k++ {
+				sum += a.At(i, k) * b.At(k, j)
+			}
+			p.data[i*p.cols+j] = sum
+		}
+	}
+	return p, nil
+}
+
+// MulVec returns m × v, for a vector v with one element per column of m.
+// The vector's length comes from the caller, so a wrong one is an error.
+func MulVec[T Float](m Matrix[T], v []T) ([]T, error) {
+	if len(v) != m.cols {
+		// Fixed: Return an error wrapping ErrShape rather than panicking
+		return nil, fmt.Errorf("%w: cannot multiply %dx%d by a vector of %d", ErrShape, m.rows, m.cols, len(v))
+	}
+	out := make([]T, m.rows)
+	for i := range out {
+		for j, x := range v {
+			out[i] += m.At(i, j) * x
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,112 @@
+package solutions
+
+import (
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// call runs f, and fails the test instead of crashing if f panics.
+func call(t *testing.T, f func()) {
+	t.Helper()
+	defer func() {
+		if p := recover(); p != nil {
+			t.Errorf("panicked instead of returning an error: %v", p)
+		}
+	}()
+	f()
+}
+
+func mustRows(t *testing.T, rows ...[]float64) Matrix[float64] {
+	t.Helper()
+	m, err := FromRows(rows...)
+	require.NoError(t, err)
+	return m
+}
+
+// filled returns a rows×cols matrix of 1, 2, 3, ...
+func filled(rows, cols int) Matrix[float64] {
+	m := Matrix[float64]{rows: rows, cols: cols, data: make([]float64, rows*cols)}
+	for i := range m.data {
+		m.data[i] = float64(i + 1)
+	}
+	return m
+}
+
+func TestFromRows(t *testing.T) {
+	m := mustRows(t, []float64{1, 2, 3}, []float64{4, 5, 6})
+	assert.Equal(t, 2, m.Rows())
+	assert.Equal(t, 3, m.Cols())
+	assert.Equal(t, 6.0, m.At(1, 2))
+
+	for _, rows := range [][][]float64{
+		{{1, 2}, {3}},
+		{{1, 2}, {3, 4, 5}},
+		{{1}, {2}, {}},
+	} {
+		_, err := FromRows(rows...)
+		assert.ErrorIs(t, err, ErrShape, "rows %v", rows)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	sum, err := Add(filled(2, 2), filled(2, 2))
+	require.NoError(t, err)
+	assert.Equal(t, mustRows(t, []float64{2, 4}, []float64{6, 8}), sum)
+
+	for _, dims := range [][4]int{{2, 2, 2, 3}, {2, 3, 2, 2}, {3, 2, 2, 3}, {2, 2, 3, 2}} {
+		call(t, func() {
+			_, err := Add(filled(dims[0], dims[1]), filled(dims[2], dims[3]))
+			assert.ErrorIs(t, err, ErrShape, "%dx%d + %dx%d", dims[0], dims[1], dims[2], dims[3])
+		})
+	}
+}
+
+func TestMul(t *testing.T) {
+	p, err := Mul(filled(2, 3), filled(3, 2))
+	require.NoError(t, err)
+	assert.Equal(t, mustRows(t, []float64{22, 28}, []float64{49, 64}), p)
+
+	call(t, func() {
+		_, err := Mul(filled(2, 3), filled(2, 3))
+		assert.ErrorIs(t, err, ErrShape, "2x3 × 2x3")
+	})
+	call(t, func() {
+		p, err := Mul(filled(1, 3), filled(3, 1))
+		require.NoError(t, err, "1x3 × 3x1")
+		assert.Equal(t, mustRows(t, []float64{14}), p)
+	})
+}
+
+func TestMulDimensions(t *testing.T) {
+	r := seed.Rand(t, 48)
+	for i := 0; i < 50; i++ {
+		rows, inner, other, cols := 1+r.Intn(4), 1+r.Intn(4), 1+r.Intn(4), 1+r.Intn(4)
+		call(t, func() {
+			p, err := Mul(filled(rows, inner), filled(other, cols))
+			if inner != other {
+				assert.ErrorIs(t, err, ErrShape, "%dx%d × %dx%d", rows, inner, other, cols)
+				return
+			}
+			if assert.NoError(t, err, "%dx%d × %dx%d", rows, inner, other, cols) {
+				assert.Equal(t, rows, p.Rows())
+				assert.Equal(t, cols, p.Cols())
+			}
+		})
+	}
+}
+
+func TestMulVec(t *testing.T) {
+	v, err := MulVec(filled(2, 3), []float64{1, 0, -1})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{-2, -2}, v)
+
+	for _, v := range [][]float64{nil, {1, 2}, {1, 2, 3, 4}} {
+		call(t, func() {
+			_, err := MulVec(filled(2, 3), v)
+			assert.ErrorIs(t, err, ErrShape, "a vector of %d", len(v))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package solutions
+
+// SOLUTION: Compose in the order the transforms apply, and report every
+// failure. matrix.Compose applies its arguments first to last; a zero
+// size is rejected before dividing by it; and the errors of all shapes
+// are joined, each wrapped with %w.
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/matrix"
+)
+
+// ErrEmpty is returned, wrapped, for a rectangle without width or height,
+// which no transform can stretch to fill another.
+var ErrEmpty = errors.New("empty rectangle")
+
+// RotateAbout returns the transform that rotates by theta radians
+// counterclockwise about center.
+func RotateAbout(center geometry.Point, theta float64) (matrix.Matrix[float64], error) {
+	return matrix.Compose(
+		matrix.Translation(-center.X, -center.Y), // Fixed: Move center to the origin first
+		matrix.Rotation(theta),
+		matrix.Translation(center.X, center.Y), // Fixed: and back last
+	)
+}
+
+// Fit returns the transform that maps the rectangle from onto the
+// rectangle to: corner onto corner, stretched to to's size.
+func Fit(from, to geometry.Rectangle) (matrix.Matrix[float64], error) {
+	if from.Width == 0 || from.Height == 0 { // Fixed: Dividing by 0 would give infinite scales
+		return matrix.Matrix[float64]{}, fmt.Errorf("%w: cannot fit %v onto %v", ErrEmpty, from, to)
+	}
+	return matrix.Compose(
+		matrix.Translation(-from.Origin.X, -from.Origin.Y),
+		matrix.Scaling(to.Width/from.Width, to.Height/from.Height),
+		matrix.Translation(to.Origin.X, to.Origin.Y),
+	)
+}
+
+// TransformAll transforms each of shapes by m. The shapes that cannot be
+// transformed are left out, and the error names each of them.
+func TransformAll(shapes []geometry.Shape, m matrix.Matrix[float64]) ([]geometry.Shape, error) {
+	var out []geometry.Shape
+	var errs []error
+	for i, s := range shapes {
+		t, err := matrix.Transform(s, m)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("shape %d: %w", i, err)) // Fixed: %w, and go on with the others
+			continue
+		}
+		out = append(out, t)
+	}
+	return out, errors.Join(errs...)
+}
@@ -0,0 +1,82 @@
+package solutions
+
+import (
+	"math"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/matrix"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func apply(t *testing.T, m matrix.Matrix[float64], p geometry.Point) geometry.Point {
+	t.Helper()
+	q, err := matrix.Apply(m, p)
+	require.NoError(t, err)
+	return q
+}
+
+func TestRotateAbout(t *testing.T) {
+	center := geometry.Point{X: 2, Y: 1}
+	m, err := RotateAbout(center, math.Pi/2)
+	require.NoError(t, err)
+	assert.True(t, vec.Near(center, apply(t, m, center), 1e-9), "the center stays put")
+	got := apply(t, m, geometry.Point{X: 3, Y: 1})
+	assert.True(t, vec.Near(geometry.Point{X: 2, Y: 2}, got, 1e-9), "a quarter turn about (2, 1) takes (3, 1) to (2, 2), not %v", got)
+
+	r := seed.Rand(t, 48)
+	for i := 0; i < 20; i++ {
+		c := geometry.Point{X: float64(r.Intn(21) - 10), Y: float64(r.Intn(21) - 10)}
+		p := geometry.Point{X: float64(r.Intn(21) - 10), Y: float64(r.Intn(21) - 10)}
+		theta := r.Float64() * 2 * math.Pi
+		m, err := RotateAbout(c, theta)
+		require.NoError(t, err)
+		got := apply(t, m, p)
+		assert.True(t, vec.Near(vec.RotateAbout(p, c, theta), got, 1e-9), "%v about %v by %.3f: got %v", p, c, theta, got)
+	}
+}
+
+func TestFit(t *testing.T) {
+	from := geometry.Rectangle{Origin: geometry.Point{X: 1, Y: 1}, Width: 2, Height: 4}
+	to := geometry.Rectangle{Origin: geometry.Point{X: 10, Y: 0}, Width: 6, Height: 2}
+	m, err := Fit(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, geometry.Point{X: 10, Y: 0}, apply(t, m, from.Origin))
+	assert.Equal(t, geometry.Point{X: 16, Y: 2}, apply(t, m, geometry.Point{X: 3, Y: 5}))
+
+	fitted, err := matrix.Transform(from, m)
+	require.NoError(t, err)
+	assert.Equal(t, to, fitted)
+
+	for _, empty := range []geometry.Rectangle{{Width: 0, Height: 3}, {Width: 3, Height: 0}, {}} {
+		_, err := Fit(empty, to)
+		assert.ErrorIs(t, err, ErrEmpty, "fitting %v", empty)
+	}
+	_, err = Fit(to, geometry.Rectangle{})
+	assert.NoError(t, err, "shrinking to nothing is allowed")
+}
+
+func TestTransformAll(t *testing.T) {
+	shapes := []geometry.Shape{
+		geometry.Triangle{B: geometry.Point{X: 1}, C: geometry.Point{Y: 1}},
+		geometry.Circle{Radius: 1},
+		geometry.Rectangle{Width: 2, Height: 1},
+		geometry.Circle{Radius: 3},
+	}
+	out, err := TransformAll(shapes, matrix.Scaling(2, 1))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, matrix.ErrNotPreserved, "the error wraps what matrix.Transform returned")
+	assert.ErrorContains(t, err, "shape 1")
+	assert.ErrorContains(t, err, "shape 3", "every shape that failed is named")
+	assert.Equal(t, []geometry.Shape{
+		geometry.Triangle{B: geometry.Point{X: 2}, C: geometry.Point{Y: 1}},
+		geometry.Rectangle{Width: 4, Height: 1},
+	}, out, "the shapes after a failed one are transformed too")
+
+	out, err = TransformAll(shapes, matrix.Translation(1, 1))
+	require.NoError(t, err)
+	assert.Len(t, out, 4)
+}
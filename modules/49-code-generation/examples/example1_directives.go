@@ -0,0 +1,47 @@
+// Package examples demonstrates code generation in Go: //go:generate
+// directives that run a generator, the course's enumgen that writes
+// String methods for enums, and the go/ast and go/format packages that
+// generators are built from.
+//
+// This file shows:
+// - An enum as a named integer type and a block of constants using iota
+// - A //go:generate directive, and the weekday_string.go file it wrote
+// - The "Code generated ... DO NOT EDIT." header that marks generated files
+// - fmt calling String for %v and %s, but not for %d
+// - The T(n) a generated String returns for a value without a constant
+package examples
+
+import "fmt"
+
+// Weekday is a day of the week. Its String method is in weekday_string.go,
+// written by the directive below: run "go generate" in this directory, or
+// "learngo generate 49", after changing the constants.
+//
+//go:generate go run github.com/TheAnarchoX/LearningGoTheHardWay/cmd/enumgen -type=Weekday
+type Weekday int
+
+// The days, numbered as time.Weekday numbers them.
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+)
+
+// Weekend reports whether d is a Saturday or a Sunday.
+func Weekend(d Weekday) bool {
+	return d == Saturday || d == Sunday
+}
+
+// DemonstrateDirectives prints weekdays with and without their names.
+func DemonstrateDirectives() {
+	fmt.Println("=== go:generate and String ===")
+	for d := Sunday; d <= Saturday; d++ {
+		fmt.Printf("%d %-9v weekend: %t\n", d, d, Weekend(d))
+	}
+	fmt.Printf("%v, %s, and %q; %d is just a number\n", Friday, Friday, Friday, Friday)
+	fmt.Println("Out of range:", Weekday(9))
+}
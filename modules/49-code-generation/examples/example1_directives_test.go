@@ -0,0 +1,38 @@
+package examples
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateDirectives(t *testing.T) {
+	DemonstrateDirectives()
+}
+
+func TestWeekdayString(t *testing.T) {
+	assert.Equal(t, "Sunday", Sunday.String())
+	assert.Equal(t, "Saturday", fmt.Sprint(Saturday))
+	assert.Equal(t, "3", fmt.Sprintf("%d", Wednesday), "%d prints the number")
+	assert.Equal(t, "Weekday(-1)", Weekday(-1).String())
+}
+
+func TestWeekend(t *testing.T) {
+	var weekend []Weekday
+	for d := Sunday; d <= Saturday; d++ {
+		if Weekend(d) {
+			weekend = append(weekend, d)
+		}
+	}
+	assert.Equal(t, []Weekday{Sunday, Saturday}, weekend)
+}
+
+func TestWeekdayStringIsGenerated(t *testing.T) {
+	src, err := os.ReadFile("weekday_string.go")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(src), `// Code generated by "enumgen -type=Weekday"; DO NOT EDIT.`))
+}
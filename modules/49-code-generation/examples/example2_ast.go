@@ -0,0 +1,105 @@
+package examples
+
+// This file shows:
+// - Parsing source text into a syntax tree with go/parser
+// - token.FileSet, which turns the positions in the tree into file:line:column
+// - Walking the tree with ast.Inspect, and switching on the node types found
+// - Printing a node back as source with go/format
+// - Blank constants (_), which the parser lists like any other name
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+)
+
+// Decl is a top-level declaration found in a file.
+type Decl struct {
+	Pos  string // file:line:column
+	Kind string // "type", "func", "method", "const", or "var"
+	Name string
+}
+
+// Declarations parses the Go file src, named filename in positions, and
+// lists its top-level declarations in order.
+func Declarations(filename, src string) ([]Decl, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filename, err)
+	}
+	var decls []Decl
+	add := func(pos token.Pos, kind, name string) {
+		decls = append(decls, Decl{Pos: fset.Position(pos).String(), Kind: kind, Name: name})
+	}
+	// A file's children are its declarations; returning false from the
+	// function stops Inspect going into a node's children.
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.File:
+			return true
+		case *ast.FuncDecl:
+			if n.Recv != nil {
+				add(n.Pos(), "method", nodeString(n.Recv.List[0].Type)+"."+n.Name.Name)
+			} else {
+				add(n.Pos(), "func", n.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range n.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					add(spec.Pos(), "type", spec.Name.Name)
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						add(name.Pos(), n.Tok.String(), name.Name)
+					}
+				}
+			}
+		}
+		return false
+	})
+	return decls, nil
+}
+
+// nodeString returns the source of n, formatted by gofmt's rules.
+func nodeString(n ast.Node) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), n); err != nil {
+		return fmt.Sprintf("<%T>", n)
+	}
+	return buf.String()
+}
+
+// DemonstrateAST lists the declarations of a small file.
+func DemonstrateAST() {
+	fmt.Println("=== go/ast ===")
+	src := `package vehicles
+
+type Kind int
+
+const (
+	Car Kind = iota
+	Truck
+	_
+	Bus
+)
+
+func (k *Kind) Set(s string) error { return nil }
+
+func Wheels(k Kind) int { return 4 }
+`
+	decls, err := Declarations("vehicles.go", src)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	for _, d := range decls {
+		fmt.Printf("%-18s %-6s %s\n", d.Pos, d.Kind, d.Name)
+	}
+
+	_, err = Declarations("broken.go", "package broken\n\nfunc {")
+	fmt.Println("Error:", err)
+}
@@ -0,0 +1,48 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateAST(t *testing.T) {
+	DemonstrateAST()
+}
+
+func TestDeclarations(t *testing.T) {
+	decls, err := Declarations("logs.go", `package logs
+
+import "fmt"
+
+var (
+	verbose, quiet bool
+)
+
+type (
+	Level int
+	Field struct{ Key, Value string }
+)
+
+func (l Level) String() string { return fmt.Sprint(int(l)) }
+
+func main() {
+	type local int
+}
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []Decl{
+		{Pos: "logs.go:6:2", Kind: "var", Name: "verbose"},
+		{Pos: "logs.go:6:11", Kind: "var", Name: "quiet"},
+		{Pos: "logs.go:10:2", Kind: "type", Name: "Level"},
+		{Pos: "logs.go:11:2", Kind: "type", Name: "Field"},
+		{Pos: "logs.go:14:1", Kind: "method", Name: "Level.String"},
+		{Pos: "logs.go:16:1", Kind: "func", Name: "main"},
+	}, decls, "imports are not declarations, and local types are not top-level")
+}
+
+func TestDeclarationsSyntaxError(t *testing.T) {
+	_, err := Declarations("bad.go", "package bad\n\nconst (\n")
+	assert.ErrorContains(t, err, "parsing bad.go: bad.go:3:9")
+}
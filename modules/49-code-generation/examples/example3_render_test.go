@@ -0,0 +1,39 @@
+package examples
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateRender(t *testing.T) {
+	DemonstrateRender()
+}
+
+func TestRenderSet(t *testing.T) {
+	src, err := RenderSet("vehicles", "Kinds", []string{"truck", "car"})
+	require.NoError(t, err)
+	assert.Contains(t, string(src), "\t\"car\":   true,\n\t\"truck\": true,\n", "sorted and gofmt'ed")
+	assert.True(t, IsGenerated(src))
+
+	f, err := parser.ParseFile(token.NewFileSet(), "kinds.go", src, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "vehicles", f.Name.Name)
+
+	again, err := RenderSet("vehicles", "Kinds", []string{"car", "truck"})
+	require.NoError(t, err)
+	assert.Equal(t, src, again, "the order of the input does not matter")
+
+	_, err = RenderSet("vehicles", "two words", nil)
+	assert.ErrorContains(t, err, "does not parse")
+}
+
+func TestIsGenerated(t *testing.T) {
+	assert.True(t, IsGenerated([]byte("// Code generated by stringer; DO NOT EDIT.\n\npackage x\n")))
+	assert.True(t, IsGenerated([]byte("// Copyright 2024\n\n// Code generated by hand. DO NOT EDIT.\npackage x\n")))
+	assert.False(t, IsGenerated([]byte("// Code generated by stringer; do not edit.\npackage x\n")))
+	assert.False(t, IsGenerated([]byte("package x // Code generated by x DO NOT EDIT.\n")))
+}
@@ -0,0 +1,57 @@
+package exercises
+
+// EXERCISE: Fix enums whose String methods are missing, incomplete, or out of date.
+// Each enum should get its String method from cmd/enumgen, the course's
+// generator, through a //go:generate directive. Fix the directives and
+// the constants, then run "learngo generate 49" to write level_string.go,
+// kind_string.go, and state_string.go, and "learngo check 49" to test
+// them. Run it again whenever the constants change.
+// Fix the bugs marked with // BUG: comments.
+
+// BUG: go generate skips this line
+// go:generate go run github.com/TheAnarchoX/LearningGoTheHardWay/cmd/enumgen -type=Level -trimprefix=Level
+
+// Level is how severe a log message is.
+type Level int
+
+// The levels, from least to most severe.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// LevelTrace is for messages even more detailed than LevelDebug.
+const LevelTrace = -1 // BUG: Untyped, so not a Level
+
+// BUG: enumgen fails: there is no type Kinds
+//go:generate go run github.com/TheAnarchoX/LearningGoTheHardWay/cmd/enumgen -type=Kinds -trimprefix=Kind
+
+// Kind is a kind of vehicle. The zero Kind is unknown, and has no name.
+type Kind int
+
+// The kinds of vehicle.
+const (
+	KindCar Kind = iota + 1
+	KindTruck
+	KindMotorcycle
+	KindBus
+)
+
+// State is where a job is in its life.
+type State int
+
+// The states of a job. StateStopped came last, after String was written.
+const (
+	StateIdle State = iota
+	StateRunning
+	StatePaused
+	StateStopped
+)
+
+// String returns the name of s.
+func (s State) String() string {
+	// BUG: Written by hand before StateStopped, and never updated; generate it instead
+	return [...]string{"Idle", "Running", "Paused"}[s]
+}
@@ -0,0 +1,71 @@
+package exercises
+
+// EXERCISE: Fix the source readers a generator needs, so they read Go the way the go tool does.
+// Directives finds the //go:generate lines of a file, as go generate
+// does, and EnumConstants finds the constants of a type with go/parser
+// and go/ast, as enumgen does. Go's rules are strict: a directive starts
+// the line, with no space after the slashes; a constant spec without a
+// type repeats the previous spec only if it has no values either; and
+// a file that does not parse has no constants to trust.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// Directives returns the commands of the //go:generate directives in
+// src, in order: for "//go:generate stringer -type=Pill", the command is
+// "stringer -type=Pill".
+func Directives(src string) []string {
+	var commands []string
+	for _, line := range strings.Split(src, "\n") {
+		// BUG: Accepts indented lines, "// go:generate", and "//go:generated"
+		_, rest, ok := strings.Cut(line, "go:generate")
+		if !ok || !strings.HasPrefix(strings.TrimSpace(line), "//") {
+			continue
+		}
+		commands = append(commands, strings.TrimSpace(rest))
+	}
+	return commands
+}
+
+// EnumConstants returns the names of the constants of type typeName
+// declared at the top level of the Go file src, in order, leaving out
+// blank ones. Within a const block, a spec without type or values repeats
+// the one before, iota and all.
+func EnumConstants(src, typeName string) ([]string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "src.go", src, parser.SkipObjectResolution)
+	if err != nil {
+		// BUG: The part that parsed is not the whole file
+		fmt.Printf("enum constants of %s: %v\n", typeName, err)
+	}
+	var names []string
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		typ := "" // the type the next spec repeats, if it has no type or values
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if vs.Type != nil {
+				typ = ""
+				if id, ok := vs.Type.(*ast.Ident); ok {
+					typ = id.Name
+				}
+			}
+			// BUG: A spec with values and no type is untyped, not the previous type
+			if typ != typeName {
+				continue
+			}
+			for _, name := range vs.Names {
+				names = append(names, name.Name) // BUG: Blank constants have no name
+			}
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,52 @@
+package solutions
+
+// SOLUTION: Generate every String method, from directives go generate sees.
+// Each type has a //go:generate directive with no space after the
+// slashes, naming the type as declared; LevelTrace is a Level, so enumgen
+// counts it; and State's hand-written String, which fell behind its
+// constants, is gone in favor of a generated one.
+
+// Fixed: No space between // and go:generate, or go generate skips the line
+//go:generate go run github.com/TheAnarchoX/LearningGoTheHardWay/cmd/enumgen -type=Level -trimprefix=Level
+
+// Level is how severe a log message is.
+type Level int
+
+// The levels, from least to most severe.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// LevelTrace is for messages even more detailed than LevelDebug.
+const LevelTrace Level = -1 // Fixed: Typed, so it is part of the enum
+
+// Fixed: -type names Kind, the type declared, not Kinds
+//go:generate go run github.com/TheAnarchoX/LearningGoTheHardWay/cmd/enumgen -type=Kind -trimprefix=Kind
+
+// Kind is a kind of vehicle. The zero Kind is unknown, and has no name.
+type Kind int
+
+// The kinds of vehicle.
+const (
+	KindCar Kind = iota + 1
+	KindTruck
+	KindMotorcycle
+	KindBus
+)
+
+// Fixed: A directive for State, whose hand-written String missed StateStopped
+//go:generate go run github.com/TheAnarchoX/LearningGoTheHardWay/cmd/enumgen -type=State -trimprefix=State
+
+// State is where a job is in its life.
+type State int
+
+// The states of a job. StateStopped came last, after String was written.
+const (
+	StateIdle State = iota
+	StateRunning
+	StatePaused
+	StateStopped
+)
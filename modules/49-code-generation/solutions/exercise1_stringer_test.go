@@ -0,0 +1,68 @@
+package solutions
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// These tests print values with fmt, which uses a String method if the
+// type has one: they compile before "learngo generate 49" has written
+// any, and pass only after.
+
+func TestLevelString(t *testing.T) {
+	tests := map[Level]string{
+		LevelTrace: "Trace",
+		LevelDebug: "Debug",
+		LevelInfo:  "Info",
+		LevelWarn:  "Warn",
+		LevelError: "Error",
+		Level(9):   "Level(9)",
+	}
+	for l, want := range tests {
+		assert.Equal(t, want, fmt.Sprint(l), "Level %d", int(l))
+	}
+}
+
+func TestKindString(t *testing.T) {
+	tests := map[Kind]string{
+		KindCar:        "Car",
+		KindTruck:      "Truck",
+		KindMotorcycle: "Motorcycle",
+		KindBus:        "Bus",
+		Kind(0):        "Kind(0)",
+	}
+	for k, want := range tests {
+		assert.Equal(t, want, fmt.Sprint(k), "Kind %d", int(k))
+	}
+}
+
+func TestStateString(t *testing.T) {
+	// fmt recovers from a panicking String method, and prints the panic.
+	tests := map[State]string{
+		StateIdle:    "Idle",
+		StateRunning: "Running",
+		StatePaused:  "Paused",
+		StateStopped: "Stopped",
+		State(-1):    "State(-1)",
+		State(7):     "State(7)",
+	}
+	for s, want := range tests {
+		assert.Equal(t, want, fmt.Sprint(s), "State %d", int(s))
+	}
+}
+
+func TestStringsAreGenerated(t *testing.T) {
+	for _, typ := range []string{"Level", "Kind", "State"} {
+		name := strings.ToLower(typ) + "_string.go"
+		src, err := os.ReadFile(name)
+		if !assert.NoError(t, err, "run \"learngo generate 49\" to write %s", name) {
+			continue
+		}
+		assert.True(t, strings.HasPrefix(string(src), `// Code generated by "enumgen -type=`+typ),
+			"%s should be written by enumgen, not by hand", name)
+	}
+}
@@ -0,0 +1,71 @@
+package solutions
+
+// SOLUTION: Read directives and constants the way the go tool does.
+// A directive is a line that starts with "//go:generate", with no space;
+// a syntax error is returned, not mistaken for a file without constants;
+// a constant spec repeats the previous type only if it has neither type
+// nor values; and blank constants have no name to list.
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// Directives returns the commands of the //go:generate directives in
+// src, in order: for "//go:generate stringer -type=Pill", the command is
+// "stringer -type=Pill".
+func Directives(src string) []string {
+	var commands []string
+	for _, line := range strings.Split(src, "\n") {
+		// Fixed: go generate reads only lines that start with the marker
+		rest, ok := strings.CutPrefix(line, "//go:generate")
+		if !ok || rest == "" || (rest[0] != ' ' && rest[0] != '\t') {
+			continue
+		}
+		commands = append(commands, strings.TrimSpace(rest))
+	}
+	return commands
+}
+
+// EnumConstants returns the names of the constants of type typeName
+// declared at the top level of the Go file src, in order, leaving out
+// blank ones. Within a const block, a spec without type or values repeats
+// the one before, iota and all.
+func EnumConstants(src, typeName string) ([]string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "src.go", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("enum constants of %s: %w", typeName, err) // Fixed: A syntax error is an error
+	}
+	var names []string
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		typ := "" // the type the next spec repeats, if it has no type or values
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			switch {
+			case vs.Type != nil:
+				typ = ""
+				if id, ok := vs.Type.(*ast.Ident); ok {
+					typ = id.Name
+				}
+			case len(vs.Values) > 0:
+				typ = "" // Fixed: Values without a type make an untyped constant
+			}
+			if typ != typeName {
+				continue
+			}
+			for _, name := range vs.Names {
+				if name.Name != "_" { // Fixed: Blank constants have no name
+					names = append(names, name.Name)
+				}
+			}
+		}
+	}
+	return names, nil
+}
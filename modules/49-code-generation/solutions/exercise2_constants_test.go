@@ -0,0 +1,77 @@
+package solutions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectives(t *testing.T) {
+	// Built line by line: go generate would run a directive at the start
+	// of a line in a raw string here, since it reads test files too.
+	src := strings.Join([]string{
+		"package logs",
+		"",
+		"//go:generate enumgen -type=Level",
+		"// go:generate enumgen -type=Kind",
+		"\t//go:generate enumgen -type=State",
+		"//go:generated not a directive",
+		"//go:generate\tstringer  -type=Pill ",
+		"/* //go:generate in a comment block */",
+		`var s = "//go:generate"`,
+	}, "\n")
+	assert.Equal(t, []string{"enumgen -type=Level", "stringer  -type=Pill"}, Directives(src))
+	assert.Empty(t, Directives("package empty\n"))
+}
+
+const logs = `package logs
+
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	_
+	LevelWarn
+	LevelTrace = -1
+	LevelError, LevelFatal Level = 10, 11
+	LevelAlias
+	NotALevel  = "x"
+)
+
+type Kind int
+
+const KindCar Kind = 1
+
+const (
+	KindTruck Kind = iota + 2
+	LevelLast Level = 99
+	KindBus
+)
+
+func f() {
+	const LevelLocal Level = 5
+}
+`
+
+func TestEnumConstants(t *testing.T) {
+	levels, err := EnumConstants(logs, "Level")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"LevelDebug", "LevelInfo", "LevelWarn", "LevelError", "LevelFatal", "LevelAlias", "LevelLast", "KindBus"}, levels,
+		"LevelTrace and NotALevel are untyped; KindBus repeats LevelLast's spec")
+
+	kinds, err := EnumConstants(logs, "Kind")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"KindCar", "KindTruck"}, kinds)
+
+	none, err := EnumConstants(logs, "State")
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestEnumConstantsSyntaxError(t *testing.T) {
+	_, err := EnumConstants("package logs\n\nconst (\n\tLevelDebug Level = iota\n", "Level")
+	assert.Error(t, err, "a file that does not parse is not a file without constants")
+}
@@ -0,0 +1,75 @@
+// Package examples demonstrates validating JSON payloads against schemas
+// with pkg/jsonschema, before a REST API decodes them: a schema says which
+// properties a request must have, of which types and in which ranges, and
+// the validator lists everything wrong with a request at once, each
+// problem with the path of the value it is about.
+//
+// This file shows:
+// - Writing a schema as JSON, and parsing it once with jsonschema.MustParse
+// - Validating a payload, and telling Errors from JSON that does not parse
+// - JSON Pointer paths, such as /tags/1, in each problem
+// - "integer" against "number": 2.0 is an integer, and 2.5 is not
+package examples
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/jsonschema"
+)
+
+// NoteSchema is the body of a request that saves a note.
+var NoteSchema = jsonschema.MustParse(`{
+	"type": "object",
+	"required": ["title", "stars"],
+	"properties": {
+		"title": {"type": "string", "minLength": 1, "maxLength": 80},
+		"stars": {"type": "integer", "minimum": 0, "maximum": 5},
+		"tags": {
+			"type": "array",
+			"maxItems": 3,
+			"items": {"type": "string", "minLength": 1}
+		}
+	}
+}`)
+
+// Problems returns what is wrong with the JSON document data under s, one
+// message per problem, or nil if nothing is. The error is for data that
+// is not JSON at all.
+func Problems(s *jsonschema.Schema, data []byte) ([]string, error) {
+	err := s.Validate(data)
+	var errs jsonschema.Errors
+	if !errors.As(err, &errs) {
+		return nil, err
+	}
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Error()
+	}
+	return messages, nil
+}
+
+// DemonstrateSchemas validates a few notes.
+func DemonstrateSchemas() {
+	fmt.Println("=== Validating payloads ===")
+	notes := []string{
+		`{"title": "Go", "stars": 5, "tags": ["lang"]}`,
+		`{"title": "Go", "stars": 5.0}`,
+		`{"title": "", "stars": 4.5, "tags": ["lang", "", "go", "web"]}`,
+		`{"stars": "five"}`,
+		`{"title": "Go",`,
+	}
+	for _, note := range notes {
+		fmt.Println(note)
+		problems, err := Problems(NoteSchema, []byte(note))
+		switch {
+		case err != nil:
+			fmt.Println("  not JSON:", err)
+		case problems == nil:
+			fmt.Println("  valid")
+		}
+		for _, p := range problems {
+			fmt.Println("  " + p)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateSchemas(t *testing.T) {
+	DemonstrateSchemas()
+}
+
+func TestProblems(t *testing.T) {
+	problems, err := Problems(NoteSchema, []byte(`{"title": "Go", "stars": 3, "tags": ["a", 2]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/tags/1: is integer, want string"}, problems)
+
+	problems, err = Problems(NoteSchema, []byte(`{"title": "Go", "stars": 3}`))
+	require.NoError(t, err)
+	assert.Nil(t, problems)
+}
+
+func TestProblemsNotJSON(t *testing.T) {
+	_, err := Problems(NoteSchema, []byte(`{"title": "Go"`))
+	assert.ErrorContains(t, err, "invalid JSON")
+}
+
+func TestIntegers(t *testing.T) {
+	for stars, valid := range map[string]bool{"5": true, "5.0": true, "4.5": false, "5e0": true, "6": false} {
+		problems, err := Problems(NoteSchema, []byte(`{"title": "Go", "stars": `+stars+`}`))
+		require.NoError(t, err)
+		assert.Equal(t, valid, problems == nil, "stars %s: %v", stars, problems)
+	}
+}
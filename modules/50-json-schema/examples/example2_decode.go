@@ -0,0 +1,76 @@
+package examples
+
+// This file shows:
+// - What json.Unmarshal accepts: missing fields become zero values, and unknown ones vanish
+// - Decoder.DisallowUnknownFields, which stops at the first unknown field
+// - Validating the raw body first, then decoding it into a struct that needs no more checks
+// - Why validation must see the body, not the struct: a missing field and a zero one look alike
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/jsonschema"
+)
+
+// Signup is the body of a request that creates an account.
+type Signup struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Age      int    `json:"age,omitempty"`
+}
+
+// SignupSchema is Signup's schema. A missing age is allowed; a zero one
+// is not.
+var SignupSchema = jsonschema.MustParse(`{
+	"type": "object",
+	"required": ["email", "password"],
+	"additionalProperties": false,
+	"properties": {
+		"email": {"type": "string", "minLength": 3, "maxLength": 254},
+		"password": {"type": "string", "minLength": 8},
+		"age": {"type": "integer", "minimum": 13}
+	}
+}`)
+
+// DecodeLoose decodes a Signup as json.Unmarshal does.
+func DecodeLoose(data []byte) (Signup, error) {
+	var s Signup
+	err := json.Unmarshal(data, &s)
+	return s, err
+}
+
+// DecodeStrict decodes a Signup, failing on the first unknown field.
+func DecodeStrict(data []byte) (Signup, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var s Signup
+	err := dec.Decode(&s)
+	return s, err
+}
+
+// DecodeValid validates data against schema, and only then decodes it
+// into v, so that v holds a payload the schema accepts.
+func DecodeValid(schema *jsonschema.Schema, data []byte, v any) error {
+	if err := schema.Validate(data); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// DemonstrateDecode decodes a signup with a misspelled field three ways.
+func DemonstrateDecode() {
+	fmt.Println("=== Decoding payloads ===")
+	body := []byte(`{"email": "ada@example.com", "pasword": "correct horse", "age": 0}`)
+	fmt.Println(string(body))
+
+	s, err := DecodeLoose(body)
+	fmt.Printf("Loose:  %+v, error %v\n", s, err)
+
+	s, err = DecodeStrict(body)
+	fmt.Printf("Strict: %+v, error %v\n", s, err)
+
+	err = DecodeValid(SignupSchema, body, &s)
+	fmt.Println("Valid: ", err)
+}
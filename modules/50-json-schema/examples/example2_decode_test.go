@@ -0,0 +1,34 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateDecode(t *testing.T) {
+	DemonstrateDecode()
+}
+
+func TestDecodeLooseAndStrict(t *testing.T) {
+	body := []byte(`{"email": "ada@example.com", "pasword": "correct horse"}`)
+	s, err := DecodeLoose(body)
+	require.NoError(t, err, "json.Unmarshal ignores unknown fields")
+	assert.Empty(t, s.Password)
+
+	_, err = DecodeStrict(body)
+	assert.ErrorContains(t, err, `unknown field "pasword"`)
+}
+
+func TestDecodeValid(t *testing.T) {
+	var s Signup
+	require.NoError(t, DecodeValid(SignupSchema, []byte(`{"email": "ada@example.com", "password": "correct horse", "age": 36}`), &s))
+	assert.Equal(t, Signup{Email: "ada@example.com", Password: "correct horse", Age: 36}, s)
+
+	err := DecodeValid(SignupSchema, []byte(`{"email": "ada@example.com", "age": 0}`), &s)
+	var errs jsonschema.Errors
+	require.ErrorAs(t, err, &errs)
+	assert.Len(t, errs, 2, "a missing password, and an age of zero: %v", errs)
+}
@@ -0,0 +1,90 @@
+package examples
+
+// This file shows:
+// - Middleware that validates a request body before the handler sees it
+// - Reading the body once, with http.MaxBytesReader, and giving the handler a fresh reader
+// - Status codes: 400 for a body that is not JSON, 413 for one too large, 422 for one the schema rejects
+// - Answering with every problem, as JSON a client can match to its form fields
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/jsonschema"
+)
+
+// Problem is one thing wrong with a request.
+type Problem struct {
+	Path    string `json:"path"` // a JSON Pointer into the body; empty for the whole body
+	Message string `json:"message"`
+}
+
+// WriteProblems answers with status and {"problems": [...]}.
+func WriteProblems(w http.ResponseWriter, status int, problems ...Problem) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string][]Problem{"problems": problems})
+}
+
+// Validated returns a handler that reads the body, at most maxBytes of it,
+// validates it against schema, and calls next only if it is valid, with
+// the body ready to be read again.
+func Validated(schema *jsonschema.Schema, maxBytes int64, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBytes))
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			WriteProblems(w, http.StatusRequestEntityTooLarge, Problem{Message: fmt.Sprintf("is over %d bytes", maxBytes)})
+			return
+		case err != nil:
+			WriteProblems(w, http.StatusBadRequest, Problem{Message: err.Error()})
+			return
+		}
+
+		err = schema.Validate(body)
+		var errs jsonschema.Errors
+		switch {
+		case errors.As(err, &errs):
+			problems := make([]Problem, len(errs))
+			for i, e := range errs {
+				problems[i] = Problem{Path: e.Path, Message: e.Message}
+			}
+			WriteProblems(w, http.StatusUnprocessableEntity, problems...)
+			return
+		case err != nil:
+			WriteProblems(w, http.StatusBadRequest, Problem{Message: err.Error()})
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// DemonstrateHandler posts notes to a handler behind Validated.
+func DemonstrateHandler() {
+	fmt.Println("=== Validating requests ===")
+	h := Validated(NoteSchema, 1<<10, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var note struct{ Title string }
+		json.NewDecoder(r.Body).Decode(&note)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, "saved %q\n", note.Title)
+	}))
+	bodies := []string{
+		`{"title": "Go", "stars": 5}`,
+		`{"title": "Go", "stars": 7, "tags": [1]}`,
+		`{"title": "Go" "stars": 5}`,
+		`{"title": "` + strings.Repeat("Go", 1000) + `", "stars": 5}`,
+	}
+	for _, body := range bodies {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/notes", strings.NewReader(body)))
+		fmt.Printf("%d %s", rec.Code, rec.Body)
+	}
+}
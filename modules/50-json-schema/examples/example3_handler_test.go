@@ -0,0 +1,59 @@
+package examples
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateHandler(t *testing.T) {
+	DemonstrateHandler()
+}
+
+// echo answers with the body it reads.
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	io.Copy(w, r.Body)
+})
+
+func serve(h http.Handler, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/notes", strings.NewReader(body)))
+	return rec
+}
+
+func TestValidatedPassesTheBody(t *testing.T) {
+	body := `{"title": "Go", "stars": 4}`
+	rec := serve(Validated(NoteSchema, 1<<10, echo), body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, body, rec.Body.String())
+}
+
+func TestValidatedStatuses(t *testing.T) {
+	h := Validated(NoteSchema, 64, echo)
+	tests := map[string]int{
+		`{"title": "Go", "stars": -1}`:                 http.StatusUnprocessableEntity,
+		`{"title": "Go", "stars": }`:                   http.StatusBadRequest,
+		`{"title": "` + strings.Repeat("x", 64) + `"}`: http.StatusRequestEntityTooLarge,
+	}
+	for body, status := range tests {
+		rec := serve(h, body)
+		assert.Equal(t, status, rec.Code, body)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	}
+}
+
+func TestValidatedListsEveryProblem(t *testing.T) {
+	rec := serve(Validated(NoteSchema, 1<<10, echo), `{"stars": 9, "tags": []}`)
+	var got struct{ Problems []Problem }
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, []Problem{
+		{Path: "/title", Message: "is required"},
+		{Path: "/stars", Message: "is 9, want at most 5"},
+	}, got.Problems)
+}
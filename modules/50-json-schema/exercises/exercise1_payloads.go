@@ -0,0 +1,47 @@
+package exercises
+
+// EXERCISE: Add the schema rules that let malformed signups and profile updates through.
+// The REST API checks each request body against a schema before it
+// decodes it, so a handler can trust what it gets. But these schemas are
+// missing rules: a signup needs no password, or one of a single
+// character; an age can be 12.5; and a profile update with a misspelled
+// property, a bio the length of an essay, or numbers for languages is
+// accepted as if it were fine.
+// Fix the bugs marked with // BUG: comments.
+
+import "github.com/TheAnarchoX/LearningGoTheHardWay/pkg/jsonschema"
+
+// SignupSchema is the body of POST /signup, which creates an account.
+// BUG: "required" leaves out the password, so a signup without one is valid.
+// BUG: password has no minLength, which should be 8.
+// BUG: age is a "number", so 30.5 passes, and it has no minimum of 13.
+var SignupSchema = jsonschema.MustParse(`{
+	"type": "object",
+	"required": ["email", "name"],
+	"additionalProperties": false,
+	"properties": {
+		"email": {"type": "string", "minLength": 3, "maxLength": 254},
+		"password": {"type": "string", "maxLength": 72},
+		"name": {"type": "string", "minLength": 1, "maxLength": 100},
+		"age": {"type": "number", "maximum": 130}
+	}
+}`)
+
+// ProfileSchema is the body of PATCH /me, which changes the properties it
+// names, and leaves the others alone.
+// BUG: other properties are allowed, so {"nmae": "Ada"} changes nothing, and succeeds.
+// BUG: bio has no maxLength, which should be 160.
+// BUG: languages has no schema for its items, so [1, 2] passes; they are
+// language tags such as "en" or "pt-BR", strings of 2 to 35 characters.
+var ProfileSchema = jsonschema.MustParse(`{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string", "minLength": 1, "maxLength": 100},
+		"bio": {"type": "string"},
+		"theme": {"enum": ["light", "dark", "system"]},
+		"languages": {
+			"type": "array",
+			"maxItems": 5
+		}
+	}
+}`)
@@ -0,0 +1,84 @@
+package exercises
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/faker"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/jsonschema"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// malformed is a payload, and the path of the problem a schema must find
+// in it.
+type malformed struct {
+	name, body, path string
+}
+
+// assertRejects checks that s finds a problem at each payload's path.
+func assertRejects(t *testing.T, s *jsonschema.Schema, tests []malformed) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Validate([]byte(tt.body))
+			var errs jsonschema.Errors
+			require.ErrorAs(t, err, &errs, "%s is accepted", tt.body)
+			var paths []string
+			for _, e := range errs {
+				paths = append(paths, e.Path)
+			}
+			assert.Contains(t, paths, tt.path, "%s: %v", tt.body, errs)
+		})
+	}
+}
+
+func TestSignupValid(t *testing.T) {
+	f := faker.For(t, 1)
+	for i := 0; i < 20; i++ {
+		p := f.Person()
+		signup := map[string]any{"email": p.Email, "password": "correct horse battery", "name": p.Name}
+		if i%2 == 0 {
+			signup["age"] = seed.Between(f.Rand(), 13, 130)
+		}
+		body, err := json.Marshal(signup)
+		require.NoError(t, err)
+		assert.NoError(t, SignupSchema.Validate(body), "%s", body)
+	}
+}
+
+func TestSignupMalformed(t *testing.T) {
+	assertRejects(t, SignupSchema, []malformed{
+		{"no password", `{"email": "ada@example.com", "name": "Ada"}`, "/password"},
+		{"short password", `{"email": "ada@example.com", "password": "hunter2", "name": "Ada"}`, "/password"},
+		{"fractional age", `{"email": "ada@example.com", "password": "correct horse", "name": "Ada", "age": 30.5}`, "/age"},
+		{"child", `{"email": "ada@example.com", "password": "correct horse", "name": "Ada", "age": 12}`, "/age"},
+		{"no email", `{"password": "correct horse", "name": "Ada"}`, "/email"},
+		{"admin", `{"email": "ada@example.com", "password": "correct horse", "name": "Ada", "admin": true}`, "/admin"},
+	})
+}
+
+func TestProfileValid(t *testing.T) {
+	valid := []string{
+		`{}`,
+		`{"name": "Ada Lovelace"}`,
+		`{"bio": "` + strings.Repeat("b", 160) + `", "theme": "system"}`,
+		`{"bio": "", "languages": ["en", "de-CH", "pt-BR"]}`,
+	}
+	for _, body := range valid {
+		assert.NoError(t, ProfileSchema.Validate([]byte(body)), body)
+	}
+}
+
+func TestProfileMalformed(t *testing.T) {
+	assertRejects(t, ProfileSchema, []malformed{
+		{"misspelled", `{"nmae": "Ada"}`, "/nmae"},
+		{"long bio", `{"bio": "` + strings.Repeat("b", 161) + `"}`, "/bio"},
+		{"numbered languages", `{"languages": [1, 2]}`, "/languages/0"},
+		{"empty language", `{"languages": ["en", ""]}`, "/languages/1"},
+		{"theme", `{"theme": "sepia"}`, "/theme"},
+		{"empty name", `{"name": ""}`, "/name"},
+	})
+}
@@ -0,0 +1,108 @@
+package exercises
+
+// EXERCISE: Fix a handler that validates the wrong bytes and hides most of what is wrong.
+// CreateTask is the REST API's POST /tasks. It should check the body
+// against a schema and answer with every problem it finds, so that a
+// client can mark each field of its form. But it validates the task after
+// decoding it, when a missing priority has become 0 and an unknown field
+// has gone; a priority of "high" never reaches the schema; it answers 400
+// with the first problem only; and it reads a body of any size.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/jsonschema"
+)
+
+// MaxTaskBody is the largest request body CreateTask reads, in bytes.
+const MaxTaskBody = 1 << 14
+
+// NewTask is the body of POST /tasks.
+type NewTask struct {
+	Title    string   `json:"title"`
+	Priority int      `json:"priority"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// Task is a saved task.
+type Task struct {
+	ID int `json:"id"`
+	NewTask
+}
+
+// Problem is one thing wrong with a request.
+type Problem struct {
+	Path    string `json:"path"` // a JSON Pointer into the body; empty for the whole body
+	Message string `json:"message"`
+}
+
+// newTaskSchema is NewTask's schema.
+var newTaskSchema = jsonschema.MustParse(`{
+	"type": "object",
+	"required": ["title", "priority"],
+	"additionalProperties": false,
+	"properties": {
+		"title": {"type": "string", "minLength": 1, "maxLength": 200},
+		"priority": {"type": "integer", "minimum": 1, "maximum": 5},
+		"tags": {
+			"type": "array",
+			"maxItems": 10,
+			"items": {"type": "string", "minLength": 1, "maxLength": 30}
+		}
+	}
+}`)
+
+// respond writes v as JSON with status.
+func respond(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// problems answers with status and {"problems": [...]}.
+func problems(w http.ResponseWriter, status int, list ...Problem) {
+	respond(w, status, map[string][]Problem{"problems": list})
+}
+
+// CreateTask returns the handler for POST /tasks. It answers 201 with the
+// Task that save returns for a valid body, and otherwise with the
+// problems: 413 for a body over MaxTaskBody, 400 for one that is not
+// JSON, and 422, listing all of them, for one the schema rejects.
+func CreateTask(save func(NewTask) Task) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// BUG: nothing limits how much of the body is read.
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			problems(w, http.StatusBadRequest, Problem{Message: err.Error()})
+			return
+		}
+
+		// BUG: decoding before validating turns a missing field into its
+		// zero value, drops unknown fields, and stops at a wrong type,
+		// so the schema never sees the body that was sent.
+		var task NewTask
+		if err := json.Unmarshal(body, &task); err != nil {
+			problems(w, http.StatusBadRequest, Problem{Message: err.Error()})
+			return
+		}
+		decoded, _ := json.Marshal(task)
+
+		err = newTaskSchema.Validate(decoded)
+		var errs jsonschema.Errors
+		if errors.As(err, &errs) {
+			// BUG: valid JSON that breaks the rules is a 422, and the
+			// client needs every problem, not just the first.
+			problems(w, http.StatusBadRequest, Problem{Path: errs[0].Path, Message: errs[0].Message})
+			return
+		}
+		if err != nil {
+			problems(w, http.StatusBadRequest, Problem{Message: err.Error()})
+			return
+		}
+		respond(w, http.StatusCreated, save(task))
+	})
+}
@@ -0,0 +1,39 @@
+package solutions
+
+// SOLUTION: Add the schema rules that let malformed signups and profile updates through.
+// Fixed: SignupSchema requires a password, of at least 8 characters
+// Fixed: age is an integer, and at least 13
+// Fixed: ProfileSchema allows no properties it does not list
+// Fixed: a bio is at most 160 characters, and languages are strings of 2 to 35
+
+import "github.com/TheAnarchoX/LearningGoTheHardWay/pkg/jsonschema"
+
+// SignupSchema is the body of POST /signup, which creates an account.
+var SignupSchema = jsonschema.MustParse(`{
+	"type": "object",
+	"required": ["email", "password", "name"],
+	"additionalProperties": false,
+	"properties": {
+		"email": {"type": "string", "minLength": 3, "maxLength": 254},
+		"password": {"type": "string", "minLength": 8, "maxLength": 72},
+		"name": {"type": "string", "minLength": 1, "maxLength": 100},
+		"age": {"type": "integer", "minimum": 13, "maximum": 130}
+	}
+}`)
+
+// ProfileSchema is the body of PATCH /me, which changes the properties it
+// names, and leaves the others alone.
+var ProfileSchema = jsonschema.MustParse(`{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"name": {"type": "string", "minLength": 1, "maxLength": 100},
+		"bio": {"type": "string", "maxLength": 160},
+		"theme": {"enum": ["light", "dark", "system"]},
+		"languages": {
+			"type": "array",
+			"maxItems": 5,
+			"items": {"type": "string", "minLength": 2, "maxLength": 35}
+		}
+	}
+}`)
@@ -0,0 +1,108 @@
+package solutions
+
+// SOLUTION: Fix a handler that validates the wrong bytes and hides most of what is wrong.
+// Fixed: the body is read through http.MaxBytesReader, and one too large is a 413
+// Fixed: the schema checks the body as sent, before it is decoded
+// Fixed: a body the schema rejects is a 422, not a 400
+// Fixed: the response lists every problem, not the first
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/jsonschema"
+)
+
+// MaxTaskBody is the largest request body CreateTask reads, in bytes.
+const MaxTaskBody = 1 << 14
+
+// NewTask is the body of POST /tasks.
+type NewTask struct {
+	Title    string   `json:"title"`
+	Priority int      `json:"priority"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// Task is a saved task.
+type Task struct {
+	ID int `json:"id"`
+	NewTask
+}
+
+// Problem is one thing wrong with a request.
+type Problem struct {
+	Path    string `json:"path"` // a JSON Pointer into the body; empty for the whole body
+	Message string `json:"message"`
+}
+
+// newTaskSchema is NewTask's schema.
+var newTaskSchema = jsonschema.MustParse(`{
+	"type": "object",
+	"required": ["title", "priority"],
+	"additionalProperties": false,
+	"properties": {
+		"title": {"type": "string", "minLength": 1, "maxLength": 200},
+		"priority": {"type": "integer", "minimum": 1, "maximum": 5},
+		"tags": {
+			"type": "array",
+			"maxItems": 10,
+			"items": {"type": "string", "minLength": 1, "maxLength": 30}
+		}
+	}
+}`)
+
+// respond writes v as JSON with status.
+func respond(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// problems answers with status and {"problems": [...]}.
+func problems(w http.ResponseWriter, status int, list ...Problem) {
+	respond(w, status, map[string][]Problem{"problems": list})
+}
+
+// CreateTask returns the handler for POST /tasks. It answers 201 with the
+// Task that save returns for a valid body, and otherwise with the
+// problems: 413 for a body over MaxTaskBody, 400 for one that is not
+// JSON, and 422, listing all of them, for one the schema rejects.
+func CreateTask(save func(NewTask) Task) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxTaskBody))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				problems(w, http.StatusRequestEntityTooLarge, Problem{Message: fmt.Sprintf("is over %d bytes", MaxTaskBody)})
+				return
+			}
+			problems(w, http.StatusBadRequest, Problem{Message: err.Error()})
+			return
+		}
+
+		err = newTaskSchema.Validate(body)
+		var errs jsonschema.Errors
+		if errors.As(err, &errs) {
+			list := make([]Problem, len(errs))
+			for i, e := range errs {
+				list[i] = Problem{Path: e.Path, Message: e.Message}
+			}
+			problems(w, http.StatusUnprocessableEntity, list...)
+			return
+		}
+		if err != nil {
+			problems(w, http.StatusBadRequest, Problem{Message: err.Error()})
+			return
+		}
+
+		var task NewTask
+		if err := json.Unmarshal(body, &task); err != nil {
+			problems(w, http.StatusBadRequest, Problem{Message: err.Error()})
+			return
+		}
+		respond(w, http.StatusCreated, save(task))
+	})
+}
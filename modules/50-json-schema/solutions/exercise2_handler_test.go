@@ -0,0 +1,87 @@
+package solutions
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// post sends body to a CreateTask handler whose save numbers the tasks
+// from 1, and returns the response and the tasks saved.
+func post(t *testing.T, body string) (*http.Response, []NewTask) {
+	t.Helper()
+	var saved []NewTask
+	h := CreateTask(func(nt NewTask) Task {
+		saved = append(saved, nt)
+		return Task{ID: len(saved), NewTask: nt}
+	})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body)))
+	resp := rec.Result()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	return resp, saved
+}
+
+// problemsOf decodes the problems in resp, and checks its status.
+func problemsOf(t *testing.T, resp *http.Response, status int) []Problem {
+	t.Helper()
+	require.Equal(t, status, resp.StatusCode)
+	var got struct{ Problems []Problem }
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	require.NotEmpty(t, got.Problems)
+	return got.Problems
+}
+
+func TestCreateTask(t *testing.T) {
+	priority := seed.Between(seed.Rand(t, 1), 1, 5)
+	resp, saved := post(t, fmt.Sprintf(`{"title": "Write the schema", "priority": %d, "tags": ["api"]}`, priority))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var task Task
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&task))
+	want := NewTask{Title: "Write the schema", Priority: priority, Tags: []string{"api"}}
+	assert.Equal(t, Task{ID: 1, NewTask: want}, task)
+	assert.Equal(t, []NewTask{want}, saved)
+}
+
+func TestCreateTaskMissingFields(t *testing.T) {
+	resp, saved := post(t, `{"tags": []}`)
+	assert.Equal(t, []Problem{
+		{Path: "/title", Message: "is required"},
+		{Path: "/priority", Message: "is required"},
+	}, problemsOf(t, resp, http.StatusUnprocessableEntity))
+	assert.Empty(t, saved)
+}
+
+func TestCreateTaskEveryProblem(t *testing.T) {
+	resp, saved := post(t, `{"title": "", "priority": "high", "tags": ["api", ""], "colour": "red"}`)
+	assert.Equal(t, []Problem{
+		{Path: "/colour", Message: "is not allowed"},
+		{Path: "/priority", Message: "is string, want integer"},
+		{Path: "/tags/1", Message: "has 0 characters, want at least 1"},
+		{Path: "/title", Message: "has 0 characters, want at least 1"},
+	}, problemsOf(t, resp, http.StatusUnprocessableEntity))
+	assert.Empty(t, saved)
+}
+
+func TestCreateTaskNotJSON(t *testing.T) {
+	for _, body := range []string{``, `{"title": "x", "priority": 1`, `{"title": 'x'}`} {
+		resp, saved := post(t, body)
+		problems := problemsOf(t, resp, http.StatusBadRequest)
+		assert.Empty(t, problems[0].Path, body)
+		assert.Empty(t, saved)
+	}
+}
+
+func TestCreateTaskTooLarge(t *testing.T) {
+	body := `{"title": "x", "priority": 1, "tags": ["` + strings.Repeat("a", MaxTaskBody) + `"]}`
+	resp, saved := post(t, body)
+	problemsOf(t, resp, http.StatusRequestEntityTooLarge)
+	assert.Empty(t, saved)
+}
@@ -0,0 +1,94 @@
+// Package examples demonstrates the reflect package and struct tags: a
+// program can look at the type of a value it knows nothing about, list
+// the fields of a struct with the tags written beside them, and read or
+// set those fields. Validators, encoders such as encoding/json, and ORMs
+// are built this way.
+//
+// This file shows:
+// - reflect.TypeOf and reflect.ValueOf, and the Kind that groups types
+// - Listing a struct's fields, with their types and tags
+// - Unexported fields, which reflection can see but not read as interfaces
+// - Following pointers with Elem, and the nil pointers that have nothing behind them
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Field describes a field of a struct.
+type Field struct {
+	Name     string
+	Type     string
+	Kind     reflect.Kind
+	Tag      reflect.StructTag
+	Exported bool
+}
+
+// Fields describes the fields of the struct v, or of the struct v points
+// to, and returns an error for anything else. It needs only the type, so
+// a nil *T will do.
+func Fields(v any) ([]Field, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, errors.New("fields of nil")
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("fields of %T: not a struct", v)
+	}
+	fields := make([]Field, t.NumField())
+	for i := range fields {
+		f := t.Field(i)
+		fields[i] = Field{Name: f.Name, Type: f.Type.String(), Kind: f.Type.Kind(), Tag: f.Tag, Exported: f.IsExported()}
+	}
+	return fields, nil
+}
+
+// Deref follows v through any number of pointers, and reports false if
+// one of them is nil.
+func Deref(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, v.IsValid()
+}
+
+// profile is a struct to look at.
+type profile struct {
+	Name    string `json:"name" validate:"required"`
+	Age     int    `json:"age,omitempty" validate:"min=13"`
+	Manager *profile
+	secret  string
+}
+
+// DemonstrateReflect looks at a few values, and the fields of a struct.
+func DemonstrateReflect() {
+	fmt.Println("=== reflect ===")
+	type Celsius float64
+	for _, v := range []any{42, Celsius(21.5), "go", []int{1}, &profile{}, nil} {
+		if v == nil {
+			fmt.Println("nil has no type")
+			continue
+		}
+		fmt.Printf("%-12s type %-18s kind %s\n", fmt.Sprint(v), reflect.TypeOf(v), reflect.TypeOf(v).Kind())
+	}
+
+	fields, _ := Fields(&profile{})
+	for _, f := range fields {
+		fmt.Printf("%-8s %-17s exported=%-5v tag %q\n", f.Name, f.Type, f.Exported, f.Tag)
+	}
+
+	p := profile{Name: "Ada", secret: "hidden"}
+	v := reflect.ValueOf(p)
+	fmt.Println("secret:", v.FieldByName("secret").String(), "CanInterface:", v.FieldByName("secret").CanInterface())
+	if _, ok := Deref(v.FieldByName("Manager")); !ok {
+		fmt.Println("Manager is a nil pointer")
+	}
+}
@@ -0,0 +1,50 @@
+package examples
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateReflect(t *testing.T) {
+	DemonstrateReflect()
+}
+
+func TestFields(t *testing.T) {
+	fields, err := Fields(profile{})
+	require.NoError(t, err)
+	require.Len(t, fields, 4)
+	assert.Equal(t, Field{Name: "Age", Type: "int", Kind: reflect.Int, Tag: `json:"age,omitempty" validate:"min=13"`, Exported: true}, fields[1])
+	assert.Equal(t, reflect.Pointer, fields[2].Kind)
+	assert.False(t, fields[3].Exported)
+
+	pointed, err := Fields(&profile{})
+	require.NoError(t, err)
+	assert.Equal(t, fields, pointed)
+}
+
+func TestFieldsErrors(t *testing.T) {
+	for _, v := range []any{nil, 42, []profile{}} {
+		_, err := Fields(v)
+		assert.Error(t, err, "%T", v)
+	}
+	var p *profile
+	_, err := Fields(p)
+	assert.NoError(t, err, "a nil *profile still has a type")
+}
+
+func TestDeref(t *testing.T) {
+	n := 7
+	p := &n
+	v, ok := Deref(reflect.ValueOf(&p))
+	require.True(t, ok)
+	assert.Equal(t, int64(7), v.Int())
+
+	p = nil
+	_, ok = Deref(reflect.ValueOf(&p))
+	assert.False(t, ok)
+	_, ok = Deref(reflect.Value{})
+	assert.False(t, ok)
+}
@@ -0,0 +1,83 @@
+package examples
+
+// This file shows:
+// - The key:"value" convention of struct tags, and StructTag.Get and Lookup
+// - Options after the name, as in json:"age,omitempty"
+// - A tag of comma-separated rules, each with an optional =parameter
+// - go vet's structtag check, which catches tags that do not follow the convention
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Column is a struct field as a database table would store it.
+type Column struct {
+	Field     string
+	Name      string
+	OmitEmpty bool
+}
+
+// Columns lists the columns of the struct type of v, from db tags such as
+// `db:"user_name,omitempty"`. A field without a db tag is a column named
+// after the field in lower case, and one tagged `db:"-"` is not a column.
+func Columns(v any) []Column {
+	t := reflect.TypeOf(v)
+	var cols []Column
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag, ok := f.Tag.Lookup("db")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if !ok || name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		cols = append(cols, Column{Field: f.Name, Name: name, OmitEmpty: opts == "omitempty"})
+	}
+	return cols
+}
+
+// Rule is one rule of a validate tag: for "min=3", the name "min" and the
+// parameter "3".
+type Rule struct {
+	Name, Param string
+}
+
+// ParseRules splits a validate tag such as "required,min=3" into rules.
+func ParseRules(tag string) []Rule {
+	var rules []Rule
+	for _, r := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(r), "=")
+		if name != "" {
+			rules = append(rules, Rule{Name: name, Param: param})
+		}
+	}
+	return rules
+}
+
+// account is a struct with tags for two packages.
+type account struct {
+	ID       string `db:"account_id" validate:"required"`
+	Owner    string `db:"owner_name,omitempty" validate:"required,min=2"`
+	Balance  int64  `validate:"min=0"`
+	Internal string `db:"-"`
+}
+
+// DemonstrateTags reads the tags of a struct.
+func DemonstrateTags() {
+	fmt.Println("=== Struct tags ===")
+	for _, c := range Columns(account{}) {
+		fmt.Printf("%-8s -> %-10s omitempty=%v\n", c.Field, c.Name, c.OmitEmpty)
+	}
+	f, _ := reflect.TypeOf(account{}).FieldByName("Owner")
+	fmt.Printf("validate tag %q: %+v\n", f.Tag.Get("validate"), ParseRules(f.Tag.Get("validate")))
+
+	_, ok := f.Tag.Lookup("json")
+	fmt.Println("json tag present:", ok)
+}
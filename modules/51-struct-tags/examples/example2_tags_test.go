@@ -0,0 +1,31 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateTags(t *testing.T) {
+	DemonstrateTags()
+}
+
+func TestColumns(t *testing.T) {
+	assert.Equal(t, []Column{
+		{Field: "ID", Name: "account_id"},
+		{Field: "Owner", Name: "owner_name", OmitEmpty: true},
+		{Field: "Balance", Name: "balance"},
+	}, Columns(account{}))
+
+	type row struct {
+		Name   string `db:",omitempty"`
+		hidden int
+	}
+	assert.Equal(t, []Column{{Field: "Name", Name: "name", OmitEmpty: true}}, Columns(row{}))
+}
+
+func TestParseRules(t *testing.T) {
+	assert.Equal(t, []Rule{{Name: "required"}, {Name: "min", Param: "3"}, {Name: "oneof", Param: "a b"}},
+		ParseRules("required, min=3,,oneof=a b"))
+	assert.Nil(t, ParseRules(""))
+}
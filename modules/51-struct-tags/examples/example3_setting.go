@@ -0,0 +1,108 @@
+package examples
+
+// This file shows:
+// - Setting fields through reflection, which needs a pointer: CanSet is false for a copy
+// - Converting a tag's text to the field's kind with strconv
+// - Filling in defaults from `default:"..."` tags for fields left at their zero value
+// - Returning errors for what reflection cannot do, rather than letting it panic
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ApplyDefaults sets each zero field of the struct ptr points to, that has
+// a default tag, to the value in the tag. It handles strings, booleans,
+// integers, floats, and time.Duration.
+func ApplyDefaults(ptr any) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("defaults: want a non-nil pointer to a struct, not %T", ptr)
+	}
+	v = v.Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		def, ok := t.Field(i).Tag.Lookup("default")
+		f := v.Field(i)
+		if !ok || !f.IsZero() {
+			continue
+		}
+		if !f.CanSet() {
+			return fmt.Errorf("defaults: cannot set unexported field %s", t.Field(i).Name)
+		}
+		if err := setFromString(f, def); err != nil {
+			return fmt.Errorf("defaults: field %s: %w", t.Field(i).Name, err)
+		}
+	}
+	return nil
+}
+
+// durationType is checked before the kind, since a Duration's kind is
+// Int64.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setFromString sets f to the value s spells.
+func setFromString(f reflect.Value, s string) error {
+	if f.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(d))
+		return nil
+	}
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		x, err := strconv.ParseFloat(s, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetFloat(x)
+	default:
+		return fmt.Errorf("no defaults for %s", f.Type())
+	}
+	return nil
+}
+
+// server is a configuration with defaults.
+type server struct {
+	Addr    string        `default:":8080"`
+	Workers int8          `default:"4"`
+	Timeout time.Duration `default:"30s"`
+	Debug   bool
+}
+
+// DemonstrateSetting fills in the defaults of a configuration.
+func DemonstrateSetting() {
+	fmt.Println("=== Setting fields ===")
+	s := server{Workers: 16}
+	if err := ApplyDefaults(&s); err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Printf("%+v\n", s)
+
+	v := reflect.ValueOf(s).Field(0)
+	fmt.Println("CanSet on a copy:", v.CanSet())
+	fmt.Println("Error:", ApplyDefaults(s))
+
+	bad := struct {
+		Workers int8 `default:"400"`
+	}{}
+	fmt.Println("Error:", ApplyDefaults(&bad))
+}
@@ -0,0 +1,44 @@
+package examples
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateSetting(t *testing.T) {
+	DemonstrateSetting()
+}
+
+func TestApplyDefaults(t *testing.T) {
+	s := server{Addr: ":9000"}
+	require.NoError(t, ApplyDefaults(&s))
+	assert.Equal(t, server{Addr: ":9000", Workers: 4, Timeout: 30 * time.Second}, s, "set fields keep their values")
+
+	rates := struct {
+		Rate  float32 `default:"0.25"`
+		Debug bool    `default:"true"`
+	}{}
+	require.NoError(t, ApplyDefaults(&rates))
+	assert.Equal(t, float32(0.25), rates.Rate)
+	assert.True(t, rates.Debug)
+}
+
+func TestApplyDefaultsErrors(t *testing.T) {
+	var nilServer *server
+	for _, v := range []any{nil, server{}, nilServer, new(int)} {
+		assert.Error(t, ApplyDefaults(v), "%T", v)
+	}
+
+	unexported := struct {
+		addr string `default:":80"`
+	}{}
+	assert.ErrorContains(t, ApplyDefaults(&unexported), "unexported field addr")
+
+	list := struct {
+		Tags []string `default:"a"`
+	}{}
+	assert.ErrorContains(t, ApplyDefaults(&list), "no defaults for []string")
+}
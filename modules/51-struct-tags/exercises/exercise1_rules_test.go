@@ -0,0 +1,89 @@
+package exercises
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/faker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// check runs Check on v, and fails the test on an error.
+func check(t *testing.T, v any, tag string) []string {
+	t.Helper()
+	problems, err := Check(reflect.ValueOf(v), tag)
+	require.NoError(t, err, "%v with %q", v, tag)
+	return problems
+}
+
+func TestRequired(t *testing.T) {
+	var nilSlice []string
+	for _, v := range []any{"", 0, int64(0), 0.0, false, nilSlice} {
+		assert.Equal(t, []string{"is required"}, check(t, v, "required"), "%T", v)
+	}
+	for _, v := range []any{"x", -1, int64(100042), 0.5, true, []string{}} {
+		assert.Empty(t, check(t, v, "required"), "%T %v", v, v)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	tests := []struct {
+		v    any
+		tag  string
+		want []string
+	}{
+		{12, "min=13", []string{"must be at least 13"}},
+		{13, "min=13,max=130", nil},
+		{uint8(200), "max=130", []string{"must be at most 130"}},
+		{int64(-1), "min=0", []string{"must be at least 0"}},
+		{0.25, "min=0,max=1", nil},
+		{1.5, "min=0,max=1", []string{"must be at most 1"}},
+		{float32(-0.1), "min=0", []string{"must be at least 0"}},
+		{"Zoë", "min=3,max=3", nil},
+		{"Al", "min=3", []string{"length must be at least 3"}},
+		{[]string{"a", "b", "c"}, "max=2", []string{"length must be at most 2"}},
+		{map[string]int{}, "min=1", []string{"length must be at least 1"}},
+		{-5, "min=0,max=-10", []string{"must be at least 0", "must be at most -10"}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, check(t, tt.v, tt.tag), "%v with %q", tt.v, tt.tag)
+	}
+}
+
+func TestEmail(t *testing.T) {
+	for _, p := range faker.For(t, 1).People(20) {
+		assert.Empty(t, check(t, p.Email, "email"), p.Email)
+	}
+	for _, s := range []string{"ada", "ada@", "@example.com", "Ada <ada@example.com>", " ada@example.com", "ada@example.com, bob@example.com"} {
+		assert.Equal(t, []string{"must be an email address"}, check(t, s, "email"), s)
+	}
+	assert.Empty(t, check(t, "", "email"), "an empty address is for required to catch")
+	assert.Equal(t, []string{"is required"}, check(t, "", "required,email"))
+}
+
+func TestOneOf(t *testing.T) {
+	for _, s := range []string{"admin", "user", "guest"} {
+		assert.Empty(t, check(t, s, "oneof=admin user guest"), s)
+	}
+	for _, s := range []string{"ad", "user guest", "Admin", "root"} {
+		assert.Equal(t, []string{"must be one of admin user guest"}, check(t, s, "oneof=admin user guest"), s)
+	}
+}
+
+func TestTagMistakes(t *testing.T) {
+	tests := map[string]any{
+		"unknown rule":       "x",
+		"min=ten":            "x",
+		"max=ten":            "x",
+		"min=1":              true,
+		"max=1":              struct{}{},
+		"email":              42,
+		"oneof=a b":          []string{"a"},
+		"required,maximum=3": 4,
+	}
+	for tag, v := range tests {
+		_, err := Check(reflect.ValueOf(v), tag)
+		assert.Error(t, err, "%v with %q", v, tag)
+	}
+}
@@ -0,0 +1,152 @@
+package exercises
+
+// EXERCISE: Fix a struct validator that panics on nil pointers and loses track of nested fields.
+// Validate walks a struct with reflection, checks each field against the
+// rules in its validate tag, and reports what is wrong by field. But it
+// panics on nil, on a nil pointer, and on a UserAccount without a
+// Manager; the fields of an Address and of a Manager's Address are all
+// reported as one City; and the accounts in a slice are never checked.
+// It checks each field with Check, from exercise 1: fix that one first.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Errors are what is wrong with the fields of a struct, by the path of
+// the field: "Email", "Address.Country", or "Accounts[2].Balance".
+type Errors map[string][]string
+
+func (e Errors) Error() string {
+	paths := make([]string, 0, len(e))
+	for path := range e {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	msgs := make([]string, len(paths))
+	for i, path := range paths {
+		msgs[i] = path + ": " + strings.Join(e[path], ", ")
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate checks the fields of the struct v, or of the struct v points
+// to, against their validate tags with Check, and goes on into the
+// structs, pointers to structs, and slices of structs among them. It
+// returns Errors if fields break rules, and another error if v is not a
+// struct or a tag is wrong.
+//
+// A nil pointer field is valid unless its tag says "required"; the rest
+// of its rules apply to what it points to.
+func Validate(v any) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	// BUG: for nil, or a nil pointer, rv is the zero Value, which is no
+	// value at all, and Type panics on it.
+	if rv.Type().Kind() != reflect.Struct {
+		return fmt.Errorf("validate: %T is not a struct", v)
+	}
+	errs := Errors{}
+	if err := validateStruct(rv, "", errs); err != nil {
+		return err
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// validateStruct adds what is wrong with the fields of the struct v to
+// errs, with prefix before their names.
+func validateStruct(v reflect.Value, prefix string, errs Errors) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if err := validateValue(v.Field(i), prefix+field.Name, field.Tag.Get("validate"), errs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateValue adds what is wrong with v, at path, to errs.
+func validateValue(v reflect.Value, path, tag string, errs Errors) error {
+	// BUG: Elem of a nil pointer is the zero Value, and the rules panic on
+	// it. A nil pointer breaks its required rule, if it has one, and has
+	// nothing else to check; the other rules are for what it points to.
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	problems, err := Check(v, tag)
+	if err != nil {
+		return fmt.Errorf("validate: field %s: %w", path, err)
+	}
+	errs[path] = append(errs[path], problems...)
+	if len(errs[path]) == 0 {
+		delete(errs, path)
+	}
+
+	// BUG: the elements of a slice of structs are never validated; each
+	// is at the path Field[i], such as Accounts[2].
+	if v.Kind() == reflect.Struct {
+		// BUG: the nested fields' paths should start with this one's, as
+		// in Address.City, or every City is the same field.
+		return validateStruct(v, "", errs)
+	}
+	return nil
+}
+
+// cutRequired returns tag without its required rule, and whether it had
+// one.
+func cutRequired(tag string) (string, bool) {
+	var rest []string
+	found := false
+	for _, r := range strings.Split(tag, ",") {
+		if strings.TrimSpace(r) == "required" {
+			found = true
+		} else {
+			rest = append(rest, r)
+		}
+	}
+	return strings.Join(rest, ","), found
+}
+
+// Address is where someone lives.
+type Address struct {
+	Street  string `validate:"required"`
+	City    string `validate:"required"`
+	Country string `validate:"required,min=2,max=2"` // ISO 3166 code, such as "NL"
+}
+
+// Account is a bank account.
+type Account struct {
+	ID      string `validate:"required,min=10"` // e.g. "ACC-100042"
+	Balance int64  `validate:"min=0"`           // in cents
+}
+
+// UserAccount is a user of the bank, with their accounts.
+type UserAccount struct {
+	Name     string       `validate:"required,max=100"`
+	Email    string       `validate:"required,email"`
+	Role     string       `validate:"oneof=admin user guest"`
+	Age      int          `validate:"min=13"`
+	Nickname *string      `validate:"min=2"` // optional, but not empty if given
+	Address  *Address     `validate:"required"`
+	Manager  *UserAccount // optional
+	Accounts []Account    `validate:"max=5"`
+	password string       `validate:"min=8"` // unexported: not validated
+}
+
+// Config is the configuration of the bank's API server.
+type Config struct {
+	Addr       string   `validate:"required"`
+	Workers    *int     `validate:"required,min=1,max=64"`
+	SampleRate float64  `validate:"min=0,max=1"`
+	Admins     []string `validate:"max=10"`
+}
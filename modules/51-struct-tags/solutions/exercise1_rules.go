@@ -0,0 +1,152 @@
+package solutions
+
+// SOLUTION: Write the missing rule handlers of a struct-tag validator, and fix the broken ones.
+// Fixed: required uses IsZero, which knows the zero value of every kind
+// Fixed: measure handles floats, so min and max apply to them
+// Fixed: max is a Rule, the mirror image of min
+// Fixed: email parses the address with net/mail, and wants nothing around it
+// Fixed: oneof compares with whole words, not substrings
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rule checks v against the rule's parameter, the text after "=" in the
+// tag, and returns what is wrong with v, or "" if nothing is. The error
+// is for a rule that cannot apply, such as min on a bool, or a parameter
+// that does not parse: mistakes in the tag, not in v.
+type Rule func(v reflect.Value, param string) (string, error)
+
+// Rules are the rules a validate tag can name.
+var Rules = map[string]Rule{
+	"required": required,
+	"min":      minRule,
+	"max":      maxRule, // Fixed: The rule exists
+	"email":    email,
+	"oneof":    oneOf,
+}
+
+// Check checks v against each rule of a validate tag such as
+// "required,min=3", and returns what is wrong with it. A rule not in
+// Rules is an error.
+func Check(v reflect.Value, tag string) ([]string, error) {
+	var problems []string
+	for _, r := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(r), "=")
+		if name == "" {
+			continue
+		}
+		rule, ok := Rules[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+		problem, err := rule(v, param)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", name, err)
+		}
+		if problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	return problems, nil
+}
+
+// required wants v not to be its type's zero value.
+func required(v reflect.Value, _ string) (string, error) {
+	if v.IsZero() { // Fixed: The zero value of any kind, not only an empty string
+		return "is required", nil
+	}
+	return "", nil
+}
+
+// measure returns the size of v that min and max compare: a number's
+// value, a string's length in characters, or the length of a slice, array,
+// or map. The string is what the size is, for messages.
+func measure(v reflect.Value) (float64, string, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), "", nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), "", nil
+	case reflect.Float32, reflect.Float64: // Fixed: Floats have a size too
+		return v.Float(), "", nil
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), "length ", nil
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), "length ", nil
+	}
+	return 0, "", fmt.Errorf("does not apply to %s", v.Type())
+}
+
+// minRule wants v's size to be at least param.
+func minRule(v reflect.Value, param string) (string, error) {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return "", err
+	}
+	size, what, err := measure(v)
+	if err != nil {
+		return "", err
+	}
+	if size < limit {
+		return fmt.Sprintf("%smust be at least %s", what, param), nil
+	}
+	return "", nil
+}
+
+// maxRule wants v's size to be at most param.
+func maxRule(v reflect.Value, param string) (string, error) {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return "", err
+	}
+	size, what, err := measure(v)
+	if err != nil {
+		return "", err
+	}
+	if size > limit {
+		return fmt.Sprintf("%smust be at most %s", what, param), nil
+	}
+	return "", nil
+}
+
+// email wants v to be an email address and nothing else, such as
+// "ada@example.com" but not "Ada <ada@example.com>". An empty string is
+// left to required.
+func email(v reflect.Value, _ string) (string, error) {
+	if v.Kind() != reflect.String {
+		return "", fmt.Errorf("does not apply to %s", v.Type())
+	}
+	s := v.String()
+	if s == "" {
+		return "", nil
+	}
+	// Fixed: Parse the address, and want it to be all of s
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Name != "" || addr.Address != s {
+		return "must be an email address", nil
+	}
+	return "", nil
+}
+
+// oneOf wants v to be one of the space-separated words in param. An empty
+// string is left to required.
+func oneOf(v reflect.Value, param string) (string, error) {
+	if v.Kind() != reflect.String {
+		return "", fmt.Errorf("does not apply to %s", v.Type())
+	}
+	s := v.String()
+	if s == "" {
+		return "", nil
+	}
+	if !slices.Contains(strings.Fields(param), s) { // Fixed: Whole words only
+		return fmt.Sprintf("must be one of %s", param), nil
+	}
+	return "", nil
+}
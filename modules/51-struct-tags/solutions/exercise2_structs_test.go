@@ -0,0 +1,147 @@
+package solutions
+
+import (
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/faker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validUser returns a UserAccount that breaks no rules, from f.
+func validUser(f *faker.Faker) UserAccount {
+	p := f.Person()
+	u := UserAccount{
+		Name:    p.Name,
+		Email:   p.Email,
+		Role:    "user",
+		Age:     13 + f.Rand().Intn(80),
+		Address: &Address{Street: "Keizersgracht 1", City: "Amsterdam", Country: "NL"},
+	}
+	for _, a := range f.Accounts(1 + f.Rand().Intn(5)) {
+		u.Accounts = append(u.Accounts, Account{ID: a.ID, Balance: a.Balance})
+	}
+	return u
+}
+
+// validate runs Validate, failing the test instead of panicking, and
+// returns the Errors it finds.
+func validate(t *testing.T, v any) Errors {
+	t.Helper()
+	var err error
+	require.NotPanics(t, func() { err = Validate(v) })
+	if err == nil {
+		return nil
+	}
+	errs, ok := err.(Errors)
+	require.True(t, ok, "%v is not Errors", err)
+	return errs
+}
+
+func TestValidateValid(t *testing.T) {
+	f := faker.For(t, 1)
+	for i := 0; i < 10; i++ {
+		u := validUser(f)
+		if i%2 == 1 {
+			manager := validUser(f)
+			nickname := "Boss"
+			manager.Nickname = &nickname
+			u.Manager = &manager
+		}
+		assert.Nil(t, validate(t, u), "%+v", u)
+		assert.Nil(t, validate(t, &u), "a pointer to a valid struct")
+	}
+}
+
+func TestValidateFields(t *testing.T) {
+	u := validUser(faker.For(t, 2))
+	u.Name = ""
+	u.Email = "not an address"
+	u.Age = 12
+	u.Role = "root"
+	u.password = "x"
+	assert.Equal(t, Errors{
+		"Name":  {"is required"},
+		"Email": {"must be an email address"},
+		"Age":   {"must be at least 13"},
+		"Role":  {"must be one of admin user guest"},
+	}, validate(t, u))
+}
+
+func TestValidateNil(t *testing.T) {
+	var nilUser *UserAccount
+	for _, v := range []any{nil, nilUser, 42, "user"} {
+		var err error
+		require.NotPanics(t, func() { err = Validate(v) }, "%T", v)
+		assert.Error(t, err, "%T", v)
+		assert.NotPanics(t, func() { _ = err.Error() })
+		_, isErrors := err.(Errors)
+		assert.False(t, isErrors, "%T is not a struct to validate", v)
+	}
+}
+
+func TestValidatePointerFields(t *testing.T) {
+	u := validUser(faker.For(t, 3))
+	u.Address = nil
+	assert.Equal(t, Errors{"Address": {"is required"}}, validate(t, u))
+
+	u = validUser(faker.For(t, 3))
+	empty := ""
+	u.Nickname = &empty
+	assert.Equal(t, Errors{"Nickname": {"length must be at least 2"}}, validate(t, u), "the rules of a pointer are for what it points to")
+
+	var cfg Config
+	cfg.Addr = ":8080"
+	assert.Equal(t, Errors{"Workers": {"is required"}}, validate(t, cfg))
+	zero := 0
+	cfg.Workers = &zero
+	assert.Equal(t, Errors{"Workers": {"must be at least 1"}}, validate(t, cfg), "a pointer to 0 is there, so required holds")
+}
+
+func TestValidateNested(t *testing.T) {
+	f := faker.For(t, 4)
+	u := validUser(f)
+	manager := validUser(f)
+	u.Address.City = ""
+	manager.Address.Country = "NLD"
+	manager.Age = 0
+	u.Manager = &manager
+	assert.Equal(t, Errors{
+		"Address.City":            {"is required"},
+		"Manager.Age":             {"must be at least 13"},
+		"Manager.Address.Country": {"length must be at most 2"},
+	}, validate(t, u))
+}
+
+func TestValidateSlices(t *testing.T) {
+	u := validUser(faker.For(t, 5))
+	u.Accounts = append(u.Accounts[:1], Account{ID: "ACC-1", Balance: -100}, Account{Balance: 5})
+	assert.Equal(t, Errors{
+		"Accounts[1].ID":      {"length must be at least 10"},
+		"Accounts[1].Balance": {"must be at least 0"},
+		"Accounts[2].ID":      {"is required", "length must be at least 10"},
+	}, validate(t, u))
+
+	u.Accounts = make([]Account, 6)
+	for i := range u.Accounts {
+		u.Accounts[i] = Account{ID: "ACC-100042"}
+	}
+	assert.Equal(t, Errors{"Accounts": {"length must be at most 5"}}, validate(t, u))
+}
+
+func TestValidateTagMistakes(t *testing.T) {
+	bad := struct {
+		Name  string `validate:"required"`
+		Count int    `validate:"min=lots"`
+	}{}
+	err := Validate(bad)
+	require.Error(t, err)
+	_, isErrors := err.(Errors)
+	assert.False(t, isErrors, "a wrong tag is the program's mistake, not the value's")
+	assert.ErrorContains(t, err, "Count")
+}
+
+func TestErrorsError(t *testing.T) {
+	errs := Errors{"Name": {"is required"}, "Address.City": {"is required"}, "Age": {"must be at least 13", "must be at most 130"}}
+	assert.Equal(t, "Address.City: is required; Age: must be at least 13, must be at most 130; Name: is required", errs.Error())
+}
@@ -0,0 +1,55 @@
+// Package examples demonstrates copying values in Go, and what a copy
+// shares with the original: a slice, a map, or a pointer copied is a
+// second name for the same data. Copying deeply, by hand or with
+// pkg/clone, gives data of its own.
+//
+// This file shows:
+// - Slices that share a backing array, so writing through one changes the other
+// - append, which writes into spare capacity that another slice may be using
+// - Maps and pointers, which a copy of a struct shares with the original
+// - Full slice expressions, s[:n:n], which leave no capacity to write into
+package examples
+
+import "fmt"
+
+// Team is a struct whose copies share their slice and map.
+type Team struct {
+	Name    string
+	Members []string
+	Scores  map[string]int
+}
+
+// AppendBoth appends a to base, and then b to base, and returns both
+// results. While base has spare capacity, the second append overwrites
+// the first.
+func AppendBoth(base []string, a, b string) ([]string, []string) {
+	withA := append(base, a)
+	withB := append(base, b)
+	return withA, withB
+}
+
+// AppendBothClipped is AppendBoth with the capacity of base cut to its
+// length, so that each append copies it into a new array.
+func AppendBothClipped(base []string, a, b string) ([]string, []string) {
+	base = base[:len(base):len(base)]
+	return append(base, a), append(base, b)
+}
+
+// DemonstrateAliasing copies a slice and a struct, and changes them.
+func DemonstrateAliasing() {
+	fmt.Println("=== Aliasing ===")
+	base := make([]string, 2, 4)
+	copy(base, []string{"car", "van"})
+	a, b := AppendBoth(base, "truck", "bus")
+	fmt.Println("append twice:", a, b)
+	a, b = AppendBothClipped(base, "truck", "bus")
+	fmt.Println("clipped:     ", a, b)
+
+	first := Team{Name: "Blue", Members: []string{"Ada", "Bob"}, Scores: map[string]int{"Ada": 3}}
+	second := first // copies the fields: the slice header and the map pointer
+	second.Name = "Red"
+	second.Members[0] = "Cy"
+	second.Scores["Ada"] = 0
+	fmt.Printf("first:  %+v\n", first)
+	fmt.Printf("second: %+v\n", second)
+}
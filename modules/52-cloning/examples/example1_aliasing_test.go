@@ -0,0 +1,31 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateAliasing(t *testing.T) {
+	DemonstrateAliasing()
+}
+
+func TestAppendBoth(t *testing.T) {
+	spare := make([]string, 1, 2)
+	a, b := AppendBoth(spare, "x", "y")
+	assert.Equal(t, []string{"", "y"}, a, "the second append wrote over the first")
+	assert.Equal(t, []string{"", "y"}, b)
+
+	full := []string{""}
+	a, b = AppendBoth(full, "x", "y")
+	assert.Equal(t, []string{"", "x"}, a, "without spare capacity, each append copies")
+	assert.Equal(t, []string{"", "y"}, b)
+}
+
+func TestAppendBothClipped(t *testing.T) {
+	spare := make([]string, 1, 2)
+	a, b := AppendBothClipped(spare, "x", "y")
+	assert.Equal(t, []string{"", "x"}, a)
+	assert.Equal(t, []string{"", "y"}, b)
+	assert.Equal(t, "", spare[:2][1], "base's spare capacity is untouched")
+}
@@ -0,0 +1,54 @@
+package examples
+
+// This file shows:
+// - slices.Clone and maps.Clone, which copy one level
+// - A deep copy by hand, which must follow every slice, map, and pointer
+// - Why maps.Clone of a map of slices still shares the slices
+// - Returning copies from methods, so that callers cannot change a value's insides
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// CopyTeam returns a copy of t that shares nothing with it.
+func CopyTeam(t Team) Team {
+	t.Members = slices.Clone(t.Members)
+	t.Scores = maps.Clone(t.Scores)
+	return t
+}
+
+// Roster is a map of slices, which takes two levels of copying.
+type Roster map[string][]string
+
+// ShallowCopy copies the map, but not the slices in it.
+func (r Roster) ShallowCopy() Roster { return maps.Clone(r) }
+
+// DeepCopy copies the map and every slice in it.
+func (r Roster) DeepCopy() Roster {
+	if r == nil {
+		return nil
+	}
+	out := make(Roster, len(r))
+	for k, v := range r {
+		out[k] = slices.Clone(v)
+	}
+	return out
+}
+
+// DemonstrateCopying copies a team and a roster.
+func DemonstrateCopying() {
+	fmt.Println("=== Copying ===")
+	blue := Team{Name: "Blue", Members: []string{"Ada", "Bob"}, Scores: map[string]int{"Ada": 3}}
+	red := CopyTeam(blue)
+	red.Members[0] = "Cy"
+	red.Scores["Ada"] = 0
+	fmt.Printf("blue: %+v\nred:  %+v\n", blue, red)
+
+	roster := Roster{"north": {"Ada", "Bob"}}
+	shallow, deep := roster.ShallowCopy(), roster.DeepCopy()
+	shallow["north"][0] = "Shallow"
+	deep["north"][1] = "Deep"
+	fmt.Println("roster:", roster, "deep copy:", deep)
+}
@@ -0,0 +1,32 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateCopying(t *testing.T) {
+	DemonstrateCopying()
+}
+
+func TestCopyTeam(t *testing.T) {
+	blue := Team{Name: "Blue", Members: []string{"Ada"}, Scores: map[string]int{"Ada": 1}}
+	red := CopyTeam(blue)
+	assert.Equal(t, blue, red)
+	red.Members[0] = "Bob"
+	red.Scores["Ada"] = 2
+	assert.Equal(t, Team{Name: "Blue", Members: []string{"Ada"}, Scores: map[string]int{"Ada": 1}}, blue)
+}
+
+func TestRosterCopies(t *testing.T) {
+	r := Roster{"north": {"Ada"}}
+	r.ShallowCopy()["north"][0] = "Bob"
+	assert.Equal(t, "Bob", r["north"][0], "a shallow copy shares the slices")
+
+	deep := r.DeepCopy()
+	deep["north"][0] = "Cy"
+	deep["south"] = nil
+	assert.Equal(t, Roster{"north": {"Bob"}}, r)
+	assert.Nil(t, Roster(nil).DeepCopy())
+}
@@ -0,0 +1,71 @@
+package examples
+
+// This file shows:
+// - clone.Clone, which copies any value deeply with reflection
+// - Pointers that appear twice, or point back, copied once
+// - Snapshots in tests: clone before a call, and check the original is unchanged after
+// - The Cloner interface, for types whose unexported fields reflection cannot copy
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/clone"
+)
+
+// Node is a node of a graph, which may have cycles.
+type Node struct {
+	Name  string
+	Edges []*Node
+}
+
+// Counts counts things. Its map is unexported, so it implements
+// clone.Cloner to be copied deeply.
+type Counts struct {
+	counts map[string]int
+}
+
+// Add adds n to the count of key.
+func (c *Counts) Add(key string, n int) {
+	if c.counts == nil {
+		c.counts = make(map[string]int)
+	}
+	c.counts[key] += n
+}
+
+// Get returns the count of key.
+func (c *Counts) Get(key string) int { return c.counts[key] }
+
+// Clone returns a copy of c, for clone.Clone.
+func (c *Counts) Clone() *Counts { return &Counts{counts: maps.Clone(c.counts)} }
+
+var _ clone.Cloner[*Counts] = (*Counts)(nil)
+
+// Unchanged runs f on v, and reports whether v is as it was before.
+func Unchanged[T any](v T, f func(T)) bool {
+	before := clone.Clone(v)
+	f(v)
+	return reflect.DeepEqual(before, v)
+}
+
+// DemonstrateClone copies a graph with a cycle, and counts.
+func DemonstrateClone() {
+	fmt.Println("=== clone.Clone ===")
+	a := &Node{Name: "a"}
+	b := &Node{Name: "b", Edges: []*Node{a}}
+	a.Edges = []*Node{b}
+	c := clone.Clone(a)
+	c.Name = "a2"
+	fmt.Println("original:", a.Name, "->", a.Edges[0].Name, "->", a.Edges[0].Edges[0].Name)
+	fmt.Println("copy:    ", c.Name, "->", c.Edges[0].Name, "->", c.Edges[0].Edges[0].Name)
+	fmt.Println("the cycle closes on the copy:", c.Edges[0].Edges[0] == c)
+
+	counts := &Counts{}
+	counts.Add("cars", 2)
+	copied := clone.Clone(counts)
+	copied.Add("cars", 5)
+	fmt.Println("counts:", counts.Get("cars"), "copy:", copied.Get("cars"))
+
+	fmt.Println("swapping in place leaves it unchanged:", Unchanged([]int{3, 1, 2}, func(s []int) { s[0], s[1] = s[1], s[0] }))
+}
@@ -0,0 +1,38 @@
+package examples
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/clone"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateClone(t *testing.T) {
+	DemonstrateClone()
+}
+
+func TestCloneGraph(t *testing.T) {
+	a := &Node{Name: "a"}
+	a.Edges = []*Node{a, {Name: "b"}}
+	c := clone.Clone(a)
+	assert.Same(t, c, c.Edges[0])
+	c.Edges[1].Name = "c"
+	assert.Equal(t, "b", a.Edges[1].Name)
+}
+
+func TestCountsCloner(t *testing.T) {
+	var counts Counts
+	counts.Add("cars", 1)
+	teams := map[string]*Counts{"blue": &counts}
+	copied := clone.Clone(teams)
+	copied["blue"].Add("cars", 1)
+	assert.Equal(t, 1, counts.Get("cars"))
+	assert.Equal(t, 2, copied["blue"].Get("cars"))
+}
+
+func TestUnchanged(t *testing.T) {
+	assert.True(t, Unchanged([]int{3, 1, 2}, func(s []int) { _ = slices.Max(s) }))
+	assert.False(t, Unchanged([]int{3, 1, 2}, slices.Sort[[]int]))
+	assert.True(t, Unchanged([]int{3, 1, 2}, func(s []int) { slices.Sort(slices.Clone(s)) }))
+}
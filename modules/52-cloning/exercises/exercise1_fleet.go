@@ -0,0 +1,57 @@
+package exercises
+
+// EXERCISE: Fix the methods of a fleet that change the fleet they were called on.
+// A Fleet's methods are meant to return a new Fleet and leave the old one
+// alone, and they look as if they do, since a Fleet is passed by value.
+// But its slices and map are not copied with it: two fleets made from one
+// with With overwrite each other's new vehicle, Park moves vehicles in
+// every copy of the fleet, Retire shuffles the vehicles of the fleet it
+// was called on, and Newest sorts them. The tests use clone.Clone to keep
+// a copy of the fleet from before each call, to compare with after.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"slices"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/faker"
+)
+
+// Fleet is a company's vehicles, and the plates of those parked at each
+// depot. Its methods return a new Fleet and leave the one they were
+// called on as it was, so that a Fleet can be kept as a record of a day.
+type Fleet struct {
+	Name     string
+	Vehicles []faker.Vehicle
+	Depots   map[string][]string // depot name -> plates
+}
+
+// With returns a fleet with v added.
+func (f Fleet) With(v faker.Vehicle) Fleet {
+	f.Vehicles = append(f.Vehicles, v) // BUG: with spare capacity, this writes into the array f shares
+	return f
+}
+
+// Park returns a fleet with the vehicle plate parked at depot.
+func (f Fleet) Park(depot, plate string) Fleet {
+	if f.Depots == nil {
+		f.Depots = make(map[string][]string)
+	}
+	f.Depots[depot] = append(f.Depots[depot], plate) // BUG: the map is the same map in every copy of f
+	return f
+}
+
+// Retire returns a fleet without the vehicle plate.
+func (f Fleet) Retire(plate string) Fleet {
+	f.Vehicles = slices.DeleteFunc(f.Vehicles, func(v faker.Vehicle) bool { // BUG: deletes in place, moving the others down
+		return v.Plate == plate
+	})
+	return f
+}
+
+// Newest returns the n newest vehicles of the fleet, newest first, or all
+// of them if it has fewer.
+func (f Fleet) Newest(n int) []faker.Vehicle {
+	vs := f.Vehicles
+	slices.SortStableFunc(vs, func(a, b faker.Vehicle) int { return b.Year - a.Year }) // BUG: sorts the fleet's own vehicles
+	return vs[:min(n, len(vs))]
+}
@@ -0,0 +1,109 @@
+package exercises
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/clone"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/deepdiff"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/faker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFleet returns a fleet of n vehicles, with room to spare in its
+// slices, as a fleet that has grown by appending has.
+func newFleet(f *faker.Faker, n int) Fleet {
+	fleet := Fleet{Name: "Northwind", Vehicles: make([]faker.Vehicle, 0, 2*n), Depots: map[string][]string{}}
+	plates := map[string]bool{}
+	for len(fleet.Vehicles) < n {
+		v := f.Vehicle()
+		if plates[v.Plate] {
+			continue
+		}
+		plates[v.Plate] = true
+		fleet.Vehicles = append(fleet.Vehicles, v)
+		depot := []string{"Leeds", "York"}[len(fleet.Vehicles)%2]
+		if fleet.Depots[depot] == nil {
+			fleet.Depots[depot] = make([]string, 0, n)
+		}
+		fleet.Depots[depot] = append(fleet.Depots[depot], v.Plate)
+	}
+	return fleet
+}
+
+// unchanged fails the test if fleet differs from before, a clone of it
+// taken before calling what.
+func unchanged(t *testing.T, what string, before, fleet Fleet) {
+	t.Helper()
+	if diff := deepdiff.Diff(before, fleet); diff != "" {
+		t.Errorf("%s changed the fleet: (want, got)\n%s", what, diff)
+	}
+}
+
+func TestWith(t *testing.T) {
+	f := faker.For(t, 1)
+	fleet := newFleet(f, 6)
+	before := clone.Clone(fleet)
+
+	a, b := f.Vehicle(), f.Vehicle()
+	withA, withB := fleet.With(a), fleet.With(b)
+	unchanged(t, "With", before, fleet)
+	require.Len(t, withA.Vehicles, 7)
+	assert.Equal(t, a, withA.Vehicles[6], "adding b to the fleet replaced a in another")
+	assert.Equal(t, b, withB.Vehicles[6])
+}
+
+func TestPark(t *testing.T) {
+	f := faker.For(t, 2)
+	fleet := newFleet(f, 6)
+	before := clone.Clone(fleet)
+
+	plate := f.Vehicle().Plate
+	parked := fleet.Park("Leeds", plate).Park("Hull", plate)
+	unchanged(t, "Park", before, fleet)
+	assert.Equal(t, append(before.Depots["Leeds"], plate), parked.Depots["Leeds"])
+	assert.Equal(t, []string{plate}, parked.Depots["Hull"])
+
+	other := fleet.Park("Leeds", "OTHER")
+	assert.Equal(t, plate, parked.Depots["Leeds"][3], "parking in one fleet moved a vehicle in another")
+	assert.Equal(t, "OTHER", other.Depots["Leeds"][3])
+
+	assert.Equal(t, map[string][]string{"York": {plate}}, Fleet{}.Park("York", plate).Depots)
+}
+
+func TestRetire(t *testing.T) {
+	fleet := newFleet(faker.For(t, 3), 6)
+	before := clone.Clone(fleet)
+
+	gone := fleet.Vehicles[2].Plate
+	retired := fleet.Retire(gone)
+	unchanged(t, "Retire", before, fleet)
+	assert.Len(t, retired.Vehicles, 5)
+	for _, v := range retired.Vehicles {
+		assert.NotEqual(t, gone, v.Plate)
+	}
+	assert.Len(t, fleet.Retire("NO-SUCH").Vehicles, 6)
+}
+
+func TestNewest(t *testing.T) {
+	f := faker.For(t, 4)
+	fleet := newFleet(f, 8)
+	before := clone.Clone(fleet)
+
+	newest := fleet.Newest(3)
+	unchanged(t, "Newest", before, fleet)
+	require.Len(t, newest, 3)
+	for _, v := range newest {
+		for _, w := range fleet.Vehicles {
+			if !slices.Contains(newest, w) {
+				assert.GreaterOrEqual(t, v.Year, w.Year, "%v is newer than %v", w, v)
+			}
+		}
+	}
+
+	_ = append(newest, f.Vehicle())
+	unchanged(t, "appending to Newest's result", before, fleet)
+	assert.Len(t, fleet.Newest(20), 8)
+	assert.Empty(t, Fleet{}.Newest(3))
+}
@@ -0,0 +1,88 @@
+package exercises
+
+// EXERCISE: Fix a cache whose values, and whose clones, share memory with their callers.
+// Cache is a least recently used cache of byte slices, and a
+// clone.Cloner. But it keeps the very slice Put is given, so a caller that
+// reuses its buffer changes what is cached, and Get hands out the slice it
+// keeps, for any caller to change. Clone copies the map of values but not
+// the order of the keys, so that using the clone reorders the original,
+// which then evicts the wrong key; and it reads the cache without the
+// lock. Run the tests with -race.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Cache holds up to a fixed number of values by key, and forgets the least
+// recently used when it is full. It is safe for concurrent use. A Cache is
+// a clone.Cloner: clone.Clone copies one with its Clone method, which can
+// see the unexported fields that reflection cannot set.
+type Cache struct {
+	mu    sync.Mutex
+	max   int
+	items map[string][]byte
+	order []string // least recently used first
+}
+
+// NewCache returns an empty cache for up to max values.
+func NewCache(max int) *Cache {
+	return &Cache{max: max, items: make(map[string][]byte)}
+}
+
+// Get returns the value for key, and reports whether there is one.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.touch(key)
+	return v, true // BUG: the caller can change the cached value through this
+}
+
+// Put sets the value for key, and forgets the least recently used value if
+// the cache is over its size.
+func (c *Cache) Put(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.items[key]; ok {
+		c.touch(key)
+	} else {
+		c.order = append(c.order, key)
+	}
+	c.items[key] = value // BUG: the caller's buffer, which it may go on to reuse
+	if len(c.order) > c.max {
+		delete(c.items, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// Len returns the number of values in the cache.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Keys returns the keys of the cache, least recently used first.
+func (c *Cache) Keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.order)
+}
+
+// touch moves key to the end of the order, as the most recently used.
+func (c *Cache) touch(key string) {
+	i := slices.Index(c.order, key)
+	c.order = append(slices.Delete(c.order, i, i+1), key)
+}
+
+// Clone returns a cache with the same values, that changes separately.
+func (c *Cache) Clone() *Cache {
+	// BUG: reads the cache without holding c.mu
+	return &Cache{max: c.max, items: maps.Clone(c.items), order: c.order} // BUG: touch and Put change the order in place, in both caches
+}
@@ -0,0 +1,121 @@
+package exercises
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/clone"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/faker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEviction(t *testing.T) {
+	c := NewCache(3)
+	for _, k := range []string{"a", "b", "c"} {
+		c.Put(k, []byte(k))
+	}
+	c.Get("a")
+	c.Put("d", []byte("d"))
+	assert.Equal(t, []string{"c", "a", "d"}, c.Keys())
+	_, ok := c.Get("b")
+	assert.False(t, ok, "b was the least recently used")
+
+	c.Put("c", []byte("C"))
+	v, ok := c.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, "C", string(v))
+	assert.Equal(t, 3, c.Len())
+}
+
+func TestPutCopies(t *testing.T) {
+	c := NewCache(10)
+	buf := make([]byte, 0, 64)
+	people := faker.For(t, 1).People(5)
+	for _, p := range people {
+		buf = append(buf[:0], p.Name...)
+		c.Put(p.Email, buf)
+	}
+	for _, p := range people {
+		v, _ := c.Get(p.Email)
+		assert.Equal(t, p.Name, string(v), "reusing the buffer passed to Put changed the cached value")
+	}
+}
+
+func TestGetCopies(t *testing.T) {
+	c := NewCache(10)
+	c.Put("greeting", []byte("hello"))
+	v, _ := c.Get("greeting")
+	copy(v, "HELLO")
+	v, _ = c.Get("greeting")
+	assert.Equal(t, "hello", string(v), "changing the value Get returned changed the cache")
+}
+
+func TestClone(t *testing.T) {
+	c := NewCache(3)
+	for _, k := range []string{"a", "b", "c"} {
+		c.Put(k, []byte(k))
+	}
+	copied := c.Clone()
+	require.Equal(t, c.Keys(), copied.Keys())
+
+	copied.Get("a")
+	copied.Put("x", []byte("x"))
+	assert.Equal(t, []string{"a", "b", "c"}, c.Keys(), "using the clone reordered the original")
+	assert.Equal(t, []string{"c", "a", "x"}, copied.Keys())
+
+	c.Put("d", []byte("d"))
+	_, ok := c.Get("a")
+	assert.False(t, ok, "the original evicted the wrong key")
+	_, ok = copied.Get("a")
+	assert.True(t, ok)
+}
+
+func TestCloneWhileUsed(t *testing.T) {
+	c := NewCache(50)
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := fmt.Sprint(g, "/", i%60)
+				c.Put(key, []byte(key))
+				c.Get(fmt.Sprint(g, "/", i%7))
+			}
+		}(g)
+	}
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		copied := c.Clone()
+		assert.LessOrEqual(t, copied.Len(), 50)
+		assert.Len(t, copied.Keys(), copied.Len())
+	}
+}
+
+// Registry holds caches, some of them under two names.
+type Registry struct {
+	Primary, Backup *Cache
+	Sizes           map[string]int
+}
+
+func TestCloneCallsCacheClone(t *testing.T) {
+	c := NewCache(3)
+	c.Put("a", []byte("a"))
+	reg := Registry{Primary: c, Backup: c, Sizes: map[string]int{"primary": 3}}
+
+	copied := clone.Clone(reg)
+	assert.NotSame(t, reg.Primary, copied.Primary)
+	assert.Same(t, copied.Primary, copied.Backup, "one cache in the original is one in the clone")
+
+	copied.Primary.Put("b", []byte("b"))
+	assert.Equal(t, []string{"a"}, c.Keys(), "the clone's cache shares the original's")
+	assert.Equal(t, []string{"a", "b"}, copied.Backup.Keys())
+}
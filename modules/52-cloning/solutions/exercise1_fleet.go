@@ -0,0 +1,56 @@
+package solutions
+
+// SOLUTION: Fix the methods of a fleet that change the fleet they were called on.
+// Fixed: With clips the vehicles before appending, so two fleets made from one never share a new slot
+// Fixed: Park copies the depots, and clips the depot's plates, before adding to them
+// Fixed: Retire deletes from a copy of the vehicles, not the fleet's own array
+// Fixed: Newest sorts a copy of the vehicles, and returns part of that
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/faker"
+)
+
+// Fleet is a company's vehicles, and the plates of those parked at each
+// depot. Its methods return a new Fleet and leave the one they were
+// called on as it was, so that a Fleet can be kept as a record of a day.
+type Fleet struct {
+	Name     string
+	Vehicles []faker.Vehicle
+	Depots   map[string][]string // depot name -> plates
+}
+
+// With returns a fleet with v added.
+func (f Fleet) With(v faker.Vehicle) Fleet {
+	f.Vehicles = append(slices.Clip(f.Vehicles), v) // Fixed: a full slice makes append copy
+	return f
+}
+
+// Park returns a fleet with the vehicle plate parked at depot.
+func (f Fleet) Park(depot, plate string) Fleet {
+	depots := maps.Clone(f.Depots) // Fixed: a new map, with the same slices in it
+	if depots == nil {
+		depots = make(map[string][]string)
+	}
+	depots[depot] = append(slices.Clip(depots[depot]), plate) // Fixed: and a new slice for the one that changes
+	f.Depots = depots
+	return f
+}
+
+// Retire returns a fleet without the vehicle plate.
+func (f Fleet) Retire(plate string) Fleet {
+	f.Vehicles = slices.DeleteFunc(slices.Clone(f.Vehicles), func(v faker.Vehicle) bool { // Fixed: delete from a copy
+		return v.Plate == plate
+	})
+	return f
+}
+
+// Newest returns the n newest vehicles of the fleet, newest first, or all
+// of them if it has fewer.
+func (f Fleet) Newest(n int) []faker.Vehicle {
+	vs := slices.Clone(f.Vehicles) // Fixed: sort a copy
+	slices.SortStableFunc(vs, func(a, b faker.Vehicle) int { return b.Year - a.Year })
+	return vs[:min(n, len(vs))]
+}
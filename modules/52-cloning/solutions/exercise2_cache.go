@@ -0,0 +1,88 @@
+package solutions
+
+// SOLUTION: Fix a cache whose values, and whose clones, share memory with their callers.
+// Fixed: Put stores a copy of the value, so the caller can reuse its buffer
+// Fixed: Get returns a copy, so the caller cannot change what the cache holds
+// Fixed: Clone copies the order of the keys, which the two caches would otherwise both change
+// Fixed: Clone holds the lock while it reads the cache
+
+import (
+	"bytes"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Cache holds up to a fixed number of values by key, and forgets the least
+// recently used when it is full. It is safe for concurrent use. A Cache is
+// a clone.Cloner: clone.Clone copies one with its Clone method, which can
+// see the unexported fields that reflection cannot set.
+type Cache struct {
+	mu    sync.Mutex
+	max   int
+	items map[string][]byte
+	order []string // least recently used first
+}
+
+// NewCache returns an empty cache for up to max values.
+func NewCache(max int) *Cache {
+	return &Cache{max: max, items: make(map[string][]byte)}
+}
+
+// Get returns the value for key, and reports whether there is one.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.touch(key)
+	return bytes.Clone(v), true // Fixed: the caller's own copy
+}
+
+// Put sets the value for key, and forgets the least recently used value if
+// the cache is over its size.
+func (c *Cache) Put(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.items[key]; ok {
+		c.touch(key)
+	} else {
+		c.order = append(c.order, key)
+	}
+	c.items[key] = bytes.Clone(value) // Fixed: not the caller's buffer
+	if len(c.order) > c.max {
+		delete(c.items, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// Len returns the number of values in the cache.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Keys returns the keys of the cache, least recently used first.
+func (c *Cache) Keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.order)
+}
+
+// touch moves key to the end of the order, as the most recently used.
+func (c *Cache) touch(key string) {
+	i := slices.Index(c.order, key)
+	c.order = append(slices.Delete(c.order, i, i+1), key)
+}
+
+// Clone returns a cache with the same values, that changes separately.
+func (c *Cache) Clone() *Cache {
+	c.mu.Lock() // Fixed: Put and Get may be changing the cache meanwhile
+	defer c.mu.Unlock()
+	// The values themselves can be shared: now that the cache keeps them to
+	// itself, it never changes one in place, only replaces it.
+	return &Cache{max: c.max, items: maps.Clone(c.items), order: slices.Clone(c.order)} // Fixed: an order of its own
+}
@@ -0,0 +1,54 @@
+// Package examples demonstrates what equality means in Go: == for
+// comparable types, reflect.DeepEqual for the rest, and Equal methods for
+// types whose fields are not the whole story. Each gives a different
+// answer for some values, and a test that picks the wrong one passes when
+// it should fail, or fails when it should pass.
+//
+// This file shows:
+// - Structs and arrays compare with ==, field by field, if every field can
+// - Pointers compare their addresses, not what they point to
+// - Interfaces compare their dynamic types and values, and panic if the values cannot be compared
+// - NaN, which is not equal to itself, and lost as a map key
+package examples
+
+import (
+	"fmt"
+	"math"
+)
+
+// Point is comparable: all its fields are.
+type Point struct {
+	X, Y int
+}
+
+// SafeEqual reports whether a == b, and returns an error instead of the
+// panic that == gives on interfaces holding slices, maps, or functions.
+func SafeEqual(a, b any) (equal bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("comparing %T and %T: %v", a, b, r)
+		}
+	}()
+	return a == b, nil
+}
+
+// DemonstrateComparable compares structs, pointers, interfaces, and NaN.
+func DemonstrateComparable() {
+	fmt.Println("=== == ===")
+	p, q := Point{1, 2}, Point{1, 2}
+	fmt.Println("p == q:", p == q, " &p == &q:", &p == &q, " [2]Point equal:", [2]Point{p} == [2]Point{q})
+
+	var a, b any = p, q
+	fmt.Println("interfaces holding equal points:", a == b, " holding 1 and int64(1):", any(1) == any(int64(1)))
+	if _, err := SafeEqual([]int{1}, []int{1}); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	nan := math.NaN()
+	fmt.Println("NaN == NaN:", nan == nan, " math.IsNaN:", math.IsNaN(nan))
+	m := map[float64]int{}
+	m[nan] = 1
+	m[nan] = 2
+	_, ok := m[nan]
+	fmt.Println("a map with NaN set twice has", len(m), "keys, and finds it:", ok)
+}
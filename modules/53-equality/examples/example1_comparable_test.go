@@ -0,0 +1,37 @@
+package examples
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateComparable(t *testing.T) {
+	DemonstrateComparable()
+}
+
+func TestSafeEqual(t *testing.T) {
+	equal, err := SafeEqual(Point{1, 2}, Point{1, 2})
+	assert.NoError(t, err)
+	assert.True(t, equal)
+
+	equal, err = SafeEqual(1, int64(1))
+	assert.NoError(t, err)
+	assert.False(t, equal, "different dynamic types are never equal")
+
+	_, err = SafeEqual(map[string]int{}, map[string]int{})
+	assert.ErrorContains(t, err, "map[string]int")
+
+	equal, err = SafeEqual([]int{1}, "x")
+	assert.NoError(t, err, "the types differ, so the slices are never compared")
+	assert.False(t, equal)
+}
+
+func TestNaN(t *testing.T) {
+	nan := math.NaN()
+	equal, err := SafeEqual(nan, nan)
+	assert.NoError(t, err)
+	assert.False(t, equal)
+	assert.NotEqual(t, nan, nan, "testify compares with ObjectsAreEqual, which says NaN is not NaN")
+}
@@ -0,0 +1,46 @@
+package examples
+
+// This file shows:
+// - reflect.DeepEqual, which follows slices, maps, and pointers that == cannot compare
+// - A nil slice and an empty one, which DeepEqual tells apart
+// - time.Time, whose location and monotonic reading DeepEqual compares, with the instant
+// - Functions, which DeepEqual finds equal only if both are nil
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// Event is a struct that == cannot compare, for its slice.
+type Event struct {
+	Name string
+	At   time.Time
+	Tags []string
+}
+
+// SameEvent reports whether a and b have the same name, happen at the same
+// instant, and have the same tags, counting no tags as no tags whether
+// the slice is nil or empty.
+func SameEvent(a, b Event) bool {
+	return a.Name == b.Name && a.At.Equal(b.At) && slices.Equal(a.Tags, b.Tags)
+}
+
+// DemonstrateDeepEqual compares events with DeepEqual and with SameEvent.
+func DemonstrateDeepEqual() {
+	fmt.Println("=== reflect.DeepEqual ===")
+	at := time.Date(2024, 5, 1, 18, 30, 0, 0, time.UTC)
+	a := Event{Name: "launch", At: at}
+	b := Event{Name: "launch", At: at, Tags: []string{}}
+	fmt.Println("nil and empty tags: DeepEqual", reflect.DeepEqual(a, b), " SameEvent", SameEvent(a, b))
+
+	b = Event{Name: "launch", At: at.In(time.FixedZone("CEST", 2*3600))}
+	fmt.Println(b.At, "is", a.At, "- DeepEqual", reflect.DeepEqual(a, b), " SameEvent", SameEvent(a, b))
+
+	now := time.Now()
+	fmt.Println("time.Now() and its Round(0): == ", now == now.Round(0), " Equal", now.Equal(now.Round(0)))
+
+	f := func() {}
+	fmt.Println("a function and itself:", reflect.DeepEqual(f, f))
+}
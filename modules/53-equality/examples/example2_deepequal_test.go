@@ -0,0 +1,39 @@
+package examples
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateDeepEqual(t *testing.T) {
+	DemonstrateDeepEqual()
+}
+
+func TestSameEvent(t *testing.T) {
+	at := time.Date(2024, 5, 1, 18, 30, 0, 0, time.UTC)
+	a := Event{Name: "launch", At: at, Tags: nil}
+	tests := []struct {
+		b          Event
+		deep, same bool
+	}{
+		{Event{Name: "launch", At: at}, true, true},
+		{Event{Name: "launch", At: at, Tags: []string{}}, false, true},
+		{Event{Name: "launch", At: at.In(time.FixedZone("CEST", 2*3600))}, false, true},
+		{Event{Name: "launch", At: at.Add(time.Nanosecond)}, false, false},
+		{Event{Name: "landing", At: at}, false, false},
+		{Event{Name: "launch", At: at, Tags: []string{"go"}}, false, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.deep, reflect.DeepEqual(a, tt.b), "DeepEqual %+v", tt.b)
+		assert.Equal(t, tt.same, SameEvent(a, tt.b), "SameEvent %+v", tt.b)
+	}
+}
+
+func TestMonotonicReading(t *testing.T) {
+	now := time.Now()
+	assert.False(t, now == now.Round(0), "Round(0) strips the monotonic reading")
+	assert.True(t, now.Equal(now.Round(0)))
+}
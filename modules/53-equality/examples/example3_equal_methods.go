@@ -0,0 +1,62 @@
+package examples
+
+// This file shows:
+// - An Equal method, for a type whose fields can differ in ways that do not matter
+// - Calling Equal on the fields that have one, instead of == or DeepEqual, inside another Equal
+// - deepdiff.Diff, which uses Equal methods and says where two values differ
+// - Keeping Equal and map keys consistent, by making a canonical key
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/deepdiff"
+)
+
+// Email is an email address. The domain of an address is not case
+// sensitive, so Ada@Example.com and Ada@example.com are one address.
+type Email struct {
+	Local, Domain string
+}
+
+// Equal reports whether e and f are the same address.
+func (e Email) Equal(f Email) bool {
+	return e.Local == f.Local && strings.EqualFold(e.Domain, f.Domain)
+}
+
+// Key returns e in a form equal addresses share, for a map key.
+func (e Email) Key() Email {
+	return Email{Local: e.Local, Domain: strings.ToLower(e.Domain)}
+}
+
+// Contact is a struct with fields that have Equal methods.
+type Contact struct {
+	Name  string
+	Email Email
+	Since time.Time
+}
+
+// Equal reports whether c and d are the same contact.
+func (c Contact) Equal(d Contact) bool {
+	return c.Name == d.Name && c.Email.Equal(d.Email) && c.Since.Equal(d.Since)
+}
+
+// DemonstrateEqualMethods compares contacts, and shows where two teams
+// differ.
+func DemonstrateEqualMethods() {
+	fmt.Println("=== Equal methods ===")
+	since := time.Date(2023, 1, 9, 12, 0, 0, 0, time.UTC)
+	a := Contact{Name: "Ada", Email: Email{"ada", "Example.com"}, Since: since}
+	b := Contact{Name: "Ada", Email: Email{"ada", "example.com"}, Since: since.Local()}
+	fmt.Println("== ", a == b, " Equal", a.Equal(b), " deepdiff.Equal", deepdiff.Equal(a, b))
+
+	byAddress := map[Email]bool{a.Email: true}
+	byKey := map[Email]bool{a.Email.Key(): true}
+	fmt.Println("found by the address:", byAddress[b.Email], " by its key:", byKey[b.Email.Key()])
+
+	// Diff compares a type with an Equal method as a whole, with the method.
+	team := map[string][]Email{"core": {a.Email, {"bob", "example.org"}}}
+	other := map[string][]Email{"core": {b.Email, {"rob", "example.org"}}, "ops": {{"cy", "example.net"}}}
+	fmt.Printf("deepdiff.Diff:\n%s\n", deepdiff.Diff(team, other))
+}
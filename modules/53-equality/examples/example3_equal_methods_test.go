@@ -0,0 +1,37 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/deepdiff"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/faker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateEqualMethods(t *testing.T) {
+	DemonstrateEqualMethods()
+}
+
+func TestEmailEqualAndKey(t *testing.T) {
+	for _, p := range faker.For(t, 1).People(10) {
+		local, domain, _ := strings.Cut(p.Email, "@")
+		lower, upper := Email{local, domain}, Email{local, strings.ToUpper(domain)}
+		assert.True(t, lower.Equal(upper), p.Email)
+		assert.Equal(t, lower.Key(), upper.Key(), "equal addresses must have equal keys")
+		assert.False(t, lower.Equal(Email{strings.ToUpper(local), domain}), "the local part is case sensitive")
+	}
+}
+
+func TestContactDiff(t *testing.T) {
+	since := time.Date(2023, 1, 9, 12, 0, 0, 0, time.UTC)
+	a := Contact{Name: "Ada", Email: Email{"ada", "example.com"}, Since: since}
+	b := Contact{Name: "Ada", Email: Email{"ada", "EXAMPLE.com"}, Since: since.In(time.FixedZone("X", -7200))}
+	assert.True(t, a.Equal(b))
+	assert.Empty(t, deepdiff.Diff([]Contact{a}, []Contact{b}))
+
+	b.Since = b.Since.Add(time.Hour)
+	assert.False(t, a.Equal(b))
+	assert.Contains(t, deepdiff.Diff([]Contact{a}, []Contact{b}), "[0]: want {Name:Ada")
+}
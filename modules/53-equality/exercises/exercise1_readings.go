@@ -0,0 +1,54 @@
+package exercises
+
+// EXERCISE: Fix the equality of sensor readings, which never finds two missing values or two equal times the same.
+// A Reading is a sensor's value at a time, with NaN for a sensor that
+// reported nothing. But Missing compares with NaN, which is equal to
+// nothing; Equal compares times with ==, which finds the same instant in
+// two time zones, or with and without a monotonic clock reading,
+// different; and Dedupe keys a map with readings, so that it compares
+// them with == too, and keeps every missing reading. Dedupe needs a map
+// key that == compares as Equal does: build one from the parts of a
+// reading, with time.Time.Unix and Nanosecond, and math.Float64bits of a
+// value made canonical first, as there are many NaNs, and -0 is 0.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"math"
+	"time"
+)
+
+// Reading is the value a sensor reported at a time. A sensor that reports
+// nothing has a reading with a Value of NaN.
+type Reading struct {
+	Sensor string
+	At     time.Time
+	Value  float64
+}
+
+// Missing reports whether the sensor reported nothing.
+func (r Reading) Missing() bool {
+	return r.Value == math.NaN() // BUG: always false
+}
+
+// Equal reports whether r and o are the same reading: from the same
+// sensor, at the same instant, wherever its time was written down, with
+// the same value, or both missing.
+func (r Reading) Equal(o Reading) bool {
+	return r.Sensor == o.Sensor &&
+		r.At == o.At && // BUG: == compares the location and the monotonic reading too
+		r.Value == o.Value // BUG: false for two missing values
+}
+
+// Dedupe returns rs without the readings Equal to one before them.
+func Dedupe(rs []Reading) []Reading {
+	seen := make(map[Reading]bool) // BUG: a key compares with ==, not Equal
+	var out []Reading
+	for _, r := range rs {
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		out = append(out, r)
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package exercises
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/deepdiff"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+)
+
+var zones = []*time.Location{time.UTC, time.FixedZone("CET", 3600), time.FixedZone("EST", -5*3600)}
+
+// readings returns n different readings, some of them missing.
+func readings(r *rand.Rand, n int) []Reading {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	rs := make([]Reading, n)
+	for i := range rs {
+		rs[i] = Reading{
+			Sensor: []string{"boiler", "attic", "garage"}[i%3],
+			At:     start.Add(time.Duration(i) * time.Minute),
+			Value:  float64(seed.Between(r, -200, 400)) / 10,
+		}
+		if r.Intn(4) == 0 {
+			rs[i].Value = math.NaN()
+		}
+	}
+	return rs
+}
+
+func TestMissing(t *testing.T) {
+	assert.True(t, Reading{Value: math.NaN()}.Missing())
+	assert.True(t, Reading{Value: math.Float64frombits(0x7ff8000000000bad)}.Missing(), "there are many NaNs")
+	for _, v := range []float64{0, -1, math.Inf(1)} {
+		assert.False(t, Reading{Value: v}.Missing(), "%v", v)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	now := time.Now()
+	r := Reading{Sensor: "attic", At: now, Value: 21.5}
+	same := []Reading{
+		r,
+		{Sensor: "attic", At: now.Round(0), Value: 21.5},
+		{Sensor: "attic", At: now.In(zones[1]), Value: 21.5},
+	}
+	for _, o := range same {
+		assert.True(t, r.Equal(o), "%v and %v", r, o)
+	}
+	missing := Reading{Sensor: "attic", At: now, Value: math.NaN()}
+	assert.True(t, missing.Equal(Reading{Sensor: "attic", At: now.In(zones[2]), Value: math.NaN()}), "two missing values are equal")
+	assert.True(t, Reading{Value: 0}.Equal(Reading{Value: math.Copysign(0, -1)}), "-0 is 0")
+
+	different := []Reading{
+		{Sensor: "garage", At: now, Value: 21.5},
+		{Sensor: "attic", At: now.Add(time.Nanosecond), Value: 21.5},
+		{Sensor: "attic", At: now, Value: 21.6},
+		missing,
+	}
+	for _, o := range different {
+		assert.False(t, r.Equal(o), "%v and %v", r, o)
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	r := seed.Rand(t, 1)
+	want := readings(r, 30)
+	var in []Reading
+	for _, reading := range want {
+		in = append(in, reading)
+		for i := seed.Between(r, 0, 2); i > 0; i-- {
+			again := reading
+			again.At = again.At.In(zones[r.Intn(len(zones))])
+			if again.Missing() {
+				again.Value = math.Float64frombits(math.Float64bits(again.Value) | uint64(r.Intn(1000)))
+			}
+			in = append(in, again)
+		}
+	}
+	r.Shuffle(len(in), func(i, j int) { in[i], in[j] = in[j], in[i] })
+
+	got := Dedupe(in)
+	if assert.Len(t, got, len(want), "Dedupe kept repeats, or lost readings") {
+		for _, g := range got {
+			n := 0
+			for _, w := range want {
+				if w.Equal(g) {
+					n++
+				}
+			}
+			assert.Equal(t, 1, n, "%v is one of the readings", g)
+		}
+	}
+	if diff := deepdiff.Diff(want, Dedupe(want)); diff != "" {
+		t.Errorf("Dedupe of readings without repeats: (want, got)\n%s", diff)
+	}
+	assert.Empty(t, Dedupe(nil))
+}
@@ -0,0 +1,101 @@
+package exercises
+
+// EXERCISE: Fix the equality of versions and releases, which compares what the eye cannot see.
+// A Version keeps the text it was parsed from, in an unexported field,
+// so that String can give it back. But == compares that field too, so
+// Version.Equal finds "v1.4" and "1.4.0" different; Release.Equal uses
+// reflect.DeepEqual, which does the same, and also tells nil Notes from
+// empty, and a time in one zone from the same instant in another; and
+// Unique keys a map with versions, so that every spelling of a version is
+// kept. Compare each field the way it should be compared, and build map
+// keys without the text.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is a semantic version, such as 1.4.2 or 2.0.0-rc.1.
+type Version struct {
+	Major, Minor, Patch int
+	Pre                 string // the pre-release, such as "rc.1"; empty for a release
+
+	raw string // the text ParseVersion was given, for String
+}
+
+// ParseVersion parses a version, with or without a "v" in front, and with
+// the patch number, or the minor and the patch, left out for 0: "v1.4",
+// "1.4.0", and "1.4.0-rc.1" are all versions.
+func ParseVersion(s string) (Version, error) {
+	v := Version{raw: s}
+	core, pre, hasPre := strings.Cut(strings.TrimPrefix(s, "v"), "-")
+	if hasPre && pre == "" {
+		return Version{}, fmt.Errorf("version %q: empty pre-release", s)
+	}
+	v.Pre = pre
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("version %q: more than three numbers", s)
+	}
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || p != strconv.Itoa(n) {
+			return Version{}, fmt.Errorf("version %q: %q is not a number", s, p)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+	return v, nil
+}
+
+// String returns the version as it was written, or in full if it was not
+// parsed.
+func (v Version) String() string {
+	if v.raw != "" {
+		return v.raw
+	}
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// Equal reports whether v and w are the same version, however each was
+// written.
+func (v Version) Equal(w Version) bool {
+	return v == w // BUG: compares raw, so "v1.4" and "1.4.0" differ
+}
+
+// Release is a version that has been published.
+type Release struct {
+	Version   Version
+	Notes     []string
+	Published time.Time
+}
+
+// Equal reports whether r and o are the same release: the same version,
+// the same notes, where no notes are no notes whether Notes is nil or
+// empty, and published at the same instant.
+func (r Release) Equal(o Release) bool {
+	return reflect.DeepEqual(r, o) // BUG: compares raw, nil Notes with empty, and the times' locations
+}
+
+// Unique returns vs without the versions Equal to one before them.
+func Unique(vs []Version) []Version {
+	seen := make(map[Version]bool)
+	var out []Version
+	for _, v := range vs {
+		if seen[v] { // BUG: every spelling of a version is a different key
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	return out
+}
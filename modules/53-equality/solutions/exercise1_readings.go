@@ -0,0 +1,69 @@
+package solutions
+
+// SOLUTION: Fix the equality of sensor readings, which never finds two missing values or two equal times the same.
+// Fixed: Missing uses math.IsNaN, since NaN == NaN is false
+// Fixed: Equal compares times with time.Time.Equal, which ignores the location and the monotonic reading
+// Fixed: Equal counts two missing values as equal
+// Fixed: Dedupe keys its map with a canonical form of each reading, instead of the reading itself
+
+import (
+	"math"
+	"time"
+)
+
+// Reading is the value a sensor reported at a time. A sensor that reports
+// nothing has a reading with a Value of NaN.
+type Reading struct {
+	Sensor string
+	At     time.Time
+	Value  float64
+}
+
+// Missing reports whether the sensor reported nothing.
+func (r Reading) Missing() bool {
+	return math.IsNaN(r.Value) // Fixed: NaN is not equal to anything, itself included
+}
+
+// Equal reports whether r and o are the same reading: from the same
+// sensor, at the same instant, wherever its time was written down, with
+// the same value, or both missing.
+func (r Reading) Equal(o Reading) bool {
+	return r.Sensor == o.Sensor &&
+		r.At.Equal(o.At) && // Fixed: the instant, not the location or the monotonic reading
+		(r.Value == o.Value || r.Missing() && o.Missing()) // Fixed: two missing values are the same
+}
+
+// readingKey is a Reading in a form that == compares as Equal does.
+type readingKey struct {
+	sensor string
+	sec    int64
+	nsec   int
+	value  uint64
+}
+
+// key returns r's readingKey.
+func (r Reading) key() readingKey {
+	v := r.Value
+	switch {
+	case r.Missing():
+		v = math.NaN() // one NaN for all, since there are many
+	case v == 0:
+		v = 0 // not -0, which == finds equal to 0 but has other bits
+	}
+	return readingKey{sensor: r.Sensor, sec: r.At.Unix(), nsec: r.At.Nanosecond(), value: math.Float64bits(v)}
+}
+
+// Dedupe returns rs without the readings Equal to one before them.
+func Dedupe(rs []Reading) []Reading {
+	seen := make(map[readingKey]bool) // Fixed: Reading as a key compares with ==
+	var out []Reading
+	for _, r := range rs {
+		k := r.key()
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, r)
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package solutions
+
+// SOLUTION: Fix the equality of versions and releases, which compares what the eye cannot see.
+// Fixed: Version.Equal compares the parts of a version, not the text it was parsed from
+// Fixed: Release.Equal compares field by field, with Equal methods and slices.Equal, not reflect.DeepEqual
+// Fixed: Unique keys its map with the version without its text
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is a semantic version, such as 1.4.2 or 2.0.0-rc.1.
+type Version struct {
+	Major, Minor, Patch int
+	Pre                 string // the pre-release, such as "rc.1"; empty for a release
+
+	raw string // the text ParseVersion was given, for String
+}
+
+// ParseVersion parses a version, with or without a "v" in front, and with
+// the patch number, or the minor and the patch, left out for 0: "v1.4",
+// "1.4.0", and "1.4.0-rc.1" are all versions.
+func ParseVersion(s string) (Version, error) {
+	v := Version{raw: s}
+	core, pre, hasPre := strings.Cut(strings.TrimPrefix(s, "v"), "-")
+	if hasPre && pre == "" {
+		return Version{}, fmt.Errorf("version %q: empty pre-release", s)
+	}
+	v.Pre = pre
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("version %q: more than three numbers", s)
+	}
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || p != strconv.Itoa(n) {
+			return Version{}, fmt.Errorf("version %q: %q is not a number", s, p)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+	return v, nil
+}
+
+// String returns the version as it was written, or in full if it was not
+// parsed.
+func (v Version) String() string {
+	if v.raw != "" {
+		return v.raw
+	}
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// Equal reports whether v and w are the same version, however each was
+// written.
+func (v Version) Equal(w Version) bool {
+	v.raw, w.raw = "", "" // Fixed: "v1.4" and "1.4.0" are one version
+	return v == w
+}
+
+// Release is a version that has been published.
+type Release struct {
+	Version   Version
+	Notes     []string
+	Published time.Time
+}
+
+// Equal reports whether r and o are the same release: the same version,
+// the same notes, where no notes are no notes whether Notes is nil or
+// empty, and published at the same instant.
+func (r Release) Equal(o Release) bool {
+	// Fixed: DeepEqual compares Version's raw text, tells nil Notes from
+	// empty, and compares the locations of the times.
+	return r.Version.Equal(o.Version) && slices.Equal(r.Notes, o.Notes) && r.Published.Equal(o.Published)
+}
+
+// Unique returns vs without the versions Equal to one before them.
+func Unique(vs []Version) []Version {
+	seen := make(map[Version]bool)
+	var out []Version
+	for _, v := range vs {
+		key := v
+		key.raw = "" // Fixed: the key must be equal for versions that are Equal
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,107 @@
+package solutions
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/deepdiff"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spellings returns the ways of writing the version major.minor.0.
+func spellings(major, minor int) []string {
+	return []string{
+		fmt.Sprintf("%d.%d.0", major, minor),
+		fmt.Sprintf("v%d.%d.0", major, minor),
+		fmt.Sprintf("v%d.%d", major, minor),
+		fmt.Sprintf("%d.%d", major, minor),
+	}
+}
+
+// parse parses s, and fails the test on an error.
+func parse(t *testing.T, s string) Version {
+	t.Helper()
+	v, err := ParseVersion(s)
+	require.NoError(t, err)
+	return v
+}
+
+func TestParseVersion(t *testing.T) {
+	v := parse(t, "v2.10.3-rc.1")
+	assert.Equal(t, [3]int{2, 10, 3}, [3]int{v.Major, v.Minor, v.Patch})
+	assert.Equal(t, "rc.1", v.Pre)
+	assert.Equal(t, "v2.10.3-rc.1", v.String())
+	assert.Equal(t, "1.4.0", Version{Major: 1, Minor: 4}.String())
+
+	for _, bad := range []string{"", "v", "1.2.3.4", "1.x", "01.2", "1.-2", "1.2-"} {
+		_, err := ParseVersion(bad)
+		assert.Error(t, err, "%q", bad)
+	}
+}
+
+func TestVersionEqual(t *testing.T) {
+	r := seed.Rand(t, 1)
+	major, minor := seed.Between(r, 0, 20), seed.Between(r, 1, 30)
+	forms := spellings(major, minor)
+	for _, a := range forms {
+		for _, b := range forms {
+			assert.True(t, parse(t, a).Equal(parse(t, b)), "%s and %s", a, b)
+		}
+	}
+	v := parse(t, forms[0])
+	assert.True(t, v.Equal(Version{Major: major, Minor: minor}), "a parsed version and a literal")
+	assert.False(t, v.Equal(parse(t, forms[0]+"-rc.1")))
+	assert.False(t, v.Equal(parse(t, fmt.Sprintf("%d.%d.1", major, minor))))
+}
+
+func TestReleaseEqual(t *testing.T) {
+	published := time.Date(2024, 9, 3, 14, 0, 0, 0, time.UTC)
+	r := Release{Version: parse(t, "v1.4"), Published: published}
+	same := []Release{
+		{Version: parse(t, "1.4.0"), Published: published},
+		{Version: parse(t, "v1.4"), Notes: []string{}, Published: published},
+		{Version: parse(t, "v1.4"), Published: published.In(time.FixedZone("PDT", -7*3600))},
+	}
+	for _, o := range same {
+		if !r.Equal(o) {
+			t.Errorf("%+v and %+v are the same release", r, o)
+		}
+	}
+	assert.False(t, r.Equal(Release{Version: parse(t, "v1.4"), Notes: []string{"Fixes"}, Published: published}))
+	assert.False(t, r.Equal(Release{Version: parse(t, "v1.4"), Published: published.Add(time.Second)}))
+	assert.False(t, r.Equal(Release{Version: parse(t, "v1.5"), Published: published}))
+}
+
+func TestUnique(t *testing.T) {
+	r := seed.Rand(t, 2)
+	var want, in []Version
+	for major := 0; major < 4; major++ {
+		minor := seed.Between(r, 0, 9)
+		forms := spellings(major, minor)
+		want = append(want, parse(t, forms[0]))
+		in = append(in, parse(t, forms[0]))
+		for _, i := range r.Perm(len(forms))[:2] {
+			in = append(in, parse(t, forms[i]))
+		}
+	}
+	if diff := deepdiff.Diff(want, Unique(in)); diff != "" {
+		t.Errorf("Unique: (want, got)\n%s", diff)
+	}
+	got := Unique(in)
+	for i := range got {
+		assert.Equal(t, in[indexOf(in, got[i])].String(), got[i].String(), "Unique keeps the first spelling")
+	}
+}
+
+// indexOf returns the index of the first version in vs Equal to v.
+func indexOf(vs []Version, v Version) int {
+	for i, w := range vs {
+		if w.Equal(v) {
+			return i
+		}
+	}
+	return -1
+}
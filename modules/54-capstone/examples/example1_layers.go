@@ -0,0 +1,106 @@
+// Package examples demonstrates how the pieces of a service fit together:
+// a layer for each job, with interfaces between them; a shutdown that
+// undoes the startup in reverse; and handlers that cope with a queue that
+// delivers a message more than once. The exercises build a whole service
+// this way, one milestone at a time.
+//
+// This file shows:
+// - Interfaces declared by the code that uses them, small enough to fake in a test
+// - Dependencies passed in as struct fields, and wired together in one place
+// - The same service over a store in memory and a store that fails, for tests
+// - Errors wrapped at each layer, and checked with errors.Is at the top
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrNoSuchUser is returned, wrapped, for a user the store does not have.
+var ErrNoSuchUser = errors.New("no such user")
+
+// Users is what Greeter needs from a store: one method, not all a
+// database offers.
+type Users interface {
+	Name(ctx context.Context, id int) (string, error)
+}
+
+// Sender is what Greeter needs to send a message.
+type Sender interface {
+	Send(ctx context.Context, to, msg string) error
+}
+
+// Greeter greets users by name. It knows nothing of where users are kept
+// or how messages travel: its dependencies are fields, set by whoever
+// builds it.
+type Greeter struct {
+	Users  Users
+	Sender Sender
+}
+
+// Greet sends user id a greeting.
+func (g *Greeter) Greet(ctx context.Context, id int) error {
+	name, err := g.Users.Name(ctx, id)
+	if err != nil {
+		return fmt.Errorf("greeting user %d: %w", id, err)
+	}
+	if err := g.Sender.Send(ctx, name, "Hello, "+name+"!"); err != nil {
+		return fmt.Errorf("greeting %s: %w", name, err)
+	}
+	return nil
+}
+
+// MemUsers is Users in a map.
+type MemUsers map[int]string
+
+// Name implements Users.
+func (m MemUsers) Name(ctx context.Context, id int) (string, error) {
+	name, ok := m[id]
+	if !ok {
+		return "", fmt.Errorf("%w: %d", ErrNoSuchUser, id)
+	}
+	return name, nil
+}
+
+// Outbox is a Sender that keeps what it sends, as a test would.
+type Outbox struct {
+	mu   sync.Mutex
+	Sent []string
+}
+
+// Send implements Sender.
+func (o *Outbox) Send(ctx context.Context, to, msg string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.Sent = append(o.Sent, to+": "+msg)
+	return nil
+}
+
+// DownSender is a Sender whose network is down.
+type DownSender struct{}
+
+// Send implements Sender.
+func (DownSender) Send(ctx context.Context, to, msg string) error {
+	return errors.New("connection refused")
+}
+
+// DemonstrateLayers wires a Greeter two ways.
+func DemonstrateLayers() {
+	fmt.Println("=== Layers ===")
+	ctx := context.Background()
+	users := MemUsers{1: "Ada", 2: "Grace"}
+
+	outbox := &Outbox{}
+	g := &Greeter{Users: users, Sender: outbox}
+	fmt.Println("Greet 1:", g.Greet(ctx, 1))
+	err := g.Greet(ctx, 3)
+	fmt.Println("Greet 3:", err)
+	fmt.Println("  no such user:", errors.Is(err, ErrNoSuchUser))
+	fmt.Println("Sent:", strings.Join(outbox.Sent, "; "))
+
+	g = &Greeter{Users: users, Sender: DownSender{}}
+	fmt.Println("Greet 2, network down:", g.Greet(ctx, 2))
+}
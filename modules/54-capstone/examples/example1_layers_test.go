@@ -0,0 +1,29 @@
+package examples
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateLayers(t *testing.T) {
+	DemonstrateLayers()
+}
+
+func TestGreet(t *testing.T) {
+	outbox := &Outbox{}
+	g := &Greeter{Users: MemUsers{7: "Linus"}, Sender: outbox}
+	assert.NoError(t, g.Greet(context.Background(), 7))
+	assert.Equal(t, []string{"Linus: Hello, Linus!"}, outbox.Sent)
+}
+
+func TestGreetErrors(t *testing.T) {
+	g := &Greeter{Users: MemUsers{}, Sender: &Outbox{}}
+	err := g.Greet(context.Background(), 7)
+	assert.ErrorIs(t, err, ErrNoSuchUser)
+	assert.EqualError(t, err, "greeting user 7: no such user: 7")
+
+	g = &Greeter{Users: MemUsers{7: "Linus"}, Sender: DownSender{}}
+	assert.EqualError(t, g.Greet(context.Background(), 7), "greeting Linus: connection refused")
+}
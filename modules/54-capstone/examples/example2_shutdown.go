@@ -0,0 +1,86 @@
+package examples
+
+// This file shows:
+// - Shutdown steps registered as the service starts, and run in reverse
+// - One deadline for the whole shutdown, shared by every step
+// - A step that fails or runs out of time, without stopping the steps after it
+// - errors.Join, to report every step that failed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Shutdown runs, when the service stops, the steps registered as it
+// started, last first: what started last depends on what started before
+// it, so it must stop first. It is safe for concurrent use.
+type Shutdown struct {
+	mu    sync.Mutex
+	steps []step
+}
+
+type step struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// Add registers stop, to run on Run.
+func (s *Shutdown) Add(name string, stop func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps = append(s.steps, step{name, stop})
+}
+
+// Run runs the steps in reverse, each with ctx, which bounds them all. A
+// step that fails does not stop the others; Run returns every error,
+// joined, and the names of the steps it ran, in order.
+func (s *Shutdown) Run(ctx context.Context) (ran []string, err error) {
+	s.mu.Lock()
+	steps := s.steps
+	s.steps = nil
+	s.mu.Unlock()
+
+	var errs []error
+	for i := len(steps) - 1; i >= 0; i-- {
+		ran = append(ran, steps[i].name)
+		if err := steps[i].stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stopping %s: %w", steps[i].name, err))
+		}
+	}
+	return ran, errors.Join(errs...)
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// DemonstrateShutdown starts three pieces, and stops them.
+func DemonstrateShutdown() {
+	fmt.Println("\n=== Shutdown ===")
+	var s Shutdown
+	s.Add("database", func(ctx context.Context) error { return nil })
+	s.Add("workers", func(ctx context.Context) error { return sleep(ctx, 10*time.Millisecond) })
+	s.Add("http server", func(ctx context.Context) error { return sleep(ctx, 5*time.Millisecond) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	ran, err := s.Run(ctx)
+	cancel()
+	fmt.Println("Ran:", ran, "err:", err)
+
+	s.Add("database", func(ctx context.Context) error { return errors.New("connection lost") })
+	s.Add("workers", func(ctx context.Context) error { return sleep(ctx, time.Second) })
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	ran, err = s.Run(ctx)
+	cancel()
+	fmt.Println("Ran:", ran)
+	fmt.Println("err:", err)
+}
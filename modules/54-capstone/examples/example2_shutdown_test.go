@@ -0,0 +1,47 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateShutdown(t *testing.T) {
+	DemonstrateShutdown()
+}
+
+func TestShutdownOrder(t *testing.T) {
+	var s Shutdown
+	var stopped []string
+	for _, name := range []string{"a", "b", "c"} {
+		name := name
+		s.Add(name, func(ctx context.Context) error {
+			stopped = append(stopped, name)
+			return nil
+		})
+	}
+	ran, err := s.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c", "b", "a"}, ran)
+	assert.Equal(t, ran, stopped)
+
+	ran, _ = s.Run(context.Background())
+	assert.Empty(t, ran, "steps run once")
+}
+
+func TestShutdownErrors(t *testing.T) {
+	var s Shutdown
+	errLost := errors.New("lost")
+	s.Add("a", func(ctx context.Context) error { return errLost })
+	s.Add("b", func(ctx context.Context) error { return sleep(ctx, time.Minute) })
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	ran, err := s.Run(ctx)
+	assert.Equal(t, []string{"b", "a"}, ran, "a step that fails does not stop the rest")
+	assert.ErrorIs(t, err, errLost)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,91 @@
+package examples
+
+// This file shows:
+// - At-least-once delivery, where a message may arrive twice
+// - An idempotent handler, which does the work of a message once however often it arrives
+// - Checking and recording a message under one lock, so two deliveries cannot both pass the check
+// - Retrying a failed message a bounded number of times, then giving up
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Message is a request to add Amount to an account. ID is unique to the
+// request, and the same on every delivery of it.
+type Message struct {
+	ID      string
+	Account string
+	Amount  int
+	Attempt int
+}
+
+// Ledger applies messages to account balances, each once. It is safe for
+// concurrent use.
+type Ledger struct {
+	mu       sync.Mutex
+	balances map[string]int
+	applied  map[string]bool // message IDs
+}
+
+// NewLedger returns an empty Ledger.
+func NewLedger() *Ledger {
+	return &Ledger{balances: make(map[string]int), applied: make(map[string]bool)}
+}
+
+// Apply adds m's amount to its account, unless m was applied before. It
+// reports whether it did.
+func (l *Ledger) Apply(m Message) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.applied[m.ID] {
+		return false
+	}
+	l.applied[m.ID] = true
+	l.balances[m.Account] += m.Amount
+	return true
+}
+
+// Balance returns account's balance.
+func (l *Ledger) Balance(account string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.balances[account]
+}
+
+// ErrGaveUp is returned by Deliver for a message that failed every attempt.
+var ErrGaveUp = errors.New("gave up")
+
+// Deliver calls handle with m until it succeeds, up to maxAttempts times,
+// numbering the attempts in m.Attempt.
+func Deliver(m Message, maxAttempts int, handle func(Message) error) error {
+	var err error
+	for m.Attempt = 1; m.Attempt <= maxAttempts; m.Attempt++ {
+		if err = handle(m); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w after %d attempts: %w", ErrGaveUp, maxAttempts, err)
+}
+
+// DemonstrateIdempotency delivers messages twice, and retries one.
+func DemonstrateIdempotency() {
+	fmt.Println("\n=== Idempotency ===")
+	l := NewLedger()
+	deposit := Message{ID: "msg-1", Account: "ada", Amount: 100}
+	fmt.Println("Apply msg-1:", l.Apply(deposit))
+	fmt.Println("Apply msg-1 again:", l.Apply(deposit))
+	fmt.Println("Balance:", l.Balance("ada"))
+
+	flaky := func(m Message) error {
+		if m.Attempt < 3 {
+			return errors.New("timeout")
+		}
+		l.Apply(m)
+		return nil
+	}
+	fmt.Println("Deliver msg-2:", Deliver(Message{ID: "msg-2", Account: "ada", Amount: 50}, 5, flaky))
+	fmt.Println("Deliver msg-3:", Deliver(Message{ID: "msg-3", Account: "ada", Amount: 50}, 2, flaky))
+	fmt.Println("Balance:", l.Balance("ada"))
+}
@@ -0,0 +1,44 @@
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateIdempotency(t *testing.T) {
+	DemonstrateIdempotency()
+}
+
+func TestLedgerConcurrent(t *testing.T) {
+	l := NewLedger()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				l.Apply(Message{ID: fmt.Sprintf("msg-%d", j), Account: "grace", Amount: 2})
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 100, l.Balance("grace"), "each message once, however often delivered")
+}
+
+func TestDeliver(t *testing.T) {
+	var attempts []int
+	errDown := errors.New("down")
+	err := Deliver(Message{ID: "msg-1"}, 3, func(m Message) error {
+		attempts = append(attempts, m.Attempt)
+		return errDown
+	})
+	assert.Equal(t, []int{1, 2, 3}, attempts)
+	assert.ErrorIs(t, err, ErrGaveUp)
+	assert.ErrorIs(t, err, errDown)
+
+	assert.NoError(t, Deliver(Message{ID: "msg-2"}, 3, func(m Message) error { return nil }))
+}
@@ -0,0 +1,194 @@
+package exercises
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/deepdiff"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/faker"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var ctx = context.Background()
+
+// newJob returns a queued job of owner's, with a few numbers.
+func newJob(f *faker.Faker, owner string) Job {
+	numbers := make([]float64, seed.Between(f.Rand(), 1, 8))
+	for i := range numbers {
+		numbers[i] = float64(seed.Between(f.Rand(), -1000, 1000)) / 4
+	}
+	return Job{Owner: owner, Numbers: numbers, Status: StatusQueued, Created: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func TestMemStoreCreateGet(t *testing.T) {
+	f := faker.For(t, 1)
+	s := NewMemStore()
+	want := newJob(f, f.Email())
+	created, err := s.Create(ctx, want)
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", created.ID)
+	want.ID = created.ID
+
+	got, err := s.Get(ctx, "job-1")
+	require.NoError(t, err)
+	if diff := deepdiff.Diff(want, got); diff != "" {
+		t.Errorf("Get: (want, got)\n%s", diff)
+	}
+
+	_, err = s.Get(ctx, "job-2")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorContains(t, err, "job-2")
+}
+
+func TestMemStoreCopies(t *testing.T) {
+	f := faker.For(t, 2)
+	s := NewMemStore()
+	job, err := s.Create(ctx, newJob(f, f.Email()))
+	require.NoError(t, err)
+	_, err = s.Update(ctx, job.ID, func(j *Job) error {
+		j.Status, j.Summary = StatusDone, &Summary{Count: len(j.Numbers)}
+		return nil
+	})
+	require.NoError(t, err)
+
+	got, _ := s.Get(ctx, job.ID)
+	got.Numbers[0] = 1e9
+	got.Summary.Count = -1
+	again, _ := s.Get(ctx, job.ID)
+	assert.Equal(t, job.Numbers, again.Numbers, "changing the job Get returned changed the store")
+	assert.Equal(t, len(job.Numbers), again.Summary.Count, "changing the summary Get returned changed the store")
+
+	jobs, _ := s.List(ctx, job.Owner)
+	jobs[0].Numbers[0] = 1e9
+	again, _ = s.Get(ctx, job.ID)
+	assert.Equal(t, job.Numbers, again.Numbers, "changing the jobs List returned changed the store")
+}
+
+func TestMemStoreUpdate(t *testing.T) {
+	f := faker.For(t, 3)
+	s := NewMemStore()
+	job, _ := s.Create(ctx, newJob(f, f.Email()))
+
+	updated, err := s.Update(ctx, job.ID, func(j *Job) error {
+		j.Status, j.Attempts = StatusRunning, 1
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, StatusRunning, updated.Status)
+
+	errBusy := errors.New("busy")
+	_, err = s.Update(ctx, job.ID, func(j *Job) error {
+		j.Status = StatusFailed
+		j.Numbers[0] = 1e9
+		return errBusy
+	})
+	assert.ErrorIs(t, err, errBusy)
+	got, _ := s.Get(ctx, job.ID)
+	assert.Equal(t, StatusRunning, got.Status, "a change that failed was stored")
+	assert.Equal(t, job.Numbers, got.Numbers, "a change that failed was stored")
+
+	_, err = s.Update(ctx, "job-99", func(j *Job) error { return nil })
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMemStoreList(t *testing.T) {
+	f := faker.For(t, 4)
+	owners := []string{f.Email(), f.Email(), f.Email()}
+	s := NewMemStore()
+	want := map[string][]string{}
+	for i := 0; i < 30; i++ {
+		owner := owners[f.Rand().Intn(len(owners))]
+		job, _ := s.Create(ctx, newJob(f, owner))
+		want[owner] = append(want[owner], job.ID)
+	}
+	for _, owner := range owners {
+		jobs, err := s.List(ctx, owner)
+		require.NoError(t, err)
+		var ids []string
+		for _, j := range jobs {
+			ids = append(ids, j.ID)
+		}
+		assert.Equal(t, want[owner], ids, "%s's jobs, oldest first", owner)
+	}
+	jobs, err := s.List(ctx, "nobody@example.com")
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestMemStoreConcurrent(t *testing.T) {
+	f := faker.For(t, 5)
+	owner := f.Email()
+	job := newJob(f, owner)
+	s := NewMemStore()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				created, _ := s.Create(ctx, job)
+				s.Update(ctx, created.ID, func(j *Job) error { j.Attempts++; return nil })
+				s.Get(ctx, created.ID)
+				s.List(ctx, owner)
+			}
+		}()
+	}
+	wg.Wait()
+	jobs, _ := s.List(ctx, owner)
+	assert.Len(t, jobs, 160)
+}
+
+// countingStore counts the Gets that reach it.
+type countingStore struct {
+	Store
+	mu   sync.Mutex
+	gets int
+}
+
+func (s *countingStore) Get(ctx context.Context, id string) (Job, error) {
+	s.mu.Lock()
+	s.gets++
+	s.mu.Unlock()
+	return s.Store.Get(ctx, id)
+}
+
+func TestCachedStore(t *testing.T) {
+	f := faker.For(t, 6)
+	slow := &countingStore{Store: NewMemStore()}
+	c := NewCachedStore(slow)
+	job, _ := c.Create(ctx, newJob(f, f.Email()))
+
+	for i := 0; i < 2; i++ {
+		got, err := c.Get(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, StatusQueued, got.Status)
+	}
+	assert.Equal(t, 2, slow.gets, "a queued job is read from the store each time")
+
+	_, err := c.Update(ctx, job.ID, func(j *Job) error {
+		j.Status, j.Summary = StatusDone, &Summary{Count: len(j.Numbers)}
+		return nil
+	})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		got, err := c.Get(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, StatusDone, got.Status, "Get %d after the job was done", i+1)
+	}
+	assert.Equal(t, 3, slow.gets, "a done job is cached")
+	hits, misses := c.Stats()
+	assert.Equal(t, [2]int{2, 3}, [2]int{hits, misses})
+
+	_, err = c.Get(ctx, "job-404")
+	assert.ErrorIs(t, err, ErrNotFound)
+	got, _ := c.Get(ctx, job.ID)
+	require.NotNil(t, got.Summary)
+	got.Summary.Count = -1
+	got, _ = c.Get(ctx, job.ID)
+	assert.Equal(t, len(job.Numbers), got.Summary.Count, "changing a cached job changed the cache")
+}
@@ -0,0 +1,126 @@
+package exercises
+
+// EXERCISE: Milestone 2: fix the queue that carries jobs from the API to the workers.
+// The API publishes the ID of each new job, and each worker receives IDs
+// until the queue is closed. But Publish takes messages without limit, so
+// a busy API fills memory instead of telling clients to come back later;
+// Receive waits forever on a closed, empty queue, so the workers never
+// stop; Requeue sends a message back without counting the attempt, so a
+// worker cannot tell when to give up; and Len reads the messages without
+// the lock.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrFull is returned by Publish when the queue holds as many messages as
+// it may. The API answers 503, and the client tries again later.
+var ErrFull = errors.New("queue full")
+
+// ErrClosed is returned by Publish after Close, and by Receive once the
+// queue is closed and empty.
+var ErrClosed = errors.New("queue closed")
+
+// Delivery is a message taken from the queue: the ID of a job, and how
+// many times it has been delivered before.
+type Delivery struct {
+	JobID   string
+	Attempt int // 1 the first time
+}
+
+// Queue is an in-memory message broker between the API, which publishes
+// the IDs of new jobs, and the workers, which receive them. Each message
+// goes to one worker. It is safe for concurrent use.
+type Queue struct {
+	mu     sync.Mutex
+	msgs   []Delivery
+	limit  int
+	closed bool
+	ready  chan struct{} // has a value while msgs may not be empty
+	done   chan struct{} // closed by Close
+}
+
+// NewQueue returns a queue that holds up to limit messages.
+func NewQueue(limit int) *Queue {
+	return &Queue{limit: limit, ready: make(chan struct{}, 1), done: make(chan struct{})}
+}
+
+// Publish adds a message for job id.
+func (q *Queue) Publish(id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+	q.push(Delivery{JobID: id, Attempt: 1}) // BUG: never ErrFull, however many are waiting
+	return nil
+}
+
+// Requeue puts a message a worker could not handle back at the end of the
+// queue, to be delivered again. It works on a closed queue too, and does
+// not count against the limit: the message was already in the queue.
+func (q *Queue) Requeue(d Delivery) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.push(d) // BUG: d.Attempt is the attempt that failed
+}
+
+// push adds d, and wakes a receiver. q.mu must be held.
+func (q *Queue) push(d Delivery) {
+	q.msgs = append(q.msgs, d)
+	select {
+	case q.ready <- struct{}{}:
+	default: // one is already waiting to be taken
+	}
+}
+
+// Receive takes the next message, waiting for one if there are none. It
+// returns ErrClosed once the queue is closed and empty, and ctx.Err() if
+// ctx is done first.
+func (q *Queue) Receive(ctx context.Context) (Delivery, error) {
+	for {
+		q.mu.Lock()
+		if len(q.msgs) > 0 {
+			d := q.msgs[0]
+			q.msgs = q.msgs[1:]
+			if len(q.msgs) > 0 {
+				select {
+				case q.ready <- struct{}{}: // for the next receiver
+				default:
+				}
+			}
+			q.mu.Unlock()
+			return d, nil
+		}
+		closed := q.closed
+		q.mu.Unlock()
+		if closed {
+			return Delivery{}, ErrClosed
+		}
+
+		select {
+		case <-q.ready: // BUG: once the queue is closed and empty, nothing is ever ready again
+		case <-ctx.Done():
+			return Delivery{}, ctx.Err()
+		}
+	}
+}
+
+// Close stops Publish. Receive goes on returning the messages already in
+// the queue, and then ErrClosed.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		close(q.done)
+	}
+}
+
+// Len returns the number of messages waiting.
+func (q *Queue) Len() int {
+	return len(q.msgs) // BUG: Publish and Receive change msgs meanwhile
+}
@@ -0,0 +1,222 @@
+package exercises
+
+// EXERCISE: Milestone 3: fix the REST API that takes jobs and reports on them.
+// Clients POST numbers to /jobs, and GET /jobs/{id} until the job is done.
+// But Metrics counts without its lock, while every request counts itself
+// at once; POST /jobs reads as big a body as the client cares to send; a
+// job the queue refuses stays queued, where no worker will see it, and
+// the client gets a 500 that does not say to try again; and GET
+// /jobs/{id} shows any client anyone's job.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/jsonschema"
+)
+
+// Metrics counts events by name, such as `http_requests_total{code="202"}`,
+// and serves them in the text format Prometheus scrapes. It is safe for
+// concurrent use.
+type Metrics struct {
+	mu       sync.Mutex
+	counters map[string]int64
+}
+
+// NewMetrics returns Metrics with every counter at 0.
+func NewMetrics() *Metrics {
+	return &Metrics{counters: make(map[string]int64)}
+}
+
+// Inc adds 1 to the counter name.
+func (m *Metrics) Inc(name string) {
+	m.counters[name]++ // BUG: a map written by two requests at once is a race, and can crash
+}
+
+// Get returns the counter name.
+func (m *Metrics) Get(name string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counters[name]
+}
+
+// ServeHTTP writes every counter, one to a line, sorted by name.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	names := make([]string, 0, len(m.counters))
+	for name := range m.counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s %d\n", name, m.counters[name])
+	}
+	m.mu.Unlock()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	io.WriteString(w, b.String())
+}
+
+// MaxBody is the largest request body the API reads.
+const MaxBody = 1 << 20
+
+// jobSchema is what POST /jobs accepts.
+var jobSchema = jsonschema.MustParse(`{
+	"type": "object",
+	"required": ["numbers"],
+	"additionalProperties": false,
+	"properties": {
+		"numbers": {"type": "array", "minItems": 1, "maxItems": 10000, "items": {"type": "number"}}
+	}
+}`)
+
+// API is the HTTP side of the project. Clients authenticate with a bearer
+// token, and see only their own jobs:
+//
+//	POST /jobs       {"numbers": [...]}: 202, the queued job, and its Location
+//	GET  /jobs       the client's jobs, oldest first
+//	GET  /jobs/{id}  one job, with its summary once it is done
+//	GET  /metrics    the counters, without a token
+type API struct {
+	Store   Store
+	Queue   *Queue
+	Metrics *Metrics
+	Tokens  map[string]string // bearer token -> owner
+	Now     func() time.Time  // time.Now if nil
+}
+
+// Handler returns the API's routes, each request counted in Metrics by
+// its status code.
+func (a *API) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jobs", a.authenticated(a.jobs))
+	mux.HandleFunc("/jobs/", a.authenticated(a.job))
+	mux.Handle("/metrics", a.Metrics)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		mux.ServeHTTP(rec, r)
+		a.Metrics.Inc(fmt.Sprintf(`http_requests_total{code="%d"}`, rec.code))
+	})
+}
+
+// statusRecorder remembers the status code a handler writes.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// authenticated calls h with the owner of the request's bearer token, and
+// answers 401 for a request without a known one.
+func (a *API) authenticated(h func(w http.ResponseWriter, r *http.Request, owner string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		owner, known := a.Tokens[token]
+		if !ok || !known {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "missing or unknown token")
+			return
+		}
+		h(w, r, owner)
+	}
+}
+
+// jobs serves /jobs.
+func (a *API) jobs(w http.ResponseWriter, r *http.Request, owner string) {
+	switch r.Method {
+	case http.MethodGet:
+		jobs, err := a.Store.List(r.Context(), owner)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, jobs)
+	case http.MethodPost:
+		a.create(w, r, owner)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" is not allowed")
+	}
+}
+
+// create serves POST /jobs.
+func (a *API) create(w http.ResponseWriter, r *http.Request, owner string) {
+	body, err := io.ReadAll(r.Body) // BUG: as much as the client sends
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("body is over %d bytes", MaxBody))
+		return
+	case err != nil:
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := jobSchema.Validate(body); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	var req struct{ Numbers []float64 }
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	now := time.Now
+	if a.Now != nil {
+		now = a.Now
+	}
+	job, err := a.Store.Create(r.Context(), Job{Owner: owner, Numbers: req.Numbers, Status: StatusQueued, Created: now()})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := a.Queue.Publish(job.ID); err != nil {
+		// BUG: the job stays queued, and the client is not told to try again
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	a.Metrics.Inc("jobs_created_total")
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// job serves GET /jobs/{id}.
+func (a *API) job(w http.ResponseWriter, r *http.Request, owner string) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" is not allowed")
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/jobs/")
+	job, err := a.Store.Get(r.Context(), id) // BUG: whoever owns it
+	switch {
+	case errors.Is(err, ErrNotFound):
+		writeError(w, http.StatusNotFound, "no job "+id)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	default:
+		writeJSON(w, http.StatusOK, job)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
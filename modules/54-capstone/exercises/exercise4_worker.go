@@ -0,0 +1,106 @@
+package exercises
+
+// EXERCISE: Milestone 4: fix the worker that takes jobs from the queue and summarizes them.
+// A Worker receives job IDs, marks each job running, works out its
+// summary, and marks it done, or requeues it to try again. But Run goes
+// round and round once the queue is closed, instead of returning; a job
+// delivered twice, as a queue may, is done twice; and a job that always
+// fails is requeued forever.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/stats"
+)
+
+// DefaultMaxAttempts is the Worker.MaxAttempts of a Worker that sets none.
+const DefaultMaxAttempts = 3
+
+// Summarize works out the summary of numbers.
+func Summarize(numbers []float64) (*Summary, error) {
+	if len(numbers) == 0 {
+		return nil, errors.New("no numbers to summarize")
+	}
+	var acc stats.Accumulator
+	acc.AddAll(numbers...)
+	return &Summary{Count: acc.Count(), Mean: acc.Mean(), Min: acc.Min(), Max: acc.Max(), Median: acc.Quantile(0.5)}, nil
+}
+
+// Worker takes job IDs from the queue, and summarizes the jobs' numbers.
+// Run as many as there are cores to use; they share the queue.
+type Worker struct {
+	Store   Store
+	Queue   *Queue
+	Metrics *Metrics
+	// MaxAttempts is how many times a job is tried before it is marked
+	// failed; DefaultMaxAttempts if 0.
+	MaxAttempts int
+	// Process works out a job's summary; Summarize of its numbers if nil.
+	Process func(ctx context.Context, job Job) (*Summary, error)
+}
+
+// Run handles messages until the queue is closed and empty, and returns
+// nil, or until ctx is done, and returns ctx.Err().
+func (w *Worker) Run(ctx context.Context) error {
+	for {
+		d, err := w.Queue.Receive(ctx)
+		if errors.Is(err, ErrClosed) {
+			continue // BUG: there will be no more; this spins forever
+		}
+		if err != nil {
+			return err
+		}
+		w.handle(ctx, d)
+	}
+}
+
+// handle handles one message.
+func (w *Worker) handle(ctx context.Context, d Delivery) {
+	job, err := w.Store.Update(ctx, d.JobID, func(j *Job) error {
+		j.Status = StatusRunning // BUG: even if it is already done
+		j.Attempts = d.Attempt
+		return nil
+	})
+	switch {
+	case errors.Is(err, errFinished):
+		w.Metrics.Inc("jobs_duplicate_total")
+		return
+	case errors.Is(err, ErrNotFound):
+		w.Metrics.Inc("jobs_lost_total") // nothing to retry
+		return
+	case err != nil:
+		w.retry(ctx, d, err)
+		return
+	}
+
+	process := w.Process
+	if process == nil {
+		process = func(_ context.Context, job Job) (*Summary, error) { return Summarize(job.Numbers) }
+	}
+	summary, err := process(ctx, job)
+	if err != nil {
+		w.retry(ctx, d, err)
+		return
+	}
+	_, err = w.Store.Update(ctx, d.JobID, func(j *Job) error {
+		j.Status, j.Summary, j.Error = StatusDone, summary, ""
+		return nil
+	})
+	if err != nil {
+		w.retry(ctx, d, err)
+		return
+	}
+	w.Metrics.Inc("jobs_done_total")
+}
+
+// errFinished stops the update of a job that is already finished.
+var errFinished = errors.New("job already finished")
+
+// retry requeues d after err, or marks its job failed if it has had its
+// attempts.
+func (w *Worker) retry(ctx context.Context, d Delivery, err error) {
+	w.Metrics.Inc("jobs_retried_total")
+	w.Queue.Requeue(d) // BUG: however many attempts it has had
+}
@@ -0,0 +1,133 @@
+package exercises
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/faker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarize(t *testing.T) {
+	got, err := Summarize([]float64{4, 1, 3, 2, 10})
+	require.NoError(t, err)
+	assert.Equal(t, &Summary{Count: 5, Mean: 4, Min: 1, Max: 10, Median: 3}, got)
+
+	_, err = Summarize(nil)
+	assert.Error(t, err)
+}
+
+// queued creates n jobs in s, and publishes them to q.
+func queued(t *testing.T, s Store, q *Queue, n int) []Job {
+	f := faker.For(t, 40)
+	var jobs []Job
+	for i := 0; i < n; i++ {
+		job, err := s.Create(ctx, newJob(f, f.Email()))
+		require.NoError(t, err)
+		require.NoError(t, q.Publish(job.ID))
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// run runs w until it returns, which it must within a second.
+func run(t *testing.T, ctx context.Context, w *Worker) error {
+	t.Helper()
+	errs := make(chan error, 1)
+	go func() { errs <- w.Run(ctx) }()
+	select {
+	case err := <-errs:
+		return err
+	case <-time.After(time.Second):
+		t.Fatal("Run still running after a second")
+		return nil
+	}
+}
+
+func TestWorker(t *testing.T) {
+	s, q := NewMemStore(), NewQueue(100)
+	jobs := queued(t, s, q, 20)
+	q.Close()
+	w := &Worker{Store: s, Queue: q, Metrics: NewMetrics()}
+	assert.NoError(t, run(t, ctx, w), "Run once the queue is closed and empty")
+
+	for _, job := range jobs {
+		got, err := s.Get(ctx, job.ID)
+		require.NoError(t, err)
+		want, _ := Summarize(job.Numbers)
+		assert.Equal(t, StatusDone, got.Status, job.ID)
+		assert.Equal(t, want, got.Summary, job.ID)
+		assert.Equal(t, 1, got.Attempts, job.ID)
+	}
+	assert.Equal(t, int64(20), w.Metrics.Get("jobs_done_total"))
+}
+
+func TestWorkerContext(t *testing.T) {
+	w := &Worker{Store: NewMemStore(), Queue: NewQueue(10), Metrics: NewMetrics()}
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, run(t, ctx, w), context.Canceled)
+}
+
+func TestWorkerRetries(t *testing.T) {
+	s, q := NewMemStore(), NewQueue(100)
+	jobs := queued(t, s, q, 2)
+	q.Close()
+	errFlaky := errors.New("flaky")
+	var mu sync.Mutex
+	tries := map[string]int{}
+	w := &Worker{Store: s, Queue: q, Metrics: NewMetrics(), MaxAttempts: 4,
+		Process: func(_ context.Context, job Job) (*Summary, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			tries[job.ID]++
+			if job.ID == jobs[0].ID && tries[job.ID] < 3 {
+				return nil, errFlaky
+			}
+			if job.ID == jobs[1].ID {
+				return nil, errFlaky
+			}
+			return Summarize(job.Numbers)
+		},
+	}
+	require.NoError(t, run(t, ctx, w))
+
+	flaky, _ := s.Get(ctx, jobs[0].ID)
+	assert.Equal(t, StatusDone, flaky.Status)
+	assert.Equal(t, 3, flaky.Attempts)
+
+	broken, _ := s.Get(ctx, jobs[1].ID)
+	assert.Equal(t, StatusFailed, broken.Status)
+	assert.Equal(t, 4, tries[broken.ID], "tries of a job that always fails")
+	assert.Contains(t, broken.Error, "flaky")
+
+	for name, want := range map[string]int64{"jobs_retried_total": 5, "jobs_done_total": 1, "jobs_failed_total": 1} {
+		assert.Equal(t, want, w.Metrics.Get(name), name)
+	}
+}
+
+func TestWorkerDuplicates(t *testing.T) {
+	s, q := NewMemStore(), NewQueue(100)
+	jobs := queued(t, s, q, 3)
+	q.Publish(jobs[1].ID)
+	q.Publish("job-404")
+	q.Close()
+	var mu sync.Mutex
+	calls := 0
+	w := &Worker{Store: s, Queue: q, Metrics: NewMetrics(),
+		Process: func(_ context.Context, job Job) (*Summary, error) {
+			mu.Lock()
+			calls++
+			mu.Unlock()
+			return Summarize(job.Numbers)
+		},
+	}
+	require.NoError(t, run(t, ctx, w))
+	assert.Equal(t, 3, calls, "a job delivered twice is done once")
+	assert.Equal(t, int64(1), w.Metrics.Get("jobs_duplicate_total"))
+	assert.Equal(t, int64(1), w.Metrics.Get("jobs_lost_total"))
+}
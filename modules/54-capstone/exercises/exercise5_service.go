@@ -0,0 +1,97 @@
+package exercises
+
+// EXERCISE: Milestone 5: fix the shutdown of the service that runs the API and the workers together.
+// Run serves the API and runs the workers until ctx is done, and then
+// should stop taking requests, finish those in flight, and finish the
+// jobs already queued before it returns. But it shuts the server down
+// with ctx, which is already done, so requests in flight are cut off;
+// it reports http.ErrServerClosed, which is how Serve says it was shut
+// down, as a failure; the workers stop with ctx, and leave jobs queued;
+// and Run does not wait for them anyway.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Config is what a Service needs to run.
+type Config struct {
+	Tokens    map[string]string // bearer token -> owner
+	Workers   int               // 1 if 0
+	QueueSize int               // 100 if 0
+	// ShutdownTimeout bounds how long Run waits for requests in flight,
+	// once ctx is done; 10s if 0. Jobs already queued are always finished.
+	ShutdownTimeout time.Duration
+	// Process is each Worker's Process.
+	Process func(ctx context.Context, job Job) (*Summary, error)
+}
+
+// Service is the API and the workers, over one store and one queue, as
+// one program: two services that would run as two in production, and
+// talk through a real broker.
+type Service struct {
+	Store   *CachedStore
+	Queue   *Queue
+	Metrics *Metrics
+	API     *API
+	cfg     Config
+}
+
+// NewService returns a Service with an empty store and queue.
+func NewService(cfg Config) *Service {
+	if cfg.Workers == 0 {
+		cfg.Workers = 1
+	}
+	if cfg.QueueSize == 0 {
+		cfg.QueueSize = 100
+	}
+	if cfg.ShutdownTimeout == 0 {
+		cfg.ShutdownTimeout = 10 * time.Second
+	}
+	s := &Service{
+		Store:   NewCachedStore(NewMemStore()),
+		Queue:   NewQueue(cfg.QueueSize),
+		Metrics: NewMetrics(),
+		cfg:     cfg,
+	}
+	s.API = &API{Store: s.Store, Queue: s.Queue, Metrics: s.Metrics, Tokens: cfg.Tokens}
+	return s
+}
+
+// Run serves the API on ln, and runs the workers, until ctx is done. Then
+// it shuts down in order: the server stops taking requests and finishes
+// those in flight, which may still queue jobs; the queue is closed; and
+// the workers finish the jobs in it. It returns once they have, with the
+// first error that stopped the server.
+func (s *Service) Run(ctx context.Context, ln net.Listener) error {
+	var workers sync.WaitGroup
+	for i := 0; i < s.cfg.Workers; i++ {
+		w := &Worker{Store: s.Store, Queue: s.Queue, Metrics: s.Metrics, Process: s.cfg.Process}
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			w.Run(ctx) // BUG: ctx is done before the queue is drained
+		}()
+	}
+
+	srv := &http.Server{Handler: s.API.Handler(), ReadHeaderTimeout: 5 * time.Second}
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.Serve(ln) }()
+
+	var err error
+	select {
+	case <-ctx.Done():
+		err = srv.Shutdown(ctx)            // BUG: ctx is done, so Shutdown gives up at once
+		err = errors.Join(err, <-serveErr) // BUG: Serve returns http.ErrServerClosed after Shutdown
+	case err = <-serveErr:
+	}
+
+	s.Queue.Close()
+	// BUG: returns before the workers finish the jobs in the queue
+	return err
+}
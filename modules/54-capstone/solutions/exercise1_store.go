@@ -0,0 +1,193 @@
+package solutions
+
+// SOLUTION: Milestone 1: fix the job store and the cache in front of it.
+// Fixed: Get returns ErrNotFound, wrapped with the ID, for a job that does not exist
+// Fixed: Get and List return copies, so callers cannot change the stored jobs through them
+// Fixed: Update changes a copy, and stores it only if the function succeeds
+// Fixed: List returns jobs in the order they were created
+// Fixed: CachedStore caches only finished jobs, which never change again
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/clone"
+)
+
+// Status is where a job is in its life.
+type Status string
+
+// The statuses of a job. Queued and running jobs change; done and failed
+// ones are finished, and never change again.
+const (
+	StatusQueued  Status = "queued"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// Finished reports whether a job with status s will never change again.
+func (s Status) Finished() bool {
+	return s == StatusDone || s == StatusFailed
+}
+
+// Job is a request to summarize a list of numbers, and its outcome.
+type Job struct {
+	ID       string    `json:"id"`
+	Owner    string    `json:"owner"`
+	Numbers  []float64 `json:"numbers"`
+	Status   Status    `json:"status"`
+	Attempts int       `json:"attempts"`
+	Summary  *Summary  `json:"summary,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	Created  time.Time `json:"created"`
+}
+
+// Summary is what a worker works out from a job's numbers.
+type Summary struct {
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Median float64 `json:"median"`
+}
+
+// ErrNotFound is returned, wrapped, for a job that does not exist.
+var ErrNotFound = errors.New("job not found")
+
+// Store keeps jobs. The API creates them, workers update them, and both
+// read them; MemStore keeps them in memory, and a database would do in
+// production.
+type Store interface {
+	// Create stores a new job, gives it an ID, and returns it.
+	Create(ctx context.Context, job Job) (Job, error)
+	// Get returns the job id.
+	Get(ctx context.Context, id string) (Job, error)
+	// Update calls change on the job id, and stores the result if change
+	// returns nil.
+	Update(ctx context.Context, id string, change func(*Job) error) (Job, error)
+	// List returns owner's jobs, oldest first.
+	List(ctx context.Context, owner string) ([]Job, error)
+}
+
+// MemStore is a Store in memory. It is safe for concurrent use.
+type MemStore struct {
+	mu   sync.Mutex
+	jobs map[string]*Job
+	seq  map[string]int // the order jobs were created in, by ID
+	next int
+}
+
+// NewMemStore returns an empty MemStore.
+func NewMemStore() *MemStore {
+	return &MemStore{jobs: make(map[string]*Job), seq: make(map[string]int)}
+}
+
+// Create implements Store. IDs are job-1, job-2, and so on.
+func (s *MemStore) Create(ctx context.Context, job Job) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next++
+	job.ID = fmt.Sprintf("job-%d", s.next)
+	stored := clone.Clone(job)
+	s.jobs[job.ID] = &stored
+	s.seq[job.ID] = s.next
+	return clone.Clone(stored), nil
+}
+
+// Get implements Store.
+func (s *MemStore) Get(ctx context.Context, id string) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return Job{}, fmt.Errorf("%w: %s", ErrNotFound, id) // Fixed: not a zero Job and no error
+	}
+	return clone.Clone(*job), nil // Fixed: not the stored Numbers and Summary
+}
+
+// Update implements Store.
+func (s *MemStore) Update(ctx context.Context, id string, change func(*Job) error) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return Job{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	changed := clone.Clone(*job) // Fixed: change a copy, in case change fails halfway
+	if err := change(&changed); err != nil {
+		return Job{}, err
+	}
+	changed.ID = id
+	*job = changed
+	return clone.Clone(changed), nil
+}
+
+// List implements Store.
+func (s *MemStore) List(ctx context.Context, owner string) ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var jobs []Job
+	for _, job := range s.jobs {
+		if job.Owner == owner {
+			jobs = append(jobs, clone.Clone(*job))
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return s.seq[jobs[i].ID] < s.seq[jobs[j].ID] }) // Fixed: not map order
+	return jobs, nil
+}
+
+// CachedStore is a Store that keeps finished jobs in memory in front of
+// another, slower Store. Unfinished jobs still change, so they are always
+// read from the other Store. It is safe for concurrent use.
+type CachedStore struct {
+	Store
+
+	mu     sync.Mutex
+	cache  map[string]Job
+	hits   int
+	misses int
+}
+
+// NewCachedStore returns a CachedStore in front of s.
+func NewCachedStore(s Store) *CachedStore {
+	return &CachedStore{Store: s, cache: make(map[string]Job)}
+}
+
+// Get implements Store.
+func (c *CachedStore) Get(ctx context.Context, id string) (Job, error) {
+	c.mu.Lock()
+	job, ok := c.cache[id]
+	if ok {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	c.mu.Unlock()
+	if ok {
+		return clone.Clone(job), nil
+	}
+
+	job, err := c.Store.Get(ctx, id)
+	if err != nil {
+		return Job{}, err
+	}
+	if job.Status.Finished() { // Fixed: a queued job cached would stay queued
+		c.mu.Lock()
+		c.cache[id] = clone.Clone(job)
+		c.mu.Unlock()
+	}
+	return job, nil
+}
+
+// Stats returns how many reads the cache answered, and how many it passed
+// on.
+func (c *CachedStore) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
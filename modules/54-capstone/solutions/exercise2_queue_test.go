@@ -0,0 +1,136 @@
+package solutions
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueOrder(t *testing.T) {
+	q := NewQueue(10)
+	for _, id := range []string{"job-1", "job-2", "job-3"} {
+		require.NoError(t, q.Publish(id))
+	}
+	assert.Equal(t, 3, q.Len())
+	for _, id := range []string{"job-1", "job-2", "job-3"} {
+		d, err := q.Receive(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, Delivery{JobID: id, Attempt: 1}, d)
+	}
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestQueueLimit(t *testing.T) {
+	q := NewQueue(2)
+	require.NoError(t, q.Publish("job-1"))
+	require.NoError(t, q.Publish("job-2"))
+	assert.ErrorIs(t, q.Publish("job-3"), ErrFull)
+
+	q.Requeue(Delivery{JobID: "job-0", Attempt: 1})
+	assert.Equal(t, 3, q.Len(), "Requeue does not count against the limit")
+
+	q.Receive(ctx)
+	q.Receive(ctx)
+	assert.NoError(t, q.Publish("job-3"), "Publish once there is room again")
+}
+
+func TestQueueRequeue(t *testing.T) {
+	q := NewQueue(1)
+	q.Publish("job-1")
+	d, _ := q.Receive(ctx)
+	for want := 2; want <= 4; want++ {
+		q.Requeue(d)
+		d, _ = q.Receive(ctx)
+		assert.Equal(t, Delivery{JobID: "job-1", Attempt: want}, d)
+	}
+}
+
+func TestQueueClose(t *testing.T) {
+	q := NewQueue(10)
+	q.Publish("job-1")
+
+	// A receiver waiting on an empty queue when it is closed.
+	waiting := NewQueue(10)
+	errs := make(chan error, 1)
+	go func() {
+		_, err := waiting.Receive(ctx)
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	q.Close()
+	waiting.Close()
+	q.Close() // twice is fine
+	assert.ErrorIs(t, q.Publish("job-2"), ErrClosed)
+
+	d, err := q.Receive(ctx)
+	require.NoError(t, err, "the messages already in the queue are still received")
+	assert.Equal(t, "job-1", d.JobID)
+	_, err = q.Receive(ctx)
+	assert.ErrorIs(t, err, ErrClosed)
+
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, ErrClosed)
+	case <-time.After(time.Second):
+		t.Fatal("Receive still waiting a second after Close")
+	}
+}
+
+func TestQueueContext(t *testing.T) {
+	q := NewQueue(10)
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err := q.Receive(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestQueueReceivers(t *testing.T) {
+	const n = 200
+	q := NewQueue(n)
+	var mu sync.Mutex
+	got := map[string]int{}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				d, err := q.Receive(ctx)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				got[d.JobID]++
+				mu.Unlock()
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				q.Len()
+			}
+		}
+	}()
+	for i := 0; i < n; i++ {
+		require.NoError(t, q.Publish(fmt.Sprintf("job-%d", i)))
+	}
+	q.Close()
+	wg.Wait()
+	close(done)
+
+	assert.Len(t, got, n)
+	for id, times := range got {
+		assert.Equal(t, 1, times, "%s received", id)
+	}
+}
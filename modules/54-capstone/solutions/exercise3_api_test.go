@@ -0,0 +1,192 @@
+package solutions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/faker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var created = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// newAPI returns an API over an empty store and a queue of size, with two
+// owners, whose tokens are "alice" and "bob".
+func newAPI(t *testing.T, size int) (*API, map[string]string) {
+	f := faker.For(t, 30)
+	owners := map[string]string{"alice": f.Email(), "bob": f.Email()}
+	for owners["bob"] == owners["alice"] {
+		owners["bob"] = f.Email()
+	}
+	a := &API{
+		Store:   NewMemStore(),
+		Queue:   NewQueue(size),
+		Metrics: NewMetrics(),
+		Tokens:  map[string]string{"alice": owners["alice"], "bob": owners["bob"]},
+		Now:     func() time.Time { return created },
+	}
+	return a, owners
+}
+
+// serve sends a request to h, as the owner of token if it is not "".
+func serve(h http.Handler, method, path, token string, body io.Reader) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, body)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func decode[T any](t *testing.T, w *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &v), "body: %s", w.Body)
+	return v
+}
+
+func TestCreateJob(t *testing.T) {
+	a, owners := newAPI(t, 10)
+	h := a.Handler()
+	w := serve(h, "POST", "/jobs", "alice", strings.NewReader(`{"numbers": [3, 1, 2]}`))
+	require.Equal(t, http.StatusAccepted, w.Code, "body: %s", w.Body)
+	assert.Equal(t, "/jobs/job-1", w.Header().Get("Location"))
+	want := Job{ID: "job-1", Owner: owners["alice"], Numbers: []float64{3, 1, 2}, Status: StatusQueued, Created: created}
+	assert.Equal(t, want, decode[Job](t, w))
+
+	d, err := a.Queue.Receive(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", d.JobID)
+	assert.Equal(t, int64(1), a.Metrics.Get("jobs_created_total"))
+}
+
+func TestCreateJobRefused(t *testing.T) {
+	a, _ := newAPI(t, 10)
+	h := a.Handler()
+	for _, tc := range []struct {
+		name, method, token, body string
+		code                      int
+	}{
+		{"no token", "POST", "", `{"numbers": [1]}`, http.StatusUnauthorized},
+		{"unknown token", "POST", "carol", `{"numbers": [1]}`, http.StatusUnauthorized},
+		{"not JSON", "POST", "alice", `numbers: 1`, http.StatusUnprocessableEntity},
+		{"no numbers", "POST", "alice", `{"numbers": []}`, http.StatusUnprocessableEntity},
+		{"not numbers", "POST", "alice", `{"numbers": ["1"]}`, http.StatusUnprocessableEntity},
+		{"other fields", "POST", "alice", `{"numbers": [1], "owner": "bob"}`, http.StatusUnprocessableEntity},
+		{"method", "PUT", "alice", `{"numbers": [1]}`, http.StatusMethodNotAllowed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serve(h, tc.method, "/jobs", tc.token, strings.NewReader(tc.body))
+			assert.Equal(t, tc.code, w.Code, "body: %s", w.Body)
+			assert.NotEmpty(t, decode[map[string]string](t, w)["error"])
+		})
+	}
+	assert.Equal(t, 0, a.Queue.Len())
+}
+
+func TestCreateJobTooLarge(t *testing.T) {
+	a, _ := newAPI(t, 10)
+	body := `{"numbers": [1` + strings.Repeat(", 1", MaxBody/3) + `]}`
+	w := serve(a.Handler(), "POST", "/jobs", "alice", strings.NewReader(body))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	jobs, _ := a.Store.List(ctx, a.Tokens["alice"])
+	assert.Empty(t, jobs)
+}
+
+func TestCreateJobQueueFull(t *testing.T) {
+	a, _ := newAPI(t, 1)
+	h := a.Handler()
+	serve(h, "POST", "/jobs", "alice", strings.NewReader(`{"numbers": [1]}`))
+	w := serve(h, "POST", "/jobs", "alice", strings.NewReader(`{"numbers": [2]}`))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	job, err := a.Store.Get(ctx, "job-2")
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, job.Status, "a job no worker will see")
+	assert.NotEmpty(t, job.Error)
+}
+
+func TestGetJob(t *testing.T) {
+	a, owners := newAPI(t, 10)
+	h := a.Handler()
+	serve(h, "POST", "/jobs", "alice", strings.NewReader(`{"numbers": [1]}`))
+	serve(h, "POST", "/jobs", "bob", strings.NewReader(`{"numbers": [2]}`))
+	serve(h, "POST", "/jobs", "alice", strings.NewReader(`{"numbers": [3]}`))
+
+	w := serve(h, "GET", "/jobs/job-1", "alice", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, owners["alice"], decode[Job](t, w).Owner)
+
+	w = serve(h, "GET", "/jobs/job-1", "bob", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code, "bob asked for alice's job")
+	assert.NotContains(t, w.Body.String(), owners["alice"])
+	w = serve(h, "GET", "/jobs/job-9", "bob", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve(h, "GET", "/jobs", "alice", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var ids []string
+	for _, j := range decode[[]Job](t, w) {
+		ids = append(ids, j.ID)
+	}
+	assert.Equal(t, []string{"job-1", "job-3"}, ids)
+}
+
+func TestMetricsConcurrent(t *testing.T) {
+	m := NewMetrics()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Inc("events_total")
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(800), m.Get("events_total"))
+}
+
+func TestMetrics(t *testing.T) {
+	a, _ := newAPI(t, 100)
+	srv := httptest.NewServer(a.Handler())
+	defer srv.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			token := "alice"
+			if i%4 == 0 {
+				token = "carol"
+			}
+			req, _ := http.NewRequest("POST", srv.URL+"/jobs", strings.NewReader(fmt.Sprintf(`{"numbers": [%d]}`, i)))
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, err := http.DefaultClient.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "http_requests_total{code=\"202\"} 15\n"+
+		"http_requests_total{code=\"401\"} 5\n"+
+		"jobs_created_total 15\n", string(body))
+}
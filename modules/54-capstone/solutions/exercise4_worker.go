@@ -0,0 +1,119 @@
+package solutions
+
+// SOLUTION: Milestone 4: fix the worker that takes jobs from the queue and summarizes them.
+// Fixed: Run returns nil once the queue is closed and empty, instead of spinning on ErrClosed
+// Fixed: a job already finished is skipped, since the queue may deliver a message twice
+// Fixed: a job that fails MaxAttempts times is marked failed, and not requeued forever
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/stats"
+)
+
+// DefaultMaxAttempts is the Worker.MaxAttempts of a Worker that sets none.
+const DefaultMaxAttempts = 3
+
+// Summarize works out the summary of numbers.
+func Summarize(numbers []float64) (*Summary, error) {
+	if len(numbers) == 0 {
+		return nil, errors.New("no numbers to summarize")
+	}
+	var acc stats.Accumulator
+	acc.AddAll(numbers...)
+	return &Summary{Count: acc.Count(), Mean: acc.Mean(), Min: acc.Min(), Max: acc.Max(), Median: acc.Quantile(0.5)}, nil
+}
+
+// Worker takes job IDs from the queue, and summarizes the jobs' numbers.
+// Run as many as there are cores to use; they share the queue.
+type Worker struct {
+	Store   Store
+	Queue   *Queue
+	Metrics *Metrics
+	// MaxAttempts is how many times a job is tried before it is marked
+	// failed; DefaultMaxAttempts if 0.
+	MaxAttempts int
+	// Process works out a job's summary; Summarize of its numbers if nil.
+	Process func(ctx context.Context, job Job) (*Summary, error)
+}
+
+// Run handles messages until the queue is closed and empty, and returns
+// nil, or until ctx is done, and returns ctx.Err().
+func (w *Worker) Run(ctx context.Context) error {
+	for {
+		d, err := w.Queue.Receive(ctx)
+		if errors.Is(err, ErrClosed) {
+			return nil // Fixed: there will be no more
+		}
+		if err != nil {
+			return err
+		}
+		w.handle(ctx, d)
+	}
+}
+
+// handle handles one message.
+func (w *Worker) handle(ctx context.Context, d Delivery) {
+	job, err := w.Store.Update(ctx, d.JobID, func(j *Job) error {
+		if j.Status.Finished() {
+			return errFinished // Fixed: delivered twice; its work is done
+		}
+		j.Status = StatusRunning
+		j.Attempts = d.Attempt
+		return nil
+	})
+	switch {
+	case errors.Is(err, errFinished):
+		w.Metrics.Inc("jobs_duplicate_total")
+		return
+	case errors.Is(err, ErrNotFound):
+		w.Metrics.Inc("jobs_lost_total") // nothing to retry
+		return
+	case err != nil:
+		w.retry(ctx, d, err)
+		return
+	}
+
+	process := w.Process
+	if process == nil {
+		process = func(_ context.Context, job Job) (*Summary, error) { return Summarize(job.Numbers) }
+	}
+	summary, err := process(ctx, job)
+	if err != nil {
+		w.retry(ctx, d, err)
+		return
+	}
+	_, err = w.Store.Update(ctx, d.JobID, func(j *Job) error {
+		j.Status, j.Summary, j.Error = StatusDone, summary, ""
+		return nil
+	})
+	if err != nil {
+		w.retry(ctx, d, err)
+		return
+	}
+	w.Metrics.Inc("jobs_done_total")
+}
+
+// errFinished stops the update of a job that is already finished.
+var errFinished = errors.New("job already finished")
+
+// retry requeues d after err, or marks its job failed if it has had its
+// attempts.
+func (w *Worker) retry(ctx context.Context, d Delivery, err error) {
+	max := w.MaxAttempts
+	if max == 0 {
+		max = DefaultMaxAttempts
+	}
+	if d.Attempt < max { // Fixed: a job that always fails would go round forever
+		w.Metrics.Inc("jobs_retried_total")
+		w.Queue.Requeue(d)
+		return
+	}
+	w.Store.Update(ctx, d.JobID, func(j *Job) error {
+		j.Status, j.Error = StatusFailed, fmt.Sprintf("after %d attempts: %v", d.Attempt, err)
+		return nil
+	})
+	w.Metrics.Inc("jobs_failed_total")
+}
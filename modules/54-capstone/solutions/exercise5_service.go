@@ -0,0 +1,97 @@
+package solutions
+
+// SOLUTION: Milestone 5: fix the shutdown of the service that runs the API and the workers together.
+// Fixed: the server is shut down with a context of its own, since ctx is already done by then
+// Fixed: http.ErrServerClosed, which Serve returns after Shutdown, is not an error
+// Fixed: the workers run on a context that outlives ctx, and stop when the queue is closed and empty
+// Fixed: Run waits for the workers before it returns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Config is what a Service needs to run.
+type Config struct {
+	Tokens    map[string]string // bearer token -> owner
+	Workers   int               // 1 if 0
+	QueueSize int               // 100 if 0
+	// ShutdownTimeout bounds how long Run waits for requests in flight,
+	// once ctx is done; 10s if 0. Jobs already queued are always finished.
+	ShutdownTimeout time.Duration
+	// Process is each Worker's Process.
+	Process func(ctx context.Context, job Job) (*Summary, error)
+}
+
+// Service is the API and the workers, over one store and one queue, as
+// one program: two services that would run as two in production, and
+// talk through a real broker.
+type Service struct {
+	Store   *CachedStore
+	Queue   *Queue
+	Metrics *Metrics
+	API     *API
+	cfg     Config
+}
+
+// NewService returns a Service with an empty store and queue.
+func NewService(cfg Config) *Service {
+	if cfg.Workers == 0 {
+		cfg.Workers = 1
+	}
+	if cfg.QueueSize == 0 {
+		cfg.QueueSize = 100
+	}
+	if cfg.ShutdownTimeout == 0 {
+		cfg.ShutdownTimeout = 10 * time.Second
+	}
+	s := &Service{
+		Store:   NewCachedStore(NewMemStore()),
+		Queue:   NewQueue(cfg.QueueSize),
+		Metrics: NewMetrics(),
+		cfg:     cfg,
+	}
+	s.API = &API{Store: s.Store, Queue: s.Queue, Metrics: s.Metrics, Tokens: cfg.Tokens}
+	return s
+}
+
+// Run serves the API on ln, and runs the workers, until ctx is done. Then
+// it shuts down in order: the server stops taking requests and finishes
+// those in flight, which may still queue jobs; the queue is closed; and
+// the workers finish the jobs in it. It returns once they have, with the
+// first error that stopped the server.
+func (s *Service) Run(ctx context.Context, ln net.Listener) error {
+	var workers sync.WaitGroup
+	for i := 0; i < s.cfg.Workers; i++ {
+		w := &Worker{Store: s.Store, Queue: s.Queue, Metrics: s.Metrics, Process: s.cfg.Process}
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			w.Run(context.Background()) // Fixed: ctx is done before the queue is drained
+		}()
+	}
+
+	srv := &http.Server{Handler: s.API.Handler(), ReadHeaderTimeout: 5 * time.Second}
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.Serve(ln) }()
+
+	var err error
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout) // Fixed: not ctx
+		defer cancel()
+		err = srv.Shutdown(shutdownCtx)
+		if serr := <-serveErr; !errors.Is(serr, http.ErrServerClosed) { // Fixed: the server closing is the plan
+			err = errors.Join(err, serr)
+		}
+	case err = <-serveErr:
+	}
+
+	s.Queue.Close()
+	workers.Wait() // Fixed: the jobs in the queue are not done yet
+	return err
+}
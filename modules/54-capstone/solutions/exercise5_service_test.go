@@ -0,0 +1,131 @@
+package solutions
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// start runs s on a port of its own until the returned stop is called;
+// stop returns what Run did, which it must within two seconds.
+func start(t *testing.T, s *Service) (url string, stop func() error) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run(ctx, ln) }()
+	return "http://" + ln.Addr().String(), func() error {
+		cancel()
+		select {
+		case err := <-errs:
+			return err
+		case <-time.After(2 * time.Second):
+			t.Fatal("Run still running two seconds after ctx was done")
+			return nil
+		}
+	}
+}
+
+// client does without keep-alives: Shutdown waits, for a while, for a
+// connection the transport dialed but did not use yet.
+var client = &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+
+// post creates a job with body, as alice.
+func post(url string, body io.Reader) (*http.Response, error) {
+	req, _ := http.NewRequest("POST", url+"/jobs", body)
+	req.Header.Set("Authorization", "Bearer alice")
+	return client.Do(req)
+}
+
+func TestService(t *testing.T) {
+	s := NewService(Config{Tokens: map[string]string{"alice": "alice@example.com"}, Workers: 2})
+	url, stop := start(t, s)
+
+	resp, err := post(url, strings.NewReader(`{"numbers": [1, 2, 6]}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	var job Job
+	require.Eventually(t, func() bool {
+		req, _ := http.NewRequest("GET", url+resp.Header.Get("Location"), nil)
+		req.Header.Set("Authorization", "Bearer alice")
+		resp, err := client.Do(req)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		return json.NewDecoder(resp.Body).Decode(&job) == nil && job.Status.Finished()
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, &Summary{Count: 3, Mean: 3, Min: 1, Max: 6, Median: 2}, job.Summary)
+
+	assert.NoError(t, stop())
+	_, err = post(url, strings.NewReader(`{"numbers": [1]}`))
+	assert.Error(t, err, "the server still answers after Run returned")
+}
+
+func TestServiceDrains(t *testing.T) {
+	s := NewService(Config{
+		Tokens:  map[string]string{"alice": "alice@example.com"},
+		Workers: 2,
+		Process: func(_ context.Context, job Job) (*Summary, error) {
+			time.Sleep(5 * time.Millisecond)
+			return Summarize(job.Numbers)
+		},
+	})
+	url, stop := start(t, s)
+	for i := 0; i < 20; i++ {
+		resp, err := post(url, strings.NewReader(`{"numbers": [1]}`))
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	require.NoError(t, stop())
+
+	jobs, _ := s.Store.List(ctx, "alice@example.com")
+	require.Len(t, jobs, 20)
+	for _, job := range jobs {
+		assert.Equal(t, StatusDone, job.Status, "%s once Run returned", job.ID)
+	}
+}
+
+func TestServiceRequestInFlight(t *testing.T) {
+	s := NewService(Config{Tokens: map[string]string{"alice": "alice@example.com"}})
+	url, stop := start(t, s)
+
+	body, w := io.Pipe()
+	responses := make(chan *http.Response, 1)
+	go func() {
+		resp, err := post(url, body)
+		if err != nil {
+			t.Error(err)
+		}
+		responses <- resp
+	}()
+	io.WriteString(w, `{"numbers": [`)
+	time.Sleep(50 * time.Millisecond) // the server is reading the body
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- stop() }()
+	time.Sleep(50 * time.Millisecond) // the server is shutting down
+	io.WriteString(w, `4, 2]}`)
+	w.Close()
+
+	resp := <-responses
+	require.NotNil(t, resp)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode, "a request sent before shutdown")
+	require.NoError(t, <-stopped)
+
+	job, err := s.Store.Get(ctx, "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, StatusDone, job.Status, "a job queued during shutdown, once Run returned")
+}
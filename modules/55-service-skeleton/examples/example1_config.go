@@ -0,0 +1,74 @@
+// Package examples demonstrates the parts every production service has
+// besides its own endpoints: configuration from flags and the environment,
+// structured logs with log/slog, and a lifecycle that starts serving only
+// when ready and drains before it stops. The exercises wire them together
+// into one main.
+//
+// This file shows:
+// - A flag.FlagSet of its own, which tests can parse again and again
+// - flag.TextVar, for flags of any type that implements encoding.TextUnmarshaler
+// - Environment variables named after the flags, read before them so that flags win
+// - flag.Visit, which reports the flags that were actually set
+package examples
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/netip"
+	"strings"
+)
+
+// Settings is a small program's configuration.
+type Settings struct {
+	Name  string
+	Level slog.Level
+	Bind  netip.AddrPort
+}
+
+// LoadSettings parses args over the variables env holds, named APP_ and
+// the flag's name in upper case, over the defaults. It returns the names
+// of the flags args set, too.
+func LoadSettings(args []string, env map[string]string) (Settings, []string, error) {
+	s := Settings{Name: "app", Level: slog.LevelInfo, Bind: netip.MustParseAddrPort("127.0.0.1:8080")}
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&s.Name, "name", s.Name, "the program's name in its logs")
+	fs.TextVar(&s.Level, "level", s.Level, "the lowest level to log")
+	fs.TextVar(&s.Bind, "bind", s.Bind, "the address and port to listen on")
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if v, ok := env["APP_"+strings.ToUpper(f.Name)]; ok && err == nil {
+			if e := f.Value.Set(v); e != nil {
+				err = fmt.Errorf("APP_%s: %w", strings.ToUpper(f.Name), e)
+			}
+		}
+	})
+	if err != nil {
+		return s, nil, err
+	}
+	if err := fs.Parse(args); err != nil {
+		return s, nil, err
+	}
+	var set []string
+	fs.Visit(func(f *flag.Flag) { set = append(set, f.Name) })
+	return s, set, nil
+}
+
+// DemonstrateConfig loads settings from each layer.
+func DemonstrateConfig() {
+	fmt.Println("=== Config ===")
+	s, set, err := LoadSettings(nil, nil)
+	fmt.Printf("Defaults: %+v %v %v\n", s, set, err)
+
+	env := map[string]string{"APP_LEVEL": "warn", "APP_NAME": "billing"}
+	s, set, err = LoadSettings([]string{"-name", "billing-canary"}, env)
+	fmt.Printf("Env and flags: %+v set=%v %v\n", s, set, err)
+
+	_, _, err = LoadSettings([]string{"-bind", "localhost"}, nil)
+	fmt.Println("Bad flag:", err)
+	_, _, err = LoadSettings(nil, map[string]string{"APP_LEVEL": "loud"})
+	fmt.Println("Bad variable:", err)
+}
@@ -0,0 +1,30 @@
+package examples
+
+import (
+	"log/slog"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateConfig(t *testing.T) {
+	DemonstrateConfig()
+}
+
+func TestLoadSettingsLayers(t *testing.T) {
+	s, set, err := LoadSettings([]string{"-level", "debug"}, map[string]string{"APP_LEVEL": "error", "APP_BIND": "[::1]:9000"})
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelDebug, s.Level, "the flag wins over the variable")
+	assert.Equal(t, netip.MustParseAddrPort("[::1]:9000"), s.Bind)
+	assert.Equal(t, "app", s.Name)
+	assert.Equal(t, []string{"level"}, set, "variables are not flags set")
+}
+
+func TestLoadSettingsErrors(t *testing.T) {
+	_, _, err := LoadSettings([]string{"-verbose"}, nil)
+	assert.ErrorContains(t, err, "-verbose")
+	_, _, err = LoadSettings(nil, map[string]string{"APP_BIND": "8080"})
+	assert.ErrorContains(t, err, "APP_BIND")
+}
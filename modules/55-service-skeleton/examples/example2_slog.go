@@ -0,0 +1,48 @@
+package examples
+
+// This file shows:
+// - slog's JSON and text handlers, and records with typed attributes
+// - Logger.With and WithGroup, for attributes every record of a request carries
+// - slog.LevelVar, to change the level of a running service
+// - slog.NewLogLogger, bridging a *log.Logger, such as http.Server.ErrorLog, to slog
+// - ReplaceAttr, to drop or rename attributes, here the time for output that does not change
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// newLogger returns a JSON logger to w, at the level of lv, without
+// times, so that the examples print the same every run.
+func newLogger(w io.Writer, lv *slog.LevelVar) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level: lv,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+// DemonstrateSlog logs at several levels, with attributes, and through a
+// *log.Logger.
+func DemonstrateSlog() {
+	fmt.Println("\n=== slog ===")
+	var lv slog.LevelVar // INFO
+	logger := newLogger(os.Stdout, &lv)
+
+	logger.Info("started", "version", "1.4.2", "workers", 4)
+	logger.Debug("not shown at INFO")
+	lv.Set(slog.LevelDebug)
+	logger.Debug("shown once the level is DEBUG")
+
+	req := logger.With("request_id", "r-17").WithGroup("http")
+	req.Info("request", "method", "GET", "path", "/hello", "code", 200)
+
+	std := slog.NewLogLogger(logger.Handler(), slog.LevelError)
+	std.Printf("http: TLS handshake error from %s: EOF", "10.0.0.9:53122")
+}
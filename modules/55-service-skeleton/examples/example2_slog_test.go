@@ -0,0 +1,51 @@
+package examples
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateSlog(t *testing.T) {
+	DemonstrateSlog()
+}
+
+// records decodes the JSON records in out.
+func records(t *testing.T, out string) []map[string]any {
+	var rs []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var r map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &r), line)
+		rs = append(rs, r)
+	}
+	return rs
+}
+
+func TestLevelVar(t *testing.T) {
+	var b strings.Builder
+	var lv slog.LevelVar
+	lv.Set(slog.LevelWarn)
+	logger := newLogger(&b, &lv)
+	logger.Info("hidden")
+	lv.Set(slog.LevelInfo)
+	logger.Info("shown")
+
+	rs := records(t, b.String())
+	require.Len(t, rs, 1)
+	assert.Equal(t, "shown", rs[0]["msg"])
+	assert.NotContains(t, rs[0], "time")
+}
+
+func TestLogLoggerBridge(t *testing.T) {
+	var b strings.Builder
+	logger := newLogger(&b, &slog.LevelVar{})
+	slog.NewLogLogger(logger.With("component", "http").Handler(), slog.LevelError).Print("accept: too many open files")
+
+	rs := records(t, b.String())
+	require.Len(t, rs, 1)
+	assert.Equal(t, map[string]any{"level": "ERROR", "msg": "accept: too many open files", "component": "http"}, rs[0])
+}
@@ -0,0 +1,94 @@
+package examples
+
+// This file shows:
+// - Listening before reporting ready, so that the port is open when traffic arrives
+// - A context that says when to stop, from signal.NotifyContext in a real main
+// - Draining: failing readiness while still serving, before shutting down
+// - http.Server.Shutdown with a timeout of its own, and http.ErrServerClosed as the normal end
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// Lifecycle runs an HTTP server through its states, reporting each to
+// Event.
+type Lifecycle struct {
+	Handler         http.Handler
+	DrainDelay      time.Duration
+	ShutdownTimeout time.Duration
+	Event           func(string)
+
+	ready atomic.Bool
+}
+
+// Ready reports whether the server should get traffic: /readyz's answer.
+func (l *Lifecycle) Ready() bool { return l.ready.Load() }
+
+// Run serves on ln until ctx is done, then drains and shuts down.
+func (l *Lifecycle) Run(ctx context.Context, ln net.Listener) error {
+	srv := &http.Server{Handler: l.Handler, ReadHeaderTimeout: 5 * time.Second}
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.Serve(ln) }()
+	l.ready.Store(true)
+	l.Event("ready")
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	l.ready.Store(false)
+	l.Event("draining")
+	time.Sleep(l.DrainDelay)
+
+	l.Event("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), l.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	l.Event("stopped")
+	return nil
+}
+
+// DemonstrateLifecycle runs a server, sends it a request, and stops it.
+func DemonstrateLifecycle() {
+	fmt.Println("\n=== Lifecycle ===")
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		fmt.Println("listen:", err)
+		return
+	}
+	l := &Lifecycle{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "hello\n")
+		}),
+		DrainDelay:      20 * time.Millisecond,
+		ShutdownTimeout: time.Second,
+		Event:           func(e string) { fmt.Println("  event:", e) },
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- l.Run(ctx, ln) }()
+
+	time.Sleep(10 * time.Millisecond)
+	resp, err := http.Get("http://" + ln.Addr().String())
+	if err == nil {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		fmt.Printf("GET: %d %q, ready: %v\n", resp.StatusCode, body, l.Ready())
+	}
+	stop() // as SIGTERM would
+	fmt.Println("Run:", <-done, "ready:", l.Ready())
+}
@@ -0,0 +1,68 @@
+package examples
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateLifecycle(t *testing.T) {
+	DemonstrateLifecycle()
+}
+
+func TestLifecycleOrder(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	var mu sync.Mutex
+	var events []string
+	l := &Lifecycle{
+		Handler:         http.NotFoundHandler(),
+		DrainDelay:      50 * time.Millisecond,
+		ShutdownTimeout: time.Second,
+		Event: func(e string) {
+			mu.Lock()
+			events = append(events, e)
+			mu.Unlock()
+		},
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- l.Run(ctx, ln) }()
+	require.Eventually(t, l.Ready, time.Second, time.Millisecond)
+
+	stop()
+	require.Eventually(t, func() bool { return !l.Ready() }, time.Second, time.Millisecond)
+	resp, err := http.Get("http://" + ln.Addr().String())
+	require.NoError(t, err, "requests are served while draining")
+	resp.Body.Close()
+
+	require.NoError(t, <-done)
+	assert.Equal(t, []string{"ready", "draining", "shutting down", "stopped"}, events)
+}
+
+func TestLifecycleShutdownTimeout(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	release := make(chan struct{})
+	defer close(release)
+	l := &Lifecycle{
+		Handler:         http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { <-release }),
+		ShutdownTimeout: 50 * time.Millisecond,
+		Event:           func(string) {},
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- l.Run(ctx, ln) }()
+	require.Eventually(t, l.Ready, time.Second, time.Millisecond)
+
+	go http.Get("http://" + ln.Addr().String())
+	time.Sleep(20 * time.Millisecond)
+	stop()
+	assert.ErrorIs(t, <-done, context.DeadlineExceeded, "a request that outlives the timeout")
+}
@@ -0,0 +1,111 @@
+package exercises
+
+// EXERCISE: Fix the configuration and the logger of the service, which lose settings and log at the wrong level.
+// Each setting has a default, an environment variable, and a flag, and a
+// flag should win over a variable: an operator who passes -addr means it.
+// But LoadConfig reads the environment after the flags, so a variable
+// left in a deployment overrides them; it stops at the first bad variable,
+// so fixing a configuration takes one run per mistake; and it logs as text
+// for any -log-format but json. NewLogger, meanwhile, ignores the level.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// EnvPrefix starts the name of each environment variable the service
+// reads: -drain-delay is SERVICED_DRAIN_DELAY.
+const EnvPrefix = "SERVICED_"
+
+// Config is the service's configuration. Each field has a default, an
+// environment variable, and a flag; a flag wins over a variable, and a
+// variable over the default.
+type Config struct {
+	Addr            string        // -addr; ":8080"
+	LogLevel        slog.Level    // -log-level; INFO
+	LogFormat       string        // -log-format, json or text; json
+	DrainDelay      time.Duration // -drain-delay, how long /readyz fails before shutdown; 5s
+	ShutdownTimeout time.Duration // -shutdown-timeout, how long requests in flight get; 10s
+}
+
+// DefaultConfig returns the configuration with no variables or flags set.
+func DefaultConfig() Config {
+	return Config{
+		Addr:            ":8080",
+		LogLevel:        slog.LevelInfo,
+		LogFormat:       "json",
+		DrainDelay:      5 * time.Second,
+		ShutdownTimeout: 10 * time.Second,
+	}
+}
+
+// flags returns the service's flags, which set the fields of cfg.
+func flags(cfg *Config) *flag.FlagSet {
+	fs := flag.NewFlagSet("serviced", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&cfg.Addr, "addr", cfg.Addr, "listen on `address`")
+	fs.TextVar(&cfg.LogLevel, "log-level", cfg.LogLevel, "log at `level` and above: DEBUG, INFO, WARN, or ERROR")
+	fs.StringVar(&cfg.LogFormat, "log-format", cfg.LogFormat, "log as json or text")
+	fs.DurationVar(&cfg.DrainDelay, "drain-delay", cfg.DrainDelay, "fail /readyz for `duration` before shutting down")
+	fs.DurationVar(&cfg.ShutdownTimeout, "shutdown-timeout", cfg.ShutdownTimeout, "give requests in flight `duration` to finish")
+	return fs
+}
+
+// LoadConfig returns the defaults, overridden by the variables getenv
+// returns, overridden by the flags in args. It reports every problem,
+// joined.
+func LoadConfig(args []string, getenv func(string) string) (Config, error) {
+	cfg := DefaultConfig()
+	fs := flags(&cfg)
+
+	var errs []error
+	if err := fs.Parse(args); err != nil {
+		errs = append(errs, err)
+	} else if fs.NArg() > 0 {
+		errs = append(errs, fmt.Errorf("unexpected argument %q", fs.Arg(0)))
+	}
+	var envErr error
+	fs.VisitAll(func(f *flag.Flag) { // BUG: after the flags, so a variable overrides a flag
+		name := EnvPrefix + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		if v := getenv(name); v != "" && envErr == nil {
+			envErr = f.Value.Set(v)
+		}
+	})
+	if envErr != nil {
+		return cfg, envErr // BUG: only the first bad variable, and without its name
+	}
+
+	// BUG: a -log-format other than json or text is accepted, and logs as text
+	if cfg.DrainDelay < 0 {
+		errs = append(errs, fmt.Errorf("drain-delay: %v is negative", cfg.DrainDelay))
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("shutdown-timeout: %v is not positive", cfg.ShutdownTimeout))
+	}
+	return cfg, errors.Join(errs...)
+}
+
+// Usage writes the flags and their defaults to w.
+func Usage(w io.Writer) {
+	cfg := DefaultConfig()
+	fs := flags(&cfg)
+	fs.SetOutput(w)
+	fmt.Fprintf(w, "Usage: serviced [flags]\n\nEach flag can also be set with %s and its name, such as %sADDR.\n\n", EnvPrefix, EnvPrefix)
+	fs.PrintDefaults()
+}
+
+// NewLogger returns a logger that writes records at cfg.LogLevel and above
+// to w, in cfg.LogFormat.
+func NewLogger(w io.Writer, cfg Config) *slog.Logger {
+	opts := &slog.HandlerOptions{} // BUG: without the level, it is always INFO
+	if cfg.LogFormat == "json" {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
@@ -0,0 +1,229 @@
+package exercises
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// servicedEnv, when set, makes the test binary run Serviced with the
+// arguments it holds, one per line, instead of the tests. That is how the
+// tests start the service as a process of its own, which they can signal.
+const servicedEnv = "LEARNGO_SERVICED_ARGS"
+
+func TestMain(m *testing.M) {
+	if args, ok := os.LookupEnv(servicedEnv); ok {
+		os.Exit(Serviced(strings.Split(args, "\n"), os.Stdout, os.Stderr))
+	}
+	os.Exit(m.Run())
+}
+
+// deadline is how long the service gets to stop after a signal, on top of
+// its drain delay.
+const deadline = 2 * time.Second
+
+// service is the service running as a separate process.
+type service struct {
+	cmd     *exec.Cmd
+	url     string
+	records chan map[string]any // what it logs, once it is listening
+	exited  chan error
+}
+
+// command returns the test binary, set to run Serviced with args.
+func command(args ...string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), servicedEnv+"="+strings.Join(args, "\n"))
+	return cmd
+}
+
+// start starts the service on a free port with args, and waits until it
+// logs that it is listening.
+func start(t *testing.T, args ...string) *service {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows cannot send SIGINT or SIGTERM to a process")
+	}
+	s := &service{
+		cmd:     command(append([]string{"-addr", "127.0.0.1:0"}, args...)...),
+		records: make(chan map[string]any, 100),
+		exited:  make(chan error, 1),
+	}
+	s.cmd.Stderr = os.Stderr
+	stdout, err := s.cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, s.cmd.Start())
+	t.Cleanup(func() { s.cmd.Process.Kill() })
+
+	lines := bufio.NewScanner(stdout)
+	for s.url == "" {
+		require.True(t, lines.Scan(), "the service exited before it listened")
+		record := decodeRecord(t, lines.Text())
+		if record["msg"] == "listening" {
+			s.url = "http://" + record["addr"].(string)
+		}
+	}
+	go func() {
+		for lines.Scan() {
+			s.records <- decodeRecord(t, lines.Text())
+		}
+		close(s.records)
+		s.exited <- s.cmd.Wait()
+	}()
+	return s
+}
+
+func decodeRecord(t *testing.T, line string) map[string]any {
+	var record map[string]any
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		t.Errorf("a log line that is not JSON: %q", line)
+	}
+	return record
+}
+
+// get requests path, and returns the status code and body.
+func (s *service) get(t *testing.T, path string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(s.url + path)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+// stop sends sig, and returns what the service logged from then on.
+func (s *service) stop(t *testing.T, sig os.Signal, drain time.Duration) []map[string]any {
+	t.Helper()
+	require.NoError(t, s.cmd.Process.Signal(sig))
+	return s.wait(t, drain)
+}
+
+// wait returns what the service logged until it exited. The test fails if
+// it does not exit with 0 within drain and the deadline.
+func (s *service) wait(t *testing.T, drain time.Duration) []map[string]any {
+	t.Helper()
+	select {
+	case err := <-s.exited:
+		require.NoError(t, err, "the service should exit with 0")
+	case <-time.After(drain + deadline):
+		s.cmd.Process.Kill()
+		t.Fatalf("the service did not exit within %v", drain+deadline)
+	}
+	var records []map[string]any
+	for r := range s.records {
+		records = append(records, r)
+	}
+	return records
+}
+
+// messages returns the msg of each record.
+func messages(records []map[string]any) []string {
+	var msgs []string
+	for _, r := range records {
+		msgs = append(msgs, r["msg"].(string))
+	}
+	return msgs
+}
+
+func TestServiced(t *testing.T) {
+	s := start(t, "-drain-delay", "0s")
+	code, body := s.get(t, "/hello?name=Ada")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "hello, Ada\n", body)
+	code, _ = s.get(t, "/healthz")
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = s.get(t, "/readyz")
+	assert.Equal(t, http.StatusOK, code)
+
+	assert.Equal(t, []string{"draining", "stopped"}, messages(s.stop(t, syscall.SIGTERM, 0)))
+}
+
+func TestServicedSignals(t *testing.T) {
+	for _, sig := range []os.Signal{os.Interrupt, syscall.SIGTERM} {
+		t.Run(sig.String(), func(t *testing.T) {
+			s := start(t, "-drain-delay", "0s")
+			assert.Contains(t, messages(s.stop(t, sig, 0)), "stopped")
+		})
+	}
+}
+
+func TestServicedDrains(t *testing.T) {
+	const drain = 500 * time.Millisecond
+	s := start(t, "-drain-delay", drain.String())
+	require.NoError(t, s.cmd.Process.Signal(syscall.SIGTERM))
+
+	require.Eventually(t, func() bool {
+		code, _ := s.get(t, "/readyz")
+		return code == http.StatusServiceUnavailable
+	}, drain/2, 10*time.Millisecond, "/readyz while the service drains")
+	code, _ := s.get(t, "/hello")
+	assert.Equal(t, http.StatusOK, code, "requests are still served while the service drains")
+
+	assert.Contains(t, messages(s.wait(t, drain)), "stopped")
+}
+
+func TestServicedMetrics(t *testing.T) {
+	s := start(t, "-drain-delay", "0s", "-log-level", "debug")
+	s.get(t, "/hello")
+	s.get(t, "/hello")
+	s.get(t, "/nowhere")
+	_, body := s.get(t, "/metrics")
+	assert.Equal(t, "http_requests_total{code=\"200\"} 2\n"+
+		"http_requests_total{code=\"404\"} 1\n", body)
+
+	records := s.stop(t, syscall.SIGTERM, 0)
+	var paths []string
+	for _, r := range records {
+		if r["msg"] == "request" {
+			assert.Equal(t, "DEBUG", r["level"])
+			paths = append(paths, r["path"].(string))
+		}
+	}
+	assert.Equal(t, []string{"/hello", "/hello", "/nowhere", "/metrics"}, paths, "requests logged at -log-level debug")
+}
+
+func TestServicedBadConfig(t *testing.T) {
+	cmd := command("-addr", "127.0.0.1:0", "-log-format", "xml")
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	require.NoError(t, cmd.Start())
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+	var err error
+	select {
+	case err = <-exited:
+	case <-time.After(deadline):
+		cmd.Process.Kill()
+		t.Fatal("the service started with -log-format xml")
+	}
+	var exit *exec.ExitError
+	require.True(t, errors.As(err, &exit), "the service should fail: %v", err)
+	assert.Equal(t, 2, exit.ExitCode())
+	assert.Contains(t, stderr.String(), "log-format")
+	assert.Contains(t, stderr.String(), "Usage")
+}
+
+func TestServicedAddrInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	out, err := command("-addr", ln.Addr().String()).Output()
+	var exit *exec.ExitError
+	require.True(t, errors.As(err, &exit), "the service should fail: %v", err)
+	assert.Equal(t, 1, exit.ExitCode())
+	assert.Contains(t, string(out), `"msg":"listen"`)
+}
@@ -0,0 +1,88 @@
+package solutions
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// env returns a getenv that looks up vars.
+func env(vars map[string]string) func(string) string {
+	return func(name string) string { return vars[name] }
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+	cfg, err := LoadConfig(nil, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultConfig(), cfg)
+}
+
+func TestLoadConfigLayers(t *testing.T) {
+	cfg, err := LoadConfig(
+		[]string{"-addr", "127.0.0.1:9000", "-drain-delay", "0s"},
+		env(map[string]string{
+			"SERVICED_ADDR":             ":7000",
+			"SERVICED_LOG_LEVEL":        "debug",
+			"SERVICED_SHUTDOWN_TIMEOUT": "3s",
+		}),
+	)
+	require.NoError(t, err)
+	want := DefaultConfig()
+	want.Addr = "127.0.0.1:9000" // the flag, over the variable
+	want.LogLevel = slog.LevelDebug
+	want.DrainDelay = 0
+	want.ShutdownTimeout = 3 * time.Second
+	assert.Equal(t, want, cfg)
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	_, err := LoadConfig(
+		[]string{"-log-format", "xml"},
+		env(map[string]string{"SERVICED_DRAIN_DELAY": "soon", "SERVICED_LOG_LEVEL": "loud"}),
+	)
+	require.Error(t, err)
+	for _, want := range []string{"log-format", "SERVICED_DRAIN_DELAY", "SERVICED_LOG_LEVEL"} {
+		assert.Contains(t, err.Error(), want, "every problem is reported")
+	}
+
+	for _, args := range [][]string{{"-port", "80"}, {"-shutdown-timeout", "0s"}, {"-drain-delay", "-1s"}, {"serve"}} {
+		_, err := LoadConfig(args, env(nil))
+		assert.Error(t, err, "%q", args)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	var b strings.Builder
+	Usage(&b)
+	for _, flag := range []string{"-addr", "-log-level", "-log-format", "-drain-delay", "-shutdown-timeout", "SERVICED_ADDR"} {
+		assert.Contains(t, b.String(), flag)
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	var b bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.LogLevel = slog.LevelWarn
+	logger := NewLogger(&b, cfg)
+	logger.Info("quiet")
+	logger.Warn("loud", "n", 3)
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	require.Len(t, lines, 1, "only WARN and above: %s", b.String())
+	var record map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "loud", record["msg"])
+	assert.Equal(t, 3.0, record["n"])
+
+	b.Reset()
+	cfg.LogFormat = "text"
+	NewLogger(&b, cfg).Error("failed", "err", "disk full")
+	assert.Contains(t, b.String(), `level=ERROR msg=failed err="disk full"`)
+}
@@ -0,0 +1,188 @@
+package solutions
+
+// SOLUTION: Fix the wiring of the service's main, which runs but cannot be deployed.
+// Fixed: a bad configuration exits with 2, as a usage error, and not with 0
+// Fixed: SIGTERM, which Docker and Kubernetes send, stops the service as SIGINT does
+// Fixed: Instrument wraps the routes, so every request is counted and logged
+// Fixed: /readyz fails while the service drains, so that load balancers stop sending it traffic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Metrics counts events by name, such as `http_requests_total{code="200"}`,
+// and serves them in the text format Prometheus scrapes. It is safe for
+// concurrent use.
+type Metrics struct {
+	mu       sync.Mutex
+	counters map[string]int64
+}
+
+// NewMetrics returns Metrics with every counter at 0.
+func NewMetrics() *Metrics {
+	return &Metrics{counters: make(map[string]int64)}
+}
+
+// Inc adds 1 to the counter name.
+func (m *Metrics) Inc(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counters[name]++
+}
+
+// ServeHTTP writes every counter, one to a line, sorted by name.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	names := make([]string, 0, len(m.counters))
+	for name := range m.counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s %d\n", name, m.counters[name])
+	}
+	m.mu.Unlock()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	io.WriteString(w, b.String())
+}
+
+// Health serves the liveness and readiness endpoints. The service is
+// live while the process runs, and ready only between listening and
+// draining.
+type Health struct {
+	ready atomic.Bool
+}
+
+// SetReady sets what /readyz answers.
+func (h *Health) SetReady(ready bool) { h.ready.Store(ready) }
+
+// Live serves /healthz.
+func (h *Health) Live(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, "ok\n")
+}
+
+// Ready serves /readyz: 503 unless the service is ready.
+func (h *Health) Ready(w http.ResponseWriter, r *http.Request) {
+	if !h.ready.Load() {
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+		return
+	}
+	io.WriteString(w, "ready\n")
+}
+
+// Routes returns the service's routes: its one endpoint, /hello, and the
+// health and metrics endpoints every service has.
+func Routes(health *Health, metrics *Metrics) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			name = "world"
+		}
+		fmt.Fprintf(w, "hello, %s\n", name)
+	})
+	mux.HandleFunc("/healthz", health.Live)
+	mux.HandleFunc("/readyz", health.Ready)
+	mux.Handle("/metrics", metrics)
+	return mux
+}
+
+// Instrument counts each request to next in metrics by its status code,
+// and logs it at DEBUG.
+func Instrument(logger *slog.Logger, metrics *Metrics, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		metrics.Inc(fmt.Sprintf(`http_requests_total{code="%d"}`, rec.code))
+		logger.Debug("request", "method", r.Method, "path", r.URL.Path, "code", rec.code, "duration", time.Since(start))
+	})
+}
+
+// statusRecorder remembers the status code a handler writes.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Serviced is the service's main: it takes the arguments after the
+// program name and returns the exit code. It logs to stdout, as a
+// container's logs are collected, and writes usage errors to stderr:
+//
+//	serviced [-addr :8080] [-log-level INFO] [-log-format json] [-drain-delay 5s] [-shutdown-timeout 10s]
+//
+// It serves until SIGINT or SIGTERM. Then it drains: /readyz fails for
+// the drain delay, while the load balancer notices, and requests still
+// arrive and are served. Then it shuts the server down, giving requests
+// in flight the shutdown timeout to finish, and returns 0.
+func Serviced(args []string, stdout, stderr io.Writer) int {
+	cfg, err := LoadConfig(args, os.Getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "serviced: %v\n\n", err)
+		Usage(stderr)
+		return 2 // Fixed: not 0, which tells the supervisor all is well
+	}
+	logger := NewLogger(stdout, cfg)
+
+	// Fixed: SIGTERM too, or the service is killed without shutting down
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ln, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		logger.Error("listen", "err", err)
+		return 1
+	}
+	health, metrics := &Health{}, NewMetrics()
+	srv := &http.Server{
+		Handler:           Instrument(logger, metrics, Routes(health, metrics)), // Fixed: not the routes alone
+		ReadHeaderTimeout: 5 * time.Second,
+		// The server logs what it cannot tell a handler, such as a
+		// panic, through the same logger.
+		ErrorLog: slog.NewLogLogger(logger.Handler(), slog.LevelError),
+	}
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.Serve(ln) }()
+	health.SetReady(true)
+	logger.Info("listening", "addr", ln.Addr().String())
+
+	select {
+	case err := <-serveErr:
+		logger.Error("serve", "err", err)
+		return 1
+	case <-ctx.Done():
+	}
+	stop() // a second signal kills the process at once
+
+	logger.Info("draining", "delay", cfg.DrainDelay)
+	health.SetReady(false) // Fixed: or traffic keeps coming until the server is gone
+	time.Sleep(cfg.DrainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("shutdown", "err", err)
+		return 1
+	}
+	logger.Info("stopped")
+	return 0
+}
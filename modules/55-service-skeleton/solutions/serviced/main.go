@@ -0,0 +1,17 @@
+// Command serviced is the reference skeleton of a production service:
+// configuration from flags and the environment, structured logs, metrics,
+// health checks, and a graceful shutdown on SIGINT or SIGTERM, wired
+// together in one main.
+//
+//	go run ./modules/55-service-skeleton/solutions/serviced -addr :8080 -log-format text
+package main
+
+import (
+	"os"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/55-service-skeleton/solutions"
+)
+
+func main() {
+	os.Exit(solutions.Serviced(os.Args[1:], os.Stdout, os.Stderr))
+}
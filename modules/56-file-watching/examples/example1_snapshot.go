@@ -0,0 +1,109 @@
+// Package examples demonstrates watching files by polling: listing a
+// tree, comparing the listing with the last one, and waiting for the
+// changes to settle before acting on them. Polling needs nothing from the
+// operating system but stat, so it works the same everywhere.
+//
+// This file shows:
+// - What a poller records of each file: its size and modification time, from one stat
+// - Walking a tree with filepath.WalkDir, so that new subdirectories are seen too
+// - Comparing two listings to find the files created, modified, and removed
+// - Why the size matters: a clock that keeps seconds gives two saves one time
+package examples
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Entry is what a Snapshot records of one file.
+type Entry struct {
+	Size    int64
+	ModTime time.Time
+}
+
+// Snapshot maps the path of each file under a directory, relative to it,
+// to its Entry.
+type Snapshot map[string]Entry
+
+// Take lists the files under root.
+func Take(root string) (Snapshot, error) {
+	s := make(Snapshot)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		s[filepath.ToSlash(rel)] = Entry{Size: info.Size(), ModTime: info.ModTime()}
+		return nil
+	})
+	return s, err
+}
+
+// Diff returns a line for each file that differs between old and s,
+// sorted by path: "+ path" for a file created, "~ path" for one modified,
+// and "- path" for one removed.
+func (s Snapshot) Diff(old Snapshot) []string {
+	var lines []string
+	for path, e := range s {
+		o, ok := old[path]
+		switch {
+		case !ok:
+			lines = append(lines, "+ "+path)
+		case o.Size != e.Size || !o.ModTime.Equal(e.ModTime):
+			lines = append(lines, "~ "+path)
+		}
+	}
+	for path := range old {
+		if _, ok := s[path]; !ok {
+			lines = append(lines, "- "+path)
+		}
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i][2:] < lines[j][2:] })
+	return lines
+}
+
+// DemonstrateSnapshot changes a tree between two snapshots, and diffs
+// them.
+func DemonstrateSnapshot() {
+	fmt.Println("\n=== Snapshots ===")
+	dir, err := os.MkdirTemp("", "snapshot")
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	// Times on whole seconds, as a file system that keeps seconds has.
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	write := func(name, data string) {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0o755)
+		os.WriteFile(path, []byte(data), 0o644)
+		os.Chtimes(path, at, at)
+	}
+	write("main.go", "package main")
+	write("go.mod", "module demo")
+	write("README.md", "# Demo")
+
+	before, err := Take(dir)
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	write("main.go", "package main // saved again in the same second")
+	write("internal/store/store.go", "package store")
+	os.Remove(filepath.Join(dir, "README.md"))
+	after, _ := Take(dir)
+	for _, line := range after.Diff(before) {
+		fmt.Println(line)
+	}
+	fmt.Printf("main.go: same time %v, sizes %d and %d\n",
+		before["main.go"].ModTime.Equal(after["main.go"].ModTime), before["main.go"].Size, after["main.go"].Size)
+}
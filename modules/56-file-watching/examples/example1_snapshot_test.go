@@ -0,0 +1,47 @@
+package examples
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateSnapshot(t *testing.T) {
+	DemonstrateSnapshot()
+}
+
+func TestTake(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a", "b", "c.go"), []byte("package b"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "empty"), 0o755))
+
+	s, err := Take(dir)
+	require.NoError(t, err)
+	require.Len(t, s, 1, "files only")
+	assert.Equal(t, int64(9), s["a/b/c.go"].Size)
+}
+
+func TestDiff(t *testing.T) {
+	t0 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	old := Snapshot{
+		"same.go":  {Size: 1, ModTime: t0},
+		"time.go":  {Size: 1, ModTime: t0},
+		"size.go":  {Size: 1, ModTime: t0},
+		"gone.go":  {Size: 1, ModTime: t0},
+		"after.go": {Size: 1, ModTime: t0},
+	}
+	now := Snapshot{
+		"same.go":  {Size: 1, ModTime: t0},
+		"time.go":  {Size: 1, ModTime: t0.Add(time.Millisecond)},
+		"size.go":  {Size: 2, ModTime: t0},
+		"after.go": {Size: 1, ModTime: t0},
+		"new.go":   {Size: 1, ModTime: t0},
+	}
+	assert.Equal(t, []string{"- gone.go", "+ new.go", "~ size.go", "~ time.go"}, now.Diff(old))
+	assert.Empty(t, now.Diff(now))
+}
@@ -0,0 +1,113 @@
+package examples
+
+// This file shows:
+// - Pacing a poll loop with a time.Ticker in a select, which also waits for the context
+// - The busy loop that a select with a default case makes, and what it costs
+// - Debouncing: collecting events until none has come for a while, with one time.Timer
+// - Stopping and draining a Timer before Reset, as Go before 1.23 requires
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PollEvery calls poll every interval until ctx is done, and returns how
+// many times it did.
+func PollEvery(ctx context.Context, interval time.Duration, poll func()) int {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	n := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return n
+		case <-t.C:
+			poll()
+			n++
+		}
+	}
+}
+
+// PollBusy calls poll until ctx is done, as often as it can: the default
+// case makes the select return at once when ctx is not done. Never write
+// a loop like this; it is here to count what it costs.
+func PollBusy(ctx context.Context, poll func()) int {
+	n := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return n
+		default:
+		}
+		poll()
+		n++
+	}
+}
+
+// Debounce passes on the events from in in batches: a batch is sent once
+// quiet has passed without a new event. When in is closed, the last
+// batch is sent and the returned channel closed.
+func Debounce(in <-chan string, quiet time.Duration) <-chan []string {
+	out := make(chan []string)
+	go func() {
+		defer close(out)
+		timer := time.NewTimer(quiet)
+		timer.Stop()
+		var batch []string
+		for {
+			select {
+			case e, ok := <-in:
+				if !ok {
+					if len(batch) > 0 {
+						out <- batch
+					}
+					return
+				}
+				batch = append(batch, e)
+				if !timer.Stop() {
+					select { // the timer fired, but its value was not received
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(quiet)
+			case <-timer.C:
+				out <- batch
+				batch = nil
+			}
+		}
+	}()
+	return out
+}
+
+// DemonstrateDebounce counts the polls of a ticker and of a busy loop,
+// and debounces the steps of an editor's save.
+func DemonstrateDebounce() {
+	fmt.Println("\n=== Pacing and debouncing ===")
+	stat := func() { time.Now() } // a cheap stand-in for listing a tree
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	ticked := PollEvery(ctx, 10*time.Millisecond, stat)
+	cancel()
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	busy := PollBusy(ctx, stat)
+	cancel()
+	fmt.Printf("in 50ms: at most 5 polls with a ticker (%v), and over 10,000 busy (%v)\n", ticked <= 5, busy > 10_000)
+
+	in := make(chan string)
+	batches := Debounce(in, 30*time.Millisecond)
+	go func() {
+		// How an editor saves main.go: a backup, a new file, a rename.
+		for _, e := range []string{"create main.go~", "write main.go.tmp", "rename main.go.tmp main.go"} {
+			in <- e
+			time.Sleep(5 * time.Millisecond)
+		}
+		time.Sleep(60 * time.Millisecond)
+		in <- "write go.mod"
+		close(in)
+	}()
+	for b := range batches {
+		fmt.Printf("batch of %d: %v\n", len(b), b)
+	}
+}
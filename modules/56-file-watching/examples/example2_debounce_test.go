@@ -0,0 +1,48 @@
+package examples
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateDebounce(t *testing.T) {
+	DemonstrateDebounce()
+}
+
+func TestPollEvery(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 55*time.Millisecond)
+	defer cancel()
+	n := PollEvery(ctx, 10*time.Millisecond, func() {})
+	assert.LessOrEqual(t, n, 5)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	assert.Zero(t, PollEvery(ctx, time.Hour, func() {}))
+	assert.Less(t, time.Since(start), time.Second, "the context ends the wait for a tick")
+}
+
+func TestDebounce(t *testing.T) {
+	in := make(chan string)
+	out := Debounce(in, 20*time.Millisecond)
+	in <- "a"
+	in <- "b"
+	select {
+	case b := <-out:
+		assert.Equal(t, []string{"a", "b"}, b)
+	case <-time.After(time.Second):
+		t.Fatal("no batch after the quiet time")
+	}
+
+	in <- "c"
+	close(in)
+	b, ok := <-out
+	require.True(t, ok)
+	assert.Equal(t, []string{"c"}, b, "the last batch, when in is closed")
+	_, ok = <-out
+	assert.False(t, ok)
+}
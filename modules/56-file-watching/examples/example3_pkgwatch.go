@@ -0,0 +1,80 @@
+package examples
+
+// This file shows:
+// - pkg/watch, the course's polling watcher, which "learngo check -watch" and module 42's config reloader use
+// - A rebuild loop: act once, then again on each settled change, until the context is done
+// - Taking the first listing before acting, so that changes made meanwhile are not missed
+// - Skipping hidden files, such as editors' swap files and .git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/watch"
+)
+
+// Rebuild calls build with no events, and then with the events of each
+// settled change under dir, until ctx is done.
+func Rebuild(ctx context.Context, dir string, debounce time.Duration, build func([]watch.Event)) error {
+	w := &watch.Watcher{
+		Paths:    []string{dir},
+		Interval: debounce / 4,
+		Debounce: debounce,
+		Skip:     watch.SkipHidden,
+	}
+	if _, err := w.Poll(); err != nil { // before the first build
+		return err
+	}
+	build(nil)
+	for {
+		events, err := w.Wait(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		build(events)
+	}
+}
+
+// DemonstrateWatch rebuilds as files in a directory are edited.
+func DemonstrateWatch() {
+	fmt.Println("\n=== pkg/watch ===")
+	dir, err := os.MkdirTemp("", "watch")
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main"), 0o644)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	builds := make(chan []watch.Event)
+	go Rebuild(ctx, dir, 40*time.Millisecond, func(events []watch.Event) { builds <- events })
+
+	report := func(events []watch.Event) {
+		fmt.Printf("build %d events:", len(events))
+		for _, e := range events {
+			rel, _ := filepath.Rel(dir, e.Path)
+			fmt.Printf(" %s %s", e.Op, rel)
+		}
+		fmt.Println()
+	}
+	report(<-builds)
+
+	// A save in steps, and a swap file, which is skipped.
+	os.WriteFile(filepath.Join(dir, ".main.go.swp"), []byte("x"), 0o644)
+	os.WriteFile(filepath.Join(dir, "main.go.tmp"), []byte("package main // v2"), 0o644)
+	time.Sleep(15 * time.Millisecond)
+	os.Rename(filepath.Join(dir, "main.go.tmp"), filepath.Join(dir, "main.go"))
+	report(<-builds)
+
+	os.MkdirAll(filepath.Join(dir, "internal", "store"), 0o755)
+	os.WriteFile(filepath.Join(dir, "internal", "store", "store.go"), []byte("package store"), 0o644)
+	report(<-builds)
+}
@@ -0,0 +1,57 @@
+package examples
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/watch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateWatch(t *testing.T) {
+	DemonstrateWatch()
+}
+
+func TestRebuild(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	builds := make(chan []watch.Event)
+	done := make(chan error, 1)
+	go func() {
+		done <- Rebuild(ctx, dir, 20*time.Millisecond, func(events []watch.Event) { builds <- events })
+	}()
+
+	assert.Empty(t, <-builds, "the first build, before any change")
+	path := filepath.Join(dir, "a.go")
+	require.NoError(t, os.WriteFile(path, []byte("package a"), 0o644))
+	select {
+	case events := <-builds:
+		assert.Equal(t, []watch.Event{{Path: path, Op: watch.Create}}, events)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no build after a change")
+	}
+
+	cancel()
+	assert.NoError(t, <-done, "stopping is not an error")
+}
+
+func TestRebuildMissingDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "later")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	builds := make(chan []watch.Event, 2)
+	go Rebuild(ctx, dir, 20*time.Millisecond, func(events []watch.Event) { builds <- events })
+	<-builds
+
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	select {
+	case events := <-builds:
+		assert.Equal(t, []watch.Event{{Path: dir, Op: watch.Create}}, events, "a directory created after the start")
+	case <-time.After(2 * time.Second):
+		t.Fatal("no build after the directory was created")
+	}
+}
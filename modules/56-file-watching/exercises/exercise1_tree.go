@@ -0,0 +1,109 @@
+package exercises
+
+// EXERCISE: Fix a poller that misses changes to the files in a tree.
+// Tree lists the files under a directory on each Poll and compares the
+// listing with the last one, the way a portable watcher does without
+// inotify or kqueue. But it compares modification times to the second,
+// so a file saved twice in one second looks unchanged, and it ignores the
+// size, which is all that changes on a file system with a coarse clock.
+// It lists the root directory and nothing below it, and it never notices
+// that a file is gone.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Op is what happened to a file between two polls.
+type Op int
+
+const (
+	Created Op = iota + 1
+	Modified
+	Removed
+)
+
+func (op Op) String() string {
+	switch op {
+	case Created:
+		return "created"
+	case Modified:
+		return "modified"
+	case Removed:
+		return "removed"
+	}
+	return "unknown"
+}
+
+// Change is one file that changed between two polls.
+type Change struct {
+	Path string
+	Op   Op
+}
+
+// stamp is what a listing records of a file, to tell whether it changed.
+type stamp struct {
+	// BUG: seconds since 1970, which two saves in one second share.
+	mod int64
+	// BUG: no size, which changes even when a coarse clock's time does not.
+}
+
+// Tree polls the files under a directory for changes. Directories are
+// walked but not reported themselves.
+type Tree struct {
+	Root string
+
+	files map[string]stamp // the last listing; nil before the first
+}
+
+// Poll lists the tree and returns the files that changed since the last
+// call, sorted by path. The first call takes the listing and returns
+// nothing.
+func (t *Tree) Poll() ([]Change, error) {
+	files, err := t.list()
+	if err != nil {
+		return nil, err
+	}
+	if t.files == nil {
+		t.files = files
+		return nil, nil
+	}
+	var changes []Change
+	for path, s := range files {
+		old, ok := t.files[path]
+		switch {
+		case !ok:
+			changes = append(changes, Change{path, Created})
+		case old.mod != s.mod:
+			changes = append(changes, Change{path, Modified})
+		}
+	}
+	// BUG: files in the old listing that are not in the new one are gone,
+	// and never reported.
+	t.files = files
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// list returns a stamp for every file under the root.
+func (t *Tree) list() (map[string]stamp, error) {
+	files := make(map[string]stamp)
+	// BUG: ReadDir lists the root only, not the directories in it.
+	entries, err := os.ReadDir(t.Root)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range entries {
+		if d.IsDir() {
+			continue
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil, err
+		}
+		files[filepath.Join(t.Root, d.Name())] = stamp{mod: info.ModTime().Unix()}
+	}
+	return files, nil
+}
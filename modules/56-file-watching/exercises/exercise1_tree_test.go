@@ -0,0 +1,102 @@
+package exercises
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// t0 is a modification time on a whole second, as a file system that
+// keeps seconds only would record it.
+var t0 = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// writeAt writes data to path, creating its directory, and sets its
+// modification time to at.
+func writeAt(t *testing.T, path, data string, at time.Time) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+	require.NoError(t, os.Chtimes(path, at, at))
+}
+
+// poll polls tree and fails the test on an error.
+func poll(t *testing.T, tree *Tree) []Change {
+	t.Helper()
+	changes, err := tree.Poll()
+	require.NoError(t, err)
+	return changes
+}
+
+func TestTreeFirstPoll(t *testing.T) {
+	dir := t.TempDir()
+	writeAt(t, filepath.Join(dir, "main.go"), "package main", t0)
+	tree := &Tree{Root: dir}
+	assert.Empty(t, poll(t, tree), "the first poll takes the listing")
+	assert.Empty(t, poll(t, tree), "nothing changed")
+}
+
+func TestTreeWritesInOneSecond(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	writeAt(t, path, "package main // v1", t0)
+	tree := &Tree{Root: dir}
+	poll(t, tree)
+
+	writeAt(t, path, "package main // v2", t0.Add(40*time.Millisecond))
+	assert.Equal(t, []Change{{path, Modified}}, poll(t, tree),
+		"a second save, 40ms after the first and the same size")
+}
+
+func TestTreeCoarseClock(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	writeAt(t, path, `{"workers": 4}`, t0)
+	tree := &Tree{Root: dir}
+	poll(t, tree)
+
+	writeAt(t, path, `{"workers": 16}`, t0)
+	assert.Equal(t, []Change{{path, Modified}}, poll(t, tree),
+		"a write in the same tick of a clock that keeps seconds, as FAT's and some network file systems' do")
+}
+
+func TestTreeSubdirectories(t *testing.T) {
+	dir := t.TempDir()
+	old := filepath.Join(dir, "pkg", "store", "store.go")
+	writeAt(t, old, "package store", t0)
+	tree := &Tree{Root: dir}
+	poll(t, tree)
+
+	writeAt(t, old, "package store // edited", t0.Add(time.Second))
+	created := filepath.Join(dir, "internal", "queue", "queue.go")
+	writeAt(t, created, "package queue", t0)
+	assert.Equal(t, []Change{{created, Created}, {old, Modified}}, poll(t, tree))
+
+	writeAt(t, created, "package queue // edited", t0.Add(time.Second))
+	assert.Equal(t, []Change{{created, Modified}}, poll(t, tree), "a directory created after the first poll")
+}
+
+func TestTreeRemovals(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.go")
+	b := filepath.Join(dir, "sub", "b.go")
+	writeAt(t, a, "package a", t0)
+	writeAt(t, b, "package b", t0)
+	tree := &Tree{Root: dir}
+	poll(t, tree)
+
+	require.NoError(t, os.Remove(a))
+	require.NoError(t, os.RemoveAll(filepath.Dir(b)))
+	assert.Equal(t, []Change{{a, Removed}, {b, Removed}}, poll(t, tree))
+
+	writeAt(t, a, "package a", t0)
+	assert.Equal(t, []Change{{a, Created}}, poll(t, tree), "the same file back again")
+}
+
+func TestOpString(t *testing.T) {
+	assert.Equal(t, "modified", Modified.String())
+	assert.Equal(t, "unknown", Op(0).String())
+}
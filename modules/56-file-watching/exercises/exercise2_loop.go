@@ -0,0 +1,60 @@
+package exercises
+
+// EXERCISE: Fix a watch loop that keeps a CPU busy and loses changes.
+// Loop polls for changes and hands them to Handle in batches, once the
+// files have been quiet for Debounce. But it sleeps between polls with
+// time.Sleep, so a zero Interval polls as fast as the CPU allows and a
+// long one keeps it from stopping, and after a failed poll it tries again
+// at once, without sleeping at all. It also keeps only the last poll's
+// changes in a batch, so a save in several steps loses all but one.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultInterval is the time between polls of a Loop without an
+// Interval.
+const DefaultInterval = 100 * time.Millisecond
+
+// Loop polls for changes every Interval, and hands them to Handle in
+// batches: once changes have been seen and then none for Debounce.
+type Loop struct {
+	Poll     func() ([]Change, error) // a Tree's Poll, say
+	Handle   func([]Change)
+	OnError  func(error) // called with each failed poll; nil ignores them
+	Interval time.Duration
+	Debounce time.Duration
+}
+
+// Run polls until ctx is done, and returns ctx's error.
+func (l *Loop) Run(ctx context.Context) error {
+	var batch []Change
+	var last time.Time // of the last change in batch
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		changes, err := l.Poll()
+		if err != nil {
+			if l.OnError != nil {
+				l.OnError(err)
+			}
+			// BUG: straight back to Poll, which fails again at once.
+			continue
+		}
+		if len(changes) > 0 {
+			// BUG: replaces the changes seen earlier in the batch.
+			batch = changes
+			last = time.Now()
+		}
+		if len(batch) > 0 && time.Since(last) >= l.Debounce {
+			l.Handle(batch)
+			batch = nil
+		}
+		// BUG: a zero Interval does not wait at all, and ctx cannot
+		// interrupt a long one.
+		time.Sleep(l.Interval)
+	}
+}
@@ -0,0 +1,107 @@
+package exercises
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runFor runs l for d, and fails the test if Run does not return soon
+// after its context is done.
+func runFor(t *testing.T, l *Loop, d time.Duration) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- l.Run(ctx) }()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(d + time.Second):
+		t.Fatal("Run did not return after its context was done")
+	}
+}
+
+// counter is a Poll that counts its calls and finds no changes.
+type counter struct{ polls atomic.Int64 }
+
+func (c *counter) Poll() ([]Change, error) {
+	c.polls.Add(1)
+	return nil, nil
+}
+
+func TestLoopPollsEveryInterval(t *testing.T) {
+	var c counter
+	runFor(t, &Loop{Poll: c.Poll, Handle: func([]Change) {}, Interval: 10 * time.Millisecond}, 105*time.Millisecond)
+	assert.Positive(t, c.polls.Load())
+	assert.LessOrEqual(t, c.polls.Load(), int64(15), "polls in 105ms, 10ms apart: more is a busy loop")
+}
+
+func TestLoopDefaultInterval(t *testing.T) {
+	var c counter
+	runFor(t, &Loop{Poll: c.Poll, Handle: func([]Change) {}}, DefaultInterval/2)
+	assert.Zero(t, c.polls.Load(), "a zero Interval is DefaultInterval, not no wait at all")
+}
+
+func TestLoopFailingPolls(t *testing.T) {
+	var failures atomic.Int64
+	l := &Loop{
+		Poll:     func() ([]Change, error) { return nil, errors.New("open exercises: permission denied") },
+		Handle:   func([]Change) { t.Error("Handle called without changes") },
+		OnError:  func(error) { failures.Add(1) },
+		Interval: 10 * time.Millisecond,
+	}
+	runFor(t, l, 105*time.Millisecond)
+	assert.Positive(t, failures.Load())
+	assert.LessOrEqual(t, failures.Load(), int64(15), "a poll that fails is tried again at the next tick, not at once")
+}
+
+func TestLoopStopsPromptly(t *testing.T) {
+	var c counter
+	l := &Loop{Poll: c.Poll, Handle: func([]Change) {}, Interval: time.Hour}
+	start := time.Now()
+	runFor(t, l, 20*time.Millisecond)
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "an hour between polls, but stopped at once")
+}
+
+func TestLoopDebounces(t *testing.T) {
+	script := [][]Change{
+		{{"a.go", Modified}},
+		{{"b.go", Created}},
+		nil,
+		{{"a.go~", Removed}},
+	}
+	var mu sync.Mutex
+	var polls int
+	var batches [][]Change
+	l := &Loop{
+		Poll: func() ([]Change, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			polls++
+			if polls <= len(script) {
+				return script[polls-1], nil
+			}
+			return nil, nil
+		},
+		Handle: func(b []Change) {
+			mu.Lock()
+			defer mu.Unlock()
+			batches = append(batches, b)
+		},
+		Interval: 5 * time.Millisecond,
+		Debounce: 40 * time.Millisecond,
+	}
+	runFor(t, l, 200*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, batches, 1, "one save, in steps 5ms apart, is one batch")
+	assert.Equal(t, []Change{{"a.go", Modified}, {"b.go", Created}, {"a.go~", Removed}}, batches[0])
+}
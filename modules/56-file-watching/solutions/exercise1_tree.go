@@ -0,0 +1,115 @@
+package solutions
+
+// SOLUTION: Fix a poller that misses changes to the files in a tree.
+// Fixed: a stamp keeps the whole modification time, so two writes in one second differ
+// Fixed: a stamp keeps the size too, for file systems whose clocks are coarser than the writes
+// Fixed: the listing walks the whole tree, subdirectories created after the start included
+// Fixed: Poll reports the files that are gone
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Op is what happened to a file between two polls.
+type Op int
+
+const (
+	Created Op = iota + 1
+	Modified
+	Removed
+)
+
+func (op Op) String() string {
+	switch op {
+	case Created:
+		return "created"
+	case Modified:
+		return "modified"
+	case Removed:
+		return "removed"
+	}
+	return "unknown"
+}
+
+// Change is one file that changed between two polls.
+type Change struct {
+	Path string
+	Op   Op
+}
+
+// stamp is what a listing records of a file, to tell whether it changed.
+type stamp struct {
+	// Fixed: the modification time to the nanosecond, or as fine as the
+	// file system keeps it.
+	mod time.Time
+	// Fixed: and the size, which changes when the time does not.
+	size int64
+}
+
+// Tree polls the files under a directory for changes. Directories are
+// walked but not reported themselves.
+type Tree struct {
+	Root string
+
+	files map[string]stamp // the last listing; nil before the first
+}
+
+// Poll lists the tree and returns the files that changed since the last
+// call, sorted by path. The first call takes the listing and returns
+// nothing.
+func (t *Tree) Poll() ([]Change, error) {
+	files, err := t.list()
+	if err != nil {
+		return nil, err
+	}
+	if t.files == nil {
+		t.files = files
+		return nil, nil
+	}
+	var changes []Change
+	for path, s := range files {
+		old, ok := t.files[path]
+		switch {
+		case !ok:
+			changes = append(changes, Change{path, Created})
+		case !old.mod.Equal(s.mod) || old.size != s.size:
+			changes = append(changes, Change{path, Modified})
+		}
+	}
+	// Fixed: a file in the old listing and not in the new one is gone.
+	for path := range t.files {
+		if _, ok := files[path]; !ok {
+			changes = append(changes, Change{path, Removed})
+		}
+	}
+	t.files = files
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// list returns a stamp for every file under the root.
+func (t *Tree) list() (map[string]stamp, error) {
+	files := make(map[string]stamp)
+	// Fixed: WalkDir goes into every directory, whenever it was created.
+	err := filepath.WalkDir(t.Root, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil // removed while the walk was under way
+		}
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		files[path] = stamp{mod: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,65 @@
+package solutions
+
+// SOLUTION: Fix a watch loop that keeps a CPU busy and loses changes.
+// Fixed: the loop waits on a ticker, which a zero Interval does not turn into a spin
+// Fixed: a failed poll waits for the next tick, like any other
+// Fixed: the loop stops as soon as its context is done, not after the current sleep
+// Fixed: the changes of a batch accumulate, so that a debounced batch has them all
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultInterval is the time between polls of a Loop without an
+// Interval.
+const DefaultInterval = 100 * time.Millisecond
+
+// Loop polls for changes every Interval, and hands them to Handle in
+// batches: once changes have been seen and then none for Debounce.
+type Loop struct {
+	Poll     func() ([]Change, error) // a Tree's Poll, say
+	Handle   func([]Change)
+	OnError  func(error) // called with each failed poll; nil ignores them
+	Interval time.Duration
+	Debounce time.Duration
+}
+
+// Run polls until ctx is done, and returns ctx's error.
+func (l *Loop) Run(ctx context.Context) error {
+	interval := l.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	// Fixed: a ticker paces the polls, and is one case of the select that
+	// also waits for ctx.
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	var batch []Change
+	var last time.Time // of the last change in batch
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+		changes, err := l.Poll()
+		if err != nil {
+			if l.OnError != nil {
+				l.OnError(err)
+			}
+			// Fixed: the next attempt comes with the next tick.
+			continue
+		}
+		if len(changes) > 0 {
+			// Fixed: appended, not replaced.
+			batch = append(batch, changes...)
+			last = time.Now()
+		}
+		if len(batch) > 0 && time.Since(last) >= l.Debounce {
+			l.Handle(batch)
+			batch = nil
+		}
+	}
+}
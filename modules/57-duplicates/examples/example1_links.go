@@ -0,0 +1,80 @@
+// Package examples demonstrates the pieces of a duplicate-file finder:
+// walking a tree without following symbolic links, hashing files in a
+// pool of workers, and stopping them all at the first error.
+//
+// This file shows:
+// - os.Stat, which follows a symbolic link, and os.Lstat, which describes the link itself
+// - fs.DirEntry.Type from filepath.WalkDir, which is Lstat's answer, and costs no extra call
+// - That WalkDir never descends into a linked directory, whatever os.Stat says about it
+// - A dangling link, which os.Stat fails on and Lstat does not
+package examples
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Kinds walks root, and describes each entry below it as WalkDir's
+// DirEntry and as os.Stat see it.
+func Kinds(root string) ([]string, error) {
+	var lines []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == root {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		stat := "error"
+		if info, err := os.Stat(path); err == nil {
+			stat = kind(info.Mode())
+		}
+		lines = append(lines, fmt.Sprintf("%-18s entry %-7s stat %s", filepath.ToSlash(rel), kind(d.Type()), stat))
+		return nil
+	})
+	return lines, err
+}
+
+// kind names the type bits of a mode.
+func kind(m fs.FileMode) string {
+	switch {
+	case m&fs.ModeSymlink != 0:
+		return "link"
+	case m.IsDir():
+		return "dir"
+	case m.IsRegular():
+		return "file"
+	}
+	return "other"
+}
+
+// DemonstrateLinks walks a tree with links to a file, to a directory, and
+// to nothing.
+func DemonstrateLinks() {
+	fmt.Println("\n=== Symbolic links ===")
+	dir, err := os.MkdirTemp("", "links")
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	os.MkdirAll(filepath.Join(dir, "photos"), 0o755)
+	os.WriteFile(filepath.Join(dir, "photos", "beach.jpg"), []byte("JPEG"), 0o644)
+	for link, target := range map[string]string{
+		"latest.jpg": filepath.Join("photos", "beach.jpg"),
+		"album":      "photos",
+		"old.jpg":    "gone.jpg",
+	} {
+		if err := os.Symlink(target, filepath.Join(dir, link)); err != nil {
+			fmt.Println("no symbolic links here:", err)
+			return
+		}
+	}
+	lines, err := Kinds(dir)
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Println(strings.Join(lines, "\n"))
+}
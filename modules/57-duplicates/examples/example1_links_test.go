@@ -0,0 +1,40 @@
+package examples
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateLinks(t *testing.T) {
+	DemonstrateLinks()
+}
+
+func TestKinds(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "a.txt"), []byte("a"), 0o644))
+	if err := os.Symlink(filepath.Join("sub", "a.txt"), filepath.Join(dir, "link")); err != nil {
+		t.Skip("no symbolic links:", err)
+	}
+	lines, err := Kinds(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"link               entry link    stat file",
+		"sub                entry dir     stat dir",
+		"sub/a.txt          entry file    stat file",
+	}, lines)
+}
+
+func TestKindsDanglingLink(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Symlink("nowhere", filepath.Join(dir, "dangling")); err != nil {
+		t.Skip("no symbolic links:", err)
+	}
+	lines, err := Kinds(dir)
+	require.NoError(t, err, "WalkDir itself does not stat the target")
+	assert.Equal(t, []string{"dangling           entry link    stat error"}, lines)
+}
@@ -0,0 +1,88 @@
+package examples
+
+// This file shows:
+// - A fixed pool of workers reading jobs from one channel, which bounds the files open at a time
+// - Results sent back on a channel to one goroutine, the only one that writes the map
+// - Closing the results channel once every worker is done, with a sync.WaitGroup
+// - Hashing a file as a stream with io.Copy, whatever its size
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// HashAll returns hash(p) for every path p, calling hash from workers
+// goroutines at a time.
+func HashAll(paths []string, workers int, hash func(path string) string) map[string]string {
+	type result struct{ path, sum string }
+	jobs := make(chan string)
+	results := make(chan result)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				results <- result{p, hash(p)}
+			}
+		}()
+	}
+	go func() {
+		for _, p := range paths {
+			jobs <- p
+		}
+		close(jobs)
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	sums := make(map[string]string, len(paths))
+	for r := range results {
+		sums[r.path] = r.sum
+	}
+	return sums
+}
+
+// SHA256File returns the hex SHA-256 of the file at path, or the error
+// that kept it from being read.
+func SHA256File(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return err.Error()
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err.Error()
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// DemonstratePool hashes a few files with two workers.
+func DemonstratePool() {
+	fmt.Println("\n=== A pool of hashers ===")
+	dir, err := os.MkdirTemp("", "pool")
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	var paths []string
+	for name, data := range map[string]string{"a.txt": "same", "b.txt": "same", "c.txt": "other"} {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte(data), 0o644)
+		paths = append(paths, path)
+	}
+	sums := HashAll(paths, 2, SHA256File)
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		fmt.Printf("%s sha256:%s\n", name, sums[filepath.Join(dir, name)][:12])
+	}
+}
@@ -0,0 +1,45 @@
+package examples
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstratePool(t *testing.T) {
+	DemonstratePool()
+}
+
+func TestHashAllBoundsWorkers(t *testing.T) {
+	var running, most atomic.Int64
+	hash := func(p string) string {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for m := most.Load(); n > m && !most.CompareAndSwap(m, n); m = most.Load() {
+		}
+		time.Sleep(time.Millisecond)
+		return "h" + p
+	}
+	paths := make([]string, 40)
+	for i := range paths {
+		paths[i] = fmt.Sprint(i)
+	}
+	sums := HashAll(paths, 3, hash)
+	require.Len(t, sums, 40)
+	assert.Equal(t, "h17", sums["17"])
+	assert.LessOrEqual(t, most.Load(), int64(3))
+	assert.Equal(t, int64(3), most.Load(), "with 40 slow jobs, every worker is busy at some point")
+}
+
+func TestSHA256File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(path, nil, 0o644))
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", SHA256File(path))
+	assert.NotRegexp(t, "^[0-9a-f]{64}$", SHA256File(path+".missing"), "the error, not a hash")
+}
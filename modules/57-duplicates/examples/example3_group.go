@@ -0,0 +1,84 @@
+package examples
+
+// This file shows:
+// - The errgroup pattern: run goroutines, wait for all, and return the first error
+// - A context that the group cancels at the first error, so that the others stop early
+// - sync.Once, to keep the first error and cancel once
+// - golang.org/x/sync/errgroup, whose API this is, written out in a few lines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Group runs goroutines and returns the first error any of them returns.
+// Its API is errgroup.Group's.
+type Group struct {
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+	cancel context.CancelFunc
+}
+
+// WithContext returns a Group and a context derived from ctx, cancelled
+// when a goroutine of the group fails or Wait returns.
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// Go runs f in a goroutine of its own.
+func (g *Group) Go(f func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := f(); err != nil {
+			g.once.Do(func() {
+				g.err = err
+				if g.cancel != nil {
+					g.cancel()
+				}
+			})
+		}
+	}()
+}
+
+// Wait waits for every goroutine started with Go, and returns the first
+// error.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	return g.err
+}
+
+// DemonstrateGroup runs three jobs, one of which fails, and shows the
+// others stop.
+func DemonstrateGroup() {
+	fmt.Println("\n=== A group that stops at the first error ===")
+	g, ctx := WithContext(context.Background())
+	results := make([]string, 3)
+	for i, d := range []time.Duration{time.Hour, 10 * time.Millisecond, time.Hour} {
+		i, d := i, d
+		g.Go(func() error {
+			if i == 1 {
+				time.Sleep(d)
+				return errors.New("hash photos/beach.jpg: permission denied")
+			}
+			select {
+			case <-time.After(d):
+				results[i] = "done"
+			case <-ctx.Done():
+				results[i] = "stopped: " + ctx.Err().Error()
+			}
+			return nil
+		})
+	}
+	fmt.Println("Wait:", g.Wait())
+	fmt.Println("job 0:", results[0])
+	fmt.Println("job 2:", results[2])
+}
@@ -0,0 +1,41 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateGroup(t *testing.T) {
+	DemonstrateGroup()
+}
+
+func TestGroupFirstError(t *testing.T) {
+	g, ctx := WithContext(context.Background())
+	first := errors.New("first")
+	g.Go(func() error { return first })
+	g.Go(func() error {
+		<-ctx.Done()
+		return errors.New("second, after the cancel")
+	})
+	assert.Equal(t, first, g.Wait())
+	assert.Error(t, ctx.Err(), "cancelled")
+}
+
+func TestGroupWaitsForAll(t *testing.T) {
+	var g Group // the zero Group works, without a context
+	var n atomic.Int64
+	for i := 0; i < 10; i++ {
+		g.Go(func() error {
+			time.Sleep(time.Millisecond)
+			n.Add(1)
+			return nil
+		})
+	}
+	assert.NoError(t, g.Wait())
+	assert.Equal(t, int64(10), n.Load())
+}
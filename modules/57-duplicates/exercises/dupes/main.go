@@ -0,0 +1,42 @@
+// Command dupes finds the files in a directory tree that are copies of
+// each other, and prints them with the space they waste.
+//
+//	go run ./modules/57-duplicates/exercises/dupes -workers 8 ~/Pictures
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/57-duplicates/exercises"
+)
+
+func main() {
+	workers := flag.Int("workers", 0, "files to hash at a time (0 for one per CPU)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: dupes [-workers n] [dir]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	root := "."
+	switch flag.NArg() {
+	case 0:
+	case 1:
+		root = flag.Arg(0)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	report, err := exercises.Find(ctx, root, *workers)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "dupes:", err)
+		os.Exit(1)
+	}
+	report.Print(os.Stdout)
+}
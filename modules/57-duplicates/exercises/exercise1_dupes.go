@@ -0,0 +1,219 @@
+package exercises
+
+// EXERCISE: Build a duplicate-file finder that walks a tree, hashes files in a worker pool, and reports the copies.
+// Find walks a directory tree, hashes the files that might be copies with a
+// pool of workers, and groups the files whose hashes match. The starter
+// runs, and finds the copies in a small tree. But it stats each file
+// with os.Stat, which follows symbolic links, so a link counts as one
+// more copy of its target, and a link to nothing stops the walk. It
+// hashes every file, even those whose size no other file has. Its workers
+// all write the one map of results, unlocked, and neither the walk nor
+// the goroutine that hands out the files notices that the context is
+// done. Run the tests with -race.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Set is a set of files with the same contents.
+type Set struct {
+	Size  int64
+	Hash  string   // hex SHA-256 of the contents
+	Paths []string // relative to the root, sorted
+}
+
+// Wasted is the space the copies take beyond the first.
+func (s Set) Wasted() int64 { return s.Size * int64(len(s.Paths)-1) }
+
+// Report is what Find found.
+type Report struct {
+	Files  int   // regular, non-empty files seen
+	Hashed int   // files read and hashed
+	Sets   []Set // most wasted space first, then by first path
+}
+
+// Wasted is the space all copies take beyond the first of each set.
+func (r *Report) Wasted() int64 {
+	var n int64
+	for _, s := range r.Sets {
+		n += s.Wasted()
+	}
+	return n
+}
+
+// Print writes the report for people to read.
+func (r *Report) Print(w io.Writer) {
+	fmt.Fprintf(w, "%d files, %d hashed, %d sets of duplicates, %d bytes wasted\n", r.Files, r.Hashed, len(r.Sets), r.Wasted())
+	for _, s := range r.Sets {
+		fmt.Fprintf(w, "\n%d bytes x %d  sha256:%s\n", s.Size, len(s.Paths), s.Hash[:12])
+		for _, p := range s.Paths {
+			fmt.Fprintf(w, "  %s\n", p)
+		}
+	}
+}
+
+// file is a regular file found by the walk.
+type file struct {
+	path string // relative to the root
+	size int64
+}
+
+// contents identifies the files that are copies of each other.
+type contents struct {
+	size int64
+	hash string
+}
+
+// Find walks the tree at root and returns the sets of regular files in it
+// with the same contents, hashing them with workers goroutines at a time,
+// or runtime.NumCPU() if workers is not positive. Empty files, symbolic
+// links, and other files that are not regular are left out.
+func Find(ctx context.Context, root string, workers int) (*Report, error) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	files, err := walk(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	// BUG: every file is read and hashed, even one whose size no other
+	// file has, which cannot have a copy.
+	candidates := files
+
+	g, ctx := withContext(ctx)
+	jobs := make(chan file)
+	g.Go(func() error {
+		defer close(jobs)
+		for _, f := range candidates {
+			jobs <- f // BUG: goes on handing out files after ctx is done.
+		}
+		return nil
+	})
+	byContents := make(map[contents][]string)
+	for i := 0; i < workers; i++ {
+		g.Go(func() error {
+			for f := range jobs {
+				h, err := hashFile(filepath.Join(root, f.path))
+				if err != nil {
+					return err
+				}
+				// BUG: every worker writes the map, with no lock.
+				c := contents{f.size, h}
+				byContents[c] = append(byContents[c], f.path)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	report := &Report{Files: len(files), Hashed: len(candidates)}
+	for c, paths := range byContents {
+		if len(paths) < 2 {
+			continue
+		}
+		sort.Strings(paths)
+		report.Sets = append(report.Sets, Set{Size: c.size, Hash: c.hash, Paths: paths})
+	}
+	sort.Slice(report.Sets, func(i, j int) bool {
+		a, b := report.Sets[i], report.Sets[j]
+		if a.Wasted() != b.Wasted() {
+			return a.Wasted() > b.Wasted()
+		}
+		return a.Paths[0] < b.Paths[0]
+	})
+	return report, nil
+}
+
+// walk returns the regular, non-empty files under root.
+func walk(ctx context.Context, root string) ([]file, error) {
+	var files []file
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// BUG: the walk goes on however long after ctx is done.
+
+		// BUG: os.Stat follows symbolic links: a link to a file looks like
+		// a copy of it, and a link to nothing is an error.
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() || info.Size() == 0 {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, file{path: filepath.ToSlash(rel), size: info.Size()})
+		return nil
+	})
+	return files, err
+}
+
+// hashFile returns the hex SHA-256 of the file at path, read in a stream.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// group runs goroutines and returns the first error any of them returns,
+// cancelling the context it was made with when one does: the API of
+// golang.org/x/sync/errgroup, which the course does not depend on.
+type group struct {
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+	cancel context.CancelFunc
+}
+
+// withContext returns a group and a context that is cancelled when a
+// goroutine of the group fails, or Wait returns.
+func withContext(ctx context.Context) (*group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &group{cancel: cancel}, ctx
+}
+
+// Go runs f in a goroutine.
+func (g *group) Go(f func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := f(); err != nil {
+			g.once.Do(func() {
+				g.err = err
+				g.cancel()
+			})
+		}
+	}()
+}
+
+// Wait waits for every goroutine, and returns the first error.
+func (g *group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	return g.err
+}
@@ -0,0 +1,150 @@
+package solutions
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tree copies the tree testdata/name to a temporary directory, adds the
+// symbolic links in links (name to target), and returns its path. The
+// links are made here, not kept in testdata, because go:embed and some
+// checkouts cannot hold them.
+func tree(t *testing.T, name string, links map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	src := filepath.Join("testdata", name)
+	require.NoError(t, filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		if d.IsDir() {
+			return os.MkdirAll(filepath.Join(dir, rel), 0o755)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dir, rel), data, 0o644)
+	}))
+	for link, target := range links {
+		if err := os.Symlink(filepath.FromSlash(target), filepath.Join(dir, filepath.FromSlash(link))); err != nil {
+			if runtime.GOOS == "windows" {
+				t.Skipf("symbolic links need Developer Mode on Windows: %v", err)
+			}
+			require.NoError(t, err)
+		}
+	}
+	return dir
+}
+
+// sha returns the hex SHA-256 of s.
+func sha(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// photoLinks are links in the photos tree: to a file, to a directory, and
+// to nothing.
+var photoLinks = map[string]string{
+	"latest.jpg":          "2024/beach.jpg",
+	"backup/current":      "../2024",
+	"2024/notes-link.txt": "../notes.txt",
+	"2024/old.jpg":        "../2023/old.jpg",
+}
+
+func TestFindPhotos(t *testing.T) {
+	dir := tree(t, "photos", photoLinks)
+	r, err := Find(context.Background(), dir, 4)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Set{
+		{Size: 19, Hash: sha("JPEG beach at noon\n"), Paths: []string{"2024/beach copy.jpg", "2024/beach.jpg", "backup/2024/beach.jpg"}},
+		{Size: 16, Hash: sha("Pack sunscreen.\n"), Paths: []string{"backup/notes.txt", "notes.txt"}},
+	}, r.Sets, "links are not copies, and empty files are not reported")
+	assert.Equal(t, 7, r.Files, "regular, non-empty files")
+	assert.Equal(t, int64(19*2+16), r.Wasted())
+}
+
+func TestFindHashesOnlySameSizes(t *testing.T) {
+	dir := tree(t, "photos", nil)
+	r, err := Find(context.Background(), dir, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 6, r.Hashed, "sunset.jpg is the only file of its size, and has no copy to find")
+
+	dir = tree(t, "code", nil)
+	r, err = Find(context.Background(), dir, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, r.Hashed)
+	require.Len(t, r.Sets, 1)
+	assert.Equal(t, []string{"max.go", "vendor/example.com/slices/max.go"}, r.Sets[0].Paths, "min.go is as long, but different")
+}
+
+func TestFindMany(t *testing.T) {
+	r := seed.Rand(t, 57)
+	dir := t.TempDir()
+	want := make(map[string][]string) // hash of the contents to paths
+	for i := 0; i < 120; i++ {
+		contents := strings.Repeat("x", seed.Between(r, 1, 12)) + fmt.Sprint(r.Intn(3))
+		path := fmt.Sprintf("d%d/d%d/f%03d.txt", r.Intn(4), r.Intn(4), i)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(contents), 0o644))
+		want[sha(contents)] = append(want[sha(contents)], path)
+	}
+	wantSets := 0
+	for _, paths := range want {
+		sort.Strings(paths)
+		if len(paths) > 1 {
+			wantSets++
+		}
+	}
+
+	report, err := Find(context.Background(), dir, 8)
+	require.NoError(t, err)
+	assert.Equal(t, 120, report.Files)
+	require.Len(t, report.Sets, wantSets)
+	for i, s := range report.Sets {
+		assert.Equal(t, want[s.Hash], s.Paths, "set %d", i)
+		if i > 0 {
+			assert.GreaterOrEqual(t, report.Sets[i-1].Wasted(), s.Wasted(), "most wasted space first")
+		}
+	}
+}
+
+func TestFindCancelled(t *testing.T) {
+	dir := tree(t, "photos", nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Find(ctx, dir, 2)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFindMissingRoot(t *testing.T) {
+	_, err := Find(context.Background(), filepath.Join(t.TempDir(), "nothing"), 0)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestReportPrint(t *testing.T) {
+	r := &Report{Files: 7, Hashed: 6, Sets: []Set{
+		{Size: 19, Hash: sha("JPEG beach at noon\n"), Paths: []string{"2024/beach.jpg", "backup/2024/beach.jpg"}},
+	}}
+	var b strings.Builder
+	r.Print(&b)
+	assert.Equal(t, "7 files, 6 hashed, 1 sets of duplicates, 19 bytes wasted\n"+
+		"\n19 bytes x 2  sha256:"+sha("JPEG beach at noon\n")[:12]+"\n"+
+		"  2024/beach.jpg\n"+
+		"  backup/2024/beach.jpg\n", b.String())
+}
@@ -0,0 +1,3 @@
+package slices
+
+func Max() {}
@@ -0,0 +1,54 @@
+// Package examples demonstrates the pieces of a polite crawler: a
+// breadth-first walk a level at a time, goroutines that finish however the
+// caller leaves, and a test site of several hosts on one test server.
+//
+// This file shows:
+// - A breadth-first walk that finishes one level before it starts the next
+// - Marking a node seen when it is queued, not when it is visited, so it is queued once
+// - That each node is then reached at its smallest depth, however the goroutines of a level are scheduled
+// - A depth limit, which keeps a walk of the web from running forever
+package examples
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Levels walks graph from start, breadth first, and returns the nodes at
+// each depth, down to maxDepth, sorted.
+func Levels(graph map[string][]string, start string, maxDepth int) [][]string {
+	seen := map[string]bool{start: true}
+	level := []string{start}
+	var levels [][]string
+	for depth := 0; depth <= maxDepth && len(level) > 0; depth++ {
+		sort.Strings(level)
+		levels = append(levels, level)
+		var next []string
+		for _, node := range level {
+			for _, to := range graph[node] {
+				if !seen[to] {
+					seen[to] = true // queued once, even if the level links to it twice
+					next = append(next, to)
+				}
+			}
+		}
+		level = next
+	}
+	return levels
+}
+
+// DemonstrateLevels walks a small site, linked in a loop.
+func DemonstrateLevels() {
+	fmt.Println("\n=== A walk by levels ===")
+	site := map[string][]string{
+		"/":          {"/docs", "/blog"},
+		"/docs":      {"/docs/tour", "/"},
+		"/blog":      {"/blog/1", "/docs/tour"},
+		"/docs/tour": {"/docs/spec"},
+		"/docs/spec": {"/"},
+	}
+	for depth, nodes := range Levels(site, "/", 5) {
+		fmt.Println(depth, nodes)
+	}
+	fmt.Println("to depth 1:", Levels(site, "/", 1))
+}
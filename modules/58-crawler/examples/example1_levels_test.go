@@ -0,0 +1,29 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateLevels(t *testing.T) {
+	DemonstrateLevels()
+}
+
+func TestLevels(t *testing.T) {
+	graph := map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d", "a"},
+		"c": {"d", "e"},
+		"e": {"b"},
+	}
+	assert.Equal(t, [][]string{{"a"}, {"b", "c"}, {"d", "e"}}, Levels(graph, "a", 10))
+	assert.Equal(t, [][]string{{"a"}, {"b", "c"}}, Levels(graph, "a", 1))
+	assert.Equal(t, [][]string{{"a"}}, Levels(graph, "a", 0))
+}
+
+func TestLevelsLoop(t *testing.T) {
+	graph := map[string][]string{"a": {"a", "b"}, "b": {"a"}}
+	assert.Equal(t, [][]string{{"a"}, {"b"}}, Levels(graph, "a", 1000))
+	assert.Equal(t, [][]string{{"z"}}, Levels(graph, "z", 3), "a start with no links")
+}
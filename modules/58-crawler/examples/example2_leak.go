@@ -0,0 +1,66 @@
+package examples
+
+// This file shows:
+// - A goroutine leak: a sender blocked on a channel no one will read again
+// - Returning early from a select on ctx.Done, which strands every goroutine still sending
+// - Two fixes: a buffer with room for every result, or reading until the senders are done
+// - runtime.NumGoroutine, to watch a leak grow
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// FirstLeaky runs every task and returns the first result, or "" if ctx
+// is done first. The tasks that finish later block on their send for
+// good: nothing reads the channel again.
+func FirstLeaky(ctx context.Context, tasks ...func() string) string {
+	results := make(chan string)
+	for _, task := range tasks {
+		task := task
+		go func() { results <- task() }()
+	}
+	select {
+	case r := <-results:
+		return r
+	case <-ctx.Done():
+		return ""
+	}
+}
+
+// First is FirstLeaky with room in the channel for every result, so that
+// every task can send, and finish, whether or not anyone reads.
+func First(ctx context.Context, tasks ...func() string) string {
+	results := make(chan string, len(tasks))
+	for _, task := range tasks {
+		task := task
+		go func() { results <- task() }()
+	}
+	select {
+	case r := <-results:
+		return r
+	case <-ctx.Done():
+		return ""
+	}
+}
+
+// DemonstrateLeak counts the goroutines left behind by each version.
+func DemonstrateLeak() {
+	fmt.Println("\n=== Goroutine leaks ===")
+	sleep := func(d time.Duration) func() string {
+		return func() string { time.Sleep(d); return d.String() }
+	}
+	tasks := []func() string{sleep(time.Millisecond), sleep(5 * time.Millisecond), sleep(10 * time.Millisecond)}
+
+	for _, f := range []struct {
+		name  string
+		first func(context.Context, ...func() string) string
+	}{{"FirstLeaky", FirstLeaky}, {"First", First}} {
+		before := runtime.NumGoroutine()
+		r := f.first(context.Background(), tasks...)
+		time.Sleep(50 * time.Millisecond) // the slower tasks finish
+		fmt.Printf("%-10s returned %s, left %d goroutines behind\n", f.name, r, runtime.NumGoroutine()-before)
+	}
+}
@@ -0,0 +1,39 @@
+package examples
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateLeak(t *testing.T) {
+	DemonstrateLeak()
+}
+
+func TestFirst(t *testing.T) {
+	leaktest.Check(t)
+	fast := func() string { return "fast" }
+	slow := func() string { time.Sleep(20 * time.Millisecond); return "slow" }
+	assert.Equal(t, "fast", First(context.Background(), slow, fast, slow))
+}
+
+func TestFirstCancelled(t *testing.T) {
+	leaktest.Check(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	slow := func() string { time.Sleep(20 * time.Millisecond); return "slow" }
+	assert.Equal(t, "", First(ctx, slow, slow))
+}
+
+func TestFirstLeakyLeaks(t *testing.T) {
+	release := make(chan struct{})
+	wait := func() string { <-release; return "late" }
+	before := len(leaktest.Goroutines())
+	assert.Equal(t, "now", FirstLeaky(context.Background(), func() string { return "now" }, wait, wait))
+	close(release)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 2, len(leaktest.Goroutines())-before, "the two late tasks are blocked on their sends")
+}
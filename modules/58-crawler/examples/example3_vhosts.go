@@ -0,0 +1,72 @@
+package examples
+
+// This file shows:
+// - Several sites on one httptest.Server, told apart by the Host header, as on a shared web host
+// - An http.Transport whose DialContext connects every host name to the test server
+// - Test sites with names that resolve nowhere, so a crawler under test cannot reach the real web
+// - Closing the server and the client's idle connections, so that their goroutines finish
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+)
+
+// VirtualHosts returns a handler that passes each request to the handler
+// for its host, and answers 404 for other hosts.
+func VirtualHosts(hosts map[string]http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		h, ok := hosts[host]
+		if !ok {
+			http.Error(w, "no site "+host, http.StatusNotFound)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// DialAll returns a client that connects to addr whatever the host of the
+// URL, and sends that host in the request, as a browser would.
+func DialAll(addr string) *http.Client {
+	var d net.Dialer
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return d.DialContext(ctx, network, addr)
+		},
+	}}
+}
+
+// DemonstrateVirtualHosts serves two sites on one test server.
+func DemonstrateVirtualHosts() {
+	fmt.Println("\n=== Virtual hosts ===")
+	site := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "%s at %s", r.URL.Path, name)
+		})
+	}
+	srv := httptest.NewServer(VirtualHosts(map[string]http.Handler{
+		"go.test":   site("the Go site"),
+		"blog.test": site("the blog"),
+	}))
+	defer srv.Close()
+	client := DialAll(srv.Listener.Addr().String())
+	defer client.CloseIdleConnections()
+
+	for _, u := range []string{"http://go.test/docs/", "http://blog.test/posts/1", "http://other.test/"} {
+		resp, err := client.Get(u)
+		if err != nil {
+			fmt.Println("error:", err)
+			continue
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		fmt.Printf("%-26s %d %s\n", u, resp.StatusCode, body)
+	}
+}
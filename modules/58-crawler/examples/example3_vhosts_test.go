@@ -0,0 +1,54 @@
+package examples
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateVirtualHosts(t *testing.T) {
+	DemonstrateVirtualHosts()
+}
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestVirtualHosts(t *testing.T) {
+	leaktest.Check(t)
+	hello := func(s string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { io.WriteString(w, s+r.URL.Path) })
+	}
+	srv := httptest.NewServer(VirtualHosts(map[string]http.Handler{"a.test": hello("a"), "b.test": hello("b")}))
+	t.Cleanup(srv.Close)
+	client := DialAll(srv.Listener.Addr().String())
+	t.Cleanup(client.CloseIdleConnections)
+
+	code, body := get(t, client, "http://a.test/x")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "a/x", body)
+	code, body = get(t, client, "http://b.test:8080/y")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "b/y", body, "the port does not name another site")
+	code, _ = get(t, client, "http://c.test/")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestVirtualHostsPlainClient(t *testing.T) {
+	srv := httptest.NewServer(VirtualHosts(nil))
+	t.Cleanup(srv.Close)
+	code, body := get(t, srv.Client(), srv.URL)
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Contains(t, body, "no site 127.0.0.1")
+}
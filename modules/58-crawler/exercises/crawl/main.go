@@ -0,0 +1,51 @@
+// Command crawl fetches a page and the pages it links to, down to a depth,
+// and prints each with its status.
+//
+//	go run ./modules/58-crawler/exercises/crawl -depth 2 -interval 1s https://go.dev/
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/58-crawler/exercises"
+)
+
+func main() {
+	depth := flag.Int("depth", 1, "links to follow from a start page")
+	workers := flag.Int("workers", exercises.DefaultWorkers, "requests in flight at once")
+	interval := flag.Duration("interval", time.Second, "time between requests to one host")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: crawl [-depth n] [-workers n] [-interval d] url...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	c := &exercises.Crawler{
+		MaxDepth: *depth,
+		Workers:  *workers,
+		Limiter:  exercises.NewLimiter(*interval),
+	}
+	pages, err := c.Crawl(ctx, flag.Args()...)
+	for _, p := range pages {
+		if p.Err != nil {
+			fmt.Printf("%d %s: %v\n", p.Depth, p.URL, p.Err)
+		} else {
+			fmt.Printf("%d %s: %d, %d links\n", p.Depth, p.URL, p.Status, len(p.Links))
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "crawl:", err)
+		os.Exit(1)
+	}
+}
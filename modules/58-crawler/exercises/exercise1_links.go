@@ -0,0 +1,94 @@
+package exercises
+
+// EXERCISE: Milestone 1: fix the link extractor and the per-host limiter the crawler is built on.
+// Links finds the links on a page, and a Limiter keeps the crawler from
+// asking one host for pages faster than one every Interval. The crawler of
+// milestone 2 calls both. But Links glues each link to the page's URL as
+// strings, which gets "/docs/" and "../about.html" wrong; it keeps the
+// fragment, so "about.html#team" looks like a page of its own; and it
+// keeps mailto: and javascript: links, which no crawler can fetch. The
+// Limiter keeps its slots per URL instead of per host, and sleeps out its
+// wait even after the context is done.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"html"
+	"io"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// hrefPattern matches the target of an <a href="..."> tag. A regular
+// expression is not an HTML parser: it misses links written by scripts
+// and finds some inside comments. A real crawler would use
+// golang.org/x/net/html; for the pages of the tests, it is enough.
+var hrefPattern = regexp.MustCompile(`(?i)<a\s[^>]*?href\s*=\s*["']([^"']*)["']`)
+
+// Links returns the links in the HTML page read from r, as absolute URLs
+// resolved against base, the page's own URL. Each link appears once, in
+// the order of the page, without its fragment. Links to anything but http
+// and https are left out.
+func Links(base *url.URL, r io.Reader) ([]string, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var links []string
+	seen := make(map[string]bool)
+	for _, m := range hrefPattern.FindAllSubmatch(body, -1) {
+		ref, err := url.Parse(html.UnescapeString(string(m[1])))
+		if err != nil {
+			continue // a broken link is the page's problem, not the crawler's
+		}
+		// BUG: "/docs/" and "../about.html" are not relative to the end of the page's URL
+		u, err := url.Parse(base.String() + ref.String())
+		if err != nil {
+			continue
+		}
+		// BUG: mailto:, javascript:, and ftp: links are kept
+		// BUG: the fragment is kept, so one page has many URLs
+		s := u.String()
+		if !seen[s] {
+			seen[s] = true
+			links = append(links, s)
+		}
+	}
+	return links, nil
+}
+
+// Limiter spaces the requests to each host at least Interval apart, however
+// many goroutines ask at once. The zero Interval does not limit.
+type Limiter struct {
+	Interval time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time // host → when its next request may go
+}
+
+// NewLimiter returns a Limiter that lets one request a host go every
+// interval.
+func NewLimiter(interval time.Duration) *Limiter {
+	return &Limiter{Interval: interval, next: make(map[string]time.Time)}
+}
+
+// Wait blocks until a request to u's host may go, and takes that slot. It
+// returns ctx.Err() if ctx is done first.
+func (l *Limiter) Wait(ctx context.Context, u *url.URL) error {
+	// BUG: every URL gets slots of its own, so a host gets many at once
+	key := u.String()
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next[key]
+	if at.Before(now) {
+		at = now
+	}
+	l.next[key] = at.Add(l.Interval)
+	l.mu.Unlock()
+
+	// BUG: sleeps out the wait even after ctx is done
+	time.Sleep(time.Until(at))
+	return nil
+}
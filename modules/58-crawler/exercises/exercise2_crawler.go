@@ -0,0 +1,195 @@
+package exercises
+
+// EXERCISE: Milestone 2: fix the crawler that fetches a site level by level with a bounded pool of workers.
+// Crawl fetches the start pages, then every page they link to, a level at
+// a time, with Links and the Limiter of milestone 1. The starter crawls
+// the test site, but fetches a page twice when two pages of one level
+// link to it, and starts a worker per page of a level instead of
+// c.Workers of them. When the context is done, fetchAll returns at once,
+// and leaves its workers and the goroutine handing out URLs blocked on
+// their sends for good. And fetch does not close the body of a page it
+// does not read, so the goroutines of its connection never finish. The
+// tests check the goroutines left running with pkg/leaktest, and the
+// requests in flight at the test server.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultWorkers is the number of requests a Crawler has in flight at once
+// when its Workers field is zero.
+const DefaultWorkers = 4
+
+// DefaultUserAgent names the crawler to the sites it visits. A polite
+// crawler says who it is, so that a site's owner can tell its requests
+// apart, and block them.
+const DefaultUserAgent = "learngo-crawler/1.0"
+
+// MaxPageSize is how much of a page the crawler reads for links.
+const MaxPageSize = 1 << 20
+
+// Page is a fetched page.
+type Page struct {
+	URL    string
+	Depth  int      // links away from a start page
+	Status int      // the HTTP status, or 0 if the request failed
+	Links  []string // absolute URLs, for pages answered 200 with HTML
+	Err    error    // why the page could not be fetched or read
+}
+
+// Crawler fetches pages and the pages they link to.
+type Crawler struct {
+	Client    *http.Client // nil means http.DefaultClient
+	MaxDepth  int          // how many links away from a start page to go
+	Workers   int          // requests in flight at once; 0 means DefaultWorkers
+	Limiter   *Limiter     // spaces the requests to each host; nil does not
+	UserAgent string       // "" means DefaultUserAgent
+}
+
+// Crawl fetches the start pages, then the pages they link to, a level at a
+// time, down to MaxDepth links away. Every URL is fetched once, at the
+// smallest depth it is linked at. The pages come back by depth, then URL.
+//
+// If ctx is done first, Crawl returns the pages it has and ctx.Err(), and
+// leaves no goroutine behind.
+func (c *Crawler) Crawl(ctx context.Context, start ...string) ([]Page, error) {
+	seen := make(map[string]bool)
+	var level []string
+	for _, u := range start {
+		if !seen[u] {
+			seen[u] = true
+			level = append(level, u)
+		}
+	}
+	var pages []Page
+	for depth := 0; depth <= c.MaxDepth && len(level) > 0; depth++ {
+		for _, u := range level {
+			seen[u] = true
+		}
+		fetched, err := c.fetchAll(ctx, level, depth)
+		pages = append(pages, fetched...)
+		if err != nil {
+			return pages, err
+		}
+		var next []string
+		for _, p := range fetched {
+			for _, l := range p.Links {
+				// BUG: not marked seen until fetched, so two pages linking to l queue it twice
+				if !seen[l] {
+					next = append(next, l)
+				}
+			}
+		}
+		level = next
+	}
+	return pages, nil
+}
+
+// fetchAll fetches the pages of one level, with at most c.Workers requests
+// in flight, and returns them sorted by URL. It returns once every worker
+// has stopped.
+func (c *Crawler) fetchAll(ctx context.Context, urls []string, depth int) ([]Page, error) {
+	jobs := make(chan string)
+	results := make(chan Page)
+
+	var wg sync.WaitGroup
+	// BUG: a worker per URL, however many c.Workers allows
+	for i := 0; i < len(urls); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for u := range jobs {
+				results <- c.fetch(ctx, u, depth)
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for _, u := range urls {
+			// BUG: hands out every URL even after ctx is done, and blocks for good once no worker takes them
+			jobs <- u
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var pages []Page
+	for {
+		select {
+		case p, ok := <-results:
+			if !ok {
+				sort.Slice(pages, func(i, j int) bool { return pages[i].URL < pages[j].URL })
+				return pages, nil
+			}
+			pages = append(pages, p)
+		case <-ctx.Done():
+			// BUG: the workers still running block on their sends to results, for good
+			return pages, ctx.Err()
+		}
+	}
+}
+
+// fetch fetches one page and the links on it.
+func (c *Crawler) fetch(ctx context.Context, rawURL string, depth int) Page {
+	p := Page{URL: rawURL, Depth: depth}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	if c.Limiter != nil {
+		if p.Err = c.Limiter.Wait(ctx, u); p.Err != nil {
+			return p
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	req.Header.Set("User-Agent", c.userAgent())
+	resp, err := c.client().Do(req)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	// BUG: the body is never closed; a page read to the end gives its
+	// connection back, but a 404 is not read at all
+	p.Status = resp.StatusCode
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		return p
+	}
+	// Links are relative to where the page ended up, after redirects.
+	p.Links, p.Err = Links(resp.Request.URL, io.LimitReader(resp.Body, MaxPageSize))
+	return p
+}
+
+func (c *Crawler) client() *http.Client {
+	if c.Client == nil {
+		return http.DefaultClient
+	}
+	return c.Client
+}
+
+func (c *Crawler) workers() int {
+	if c.Workers <= 0 {
+		return DefaultWorkers
+	}
+	return c.Workers
+}
+
+func (c *Crawler) userAgent() string {
+	if c.UserAgent == "" {
+		return DefaultUserAgent
+	}
+	return c.UserAgent
+}
@@ -0,0 +1,96 @@
+package solutions
+
+// SOLUTION: Milestone 1: fix the link extractor and the per-host limiter the crawler is built on.
+// Fixed: links are resolved against the page's URL with ResolveReference, which knows what "/" and "../" mean
+// Fixed: the fragment is dropped, so "about.html#team" and "about.html" are one page
+// Fixed: links to anything but http and https, such as mailto: and javascript:, are left out
+// Fixed: the limiter spaces requests by host, not by URL
+// Fixed: Wait returns when the context is done instead of sleeping out the wait
+
+import (
+	"context"
+	"html"
+	"io"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// hrefPattern matches the target of an <a href="..."> tag. A regular
+// expression is not an HTML parser: it misses links written by scripts
+// and finds some inside comments. A real crawler would use
+// golang.org/x/net/html; for the pages of the tests, it is enough.
+var hrefPattern = regexp.MustCompile(`(?i)<a\s[^>]*?href\s*=\s*["']([^"']*)["']`)
+
+// Links returns the links in the HTML page read from r, as absolute URLs
+// resolved against base, the page's own URL. Each link appears once, in
+// the order of the page, without its fragment. Links to anything but http
+// and https are left out.
+func Links(base *url.URL, r io.Reader) ([]string, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var links []string
+	seen := make(map[string]bool)
+	for _, m := range hrefPattern.FindAllSubmatch(body, -1) {
+		ref, err := url.Parse(html.UnescapeString(string(m[1])))
+		if err != nil {
+			continue // a broken link is the page's problem, not the crawler's
+		}
+		// Fixed: ResolveReference, not base.String() + href
+		u := base.ResolveReference(ref)
+		// Fixed: only pages a crawler can fetch
+		if u.Scheme != "http" && u.Scheme != "https" {
+			continue
+		}
+		// Fixed: the fragment names a place on the page, not another page
+		u.Fragment = ""
+		s := u.String()
+		if !seen[s] {
+			seen[s] = true
+			links = append(links, s)
+		}
+	}
+	return links, nil
+}
+
+// Limiter spaces the requests to each host at least Interval apart, however
+// many goroutines ask at once. The zero Interval does not limit.
+type Limiter struct {
+	Interval time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time // host → when its next request may go
+}
+
+// NewLimiter returns a Limiter that lets one request a host go every
+// interval.
+func NewLimiter(interval time.Duration) *Limiter {
+	return &Limiter{Interval: interval, next: make(map[string]time.Time)}
+}
+
+// Wait blocks until a request to u's host may go, and takes that slot. It
+// returns ctx.Err() if ctx is done first.
+func (l *Limiter) Wait(ctx context.Context, u *url.URL) error {
+	l.mu.Lock()
+	now := time.Now()
+	// Fixed: the host, not the whole URL
+	at := l.next[u.Host]
+	if at.Before(now) {
+		at = now
+	}
+	l.next[u.Host] = at.Add(l.Interval)
+	l.mu.Unlock()
+
+	// Fixed: a timer in a select, not time.Sleep
+	t := time.NewTimer(time.Until(at))
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
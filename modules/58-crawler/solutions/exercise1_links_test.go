@@ -0,0 +1,121 @@
+package solutions
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// links returns the links on the test site's page file, served at rawURL.
+func links(t *testing.T, rawURL, file string) []string {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", "site", filepath.FromSlash(file)))
+	require.NoError(t, err)
+	defer f.Close()
+	base, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	got, err := Links(base, f)
+	require.NoError(t, err)
+	return got
+}
+
+func TestLinks(t *testing.T) {
+	assert.Equal(t, []string{
+		"http://go.test/docs/",
+		"http://go.test/about.html",
+		"http://blog.test/",
+	}, links(t, "http://go.test/", "go.test/index.html"), "no mailto:, no fragment, no repeats")
+}
+
+func TestLinksRelative(t *testing.T) {
+	assert.Equal(t, []string{
+		"http://go.test/docs/tour.html",
+		"http://go.test/about.html",
+		"http://go.test/docs/spec.html",
+	}, links(t, "http://go.test/docs/", "go.test/docs/index.html"))
+	assert.Equal(t, []string{
+		"http://blog.test/posts/2.html",
+		"http://blog.test/",
+	}, links(t, "http://blog.test/posts/1.html", "blog.test/posts/1.html"))
+}
+
+func TestLinksSchemes(t *testing.T) {
+	page := `<p><a href="ftp://files.test/a">ftp</a>
+<a class="x" href='https://go.test/search?q=go&amp;page=2#results'>search</a>
+<A HREF="JavaScript:alert(1)">js</A> <a href="tel:+3212345678">call</a>
+<a href="//cdn.test/lib.html">cdn</a></p>`
+	base, err := url.Parse("https://go.test/docs/")
+	require.NoError(t, err)
+	got, err := Links(base, strings.NewReader(page))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"https://go.test/search?q=go&page=2",
+		"https://cdn.test/lib.html",
+	}, got)
+}
+
+// waitAll calls l.Wait for each URL at once, and returns how long they
+// took in all.
+func waitAll(t *testing.T, l *Limiter, urls ...string) time.Duration {
+	t.Helper()
+	start := time.Now()
+	var wg sync.WaitGroup
+	errs := make([]error, len(urls))
+	for i, raw := range urls {
+		u, err := url.Parse(raw)
+		require.NoError(t, err)
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = l.Wait(context.Background(), u)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	return time.Since(start)
+}
+
+func TestLimiterSpacesHost(t *testing.T) {
+	const interval = 20 * time.Millisecond
+	var urls []string
+	for i := 0; i < 6; i++ {
+		urls = append(urls, fmt.Sprintf("http://go.test/page%d.html", i))
+	}
+	took := waitAll(t, NewLimiter(interval), urls...)
+	assert.GreaterOrEqual(t, took, 5*interval, "6 requests to one host, %v apart", interval)
+}
+
+func TestLimiterHostsApart(t *testing.T) {
+	took := waitAll(t, NewLimiter(time.Hour), "http://go.test/", "http://blog.test/", "http://go.test:8080/")
+	assert.Less(t, took, time.Second, "the first request to each host goes at once")
+}
+
+func TestLimiterCancel(t *testing.T) {
+	l := NewLimiter(time.Hour)
+	u, err := url.Parse("http://go.test/")
+	require.NoError(t, err)
+	require.NoError(t, l.Wait(context.Background(), u))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- l.Wait(ctx, u) }()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait is still waiting for its slot, an hour away, after the context is done")
+	}
+}
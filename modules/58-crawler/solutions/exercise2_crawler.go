@@ -0,0 +1,185 @@
+package solutions
+
+// SOLUTION: Milestone 2: fix the crawler that fetches a site level by level with a bounded pool of workers.
+// Fixed: a link is marked seen when it joins the next level, so two pages linking to it do not queue it twice
+// Fixed: fetchAll starts c.Workers workers, not one per URL
+// Fixed: the goroutine handing out URLs stops when the context is done, instead of blocking on a send
+// Fixed: fetchAll reads every result before it returns, so no worker is left blocked on a send
+// Fixed: every response body is closed, so its connection's goroutines can finish
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultWorkers is the number of requests a Crawler has in flight at once
+// when its Workers field is zero.
+const DefaultWorkers = 4
+
+// DefaultUserAgent names the crawler to the sites it visits. A polite
+// crawler says who it is, so that a site's owner can tell its requests
+// apart, and block them.
+const DefaultUserAgent = "learngo-crawler/1.0"
+
+// MaxPageSize is how much of a page the crawler reads for links.
+const MaxPageSize = 1 << 20
+
+// Page is a fetched page.
+type Page struct {
+	URL    string
+	Depth  int      // links away from a start page
+	Status int      // the HTTP status, or 0 if the request failed
+	Links  []string // absolute URLs, for pages answered 200 with HTML
+	Err    error    // why the page could not be fetched or read
+}
+
+// Crawler fetches pages and the pages they link to.
+type Crawler struct {
+	Client    *http.Client // nil means http.DefaultClient
+	MaxDepth  int          // how many links away from a start page to go
+	Workers   int          // requests in flight at once; 0 means DefaultWorkers
+	Limiter   *Limiter     // spaces the requests to each host; nil does not
+	UserAgent string       // "" means DefaultUserAgent
+}
+
+// Crawl fetches the start pages, then the pages they link to, a level at a
+// time, down to MaxDepth links away. Every URL is fetched once, at the
+// smallest depth it is linked at. The pages come back by depth, then URL.
+//
+// If ctx is done first, Crawl returns the pages it has and ctx.Err(), and
+// leaves no goroutine behind.
+func (c *Crawler) Crawl(ctx context.Context, start ...string) ([]Page, error) {
+	seen := make(map[string]bool)
+	var level []string
+	for _, u := range start {
+		if !seen[u] {
+			seen[u] = true
+			level = append(level, u)
+		}
+	}
+	var pages []Page
+	for depth := 0; depth <= c.MaxDepth && len(level) > 0; depth++ {
+		fetched, err := c.fetchAll(ctx, level, depth)
+		pages = append(pages, fetched...)
+		if err != nil {
+			return pages, err
+		}
+		var next []string
+		for _, p := range fetched {
+			for _, l := range p.Links {
+				if !seen[l] {
+					// Fixed: seen as soon as it is queued
+					seen[l] = true
+					next = append(next, l)
+				}
+			}
+		}
+		level = next
+	}
+	return pages, nil
+}
+
+// fetchAll fetches the pages of one level, with at most c.Workers requests
+// in flight, and returns them sorted by URL. It returns once every worker
+// has stopped.
+func (c *Crawler) fetchAll(ctx context.Context, urls []string, depth int) ([]Page, error) {
+	jobs := make(chan string)
+	results := make(chan Page)
+
+	var wg sync.WaitGroup
+	// Fixed: a fixed pool of workers, whatever the size of the level
+	for i := 0; i < c.workers(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for u := range jobs {
+				results <- c.fetch(ctx, u, depth)
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for _, u := range urls {
+			// Fixed: no one takes jobs once the workers have stopped
+			select {
+			case jobs <- u:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Fixed: read until the workers are done, even after ctx is; each
+	// of them is at most one request away from noticing
+	var pages []Page
+	for p := range results {
+		pages = append(pages, p)
+	}
+	sort.Slice(pages, func(i, j int) bool { return pages[i].URL < pages[j].URL })
+	return pages, ctx.Err()
+}
+
+// fetch fetches one page and the links on it.
+func (c *Crawler) fetch(ctx context.Context, rawURL string, depth int) Page {
+	p := Page{URL: rawURL, Depth: depth}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	if c.Limiter != nil {
+		if p.Err = c.Limiter.Wait(ctx, u); p.Err != nil {
+			return p
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	req.Header.Set("User-Agent", c.userAgent())
+	resp, err := c.client().Do(req)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	// Fixed: closed whether or not the page is read
+	defer resp.Body.Close()
+	p.Status = resp.StatusCode
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		return p
+	}
+	// Links are relative to where the page ended up, after redirects.
+	p.Links, p.Err = Links(resp.Request.URL, io.LimitReader(resp.Body, MaxPageSize))
+	return p
+}
+
+func (c *Crawler) client() *http.Client {
+	if c.Client == nil {
+		return http.DefaultClient
+	}
+	return c.Client
+}
+
+func (c *Crawler) workers() int {
+	if c.Workers <= 0 {
+		return DefaultWorkers
+	}
+	return c.Workers
+}
+
+func (c *Crawler) userAgent() string {
+	if c.UserAgent == "" {
+		return DefaultUserAgent
+	}
+	return c.UserAgent
+}
@@ -0,0 +1,197 @@
+package solutions
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// site serves testdata/site on one test server, with a directory per host:
+// http://go.test/docs/ is testdata/site/go.test/docs/index.html. It answers
+// each request after delay, and records what the crawler did.
+type site struct {
+	srv   *httptest.Server
+	delay time.Duration
+
+	mu       sync.Mutex
+	hits     map[string]int         // URL → requests for it
+	times    map[string][]time.Time // host → when its requests came
+	agents   map[string]bool        // User-Agent headers seen
+	inFlight int
+	most     int // requests in flight at once, at most
+}
+
+func newSite(t *testing.T, delay time.Duration) *site {
+	s := &site{
+		delay:  delay,
+		hits:   make(map[string]int),
+		times:  make(map[string][]time.Time),
+		agents: make(map[string]bool),
+	}
+	files := http.FileServer(http.Dir(filepath.Join("testdata", "site")))
+	s.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.begin(r)
+		defer s.end()
+		time.Sleep(s.delay)
+		r.URL.Path = "/" + r.Host + r.URL.Path
+		files.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.srv.Close)
+	return s
+}
+
+func (s *site) begin(r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hits["http://"+r.Host+r.URL.Path]++
+	s.times[r.Host] = append(s.times[r.Host], time.Now())
+	s.agents[r.UserAgent()] = true
+	s.inFlight++
+	if s.inFlight > s.most {
+		s.most = s.inFlight
+	}
+}
+
+func (s *site) end() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inFlight--
+}
+
+// client returns a client that reaches go.test and blog.test on the test
+// server, and no other host.
+func (s *site) client(t *testing.T) *http.Client {
+	addr := s.srv.Listener.Addr().String()
+	var d net.Dialer
+	tr := &http.Transport{
+		DialContext: func(ctx context.Context, network, hostport string) (net.Conn, error) {
+			host, _, err := net.SplitHostPort(hostport)
+			if err != nil {
+				return nil, err
+			}
+			if host != "go.test" && host != "blog.test" {
+				return nil, fmt.Errorf("no such host %s", host)
+			}
+			return d.DialContext(ctx, network, addr)
+		},
+	}
+	t.Cleanup(tr.CloseIdleConnections)
+	return &http.Client{Transport: tr}
+}
+
+// summary lists the pages as "depth status URL" lines.
+func summary(pages []Page) []string {
+	var lines []string
+	for _, p := range pages {
+		lines = append(lines, fmt.Sprintf("%d %d %s", p.Depth, p.Status, p.URL))
+	}
+	return lines
+}
+
+func TestCrawlSite(t *testing.T) {
+	leaktest.Check(t)
+	s := newSite(t, 0)
+	c := &Crawler{Client: s.client(t), MaxDepth: 10, Workers: 3}
+	pages, err := c.Crawl(context.Background(), "http://go.test/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"0 200 http://go.test/",
+		"1 200 http://blog.test/",
+		"1 200 http://go.test/about.html",
+		"1 200 http://go.test/docs/",
+		"2 200 http://blog.test/posts/1.html",
+		"2 200 http://blog.test/posts/2.html",
+		"2 200 http://go.test/docs/spec.html",
+		"2 200 http://go.test/docs/tour.html",
+		"3 404 http://blog.test/missing.html",
+		"3 200 http://go.test/docs/deep/1.html",
+		"3 0 https://elsewhere.test/",
+		"4 200 http://go.test/docs/deep/2.html",
+		"5 200 http://go.test/docs/deep/3.html",
+	}, summary(pages))
+	for _, p := range pages {
+		if p.URL == "https://elsewhere.test/" {
+			assert.ErrorContains(t, p.Err, "no such host")
+		} else {
+			assert.NoError(t, p.Err, p.URL)
+		}
+	}
+	assert.Equal(t, []string{"http://go.test/docs/spec.html", "http://go.test/docs/deep/1.html", "http://blog.test/posts/1.html"}, pages[7].Links)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for u, n := range s.hits {
+		assert.Equal(t, 1, n, "%s fetched more than once", u)
+	}
+	assert.Len(t, s.hits, 12, "every page but the unreachable one")
+	assert.Equal(t, map[string]bool{DefaultUserAgent: true}, s.agents)
+}
+
+func TestCrawlDepth(t *testing.T) {
+	leaktest.Check(t)
+	s := newSite(t, 0)
+	c := &Crawler{Client: s.client(t), MaxDepth: 1, UserAgent: "test-bot"}
+	pages, err := c.Crawl(context.Background(), "http://blog.test/posts/2.html", "http://blog.test/posts/2.html")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"0 200 http://blog.test/posts/2.html",
+		"1 404 http://blog.test/missing.html",
+		"1 200 http://blog.test/posts/1.html",
+		"1 0 https://elsewhere.test/",
+	}, summary(pages))
+	assert.Equal(t, map[string]bool{"test-bot": true}, s.agents)
+}
+
+func TestCrawlBoundsConcurrency(t *testing.T) {
+	leaktest.Check(t)
+	s := newSite(t, 20*time.Millisecond)
+	c := &Crawler{Client: s.client(t), MaxDepth: 10, Workers: 2}
+	_, err := c.Crawl(context.Background(), "http://go.test/")
+	require.NoError(t, err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	assert.Equal(t, 2, s.most, "4 pages at depth 2, and 2 workers")
+}
+
+func TestCrawlPerHost(t *testing.T) {
+	leaktest.Check(t)
+	const interval = 30 * time.Millisecond
+	s := newSite(t, 0)
+	c := &Crawler{Client: s.client(t), MaxDepth: 2, Workers: 4, Limiter: NewLimiter(interval)}
+	_, err := c.Crawl(context.Background(), "http://go.test/", "http://blog.test/")
+	require.NoError(t, err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	require.Len(t, s.times["go.test"], 5)
+	for host, times := range s.times {
+		for i := 1; i < len(times); i++ {
+			// The requests leave interval apart; the network may move them a little.
+			assert.Greater(t, times[i].Sub(times[i-1]), interval/2, "request %d to %s", i, host)
+		}
+	}
+}
+
+func TestCrawlCancel(t *testing.T) {
+	leaktest.Check(t)
+	s := newSite(t, 30*time.Millisecond)
+	c := &Crawler{Client: s.client(t), MaxDepth: 10, Workers: 2}
+	ctx, cancel := context.WithTimeout(context.Background(), 80*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	pages, err := c.Crawl(ctx, "http://go.test/")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	require.NotEmpty(t, pages)
+	assert.Equal(t, "http://go.test/", pages[0].URL)
+	assert.Less(t, len(pages), 13)
+}
@@ -0,0 +1,74 @@
+// Package examples demonstrates the pieces of a chat server: fanning a
+// message out to many clients without waiting on the slowest, the
+// WebSocket handshake and frames, and a protocol of lines over TCP.
+//
+// This file shows:
+// - A room as a set of buffered channels, one per subscriber
+// - Fan-out with a select and a default case, so a full channel is skipped instead of waited on
+// - Dropping a subscriber that has fallen too far behind, and closing its channel to tell it
+// - A mutex shared by Publish and Subscribe, since both change the set
+package examples
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Room fans each message out to its subscribers.
+type Room struct {
+	mu   sync.Mutex
+	subs map[chan string]bool
+}
+
+// NewRoom returns a room with no subscribers.
+func NewRoom() *Room { return &Room{subs: make(map[chan string]bool)} }
+
+// Subscribe returns a channel that gets the room's messages, and has room
+// for queue of them. The channel is closed if its reader falls further
+// behind than that.
+func (r *Room) Subscribe(queue int) <-chan string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ch := make(chan string, queue)
+	r.subs[ch] = true
+	return ch
+}
+
+// Publish sends msg to every subscriber that has room for it, drops those
+// that do not, and returns how many it dropped.
+func (r *Room) Publish(msg string) (dropped int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for ch := range r.subs {
+		select {
+		case ch <- msg:
+		default: // full: the reader is too slow, and the room will not wait
+			delete(r.subs, ch)
+			close(ch)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// DemonstrateFanOut publishes to a reader that keeps up and one that does
+// not.
+func DemonstrateFanOut() {
+	fmt.Println("\n=== Fan-out ===")
+	room := NewRoom()
+	fast := room.Subscribe(2)
+	slow := room.Subscribe(2)
+	var got []string
+	for i := 1; i <= 4; i++ {
+		if n := room.Publish(fmt.Sprint("message ", i)); n > 0 {
+			fmt.Printf("message %d: dropped %d slow subscriber\n", i, n)
+		}
+		got = append(got, <-fast)
+	}
+	fmt.Println("fast got:", got)
+	var late []string
+	for m := range slow {
+		late = append(late, m)
+	}
+	fmt.Println("slow got:", late, "then its channel closed")
+}
@@ -0,0 +1,53 @@
+package examples
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateFanOut(t *testing.T) {
+	DemonstrateFanOut()
+}
+
+func TestRoomPublish(t *testing.T) {
+	room := NewRoom()
+	a, b := room.Subscribe(1), room.Subscribe(1)
+	assert.Equal(t, 0, room.Publish("one"))
+	assert.Equal(t, "one", <-a)
+	assert.Equal(t, 1, room.Publish("two"), "b never read")
+	assert.Equal(t, "two", <-a)
+	assert.Equal(t, []string{"one"}, drain(b), "what b had room for, then closed")
+	assert.Equal(t, 0, room.Publish("three"))
+}
+
+func drain(ch <-chan string) []string {
+	var got []string
+	for m := range ch {
+		got = append(got, m)
+	}
+	return got
+}
+
+func TestRoomConcurrent(t *testing.T) {
+	room := NewRoom()
+	var wg sync.WaitGroup
+	readers := make([]<-chan string, 10)
+	for i := range readers {
+		readers[i] = room.Subscribe(100)
+	}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				room.Publish("x")
+			}
+		}()
+	}
+	wg.Wait()
+	for _, r := range readers {
+		assert.Len(t, r, 100)
+	}
+}
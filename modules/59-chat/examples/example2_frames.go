@@ -0,0 +1,96 @@
+package examples
+
+// This file shows:
+// - The WebSocket handshake: Sec-WebSocket-Accept is the SHA-1 of the client's key and a fixed GUID
+// - A frame's header: FIN, the opcode, the mask bit, and a length in 7, 16, or 64 bits
+// - Masking: a client XORs its payload with 4 random bytes, and sends them first
+// - Reading a frame back with encoding/binary
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// AcceptKey answers a client's Sec-WebSocket-Key, as RFC 6455 says.
+func AcceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// EncodeFrame returns a final frame of opcode op carrying payload, masked
+// with mask if it is 4 bytes long, as a client's frames must be.
+func EncodeFrame(op byte, payload, mask []byte) []byte {
+	b := []byte{0x80 | op}
+	var maskBit byte
+	if len(mask) == 4 {
+		maskBit = 0x80
+	}
+	switch n := len(payload); {
+	case n < 126:
+		b = append(b, maskBit|byte(n))
+	case n < 1<<16:
+		b = binary.BigEndian.AppendUint16(append(b, maskBit|126), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint64(append(b, maskBit|127), uint64(n))
+	}
+	if maskBit == 0 {
+		return append(b, payload...)
+	}
+	b = append(b, mask...)
+	for i, c := range payload {
+		b = append(b, c^mask[i%4])
+	}
+	return b
+}
+
+// DecodeFrame reads the frame at the start of b, and returns its FIN bit,
+// opcode, and unmasked payload.
+func DecodeFrame(b []byte) (fin bool, op byte, payload []byte, err error) {
+	short := errors.New("short frame")
+	if len(b) < 2 {
+		return false, 0, nil, short
+	}
+	fin, op = b[0]&0x80 != 0, b[0]&0x0F
+	masked := b[1]&0x80 != 0
+	n, b := uint64(b[1]&0x7F), b[2:]
+	switch {
+	case n == 126 && len(b) >= 2:
+		n, b = uint64(binary.BigEndian.Uint16(b)), b[2:]
+	case n == 127 && len(b) >= 8:
+		n, b = binary.BigEndian.Uint64(b), b[8:]
+	case n >= 126:
+		return false, 0, nil, short
+	}
+	var mask []byte
+	if masked {
+		if len(b) < 4 {
+			return false, 0, nil, short
+		}
+		mask, b = b[:4], b[4:]
+	}
+	if uint64(len(b)) < n {
+		return false, 0, nil, short
+	}
+	payload = make([]byte, n)
+	copy(payload, b)
+	for i := range payload {
+		if masked {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return fin, op, payload, nil
+}
+
+// DemonstrateFrames prints a handshake answer, and a frame from each side.
+func DemonstrateFrames() {
+	fmt.Println("\n=== WebSocket frames ===")
+	fmt.Println("key dGhlIHNhbXBsZSBub25jZQ== is answered", AcceptKey("dGhlIHNhbXBsZSBub25jZQ=="))
+	fmt.Printf("server sends Hello: % x\n", EncodeFrame(0x1, []byte("Hello"), nil))
+	masked := EncodeFrame(0x1, []byte("Hello"), []byte{0x37, 0xfa, 0x21, 0x3d})
+	fmt.Printf("client sends Hello: % x\n", masked)
+	_, op, payload, err := DecodeFrame(masked)
+	fmt.Printf("decoded: opcode %d, %q, %v\n", op, payload, err)
+}
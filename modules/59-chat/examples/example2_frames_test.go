@@ -0,0 +1,36 @@
+package examples
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateFrames(t *testing.T) {
+	DemonstrateFrames()
+}
+
+func TestEncodeFrameRFC(t *testing.T) {
+	// The examples of RFC 6455, section 5.7.
+	assert.Equal(t, []byte{0x81, 0x05, 0x48, 0x65, 0x6c, 0x6c, 0x6f}, EncodeFrame(0x1, []byte("Hello"), nil))
+	assert.Equal(t, []byte{0x81, 0x85, 0x37, 0xfa, 0x21, 0x3d, 0x7f, 0x9f, 0x4d, 0x51, 0x58},
+		EncodeFrame(0x1, []byte("Hello"), []byte{0x37, 0xfa, 0x21, 0x3d}))
+	assert.Equal(t, []byte{0x82, 0x7E, 0x01, 0x00}, EncodeFrame(0x2, make([]byte, 256), nil)[:4])
+}
+
+func TestDecodeFrame(t *testing.T) {
+	for _, n := range []int{0, 125, 126, 300, 70000} {
+		payload := bytes.Repeat([]byte("ab"), n)[:n]
+		for _, mask := range [][]byte{nil, {1, 2, 3, 4}} {
+			fin, op, got, err := DecodeFrame(EncodeFrame(0x2, payload, mask))
+			require.NoError(t, err, n)
+			assert.True(t, fin)
+			assert.Equal(t, byte(0x2), op)
+			assert.Equal(t, payload, got, "%d bytes, mask %v", n, mask)
+		}
+	}
+	_, _, _, err := DecodeFrame([]byte{0x81, 0x05, 'H'})
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package examples
+
+// This file shows:
+// - A protocol of lines, the simplest there is: nc and telnet speak it
+// - bufio.Scanner with a limit on the length of a line, so a client cannot send one without end
+// - Trimming the "\r" telnet sends before "\n"
+// - net.Pipe, an in-memory connection, to try a protocol without a port
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// ServeLines reads lines from rw, and writes handle's answer to each,
+// until rw is closed or handle returns "". Lines longer than max bytes
+// are an error.
+func ServeLines(rw io.ReadWriter, max int, handle func(line string) string) error {
+	sc := bufio.NewScanner(rw)
+	sc.Buffer(make([]byte, 0, min(256, max)), max)
+	for sc.Scan() {
+		answer := handle(strings.TrimSuffix(sc.Text(), "\r"))
+		if answer == "" {
+			return nil
+		}
+		if _, err := io.WriteString(rw, answer+"\r\n"); err != nil {
+			return err
+		}
+	}
+	err := sc.Err()
+	if errors.Is(err, bufio.ErrTooLong) {
+		io.WriteString(rw, "line too long\r\n")
+	}
+	return err
+}
+
+// DemonstrateLines talks to ServeLines over net.Pipe.
+func DemonstrateLines() {
+	fmt.Println("\n=== A protocol of lines ===")
+	server, client := net.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		defer server.Close()
+		done <- ServeLines(server, 64, func(line string) string {
+			if line == "/quit" {
+				return ""
+			}
+			return "you said: " + strings.ToUpper(line)
+		})
+	}()
+	r := bufio.NewReader(client)
+	for _, line := range []string{"hello", "from telnet\r"} {
+		fmt.Fprintf(client, "%s\n", line)
+		answer, _ := r.ReadString('\n')
+		fmt.Printf("%q\n", answer)
+	}
+	fmt.Fprintln(client, "/quit")
+	fmt.Println("server stopped:", <-done)
+	client.Close()
+}
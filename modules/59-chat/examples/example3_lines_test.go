@@ -0,0 +1,45 @@
+package examples
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateLines(t *testing.T) {
+	DemonstrateLines()
+}
+
+func echo(line string) string { return "> " + line }
+
+func TestServeLines(t *testing.T) {
+	server, client := net.Pipe()
+	done := make(chan error, 1)
+	go func() { done <- ServeLines(server, 64, echo) }()
+	r := bufio.NewReader(client)
+	for _, line := range []string{"a\n", "b\r\n", "\n"} {
+		io.WriteString(client, line)
+		got, err := r.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "> "+strings.TrimRight(line, "\r\n")+"\r\n", got)
+	}
+	client.Close()
+	assert.NoError(t, <-done, "the client hung up")
+}
+
+func TestServeLinesTooLong(t *testing.T) {
+	server, client := net.Pipe()
+	done := make(chan error, 1)
+	go func() { done <- ServeLines(server, 16, echo) }()
+	go io.WriteString(client, strings.Repeat("x", 100)+"\n")
+	got, err := bufio.NewReader(client).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "line too long\r\n", got)
+	assert.ErrorIs(t, <-done, bufio.ErrTooLong)
+	client.Close()
+}
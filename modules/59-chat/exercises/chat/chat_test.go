@@ -0,0 +1,383 @@
+package chat
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// peer is a simulated client. A goroutine reads its lines into a channel,
+// so that a test can wait for a line, and give up.
+type peer struct {
+	t     *testing.T
+	conn  Conn
+	lines chan string
+	done  chan struct{}
+}
+
+func newPeer(t *testing.T, conn Conn) *peer {
+	p := &peer{t: t, conn: conn, lines: make(chan string, 1024), done: make(chan struct{})}
+	go func() {
+		defer close(p.lines)
+		for {
+			line, err := conn.ReadLine()
+			if err != nil {
+				return
+			}
+			select {
+			case p.lines <- line:
+			case <-p.done:
+				return
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		close(p.done)
+		conn.Close()
+	})
+	require.True(t, strings.HasPrefix(p.next(), "* welcome, guest"))
+	return p
+}
+
+func (p *peer) send(line string) {
+	p.t.Helper()
+	require.NoError(p.t, p.conn.WriteLine(line))
+}
+
+// next returns the next line the peer gets.
+func (p *peer) next() string {
+	p.t.Helper()
+	select {
+	case line, ok := <-p.lines:
+		require.True(p.t, ok, "the connection closed")
+		return line
+	case <-time.After(5 * time.Second):
+		p.t.Fatal("no line in 5s")
+		return ""
+	}
+}
+
+// waitFor skips lines until want.
+func (p *peer) waitFor(want string) {
+	p.t.Helper()
+	for {
+		if p.next() == want {
+			return
+		}
+	}
+}
+
+// server serves h on TCP and on WebSocket, and stops both when the test
+// ends.
+type server struct {
+	tcp string // host:port
+	ws  string // ws://host:port/chat
+}
+
+func serve(t *testing.T, h *Hub) server {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- h.ServeTCP(ctx, ln) }()
+
+	mux := http.NewServeMux()
+	mux.Handle("/chat", h)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-stopped)
+		h.Close()
+		srv.Close()
+	})
+	return server{tcp: ln.Addr().String(), ws: "ws" + strings.TrimPrefix(srv.URL, "http") + "/chat"}
+}
+
+func (s server) dialTCP(t *testing.T) *peer {
+	conn, err := net.Dial("tcp", s.tcp)
+	require.NoError(t, err)
+	return newPeer(t, NewLineConn(conn))
+}
+
+func (s server) dialWS(t *testing.T) *peer {
+	ws, err := DialWebSocket(context.Background(), s.ws)
+	require.NoError(t, err)
+	return newPeer(t, ws)
+}
+
+func TestHubFanOut(t *testing.T) {
+	h := NewHub()
+	a, b, c := h.Connect(), h.Connect(), h.Connect()
+	require.NoError(t, h.Join(a, "lobby"))
+	require.NoError(t, h.Join(b, "lobby"))
+	require.NoError(t, h.Join(c, "kitchen"))
+	require.NoError(t, h.Say(a, "hi"))
+	assert.ErrorIs(t, h.Say(h.Connect(), "anyone?"), ErrNoRoom)
+	h.Close()
+
+	var got [3][]string
+	for i, cl := range []*Client{a, b, c} {
+		for m := range cl.Messages() {
+			got[i] = append(got[i], m.String())
+		}
+	}
+	assert.Equal(t, []string{"[lobby] * guest1 joined", "[lobby] * guest2 joined", "[lobby] guest1: hi"}, got[0][:3])
+	assert.Equal(t, []string{"[lobby] * guest2 joined", "[lobby] guest1: hi"}, got[1][:2])
+	assert.Equal(t, []string{"[kitchen] * guest3 joined"}, got[2])
+	assert.ErrorIs(t, h.Join(a, "lobby"), ErrGone)
+}
+
+func TestHubDropsSlowClient(t *testing.T) {
+	h := NewHub()
+	slow, fast := h.Connect(), h.Connect()
+	require.NoError(t, h.Join(slow, "lobby"))
+	require.NoError(t, h.Join(fast, "lobby"))
+	var got []string
+	for i := 0; i < 2*QueueSize; i++ {
+		require.NoError(t, h.Say(fast, fmt.Sprint(i)))
+		got = append(got, (<-fast.Messages()).String())
+	}
+	assert.Contains(t, got, "[lobby] * guest1 left: too slow")
+	assert.Equal(t, []string{"guest2"}, h.Who("lobby"))
+	n := 0
+	for range slow.Messages() {
+		n++
+	}
+	assert.Equal(t, QueueSize, n, "what it had room for, then its channel closed")
+}
+
+func TestChatTCPAndWebSocket(t *testing.T) {
+	leaktest.Check(t)
+	s := serve(t, NewHub())
+	alice, bob := s.dialTCP(t), s.dialWS(t)
+
+	alice.send("hello?")
+	assert.Equal(t, "* "+ErrNoRoom.Error(), alice.next())
+	alice.send("/nick alice")
+	assert.Equal(t, "* you are alice", alice.next())
+	alice.send("/join lobby")
+	alice.waitFor("[lobby] * alice joined")
+	bob.send("/join lobby")
+	bob.waitFor("[lobby] * guest2 joined")
+	alice.waitFor("[lobby] * guest2 joined")
+
+	bob.send("hi from a browser")
+	alice.waitFor("[lobby] guest2: hi from a browser")
+	alice.send("hi from a terminal")
+	bob.waitFor("[lobby] alice: hi from a terminal")
+	bob.send("/who")
+	bob.waitFor("* in lobby: alice, guest2")
+	bob.send("/nick bob")
+	alice.waitFor("[lobby] * guest2 is now bob")
+	bob.send("/quit")
+	alice.waitFor("[lobby] * bob left")
+}
+
+func TestChatManyClients(t *testing.T) {
+	leaktest.Check(t)
+	const clients, each = 20, 10
+	h := NewHub()
+	s := serve(t, h)
+	peers := make([]*peer, clients)
+	for i := range peers {
+		if i%2 == 0 {
+			peers[i] = s.dialTCP(t)
+		} else {
+			peers[i] = s.dialWS(t)
+		}
+		peers[i].send(fmt.Sprintf("/nick p%02d", i))
+		peers[i].waitFor(fmt.Sprintf("* you are p%02d", i))
+		peers[i].send("/join lobby")
+	}
+	for deadline := time.Now().Add(5 * time.Second); len(h.Who("lobby")) < clients; time.Sleep(time.Millisecond) {
+		require.True(t, time.Now().Before(deadline), "not everyone joined")
+	}
+
+	// Each client says its lines as a person would, one after it sees
+	// the one before, while the others talk. It must get every message,
+	// and each sender's in the order sent.
+	var wg sync.WaitGroup
+	errs := make(chan error, clients)
+	for i, p := range peers {
+		i, p := i, p
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := p.talk(fmt.Sprintf("p%02d", i), each, clients*each); err != nil {
+				errs <- fmt.Errorf("p%02d: %w", i, err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// talk says n lines, "message 0" to "message n-1", each after seeing the
+// one before, and reads until it has seen total messages, checking that
+// each sender's come in order. It runs on a goroutine of its own, so it
+// returns errors instead of failing the test.
+func (p *peer) talk(name string, n, total int) error {
+	next := make(map[string]int)
+	for said, seen := 0, 0; seen < total; {
+		if said < n && next[name] == said {
+			if err := p.conn.WriteLine(fmt.Sprintf("message %d", said)); err != nil {
+				return err
+			}
+			said++
+		}
+		var line string
+		select {
+		case l, ok := <-p.lines:
+			if !ok {
+				return errors.New("the connection closed")
+			}
+			line = l
+		case <-time.After(5 * time.Second):
+			return fmt.Errorf("no line in 5s, after %d messages", seen)
+		}
+		if strings.Contains(line, "] * ") {
+			continue // a notice
+		}
+		var from string
+		var i int
+		if _, err := fmt.Sscanf(line, "[lobby] %s message %d", &from, &i); err != nil {
+			return fmt.Errorf("%q: %w", line, err)
+		}
+		from = strings.TrimSuffix(from, ":")
+		if i != next[from] {
+			return fmt.Errorf("%q out of order: want message %d", line, next[from])
+		}
+		next[from]++
+		seen++
+	}
+	return nil
+}
+
+func TestChatServeStops(t *testing.T) {
+	leaktest.Check(t)
+	h := NewHub()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- h.ServeTCP(ctx, ln) }()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	_, err = r.ReadString('\n')
+	require.NoError(t, err)
+
+	cancel()
+	select {
+	case err := <-stopped:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeTCP is still waiting for a session")
+	}
+	_, err = r.ReadString('\n')
+	assert.ErrorIs(t, err, io.EOF, "the session closed the connection")
+}
+
+func TestAcceptKey(t *testing.T) {
+	// The example of RFC 6455, section 1.3.
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", AcceptKey("dGhlIHNhbXBsZSBub25jZQ=="))
+}
+
+func TestUpgradeRejects(t *testing.T) {
+	srv := httptest.NewServer(NewHub())
+	t.Cleanup(srv.Close)
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "8")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUpgradeRequired, resp.StatusCode)
+	assert.Equal(t, "13", resp.Header.Get("Sec-WebSocket-Version"))
+}
+
+// pipe returns a server and a client WebSocket joined by net.Pipe.
+func pipe() (server, client *WebSocket) {
+	a, b := net.Pipe()
+	return &WebSocket{conn: a, r: bufio.NewReader(a)}, &WebSocket{conn: b, r: bufio.NewReader(b), client: true}
+}
+
+func TestWebSocketFrames(t *testing.T) {
+	server, client := pipe()
+	go func() {
+		// "hello, world" in two fragments, with a ping between them.
+		frame := func(fin bool, op byte, payload string) {
+			client.wmu.Lock()
+			defer client.wmu.Unlock()
+			head := op
+			if fin {
+				head |= 0x80
+			}
+			key := [4]byte{1, 2, 3, 4}
+			buf := []byte{head, 0x80 | byte(len(payload))}
+			buf = append(buf, key[:]...)
+			for i := range payload {
+				buf = append(buf, payload[i]^key[i%4])
+			}
+			client.conn.Write(buf)
+		}
+		frame(false, opText, "hello, ")
+		frame(true, opPing, "are you there")
+		frame(true, opContinuation, "world")
+		client.WriteLine(strings.Repeat("x", 300)) // a 16-bit length
+	}()
+	pong := make(chan string, 1)
+	go func() {
+		_, op, payload, err := client.readFrame()
+		if err == nil && op == opPong {
+			pong <- string(payload)
+		}
+	}()
+
+	line, err := server.ReadLine()
+	require.NoError(t, err)
+	assert.Equal(t, "hello, world", line)
+	assert.Equal(t, "are you there", <-pong)
+	line, err = server.ReadLine()
+	require.NoError(t, err)
+	assert.Len(t, line, 300)
+
+	go client.Close()
+	_, err = server.ReadLine()
+	assert.ErrorIs(t, err, io.EOF)
+	server.Close()
+}
+
+func TestWebSocketUnmaskedFrame(t *testing.T) {
+	server, client := pipe()
+	defer client.Close()
+	defer server.Close()
+	go client.conn.Write([]byte{0x80 | opText, 2, 'h', 'i'}) // a client that does not mask
+	_, err := server.ReadLine()
+	assert.True(t, errors.Is(err, ErrProtocol), err)
+}
@@ -0,0 +1,311 @@
+// Package chat is the core of a chat server: a hub of rooms that fans each
+// message out to everyone in the room, and two frontends, lines of text
+// over TCP and WebSocket, that share one hub. A client on either can talk
+// to a client on the other.
+//
+// The hub asks a Names for the names clients go by, and keeps the recent
+// messages of each room in a Log. Both are interfaces, left to the
+// exercises: without them, anyone may take any name, and a room forgets.
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Message is a line of chat.
+type Message struct {
+	Room string // "" for a reply to one client
+	From string // "" for a notice from the server
+	Text string
+}
+
+// String formats m as clients see it:
+//
+//	[lobby] alice: hello
+//	[lobby] * bob joined
+//	* name taken: alice
+func (m Message) String() string {
+	switch {
+	case m.Room == "":
+		return "* " + m.Text
+	case m.From == "":
+		return "[" + m.Room + "] * " + m.Text
+	default:
+		return "[" + m.Room + "] " + m.From + ": " + m.Text
+	}
+}
+
+// Names hands out the names clients go by. The hub calls it with its lock
+// held, one call at a time.
+type Names interface {
+	// Claim takes name, or returns why it cannot be had.
+	Claim(name string) error
+	// Release gives back a name taken with Claim.
+	Release(name string)
+}
+
+// Log keeps the recent messages of each room. The hub calls it with its
+// lock held, so a client that joins gets the room's history, then every
+// message after it, with none lost or repeated between the two.
+type Log interface {
+	Append(m Message)
+	Recent(room string) []Message
+}
+
+// QueueSize is how many messages a client may fall behind before the hub
+// drops it. One slow client must not hold up a room.
+const QueueSize = 64
+
+// ErrNoRoom is returned by Say for a client that has joined no room.
+var ErrNoRoom = errors.New("join a room first: /join <room>")
+
+// ErrGone is returned for a client the hub has dropped.
+var ErrGone = errors.New("disconnected")
+
+// Client is a client connected to a Hub.
+type Client struct {
+	name string       // guarded by the hub's mu
+	room string       // guarded by the hub's mu
+	out  chan Message // closed when the hub drops the client
+}
+
+// Messages returns the messages for c. The channel is closed when c is
+// disconnected.
+func (c *Client) Messages() <-chan Message { return c.out }
+
+// Hub is a set of rooms, and the clients in them. Set Names and Log
+// before the first client connects.
+type Hub struct {
+	Names Names // nil lets anyone take any name
+	Log   Log   // nil keeps no history
+
+	mu      sync.Mutex
+	clients map[*Client]bool
+	rooms   map[string]map[*Client]bool
+	guests  int
+}
+
+// NewHub returns an empty hub.
+func NewHub() *Hub {
+	return &Hub{clients: make(map[*Client]bool), rooms: make(map[string]map[*Client]bool)}
+}
+
+// Connect adds a client under a guest name, in no room.
+func (h *Hub) Connect() *Client {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	c := &Client{out: make(chan Message, QueueSize)}
+	for {
+		h.guests++
+		c.name = fmt.Sprintf("guest%d", h.guests)
+		if h.claim(c.name) == nil {
+			break
+		}
+	}
+	h.clients[c] = true
+	return c
+}
+
+// Disconnect removes c from its room and from the hub, and closes its
+// channel. Disconnecting a client twice does nothing.
+func (h *Hub) Disconnect(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(c, "left")
+}
+
+// Close disconnects every client.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.clients {
+		h.remove(c, "left: the server is shutting down")
+	}
+}
+
+// Name returns the name c goes by.
+func (h *Hub) Name(c *Client) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return c.name
+}
+
+// Rename gives c another name, if Names lets it have it, and tells its
+// room.
+func (h *Hub) Rename(c *Client, name string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.clients[c] {
+		return ErrGone
+	}
+	old := c.name
+	if name == old {
+		return nil
+	}
+	// Release first, so that "alice" may become "Alice" under Names that
+	// ignore case. No one can take the old name in between: the lock is
+	// held.
+	h.release(old)
+	if err := h.claim(name); err != nil {
+		h.claim(old)
+		return err
+	}
+	c.name = name
+	if c.room != "" {
+		h.broadcast(Message{Room: c.room, Text: old + " is now " + name})
+	}
+	return nil
+}
+
+// Join moves c to room, leaving the room it was in. It sends c the room's
+// history from the Log, then tells the room.
+func (h *Hub) Join(c *Client, room string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.clients[c] {
+		return ErrGone
+	}
+	if room == c.room {
+		return nil
+	}
+	h.leave(c, "left")
+	if h.Log != nil {
+		for _, m := range h.Log.Recent(room) {
+			if !h.deliver(c, m) {
+				return ErrGone
+			}
+		}
+	}
+	c.room = room
+	if h.rooms[room] == nil {
+		h.rooms[room] = make(map[*Client]bool)
+	}
+	h.rooms[room][c] = true
+	h.broadcast(Message{Room: room, Text: c.name + " joined"})
+	return nil
+}
+
+// Say sends text from c to everyone in c's room, c included, and adds it
+// to the Log.
+func (h *Hub) Say(c *Client, text string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.clients[c] {
+		return ErrGone
+	}
+	if c.room == "" {
+		return ErrNoRoom
+	}
+	m := Message{Room: c.room, From: c.name, Text: text}
+	if h.Log != nil {
+		h.Log.Append(m)
+	}
+	h.broadcast(m)
+	return nil
+}
+
+// Tell sends text to c alone.
+func (h *Hub) Tell(c *Client, text string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.clients[c] {
+		h.deliver(c, Message{Text: text})
+	}
+}
+
+// Who returns the names of the clients in room, sorted.
+func (h *Hub) Who(room string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var names []string
+	for c := range h.rooms[room] {
+		names = append(names, c.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Room returns the room c is in, or "".
+func (h *Hub) Room(c *Client) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return c.room
+}
+
+// The methods below are called with h.mu held.
+
+func (h *Hub) claim(name string) error {
+	if h.Names == nil {
+		if strings.TrimSpace(name) == "" {
+			return errors.New("a name cannot be blank")
+		}
+		return nil
+	}
+	return h.Names.Claim(name)
+}
+
+func (h *Hub) release(name string) {
+	if h.Names != nil {
+		h.Names.Release(name)
+	}
+}
+
+// broadcast fans m out to its room. A client whose queue is full is
+// dropped, not waited for.
+func (h *Hub) broadcast(m Message) {
+	var slow []*Client
+	for c := range h.rooms[m.Room] {
+		if !h.send(c, m) {
+			slow = append(slow, c)
+		}
+	}
+	for _, c := range slow {
+		h.remove(c, "left: too slow")
+	}
+}
+
+// deliver sends m to c alone, and drops c if its queue is full.
+func (h *Hub) deliver(c *Client, m Message) bool {
+	if !h.send(c, m) {
+		h.remove(c, "left: too slow")
+		return false
+	}
+	return true
+}
+
+func (h *Hub) send(c *Client, m Message) bool {
+	select {
+	case c.out <- m:
+		return true
+	default:
+		return false
+	}
+}
+
+// leave takes c out of its room, and tells the room why.
+func (h *Hub) leave(c *Client, why string) {
+	if c.room == "" {
+		return
+	}
+	room := c.room
+	delete(h.rooms[room], c)
+	if len(h.rooms[room]) == 0 {
+		delete(h.rooms, room)
+	}
+	c.room = ""
+	h.broadcast(Message{Room: room, Text: c.name + " " + why})
+}
+
+func (h *Hub) remove(c *Client, why string) {
+	if !h.clients[c] {
+		return
+	}
+	delete(h.clients, c)
+	h.leave(c, why)
+	h.release(c.name)
+	close(c.out)
+}
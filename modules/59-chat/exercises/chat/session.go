@@ -0,0 +1,120 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+)
+
+// Conn is a connection to one client, as lines of text. The TCP frontend
+// and the WebSocket frontend each implement it, and Serve runs the same
+// session on both.
+type Conn interface {
+	// ReadLine returns the next line, without its line ending, or io.EOF
+	// when the client has gone.
+	ReadLine() (string, error)
+	// WriteLine sends one line.
+	WriteLine(line string) error
+	// Close closes the connection. A ReadLine blocked on it returns.
+	Close() error
+}
+
+// MaxLine is the longest line a client may send, in bytes.
+const MaxLine = 4096
+
+// Help is the reply to /help.
+const Help = "commands: /join <room>, /nick <name>, /who, /quit"
+
+// Serve connects a client on conn to the hub, and runs its session: it
+// reads commands and chat from conn, and writes the client's messages to
+// it, until the client quits, conn fails, the hub drops the client, or
+// ctx is done. It closes conn.
+//
+// A client says /join <room> to enter a room, /nick <name> to go by
+// another name, /who to list who is in its room, and /quit to leave. Any
+// other line is said to the room.
+func (h *Hub) Serve(ctx context.Context, conn Conn) error {
+	c := h.Connect()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	// The writer is the only goroutine that writes to conn. When the hub
+	// closes c's channel, or a write fails, it closes conn, so that
+	// ReadLine below returns. What the hub sends after that fills c's
+	// queue, and the hub does not wait on a full queue.
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		defer conn.Close()
+		for m := range c.Messages() {
+			if err := conn.WriteLine(m.String()); err != nil {
+				return
+			}
+		}
+	}()
+
+	h.Tell(c, "welcome, "+h.Name(c)+"; "+Help)
+	var err error
+	for {
+		var line string
+		if line, err = conn.ReadLine(); err != nil {
+			break
+		}
+		if !h.command(c, line) {
+			break
+		}
+	}
+	h.Disconnect(c)
+	<-written
+	if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) || ctx.Err() != nil {
+		err = nil
+	}
+	return err
+}
+
+// command runs one line from c, and reports whether the session goes on.
+func (h *Hub) command(c *Client, line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return true
+	}
+	if !strings.HasPrefix(line, "/") {
+		if err := h.Say(c, line); err != nil {
+			h.Tell(c, err.Error())
+		}
+		return true
+	}
+	cmd, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+	var err error
+	switch cmd {
+	case "/join":
+		if arg == "" {
+			err = errors.New("usage: /join <room>")
+		} else {
+			err = h.Join(c, arg)
+		}
+	case "/nick":
+		if err = h.Rename(c, arg); err == nil {
+			h.Tell(c, "you are "+h.Name(c))
+		}
+	case "/who":
+		if room := h.Room(c); room == "" {
+			err = ErrNoRoom
+		} else {
+			h.Tell(c, "in "+room+": "+strings.Join(h.Who(room), ", "))
+		}
+	case "/help":
+		h.Tell(c, Help)
+	case "/quit":
+		return false
+	default:
+		err = errors.New("unknown command " + cmd + "; " + Help)
+	}
+	if err != nil {
+		h.Tell(c, err.Error())
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package chat
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+)
+
+// ServeTCP accepts connections on ln, and serves each as lines of text,
+// as nc or telnet send them, until ctx is done. It closes ln, and returns
+// once every session it started has ended.
+func (h *Hub) ServeTCP(ctx context.Context, ln net.Listener) error {
+	stop := context.AfterFunc(ctx, func() { ln.Close() })
+	defer stop()
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.Serve(ctx, NewLineConn(conn))
+		}()
+	}
+}
+
+// LineConn is a Conn of lines of text, ended by "\n" or "\r\n", over a
+// stream such as a TCP connection.
+type LineConn struct {
+	conn net.Conn
+	sc   *bufio.Scanner
+}
+
+// NewLineConn returns a LineConn over conn.
+func NewLineConn(conn net.Conn) *LineConn {
+	sc := bufio.NewScanner(conn)
+	sc.Buffer(make([]byte, 0, 512), MaxLine)
+	return &LineConn{conn: conn, sc: sc}
+}
+
+// ReadLine returns the next line. A line longer than MaxLine is an error.
+func (c *LineConn) ReadLine() (string, error) {
+	if !c.sc.Scan() {
+		if err := c.sc.Err(); err != nil {
+			if errors.Is(err, bufio.ErrTooLong) {
+				return "", errors.New("line too long")
+			}
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return strings.TrimSuffix(c.sc.Text(), "\r"), nil
+}
+
+// WriteLine writes line and "\r\n", as telnet expects.
+func (c *LineConn) WriteLine(line string) error {
+	_, err := c.conn.Write([]byte(line + "\r\n"))
+	return err
+}
+
+// Close closes the connection.
+func (c *LineConn) Close() error { return c.conn.Close() }
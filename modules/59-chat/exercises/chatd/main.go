@@ -0,0 +1,101 @@
+// Command chatd runs a chat server: lines of text on a TCP port, for nc
+// and telnet, and WebSockets on an HTTP port, with a page for browsers.
+// Both reach the same rooms.
+//
+//	go run ./modules/59-chat/exercises/chatd -tcp :4000 -http :8080
+//	nc localhost 4000
+//	open http://localhost:8080/
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/59-chat/exercises"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/59-chat/exercises/chat"
+)
+
+// page is a chat client in one page of HTML, for the WebSocket at /chat.
+const page = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>chatd</title></head>
+<body style="font-family: monospace">
+<pre id="log" style="height: 80vh; overflow-y: scroll"></pre>
+<form id="form"><input id="line" size="80" autofocus placeholder="/join lobby"></form>
+<script>
+const log = document.getElementById("log");
+const ws = new WebSocket("ws://" + location.host + "/chat");
+ws.onmessage = (e) => { log.textContent += e.data + "\n"; log.scrollTop = log.scrollHeight; };
+ws.onclose = () => { log.textContent += "* disconnected\n"; };
+document.getElementById("form").onsubmit = (e) => {
+	e.preventDefault();
+	const line = document.getElementById("line");
+	ws.send(line.value);
+	line.value = "";
+};
+</script>
+</body>
+</html>
+`
+
+func main() {
+	tcpAddr := flag.String("tcp", ":4000", "address for clients sending lines of text")
+	httpAddr := flag.String("http", ":8080", "address for browsers and WebSocket clients")
+	history := flag.Int("history", 20, "messages of each room shown to those who join")
+	flag.Parse()
+
+	hub := chat.NewHub()
+	hub.Names = exercises.NewNicknames()
+	hub.Log = exercises.NewHistory(*history)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := run(ctx, hub, *tcpAddr, *httpAddr); err != nil {
+		fmt.Fprintln(os.Stderr, "chatd:", err)
+		os.Exit(1)
+	}
+}
+
+// run serves hub on both addresses until ctx is done.
+func run(ctx context.Context, hub *chat.Hub, tcpAddr, httpAddr string) error {
+	ln, err := net.Listen("tcp", tcpAddr)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/chat", hub)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, page)
+	})
+	srv := &http.Server{Addr: httpAddr, Handler: mux}
+	srv.RegisterOnShutdown(hub.Close)
+
+	errs := make(chan error, 2)
+	go func() { errs <- hub.ServeTCP(ctx, ln) }()
+	go func() {
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			errs <- err
+			return
+		}
+		errs <- nil
+	}()
+	fmt.Printf("chatd: lines on %s, WebSockets on http://%s/\n", ln.Addr(), httpAddr)
+
+	select {
+	case err = <-errs:
+	case <-ctx.Done():
+	}
+	shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	srv.Shutdown(shutdown)
+	hub.Close()
+	return err
+}
@@ -0,0 +1,74 @@
+package exercises
+
+// EXERCISE: Milestone 1: give the chat hub nicknames, unique whatever their case, and fit to show.
+// The hub in package chat asks its Names for every name a client takes:
+// its guest name when it connects, and each /nick after that. Nicknames
+// is meant to let one client at a time have each name. But it tells
+// "Alice" from "alice", so two clients can look like one; it counts the
+// length of a name in bytes, so "Zoë" costs four; and it lets any
+// characters through, so a client can call itself "alice:" and put words
+// in alice's mouth. It also reads and writes its map without a lock. Run
+// the tests with -race.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MaxNameLen is the longest a nickname may be, in characters.
+const MaxNameLen = 16
+
+// ErrNameTaken is returned by Claim for a name someone already goes by.
+var ErrNameTaken = errors.New("name taken")
+
+// ErrBadName is returned by Claim for a name that is not allowed.
+var ErrBadName = errors.New("bad name")
+
+// Nicknames is a chat.Names that lets one client at a time go by each
+// name, whatever its case, and lets only names fit to show in a chat.
+type Nicknames struct {
+	mu    sync.Mutex
+	taken map[string]bool // by the name in lower case
+}
+
+// NewNicknames returns a Nicknames with every name free.
+func NewNicknames() *Nicknames {
+	return &Nicknames{taken: make(map[string]bool)}
+}
+
+// Claim takes name, or returns an error wrapping ErrBadName or
+// ErrNameTaken.
+func (n *Nicknames) Claim(name string) error {
+	if err := ValidName(name); err != nil {
+		return err
+	}
+	// BUG: "Alice" and "alice" are two names, but Release frees both as one
+	key := name
+	// BUG: not locked
+	if n.taken[key] {
+		return fmt.Errorf("%w: %s", ErrNameTaken, name)
+	}
+	n.taken[key] = true
+	return nil
+}
+
+// Release frees name for someone else.
+func (n *Nicknames) Release(name string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.taken, strings.ToLower(name))
+}
+
+// ValidName returns an error wrapping ErrBadName unless name is 1 to
+// MaxNameLen letters, digits, hyphens, and underscores.
+func ValidName(name string) error {
+	// BUG: len counts bytes; ë is two of them
+	if n := len(name); n == 0 || n > MaxNameLen {
+		return fmt.Errorf("%w: a name is 1 to %d characters", ErrBadName, MaxNameLen)
+	}
+	// BUG: spaces, colons, and "*" get through, and make names that pass for the chat's own lines
+	return nil
+}
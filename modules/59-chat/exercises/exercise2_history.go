@@ -0,0 +1,69 @@
+package exercises
+
+// EXERCISE: Milestone 2: give each chat room a history that those who join see first.
+// The hub in package chat adds every message said to its Log, and sends
+// a client that joins a room the room's Recent messages before anything
+// else. History is meant to keep the last Size messages of each room in
+// a ring. But it keeps one ring for all rooms; once the ring is full, it
+// returns the messages in the order they sit in it, not the order they
+// were said; it returns the ring itself, which later messages write
+// over; and a History of size 0 divides by zero.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"sync"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/59-chat/exercises/chat"
+)
+
+// History is a chat.Log that keeps the last Size messages of each room.
+type History struct {
+	Size int
+
+	mu    sync.Mutex
+	rooms map[string]*ring
+}
+
+// ring holds the last messages of a room. Once full, each new message
+// takes the place of the oldest, at next.
+type ring struct {
+	msgs []chat.Message
+	next int
+}
+
+// NewHistory returns a History that keeps the last size messages of each
+// room.
+func NewHistory(size int) *History {
+	return &History{Size: size, rooms: make(map[string]*ring)}
+}
+
+// Append adds m to the history of its room.
+func (h *History) Append(m chat.Message) {
+	// BUG: a size of 0 reaches the % below
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// BUG: every room shares one ring
+	r := h.rooms[""]
+	if r == nil {
+		r = &ring{}
+		h.rooms[""] = r
+	}
+	if len(r.msgs) < h.Size {
+		r.msgs = append(r.msgs, m)
+		return
+	}
+	r.msgs[r.next] = m
+	r.next = (r.next + 1) % h.Size
+}
+
+// Recent returns the history of room, oldest first.
+func (h *History) Recent(room string) []chat.Message {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	r := h.rooms[""]
+	if r == nil {
+		return nil
+	}
+	// BUG: the ring itself, in the order it is stored, not from the oldest
+	return r.msgs
+}
@@ -0,0 +1,111 @@
+package exercises
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/59-chat/exercises/chat"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/leaktest"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func msg(room string, i int) chat.Message {
+	return chat.Message{Room: room, From: "alice", Text: fmt.Sprint(i)}
+}
+
+// texts returns the text of each message.
+func texts(msgs []chat.Message) []string {
+	var s []string
+	for _, m := range msgs {
+		s = append(s, m.Text)
+	}
+	return s
+}
+
+func TestHistoryRecent(t *testing.T) {
+	r := seed.Rand(t, 3)
+	size := seed.Between(r, 3, 8)
+	h := NewHistory(size)
+	assert.Empty(t, h.Recent("lobby"))
+	var want []string
+	for i := 0; i < 3*size+1; i++ {
+		h.Append(msg("lobby", i))
+		want = append(want, fmt.Sprint(i))
+		if len(want) > size {
+			want = want[1:]
+		}
+		require.Equal(t, want, texts(h.Recent("lobby")), "after %d messages, with room for %d", i+1, size)
+	}
+}
+
+func TestHistoryRooms(t *testing.T) {
+	h := NewHistory(3)
+	for i := 0; i < 5; i++ {
+		h.Append(msg("lobby", i))
+	}
+	h.Append(msg("kitchen", 100))
+	assert.Equal(t, []string{"2", "3", "4"}, texts(h.Recent("lobby")))
+	assert.Equal(t, []string{"100"}, texts(h.Recent("kitchen")))
+	assert.Empty(t, h.Recent("garden"))
+}
+
+func TestHistoryCopy(t *testing.T) {
+	h := NewHistory(3)
+	h.Append(msg("lobby", 0))
+	h.Append(msg("lobby", 1))
+	recent := h.Recent("lobby")
+	for i := 2; i < 6; i++ {
+		h.Append(msg("lobby", i))
+	}
+	assert.Equal(t, []string{"0", "1"}, texts(recent), "later messages changed what Recent returned")
+	recent = h.Recent("lobby")
+	recent[0].Text = "edited"
+	assert.Equal(t, []string{"3", "4", "5"}, texts(h.Recent("lobby")), "the caller changed the history")
+}
+
+func TestHistoryZeroSize(t *testing.T) {
+	h := NewHistory(0)
+	assert.NotPanics(t, func() { h.Append(msg("lobby", 0)) })
+	assert.Empty(t, h.Recent("lobby"))
+}
+
+func TestChatHistory(t *testing.T) {
+	leaktest.Check(t)
+	h := chat.NewHub()
+	h.Names = NewNicknames()
+	h.Log = NewHistory(3)
+	connect := dial(t, h)
+
+	alice := connect()
+	alice.send("/nick alice")
+	alice.send("/join lobby")
+	for i := 1; i <= 5; i++ {
+		alice.send(fmt.Sprintf("message %d", i))
+	}
+	alice.waitFor("[lobby] alice: message 5")
+	alice.send("/join kitchen")
+	alice.send("in the kitchen")
+	alice.waitFor("[kitchen] alice: in the kitchen")
+
+	bob := connect()
+	bob.send("/join lobby")
+	assert.Equal(t, "[lobby] alice: message 3", bob.next(), "the last 3 messages, then the notice")
+	assert.Equal(t, "[lobby] alice: message 4", bob.next())
+	assert.Equal(t, "[lobby] alice: message 5", bob.next())
+	assert.Equal(t, "[lobby] * guest2 joined", bob.next())
+	bob.send("/join kitchen")
+	assert.Equal(t, "[kitchen] alice: in the kitchen", bob.next())
+	assert.Equal(t, "[kitchen] * guest2 joined", bob.next())
+	alice.waitFor("[kitchen] * guest2 joined")
+
+	bob.send("/nick bob")
+	alice.send("/nick Alice")
+	bob.send("hi")
+	alice.waitFor("[kitchen] bob: hi")
+	carol := connect()
+	carol.send("/join kitchen")
+	assert.Equal(t, "[kitchen] alice: in the kitchen", carol.next(), "history keeps the name a message was sent under")
+	assert.Equal(t, "[kitchen] bob: hi", carol.next())
+}
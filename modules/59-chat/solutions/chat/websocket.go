@@ -0,0 +1,341 @@
+package chat
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// websocketGUID is the constant RFC 6455 mixes into the handshake, so that
+// a server that answers it knows the protocol, and is not a plain HTTP
+// server echoing headers.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// The frame opcodes of RFC 6455, section 5.2. Those from 0x8 up are
+// control frames, which may come between the fragments of a message.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+// closeNormal is the payload of a close frame: status 1000, normal closure.
+var closeNormal = []byte{0x03, 0xE8}
+
+// ErrProtocol is returned by ReadLine for a frame RFC 6455 does not allow.
+var ErrProtocol = errors.New("websocket: protocol error")
+
+// AcceptKey returns the Sec-WebSocket-Accept a server answers the
+// Sec-WebSocket-Key of a handshake with.
+func AcceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// WebSocket is a Conn over a WebSocket, RFC 6455: each line is one text
+// message. It speaks as much of the protocol as a chat needs: text
+// messages, whole or in fragments, ping, pong, and close, and no
+// extensions.
+type WebSocket struct {
+	conn   net.Conn
+	r      *bufio.Reader
+	client bool // a client masks the frames it sends; a server must not
+
+	wmu    sync.Mutex // held while a frame is written
+	closed bool       // a close frame has been sent; guarded by wmu
+}
+
+// Upgrade answers a WebSocket handshake, and takes the connection over
+// from the HTTP server. It answers a request that is not a handshake with
+// an error status.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*WebSocket, error) {
+	if r.Method != http.MethodGet || !headerHas(r.Header, "Connection", "upgrade") || !headerHas(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "this is a WebSocket endpoint", http.StatusBadRequest)
+		return nil, fmt.Errorf("%w: not a handshake", ErrProtocol)
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "WebSocket version 13 only", http.StatusUpgradeRequired)
+		return nil, fmt.Errorf("%w: version %q", ErrProtocol, r.Header.Get("Sec-WebSocket-Version"))
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if k, err := base64.StdEncoding.DecodeString(key); err != nil || len(k) != 16 {
+		http.Error(w, "bad Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, fmt.Errorf("%w: key %q", ErrProtocol, key)
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "cannot take the connection over", http.StatusInternalServerError)
+		return nil, errors.New("websocket: the ResponseWriter is not an http.Hijacker")
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + AcceptKey(key) + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &WebSocket{conn: conn, r: rw.Reader}, nil
+}
+
+// headerHas reports whether a header of h lists token, in any case, as
+// "Connection: keep-alive, Upgrade" does.
+func headerHas(h http.Header, name, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DialWebSocket connects to the WebSocket at rawURL, such as
+// ws://localhost:8080/chat. It does not do TLS, so wss:// is not
+// supported.
+func DialWebSocket(ctx context.Context, rawURL string) (*WebSocket, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "ws" {
+		return nil, fmt.Errorf("websocket: %s: only ws:// URLs are supported", rawURL)
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "80")
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	// The handshake gives up when ctx is done.
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Unix(1, 0)) })
+	defer stop()
+
+	k := make([]byte, 16)
+	rand.Read(k)
+	key := base64.StdEncoding.EncodeToString(k)
+	req := &http.Request{
+		Method: http.MethodGet,
+		URL:    &url.URL{Path: u.Path, RawQuery: u.RawQuery},
+		Host:   u.Host,
+		Header: http.Header{
+			"Upgrade":               {"websocket"},
+			"Connection":            {"Upgrade"},
+			"Sec-WebSocket-Key":     {key},
+			"Sec-WebSocket-Version": {"13"},
+		},
+	}
+	if req.URL.Path == "" {
+		req.URL.Path = "/"
+	}
+	br := bufio.NewReader(conn)
+	resp, err := handshake(conn, br, req)
+	if err == nil && (resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != AcceptKey(key)) {
+		err = fmt.Errorf("websocket: %s: handshake answered %s", rawURL, resp.Status)
+	}
+	if err == nil && !stop() {
+		err = ctx.Err()
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &WebSocket{conn: conn, r: br, client: true}, nil
+}
+
+func handshake(conn net.Conn, br *bufio.Reader, req *http.Request) (*http.Response, error) {
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// ReadLine returns the next text message. It answers pings, and returns
+// io.EOF once the other side has closed.
+func (ws *WebSocket) ReadLine() (string, error) {
+	var msg []byte
+	started := false
+	for {
+		fin, op, payload, err := ws.readFrame()
+		if err != nil {
+			return "", err
+		}
+		switch op {
+		case opPing:
+			ws.writeFrame(opPong, payload)
+			continue
+		case opPong:
+			continue
+		case opClose:
+			ws.writeFrame(opClose, closeNormal)
+			return "", io.EOF
+		case opText, opBinary:
+			if started {
+				return "", fmt.Errorf("%w: a message inside a fragmented one", ErrProtocol)
+			}
+			started = true
+			msg = append(msg, payload...)
+		case opContinuation:
+			if !started {
+				return "", fmt.Errorf("%w: a continuation of nothing", ErrProtocol)
+			}
+			msg = append(msg, payload...)
+		default:
+			return "", fmt.Errorf("%w: opcode %#x", ErrProtocol, op)
+		}
+		if len(msg) > MaxLine {
+			return "", errors.New("line too long")
+		}
+		if fin {
+			return string(msg), nil
+		}
+	}
+}
+
+// readFrame reads one frame, and unmasks its payload.
+func (ws *WebSocket) readFrame() (fin bool, op byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err = io.ReadFull(ws.r, head[:]); err != nil {
+		return
+	}
+	fin = head[0]&0x80 != 0
+	op = head[0] & 0x0F
+	if head[0]&0x70 != 0 {
+		return fin, op, nil, fmt.Errorf("%w: reserved bits set", ErrProtocol)
+	}
+	// Clients mask every frame, and servers none: a proxy that caches
+	// cannot be fooled by a payload that looks like HTTP.
+	if masked := head[1]&0x80 != 0; masked == ws.client {
+		return fin, op, nil, fmt.Errorf("%w: masking", ErrProtocol)
+	}
+	n := uint64(head[1] & 0x7F)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(ws.r, ext[:]); err != nil {
+			return
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(ws.r, ext[:]); err != nil {
+			return
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+	if op >= opClose && (n > 125 || !fin) {
+		return fin, op, nil, fmt.Errorf("%w: control frame", ErrProtocol)
+	}
+	if n > MaxLine {
+		return fin, op, nil, errors.New("line too long")
+	}
+	var key [4]byte
+	if !ws.client {
+		if _, err = io.ReadFull(ws.r, key[:]); err != nil {
+			return
+		}
+	}
+	payload = make([]byte, n)
+	if _, err = io.ReadFull(ws.r, payload); err != nil {
+		return
+	}
+	if !ws.client {
+		for i := range payload {
+			payload[i] ^= key[i%4]
+		}
+	}
+	return fin, op, payload, nil
+}
+
+// WriteLine sends line as one text message.
+func (ws *WebSocket) WriteLine(line string) error {
+	return ws.writeFrame(opText, []byte(line))
+}
+
+// writeFrame sends one whole frame, masked if ws is a client. Nothing is
+// sent after a close frame.
+func (ws *WebSocket) writeFrame(op byte, payload []byte) error {
+	ws.wmu.Lock()
+	defer ws.wmu.Unlock()
+	if ws.closed {
+		return net.ErrClosed
+	}
+	if op == opClose {
+		ws.closed = true
+	}
+	buf := make([]byte, 0, 14+len(payload))
+	buf = append(buf, 0x80|op)
+	var mask byte
+	if ws.client {
+		mask = 0x80
+	}
+	switch n := len(payload); {
+	case n < 126:
+		buf = append(buf, mask|byte(n))
+	case n < 1<<16:
+		buf = binary.BigEndian.AppendUint16(append(buf, mask|126), uint16(n))
+	default:
+		buf = binary.BigEndian.AppendUint64(append(buf, mask|127), uint64(n))
+	}
+	if ws.client {
+		var key [4]byte
+		rand.Read(key[:])
+		buf = append(buf, key[:]...)
+		for i, b := range payload {
+			buf = append(buf, b^key[i%4])
+		}
+	} else {
+		buf = append(buf, payload...)
+	}
+	_, err := ws.conn.Write(buf)
+	return err
+}
+
+// Close sends a close frame, if none has been sent, and closes the
+// connection.
+func (ws *WebSocket) Close() error {
+	ws.conn.SetWriteDeadline(time.Now().Add(time.Second))
+	ws.writeFrame(opClose, closeNormal)
+	return ws.conn.Close()
+}
+
+// ServeHTTP runs a chat session on a WebSocket, so that a Hub can be
+// mounted on an http.ServeMux. The HTTP server does not close the
+// connections it has handed over when it shuts down: register the hub's
+// Close with http.Server.RegisterOnShutdown.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := Upgrade(w, r)
+	if err != nil {
+		return
+	}
+	h.Serve(r.Context(), ws)
+}
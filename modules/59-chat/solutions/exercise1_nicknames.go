@@ -0,0 +1,78 @@
+package solutions
+
+// SOLUTION: Milestone 1: give the chat hub nicknames, unique whatever their case, and fit to show.
+// Fixed: names are compared folded to lower case, so "Alice" and "alice" are one name
+// Fixed: the length limit counts characters, not bytes, so "Zoë" is not penalised for its ë
+// Fixed: a name is letters, digits, "-", and "_" only, so no one can call themselves "alice:" or "*" and pass for someone else
+// Fixed: the map is guarded by a mutex; the hub calls one method at a time, but a Nicknames may be shared
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxNameLen is the longest a nickname may be, in characters.
+const MaxNameLen = 16
+
+// ErrNameTaken is returned by Claim for a name someone already goes by.
+var ErrNameTaken = errors.New("name taken")
+
+// ErrBadName is returned by Claim for a name that is not allowed.
+var ErrBadName = errors.New("bad name")
+
+// Nicknames is a chat.Names that lets one client at a time go by each
+// name, whatever its case, and lets only names fit to show in a chat.
+type Nicknames struct {
+	mu    sync.Mutex
+	taken map[string]bool // by the name in lower case
+}
+
+// NewNicknames returns a Nicknames with every name free.
+func NewNicknames() *Nicknames {
+	return &Nicknames{taken: make(map[string]bool)}
+}
+
+// Claim takes name, or returns an error wrapping ErrBadName or
+// ErrNameTaken.
+func (n *Nicknames) Claim(name string) error {
+	if err := ValidName(name); err != nil {
+		return err
+	}
+	// Fixed: one key for every case of a name
+	key := strings.ToLower(name)
+	// Fixed: locked
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.taken[key] {
+		return fmt.Errorf("%w: %s", ErrNameTaken, name)
+	}
+	n.taken[key] = true
+	return nil
+}
+
+// Release frees name for someone else.
+func (n *Nicknames) Release(name string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.taken, strings.ToLower(name))
+}
+
+// ValidName returns an error wrapping ErrBadName unless name is 1 to
+// MaxNameLen letters, digits, hyphens, and underscores.
+func ValidName(name string) error {
+	// Fixed: characters, not bytes
+	if n := utf8.RuneCountInString(name); n == 0 || n > MaxNameLen {
+		return fmt.Errorf("%w: a name is 1 to %d characters", ErrBadName, MaxNameLen)
+	}
+	// Fixed: only characters that cannot pass for the chat's own
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+			return fmt.Errorf("%w: %q is not allowed; use letters, digits, - and _", ErrBadName, r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package solutions
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/59-chat/solutions/chat"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// peer is a simulated client, on TCP or on a WebSocket.
+type peer struct {
+	t     *testing.T
+	conn  chat.Conn
+	lines chan string
+	done  chan struct{}
+}
+
+func newPeer(t *testing.T, conn chat.Conn) *peer {
+	p := &peer{t: t, conn: conn, lines: make(chan string, 1024), done: make(chan struct{})}
+	go func() {
+		defer close(p.lines)
+		for {
+			line, err := conn.ReadLine()
+			if err != nil {
+				return
+			}
+			select {
+			case p.lines <- line:
+			case <-p.done:
+				return
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		close(p.done)
+		conn.Close()
+	})
+	require.True(t, strings.HasPrefix(p.next(), "* welcome, guest"))
+	return p
+}
+
+func (p *peer) send(line string) {
+	p.t.Helper()
+	require.NoError(p.t, p.conn.WriteLine(line))
+}
+
+func (p *peer) next() string {
+	p.t.Helper()
+	select {
+	case line, ok := <-p.lines:
+		require.True(p.t, ok, "the connection closed")
+		return line
+	case <-time.After(5 * time.Second):
+		p.t.Fatal("no line in 5s")
+		return ""
+	}
+}
+
+// waitFor skips lines until want, and returns those it skipped.
+func (p *peer) waitFor(want string) []string {
+	p.t.Helper()
+	var skipped []string
+	for {
+		line := p.next()
+		if line == want {
+			return skipped
+		}
+		skipped = append(skipped, line)
+	}
+}
+
+// dial serves h on TCP and on a WebSocket, and returns a function that
+// connects a peer to one or the other, in turn.
+func dial(t *testing.T, h *chat.Hub) func() *peer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		h.ServeTCP(ctx, ln)
+	}()
+	srv := httptest.NewServer(h)
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+		h.Close()
+		srv.Close()
+	})
+
+	n := 0
+	return func() *peer {
+		n++
+		if n%2 == 1 {
+			conn, err := net.Dial("tcp", ln.Addr().String())
+			require.NoError(t, err)
+			return newPeer(t, chat.NewLineConn(conn))
+		}
+		ws, err := chat.DialWebSocket(context.Background(), "ws"+strings.TrimPrefix(srv.URL, "http"))
+		require.NoError(t, err)
+		return newPeer(t, ws)
+	}
+}
+
+func TestNicknamesClaim(t *testing.T) {
+	n := NewNicknames()
+	require.NoError(t, n.Claim("Alice"))
+	assert.ErrorIs(t, n.Claim("Alice"), ErrNameTaken)
+	assert.ErrorIs(t, n.Claim("alice"), ErrNameTaken, "names differing in case only are one name")
+	assert.ErrorIs(t, n.Claim("ALICE"), ErrNameTaken)
+	require.NoError(t, n.Claim("bob"))
+
+	n.Release("Alice")
+	require.NoError(t, n.Claim("alice"), "released")
+	n.Release("ALICE")
+	require.NoError(t, n.Claim("Alice"), "released, in another case")
+}
+
+func TestNicknamesValid(t *testing.T) {
+	for _, name := range []string{"bob", "guest12", "Zoë", "jean-luc", "r2_d2", "Ωμέγα-Άλφα-Βήτα1", "0123456789abcdef"} {
+		assert.NoError(t, ValidName(name), name)
+		assert.NoError(t, NewNicknames().Claim(name), name)
+	}
+	for _, name := range []string{"", "0123456789abcdefg", "bob smith", "alice:", "*", "[lobby]", "tab\there", "new\nline"} {
+		assert.ErrorIs(t, ValidName(name), ErrBadName, "%q", name)
+		assert.ErrorIs(t, NewNicknames().Claim(name), ErrBadName, "%q", name)
+	}
+}
+
+func TestNicknamesConcurrent(t *testing.T) {
+	n := NewNicknames()
+	names := []string{"ann", "ben", "cat", "dan"}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	won := make(map[string]int)
+	for i := 0; i < 40; i++ {
+		name := names[i%len(names)]
+		if i%3 == 0 {
+			name = strings.ToUpper(name)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if n.Claim(name) == nil {
+				mu.Lock()
+				won[strings.ToLower(name)]++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, map[string]int{"ann": 1, "ben": 1, "cat": 1, "dan": 1}, won, "one client per name")
+}
+
+func TestChatNicknames(t *testing.T) {
+	leaktest.Check(t)
+	h := chat.NewHub()
+	h.Names = NewNicknames()
+	connect := dial(t, h)
+	alice, bob := connect(), connect() // TCP and WebSocket
+
+	alice.send("/nick Alice")
+	assert.Equal(t, "* you are Alice", alice.next())
+	bob.send("/nick alice")
+	assert.Equal(t, "* name taken: alice", bob.next())
+	bob.send("/nick alice:")
+	assert.Contains(t, bob.next(), "* bad name")
+	bob.send("/nick Bob")
+	assert.Equal(t, "* you are Bob", bob.next())
+
+	alice.send("/join lobby")
+	assert.Equal(t, "[lobby] * Alice joined", alice.next())
+	bob.send("/join lobby")
+	assert.Equal(t, "[lobby] * Bob joined", alice.next())
+	alice.send("/nick alice")
+	assert.Equal(t, "[lobby] * Alice is now alice", alice.next(), "a change of case is hers to make")
+	bob.waitFor("[lobby] * Alice is now alice")
+
+	bob.send("/quit")
+	alice.waitFor("[lobby] * Bob left")
+	carol := connect()
+	carol.send("/nick bob")
+	assert.Equal(t, "* you are bob", carol.next(), "free once Bob has gone")
+}
+
+func TestChatGuestNames(t *testing.T) {
+	leaktest.Check(t)
+	h := chat.NewHub()
+	h.Names = NewNicknames()
+	connect := dial(t, h)
+	squatter := connect()
+	squatter.send("/nick guest2")
+	assert.Equal(t, "* you are guest2", squatter.next())
+	p := connect()
+	p.send("/join lobby")
+	assert.Equal(t, "[lobby] * guest3 joined", p.next(), "the hub skips a guest name someone took")
+	old := "guest3"
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("x%d", i)
+		p.send("/nick " + name)
+		assert.Equal(t, "[lobby] * "+old+" is now "+name, p.next())
+		assert.Equal(t, "* you are "+name, p.next())
+		old = name
+	}
+	squatter.send("/nick guest3")
+	assert.Equal(t, "* you are guest3", squatter.next(), "every old name was given back")
+}
@@ -0,0 +1,70 @@
+package solutions
+
+// SOLUTION: Milestone 2: give each chat room a history that those who join see first.
+// Fixed: each room has a ring of its own, so one busy room does not push out another's history
+// Fixed: a full ring is read from its oldest message, at next, round to the newest
+// Fixed: Recent returns a copy, which later messages cannot change under the caller
+// Fixed: a History of size 0 keeps nothing, instead of dividing by zero
+
+import (
+	"sync"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/59-chat/solutions/chat"
+)
+
+// History is a chat.Log that keeps the last Size messages of each room.
+type History struct {
+	Size int
+
+	mu    sync.Mutex
+	rooms map[string]*ring
+}
+
+// ring holds the last messages of a room. Once full, each new message
+// takes the place of the oldest, at next.
+type ring struct {
+	msgs []chat.Message
+	next int
+}
+
+// NewHistory returns a History that keeps the last size messages of each
+// room.
+func NewHistory(size int) *History {
+	return &History{Size: size, rooms: make(map[string]*ring)}
+}
+
+// Append adds m to the history of its room.
+func (h *History) Append(m chat.Message) {
+	// Fixed: nothing to keep
+	if h.Size <= 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// Fixed: by room
+	r := h.rooms[m.Room]
+	if r == nil {
+		r = &ring{}
+		h.rooms[m.Room] = r
+	}
+	if len(r.msgs) < h.Size {
+		r.msgs = append(r.msgs, m)
+		return
+	}
+	r.msgs[r.next] = m
+	r.next = (r.next + 1) % h.Size
+}
+
+// Recent returns the history of room, oldest first.
+func (h *History) Recent(room string) []chat.Message {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	r := h.rooms[room]
+	if r == nil {
+		return nil
+	}
+	// Fixed: a new slice, from the oldest at next round to the newest
+	recent := make([]chat.Message, 0, len(r.msgs))
+	recent = append(recent, r.msgs[r.next:]...)
+	return append(recent, r.msgs[:r.next]...)
+}
@@ -0,0 +1,61 @@
+// Package examples demonstrates what it takes for a write to survive a
+// crash: where a write waits on its way to the disk, how to replace a
+// file in one step, and how to find where a crash cut a file short.
+//
+// This file shows:
+// - The three places a write can be: the process's buffer, the operating system's cache, and the disk
+// - That a killed process loses its buffer, and a power cut the cache as well
+// - bufio.Writer.Flush, which hands the buffer to the operating system, and File.Sync, which waits for the disk
+// - Why the order is Flush, then Sync
+package examples
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+)
+
+// Disk is a file as the operating system keeps it: what is written to it
+// waits in a cache, and is on the disk once it is synced. It is an
+// io.Writer, with Sync like *os.File.
+type Disk struct {
+	cache, disk []byte
+}
+
+// Write puts p in the cache.
+func (d *Disk) Write(p []byte) (int, error) {
+	d.cache = append(d.cache, p...)
+	return len(p), nil
+}
+
+// Sync writes the cache to the disk.
+func (d *Disk) Sync() error {
+	d.disk = bytes.Clone(d.cache)
+	return nil
+}
+
+// AfterKill returns what the file holds after the process that wrote it
+// is killed: the operating system still writes out its cache.
+func (d *Disk) AfterKill() string { return string(d.cache) }
+
+// AfterPowerCut returns what the file holds after a power cut.
+func (d *Disk) AfterPowerCut() string { return string(d.disk) }
+
+// DemonstrateDurability writes through a bufio.Writer, and shows what
+// survives after each step.
+func DemonstrateDurability() {
+	fmt.Println("\n=== Durability ===")
+	d := &Disk{}
+	w := bufio.NewWriter(d)
+	show := func(step string) {
+		fmt.Printf("%-22s after a kill: %-10q after a power cut: %q\n", step, d.AfterKill(), d.AfterPowerCut())
+	}
+	w.WriteString("record\n")
+	show("written to the buffer")
+	d.Sync()
+	show("synced, not flushed")
+	w.Flush()
+	show("flushed")
+	d.Sync()
+	show("flushed, then synced")
+}
@@ -0,0 +1,27 @@
+package examples
+
+import (
+	"bufio"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateDurability(t *testing.T) {
+	DemonstrateDurability()
+}
+
+func TestDisk(t *testing.T) {
+	d := &Disk{}
+	w := bufio.NewWriter(d)
+	w.WriteString("a")
+	assert.Equal(t, "", d.AfterKill(), "still in the process")
+	w.Flush()
+	assert.Equal(t, "a", d.AfterKill())
+	assert.Equal(t, "", d.AfterPowerCut(), "still in the cache")
+	d.Sync()
+	assert.Equal(t, "a", d.AfterPowerCut())
+	d.Write([]byte("b"))
+	assert.Equal(t, "ab", d.AfterKill())
+	assert.Equal(t, "a", d.AfterPowerCut())
+}
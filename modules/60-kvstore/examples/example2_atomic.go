@@ -0,0 +1,79 @@
+package examples
+
+// This file shows:
+// - Replacing a file in one step: write a new file beside it, sync it, and rename it over the old
+// - That os.Rename within a directory is atomic: a reader, or a crash, sees the old file or the new, never half of one
+// - Syncing the directory, so that the rename itself survives a power cut
+// - Cleaning up the new file when something fails before the rename
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// WriteFileAtomic replaces the file at path with data, so that after a
+// crash at any point it holds the old data or the new.
+func WriteFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return SyncDir(filepath.Dir(path))
+}
+
+// SyncDir syncs a directory, and with it the names it holds. Windows
+// cannot open a directory to sync it.
+func SyncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = d.Sync()
+	if cerr := d.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// DemonstrateAtomicWrite replaces a file, and shows that nothing else is
+// left in its directory.
+func DemonstrateAtomicWrite() {
+	fmt.Println("\n=== Atomic replacement ===")
+	dir, err := os.MkdirTemp("", "atomic")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+	for _, data := range []string{`{"version": 1}`, `{"version": 2}`} {
+		if err := WriteFileAtomic(path, []byte(data)); err != nil {
+			fmt.Println(err)
+			return
+		}
+		got, _ := os.ReadFile(path)
+		fmt.Printf("config.json: %s\n", got)
+	}
+	entries, _ := os.ReadDir(dir)
+	fmt.Println("files in the directory:", len(entries))
+}
@@ -0,0 +1,38 @@
+package examples
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateAtomicWrite(t *testing.T) {
+	DemonstrateAtomicWrite()
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data")
+	require.NoError(t, WriteFileAtomic(path, []byte("one")))
+	require.NoError(t, WriteFileAtomic(path, []byte("two")))
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "two", string(got))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary file left behind")
+}
+
+func TestWriteFileAtomicFails(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data")
+	require.NoError(t, os.Mkdir(path, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(path, "inside"), nil, 0o644))
+	assert.Error(t, WriteFileAtomic(path, []byte("x")), "a directory that is not empty cannot be renamed over")
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "the temporary file is removed")
+}
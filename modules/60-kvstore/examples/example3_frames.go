@@ -0,0 +1,59 @@
+package examples
+
+// This file shows:
+// - Framing: a length before each payload, so a reader knows where it ends
+// - A CRC-32C of each frame, so a reader knows it is whole
+// - Reading frames until the first that is cut short or garbled, which is where a crash interrupted a write
+// - Returning the length of the good prefix, to cut the file back to
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// AppendFrame appends payload to dst, after its length and checksum.
+func AppendFrame(dst, payload []byte) []byte {
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(payload)))
+	dst = binary.LittleEndian.AppendUint32(dst, crc32.Checksum(payload, crcTable))
+	return append(dst, payload...)
+}
+
+// ReadFrames returns the payloads of the frames in b, up to the first
+// that is cut short or fails its checksum, and the length of b they take.
+func ReadFrames(b []byte) (payloads [][]byte, good int) {
+	for len(b[good:]) >= 8 {
+		n := int(binary.LittleEndian.Uint32(b[good:]))
+		sum := binary.LittleEndian.Uint32(b[good+4:])
+		if n > len(b[good+8:]) {
+			break
+		}
+		payload := b[good+8 : good+8+n]
+		if crc32.Checksum(payload, crcTable) != sum {
+			break
+		}
+		payloads = append(payloads, payload)
+		good += 8 + n
+	}
+	return payloads, good
+}
+
+// DemonstrateFrames reads back a log whose last frame was torn, and one
+// whose last frame was garbled.
+func DemonstrateFrames() {
+	fmt.Println("\n=== Checksummed frames ===")
+	var log []byte
+	for _, p := range []string{"first", "second", "third"} {
+		log = AppendFrame(log, []byte(p))
+	}
+	torn := log[:len(log)-2]
+	payloads, good := ReadFrames(torn)
+	fmt.Printf("torn: %d frames, %s; cut the file to %d of %d bytes\n", len(payloads), payloads, good, len(torn))
+
+	garbled := append([]byte(nil), log...)
+	garbled[len(garbled)-1] ^= 0xFF
+	payloads, good = ReadFrames(garbled)
+	fmt.Printf("garbled: %d frames, %s; cut the file to %d of %d bytes\n", len(payloads), payloads, good, len(garbled))
+}
@@ -0,0 +1,39 @@
+package examples
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateFrames(t *testing.T) {
+	DemonstrateFrames()
+}
+
+func TestReadFrames(t *testing.T) {
+	var log []byte
+	var want [][]byte
+	for i := 0; i < 5; i++ {
+		p := []byte(fmt.Sprint("payload ", i))
+		log = AppendFrame(log, p)
+		want = append(want, p)
+	}
+	got, good := ReadFrames(log)
+	assert.Equal(t, want, got)
+	assert.Equal(t, len(log), good)
+
+	for cut := 1; cut < 8+len(want[4]); cut++ {
+		got, good := ReadFrames(log[:len(log)-cut])
+		assert.Equal(t, want[:4], got, "the last frame cut %d bytes short", cut)
+		assert.Equal(t, len(log)-8-len(want[4]), good)
+	}
+
+	garbled := append([]byte(nil), log...)
+	garbled[len(garbled)-1]++
+	got, _ = ReadFrames(garbled)
+	assert.Equal(t, want[:4], got)
+	got, good = ReadFrames(nil)
+	assert.Empty(t, got)
+	assert.Zero(t, good)
+}
@@ -0,0 +1,189 @@
+package exercises
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// open opens the store in dir, and closes it when the test ends.
+func open(t *testing.T, dir string) *Store {
+	t.Helper()
+	s, err := Open(dir)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// contents returns every key of s with its value.
+func contents(s *Store) map[string]string {
+	m := make(map[string]string)
+	for _, k := range s.Keys() {
+		v, _ := s.Get(k)
+		m[k] = string(v)
+	}
+	return m
+}
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	s := open(t, dir)
+	require.NoError(t, s.Put("a", []byte("1")))
+	require.NoError(t, s.Put("b", []byte("2")))
+	require.NoError(t, s.Put("a", []byte("3")))
+	require.NoError(t, s.Delete("b"))
+	require.NoError(t, s.Delete("missing"))
+	v, ok := s.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "3", string(v))
+	_, ok = s.Get("b")
+	assert.False(t, ok)
+	assert.Equal(t, 1, s.Len())
+	assert.Equal(t, 3, s.Garbage(), "a=1, b=2, and the delete of b")
+
+	v[0] = 'x'
+	v, _ = s.Get("a")
+	assert.Equal(t, "3", string(v), "Get returns a copy")
+	require.NoError(t, s.Close())
+	assert.ErrorIs(t, s.Put("c", nil), ErrClosed)
+	assert.Equal(t, map[string]string{"a": "3"}, contents(open(t, dir)))
+}
+
+func TestStorePutFails(t *testing.T) {
+	s := open(t, t.TempDir())
+	require.NoError(t, s.Put("a", []byte("1")))
+	assert.ErrorIs(t, s.Put("a", make([]byte, MaxValue+1)), ErrTooLarge)
+	v, _ := s.Get("a")
+	assert.Equal(t, "1", string(v), "a change the log refused is not made")
+	assert.ErrorIs(t, s.Put("b", make([]byte, MaxValue+1)), ErrTooLarge)
+	_, ok := s.Get("b")
+	assert.False(t, ok)
+}
+
+func TestStoreKillReplays(t *testing.T) {
+	dir := t.TempDir()
+	p := start(t, "store "+dir, "")
+	p.do("put a 1")
+	p.do("put b 2")
+	p.do("put c 3")
+	p.do("put a 4")
+	p.do("del b")
+	p.do("del missing")
+	p.kill()
+
+	s := open(t, dir)
+	assert.Equal(t, map[string]string{"a": "4", "c": "3"}, contents(s), "the store as it was when it was killed")
+	assert.Equal(t, 3, s.Garbage())
+}
+
+func TestStoreCompact(t *testing.T) {
+	r := seed.Rand(t, 2)
+	dir := t.TempDir()
+	s := open(t, dir)
+	want := make(map[string]string)
+	for i := 0; i < 200; i++ {
+		key := fmt.Sprint("key", r.Intn(20))
+		if r.Intn(5) == 0 {
+			require.NoError(t, s.Delete(key))
+			delete(want, key)
+			continue
+		}
+		value := fmt.Sprint("value", i)
+		require.NoError(t, s.Put(key, []byte(value)))
+		want[key] = value
+	}
+	before, err := os.Stat(filepath.Join(dir, logName))
+	require.NoError(t, err)
+	require.NoError(t, s.Compact())
+	assert.Equal(t, 0, s.Garbage())
+	after, err := os.Stat(filepath.Join(dir, logName))
+	require.NoError(t, err)
+	assert.Less(t, after.Size(), before.Size())
+	assert.Equal(t, want, contents(s))
+
+	require.NoError(t, s.Put("new", []byte("after compaction")))
+	want["new"] = "after compaction"
+	require.NoError(t, s.Close())
+	assert.Equal(t, want, contents(open(t, dir)))
+	_, err = os.Stat(filepath.Join(dir, tmpName))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestStoreKillAfterCompact(t *testing.T) {
+	dir := t.TempDir()
+	p := start(t, "store "+dir, "")
+	for i := 0; i < 10; i++ {
+		p.do("put key%d old%d", i, i)
+	}
+	p.do("compact")
+	p.do("put key0 new0")
+	p.do("del key1")
+	p.do("put key10 new10")
+	p.kill()
+
+	got := contents(open(t, dir))
+	assert.Equal(t, "new0", got["key0"], "written after the compaction")
+	assert.NotContains(t, got, "key1", "deleted after the compaction")
+	assert.Equal(t, "new10", got["key10"], "written after the compaction")
+	assert.Len(t, got, 10)
+}
+
+func TestStoreCrashInCompact(t *testing.T) {
+	dir := t.TempDir()
+	p := start(t, "store "+dir, "compact-renamed")
+	want := make(map[string]string)
+	for i := 0; i < 10; i++ {
+		p.do("put key%d value%d", i, i)
+		want[fmt.Sprint("key", i)] = fmt.Sprint("value", i)
+	}
+	p.do("put key3 changed")
+	want["key3"] = "changed"
+	p.crash("compact")
+
+	assert.Equal(t, want, contents(open(t, dir)), "the compacted log, renamed into place just before the crash")
+}
+
+func TestStoreLeftoverCompaction(t *testing.T) {
+	dir := t.TempDir()
+	s := open(t, dir)
+	require.NoError(t, s.Put("a", []byte("1")))
+	require.NoError(t, s.Close())
+	// A compaction that crashed before its rename left this behind.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, tmpName), encode(nil, put("b", "2")), 0o644))
+
+	assert.Equal(t, map[string]string{"a": "1"}, contents(open(t, dir)))
+	_, err := os.Stat(filepath.Join(dir, tmpName))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestStoreConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	s := open(t, dir)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		g := g
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				key := fmt.Sprintf("g%d-%d", g, i)
+				assert.NoError(t, s.Put(key, []byte(key)))
+				v, ok := s.Get(key)
+				assert.True(t, ok)
+				assert.Equal(t, key, string(v))
+				if i == 10 && g == 0 {
+					assert.NoError(t, s.Compact())
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, s.Close())
+	assert.Equal(t, 160, open(t, dir).Len())
+}
@@ -0,0 +1,71 @@
+// Command kv reads and changes the store in a directory.
+//
+//	go run ./modules/60-kvstore/exercises/kv -dir /tmp/kv put greeting hello
+//	go run ./modules/60-kvstore/exercises/kv -dir /tmp/kv get greeting
+//	go run ./modules/60-kvstore/exercises/kv -dir /tmp/kv compact
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/60-kvstore/exercises"
+)
+
+func main() {
+	dir := flag.String("dir", "kv", "the store's directory")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: kv [-dir dir] get key | put key value | del key | keys | compact | stats")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	s, err := exercises.Open(*dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "kv:", err)
+		os.Exit(1)
+	}
+	code := run(s, flag.Args())
+	if err := s.Close(); err != nil {
+		fmt.Fprintln(os.Stderr, "kv:", err)
+		code = 1
+	}
+	os.Exit(code)
+}
+
+func run(s *exercises.Store, args []string) int {
+	var err error
+	switch cmd := args[0]; {
+	case cmd == "get" && len(args) == 2:
+		v, ok := s.Get(args[1])
+		if !ok {
+			fmt.Fprintf(os.Stderr, "kv: %s: no such key\n", args[1])
+			return 1
+		}
+		fmt.Printf("%s\n", v)
+	case cmd == "put" && len(args) == 3:
+		err = s.Put(args[1], []byte(args[2]))
+	case cmd == "del" && len(args) == 2:
+		err = s.Delete(args[1])
+	case cmd == "keys" && len(args) == 1:
+		for _, k := range s.Keys() {
+			fmt.Println(k)
+		}
+	case cmd == "compact" && len(args) == 1:
+		err = s.Compact()
+	case cmd == "stats" && len(args) == 1:
+		fmt.Printf("%d keys, %d records of garbage\n", s.Len(), s.Garbage())
+	default:
+		flag.Usage()
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "kv:", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,183 @@
+package solutions
+
+// SOLUTION: Milestone 1: fix the write-ahead log, so that a write it acknowledges survives a crash, and a write a crash tore does not break the log.
+// Fixed: Append syncs the record before it returns: a record still in the buffer is lost when the process dies, and the caller was told it was safe
+// Fixed: Sync flushes the buffer to the file before it syncs the file; the other way round, the sync is over before the last records reach the file
+// Fixed: a record cut short at the end of the log is the write a crash interrupted, and ends the log instead of failing it
+// Fixed: ReadRecords checks each record's checksum, so the garbage a crash leaves at the end of a file is not replayed as data
+// Fixed: OpenWAL cuts the log back to its last whole record before it appends: a record after the garbage is one no replay reaches
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// Op is what a record does to its key.
+type Op byte
+
+// The ops of a record. Zero is not one, so a file of zeros, which a crash
+// can leave behind, is not read as records.
+const (
+	OpPut    Op = 1
+	OpDelete Op = 2
+)
+
+// Record is one change, as the log keeps it.
+type Record struct {
+	Op    Op
+	Key   string
+	Value []byte
+}
+
+// MaxKey and MaxValue bound the size of a record, in bytes, so that a
+// garbled length cannot make ReadRecords allocate gigabytes.
+const (
+	MaxKey   = 1 << 10
+	MaxValue = 1 << 20
+)
+
+// ErrTooLarge is returned for a key or value over MaxKey or MaxValue.
+var ErrTooLarge = errors.New("kv: key or value too large")
+
+// headerSize is the length of a record's header: the CRC-32C of the rest
+// of the record, the op, and the lengths of the key and of the value.
+const headerSize = 4 + 1 + 4 + 4
+
+// castagnoli is the CRC-32 table ext4, iSCSI, and LevelDB use.
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// encode appends rec, as the log stores it, to dst.
+func encode(dst []byte, rec Record) []byte {
+	start := len(dst)
+	dst = append(dst, 0, 0, 0, 0, byte(rec.Op))
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(rec.Key)))
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(rec.Value)))
+	dst = append(dst, rec.Key...)
+	dst = append(dst, rec.Value...)
+	binary.LittleEndian.PutUint32(dst[start:], crc32.Checksum(dst[start+4:], castagnoli))
+	return dst
+}
+
+// ReadRecords reads a log from r. It stops at the first record that is
+// cut short or fails its checksum, which is where a crash interrupted a
+// write, and returns the records before it and the offset at which it
+// starts. Only errors from r other than its end are returned.
+func ReadRecords(r io.Reader) (recs []Record, good int64, err error) {
+	br := bufio.NewReader(r)
+	var head [headerSize]byte
+	for {
+		if _, err := io.ReadFull(br, head[:]); err != nil {
+			return recs, good, ended(err)
+		}
+		op := Op(head[4])
+		klen := binary.LittleEndian.Uint32(head[5:])
+		vlen := binary.LittleEndian.Uint32(head[9:])
+		if (op != OpPut && op != OpDelete) || klen > MaxKey || vlen > MaxValue {
+			return recs, good, nil
+		}
+		body := make([]byte, klen+vlen)
+		if _, err := io.ReadFull(br, body); err != nil {
+			return recs, good, ended(err)
+		}
+		sum := crc32.Update(crc32.Checksum(head[4:], castagnoli), castagnoli, body)
+		if sum != binary.LittleEndian.Uint32(head[:4]) {
+			return recs, good, nil
+		}
+		rec := Record{Op: op, Key: string(body[:klen])}
+		if vlen > 0 {
+			rec.Value = body[klen:]
+		}
+		recs = append(recs, rec)
+		good += int64(headerSize + len(body))
+	}
+}
+
+// ended returns nil for the errors of a log that ends, between records or
+// in the middle of one.
+func ended(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil
+	}
+	return err
+}
+
+// File is what a WAL needs of the file it appends to. *os.File is one.
+type File interface {
+	io.Writer
+	Sync() error
+	Close() error
+}
+
+// WAL is a write-ahead log: a file of records, to which each change is
+// appended, and synced, before it is made.
+type WAL struct {
+	f   File
+	w   *bufio.Writer
+	buf []byte
+}
+
+// NewWAL returns a WAL that appends to f.
+func NewWAL(f File) *WAL {
+	return &WAL{f: f, w: bufio.NewWriter(f)}
+}
+
+// OpenWAL opens the log at path, creating it if need be, and returns it
+// with the records it holds. Whatever follows the last whole record, a
+// write a crash tore, is cut off.
+func OpenWAL(path string) (*WAL, []Record, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+	recs, good, err := ReadRecords(f)
+	if err == nil {
+		err = f.Truncate(good)
+	}
+	if err == nil {
+		_, err = f.Seek(good, io.SeekStart)
+	}
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return NewWAL(f), recs, nil
+}
+
+// Write adds rec to the log's buffer. It is on the disk once Sync returns.
+func (l *WAL) Write(rec Record) error {
+	if len(rec.Key) > MaxKey || len(rec.Value) > MaxValue {
+		return ErrTooLarge
+	}
+	l.buf = encode(l.buf[:0], rec)
+	_, err := l.w.Write(l.buf)
+	return err
+}
+
+// Sync flushes the buffer to the file, and the file to the disk.
+func (l *WAL) Sync() error {
+	if err := l.w.Flush(); err != nil {
+		return err
+	}
+	return l.f.Sync()
+}
+
+// Append writes rec to the log, and returns once it is on the disk.
+func (l *WAL) Append(rec Record) error {
+	if err := l.Write(rec); err != nil {
+		return err
+	}
+	return l.Sync()
+}
+
+// Close syncs the log and closes its file.
+func (l *WAL) Close() error {
+	err := l.Sync()
+	if cerr := l.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,279 @@
+package solutions
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// childEnv, when set, makes the test binary a process the tests can kill:
+// with "wal <path>" it appends to the log at path, and with "store <dir>"
+// it runs the store in dir. It reads commands from stdin, "put <key>
+// <value>", "del <key>", and "compact", and answers each with "ok" once
+// it is done.
+const childEnv = "LEARNGO_KV_CHILD"
+
+// crashEnv, when set, makes the child kill itself at the crash point it
+// names.
+const crashEnv = "LEARNGO_KV_CRASH"
+
+func TestMain(m *testing.M) {
+	if spec, ok := os.LookupEnv(childEnv); ok {
+		os.Exit(child(spec))
+	}
+	os.Exit(m.Run())
+}
+
+func child(spec string) int {
+	if point := os.Getenv(crashEnv); point != "" {
+		crashPoint = func(name string) {
+			if name == point {
+				self, _ := os.FindProcess(os.Getpid())
+				self.Kill()
+				select {}
+			}
+		}
+	}
+	mode, path, _ := strings.Cut(spec, " ")
+	var do func(cmd, key, value string) error
+	switch mode {
+	case "wal":
+		log, _, err := OpenWAL(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		do = func(cmd, key, value string) error {
+			if cmd == "del" {
+				return log.Append(Record{Op: OpDelete, Key: key})
+			}
+			return log.Append(Record{Op: OpPut, Key: key, Value: []byte(value)})
+		}
+	case "store":
+		s, err := Open(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		do = func(cmd, key, value string) error {
+			switch cmd {
+			case "del":
+				return s.Delete(key)
+			case "compact":
+				return s.Compact()
+			}
+			return s.Put(key, []byte(value))
+		}
+	}
+	sc := bufio.NewScanner(os.Stdin)
+	for sc.Scan() {
+		f := append(strings.Fields(sc.Text()), "", "")
+		if err := do(f[0], f[1], f[2]); err != nil {
+			fmt.Println("error:", err)
+			continue
+		}
+		fmt.Println("ok")
+	}
+	// Exit without closing anything, as a crash would.
+	return 0
+}
+
+// proc is a child process.
+type proc struct {
+	t   *testing.T
+	cmd *exec.Cmd
+	in  io.Writer
+	out *bufio.Scanner
+}
+
+// start starts a child with spec, which kills itself at the crash point
+// named by crash, if it is not "".
+func start(t *testing.T, spec, crash string) *proc {
+	t.Helper()
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), childEnv+"="+spec, crashEnv+"="+crash)
+	cmd.Stderr = os.Stderr
+	in, err := cmd.StdinPipe()
+	require.NoError(t, err)
+	out, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+	return &proc{t: t, cmd: cmd, in: in, out: bufio.NewScanner(out)}
+}
+
+// do sends the child a command, and waits for its "ok".
+func (p *proc) do(format string, args ...any) {
+	p.t.Helper()
+	fmt.Fprintf(p.in, format+"\n", args...)
+	require.True(p.t, p.out.Scan(), "the child died")
+	require.Equal(p.t, "ok", p.out.Text())
+}
+
+// crash sends the child a command that is to kill it, and waits for it to
+// die without an answer.
+func (p *proc) crash(line string) {
+	p.t.Helper()
+	fmt.Fprintln(p.in, line)
+	if p.out.Scan() {
+		p.t.Fatalf("the child answered %q to %q, and did not crash", p.out.Text(), line)
+	}
+	assert.Error(p.t, p.cmd.Wait(), "killed")
+}
+
+// kill kills the child, as a crash would.
+func (p *proc) kill() {
+	p.cmd.Process.Kill()
+	p.cmd.Wait()
+}
+
+// disk is a File that keeps what is written to it in a cache, as an
+// operating system does, and loses what was not synced in a power cut.
+type disk struct {
+	written, synced []byte
+}
+
+func (d *disk) Write(p []byte) (int, error) {
+	d.written = append(d.written, p...)
+	return len(p), nil
+}
+
+func (d *disk) Sync() error {
+	d.synced = bytes.Clone(d.written)
+	return nil
+}
+
+func (d *disk) Close() error { return nil }
+
+func put(key, value string) Record {
+	return Record{Op: OpPut, Key: key, Value: []byte(value)}
+}
+
+// appendRecords writes recs to a new log at path, and closes it.
+func appendRecords(t *testing.T, path string, recs ...Record) {
+	t.Helper()
+	l, _, err := OpenWAL(path)
+	require.NoError(t, err)
+	for _, rec := range recs {
+		require.NoError(t, l.Append(rec))
+	}
+	require.NoError(t, l.Close())
+}
+
+func TestWALRecords(t *testing.T) {
+	r := seed.Rand(t, 1)
+	path := filepath.Join(t.TempDir(), "kv.log")
+	var want []Record
+	for i := 0; i < 50; i++ {
+		key := fmt.Sprintf("key%d", r.Intn(10))
+		if r.Intn(4) == 0 {
+			want = append(want, Record{Op: OpDelete, Key: key})
+			continue
+		}
+		want = append(want, put(key, strings.Repeat("v", r.Intn(300)+1)))
+	}
+	want = append(want, put("", "an empty key"), Record{Op: OpPut, Key: "no value"})
+	appendRecords(t, path, want...)
+
+	l, got, err := OpenWAL(path)
+	require.NoError(t, err)
+	defer l.Close()
+	assert.Equal(t, want, got)
+	assert.ErrorIs(t, l.Append(put(strings.Repeat("k", MaxKey+1), "")), ErrTooLarge)
+	assert.ErrorIs(t, l.Append(Record{Op: OpPut, Key: "k", Value: make([]byte, MaxValue+1)}), ErrTooLarge)
+}
+
+func TestWALSyncsBeforeAck(t *testing.T) {
+	d := &disk{}
+	l := NewWAL(d)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, l.Append(put(fmt.Sprint("key", i), "value")))
+		recs, _, err := ReadRecords(bytes.NewReader(d.synced))
+		require.NoError(t, err)
+		require.Len(t, recs, i+1, "Append returned, and then a power cut lost its record")
+	}
+}
+
+func TestWALKill(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kv.log")
+	p := start(t, "wal "+path, "")
+	for i := 0; i < 20; i++ {
+		p.do("put key%d value%d", i, i)
+	}
+	p.kill()
+
+	l, recs, err := OpenWAL(path)
+	require.NoError(t, err)
+	defer l.Close()
+	require.Len(t, recs, 20, "every record acknowledged before the kill")
+	assert.Equal(t, put("key19", "value19"), recs[19])
+}
+
+func TestWALTornTail(t *testing.T) {
+	r := seed.Rand(t, 3)
+	torn := encode(nil, put("torn", "a record a crash cut short"))
+	for i := 0; i < 3; i++ {
+		cut := seed.Between(r, 1, len(torn)-1)
+		path := filepath.Join(t.TempDir(), "kv.log")
+		appendRecords(t, path, put("a", "1"), put("b", "2"))
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+		require.NoError(t, err)
+		f.Write(torn[:cut])
+		f.Close()
+
+		l, recs, err := OpenWAL(path)
+		require.NoError(t, err, "a log cut %d bytes into its last record", cut)
+		assert.Len(t, recs, 2, "the torn record is not one")
+		require.NoError(t, l.Append(put("c", "3")))
+		require.NoError(t, l.Close())
+
+		l, recs, err = OpenWAL(path)
+		require.NoError(t, err)
+		assert.Equal(t, []Record{put("a", "1"), put("b", "2"), put("c", "3")}, recs,
+			"the record appended after a tear cut %d bytes in", cut)
+		l.Close()
+	}
+}
+
+func TestWALChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kv.log")
+	appendRecords(t, path, put("a", "1"), put("b", "2"))
+	garbled := encode(nil, put("c", "a value a crash garbled"))
+	garbled[len(garbled)-3] ^= 0x20
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	f.Write(garbled)
+	f.Close()
+
+	l, recs, err := OpenWAL(path)
+	require.NoError(t, err)
+	defer l.Close()
+	assert.Equal(t, []Record{put("a", "1"), put("b", "2")}, recs, "a record that fails its checksum is not one")
+}
+
+func TestWALZeros(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kv.log")
+	appendRecords(t, path, put("a", "1"))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	f.Write(make([]byte, 64))
+	f.Close()
+
+	l, recs, err := OpenWAL(path)
+	require.NoError(t, err)
+	defer l.Close()
+	assert.Equal(t, []Record{put("a", "1")}, recs, "a file system can leave zeros where a crash interrupted a write")
+}
@@ -0,0 +1,210 @@
+package solutions
+
+// SOLUTION: Milestone 2: fix the store that keeps its index in memory and its changes in the log, and compacts the log without putting it at risk.
+// Fixed: Put logs the change before it makes it, so a change that did not reach the log is never seen
+// Fixed: Open replays deletes as well as puts, or every key deleted comes back after a restart
+// Fixed: Compact syncs the new log before it renames it over the old; renamed first, a log still in the buffer is empty on the disk if the process dies
+// Fixed: after Compact the store appends to the new log: the old file is gone from the directory, and what is written to it is lost at the next Open
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// The files of a store's directory: the log, and the log Compact writes
+// before it renames it over the first.
+const (
+	logName = "kv.log"
+	tmpName = "kv.log.tmp"
+)
+
+// ErrClosed is returned by the methods of a closed Store.
+var ErrClosed = errors.New("kv: store closed")
+
+// crashPoint is called, with a name, at the point of Compact where a crash
+// does the most harm. It does nothing, except in the tests, which kill the
+// process there.
+var crashPoint = func(name string) {}
+
+// Store is a key-value store in a directory. Every value is in memory,
+// and every change in a write-ahead log, which Open replays.
+type Store struct {
+	dir string
+
+	mu      sync.RWMutex
+	log     *WAL // nil once closed
+	index   map[string][]byte
+	records int // in the log, live or not
+}
+
+// Open opens the store in dir, creating it if need be.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	// A new log that Compact did not rename is unfinished; the old is whole.
+	if err := os.Remove(filepath.Join(dir, tmpName)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	log, recs, err := OpenWAL(filepath.Join(dir, logName))
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{dir: dir, log: log, index: make(map[string][]byte), records: len(recs)}
+	for _, rec := range recs {
+		switch rec.Op {
+		case OpPut:
+			s.index[rec.Key] = rec.Value
+		case OpDelete:
+			delete(s.index, rec.Key)
+		}
+	}
+	return s, nil
+}
+
+// Get returns the value of key, and whether it has one.
+func (s *Store) Get(key string) ([]byte, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.index[key]
+	return bytes.Clone(v), ok
+}
+
+// Put sets key to value. The change is on the disk when Put returns.
+func (s *Store) Put(key string, value []byte) error {
+	value = bytes.Clone(value)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.log == nil {
+		return ErrClosed
+	}
+	if err := s.log.Append(Record{Op: OpPut, Key: key, Value: value}); err != nil {
+		return err
+	}
+	s.index[key] = value
+	s.records++
+	return nil
+}
+
+// Delete removes key, if it has a value.
+func (s *Store) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.log == nil {
+		return ErrClosed
+	}
+	if _, ok := s.index[key]; !ok {
+		return nil
+	}
+	if err := s.log.Append(Record{Op: OpDelete, Key: key}); err != nil {
+		return err
+	}
+	delete(s.index, key)
+	s.records++
+	return nil
+}
+
+// Len returns the number of keys with a value.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.index)
+}
+
+// Keys returns the keys with a value, sorted.
+func (s *Store) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keys()
+}
+
+func (s *Store) keys() []string {
+	keys := make([]string, 0, len(s.index))
+	for k := range s.index {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Garbage returns the number of records in the log that Compact would
+// drop: the values since overwritten, and the deleted keys.
+func (s *Store) Garbage() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.records - len(s.index)
+}
+
+// Compact rewrites the log with a record for each key, and none of the
+// garbage. The new log replaces the old only once it is whole and on the
+// disk, so a crash leaves one log or the other.
+func (s *Store) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.log == nil {
+		return ErrClosed
+	}
+	tmp := filepath.Join(s.dir, tmpName)
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	log := NewWAL(f)
+	for _, key := range s.keys() {
+		if err := log.Write(Record{Op: OpPut, Key: key, Value: s.index[key]}); err != nil {
+			log.Close()
+			return err
+		}
+	}
+	if err := log.Sync(); err != nil {
+		log.Close()
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(s.dir, logName)); err != nil {
+		log.Close()
+		return err
+	}
+	crashPoint("compact-renamed")
+	// f follows the rename: it is the log now.
+	s.log.Close()
+	s.log = log
+	s.records = len(s.index)
+	// The rename is a change to the directory, which is synced on its own.
+	return syncDir(s.dir)
+}
+
+// syncDir syncs a directory, so that a rename in it survives a power cut.
+// Windows cannot open a directory to sync it.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = d.Sync()
+	if cerr := d.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Close closes the store's log. The store's methods return ErrClosed
+// after it.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.log == nil {
+		return ErrClosed
+	}
+	err := s.log.Close()
+	s.log = nil
+	return err
+}
@@ -0,0 +1,66 @@
+// Package examples demonstrates the choices behind a URL shortener: how
+// its IDs are made, how much a small cache saves on skewed traffic, and
+// what each kind of redirect asks of a browser.
+//
+// This file shows:
+// - How long an ID is in base 10, 16, 36, and 62, for the same number
+// - Sequential IDs, short and guessable: whoever has /4C92 can try /4C93
+// - Random IDs, unguessable, and the birthday bound on their collisions
+// - math.Expm1 for a probability that is close to 0
+package examples
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Digits62 are the digits of base62.
+const Digits62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// InBase returns n written with the digits of digits, in base
+// len(digits).
+func InBase(n uint64, digits string) string {
+	base := uint64(len(digits))
+	if n == 0 {
+		return digits[:1]
+	}
+	var buf [64]byte
+	i := len(buf)
+	for ; n > 0; n /= base {
+		i--
+		buf[i] = digits[n%base]
+	}
+	return string(buf[i:])
+}
+
+// RandomID returns an ID of n random base62 digits.
+func RandomID(r *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = Digits62[r.Intn(62)]
+	}
+	return string(b)
+}
+
+// CollisionChance returns the chance that, of ids random IDs of n base62
+// digits, two are the same: about 1 - e^(-ids²/2·62^n).
+func CollisionChance(ids float64, n int) float64 {
+	space := math.Pow(62, float64(n))
+	return -math.Expm1(-ids * (ids - 1) / (2 * space))
+}
+
+// DemonstrateIDs compares the lengths of IDs in several bases, and the
+// collisions of random ones.
+func DemonstrateIDs() {
+	fmt.Println("\n=== IDs ===")
+	for _, n := range []uint64{1_000, 1_000_000, 1_000_000_000} {
+		fmt.Printf("link %-13d base10 %-10s base16 %-8s base36 %-6s base62 %s\n", n,
+			InBase(n, Digits62[:10]), InBase(n, "0123456789abcdef"), InBase(n, Digits62[:36]), InBase(n, Digits62))
+	}
+	r := rand.New(rand.NewSource(1))
+	fmt.Println("random IDs:", RandomID(r, 7), RandomID(r, 7), RandomID(r, 7))
+	for _, n := range []int{6, 7, 8} {
+		fmt.Printf("a million random IDs of %d digits collide with a chance of %.2g\n", n, CollisionChance(1e6, n))
+	}
+}
@@ -0,0 +1,39 @@
+package examples
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateIDs(t *testing.T) {
+	DemonstrateIDs()
+}
+
+func TestInBase(t *testing.T) {
+	for _, n := range []uint64{0, 1, 35, 36, 1_000_000, 1<<64 - 1} {
+		assert.Equal(t, strconv.FormatUint(n, 36), InBase(n, Digits62[:10]+"abcdefghijklmnopqrstuvwxyz"), n)
+		assert.Equal(t, strconv.FormatUint(n, 2), InBase(n, "01"), n)
+	}
+	assert.Equal(t, "4C92", InBase(1_000_000, Digits62))
+}
+
+func TestRandomID(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		id := RandomID(r, 7)
+		assert.Len(t, id, 7)
+		assert.False(t, seen[id])
+		seen[id] = true
+	}
+}
+
+func TestCollisionChance(t *testing.T) {
+	assert.Zero(t, CollisionChance(1, 7), "one ID collides with nothing")
+	assert.InDelta(t, 0.5, CollisionChance(73, 2), 0.01, "the birthday bound: sqrt(2 ln 2 · 62²) IDs for even odds")
+	assert.InDelta(t, 0.0023, CollisionChance(1e6, 8), 0.0001)
+	assert.Greater(t, CollisionChance(1e6, 5), 0.999)
+}
@@ -0,0 +1,69 @@
+package examples
+
+// This file shows:
+// - An LRU cache: a map to find an entry, and a container/list to keep the entries in order of use
+// - Moving an entry to the front on every hit, which is what makes it least recently used, not first in
+// - A FIFO cache, the same but for that move, for comparison
+// - Measuring a hit ratio on skewed traffic, drawn from rand.Zipf
+
+import (
+	"container/list"
+	"fmt"
+	"math/rand"
+)
+
+// LRU is a cache of at most Size keys, which evicts the least recently
+// used. With FIFO set, a hit does not count as a use, and it evicts the
+// key it took in first.
+type LRU struct {
+	Size int
+	FIFO bool
+
+	entries map[int]*list.Element
+	order   list.List // most recently used, or added, at the front
+}
+
+// Get reports whether key is cached, and caches it if not.
+func (c *LRU) Get(key int) (hit bool) {
+	if c.entries == nil {
+		c.entries = make(map[int]*list.Element)
+	}
+	if e, ok := c.entries[key]; ok {
+		if !c.FIFO {
+			c.order.MoveToFront(e)
+		}
+		return true
+	}
+	c.entries[key] = c.order.PushFront(key)
+	if c.order.Len() > c.Size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(int))
+	}
+	return false
+}
+
+// HitRatio returns the share of n requests, for keys drawn from r with a
+// Zipf distribution of parameter s over keys, that c answers.
+func HitRatio(c *LRU, r *rand.Rand, s float64, keys, n int) float64 {
+	z := rand.NewZipf(r, s, 1, uint64(keys-1))
+	hits := 0
+	for i := 0; i < n; i++ {
+		if c.Get(int(z.Uint64())) {
+			hits++
+		}
+	}
+	return float64(hits) / float64(n)
+}
+
+// DemonstrateLRU compares LRU and FIFO caches of several sizes on the
+// same traffic.
+func DemonstrateLRU() {
+	fmt.Println("\n=== LRU and FIFO ===")
+	const keys, requests = 10_000, 200_000
+	for _, size := range []int{10, 100, 1000} {
+		lru := HitRatio(&LRU{Size: size}, rand.New(rand.NewSource(1)), 1.1, keys, requests)
+		fifo := HitRatio(&LRU{Size: size, FIFO: true}, rand.New(rand.NewSource(1)), 1.1, keys, requests)
+		fmt.Printf("cache of %4d of %d links: LRU answers %.0f%%, FIFO %.0f%%\n", size, keys, 100*lru, 100*fifo)
+	}
+}
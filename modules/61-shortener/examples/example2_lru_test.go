@@ -0,0 +1,36 @@
+package examples
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateLRU(t *testing.T) {
+	DemonstrateLRU()
+}
+
+func TestLRU(t *testing.T) {
+	c := &LRU{Size: 2}
+	assert.False(t, c.Get(1))
+	assert.False(t, c.Get(2))
+	assert.True(t, c.Get(1))
+	assert.False(t, c.Get(3), "evicts 2")
+	assert.True(t, c.Get(1))
+	assert.False(t, c.Get(2))
+
+	f := &LRU{Size: 2, FIFO: true}
+	f.Get(1)
+	f.Get(2)
+	f.Get(1)
+	f.Get(3) // evicts 1, first in
+	assert.False(t, f.Get(1))
+}
+
+func TestHitRatio(t *testing.T) {
+	lru := HitRatio(&LRU{Size: 100}, rand.New(rand.NewSource(1)), 1.1, 10_000, 50_000)
+	fifo := HitRatio(&LRU{Size: 100, FIFO: true}, rand.New(rand.NewSource(1)), 1.1, 10_000, 50_000)
+	assert.Greater(t, lru, fifo)
+	assert.Greater(t, lru, 0.4, "1% of the links answer most requests")
+}
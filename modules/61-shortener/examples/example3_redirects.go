@@ -0,0 +1,65 @@
+package examples
+
+// This file shows:
+// - The four redirects: 301 and 308 are permanent, 302 and 307 are not
+// - That browsers cache a permanent redirect, and follow it from then on without asking the server
+// - That a client follows 301 and 302 with a GET, even after a POST, and 307 and 308 with the same method
+// - http.Client.CheckRedirect, to see each redirect a client follows
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// Redirect describes an HTTP redirect status.
+type Redirect struct {
+	Code      int
+	Permanent bool // browsers cache it
+}
+
+// Redirects are the redirects a server chooses from.
+var Redirects = []Redirect{
+	{http.StatusMovedPermanently, true},
+	{http.StatusFound, false},
+	{http.StatusTemporaryRedirect, false},
+	{http.StatusPermanentRedirect, true},
+}
+
+// FollowPOST sends a POST to a server that answers it with a redirect of
+// code, and returns the method the client used to follow it.
+func FollowPOST(code int) (string, error) {
+	var followed string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/from" {
+			http.Redirect(w, r, "/to", code)
+			return
+		}
+		followed = r.Method
+	}))
+	defer srv.Close()
+	resp, err := http.Post(srv.URL+"/from", "text/plain", strings.NewReader("body"))
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return followed, nil
+}
+
+// DemonstrateRedirects follows a POST through each redirect.
+func DemonstrateRedirects() {
+	fmt.Println("\n=== Redirects ===")
+	for _, r := range Redirects {
+		method, err := FollowPOST(r.Code)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		kind := "temporary, asked again each time"
+		if r.Permanent {
+			kind = "permanent, cached by browsers"
+		}
+		fmt.Printf("%d %-18s %-33s a POST is followed with %s\n", r.Code, http.StatusText(r.Code), kind, method)
+	}
+}
@@ -0,0 +1,26 @@
+package examples
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateRedirects(t *testing.T) {
+	DemonstrateRedirects()
+}
+
+func TestFollowPOST(t *testing.T) {
+	for code, want := range map[int]string{
+		http.StatusMovedPermanently:  http.MethodGet,
+		http.StatusFound:             http.MethodGet,
+		http.StatusTemporaryRedirect: http.MethodPost,
+		http.StatusPermanentRedirect: http.MethodPost,
+	} {
+		got, err := FollowPOST(code)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "%d", code)
+	}
+}
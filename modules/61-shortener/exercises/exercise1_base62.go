@@ -0,0 +1,52 @@
+package exercises
+
+// EXERCISE: Milestone 1: fix the base62 codec that turns a link's number into its short ID, and back.
+//
+// Each link has a number, 1, 2, 3, and so on, and its ID is the number in
+// base62: digits, then capital letters, then small ones, 62 characters
+// that need no escaping in a URL. Eleven of them reach any uint64. This
+// codec gets 0 wrong, writes its digits backwards, and decodes strings
+// that are no ID at all.
+//
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Alphabet holds the digits of base62, in order: the 62 characters that
+// need no escaping anywhere in a URL.
+const Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// ErrBadID is returned, wrapped, for a string that is not an ID.
+var ErrBadID = errors.New("bad ID")
+
+// Encode returns the base62 ID of n.
+func Encode(n uint64) string {
+	var buf []byte
+	// BUG: 0 has no digits this way, and its ID is "", which no route matches
+	for n > 0 {
+		buf = append(buf, Alphabet[n%62])
+		n /= 62
+	}
+	// BUG: the digits come out least significant first, and Decode reads them the other way
+	return string(buf)
+}
+
+// Decode returns the number whose ID is id.
+func Decode(id string) (uint64, error) {
+	// BUG: "007" decodes as 7, a second ID for one link; only "0" itself may start with a zero
+	if id == "" {
+		return 0, fmt.Errorf("%w: %q", ErrBadID, id)
+	}
+	var n uint64
+	for i := 0; i < len(id); i++ {
+		d := strings.IndexByte(Alphabet, id[i])
+		// BUG: a character outside the alphabet is d == -1, which goes into n as a huge number
+		// BUG: an ID too large for a uint64 overflows n, and wraps round to some other link
+		n = n*62 + uint64(d)
+	}
+	return n, nil
+}
@@ -0,0 +1,56 @@
+package exercises
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	for n, want := range map[uint64]string{
+		0:              "0",
+		9:              "9",
+		10:             "A",
+		61:             "z",
+		62:             "10",
+		63:             "11",
+		3843:           "zz",
+		3844:           "100",
+		1_000_000:      "4C92",
+		math.MaxUint64: "LygHa16AHYF",
+	} {
+		assert.Equal(t, want, Encode(n), "Encode(%d)", n)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	for id, want := range map[string]uint64{"0": 0, "z": 61, "10": 62, "4C92": 1_000_000, "LygHa16AHYF": math.MaxUint64} {
+		n, err := Decode(id)
+		require.NoError(t, err, id)
+		assert.Equal(t, want, n, "Decode(%q)", id)
+	}
+}
+
+func TestDecodeBad(t *testing.T) {
+	for _, id := range []string{"", "ab-c", "a b", "ab/", "é", "%41", "LygHa16AHYG", "zzzzzzzzzzz", "100000000000", "00", "0A"} {
+		_, err := Decode(id)
+		assert.ErrorIs(t, err, ErrBadID, "%q", id)
+	}
+}
+
+func TestBase62RoundTrip(t *testing.T) {
+	r := seed.Rand(t, 1)
+	for i := 0; i < 1000; i++ {
+		n := r.Uint64() >> r.Intn(64)
+		id := Encode(n)
+		assert.LessOrEqual(t, len(id), 11)
+		assert.Equal(t, strings.Trim(id, Alphabet), "", "only the characters of the alphabet")
+		got, err := Decode(id)
+		require.NoError(t, err, id)
+		require.Equal(t, n, got, "Decode(Encode(%d))", n)
+	}
+}
@@ -0,0 +1,189 @@
+package exercises
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowRepo is a Repository that takes a while to answer Get, as a
+// database does, and counts the calls. It fails the next fail calls.
+type slowRepo struct {
+	Repository
+	delay time.Duration
+	gets  atomic.Int64
+	fail  atomic.Int64
+}
+
+var errDown = errors.New("repository down")
+
+func (r *slowRepo) Get(ctx context.Context, id string) (Link, error) {
+	r.gets.Add(1)
+	time.Sleep(r.delay)
+	if r.fail.Add(-1) >= 0 {
+		return Link{}, errDown
+	}
+	return r.Repository.Get(ctx, id)
+}
+
+// newRepo returns a slowRepo with n links, to https://example.com/1 and
+// on, whose IDs are Encode(1) and on.
+func newRepo(t *testing.T, n int, delay time.Duration) *slowRepo {
+	t.Helper()
+	mem := NewMemRepository()
+	for i := 1; i <= n; i++ {
+		_, err := mem.Create(context.Background(), fmt.Sprint("https://example.com/", i))
+		require.NoError(t, err)
+	}
+	return &slowRepo{Repository: mem, delay: delay}
+}
+
+// follow looks link i up in c, and fails the test unless it finds its URL.
+func follow(t *testing.T, c *Cache, i int) {
+	t.Helper()
+	url, err := c.URL(context.Background(), Encode(uint64(i)))
+	if assert.NoError(t, err, "link %d", i) {
+		assert.Equal(t, fmt.Sprint("https://example.com/", i), url)
+	}
+}
+
+// popular returns a function that picks a link from 1 to n, the first far
+// more often than the last, as the links of a shortener are followed.
+func popular(r *rand.Rand, n int) func() int {
+	z := rand.NewZipf(r, 1.3, 1, uint64(n-1))
+	return func() int { return int(z.Uint64()) + 1 }
+}
+
+func TestMemRepository(t *testing.T) {
+	ctx := context.Background()
+	r := NewMemRepository()
+	a, err := r.Create(ctx, "https://go.dev/")
+	require.NoError(t, err)
+	b, _ := r.Create(ctx, "https://pkg.go.dev/")
+	assert.Equal(t, "1", a.ID)
+	assert.Equal(t, "2", b.ID)
+	require.NoError(t, r.AddHits(ctx, "2", 5))
+	got, err := r.Get(ctx, "2")
+	require.NoError(t, err)
+	assert.Equal(t, "https://pkg.go.dev/", got.URL)
+	assert.EqualValues(t, 5, got.Hits)
+	_, err = r.Get(ctx, "3")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, r.AddHits(ctx, "3", 1), ErrNotFound)
+}
+
+func TestCacheHits(t *testing.T) {
+	repo := newRepo(t, 3, 0)
+	c := NewCache(repo, 10)
+	for round := 0; round < 3; round++ {
+		for i := 1; i <= 3; i++ {
+			follow(t, c, i)
+		}
+	}
+	assert.EqualValues(t, 3, repo.gets.Load(), "one lookup for each link")
+	hits, misses := c.Stats()
+	assert.EqualValues(t, 6, hits)
+	assert.EqualValues(t, 3, misses)
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	repo := newRepo(t, 3, 0)
+	c := NewCache(repo, 2)
+	follow(t, c, 1)
+	follow(t, c, 2)
+	follow(t, c, 1) // a hit, which makes 1 the most recently used
+	follow(t, c, 3) // evicts 2
+	assert.Equal(t, 2, c.Len())
+	assert.EqualValues(t, 3, repo.gets.Load())
+	follow(t, c, 1)
+	assert.EqualValues(t, 3, repo.gets.Load(), "link 1 was used more recently than link 2")
+	follow(t, c, 2)
+	assert.EqualValues(t, 4, repo.gets.Load(), "link 2 was evicted")
+}
+
+func TestCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t, 1, 0)
+	c := NewCache(repo, 10)
+	_, err := c.URL(ctx, "2")
+	assert.ErrorIs(t, err, ErrNotFound)
+	repo.Create(ctx, "https://example.com/2")
+	follow(t, c, 2)
+
+	repo.fail.Store(1)
+	_, err = c.URL(ctx, "1")
+	assert.ErrorIs(t, err, errDown)
+	follow(t, c, 1)
+	follow(t, c, 1)
+	assert.EqualValues(t, 4, repo.gets.Load(), "failures are not cached, and successes are")
+}
+
+func TestCacheConcurrentMisses(t *testing.T) {
+	repo := newRepo(t, 5, 20*time.Millisecond)
+	c := NewCache(repo, 10)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for g := 0; g < 50; g++ {
+		i := g%5 + 1
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			follow(t, c, i)
+		}()
+	}
+	close(start)
+	wg.Wait()
+	assert.EqualValues(t, 5, repo.gets.Load(), "50 requests for 5 links that are not cached: one lookup for each link")
+}
+
+func TestCacheConcurrentMissCanceled(t *testing.T) {
+	repo := newRepo(t, 1, 50*time.Millisecond)
+	c := NewCache(repo, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := c.URL(ctx, "1")
+		first <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	follow(t, c, 1)
+	assert.NoError(t, <-first, "the lookup went on for the other request")
+}
+
+func TestCacheLoad(t *testing.T) {
+	const links, size, workers, each = 200, 40, 8, 500
+	repo := newRepo(t, links, 0)
+	c := NewCache(repo, size)
+	var wg sync.WaitGroup
+	for g := 0; g < workers; g++ {
+		r := seed.Rand(t, int64(10+g))
+		popular := popular(r, links)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < each; i++ {
+				follow(t, c, popular())
+			}
+		}()
+	}
+	wg.Wait()
+	hits, misses := c.Stats()
+	require.EqualValues(t, workers*each, hits+misses)
+	ratio := float64(hits) / float64(hits+misses)
+	t.Logf("%d hits, %d misses, %d lookups: %.0f%% of requests answered from a cache of %d of %d links",
+		hits, misses, repo.gets.Load(), 100*ratio, size, links)
+	assert.Greater(t, ratio, 0.75, "a few links get most of the traffic, and a cache of those answers most requests")
+	assert.LessOrEqual(t, repo.gets.Load(), misses)
+	assert.LessOrEqual(t, c.Len(), size)
+}
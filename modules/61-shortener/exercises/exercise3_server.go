@@ -0,0 +1,304 @@
+package exercises
+
+// EXERCISE: Milestone 3: fix the HTTP server that creates short links, redirects them, and counts their hits.
+//
+// The server creates links, redirects their short URLs through the cache,
+// and counts each hit in memory, flushing the counts to the repository
+// every so often. This one shortens any string that parses as a URL,
+// redirects so that browsers stop coming back to be counted, and loses
+// hits, some for good and some until the next flush.
+//
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics counts events by name, such as `http_requests_total{code="302"}`,
+// and serves them in the text format Prometheus scrapes. It is safe for
+// concurrent use.
+type Metrics struct {
+	mu       sync.Mutex
+	counters map[string]int64
+}
+
+// NewMetrics returns Metrics with every counter at 0.
+func NewMetrics() *Metrics {
+	return &Metrics{counters: make(map[string]int64)}
+}
+
+// Inc adds 1 to the counter name.
+func (m *Metrics) Inc(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counters[name]++
+}
+
+// Set sets the counter name to n, for counts kept elsewhere.
+func (m *Metrics) Set(name string, n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counters[name] = n
+}
+
+// Get returns the counter name.
+func (m *Metrics) Get(name string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counters[name]
+}
+
+// ServeHTTP writes every counter, one to a line, sorted by name.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	names := make([]string, 0, len(m.counters))
+	for name := range m.counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s %d\n", name, m.counters[name])
+	}
+	m.mu.Unlock()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	io.WriteString(w, b.String())
+}
+
+// Hits counts the hits of links in memory, until Flush adds them to the
+// repository: a write to the repository for every redirect would cost
+// what the cache saves. It is safe for concurrent use.
+type Hits struct {
+	mu      sync.Mutex
+	pending map[string]int64
+}
+
+// NewHits returns Hits with none pending.
+func NewHits() *Hits {
+	return &Hits{pending: make(map[string]int64)}
+}
+
+// Add counts a hit of the link id.
+func (h *Hits) Add(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pending[id]++
+}
+
+// Pending returns the hits of the link id not yet flushed.
+func (h *Hits) Pending(id string) int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.pending[id]
+}
+
+// Flush adds the pending hits to repo. Those it cannot add stay pending.
+func (h *Hits) Flush(ctx context.Context, repo Repository) error {
+	h.mu.Lock()
+	pending := make(map[string]int64, len(h.pending))
+	for id, n := range h.pending {
+		pending[id] = n
+	}
+	h.mu.Unlock()
+
+	var errs []error
+	for id, n := range pending {
+		if err := repo.AddHits(ctx, id, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	// BUG: forgets every pending hit, those counted while the loop above was writing, and those it failed to write
+	h.mu.Lock()
+	h.pending = make(map[string]int64)
+	h.mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// MaxURL is the longest URL the server shortens, in bytes.
+const MaxURL = 2048
+
+// Server is the HTTP side of the shortener:
+//
+//	POST /links       {"url": "https://..."}: 201, and the link with its short URL
+//	GET  /links/{id}  the link, with its hits
+//	GET  /metrics     the counters
+//	GET  /{id}        302 to the link's URL
+type Server struct {
+	Repo    Repository
+	Cache   *Cache
+	Hits    *Hits
+	Metrics *Metrics
+	Base    string // what short URLs start with, such as https://sho.rt
+}
+
+// NewServer returns a Server of the links in repo, with a cache of
+// cacheSize of them.
+func NewServer(repo Repository, cacheSize int, base string) *Server {
+	return &Server{
+		Repo:    repo,
+		Cache:   NewCache(repo, cacheSize),
+		Hits:    NewHits(),
+		Metrics: NewMetrics(),
+		Base:    strings.TrimSuffix(base, "/"),
+	}
+}
+
+// Handler returns the server's routes, each request counted in Metrics by
+// its status code.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/links", s.create)
+	mux.HandleFunc("/links/", s.link)
+	mux.HandleFunc("/metrics", s.metrics)
+	mux.HandleFunc("/", s.redirect)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		mux.ServeHTTP(rec, r)
+		s.Metrics.Inc(fmt.Sprintf(`http_requests_total{code="%d"}`, rec.code))
+	})
+}
+
+// statusRecorder remembers the status code a handler writes.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// FlushEvery flushes the pending hits every interval until ctx is done,
+// and once more then.
+func (s *Server) FlushEvery(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			s.Hits.Flush(ctx, s.Repo)
+		case <-ctx.Done():
+			s.Hits.Flush(context.WithoutCancel(ctx), s.Repo)
+			return
+		}
+	}
+}
+
+// linkResponse is a link as the API shows it.
+type linkResponse struct {
+	Link
+	ShortURL string `json:"short_url"`
+}
+
+// create serves POST /links.
+func (s *Server) create(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" is not allowed")
+		return
+	}
+	var req struct {
+		URL string `json:"url"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxURL+1024)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := checkURL(req.URL); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	link, err := s.Repo.Create(r.Context(), req.URL)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.Metrics.Inc("links_created_total")
+	w.Header().Set("Location", "/links/"+link.ID)
+	writeJSON(w, http.StatusCreated, linkResponse{Link: link, ShortURL: s.Base + "/" + link.ID})
+}
+
+// checkURL returns an error unless u is an absolute http or https URL.
+func checkURL(u string) error {
+	if len(u) > MaxURL {
+		return fmt.Errorf("url is over %d bytes", MaxURL)
+	}
+	// BUG: anything url.Parse accepts will do: "example.com", "/path", and "javascript:alert(1)"
+	_, err := url.Parse(u)
+	return err
+}
+
+// link serves GET /links/{id}.
+func (s *Server) link(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" is not allowed")
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/links/")
+	link, err := s.Repo.Get(r.Context(), id)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		writeError(w, http.StatusNotFound, "no link "+id)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	default:
+		// BUG: shows only the hits flushed to the repository, not those still pending
+		writeJSON(w, http.StatusOK, linkResponse{Link: link, ShortURL: s.Base + "/" + link.ID})
+	}
+}
+
+// redirect serves GET /{id}.
+func (s *Server) redirect(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" is not allowed")
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/")
+	// A string that is no ID is no link, and need not reach the cache.
+	if _, err := Decode(id); err != nil {
+		writeError(w, http.StatusNotFound, "no link "+id)
+		return
+	}
+	target, err := s.Cache.URL(r.Context(), id)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		writeError(w, http.StatusNotFound, "no link "+id)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	default:
+		s.Hits.Add(id)
+		s.Metrics.Inc("redirects_total")
+		http.Redirect(w, r, target, http.StatusMovedPermanently) // BUG: a browser caches a 301, and follows it from then on without asking, or being counted
+	}
+}
+
+// metrics serves GET /metrics, with the cache's counts.
+func (s *Server) metrics(w http.ResponseWriter, r *http.Request) {
+	hits, misses := s.Cache.Stats()
+	s.Metrics.Set("cache_hits_total", hits)
+	s.Metrics.Set("cache_misses_total", misses)
+	s.Metrics.ServeHTTP(w, r)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
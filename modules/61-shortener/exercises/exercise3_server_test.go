@@ -0,0 +1,261 @@
+package exercises
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shortener serves a Server of the links in repo, and returns it with the
+// test server's URL, which short URLs start with.
+func shortener(t *testing.T, repo Repository, cacheSize int) (*Server, string) {
+	t.Helper()
+	srv := httptest.NewServer(nil)
+	t.Cleanup(srv.Close)
+	s := NewServer(repo, cacheSize, srv.URL)
+	srv.Config.Handler = s.Handler()
+	return s, srv.URL
+}
+
+// client does not follow redirects, so that the tests see them.
+var client = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+}
+
+// shorten creates a link to target, and returns its ID.
+func shorten(t *testing.T, base, target string) string {
+	t.Helper()
+	resp, body := do(t, http.MethodPost, base+"/links", fmt.Sprintf(`{"url": %q}`, target))
+	require.Equal(t, http.StatusCreated, resp.StatusCode, body)
+	var link linkResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &link))
+	return link.ID
+}
+
+func do(t *testing.T, method, url, body string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(b)
+}
+
+func TestServerCreate(t *testing.T) {
+	_, base := shortener(t, NewMemRepository(), 10)
+	resp, body := do(t, http.MethodPost, base+"/links", `{"url": "https://go.dev/doc/"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, body)
+	assert.Equal(t, "/links/1", resp.Header.Get("Location"))
+	var link linkResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &link))
+	assert.Equal(t, "1", link.ID)
+	assert.Equal(t, "https://go.dev/doc/", link.URL)
+	assert.Equal(t, base+"/1", link.ShortURL)
+	assert.Equal(t, "2", shorten(t, base, "https://go.dev/doc/"), "a link each time, even to the same URL")
+
+	resp, _ = do(t, http.MethodPost, base+"/links", `{"url": `)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = do(t, http.MethodGet, base+"/links", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestServerChecksURLs(t *testing.T) {
+	_, base := shortener(t, NewMemRepository(), 10)
+	for _, target := range []string{"https://example.com", "http://localhost:8080/a/b?c=d#e"} {
+		shorten(t, base, target)
+	}
+	for _, target := range []string{
+		"",
+		"example.com",
+		"/relative/path",
+		"javascript:alert(document.cookie)",
+		"data:text/html,<script>alert(1)</script>",
+		"ftp://example.com/file",
+		"https://",
+		"https://example.com/" + strings.Repeat("a", MaxURL),
+	} {
+		resp, body := do(t, http.MethodPost, base+"/links", fmt.Sprintf(`{"url": %q}`, target))
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "%.40q: %s", target, body)
+	}
+}
+
+func TestServerRedirect(t *testing.T) {
+	_, base := shortener(t, NewMemRepository(), 10)
+	id := shorten(t, base, "https://go.dev/blog/")
+	resp, _ := do(t, http.MethodGet, base+"/"+id, "")
+	assert.Equal(t, http.StatusFound, resp.StatusCode, "a permanent redirect is cached by browsers, which then never come back to be counted")
+	assert.Equal(t, "https://go.dev/blog/", resp.Header.Get("Location"))
+
+	for _, path := range []string{"/2", "/zzzzzz", "/not-an-id", "/01", "/"} {
+		resp, _ := do(t, http.MethodGet, base+path, "")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, path)
+	}
+	resp, _ = do(t, http.MethodPost, base+"/"+id, "")
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestServerHits(t *testing.T) {
+	repo := NewMemRepository()
+	s, base := shortener(t, repo, 10)
+	a := shorten(t, base, "https://example.com/a")
+	b := shorten(t, base, "https://example.com/b")
+	for i := 0; i < 3; i++ {
+		do(t, http.MethodGet, base+"/"+a, "")
+	}
+	do(t, http.MethodGet, base+"/"+b, "")
+
+	hits := func(id string) int64 {
+		_, body := do(t, http.MethodGet, base+"/links/"+id, "")
+		var link linkResponse
+		require.NoError(t, json.Unmarshal([]byte(body), &link), body)
+		return link.Hits
+	}
+	assert.EqualValues(t, 3, hits(a), "hits not yet flushed count")
+	assert.EqualValues(t, 1, hits(b))
+	require.NoError(t, s.Hits.Flush(context.Background(), repo))
+	assert.EqualValues(t, 3, hits(a), "once flushed, hits count once")
+	stored, _ := repo.Get(context.Background(), a)
+	assert.EqualValues(t, 3, stored.Hits)
+	resp, _ := do(t, http.MethodGet, base+"/links/9", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+// slowWrites is a Repository whose AddHits takes a while, and fails while
+// down is set.
+type slowWrites struct {
+	Repository
+	down atomic.Bool
+}
+
+func (r *slowWrites) AddHits(ctx context.Context, id string, n int64) error {
+	time.Sleep(time.Millisecond)
+	if r.down.Load() {
+		return errDown
+	}
+	return r.Repository.AddHits(ctx, id, n)
+}
+
+func TestHitsFlush(t *testing.T) {
+	ctx := context.Background()
+	repo := &slowWrites{Repository: newRepo(t, 3, 0)}
+	h := NewHits()
+	const adders, each = 8, 200
+	stop := make(chan struct{})
+	flushed := make(chan struct{})
+	go func() {
+		defer close(flushed)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				h.Flush(ctx, repo)
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	for g := 0; g < adders; g++ {
+		id := Encode(uint64(g%3 + 1))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < each; i++ {
+				h.Add(id)
+				time.Sleep(10 * time.Microsecond)
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+	<-flushed
+	require.NoError(t, h.Flush(ctx, repo))
+
+	var total int64
+	for i := 1; i <= 3; i++ {
+		link, _ := repo.Get(ctx, Encode(uint64(i)))
+		total += link.Hits
+	}
+	assert.EqualValues(t, adders*each, total, "every hit, including those counted while a flush was writing")
+
+	h.Add("1")
+	repo.down.Store(true)
+	assert.ErrorIs(t, h.Flush(ctx, repo), errDown)
+	assert.EqualValues(t, 1, h.Pending("1"), "kept for the next flush")
+	repo.down.Store(false)
+	require.NoError(t, h.Flush(ctx, repo))
+	assert.Zero(t, h.Pending("1"))
+}
+
+func TestServerLoad(t *testing.T) {
+	const links, workers, each = 50, 8, 200
+	repo := &slowRepo{Repository: NewMemRepository(), delay: 2 * time.Millisecond}
+	s, base := shortener(t, repo, links)
+	for i := 1; i <= links; i++ {
+		shorten(t, base, fmt.Sprint("https://example.com/", i))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	flushing := make(chan struct{})
+	go func() {
+		defer close(flushing)
+		s.FlushEvery(ctx, 5*time.Millisecond)
+	}()
+
+	var wg sync.WaitGroup
+	for g := 0; g < workers; g++ {
+		popular := popular(seed.Rand(t, int64(20+g)), links)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < each; i++ {
+				resp, err := client.Get(base + "/" + Encode(uint64(popular())))
+				if !assert.NoError(t, err) {
+					return
+				}
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+	cancel()
+	<-flushing
+
+	var total int64
+	for i := 1; i <= links; i++ {
+		link, err := repo.Repository.Get(context.Background(), Encode(uint64(i)))
+		require.NoError(t, err)
+		total += link.Hits
+	}
+	assert.EqualValues(t, workers*each, total, "every redirect counted, once")
+
+	resp, body := do(t, http.MethodGet, base+"/metrics", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	t.Logf("metrics:\n%s", body)
+	assert.EqualValues(t, workers*each, s.Metrics.Get(`http_requests_total{code="302"}`))
+	assert.EqualValues(t, workers*each, s.Metrics.Get("redirects_total"))
+	hits, misses := s.Cache.Stats()
+	assert.Contains(t, body, fmt.Sprintf("cache_hits_total %d\n", hits))
+	assert.LessOrEqual(t, repo.gets.Load(), int64(links), "the cache holds every link: one lookup each, at most")
+	assert.Greater(t, float64(hits)/float64(hits+misses), 0.95)
+}
+
+func TestServerBodyLimit(t *testing.T) {
+	_, base := shortener(t, NewMemRepository(), 10)
+	body := `{"url": "https://example.com/` + strings.Repeat("a", 1<<20) + `"}`
+	resp, _ := do(t, http.MethodPost, base+"/links", body)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "the body is cut off long before the URL ends")
+}
@@ -0,0 +1,70 @@
+// Command shortd runs a URL shortener, with its links in memory.
+//
+//	go run ./modules/61-shortener/exercises/shortd -addr :8080
+//	curl -d '{"url": "https://go.dev/"}' localhost:8080/links
+//	curl -i localhost:8080/1
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/61-shortener/exercises"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	base := flag.String("base", "http://localhost:8080", "what short URLs start with")
+	cache := flag.Int("cache", 10000, "links to keep in the cache")
+	flush := flag.Duration("flush", time.Second, "how often hits are written to the repository")
+	flag.Parse()
+
+	s := exercises.NewServer(exercises.NewMemRepository(), *cache, *base)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := run(ctx, s, *addr, *flush); err != nil {
+		fmt.Fprintln(os.Stderr, "shortd:", err)
+		os.Exit(1)
+	}
+}
+
+// run serves s on addr until ctx is done, and flushes its hits every
+// flush until then.
+func run(ctx context.Context, s *exercises.Server, addr string, flush time.Duration) error {
+	srv := &http.Server{Addr: addr, Handler: s.Handler(), ReadHeaderTimeout: 5 * time.Second}
+	flushCtx, stopFlushing := context.WithCancel(context.Background())
+	flushed := make(chan struct{})
+	go func() {
+		defer close(flushed)
+		s.FlushEvery(flushCtx, flush)
+	}()
+
+	errs := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			errs <- err
+			return
+		}
+		errs <- nil
+	}()
+	fmt.Printf("shortd: on %s, short URLs at %s\n", addr, s.Base)
+
+	var err error
+	select {
+	case err = <-errs:
+	case <-ctx.Done():
+	}
+	shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	srv.Shutdown(shutdown)
+	// The last redirects are in; count them.
+	stopFlushing()
+	<-flushed
+	return err
+}
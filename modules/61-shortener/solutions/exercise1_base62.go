@@ -0,0 +1,56 @@
+package solutions
+
+// SOLUTION: Milestone 1: fix the base62 codec that turns a link's number into its short ID, and back.
+// Fixed: Encode(0) is "0", not "", which no route can match
+// Fixed: Encode writes the most significant digit first, as Decode reads it
+// Fixed: Decode rejects a character outside the alphabet instead of reading it as -1
+// Fixed: Decode rejects an ID too large for a uint64 instead of wrapping round to a small one
+// Fixed: Decode rejects leading zeros, so each number has one ID, and "007" is not a second name for "7"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Alphabet holds the digits of base62, in order: the 62 characters that
+// need no escaping anywhere in a URL.
+const Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// ErrBadID is returned, wrapped, for a string that is not an ID.
+var ErrBadID = errors.New("bad ID")
+
+// Encode returns the base62 ID of n.
+func Encode(n uint64) string {
+	if n == 0 {
+		return Alphabet[:1]
+	}
+	var buf [11]byte // 62^11 > 2^64
+	i := len(buf)
+	for n > 0 {
+		i--
+		buf[i] = Alphabet[n%62]
+		n /= 62
+	}
+	return string(buf[i:])
+}
+
+// Decode returns the number whose ID is id.
+func Decode(id string) (uint64, error) {
+	if id == "" || (len(id) > 1 && id[0] == Alphabet[0]) {
+		return 0, fmt.Errorf("%w: %q", ErrBadID, id)
+	}
+	var n uint64
+	for i := 0; i < len(id); i++ {
+		d := strings.IndexByte(Alphabet, id[i])
+		if d < 0 {
+			return 0, fmt.Errorf("%w: %q", ErrBadID, id)
+		}
+		if n > (math.MaxUint64-uint64(d))/62 {
+			return 0, fmt.Errorf("%w: %q is too large", ErrBadID, id)
+		}
+		n = n*62 + uint64(d)
+	}
+	return n, nil
+}
@@ -0,0 +1,182 @@
+package solutions
+
+// SOLUTION: Milestone 2: fix the cache that answers most redirects without asking the repository.
+// Fixed: a hit moves its link to the front, so the links in use stay, and the least recently used is the one evicted
+// Fixed: a failed lookup is not cached: the link may not exist yet, or the repository may be down for a moment
+// Fixed: a miss that another request is already looking up waits for that lookup, instead of making its own
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Link is a short link: its ID, the URL it leads to, and how often it has
+// been followed.
+type Link struct {
+	ID      string    `json:"id"`
+	URL     string    `json:"url"`
+	Hits    int64     `json:"hits"`
+	Created time.Time `json:"created"`
+}
+
+// ErrNotFound is returned, wrapped, for a link that does not exist.
+var ErrNotFound = errors.New("link not found")
+
+// Repository keeps links. MemRepository keeps them in memory; a database
+// would do in production, and be slower.
+type Repository interface {
+	// Create stores a link to url, gives it the next ID, and returns it.
+	Create(ctx context.Context, url string) (Link, error)
+	// Get returns the link id.
+	Get(ctx context.Context, id string) (Link, error)
+	// AddHits adds n to the hits of the link id.
+	AddHits(ctx context.Context, id string, n int64) error
+}
+
+// MemRepository is a Repository in memory. It numbers links from 1, and
+// their IDs are the numbers in base62. It is safe for concurrent use.
+type MemRepository struct {
+	mu    sync.Mutex
+	links map[string]*Link
+	next  uint64
+}
+
+// NewMemRepository returns an empty MemRepository.
+func NewMemRepository() *MemRepository {
+	return &MemRepository{links: make(map[string]*Link)}
+}
+
+// Create implements Repository.
+func (r *MemRepository) Create(ctx context.Context, url string) (Link, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.next++
+	l := &Link{ID: Encode(r.next), URL: url, Created: time.Now()}
+	r.links[l.ID] = l
+	return *l, nil
+}
+
+// Get implements Repository.
+func (r *MemRepository) Get(ctx context.Context, id string) (Link, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l, ok := r.links[id]
+	if !ok {
+		return Link{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return *l, nil
+}
+
+// AddHits implements Repository.
+func (r *MemRepository) AddHits(ctx context.Context, id string, n int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l, ok := r.links[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	l.Hits += n
+	return nil
+}
+
+// Cache keeps the URLs of the Size links used most recently, in front of
+// a Repository. A link's URL never changes, so a cached one is never
+// stale. It is safe for concurrent use.
+type Cache struct {
+	Repo Repository
+	Size int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element // of *entry
+	lru     list.List                // most recently used at the front
+	lookups map[string]*lookup       // misses being looked up
+	hits    int64
+	misses  int64
+}
+
+type entry struct {
+	id, url string
+}
+
+// lookup is a Repository.Get in progress, which other misses for the same
+// link wait for. url and err are set before done is closed.
+type lookup struct {
+	done chan struct{}
+	url  string
+	err  error
+}
+
+// NewCache returns a Cache of size links in front of repo.
+func NewCache(repo Repository, size int) *Cache {
+	return &Cache{Repo: repo, Size: size, entries: make(map[string]*list.Element), lookups: make(map[string]*lookup)}
+}
+
+// URL returns the URL of the link id, from the cache if it can.
+func (c *Cache) URL(ctx context.Context, id string) (string, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[id]; ok {
+		c.hits++
+		c.lru.MoveToFront(e)
+		c.mu.Unlock()
+		return e.Value.(*entry).url, nil
+	}
+	c.misses++
+	if l, ok := c.lookups[id]; ok {
+		c.mu.Unlock()
+		select {
+		case <-l.done:
+			return l.url, l.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	l := &lookup{done: make(chan struct{})}
+	c.lookups[id] = l
+	c.mu.Unlock()
+
+	// Others may wait for this lookup: it goes on if this client hangs up.
+	link, err := c.Repo.Get(context.WithoutCancel(ctx), id)
+	l.url, l.err = link.URL, err
+
+	c.mu.Lock()
+	delete(c.lookups, id)
+	if err == nil {
+		c.add(id, link.URL)
+	}
+	c.mu.Unlock()
+	close(l.done)
+	return l.url, l.err
+}
+
+// add caches the URL of id, and evicts the least recently used link if
+// the cache is over its size. c.mu must be held.
+func (c *Cache) add(id, url string) {
+	if c.Size <= 0 {
+		return
+	}
+	c.entries[id] = c.lru.PushFront(&entry{id: id, url: url})
+	if c.lru.Len() > c.Size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry).id)
+	}
+}
+
+// Len returns the number of links cached.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Stats returns how many lookups the cache answered, and how many it
+// passed on or waited for.
+func (c *Cache) Stats() (hits, misses int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
@@ -0,0 +1,71 @@
+// Package examples demonstrates the parts of a static site generator:
+// files built into the program with embed, walking a tree of files with
+// fs.WalkDir, and a pool of workers that stops at the first error.
+//
+// This file shows:
+// - //go:embed, into a string, and into an embed.FS of a whole directory
+// - That a directory leaves out files starting with . or _, unless it is embedded with all:
+// - fs.Sub, to serve an embedded directory from its root
+// - http.FileServer over http.FS, which serves files built into the binary
+package examples
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+)
+
+//go:embed assets/hello.txt
+var hello string
+
+//go:embed assets
+var assets embed.FS
+
+//go:embed all:assets
+var allAssets embed.FS
+
+// EmbeddedFiles returns the paths of the files in fsys.
+func EmbeddedFiles(fsys fs.FS) ([]string, error) {
+	var paths []string
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			paths = append(paths, p)
+		}
+		return err
+	})
+	return paths, err
+}
+
+// Assets returns a handler that serves the embedded assets directory:
+// GET /css/site.css is assets/css/site.css.
+func Assets() http.Handler {
+	sub, err := fs.Sub(assets, "assets")
+	if err != nil {
+		panic(err) // only if "assets" is not a valid path
+	}
+	return http.FileServer(http.FS(sub))
+}
+
+// DemonstrateEmbed lists the embedded files, and serves one.
+func DemonstrateEmbed() {
+	fmt.Println("\n=== Embed ===")
+	fmt.Printf("hello.txt, as a string: %q\n", hello)
+	files, _ := EmbeddedFiles(assets)
+	fmt.Println("//go:embed assets:    ", files)
+	files, _ = EmbeddedFiles(allAssets)
+	fmt.Println("//go:embed all:assets:", files)
+
+	srv := httptest.NewServer(Assets())
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "/css/site.css")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	fmt.Printf("GET /css/site.css: %s, %s, %q\n", resp.Status, resp.Header.Get("Content-Type"), body)
+}
@@ -0,0 +1,42 @@
+package examples
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateEmbed(t *testing.T) {
+	DemonstrateEmbed()
+}
+
+func TestEmbeddedFiles(t *testing.T) {
+	assert.Equal(t, "Hello from inside the binary.\n", hello)
+	files, err := EmbeddedFiles(assets)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"assets/css/site.css", "assets/hello.txt"}, files)
+	files, err = EmbeddedFiles(allAssets)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"assets/_draft.txt", "assets/css/site.css", "assets/hello.txt"}, files)
+}
+
+func TestAssets(t *testing.T) {
+	srv := httptest.NewServer(Assets())
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "/hello.txt")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, hello, string(body))
+
+	resp, err = http.Get(srv.URL + "/_draft.txt")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
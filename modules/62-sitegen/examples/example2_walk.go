@@ -0,0 +1,86 @@
+package examples
+
+// This file shows:
+// - fs.WalkDir, which visits a tree of files in lexical order, directories before what is in them
+// - fs.SkipDir, which skips a directory returned for one, and the rest of the directory returned for a file
+// - fs.SkipAll, which ends the walk without an error
+// - testing/fstest.MapFS, a tree of files in memory, and why an fs.FS path always uses "/", never "\"
+
+import (
+	"fmt"
+	"io/fs"
+	"testing/fstest"
+)
+
+// Walk returns the paths of the files in fsys that visit keeps, in the
+// order fs.WalkDir visits them. visit is called for every file and
+// directory but the root, and the error it returns is returned to
+// WalkDir: nil, fs.SkipDir, or fs.SkipAll.
+func Walk(fsys fs.FS, visit func(path string, d fs.DirEntry) (keep bool, err error)) ([]string, error) {
+	var paths []string
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err // d may be nil: the directory could not be read
+		}
+		if p == "." {
+			return nil
+		}
+		keep, err := visit(p, d)
+		if keep && !d.IsDir() {
+			paths = append(paths, p)
+		}
+		return err
+	})
+	return paths, err
+}
+
+// hidden skips directories and files whose names start with ".", as a
+// site generator should.
+func hidden(_ string, d fs.DirEntry) (bool, error) {
+	if d.Name()[0] != '.' {
+		return true, nil
+	}
+	if d.IsDir() {
+		return false, fs.SkipDir
+	}
+	return false, nil
+}
+
+// hiddenWrong returns fs.SkipDir for hidden files too.
+func hiddenWrong(_ string, d fs.DirEntry) (bool, error) {
+	if d.Name()[0] == '.' {
+		return false, fs.SkipDir
+	}
+	return true, nil
+}
+
+// tree is a site's content in memory.
+var tree = fstest.MapFS{
+	"README.md":          {Data: []byte("# Home")},
+	".git/HEAD":          {Data: []byte("ref: refs/heads/main")},
+	"guide/.DS_Store":    {Data: []byte{0}},
+	"guide/README.md":    {Data: []byte("# Guide")},
+	"guide/install.md":   {Data: []byte("# Install")},
+	"img/gopher.png":     {Data: []byte{0x89, 'P', 'N', 'G'}},
+	"zz/last/page.md":    {Data: []byte("# Last")},
+	"guide/sub/notes.md": {Data: []byte("# Notes")},
+}
+
+// DemonstrateWalk walks one tree three ways.
+func DemonstrateWalk() {
+	fmt.Println("\n=== Walking a tree ===")
+	all, _ := Walk(tree, func(string, fs.DirEntry) (bool, error) { return true, nil })
+	fmt.Println("everything:          ", all)
+	paths, _ := Walk(tree, hidden)
+	fmt.Println("without hidden files:", paths)
+	paths, _ = Walk(tree, hiddenWrong)
+	fmt.Println("SkipDir for a file:  ", paths, "- guide/.DS_Store took the rest of guide with it")
+	n := 0
+	paths, _ = Walk(tree, func(string, fs.DirEntry) (bool, error) {
+		if n++; n > 3 {
+			return false, fs.SkipAll
+		}
+		return true, nil
+	})
+	fmt.Println("SkipAll at the 4th:  ", paths)
+}
@@ -0,0 +1,27 @@
+package examples
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstrateWalk(t *testing.T) {
+	DemonstrateWalk()
+}
+
+func TestWalk(t *testing.T) {
+	paths, err := Walk(tree, hidden)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "guide/README.md", "guide/install.md", "guide/sub/notes.md", "img/gopher.png", "zz/last/page.md"}, paths)
+
+	paths, err = Walk(tree, hiddenWrong)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "img/gopher.png", "zz/last/page.md"}, paths)
+
+	_, err = Walk(os.DirFS("no-such-dir"), hidden)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
@@ -0,0 +1,89 @@
+package examples
+
+// This file shows:
+// - A fixed pool of workers taking jobs from a channel, so that a thousand jobs are not a thousand goroutines
+// - Stopping at the first error: context.WithCancelCause, and context.Cause to return it
+// - A feeder that stops sending when the context is done, so it is never left blocked
+// - Writing each result at its index, so that the output is in order whatever order the jobs finished in
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ForEach calls fn for 0 to n-1, from workers goroutines. The first
+// error cancels the context fn is given, and the calls not yet made are
+// not made; ForEach returns that error, or ctx's.
+func ForEach(ctx context.Context, n, workers int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := fn(ctx, i); err != nil {
+					cancel(err)
+				}
+			}
+		}()
+	}
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return context.Cause(ctx)
+}
+
+// Render "renders" n pages with workers workers, each taking d, and
+// returns them in order.
+func Render(ctx context.Context, n, workers int, d time.Duration) ([]string, error) {
+	pages := make([]string, n)
+	err := ForEach(ctx, n, workers, func(ctx context.Context, i int) error {
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		pages[i] = fmt.Sprintf("page%d.html", i) // each worker its own element: no lock
+		return nil
+	})
+	return pages, err
+}
+
+// DemonstratePool renders 20 pages of 10ms each with more and more
+// workers, then fails one.
+func DemonstratePool() {
+	fmt.Println("\n=== A pool of workers ===")
+	for _, workers := range []int{1, 4, 20} {
+		start := time.Now()
+		pages, _ := Render(context.Background(), 20, workers, 10*time.Millisecond)
+		fmt.Printf("%2d workers: %3dms, %s ... %s\n", workers, time.Since(start).Milliseconds(), pages[0], pages[19])
+	}
+	var mu sync.Mutex
+	calls := 0
+	err := ForEach(context.Background(), 100, 4, func(ctx context.Context, i int) error {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		if i == 10 {
+			return errors.New("page 10: template failed")
+		}
+		return nil
+	})
+	fmt.Printf("an error at page 10 of 100: %v, after %d calls\n", err, calls)
+}
@@ -0,0 +1,65 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemonstratePool(t *testing.T) {
+	DemonstratePool()
+}
+
+func TestForEach(t *testing.T) {
+	leaktest.Check(t)
+	var running, most atomic.Int32
+	var sum atomic.Int64
+	err := ForEach(context.Background(), 50, 5, func(ctx context.Context, i int) error {
+		n := running.Add(1)
+		for m := most.Load(); n > m && !most.CompareAndSwap(m, n); m = most.Load() {
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		sum.Add(int64(i))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(49*50/2), sum.Load(), "every job once")
+	assert.LessOrEqual(t, most.Load(), int32(5))
+}
+
+func TestForEachStops(t *testing.T) {
+	leaktest.Check(t)
+	boom := errors.New("boom")
+	var calls atomic.Int32
+	err := ForEach(context.Background(), 1000, 1, func(ctx context.Context, i int) error {
+		calls.Add(1)
+		if i == 3 {
+			return boom
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, int32(4), calls.Load(), "nothing after the error")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = ForEach(ctx, 10, 2, func(context.Context, int) error {
+		t.Error("called after the context was canceled")
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRender(t *testing.T) {
+	leaktest.Check(t)
+	pages, err := Render(context.Background(), 5, 3, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"page0.html", "page1.html", "page2.html", "page3.html", "page4.html"}, pages, "in order")
+}
@@ -0,0 +1,191 @@
+package exercises
+
+// EXERCISE: Milestone 1: fix the loader that finds the pages of a site, reads their front matter, and points their links at pages.
+//
+// Load walks the content for Markdown files, skipping those that are
+// hidden or ignored, reads the front matter of each, and renders it, with
+// its links pointing at the pages the site will have. This one loses
+// pages, publishes drafts, breaks links to directories and to other
+// sites, and panics on a content directory that is not there.
+//
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/62-sitegen/exercises/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+// Meta is the front matter of a page, YAML between lines of "---" at its
+// start. All of it is optional.
+//
+//	---
+//	title: Installing Go
+//	weight: 2
+//	---
+type Meta struct {
+	Title  string `yaml:"title"`
+	Weight int    `yaml:"weight"` // pages are in order of weight, then of path
+	Draft  bool   `yaml:"draft"`  // a draft is left out of the site
+}
+
+// ErrFrontMatter is returned for front matter that is not closed, or is
+// not the YAML of a Meta.
+var ErrFrontMatter = errors.New("bad front matter")
+
+// SplitFrontMatter returns the front matter of src, and the Markdown
+// after it. src without front matter has a zero Meta.
+func SplitFrontMatter(src string) (Meta, string, error) {
+	var meta Meta
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	rest, ok := strings.CutPrefix(src, "---\n")
+	if !ok {
+		return meta, src, nil
+	}
+	// The closing line may be the first, or the last, with no newline.
+	front, body, ok := strings.Cut("\n"+rest, "\n---\n")
+	if !ok {
+		front, ok = strings.CutSuffix("\n"+rest, "\n---")
+		if !ok {
+			return meta, "", fmt.Errorf("%w: no closing ---", ErrFrontMatter)
+		}
+	}
+	dec := yaml.NewDecoder(strings.NewReader(front))
+	dec.KnownFields(true)
+	if err := dec.Decode(&meta); err != nil && !errors.Is(err, io.EOF) {
+		return meta, "", fmt.Errorf("%w: %v", ErrFrontMatter, err)
+	}
+	return meta, body, nil
+}
+
+// Page is a page of the site, made from a Markdown file.
+type Page struct {
+	Source  string `json:"source"` // the file, a slash-separated path in the content, such as "guide/README.md"
+	Path    string `json:"path"`   // the page, a slash-separated path in the site, such as "guide/index.html"
+	Title   string `json:"title"`
+	Weight  int    `json:"weight"`
+	Content string `json:"content"` // the HTML of the Markdown, with its links pointing at pages
+}
+
+// Site is what Load finds in the content: its pages, in order, and its
+// other files, assets such as images, which are copied as they are.
+type Site struct {
+	Pages  []*Page  `json:"pages"`
+	Assets []string `json:"assets"`
+}
+
+// OutputPath returns the path of the page made from the Markdown file at
+// source: "guide/install.md" is "guide/install.html". A README.md or an
+// index.md is "index.html", the page a server shows for its directory.
+func OutputPath(source string) string {
+	dir, file := path.Split(source)
+	// BUG: a README.md is README.html, which no link to its directory finds
+	return dir + strings.TrimSuffix(file, ".md") + ".html"
+}
+
+// RewriteLink returns the URL a link to dest in a page should have in
+// the site: a link to a Markdown file points at its page, and a link to
+// a directory at its index.html. Pages are where their sources were, so
+// a relative link stays relative. Links to other sites, to the root, and
+// within the page are left alone.
+func RewriteLink(dest string) string {
+	if dest == "" || strings.HasPrefix(dest, "/") || strings.HasPrefix(dest, "#") {
+		return dest
+	}
+	// BUG: a link to another site that ends in .md is rewritten too, to a page that site does not have
+	p, frag, hasFrag := strings.Cut(dest, "#")
+	switch {
+	case strings.HasSuffix(p, ".md"):
+		p = OutputPath(p)
+	case strings.HasSuffix(p, "/"):
+		p += "index.html"
+	default:
+		return dest
+	}
+	if hasFrag {
+		p += "#" + frag
+	}
+	return p
+}
+
+// ignored reports whether Load skips a file or directory: those whose
+// names start with "." or "_", and directories called testdata, as the
+// go command does.
+func ignored(name string, dir bool) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || dir && name == "testdata"
+}
+
+// Load walks content for its pages, its .md files, and its assets, the
+// rest. It reads the front matter of each page, and renders its Markdown.
+func Load(content fs.FS) (*Site, error) {
+	site := &Site{Assets: []string{}}
+	byPath := make(map[string]string) // the source of each page
+	err := fs.WalkDir(content, ".", func(p string, d fs.DirEntry, err error) error {
+		// BUG: err is ignored; when the root cannot be read, d is nil
+		if p != "." && ignored(d.Name(), d.IsDir()) {
+			return fs.SkipDir // BUG: from a file, SkipDir skips the rest of its directory too
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if !strings.HasSuffix(p, ".md") {
+			site.Assets = append(site.Assets, p)
+			return nil
+		}
+		page, err := loadPage(content, p)
+		if err != nil || page == nil {
+			return err
+		}
+		if other, ok := byPath[page.Path]; ok {
+			return fmt.Errorf("%s and %s are both %s", other, p, page.Path)
+		}
+		byPath[page.Path] = p
+		site.Pages = append(site.Pages, page)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(site.Pages, func(i, j int) bool {
+		a, b := site.Pages[i], site.Pages[j]
+		if a.Weight != b.Weight {
+			return a.Weight < b.Weight
+		}
+		return a.Source < b.Source
+	})
+	return site, nil
+}
+
+// loadPage reads the page at source, or returns nil if it is a draft.
+func loadPage(content fs.FS, source string) (*Page, error) {
+	src, err := fs.ReadFile(content, source)
+	if err != nil {
+		return nil, err
+	}
+	meta, body, err := SplitFrontMatter(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	// BUG: drafts are published
+	title := meta.Title
+	if title == "" {
+		title = markdown.Title(body)
+	}
+	if title == "" {
+		title = strings.TrimSuffix(path.Base(source), ".md")
+	}
+	return &Page{
+		Source:  source,
+		Path:    OutputPath(source),
+		Title:   title,
+		Weight:  meta.Weight,
+		Content: markdown.Render(body, RewriteLink),
+	}, nil
+}
@@ -0,0 +1,131 @@
+package exercises
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputPath(t *testing.T) {
+	for source, want := range map[string]string{
+		"intro.md":               "intro.html",
+		"guide/install.md":       "guide/install.html",
+		"README.md":              "index.html",
+		"guide/README.md":        "guide/index.html",
+		"guide/index.md":         "guide/index.html",
+		"docs/readme-first.md":   "docs/readme-first.html",
+		"../modules/README.md":   "../modules/index.html",
+		"modules/01-basics/a.md": "modules/01-basics/a.html",
+	} {
+		assert.Equal(t, want, OutputPath(source), source)
+	}
+}
+
+func TestRewriteLink(t *testing.T) {
+	for dest, want := range map[string]string{
+		"install.md":                       "install.html",
+		"../README.md":                     "../index.html",
+		"docs/gotchas.md#nil-maps":         "docs/gotchas.html#nil-maps",
+		"./guide/":                         "./guide/index.html",
+		"./modules/59-chat/#prerequisites": "./modules/59-chat/index.html#prerequisites",
+		"img/gopher.svg":                   "img/gopher.svg",
+		"exercises/exercise1_pages.go":     "exercises/exercise1_pages.go",
+		"#loop-variables":                  "#loop-variables",
+		"/README.md":                       "/README.md",
+		"https://go.dev/":                  "https://go.dev/",
+		"https://github.com/a/b/README.md": "https://github.com/a/b/README.md",
+		"http://example.com/notes.md#top":  "http://example.com/notes.md#top",
+		"mailto:gopher@example.com":        "mailto:gopher@example.com",
+	} {
+		assert.Equal(t, want, RewriteLink(dest), dest)
+	}
+}
+
+func TestSplitFrontMatter(t *testing.T) {
+	meta, body, err := SplitFrontMatter("---\ntitle: Installing Go\nweight: 2\n---\n# Body\n")
+	require.NoError(t, err)
+	assert.Equal(t, Meta{Title: "Installing Go", Weight: 2}, meta)
+	assert.Equal(t, "# Body\n", body)
+
+	meta, body, err = SplitFrontMatter("---\r\ndraft: true\r\n---")
+	require.NoError(t, err)
+	assert.Equal(t, Meta{Draft: true}, meta)
+	assert.Empty(t, body)
+
+	meta, body, err = SplitFrontMatter("# No front matter\n\n---\n")
+	require.NoError(t, err)
+	assert.Zero(t, meta)
+	assert.Equal(t, "# No front matter\n\n---\n", body, "a rule later on is not front matter")
+
+	_, _, err = SplitFrontMatter("---\n---\nbody")
+	assert.NoError(t, err, "empty front matter")
+
+	_, _, err = SplitFrontMatter("---\ntitle: Forever\n# Body")
+	assert.ErrorIs(t, err, ErrFrontMatter, "not closed")
+	_, _, err = SplitFrontMatter("---\ntitel: Typo\n---\n")
+	assert.ErrorIs(t, err, ErrFrontMatter, "an unknown key")
+	_, _, err = SplitFrontMatter("---\nweight: heavy\n---\n")
+	assert.ErrorIs(t, err, ErrFrontMatter)
+}
+
+// paths returns the path of each page.
+func paths(site *Site) []string {
+	var p []string
+	for _, page := range site.Pages {
+		p = append(p, page.Path)
+	}
+	return p
+}
+
+func TestLoad(t *testing.T) {
+	site, err := Load(fstest.MapFS{
+		"b.md":              {Data: []byte("# Bee")},
+		"a.md":              {Data: []byte("no title")},
+		"first.md":          {Data: []byte("---\nweight: -1\ntitle: First\n---\n# Ignored")},
+		"logo.png":          {Data: []byte("png")},
+		"sub/.hidden.md":    {Data: []byte("# Hidden")},
+		"sub/.DS_Store":     {Data: []byte("junk")},
+		"sub/README.md":     {Data: []byte("# Sub\n[b](../b.md)")},
+		"sub/z.md":          {Data: []byte("# Zed")},
+		"sub/draft.md":      {Data: []byte("---\ndraft: true\n---\n# Draft")},
+		".git/config":       {Data: []byte("[core]")},
+		"_layouts/x.md":     {Data: []byte("# X")},
+		"testdata/t.md":     {Data: []byte("# T")},
+		"notes/testdata.md": {Data: []byte("# A page called testdata")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first.html", "a.html", "b.html", "notes/testdata.html", "sub/index.html", "sub/z.html"}, paths(site),
+		"by weight, then by source; no hidden, ignored, or draft pages")
+	assert.Equal(t, []string{"logo.png"}, site.Assets)
+
+	titles, content := make(map[string]string), make(map[string]string)
+	for _, p := range site.Pages {
+		titles[p.Path], content[p.Path] = p.Title, p.Content
+	}
+	assert.Equal(t, "First", titles["first.html"], "the front matter's title first")
+	assert.Equal(t, "Bee", titles["b.html"], "then the first heading")
+	assert.Equal(t, "a", titles["a.html"], "then the file's name")
+	assert.Equal(t, `<h1 id="sub">Sub</h1>`+"\n"+`<p><a href="../b.html">b</a></p>`+"\n", content["sub/index.html"])
+}
+
+func TestLoadErrors(t *testing.T) {
+	_, err := Load(os.DirFS("testdata/no-such-site"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = Load(fstest.MapFS{"guide/bad.md": {Data: []byte("---\ntitle: x\n")}})
+	assert.ErrorIs(t, err, ErrFrontMatter)
+	assert.ErrorContains(t, err, "guide/bad.md", "the error says which file")
+
+	_, err = Load(fstest.MapFS{"README.md": {Data: []byte("# A")}, "index.md": {Data: []byte("# B")}})
+	assert.ErrorContains(t, err, "index.html", "two pages in one place")
+}
+
+func TestLoadSite(t *testing.T) {
+	site, err := Load(os.DirFS("testdata/site"))
+	require.NoError(t, err)
+	snapshot.Match(t, "pages.json", site)
+}
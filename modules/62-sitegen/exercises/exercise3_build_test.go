@@ -0,0 +1,167 @@
+package exercises
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/internal/snapshot"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// watchWrites has f see every file Build writes, before it is written;
+// if f returns an error, the write fails with it.
+func watchWrites(t *testing.T, f func(name string) error) {
+	orig := writeFile
+	writeFile = func(name string, data []byte) error {
+		if err := f(name); err != nil {
+			return err
+		}
+		return orig(name, data)
+	}
+	t.Cleanup(func() { writeFile = orig })
+}
+
+// manyPages returns the content of a site of n pages, p00.md, p01.md,
+// and so on.
+func manyPages(n int) fstest.MapFS {
+	content := fstest.MapFS{}
+	for i := 0; i < n; i++ {
+		content[fmt.Sprintf("p%02d.md", i)] = &fstest.MapFile{Data: []byte(fmt.Sprintf("# Page %d", i))}
+	}
+	return content
+}
+
+// files returns the slash-separated paths of the files under dir.
+func files(t *testing.T, dir string) []string {
+	var paths []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		paths = append(paths, filepath.ToSlash(rel))
+		return err
+	})
+	require.NoError(t, err)
+	return paths
+}
+
+func TestBuildSite(t *testing.T) {
+	leaktest.Check(t)
+	out := t.TempDir()
+	report, err := Build(context.Background(), os.DirFS("testdata/site"), out, Options{Title: "Learning Go", Workers: 4})
+	require.NoError(t, err)
+	assert.Equal(t, Report{Pages: 5, Assets: 2}, report)
+
+	built := files(t, out)
+	snapshot.Match(t, "site.txt", strings.Join(built, "\n")+"\n")
+	for _, p := range built {
+		data, err := os.ReadFile(filepath.Join(out, p))
+		require.NoError(t, err)
+		switch {
+		case strings.HasSuffix(p, ".html"):
+			snapshot.Match(t, "site/"+p, data)
+		case p == "style.css":
+			want, err := os.ReadFile("static/style.css")
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(data), p)
+		default:
+			want, err := os.ReadFile(filepath.Join("testdata/site", p))
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(data), p)
+		}
+	}
+}
+
+func TestBuildWorkers(t *testing.T) {
+	leaktest.Check(t)
+	var mu sync.Mutex
+	running, most := 0, 0
+	watchWrites(t, func(name string) error {
+		if !strings.HasSuffix(name, ".html") {
+			return nil
+		}
+		mu.Lock()
+		running++
+		most = max(most, running)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	})
+	out := t.TempDir()
+	report, err := Build(context.Background(), manyPages(30), out, Options{Title: "Many", Workers: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 30, report.Pages)
+	assert.LessOrEqual(t, most, 3, "no more pages at once than there are workers")
+	assert.Greater(t, most, 1, "more than one page at once")
+	assert.Len(t, files(t, out), 31, "30 pages and style.css")
+}
+
+var errDiskFull = errors.New("disk full")
+
+func TestBuildFails(t *testing.T) {
+	leaktest.Check(t)
+	var mu sync.Mutex
+	var written []string
+	watchWrites(t, func(name string) error {
+		if filepath.Base(name) == "p00.html" {
+			return errDiskFull
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if strings.HasSuffix(name, ".html") {
+			written = append(written, filepath.Base(name))
+		}
+		return nil
+	})
+	_, err := Build(context.Background(), manyPages(20), t.TempDir(), Options{Title: "Fails", Workers: 1})
+	assert.ErrorIs(t, err, errDiskFull)
+	assert.ErrorContains(t, err, "p00.md", "the error says which page")
+	assert.Empty(t, written, "the build stops at the first error")
+
+	for i := 0; i < 10; i++ {
+		_, err = Build(context.Background(), manyPages(40), t.TempDir(), Options{Title: "Fails", Workers: 8})
+		require.ErrorIs(t, err, errDiskFull, "with many workers")
+	}
+}
+
+func TestBuildTemplateFails(t *testing.T) {
+	leaktest.Check(t)
+	_, err := Build(context.Background(), manyPages(3), t.TempDir(), Options{Title: "Fails", Templates: fstest.MapFS{
+		"layout.html": {Data: []byte(`{{if eq .Page.Path "p01.html"}}{{index .Pages 99}}{{end}}`)},
+	}})
+	assert.ErrorContains(t, err, "p01.md", "a template that fails")
+}
+
+func TestBuildCanceled(t *testing.T) {
+	leaktest.Check(t)
+	var mu sync.Mutex
+	pages := 0
+	watchWrites(t, func(name string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if strings.HasSuffix(name, ".html") {
+			pages++
+		}
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Build(ctx, manyPages(20), t.TempDir(), Options{Title: "Canceled", Workers: 4})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, pages)
+}
@@ -0,0 +1,401 @@
+// Package markdown renders the Markdown the course is written in to HTML:
+// headings, paragraphs, fenced code, lists, block quotes, tables, and
+// rules, with code spans, links, images, and emphasis inside them. It is
+// not CommonMark, which takes thousands of lines; it is enough for
+// lessons.
+//
+// Text is escaped, so the HTML it returns is safe to put in a page as it
+// is. Raw HTML is escaped too, except for comments on lines of their own,
+// which are dropped. Links and images keep only http, https, mailto, and
+// relative URLs.
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Render returns the HTML of src. If link is not nil, the URL of every
+// link and image is passed through it, so that a site can point links
+// to its pages rather than to their sources.
+func Render(src string, link func(url string) string) string {
+	r := renderer{link: link, ids: make(map[string]int)}
+	r.blocks(splitLines(src))
+	return r.b.String()
+}
+
+// Title returns the text of the first level 1 heading of src, or "".
+func Title(src string) string {
+	fence := ""
+	for _, line := range splitLines(src) {
+		switch {
+		case fence != "":
+			if closes(line, fence) {
+				fence = ""
+			}
+		case fenceOf(line) != "":
+			fence = fenceOf(line)
+		case heading.MatchString(line):
+			if m := heading.FindStringSubmatch(line); len(m[1]) == 1 {
+				return plain(m[2])
+			}
+		}
+	}
+	return ""
+}
+
+// Slug returns the id GitHub gives a heading of that text: in lower
+// case, with spaces as hyphens, and without punctuation or emoji.
+func Slug(text string) string {
+	var b strings.Builder
+	for _, c := range strings.ToLower(plain(text)) {
+		switch {
+		case c == ' ':
+			b.WriteByte('-')
+		case c == '-' || c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c):
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+var (
+	heading  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	bullet   = regexp.MustCompile(`^\s{0,3}[-*+]\s+`)
+	numbered = regexp.MustCompile(`^\s{0,3}\d+[.)]\s+`)
+	rule     = regexp.MustCompile(`^\s{0,3}(-\s*){3,}$|^\s{0,3}(\*\s*){3,}$|^\s{0,3}(_\s*){3,}$`)
+	comment  = regexp.MustCompile(`^\s*<!--.*-->\s*$`)
+	tableSep = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+)
+
+type renderer struct {
+	b    strings.Builder
+	link func(string) string
+	ids  map[string]int // how often each heading id has been used
+}
+
+// blocks renders lines, one block at a time.
+func (r *renderer) blocks(lines []string) {
+	for len(lines) > 0 {
+		line := lines[0]
+		switch {
+		case strings.TrimSpace(line) == "" || comment.MatchString(line):
+			lines = lines[1:]
+		case fenceOf(line) != "":
+			lines = r.code(lines)
+		case heading.MatchString(line):
+			m := heading.FindStringSubmatch(line)
+			r.heading(len(m[1]), m[2])
+			lines = lines[1:]
+		case rule.MatchString(line):
+			r.b.WriteString("<hr>\n")
+			lines = lines[1:]
+		case strings.HasPrefix(strings.TrimSpace(line), ">"):
+			lines = r.quote(lines)
+		case bullet.MatchString(line):
+			lines = r.list(lines, "ul", bullet)
+		case numbered.MatchString(line):
+			lines = r.list(lines, "ol", numbered)
+		case strings.Contains(line, "|") && len(lines) > 1 && tableSep.MatchString(lines[1]):
+			lines = r.table(lines)
+		default:
+			lines = r.paragraph(lines)
+		}
+	}
+}
+
+// fenceOf returns the fence a line opens a code block with, ``` or ~~~
+// or longer, or "".
+func fenceOf(line string) string {
+	t := strings.TrimLeft(line, " ")
+	if len(line)-len(t) > 3 {
+		return ""
+	}
+	for _, c := range []string{"`", "~"} {
+		n := len(t) - len(strings.TrimLeft(t, c))
+		if n >= 3 {
+			return strings.Repeat(c, n)
+		}
+	}
+	return ""
+}
+
+// code renders a fenced code block, which runs to a closing fence at
+// least as long, or to the end.
+func (r *renderer) code(lines []string) []string {
+	fence := fenceOf(lines[0])
+	lang := strings.Fields(strings.TrimLeft(strings.TrimSpace(lines[0]), fence[:1]))
+	if len(lang) > 0 {
+		r.b.WriteString(`<pre><code class="language-` + html.EscapeString(lang[0]) + `">`)
+	} else {
+		r.b.WriteString("<pre><code>")
+	}
+	rest := lines[1:]
+	for len(rest) > 0 {
+		line := rest[0]
+		rest = rest[1:]
+		if closes(line, fence) {
+			break
+		}
+		r.b.WriteString(html.EscapeString(line) + "\n")
+	}
+	r.b.WriteString("</code></pre>\n")
+	return rest
+}
+
+// closes reports whether line closes a code block opened with fence.
+func closes(line, fence string) bool {
+	t := strings.TrimSpace(line)
+	return strings.HasPrefix(t, fence) && strings.Trim(t, fence[:1]) == ""
+}
+
+// heading renders a heading, with an id to link to. A second heading of
+// the same text gets the id with "-1" after it, as on GitHub.
+func (r *renderer) heading(level int, text string) {
+	id := Slug(text)
+	if n := r.ids[id]; n > 0 {
+		r.ids[id]++
+		id += "-" + strconv.Itoa(n)
+	}
+	r.ids[id]++
+	l := strconv.Itoa(level)
+	r.b.WriteString("<h" + l + ` id="` + html.EscapeString(id) + `">` + r.inline(text) + "</h" + l + ">\n")
+}
+
+// quote renders a block quote, whose lines start with ">", and whatever
+// blocks are inside it.
+func (r *renderer) quote(lines []string) []string {
+	var inner []string
+	for len(lines) > 0 && strings.HasPrefix(strings.TrimSpace(lines[0]), ">") {
+		t := strings.TrimPrefix(strings.TrimSpace(lines[0]), ">")
+		inner = append(inner, strings.TrimPrefix(t, " "))
+		lines = lines[1:]
+	}
+	r.b.WriteString("<blockquote>\n")
+	r.blocks(inner)
+	r.b.WriteString("</blockquote>\n")
+	return lines
+}
+
+// listItem is an item of a list: its text, and the lines of what is
+// nested in it.
+type listItem struct {
+	text string
+	sub  []string
+}
+
+// list renders a list. A line indented further than the first continues
+// the item before it, or, once one starts an item, holds blocks nested
+// in it; a line not indented that is not an item continues it too.
+func (r *renderer) list(lines []string, tag string, marker *regexp.Regexp) []string {
+	indent := indentOf(lines[0])
+	var items []listItem
+	for ; len(lines) > 0; lines = lines[1:] {
+		line := lines[0]
+		t := strings.TrimSpace(line)
+		if t == "" || comment.MatchString(line) || fenceOf(line) != "" && indentOf(line) <= indent || heading.MatchString(line) {
+			break
+		}
+		if indentOf(line) <= indent {
+			if loc := marker.FindStringIndex(line); loc != nil {
+				items = append(items, listItem{text: line[loc[1]:]})
+				continue
+			}
+			if isItem(line) {
+				break // a list of the other kind
+			}
+			items[len(items)-1].text += "\n" + t
+			continue
+		}
+		last := &items[len(items)-1]
+		if last.sub != nil || isItem(t) {
+			last.sub = append(last.sub, line)
+		} else {
+			last.text += "\n" + t
+		}
+	}
+	r.b.WriteString("<" + tag + ">\n")
+	for _, it := range items {
+		r.b.WriteString("<li>" + r.inline(it.text))
+		if it.sub != nil {
+			r.b.WriteString("\n")
+			r.blocks(dedent(it.sub))
+		}
+		r.b.WriteString("</li>\n")
+	}
+	r.b.WriteString("</" + tag + ">\n")
+	return lines
+}
+
+func isItem(line string) bool {
+	return bullet.MatchString(line) || numbered.MatchString(line)
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// dedent removes the indentation of the first line from all of lines.
+func dedent(lines []string) []string {
+	n := indentOf(lines[0])
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = line[min(n, indentOf(line)):]
+	}
+	return out
+}
+
+// table renders a table: a row of headers, a row of dashes, and a row
+// of cells on each line after them.
+func (r *renderer) table(lines []string) []string {
+	r.b.WriteString("<table>\n<thead>\n")
+	r.row("th", lines[0])
+	r.b.WriteString("</thead>\n<tbody>\n")
+	lines = lines[2:]
+	for len(lines) > 0 && strings.Contains(lines[0], "|") {
+		r.row("td", lines[0])
+		lines = lines[1:]
+	}
+	r.b.WriteString("</tbody>\n</table>\n")
+	return lines
+}
+
+func (r *renderer) row(tag, line string) {
+	line = strings.TrimSpace(line)
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	r.b.WriteString("<tr>")
+	for _, cell := range splitCells(line) {
+		r.b.WriteString("<" + tag + ">" + r.inline(strings.TrimSpace(cell)) + "</" + tag + ">")
+	}
+	r.b.WriteString("</tr>\n")
+}
+
+// splitCells splits a row at the bars outside code spans, so that a
+// cell may show `a | b`.
+func splitCells(line string) []string {
+	var cells []string
+	inCode := false
+	start := 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '`':
+			inCode = !inCode
+		case '|':
+			if !inCode && (i == 0 || line[i-1] != '\\') {
+				cells = append(cells, line[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(cells, line[start:])
+}
+
+// paragraph renders the lines up to the next blank line, or the next
+// line that starts another kind of block.
+func (r *renderer) paragraph(lines []string) []string {
+	n := 1
+	for n < len(lines) {
+		line := lines[n]
+		if strings.TrimSpace(line) == "" || comment.MatchString(line) || fenceOf(line) != "" || heading.MatchString(line) ||
+			rule.MatchString(line) || strings.HasPrefix(strings.TrimSpace(line), ">") || isItem(line) {
+			break
+		}
+		n++
+	}
+	text := strings.Join(lines[:n], "\n")
+	r.b.WriteString("<p>" + r.inline(text) + "</p>\n")
+	return lines[n:]
+}
+
+var (
+	codeSpan = regexp.MustCompile("`+")
+	image    = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)\)`)
+	link     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	strong   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	emphasis = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_]+)_\b`)
+	hardBr   = regexp.MustCompile(`( {2,}|\\)\n`)
+)
+
+// inline renders the spans of a block: code, images, links, emphasis,
+// and line breaks.
+func (r *renderer) inline(text string) string {
+	var b strings.Builder
+	for text != "" {
+		loc := codeSpan.FindStringIndex(text)
+		if loc == nil {
+			b.WriteString(r.spans(text))
+			break
+		}
+		fence := text[loc[0]:loc[1]]
+		end := strings.Index(text[loc[1]:], fence)
+		if end < 0 {
+			b.WriteString(r.spans(text))
+			break
+		}
+		b.WriteString(r.spans(text[:loc[0]]))
+		code := strings.TrimSpace(text[loc[1] : loc[1]+end])
+		b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+		text = text[loc[1]+end+len(fence):]
+	}
+	return b.String()
+}
+
+// spans renders images, links, emphasis, and line breaks in text
+// without code spans.
+func (r *renderer) spans(text string) string {
+	text = hardBr.ReplaceAllString(html.EscapeString(text), "<br>\n")
+	text = image.ReplaceAllStringFunc(text, func(s string) string {
+		m := image.FindStringSubmatch(s)
+		url, ok := r.url(m[2])
+		if !ok {
+			return m[1]
+		}
+		return `<img src="` + url + `" alt="` + m[1] + `">`
+	})
+	text = link.ReplaceAllStringFunc(text, func(s string) string {
+		m := link.FindStringSubmatch(s)
+		url, ok := r.url(m[2])
+		if !ok {
+			return m[1]
+		}
+		return `<a href="` + url + `">` + m[1] + "</a>"
+	})
+	text = strong.ReplaceAllString(text, "<strong>$1$2</strong>")
+	return emphasis.ReplaceAllString(text, "<em>$1$2</em>")
+}
+
+// url returns the escaped URL a link or image points at, through r.link,
+// and false if it may not point there: no javascript: and the like.
+func (r *renderer) url(escaped string) (string, bool) {
+	u := html.UnescapeString(escaped)
+	scheme, _, ok := strings.Cut(u, ":")
+	if ok && !strings.ContainsAny(scheme, "/?#") {
+		switch strings.ToLower(scheme) {
+		case "http", "https", "mailto":
+		default:
+			return "", false
+		}
+	}
+	if r.link != nil {
+		u = r.link(u)
+	}
+	return html.EscapeString(u), true
+}
+
+// plain strips the markup from inline Markdown: code spans keep their
+// text, and links and images their words.
+func plain(text string) string {
+	text = image.ReplaceAllString(text, "$1")
+	text = link.ReplaceAllString(text, "$1")
+	text = strong.ReplaceAllString(text, "$1$2")
+	text = emphasis.ReplaceAllString(text, "$1$2")
+	return strings.TrimSpace(strings.ReplaceAll(text, "`", ""))
+}
+
+// splitLines splits src into lines, without their line endings.
+func splitLines(src string) []string {
+	return strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,67 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	for _, tc := range []struct {
+		name, src, want string
+	}{
+		{"paragraphs", "one\ntwo\n\nthree", "<p>one\ntwo</p>\n<p>three</p>\n"},
+		{"headings", "# Go & <you>\n## 🎯 Learning Objectives\ntext", `<h1 id="go--you">Go &amp; &lt;you&gt;</h1>` + "\n" +
+			`<h2 id="-learning-objectives">🎯 Learning Objectives</h2>` + "\n<p>text</p>\n"},
+		{"the same heading twice", "## Notes\n## Notes\n## Notes", `<h2 id="notes">Notes</h2>` + "\n" +
+			`<h2 id="notes-1">Notes</h2>` + "\n" + `<h2 id="notes-2">Notes</h2>` + "\n"},
+		{"fenced code", "```go\nif a < b {\n\treturn \"*x*\"\n}\n```\nafter", `<pre><code class="language-go">if a &lt; b {` + "\n\treturn &#34;*x*&#34;\n}\n</code></pre>\n<p>after</p>\n"},
+		{"a fence that is not closed", "~~~\n# not a heading", "<pre><code># not a heading\n</code></pre>\n"},
+		{"a longer fence", "````\n```\n````", "<pre><code>```\n</code></pre>\n"},
+		{"lists", "- a\n- b\n  more b\n  1. c\n  2. d\n- e", "<ul>\n<li>a</li>\n<li>b\nmore b\n<ol>\n<li>c</li>\n<li>d</li>\n</ol>\n</li>\n<li>e</li>\n</ul>\n"},
+		{"a list after a paragraph", "Steps:\n1. one\n2. two", "<p>Steps:</p>\n<ol>\n<li>one</li>\n<li>two</li>\n</ol>\n"},
+		{"a quote", "> **Note:** this\n> and that", "<blockquote>\n<p><strong>Note:</strong> this\nand that</p>\n</blockquote>\n"},
+		{"a table", "| a | `b | c` |\n|---|:--:|\n| 1 | *2* |", "<table>\n<thead>\n<tr><th>a</th><th><code>b | c</code></th></tr>\n</thead>\n<tbody>\n<tr><td>1</td><td><em>2</em></td></tr>\n</tbody>\n</table>\n"},
+		{"a rule", "a\n\n---\n\nb", "<p>a</p>\n<hr>\n<p>b</p>\n"},
+		{"comments", "<!-- marker -->\ntext\n<!-- /marker -->", "<p>text</p>\n"},
+		{"raw HTML", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+		{"line breaks", "one  \ntwo\\\nthree", "<p>one<br>\ntwo<br>\nthree</p>\n"},
+		{"code spans", "`a*b*c` and ``x ` y``", "<p><code>a*b*c</code> and <code>x ` y</code></p>\n"},
+		{"snake_case", "a snake_case_name and _this_", "<p>a snake_case_name and <em>this</em></p>\n"},
+		{"links and images", "[Go](https://go.dev/?a=1&b=2) ![a gopher](gopher.png)", `<p><a href="https://go.dev/?a=1&amp;b=2">Go</a> <img src="gopher.png" alt="a gopher"></p>` + "\n"},
+		{"unsafe links", "[click](javascript:void) [mail](mailto:a@b.c)", `<p>click <a href="mailto:a@b.c">mail</a></p>` + "\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Render(tc.src, nil))
+		})
+	}
+}
+
+func TestRenderLinks(t *testing.T) {
+	var seen []string
+	got := Render("[a](intro.md) and ![b](img/b.png)\n\n[c](https://go.dev) [d](javascript:x)", func(url string) string {
+		seen = append(seen, url)
+		return strings.ToUpper(url)
+	})
+	assert.Equal(t, []string{"img/b.png", "intro.md", "https://go.dev"}, seen, "every URL, except those dropped, once")
+	assert.Equal(t, `<p><a href="INTRO.MD">a</a> and <img src="IMG/B.PNG" alt="b"></p>`+"\n"+`<p><a href="HTTPS://GO.DEV">c</a> d</p>`+"\n", got)
+}
+
+func TestTitle(t *testing.T) {
+	assert.Equal(t, "Module 1: Go basics", Title("intro\n```\n# a comment\n```\n## Sub\n# Module 1: Go `basics`\n# Second"))
+	assert.Equal(t, "Links", Title("# [Links](x.md)"))
+	assert.Empty(t, Title("## Only a subheading"))
+}
+
+func TestSlug(t *testing.T) {
+	for text, want := range map[string]string{
+		"Coming From Other Languages": "coming-from-other-languages",
+		"1. Fan-Out":                  "1-fan-out",
+		"📚 Additional Resources":      "-additional-resources",
+		"`len` for Characters":        "len-for-characters",
+		"Zoë's snake_case":            "zoës-snake_case",
+	} {
+		assert.Equal(t, want, Slug(text), text)
+	}
+}
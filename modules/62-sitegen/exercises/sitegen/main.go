@@ -0,0 +1,38 @@
+// Command sitegen builds a static site from a directory of Markdown. Run
+// from the root of the repository, it publishes the course itself:
+//
+//	go run ./modules/62-sitegen/exercises/sitegen -src . -out _site
+//	open _site/index.html
+//
+// The output directory starts with "_", so a later build does not read
+// it back in as content.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/62-sitegen/exercises"
+)
+
+func main() {
+	src := flag.String("src", ".", "directory of the content")
+	out := flag.String("out", "_site", "directory to write the site to")
+	title := flag.String("title", "Learning Go the Hard Way", "the site's title")
+	workers := flag.Int("workers", 0, "pages to render at once; 0 for GOMAXPROCS")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	start := time.Now()
+	report, err := exercises.Build(ctx, os.DirFS(*src), *out, exercises.Options{Title: *title, Workers: *workers})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "sitegen:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("sitegen: %d pages and %d other files in %s, in %v\n", report.Pages, report.Assets, *out, time.Since(start).Round(time.Millisecond))
+}
@@ -0,0 +1,199 @@
+package solutions
+
+// SOLUTION: Milestone 1: fix the loader that finds the pages of a site, reads their front matter, and points their links at pages.
+// Fixed: a hidden or ignored file is skipped with nil; fs.SkipDir from a file skips the rest of its directory
+// Fixed: the error WalkDir passes in is returned, before d, which is nil when the root cannot be read, is used
+// Fixed: a README.md or index.md is the index.html of its directory, which a link to the directory finds
+// Fixed: links with a scheme, to other sites, are left alone, even if they end in .md
+// Fixed: drafts are left out
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/modules/62-sitegen/solutions/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+// Meta is the front matter of a page, YAML between lines of "---" at its
+// start. All of it is optional.
+//
+//	---
+//	title: Installing Go
+//	weight: 2
+//	---
+type Meta struct {
+	Title  string `yaml:"title"`
+	Weight int    `yaml:"weight"` // pages are in order of weight, then of path
+	Draft  bool   `yaml:"draft"`  // a draft is left out of the site
+}
+
+// ErrFrontMatter is returned for front matter that is not closed, or is
+// not the YAML of a Meta.
+var ErrFrontMatter = errors.New("bad front matter")
+
+// SplitFrontMatter returns the front matter of src, and the Markdown
+// after it. src without front matter has a zero Meta.
+func SplitFrontMatter(src string) (Meta, string, error) {
+	var meta Meta
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	rest, ok := strings.CutPrefix(src, "---\n")
+	if !ok {
+		return meta, src, nil
+	}
+	// The closing line may be the first, or the last, with no newline.
+	front, body, ok := strings.Cut("\n"+rest, "\n---\n")
+	if !ok {
+		front, ok = strings.CutSuffix("\n"+rest, "\n---")
+		if !ok {
+			return meta, "", fmt.Errorf("%w: no closing ---", ErrFrontMatter)
+		}
+	}
+	dec := yaml.NewDecoder(strings.NewReader(front))
+	dec.KnownFields(true)
+	if err := dec.Decode(&meta); err != nil && !errors.Is(err, io.EOF) {
+		return meta, "", fmt.Errorf("%w: %v", ErrFrontMatter, err)
+	}
+	return meta, body, nil
+}
+
+// Page is a page of the site, made from a Markdown file.
+type Page struct {
+	Source  string `json:"source"` // the file, a slash-separated path in the content, such as "guide/README.md"
+	Path    string `json:"path"`   // the page, a slash-separated path in the site, such as "guide/index.html"
+	Title   string `json:"title"`
+	Weight  int    `json:"weight"`
+	Content string `json:"content"` // the HTML of the Markdown, with its links pointing at pages
+}
+
+// Site is what Load finds in the content: its pages, in order, and its
+// other files, assets such as images, which are copied as they are.
+type Site struct {
+	Pages  []*Page  `json:"pages"`
+	Assets []string `json:"assets"`
+}
+
+// OutputPath returns the path of the page made from the Markdown file at
+// source: "guide/install.md" is "guide/install.html". A README.md or an
+// index.md is "index.html", the page a server shows for its directory.
+func OutputPath(source string) string {
+	dir, file := path.Split(source)
+	if strings.EqualFold(file, "README.md") || file == "index.md" {
+		return dir + "index.html"
+	}
+	return dir + strings.TrimSuffix(file, ".md") + ".html"
+}
+
+// RewriteLink returns the URL a link to dest in a page should have in
+// the site: a link to a Markdown file points at its page, and a link to
+// a directory at its index.html. Pages are where their sources were, so
+// a relative link stays relative. Links to other sites, to the root, and
+// within the page are left alone.
+func RewriteLink(dest string) string {
+	if dest == "" || strings.HasPrefix(dest, "/") || strings.HasPrefix(dest, "#") {
+		return dest
+	}
+	if scheme, _, ok := strings.Cut(dest, ":"); ok && !strings.ContainsAny(scheme, "/?#") {
+		return dest
+	}
+	p, frag, hasFrag := strings.Cut(dest, "#")
+	switch {
+	case strings.HasSuffix(p, ".md"):
+		p = OutputPath(p)
+	case strings.HasSuffix(p, "/"):
+		p += "index.html"
+	default:
+		return dest
+	}
+	if hasFrag {
+		p += "#" + frag
+	}
+	return p
+}
+
+// ignored reports whether Load skips a file or directory: those whose
+// names start with "." or "_", and directories called testdata, as the
+// go command does.
+func ignored(name string, dir bool) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || dir && name == "testdata"
+}
+
+// Load walks content for its pages, its .md files, and its assets, the
+// rest. It reads the front matter of each page, and renders its Markdown.
+func Load(content fs.FS) (*Site, error) {
+	site := &Site{Assets: []string{}}
+	byPath := make(map[string]string) // the source of each page
+	err := fs.WalkDir(content, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != "." && ignored(d.Name(), d.IsDir()) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if !strings.HasSuffix(p, ".md") {
+			site.Assets = append(site.Assets, p)
+			return nil
+		}
+		page, err := loadPage(content, p)
+		if err != nil || page == nil {
+			return err
+		}
+		if other, ok := byPath[page.Path]; ok {
+			return fmt.Errorf("%s and %s are both %s", other, p, page.Path)
+		}
+		byPath[page.Path] = p
+		site.Pages = append(site.Pages, page)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(site.Pages, func(i, j int) bool {
+		a, b := site.Pages[i], site.Pages[j]
+		if a.Weight != b.Weight {
+			return a.Weight < b.Weight
+		}
+		return a.Source < b.Source
+	})
+	return site, nil
+}
+
+// loadPage reads the page at source, or returns nil if it is a draft.
+func loadPage(content fs.FS, source string) (*Page, error) {
+	src, err := fs.ReadFile(content, source)
+	if err != nil {
+		return nil, err
+	}
+	meta, body, err := SplitFrontMatter(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	if meta.Draft {
+		return nil, nil
+	}
+	title := meta.Title
+	if title == "" {
+		title = markdown.Title(body)
+	}
+	if title == "" {
+		title = strings.TrimSuffix(path.Base(source), ".md")
+	}
+	return &Page{
+		Source:  source,
+		Path:    OutputPath(source),
+		Title:   title,
+		Weight:  meta.Weight,
+		Content: markdown.Render(body, RewriteLink),
+	}, nil
+}
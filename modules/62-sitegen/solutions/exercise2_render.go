@@ -0,0 +1,99 @@
+package solutions
+
+// SOLUTION: Milestone 2: fix the renderer that puts each page in the site's templates.
+// Fixed: Rel counts no directory for a page at the root, whose path.Dir is "."
+// Fixed: the page's HTML is passed as template.HTML, which the markdown package made safe, so html/template does not escape it again
+// Fixed: Render executes into a buffer, and writes nothing if the template fails halfway
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// templateFS holds the site's templates: layout.html, the page, with a
+// "content" block that the other files define.
+//
+//go:embed templates
+var templateFS embed.FS
+
+// Templates returns the templates built into the program.
+func Templates() fs.FS {
+	sub, err := fs.Sub(templateFS, "templates")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// Rel returns a relative URL from the page at from to the file at to,
+// both slash-separated paths in the site: "../style.css" from
+// "guide/index.html". With relative URLs, the site works wherever it is
+// served from, even opened from the disk.
+func Rel(from, to string) string {
+	dir := path.Dir(from)
+	if dir == "." {
+		return to
+	}
+	return strings.Repeat("../", strings.Count(dir, "/")+1) + to
+}
+
+// Renderer renders the pages of a site with a set of templates. It is
+// safe for concurrent use.
+type Renderer struct {
+	title string
+	tmpl  *template.Template
+}
+
+// NewRenderer parses the .html files of templates, one of which must be
+// layout.html, for a site called title.
+func NewRenderer(title string, templates fs.FS) (*Renderer, error) {
+	tmpl, err := template.ParseFS(templates, "*.html")
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.Lookup("layout.html") == nil {
+		return nil, fmt.Errorf("no layout.html in the templates: %w", fs.ErrNotExist)
+	}
+	return &Renderer{title: title, tmpl: tmpl}, nil
+}
+
+// view is what the templates see of a page.
+type view struct {
+	Site       string // the site's title
+	Page       *Page
+	Content    template.HTML
+	Pages      []*Page // every page, for the navigation
+	Prev, Next *Page   // the pages before and after, or nil
+}
+
+// Rel returns a relative URL from the page to the file at to.
+func (v *view) Rel(to string) string { return Rel(v.Page.Path, to) }
+
+// Render writes the page i of site to w.
+func (r *Renderer) Render(w io.Writer, site *Site, i int) error {
+	page := site.Pages[i]
+	v := &view{
+		Site:    r.title,
+		Page:    page,
+		Content: template.HTML(page.Content), // rendered and escaped by the markdown package
+		Pages:   site.Pages,
+	}
+	if i > 0 {
+		v.Prev = site.Pages[i-1]
+	}
+	if i+1 < len(site.Pages) {
+		v.Next = site.Pages[i+1]
+	}
+	var buf bytes.Buffer
+	if err := r.tmpl.ExecuteTemplate(&buf, "layout.html", v); err != nil {
+		return err
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
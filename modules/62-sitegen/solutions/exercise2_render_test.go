@@ -0,0 +1,92 @@
+package solutions
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRel(t *testing.T) {
+	for _, tc := range []struct{ from, to, want string }{
+		{"index.html", "style.css", "style.css"},
+		{"index.html", "guide/install.html", "guide/install.html"},
+		{"guide/index.html", "style.css", "../style.css"},
+		{"guide/index.html", "index.html", "../index.html"},
+		{"guide/install.html", "guide/tags.html", "../guide/tags.html"},
+		{"modules/59-chat/index.html", "style.css", "../../style.css"},
+		{"modules/59-chat/index.html", "modules/60-kvstore/index.html", "../../modules/60-kvstore/index.html"},
+	} {
+		assert.Equal(t, tc.want, Rel(tc.from, tc.to), "from %s to %s", tc.from, tc.to)
+	}
+}
+
+// testSite is a site of three pages, with a title that needs escaping.
+func testSite() *Site {
+	return &Site{Pages: []*Page{
+		{Source: "README.md", Path: "index.html", Title: "Home", Content: "<p>Welcome.</p>\n"},
+		{Source: "guide/tags.md", Path: "guide/tags.html", Title: "Tags & <Templates>", Content: `<h2 id="x">{{x}}</h2>` + "\n"},
+		{Source: "guide/z.md", Path: "guide/z.html", Title: "Last", Content: "<p>The end.</p>\n"},
+	}}
+}
+
+func render(t *testing.T, r *Renderer, site *Site, i int) string {
+	t.Helper()
+	var b strings.Builder
+	require.NoError(t, r.Render(&b, site, i))
+	return b.String()
+}
+
+func TestRender(t *testing.T) {
+	r, err := NewRenderer("Go <Course>", Templates())
+	require.NoError(t, err)
+	site := testSite()
+
+	out := render(t, r, site, 1)
+	assert.Contains(t, out, "<title>Tags &amp; &lt;Templates&gt; · Go &lt;Course&gt;</title>", "titles are escaped")
+	assert.Contains(t, out, `<h2 id="x">{{x}}</h2>`, "the page's HTML is not escaped again")
+	assert.Contains(t, out, `<link rel="stylesheet" href="../style.css">`)
+	assert.Contains(t, out, `<li><a href="../guide/tags.html" aria-current="page">Tags &amp; &lt;Templates&gt;</a></li>`)
+	assert.Contains(t, out, `<a rel="prev" href="../index.html">← Home</a>`)
+	assert.Contains(t, out, `<a rel="next" href="../guide/z.html">Last →</a>`)
+
+	out = render(t, r, site, 0)
+	assert.Contains(t, out, `<link rel="stylesheet" href="style.css">`, "a page at the root")
+	assert.Contains(t, out, `<a class="site" href="index.html">`)
+	assert.NotContains(t, out, `rel="prev"`)
+
+	out = render(t, r, site, 2)
+	assert.Contains(t, out, `rel="prev"`)
+	assert.NotContains(t, out, `rel="next"`, "the last page")
+}
+
+func TestRenderFails(t *testing.T) {
+	r, err := NewRenderer("Site", fstest.MapFS{
+		"layout.html": {Data: []byte(`<html>{{range .Pages}}<p>{{.Title}}</p>{{end}}{{index .Pages 99}}</html>`)},
+	})
+	require.NoError(t, err)
+	var b bytes.Buffer
+	assert.Error(t, r.Render(&b, testSite(), 0))
+	assert.Empty(t, b.String(), "half a page is worse than none")
+
+	_, err = NewRenderer("Site", fstest.MapFS{"layout.html": {Data: []byte(`{{if}}`)}})
+	assert.Error(t, err)
+	_, err = NewRenderer("Site", fstest.MapFS{"page.html": {Data: []byte(`{{define "content"}}{{end}}`)}})
+	assert.ErrorIs(t, err, fs.ErrNotExist, "no layout")
+}
+
+func TestRenderSite(t *testing.T) {
+	site, err := Load(os.DirFS("testdata/site"))
+	require.NoError(t, err)
+	r, err := NewRenderer("Learning Go", Templates())
+	require.NoError(t, err)
+	for i, p := range site.Pages {
+		snapshot.Match(t, "site/"+p.Path, render(t, r, site, i))
+	}
+}
@@ -0,0 +1,147 @@
+package solutions
+
+// SOLUTION: Milestone 3: fix Build, which renders the pages of a site with a pool of workers and writes them out.
+// Fixed: Build starts Options.Workers workers, not one for each page, which for a large site is thousands of goroutines writing thousands of files at once
+// Fixed: the first worker to fail cancels the build, with its error as the cause, and the other workers skip the pages left; the workers no longer share an error variable without a lock
+// Fixed: the static files are written at their paths in fs.Sub(staticFS, "static"), not with static/ in front
+// Fixed: writeFile creates the directories a file is in
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// staticFS holds the files every site has, such as its stylesheet.
+//
+//go:embed static
+var staticFS embed.FS
+
+// Options are the options of Build.
+type Options struct {
+	Title     string // the site's title
+	Workers   int    // how many pages are rendered at once; if 0, GOMAXPROCS
+	Templates fs.FS  // the templates; if nil, those built in
+}
+
+// Report says what Build wrote.
+type Report struct {
+	Pages  int // pages rendered
+	Assets int // files copied: the content's assets, and the static files
+}
+
+// writeFile writes a file of the site. Tests replace it to watch the
+// writes, or to fail them.
+var writeFile = func(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0o644)
+}
+
+// Build loads the site in content, and writes it to the directory out:
+// a page for each Markdown file, the content's other files, and the
+// static files. Pages are rendered by opts.Workers goroutines at once.
+// The first error stops the build, and is returned, as is ctx's if it
+// is canceled. Files in out that the build does not write are left as
+// they are.
+func Build(ctx context.Context, content fs.FS, out string, opts Options) (Report, error) {
+	site, err := Load(content)
+	if err != nil {
+		return Report{}, err
+	}
+	templates := opts.Templates
+	if templates == nil {
+		templates = Templates()
+	}
+	r, err := NewRenderer(opts.Title, templates)
+	if err != nil {
+		return Report{}, err
+	}
+	report := Report{Pages: len(site.Pages), Assets: len(site.Assets)}
+
+	for _, a := range site.Assets {
+		if err := copyFile(content, a, out); err != nil {
+			return Report{}, err
+		}
+	}
+	static, err := fs.Sub(staticFS, "static")
+	if err != nil {
+		return Report{}, err
+	}
+	err = fs.WalkDir(static, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		report.Assets++
+		return copyFile(static, p, out)
+	})
+	if err != nil {
+		return Report{}, err
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					continue // the build has failed: skip the pages left
+				}
+				if err := buildPage(r, site, i, out); err != nil {
+					cancel(err)
+				}
+			}
+		}()
+	}
+feed:
+	for i := range site.Pages {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := context.Cause(ctx); err != nil {
+		return Report{}, err
+	}
+	return report, nil
+}
+
+// buildPage renders the page i of site, and writes it to out.
+func buildPage(r *Renderer, site *Site, i int, out string) error {
+	page := site.Pages[i]
+	var buf bytes.Buffer
+	if err := r.Render(&buf, site, i); err != nil {
+		return fmt.Errorf("%s: %w", page.Source, err)
+	}
+	if err := writeFile(filepath.Join(out, filepath.FromSlash(page.Path)), buf.Bytes()); err != nil {
+		return fmt.Errorf("%s: %w", page.Source, err)
+	}
+	return nil
+}
+
+// copyFile copies the file at p in fsys to the same path in out.
+func copyFile(fsys fs.FS, p, out string) error {
+	data, err := fs.ReadFile(fsys, p)
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(out, filepath.FromSlash(p)), data)
+}
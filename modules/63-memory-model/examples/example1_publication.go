@@ -0,0 +1,144 @@
+// Package examples demonstrates the Go memory model: when a write in one
+// goroutine is certain to be seen by a read in another, what a program
+// that leaves it to chance does, and the synchronization that makes it
+// certain.
+//
+// This file shows:
+// - Unsynchronized publication: a flag set after the data, and read without synchronization, promises nothing
+// - That such a program usually works, which is why it is dangerous: -race sees the race on every run
+// - Publishing once by closing a channel, and any number of times with atomic.Pointer
+// - RaceEnabled, which tells from the build info whether the program was built with -race
+package examples
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// RaceEnabled reports whether the program was built with the race
+// detector.
+func RaceEnabled() bool {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return false
+	}
+	for _, s := range info.Settings {
+		if s.Key == "-race" {
+			return s.Value == "true"
+		}
+	}
+	return false
+}
+
+// Config is what one goroutine publishes to others.
+type Config struct {
+	Addr    string
+	Workers int
+}
+
+// RacyBox publishes a Config with a plain flag, and races: nothing
+// orders the writes to cfg before a read that sees ready set. A reader
+// may see ready and a zero or half-written Config, and a loop waiting for
+// ready may be compiled to read it once.
+type RacyBox struct {
+	cfg   Config
+	ready bool
+}
+
+// Publish stores cfg, then sets the flag.
+func (b *RacyBox) Publish(cfg Config) {
+	b.cfg = cfg
+	b.ready = true
+}
+
+// Get returns the Config, and whether it has been published.
+func (b *RacyBox) Get() (Config, bool) {
+	if !b.ready {
+		return Config{}, false
+	}
+	return b.cfg, true
+}
+
+// OnceBox publishes a Config once, by closing a channel: the close
+// happens before every receive that returns because of it.
+type OnceBox struct {
+	cfg  Config
+	done chan struct{}
+}
+
+// NewOnceBox returns an empty OnceBox.
+func NewOnceBox() *OnceBox {
+	return &OnceBox{done: make(chan struct{})}
+}
+
+// Publish stores cfg. It must be called once.
+func (b *OnceBox) Publish(cfg Config) {
+	b.cfg = cfg
+	close(b.done)
+}
+
+// Wait waits for Publish, and returns the Config.
+func (b *OnceBox) Wait() Config {
+	<-b.done
+	return b.cfg
+}
+
+// AtomicBox publishes a Config any number of times. A Load that returns
+// the pointer a Store stored happens after the Store, and so after every
+// write to the Config before it. The Config is never changed once stored.
+type AtomicBox struct {
+	p atomic.Pointer[Config]
+}
+
+// Publish stores a copy of cfg.
+func (b *AtomicBox) Publish(cfg Config) {
+	b.p.Store(&cfg)
+}
+
+// Get returns the last Config published, and whether there was one.
+func (b *AtomicBox) Get() (Config, bool) {
+	c := b.p.Load()
+	if c == nil {
+		return Config{}, false
+	}
+	return *c, true
+}
+
+// poll calls get until it reports a Config, yielding between calls.
+func poll(get func() (Config, bool)) (Config, bool) {
+	for i := 0; i < 1_000_000; i++ {
+		if c, ok := get(); ok {
+			return c, true
+		}
+		runtime.Gosched()
+	}
+	return Config{}, false
+}
+
+// DemonstratePublication publishes a Config to another goroutine in each
+// of the three ways. The racy one runs only without the race detector,
+// which would report it.
+func DemonstratePublication() {
+	fmt.Println("\n=== Publication ===")
+	cfg := Config{Addr: ":8080", Workers: 4}
+	if RaceEnabled() {
+		fmt.Println("plain flag: not run, as the race detector would report it")
+	} else {
+		var racy RacyBox
+		go racy.Publish(cfg)
+		got, ok := poll(racy.Get)
+		fmt.Printf("plain flag: %+v %v, this time; nothing promised it\n", got, ok)
+	}
+
+	once := NewOnceBox()
+	go once.Publish(cfg)
+	fmt.Printf("closed channel: %+v\n", once.Wait())
+
+	var box AtomicBox
+	go box.Publish(cfg)
+	got, _ := poll(box.Get)
+	fmt.Printf("atomic.Pointer: %+v\n", got)
+	fmt.Println("built with -race:", RaceEnabled())
+}
@@ -0,0 +1,59 @@
+package examples
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstratePublication(t *testing.T) {
+	DemonstratePublication()
+}
+
+func TestRacyBoxOneGoroutine(t *testing.T) {
+	var b RacyBox
+	_, ok := b.Get()
+	assert.False(t, ok)
+	b.Publish(Config{Addr: ":80"})
+	got, ok := b.Get()
+	assert.True(t, ok, "in one goroutine, program order is enough")
+	assert.Equal(t, ":80", got.Addr)
+}
+
+func TestOnceBox(t *testing.T) {
+	b := NewOnceBox()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, Config{Addr: ":80", Workers: 2}, b.Wait())
+		}()
+	}
+	b.Publish(Config{Addr: ":80", Workers: 2})
+	wg.Wait()
+}
+
+func TestAtomicBox(t *testing.T) {
+	var b AtomicBox
+	_, ok := b.Get()
+	assert.False(t, ok)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 1000; i++ {
+			b.Publish(Config{Addr: ":80", Workers: i})
+		}
+	}()
+	last := 0
+	for last < 1000 {
+		if c, ok := b.Get(); ok {
+			assert.GreaterOrEqual(t, c.Workers, last, "a reader never goes back")
+			assert.Equal(t, ":80", c.Addr)
+			last = c.Workers
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,128 @@
+package examples
+
+// This file shows:
+// - Double-checked locking done wrong: the check outside the lock reads what another goroutine writes inside it
+// - That a mutex orders only the goroutines that take it
+// - Building a value once with sync.Once, and with sync.OnceValue when it is returned
+// - Double-checked locking done right, with an atomic.Pointer checked before and after taking the lock
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Table stands for something slow to build, and built on first use.
+type Table struct {
+	Rows map[string]int
+}
+
+// NewTable builds a Table, counting the builds in builds.
+func NewTable(builds *atomic.Int32) *Table {
+	builds.Add(1)
+	return &Table{Rows: map[string]int{"alpha": 1, "beta": 2, "gamma": 3}}
+}
+
+// BrokenLazy builds a Table on first use with double-checked locking done
+// wrong: Get reads t without the lock, so a goroutine that finds it set
+// has no happens-before edge to the writes that built it, and may see the
+// pointer before the map it points to.
+type BrokenLazy struct {
+	New func() *Table
+	mu  sync.Mutex
+	t   *Table
+}
+
+// Get returns the Table, building it on the first call.
+func (l *BrokenLazy) Get() *Table {
+	if l.t == nil { // races with the write below
+		l.mu.Lock()
+		if l.t == nil {
+			l.t = l.New()
+		}
+		l.mu.Unlock()
+	}
+	return l.t
+}
+
+// OnceLazy builds a Table on first use with sync.Once: the function
+// passed to Do returns before any call of Do returns.
+type OnceLazy struct {
+	New  func() *Table
+	once sync.Once
+	t    *Table
+}
+
+// Get returns the Table, building it on the first call.
+func (l *OnceLazy) Get() *Table {
+	l.once.Do(func() { l.t = l.New() })
+	return l.t
+}
+
+// AtomicLazy builds a Table on first use with double-checked locking done
+// right. Only the first callers take the lock; later ones load the
+// pointer, and the Load that sees it happens after the Store that stored
+// it.
+type AtomicLazy struct {
+	New func() *Table
+	mu  sync.Mutex
+	t   atomic.Pointer[Table]
+}
+
+// Get returns the Table, building it on the first call.
+func (l *AtomicLazy) Get() *Table {
+	if t := l.t.Load(); t != nil {
+		return t
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if t := l.t.Load(); t != nil {
+		return t // built while we waited for the lock
+	}
+	t := l.New()
+	l.t.Store(t)
+	return t
+}
+
+// getMany calls get from n goroutines at once, and returns the number of
+// Tables they were given.
+func getMany(n int, get func() *Table) int {
+	var mu sync.Mutex
+	seen := make(map[*Table]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t := get()
+			mu.Lock()
+			seen[t] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return len(seen)
+}
+
+// DemonstrateLazy builds a Table from many goroutines at once, in each
+// way but the broken one, and counts the builds.
+func DemonstrateLazy() {
+	fmt.Println("\n=== Lazy initialization ===")
+	var builds atomic.Int32
+	build := func() *Table { return NewTable(&builds) }
+
+	once := &OnceLazy{New: build}
+	tables := getMany(50, once.Get)
+	fmt.Printf("sync.Once: %d table, %d build\n", tables, builds.Swap(0))
+
+	get := sync.OnceValue(build)
+	tables = getMany(50, get)
+	fmt.Printf("sync.OnceValue: %d table, %d build\n", tables, builds.Swap(0))
+
+	atomicLazy := &AtomicLazy{New: build}
+	tables = getMany(50, atomicLazy.Get)
+	fmt.Printf("atomic.Pointer: %d table, %d build\n", tables, builds.Swap(0))
+
+	broken := &BrokenLazy{New: build}
+	fmt.Println("unlocked check, from one goroutine:", broken.Get().Rows["beta"])
+}
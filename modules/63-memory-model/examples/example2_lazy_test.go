@@ -0,0 +1,32 @@
+package examples
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateLazy(t *testing.T) {
+	DemonstrateLazy()
+}
+
+func TestLazyBuildsOnce(t *testing.T) {
+	for name, get := range map[string]func(build func() *Table) func() *Table{
+		"Once":   func(build func() *Table) func() *Table { return (&OnceLazy{New: build}).Get },
+		"Atomic": func(build func() *Table) func() *Table { return (&AtomicLazy{New: build}).Get },
+	} {
+		var builds atomic.Int32
+		get := get(func() *Table { return NewTable(&builds) })
+		assert.Equal(t, 1, getMany(100, get), name)
+		assert.Equal(t, int32(1), builds.Load(), name)
+		assert.Equal(t, 2, get().Rows["beta"], name)
+	}
+}
+
+func TestBrokenLazyOneGoroutine(t *testing.T) {
+	var builds atomic.Int32
+	l := &BrokenLazy{New: func() *Table { return NewTable(&builds) }}
+	assert.Same(t, l.Get(), l.Get())
+	assert.Equal(t, int32(1), builds.Load())
+}
@@ -0,0 +1,132 @@
+package examples
+
+// This file shows:
+// - The happens-before edges of the Go memory model, each making one goroutine's write visible to another's read
+// - A send happens before the receive completes, and a close before a receive that returns because of it
+// - On an unbuffered channel, the receive happens before the send completes; with capacity C, the kth receive before the k+Cth send completes
+// - Unlock happens before the next Lock returns, and Done before the Wait it releases returns
+// - An atomic Store happens before a Load that sees it
+// - The go statement happens before the goroutine starts, but the goroutine's end is no edge at all
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Edge is one happens-before edge of the memory model.
+type Edge struct {
+	Name string
+	Rule string
+	// Pass writes 42 to a plain variable in one goroutine, and returns
+	// what another reads from it after the edge.
+	Pass func() int
+}
+
+// Edges lists the happens-before edges, in the order of the memory model.
+var Edges = []Edge{
+	{"go statement", "a go statement happens before the goroutine it starts begins", func() int {
+		x := 42
+		got := make(chan int)
+		go func() { got <- x }()
+		return <-got
+	}},
+	{"send", "a send happens before the receive of the value completes", func() int {
+		var x int
+		ch := make(chan struct{}, 1)
+		go func() {
+			x = 42
+			ch <- struct{}{}
+		}()
+		<-ch
+		return x
+	}},
+	{"close", "a close happens before a receive that returns because the channel is closed", func() int {
+		var x int
+		ch := make(chan struct{})
+		go func() {
+			x = 42
+			close(ch)
+		}()
+		<-ch
+		return x
+	}},
+	{"unbuffered receive", "a receive from an unbuffered channel happens before the send completes", func() int {
+		var x int
+		ch := make(chan struct{})
+		go func() {
+			x = 42
+			<-ch
+		}()
+		ch <- struct{}{}
+		return x
+	}},
+	{"buffered receive", "the kth receive from a channel of capacity C happens before the k+Cth send completes", func() int {
+		var x int
+		sem := make(chan struct{}, 1)
+		sem <- struct{}{}
+		go func() {
+			x = 42
+			<-sem
+		}()
+		sem <- struct{}{} // waits for the receive, as a semaphore does
+		return x
+	}},
+	{"mutex", "the nth Unlock happens before the n+1th Lock returns", func() int {
+		var x int
+		var mu sync.Mutex
+		mu.Lock()
+		go func() {
+			x = 42
+			mu.Unlock()
+		}()
+		mu.Lock()
+		defer mu.Unlock()
+		return x
+	}},
+	{"WaitGroup", "a Done happens before the Wait it releases returns", func() int {
+		var x int
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			x = 42
+		}()
+		wg.Wait()
+		return x
+	}},
+	{"sync.Once", "the function passed to Do returns before any call of Do returns", func() int {
+		var x int
+		var once sync.Once
+		got := make(chan int, 2)
+		for i := 0; i < 2; i++ {
+			go func() {
+				once.Do(func() { x = 42 })
+				got <- x // the goroutine that did not write x reads it after its Do
+			}()
+		}
+		<-got
+		return <-got
+	}},
+	{"atomic", "an atomic Store happens before a Load that observes it", func() int {
+		var x int
+		var ready atomic.Bool
+		go func() {
+			x = 42
+			ready.Store(true)
+		}()
+		for !ready.Load() {
+			runtime.Gosched()
+		}
+		return x
+	}},
+}
+
+// DemonstrateEdges passes a value across each edge.
+func DemonstrateEdges() {
+	fmt.Println("\n=== Happens-before edges ===")
+	for _, e := range Edges {
+		fmt.Printf("%-18s %d: %s\n", e.Name, e.Pass(), e.Rule)
+	}
+}
@@ -0,0 +1,24 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateEdges(t *testing.T) {
+	DemonstrateEdges()
+}
+
+// TestEdges is only meaningful with -race: without an edge, the value
+// would usually arrive anyway.
+func TestEdges(t *testing.T) {
+	for _, e := range Edges {
+		for i := 0; i < 100; i++ {
+			if got := e.Pass(); got != 42 {
+				t.Fatalf("%s: got %d", e.Name, got)
+			}
+		}
+	}
+	assert.Len(t, Edges, 9)
+}
@@ -0,0 +1,88 @@
+package exercises
+
+// EXERCISE: Fix a background loader that hands over its results without a happens-before edge.
+// Loader fetches keys in a goroutine of its own, while callers ask whether
+// it is ready, watch its progress, and cancel it. Every test passes
+// without the race detector, and on most machines always will; but the
+// loader and its callers share plain variables with nothing ordering
+// their reads and writes, and go test -race reports each of them.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrCanceled is returned by Wait for a load stopped by Cancel.
+var ErrCanceled = errors.New("load canceled")
+
+// Loader fetches a set of keys in the background, one at a time.
+type Loader struct {
+	data map[string]string
+	err  error
+	// BUG: the loader sets done after data and err, but a goroutine that
+	// reads done has no happens-before edge to those writes, or to this
+	// one. Closing a channel is an edge to every receive it releases.
+	done bool
+	// BUG: written by the loader while others read it. An atomic.Int64
+	// orders each Load after the Add it sees.
+	progress int
+	// BUG: written by any goroutine, read by the loader, and nothing
+	// orders the two.
+	canceled bool
+}
+
+// Load starts fetching keys with fetch, and returns at once.
+func Load(keys []string, fetch func(key string) (string, error)) *Loader {
+	l := &Loader{}
+	go l.run(keys, fetch)
+	return l
+}
+
+func (l *Loader) run(keys []string, fetch func(string) (string, error)) {
+	defer func() { l.done = true }()
+	data := make(map[string]string, len(keys))
+	for _, k := range keys {
+		if l.canceled {
+			l.err = ErrCanceled
+			return
+		}
+		v, err := fetch(k)
+		if err != nil {
+			l.err = err
+			return
+		}
+		data[k] = v
+		l.progress++
+	}
+	l.data = data
+}
+
+// Ready reports whether the load has finished.
+func (l *Loader) Ready() bool {
+	return l.done
+}
+
+// Progress returns the number of keys fetched so far.
+func (l *Loader) Progress() int {
+	return l.progress
+}
+
+// Cancel stops the load before the next key.
+func (l *Loader) Cancel() {
+	l.canceled = true
+}
+
+// Wait waits for the load to finish, and returns what it fetched, or the
+// error that stopped it. It gives up when ctx is done.
+func (l *Loader) Wait(ctx context.Context) (map[string]string, error) {
+	for !l.done {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Millisecond):
+		}
+	}
+	return l.data, l.err
+}
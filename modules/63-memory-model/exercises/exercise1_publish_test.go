@@ -0,0 +1,107 @@
+package exercises
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func upper(key string) (string, error) {
+	return strings.ToUpper(key), nil
+}
+
+// slowly returns fetch, made to take d per key.
+func slowly(d time.Duration, fetch func(string) (string, error)) func(string) (string, error) {
+	return func(key string) (string, error) {
+		time.Sleep(d)
+		return fetch(key)
+	}
+}
+
+func keys(n int) []string {
+	var ks []string
+	for i := 0; i < n; i++ {
+		ks = append(ks, fmt.Sprintf("k%d", i))
+	}
+	return ks
+}
+
+func TestLoaderWait(t *testing.T) {
+	l := Load([]string{"a", "b", "c"}, slowly(time.Millisecond, upper))
+	data, err := l.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "A", "b": "B", "c": "C"}, data)
+	assert.True(t, l.Ready())
+	assert.Equal(t, 3, l.Progress())
+}
+
+func TestLoaderReady(t *testing.T) {
+	l := Load([]string{"a", "b"}, slowly(10*time.Millisecond, upper))
+	assert.False(t, l.Ready(), "still fetching")
+	deadline := time.Now().Add(5 * time.Second)
+	for !l.Ready() {
+		require.True(t, time.Now().Before(deadline), "never ready")
+		time.Sleep(time.Millisecond)
+	}
+	data, err := l.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "B", data["b"])
+}
+
+func TestLoaderProgress(t *testing.T) {
+	l := Load(keys(20), slowly(time.Millisecond, upper))
+	last := 0
+	for !l.Ready() {
+		p := l.Progress()
+		require.GreaterOrEqual(t, p, last, "progress went back")
+		require.LessOrEqual(t, p, 20)
+		last = p
+		time.Sleep(100 * time.Microsecond)
+	}
+	assert.Equal(t, 20, l.Progress())
+}
+
+func TestLoaderCancel(t *testing.T) {
+	l := Load(keys(1000), slowly(time.Millisecond, upper))
+	for l.Progress() < 2 {
+		time.Sleep(100 * time.Microsecond)
+	}
+	l.Cancel()
+	data, err := l.Wait(context.Background())
+	assert.ErrorIs(t, err, ErrCanceled)
+	assert.Nil(t, data)
+	assert.Less(t, l.Progress(), 1000, "the loader went on after Cancel")
+}
+
+func TestLoaderError(t *testing.T) {
+	errDown := errors.New("backend down")
+	l := Load([]string{"a", "b", "c"}, func(key string) (string, error) {
+		if key == "b" {
+			return "", errDown
+		}
+		return upper(key)
+	})
+	data, err := l.Wait(context.Background())
+	assert.ErrorIs(t, err, errDown)
+	assert.Nil(t, data)
+	assert.Equal(t, 1, l.Progress())
+}
+
+func TestLoaderWaitGivesUp(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	l := Load([]string{"a"}, func(key string) (string, error) {
+		<-release
+		return upper(key)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := l.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
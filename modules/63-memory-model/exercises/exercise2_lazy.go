@@ -0,0 +1,79 @@
+package exercises
+
+// EXERCISE: Fix a lazily built registry whose double-checked locking races.
+// Registry builds its index on first use, with a slow function, and only
+// the first callers should wait for it. It checks whether the index is
+// built before taking the lock, and again after. The tests pass without
+// the race detector; with it, they show that a reader who skips the lock
+// has no happens-before edge to the build, and that Add writes to a map
+// that readers are reading.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"sort"
+	"sync"
+)
+
+// Registry maps names to IDs. It is built on first use, by a function
+// that is slow, and can be added to afterwards. Its methods may be called
+// from any number of goroutines.
+type Registry struct {
+	build func() map[string]int
+	mu    sync.Mutex // serializes building and Add; readers take no lock
+	index map[string]int
+}
+
+// NewRegistry returns a Registry that build will fill on first use.
+func NewRegistry(build func() map[string]int) *Registry {
+	return &Registry{build: build}
+}
+
+// load returns the index, building it on the first call.
+func (r *Registry) load() map[string]int {
+	// BUG: a plain read of what another goroutine writes under the lock.
+	// The lock orders only the goroutines that take it, so this one may
+	// see the map before the writes that filled it. An atomic.Pointer,
+	// loaded here and again under the lock, is double-checked locking
+	// done right.
+	if r.index != nil {
+		return r.index
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.loadLocked()
+}
+
+// loadLocked is load, for a caller that holds r.mu.
+func (r *Registry) loadLocked() map[string]int {
+	if r.index == nil {
+		r.index = r.build()
+	}
+	return r.index
+}
+
+// Lookup returns the ID of name.
+func (r *Registry) Lookup(name string) (int, bool) {
+	id, ok := r.load()[name]
+	return id, ok
+}
+
+// Names returns the names in the registry, sorted.
+func (r *Registry) Names() []string {
+	m := r.load()
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Add gives name the ID id.
+func (r *Registry) Add(name string, id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// BUG: this writes to the map that readers hold without the lock.
+	// Change a copy, and store the copy.
+	m := r.loadLocked()
+	m[name] = id
+}
@@ -0,0 +1,70 @@
+package exercises
+
+// EXERCISE: Fix a parallel map whose workers hand their results back without a happens-before edge.
+// Map calls a function on each item with a few workers, stops at the
+// first error, and returns the results in order. The tests pass without
+// the race detector. With it, they show that the caller reads the results
+// with nothing ordering its reads after the workers' writes, and that the
+// workers share the first error and the stop flag the same way.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Map calls f on each of items with up to workers goroutines, and returns
+// the results in the order of items. If a call fails, Map starts no more
+// calls, and returns the first error reported.
+func Map[T, R any](items []T, workers int, f func(T) (R, error)) ([]R, error) {
+	workers = max(1, min(workers, len(items)))
+	results := make([]R, len(items))
+	jobs := make(chan int)
+	var (
+		mu       sync.Mutex
+		finished int
+		firstErr error
+		stop     bool
+	)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer func() {
+				mu.Lock()
+				finished++
+				mu.Unlock()
+			}()
+			for i := range jobs {
+				// BUG: another worker writes stop, with nothing ordering
+				// its write and this read.
+				if stop {
+					continue
+				}
+				r, err := f(items[i])
+				if err != nil {
+					// BUG: workers that fail at the same time write firstErr
+					// at the same time.
+					if firstErr == nil {
+						firstErr = err
+					}
+					stop = true
+					continue
+				}
+				results[i] = r
+			}
+		}()
+	}
+	for i := range items {
+		jobs <- i
+	}
+	close(jobs)
+	// BUG: the workers count themselves under mu, but this reads finished
+	// without it. A lock orders only the goroutines that take it, so the
+	// results are read with no edge to the writes. A sync.WaitGroup is one.
+	for finished < workers {
+		runtime.Gosched()
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
@@ -0,0 +1,84 @@
+package exercises
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errOdd = errors.New("odd")
+
+func TestMapResults(t *testing.T) {
+	items := make([]int, 200)
+	for i := range items {
+		items[i] = i
+	}
+	got, err := Map(items, 8, func(n int) (string, error) {
+		return fmt.Sprint(n * n), nil
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 200)
+	for i, s := range got {
+		assert.Equal(t, fmt.Sprint(i*i), s)
+	}
+}
+
+func TestMapWorkers(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 100} {
+		var running, most atomic.Int32
+		got, err := Map([]int{1, 2, 3, 4, 5, 6}, workers, func(n int) (int, error) {
+			now := running.Add(1)
+			defer running.Add(-1)
+			for {
+				m := most.Load()
+				if now <= m || most.CompareAndSwap(m, now) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			return -n, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{-1, -2, -3, -4, -5, -6}, got)
+		assert.LessOrEqual(t, most.Load(), int32(max(1, workers)), "%d workers", workers)
+	}
+
+	got, err := Map(nil, 4, func(n int) (int, error) { return n, nil })
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+// TestMapStopsAfterError fails the first call, while the other workers
+// are busy with theirs.
+func TestMapStopsAfterError(t *testing.T) {
+	var calls atomic.Int32
+	_, err := Map(make([]int, 200), 4, func(int) (int, error) {
+		n := calls.Add(1)
+		time.Sleep(time.Millisecond)
+		if n == 1 {
+			return 0, errOdd
+		}
+		return 0, nil
+	})
+	assert.ErrorIs(t, err, errOdd)
+	assert.Less(t, calls.Load(), int32(20), "the workers went on after the error")
+}
+
+// TestMapFailsTogether fails every call, all at the same time.
+func TestMapFailsTogether(t *testing.T) {
+	var arrived sync.WaitGroup
+	arrived.Add(4)
+	_, err := Map([]int{1, 3, 5, 7}, 4, func(n int) (int, error) {
+		arrived.Done()
+		arrived.Wait() // until every worker has a call
+		return 0, fmt.Errorf("item %d: %w", n, errOdd)
+	})
+	assert.ErrorIs(t, err, errOdd)
+	assert.Regexp(t, `^item [1357]: odd$`, err.Error())
+}
@@ -0,0 +1,81 @@
+package solutions
+
+// SOLUTION: Fix a background loader that hands over its results without a happens-before edge.
+// Closing ready, after data and err are set, is the edge from the loader
+// to every Ready and Wait that sees it closed. The progress counter and
+// the cancel flag are atomics, each Load ordered after the Store it sees.
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrCanceled is returned by Wait for a load stopped by Cancel.
+var ErrCanceled = errors.New("load canceled")
+
+// Loader fetches a set of keys in the background, one at a time.
+type Loader struct {
+	data     map[string]string
+	err      error
+	ready    chan struct{} // Fixed: closed once data and err are set
+	progress atomic.Int64  // Fixed: written by the loader, read by anyone
+	canceled atomic.Bool   // Fixed: written by anyone, read by the loader
+}
+
+// Load starts fetching keys with fetch, and returns at once.
+func Load(keys []string, fetch func(key string) (string, error)) *Loader {
+	l := &Loader{ready: make(chan struct{})}
+	go l.run(keys, fetch)
+	return l
+}
+
+func (l *Loader) run(keys []string, fetch func(string) (string, error)) {
+	defer close(l.ready)
+	data := make(map[string]string, len(keys))
+	for _, k := range keys {
+		if l.canceled.Load() {
+			l.err = ErrCanceled
+			return
+		}
+		v, err := fetch(k)
+		if err != nil {
+			l.err = err
+			return
+		}
+		data[k] = v
+		l.progress.Add(1)
+	}
+	l.data = data
+}
+
+// Ready reports whether the load has finished.
+func (l *Loader) Ready() bool {
+	select {
+	case <-l.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// Progress returns the number of keys fetched so far.
+func (l *Loader) Progress() int {
+	return int(l.progress.Load())
+}
+
+// Cancel stops the load before the next key.
+func (l *Loader) Cancel() {
+	l.canceled.Store(true)
+}
+
+// Wait waits for the load to finish, and returns what it fetched, or the
+// error that stopped it. It gives up when ctx is done.
+func (l *Loader) Wait(ctx context.Context) (map[string]string, error) {
+	select {
+	case <-l.ready:
+		return l.data, l.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
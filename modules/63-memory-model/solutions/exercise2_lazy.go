@@ -0,0 +1,76 @@
+package solutions
+
+// SOLUTION: Fix a lazily built registry whose double-checked locking races.
+// The index is an atomic.Pointer to a map that never changes once stored.
+// A reader that loads it has a happens-before edge to the build that
+// filled it, and Add copies the map and stores the copy, so that no
+// reader ever sees a map being written.
+
+import (
+	"maps"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Registry maps names to IDs. It is built on first use, by a function
+// that is slow, and can be added to afterwards. Its methods may be called
+// from any number of goroutines.
+type Registry struct {
+	build func() map[string]int
+	mu    sync.Mutex // serializes building and Add; readers take no lock
+	index atomic.Pointer[map[string]int]
+}
+
+// NewRegistry returns a Registry that build will fill on first use.
+func NewRegistry(build func() map[string]int) *Registry {
+	return &Registry{build: build}
+}
+
+// load returns the index, building it on the first call.
+func (r *Registry) load() map[string]int {
+	// Fixed: an atomic Load, which happens after the Store it sees.
+	if m := r.index.Load(); m != nil {
+		return *m
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.loadLocked()
+}
+
+// loadLocked is load, for a caller that holds r.mu.
+func (r *Registry) loadLocked() map[string]int {
+	if m := r.index.Load(); m != nil {
+		return *m // built while we waited for the lock
+	}
+	m := r.build()
+	r.index.Store(&m)
+	return m
+}
+
+// Lookup returns the ID of name.
+func (r *Registry) Lookup(name string) (int, bool) {
+	id, ok := r.load()[name]
+	return id, ok
+}
+
+// Names returns the names in the registry, sorted.
+func (r *Registry) Names() []string {
+	m := r.load()
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Add gives name the ID id.
+func (r *Registry) Add(name string, id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Fixed: a copy, which no reader has until it is stored.
+	m := maps.Clone(r.loadLocked())
+	m[name] = id
+	r.index.Store(&m)
+}
@@ -0,0 +1,99 @@
+package solutions
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// planets returns a slow build function, which counts its calls in calls.
+func planets(calls *atomic.Int32) func() map[string]int {
+	return func() map[string]int {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return map[string]int{"mercury": 1, "venus": 2, "earth": 3}
+	}
+}
+
+func TestRegistryLookup(t *testing.T) {
+	var calls atomic.Int32
+	r := NewRegistry(planets(&calls))
+	assert.Equal(t, int32(0), calls.Load(), "built on first use")
+	id, ok := r.Lookup("venus")
+	assert.True(t, ok)
+	assert.Equal(t, 2, id)
+	_, ok = r.Lookup("pluto")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"earth", "mercury", "venus"}, r.Names())
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRegistryBuildsOnce(t *testing.T) {
+	var calls atomic.Int32
+	r := NewRegistry(planets(&calls))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, _ := r.Lookup("earth")
+			assert.Equal(t, 3, id)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+// TestRegistryLateReader has a goroutine look up a name after another has
+// built the index. Nothing orders the two but time, which is not enough.
+func TestRegistryLateReader(t *testing.T) {
+	var calls atomic.Int32
+	r := NewRegistry(planets(&calls))
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		r.Lookup("mercury")
+	}()
+	time.Sleep(50 * time.Millisecond)
+	go func() {
+		defer wg.Done()
+		id, ok := r.Lookup("mercury")
+		assert.True(t, ok)
+		assert.Equal(t, 1, id)
+	}()
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRegistryAdd(t *testing.T) {
+	var calls atomic.Int32
+	r := NewRegistry(planets(&calls))
+	r.Add("mars", 4)
+	assert.Equal(t, []string{"earth", "mars", "mercury", "venus"}, r.Names(), "built before the first Add")
+	r.Add("venus", 20)
+	id, _ := r.Lookup("venus")
+	assert.Equal(t, 20, id)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+// TestRegistryAddWhileReading adds a name after a reader has started
+// listing them, with nothing ordering the two but time.
+func TestRegistryAddWhileReading(t *testing.T) {
+	var calls atomic.Int32
+	r := NewRegistry(planets(&calls))
+	r.Lookup("earth")
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.Equal(t, []string{"earth", "mercury", "venus"}, r.Names())
+	}()
+	time.Sleep(20 * time.Millisecond)
+	r.Add("mars", 4)
+	wg.Wait()
+	assert.Len(t, r.Names(), 4)
+}
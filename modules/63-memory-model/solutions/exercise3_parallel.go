@@ -0,0 +1,55 @@
+package solutions
+
+// SOLUTION: Fix a parallel map whose workers hand their results back without a happens-before edge.
+// A WaitGroup is the edge from every worker to the caller: each Done
+// happens before Wait returns, and so does each write to the results.
+// The first error is kept with sync.Once, and the stop flag is an
+// atomic.Bool, because workers write and read them while others run.
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Map calls f on each of items with up to workers goroutines, and returns
+// the results in the order of items. If a call fails, Map starts no more
+// calls, and returns the first error reported.
+func Map[T, R any](items []T, workers int, f func(T) (R, error)) ([]R, error) {
+	workers = max(1, min(workers, len(items)))
+	results := make([]R, len(items))
+	jobs := make(chan int)
+	var (
+		wg       sync.WaitGroup // Fixed: the edge from the workers to the caller
+		errOnce  sync.Once
+		firstErr error
+		stop     atomic.Bool
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if stop.Load() { // Fixed: an atomic, which the other workers write
+					continue
+				}
+				r, err := f(items[i])
+				if err != nil {
+					// Fixed: once, however many workers fail at the same time.
+					errOnce.Do(func() { firstErr = err })
+					stop.Store(true)
+					continue
+				}
+				results[i] = r
+			}
+		}()
+	}
+	for i := range items {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
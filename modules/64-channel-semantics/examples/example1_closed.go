@@ -0,0 +1,73 @@
+// Package examples demonstrates the corners of Go's channels: what a
+// closed channel gives its receivers, which operations panic, what a nil
+// channel does, and who may close a channel.
+//
+// This file shows:
+// - A receive from a closed channel returns at once, with the zero value, and ok false in v, ok := <-ch
+// - A closed buffered channel still gives the values in it, and only then zero values
+// - range over a channel ends when the channel is closed and drained, and never if it is not closed
+// - Closing as a broadcast: one close releases every receiver, where a send reaches one
+package examples
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Receive describes what one receive from ch returns.
+func Receive(ch <-chan int) string {
+	v, ok := <-ch
+	return fmt.Sprintf("%d %v", v, ok)
+}
+
+// Drain receives from ch until it is closed, and returns what it received.
+func Drain(ch <-chan int) []int {
+	var got []int
+	for v := range ch {
+		got = append(got, v)
+	}
+	return got
+}
+
+// Broadcast starts n goroutines that wait on start, closes it, and
+// returns how many were released. A send on start would release one.
+func Broadcast(n int) int {
+	start := make(chan struct{})
+	var mu sync.Mutex
+	released := 0
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			mu.Lock()
+			released++
+			mu.Unlock()
+		}()
+	}
+	close(start)
+	wg.Wait()
+	return released
+}
+
+// DemonstrateClosed receives from channels open and closed, buffered and
+// not.
+func DemonstrateClosed() {
+	fmt.Println("\n=== Closed channels ===")
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	close(ch)
+	fmt.Printf("closed with 2 values: len %d, cap %d\n", len(ch), cap(ch))
+	for i := 0; i < 4; i++ {
+		fmt.Println("receive:", Receive(ch)) // 1 true, 2 true, then 0 false forever
+	}
+
+	ch = make(chan int, 3)
+	ch <- 7
+	ch <- 8
+	close(ch)
+	fmt.Println("range until drained:", Drain(ch))
+	fmt.Println("one close released", Broadcast(5), "goroutines")
+}
@@ -0,0 +1,35 @@
+package examples
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateClosed(t *testing.T) {
+	DemonstrateClosed()
+}
+
+func TestReceiveClosed(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 5
+	close(ch)
+	assert.Equal(t, "5 true", Receive(ch), "the value sent before the close")
+	assert.Equal(t, "0 false", Receive(ch))
+	assert.Equal(t, "0 false", Receive(ch), "and so on, without blocking")
+}
+
+func TestDrain(t *testing.T) {
+	leaktest.NoDeadlock(t, time.Second, func() {
+		assert.Equal(t, []int{1, 2, 3}, Drain(Count(3)))
+		assert.Empty(t, Drain(Count(0)))
+	})
+}
+
+func TestBroadcast(t *testing.T) {
+	leaktest.NoDeadlock(t, time.Second, func() {
+		assert.Equal(t, 10, Broadcast(10))
+	})
+}
@@ -0,0 +1,113 @@
+package examples
+
+// This file shows:
+// - The three panics: closing a closed channel, closing a nil channel, and sending on a closed channel
+// - Turning a panic into an error with recover, to show it without ending the program
+// - Ownership: the goroutine that sends on a channel closes it, and hands out only its receive side
+// - Many senders: a WaitGroup, and one goroutine that closes the channel once they are all done
+// - Telling senders to stop by closing a done channel of their own, never the channel they send on
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Try calls f, and returns the panic it raised, if any, as an error.
+func Try(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	f()
+	return nil
+}
+
+// Count sends 1 to n on the channel it returns, and closes it. It owns
+// the channel: it makes it, is its only sender, and closes it. Its caller
+// can only receive.
+func Count(n int) <-chan int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= n; i++ {
+			ch <- i
+		}
+	}()
+	return ch
+}
+
+// CountAll sends 1 to n on the channel it returns once for each of
+// senders goroutines. None of them may close it, as the others would
+// then send on a closed channel; a goroutine of its own closes it once
+// they are all done.
+func CountAll(senders, n int) <-chan int {
+	ch := make(chan int)
+	var wg sync.WaitGroup
+	for s := 0; s < senders; s++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 1; i <= n; i++ {
+				ch <- i
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+	return ch
+}
+
+// Naturals sends 0, 1, 2, ... on the channel it returns until done is
+// closed, then closes it. A receiver that has had enough closes done: it
+// cannot close the channel it receives from, as Naturals would panic
+// sending on it.
+func Naturals(done <-chan struct{}) <-chan int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 0; ; i++ {
+			select {
+			case ch <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// DemonstrateClosing shows the panics, then channels closed by the
+// goroutines that own them.
+func DemonstrateClosing() {
+	fmt.Println("\n=== Who closes ===")
+	fmt.Println("close twice:", Try(func() {
+		ch := make(chan int)
+		close(ch)
+		close(ch)
+	}))
+	fmt.Println("close nil:", Try(func() {
+		var ch chan int
+		close(ch)
+	}))
+	fmt.Println("send on closed:", Try(func() {
+		ch := make(chan int, 1)
+		close(ch)
+		ch <- 1
+	}))
+
+	fmt.Println("one sender:", Drain(Count(5)))
+	fmt.Println("three senders, values received:", len(Drain(CountAll(3, 5))))
+
+	done := make(chan struct{})
+	var got []int
+	for v := range Naturals(done) {
+		got = append(got, v)
+		if len(got) == 5 {
+			close(done) // and range on, until Naturals closes the channel
+		}
+	}
+	fmt.Println("until done:", got[:5], "and then Naturals closed its channel")
+}
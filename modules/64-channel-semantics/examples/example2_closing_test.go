@@ -0,0 +1,53 @@
+package examples
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateClosing(t *testing.T) {
+	DemonstrateClosing()
+}
+
+func TestTry(t *testing.T) {
+	assert.NoError(t, Try(func() {}))
+	assert.EqualError(t, Try(func() {
+		ch := make(chan int)
+		close(ch)
+		close(ch)
+	}), "panic: close of closed channel")
+	assert.EqualError(t, Try(func() {
+		var ch chan int
+		close(ch)
+	}), "panic: close of nil channel")
+	assert.EqualError(t, Try(func() {
+		ch := make(chan int, 1)
+		close(ch)
+		ch <- 1
+	}), "panic: send on closed channel")
+}
+
+func TestCountAll(t *testing.T) {
+	leaktest.Check(t)
+	leaktest.NoDeadlock(t, time.Second, func() {
+		got := Drain(CountAll(3, 4))
+		sort.Ints(got)
+		assert.Equal(t, []int{1, 1, 1, 2, 2, 2, 3, 3, 3, 4, 4, 4}, got)
+	})
+}
+
+func TestNaturalsStops(t *testing.T) {
+	leaktest.Check(t)
+	leaktest.NoDeadlock(t, time.Second, func() {
+		done := make(chan struct{})
+		ch := Naturals(done)
+		assert.Equal(t, 0, <-ch)
+		assert.Equal(t, 1, <-ch)
+		close(done)
+		Drain(ch) // ends once Naturals sees done
+	})
+}
@@ -0,0 +1,107 @@
+package examples
+
+// This file shows:
+// - A send or a receive on a nil channel blocks forever, and a select never chooses its case
+// - Setting a closed input to nil, so that a select stops choosing it and a loop can end
+// - Setting an output to nil while there is nothing to send, so that one select reads and writes
+// - That select with a default never blocks, which makes a blocked operation visible
+
+import (
+	"fmt"
+	"time"
+)
+
+// Ready reports whether a receive from ch would proceed now.
+func Ready(ch <-chan int) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// Merge sends the values of a and b on the channel it returns, in the
+// order they come, and closes it once both are closed. A closed input is
+// set to nil: without that, the select would keep choosing it, receiving
+// zero values, and the loop would never end.
+func Merge(a, b <-chan int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for a != nil || b != nil {
+			select {
+			case v, ok := <-a:
+				if !ok {
+					a = nil
+					continue
+				}
+				out <- v
+			case v, ok := <-b:
+				if !ok {
+					b = nil
+					continue
+				}
+				out <- v
+			}
+		}
+	}()
+	return out
+}
+
+// Buffer receives from in and sends on the channel it returns, holding
+// any number of values in between, so that in never waits for a slow
+// receiver. The send case has a nil channel while there is nothing to
+// send.
+func Buffer(in <-chan int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		var queue []int
+		for in != nil || len(queue) > 0 {
+			var send chan<- int // nil: nothing to send, so its case is never chosen
+			var next int
+			if len(queue) > 0 {
+				send, next = out, queue[0]
+			}
+			select {
+			case v, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				queue = append(queue, v)
+			case send <- next:
+				queue = queue[1:]
+			}
+		}
+	}()
+	return out
+}
+
+// DemonstrateNil shows nil channels blocking, and disabling select cases.
+func DemonstrateNil() {
+	fmt.Println("\n=== Nil channels ===")
+	var nilCh chan int
+	fmt.Println("nil channel ready:", Ready(nilCh))
+	select {
+	case nilCh <- 1:
+		fmt.Println("sent on nil")
+	case <-time.After(10 * time.Millisecond):
+		fmt.Println("a send on a nil channel is still waiting after 10ms, and always will be")
+	}
+
+	closed := make(chan int)
+	close(closed)
+	fmt.Println("closed channel ready:", Ready(closed))
+
+	fmt.Println("merged:", len(Drain(Merge(Count(3), Count(4)))), "values")
+
+	in := make(chan int)
+	out := Buffer(in)
+	for i := 0; i < 5; i++ {
+		in <- i // no receiver yet: Buffer holds them
+	}
+	close(in)
+	fmt.Println("buffered:", Drain(out))
+}
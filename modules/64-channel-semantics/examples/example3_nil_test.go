@@ -0,0 +1,46 @@
+package examples
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateNil(t *testing.T) {
+	DemonstrateNil()
+}
+
+func TestReady(t *testing.T) {
+	assert.False(t, Ready(nil))
+	assert.False(t, Ready(make(chan int)))
+	ch := make(chan int, 1)
+	ch <- 1
+	assert.True(t, Ready(ch))
+}
+
+func TestMerge(t *testing.T) {
+	leaktest.Check(t)
+	leaktest.NoDeadlock(t, time.Second, func() {
+		got := Drain(Merge(Count(3), Count(2)))
+		sort.Ints(got)
+		assert.Equal(t, []int{1, 1, 2, 2, 3}, got, "no zero values from the closed input")
+	})
+}
+
+func TestBuffer(t *testing.T) {
+	leaktest.Check(t)
+	leaktest.NoDeadlock(t, time.Second, func() {
+		in := make(chan int)
+		out := Buffer(in)
+		for i := 0; i < 100; i++ {
+			in <- i
+		}
+		close(in)
+		got := Drain(out)
+		assert.Len(t, got, 100)
+		assert.Equal(t, 99, got[99], "in order")
+	})
+}
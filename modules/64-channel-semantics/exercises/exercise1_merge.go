@@ -0,0 +1,40 @@
+package exercises
+
+// EXERCISE: Fix a merge of channels that reads zero values from closed inputs and never ends.
+// Merge fans two channels into one, and should close its output once both
+// inputs are closed. But it passes on zero values from an input that has
+// closed, it never sees both inputs closed, and when its receiver gives up
+// it stays blocked forever, sending a value nobody will receive.
+// Fix the bugs marked with // BUG: comments.
+
+import "context"
+
+// Merge sends the values of a and b on the channel it returns, in the
+// order they arrive, and closes it once both are closed or ctx is done.
+func Merge[T any](ctx context.Context, a, b <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for a != nil || b != nil {
+			var v T
+			var ok bool
+			select {
+			// BUG: a receive from a closed channel returns at once, with
+			// the zero value. Only v, ok := <-a tells it from a value sent.
+			case v = <-a:
+			case v, ok = <-b:
+				if !ok {
+					// BUG: a closed channel is always ready, so the select
+					// keeps choosing it, and b is never nil, so the loop
+					// never ends. A nil channel's case is never chosen.
+					continue
+				}
+			case <-ctx.Done():
+				return
+			}
+			// BUG: if the receiver has given up, this waits forever.
+			out <- v
+		}
+	}()
+	return out
+}
@@ -0,0 +1,66 @@
+package exercises
+
+// EXERCISE: Fix a work queue that panics on a second Close or a late Put, and deadlocks closing.
+// Queue owns a buffered channel that producers Put to and consumers range
+// over, and Close closes it, so that consumers know the work is done. But
+// closing it twice panics, a Put after Close sends on a closed channel,
+// and a Close while a Put waits on a full queue waits for that Put for
+// ever.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by Put on a closed Queue.
+var ErrClosed = errors.New("queue closed")
+
+// Queue is a bounded queue of work, with any number of producers and
+// consumers. Closing it tells the consumers that no more is coming.
+type Queue[T any] struct {
+	items chan T
+	mu    sync.RWMutex // held for reading by Put while it sends, so Close waits for it
+	// closed is set by Close, under mu.
+	closed bool
+}
+
+// NewQueue returns an empty Queue that holds up to size items.
+func NewQueue[T any](size int) *Queue[T] {
+	return &Queue[T]{items: make(chan T, size)}
+}
+
+// Put adds v to the queue, waiting while it is full. It returns ErrClosed
+// if the queue is closed, and ctx.Err() if ctx is done first.
+func (q *Queue[T]) Put(ctx context.Context, v T) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	// BUG: after Close, this sends on a closed channel, which panics.
+	select {
+	case q.items <- v:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Items returns the channel the consumers receive from. It is closed once
+// the queue is closed and every item in it has been received.
+func (q *Queue[T]) Items() <-chan T {
+	return q.items
+}
+
+// Close closes the queue. Items already in it can still be received.
+// Calling Close again does nothing.
+func (q *Queue[T]) Close() {
+	// BUG: a Put waiting on a full queue holds the read lock until a
+	// consumer makes room, which may be never, so this waits with it.
+	// Close a second channel first, one that wakes waiting Puts, and that
+	// they select on.
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	// BUG: closing a closed channel panics.
+	close(q.items)
+}
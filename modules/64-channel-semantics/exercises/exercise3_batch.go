@@ -0,0 +1,59 @@
+package exercises
+
+// EXERCISE: Fix a batcher whose select waits on channels it should have switched off.
+// Batch groups the values of a channel into slices, sending each when it
+// is full or has waited long enough. But a slow receiver stops it reading
+// its input, the timer of a batch already sent sends an empty one, and it
+// never notices that its input is closed for good. Each fix is a case
+// of its select switched off with a nil channel.
+// Fix the bugs marked with // BUG: comments.
+
+import "time"
+
+// Batch receives from in until it is closed, and sends what it receives
+// on the channel it returns, in batches of size values. A batch that is
+// not full is sent once wait has passed since its first value, and when
+// in is closed. The channel is closed after the last batch.
+func Batch[T any](in <-chan T, size int, wait time.Duration) <-chan []T {
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		var (
+			batch  []T
+			expire <-chan time.Time // nil while there is no batch
+		)
+		flush := func() {
+			// BUG: this waits for a receiver, and meanwhile nothing reads
+			// in. Keep the batches that are ready, and send the first from
+			// the select, in a case whose channel is nil while there are
+			// none.
+			out <- batch
+			// BUG: the timer of the batch just sent is still running, and
+			// flushes the next batch, empty or not, when it fires.
+			batch = nil
+		}
+		for in != nil {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					// BUG: in is closed, but not nil, so the loop goes on,
+					// and the select chooses this case again at once.
+					if len(batch) > 0 {
+						flush()
+					}
+					continue
+				}
+				if len(batch) == 0 {
+					expire = time.After(wait)
+				}
+				batch = append(batch, v)
+				if len(batch) == size {
+					flush()
+				}
+			case <-expire:
+				flush()
+			}
+		}
+	}()
+	return out
+}
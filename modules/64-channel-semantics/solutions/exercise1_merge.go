@@ -0,0 +1,42 @@
+package solutions
+
+// SOLUTION: Fix a merge of channels that reads zero values from closed inputs and never ends.
+// Each receive checks ok, and a closed input is set to nil, so that the
+// select stops choosing it and the loop ends once both are closed. Every
+// send also waits on ctx, so that a receiver that gives up does not leave
+// the merging goroutine blocked for good.
+
+import "context"
+
+// Merge sends the values of a and b on the channel it returns, in the
+// order they arrive, and closes it once both are closed or ctx is done.
+func Merge[T any](ctx context.Context, a, b <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for a != nil || b != nil {
+			var v T
+			var ok bool
+			select {
+			case v, ok = <-a:
+				if !ok { // Fixed: a closed channel gives zero values forever
+					a = nil // Fixed: and is always ready, unless set to nil
+					continue
+				}
+			case v, ok = <-b:
+				if !ok {
+					b = nil
+					continue
+				}
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case out <- v:
+			case <-ctx.Done(): // Fixed: nobody may ever receive it
+				return
+			}
+		}
+	}()
+	return out
+}
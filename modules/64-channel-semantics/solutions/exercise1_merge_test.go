@@ -0,0 +1,73 @@
+package solutions
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+// send returns a channel that has the values vs, and is closed.
+func send(vs ...int) <-chan int {
+	ch := make(chan int, len(vs))
+	for _, v := range vs {
+		ch <- v
+	}
+	close(ch)
+	return ch
+}
+
+// collect receives up to limit values from ch, or until it is closed.
+func collect[T any](ch <-chan T, limit int) []T {
+	var got []T
+	for v := range ch {
+		got = append(got, v)
+		if len(got) == limit {
+			break
+		}
+	}
+	return got
+}
+
+func TestMergeValues(t *testing.T) {
+	leaktest.Check(t)
+	leaktest.NoDeadlock(t, time.Second, func() {
+		got := collect(Merge(context.Background(), send(1, 2, 3), send(10, 20)), 10)
+		sort.Ints(got)
+		assert.Equal(t, []int{1, 2, 3, 10, 20}, got, "no zero values from a closed input")
+	})
+}
+
+func TestMergeEnds(t *testing.T) {
+	leaktest.Check(t)
+	leaktest.NoDeadlock(t, time.Second, func() {
+		a := make(chan int)
+		out := Merge(context.Background(), a, send())
+		a <- 7
+		assert.Equal(t, 7, <-out)
+		close(a)
+		_, ok := <-out
+		assert.False(t, ok, "out is closed once both inputs are")
+	})
+}
+
+func TestMergeNilInput(t *testing.T) {
+	leaktest.Check(t)
+	leaktest.NoDeadlock(t, time.Second, func() {
+		got := collect(Merge(context.Background(), nil, send(4, 5)), 10)
+		assert.ElementsMatch(t, []int{4, 5}, got, "a nil input is one already closed")
+	})
+}
+
+func TestMergeCanceled(t *testing.T) {
+	leaktest.Check(t)
+	leaktest.NoDeadlock(t, time.Second, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		out := Merge(ctx, send(1, 2, 3), make(chan int))
+		assert.Equal(t, 1, <-out)
+		cancel() // and receive no more
+	})
+}
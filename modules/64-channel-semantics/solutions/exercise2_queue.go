@@ -0,0 +1,67 @@
+package solutions
+
+// SOLUTION: Fix a work queue that panics on a second Close or a late Put, and deadlocks closing.
+// The queue owns its channel, and only Close closes it, once, under the
+// lock that Put holds while it sends. Close first closes done, which
+// wakes a Put blocked on a full queue, so that it lets go of the lock.
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by Put on a closed Queue.
+var ErrClosed = errors.New("queue closed")
+
+// Queue is a bounded queue of work, with any number of producers and
+// consumers. Closing it tells the consumers that no more is coming.
+type Queue[T any] struct {
+	items chan T
+	done  chan struct{} // closed first by Close, to wake blocked Puts
+	once  sync.Once
+	mu    sync.RWMutex // held for reading by Put while it sends, so Close waits for it
+	// closed is set by Close, under mu.
+	closed bool
+}
+
+// NewQueue returns an empty Queue that holds up to size items.
+func NewQueue[T any](size int) *Queue[T] {
+	return &Queue[T]{items: make(chan T, size), done: make(chan struct{})}
+}
+
+// Put adds v to the queue, waiting while it is full. It returns ErrClosed
+// if the queue is closed, and ctx.Err() if ctx is done first.
+func (q *Queue[T]) Put(ctx context.Context, v T) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed { // Fixed: a send on the closed channel would panic
+		return ErrClosed
+	}
+	select {
+	case q.items <- v:
+		return nil
+	case <-q.done:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Items returns the channel the consumers receive from. It is closed once
+// the queue is closed and every item in it has been received.
+func (q *Queue[T]) Items() <-chan T {
+	return q.items
+}
+
+// Close closes the queue. Items already in it can still be received.
+// Calling Close again does nothing.
+func (q *Queue[T]) Close() {
+	q.once.Do(func() { // Fixed: a second close would panic
+		close(q.done) // Fixed: before waiting for the lock a blocked Put holds
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.closed = true
+		close(q.items)
+	})
+}
@@ -0,0 +1,96 @@
+package solutions
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueOrder(t *testing.T) {
+	leaktest.NoDeadlock(t, time.Second, func() {
+		q := NewQueue[string](3)
+		for _, s := range []string{"a", "b", "c"} {
+			require.NoError(t, q.Put(context.Background(), s))
+		}
+		q.Close()
+		assert.Equal(t, []string{"a", "b", "c"}, collect(q.Items(), 10), "items put before Close are still received")
+	})
+}
+
+func TestQueueCloseTwice(t *testing.T) {
+	leaktest.NoDeadlock(t, time.Second, func() {
+		q := NewQueue[int](1)
+		q.Close()
+		q.Close()
+		_, ok := <-q.Items()
+		assert.False(t, ok)
+	})
+}
+
+func TestQueuePutAfterClose(t *testing.T) {
+	leaktest.NoDeadlock(t, time.Second, func() {
+		q := NewQueue[int](1)
+		q.Close()
+		for i := 0; i < 10; i++ {
+			assert.ErrorIs(t, q.Put(context.Background(), i), ErrClosed)
+		}
+	})
+}
+
+func TestQueueCloseWakesPut(t *testing.T) {
+	leaktest.NoDeadlock(t, time.Second, func() {
+		q := NewQueue[int](1)
+		require.NoError(t, q.Put(context.Background(), 1))
+		blocked := make(chan error)
+		go func() { blocked <- q.Put(context.Background(), 2) }()
+		time.Sleep(20 * time.Millisecond) // for the Put to wait on the full queue
+		q.Close()
+		assert.ErrorIs(t, <-blocked, ErrClosed)
+		assert.Equal(t, []int{1}, collect(q.Items(), 10))
+	})
+}
+
+func TestQueuePutCanceled(t *testing.T) {
+	leaktest.NoDeadlock(t, time.Second, func() {
+		q := NewQueue[int](1)
+		require.NoError(t, q.Put(context.Background(), 1))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, q.Put(ctx, 2), context.DeadlineExceeded)
+	})
+}
+
+func TestQueueConcurrent(t *testing.T) {
+	leaktest.Check(t)
+	leaktest.NoDeadlock(t, 5*time.Second, func() {
+		q := NewQueue[int](4)
+		var producers sync.WaitGroup
+		for p := 0; p < 4; p++ {
+			producers.Add(1)
+			go func() {
+				defer producers.Done()
+				for i := 0; i < 100; i++ {
+					assert.NoError(t, q.Put(context.Background(), i))
+				}
+			}()
+		}
+		received := make(chan int)
+		for c := 0; c < 2; c++ {
+			go func() {
+				n := 0
+				for range q.Items() {
+					n++
+				}
+				received <- n
+			}()
+		}
+		producers.Wait()
+		q.Close()
+		assert.Equal(t, 400, <-received+<-received)
+	})
+}
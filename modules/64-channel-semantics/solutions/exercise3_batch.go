@@ -0,0 +1,59 @@
+package solutions
+
+// SOLUTION: Fix a batcher whose select waits on channels it should have switched off.
+// One select receives values, flushes a batch that has waited long
+// enough, and sends batches that are ready. Each case is switched off
+// with a nil channel when it has nothing to do: the input once it is
+// closed, the timer when no batch is waiting, and the output when no
+// batch is ready.
+
+import "time"
+
+// Batch receives from in until it is closed, and sends what it receives
+// on the channel it returns, in batches of size values. A batch that is
+// not full is sent once wait has passed since its first value, and when
+// in is closed. The channel is closed after the last batch.
+func Batch[T any](in <-chan T, size int, wait time.Duration) <-chan []T {
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		var (
+			batch  []T
+			ready  [][]T            // batches waiting to be sent
+			expire <-chan time.Time // nil while there is no batch
+		)
+		flush := func() {
+			ready = append(ready, batch)
+			batch, expire = nil, nil // Fixed: the timer was for this batch
+		}
+		for in != nil || len(ready) > 0 {
+			var send chan<- []T // Fixed: nil while no batch is ready
+			var next []T
+			if len(ready) > 0 {
+				send, next = out, ready[0]
+			}
+			select {
+			case v, ok := <-in:
+				if !ok {
+					in = nil // Fixed: or it is always ready, and the loop never ends
+					if len(batch) > 0 {
+						flush()
+					}
+					continue
+				}
+				if len(batch) == 0 {
+					expire = time.After(wait)
+				}
+				batch = append(batch, v)
+				if len(batch) == size {
+					flush()
+				}
+			case <-expire:
+				flush()
+			case send <- next:
+				ready = ready[1:]
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,82 @@
+package solutions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchFull(t *testing.T) {
+	leaktest.Check(t)
+	leaktest.NoDeadlock(t, time.Second, func() {
+		got := collect(Batch(send(1, 2, 3, 4, 5, 6, 7), 3, time.Hour), 10)
+		assert.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}, got, "the rest is sent when in is closed")
+	})
+}
+
+func TestBatchEnds(t *testing.T) {
+	leaktest.Check(t)
+	leaktest.NoDeadlock(t, time.Second, func() {
+		in := make(chan int)
+		out := Batch(in, 2, time.Hour)
+		in <- 1
+		in <- 2
+		assert.Equal(t, []int{1, 2}, <-out)
+		close(in)
+		_, ok := <-out
+		assert.False(t, ok, "closed after the last batch")
+	})
+}
+
+func TestBatchWait(t *testing.T) {
+	leaktest.Check(t)
+	leaktest.NoDeadlock(t, time.Second, func() {
+		in := make(chan int)
+		defer close(in)
+		out := Batch(in, 10, 20*time.Millisecond)
+		start := time.Now()
+		in <- 1
+		in <- 2
+		assert.Equal(t, []int{1, 2}, <-out)
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+		in <- 3
+		assert.Equal(t, []int{3}, <-out, "a new timer for the next batch")
+	})
+}
+
+// TestBatchNoEmptyBatches lets time pass with no values, after a full
+// batch and after a timed one.
+func TestBatchNoEmptyBatches(t *testing.T) {
+	leaktest.Check(t)
+	leaktest.NoDeadlock(t, time.Second, func() {
+		in := make(chan int)
+		out := Batch(in, 2, 20*time.Millisecond)
+		in <- 1
+		in <- 2
+		require.Equal(t, []int{1, 2}, <-out)
+		time.Sleep(50 * time.Millisecond)
+		in <- 3
+		require.Equal(t, []int{3}, <-out)
+		time.Sleep(50 * time.Millisecond)
+		close(in)
+		for b := range out {
+			assert.NotEmpty(t, b, "an empty batch")
+		}
+	})
+}
+
+func TestBatchSlowReceiver(t *testing.T) {
+	leaktest.Check(t)
+	leaktest.NoDeadlock(t, time.Second, func() {
+		in := make(chan int)
+		out := Batch(in, 1, time.Hour)
+		for i := 0; i < 5; i++ {
+			in <- i // nobody receives yet: Batch keeps the batches
+		}
+		close(in)
+		assert.Equal(t, [][]int{{0}, {1}, {2}, {3}, {4}}, collect(out, 10))
+	})
+}
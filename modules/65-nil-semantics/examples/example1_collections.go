@@ -0,0 +1,86 @@
+// Package examples demonstrates what nil means for each kind of Go type
+// that has it: slices, maps, pointers, functions, and interfaces. For some
+// a nil value works like an empty one; for others it panics at the first
+// use; and a nil interface is not the same as an interface holding nil.
+//
+// This file shows:
+// - A nil slice has length 0, ranges over nothing, and can be appended to
+// - A nil slice and an empty one differ only for == nil, reflect.DeepEqual, and encoding/json, which writes null
+// - A nil map can be read, ranged over, and deleted from, but writing to it panics
+// - Zero values ready to use: making a map on the first write, rather than in a constructor
+package examples
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Try calls f, and returns the panic it raised, if any, as an error.
+func Try(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	f()
+	return nil
+}
+
+// Evens returns the even numbers in ns. It starts from a nil slice, which
+// append grows like any other: nothing needs making first.
+func Evens(ns []int) []int {
+	var evens []int
+	for _, n := range ns {
+		if n%2 == 0 {
+			evens = append(evens, n)
+		}
+	}
+	return evens
+}
+
+// Counter counts strings. Its zero value is ready to use: Add makes the
+// map on the first write, and Count reads a nil map like an empty one.
+type Counter struct {
+	counts map[string]int
+}
+
+// Add counts s once more.
+func (c *Counter) Add(s string) {
+	if c.counts == nil {
+		c.counts = make(map[string]int)
+	}
+	c.counts[s]++
+}
+
+// Count returns how many times s was added.
+func (c *Counter) Count(s string) int {
+	return c.counts[s]
+}
+
+// DemonstrateCollections uses nil slices and maps as empty ones, and
+// shows where they differ.
+func DemonstrateCollections() {
+	fmt.Println("=== Nil Slices ===")
+	var none []int
+	empty := []int{}
+	fmt.Println("len:", len(none), len(empty), " == nil:", none == nil, empty == nil)
+	fmt.Println("evens of 1, 3:", Evens([]int{1, 3}), " is nil:", Evens([]int{1, 3}) == nil)
+	fmt.Println("DeepEqual(nil, empty):", reflect.DeepEqual(none, empty))
+	a, _ := json.Marshal(none)
+	b, _ := json.Marshal(empty)
+	fmt.Printf("JSON: %s and %s\n", a, b)
+
+	fmt.Println("=== Nil Maps ===")
+	var m map[string]int
+	fmt.Println("m[\"x\"]:", m["x"], " len:", len(m))
+	delete(m, "x")
+	if err := Try(func() { m["x"] = 1 }); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	var c Counter
+	c.Add("go")
+	c.Add("go")
+	fmt.Println("a zero Counter counted go", c.Count("go"), "times, and rust", c.Count("rust"))
+}
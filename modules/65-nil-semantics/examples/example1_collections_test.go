@@ -0,0 +1,40 @@
+package examples
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateCollections(t *testing.T) {
+	DemonstrateCollections()
+}
+
+func TestTry(t *testing.T) {
+	assert.NoError(t, Try(func() {}))
+	assert.EqualError(t, Try(func() {
+		var m map[string]int
+		m["x"] = 1
+	}), "panic: assignment to entry in nil map")
+}
+
+func TestEvens(t *testing.T) {
+	assert.Equal(t, []int{2, 4}, Evens([]int{1, 2, 3, 4}))
+	assert.Nil(t, Evens([]int{1, 3}))
+	assert.Nil(t, Evens(nil), "ranging over nil does nothing")
+
+	b, err := json.Marshal(Evens(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(b))
+}
+
+func TestCounter(t *testing.T) {
+	var c Counter
+	assert.Equal(t, 0, c.Count("go"), "reading the nil map")
+	c.Add("go")
+	c.Add("go")
+	c.Add("rust")
+	assert.Equal(t, 2, c.Count("go"))
+	assert.Equal(t, 1, c.Count("rust"))
+}
@@ -0,0 +1,94 @@
+package examples
+
+// This file shows:
+// - Dereferencing a nil pointer panics, and so does reading a field through it
+// - A method with a pointer receiver can be called on a nil pointer, and can check for it
+// - A nil *List as the empty list, so that recursive methods need no special case for the end
+// - A method with a value receiver cannot be called on a nil pointer: the call dereferences it
+// - A nil function panics when called, so optional hooks are checked first
+
+import "fmt"
+
+// List is a linked list of ints. A nil *List is the empty list, and every
+// method works on it.
+type List struct {
+	Head int
+	Tail *List
+}
+
+// Push returns l with v in front. It works on the empty list, nil,
+// because it only stores l, and never dereferences it.
+func (l *List) Push(v int) *List {
+	return &List{Head: v, Tail: l}
+}
+
+// Len returns the number of values in l.
+func (l *List) Len() int {
+	if l == nil {
+		return 0
+	}
+	return 1 + l.Tail.Len()
+}
+
+// Sum returns the sum of the values in l.
+func (l *List) Sum() int {
+	if l == nil {
+		return 0
+	}
+	return l.Head + l.Tail.Sum()
+}
+
+// Point has a method with a value receiver.
+type Point struct {
+	X, Y int
+}
+
+// String formats p. Called on a *Point, it is (*p).String(), which panics
+// if the pointer is nil.
+func (p Point) String() string {
+	return fmt.Sprintf("(%d, %d)", p.X, p.Y)
+}
+
+// Hooks are functions called at points of a job. Each may be nil.
+type Hooks struct {
+	OnStart func(name string)
+	OnDone  func(name string, err error)
+}
+
+// Run calls job, and the hooks that are set.
+func (h Hooks) Run(name string, job func() error) error {
+	if h.OnStart != nil {
+		h.OnStart(name)
+	}
+	err := job()
+	if h.OnDone != nil {
+		h.OnDone(name, err)
+	}
+	return err
+}
+
+// DemonstrateReceivers calls methods on nil pointers, with pointer and
+// value receivers, and runs a job with nil hooks.
+func DemonstrateReceivers() {
+	fmt.Println("=== Nil Pointers ===")
+	var p *Point
+	if err := Try(func() { fmt.Println(p.X) }); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	fmt.Println("=== Nil Receivers ===")
+	var l *List
+	fmt.Println("empty list: len", l.Len(), "sum", l.Sum())
+	l = l.Push(3).Push(2).Push(1)
+	fmt.Println("1, 2, 3: len", l.Len(), "sum", l.Sum())
+	if err := Try(func() { _ = p.String() }); err != nil {
+		fmt.Println("a value receiver on nil:", err)
+	}
+
+	fmt.Println("=== Nil Functions ===")
+	h := Hooks{OnDone: func(name string, err error) { fmt.Println(name, "done:", err) }}
+	_ = h.Run("backup", func() error { return nil })
+	if err := Try(func() { h.OnStart("backup") }); err != nil {
+		fmt.Println("calling the unset hook:", err)
+	}
+}
@@ -0,0 +1,44 @@
+package examples
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateReceivers(t *testing.T) {
+	DemonstrateReceivers()
+}
+
+func TestList(t *testing.T) {
+	var l *List
+	assert.Equal(t, 0, l.Len())
+	assert.Equal(t, 0, l.Sum())
+
+	l = l.Push(3).Push(2).Push(1)
+	assert.Equal(t, 3, l.Len())
+	assert.Equal(t, 6, l.Sum())
+	assert.Equal(t, 1, l.Head)
+}
+
+func TestValueReceiverOnNil(t *testing.T) {
+	assert.Equal(t, "(1, 2)", (&Point{1, 2}).String())
+	var p *Point
+	assert.ErrorContains(t, Try(func() { _ = p.String() }), "nil pointer dereference")
+}
+
+func TestHooks(t *testing.T) {
+	boom := errors.New("boom")
+	var calls []string
+	h := Hooks{
+		OnStart: func(name string) { calls = append(calls, "start "+name) },
+		OnDone:  func(name string, err error) { calls = append(calls, "done "+name+": "+err.Error()) },
+	}
+	assert.Equal(t, boom, h.Run("job", func() error { return boom }))
+	assert.Equal(t, []string{"start job", "done job: boom"}, calls)
+
+	assert.NotPanics(t, func() {
+		_ = Hooks{}.Run("job", func() error { return nil })
+	}, "no hooks set")
+}
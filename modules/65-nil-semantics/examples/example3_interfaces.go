@@ -0,0 +1,108 @@
+package examples
+
+// This file shows:
+// - An interface value is a dynamic type and a value, and is nil only when both are
+// - An interface holding a nil pointer is not nil, and its methods are called on the nil pointer
+// - The typed-nil error: a function that returns a nil *MyError as an error returns a non-nil error
+// - Calling a method on a nil interface panics, as there is no type to find the method on
+// - Finding the nil inside an interface with reflect, when there is no way around it
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Speaker is implemented by *Person.
+type Speaker interface {
+	Speak() string
+}
+
+// Person speaks, even as a nil *Person.
+type Person struct {
+	Name string
+}
+
+// Speak returns what p says.
+func (p *Person) Speak() string {
+	if p == nil {
+		return "nobody is here"
+	}
+	return "hi, I am " + p.Name
+}
+
+// NilInterface puts a nil *Person in a Speaker, and describes the result:
+// a Speaker that is not nil, although what it holds is.
+func NilInterface() string {
+	var p *Person
+	var s Speaker = p
+	return fmt.Sprintf("p == nil: %v, s == nil: %v, s holds %T, and says %q", p == nil, s == nil, s, s.Speak())
+}
+
+// NotFoundError is returned for a missing key.
+type NotFoundError struct {
+	Key string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s: not found", e.Key)
+}
+
+// lookup returns a nil *NotFoundError when key is there.
+func lookup(m map[string]int, key string) *NotFoundError {
+	if _, ok := m[key]; !ok {
+		return &NotFoundError{Key: key}
+	}
+	return nil
+}
+
+// FindTyped returns lookup's result as an error, which is never nil: a
+// nil *NotFoundError becomes an error holding it.
+func FindTyped(m map[string]int, key string) error {
+	return lookup(m, key)
+}
+
+// Find returns an error only if lookup found a problem, and a nil error
+// otherwise.
+func Find(m map[string]int, key string) error {
+	if err := lookup(m, key); err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsNil reports whether v is nil, or holds a nil pointer, map, slice,
+// channel, or function. Code that needs it is usually better off not
+// putting the nil in the interface in the first place.
+func IsNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// DemonstrateInterfaces shows interfaces holding nil, the typed-nil error,
+// and a method called on a nil interface.
+func DemonstrateInterfaces() {
+	fmt.Println("=== Nil in an Interface ===")
+	fmt.Println(NilInterface())
+
+	fmt.Println("=== The Typed-Nil Error ===")
+	m := map[string]int{"a": 1}
+	err := FindTyped(m, "a")
+	fmt.Printf("FindTyped of a key that is there: err == nil: %v, holds %T\n", err == nil, err)
+	fmt.Println("Find of a key that is there: err == nil:", Find(m, "a") == nil)
+	var nf *NotFoundError
+	fmt.Println("Find of a missing key:", Find(m, "b"), " errors.As:", errors.As(Find(m, "b"), &nf))
+
+	fmt.Println("=== Nil Interfaces ===")
+	var s Speaker
+	if err := Try(func() { _ = s.Speak() }); err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println("IsNil:", IsNil(nil), IsNil((*Person)(nil)), IsNil(&Person{}), IsNil(0))
+}
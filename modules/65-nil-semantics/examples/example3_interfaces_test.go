@@ -0,0 +1,45 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateInterfaces(t *testing.T) {
+	DemonstrateInterfaces()
+}
+
+func TestNilInterface(t *testing.T) {
+	assert.Equal(t, `p == nil: true, s == nil: false, s holds *examples.Person, and says "nobody is here"`, NilInterface())
+}
+
+func TestFind(t *testing.T) {
+	m := map[string]int{"a": 1}
+
+	err := FindTyped(m, "a")
+	assert.True(t, err != nil, "a nil *NotFoundError in an error")
+	assert.Nil(t, err, "assert.Nil looks inside the interface, and misses it")
+
+	assert.NoError(t, Find(m, "a"))
+	assert.EqualError(t, Find(m, "b"), "b: not found")
+	assert.EqualError(t, FindTyped(m, "b"), "b: not found")
+}
+
+func TestNilInterfaceMethod(t *testing.T) {
+	var s Speaker
+	assert.ErrorContains(t, Try(func() { _ = s.Speak() }), "nil pointer dereference")
+	s = (*Person)(nil)
+	assert.Equal(t, "nobody is here", s.Speak())
+}
+
+func TestIsNil(t *testing.T) {
+	var m map[string]int
+	var f func()
+	for _, v := range []any{nil, (*Person)(nil), m, []int(nil), f} {
+		assert.True(t, IsNil(v), "%T", v)
+	}
+	for _, v := range []any{0, "", &Person{}, map[string]int{}, []int{}, Person{}} {
+		assert.False(t, IsNil(v), "%T", v)
+	}
+}
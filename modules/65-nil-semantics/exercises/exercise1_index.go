@@ -0,0 +1,62 @@
+package exercises
+
+// EXERCISE: Fix a tag index that panics when it is new, and tells nil from empty where it should not.
+// Index maps tags to the items that have them, and promises that its zero
+// value is ready to use. But the first Add to a zero Index writes to a nil
+// map; Tags lists a tag whose items were all removed, as their slice is
+// empty but not nil; and Lookup sends clients "items": null for a tag
+// with no items, where they expect an array.
+// Fix the bugs marked with // BUG: comments.
+
+import "sort"
+
+// Index maps tags to the items that have them. Its zero value is an empty
+// Index, ready to use.
+type Index struct {
+	items map[string][]string // by tag, in the order they were added
+}
+
+// Add tags item with tag.
+func (x *Index) Add(tag, item string) {
+	// BUG: in the zero Index, x.items is nil, and writing to a nil map
+	// panics. Appending to the nil slice that reading it gives is fine.
+	x.items[tag] = append(x.items[tag], item)
+}
+
+// Remove removes tag from item. Reading a nil map, or a tag that is not
+// in it, gives a nil slice, so there is nothing to remove.
+func (x *Index) Remove(tag, item string) {
+	items := x.items[tag]
+	for i, it := range items {
+		if it == item {
+			x.items[tag] = append(items[:i:i], items[i+1:]...)
+			return
+		}
+	}
+}
+
+// Tags returns the tags that have at least one item, sorted.
+func (x *Index) Tags() []string {
+	var tags []string
+	for tag, items := range x.items {
+		if items != nil { // BUG: true for the empty slice Remove leaves
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Result is the answer to a lookup, as it is sent to clients in JSON. Its
+// Items are a JSON array, [] when there are none.
+type Result struct {
+	Tag   string   `json:"tag"`
+	Items []string `json:"items"`
+}
+
+// Lookup returns the items tagged with tag. The Result has a copy of them.
+func (x *Index) Lookup(tag string) Result {
+	// BUG: this is not a copy, and for a tag that is not there it is nil,
+	// which encoding/json writes as null.
+	return Result{Tag: tag, Items: x.items[tag]}
+}
@@ -0,0 +1,53 @@
+package exercises
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexZeroValue(t *testing.T) {
+	var x Index
+	assert.Empty(t, x.Tags())
+	assert.NotPanics(t, func() { x.Remove("go", "gopher") }, "Remove on the empty index")
+	require.NotPanics(t, func() { x.Add("go", "gopher") }, "Add on the zero Index")
+	assert.Equal(t, []string{"go"}, x.Tags())
+}
+
+func TestIndexTags(t *testing.T) {
+	x := &Index{items: map[string][]string{}}
+	x.Add("lang", "go")
+	x.Add("lang", "rust")
+	x.Add("animal", "gopher")
+	x.Add("animal", "crab")
+	assert.Equal(t, []string{"animal", "lang"}, x.Tags())
+
+	x.Remove("animal", "crab")
+	x.Remove("animal", "gopher")
+	x.Remove("lang", "zig")
+	assert.Equal(t, []string{"lang"}, x.Tags(), "animal has no items left")
+}
+
+func TestIndexLookup(t *testing.T) {
+	x := &Index{items: map[string][]string{}}
+	x.Add("lang", "go")
+	x.Add("lang", "rust")
+	got := x.Lookup("lang")
+	assert.Equal(t, Result{Tag: "lang", Items: []string{"go", "rust"}}, got)
+
+	got.Items[0] = "changed"
+	assert.Equal(t, []string{"go", "rust"}, x.Lookup("lang").Items, "Lookup returns a copy")
+}
+
+func TestIndexLookupJSON(t *testing.T) {
+	x := &Index{items: map[string][]string{}}
+	x.Add("lang", "go")
+	x.Remove("lang", "go")
+	for _, tag := range []string{"lang", "none"} {
+		b, err := json.Marshal(x.Lookup(tag))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"tag": "`+tag+`", "items": []}`, string(b), "clients expect an array")
+	}
+}
@@ -0,0 +1,68 @@
+package exercises
+
+// EXERCISE: Fix a binary search tree whose empty tree, a nil *Tree, panics, and which loses what is inserted.
+// Tree's methods are meant to work on a nil *Tree, the empty tree, so
+// that recursion needs no special case at a missing child. But Contains
+// never checks for nil, Len has a value receiver, which dereferences the
+// pointer it is called on, and Insert drops the nodes it makes for
+// missing children: a nil receiver cannot turn itself into a node, so
+// the caller has to store what Insert returns.
+// Fix the bugs marked with // BUG: comments.
+
+// Tree is a binary search tree of ints. A nil *Tree is the empty tree,
+// and every method works on it.
+type Tree struct {
+	Key         int
+	Left, Right *Tree
+}
+
+// Insert returns t with key added, or t unchanged if key is in it
+// already. Inserting into the empty tree returns a new one, so callers
+// write t = t.Insert(key).
+func (t *Tree) Insert(key int) *Tree {
+	if t == nil {
+		return &Tree{Key: key}
+	}
+	switch {
+	case key < t.Key:
+		t.Left.Insert(key) // BUG: when t.Left is nil, the new node is lost
+	case key > t.Key:
+		t.Right.Insert(key) // BUG: and here
+	}
+	return t
+}
+
+// Contains reports whether key is in t.
+func (t *Tree) Contains(key int) bool {
+	// BUG: t is nil for the empty tree, and at the end of every search
+	// that fails, and t.Key dereferences it.
+	switch {
+	case key < t.Key:
+		return t.Left.Contains(key)
+	case key > t.Key:
+		return t.Right.Contains(key)
+	}
+	return true
+}
+
+// Len returns the number of keys in t.
+func (t Tree) Len() int { // BUG: called on a nil *Tree, a value receiver panics
+	n := 1
+	if t.Left != nil {
+		n += t.Left.Len()
+	}
+	if t.Right != nil {
+		n += t.Right.Len()
+	}
+	return n
+}
+
+// Walk calls f for each key in t, in increasing order.
+func (t *Tree) Walk(f func(key int)) {
+	if t == nil {
+		return
+	}
+	t.Left.Walk(f)
+	f(t.Key)
+	t.Right.Walk(f)
+}
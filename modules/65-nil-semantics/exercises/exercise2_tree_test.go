@@ -0,0 +1,69 @@
+package exercises
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// keys returns the keys of t, in the order Walk gives them.
+func keys(t *Tree) []int {
+	var ks []int
+	t.Walk(func(k int) { ks = append(ks, k) })
+	return ks
+}
+
+func TestTreeEmpty(t *testing.T) {
+	var tree *Tree
+	assert.NotPanics(t, func() { assert.False(t, tree.Contains(1)) }, "Contains on the empty tree")
+	assert.NotPanics(t, func() { assert.Equal(t, 0, tree.Len()) }, "Len on the empty tree")
+	assert.Empty(t, keys(tree))
+}
+
+func TestTreeInsert(t *testing.T) {
+	var tree *Tree
+	for _, k := range []int{5, 3, 8, 1, 4, 9, 3} {
+		tree = tree.Insert(k)
+	}
+	require.NotNil(t, tree)
+	assert.Equal(t, []int{1, 3, 4, 5, 8, 9}, keys(tree), "every key once, in order")
+}
+
+func TestTreeContains(t *testing.T) {
+	tree := &Tree{Key: 5, Left: &Tree{Key: 3}, Right: &Tree{Key: 8}}
+	tree = tree.Insert(4)
+	assert.NotPanics(t, func() {
+		for _, k := range []int{3, 4, 5, 8} {
+			assert.True(t, tree.Contains(k), "%d", k)
+		}
+		for _, k := range []int{1, 6, 9} {
+			assert.False(t, tree.Contains(k), "%d", k)
+		}
+	}, "a search that ends at a nil child")
+}
+
+func TestTreeRandom(t *testing.T) {
+	r := seed.Rand(t, 1)
+	var tree *Tree
+	set := map[int]bool{}
+	for i := 0; i < 200; i++ {
+		k := r.Intn(100)
+		tree = tree.Insert(k)
+		set[k] = true
+	}
+	var want []int
+	for k := range set {
+		want = append(want, k)
+	}
+	sort.Ints(want)
+	assert.Equal(t, want, keys(tree))
+	assert.NotPanics(t, func() { assert.Equal(t, len(want), tree.Len()) })
+	for k := 0; k < 100; k++ {
+		if !assert.NotPanics(t, func() { assert.Equal(t, set[k], tree.Contains(k), "%d", k) }) {
+			break
+		}
+	}
+}
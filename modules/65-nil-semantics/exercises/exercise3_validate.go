@@ -0,0 +1,106 @@
+package exercises
+
+// EXERCISE: Fix a validator that reports errors for valid users, and panics without a logger.
+// Validator checks the users a sign-up form sends. But First and All
+// return a nil *FieldError and a nil Errors as an error, and an error
+// holding a nil pointer or slice is not a nil error, so every caller that
+// checks err != nil rejects valid users. And a Validator whose optional
+// Log is not set calls a method on a nil interface, which panics: unlike
+// a nil pointer, a nil interface has no type to find the method on.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// User is what a sign-up form sends.
+type User struct {
+	Name  string
+	Email string
+	Age   int
+}
+
+// FieldError is a problem with one field of a User.
+type FieldError struct {
+	Field   string
+	Problem string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Problem
+}
+
+// Errors is every problem with a User.
+type Errors []*FieldError
+
+func (es Errors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Logger is the part of *log.Logger that Validator uses.
+type Logger interface {
+	Printf(format string, args ...any)
+}
+
+// Validator checks users. Its zero value is ready to use.
+type Validator struct {
+	Log Logger // optional: a nil Log logs nothing
+}
+
+// checks are the checks of a User, in the order they run. Each returns
+// nil if its field is fine.
+var checks = []func(User) *FieldError{
+	func(u User) *FieldError {
+		if strings.TrimSpace(u.Name) == "" {
+			return &FieldError{Field: "name", Problem: "missing"}
+		}
+		return nil
+	},
+	func(u User) *FieldError {
+		if !strings.Contains(u.Email, "@") {
+			return &FieldError{Field: "email", Problem: fmt.Sprintf("%q is not an address", u.Email)}
+		}
+		return nil
+	},
+	func(u User) *FieldError {
+		if u.Age < 13 || u.Age > 150 {
+			return &FieldError{Field: "age", Problem: fmt.Sprintf("%d is out of range", u.Age)}
+		}
+		return nil
+	},
+}
+
+// logf logs to v.Log, if there is one.
+func (v *Validator) logf(format string, args ...any) {
+	v.Log.Printf(format, args...) // BUG: panics when Log is nil
+}
+
+// First returns the first problem with u, as a *FieldError, or nil if
+// there is none.
+func (v *Validator) First(u User) error {
+	var fe *FieldError
+	for _, check := range checks {
+		if fe = check(u); fe != nil {
+			v.logf("%s: %v", u.Name, fe)
+			break
+		}
+	}
+	return fe // BUG: a nil *FieldError becomes a non-nil error
+}
+
+// All returns every problem with u, as Errors, or nil if there are none.
+func (v *Validator) All(u User) error {
+	var errs Errors
+	for _, check := range checks {
+		if fe := check(u); fe != nil {
+			errs = append(errs, fe)
+		}
+	}
+	v.logf("%s: %d problems", u.Name, len(errs))
+	return errs // BUG: so does a nil Errors
+}
@@ -0,0 +1,65 @@
+package exercises
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logs is a Logger that keeps what is logged.
+type logs []string
+
+func (l *logs) Printf(format string, args ...any) {
+	*l = append(*l, fmt.Sprintf(format, args...))
+}
+
+var valid = User{Name: "Ada", Email: "ada@example.com", Age: 36}
+
+// TestFirstValid checks err == nil, as callers do. assert.Nil would pass
+// for a nil *FieldError in an error, and assert.NoError would call its
+// Error method, which panics on the nil pointer.
+func TestFirstValid(t *testing.T) {
+	v := &Validator{Log: &logs{}}
+	err := v.First(valid)
+	assert.True(t, err == nil, "err == nil, but err is %#v", err)
+}
+
+func TestFirstProblem(t *testing.T) {
+	v := &Validator{Log: &logs{}}
+	err := v.First(User{Name: "Ada", Email: "ada", Age: 7})
+	var fe *FieldError
+	require.True(t, errors.As(err, &fe))
+	assert.Equal(t, "email", fe.Field, "the first problem")
+	assert.EqualError(t, err, `email: "ada" is not an address`)
+}
+
+func TestAll(t *testing.T) {
+	v := &Validator{Log: &logs{}}
+	err := v.All(User{Email: "nobody", Age: 200})
+	var errs Errors
+	require.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 3)
+	assert.EqualError(t, err, `name: missing; email: "nobody" is not an address; age: 200 is out of range`)
+
+	err = v.All(valid)
+	assert.True(t, err == nil, "err == nil, but err is %#v", err)
+}
+
+func TestValidatorLog(t *testing.T) {
+	l := &logs{}
+	v := &Validator{Log: l}
+	_ = v.First(User{Name: "Bob", Email: "bob@example.com", Age: 3})
+	_ = v.All(valid)
+	assert.Equal(t, logs{"Bob: age: 3 is out of range", "Ada: 0 problems"}, *l)
+}
+
+func TestValidatorNoLog(t *testing.T) {
+	var v Validator
+	assert.NotPanics(t, func() {
+		assert.Error(t, v.First(User{}))
+		assert.Error(t, v.All(User{}))
+	}, "a Validator without a Log")
+}
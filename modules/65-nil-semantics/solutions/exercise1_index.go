@@ -0,0 +1,60 @@
+package solutions
+
+// SOLUTION: Fix a tag index that panics when it is new, and tells nil from empty where it should not.
+// Fixed: Add makes the map on the first write, so the zero Index is ready to use
+// Fixed: Tags checks a tag's items by length, since a tag whose items were all removed has an empty slice, not nil
+// Fixed: Lookup returns a copy of the items that is never nil, which encoding/json writes as [], not null
+
+import "sort"
+
+// Index maps tags to the items that have them. Its zero value is an empty
+// Index, ready to use.
+type Index struct {
+	items map[string][]string // by tag, in the order they were added
+}
+
+// Add tags item with tag.
+func (x *Index) Add(tag, item string) {
+	if x.items == nil {
+		x.items = make(map[string][]string) // Fixed: writing to a nil map panics
+	}
+	x.items[tag] = append(x.items[tag], item)
+}
+
+// Remove removes tag from item. Reading a nil map, or a tag that is not
+// in it, gives a nil slice, so there is nothing to remove.
+func (x *Index) Remove(tag, item string) {
+	items := x.items[tag]
+	for i, it := range items {
+		if it == item {
+			x.items[tag] = append(items[:i:i], items[i+1:]...)
+			return
+		}
+	}
+}
+
+// Tags returns the tags that have at least one item, sorted.
+func (x *Index) Tags() []string {
+	var tags []string
+	for tag, items := range x.items {
+		if len(items) > 0 { // Fixed: not items != nil, which holds for an empty slice
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Result is the answer to a lookup, as it is sent to clients in JSON. Its
+// Items are a JSON array, [] when there are none.
+type Result struct {
+	Tag   string   `json:"tag"`
+	Items []string `json:"items"`
+}
+
+// Lookup returns the items tagged with tag. The Result has a copy of them.
+func (x *Index) Lookup(tag string) Result {
+	// Fixed: append to an empty slice copies, and is never nil, even for
+	// no items
+	return Result{Tag: tag, Items: append([]string{}, x.items[tag]...)}
+}
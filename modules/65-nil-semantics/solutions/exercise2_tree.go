@@ -0,0 +1,61 @@
+package solutions
+
+// SOLUTION: Fix a binary search tree whose empty tree, a nil *Tree, panics, and which loses what is inserted.
+// Fixed: Contains checks for a nil receiver, which is the empty tree and every missing child
+// Fixed: Insert stores the subtree that inserting into a child returns, since a nil child cannot make itself a node
+// Fixed: Len has a pointer receiver and checks for nil, since a value receiver dereferences the pointer it is called on
+
+// Tree is a binary search tree of ints. A nil *Tree is the empty tree,
+// and every method works on it.
+type Tree struct {
+	Key         int
+	Left, Right *Tree
+}
+
+// Insert returns t with key added, or t unchanged if key is in it
+// already. Inserting into the empty tree returns a new one, so callers
+// write t = t.Insert(key).
+func (t *Tree) Insert(key int) *Tree {
+	if t == nil {
+		return &Tree{Key: key}
+	}
+	switch {
+	case key < t.Key:
+		t.Left = t.Left.Insert(key) // Fixed: a nil Left returns a new node, which must be kept
+	case key > t.Key:
+		t.Right = t.Right.Insert(key)
+	}
+	return t
+}
+
+// Contains reports whether key is in t.
+func (t *Tree) Contains(key int) bool {
+	if t == nil { // Fixed: the empty tree, and the end of every search that fails
+		return false
+	}
+	switch {
+	case key < t.Key:
+		return t.Left.Contains(key)
+	case key > t.Key:
+		return t.Right.Contains(key)
+	}
+	return true
+}
+
+// Len returns the number of keys in t.
+func (t *Tree) Len() int { // Fixed: a pointer receiver, so that it can be nil
+	if t == nil {
+		return 0
+	}
+	return 1 + t.Left.Len() + t.Right.Len()
+}
+
+// Walk calls f for each key in t, in increasing order.
+func (t *Tree) Walk(f func(key int)) {
+	if t == nil {
+		return
+	}
+	t.Left.Walk(f)
+	f(t.Key)
+	t.Right.Walk(f)
+}
@@ -0,0 +1,107 @@
+package solutions
+
+// SOLUTION: Fix a validator that reports errors for valid users, and panics without a logger.
+// Fixed: First returns a nil error, not a nil *FieldError in an error, when there is no problem
+// Fixed: All returns nil, not an empty Errors in an error, when there is no problem
+// Fixed: logf checks for a nil Log, since calling a method on a nil interface panics
+
+import (
+	"fmt"
+	"strings"
+)
+
+// User is what a sign-up form sends.
+type User struct {
+	Name  string
+	Email string
+	Age   int
+}
+
+// FieldError is a problem with one field of a User.
+type FieldError struct {
+	Field   string
+	Problem string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Problem
+}
+
+// Errors is every problem with a User.
+type Errors []*FieldError
+
+func (es Errors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Logger is the part of *log.Logger that Validator uses.
+type Logger interface {
+	Printf(format string, args ...any)
+}
+
+// Validator checks users. Its zero value is ready to use.
+type Validator struct {
+	Log Logger // optional: a nil Log logs nothing
+}
+
+// checks are the checks of a User, in the order they run. Each returns
+// nil if its field is fine.
+var checks = []func(User) *FieldError{
+	func(u User) *FieldError {
+		if strings.TrimSpace(u.Name) == "" {
+			return &FieldError{Field: "name", Problem: "missing"}
+		}
+		return nil
+	},
+	func(u User) *FieldError {
+		if !strings.Contains(u.Email, "@") {
+			return &FieldError{Field: "email", Problem: fmt.Sprintf("%q is not an address", u.Email)}
+		}
+		return nil
+	},
+	func(u User) *FieldError {
+		if u.Age < 13 || u.Age > 150 {
+			return &FieldError{Field: "age", Problem: fmt.Sprintf("%d is out of range", u.Age)}
+		}
+		return nil
+	},
+}
+
+// logf logs to v.Log, if there is one.
+func (v *Validator) logf(format string, args ...any) {
+	if v.Log == nil { // Fixed: a nil interface has no method to call
+		return
+	}
+	v.Log.Printf(format, args...)
+}
+
+// First returns the first problem with u, as a *FieldError, or nil if
+// there is none.
+func (v *Validator) First(u User) error {
+	for _, check := range checks {
+		if fe := check(u); fe != nil {
+			v.logf("%s: %v", u.Name, fe)
+			return fe
+		}
+	}
+	return nil // Fixed: not a nil *FieldError, which is a non-nil error
+}
+
+// All returns every problem with u, as Errors, or nil if there are none.
+func (v *Validator) All(u User) error {
+	var errs Errors
+	for _, check := range checks {
+		if fe := check(u); fe != nil {
+			errs = append(errs, fe)
+		}
+	}
+	v.logf("%s: %d problems", u.Name, len(errs))
+	if len(errs) == 0 {
+		return nil // Fixed: a nil Errors in an error is not nil
+	}
+	return errs
+}
@@ -0,0 +1,66 @@
+// Package examples demonstrates scopes in Go, and the shadowing that :=
+// makes easy: a declaration in an inner block that hides a variable of
+// the same name outside it, so that what was meant for the outer one is
+// set on the inner one, and lost when the block ends.
+//
+// This file shows:
+// - The scopes a name can be declared in: universe, package, file, function, and each block
+// - That if, for, switch, and each case and select clause are blocks of their own, with the statement's init in them
+// - := declares every name on its left that is not yet declared in the same scope, and assigns the others
+// - A name from an outer scope is never reused by :=, only hidden
+package examples
+
+import "fmt"
+
+// Level is a package-level variable, in scope in every file of the package.
+var Level = "package"
+
+// Scopes returns the value Level has in each of the blocks it is
+// redeclared in, and after them.
+func Scopes() []string {
+	var seen []string
+	seen = append(seen, Level)
+	Level := "function"
+	seen = append(seen, Level)
+	if Level := "if"; Level != "" {
+		seen = append(seen, Level)
+		for Level := 0; Level < 1; Level++ {
+			seen = append(seen, fmt.Sprint("for ", Level))
+		}
+	}
+	switch Level := "switch"; {
+	case Level != "":
+		Level := "case"
+		seen = append(seen, Level)
+	}
+	return append(seen, Level)
+}
+
+// Redeclare returns what := did to a and b: both are assigned when they
+// were declared in the same scope, and b is new when it is in an inner
+// one.
+func Redeclare() (same, inner string) {
+	a, b := 1, 2
+	a, c := 3, 4 // c is new, so a is assigned
+	same = fmt.Sprintf("a=%d b=%d c=%d", a, b, c)
+	{
+		b, d := 5, 6 // both new in this block: b hides the outer b
+		_, _ = b, d
+	}
+	return same, fmt.Sprintf("a=%d b=%d", a, b)
+}
+
+// DemonstrateScopes prints the scopes a name is declared in, and what :=
+// does with names already declared.
+func DemonstrateScopes() {
+	fmt.Println("=== Scopes ===")
+	for _, s := range Scopes() {
+		fmt.Println(" ", s)
+	}
+	fmt.Println("package Level is still", Level)
+
+	fmt.Println("=== := ===")
+	same, inner := Redeclare()
+	fmt.Println("same scope:", same)
+	fmt.Println("after the inner block:", inner, "(the 5 went to another b)")
+}
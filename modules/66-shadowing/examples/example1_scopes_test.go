@@ -0,0 +1,22 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateScopes(t *testing.T) {
+	DemonstrateScopes()
+}
+
+func TestScopes(t *testing.T) {
+	assert.Equal(t, []string{"package", "function", "if", "for 0", "case", "function"}, Scopes())
+	assert.Equal(t, "package", Level, "Scopes declared its own Levels")
+}
+
+func TestRedeclare(t *testing.T) {
+	same, inner := Redeclare()
+	assert.Equal(t, "a=3 b=2 c=4", same)
+	assert.Equal(t, "a=3 b=2", inner)
+}
@@ -0,0 +1,98 @@
+package examples
+
+// This file shows:
+// - The err shadowing bug: n, err := inside a loop or if block sets a new err, and the outer one stays nil
+// - Fixing it by declaring the other names first, and assigning with =
+// - if err := f(); err != nil { return err } is fine: the inner err is the one returned
+// - The compiler rejects a bare return while a named result is shadowed, but not return err of the inner one
+// - Finding shadowing with the course's analyzer, go run ./cmd/shadow, which go vet does not run by default
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// SumShadowed adds up xs, and is meant to stop at the first that is not
+// a number and return its error. It returns nil instead: the loop's err
+// is not the one returned.
+func SumShadowed(xs []string) (int, error) {
+	var err error
+	total := 0
+	for _, x := range xs {
+		n, err := strconv.Atoi(x)
+		if err != nil {
+			break
+		}
+		total += n
+	}
+	return total, err
+}
+
+// Sum adds up xs, and stops at the first that is not a number, returning
+// its error. n is declared before the loop's block, so = assigns both.
+func Sum(xs []string) (int, error) {
+	var err error
+	total := 0
+	for _, x := range xs {
+		var n int
+		n, err = strconv.Atoi(x)
+		if err != nil {
+			break
+		}
+		total += n
+	}
+	return total, err
+}
+
+// SumReturn returns the error from inside the loop, so there is no outer
+// err to lose: shadowing would be harmless, and there is none.
+func SumReturn(xs []string) (int, error) {
+	total := 0
+	for _, x := range xs {
+		n, err := strconv.Atoi(x)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// ParsePair parses "a,b". Its named results are set by the inner
+// declarations only because each block returns them explicitly: a bare
+// return there would not compile ("result parameter err not in scope at
+// return").
+func ParsePair(s string) (a, b int, err error) {
+	for i := range s {
+		if s[i] != ',' {
+			continue
+		}
+		a, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, 0, err
+		}
+		b, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return 0, 0, err
+		}
+		return a, b, nil
+	}
+	return 0, 0, fmt.Errorf("%q: no comma", s)
+}
+
+// DemonstrateErrors runs the sums on input with a bad number.
+func DemonstrateErrors() {
+	fmt.Println("=== The Lost err ===")
+	xs := []string{"1", "2", "three", "4"}
+	total, err := SumShadowed(xs)
+	fmt.Println("SumShadowed:", total, err)
+	total, err = Sum(xs)
+	fmt.Println("Sum:", total, err)
+	total, err = SumReturn(xs)
+	fmt.Println("SumReturn:", total, err)
+
+	fmt.Println("=== Named Results ===")
+	a, b, err := ParsePair("3,4")
+	fmt.Println("ParsePair:", a, b, err)
+	fmt.Println("go run ./cmd/shadow reports SumShadowed's err, and not ParsePair's a, b, and err, which are not used after")
+}
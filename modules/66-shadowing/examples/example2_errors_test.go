@@ -0,0 +1,40 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateErrors(t *testing.T) {
+	DemonstrateErrors()
+}
+
+func TestSums(t *testing.T) {
+	xs := []string{"1", "2", "three", "4"}
+
+	total, err := SumShadowed(xs)
+	assert.Equal(t, 3, total)
+	assert.NoError(t, err, "the error was set on the loop's err")
+
+	for _, sum := range []func([]string) (int, error){Sum, SumReturn} {
+		total, err := sum(xs)
+		assert.Equal(t, 3, total)
+		assert.ErrorContains(t, err, `"three"`)
+
+		total, err = sum([]string{"1", "2"})
+		assert.Equal(t, 3, total)
+		assert.NoError(t, err)
+	}
+}
+
+func TestParsePair(t *testing.T) {
+	a, b, err := ParsePair("3,4")
+	assert.NoError(t, err)
+	assert.Equal(t, [2]int{3, 4}, [2]int{a, b})
+
+	_, _, err = ParsePair("3,x")
+	assert.Error(t, err)
+	_, _, err = ParsePair("34")
+	assert.EqualError(t, err, `"34": no comma`)
+}
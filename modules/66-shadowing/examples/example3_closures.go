@@ -0,0 +1,89 @@
+package examples
+
+// This file shows:
+// - Before Go 1.22, a for loop has one variable for all its iterations, which every closure made in it shares
+// - This course's go.mod says go 1.21, so its loops still work that way
+// - The fix, v := v, is shadowing on purpose, and the analyzer leaves it out
+// - Shadowing a package name, as url, err := url.Parse(s) does, hides the package for the rest of the block
+// - Shadowing a builtin such as len or new compiles, and breaks every later use of it in the scope
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+)
+
+// Shared returns a function for each of xs that returns it, made in a
+// loop without a copy: each returns the loop variable, which ends as the
+// last of xs.
+func Shared(xs []string) []func() string {
+	var fs []func() string
+	for _, x := range xs {
+		fs = append(fs, func() string { return x })
+	}
+	return fs
+}
+
+// Copied is Shared with a copy of the loop variable for each closure.
+func Copied(xs []string) []func() string {
+	var fs []func() string
+	for _, x := range xs {
+		x := x // a new x for each iteration, which the closure captures
+		fs = append(fs, func() string { return x })
+	}
+	return fs
+}
+
+// Squares computes the squares of 0 to n-1 in goroutines. Each is passed
+// its i as an argument, the other way to give it a value of its own.
+func Squares(n int) []int {
+	out := make([]int, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			out[i] = i * i
+		}(i)
+	}
+	wg.Wait()
+	return out
+}
+
+// Hosts returns the host of each of raw, skipping those that do not
+// parse. It names the parsed URL u: called url, it would hide the package
+// url from the rest of the loop's block.
+func Hosts(raw []string) []string {
+	var hosts []string
+	for _, s := range raw {
+		u, err := url.Parse(s)
+		if err != nil {
+			continue
+		}
+		hosts = append(hosts, u.Host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// DemonstrateClosures calls closures made in loops, with and without
+// copies, and lists the shadowing the compiler allows.
+func DemonstrateClosures() {
+	fmt.Println("=== Loop Variables ===")
+	xs := []string{"a", "b", "c"}
+	for i, f := range Shared(xs) {
+		fmt.Printf("Shared[%d]() = %s, ", i, f())
+	}
+	fmt.Println()
+	for i, f := range Copied(xs) {
+		fmt.Printf("Copied[%d]() = %s, ", i, f())
+	}
+	fmt.Println()
+	fmt.Println("Squares:", Squares(5))
+
+	fmt.Println("=== Hiding Names ===")
+	fmt.Println("hosts:", Hosts([]string{"https://go.dev/doc", "http://example.com", "%"}))
+	len := func(s string) int { return 42 } // compiles, and len means this until the block ends
+	fmt.Println(`len("go") with len shadowed:`, len("go"))
+}
@@ -0,0 +1,33 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemonstrateClosures(t *testing.T) {
+	DemonstrateClosures()
+}
+
+// call returns the results of fs.
+func call(fs []func() string) []string {
+	var got []string
+	for _, f := range fs {
+		got = append(got, f())
+	}
+	return got
+}
+
+func TestShared(t *testing.T) {
+	assert.Equal(t, []string{"c", "c", "c"}, call(Shared([]string{"a", "b", "c"})), "go.mod says go 1.21: one x for the whole loop")
+	assert.Equal(t, []string{"a", "b", "c"}, call(Copied([]string{"a", "b", "c"})))
+}
+
+func TestSquares(t *testing.T) {
+	assert.Equal(t, []int{0, 1, 4, 9, 16, 25}, Squares(6))
+}
+
+func TestHosts(t *testing.T) {
+	assert.Equal(t, []string{"example.com", "go.dev"}, Hosts([]string{"https://go.dev/doc", "%", "http://example.com"}))
+}
@@ -0,0 +1,111 @@
+package exercises
+
+// EXERCISE: Fix a config parser that ignores bad values, and a loader that returns the defaults it was meant to override.
+// Parse should reject a port or a timeout that does not parse, and Load
+// should return the config of the first file that exists. But each of
+// them declares, with :=, a variable that was meant to be assigned: the
+// err the switch sets is not the err checked after it, and the cfg Load
+// parses is not the cfg it returns. The tests find the bugs by what they
+// do, and TestConfigNoShadowing by running the course's shadow analyzer
+// on this file, which you can also run yourself:
+//
+//	go run ./cmd/shadow ./modules/66-shadowing/exercises
+//
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config is a server's configuration.
+type Config struct {
+	Port    int
+	Timeout time.Duration
+	Hosts   []string
+	Debug   bool
+}
+
+// Defaults returns the Config used for what a file does not set.
+func Defaults() Config {
+	return Config{Port: 8080, Timeout: 30 * time.Second, Hosts: []string{"localhost"}}
+}
+
+// Parse reads lines of key = value over the Defaults. Blank lines and
+// lines starting with # are skipped. The keys are port, a number from 1
+// to 65535; timeout, a duration such as 5s; hosts, a list separated by
+// spaces; and debug, true or false. An unknown key or a value that does
+// not parse is an error, which names the line.
+func Parse(text string) (Config, error) {
+	cfg := Defaults()
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return Config{}, fmt.Errorf("line %d: want key = value", n+1)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		var err error
+		switch key {
+		case "port":
+			// BUG: a case is a block, and := declares an err for it alone,
+			// which the range check below sets too.
+			port, err := strconv.Atoi(value)
+			if err == nil && (port < 1 || port > 65535) {
+				err = fmt.Errorf("port %d out of range", port)
+			}
+			cfg.Port = port
+		case "timeout":
+			// BUG: the if declares its own err, and a bad duration leaves
+			// the default, with no error.
+			if d, err := time.ParseDuration(value); err == nil {
+				cfg.Timeout = d
+			}
+		case "hosts":
+			cfg.Hosts = strings.Fields(value)
+		case "debug":
+			cfg.Debug, err = strconv.ParseBool(value)
+		default:
+			err = fmt.Errorf("unknown key %q", key)
+		}
+		if err != nil {
+			return Config{}, fmt.Errorf("line %d: %w", n+1, err)
+		}
+	}
+	return cfg, nil
+}
+
+// Load parses the first file of paths that exists, which must leave at
+// least one host. If none exists, it returns the Defaults.
+func Load(paths ...string) (Config, error) {
+	cfg := Defaults()
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return Config{}, err
+		}
+		// BUG: err was declared in this block, and is assigned, but cfg
+		// was not, and := declares a new one here.
+		cfg, err := Parse(string(data))
+		if err != nil {
+			return Config{}, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(cfg.Hosts) == 0 {
+			return Config{}, fmt.Errorf("%s: no hosts", path)
+		}
+		break
+	}
+	return cfg, nil
+}
@@ -0,0 +1,79 @@
+package exercises
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/shadow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const configText = `# the staging server
+port = 9090
+timeout = 5s
+
+hosts = a.example.com b.example.com
+debug = true
+`
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse(configText)
+	require.NoError(t, err)
+	assert.Equal(t, Config{Port: 9090, Timeout: 5 * time.Second, Hosts: []string{"a.example.com", "b.example.com"}, Debug: true}, cfg)
+
+	cfg, err = Parse("debug = true\n")
+	require.NoError(t, err)
+	want := Defaults()
+	want.Debug = true
+	assert.Equal(t, want, cfg, "the rest is the Defaults")
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct{ text, err string }{
+		{"port = 9090\nport = eighty", `line 2: strconv.Atoi: parsing "eighty": invalid syntax`},
+		{"port = 70000", "line 1: port 70000 out of range"},
+		{"port = 0", "line 1: port 0 out of range"},
+		{"# timeout\ntimeout = soon", `line 2: time: invalid duration "soon"`},
+		{"debug = maybe", `line 1: strconv.ParseBool: parsing "maybe": invalid syntax`},
+		{"color = blue", `line 1: unknown key "color"`},
+		{"port 9090", "line 1: want key = value"},
+	} {
+		_, err := Parse(tc.text)
+		assert.EqualError(t, err, tc.err, "%q", tc.text)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	first, second := filepath.Join(dir, "first.conf"), filepath.Join(dir, "second.conf")
+	require.NoError(t, os.WriteFile(second, []byte("port = 9091\n"), 0o644))
+
+	cfg, err := Load(first, second)
+	require.NoError(t, err)
+	assert.Equal(t, 9091, cfg.Port, "the first that exists is second")
+	assert.Equal(t, Defaults().Timeout, cfg.Timeout)
+
+	require.NoError(t, os.WriteFile(first, []byte(configText), 0o644))
+	cfg, err = Load(first, second)
+	require.NoError(t, err)
+	assert.Equal(t, 9090, cfg.Port)
+
+	cfg, err = Load(filepath.Join(dir, "none.conf"))
+	require.NoError(t, err)
+	assert.Equal(t, Defaults(), cfg)
+
+	require.NoError(t, os.WriteFile(first, []byte("port = x\n"), 0o644))
+	_, err = Load(first)
+	assert.ErrorContains(t, err, "first.conf: line 1:")
+
+	require.NoError(t, os.WriteFile(first, []byte("hosts =\n"), 0o644))
+	_, err = Load(first)
+	assert.EqualError(t, err, first+": no hosts")
+}
+
+func TestConfigNoShadowing(t *testing.T) {
+	shadow.Check(t, "exercise1_config.go")
+}
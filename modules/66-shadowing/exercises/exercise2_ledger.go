@@ -0,0 +1,106 @@
+package exercises
+
+// EXERCISE: Fix a ledger whose lookups find nothing, and whose batches of transfers neither roll back nor keep to their limit.
+// A Ledger applies batches of transfers all or none, and caps how much
+// one batch may move. But Find declares the account it finds in an if of
+// its own, Apply declares a new total for each transfer, and a new err
+// for each transfer's error, so none of them reach the code after the
+// loop that reads them. The shadow analyzer finds all three.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNoAccount is returned for a name no account has.
+	ErrNoAccount = errors.New("no such account")
+	// ErrInsufficient is returned for a transfer larger than its account's balance.
+	ErrInsufficient = errors.New("insufficient funds")
+	// ErrLimit is returned for a batch of transfers that moves more than the Limit.
+	ErrLimit = errors.New("over the limit")
+)
+
+// Account is a named balance, in cents.
+type Account struct {
+	Name    string
+	Balance int
+}
+
+// Transfer moves Amount cents from one account to another.
+type Transfer struct {
+	From, To string
+	Amount   int
+}
+
+// Ledger is a set of accounts.
+type Ledger struct {
+	Accounts []Account
+	Limit    int // the most one call of Apply may move, or 0 for no limit
+	Moved    int // the total moved by Apply so far
+}
+
+// Find returns the account called name, ignoring case.
+func (l *Ledger) Find(name string) (*Account, error) {
+	var found *Account
+	for i := range l.Accounts {
+		// BUG: the if declares a found of its own, and the one checked
+		// after the loop stays nil.
+		if found := &l.Accounts[i]; strings.EqualFold(found.Name, name) {
+			break
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoAccount, name)
+	}
+	return found, nil
+}
+
+// transfer makes t.
+func (l *Ledger) transfer(t Transfer) error {
+	from, err := l.Find(t.From)
+	if err != nil {
+		return err
+	}
+	to, err := l.Find(t.To)
+	if err != nil {
+		return err
+	}
+	if from.Balance < t.Amount {
+		return fmt.Errorf("%w: %s has %d", ErrInsufficient, from.Name, from.Balance)
+	}
+	from.Balance -= t.Amount
+	to.Balance += t.Amount
+	return nil
+}
+
+// Apply makes transfers in order, all or none: if one fails, or together
+// they move more than the Limit, the balances are restored, and the error
+// is returned.
+func (l *Ledger) Apply(transfers []Transfer) error {
+	saved := append([]Account(nil), l.Accounts...)
+	var err error
+	total := 0
+	for i, t := range transfers {
+		total := total + t.Amount // BUG: a total for this transfer alone, which starts from 0 each time
+		if l.Limit > 0 && total > l.Limit {
+			err = fmt.Errorf("%w: %d of %d", ErrLimit, total, l.Limit)
+			break
+		}
+		// BUG: a new err, for this iteration's block; the err checked after
+		// the loop stays nil, and nothing is rolled back.
+		err := l.transfer(t)
+		if err != nil {
+			err = fmt.Errorf("transfer %d: %w", i+1, err)
+			break
+		}
+	}
+	if err != nil {
+		l.Accounts = saved
+		return err
+	}
+	l.Moved += total
+	return nil
+}
@@ -0,0 +1,87 @@
+package exercises
+
+// EXERCISE: Fix a route table whose handlers all serve the last route, and which is never installed.
+// Handlers and Alias make a closure for each route in a loop. This
+// course's go.mod says go 1.21, and before Go 1.22 a loop has one
+// variable for all its iterations: every closure captures the same one,
+// and sees its last value. A copy for each iteration, r := r, is
+// shadowing on purpose, which the analyzer leaves alone. What it does
+// report is Install, whose := declares a routes of its own, so that the
+// package's routes, which Serve reads, stays nil.
+// Fix the bugs marked with // BUG: comments.
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Route is a path, and the body served for it.
+type Route struct {
+	Path string
+	Body string
+}
+
+// Handlers returns a function for each route that returns its body, by
+// path.
+func Handlers(rs []Route) map[string]func() string {
+	hs := make(map[string]func() string, len(rs))
+	for _, r := range rs {
+		hs[r.Path] = func() string { return r.Body } // BUG: every closure shares r
+	}
+	return hs
+}
+
+// Alias adds a handler to hs for each alias, which serves what its target
+// does. A target is looked up when the alias is called, so it can be an
+// alias too.
+func Alias(hs map[string]func() string, aliases map[string]string) {
+	for alias, target := range aliases {
+		hs[alias] = func() string {
+			h, ok := hs[target] // BUG: and these share target
+			if !ok {
+				return ""
+			}
+			return h()
+		}
+	}
+}
+
+// build checks rs, and returns their handlers.
+func build(rs []Route) (map[string]func() string, error) {
+	if len(rs) == 0 {
+		return nil, errors.New("no routes")
+	}
+	for i, r := range rs {
+		if !strings.HasPrefix(r.Path, "/") {
+			return nil, fmt.Errorf("route %d: path %q does not start with /", i+1, r.Path)
+		}
+	}
+	return Handlers(rs), nil
+}
+
+// routes is what Serve answers from. Install sets it.
+var routes map[string]func() string
+
+// Install checks rs, which must have different paths, and makes them the
+// routes Serve answers from.
+func Install(rs []Route) error {
+	routes, err := build(rs) // BUG: neither name is declared in this function, so := declares both
+	if err != nil {
+		return err
+	}
+	if len(routes) < len(rs) {
+		return errors.New("two routes have the same path")
+	}
+	return nil
+}
+
+// Serve returns the body for path from the installed routes, and whether
+// there is one.
+func Serve(path string) (string, bool) {
+	h, ok := routes[path]
+	if !ok {
+		return "", false
+	}
+	return h(), true
+}
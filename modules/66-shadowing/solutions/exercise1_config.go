@@ -0,0 +1,94 @@
+package solutions
+
+// SOLUTION: Fix a config parser that ignores bad values, and a loader that returns the defaults it was meant to override.
+// Fixed: the port is assigned to cfg.Port and the loop's err with =, so a bad port is an error
+// Fixed: the timeout is assigned with = instead of declared in the if, so a bad duration is an error
+// Fixed: Load assigns the parsed config to its own cfg, declared outside the loop, with =
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config is a server's configuration.
+type Config struct {
+	Port    int
+	Timeout time.Duration
+	Hosts   []string
+	Debug   bool
+}
+
+// Defaults returns the Config used for what a file does not set.
+func Defaults() Config {
+	return Config{Port: 8080, Timeout: 30 * time.Second, Hosts: []string{"localhost"}}
+}
+
+// Parse reads lines of key = value over the Defaults. Blank lines and
+// lines starting with # are skipped. The keys are port, a number from 1
+// to 65535; timeout, a duration such as 5s; hosts, a list separated by
+// spaces; and debug, true or false. An unknown key or a value that does
+// not parse is an error, which names the line.
+func Parse(text string) (Config, error) {
+	cfg := Defaults()
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return Config{}, fmt.Errorf("line %d: want key = value", n+1)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		var err error
+		switch key {
+		case "port":
+			cfg.Port, err = strconv.Atoi(value) // Fixed: the err checked after the switch
+			if err == nil && (cfg.Port < 1 || cfg.Port > 65535) {
+				err = fmt.Errorf("port %d out of range", cfg.Port)
+			}
+		case "timeout":
+			cfg.Timeout, err = time.ParseDuration(value) // Fixed: not declared in an if of its own
+		case "hosts":
+			cfg.Hosts = strings.Fields(value)
+		case "debug":
+			cfg.Debug, err = strconv.ParseBool(value)
+		default:
+			err = fmt.Errorf("unknown key %q", key)
+		}
+		if err != nil {
+			return Config{}, fmt.Errorf("line %d: %w", n+1, err)
+		}
+	}
+	return cfg, nil
+}
+
+// Load parses the first file of paths that exists, which must leave at
+// least one host. If none exists, it returns the Defaults.
+func Load(paths ...string) (Config, error) {
+	cfg := Defaults()
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return Config{}, err
+		}
+		cfg, err = Parse(string(data)) // Fixed: = sets Load's cfg; := would declare one for this block
+		if err != nil {
+			return Config{}, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(cfg.Hosts) == 0 {
+			return Config{}, fmt.Errorf("%s: no hosts", path)
+		}
+		break
+	}
+	return cfg, nil
+}
@@ -0,0 +1,99 @@
+package solutions
+
+// SOLUTION: Fix a ledger whose lookups find nothing, and whose batches of transfers neither roll back nor keep to their limit.
+// Fixed: Find assigns the account it finds to its own found, instead of declaring one in the if
+// Fixed: Apply adds each amount to the batch's total with +=, instead of declaring a total for each transfer
+// Fixed: Apply assigns a transfer's error to the err checked after the loop, so a failed batch is rolled back and reported
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNoAccount is returned for a name no account has.
+	ErrNoAccount = errors.New("no such account")
+	// ErrInsufficient is returned for a transfer larger than its account's balance.
+	ErrInsufficient = errors.New("insufficient funds")
+	// ErrLimit is returned for a batch of transfers that moves more than the Limit.
+	ErrLimit = errors.New("over the limit")
+)
+
+// Account is a named balance, in cents.
+type Account struct {
+	Name    string
+	Balance int
+}
+
+// Transfer moves Amount cents from one account to another.
+type Transfer struct {
+	From, To string
+	Amount   int
+}
+
+// Ledger is a set of accounts.
+type Ledger struct {
+	Accounts []Account
+	Limit    int // the most one call of Apply may move, or 0 for no limit
+	Moved    int // the total moved by Apply so far
+}
+
+// Find returns the account called name, ignoring case.
+func (l *Ledger) Find(name string) (*Account, error) {
+	var found *Account
+	for i := range l.Accounts {
+		if strings.EqualFold(l.Accounts[i].Name, name) {
+			found = &l.Accounts[i] // Fixed: the found checked after the loop
+			break
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoAccount, name)
+	}
+	return found, nil
+}
+
+// transfer makes t.
+func (l *Ledger) transfer(t Transfer) error {
+	from, err := l.Find(t.From)
+	if err != nil {
+		return err
+	}
+	to, err := l.Find(t.To)
+	if err != nil {
+		return err
+	}
+	if from.Balance < t.Amount {
+		return fmt.Errorf("%w: %s has %d", ErrInsufficient, from.Name, from.Balance)
+	}
+	from.Balance -= t.Amount
+	to.Balance += t.Amount
+	return nil
+}
+
+// Apply makes transfers in order, all or none: if one fails, or together
+// they move more than the Limit, the balances are restored, and the error
+// is returned.
+func (l *Ledger) Apply(transfers []Transfer) error {
+	saved := append([]Account(nil), l.Accounts...)
+	var err error
+	total := 0
+	for i, t := range transfers {
+		total += t.Amount // Fixed: the batch's total, not one for each transfer
+		if l.Limit > 0 && total > l.Limit {
+			err = fmt.Errorf("%w: %d of %d", ErrLimit, total, l.Limit)
+			break
+		}
+		if err = l.transfer(t); err != nil { // Fixed: the err checked after the loop
+			err = fmt.Errorf("transfer %d: %w", i+1, err)
+			break
+		}
+	}
+	if err != nil {
+		l.Accounts = saved
+		return err
+	}
+	l.Moved += total
+	return nil
+}
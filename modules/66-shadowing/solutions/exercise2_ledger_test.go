@@ -0,0 +1,78 @@
+package solutions
+
+import (
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/clone"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/shadow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLedger returns a ledger of three accounts with random balances.
+func newLedger(t *testing.T) *Ledger {
+	r := seed.Rand(t, 1)
+	return &Ledger{Accounts: []Account{
+		{Name: "Alice", Balance: seed.Between(r, 100, 1000)},
+		{Name: "Bob", Balance: seed.Between(r, 100, 1000)},
+		{Name: "Carol", Balance: seed.Between(r, 100, 1000)},
+	}}
+}
+
+func TestFind(t *testing.T) {
+	l := newLedger(t)
+	a, err := l.Find("bob")
+	require.NoError(t, err)
+	assert.Equal(t, "Bob", a.Name)
+	a.Balance = 7
+	assert.Equal(t, 7, l.Accounts[1].Balance, "Find returns the account, not a copy")
+
+	_, err = l.Find("Dave")
+	assert.ErrorIs(t, err, ErrNoAccount)
+}
+
+func TestApply(t *testing.T) {
+	l := newLedger(t)
+	alice, bob, carol := l.Accounts[0].Balance, l.Accounts[1].Balance, l.Accounts[2].Balance
+	require.NoError(t, l.Apply([]Transfer{
+		{From: "Alice", To: "Bob", Amount: 50},
+		{From: "bob", To: "Carol", Amount: 30},
+	}))
+	assert.Equal(t, []Account{{"Alice", alice - 50}, {"Bob", bob + 20}, {"Carol", carol + 30}}, l.Accounts)
+	assert.Equal(t, 80, l.Moved)
+}
+
+func TestApplyRollsBack(t *testing.T) {
+	for name, transfers := range map[string][]Transfer{
+		"too much": {{From: "Alice", To: "Bob", Amount: 50}, {From: "Carol", To: "Alice", Amount: 5000}},
+		"no Dave":  {{From: "Alice", To: "Bob", Amount: 50}, {From: "Bob", To: "Dave", Amount: 1}},
+	} {
+		l := newLedger(t)
+		before := clone.Clone(l.Accounts)
+		err := l.Apply(transfers)
+		assert.ErrorContains(t, err, "transfer 2:", name)
+		assert.Equal(t, before, l.Accounts, "%s: the first transfer is undone", name)
+		assert.Equal(t, 0, l.Moved, name)
+	}
+}
+
+func TestApplyLimit(t *testing.T) {
+	l := newLedger(t)
+	l.Limit = 100
+	before := clone.Clone(l.Accounts)
+	err := l.Apply([]Transfer{
+		{From: "Alice", To: "Bob", Amount: 40},
+		{From: "Bob", To: "Carol", Amount: 40},
+		{From: "Carol", To: "Alice", Amount: 40},
+	})
+	assert.ErrorIs(t, err, ErrLimit, "each is under the limit, but not the three")
+	assert.Equal(t, before, l.Accounts)
+
+	require.NoError(t, l.Apply([]Transfer{{From: "Alice", To: "Bob", Amount: 60}, {From: "Bob", To: "Carol", Amount: 40}}))
+	assert.Equal(t, 100, l.Moved)
+}
+
+func TestLedgerNoShadowing(t *testing.T) {
+	shadow.Check(t, "exercise2_ledger.go")
+}
@@ -0,0 +1,85 @@
+package solutions
+
+// SOLUTION: Fix a route table whose handlers all serve the last route, and which is never installed.
+// Fixed: Handlers copies the loop variable for each closure, with r := r
+// Fixed: the aliases copy theirs too, with target := target
+// Fixed: Install assigns the package's routes with =, instead of declaring a local routes with :=
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Route is a path, and the body served for it.
+type Route struct {
+	Path string
+	Body string
+}
+
+// Handlers returns a function for each route that returns its body, by
+// path.
+func Handlers(rs []Route) map[string]func() string {
+	hs := make(map[string]func() string, len(rs))
+	for _, r := range rs {
+		r := r // Fixed: go.mod says go 1.21, so the loop has one r for every iteration
+		hs[r.Path] = func() string { return r.Body }
+	}
+	return hs
+}
+
+// Alias adds a handler to hs for each alias, which serves what its target
+// does. A target is looked up when the alias is called, so it can be an
+// alias too.
+func Alias(hs map[string]func() string, aliases map[string]string) {
+	for alias, target := range aliases {
+		target := target // Fixed: and so does a range over a map
+		hs[alias] = func() string {
+			h, ok := hs[target]
+			if !ok {
+				return ""
+			}
+			return h()
+		}
+	}
+}
+
+// build checks rs, and returns their handlers.
+func build(rs []Route) (map[string]func() string, error) {
+	if len(rs) == 0 {
+		return nil, errors.New("no routes")
+	}
+	for i, r := range rs {
+		if !strings.HasPrefix(r.Path, "/") {
+			return nil, fmt.Errorf("route %d: path %q does not start with /", i+1, r.Path)
+		}
+	}
+	return Handlers(rs), nil
+}
+
+// routes is what Serve answers from. Install sets it.
+var routes map[string]func() string
+
+// Install checks rs, which must have different paths, and makes them the
+// routes Serve answers from.
+func Install(rs []Route) error {
+	hs, err := build(rs)
+	if err != nil {
+		return err
+	}
+	if len(hs) < len(rs) {
+		return errors.New("two routes have the same path")
+	}
+	routes = hs // Fixed: routes, err := build(rs) declared a local routes, and left this one nil
+	return nil
+}
+
+// Serve returns the body for path from the installed routes, and whether
+// there is one.
+func Serve(path string) (string, bool) {
+	h, ok := routes[path]
+	if !ok {
+		return "", false
+	}
+	return h(), true
+}
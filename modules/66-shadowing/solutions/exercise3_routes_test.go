@@ -0,0 +1,65 @@
+package solutions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/shadow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// someRoutes returns n routes with random bodies.
+func someRoutes(t *testing.T, n int) []Route {
+	r := seed.Rand(t, 1)
+	rs := make([]Route, n)
+	for i := range rs {
+		rs[i] = Route{Path: fmt.Sprintf("/page%d", i), Body: fmt.Sprintf("page %d of %d", i, seed.Between(r, 100, 999))}
+	}
+	return rs
+}
+
+func TestHandlers(t *testing.T) {
+	rs := someRoutes(t, 5)
+	hs := Handlers(rs)
+	require.Len(t, hs, 5)
+	for _, r := range rs {
+		assert.Equal(t, r.Body, hs[r.Path](), r.Path)
+	}
+}
+
+func TestAlias(t *testing.T) {
+	rs := someRoutes(t, 5)
+	hs := Handlers(rs)
+	aliases := map[string]string{"/": "/page0", "/home": "/", "/last": "/page4", "/about": "/page2", "/gone": "/missing"}
+	Alias(hs, aliases)
+	want := map[string]string{"/": rs[0].Body, "/home": rs[0].Body, "/last": rs[4].Body, "/about": rs[2].Body, "/gone": ""}
+	for path, body := range want {
+		assert.Equal(t, body, hs[path](), path)
+	}
+}
+
+func TestInstall(t *testing.T) {
+	rs := someRoutes(t, 3)
+	require.NoError(t, Install(rs))
+	t.Cleanup(func() { routes = nil })
+	for _, r := range rs {
+		body, ok := Serve(r.Path)
+		assert.True(t, ok, r.Path)
+		assert.Equal(t, r.Body, body, r.Path)
+	}
+	_, ok := Serve("/nowhere")
+	assert.False(t, ok)
+
+	assert.EqualError(t, Install([]Route{{Path: "/a"}, {Path: "b"}}), `route 2: path "b" does not start with /`)
+	assert.EqualError(t, Install(nil), "no routes")
+	assert.EqualError(t, Install([]Route{{Path: "/a"}, {Path: "/a"}}), "two routes have the same path")
+	body, ok := Serve(rs[0].Path)
+	assert.True(t, ok, "a failed Install keeps the routes installed before")
+	assert.Equal(t, rs[0].Body, body)
+}
+
+func TestRoutesNoShadowing(t *testing.T) {
+	shadow.Check(t, "exercise3_routes.go")
+}
@@ -0,0 +1,177 @@
+// Package achievements awards badges for milestones in a learner's
+// progress. Badges are worked out from the progress store, so they can be
+// checked after any command that records progress, and unlocked badges are
+// kept in the store so each is announced once.
+package achievements
+
+import (
+	"sort"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+)
+
+// Badge is an achievement that can be unlocked.
+type Badge struct {
+	ID          string
+	Name        string
+	Description string
+	// earned reports whether the progress in s has earned the badge, and
+	// when.
+	earned func(c *course.Course, s *progress.Store) (time.Time, bool)
+}
+
+// Badges lists every badge in display order.
+var Badges = []*Badge{
+	{
+		ID:          "first-green",
+		Name:        "First Green",
+		Description: "Make an exercise's tests pass",
+		earned:      firstGreen,
+	},
+	{
+		ID:          "module-complete",
+		Name:        "Module Complete",
+		Description: "Finish every exercise in a module",
+		earned: func(c *course.Course, s *progress.Store) (time.Time, bool) {
+			return firstModule(c, s, func(m *course.Module) bool { return true })
+		},
+	},
+	{
+		ID:          "no-hints",
+		Name:        "Unassisted",
+		Description: "Finish every exercise in a module without using a hint",
+		earned: func(c *course.Course, s *progress.Store) (time.Time, bool) {
+			return firstModule(c, s, func(m *course.Module) bool {
+				for _, e := range m.Exercises {
+					if s.Get(e.ID).HintsUsed > 0 {
+						return false
+					}
+				}
+				return true
+			})
+		},
+	},
+	{
+		ID:          "race-free",
+		Name:        "Race Free",
+		Description: "Pass every exercise in a concurrency module with the race detector on",
+		earned:      raceFree,
+	},
+	{
+		ID:          "quiz-ace",
+		Name:        "Quiz Ace",
+		Description: "Answer every question of a module quiz correctly",
+		earned:      quizAce,
+	},
+}
+
+// Lookup returns the badge with the given ID, or nil.
+func Lookup(id string) *Badge {
+	for _, b := range Badges {
+		if b.ID == id {
+			return b
+		}
+	}
+	return nil
+}
+
+// Award is an unlocked badge.
+type Award struct {
+	Badge *Badge
+	At    time.Time
+}
+
+// Update unlocks in s every badge its progress has earned and returns the
+// newly unlocked ones. A badge is dated when it was earned, which may be
+// before Update noticed it.
+func Update(c *course.Course, s *progress.Store) []Award {
+	var awards []Award
+	for _, b := range Badges {
+		if _, ok := s.Unlocked(b.ID); ok {
+			continue
+		}
+		if at, ok := b.earned(c, s); ok && s.Unlock(b.ID, at) {
+			awards = append(awards, Award{Badge: b, At: at})
+		}
+	}
+	return awards
+}
+
+// Unlocked returns the badges unlocked in s, oldest first. Unknown IDs
+// (say, from a newer learngo) are skipped.
+func Unlocked(s *progress.Store) []Award {
+	var awards []Award
+	for _, b := range Badges {
+		if at, ok := s.Unlocked(b.ID); ok {
+			awards = append(awards, Award{Badge: b, At: at})
+		}
+	}
+	sort.SliceStable(awards, func(i, j int) bool { return awards[i].At.Before(awards[j].At) })
+	return awards
+}
+
+func firstGreen(c *course.Course, s *progress.Store) (time.Time, bool) {
+	var first time.Time
+	for _, e := range c.Exercises() {
+		if at := s.Get(e.ID).CompletedAt; !at.IsZero() && (first.IsZero() || at.Before(first)) {
+			first = at
+		}
+	}
+	return first, !first.IsZero()
+}
+
+// firstModule returns when the first module matching ok was completed.
+func firstModule(c *course.Course, s *progress.Store, ok func(*course.Module) bool) (time.Time, bool) {
+	var first time.Time
+	for _, m := range c.Modules {
+		at, done := completed(m, s)
+		if done && ok(m) && (first.IsZero() || at.Before(first)) {
+			first = at
+		}
+	}
+	return first, !first.IsZero()
+}
+
+// completed returns when the last exercise of m was completed. Modules
+// without exercises never count as completed.
+func completed(m *course.Module, s *progress.Store) (time.Time, bool) {
+	var last time.Time
+	for _, e := range m.Exercises {
+		at := s.Get(e.ID).CompletedAt
+		if at.IsZero() {
+			return time.Time{}, false
+		}
+		if at.After(last) {
+			last = at
+		}
+	}
+	return last, len(m.Exercises) > 0
+}
+
+func raceFree(c *course.Course, s *progress.Store) (time.Time, bool) {
+	var first time.Time
+	for _, m := range c.Modules {
+		if !m.Race || len(m.Exercises) == 0 {
+			continue
+		}
+		run, ok := s.LastRun(m.ID)
+		if ok && run.Race && len(run.Failed) == 0 && len(run.Passed) == len(m.Exercises) &&
+			(first.IsZero() || run.At.Before(first)) {
+			first = run.At
+		}
+	}
+	return first, !first.IsZero()
+}
+
+func quizAce(c *course.Course, s *progress.Store) (time.Time, bool) {
+	var first time.Time
+	for _, m := range c.Modules {
+		best := s.Quiz(m.ID).Best
+		if best.Total > 0 && best.Correct == best.Total && (first.IsZero() || best.At.Before(first)) {
+			first = best.At
+		}
+	}
+	return first, !first.IsZero()
+}
@@ -0,0 +1,101 @@
+package achievements
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var t0 = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func testCourse() *course.Course {
+	module := func(id string, exercises ...string) *course.Module {
+		m := &course.Module{ID: id}
+		for _, name := range exercises {
+			m.Exercises = append(m.Exercises, &course.Exercise{ID: id + "/" + name, Module: id, Name: name})
+		}
+		return m
+	}
+	concurrency := module("03-concurrency", "ex1")
+	concurrency.Race = true
+	return &course.Course{Modules: []*course.Module{
+		module("01-basics", "ex1", "ex2"),
+		module("02-types", "ex1"),
+		concurrency,
+		module("04-empty"),
+	}}
+}
+
+func ids(awards []Award) []string {
+	var out []string
+	for _, a := range awards {
+		out = append(out, a.Badge.ID)
+	}
+	return out
+}
+
+func TestUpdate(t *testing.T) {
+	c := testCourse()
+	var s progress.Store
+	assert.Empty(t, Update(c, &s), "nothing earned yet")
+
+	s.RecordAttempt("01-basics/ex1", false, t0)
+	s.RecordAttempt("01-basics/ex1", true, t0.Add(time.Hour))
+	awards := Update(c, &s)
+	assert.Equal(t, []string{"first-green"}, ids(awards))
+	assert.Equal(t, t0.Add(time.Hour), awards[0].At)
+	assert.Empty(t, Update(c, &s), "each badge is awarded once")
+
+	s.RecordHint("01-basics/ex2")
+	s.RecordAttempt("01-basics/ex2", true, t0.Add(2*time.Hour))
+	assert.Equal(t, []string{"module-complete"}, ids(Update(c, &s)), "a hint rules out no-hints")
+
+	s.RecordAttempt("02-types/ex1", true, t0.Add(3*time.Hour))
+	awards = Update(c, &s)
+	assert.Equal(t, []string{"no-hints"}, ids(awards))
+	assert.Equal(t, t0.Add(3*time.Hour), awards[0].At)
+
+	s.RecordQuiz("02-types", progress.Score{Correct: 4, Total: 5, At: t0})
+	assert.Empty(t, Update(c, &s))
+	s.RecordQuiz("02-types", progress.Score{Correct: 5, Total: 5, At: t0.Add(4 * time.Hour)})
+	assert.Equal(t, []string{"quiz-ace"}, ids(Update(c, &s)))
+
+	assert.Equal(t, []string{"first-green", "module-complete", "no-hints", "quiz-ace"}, ids(Unlocked(&s)))
+}
+
+func TestRaceFree(t *testing.T) {
+	c := testCourse()
+	var s progress.Store
+	s.RecordRun("03-concurrency", progress.Run{At: t0, Passed: []string{"03-concurrency/ex1"}})
+	assert.NotContains(t, ids(Update(c, &s)), "race-free", "run without -race")
+
+	s.RecordRun("03-concurrency", progress.Run{At: t0, Race: true, Failed: []string{"03-concurrency/ex1"}})
+	assert.NotContains(t, ids(Update(c, &s)), "race-free", "failing run")
+
+	s.RecordRun("03-concurrency", progress.Run{At: t0, Race: true, Passed: []string{"03-concurrency/ex1"}})
+	assert.Contains(t, ids(Update(c, &s)), "race-free")
+}
+
+func TestLookup(t *testing.T) {
+	require.NotNil(t, Lookup("race-free"))
+	assert.Nil(t, Lookup("nope"))
+	seen := make(map[string]bool)
+	for _, b := range Badges {
+		assert.False(t, seen[b.ID], "duplicate badge %s", b.ID)
+		seen[b.ID] = true
+		assert.NotEmpty(t, b.Name)
+		assert.NotEmpty(t, b.Description)
+	}
+}
+
+func TestUnlockedSkipsUnknownBadges(t *testing.T) {
+	var s progress.Store
+	s.Unlock("from-the-future", t0)
+	s.Unlock("quiz-ace", t0.Add(time.Hour))
+	s.Unlock("first-green", t0.Add(2*time.Hour))
+	assert.Equal(t, []string{"quiz-ace", "first-green"}, ids(Unlocked(&s)))
+}
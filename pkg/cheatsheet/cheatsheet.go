@@ -0,0 +1,255 @@
+// Package cheatsheet builds a module's concept cheat sheet from the doc
+// comments and declarations in its examples/ package, so the reference
+// material is regenerated from the example code instead of kept in sync
+// by hand.
+//
+// A sheet has a section per example file. The section opens with the
+// file's introduction, the package comment or the free-standing comment
+// after the imports, and lists the file's exported functions, types, and
+// methods with their signatures and the first sentence of their docs.
+package cheatsheet
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+)
+
+// ExamplesDir is the directory of a module the sheet is built from.
+const ExamplesDir = "examples"
+
+// ErrNoExamples is returned by Build for a module without examples.
+var ErrNoExamples = errors.New("module has no examples")
+
+// Sheet is a module's cheat sheet.
+type Sheet struct {
+	Module   *course.Module
+	Synopsis string // the package comment's first sentence
+	Files    []File
+}
+
+// File is the part of a sheet from one example file.
+type File struct {
+	Name  string // e.g. "example1_types.go"
+	Intro string // doc comment text
+	Decls []Decl
+}
+
+// Decl is one exported declaration.
+type Decl struct {
+	Name      string // "Stack.Push" for methods
+	Signature string // e.g. "func (s *Stack[T]) Push(v T)"
+	Synopsis  string
+}
+
+// Build parses the module's examples and returns its sheet.
+func Build(m *course.Module) (*Sheet, error) {
+	dir := filepath.Join(m.Dir, ExamplesDir)
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	intros := make(map[string]string)
+	var pkgDoc string
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") || course.Generated(name) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		intro := fileIntro(f)
+		if f.Doc != nil && pkgDoc == "" {
+			pkgDoc = f.Doc.Text()
+		}
+		intros[filepath.Base(name)] = intro
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s: %w", m.ID, ErrNoExamples)
+	}
+
+	// doc.NewFromFiles takes the comments out of the files, so the
+	// introductions are read first.
+	pkg, err := doc.NewFromFiles(fset, files, ExamplesDir)
+	if err != nil {
+		return nil, err
+	}
+	s := &Sheet{Module: m, Synopsis: pkg.Synopsis(pkgDoc)}
+	byFile := make(map[string][]posDecl)
+	add := func(pos token.Pos, d Decl, text string) {
+		d.Synopsis = pkg.Synopsis(text)
+		p := fset.Position(pos)
+		name := filepath.Base(p.Filename)
+		byFile[name] = append(byFile[name], posDecl{p.Offset, d})
+	}
+	addFuncs := func(funcs []*doc.Func, recv string) {
+		for _, fn := range funcs {
+			sig := *fn.Decl
+			sig.Body, sig.Doc = nil, nil
+			name := fn.Name
+			if recv != "" && fn.Recv != "" {
+				name = recv + "." + name
+			}
+			add(fn.Decl.Pos(), Decl{Name: name, Signature: nodeString(fset, &sig)}, fn.Doc)
+		}
+	}
+	addFuncs(pkg.Funcs, "")
+	for _, t := range pkg.Types {
+		spec := typeSpec(t)
+		if spec == nil {
+			continue
+		}
+		add(spec.Pos(), Decl{Name: t.Name, Signature: typeSignature(fset, spec)}, t.Doc)
+		addFuncs(t.Funcs, "")
+		addFuncs(t.Methods, t.Name)
+	}
+
+	for _, f := range files {
+		name := filepath.Base(fset.Position(f.Package).Filename)
+		decls := byFile[name]
+		sort.Slice(decls, func(i, j int) bool { return decls[i].offset < decls[j].offset })
+		file := File{Name: name, Intro: intros[name]}
+		for _, d := range decls {
+			file.Decls = append(file.Decls, d.Decl)
+		}
+		s.Files = append(s.Files, file)
+	}
+	return s, nil
+}
+
+type posDecl struct {
+	offset int
+	Decl
+}
+
+// fileIntro returns what a file says about itself: its package comment
+// without the first paragraph, which introduces the whole package, or
+// else the first comment after the imports that documents no declaration.
+func fileIntro(f *ast.File) string {
+	if f.Doc != nil {
+		text := f.Doc.Text()
+		if strings.HasPrefix(text, "Package ") {
+			_, text, _ = strings.Cut(text, "\n\n")
+		}
+		return text
+	}
+	docs := make(map[*ast.CommentGroup]bool)
+	end := f.Name.End()
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT {
+				end = decl.End()
+				continue
+			}
+			docs[decl.Doc] = true
+		case *ast.FuncDecl:
+			docs[decl.Doc] = true
+		}
+	}
+	for _, cg := range f.Comments {
+		if cg.Pos() > end && !docs[cg] {
+			return cg.Text()
+		}
+	}
+	return ""
+}
+
+// typeSpec returns the declaration of t, without those of other types in
+// the same group.
+func typeSpec(t *doc.Type) *ast.TypeSpec {
+	for _, spec := range t.Decl.Specs {
+		if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == t.Name {
+			return ts
+		}
+	}
+	return nil
+}
+
+// typeSignature renders a type declaration, leaving out the fields of
+// structs and the methods of interfaces.
+func typeSignature(fset *token.FileSet, ts *ast.TypeSpec) string {
+	short := *ts
+	short.Doc, short.Comment = nil, nil
+	switch ts.Type.(type) {
+	case *ast.StructType:
+		short.Type = &ast.Ident{Name: "struct{ ... }"}
+	case *ast.InterfaceType:
+		short.Type = &ast.Ident{Name: "interface{ ... }"}
+	}
+	return "type " + nodeString(fset, &short)
+}
+
+func nodeString(fset *token.FileSet, node any) string {
+	var sb strings.Builder
+	if err := printer.Fprint(&sb, fset, node); err != nil {
+		return ""
+	}
+	return sb.String()
+}
+
+// WriteMarkdown writes the sheet as Markdown.
+func (s *Sheet) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Cheat Sheet: %s\n\n", s.Module.Title)
+	if s.Module.Description != "" {
+		fmt.Fprintf(&sb, "%s\n\n", s.Module.Description)
+	} else if s.Synopsis != "" {
+		fmt.Fprintf(&sb, "%s\n\n", s.Synopsis)
+	}
+	fmt.Fprintf(&sb, "Generated from the doc comments in %s/%s/.\n", s.Module.ID, ExamplesDir)
+	for _, f := range s.Files {
+		fmt.Fprintf(&sb, "\n## %s\n\n", f.Name)
+		if f.Intro != "" {
+			fmt.Fprintf(&sb, "%s\n", markdownText(f.Intro))
+		}
+		for _, d := range f.Decls {
+			fmt.Fprintf(&sb, "- `%s`", oneLine(d.Signature))
+			if d.Synopsis != "" {
+				fmt.Fprintf(&sb, ": %s", d.Synopsis)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// markdownText turns doc comment text into Markdown. Doc comments and
+// Markdown agree on paragraphs and "-" lists; indented code blocks are
+// fenced so they cannot be taken for part of a list item.
+func markdownText(text string) string {
+	var sb strings.Builder
+	inCode := false
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		if code := strings.HasPrefix(line, "\t"); code != inCode && line != "" {
+			sb.WriteString("```\n")
+			inCode = code
+		}
+		sb.WriteString(strings.TrimPrefix(line, "\t") + "\n")
+	}
+	if inCode {
+		sb.WriteString("```\n")
+	}
+	return sb.String()
+}
+
+// oneLine collapses a multi-line signature, such as one with a function
+// literal parameter, onto one line.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,140 @@
+package cheatsheet
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const example1 = `// Package examples demonstrates stacks.
+//
+// This file shows:
+// - Push and Pop
+// - Generics
+package examples
+
+// Stack is a last-in, first-out collection. It grows as needed.
+type Stack[T any] struct {
+	items []T
+}
+
+// NewStack returns an empty stack.
+func NewStack[T any]() *Stack[T] { return &Stack[T]{} }
+
+// Push adds v to the top.
+func (s *Stack[T]) Push(v T) { s.items = append(s.items, v) }
+
+func (s *Stack[T]) pop() {}
+
+// Reader reads.
+type Reader interface {
+	Read() string
+}
+`
+
+const example2 = `package examples
+
+import "fmt"
+
+// LoopExamples demonstrates loops. Run them with:
+//
+//	go test -run Loops
+
+// Count prints 0 to n-1.
+func Count(n int) {
+	for i := 0; i < n; i++ {
+		fmt.Println(i)
+	}
+}
+`
+
+func testModule(t *testing.T, files map[string]string) *course.Module {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return &course.Module{ID: "12-stacks", Title: "Stacks", Dir: dir}
+}
+
+func TestBuild(t *testing.T) {
+	m := testModule(t, map[string]string{
+		"examples/example1_stack.go":      example1,
+		"examples/example1_stack_test.go": "package examples\n\nfunc TestIgnored() {}\n",
+		"examples/example2_loops.go":      example2,
+		"examples/kind_string.go":         "// Code generated by \"enumgen -type=Kind\"; DO NOT EDIT.\n\npackage examples\n\n// Kinds is generated.\nvar Kinds = 3\n",
+	})
+	s, err := Build(m)
+	require.NoError(t, err)
+	assert.Equal(t, "Package examples demonstrates stacks.", s.Synopsis)
+	require.Len(t, s.Files, 2, "generated files are left out")
+
+	f := s.Files[0]
+	assert.Equal(t, "example1_stack.go", f.Name)
+	assert.Equal(t, "This file shows:\n- Push and Pop\n- Generics\n", f.Intro)
+	assert.Equal(t, []Decl{
+		{Name: "Stack", Signature: "type Stack[T any] struct{ ... }", Synopsis: "Stack is a last-in, first-out collection."},
+		{Name: "NewStack", Signature: "func NewStack[T any]() *Stack[T]", Synopsis: "NewStack returns an empty stack."},
+		{Name: "Stack.Push", Signature: "func (s *Stack[T]) Push(v T)", Synopsis: "Push adds v to the top."},
+		{Name: "Reader", Signature: "type Reader interface{ ... }", Synopsis: "Reader reads."},
+	}, f.Decls, "exported only, in source order")
+
+	f = s.Files[1]
+	assert.Equal(t, "LoopExamples demonstrates loops. Run them with:\n\n\tgo test -run Loops\n", f.Intro, "the free-standing comment after the imports")
+	assert.Equal(t, []Decl{{Name: "Count", Signature: "func Count(n int)", Synopsis: "Count prints 0 to n-1."}}, f.Decls)
+}
+
+func TestBuildNoExamples(t *testing.T) {
+	m := testModule(t, map[string]string{"README.md": "# Stacks\n"})
+	_, err := Build(m)
+	assert.True(t, errors.Is(err, ErrNoExamples))
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	m := testModule(t, map[string]string{"examples/example2_loops.go": example2})
+	m.Description = "Stacks and loops."
+	s, err := Build(m)
+	require.NoError(t, err)
+	var sb strings.Builder
+	require.NoError(t, s.WriteMarkdown(&sb))
+	assert.Equal(t, "# Cheat Sheet: Stacks\n\n"+
+		"Stacks and loops.\n\n"+
+		"Generated from the doc comments in 12-stacks/examples/.\n\n"+
+		"## example2_loops.go\n\n"+
+		"LoopExamples demonstrates loops. Run them with:\n\n"+
+		"```\ngo test -run Loops\n```\n\n"+
+		"- `func Count(n int)`: Count prints 0 to n-1.\n", sb.String())
+}
+
+// TestRepositorySheets checks that every module's examples make a cheat
+// sheet in which each function and type is documented. Methods may go
+// without, like Len, Less, and Swap of a sort.Interface.
+func TestRepositorySheets(t *testing.T) {
+	root, err := course.FindRoot(".")
+	require.NoError(t, err)
+	c, err := course.Load(root)
+	require.NoError(t, err)
+	for _, m := range c.Modules {
+		s, err := Build(m)
+		if errors.Is(err, ErrNoExamples) {
+			continue
+		}
+		require.NoError(t, err)
+		for _, f := range s.Files {
+			for _, d := range f.Decls {
+				if strings.Contains(d.Name, ".") {
+					continue
+				}
+				assert.NotEmpty(t, d.Synopsis, "%s/%s/%s: %s has no doc comment", m.ID, ExamplesDir, f.Name, d.Name)
+			}
+		}
+	}
+}
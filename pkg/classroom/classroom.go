@@ -0,0 +1,218 @@
+// Package classroom collects learners' results on an instructor's server.
+//
+// Learners opt in by pointing learngo at the server; after each test run it
+// pushes a Report for the module with Push. The server keeps the latest
+// result of every learner on every exercise in a Cohort, which it saves to
+// a JSON file so a restart loses nothing.
+package classroom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReportsPath is where the server accepts reports.
+const ReportsPath = "/reports"
+
+// Exercise is a learner's standing on one exercise.
+type Exercise struct {
+	ID         string `json:"id"` // "01-basics/exercise1_fix_bugs"
+	Passed     bool   `json:"passed"`
+	Completed  bool   `json:"completed"` // passed at least once
+	Attempts   int    `json:"attempts"`
+	FailedRuns int    `json:"failed_runs"`
+	HintsUsed  int    `json:"hints_used,omitempty"`
+
+	// Source is the learner's exercise file, sent once they completed the
+	// exercise so the instructor can look for copied solutions.
+	Source string `json:"source,omitempty"`
+}
+
+// Report is what a learner's learngo sends after a test run of a module.
+type Report struct {
+	Learner   string     `json:"learner"`
+	Module    string     `json:"module"`
+	At        time.Time  `json:"at"`
+	Exercises []Exercise `json:"exercises"`
+}
+
+// Validate checks that a report names its learner and exercises, and that
+// the exercises belong to the module.
+func (r Report) Validate() error {
+	switch {
+	case strings.TrimSpace(r.Learner) == "":
+		return errors.New("report has no learner")
+	case r.Module == "":
+		return errors.New("report has no module")
+	}
+	for _, e := range r.Exercises {
+		if !strings.HasPrefix(e.ID, r.Module+"/") {
+			return fmt.Errorf("exercise %q is not in module %s", e.ID, r.Module)
+		}
+	}
+	return nil
+}
+
+// Push sends a report to the server at baseURL. A non-empty token is sent
+// as a bearer token, for servers that require one.
+func Push(ctx context.Context, client *http.Client, baseURL, token string, r Report) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(baseURL, "/")+ReportsPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("classroom server: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// Learner is everything the server knows about one learner.
+type Learner struct {
+	Name      string               `json:"name"`
+	LastSeen  time.Time            `json:"last_seen"`
+	Exercises map[string]*Exercise `json:"exercises"`
+}
+
+// Completed counts the learner's completed exercises among ids, or among
+// all of them if ids is nil.
+func (l *Learner) Completed(ids []string) int {
+	n := 0
+	if ids == nil {
+		for _, e := range l.Exercises {
+			if e.Completed {
+				n++
+			}
+		}
+		return n
+	}
+	for _, id := range ids {
+		if e, ok := l.Exercises[id]; ok && e.Completed {
+			n++
+		}
+	}
+	return n
+}
+
+// Cohort is the latest standing of every learner. It is safe for
+// concurrent use.
+type Cohort struct {
+	path string
+
+	mu       sync.Mutex
+	learners map[string]*Learner
+}
+
+// Open reads the cohort saved at path. A missing file is an empty cohort;
+// an empty path is a cohort that is never saved.
+func Open(path string) (*Cohort, error) {
+	c := &Cohort{path: path, learners: make(map[string]*Learner)}
+	if path == "" {
+		return c, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var learners []*Learner
+	if err := json.Unmarshal(data, &learners); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	for _, l := range learners {
+		c.learners[l.Name] = l
+	}
+	return c, nil
+}
+
+// Add records a report, replacing the learner's earlier results for the
+// same exercises, and saves the cohort.
+func (c *Cohort) Add(r Report) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l, ok := c.learners[r.Learner]
+	if !ok {
+		l = &Learner{Name: r.Learner, Exercises: make(map[string]*Exercise)}
+		c.learners[r.Learner] = l
+	}
+	if r.At.After(l.LastSeen) {
+		l.LastSeen = r.At
+	}
+	for _, e := range r.Exercises {
+		e := e
+		l.Exercises[e.ID] = &e
+	}
+	return c.save()
+}
+
+// Learners returns a copy of every learner, sorted by name.
+func (c *Cohort) Learners() []*Learner {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]*Learner, 0, len(c.learners))
+	for _, l := range c.learners {
+		cp := &Learner{Name: l.Name, LastSeen: l.LastSeen, Exercises: make(map[string]*Exercise, len(l.Exercises))}
+		for id, e := range l.Exercises {
+			e := *e
+			cp.Exercises[id] = &e
+		}
+		out = append(out, cp)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// save writes the cohort to its file like progress.Store.Save: to a
+// temporary file first, then renamed into place. c.mu must be held.
+func (c *Cohort) save() error {
+	if c.path == "" {
+		return nil
+	}
+	learners := make([]*Learner, 0, len(c.learners))
+	for _, l := range c.learners {
+		learners = append(learners, l)
+	}
+	sort.Slice(learners, func(i, j int) bool { return learners[i].Name < learners[j].Name })
+	data, err := json.MarshalIndent(learners, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
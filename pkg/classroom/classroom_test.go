@@ -0,0 +1,93 @@
+package classroom
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var t0 = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func TestValidate(t *testing.T) {
+	ok := Report{Learner: "ada", Module: "01-basics", Exercises: []Exercise{{ID: "01-basics/exercise1"}}}
+	assert.NoError(t, ok.Validate())
+
+	noLearner := ok
+	noLearner.Learner = " "
+	assert.ErrorContains(t, noLearner.Validate(), "no learner")
+
+	wrongModule := ok
+	wrongModule.Exercises = []Exercise{{ID: "02-types/exercise1"}}
+	assert.ErrorContains(t, wrongModule.Validate(), "not in module 01-basics")
+}
+
+func TestCohortAdd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cohort.json")
+	c, err := Open(path)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Add(Report{Learner: "ada", Module: "01-basics", At: t0, Exercises: []Exercise{
+		{ID: "01-basics/exercise1", Attempts: 3, FailedRuns: 2},
+		{ID: "01-basics/exercise2", Passed: true, Completed: true, Attempts: 1},
+	}}))
+	require.NoError(t, c.Add(Report{Learner: "ada", Module: "01-basics", At: t0.Add(time.Hour), Exercises: []Exercise{
+		{ID: "01-basics/exercise1", Passed: true, Completed: true, Attempts: 4, FailedRuns: 2},
+	}}))
+	require.NoError(t, c.Add(Report{Learner: "bo", Module: "01-basics", At: t0, Exercises: []Exercise{
+		{ID: "01-basics/exercise1", Attempts: 1, FailedRuns: 1},
+	}}))
+	assert.Error(t, c.Add(Report{Module: "01-basics"}))
+
+	reopened, err := Open(path)
+	require.NoError(t, err)
+	learners := reopened.Learners()
+	require.Len(t, learners, 2)
+	ada, bo := learners[0], learners[1]
+	assert.Equal(t, "ada", ada.Name)
+	assert.Equal(t, t0.Add(time.Hour), ada.LastSeen)
+	assert.Equal(t, 4, ada.Exercises["01-basics/exercise1"].Attempts, "the latest report wins")
+	assert.Equal(t, 2, ada.Completed(nil))
+	assert.Equal(t, 1, ada.Completed([]string{"01-basics/exercise2", "02-types/exercise1"}))
+	assert.Equal(t, 0, bo.Completed(nil))
+}
+
+func TestLearnersIsACopy(t *testing.T) {
+	c, err := Open("")
+	require.NoError(t, err)
+	require.NoError(t, c.Add(Report{Learner: "ada", Module: "01-basics", Exercises: []Exercise{{ID: "01-basics/exercise1"}}}))
+	c.Learners()[0].Exercises["01-basics/exercise1"].Passed = true
+	assert.False(t, c.Learners()[0].Exercises["01-basics/exercise1"].Passed)
+}
+
+func TestPush(t *testing.T) {
+	var got Report
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, ReportsPath, r.URL.Path)
+		auth = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		if got.Learner == "mallory" {
+			http.Error(w, "unknown learner", http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	r := Report{Learner: "ada", Module: "01-basics", At: t0, Exercises: []Exercise{{ID: "01-basics/exercise1", Passed: true}}}
+	require.NoError(t, Push(context.Background(), srv.Client(), srv.URL+"/", "s3cret", r))
+	assert.Equal(t, r, got)
+	assert.Equal(t, "Bearer s3cret", auth)
+
+	r.Learner = "mallory"
+	err := Push(context.Background(), srv.Client(), srv.URL, "", r)
+	assert.ErrorContains(t, err, "403 Forbidden: unknown learner")
+	assert.Empty(t, auth)
+}
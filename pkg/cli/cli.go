@@ -0,0 +1,439 @@
+// Package cli is a small framework for command-line tools with nested
+// subcommands, in the style of github.com/spf13/cobra but built on the
+// standard flag package. Module 17 uses it for the shapes tool.
+//
+// A tool is a tree of Commands. Each has its own flags and may have
+// persistent flags, which also apply to every command below it:
+//
+//	root := &cli.Command{Use: "shapes", Short: "Work with shapes"}
+//	precision := root.PersistentFlags().Int("precision", 2, "digits after the point")
+//	area := &cli.Command{
+//		Use:   "area <shape> <size>...",
+//		Short: "Print the area of a shape",
+//		Args:  cli.MinimumArgs(2),
+//		Run: func(ctx *cli.Context) error {
+//			...
+//		},
+//	}
+//	root.AddCommand(area)
+//	os.Exit(root.Execute(os.Args[1:], os.Stdout, os.Stderr))
+//
+// Execute finds the command the arguments name, parses the flags, which may
+// come before or after the positional arguments, and runs it. -h, --help,
+// and "help <command>" print help generated from the tree.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Exit codes returned by Execute.
+const (
+	ExitOK    = 0
+	ExitError = 1 // Run returned an error
+	ExitUsage = 2 // the command line was wrong
+)
+
+// ErrUsage marks errors in how a command was called rather than in what it
+// did. Execute exits with ExitUsage for them and points to the help.
+var ErrUsage = errors.New("usage")
+
+// Usagef returns an error wrapping ErrUsage.
+func Usagef(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrUsage, fmt.Sprintf(format, args...))
+}
+
+// Command is a command of a tool: the root, or a subcommand.
+type Command struct {
+	// Use is the command's name followed by a synopsis of its positional
+	// arguments, e.g. "area <shape> <size>...".
+	Use string
+	// Aliases are other names that select the command.
+	Aliases []string
+	// Short is the one-line description listed in the parent's help.
+	Short string
+	// Long is the description at the top of the command's own help; Short
+	// if empty.
+	Long string
+	// Args checks the positional arguments before Run is called.
+	Args PositionalArgs
+	// Run does the command's work. A command without Run only groups
+	// subcommands; run on its own, it prints its help.
+	Run func(ctx *Context) error
+
+	parent     *Command
+	commands   []*Command
+	flags      *flag.FlagSet
+	persistent *flag.FlagSet
+}
+
+// Context is what Run gets: the positional arguments and the output.
+type Context struct {
+	Command *Command
+	Args    []string
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// Name is the first word of Use.
+func (c *Command) Name() string {
+	name, _, _ := strings.Cut(c.Use, " ")
+	return name
+}
+
+// Path is the names of the command and its ancestors, e.g. "shapes area".
+func (c *Command) Path() string {
+	if c.parent == nil {
+		return c.Name()
+	}
+	return c.parent.Path() + " " + c.Name()
+}
+
+// Parent returns the command c was added to, or nil for the root.
+func (c *Command) Parent() *Command { return c.parent }
+
+// AddCommand adds subcommands to c.
+func (c *Command) AddCommand(cmds ...*Command) {
+	for _, sub := range cmds {
+		sub.parent = c
+		c.commands = append(c.commands, sub)
+	}
+}
+
+// Commands returns the subcommands of c, sorted by name.
+func (c *Command) Commands() []*Command {
+	cmds := append([]*Command(nil), c.commands...)
+	sort.Slice(cmds, func(i, j int) bool { return cmds[i].Name() < cmds[j].Name() })
+	return cmds
+}
+
+// Flags returns the flags of c alone.
+func (c *Command) Flags() *flag.FlagSet {
+	if c.flags == nil {
+		c.flags = flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	}
+	return c.flags
+}
+
+// PersistentFlags returns the flags of c that apply to its subcommands too.
+func (c *Command) PersistentFlags() *flag.FlagSet {
+	if c.persistent == nil {
+		c.persistent = flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	}
+	return c.persistent
+}
+
+// find returns the subcommand of c called name, or nil.
+func (c *Command) find(name string) *Command {
+	for _, sub := range c.commands {
+		if sub.Name() == name {
+			return sub
+		}
+		for _, alias := range sub.Aliases {
+			if alias == name {
+				return sub
+			}
+		}
+	}
+	return nil
+}
+
+// inherited returns the persistent flags of c's ancestors, nearest first.
+func (c *Command) inherited() []*flag.FlagSet {
+	var sets []*flag.FlagSet
+	for p := c.parent; p != nil; p = p.parent {
+		if p.persistent != nil {
+			sets = append(sets, p.persistent)
+		}
+	}
+	return sets
+}
+
+// flagSet returns a FlagSet with every flag that applies to c. The flags
+// keep their variables, so parsing it sets what the commands defined.
+func (c *Command) flagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet(c.Path(), flag.ContinueOnError)
+	fs.SetOutput(io.Discard) // Execute reports errors itself
+	fs.Usage = func() {}
+	for _, set := range append([]*flag.FlagSet{c.flags, c.persistent}, c.inherited()...) {
+		if set == nil {
+			continue
+		}
+		set.VisitAll(func(f *flag.Flag) {
+			if fs.Lookup(f.Name) == nil { // a closer command's flag wins
+				addFlag(fs, f)
+			}
+		})
+	}
+	return fs
+}
+
+// addFlag defines f on fs, keeping its default.
+func addFlag(fs *flag.FlagSet, f *flag.Flag) {
+	fs.Var(f.Value, f.Name, f.Usage)
+	fs.Lookup(f.Name).DefValue = f.DefValue
+}
+
+// Execute runs the command that args name below c, and returns the exit
+// code. Errors go to stderr. The flags are parsed into the variables they
+// were defined with, so build a new tree to execute again from scratch.
+func (c *Command) Execute(args []string, stdout, stderr io.Writer) int {
+	cmd, rest := c.resolve(args)
+	if cmd == c && len(rest) > 0 && rest[0] == "help" {
+		cmd, rest = c.resolve(rest[1:])
+		if len(rest) > 0 {
+			return cmd.fail(stderr, Usagef("unknown help topic %q", strings.Join(rest, " ")))
+		}
+		cmd.Help(stdout)
+		return ExitOK
+	}
+
+	fs := cmd.flagSet()
+	positional, err := parse(fs, rest)
+	if errors.Is(err, flag.ErrHelp) {
+		cmd.Help(stdout)
+		return ExitOK
+	}
+	if err != nil {
+		return cmd.fail(stderr, Usagef("%v", err))
+	}
+	if cmd.Run == nil {
+		if len(positional) > 0 {
+			return cmd.fail(stderr, cmd.unknown(positional[0]))
+		}
+		cmd.Help(stdout)
+		return ExitOK
+	}
+	if cmd.Args != nil {
+		if err := cmd.Args(cmd, positional); err != nil {
+			return cmd.fail(stderr, err)
+		}
+	}
+	if err := cmd.Run(&Context{Command: cmd, Args: positional, Stdout: stdout, Stderr: stderr}); err != nil {
+		return cmd.fail(stderr, err)
+	}
+	return ExitOK
+}
+
+// resolve walks down from c along the subcommand names in args, skipping
+// the flags that apply on the way, and returns the command and the
+// arguments left for it.
+func (c *Command) resolve(args []string) (*Command, []string) {
+	cmd := c
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return cmd, append(rest, args[i:]...)
+		case strings.HasPrefix(arg, "-") && arg != "-":
+			rest = append(rest, arg)
+			if takesValue(cmd, arg) && i+1 < len(args) {
+				i++
+				rest = append(rest, args[i])
+			}
+		default:
+			sub := cmd.find(arg)
+			if sub == nil {
+				return cmd, append(rest, args[i:]...)
+			}
+			cmd = sub
+		}
+	}
+	return cmd, rest
+}
+
+// takesValue reports whether arg is a flag of cmd that reads the next
+// argument as its value: a flag other than a boolean one, without "=".
+func takesValue(cmd *Command, arg string) bool {
+	name := strings.TrimLeft(arg, "-")
+	if strings.Contains(name, "=") {
+		return false
+	}
+	f := cmd.flagSet().Lookup(name)
+	if f == nil {
+		return false
+	}
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return !ok || !b.IsBoolFlag()
+}
+
+// parse parses flags anywhere among args, returning the positional
+// arguments. Everything after "--" is positional.
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		if n := len(args) - fs.NArg(); n > 0 && args[n-1] == "--" {
+			return append(positional, fs.Args()...), nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// fail reports err and returns its exit code.
+func (c *Command) fail(stderr io.Writer, err error) int {
+	if !errors.Is(err, ErrUsage) {
+		fmt.Fprintf(stderr, "%s: %v\n", c.Path(), err)
+		return ExitError
+	}
+	msg := strings.TrimPrefix(err.Error(), ErrUsage.Error()+": ")
+	fmt.Fprintf(stderr, "%s: %s\nRun '%s -h' for usage.\n", c.Path(), msg, c.Path())
+	return ExitUsage
+}
+
+// unknown is the error for an argument that names no subcommand of c.
+func (c *Command) unknown(name string) error {
+	// Allow a typo or two, fewer in short names: "ad" is close to "add",
+	// but not to "rm".
+	maxDistance := 1 + len(name)/4
+	var close []string
+	for _, sub := range c.Commands() {
+		if distance(name, sub.Name()) <= maxDistance || strings.HasPrefix(sub.Name(), name) {
+			close = append(close, sub.Name())
+		}
+	}
+	if len(close) > 0 {
+		return Usagef("unknown command %q; did you mean %s?", name, strings.Join(close, " or "))
+	}
+	return Usagef("unknown command %q", name)
+}
+
+// distance is the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// Help writes the help of c: its description, usage, subcommands, and
+// flags.
+func (c *Command) Help(w io.Writer) {
+	if desc := c.Long; desc != "" {
+		fmt.Fprintf(w, "%s\n\n", desc)
+	} else if c.Short != "" {
+		fmt.Fprintf(w, "%s\n\n", c.Short)
+	}
+	fmt.Fprintln(w, "Usage:")
+	if c.Run != nil {
+		line := c.Path() + " [flags]"
+		if _, args, ok := strings.Cut(c.Use, " "); ok {
+			line += " " + args
+		}
+		fmt.Fprintf(w, "  %s\n", line)
+	}
+	if len(c.commands) > 0 {
+		fmt.Fprintf(w, "  %s <command>\n", c.Path())
+	}
+	if len(c.Aliases) > 0 {
+		fmt.Fprintf(w, "\nAliases: %s\n", strings.Join(c.Aliases, ", "))
+	}
+
+	if cmds := c.Commands(); len(cmds) > 0 {
+		fmt.Fprintln(w, "\nCommands:")
+		width := 0
+		for _, sub := range cmds {
+			width = max(width, len(sub.Name()))
+		}
+		for _, sub := range cmds {
+			fmt.Fprintf(w, "  %-*s  %s\n", width, sub.Name(), sub.Short)
+		}
+	}
+
+	own := flag.NewFlagSet(c.Path(), flag.ContinueOnError)
+	for _, set := range []*flag.FlagSet{c.flags, c.persistent} {
+		if set != nil {
+			set.VisitAll(func(f *flag.Flag) { addFlag(own, f) })
+		}
+	}
+	printFlags(w, "Flags", own)
+	global := flag.NewFlagSet(c.Path(), flag.ContinueOnError)
+	for _, set := range c.inherited() {
+		set.VisitAll(func(f *flag.Flag) {
+			if own.Lookup(f.Name) == nil && global.Lookup(f.Name) == nil {
+				addFlag(global, f)
+			}
+		})
+	}
+	printFlags(w, "Global flags", global)
+
+	if len(c.commands) > 0 {
+		fmt.Fprintf(w, "\nRun '%s <command> -h' for help on a command.\n", c.Path())
+	}
+}
+
+// printFlags writes the flags of fs, if it has any, under a heading.
+func printFlags(w io.Writer, heading string, fs *flag.FlagSet) {
+	n := 0
+	fs.VisitAll(func(*flag.Flag) { n++ })
+	if n == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s:\n", heading)
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+}
+
+// PositionalArgs checks the positional arguments of a command.
+type PositionalArgs func(cmd *Command, args []string) error
+
+// NoArgs accepts no positional arguments.
+func NoArgs(cmd *Command, args []string) error {
+	if len(args) > 0 {
+		return Usagef("unexpected argument %q", args[0])
+	}
+	return nil
+}
+
+// ExactArgs accepts exactly n positional arguments.
+func ExactArgs(n int) PositionalArgs {
+	return RangeArgs(n, n)
+}
+
+// MinimumArgs accepts at least n positional arguments.
+func MinimumArgs(n int) PositionalArgs {
+	return func(cmd *Command, args []string) error {
+		if len(args) < n {
+			return Usagef("want at least %d arguments, got %d", n, len(args))
+		}
+		return nil
+	}
+}
+
+// RangeArgs accepts between lo and hi positional arguments.
+func RangeArgs(lo, hi int) PositionalArgs {
+	return func(cmd *Command, args []string) error {
+		if len(args) >= lo && len(args) <= hi {
+			return nil
+		}
+		if lo == hi {
+			return Usagef("want %d arguments, got %d", lo, len(args))
+		}
+		return Usagef("want %d to %d arguments, got %d", lo, hi, len(args))
+	}
+}
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testTree returns a tool with a persistent flag, a nested group, and a
+// command that records what it was run with.
+func testTree(ran *[]string) *Command {
+	root := &Command{Use: "tool", Short: "A test tool"}
+	verbose := root.PersistentFlags().Bool("v", false, "verbose output")
+	name := root.PersistentFlags().String("name", "world", "who to greet")
+
+	greet := &Command{
+		Use:     "greet <greeting>...",
+		Aliases: []string{"hi"},
+		Short:   "Greet someone",
+		Args:    MinimumArgs(1),
+		Run: func(ctx *Context) error {
+			*ran = append(*ran, fmt.Sprintf("greet %q v=%t name=%s", ctx.Args, *verbose, *name))
+			fmt.Fprintf(ctx.Stdout, "%s, %s\n", strings.Join(ctx.Args, " "), *name)
+			return nil
+		},
+	}
+	greet.Flags().Bool("loud", false, "shout")
+
+	remote := &Command{Use: "remote", Short: "Manage remotes"}
+	url := remote.PersistentFlags().String("url", "", "remote `URL`")
+	add := &Command{
+		Use:   "add <name>",
+		Short: "Add a remote",
+		Args:  ExactArgs(1),
+		Run: func(ctx *Context) error {
+			*ran = append(*ran, fmt.Sprintf("remote add %s url=%s v=%t", ctx.Args[0], *url, *verbose))
+			if ctx.Args[0] == "bad" {
+				return errors.New("remote exists")
+			}
+			return nil
+		},
+	}
+	remote.AddCommand(add, &Command{Use: "list", Short: "List remotes", Args: NoArgs, Run: func(*Context) error { return nil }})
+	root.AddCommand(remote, greet)
+	return root
+}
+
+func execute(args ...string) (code int, stdout, stderr string, ran []string) {
+	var out, errs strings.Builder
+	code = testTree(&ran).Execute(args, &out, &errs)
+	return code, out.String(), errs.String(), ran
+}
+
+func TestExecute(t *testing.T) {
+	for _, tt := range []struct {
+		args string
+		ran  string
+	}{
+		{"greet hello", `greet ["hello"] v=false name=world`},
+		{"greet -v hello there", `greet ["hello" "there"] v=true name=world`},
+		{"-v greet hello", `greet ["hello"] v=true name=world`},
+		{"-name ann greet hello -v", `greet ["hello"] v=true name=ann`},
+		{"greet hello -name=bo", `greet ["hello"] v=false name=bo`},
+		{"hi -- -v", `greet ["-v"] v=false name=world`},
+		{"remote add origin -url http://x", `remote add origin url=http://x v=false`},
+		{"-v remote -url u add origin", `remote add origin url=u v=true`},
+	} {
+		code, _, stderr, ran := execute(strings.Fields(tt.args)...)
+		assert.Equal(t, ExitOK, code, "%s: %s", tt.args, stderr)
+		assert.Equal(t, []string{tt.ran}, ran, tt.args)
+	}
+}
+
+func TestExecuteErrors(t *testing.T) {
+	for _, tt := range []struct {
+		args   string
+		code   int
+		stderr string
+	}{
+		{"greet", ExitUsage, "tool greet: want at least 1 arguments, got 0\nRun 'tool greet -h' for usage.\n"},
+		{"greet -x hi", ExitUsage, "tool greet: flag provided but not defined: -x\n"},
+		{"remote add", ExitUsage, "tool remote add: want 1 arguments, got 0\n"},
+		{"remote list extra", ExitUsage, `tool remote list: unexpected argument "extra"`},
+		{"remote add bad", ExitError, "tool remote add: remote exists\n"},
+		{"-url u remote add x", ExitUsage, "flag provided but not defined: -url"},
+		{"greet -loud", ExitUsage, "want at least 1 arguments"},
+		{"remote ad x", ExitUsage, `tool remote: unknown command "ad"; did you mean add?`},
+		{"gret", ExitUsage, `tool: unknown command "gret"; did you mean greet?`},
+		{"help nope", ExitUsage, `unknown help topic "nope"`},
+	} {
+		code, stdout, stderr, ran := execute(strings.Fields(tt.args)...)
+		assert.Equal(t, tt.code, code, tt.args)
+		assert.Contains(t, stderr, tt.stderr, tt.args)
+		assert.Empty(t, stdout, tt.args)
+		if tt.code == ExitUsage {
+			assert.Empty(t, ran, "%s: Run is not called", tt.args)
+		}
+	}
+}
+
+func TestHelp(t *testing.T) {
+	for _, args := range []string{"-h", "help", ""} {
+		code, stdout, _, _ := execute(strings.Fields(args)...)
+		assert.Equal(t, ExitOK, code, args)
+		assert.Equal(t, `A test tool
+
+Usage:
+  tool <command>
+
+Commands:
+  greet   Greet someone
+  remote  Manage remotes
+
+Flags:
+  -name string
+    	who to greet (default "world")
+  -v	verbose output
+
+Run 'tool <command> -h' for help on a command.
+`, stdout, "%q", args)
+	}
+
+	code, stdout, _, _ := execute("help", "remote", "add")
+	assert.Equal(t, ExitOK, code)
+	assert.Equal(t, `Add a remote
+
+Usage:
+  tool remote add [flags] <name>
+
+Global flags:
+  -name string
+    	who to greet (default "world")
+  -url URL
+    	remote URL
+  -v	verbose output
+`, stdout)
+
+	_, stdout, _, _ = execute("greet", "-name", "x", "-h")
+	assert.Contains(t, stdout, "Usage:\n  tool greet [flags] <greeting>...\n\nAliases: hi\n")
+	assert.Contains(t, stdout, "Flags:\n  -loud\n    \tshout\n")
+	assert.Contains(t, stdout, `(default "world")`, "defaults stay the defaults")
+}
+
+func TestPath(t *testing.T) {
+	root := testTree(new([]string))
+	remote := root.Commands()[1]
+	add := remote.Commands()[0]
+	assert.Equal(t, "tool remote add", add.Path())
+	assert.Equal(t, "add", add.Name())
+	assert.Same(t, root, remote.Parent())
+	assert.Nil(t, root.Parent())
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, distance("area", "area"))
+	assert.Equal(t, 2, distance("aera", "area"))
+	assert.Equal(t, 3, distance("kitten", "sitting"))
+	assert.Equal(t, 4, distance("", "list"))
+}
@@ -0,0 +1,138 @@
+// Package clone makes deep copies of Go values with reflection: a copy
+// shares no slice, map, or pointer with the original, so that changing one
+// never changes the other.
+//
+//	snapshot := clone.Clone(fleet)
+//	fleet.Retire("KX-482-B")
+//	// snapshot still has the vehicle
+//
+// Pointers, maps, and slices that appear more than once in the original
+// are copied once, and appear as often in the copy, so shared structure
+// stays shared and cycles end. Channels and functions are not data, and
+// are copied as they are.
+//
+// Reflection cannot set unexported fields, so they are copied as they are,
+// shallowly: a *T field of one still points into the original. A type
+// whose unexported fields need copying implements Cloner, as a cache with
+// an unexported map does, and Clone calls its method instead.
+package clone
+
+import "reflect"
+
+// Cloner is implemented by types that copy themselves, with a method
+// Clone() T for their own type T. Clone calls it instead of looking
+// inside the value, for a non-nil value whose method set has it.
+//
+// The method must not call clone.Clone on its receiver, which would call
+// it again; cloning the receiver's fields is fine.
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// Clone returns a deep copy of v.
+func Clone[T any](v T) T {
+	c := cloner{seen: make(map[seenKey]reflect.Value)}
+	var out T // set through reflection, since a nil interface does not convert to T
+	reflect.ValueOf(&out).Elem().Set(c.clone(reflect.ValueOf(&v).Elem()))
+	return out
+}
+
+// seenKey identifies a pointer, map, or slice that has been copied. Two
+// slices of one array are the same only with the same length, as
+// copying one does not copy the other.
+type seenKey struct {
+	typ reflect.Type
+	ptr uintptr
+	len int
+}
+
+type cloner struct {
+	seen map[seenKey]reflect.Value
+}
+
+// clone returns a copy of v, of v's type.
+func (c *cloner) clone(v reflect.Value) reflect.Value {
+	t := v.Type()
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		if v.IsNil() {
+			return reflect.Zero(t)
+		}
+	}
+	var key seenKey
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map:
+		key = seenKey{typ: t, ptr: v.Pointer()}
+	case reflect.Slice:
+		key = seenKey{typ: t, ptr: v.Pointer(), len: v.Len()}
+	}
+	if key.typ != nil {
+		if out, ok := c.seen[key]; ok {
+			return out
+		}
+	}
+	if m, ok := cloneMethod(t); ok {
+		out := v.Method(m.Index).Call(nil)[0]
+		if key.typ != nil {
+			c.seen[key] = out
+		}
+		return out
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		out := reflect.New(t.Elem())
+		c.seen[key] = out // before the copy of what it points to, which may point back
+		out.Elem().Set(c.clone(v.Elem()))
+		return out
+	case reflect.Map:
+		out := reflect.MakeMapWithSize(t, v.Len())
+		c.seen[key] = out
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(c.clone(iter.Key()), c.clone(iter.Value()))
+		}
+		return out
+	case reflect.Slice:
+		out := reflect.MakeSlice(t, v.Len(), v.Len())
+		c.seen[key] = out
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(t).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return out
+	case reflect.Struct:
+		out := reflect.New(t).Elem()
+		out.Set(v) // the unexported fields, as they are
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				out.Field(i).Set(c.clone(v.Field(i)))
+			}
+		}
+		return out
+	case reflect.Interface:
+		out := reflect.New(t).Elem()
+		out.Set(c.clone(v.Elem()))
+		return out
+	}
+	return v // numbers, strings, booleans, channels, and functions
+}
+
+// cloneMethod returns t's Clone method, if it has one that returns a t.
+// An interface type's methods are those of the value inside, which clone
+// looks at separately.
+func cloneMethod(t reflect.Type) (reflect.Method, bool) {
+	if t.Kind() == reflect.Interface {
+		return reflect.Method{}, false
+	}
+	m, ok := t.MethodByName("Clone")
+	if !ok || m.Type.NumIn() != 1 || m.Type.NumOut() != 1 || m.Type.Out(0) != t {
+		return reflect.Method{}, false
+	}
+	return m, true
+}
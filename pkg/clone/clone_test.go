@@ -0,0 +1,157 @@
+package clone
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type vehicle struct {
+	Plate string
+	Tags  []string
+	Specs map[string]float64
+	Owner *person
+	Extra any
+	Built time.Time
+}
+
+type person struct {
+	Name    string
+	Friends []*person
+}
+
+func TestCloneIsEqualAndSeparate(t *testing.T) {
+	owner := &person{Name: "Ada"}
+	v := vehicle{
+		Plate: "KX-482-B",
+		Tags:  []string{"van", "diesel"},
+		Specs: map[string]float64{"length": 5.3},
+		Owner: owner,
+		Extra: []int{1, 2},
+		Built: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	c := Clone(v)
+	require.Equal(t, v, c)
+
+	c.Tags[0] = "car"
+	c.Specs["length"] = 4
+	c.Owner.Name = "Bob"
+	c.Extra.([]int)[0] = 9
+	assert.Equal(t, []string{"van", "diesel"}, v.Tags)
+	assert.Equal(t, 5.3, v.Specs["length"])
+	assert.Equal(t, "Ada", owner.Name)
+	assert.Equal(t, []int{1, 2}, v.Extra)
+}
+
+func TestCloneNils(t *testing.T) {
+	assert.Equal(t, vehicle{}, Clone(vehicle{}))
+	var p *person
+	assert.Nil(t, Clone(p))
+	var err error
+	assert.Nil(t, Clone(err))
+	var tags []string
+	assert.Nil(t, Clone(tags))
+	assert.NotNil(t, Clone([]string{}), "an empty slice is not nil")
+	assert.Equal(t, 42, Clone(42))
+	assert.Equal(t, errors.New("x"), Clone(errors.New("x")))
+}
+
+func TestCloneCycles(t *testing.T) {
+	ada := &person{Name: "Ada"}
+	bob := &person{Name: "Bob", Friends: []*person{ada}}
+	ada.Friends = []*person{bob, ada}
+
+	c := Clone(ada)
+	require.NotSame(t, ada, c)
+	assert.Same(t, c, c.Friends[1], "a pointer to itself stays one")
+	assert.Same(t, c, c.Friends[0].Friends[0])
+	assert.Equal(t, "Bob", c.Friends[0].Name)
+	assert.NotSame(t, bob, c.Friends[0])
+
+	self := map[string]any{}
+	self["self"] = self
+	cm := Clone(self)
+	cm["x"] = 1
+	assert.NotContains(t, self, "x")
+	assert.Contains(t, cm["self"], "x", "the map inside is the copy itself")
+}
+
+func TestCloneSharing(t *testing.T) {
+	shared := &person{Name: "Ada"}
+	pair := [2]*person{shared, shared}
+	c := Clone(pair)
+	assert.Same(t, c[0], c[1])
+	assert.NotSame(t, shared, c[0])
+
+	tags := []string{"a", "b", "c"}
+	both := struct{ All, Head []string }{tags, tags[:2]}
+	cb := Clone(both)
+	cb.All[0] = "z"
+	assert.Equal(t, "a", cb.Head[0], "slices of different lengths are copied separately")
+	assert.Equal(t, "a", tags[0])
+}
+
+// counter copies itself, since Clone cannot reach its unexported fields.
+type counter struct {
+	mu     sync.Mutex
+	counts map[string]int
+	copies *int
+}
+
+func (c *counter) Clone() *counter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*c.copies++
+	counts := make(map[string]int, len(c.counts))
+	for k, n := range c.counts {
+		counts[k] = n
+	}
+	return &counter{counts: counts, copies: c.copies}
+}
+
+func TestCloner(t *testing.T) {
+	copies := 0
+	c := &counter{counts: map[string]int{"a": 1}, copies: &copies}
+	holder := struct {
+		Main   *counter
+		ByName map[string]*counter
+	}{c, map[string]*counter{"main": c}}
+
+	h := Clone(holder)
+	assert.Equal(t, 1, copies, "Clone is called once for the counter it meets twice")
+	assert.Same(t, h.Main, h.ByName["main"])
+	h.Main.counts["a"] = 5
+	assert.Equal(t, 1, c.counts["a"])
+
+	var none *counter
+	assert.Nil(t, Clone(none), "Clone is not called on nil")
+	assert.Equal(t, 1, copies)
+}
+
+func TestUnexportedFieldsAreShallow(t *testing.T) {
+	type wrapper struct {
+		Public  []int
+		private []int
+	}
+	w := wrapper{Public: []int{1}, private: []int{2}}
+	c := Clone(w)
+	c.Public[0] = 10
+	c.private[0] = 20
+	assert.Equal(t, 1, w.Public[0])
+	assert.Equal(t, 20, w.private[0], "reflection cannot copy unexported fields; implement Cloner")
+}
+
+func TestChannelsAndFuncs(t *testing.T) {
+	ch := make(chan int)
+	f := func() int { return 7 }
+	c := Clone(struct {
+		C chan int
+		F func() int
+	}{ch, f})
+	assert.Equal(t, ch, c.C)
+	assert.Equal(t, 7, c.F())
+}
@@ -0,0 +1,270 @@
+// Package course discovers the modules and exercises that make up the
+// course by reading the modules/ directory, so tools never need a
+// hand-maintained list that drifts out of date.
+package course
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when a module or exercise does not exist.
+var ErrNotFound = errors.New("not found")
+
+// Course is the set of modules under a repository root.
+type Course struct {
+	Root    string
+	Title   string // from the ManifestFile, if any
+	Modules []*Module
+}
+
+// Module is one modules/NN-topic directory, or a module from a Provider.
+type Module struct {
+	ID           string // directory name, e.g. "01-basics"
+	Number       int    // 1 for "01-basics"
+	Title        string // from the README heading, e.g. "Go Basics for Experienced Developers"
+	Dir          string
+	Exercises    []*Exercise
+	Requires     []string    // IDs of the modules to finish first, from MetaFile
+	Flashcards   []Flashcard // terminology cards, from MetaFile
+	Browser      bool        // the exercises can run in a browser, from MetaFile
+	Race         bool        // the exercises are graded with the race detector, from MetaFile
+	RaceRequired bool        // the exercises fail where the race detector is missing, rather than be graded without it, from MetaFile
+	Parallel     bool        // the exercises' tests are graded in parallel and shuffled, from MetaFile
+	Staged       bool        // the exercises are milestones, each passing only after those before it, from MetaFile
+	Apps         []string    // directories of programs for a web page, from MetaFile
+	Provider     string      // name of the Provider it came from; empty for the course's own
+
+	// From the ManifestFile, if the course has one.
+	Description string
+	Objectives  []string
+	Estimate    time.Duration // time to work through the module; 0 if unknown
+}
+
+// Exercise is one exercises/*.go file (tests excluded), along with the
+// files whose names extend its own, such as the platform-specific
+// exercise1_paths_windows.go of exercise1_paths.go.
+type Exercise struct {
+	ID     string // "<module>/<name>", e.g. "01-basics/exercise1_fix_bugs"
+	Module string // module ID
+	Name   string // file name without ".go"
+	File   string // absolute path to the exercise file
+
+	// From the module's MetaFile, if it lists the exercise.
+	Concepts   []string    // e.g. "recursion", "generics"
+	Difficulty int         // Easy, Medium, Hard, or Unrated
+	Benchmarks []Benchmark // sorted by name
+}
+
+// FindRoot walks up from start until it finds a directory containing both
+// go.mod and modules/, the layout of this repository.
+func FindRoot(start string) (string, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if isFile(filepath.Join(dir, "go.mod")) && isDir(filepath.Join(dir, "modules")) {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no course root (go.mod and modules/) above %s", start)
+		}
+		dir = parent
+	}
+}
+
+// Load reads the modules under root/modules: those the ManifestFile lists,
+// in its order, or every one in ID order if there is no manifest.
+func Load(root string) (*Course, error) {
+	return LoadWith(root)
+}
+
+// LoadWith is Load plus the modules of the providers, which follow the
+// course's own in ID order.
+func LoadWith(root string, providers ...Provider) (*Course, error) {
+	c := &Course{Root: root}
+	manifest, err := c.loadManifestModules()
+	if err != nil {
+		return nil, err
+	}
+	if !manifest {
+		if err := c.scanModules(); err != nil {
+			return nil, err
+		}
+	}
+	own := len(c.Modules)
+	if err := c.addProviders(providers); err != nil {
+		return nil, err
+	}
+	provided := c.Modules[own:]
+	sort.Slice(provided, func(i, j int) bool {
+		return provided[i].ID < provided[j].ID
+	})
+	if err := c.resolveRequires(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// scanModules loads every directory under modules/, in ID order.
+func (c *Course) scanModules() error {
+	entries, err := os.ReadDir(filepath.Join(c.Root, "modules"))
+	if err != nil {
+		return fmt.Errorf("reading modules: %w", err)
+	}
+	for _, e := range entries { // sorted by name
+		if !e.IsDir() {
+			continue
+		}
+		m, err := loadModule(filepath.Join(c.Root, "modules", e.Name()))
+		if err != nil {
+			return err
+		}
+		c.Modules = append(c.Modules, m)
+	}
+	return nil
+}
+
+func loadModule(dir string) (*Module, error) {
+	id := filepath.Base(dir)
+	m := &Module{ID: id, Dir: dir, Title: id}
+	if prefix, _, ok := strings.Cut(id, "-"); ok {
+		m.Number, _ = strconv.Atoi(prefix)
+	}
+	if title, err := readTitle(filepath.Join(dir, "README.md")); err == nil && title != "" {
+		m.Title = title
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "exercises", "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		if strings.HasSuffix(f, "_test.go") || Generated(f) {
+			continue
+		}
+		name := strings.TrimSuffix(filepath.Base(f), ".go")
+		// exercise1_paths_windows.go is part of exercise1_paths.go, which
+		// sorts just before it.
+		if n := len(m.Exercises); n > 0 && strings.HasPrefix(name, m.Exercises[n-1].Name+"_") {
+			continue
+		}
+		m.Exercises = append(m.Exercises, &Exercise{
+			ID:     id + "/" + name,
+			Module: id,
+			Name:   name,
+			File:   f,
+		})
+	}
+	if err := loadMeta(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// generatedHeader matches the comment that marks a generated Go file.
+var generatedHeader = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// Generated reports whether the Go file at path is marked as written by a
+// generator, such as the String methods "learngo generate" writes: it
+// belongs to an exercise or example, and is not one of its own. The mark
+// is a comment line before the package clause.
+func Generated(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if generatedHeader.MatchString(line) {
+			return true
+		}
+		if strings.HasPrefix(line, "package ") {
+			return false
+		}
+	}
+	return false
+}
+
+// readTitle returns the first Markdown heading of a README with any
+// "Module NN:" prefix removed.
+func readTitle(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if !strings.HasPrefix(line, "# ") {
+			continue
+		}
+		title := strings.TrimPrefix(line, "# ")
+		if _, rest, ok := strings.Cut(title, ": "); ok && strings.HasPrefix(title, "Module ") {
+			title = rest
+		}
+		return title, nil
+	}
+	return "", sc.Err()
+}
+
+// Module finds a module by ID ("01-basics"), number ("01" or "1"), or topic
+// ("basics").
+func (c *Course) Module(name string) (*Module, error) {
+	n, numErr := strconv.Atoi(name)
+	for _, m := range c.Modules {
+		if m.ID == name || (numErr == nil && m.Number == n) || strings.TrimLeft(m.ID, "0123456789-") == name {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("module %q: %w", name, ErrNotFound)
+}
+
+// Exercise finds an exercise by its full ID.
+func (c *Course) Exercise(id string) (*Exercise, error) {
+	moduleID, _, _ := strings.Cut(id, "/")
+	m, err := c.Module(moduleID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range m.Exercises {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("exercise %q: %w", id, ErrNotFound)
+}
+
+// Exercises returns every exercise in module order.
+func (c *Course) Exercises() []*Exercise {
+	var all []*Exercise
+	for _, m := range c.Modules {
+		all = append(all, m.Exercises...)
+	}
+	return all
+}
+
+func isFile(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode().IsRegular()
+}
+
+func isDir(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
@@ -0,0 +1,117 @@
+package course
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles creates each path (relative to root) with the given content.
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func testCourse(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":                      "module example.com/course\n",
+		"modules/01-basics/README.md": "# Module 01: Go Basics\n\nIntro.\n",
+		"modules/01-basics/exercises/exercise1.go":      "package exercises\n",
+		"modules/01-basics/exercises/exercise1_test.go": "package exercises\n",
+		"modules/01-basics/exercises/exercise2.go":      "package exercises\n",
+		"modules/01-basics/exercises/exercise2_unix.go": "package exercises\n",
+		"modules/01-basics/exercises/level_string.go":   "// Code generated by \"enumgen -type=Level\"; DO NOT EDIT.\n\npackage exercises\n",
+		"modules/02-types/README.md":                    "Intro without heading\n",
+		"modules/notes.txt":                             "not a module\n",
+	})
+	return root
+}
+
+func TestLoad(t *testing.T) {
+	root := testCourse(t)
+	c, err := Load(root)
+	require.NoError(t, err)
+	require.Len(t, c.Modules, 2)
+
+	basics := c.Modules[0]
+	assert.Equal(t, "01-basics", basics.ID)
+	assert.Equal(t, 1, basics.Number)
+	assert.Equal(t, "Go Basics", basics.Title)
+	require.Len(t, basics.Exercises, 2, "test files, parts of exercises, and generated files are not exercises")
+	assert.Equal(t, "01-basics/exercise1", basics.Exercises[0].ID)
+	assert.Equal(t, filepath.Join(root, "modules/01-basics/exercises/exercise1.go"), basics.Exercises[0].File)
+
+	types := c.Modules[1]
+	assert.Equal(t, "02-types", types.Title, "falls back to the directory name")
+	assert.Empty(t, types.Exercises)
+	assert.Len(t, c.Exercises(), 2)
+}
+
+func TestGenerated(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"gen.go":   "// Code generated by stringer; DO NOT EDIT.\n\npackage x\n",
+		"late.go":  "// Copyright 2024\n\n// Code generated by hand. DO NOT EDIT.\npackage x\n",
+		"body.go":  "package x\n\n// Code generated by nothing. DO NOT EDIT.\n",
+		"lower.go": "// Code generated by stringer; do not edit.\npackage x\n",
+		"plain.go": "package x\n",
+	})
+	assert.True(t, Generated(filepath.Join(root, "gen.go")))
+	assert.True(t, Generated(filepath.Join(root, "late.go")))
+	assert.False(t, Generated(filepath.Join(root, "body.go")), "after the package clause")
+	assert.False(t, Generated(filepath.Join(root, "lower.go")))
+	assert.False(t, Generated(filepath.Join(root, "plain.go")))
+	assert.False(t, Generated(filepath.Join(root, "missing.go")))
+}
+
+func TestFindRoot(t *testing.T) {
+	root := testCourse(t)
+	got, err := FindRoot(filepath.Join(root, "modules/01-basics/exercises"))
+	require.NoError(t, err)
+	assert.Equal(t, root, got)
+
+	_, err = FindRoot(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestLookup(t *testing.T) {
+	c, err := Load(testCourse(t))
+	require.NoError(t, err)
+
+	for _, name := range []string{"01-basics", "01", "1", "basics"} {
+		m, err := c.Module(name)
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, "01-basics", m.ID)
+		}
+	}
+	_, err = c.Module("99")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	e, err := c.Exercise("01-basics/exercise2")
+	require.NoError(t, err)
+	assert.Equal(t, "exercise2", e.Name)
+	_, err = c.Exercise("01-basics/missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLoadRepository(t *testing.T) {
+	root, err := FindRoot(".")
+	require.NoError(t, err)
+	c, err := Load(root)
+	require.NoError(t, err)
+
+	m, err := c.Module("01")
+	require.NoError(t, err)
+	assert.Equal(t, "Go Basics for Experienced Developers", m.Title)
+	_, err = c.Exercise("01-basics/exercise1_fix_bugs")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,80 @@
+package course
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"strings"
+)
+
+// Doc is the documentation of one exercise file: the free-standing
+// "EXERCISE:" (or "SOLUTION:") comment at the top, and the doc comment of
+// every top-level function and type, in source order.
+type Doc struct {
+	Intro string
+	Decls []DeclDoc
+}
+
+// DeclDoc documents one declaration.
+type DeclDoc struct {
+	Name      string
+	Signature string // e.g. "func Merge[K comparable, V any](dst, src map[K]V, ...)"
+	Doc       string
+}
+
+// Doc parses the exercise file and returns its documentation.
+func (e *Exercise) Doc() (*Doc, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, e.File, nil, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Doc{}
+	for _, cg := range f.Comments {
+		text := cg.Text()
+		if strings.HasPrefix(text, "EXERCISE:") || strings.HasPrefix(text, "SOLUTION:") {
+			d.Intro = text
+			break
+		}
+	}
+
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			sig := *decl
+			sig.Body, sig.Doc = nil, nil
+			d.Decls = append(d.Decls, DeclDoc{
+				Name:      decl.Name.Name,
+				Signature: nodeString(fset, &sig),
+				Doc:       decl.Doc.Text(),
+			})
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				ts := spec.(*ast.TypeSpec)
+				doc := ts.Doc.Text()
+				if doc == "" && len(decl.Specs) == 1 {
+					doc = decl.Doc.Text()
+				}
+				d.Decls = append(d.Decls, DeclDoc{
+					Name:      ts.Name.Name,
+					Signature: "type " + nodeString(fset, ts),
+					Doc:       doc,
+				})
+			}
+		}
+	}
+	return d, nil
+}
+
+func nodeString(fset *token.FileSet, node any) string {
+	var sb strings.Builder
+	if err := printer.Fprint(&sb, fset, node); err != nil {
+		return ""
+	}
+	return sb.String()
+}
@@ -0,0 +1,57 @@
+package course
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const docSource = `package exercises
+
+// EXERCISE: Fix the stack.
+// It has bugs.
+
+import "fmt"
+
+// Stack is a LIFO stack.
+type Stack struct {
+	items []int
+}
+
+// Push adds v.
+// BUG: Drops v.
+func (s *Stack) Push(v int) {
+	fmt.Println(v)
+}
+
+func helper() {}
+`
+
+func TestExerciseDoc(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":                             "module example.com/course\n",
+		"modules/01-demo/exercises/stack.go": docSource,
+	})
+	c, err := Load(root)
+	require.NoError(t, err)
+	e, err := c.Exercise("01-demo/stack")
+	require.NoError(t, err)
+
+	d, err := e.Doc()
+	require.NoError(t, err)
+	assert.Equal(t, "EXERCISE: Fix the stack.\nIt has bugs.\n", d.Intro)
+	require.Len(t, d.Decls, 3)
+
+	assert.Equal(t, "Stack", d.Decls[0].Name)
+	assert.Equal(t, "Stack is a LIFO stack.\n", d.Decls[0].Doc)
+	assert.Contains(t, d.Decls[0].Signature, "type Stack struct")
+
+	assert.Equal(t, "Push", d.Decls[1].Name)
+	assert.Equal(t, "func (s *Stack) Push(v int)", d.Decls[1].Signature)
+	assert.Equal(t, "Push adds v.\nBUG: Drops v.\n", d.Decls[1].Doc)
+
+	assert.Equal(t, "helper", d.Decls[2].Name)
+	assert.Empty(t, d.Decls[2].Doc)
+}
@@ -0,0 +1,157 @@
+package course
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile is the optional course manifest at the course root. When it
+// exists it decides which modules make up the course and in what order,
+// instead of every directory under modules/ in ID order, and it describes
+// them beyond what a README heading can:
+//
+//	title: Learning Go The Hard Way
+//	modules:
+//	  - id: 01-basics
+//	    title: Go Basics for Experienced Developers   # default: the README heading
+//	    description: Syntax, types, and control flow, compared with other languages.
+//	    objectives:
+//	      - Master Go's type system and zero values
+//	    estimated_time: 4h
+//	    exercises: [exercise1_fix_bugs, exercise2_strings_slices]
+//
+// Every module directory must be listed, and every listed module must
+// exist. exercises, if present, gives the order of the module's exercises
+// and must name each of them exactly once; without it they are found by
+// scanning the exercises/ directory.
+const ManifestFile = "course.yaml"
+
+// Manifest is the parsed ManifestFile.
+type Manifest struct {
+	Title   string           `yaml:"title"`
+	Modules []ModuleManifest `yaml:"modules"`
+}
+
+// ModuleManifest describes one module in the Manifest.
+type ModuleManifest struct {
+	ID            string   `yaml:"id"`
+	Title         string   `yaml:"title"`
+	Description   string   `yaml:"description"`
+	Objectives    []string `yaml:"objectives"`
+	EstimatedTime string   `yaml:"estimated_time"` // a time.Duration, e.g. "90m" or "4h"
+	Exercises     []string `yaml:"exercises"`
+}
+
+// LoadManifest reads the manifest at path. It returns an error wrapping
+// fs.ErrNotExist if there is none.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true) // catch misspelled keys
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	seen := make(map[string]bool)
+	for i, mm := range m.Modules {
+		switch {
+		case mm.ID == "":
+			return nil, fmt.Errorf("%s: module %d has no id", path, i+1)
+		case seen[mm.ID]:
+			return nil, fmt.Errorf("%s: module %s is listed twice", path, mm.ID)
+		}
+		seen[mm.ID] = true
+		if mm.EstimatedTime != "" {
+			if _, err := time.ParseDuration(mm.EstimatedTime); err != nil {
+				return nil, fmt.Errorf("%s: module %s: estimated_time: %w", path, mm.ID, err)
+			}
+		}
+	}
+	return &m, nil
+}
+
+// loadManifestModules loads the modules the course's manifest lists, in
+// its order. ok is false if the course has no manifest.
+func (c *Course) loadManifestModules() (ok bool, err error) {
+	path := filepath.Join(c.Root, ManifestFile)
+	man, err := LoadManifest(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	c.Title = man.Title
+
+	listed := make(map[string]bool)
+	for _, mm := range man.Modules {
+		listed[mm.ID] = true
+		dir := filepath.Join(c.Root, "modules", mm.ID)
+		if !isDir(dir) {
+			return false, fmt.Errorf("%s: module %s: no directory %s", path, mm.ID, dir)
+		}
+		m, err := loadModule(dir)
+		if err != nil {
+			return false, err
+		}
+		if err := applyManifest(m, mm); err != nil {
+			return false, fmt.Errorf("%s: module %s: %w", path, mm.ID, err)
+		}
+		c.Modules = append(c.Modules, m)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(c.Root, "modules"))
+	if err != nil {
+		return false, fmt.Errorf("reading modules: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() && !listed[e.Name()] {
+			return false, fmt.Errorf("%s: module %s is not listed", path, e.Name())
+		}
+	}
+	return true, nil
+}
+
+func applyManifest(m *Module, mm ModuleManifest) error {
+	if mm.Title != "" {
+		m.Title = mm.Title
+	}
+	m.Description = strings.TrimSpace(mm.Description)
+	m.Objectives = mm.Objectives
+	m.Estimate, _ = time.ParseDuration(mm.EstimatedTime) // validated by LoadManifest
+	if mm.Exercises == nil {
+		return nil
+	}
+
+	byName := make(map[string]*Exercise, len(m.Exercises))
+	for _, e := range m.Exercises {
+		byName[e.Name] = e
+	}
+	ordered := make([]*Exercise, 0, len(mm.Exercises))
+	for _, name := range mm.Exercises {
+		e, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("exercise %q does not exist or is listed twice", name)
+		}
+		delete(byName, name)
+		ordered = append(ordered, e)
+	}
+	for _, e := range m.Exercises {
+		if _, ok := byName[e.Name]; ok {
+			return fmt.Errorf("exercise %q is not listed", e.Name)
+		}
+	}
+	m.Exercises = ordered
+	return nil
+}
@@ -0,0 +1,89 @@
+package course
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `title: Test Course
+modules:
+  - id: 02-types
+    title: Types First
+    description: |
+      Types before basics,
+      to check the order.
+    objectives: [Use structs, Use interfaces]
+    estimated_time: 90m
+  - id: 01-basics
+    exercises: [exercise2, exercise1]
+`
+
+func TestLoadWithManifest(t *testing.T) {
+	root := testCourse(t)
+	writeFiles(t, root, map[string]string{ManifestFile: testManifest})
+	c, err := Load(root)
+	require.NoError(t, err)
+	assert.Equal(t, "Test Course", c.Title)
+	require.Len(t, c.Modules, 2)
+
+	types, basics := c.Modules[0], c.Modules[1]
+	assert.Equal(t, "02-types", types.ID, "manifest order")
+	assert.Equal(t, "Types First", types.Title)
+	assert.Equal(t, "Types before basics,\nto check the order.", types.Description)
+	assert.Equal(t, []string{"Use structs", "Use interfaces"}, types.Objectives)
+	assert.Equal(t, 90*time.Minute, types.Estimate)
+
+	assert.Equal(t, "Go Basics", basics.Title, "README heading by default")
+	assert.Zero(t, basics.Estimate)
+	require.Len(t, basics.Exercises, 2)
+	assert.Equal(t, "01-basics/exercise2", basics.Exercises[0].ID, "manifest exercise order")
+}
+
+func TestLoadWithManifestErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     string
+	}{
+		{"unknown key", "modules:\n  - id: 01-basics\n    objective: [x]\n", "field objective not found"},
+		{"no id", "modules:\n  - title: Basics\n", "module 1 has no id"},
+		{"listed twice", "modules:\n  - id: 01-basics\n  - id: 01-basics\n", "module 01-basics is listed twice"},
+		{"bad estimate", "modules:\n  - id: 01-basics\n    estimated_time: a while\n", "estimated_time"},
+		{"missing module", "modules:\n  - id: 01-basics\n  - id: 02-types\n  - id: 03-gone\n", "no directory"},
+		{"unlisted module", "modules:\n  - id: 01-basics\n", "module 02-types is not listed"},
+		{"missing exercise", "modules:\n  - id: 01-basics\n    exercises: [exercise1, exercise2, exercise3]\n  - id: 02-types\n", `exercise "exercise3" does not exist`},
+		{"duplicate exercise", "modules:\n  - id: 01-basics\n    exercises: [exercise1, exercise1, exercise2]\n  - id: 02-types\n", `"exercise1" does not exist or is listed twice`},
+		{"unlisted exercise", "modules:\n  - id: 01-basics\n    exercises: [exercise1]\n  - id: 02-types\n", `exercise "exercise2" is not listed`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := testCourse(t)
+			writeFiles(t, root, map[string]string{ManifestFile: tt.manifest})
+			_, err := Load(root)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestLoadManifestMissing(t *testing.T) {
+	_, err := LoadManifest(filepath.Join(t.TempDir(), ManifestFile))
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestRepositoryManifest(t *testing.T) {
+	root, err := FindRoot(".")
+	require.NoError(t, err)
+	c, err := Load(root)
+	require.NoError(t, err, "every module and exercise must be listed in %s", ManifestFile)
+	assert.NotEmpty(t, c.Title)
+	for _, m := range c.Modules {
+		assert.NotEmpty(t, m.Description, "%s has no description in %s", m.ID, ManifestFile)
+		assert.NotEmpty(t, m.Objectives, "%s has no objectives in %s", m.ID, ManifestFile)
+		assert.NotZero(t, m.Estimate, "%s has no estimated_time in %s", m.ID, ManifestFile)
+	}
+}
@@ -0,0 +1,286 @@
+package course
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MetaFile is the optional metadata file in a module directory. It lists
+// the modules to finish first, tags exercises with the concepts they
+// practice, how hard they are, and the benchmarks they must be fast
+// enough in, holds flashcards on the module's
+// terminology, says whether the exercises can run in a browser or must
+// run with the race detector or in parallel, and lists WebAssembly
+// programs that come with a web page:
+//
+//	{
+//	  "requires": ["01-basics"],
+//	  "browser": true,
+//	  "race": true,
+//	  "race_required": true,
+//	  "parallel": true,
+//	  "staged": true,
+//	  "apps": ["examples/calculator"],
+//	  "exercises": {
+//	    "exercise1_fix_bugs": {"concepts": ["functions", "maps"], "difficulty": 1},
+//	    "exercise2_search": {"concepts": ["searching"], "difficulty": 2, "benchmarks": {
+//	      "BenchmarkSearch": {"max_ns_op": 1000, "max_allocs_op": 0, "tolerance": 0.5}
+//	    }}
+//	  },
+//	  "flashcards": [
+//	    {"front": "What is the zero value of a map?", "back": "nil"}
+//	  ]
+//	}
+//
+// requires accepts any name Course.Module does. Exercises missing from the
+// file have no concepts and difficulty 0. Only modules whose exercises and
+// tests are pure Go, using no files, network, or processes, should set
+// browser: their tests are compiled to WebAssembly for the dashboard.
+// Each of apps is a directory of the module, slash-separated, holding a
+// main package for GOOS=js and the AppPage that loads it; the dashboard
+// builds and serves them. Modules about concurrency set race, so that
+// their exercises are always graded with -race: a data race fails them
+// even when every assertion passes. Where the detector is missing, such
+// as without cgo, they are graded without it; a module whose bugs only
+// the detector can see sets race_required as well, which implies race,
+// and then its exercises fail there instead. Modules about test
+// isolation set parallel as well: their tests call t.Parallel, and are
+// graded with many of them at once and in a random order, so that tests
+// sharing state fail however few CPUs the machine has. A project built
+// in milestones, such as a capstone, sets staged: each exercise is a
+// milestone that uses the code of those before it, and passes only once
+// they all pass. An exercise's benchmarks are named as go test -bench
+// prints them, without the GOMAXPROCS suffix, and each sets max_ns_op,
+// max_allocs_op, or both; see Benchmark.
+const MetaFile = "module.json"
+
+// AppPage is the web page in each directory of a module's apps.
+const AppPage = "index.html"
+
+// Difficulty levels for Exercise.Difficulty.
+const (
+	Unrated = iota
+	Easy
+	Medium
+	Hard
+)
+
+type moduleMeta struct {
+	Requires     []string                `json:"requires"`
+	Browser      bool                    `json:"browser"`
+	Race         bool                    `json:"race"`
+	RaceRequired bool                    `json:"race_required"`
+	Parallel     bool                    `json:"parallel"`
+	Staged       bool                    `json:"staged"`
+	Apps         []string                `json:"apps"`
+	Exercises    map[string]exerciseMeta `json:"exercises"`
+	Flashcards   []Flashcard             `json:"flashcards"`
+}
+
+// Flashcard is a question and its answer, for drilling a module's
+// terminology away from the keyboard.
+type Flashcard struct {
+	Front string `json:"front"`
+	Back  string `json:"back"`
+}
+
+type exerciseMeta struct {
+	Concepts   []string             `json:"concepts"`
+	Difficulty int                  `json:"difficulty"`
+	Benchmarks map[string]benchMeta `json:"benchmarks"`
+}
+
+type benchMeta struct {
+	MaxNsPerOp     float64  `json:"max_ns_op"`
+	MaxAllocsPerOp *int64   `json:"max_allocs_op"` // 0 is a limit, so absent is nil
+	Tolerance      *float64 `json:"tolerance"`
+}
+
+// DefaultTolerance is the Benchmark.Tolerance of benchmarks that do not
+// set one: a machine half as fast again as the author's still passes.
+const DefaultTolerance = 0.5
+
+// Benchmark is a performance threshold that an exercise must meet, on top
+// of passing its tests.
+type Benchmark struct {
+	Name           string  // e.g. "BenchmarkSearch" or "BenchmarkSearch/small"
+	MaxNsPerOp     float64 // 0 for no limit
+	MaxAllocsPerOp int64   // -1 for no limit
+	// Tolerance is how far over MaxNsPerOp a result may be, as a fraction
+	// of it, since timings depend on the machine. Allocations do not, and
+	// have none.
+	Tolerance float64
+}
+
+// NsLimit returns the most ns/op that passes, tolerance included.
+func (b Benchmark) NsLimit() float64 {
+	return b.MaxNsPerOp * (1 + b.Tolerance)
+}
+
+// loadMeta applies the module's MetaFile, if any, to its exercises. The
+// prerequisites are kept as written until resolveRequires sees every
+// module.
+func loadMeta(m *Module) error {
+	path := filepath.Join(m.Dir, MetaFile)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var meta moduleMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	byName := make(map[string]*Exercise, len(m.Exercises))
+	for _, e := range m.Exercises {
+		byName[e.Name] = e
+	}
+	for name, em := range meta.Exercises {
+		e, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("%s: unknown exercise %q", path, name)
+		}
+		if em.Difficulty < Unrated || em.Difficulty > Hard {
+			return fmt.Errorf("%s: exercise %q: difficulty %d is not between %d and %d", path, name, em.Difficulty, Unrated, Hard)
+		}
+		e.Concepts = em.Concepts
+		e.Difficulty = em.Difficulty
+		e.Benchmarks = nil
+		for bench, bm := range em.Benchmarks {
+			b, err := bm.benchmark(bench)
+			if err != nil {
+				return fmt.Errorf("%s: exercise %q: %w", path, name, err)
+			}
+			e.Benchmarks = append(e.Benchmarks, b)
+		}
+		sort.Slice(e.Benchmarks, func(i, j int) bool { return e.Benchmarks[i].Name < e.Benchmarks[j].Name })
+	}
+	for i, card := range meta.Flashcards {
+		if strings.TrimSpace(card.Front) == "" || strings.TrimSpace(card.Back) == "" {
+			return fmt.Errorf("%s: flashcard %d: front and back must not be empty", path, i+1)
+		}
+	}
+	for _, app := range meta.Apps {
+		if !fs.ValidPath(app) || app == "." {
+			return fmt.Errorf("%s: app %q is not a directory of the module", path, app)
+		}
+		if _, err := os.Stat(filepath.Join(m.Dir, filepath.FromSlash(app), AppPage)); err != nil {
+			return fmt.Errorf("%s: app %q: %w", path, app, err)
+		}
+	}
+	m.Requires = meta.Requires
+	m.Browser = meta.Browser
+	m.Race = meta.Race || meta.RaceRequired
+	m.RaceRequired = meta.RaceRequired
+	m.Parallel = meta.Parallel
+	m.Staged = meta.Staged
+	m.Apps = meta.Apps
+	m.Flashcards = meta.Flashcards
+	return nil
+}
+
+// benchmark validates the thresholds of the benchmark called name.
+func (bm benchMeta) benchmark(name string) (Benchmark, error) {
+	b := Benchmark{Name: name, MaxNsPerOp: bm.MaxNsPerOp, MaxAllocsPerOp: -1, Tolerance: DefaultTolerance}
+	if !strings.HasPrefix(name, "Benchmark") {
+		return b, fmt.Errorf("benchmark %q: name does not start with Benchmark", name)
+	}
+	if bm.MaxAllocsPerOp != nil {
+		b.MaxAllocsPerOp = *bm.MaxAllocsPerOp
+	}
+	if bm.Tolerance != nil {
+		b.Tolerance = *bm.Tolerance
+	}
+	switch {
+	case b.MaxNsPerOp < 0 || b.MaxAllocsPerOp < -1 || b.Tolerance < 0:
+		return b, fmt.Errorf("benchmark %q: thresholds must not be negative", name)
+	case b.MaxNsPerOp == 0 && b.MaxAllocsPerOp < 0:
+		return b, fmt.Errorf("benchmark %q: sets neither max_ns_op nor max_allocs_op", name)
+	}
+	return b, nil
+}
+
+// resolveRequires turns each module's prerequisites into module IDs and
+// rejects cycles.
+func (c *Course) resolveRequires() error {
+	for _, m := range c.Modules {
+		for i, name := range m.Requires {
+			req, err := c.Module(name)
+			if err != nil {
+				return fmt.Errorf("%s: requires: %w", filepath.Join(m.Dir, MetaFile), err)
+			}
+			if req == m {
+				return fmt.Errorf("%s: module requires itself", filepath.Join(m.Dir, MetaFile))
+			}
+			m.Requires[i] = req.ID
+		}
+	}
+	_, err := c.Order()
+	return err
+}
+
+// Prerequisites returns the modules m requires directly.
+func (c *Course) Prerequisites(m *Module) []*Module {
+	var reqs []*Module
+	for _, id := range m.Requires {
+		if req, err := c.Module(id); err == nil {
+			reqs = append(reqs, req)
+		}
+	}
+	return reqs
+}
+
+// Order returns the modules in course order, except that a module's
+// prerequisites are moved ahead of it. It fails if the prerequisites form
+// a cycle.
+func (c *Course) Order() ([]*Module, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[*Module]int)
+	var order []*Module
+	var visit func(m *Module, path []string) error
+	visit = func(m *Module, path []string) error {
+		switch state[m] {
+		case visiting:
+			return fmt.Errorf("module prerequisites form a cycle: %s", strings.Join(append(path, m.ID), " -> "))
+		case visited:
+			return nil
+		}
+		state[m] = visiting
+		for _, req := range c.Prerequisites(m) {
+			if err := visit(req, append(path, m.ID)); err != nil {
+				return err
+			}
+		}
+		state[m] = visited
+		order = append(order, m)
+		return nil
+	}
+	for _, m := range c.Modules {
+		if err := visit(m, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// HasConcept reports whether the exercise is tagged with concept.
+func (e *Exercise) HasConcept(concept string) bool {
+	for _, c := range e.Concepts {
+		if c == concept {
+			return true
+		}
+	}
+	return false
+}
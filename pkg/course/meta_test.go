@@ -0,0 +1,145 @@
+package course
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMeta(t *testing.T) {
+	root := testCourse(t)
+	writeFiles(t, root, map[string]string{
+		"modules/01-basics/" + MetaFile:               `{"browser": true, "race_required": true, "parallel": true, "staged": true, "apps": ["examples/hello"], "exercises": {"exercise2": {"concepts": ["slices", "maps"], "difficulty": 2}}}`,
+		"modules/01-basics/examples/hello/" + AppPage: "<!DOCTYPE html>\n",
+	})
+	c, err := Load(root)
+	require.NoError(t, err)
+	m, err := c.Module("01")
+	require.NoError(t, err)
+	assert.True(t, m.Browser)
+	assert.True(t, m.Race, "implied by race_required")
+	assert.True(t, m.RaceRequired)
+	assert.True(t, m.Parallel)
+	assert.True(t, m.Staged)
+	assert.Equal(t, []string{"examples/hello"}, m.Apps)
+
+	e, err := c.Exercise("01-basics/exercise2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"slices", "maps"}, e.Concepts)
+	assert.Equal(t, Medium, e.Difficulty)
+	assert.True(t, e.HasConcept("maps"))
+	assert.False(t, e.HasConcept("channels"))
+
+	e, err = c.Exercise("01-basics/exercise1")
+	require.NoError(t, err)
+	assert.Empty(t, e.Concepts, "not listed")
+	assert.Equal(t, Unrated, e.Difficulty)
+}
+
+func TestLoadMetaBenchmarks(t *testing.T) {
+	root := testCourse(t)
+	writeFiles(t, root, map[string]string{
+		"modules/01-basics/" + MetaFile: `{"exercises": {"exercise1": {"benchmarks": {
+			"BenchmarkSearch": {"max_ns_op": 1000, "tolerance": 0.2},
+			"BenchmarkAppend/small": {"max_allocs_op": 0}
+		}}}}`,
+	})
+	c, err := Load(root)
+	require.NoError(t, err)
+	e, err := c.Exercise("01-basics/exercise1")
+	require.NoError(t, err)
+	assert.Equal(t, []Benchmark{
+		{Name: "BenchmarkAppend/small", MaxNsPerOp: 0, MaxAllocsPerOp: 0, Tolerance: DefaultTolerance},
+		{Name: "BenchmarkSearch", MaxNsPerOp: 1000, MaxAllocsPerOp: -1, Tolerance: 0.2},
+	}, e.Benchmarks)
+	assert.Equal(t, 1200.0, e.Benchmarks[1].NsLimit())
+}
+
+func TestLoadMetaFlashcards(t *testing.T) {
+	root := testCourse(t)
+	writeFiles(t, root, map[string]string{
+		"modules/01-basics/" + MetaFile: `{"flashcards": [{"front": "Zero value of a map?", "back": "nil"}]}`,
+	})
+	c, err := Load(root)
+	require.NoError(t, err)
+	m, err := c.Module("01")
+	require.NoError(t, err)
+	assert.Equal(t, []Flashcard{{Front: "Zero value of a map?", Back: "nil"}}, m.Flashcards)
+}
+
+func TestLoadMetaErrors(t *testing.T) {
+	tests := map[string]string{
+		"parsing":          `{"exercises": `,
+		"unknown exercise": `{"exercises": {"exercise9": {}}}`,
+		"difficulty 4":     `{"exercises": {"exercise1": {"difficulty": 4}}}`,
+		"flashcard 2":      `{"flashcards": [{"front": "Q", "back": "A"}, {"front": "Q"}]}`,
+		"Benchmark":        `{"exercises": {"exercise1": {"benchmarks": {"Search": {"max_ns_op": 10}}}}}`,
+		"neither":          `{"exercises": {"exercise1": {"benchmarks": {"BenchmarkSearch": {"tolerance": 1}}}}}`,
+		"negative":         `{"exercises": {"exercise1": {"benchmarks": {"BenchmarkSearch": {"max_ns_op": -1}}}}}`,
+		"app \"../02\"":    `{"apps": ["../02"]}`,
+		"app \"examples\"": `{"apps": ["examples"]}`,
+	}
+	for want, meta := range tests {
+		root := testCourse(t)
+		writeFiles(t, root, map[string]string{"modules/01-basics/" + MetaFile: meta})
+		_, err := Load(root)
+		assert.ErrorContains(t, err, want)
+		assert.ErrorContains(t, err, filepath.Join("01-basics", MetaFile))
+	}
+}
+
+// TestRepositoryMeta checks that every exercise in the repository is tagged.
+func TestRepositoryMeta(t *testing.T) {
+	root, err := FindRoot(".")
+	require.NoError(t, err)
+	c, err := Load(root)
+	require.NoError(t, err)
+	for _, e := range c.Exercises() {
+		assert.NotEmpty(t, e.Concepts, "%s has no concepts in %s", e.ID, MetaFile)
+		assert.NotEqual(t, Unrated, e.Difficulty, "%s has no difficulty in %s", e.ID, MetaFile)
+	}
+}
+
+func TestRequires(t *testing.T) {
+	root := testCourse(t)
+	writeFiles(t, root, map[string]string{
+		"modules/01-basics/" + MetaFile:   `{"requires": ["03"]}`,
+		"modules/03-advanced/README.md":   "# Module 03: Advanced\n",
+		"modules/03-advanced/" + MetaFile: `{"requires": ["types"]}`,
+	})
+	c, err := Load(root)
+	require.NoError(t, err)
+
+	basics, err := c.Module("01")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"03-advanced"}, basics.Requires, "names are resolved to IDs")
+	require.Len(t, c.Prerequisites(basics), 1)
+	assert.Equal(t, "03-advanced", c.Prerequisites(basics)[0].ID)
+
+	order, err := c.Order()
+	require.NoError(t, err)
+	var ids []string
+	for _, m := range order {
+		ids = append(ids, m.ID)
+	}
+	assert.Equal(t, []string{"02-types", "03-advanced", "01-basics"}, ids)
+}
+
+func TestRequiresErrors(t *testing.T) {
+	tests := map[string]map[string]string{
+		"not found":       {"modules/01-basics/" + MetaFile: `{"requires": ["99"]}`},
+		"requires itself": {"modules/01-basics/" + MetaFile: `{"requires": ["basics"]}`},
+		"cycle: 01-basics -> 02-types -> 01-basics": {
+			"modules/01-basics/" + MetaFile: `{"requires": ["02"]}`,
+			"modules/02-types/" + MetaFile:  `{"requires": ["01"]}`,
+		},
+	}
+	for want, files := range tests {
+		root := testCourse(t)
+		writeFiles(t, root, files)
+		_, err := Load(root)
+		assert.ErrorContains(t, err, want)
+	}
+}
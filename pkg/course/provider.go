@@ -0,0 +1,175 @@
+package course
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Provider supplies modules from outside the course's modules/ directory,
+// so other repositories can ship modules of their own. Each module is a
+// directory laid out like the course's: a README.md, an exercises/
+// directory inside a Go module, and optionally a MetaFile. Its exercises
+// are graded and their progress recorded like any other.
+type Provider interface {
+	// Name identifies the provider in listings and errors.
+	Name() string
+	// ModuleDirs returns the absolute paths of the provided modules.
+	ModuleDirs() ([]string, error)
+}
+
+// DirProvider provides the modules in a directory: Path/modules/* if it
+// exists, as in a checkout of a course repository, or Path/* otherwise.
+type DirProvider struct {
+	Path string
+}
+
+func (p DirProvider) Name() string { return filepath.Base(p.Path) }
+
+func (p DirProvider) ModuleDirs() ([]string, error) {
+	dir, err := filepath.Abs(p.Path)
+	if err != nil {
+		return nil, err
+	}
+	if isDir(filepath.Join(dir, "modules")) {
+		dir = filepath.Join(dir, "modules")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, e := range entries {
+		if e.IsDir() && isDir(filepath.Join(dir, e.Name(), "exercises")) {
+			dirs = append(dirs, filepath.Join(dir, e.Name()))
+		}
+	}
+	return dirs, nil
+}
+
+// ExecPrefix starts the names of executables that ExecProvider runs.
+const ExecPrefix = "learngo-modules-"
+
+// ExecProvider asks an executable for its modules, so a provider can
+// download, generate, or locate them however it likes. learngo runs
+//
+//	<Path> modules
+//
+// and expects JSON on standard output:
+//
+//	{"version": 1, "modules": ["/abs/path/to/01-topic", "relative/02-topic"]}
+//
+// Relative paths are relative to the directory holding the executable.
+type ExecProvider struct {
+	Path string
+}
+
+// execTimeout bounds how long a provider may take to answer.
+const execTimeout = 30 * time.Second
+
+func (p ExecProvider) Name() string {
+	return strings.TrimPrefix(strings.TrimSuffix(filepath.Base(p.Path), filepath.Ext(p.Path)), ExecPrefix)
+}
+
+type execResponse struct {
+	Version int      `json:"version"`
+	Modules []string `json:"modules"`
+}
+
+func (p ExecProvider) ModuleDirs() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), execTimeout)
+	defer cancel()
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Path, "modules")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s modules: %w: %s", p.Path, err, strings.TrimSpace(stderr.String()))
+	}
+	var resp execResponse
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return nil, fmt.Errorf("%s modules: bad response: %w", p.Path, err)
+	}
+	if resp.Version != 1 {
+		return nil, fmt.Errorf("%s modules: unsupported protocol version %d", p.Path, resp.Version)
+	}
+	base := filepath.Dir(p.Path)
+	dirs := make([]string, len(resp.Modules))
+	for i, d := range resp.Modules {
+		if !filepath.IsAbs(d) {
+			d = filepath.Join(base, d)
+		}
+		dirs[i] = d
+	}
+	return dirs, nil
+}
+
+// ModulesEnv lists extra module directories for Discover, separated like
+// PATH.
+const ModulesEnv = "LEARNGO_MODULES"
+
+// Discover finds the installed providers: a DirProvider for each directory
+// in ModulesEnv, and an ExecProvider for each executable on PATH whose
+// name starts with ExecPrefix.
+func Discover() []Provider {
+	var providers []Provider
+	for _, dir := range filepath.SplitList(os.Getenv(ModulesEnv)) {
+		if dir != "" {
+			providers = append(providers, DirProvider{Path: dir})
+		}
+	}
+	seen := make(map[string]bool)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		matches, _ := filepath.Glob(filepath.Join(dir, ExecPrefix+"*"))
+		sort.Strings(matches)
+		for _, path := range matches {
+			p := ExecProvider{Path: path}
+			if seen[p.Name()] || !isExecutable(path) {
+				continue // earlier PATH entries win, as in a shell
+			}
+			seen[p.Name()] = true
+			providers = append(providers, p)
+		}
+	}
+	return providers
+}
+
+func isExecutable(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode().IsRegular() && fi.Mode().Perm()&0o111 != 0
+}
+
+// addProviders loads the modules of each provider into c. A module ID may
+// only be used once across the course and its providers.
+func (c *Course) addProviders(providers []Provider) error {
+	owner := make(map[string]string)
+	for _, m := range c.Modules {
+		owner[m.ID] = "the course"
+	}
+	for _, p := range providers {
+		dirs, err := p.ModuleDirs()
+		if err != nil {
+			return fmt.Errorf("module provider %s: %w", p.Name(), err)
+		}
+		for _, dir := range dirs {
+			m, err := loadModule(dir)
+			if err != nil {
+				return fmt.Errorf("module provider %s: %w", p.Name(), err)
+			}
+			if prev, ok := owner[m.ID]; ok {
+				return fmt.Errorf("module provider %s: module %s is already provided by %s", p.Name(), m.ID, prev)
+			}
+			owner[m.ID] = "provider " + p.Name()
+			m.Provider = p.Name()
+			c.Modules = append(c.Modules, m)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package course
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// extraModules writes a third-party course with one module that builds on
+// the test course.
+func extraModules(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":                                             "module example.com/k8s\n",
+		"modules/50-controllers/README.md":                   "# Module 50: Writing Controllers\n",
+		"modules/50-controllers/module.json":                 `{"requires": ["01-basics"], "exercises": {"reconcile": {"concepts": ["controllers"], "difficulty": 2}}}`,
+		"modules/50-controllers/exercises/reconcile.go":      "package exercises\n",
+		"modules/50-controllers/exercises/reconcile_test.go": "package exercises\n",
+		"modules/notes/README.md":                            "not a module: no exercises directory\n",
+	})
+	return dir
+}
+
+func TestLoadWithDirProvider(t *testing.T) {
+	extra := extraModules(t)
+	c, err := LoadWith(testCourse(t), DirProvider{Path: extra})
+	require.NoError(t, err)
+	require.Len(t, c.Modules, 3)
+
+	m, err := c.Module("50")
+	require.NoError(t, err)
+	assert.Equal(t, "50-controllers", m.ID)
+	assert.Equal(t, "Writing Controllers", m.Title)
+	assert.Equal(t, filepath.Base(extra), m.Provider)
+	assert.Equal(t, []string{"01-basics"}, m.Requires, "may build on the course's modules")
+	require.Len(t, m.Exercises, 1)
+	assert.Equal(t, "50-controllers/reconcile", m.Exercises[0].ID)
+	assert.Equal(t, Medium, m.Exercises[0].Difficulty)
+
+	basics, err := c.Module("01")
+	require.NoError(t, err)
+	assert.Empty(t, basics.Provider)
+}
+
+func TestLoadWithDuplicateModule(t *testing.T) {
+	extra := t.TempDir()
+	writeFiles(t, extra, map[string]string{"01-basics/exercises/other.go": "package exercises\n"})
+	_, err := LoadWith(testCourse(t), DirProvider{Path: extra})
+	assert.ErrorContains(t, err, "module 01-basics is already provided by the course")
+}
+
+// writeProvider writes an executable provider that prints response.
+func writeProvider(t *testing.T, dir, name, response string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script provider")
+	}
+	path := filepath.Join(dir, ExecPrefix+name)
+	script := "#!/bin/sh\n[ \"$1\" = modules ] || exit 2\ncat <<'EOF'\n" + response + "\nEOF\n"
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+	return path
+}
+
+func TestExecProvider(t *testing.T) {
+	extra := extraModules(t)
+	bin := t.TempDir()
+	path := writeProvider(t, bin, "k8s", `{"version": 1, "modules": ["`+filepath.Join(extra, "modules", "50-controllers")+`"]}`)
+
+	p := ExecProvider{Path: path}
+	assert.Equal(t, "k8s", p.Name())
+	c, err := LoadWith(testCourse(t), p)
+	require.NoError(t, err)
+	m, err := c.Module("50-controllers")
+	require.NoError(t, err)
+	assert.Equal(t, "k8s", m.Provider)
+
+	rel := writeProvider(t, bin, "rel", `{"version": 1, "modules": ["modules/50-controllers"]}`)
+	dirs, err := ExecProvider{Path: rel}.ModuleDirs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(bin, "modules/50-controllers")}, dirs, "relative to the executable")
+
+	future := writeProvider(t, bin, "future", `{"version": 2, "modules": []}`)
+	_, err = ExecProvider{Path: future}.ModuleDirs()
+	assert.ErrorContains(t, err, "unsupported protocol version 2")
+}
+
+func TestDiscover(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	writeProvider(t, first, "k8s", `{"version": 1, "modules": []}`)
+	writeProvider(t, second, "k8s", `{"version": 1, "modules": []}`)
+	writeProvider(t, second, "web", `{"version": 1, "modules": []}`)
+	require.NoError(t, os.WriteFile(filepath.Join(second, ExecPrefix+"notes.txt"), []byte("not executable"), 0o644))
+	t.Setenv("PATH", first+string(filepath.ListSeparator)+second)
+	t.Setenv(ModulesEnv, "/courses/a"+string(filepath.ListSeparator)+"/courses/b")
+
+	assert.Equal(t, []Provider{
+		DirProvider{Path: "/courses/a"},
+		DirProvider{Path: "/courses/b"},
+		ExecProvider{Path: filepath.Join(first, ExecPrefix+"k8s")},
+		ExecProvider{Path: filepath.Join(second, ExecPrefix+"web")},
+	}, Discover())
+}
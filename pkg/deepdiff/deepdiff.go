@@ -0,0 +1,244 @@
+// Package deepdiff compares two values as reflect.DeepEqual does, and
+// says where they differ, for the failure messages of tests:
+//
+//	if diff := deepdiff.Diff(want, got); diff != "" {
+//		t.Errorf("Fleet.Retire: (want, got)\n%s", diff)
+//	}
+//
+// Each difference is a line with the path to it, such as
+// Vehicles[2].Year or Depots["York"][0], and the two values found there.
+//
+// It differs from reflect.DeepEqual in two ways, both about values a test
+// means as equal: two NaNs are equal, and a type with a method
+// Equal(T) bool, such as time.Time, is compared with it, so two times at
+// the same instant in different locations are equal. Like DeepEqual, it
+// compares unexported fields, tells a nil slice or map from an empty one,
+// and finds functions equal only if both are nil.
+package deepdiff
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// maxDiffs is the number of differences Diff lists before it only counts
+// them.
+const maxDiffs = 10
+
+// maxWidth is the length beyond which a value in a diff is cut short.
+const maxWidth = 80
+
+// Diff returns the differences between want and got, one to a line, or ""
+// if there are none.
+func Diff(want, got any) string {
+	d := differ{visited: make(map[visit]bool)}
+	d.diff("", reflect.ValueOf(want), reflect.ValueOf(got))
+	if d.more > 0 {
+		d.lines = append(d.lines, fmt.Sprintf("... and %d more", d.more))
+	}
+	return strings.Join(d.lines, "\n")
+}
+
+// Equal reports whether Diff finds no differences between want and got.
+func Equal(want, got any) bool {
+	return Diff(want, got) == ""
+}
+
+// visit is a pair of pointers, maps, or slices being compared; finding it
+// again means a cycle, which compares equal, as in reflect.DeepEqual.
+type visit struct {
+	want, got uintptr
+	typ       reflect.Type
+}
+
+type differ struct {
+	lines   []string
+	more    int
+	visited map[visit]bool
+}
+
+// report adds a difference at path.
+func (d *differ) report(path, format string, args ...any) {
+	if len(d.lines) == maxDiffs {
+		d.more++
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	if path = strings.TrimPrefix(path, "."); path != "" {
+		msg = path + ": " + msg
+	}
+	d.lines = append(d.lines, msg)
+}
+
+// mismatch reports that want and got, at path, are not equal.
+func (d *differ) mismatch(path string, want, got reflect.Value) {
+	d.report(path, "want %s, got %s", format(want), format(got))
+}
+
+func (d *differ) diff(path string, want, got reflect.Value) {
+	if !want.IsValid() || !got.IsValid() {
+		if want.IsValid() != got.IsValid() {
+			d.mismatch(path, want, got)
+		}
+		return
+	}
+	t := want.Type()
+	if t != got.Type() {
+		d.report(path, "want %s of type %s, got %s of type %s", format(want), t, format(got), got.Type())
+		return
+	}
+	if m, ok := equalMethod(t); ok && want.CanInterface() && got.CanInterface() {
+		if !want.Method(m.Index).Call([]reflect.Value{got})[0].Bool() {
+			d.mismatch(path, want, got)
+		}
+		return
+	}
+
+	switch want.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if want.IsNil() || got.IsNil() {
+			if want.IsNil() != got.IsNil() {
+				d.mismatch(path, want, got)
+			}
+			return
+		}
+		if want.Pointer() == got.Pointer() && (want.Kind() != reflect.Slice || want.Len() == got.Len()) {
+			return
+		}
+		v := visit{want: want.Pointer(), got: got.Pointer(), typ: t}
+		if d.visited[v] {
+			return
+		}
+		d.visited[v] = true
+	}
+
+	switch want.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if want.Kind() == reflect.Interface && (want.IsNil() || got.IsNil()) {
+			if want.IsNil() != got.IsNil() {
+				d.mismatch(path, want, got)
+			}
+			return
+		}
+		d.diff(path, want.Elem(), got.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			d.diff(path+"."+t.Field(i).Name, want.Field(i), got.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		n := min(want.Len(), got.Len())
+		for i := 0; i < n; i++ {
+			d.diff(fmt.Sprintf("%s[%d]", path, i), want.Index(i), got.Index(i))
+		}
+		for i := n; i < want.Len(); i++ {
+			d.report(fmt.Sprintf("%s[%d]", path, i), "missing %s", format(want.Index(i)))
+		}
+		for i := n; i < got.Len(); i++ {
+			d.report(fmt.Sprintf("%s[%d]", path, i), "unexpected %s", format(got.Index(i)))
+		}
+	case reflect.Map:
+		keys := append(want.MapKeys(), got.MapKeys()...)
+		sort.Slice(keys, func(i, j int) bool { return format(keys[i]) < format(keys[j]) })
+		for i, k := range keys {
+			if i > 0 && format(k) == format(keys[i-1]) {
+				continue // in both maps
+			}
+			at := fmt.Sprintf("%s[%s]", path, format(k))
+			w, g := want.MapIndex(k), got.MapIndex(k)
+			switch {
+			case !w.IsValid():
+				d.report(at, "unexpected %s", format(g))
+			case !g.IsValid():
+				d.report(at, "missing %s", format(w))
+			default:
+				d.diff(at, w, g)
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		a, b := want.Float(), got.Float()
+		if a != b && !(math.IsNaN(a) && math.IsNaN(b)) {
+			d.mismatch(path, want, got)
+		}
+	case reflect.Complex64, reflect.Complex128:
+		a, b := want.Complex(), got.Complex()
+		if a != b && !(isNaN(a) && isNaN(b)) {
+			d.mismatch(path, want, got)
+		}
+	case reflect.Func:
+		if !want.IsNil() || !got.IsNil() {
+			d.report(path, "functions are equal only if both are nil")
+		}
+	case reflect.Chan, reflect.UnsafePointer:
+		if want.Pointer() != got.Pointer() {
+			d.mismatch(path, want, got)
+		}
+	case reflect.Bool:
+		if want.Bool() != got.Bool() {
+			d.mismatch(path, want, got)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if want.Int() != got.Int() {
+			d.mismatch(path, want, got)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if want.Uint() != got.Uint() {
+			d.mismatch(path, want, got)
+		}
+	case reflect.String:
+		if want.String() != got.String() {
+			d.mismatch(path, want, got)
+		}
+	}
+}
+
+func isNaN(c complex128) bool {
+	return math.IsNaN(real(c)) || math.IsNaN(imag(c))
+}
+
+// equalMethod returns t's Equal method, if it has one that takes a t and
+// returns a bool.
+func equalMethod(t reflect.Type) (reflect.Method, bool) {
+	if t.Kind() == reflect.Interface {
+		return reflect.Method{}, false
+	}
+	m, ok := t.MethodByName("Equal")
+	if !ok || m.Type.NumIn() != 2 || m.Type.In(1) != t || m.Type.NumOut() != 1 || m.Type.Out(0).Kind() != reflect.Bool {
+		return reflect.Method{}, false
+	}
+	return m, true
+}
+
+// format returns v as a diff shows it: strings quoted, structs with the
+// names of their fields, and anything long cut short.
+func format(v reflect.Value) string {
+	var s string
+	switch {
+	case !v.IsValid():
+		s = "nil"
+	case v.Kind() == reflect.String:
+		s = fmt.Sprintf("%q", v)
+	case canBeNil(v.Kind()) && v.IsNil():
+		s = "nil"
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.Len() == 0:
+		s = v.Type().String() + "{}" // not [] or map[], which look like nil
+	case v.Kind() == reflect.Func:
+		s = "func"
+	default:
+		s = fmt.Sprintf("%+v", v)
+	}
+	if len(s) > maxWidth {
+		s = strings.ToValidUTF8(s[:maxWidth-3], "") + "..."
+	}
+	return s
+}
+
+func canBeNil(k reflect.Kind) bool {
+	switch k {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package deepdiff
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/faker"
+	"github.com/stretchr/testify/assert"
+)
+
+type depot struct {
+	Name     string
+	Vehicles []faker.Vehicle
+	Plates   map[string][]string
+	Opened   time.Time
+	Next     *depot
+	secret   int
+}
+
+func TestEqualValues(t *testing.T) {
+	f := faker.For(t, 1)
+	vs := []faker.Vehicle{f.Vehicle(), f.Vehicle()}
+	opened := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	a := depot{Name: "York", Vehicles: vs, Plates: map[string][]string{"north": {vs[0].Plate}}, Opened: opened, secret: 1}
+	b := depot{Name: "York", Vehicles: append([]faker.Vehicle(nil), vs...), Plates: map[string][]string{"north": {vs[0].Plate}}, Opened: opened, secret: 1}
+	assert.Equal(t, "", Diff(a, b))
+	assert.True(t, Equal(&a, &b))
+	assert.True(t, Equal(nil, nil))
+	assert.True(t, Equal([]any{1, "x", nil}, []any{1, "x", nil}))
+}
+
+func TestDifferences(t *testing.T) {
+	tests := []struct {
+		want, got any
+		diff      string
+	}{
+		{1, 2, "want 1, got 2"},
+		{1, "1", `want 1 of type int, got "1" of type string`},
+		{nil, 0, "want nil, got 0"},
+		{[]int{1, 2, 3}, []int{1, 5}, "[1]: want 2, got 5\n[2]: missing 3"},
+		{[]string{"a"}, []string{"a", "b"}, `[1]: unexpected "b"`},
+		{[]int(nil), []int{}, "want nil, got []int{}"},
+		{map[string]int{"a": 1, "b": 2}, map[string]int{"b": 3, "c": 4}, `["a"]: missing 1` + "\n" + `["b"]: want 2, got 3` + "\n" + `["c"]: unexpected 4`},
+		{depot{Name: "York"}, depot{Name: "Leeds"}, `Name: want "York", got "Leeds"`},
+		{depot{secret: 1}, depot{secret: 2}, "secret: want 1, got 2"},
+		{depot{Next: &depot{}}, depot{}, "Next: want &{Name: Vehicles:[] Plates:map[] Opened:0001-01-01 00:00:00 +0000 UTC Next:<n..., got nil"},
+		{depot{Plates: map[string][]string{"n": {"A", "B"}}}, depot{Plates: map[string][]string{"n": {"A", "C"}}}, `Plates["n"][1]: want "B", got "C"`},
+		{[]any{1}, []any{1.0}, "[0]: want 1 of type int, got 1 of type float64"},
+		{func() {}, func() {}, "functions are equal only if both are nil"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.diff, Diff(tt.want, tt.got), "%#v and %#v", tt.want, tt.got)
+	}
+}
+
+func TestNaNAndEqualMethods(t *testing.T) {
+	nan := math.NaN()
+	assert.False(t, reflect.DeepEqual([]float64{nan}, []float64{nan}))
+	assert.True(t, Equal([]float64{nan}, []float64{nan}))
+	assert.True(t, Equal(complex(nan, 1), complex(nan, 1)))
+	assert.Equal(t, "want NaN, got 1", Diff(nan, 1.0))
+
+	utc := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	paris := utc.In(time.FixedZone("CET", 3600))
+	assert.False(t, reflect.DeepEqual(utc, paris))
+	assert.True(t, Equal(depot{Opened: utc}, depot{Opened: paris}))
+	assert.Equal(t, "Opened: want 2024-03-01 09:00:00 +0000 UTC, got 2024-03-01 09:00:01 +0000 UTC",
+		Diff(depot{Opened: utc}, depot{Opened: utc.Add(time.Second)}))
+}
+
+func TestCycles(t *testing.T) {
+	a := &depot{Name: "a"}
+	a.Next = a
+	b := &depot{Name: "a"}
+	b.Next = b
+	assert.True(t, Equal(a, b))
+	b.Next = &depot{Name: "b", Next: b}
+	assert.Equal(t, `Next.Name: want "a", got "b"`, Diff(a, b))
+}
+
+func TestLongDiffs(t *testing.T) {
+	want, got := make([]int, 30), make([]int, 30)
+	for i := range got {
+		got[i] = i + 1
+	}
+	lines := strings.Split(Diff(want, got), "\n")
+	assert.Len(t, lines, maxDiffs+1)
+	assert.Equal(t, "... and 20 more", lines[maxDiffs])
+
+	diff := Diff(strings.Repeat("a", 200), "b")
+	assert.Less(t, len(diff), 2*maxWidth)
+	assert.Contains(t, diff, `..., got "b"`)
+}
@@ -0,0 +1,40 @@
+package faker
+
+// The lists the Faker picks from. The names come from many places, so
+// that tests meet accents, apostrophes, and short and long names.
+
+var firstNames = []string{
+	"Ada", "Alan", "Amara", "Ana", "Barbara", "Bjarne", "Chen", "Dennis",
+	"Edsger", "Fatima", "Frances", "Grace", "Hedy", "Ingrid", "Jean", "Joan",
+	"Ken", "Kofi", "Leslie", "Linus", "Margaret", "Mateo", "Niklaus", "Noor",
+	"Olivia", "Priya", "Radia", "Rob", "Sofía", "Søren", "Tim", "Yuki",
+}
+
+var lastNames = []string{
+	"Allen", "Bartik", "Berners-Lee", "Clarke", "Dijkstra", "Goldberg",
+	"Hamilton", "Hopper", "Johnson", "Kernighan", "Lamarr", "Liskov",
+	"Lovelace", "Mensah", "Nakamura", "O'Neil", "Okafor", "Perlman", "Pike",
+	"Ritchie", "Sammet", "Sharma", "Spärck Jones", "Stroustrup", "Thompson",
+	"Torvalds", "Turing", "Wilkes", "Wirth", "Zhang",
+}
+
+// domains are reserved for examples by RFC 2606.
+var domains = []string{"example.com", "example.org", "example.net"}
+
+var vehicles = []struct {
+	make, model string
+	wheels      int
+	kind        VehicleKind
+	engine      Engine
+}{
+	{"Toyota", "Corolla", 4, VehicleCar, EngineHybrid},
+	{"Volkswagen", "Golf", 4, VehicleCar, EnginePetrol},
+	{"Ford", "Transit", 4, VehicleVan, EngineDiesel},
+	{"Tesla", "Model 3", 4, VehicleCar, EngineElectric},
+	{"Fiat", "500", 4, VehicleCar, EnginePetrol},
+	{"Volvo", "FH16", 6, VehicleTruck, EngineDiesel},
+	{"Scania", "R 450", 10, VehicleTruck, EngineDiesel},
+	{"Honda", "CB500F", 2, VehicleMotorcycle, EnginePetrol},
+	{"Vespa", "Primavera", 2, VehicleMotorcycle, EnginePetrol},
+	{"Piaggio", "Ape", 3, VehicleVan, EnginePetrol},
+}
@@ -0,0 +1,241 @@
+// Package faker makes test data that looks real: people's names and email
+// addresses, vehicles, shapes, and bank accounts. A Faker draws them from
+// a random source, usually seed.Rand, so that a test builds its fixtures
+// instead of copying the same few literals from file to file, and each
+// learner's tests see different ones:
+//
+//	f := faker.For(t, 1)
+//	accounts := f.Accounts(20)
+//
+// The same source gives the same data, so a failure replays with
+// LEARNGO_SEED as any other. Email addresses use the domains reserved for
+// examples, and never reach anyone.
+package faker
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+)
+
+// Faker makes test data from a random source. It is not safe for
+// concurrent use, like the source.
+type Faker struct {
+	r      *rand.Rand
+	emails map[string]bool
+	lastID int
+}
+
+// New returns a Faker drawing from r.
+func New(r *rand.Rand) *Faker {
+	return &Faker{r: r, emails: make(map[string]bool), lastID: 100000}
+}
+
+// For returns a Faker for one test, drawing from seed.Rand(tb, base).
+func For(tb testing.TB, base int64) *Faker {
+	tb.Helper()
+	return New(seed.Rand(tb, base))
+}
+
+// Rand returns the Faker's random source, for the numbers a test needs
+// besides its data.
+func (f *Faker) Rand() *rand.Rand { return f.r }
+
+func pick[T any](r *rand.Rand, from []T) T { return from[r.Intn(len(from))] }
+
+// FirstName returns a first name.
+func (f *Faker) FirstName() string { return pick(f.r, firstNames) }
+
+// LastName returns a last name.
+func (f *Faker) LastName() string { return pick(f.r, lastNames) }
+
+// Name returns a first and last name.
+func (f *Faker) Name() string { return f.FirstName() + " " + f.LastName() }
+
+// Person is someone with a name and an email address.
+type Person struct {
+	Name  string
+	Email string
+}
+
+// Address returns p as an RFC 5322 address, "Name <email>".
+func (p Person) Address() string { return fmt.Sprintf("%s <%s>", p.Name, p.Email) }
+
+// Person returns a person whose email address is made from their name, and
+// differs from every other this Faker has returned.
+func (f *Faker) Person() Person {
+	name := f.Name()
+	return Person{Name: name, Email: f.email(name)}
+}
+
+// Email returns an email address, different from every other this Faker
+// has returned.
+func (f *Faker) Email() string { return f.email(f.Name()) }
+
+func (f *Faker) email(name string) string {
+	local := strings.ToLower(asciiLocal.Replace(name))
+	domain := pick(f.r, domains)
+	addr := local + "@" + domain
+	for n := 2; f.emails[addr]; n++ {
+		addr = fmt.Sprintf("%s%d@%s", local, n, domain)
+	}
+	f.emails[addr] = true
+	return addr
+}
+
+// asciiLocal turns a name into the local part of an address, in ASCII,
+// which every mail system accepts.
+var asciiLocal = strings.NewReplacer(" ", ".", "'", "", "í", "i", "ø", "o", "ä", "a")
+
+// People returns n people, with different email addresses.
+func (f *Faker) People(n int) []Person {
+	out := make([]Person, n)
+	for i := range out {
+		out[i] = f.Person()
+	}
+	return out
+}
+
+//go:generate go run github.com/TheAnarchoX/LearningGoTheHardWay/cmd/enumgen -type=VehicleKind -trimprefix=Vehicle -json
+
+// VehicleKind is what a vehicle is built for. The zero VehicleKind is
+// unknown, and has no name.
+type VehicleKind int
+
+// The kinds of vehicle. Their String methods, ParseVehicleKind, and JSON
+// encoding are generated by cmd/enumgen.
+const (
+	VehicleCar VehicleKind = iota + 1
+	VehicleVan
+	VehicleTruck
+	VehicleMotorcycle
+)
+
+//go:generate go run github.com/TheAnarchoX/LearningGoTheHardWay/cmd/enumgen -type=Engine -trimprefix=Engine -json
+
+// Engine is what drives a vehicle. The zero Engine is unknown, and has no
+// name.
+type Engine int
+
+// The engines, also with generated methods.
+const (
+	EnginePetrol Engine = iota + 1
+	EngineDiesel
+	EngineElectric
+	EngineHybrid
+)
+
+// Vehicle is a road vehicle.
+type Vehicle struct {
+	Make, Model string
+	Kind        VehicleKind
+	Engine      Engine
+	Year        int
+	Wheels      int
+	Plate       string // e.g. "KX-482-B"
+}
+
+// Vehicle returns a vehicle from 1990 to 2024.
+func (f *Faker) Vehicle() Vehicle {
+	m := pick(f.r, vehicles)
+	return Vehicle{
+		Make:   m.make,
+		Model:  m.model,
+		Kind:   m.kind,
+		Engine: m.engine,
+		Year:   seed.Between(f.r, 1990, 2024),
+		Wheels: m.wheels,
+		Plate:  f.plate(),
+	}
+}
+
+func (f *Faker) plate() string {
+	letter := func() byte { return byte('A' + f.r.Intn(26)) }
+	return fmt.Sprintf("%c%c-%03d-%c", letter(), letter(), f.r.Intn(1000), letter())
+}
+
+// Point returns a point with coordinates in [-100, 100], to two decimal
+// places.
+func (f *Faker) Point() geometry.Point {
+	return geometry.Point{X: f.coord(-100, 100), Y: f.coord(-100, 100)}
+}
+
+// coord returns a number in [lo, hi] with two decimal places.
+func (f *Faker) coord(lo, hi float64) float64 {
+	return math.Round((lo+f.r.Float64()*(hi-lo))*100) / 100
+}
+
+// Circle returns a circle with a radius in [0.5, 50].
+func (f *Faker) Circle() geometry.Circle {
+	return geometry.Circle{Center: f.Point(), Radius: f.coord(0.5, 50)}
+}
+
+// Rectangle returns a rectangle with sides in [0.5, 100].
+func (f *Faker) Rectangle() geometry.Rectangle {
+	return geometry.Rectangle{Origin: f.Point(), Width: f.coord(0.5, 100), Height: f.coord(0.5, 100)}
+}
+
+// Triangle returns a triangle with an area of at least 1: never flat.
+func (f *Faker) Triangle() geometry.Triangle {
+	for {
+		t := geometry.Triangle{A: f.Point(), B: f.Point(), C: f.Point()}
+		if t.Area() >= 1 {
+			return t
+		}
+	}
+}
+
+// Shape returns a circle, a rectangle, or a triangle.
+func (f *Faker) Shape() geometry.Shape {
+	switch f.r.Intn(3) {
+	case 0:
+		return f.Circle()
+	case 1:
+		return f.Rectangle()
+	default:
+		return f.Triangle()
+	}
+}
+
+// Shapes returns n shapes.
+func (f *Faker) Shapes(n int) []geometry.Shape {
+	out := make([]geometry.Shape, n)
+	for i := range out {
+		out[i] = f.Shape()
+	}
+	return out
+}
+
+// Account is a bank account.
+type Account struct {
+	ID      string // e.g. "ACC-100042"
+	Owner   Person
+	Balance int64 // in cents, from 0 to 10,000.00
+}
+
+// Account returns an account whose ID sorts after that of every other this
+// Faker has returned, with gaps in between, so that a test can look up IDs
+// that are missing as well as ones that are not. IDs keep their six digits,
+// and so their order as strings, for the first 40,000 or so accounts.
+func (f *Faker) Account() Account {
+	f.lastID += 1 + f.r.Intn(20)
+	balance := int64(f.r.Intn(1_000_000 + 1))
+	if f.r.Intn(10) == 0 {
+		balance = 0
+	}
+	return Account{ID: fmt.Sprintf("ACC-%d", f.lastID), Owner: f.Person(), Balance: balance}
+}
+
+// Accounts returns n accounts, sorted by ID.
+func (f *Faker) Accounts(n int) []Account {
+	out := make([]Account, n)
+	for i := range out {
+		out[i] = f.Account()
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package faker
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFaker(n int64) *Faker { return New(rand.New(rand.NewSource(n))) }
+
+func TestDeterministic(t *testing.T) {
+	a, b := newFaker(7), newFaker(7)
+	assert.Equal(t, a.People(10), b.People(10))
+	assert.Equal(t, a.Accounts(10), b.Accounts(10))
+	assert.Equal(t, a.Shapes(10), b.Shapes(10))
+	assert.NotEqual(t, newFaker(8).People(10), newFaker(7).People(10))
+}
+
+func TestFor(t *testing.T) {
+	t.Setenv(seed.EnvVar, "")
+	assert.Equal(t, newFaker(3).Vehicle(), For(t, 3).Vehicle(), "without a learner seed, base is the seed")
+	t.Setenv(seed.EnvVar, "42")
+	assert.NotEqual(t, newFaker(3).Vehicle(), For(t, 3).Vehicle())
+}
+
+func TestPeople(t *testing.T) {
+	people := newFaker(1).People(500)
+	seen := make(map[string]bool)
+	for _, p := range people {
+		require.False(t, seen[p.Email], "%s is repeated", p.Email)
+		seen[p.Email] = true
+		addr, err := mail.ParseAddress(p.Address())
+		require.NoError(t, err, p.Address())
+		assert.Equal(t, p.Email, addr.Address)
+		local, domain, _ := strings.Cut(p.Email, "@")
+		assert.Regexp(t, `^[a-z.-]+[0-9]*$`, local)
+		assert.True(t, strings.HasPrefix(domain, "example."), domain)
+	}
+}
+
+func TestVehicle(t *testing.T) {
+	f := newFaker(1)
+	plate := regexp.MustCompile(`^[A-Z]{2}-[0-9]{3}-[A-Z]$`)
+	for i := 0; i < 100; i++ {
+		v := f.Vehicle()
+		assert.NotEmpty(t, v.Make)
+		assert.True(t, v.Year >= 1990 && v.Year <= 2024, v.Year)
+		assert.Contains(t, []int{2, 3, 4, 6, 10}, v.Wheels)
+		assert.Regexp(t, plate, v.Plate)
+		assert.NotContains(t, v.Kind.String(), "(", "every vehicle has a kind")
+		assert.NotContains(t, v.Engine.String(), "(", "every vehicle has an engine")
+	}
+}
+
+func TestVehicleJSON(t *testing.T) {
+	v := newFaker(1).Vehicle()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Kind":"`+v.Kind.String()+`"`)
+
+	var back Vehicle
+	require.NoError(t, json.Unmarshal(data, &back))
+	assert.Equal(t, v, back)
+
+	var engine Engine
+	require.NoError(t, json.Unmarshal([]byte(`"diesel"`), &engine), "names parse in any case")
+	assert.Equal(t, EngineDiesel, engine)
+	assert.ErrorContains(t, json.Unmarshal([]byte(`"steam"`), &engine), `"steam" is not a valid Engine`)
+	assert.ErrorContains(t, json.Unmarshal([]byte(`2`), &engine), "should be a string")
+
+	_, err = json.Marshal(Vehicle{})
+	assert.Error(t, err, "the zero kind has no name")
+}
+
+func TestShapes(t *testing.T) {
+	kinds := make(map[string]int)
+	for _, s := range newFaker(1).Shapes(300) {
+		switch s.(type) {
+		case geometry.Circle:
+			kinds["circle"]++
+		case geometry.Rectangle:
+			kinds["rectangle"]++
+		case geometry.Triangle:
+			kinds["triangle"]++
+		}
+		assert.GreaterOrEqual(t, s.Area(), 0.25, "%v has no area to speak of", s)
+		assert.Positive(t, s.Perimeter())
+	}
+	assert.Len(t, kinds, 3, "circles, rectangles, and triangles: %v", kinds)
+}
+
+func TestAccounts(t *testing.T) {
+	accounts := newFaker(1).Accounts(200)
+	require.True(t, slices.IsSortedFunc(accounts, func(a, b Account) int { return strings.Compare(a.ID, b.ID) }))
+	zero := 0
+	for i, a := range accounts {
+		assert.Regexp(t, `^ACC-[0-9]{6}$`, a.ID)
+		assert.True(t, a.Balance >= 0 && a.Balance <= 1_000_000, a.Balance)
+		if a.Balance == 0 {
+			zero++
+		}
+		if i > 0 {
+			assert.NotEqual(t, accounts[i-1].ID, a.ID)
+		}
+	}
+	assert.Positive(t, zero, "some accounts are empty")
+}
@@ -0,0 +1,88 @@
+// Package geometry provides the shape types shared across the course.
+//
+// Modules use these types whenever they need realistic data to sort,
+// search, serialize, or draw, so learners meet the same few types in many
+// different contexts instead of a new toy type in every lesson.
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+// Point is a location in the plane.
+type Point struct {
+	X, Y float64
+}
+
+// Distance returns the Euclidean distance between p and q.
+func (p Point) Distance(q Point) float64 {
+	return math.Hypot(q.X-p.X, q.Y-p.Y)
+}
+
+// Shape is implemented by every closed figure in the course.
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+}
+
+// Circle is defined by its center and radius.
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// Area returns πr².
+func (c Circle) Area() float64 { return math.Pi * c.Radius * c.Radius }
+
+// Perimeter returns the circumference 2πr.
+func (c Circle) Perimeter() float64 { return 2 * math.Pi * c.Radius }
+
+// String implements fmt.Stringer.
+func (c Circle) String() string {
+	return fmt.Sprintf("Circle(r=%g)", c.Radius)
+}
+
+// Rectangle is axis-aligned, anchored at its minimum corner.
+type Rectangle struct {
+	Origin        Point
+	Width, Height float64
+}
+
+// Area returns width × height.
+func (r Rectangle) Area() float64 { return r.Width * r.Height }
+
+// Perimeter returns 2 × (width + height).
+func (r Rectangle) Perimeter() float64 { return 2 * (r.Width + r.Height) }
+
+// String implements fmt.Stringer.
+func (r Rectangle) String() string {
+	return fmt.Sprintf("Rectangle(%gx%g)", r.Width, r.Height)
+}
+
+// Triangle is defined by its three vertices.
+type Triangle struct {
+	A, B, C Point
+}
+
+// Area uses the shoelace formula, which works for any vertex order.
+func (t Triangle) Area() float64 {
+	return math.Abs((t.A.X*(t.B.Y-t.C.Y) + t.B.X*(t.C.Y-t.A.Y) + t.C.X*(t.A.Y-t.B.Y)) / 2)
+}
+
+// Perimeter returns the sum of the side lengths.
+func (t Triangle) Perimeter() float64 {
+	return t.A.Distance(t.B) + t.B.Distance(t.C) + t.C.Distance(t.A)
+}
+
+// String implements fmt.Stringer.
+func (t Triangle) String() string {
+	return fmt.Sprintf("Triangle(%v, %v, %v)", t.A, t.B, t.C)
+}
+
+// Compile-time checks that every type satisfies Shape.
+var (
+	_ Shape = Circle{}
+	_ Shape = Rectangle{}
+	_ Shape = Triangle{}
+)
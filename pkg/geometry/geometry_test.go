@@ -0,0 +1,35 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointDistance(t *testing.T) {
+	assert.Equal(t, 5.0, Point{0, 0}.Distance(Point{3, 4}))
+	assert.Equal(t, 0.0, Point{1, 1}.Distance(Point{1, 1}))
+}
+
+func TestShapes(t *testing.T) {
+	tests := []struct {
+		name      string
+		shape     Shape
+		area      float64
+		perimeter float64
+		str       string
+	}{
+		{"circle", Circle{Radius: 2}, 4 * math.Pi, 4 * math.Pi, "Circle(r=2)"},
+		{"rectangle", Rectangle{Width: 3, Height: 4}, 12, 14, "Rectangle(3x4)"},
+		{"right triangle", Triangle{Point{0, 0}, Point{3, 0}, Point{0, 4}}, 6, 12, "Triangle({0 0}, {3 0}, {0 4})"},
+		{"clockwise triangle", Triangle{Point{0, 0}, Point{0, 4}, Point{3, 0}}, 6, 12, "Triangle({0 0}, {0 4}, {3 0})"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.area, tt.shape.Area(), 1e-9)
+			assert.InDelta(t, tt.perimeter, tt.shape.Perimeter(), 1e-9)
+			assert.Equal(t, tt.str, tt.shape.(interface{ String() string }).String())
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package grader
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/stats"
+)
+
+// BenchCount is how many times each benchmark runs. The fastest run is
+// the one compared with the thresholds, since noise only ever slows a
+// benchmark down.
+const BenchCount = 3
+
+// BenchResult is the outcome of one of an exercise's Benchmarks.
+type BenchResult struct {
+	course.Benchmark
+	Ran         bool // false if the benchmark is missing or failed
+	NsPerOp     float64
+	NsSpread    float64 // standard deviation of ns/op over its mean across the runs
+	AllocsPerOp int64   // -1 unless the benchmark reports allocations
+	Passed      bool
+}
+
+// String describes the result and its thresholds, such as
+//
+//	BenchmarkSearch: 1520 ns/op ±4% (max 1000 + 50%), 0 allocs/op (max 0)
+//
+// A spread of more than a few percent means the machine was busy, and
+// the result is worth a second run before optimizing anything.
+func (b BenchResult) String() string {
+	if !b.Ran {
+		return b.Name + ": did not run"
+	}
+	var parts []string
+	if b.MaxNsPerOp > 0 {
+		spread := ""
+		if b.NsSpread >= 0.005 {
+			spread = fmt.Sprintf(" ±%.0f%%", b.NsSpread*100)
+		}
+		parts = append(parts, fmt.Sprintf("%.4g ns/op%s (max %.4g + %.0f%%)", b.NsPerOp, spread, b.MaxNsPerOp, b.Tolerance*100))
+	}
+	if b.MaxAllocsPerOp >= 0 {
+		if b.AllocsPerOp < 0 {
+			parts = append(parts, fmt.Sprintf("allocs/op not reported (max %d)", b.MaxAllocsPerOp))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d allocs/op (max %d)", b.AllocsPerOp, b.MaxAllocsPerOp))
+		}
+	}
+	return b.Name + ": " + strings.Join(parts, ", ")
+}
+
+// benchmark runs the benchmarks of the exercises in run whose tests
+// passed, and fails the exercises that miss a threshold. Benchmarks never
+// run with the race detector, which slows code down many times over.
+func (run *Run) benchmark(ctx context.Context, m *course.Module, env []string, benchtime string) error {
+	var top []string
+	seen := make(map[string]bool)
+	for i, e := range m.Exercises {
+		if !run.Results[i].Passed {
+			continue
+		}
+		for _, b := range e.Benchmarks {
+			name, _, _ := strings.Cut(b.Name, "/")
+			if !seen[name] {
+				seen[name] = true
+				top = append(top, name)
+			}
+		}
+	}
+	if len(top) == 0 {
+		return nil
+	}
+	sort.Strings(top)
+
+	args := []string{"test", "-run=^$", "-bench=^(" + strings.Join(top, "|") + ")$", "-benchmem", fmt.Sprintf("-count=%d", BenchCount)}
+	args = append(args, run.testFlags()...)
+	if benchtime != "" {
+		args = append(args, "-benchtime="+benchtime)
+	}
+	cmd := exec.CommandContext(ctx, "go", append(args, ".")...)
+	cmd.Dir = filepath.Join(m.Dir, "exercises")
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return fmt.Errorf("running go test -bench: %w", err)
+	}
+	if err != nil {
+		run.BenchOutput = strings.TrimSpace(string(out))
+	}
+
+	measured := parseBenchmarks(string(out))
+	for i, e := range m.Exercises {
+		res := run.Results[i]
+		if !res.Passed {
+			continue
+		}
+		for _, b := range e.Benchmarks {
+			br := BenchResult{Benchmark: b, AllocsPerOp: -1}
+			if got, ok := measured[b.Name]; ok {
+				br.Ran, br.NsPerOp, br.NsSpread, br.AllocsPerOp = true, got.NsPerOp, got.NsSpread, got.AllocsPerOp
+			}
+			br.Passed = br.Ran &&
+				(b.MaxNsPerOp == 0 || br.NsPerOp <= b.NsLimit()) &&
+				(b.MaxAllocsPerOp < 0 || (br.AllocsPerOp >= 0 && br.AllocsPerOp <= b.MaxAllocsPerOp))
+			if !br.Passed {
+				res.Passed = false
+			}
+			res.Benchmarks = append(res.Benchmarks, br)
+		}
+	}
+	return nil
+}
+
+// measurement is the best of a benchmark's runs, and how much they varied.
+type measurement struct {
+	NsPerOp     float64
+	NsSpread    float64 // 0 for a single run
+	AllocsPerOp int64   // -1 if not reported
+}
+
+// merge returns the best of m and a later run, got.
+func (m measurement) merge(got measurement) measurement {
+	got.NsPerOp = min(got.NsPerOp, m.NsPerOp)
+	if m.AllocsPerOp >= 0 && (got.AllocsPerOp < 0 || m.AllocsPerOp < got.AllocsPerOp) {
+		got.AllocsPerOp = m.AllocsPerOp
+	}
+	return got
+}
+
+var (
+	// "BenchmarkSearch-8   	13636419	        85.92 ns/op	       0 B/op	       0 allocs/op"
+	benchLine = regexp.MustCompile(`^(Benchmark\S*)\s+\d+\s+(.*)$`)
+	// the GOMAXPROCS suffix, absent when it is 1
+	procsSuffix = regexp.MustCompile(`-\d+$`)
+)
+
+// parseBenchmarks reads the output of go test -bench, and returns the
+// lowest ns/op and allocs/op of each benchmark by name, both with and
+// without what looks like a GOMAXPROCS suffix: "BenchmarkSize/n-10" may
+// be a sub-benchmark called n-10 on one CPU.
+func parseBenchmarks(output string) map[string]measurement {
+	best := make(map[string]measurement)
+	runs := make(map[string]*stats.Accumulator)
+	sc := bufio.NewScanner(strings.NewReader(output))
+	for sc.Scan() {
+		m := benchLine.FindStringSubmatch(strings.TrimSpace(sc.Text()))
+		if m == nil {
+			continue
+		}
+		got := measurement{NsPerOp: -1, AllocsPerOp: -1}
+		fields := strings.Fields(m[2])
+		for i := 0; i+1 < len(fields); i += 2 {
+			switch fields[i+1] {
+			case "ns/op":
+				got.NsPerOp, _ = strconv.ParseFloat(fields[i], 64)
+			case "allocs/op":
+				got.AllocsPerOp, _ = strconv.ParseInt(fields[i], 10, 64)
+			}
+		}
+		if got.NsPerOp < 0 {
+			continue
+		}
+		names := []string{m[1]}
+		if short := procsSuffix.ReplaceAllString(m[1], ""); short != m[1] {
+			names = append(names, short)
+		}
+		for _, name := range names {
+			if runs[name] == nil {
+				runs[name] = new(stats.Accumulator)
+			}
+			runs[name].Add(got.NsPerOp)
+			if prev, ok := best[name]; ok {
+				best[name] = prev.merge(got)
+			} else {
+				best[name] = got
+			}
+		}
+	}
+	for name, m := range best {
+		if ns := runs[name]; ns.Mean() > 0 {
+			m.NsSpread = ns.StdDev() / ns.Mean()
+			best[name] = m
+		}
+	}
+	return best
+}
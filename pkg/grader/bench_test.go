@@ -0,0 +1,123 @@
+package grader
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleBench = `goos: linux
+goarch: amd64
+pkg: example.com/course/modules/15-searching/exercises
+BenchmarkSearch-8   	13636419	        85.92 ns/op	       0 B/op	       0 allocs/op
+BenchmarkSearch-8   	14120533	        81.30 ns/op	       0 B/op	       0 allocs/op
+BenchmarkSearch-8   	13978122	        90.01 ns/op	       0 B/op	       0 allocs/op
+BenchmarkAppend/n-10-8         	 3000000	       402.1 ns/op	     248 B/op	       5 allocs/op
+BenchmarkAppend/n-10-8         	 3000000	       398.7 ns/op	     248 B/op	       4 allocs/op
+BenchmarkPlain 	 1000000	      1052 ns/op
+--- FAIL: BenchmarkBroken
+    search_test.go:40: wrong answer
+PASS
+`
+
+func TestParseBenchmarks(t *testing.T) {
+	got := parseBenchmarks(sampleBench)
+	search := got["BenchmarkSearch"]
+	assert.Equal(t, 81.30, search.NsPerOp, "the best of three")
+	assert.Equal(t, int64(0), search.AllocsPerOp)
+	assert.InDelta(t, 0.0508, search.NsSpread, 0.0001, "the standard deviation over the mean of all three")
+	assert.Equal(t, search, got["BenchmarkSearch-8"])
+	appendN := got["BenchmarkAppend/n-10"]
+	assert.Equal(t, 398.7, appendN.NsPerOp)
+	assert.Equal(t, int64(4), appendN.AllocsPerOp)
+	assert.Equal(t, measurement{NsPerOp: 1052, AllocsPerOp: -1}, got["BenchmarkPlain"], "no -benchmem")
+	assert.NotContains(t, got, "BenchmarkBroken")
+}
+
+func TestBenchResultString(t *testing.T) {
+	b := BenchResult{
+		Benchmark: course.Benchmark{Name: "BenchmarkSearch", MaxNsPerOp: 1000, MaxAllocsPerOp: 0, Tolerance: 0.5},
+		Ran:       true, NsPerOp: 1520, AllocsPerOp: 0,
+	}
+	assert.Equal(t, "BenchmarkSearch: 1520 ns/op (max 1000 + 50%), 0 allocs/op (max 0)", b.String())
+	b.NsSpread = 0.042
+	assert.Equal(t, "BenchmarkSearch: 1520 ns/op ±4% (max 1000 + 50%), 0 allocs/op (max 0)", b.String())
+	b.NsSpread = 0.001
+	assert.Equal(t, "BenchmarkSearch: 1520 ns/op (max 1000 + 50%), 0 allocs/op (max 0)", b.String(), "too little to mention")
+	b.MaxNsPerOp = 0
+	assert.Equal(t, "BenchmarkSearch: 0 allocs/op (max 0)", b.String())
+	b.Ran = false
+	assert.Equal(t, "BenchmarkSearch: did not run", b.String())
+}
+
+func TestGradeBenchmarks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test -bench")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	m := writeModule(t, map[string]string{
+		"modules/15-demo/module.json": `{"exercises": {
+			"fast": {"benchmarks": {"BenchmarkFast": {"max_allocs_op": 0}}},
+			"slow": {"benchmarks": {"BenchmarkSlow": {"max_allocs_op": 0}}},
+			"broken": {"benchmarks": {"BenchmarkBroken": {"max_ns_op": 1e9}}}
+		}}`,
+		"modules/15-demo/exercises/fast.go": "package exercises\n\nfunc Fast(n int) int { return n * 2 }\n",
+		"modules/15-demo/exercises/fast_test.go": `package exercises
+
+import "testing"
+
+func TestFast(t *testing.T) {}
+
+func BenchmarkFast(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Fast(i)
+	}
+}
+`,
+		"modules/15-demo/exercises/slow.go": "package exercises\n\nvar Sink []int\n\nfunc Slow(n int) { Sink = make([]int, n) }\n",
+		"modules/15-demo/exercises/slow_test.go": `package exercises
+
+import "testing"
+
+func TestSlow(t *testing.T) {}
+
+func BenchmarkSlow(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Slow(100)
+	}
+}
+`,
+		"modules/15-demo/exercises/broken.go":      "package exercises\n",
+		"modules/15-demo/exercises/broken_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestBroken(t *testing.T) {}\n\nfunc BenchmarkBroken(b *testing.B) { b.Fatal(\"wrong\") }\n",
+	})
+
+	run, err := GradeWith(context.Background(), m, Options{BenchTime: "100x"})
+	require.NoError(t, err)
+	byName := make(map[string]*Result)
+	for _, res := range run.Results {
+		byName[res.Exercise] = res
+	}
+
+	fast := byName["15-demo/fast"]
+	assert.True(t, fast.Passed)
+	require.Len(t, fast.Benchmarks, 1)
+	assert.True(t, fast.Benchmarks[0].Ran)
+	assert.Equal(t, int64(0), fast.Benchmarks[0].AllocsPerOp)
+
+	slow := byName["15-demo/slow"]
+	assert.False(t, slow.Passed, "allocates")
+	require.Len(t, slow.Benchmarks, 1)
+	assert.Equal(t, int64(1), slow.Benchmarks[0].AllocsPerOp)
+
+	broken := byName["15-demo/broken"]
+	assert.False(t, broken.Passed)
+	require.Len(t, broken.Benchmarks, 1)
+	assert.False(t, broken.Benchmarks[0].Ran)
+	assert.Contains(t, run.BenchOutput, "--- FAIL: BenchmarkBroken")
+}
@@ -0,0 +1,212 @@
+package grader
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+)
+
+// FlakyOptions changes how Flaky reruns the tests.
+type FlakyOptions struct {
+	Runs  int      // how many times to run the tests; 10 if zero
+	Race  bool     // run with -race, as modules marked Race always are
+	Procs []int    // GOMAXPROCS for each run in turn, e.g. 1, 2, 8; unset if empty
+	Env   []string // extra "KEY=value" environment for the tests
+}
+
+// FlakyReport is what the tests of a module did over several runs.
+type FlakyReport struct {
+	Module string
+	Runs   int
+	Race   bool
+	Tests  []*FlakyTest // in course order
+	// BuildOutput holds compiler errors when the package did not build,
+	// after which Flaky stops.
+	BuildOutput string
+}
+
+// FlakyTest is what one test did over the runs.
+type FlakyTest struct {
+	Exercise string
+	Name     string
+	Runs     int // the runs in which it ran rather than skipped
+	Failures int
+	// FailedProcs holds the GOMAXPROCS of the runs it failed in, with
+	// FlakyOptions.Procs, each once.
+	FailedProcs []int
+	Messages    []FailureMessage // most frequent first
+}
+
+// FailureMessage is one way a test failed, and how many runs it failed
+// that way in.
+type FailureMessage struct {
+	Text  string
+	Count int
+}
+
+// Flaky reports whether the test failed in some runs but not in all: it
+// depends on timing, scheduling, or the order of the tests.
+func (t *FlakyTest) Flaky() bool { return t.Failures > 0 && t.Failures < t.Runs }
+
+// Flaky returns the tests that failed in some runs but not in all.
+func (r *FlakyReport) Flaky() []*FlakyTest {
+	var out []*FlakyTest
+	for _, t := range r.Tests {
+		if t.Flaky() {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Failing returns the tests that failed in every run.
+func (r *FlakyReport) Failing() []*FlakyTest {
+	var out []*FlakyTest
+	for _, t := range r.Tests {
+		if t.Runs > 0 && t.Failures == t.Runs {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Flaky runs the module's exercise tests opts.Runs times and reports the
+// tests whose outcome changed from run to run. Each run is a go test of
+// its own, with -count=1 so that nothing is cached, and with the flags
+// Grade uses, -shuffle=on included for modules marked Parallel. Failures
+// are grouped by their message, with addresses and timings left out, so
+// that the same failure in different runs counts once.
+func Flaky(ctx context.Context, m *course.Module, opts FlakyOptions) (*FlakyReport, error) {
+	owners, err := TestsByExercise(m)
+	if err != nil {
+		return nil, err
+	}
+	tagged, err := IntegrationTests(m)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Runs <= 0 {
+		opts.Runs = 10
+	}
+	report := &FlakyReport{Module: m.ID, Runs: opts.Runs, Race: opts.Race || m.Race}
+	byName := make(map[string]*FlakyTest)
+	for _, e := range m.Exercises {
+		for _, name := range owners[e.ID] {
+			t := &FlakyTest{Exercise: e.ID, Name: name}
+			byName[name] = t
+			report.Tests = append(report.Tests, t)
+		}
+	}
+	dir := filepath.Join(m.Dir, "exercises")
+	for i := 0; i < opts.Runs; i++ {
+		env := opts.Env
+		procs := 0
+		if len(opts.Procs) > 0 {
+			procs = opts.Procs[i%len(opts.Procs)]
+			env = append(slices.Clip(env), fmt.Sprintf("GOMAXPROCS=%d", procs))
+		}
+		run := &Run{Module: m.ID, Race: report.Race}
+		if err := run.grade(ctx, m, owners, tagged, env); err != nil {
+			return nil, err
+		}
+		if run.BuildOutput != "" {
+			if report.Race && !opts.Race && !m.RaceRequired && raceUnsupported(run.BuildOutput) {
+				report.Race = false
+				i--
+				continue
+			}
+			report.BuildOutput = run.BuildOutput
+			return report, nil
+		}
+		for _, res := range run.Results {
+			for _, tr := range res.Tests {
+				t := byName[tr.Name]
+				if t == nil || tr.Skipped {
+					continue
+				}
+				t.Runs++
+				if !tr.Passed {
+					t.fail(procs, failureMessage(tr, dir))
+				}
+			}
+		}
+	}
+	for _, t := range report.Tests {
+		slices.SortStableFunc(t.Messages, func(a, b FailureMessage) int { return b.Count - a.Count })
+		slices.Sort(t.FailedProcs)
+	}
+	return report, nil
+}
+
+func (t *FlakyTest) fail(procs int, msg string) {
+	t.Failures++
+	if procs > 0 && !slices.Contains(t.FailedProcs, procs) {
+		t.FailedProcs = append(t.FailedProcs, procs)
+	}
+	for i := range t.Messages {
+		if t.Messages[i].Text == msg {
+			t.Messages[i].Count++
+			return
+		}
+	}
+	t.Messages = append(t.Messages, FailureMessage{Text: msg, Count: 1})
+}
+
+// maxMessageLines bounds a failure message; the first lines say what
+// failed, and the rest is mostly stack.
+const maxMessageLines = 8
+
+var (
+	hexAddress = regexp.MustCompile(`0x[0-9a-f]+`)
+	goroutine  = regexp.MustCompile(`goroutine \d+`)
+	timing     = regexp.MustCompile(`\s*\(\d+\.\d+s\)`)
+)
+
+// failureMessage describes how a test failed, the same way each time it
+// fails for the same reason: its data races, or otherwise the lines of its
+// output besides go test's own, without addresses, goroutine numbers, or
+// timings.
+func failureMessage(t TestResult, dir string) string {
+	if len(t.Races) > 0 {
+		return "data race: " + strings.Join(Summaries(t.Races, dir), "; ")
+	}
+	var lines []string
+	for _, line := range strings.Split(t.Output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "=== ") || strings.HasPrefix(trimmed, "--- ") {
+			continue
+		}
+		line = hexAddress.ReplaceAllString(line, "0x...")
+		line = goroutine.ReplaceAllString(line, "goroutine N")
+		line = timing.ReplaceAllString(line, "")
+		lines = append(lines, strings.TrimRight(line, " \t"))
+		if len(lines) == maxMessageLines {
+			break
+		}
+	}
+	if len(lines) == 0 {
+		return "failed without a message"
+	}
+	return strings.Join(dedent(lines), "\n")
+}
+
+// dedent removes the indentation that every line shares.
+func dedent(lines []string) []string {
+	common := -1
+	for _, l := range lines {
+		n := len(l) - len(strings.TrimLeft(l, " \t"))
+		if common < 0 || n < common {
+			common = n
+		}
+	}
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = l[common:]
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+package grader
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlaky(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	m := writeModule(t, map[string]string{
+		"modules/01-demo/exercises/procs.go": "package exercises\n",
+		"modules/01-demo/exercises/procs_test.go": `package exercises
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func TestProcs(t *testing.T) {
+	if n := runtime.GOMAXPROCS(0); n == 2 {
+		t.Fatalf("fails with %d procs, at %p", n, t)
+	}
+}
+
+func TestAlways(t *testing.T) { t.Fatal("always") }
+
+func TestNever(t *testing.T) { fmt.Println("fine") }
+`,
+	})
+
+	r, err := Flaky(context.Background(), m, FlakyOptions{Runs: 4, Procs: []int{1, 2}})
+	require.NoError(t, err)
+	assert.Equal(t, 4, r.Runs)
+	require.Len(t, r.Tests, 3)
+
+	procs := r.Tests[0]
+	assert.Equal(t, "TestProcs", procs.Name)
+	assert.Equal(t, "01-demo/procs", procs.Exercise)
+	assert.True(t, procs.Flaky())
+	assert.Equal(t, 4, procs.Runs)
+	assert.Equal(t, 2, procs.Failures)
+	assert.Equal(t, []int{2}, procs.FailedProcs)
+	require.Len(t, procs.Messages, 1, "the address differs between runs, and is left out")
+	assert.Equal(t, FailureMessage{Text: "procs_test.go:11: fails with 2 procs, at 0x...", Count: 2}, procs.Messages[0])
+
+	assert.Equal(t, []*FlakyTest{procs}, r.Flaky())
+	require.Len(t, r.Failing(), 1)
+	assert.Equal(t, "TestAlways", r.Failing()[0].Name)
+	assert.Equal(t, 4, r.Failing()[0].Failures)
+	assert.Zero(t, r.Tests[2].Failures)
+}
+
+func TestFlakyBuildFailure(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	m := writeModule(t, map[string]string{
+		"modules/01-demo/exercises/add.go":      "package exercises\n\nfunc Add(a, b int) int { return a + }\n",
+		"modules/01-demo/exercises/add_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {}\n",
+	})
+	r, err := Flaky(context.Background(), m, FlakyOptions{Runs: 3})
+	require.NoError(t, err)
+	assert.NotEmpty(t, r.BuildOutput)
+	assert.Zero(t, r.Tests[0].Runs, "it stops at the first run")
+}
+
+func TestFailureMessage(t *testing.T) {
+	out := "=== RUN   TestX\n    x_test.go:9: \n        \tError Trace:\tx_test.go:9\n        \tError:      \tgot 0xc000012345 in goroutine 17\n--- FAIL: TestX (0.01s)\n"
+	assert.Equal(t, "x_test.go:9:\n    \tError Trace:\tx_test.go:9\n    \tError:      \tgot 0x... in goroutine N",
+		failureMessage(TestResult{Output: out}, "."))
+	assert.Equal(t, "failed without a message", failureMessage(TestResult{Output: "=== RUN   TestX\n--- FAIL: TestX (0.00s)\n"}, "."))
+
+	race := Race{
+		Access:   Access{Op: "write", Stack: []Frame{{Func: "m/exercises.Inc", File: "/w/counter.go", Line: 3}}},
+		Previous: Access{Op: "read", Stack: []Frame{{Func: "m/exercises.Get", File: "/w/counter.go", Line: 7}}},
+	}
+	assert.Equal(t, "data race: write in Inc at counter.go:3 races with read in Get at counter.go:7",
+		failureMessage(TestResult{Output: "anything", Races: []Race{race}}, "/w"))
+}
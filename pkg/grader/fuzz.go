@@ -0,0 +1,80 @@
+package grader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+)
+
+// FuzzResult is the outcome of fuzzing one of an exercise's fuzz targets.
+type FuzzResult struct {
+	Name   string
+	Passed bool
+	// Input is the file the failing input was written to, relative to the
+	// exercises directory, e.g. "testdata/fuzz/FuzzReverse/8f3a...". From
+	// then on it is part of the target's seed corpus, so plain test runs
+	// fail on it too until the exercise is fixed.
+	Input  string
+	Output string // go test's output, if fuzzing failed
+}
+
+// failingInput matches the line in which go test reports where it saved
+// the input that failed a fuzz target.
+var failingInput = regexp.MustCompile(`Failing input written to (\S+)`)
+
+// fuzz fuzzes the fuzz targets of the exercises in run whose tests passed,
+// each for d, and fails the exercises whose targets fail. go test fuzzes
+// one target at a time, so a module with many targets takes a while.
+func (run *Run) fuzz(ctx context.Context, m *course.Module, owners map[string][]string, env []string, d time.Duration) error {
+	for _, res := range run.Results {
+		if !res.Passed {
+			continue
+		}
+		for _, name := range owners[res.Exercise] {
+			if !isFuzzName(name) {
+				continue
+			}
+			fr, err := fuzzTarget(ctx, m, name, run.testFlags(), env, d)
+			if err != nil {
+				return err
+			}
+			if !fr.Passed {
+				res.Passed = false
+			}
+			res.Fuzz = append(res.Fuzz, fr)
+		}
+	}
+	return nil
+}
+
+// fuzzTarget runs go test -fuzz on one target, with the given test flags.
+func fuzzTarget(ctx context.Context, m *course.Module, name string, flags, env []string, d time.Duration) (FuzzResult, error) {
+	fr := FuzzResult{Name: name}
+	args := append([]string{"test", "-run=^$", "-fuzz=^" + regexp.QuoteMeta(name) + "$", fmt.Sprintf("-fuzztime=%s", d)}, flags...)
+	cmd := exec.CommandContext(ctx, "go", append(args, ".")...)
+	cmd.Dir = filepath.Join(m.Dir, "exercises")
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return fr, fmt.Errorf("running go test -fuzz: %w", err)
+	}
+	fr.Passed = err == nil
+	if !fr.Passed {
+		fr.Output = strings.TrimSpace(string(out))
+		if match := failingInput.FindStringSubmatch(fr.Output); match != nil {
+			fr.Input = filepath.ToSlash(match[1])
+		}
+	}
+	return fr, nil
+}
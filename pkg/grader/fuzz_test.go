@@ -0,0 +1,73 @@
+package grader
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGradeFuzz(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test -fuzz")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	m := writeModule(t, map[string]string{
+		"modules/01-demo/exercises/clamp.go": "package exercises\n\n// Clamp is s without its first n bytes.\nfunc Clamp(s string, n int) string { return s[n:] }\n",
+		"modules/01-demo/exercises/clamp_test.go": `package exercises
+
+import "testing"
+
+func TestClamp(t *testing.T) {
+	if Clamp("abc", 1) != "bc" {
+		t.Fatal("wrong")
+	}
+}
+
+func FuzzClamp(f *testing.F) {
+	f.Add("abc", 1)
+	f.Fuzz(func(t *testing.T, s string, n int) {
+		defer func() {
+			if v := recover(); v != nil {
+				t.Fatal(v)
+			}
+		}()
+		Clamp(s, n)
+	})
+}
+`,
+		"modules/01-demo/exercises/broken.go":      "package exercises\n",
+		"modules/01-demo/exercises/broken_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestBroken(t *testing.T) { t.Fatal(\"broken\") }\n\nfunc FuzzBroken(f *testing.F) { f.Fuzz(func(*testing.T, int) {}) }\n",
+	})
+
+	run, err := Grade(context.Background(), m)
+	require.NoError(t, err)
+	require.Len(t, run.Results, 2)
+	assert.True(t, run.Results[1].Passed, "the seed corpus passes")
+	assert.Empty(t, run.Results[1].Fuzz)
+
+	run, err = GradeWith(context.Background(), m, Options{Fuzz: 10 * time.Second})
+	require.NoError(t, err)
+	assert.Empty(t, run.Results[0].Fuzz, "broken fails its tests and is not fuzzed")
+	clamp := run.Results[1]
+	assert.False(t, clamp.Passed)
+	require.Len(t, clamp.Fuzz, 1)
+	f := clamp.Fuzz[0]
+	assert.Equal(t, "FuzzClamp", f.Name)
+	assert.False(t, f.Passed)
+	require.True(t, strings.HasPrefix(f.Input, "testdata/fuzz/FuzzClamp/"), f.Output)
+	_, err = os.Stat(filepath.Join(m.Dir, "exercises", filepath.FromSlash(f.Input)))
+	assert.NoError(t, err)
+
+	run, err = Grade(context.Background(), m)
+	require.NoError(t, err)
+	assert.False(t, run.Results[1].Passed, "the failing input is part of the seed corpus now")
+}
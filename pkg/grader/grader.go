@@ -0,0 +1,404 @@
+// Package grader runs a module's exercise tests and works out which
+// exercises pass. Tests are attributed to exercises by file:
+// TestFoo in exercise1_fix_bugs_test.go belongs to exercise1_fix_bugs.go.
+//
+// Tests can also run away from the machine that grades them: BuildWasm
+// compiles them to WebAssembly for a browser, which runs the binary with
+// WasmExec's support script and -test.v, and GradeOutput grades what it
+// printed.
+//
+// Modules marked Race are always graded with the race detector, and the
+// races it reports are parsed, so that a learner sees which lines of the
+// exercise raced rather than a page of stack traces. Where the toolchain
+// cannot build with it, they are graded without it, unless they are
+// marked RaceRequired as well: their bugs are races that no assertion
+// catches, so every exercise fails instead.
+//
+// An exercise with Benchmarks passes only if they meet their thresholds
+// as well, and with Options.Fuzz, so must its fuzz targets. Both run
+// after the tests, for the exercises whose tests pass, and only on this
+// machine: GradeOutput grades the tests alone.
+//
+// Modules marked Parallel are graded with -parallel=ParallelTests and
+// -shuffle=on: their tests call t.Parallel, and these make tests that
+// share state with each other fail even on a machine with one CPU.
+//
+// Modules marked Staged are projects built in milestones, one to an
+// exercise, each using the code of those before it. GradeWith passes a
+// milestone only if every earlier one passes, and says which it waits for.
+//
+// Tests run with -short unless Options.Integration asks for the full
+// suite, so that integration tests (see package testenv) skip themselves.
+// The tests in an exercise's _integration_test.go file build only with
+// -tags integration, and count as skipped without it. An exercise with a
+// skipped test does not pass: the skipped test may be the one that checks
+// its bug.
+package grader
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+)
+
+// TestResult is the outcome of one top-level test function.
+type TestResult struct {
+	Name    string
+	Passed  bool
+	Skipped bool
+	Elapsed time.Duration
+	Output  string
+	Races   []Race // data races reported during the test, which fail it
+}
+
+// Result is the outcome of one exercise: it passes when its tests ran,
+// and none of them failed or was skipped.
+type Result struct {
+	Exercise   string // exercise ID
+	Passed     bool
+	Tests      []TestResult
+	Benchmarks []BenchResult // run only if the tests passed
+	Fuzz       []FuzzResult  // likewise, and only with Options.Fuzz
+	// Blocked is, in a Staged module, the first earlier exercise that did
+	// not pass, which keeps this one from passing whatever its tests say.
+	Blocked string
+}
+
+// Run is one grading run of a module.
+type Run struct {
+	Module   string
+	Started  time.Time
+	Duration time.Duration
+	Results  []*Result // in course order
+	// BuildOutput holds compiler errors when the package did not build.
+	// Every exercise fails in that case.
+	BuildOutput string
+	// BenchOutput holds the output of go test -bench when it failed.
+	BenchOutput string
+	Race        bool // tests ran with the race detector
+	Integration bool // the full suite ran, not only the unit tests
+	// RaceUnavailable is set when the module is RaceRequired and this
+	// toolchain cannot build with the race detector. BuildOutput says why.
+	RaceUnavailable bool
+}
+
+// Options changes how GradeWith runs the tests.
+type Options struct {
+	Race      bool     // run with -race, as modules marked Race always are
+	Env       []string // extra "KEY=value" environment for the tests
+	BenchTime string   // -benchtime for the exercises' benchmarks, if not 1s
+	// Fuzz is how long to fuzz each fuzz target of the exercises, if not
+	// zero. Without it, fuzz targets only run their seed corpus.
+	Fuzz time.Duration
+	// Integration runs the integration tests as well as the unit tests:
+	// without -short, and with -tags integration.
+	Integration bool
+}
+
+// ParallelTests is how many tests of a module marked Parallel run at once.
+const ParallelTests = 8
+
+// IntegrationSuffix ends the name of an exercise's test file that builds
+// only with -tags integration, e.g. exercise1_store_integration_test.go.
+const IntegrationSuffix = "_integration_test.go"
+
+// Passed reports whether every exercise in the run passed.
+func (r *Run) Passed() bool {
+	for _, res := range r.Results {
+		if !res.Passed {
+			return false
+		}
+	}
+	return len(r.Results) > 0
+}
+
+// Races returns the data races reported during the exercise's tests.
+func (r *Result) Races() []Race {
+	var races []Race
+	for _, t := range r.Tests {
+		races = append(races, t.Races...)
+	}
+	return races
+}
+
+// Grade runs `go test -json` in the module's exercises directory.
+func Grade(ctx context.Context, m *course.Module) (*Run, error) {
+	return GradeWith(ctx, m, Options{})
+}
+
+// GradeWith is Grade with options. A module marked Race runs with -race
+// unless the toolchain cannot build with it, in which case Run.Race is
+// false; if it is marked RaceRequired as well, Run.RaceUnavailable is set
+// and every exercise fails.
+func GradeWith(ctx context.Context, m *course.Module, opts Options) (*Run, error) {
+	owners, err := TestsByExercise(m)
+	if err != nil {
+		return nil, err
+	}
+	tagged, err := IntegrationTests(m)
+	if err != nil {
+		return nil, err
+	}
+	run := &Run{Module: m.ID, Race: opts.Race || m.Race, Integration: opts.Integration}
+	err = run.grade(ctx, m, owners, tagged, opts.Env)
+	if err == nil && run.Race && raceUnsupported(run.BuildOutput) {
+		switch {
+		case m.RaceRequired:
+			run.RaceUnavailable = true
+		case !opts.Race:
+			run = &Run{Module: m.ID, Integration: opts.Integration}
+			err = run.grade(ctx, m, owners, tagged, opts.Env)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if opts.Fuzz > 0 {
+		if err := run.fuzz(ctx, m, owners, opts.Env, opts.Fuzz); err != nil {
+			return nil, err
+		}
+	}
+	if err := run.benchmark(ctx, m, opts.Env, opts.BenchTime); err != nil {
+		return nil, err
+	}
+	run.stage(m)
+	return run, nil
+}
+
+// stage fails every exercise of a Staged module that comes after one that
+// did not pass.
+func (run *Run) stage(m *course.Module) {
+	if !m.Staged {
+		return
+	}
+	blocked := ""
+	for _, res := range run.Results {
+		if blocked != "" {
+			res.Blocked, res.Passed = blocked, false
+		} else if !res.Passed {
+			blocked = res.Exercise
+		}
+	}
+}
+
+// grade runs the tests once, as run.Race and run.Integration say.
+func (run *Run) grade(ctx context.Context, m *course.Module, owners map[string][]string, tagged map[string]bool, env []string) error {
+	run.Started = time.Now()
+	args := append([]string{"test", "-json", "-count=1"}, run.testFlags()...)
+	if run.Race {
+		args = append(args, "-race")
+	}
+	if m.Parallel {
+		args = append(args, fmt.Sprintf("-parallel=%d", ParallelTests), "-shuffle=on")
+	}
+	cmd := exec.CommandContext(ctx, "go", append(args, ".")...)
+	cmd.Dir = filepath.Join(m.Dir, "exercises")
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, runErr := cmd.Output()
+	run.Duration = time.Since(run.Started)
+
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return fmt.Errorf("running go test: %w", runErr) // go missing, context canceled, ...
+	}
+
+	tests, pkgOutput, err := ParseEvents(bytes.NewReader(out))
+	if err != nil {
+		return err
+	}
+	if len(tests) == 0 && runErr != nil {
+		run.BuildOutput = strings.TrimSpace(pkgOutput + stderr.String())
+	}
+
+	run.attribute(m, owners, tagged, tests)
+	return nil
+}
+
+// testFlags returns the go test flags that pick the unit tests alone, or
+// the full suite.
+func (run *Run) testFlags() []string {
+	if run.Integration {
+		return []string{"-tags=integration"}
+	}
+	return []string{"-short"}
+}
+
+// attribute fills in run.Results from the results of the tests that ran.
+// The tagged tests were not built unless run.Integration is set, and are
+// skipped rather than failed.
+func (run *Run) attribute(m *course.Module, owners map[string][]string, tagged map[string]bool, tests map[string]*TestResult) {
+	for _, e := range m.Exercises {
+		res := &Result{Exercise: e.ID}
+		for _, name := range owners[e.ID] {
+			if t, ok := tests[name]; ok {
+				res.Tests = append(res.Tests, *t)
+			} else if tagged[name] && !run.Integration {
+				res.Tests = append(res.Tests, TestResult{Name: name, Skipped: true})
+			} else {
+				res.Tests = append(res.Tests, TestResult{Name: name}) // never ran
+			}
+		}
+		res.Passed = len(res.Tests) > 0 && len(res.Skipped()) == 0 && len(res.Failed()) == 0 && run.BuildOutput == ""
+		run.Results = append(run.Results, res)
+	}
+}
+
+// Skipped returns the names of the exercise's tests that were skipped.
+func (r *Result) Skipped() []string {
+	var names []string
+	for _, t := range r.Tests {
+		if t.Skipped {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// Failed returns the names of the exercise's tests that ran and failed.
+func (r *Result) Failed() []string {
+	var names []string
+	for _, t := range r.Tests {
+		if !t.Passed && !t.Skipped {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// TestsByExercise maps each exercise ID in m to the names of the Test
+// functions and fuzz targets declared in its _test.go file, then in its
+// _integration_test.go file if it has one.
+func TestsByExercise(m *course.Module) (map[string][]string, error) {
+	owners := make(map[string][]string)
+	fset := token.NewFileSet()
+	for _, e := range m.Exercises {
+		for _, suffix := range []string{"_test.go", IntegrationSuffix} {
+			names, ok, err := declaredTests(fset, strings.TrimSuffix(e.File, ".go")+suffix)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				owners[e.ID] = append(owners[e.ID], names...)
+			}
+		}
+	}
+	return owners, nil
+}
+
+// IntegrationTests returns the names of the tests declared in the
+// _integration_test.go files of m's exercises.
+func IntegrationTests(m *course.Module) (map[string]bool, error) {
+	tagged := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, e := range m.Exercises {
+		names, _, err := declaredTests(fset, strings.TrimSuffix(e.File, ".go")+IntegrationSuffix)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			tagged[name] = true
+		}
+	}
+	return tagged, nil
+}
+
+// declaredTests returns the tests declared in a test file, and false if
+// there is no such file.
+func declaredTests(fset *token.FileSet, path string) ([]string, bool, error) {
+	f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return testFuncs(f), true, nil
+}
+
+// event is one line of `go test -json` output (see `go doc test2json`).
+type event struct {
+	Action  string
+	Test    string
+	Elapsed float64
+	Output  string
+}
+
+// ParseEvents reads `go test -json` output and returns the result of every
+// top-level test, plus any output not attributed to a test (build errors,
+// the final PASS/FAIL line).
+func ParseEvents(r io.Reader) (map[string]*TestResult, string, error) {
+	tests := make(map[string]*TestResult)
+	var pkgOutput strings.Builder
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var ev event
+		if err := json.Unmarshal(line, &ev); err != nil {
+			// Not JSON: the toolchain printed something directly
+			pkgOutput.Write(line)
+			pkgOutput.WriteByte('\n')
+			continue
+		}
+		if ev.Test == "" {
+			if ev.Action == "output" || ev.Action == "build-output" {
+				pkgOutput.WriteString(ev.Output)
+			}
+			continue
+		}
+		name, _, _ := strings.Cut(ev.Test, "/") // subtests roll up into their parent
+		t, ok := tests[name]
+		if !ok {
+			t = &TestResult{Name: name}
+			tests[name] = t
+		}
+		if name != ev.Test {
+			if ev.Action == "output" {
+				t.Output += ev.Output
+			}
+			continue
+		}
+		switch ev.Action {
+		case "output":
+			t.Output += ev.Output
+		case "pass":
+			t.Passed = true
+			t.Elapsed = seconds(ev.Elapsed)
+		case "fail":
+			t.Passed = false
+			t.Elapsed = seconds(ev.Elapsed)
+		case "skip":
+			t.Skipped = true
+			t.Elapsed = seconds(ev.Elapsed)
+		}
+	}
+	for _, t := range tests {
+		t.Races = ParseRaces(t.Output)
+	}
+	return tests, pkgOutput.String(), sc.Err()
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
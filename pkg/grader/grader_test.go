@@ -0,0 +1,302 @@
+package grader
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleEvents = `{"Action":"start","Package":"example.com/m"}
+{"Action":"run","Package":"example.com/m","Test":"TestAdd"}
+{"Action":"output","Package":"example.com/m","Test":"TestAdd","Output":"=== RUN   TestAdd\n"}
+{"Action":"pass","Package":"example.com/m","Test":"TestAdd","Elapsed":0.25}
+{"Action":"run","Package":"example.com/m","Test":"TestSub"}
+{"Action":"run","Package":"example.com/m","Test":"TestSub/negative"}
+{"Action":"output","Package":"example.com/m","Test":"TestSub/negative","Output":"    sub_test.go:9: got 3, want -1\n"}
+{"Action":"fail","Package":"example.com/m","Test":"TestSub/negative","Elapsed":0}
+{"Action":"fail","Package":"example.com/m","Test":"TestSub","Elapsed":0.01}
+{"Action":"skip","Package":"example.com/m","Test":"TestSlow","Elapsed":0}
+{"Action":"output","Package":"example.com/m","Output":"FAIL\n"}
+{"Action":"fail","Package":"example.com/m","Elapsed":0.3}
+`
+
+func TestParseEvents(t *testing.T) {
+	tests, pkgOutput, err := ParseEvents(strings.NewReader(sampleEvents))
+	require.NoError(t, err)
+	require.Len(t, tests, 3)
+
+	assert.True(t, tests["TestAdd"].Passed)
+	assert.Equal(t, 250*time.Millisecond, tests["TestAdd"].Elapsed)
+
+	sub := tests["TestSub"]
+	assert.False(t, sub.Passed)
+	assert.Contains(t, sub.Output, "got 3, want -1", "subtest output rolls up")
+
+	assert.True(t, tests["TestSlow"].Skipped)
+	assert.Equal(t, "FAIL\n", pkgOutput)
+}
+
+func TestParseEventsNonJSON(t *testing.T) {
+	_, pkgOutput, err := ParseEvents(strings.NewReader("go: cannot find main module\n"))
+	require.NoError(t, err)
+	assert.Contains(t, pkgOutput, "cannot find main module")
+}
+
+func TestIsTestName(t *testing.T) {
+	assert.True(t, isTestName("Test"))
+	assert.True(t, isTestName("TestAdd"))
+	assert.True(t, isTestName("Test_add"))
+	assert.False(t, isTestName("Testify"))
+	assert.False(t, isTestName("BenchmarkAdd"))
+	assert.False(t, isTestName("helper"))
+	assert.True(t, isFuzzName("FuzzAdd"))
+	assert.False(t, isFuzzName("Fuzzy"))
+}
+
+// writeModule creates a throwaway Go module with one course module whose
+// exercises are given as file name -> source.
+func writeModule(t *testing.T, files map[string]string) *course.Module {
+	t.Helper()
+	root := t.TempDir()
+	files["go.mod"] = "module example.com/course\n\ngo 1.21\n"
+	for name, src := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(src), 0o644))
+	}
+	c, err := course.Load(root)
+	require.NoError(t, err)
+	require.Len(t, c.Modules, 1)
+	return c.Modules[0]
+}
+
+func TestTestsByExercise(t *testing.T) {
+	m := writeModule(t, map[string]string{
+		"modules/01-demo/exercises/add.go": "package exercises\n",
+		"modules/01-demo/exercises/add_test.go": `package exercises
+import "testing"
+func TestAdd(t *testing.T) {}
+func TestAddMore(t *testing.T) {}
+func BenchmarkAdd(b *testing.B) {}
+func FuzzAdd(f *testing.F) {}
+func FuzzWrong(t *testing.T) {}
+func helper(t *testing.T) {}
+`,
+		"modules/01-demo/exercises/add_integration_test.go": "//go:build integration\n\npackage exercises\nimport \"testing\"\nfunc TestAddDB(t *testing.T) {}\n",
+		"modules/01-demo/exercises/untested.go":             "package exercises\n",
+	})
+	owners, err := TestsByExercise(m)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"01-demo/add": {"TestAdd", "TestAddMore", "FuzzAdd", "TestAddDB"},
+	}, owners)
+	tagged, err := IntegrationTests(m)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"TestAddDB": true}, tagged)
+}
+
+func TestGrade(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	m := writeModule(t, map[string]string{
+		"modules/01-demo/exercises/add.go":      "package exercises\n\nfunc Add(a, b int) int { return a + b }\n",
+		"modules/01-demo/exercises/add_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {\n\tif Add(1, 2) != 3 {\n\t\tt.Fatal(\"wrong\")\n\t}\n}\n",
+		"modules/01-demo/exercises/sub.go":      "package exercises\n\nfunc Sub(a, b int) int { return a + b } // bug\n",
+		"modules/01-demo/exercises/sub_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestSub(t *testing.T) {\n\tif Sub(3, 1) != 2 {\n\t\tt.Fatal(\"wrong\")\n\t}\n}\n",
+	})
+
+	run, err := Grade(context.Background(), m)
+	require.NoError(t, err)
+	assert.Empty(t, run.BuildOutput)
+	require.Len(t, run.Results, 2)
+	assert.True(t, run.Results[0].Passed, "add")
+	assert.False(t, run.Results[1].Passed, "sub")
+	assert.Contains(t, run.Results[1].Tests[0].Output, "wrong")
+	assert.False(t, run.Passed())
+}
+
+func TestGradeStaged(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	files := map[string]string{
+		"modules/01-demo/exercises/m1_sub.go":      "package exercises\n\nfunc Sub(a, b int) int { return a + b } // bug\n",
+		"modules/01-demo/exercises/m1_sub_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestSub(t *testing.T) {\n\tif Sub(3, 1) != 2 {\n\t\tt.Fatal(\"wrong\")\n\t}\n}\n",
+		"modules/01-demo/exercises/m2_add.go":      "package exercises\n\nfunc Add(a, b int) int { return a + b }\n",
+		"modules/01-demo/exercises/m2_add_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {}\n",
+		"modules/01-demo/exercises/m3_mul.go":      "package exercises\n\nfunc Mul(a, b int) int { return a * b }\n",
+		"modules/01-demo/exercises/m3_mul_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestMul(t *testing.T) {}\n",
+	}
+	run, err := Grade(context.Background(), writeModule(t, files))
+	require.NoError(t, err)
+	assert.True(t, run.Results[1].Passed, "without staged, each exercise stands alone")
+	assert.Empty(t, run.Results[1].Blocked)
+
+	files["modules/01-demo/module.json"] = `{"staged": true}`
+	run, err = Grade(context.Background(), writeModule(t, files))
+	require.NoError(t, err)
+	require.Len(t, run.Results, 3)
+	assert.False(t, run.Results[0].Passed)
+	assert.Empty(t, run.Results[0].Blocked)
+	for _, res := range run.Results[1:] {
+		assert.False(t, res.Passed, res.Exercise)
+		assert.Equal(t, "01-demo/m1_sub", res.Blocked, res.Exercise)
+		assert.True(t, res.Tests[0].Passed, "the tests themselves pass")
+	}
+}
+
+func TestGradeBuildFailure(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	m := writeModule(t, map[string]string{
+		"modules/01-demo/exercises/add.go":      "package exercises\n\nfunc Add(a, b int) int { return a + }\n",
+		"modules/01-demo/exercises/add_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {}\n",
+	})
+
+	run, err := Grade(context.Background(), m)
+	require.NoError(t, err)
+	assert.Contains(t, run.BuildOutput, "add.go")
+	require.Len(t, run.Results, 1)
+	assert.False(t, run.Results[0].Passed)
+}
+
+func TestGradeIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	m := writeModule(t, map[string]string{
+		"modules/01-demo/exercises/add.go":      "package exercises\n\nfunc Add(a, b int) int { return a + b }\n",
+		"modules/01-demo/exercises/add_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {}\n",
+		"modules/01-demo/exercises/add_integration_test.go": `//go:build integration
+
+package exercises
+
+import "testing"
+
+func TestAddTagged(t *testing.T) { t.Fatal("tagged") }
+`,
+		"modules/01-demo/exercises/shell.go": "package exercises\n",
+		"modules/01-demo/exercises/shell_test.go": `package exercises
+
+import "testing"
+
+func TestShell(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs sh")
+	}
+}
+`,
+	})
+
+	run, err := Grade(context.Background(), m)
+	require.NoError(t, err)
+	assert.False(t, run.Integration)
+	add, shell := run.Results[0], run.Results[1]
+	assert.False(t, add.Passed, "the tagged test is not built, and counts as skipped, which checked nothing")
+	assert.Equal(t, []string{"TestAddTagged"}, add.Skipped())
+	assert.Empty(t, add.Failed())
+	assert.False(t, shell.Passed, "every test was skipped")
+	assert.Equal(t, []string{"TestShell"}, shell.Skipped())
+
+	run, err = GradeWith(context.Background(), m, Options{Integration: true})
+	require.NoError(t, err)
+	assert.True(t, run.Integration)
+	add, shell = run.Results[0], run.Results[1]
+	assert.False(t, add.Passed)
+	assert.Contains(t, add.Tests[1].Output, "tagged")
+	assert.True(t, shell.Passed)
+	assert.Empty(t, shell.Skipped())
+}
+
+func TestGradeParallel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	files := map[string]string{
+		"modules/01-demo/exercises/count.go": "package exercises\n\nimport \"sync/atomic\"\n\n// Running counts the tests running; it is shared by all of them.\nvar Running atomic.Int32\n",
+		"modules/01-demo/exercises/count_test.go": `package exercises
+
+import (
+	"testing"
+	"time"
+)
+
+func alone(t *testing.T) {
+	t.Parallel()
+	Running.Add(1)
+	defer Running.Add(-1)
+	time.Sleep(50 * time.Millisecond)
+	if n := Running.Load(); n != 1 {
+		t.Fatalf("%d tests running", n)
+	}
+}
+
+func TestA(t *testing.T) { alone(t) }
+func TestB(t *testing.T) { alone(t) }
+`,
+	}
+	// With one CPU, go test runs one parallel test at a time by default.
+	opts := Options{Env: []string{"GOMAXPROCS=1"}}
+	run, err := GradeWith(context.Background(), writeModule(t, files), opts)
+	require.NoError(t, err)
+	assert.True(t, run.Passed(), "one test at a time")
+
+	files["modules/01-demo/module.json"] = `{"parallel": true}`
+	run, err = GradeWith(context.Background(), writeModule(t, files), opts)
+	require.NoError(t, err)
+	assert.False(t, run.Passed(), "the tests run at once")
+	assert.Contains(t, run.Results[0].Tests[0].Output+run.Results[0].Tests[1].Output, "2 tests running")
+}
+
+func TestGradeRaceRequired(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	files := map[string]string{
+		"modules/01-demo/module.json":           `{"race": true}`,
+		"modules/01-demo/exercises/add.go":      "package exercises\n\nfunc Add(a, b int) int { return a + b }\n",
+		"modules/01-demo/exercises/add_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {}\n",
+	}
+	// Without cgo, go test cannot build with -race.
+	opts := Options{Env: []string{"CGO_ENABLED=0"}}
+	run, err := GradeWith(context.Background(), writeModule(t, files), opts)
+	require.NoError(t, err)
+	assert.False(t, run.Race, "graded without the race detector")
+	assert.False(t, run.RaceUnavailable)
+	assert.True(t, run.Passed())
+
+	files["modules/01-demo/module.json"] = `{"race_required": true}`
+	run, err = GradeWith(context.Background(), writeModule(t, files), opts)
+	require.NoError(t, err)
+	assert.True(t, run.RaceUnavailable)
+	assert.Contains(t, run.BuildOutput, "-race")
+	assert.False(t, run.Passed(), "not graded at all")
+}
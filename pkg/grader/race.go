@@ -0,0 +1,156 @@
+package grader
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Race is a data race that the race detector reported during a test: two
+// goroutines accessed the same memory, at least one of them writing,
+// without synchronizing.
+type Race struct {
+	Access   Access // the access that found the race
+	Previous Access // the earlier access it conflicts with
+}
+
+// Access is one side of a Race.
+type Access struct {
+	Op        string  // "write" or "read", as the report words it
+	Goroutine int     // 0 for the main goroutine
+	Stack     []Frame // innermost call first
+}
+
+// Frame is one call in the stack of an Access.
+type Frame struct {
+	Func string // e.g. "example.com/m/exercises.(*Counter).Inc"
+	File string
+	Line int
+}
+
+var (
+	// "Write at 0x00c000014148 by goroutine 8:"
+	// "Previous read at 0x00c000014148 by main goroutine:"
+	accessLine = regexp.MustCompile(`^(?:Previous )?([A-Za-z ]+) at 0x[0-9a-f]+ by (?:goroutine (\d+)|main goroutine):$`)
+	// "      /src/m/exercises/counter.go:12 +0x64"
+	fileLine = regexp.MustCompile(`^\s+(\S.*\.go):(\d+)(?: \+0x[0-9a-f]+)?$`)
+)
+
+// ParseRaces returns the data races reported in a test's output.
+func ParseRaces(output string) []Race {
+	var races []Race
+	var accesses []Access
+	inRace, inAccess := false, false
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case line == "WARNING: DATA RACE":
+			inRace, inAccess, accesses = true, false, nil
+		case !inRace:
+		case strings.HasPrefix(line, "=================="):
+			if len(accesses) >= 2 {
+				races = append(races, Race{Access: accesses[0], Previous: accesses[1]})
+			}
+			inRace, inAccess = false, false
+		case accessLine.MatchString(line):
+			m := accessLine.FindStringSubmatch(line)
+			g, _ := strconv.Atoi(m[2])
+			accesses = append(accesses, Access{Op: strings.ToLower(m[1]), Goroutine: g})
+			inAccess = true
+		case strings.TrimSpace(line) == "":
+			inAccess = false
+		case !inAccess:
+			// "Goroutine 8 (running) created at:" and its stack
+		case fileLine.MatchString(line):
+			m := fileLine.FindStringSubmatch(line)
+			a := &accesses[len(accesses)-1]
+			if n := len(a.Stack); n > 0 && a.Stack[n-1].File == "" {
+				a.Stack[n-1].File = m[1]
+				a.Stack[n-1].Line, _ = strconv.Atoi(m[2])
+			}
+		default:
+			a := &accesses[len(accesses)-1]
+			a.Stack = append(a.Stack, Frame{Func: strings.TrimSuffix(strings.TrimSpace(line), "()")})
+		}
+	}
+	return races
+}
+
+// Site returns the frame that places the access in a learner's code: the
+// innermost one in dir, preferring the exercises to their tests, or the
+// innermost frame of all if none is in dir.
+func (a Access) Site(dir string) Frame {
+	real, _ := filepath.EvalSymlinks(dir)
+	var test, first *Frame
+	for i := range a.Stack {
+		f := &a.Stack[i]
+		if first == nil {
+			first = f
+		}
+		if d := filepath.Dir(f.File); f.File == "" || (d != dir && d != real) {
+			continue
+		}
+		if !strings.HasSuffix(f.File, "_test.go") {
+			return *f
+		}
+		if test == nil {
+			test = f
+		}
+	}
+	switch {
+	case test != nil:
+		return *test
+	case first != nil:
+		return *first
+	}
+	return Frame{}
+}
+
+// Summary describes r in a line, with file names relative to dir, such as
+//
+//	write in (*Counter).Inc at counter.go:12 races with read in (*Counter).Get at counter.go:17
+func (r Race) Summary(dir string) string {
+	return describe(r.Access, dir) + " races with " + describe(r.Previous, dir)
+}
+
+func describe(a Access, dir string) string {
+	f := a.Site(dir)
+	file := f.File
+	if rel, err := filepath.Rel(dir, file); err == nil && !strings.HasPrefix(rel, "..") {
+		file = rel
+	}
+	return fmt.Sprintf("%s in %s at %s:%d", a.Op, shortFunc(f.Func), file, f.Line)
+}
+
+// shortFunc drops the import path and package name from a function name:
+// "example.com/m/exercises.(*Counter).Inc" becomes "(*Counter).Inc".
+func shortFunc(name string) string {
+	name = name[strings.LastIndex(name, "/")+1:]
+	if _, rest, ok := strings.Cut(name, "."); ok {
+		return rest
+	}
+	return name
+}
+
+// Summaries returns the Summary of each race, leaving out repeats: one
+// unsynchronized line often races on every field it touches.
+func Summaries(races []Race, dir string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, r := range races {
+		s := r.Summary(dir)
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// raceUnsupported reports whether go test failed because this toolchain
+// cannot build with the race detector, e.g. without cgo.
+func raceUnsupported(output string) bool {
+	return strings.Contains(output, "-race requires cgo") || strings.Contains(output, "-race is not supported")
+}
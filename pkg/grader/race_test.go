@@ -0,0 +1,150 @@
+package grader
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleRace is the output of a test that raced, as go test -race prints
+// it, shortened.
+const sampleRace = `=== RUN   TestConcurrentReads
+==================
+WARNING: DATA RACE
+Write at 0x00c000083d10 by goroutine 9:
+  reflect.Value.SetInt()
+      /usr/local/go/src/reflect/value.go:2254 +0xb7
+  encoding/json.Unmarshal()
+      /usr/local/go/src/encoding/json/v2_decode.go:111 +0x145
+  example.com/course/modules/42-config/exercises.(*Reloader).Reload()
+      /src/modules/42-config/exercises/exercise1_torn.go:60 +0x146
+  example.com/course/modules/42-config/exercises.TestConcurrentReads.func1()
+      /src/modules/42-config/exercises/exercise1_torn_test.go:76 +0x164
+
+Previous read at 0x00c000083d10 by goroutine 12:
+  example.com/course/modules/42-config/exercises.TestConcurrentReads.func2()
+      /src/modules/42-config/exercises/exercise1_torn_test.go:85 +0xfe
+
+Goroutine 9 (running) created at:
+  example.com/course/modules/42-config/exercises.TestConcurrentReads()
+      /src/modules/42-config/exercises/exercise1_torn_test.go:73 +0x186
+  testing.tRunner()
+      /usr/local/go/src/testing/testing.go:2193 +0x21c
+==================
+==================
+WARNING: DATA RACE
+Write at 0x00c000083d18 by goroutine 9:
+  example.com/course/modules/42-config/exercises.(*Reloader).Reload()
+      /src/modules/42-config/exercises/exercise1_torn.go:60 +0x146
+
+Previous read at 0x00c000083d18 by main goroutine:
+  example.com/course/modules/42-config/exercises.TestConcurrentReads.func2()
+      /src/modules/42-config/exercises/exercise1_torn_test.go:85 +0x117
+==================
+    testing.go:1865: race detected during execution of test
+--- FAIL: TestConcurrentReads (0.01s)
+`
+
+const sampleDir = "/src/modules/42-config/exercises"
+
+func TestParseRaces(t *testing.T) {
+	races := ParseRaces(sampleRace)
+	require.Len(t, races, 2)
+
+	r := races[0]
+	assert.Equal(t, "write", r.Access.Op)
+	assert.Equal(t, 9, r.Access.Goroutine)
+	require.Len(t, r.Access.Stack, 4)
+	assert.Equal(t, Frame{
+		Func: "example.com/course/modules/42-config/exercises.(*Reloader).Reload",
+		File: sampleDir + "/exercise1_torn.go",
+		Line: 60,
+	}, r.Access.Stack[2])
+	assert.Equal(t, "read", r.Previous.Op)
+	assert.Equal(t, 12, r.Previous.Goroutine)
+	require.Len(t, r.Previous.Stack, 1, "the goroutine's creation is not part of the access")
+
+	assert.Equal(t, 0, races[1].Previous.Goroutine, "main goroutine")
+	assert.Empty(t, ParseRaces("=== RUN   TestAdd\n--- PASS: TestAdd (0.00s)\n"))
+}
+
+func TestRaceSummary(t *testing.T) {
+	races := ParseRaces(sampleRace)
+	assert.Equal(t,
+		"write in (*Reloader).Reload at exercise1_torn.go:60 races with read in TestConcurrentReads.func2 at exercise1_torn_test.go:85",
+		races[0].Summary(sampleDir))
+	assert.Equal(t, []string{races[0].Summary(sampleDir)}, Summaries(races, sampleDir), "the same lines race twice")
+
+	assert.Equal(t, "reflect.Value.SetInt", races[0].Access.Site("/elsewhere").Func, "no frame in dir")
+}
+
+func TestParseEventsRaces(t *testing.T) {
+	var events strings.Builder
+	for _, line := range strings.SplitAfter(sampleRace, "\n") {
+		if line != "" {
+			events.WriteString(`{"Action":"output","Test":"TestConcurrentReads","Output":` + quote(line) + "}\n")
+		}
+	}
+	events.WriteString(`{"Action":"fail","Test":"TestConcurrentReads","Elapsed":0.01}` + "\n")
+	tests, _, err := ParseEvents(strings.NewReader(events.String()))
+	require.NoError(t, err)
+	assert.Len(t, tests["TestConcurrentReads"].Races, 2)
+}
+
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
+
+func TestRaceUnsupported(t *testing.T) {
+	assert.True(t, raceUnsupported("go: -race requires cgo; enable cgo by setting CGO_ENABLED=1"))
+	assert.True(t, raceUnsupported("-race is not supported on js/wasm"))
+	assert.False(t, raceUnsupported("./counter.go:3:1: syntax error"))
+}
+
+func TestGradeRace(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test -race")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	m := writeModule(t, map[string]string{
+		"modules/03-demo/module.json": `{"race": true}`,
+		"modules/03-demo/exercises/counter.go": `package exercises
+
+import "sync"
+
+func Count(n int) int {
+	total := 0
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			total++
+		}()
+	}
+	wg.Wait()
+	return total
+}
+`,
+		"modules/03-demo/exercises/counter_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestCount(t *testing.T) {\n\tCount(100)\n}\n",
+	})
+
+	run, err := Grade(context.Background(), m)
+	require.NoError(t, err)
+	if !run.Race {
+		t.Skip("the race detector is not available")
+	}
+	require.Len(t, run.Results, 1)
+	res := run.Results[0]
+	assert.False(t, res.Passed, "a race fails a test without assertions")
+	require.NotEmpty(t, res.Races())
+	assert.Contains(t, Summaries(res.Races(), m.Dir+"/exercises")[0], "Count.func1 at counter.go:12")
+}
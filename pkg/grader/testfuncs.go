@@ -0,0 +1,65 @@
+package grader
+
+import (
+	"go/ast"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// testFuncs returns the names of the Test functions and fuzz targets
+// declared in f, using the same rule as `go test`: TestXxx or FuzzXxx
+// where Xxx does not start with a lowercase letter, with one *testing.T
+// or *testing.F parameter and no receiver. A fuzz target runs its seed
+// corpus with the tests.
+func testFuncs(f *ast.File) []string {
+	var names []string
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil {
+			continue
+		}
+		param := ""
+		switch {
+		case isTestName(fn.Name.Name):
+			param = "T"
+		case isFuzzName(fn.Name.Name):
+			param = "F"
+		default:
+			continue
+		}
+		params := fn.Type.Params.List
+		if len(params) != 1 || len(params[0].Names) > 1 {
+			continue
+		}
+		star, ok := params[0].Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		if sel, ok := star.X.(*ast.SelectorExpr); ok && sel.Sel.Name == param {
+			names = append(names, fn.Name.Name)
+		}
+	}
+	return names
+}
+
+func isTestName(name string) bool {
+	return hasTestPrefix(name, "Test")
+}
+
+// isFuzzName reports whether name is that of a fuzz target.
+func isFuzzName(name string) bool {
+	return hasTestPrefix(name, "Fuzz")
+}
+
+func hasTestPrefix(name, prefix string) bool {
+	rest, ok := strings.CutPrefix(name, prefix)
+	if !ok {
+		return false
+	}
+	if rest == "" {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(rest)
+	return !unicode.IsLower(r)
+}
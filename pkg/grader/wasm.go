@@ -0,0 +1,193 @@
+package grader
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+)
+
+// BuildWasm compiles the tests of the module's exercises to a WebAssembly
+// test binary. Like BuildOutput in a Run, buildOutput holds the compiler
+// errors if the package does not build; err is for failures to run the
+// compiler at all.
+func BuildWasm(ctx context.Context, m *course.Module) (wasm []byte, buildOutput string, err error) {
+	tmp, err := os.MkdirTemp("", "learngo-wasm-")
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.RemoveAll(tmp)
+	out := filepath.Join(tmp, "exercises.test.wasm")
+
+	cmd := exec.CommandContext(ctx, "go", "test", "-c", "-o", out, ".")
+	cmd.Dir = filepath.Join(m.Dir, "exercises")
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	output, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return nil, strings.TrimSpace(string(output)), nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("running go test -c: %w", err)
+	}
+	wasm, err = os.ReadFile(out)
+	return wasm, "", err
+}
+
+// BuildWasmProgram compiles the main package in dir to WebAssembly for a
+// web page, as BuildWasm does the tests.
+func BuildWasmProgram(ctx context.Context, dir string) (wasm []byte, buildOutput string, err error) {
+	tmp, err := os.MkdirTemp("", "learngo-wasm-")
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.RemoveAll(tmp)
+	out := filepath.Join(tmp, "main.wasm")
+
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", out, ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	output, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return nil, strings.TrimSpace(string(output)), nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("running go build: %w", err)
+	}
+	wasm, err = os.ReadFile(out)
+	return wasm, "", err
+}
+
+// WasmExec returns the JavaScript support file that runs Go WebAssembly
+// in a browser. It comes with the Go toolchain, and has to be the one of
+// the toolchain that built the binary.
+func WasmExec(ctx context.Context) ([]byte, error) {
+	out, err := exec.CommandContext(ctx, "go", "env", "GOROOT").Output()
+	if err != nil {
+		return nil, fmt.Errorf("running go env: %w", err)
+	}
+	goroot := strings.TrimSpace(string(out))
+	// lib/wasm since Go 1.24, misc/wasm before.
+	data, err := os.ReadFile(filepath.Join(goroot, "lib", "wasm", "wasm_exec.js"))
+	if errors.Is(err, os.ErrNotExist) {
+		data, err = os.ReadFile(filepath.Join(goroot, "misc", "wasm", "wasm_exec.js"))
+	}
+	return data, err
+}
+
+// RunPattern returns the -test.run pattern that selects exactly the named
+// tests.
+func RunPattern(tests []string) string {
+	quoted := make([]string, len(tests))
+	for i, name := range tests {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	return "^(" + strings.Join(quoted, "|") + ")$"
+}
+
+// BrowserTests returns tests without the fuzz targets, which cannot run
+// in a browser: they read their seed corpus from testdata, and a browser
+// has no file system.
+func BrowserTests(tests []string) []string {
+	var out []string
+	for _, name := range tests {
+		if !isFuzzName(name) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// GradeOutput grades the -test.v output of a test binary of the module
+// that ran somewhere else, such as one from BuildWasm in a browser. Tests
+// that did not print a result failed, except fuzz targets, which are left
+// out (see BrowserTests), and integration tests, which BuildWasm does not
+// build and count as skipped; if the binary ran only some tests, only the
+// exercises they belong to mean anything.
+func GradeOutput(m *course.Module, verbose io.Reader) (*Run, error) {
+	owners, err := TestsByExercise(m)
+	if err != nil {
+		return nil, err
+	}
+	for id, tests := range owners {
+		owners[id] = BrowserTests(tests)
+	}
+	tagged, err := IntegrationTests(m)
+	if err != nil {
+		return nil, err
+	}
+	tests, _, err := ParseVerbose(verbose)
+	if err != nil {
+		return nil, err
+	}
+	run := &Run{Module: m.ID}
+	for _, t := range tests {
+		run.Duration += t.Elapsed
+	}
+	run.attribute(m, owners, tagged, tests)
+	return run, nil
+}
+
+// verboseResult matches the result line of a top-level test in -test.v
+// output, e.g. "--- FAIL: TestAdd (0.01s)".
+var verboseResult = regexp.MustCompile(`^--- (PASS|FAIL|SKIP): (\S+) \(([0-9.]+s)\)$`)
+
+// ParseVerbose reads the -test.v output of a test binary and returns the
+// result of every top-level test, plus any output not attributed to a
+// test, like ParseEvents. A test's output runs from its "=== RUN" line to
+// its result line and the indented lines under it.
+func ParseVerbose(r io.Reader) (map[string]*TestResult, string, error) {
+	tests := make(map[string]*TestResult)
+	var pkgOutput strings.Builder
+	var current *TestResult // test the next lines belong to
+	running := false        // current has not printed its result yet
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if f := strings.Fields(line); len(f) == 3 && f[0] == "===" {
+			// "=== RUN   TestAdd/sub", also PAUSE, CONT, and NAME.
+			name, _, _ := strings.Cut(f[2], "/")
+			if _, ok := tests[name]; !ok {
+				tests[name] = &TestResult{Name: name}
+			}
+			current, running = tests[name], true
+			current.Output += line + "\n"
+			continue
+		}
+		if m := verboseResult.FindStringSubmatch(line); m != nil {
+			name, _, _ := strings.Cut(m[2], "/")
+			t, ok := tests[name]
+			if !ok {
+				t = &TestResult{Name: name}
+				tests[name] = t
+			}
+			t.Passed = m[1] == "PASS"
+			t.Skipped = m[1] == "SKIP"
+			t.Elapsed, _ = time.ParseDuration(m[3])
+			t.Output += line + "\n"
+			current, running = t, false
+			continue
+		}
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		if current != nil && (running || indented) {
+			current.Output += line + "\n"
+			continue
+		}
+		current = nil
+		if strings.TrimSpace(line) != "" {
+			pkgOutput.WriteString(line + "\n")
+		}
+	}
+	return tests, pkgOutput.String(), sc.Err()
+}
@@ -0,0 +1,161 @@
+package grader
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleVerbose = `=== RUN   TestAdd
+--- PASS: TestAdd (0.25s)
+=== RUN   TestSub
+=== RUN   TestSub/negative
+    sub_test.go:9: got 3, want -1
+--- FAIL: TestSub (0.01s)
+    --- FAIL: TestSub/negative (0.00s)
+=== RUN   TestSlow
+    slow_test.go:4: slow
+--- SKIP: TestSlow (0.00s)
+=== RUN   TestPanics
+panic: runtime error: index out of range [recovered]
+	goroutine 7 [running]:
+FAIL
+`
+
+func TestParseVerbose(t *testing.T) {
+	tests, pkgOutput, err := ParseVerbose(strings.NewReader(sampleVerbose))
+	require.NoError(t, err)
+	require.Len(t, tests, 4)
+
+	assert.True(t, tests["TestAdd"].Passed)
+	assert.Equal(t, 250*time.Millisecond, tests["TestAdd"].Elapsed)
+
+	sub := tests["TestSub"]
+	assert.False(t, sub.Passed)
+	assert.Contains(t, sub.Output, "got 3, want -1", "subtest output rolls up")
+	assert.Contains(t, sub.Output, "--- FAIL: TestSub/negative")
+
+	assert.True(t, tests["TestSlow"].Skipped)
+	assert.False(t, tests["TestPanics"].Passed, "no result line: it crashed")
+	assert.Contains(t, tests["TestPanics"].Output, "index out of range")
+	assert.Empty(t, pkgOutput, "FAIL printed while TestPanics was running")
+
+	_, pkgOutput, err = ParseVerbose(strings.NewReader("=== RUN   TestAdd\n--- PASS: TestAdd (0.00s)\n    extra\nPASS\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "PASS\n", pkgOutput)
+}
+
+func TestRunPattern(t *testing.T) {
+	re := regexp.MustCompile(RunPattern([]string{"TestAdd", "TestSub"}))
+	assert.True(t, re.MatchString("TestAdd"))
+	assert.True(t, re.MatchString("TestSub"))
+	assert.False(t, re.MatchString("TestAddMore"))
+}
+
+func TestGradeOutput(t *testing.T) {
+	m := writeModule(t, map[string]string{
+		"modules/01-demo/exercises/add.go":      "package exercises\n",
+		"modules/01-demo/exercises/add_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {}\n",
+		"modules/01-demo/exercises/sub.go":      "package exercises\n",
+		"modules/01-demo/exercises/sub_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestSub(t *testing.T) {}\nfunc TestSlow(t *testing.T) {}\n",
+	})
+	run, err := GradeOutput(m, strings.NewReader(sampleVerbose))
+	require.NoError(t, err)
+	require.Len(t, run.Results, 2)
+	assert.True(t, run.Results[0].Passed, "add")
+	assert.False(t, run.Results[1].Passed, "sub")
+	assert.Equal(t, 260*time.Millisecond, run.Duration)
+}
+
+func TestBuildWasm(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds for js/wasm")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	m := writeModule(t, map[string]string{
+		"modules/01-demo/exercises/add.go":      "package exercises\n\nfunc Add(a, b int) int { return a + b }\n",
+		"modules/01-demo/exercises/add_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {\n\tif Add(1, 2) != 3 {\n\t\tt.Fatal(\"wrong\")\n\t}\n}\n",
+		"modules/01-demo/exercises/sub.go":      "package exercises\n\nfunc Sub(a, b int) int { return a + b } // bug\n",
+		"modules/01-demo/exercises/sub_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestSub(t *testing.T) {\n\tif Sub(3, 1) != 2 {\n\t\tt.Fatal(\"wrong\")\n\t}\n}\n",
+	})
+	ctx := context.Background()
+	wasm, buildOutput, err := BuildWasm(ctx, m)
+	require.NoError(t, err)
+	require.Empty(t, buildOutput)
+	assert.True(t, bytes.HasPrefix(wasm, []byte("\x00asm")), "a WebAssembly module")
+
+	support, err := WasmExec(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, string(support), "globalThis.Go")
+
+	// Node.js stands in for the browser, if it is installed.
+	node, err := exec.LookPath("node")
+	if err != nil {
+		return
+	}
+	goroot, err := exec.Command("go", "env", "GOROOT").Output()
+	require.NoError(t, err)
+	runner := filepath.Join(strings.TrimSpace(string(goroot)), "lib", "wasm", "wasm_exec_node.js")
+	if _, err := os.Stat(runner); err != nil {
+		return
+	}
+	bin := filepath.Join(t.TempDir(), "exercises.test.wasm")
+	require.NoError(t, os.WriteFile(bin, wasm, 0o644))
+	out, _ := exec.Command(node, runner, bin, "-test.v").Output()
+	run, err := GradeOutput(m, bytes.NewReader(out))
+	require.NoError(t, err)
+	require.Len(t, run.Results, 2)
+	assert.True(t, run.Results[0].Passed, "add")
+	assert.False(t, run.Results[1].Passed, "sub")
+	assert.Contains(t, run.Results[1].Tests[0].Output, "wrong")
+}
+
+func TestBuildWasmFailure(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds for js/wasm")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	m := writeModule(t, map[string]string{
+		"modules/01-demo/exercises/add.go":      "package exercises\n\nfunc Add(a, b int) int { return a + }\n",
+		"modules/01-demo/exercises/add_test.go": "package exercises\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {}\n",
+	})
+	wasm, buildOutput, err := BuildWasm(context.Background(), m)
+	require.NoError(t, err)
+	assert.Nil(t, wasm)
+	assert.Contains(t, buildOutput, "add.go")
+}
+
+func TestBuildWasmProgram(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds for js/wasm")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	m := writeModule(t, map[string]string{
+		"modules/01-demo/examples/hello/main.go":  "package main\n\nimport \"syscall/js\"\n\nfunc main() {\n\tjs.Global().Get(\"console\").Call(\"log\", \"hello\")\n}\n",
+		"modules/01-demo/examples/broken/main.go": "package main\n\nfunc main() { return 1 }\n",
+	})
+	wasm, buildOutput, err := BuildWasmProgram(context.Background(), filepath.Join(m.Dir, "examples", "hello"))
+	require.NoError(t, err)
+	require.Empty(t, buildOutput)
+	assert.True(t, bytes.HasPrefix(wasm, []byte("\x00asm")), "a WebAssembly module")
+
+	wasm, buildOutput, err = BuildWasmProgram(context.Background(), filepath.Join(m.Dir, "examples", "broken"))
+	require.NoError(t, err)
+	assert.Nil(t, wasm)
+	assert.Contains(t, buildOutput, "main.go")
+}
@@ -0,0 +1,298 @@
+// Package headless runs Go WebAssembly programs written for a web page
+// without a browser, so that tests can use the page the way a person
+// would: type into a field, pick an option, click a button, and read what
+// the program wrote back. Module 23 tests its calculators with it.
+//
+// The program runs in Node.js, next to a stand-in for the DOM made from
+// the page's HTML. Only elements with an id are there, and only what small
+// pages use: value, textContent, className, hidden, disabled, the options
+// of a select, and event listeners added with addEventListener. There is
+// no layout, CSS, or network:
+//
+//	page := headless.Load(t, "calculator")
+//	require.NoError(t, page.Set("shape", "circle"))
+//	require.NoError(t, page.Set("a", "2"))
+//	result, err := page.Element("result")
+//	require.NoError(t, err)
+//	assert.Equal(t, "Area: 12.57", result.Text)
+package headless
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// PageFile is the page a program directory holds next to its Go files.
+const PageFile = "index.html"
+
+//go:embed runner.js
+var runnerJS []byte
+
+// Element is the state of an element of the page.
+type Element struct {
+	Tag       string   `json:"tag"`
+	ID        string   `json:"id"`
+	Value     string   `json:"value"`
+	Text      string   `json:"text"` // textContent
+	Class     string   `json:"class"`
+	Hidden    bool     `json:"hidden"`
+	Disabled  bool     `json:"disabled"`
+	Listeners []string `json:"listeners"` // event types with a listener, sorted
+}
+
+// Listens reports whether the element has a listener for the event type.
+func (e Element) Listens(event string) bool {
+	for _, l := range e.Listeners {
+		if l == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Build compiles the main package in dir to WebAssembly for a browser,
+// GOOS=js GOARCH=wasm, writing it to out. The error holds the compiler's
+// output if the package does not build.
+func Build(ctx context.Context, dir, out string) error {
+	abs, err := filepath.Abs(out)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", abs, ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("building %s for js/wasm: %w\n%s", dir, err, bytes.TrimSpace(output))
+	}
+	return nil
+}
+
+// WasmExec returns the path of the toolchain's wasm_exec.js, which every
+// Go WebAssembly program needs on its page.
+func WasmExec(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, "go", "env", "GOROOT").Output()
+	if err != nil {
+		return "", fmt.Errorf("running go env: %w", err)
+	}
+	goroot := strings.TrimSpace(string(out))
+	// lib/wasm since Go 1.24, misc/wasm before.
+	for _, dir := range []string{"lib", "misc"} {
+		path := filepath.Join(goroot, dir, "wasm", "wasm_exec.js")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no wasm_exec.js in %s", goroot)
+}
+
+// Page is a program running on its page.
+type Page struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Scanner
+	output lockedBuffer
+	tmp    string
+	exited string // why the program stopped, once it has
+}
+
+// Open starts the WebAssembly program in wasm on the page in html. It
+// returns once the program's main function has set the page up and
+// blocked, or returned.
+func Open(ctx context.Context, wasm, html string) (*Page, error) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		return nil, err
+	}
+	wasmExec, err := WasmExec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.MkdirTemp("", "learngo-headless-")
+	if err != nil {
+		return nil, err
+	}
+	runner := filepath.Join(tmp, "runner.js")
+	if err := os.WriteFile(runner, runnerJS, 0o644); err != nil {
+		os.RemoveAll(tmp)
+		return nil, err
+	}
+
+	p := &Page{tmp: tmp}
+	p.cmd = exec.CommandContext(ctx, node, runner, wasmExec, wasm, html)
+	p.cmd.Stderr = &p.output
+	p.cmd.WaitDelay = time.Second
+	if p.stdin, err = p.cmd.StdinPipe(); err != nil {
+		os.RemoveAll(tmp)
+		return nil, err
+	}
+	stdout, err := p.cmd.StdoutPipe()
+	if err != nil {
+		os.RemoveAll(tmp)
+		return nil, err
+	}
+	p.stdout = bufio.NewScanner(stdout)
+	p.stdout.Buffer(nil, 1<<20)
+	if err := p.cmd.Start(); err != nil {
+		os.RemoveAll(tmp)
+		return nil, err
+	}
+	if _, err := p.reply(); err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// Load builds the program in dir, opens it on dir's PageFile, and closes
+// it when the test ends. It skips the test in -short mode and where the go
+// command or Node.js is missing.
+func Load(t testing.TB, dir string) *Page {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("builds and runs WebAssembly")
+	}
+	for _, tool := range []string{"go", "node"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s not in PATH", tool)
+		}
+	}
+	wasm := filepath.Join(t.TempDir(), "main.wasm")
+	if err := Build(context.Background(), dir, wasm); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Open(context.Background(), wasm, filepath.Join(dir, PageFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if out := p.Output(); out != "" {
+			t.Logf("the program printed:\n%s", out)
+		}
+		p.Close()
+	})
+	return p
+}
+
+// Element returns the state of the element with the given id.
+func (p *Page) Element(id string) (Element, error) {
+	return p.do("get", id, "")
+}
+
+// Set sets the value of an input, textarea, or select, as if typed or
+// picked, and fires its input and change events.
+func (p *Page) Set(id, value string) error {
+	_, err := p.do("set", id, value)
+	return err
+}
+
+// Click fires a click event on an element. Disabled elements do not get
+// clicked.
+func (p *Page) Click(id string) error {
+	_, err := p.do("click", id, "")
+	return err
+}
+
+// Submit fires a submit event on a form. A browser would then leave the
+// page, so Submit fails unless a listener calls preventDefault.
+func (p *Page) Submit(id string) error {
+	_, err := p.do("submit", id, "")
+	return err
+}
+
+// Exited returns why the program stopped, or "" if it is still running.
+// A program for a page has to keep running for its event listeners to
+// work.
+func (p *Page) Exited() string {
+	return p.exited
+}
+
+// Output returns what the program has printed so far.
+func (p *Page) Output() string {
+	return p.output.String()
+}
+
+// Close stops the program.
+func (p *Page) Close() error {
+	p.stdin.Close()
+	err := p.cmd.Wait()
+	os.RemoveAll(p.tmp)
+	return err
+}
+
+func (p *Page) do(op, id, value string) (Element, error) {
+	cmd, err := json.Marshal(map[string]string{"op": op, "id": id, "value": value})
+	if err != nil {
+		return Element{}, err
+	}
+	if _, err := fmt.Fprintf(p.stdin, "%s\n", cmd); err != nil {
+		return Element{}, p.stopped(err)
+	}
+	r, err := p.reply()
+	if err != nil {
+		return Element{}, err
+	}
+	return r.Element, nil
+}
+
+type reply struct {
+	Element Element `json:"element"`
+	Error   string  `json:"error"`
+	Exited  string  `json:"exited"`
+}
+
+func (p *Page) reply() (reply, error) {
+	var r reply
+	if !p.stdout.Scan() {
+		return r, p.stopped(p.stdout.Err())
+	}
+	if err := json.Unmarshal(p.stdout.Bytes(), &r); err != nil {
+		return r, fmt.Errorf("headless runner: %w", err)
+	}
+	p.exited = r.Exited
+	if r.Error == "" {
+		return r, nil
+	}
+	if r.Exited != "" && strings.Contains(r.Error, "Go program has already exited") {
+		return r, fmt.Errorf("%s, and its event listeners no longer work: %s", r.Exited, r.Error)
+	}
+	return r, errors.New(r.Error)
+}
+
+// stopped explains why the runner stopped answering.
+func (p *Page) stopped(err error) error {
+	if err == nil {
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("headless runner stopped: %w\n%s", err, p.output.String())
+}
+
+// lockedBuffer is a bytes.Buffer that exec may write while Output reads.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,51 @@
+package headless
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPage(t *testing.T) {
+	page := Load(t, "testdata/counter")
+
+	greeting, err := page.Element("greeting")
+	require.NoError(t, err)
+	assert.True(t, greeting.Hidden)
+	assert.Equal(t, "p", greeting.Tag)
+
+	color, err := page.Element("color")
+	require.NoError(t, err)
+	assert.Equal(t, "blue", color.Value, "the selected option")
+	assert.Error(t, page.Set("color", "green"), "not an option")
+	require.NoError(t, page.Set("color", "red"))
+
+	add, err := page.Element("add")
+	require.NoError(t, err)
+	assert.True(t, add.Listens("click"))
+	assert.False(t, add.Listens("input"))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, page.Click("add"))
+	}
+	count, err := page.Element("count")
+	require.NoError(t, err)
+	assert.Equal(t, "3", count.Text)
+
+	require.NoError(t, page.Set("name", "Gopher"))
+	greeting, err = page.Element("greeting")
+	require.NoError(t, err)
+	assert.False(t, greeting.Hidden)
+	assert.Equal(t, "Hello, Gopher", greeting.Text)
+
+	assert.ErrorContains(t, page.Click("off"), "disabled")
+	assert.ErrorContains(t, page.Click("missing"), `no element with id "missing"`)
+	assert.NoError(t, page.Submit("form"))
+	assert.ErrorContains(t, page.Submit("plain"), "preventDefault")
+	assert.Equal(t, "ready\n", page.Output())
+
+	assert.Empty(t, page.Exited())
+	require.NoError(t, page.Click("quit"))
+	assert.Contains(t, page.Exited(), "exited with code 0")
+	assert.ErrorContains(t, page.Click("add"), "no longer work")
+}
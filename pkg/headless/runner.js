@@ -0,0 +1,193 @@
+// runner.js runs a Go WebAssembly program written for a web page in
+// Node.js, with a stand-in for the part of the DOM such programs use: the
+// elements of the page that have an id, their values, text, and hidden and
+// disabled flags, and event listeners. It takes the paths of wasm_exec.js,
+// the program, and the page, then reads one command per line on stdin and
+// writes one JSON reply per line on stdout. Whatever the program prints
+// goes to stderr. See headless.go.
+"use strict";
+
+const fs = require("fs");
+const readline = require("readline");
+
+const [wasmExec, wasmFile, pageFile] = process.argv.slice(2);
+
+console.log = (...args) => process.stderr.write(args.join(" ") + "\n");
+console.error = console.warn = console.log;
+if (!globalThis.crypto) {
+  globalThis.crypto = require("crypto").webcrypto;
+}
+
+const attr = (attrs, name) => {
+  const m = attrs.match(new RegExp(`\\b${name}\\s*=\\s*"([^"]*)"`));
+  return m ? m[1] : null;
+};
+const flag = (attrs, name) => new RegExp(`(^|\\s)${name}(\\s|=|$)`).test(attrs);
+
+class Element {
+  constructor(tagName, attrs, text) {
+    this.tagName = tagName.toUpperCase();
+    this.id = attr(attrs, "id");
+    this.type = attr(attrs, "type") || (this.tagName === "BUTTON" ? "submit" : "");
+    this.value = attr(attrs, "value") || "";
+    this.textContent = text;
+    this.className = attr(attrs, "class") || "";
+    this.hidden = flag(attrs, "hidden");
+    this.disabled = flag(attrs, "disabled");
+    this.options = [];
+    this.listeners = {};
+  }
+
+  addEventListener(type, fn) {
+    (this.listeners[type] ||= []).push(fn);
+  }
+
+  removeEventListener(type, fn) {
+    this.listeners[type] = (this.listeners[type] || []).filter((f) => f !== fn);
+  }
+
+  // dispatch calls the listeners for an event and returns it.
+  dispatch(type) {
+    const event = {
+      type,
+      target: this,
+      currentTarget: this,
+      defaultPrevented: false,
+      preventDefault() {
+        this.defaultPrevented = true;
+      },
+    };
+    for (const fn of [...(this.listeners[type] || [])]) {
+      fn.call(this, event);
+    }
+    return event;
+  }
+
+  state() {
+    return {
+      tag: this.tagName.toLowerCase(),
+      id: this.id,
+      value: this.value,
+      text: this.textContent,
+      class: this.className,
+      hidden: this.hidden,
+      disabled: this.disabled,
+      listeners: Object.keys(this.listeners).filter((t) => this.listeners[t].length > 0).sort(),
+    };
+  }
+}
+
+// parse finds the elements of the page that have an id, and the options
+// of its selects.
+const parse = (html) => {
+  const elements = new Map();
+  const tag = /<([a-zA-Z][a-zA-Z0-9]*)\b([^>]*)>([^<]*)/g;
+  let select = null;
+  for (const m of html.matchAll(tag)) {
+    const [, name, attrs, text] = m;
+    if (name.toLowerCase() === "option" && select) {
+      const value = attr(attrs, "value") ?? text.trim();
+      select.options.push(value);
+      if (select.options.length === 1 || flag(attrs, "selected")) {
+        select.value = value;
+      }
+      continue;
+    }
+    if (name.toLowerCase() === "select") {
+      select = null;
+    }
+    if (attr(attrs, "id") === null) {
+      continue;
+    }
+    const el = new Element(name, attrs, text.trim());
+    if (el.tagName === "SELECT") {
+      select = el;
+    }
+    elements.set(el.id, el);
+  }
+  return elements;
+};
+
+const elements = parse(fs.readFileSync(pageFile, "utf8"));
+globalThis.document = {
+  getElementById: (id) => elements.get(id) ?? null,
+};
+
+let exited = null;
+
+const element = (id) => {
+  const el = elements.get(id);
+  if (!el) {
+    throw new Error(`the page has no element with id "${id}"`);
+  }
+  return el;
+};
+
+const commands = {
+  get: ({id}) => element(id).state(),
+
+  set: ({id, value}) => {
+    const el = element(id);
+    if (el.tagName === "SELECT" && !el.options.includes(value)) {
+      throw new Error(`#${id} has no option "${value}"`);
+    }
+    el.value = value;
+    el.dispatch("input");
+    el.dispatch("change");
+    return el.state();
+  },
+
+  click: ({id}) => {
+    const el = element(id);
+    if (el.disabled) {
+      throw new Error(`#${id} is disabled`);
+    }
+    el.dispatch("click");
+    return el.state();
+  },
+
+  submit: ({id}) => {
+    const el = element(id);
+    if (!el.dispatch("submit").defaultPrevented) {
+      throw new Error(`#${id} was submitted and the browser would load a new page: the submit listener must call preventDefault`);
+    }
+    return el.state();
+  },
+};
+
+const reply = (r) => process.stdout.write(JSON.stringify(r) + "\n");
+
+// settle lets timers and promises the program started run.
+const settle = () => new Promise((resolve) => setTimeout(resolve, 0));
+
+(async () => {
+  require(wasmExec);
+  const go = new Go();
+  go.argv = [wasmFile];
+  let code = 0;
+  go.exit = (c) => {
+    code = c;
+  };
+  const {instance} = await WebAssembly.instantiate(fs.readFileSync(wasmFile), go.importObject);
+  go.run(instance).then(() => {
+    exited = `the program exited with code ${code}`;
+  });
+  await settle();
+  reply({ready: true, exited});
+
+  for await (const line of readline.createInterface({input: process.stdin})) {
+    const cmd = JSON.parse(line);
+    try {
+      const state = commands[cmd.op](cmd);
+      await settle();
+      reply({element: cmd.op === "get" ? state : element(cmd.id).state(), exited});
+    } catch (err) {
+      await settle();
+      reply({error: err.message, exited});
+    }
+  }
+  process.exit(0);
+})().catch((err) => {
+  reply({error: err.message, exited});
+  process.exit(1);
+});
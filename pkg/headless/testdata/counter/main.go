@@ -0,0 +1,38 @@
+//go:build js && wasm
+
+// Command counter is a page for the headless tests: it counts clicks,
+// echoes the name typed, and exits when asked to.
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"syscall/js"
+)
+
+func main() {
+	doc := js.Global().Get("document")
+	count, name, greeting := doc.Call("getElementById", "count"), doc.Call("getElementById", "name"), doc.Call("getElementById", "greeting")
+	quit := make(chan struct{})
+
+	doc.Call("getElementById", "add").Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		n, _ := strconv.Atoi(count.Get("textContent").String())
+		count.Set("textContent", strconv.Itoa(n+1))
+		return nil
+	}))
+	name.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) any {
+		greeting.Set("textContent", "Hello, "+name.Get("value").String())
+		greeting.Set("hidden", false)
+		return nil
+	}))
+	doc.Call("getElementById", "form").Call("addEventListener", "submit", js.FuncOf(func(this js.Value, args []js.Value) any {
+		args[0].Call("preventDefault")
+		return nil
+	}))
+	doc.Call("getElementById", "quit").Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		close(quit)
+		return nil
+	}))
+	fmt.Println("ready")
+	<-quit
+}
@@ -0,0 +1,206 @@
+// Package i18n translates learngo and the course into other languages.
+//
+// Translations are message catalogs: one YAML file per locale in the
+// course's locales/ directory, named after the locale (de.yaml, pt-BR.yaml)
+// and read when learngo starts, so translating needs no Go and no rebuild.
+// A catalog maps English text to its translation; whatever it leaves out
+// stays English.
+//
+//	locale: de
+//	name: Deutsch
+//	messages:                  # learngo's output and the dashboard
+//	  "Testing %s...": "Teste %s..."
+//	hints:                     # go vet explanations, by analyzer
+//	  copylocks: Du hast einen Wert kopiert, der ein sync.Mutex enthält...
+//	exercises:                 # exercise doc comments, by exercise ID
+//	  01-basics/exercise1_fix_bugs:
+//	    intro: "EXERCISE: Behebe die Fehler in dieser Datei..."
+//	    decls:
+//	      CalculateSum: CalculateSum soll die Summe zweier Zahlen liefern.
+//
+// Messages are keyed by their English text as it appears in the source,
+// without leading and trailing newlines. Many are format strings: the
+// translation must use the same verbs (%s, %d, ...), though it may reorder
+// them with explicit argument indexes such as %[2]s.
+package i18n
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Env selects the language, e.g. LEARNGO_LANG=de. English needs no
+// catalog and is the default.
+const Env = "LEARNGO_LANG"
+
+// Dir is the directory of catalogs, relative to the course root.
+const Dir = "locales"
+
+// Catalog holds the translations for one locale. A nil *Catalog is
+// English: its methods return the text they are given.
+type Catalog struct {
+	Locale    string                  `yaml:"locale"`
+	Name      string                  `yaml:"name"`      // the language's own name for itself
+	Messages  map[string]string       `yaml:"messages"`  // by English text
+	Hints     map[string]string       `yaml:"hints"`     // by analyzer, e.g. "copylocks"
+	Exercises map[string]ExerciseText `yaml:"exercises"` // by exercise ID
+}
+
+// ExerciseText translates an exercise's documentation.
+type ExerciseText struct {
+	Intro string            `yaml:"intro"` // the EXERCISE: comment at the top of the file
+	Decls map[string]string `yaml:"decls"` // doc comments, by function or type name
+}
+
+// Lang returns the locale Env asks for, in the form catalogs are named
+// after: "de_DE.UTF-8" becomes "de-DE". It returns "" for English.
+func Lang() string {
+	lang := os.Getenv(Env)
+	if i := strings.IndexAny(lang, ".@"); i >= 0 {
+		lang = lang[:i] // drop the encoding and modifier
+	}
+	lang = strings.ReplaceAll(lang, "_", "-")
+	switch strings.ToLower(lang) {
+	case "", "c", "posix", "en":
+		return ""
+	}
+	return lang
+}
+
+// Load reads the catalog for locale from the course at root, falling back
+// from a regional locale to its language: de-AT, then de. It returns a nil
+// catalog for English, and an error wrapping fs.ErrNotExist if there is no
+// catalog for the locale.
+func Load(root, locale string) (*Catalog, error) {
+	if locale == "" {
+		return nil, nil
+	}
+	candidates := []string{locale}
+	if lang, _, ok := strings.Cut(locale, "-"); ok {
+		candidates = append(candidates, lang)
+	}
+	var err error
+	for _, name := range candidates {
+		var c *Catalog
+		c, err = LoadFile(filepath.Join(root, Dir, name+".yaml"))
+		if err == nil {
+			return c, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("no translation for %q in %s: %w", locale, Dir, err)
+}
+
+// LoadFile reads and checks one catalog file.
+func LoadFile(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Catalog
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true) // catch misspelled keys
+	if err := dec.Decode(&c); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if c.Locale == "" {
+		c.Locale = strings.TrimSuffix(filepath.Base(path), ".yaml")
+	}
+	for msg, tr := range c.Messages {
+		if strings.Trim(msg, "\n") != msg {
+			return nil, fmt.Errorf("%s: message %q: leave out leading and trailing newlines", path, msg)
+		}
+		if got, want := verbs(tr), verbs(msg); !slices.Equal(got, want) {
+			return nil, fmt.Errorf("%s: message %q: translation uses verbs %v, want %v", path, msg, got, want)
+		}
+	}
+	return &c, nil
+}
+
+// Lang returns the catalog's locale, or "en".
+func (c *Catalog) Lang() string {
+	if c == nil {
+		return "en"
+	}
+	return c.Locale
+}
+
+// T translates msg. Leading and trailing newlines are kept as they are,
+// so catalogs need not repeat them.
+func (c *Catalog) T(msg string) string {
+	if c == nil {
+		return msg
+	}
+	core := strings.Trim(msg, "\n")
+	tr, ok := c.Messages[core]
+	if !ok || core == "" {
+		return msg
+	}
+	start := strings.Index(msg, core)
+	return msg[:start] + tr + msg[start+len(core):]
+}
+
+// Hint translates the explanation of a go vet or staticcheck analyzer.
+func (c *Catalog) Hint(analyzer, english string) string {
+	if c == nil {
+		return english
+	}
+	if tr, ok := c.Hints[analyzer]; ok {
+		return tr
+	}
+	return english
+}
+
+// Prompt translates part of an exercise's documentation: the doc comment
+// of the declaration named decl, or its introduction if decl is "".
+func (c *Catalog) Prompt(exercise, decl, english string) string {
+	if c == nil {
+		return english
+	}
+	text, ok := c.Exercises[exercise]
+	if !ok {
+		return english
+	}
+	tr := text.Intro
+	if decl != "" {
+		tr = text.Decls[decl]
+	}
+	if tr == "" {
+		return english
+	}
+	return tr
+}
+
+// verbs returns the formatting verbs in format, sorted, ignoring flags,
+// widths, and argument indexes: "%-8s is %[1]d%%" gives [d s].
+func verbs(format string) []string {
+	var vs []string
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		j := i + 1
+		for j < len(format) && strings.IndexByte("+-# 0123456789.*[]", format[j]) >= 0 {
+			j++
+		}
+		if j == len(format) {
+			break
+		}
+		if format[j] != '%' {
+			vs = append(vs, format[j:j+1])
+		}
+		i = j
+	}
+	sort.Strings(vs)
+	return vs
+}
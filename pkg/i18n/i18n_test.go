@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCatalog = `locale: de
+name: Deutsch
+messages:
+  "Testing %s...": "Teste %s..."
+  "%d/%d exercises pass in %s.": "In %[3]s bestehen %[1]d/%[2]d Übungen."
+hints:
+  copylocks: Du hast eine Sperre kopiert.
+exercises:
+  01-basics/exercise1:
+    intro: Behebe die Fehler.
+    decls:
+      Sum: Sum addiert.
+`
+
+func writeCatalog(t *testing.T, name, data string) string {
+	t.Helper()
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, Dir), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, Dir, name), []byte(data), 0o644))
+	return root
+}
+
+func TestLang(t *testing.T) {
+	tests := map[string]string{
+		"":            "",
+		"en":          "",
+		"C":           "",
+		"de":          "de",
+		"de_DE.UTF-8": "de-DE",
+		"pt-BR":       "pt-BR",
+		"sr@latin":    "sr",
+	}
+	for env, want := range tests {
+		t.Setenv(Env, env)
+		assert.Equal(t, want, Lang(), "%s=%q", Env, env)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	root := writeCatalog(t, "de.yaml", testCatalog)
+	c, err := Load(root, "de-AT")
+	require.NoError(t, err, "falls back to the language")
+	assert.Equal(t, "de", c.Lang())
+	assert.Equal(t, "Deutsch", c.Name)
+
+	assert.Equal(t, "Testing 01-basics...\n", c.T("Testing 01-basics...\n"), "translate the format, not the result")
+	assert.Equal(t, "Teste %s...\n", c.T("Testing %s...\n"))
+	assert.Equal(t, "\nIn %[3]s bestehen %[1]d/%[2]d Übungen.\n", c.T("\n%d/%d exercises pass in %s.\n"))
+	assert.Equal(t, "Not translated", c.T("Not translated"))
+
+	assert.Equal(t, "Du hast eine Sperre kopiert.", c.Hint("copylocks", "You copied a lock."))
+	assert.Equal(t, "Use a pointer.", c.Hint("printf", "Use a pointer."))
+	assert.Equal(t, "Behebe die Fehler.", c.Prompt("01-basics/exercise1", "", "Fix the bugs."))
+	assert.Equal(t, "Sum addiert.", c.Prompt("01-basics/exercise1", "Sum", "Sum adds."))
+	assert.Equal(t, "Mean averages.", c.Prompt("01-basics/exercise1", "Mean", "Mean averages."))
+	assert.Equal(t, "Fix the bugs.", c.Prompt("01-basics/exercise2", "", "Fix the bugs."))
+}
+
+func TestLoadEnglish(t *testing.T) {
+	c, err := Load(t.TempDir(), "")
+	require.NoError(t, err)
+	assert.Nil(t, c)
+	assert.Equal(t, "en", c.Lang())
+	assert.Equal(t, "Testing %s...\n", c.T("Testing %s...\n"))
+	assert.Equal(t, "Fix the bugs.", c.Prompt("01-basics/exercise1", "", "Fix the bugs."))
+}
+
+func TestLoadMissing(t *testing.T) {
+	_, err := Load(t.TempDir(), "fr-CA")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	assert.ErrorContains(t, err, `no translation for "fr-CA"`)
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name, catalog, want string
+	}{
+		{"unknown key", "mesages: {}\n", "field mesages not found"},
+		{"missing verb", "messages:\n  \"Testing %s...\": \"Teste...\"\n", "translation uses verbs [], want [s]"},
+		{"wrong verb", "messages:\n  \"%d exercises\": \"%s Übungen\"\n", "translation uses verbs [s], want [d]"},
+		{"newline", "messages:\n  \"Why:\\n\": \"Warum:\\n\"\n", "leave out leading and trailing newlines"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeCatalog(t, "de.yaml", tt.catalog)
+			_, err := Load(root, "de")
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestVerbs(t *testing.T) {
+	assert.Equal(t, []string{"d", "s"}, verbs("%-8s is %[1]d%%"))
+	assert.Equal(t, []string{"f", "q", "v"}, verbs("%v %.1f %+q %"))
+	assert.Empty(t, verbs("100%% done"))
+}
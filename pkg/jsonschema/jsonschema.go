@@ -0,0 +1,329 @@
+// Package jsonschema validates JSON documents against schemas written in
+// a small part of JSON Schema: types, required and unexpected properties,
+// ranges for numbers, lengths for strings and arrays, and lists of
+// allowed values. It is enough to check the payloads of a REST API before
+// decoding them, and small enough to read.
+//
+//	var signup = jsonschema.MustParse(`{
+//		"type": "object",
+//		"required": ["email", "password"],
+//		"properties": {
+//			"email": {"type": "string", "minLength": 3},
+//			"password": {"type": "string", "minLength": 8}
+//		}
+//	}`)
+//
+//	if err := signup.Validate(body); err != nil { ... }
+//
+// Validate reports every problem in a document, not just the first, each
+// with the JSON Pointer of the value it is about, so that a client can
+// show them next to the fields of a form. Keywords this package does not
+// know are an error when the schema is parsed, rather than rules that
+// quietly never apply.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema is a parsed schema. The zero Schema accepts any document.
+type Schema struct {
+	// Type is "object", "array", "string", "number", "integer",
+	// "boolean", or "null". An integer is a number with no fraction, so
+	// 2.0 is one; every integer is also a number. Empty allows any type.
+	// Lists of types, such as ["string", "null"], are not supported.
+	Type string `json:"type,omitempty"`
+
+	// Properties are the schemas of an object's properties, by name.
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// Required lists the properties an object must have. Null counts as
+	// having one; a property's schema can rule null out with its type.
+	Required []string `json:"required,omitempty"`
+	// AdditionalProperties, if false, rules out properties not listed in
+	// Properties.
+	AdditionalProperties *bool `json:"additionalProperties,omitempty"`
+
+	// Items is the schema of every element of an array.
+	Items *Schema `json:"items,omitempty"`
+	// MinItems and MaxItems bound the length of an array.
+	MinItems *int `json:"minItems,omitempty"`
+	MaxItems *int `json:"maxItems,omitempty"`
+
+	// Minimum and Maximum bound a number, inclusively.
+	Minimum *float64 `json:"minimum,omitempty"`
+	Maximum *float64 `json:"maximum,omitempty"`
+
+	// MinLength and MaxLength bound the length of a string, counted in
+	// characters (runes), not bytes.
+	MinLength *int `json:"minLength,omitempty"`
+	MaxLength *int `json:"maxLength,omitempty"`
+
+	// Enum, if not empty, lists the values allowed.
+	Enum []any `json:"enum,omitempty"`
+}
+
+// types are the values of the type keyword.
+var types = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true,
+	"integer": true, "boolean": true, "null": true,
+}
+
+// Parse parses a schema. Unknown keywords and types are errors.
+func Parse(data []byte) (*Schema, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	dec.UseNumber()
+	var s Schema
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("jsonschema: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("jsonschema: data after the schema")
+	}
+	if err := s.check(""); err != nil {
+		return nil, fmt.Errorf("jsonschema: %w", err)
+	}
+	return &s, nil
+}
+
+// MustParse is like Parse, but panics if the schema does not parse. It
+// is for schemas in the source, as package-level variables.
+func MustParse(schema string) *Schema {
+	s, err := Parse([]byte(schema))
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// check reports the first mistake in s, which is at path in the schema.
+func (s *Schema) check(path string) error {
+	if s.Type != "" && !types[s.Type] {
+		return fmt.Errorf("%s: unknown type %q", pointer(path), s.Type)
+	}
+	if s.Minimum != nil && s.Maximum != nil && *s.Minimum > *s.Maximum {
+		return fmt.Errorf("%s: minimum %v is above maximum %v", pointer(path), *s.Minimum, *s.Maximum)
+	}
+	for _, name := range s.Required {
+		if s.AdditionalProperties != nil && !*s.AdditionalProperties && s.Properties[name] == nil {
+			return fmt.Errorf("%s: %q is required but not allowed", pointer(path), name)
+		}
+	}
+	for _, name := range sortedKeys(s.Properties) {
+		if s.Properties[name] == nil {
+			return fmt.Errorf("%s: no schema", pointer(path+"/properties/"+escape(name)))
+		}
+		if err := s.Properties[name].check(path + "/properties/" + escape(name)); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.check(path + "/items")
+	}
+	return nil
+}
+
+// Error is a way in which a document does not match a schema.
+type Error struct {
+	// Path is the JSON Pointer of the value, such as "/tags/2"; the
+	// empty string is the whole document.
+	Path    string
+	Message string
+}
+
+func (e *Error) Error() string { return pointer(e.Path) + ": " + e.Message }
+
+// Errors are all the ways in which a document does not match a schema.
+// Within an object, missing properties come first, then the others by
+// name; within an array, elements come in order.
+type Errors []*Error
+
+func (errs Errors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// pointer returns path for messages, where the empty path is unreadable.
+func pointer(path string) string {
+	if path == "" {
+		return "(document)"
+	}
+	return path
+}
+
+// escape escapes a property name for a JSON Pointer (RFC 6901).
+func escape(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// Validate checks the JSON document data against s. It returns Errors if
+// the document does not match, and another error if data is not one JSON
+// value.
+func (s *Schema) Validate(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("invalid JSON: data after the value")
+	}
+	var errs Errors
+	s.validate("", v, &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// validate adds the ways in which v, at path, does not match s to errs.
+func (s *Schema) validate(path string, v any, errs *Errors) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, &Error{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	if s.Type != "" && !hasType(v, s.Type) {
+		fail("is %s, want %s", typeOf(v), s.Type)
+		return
+	}
+	if len(s.Enum) > 0 && !contains(s.Enum, v) {
+		fail("is %s, want one of %s", encode(v), encode(s.Enum))
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, &Error{Path: path + "/" + escape(name), Message: "is required"})
+			}
+		}
+		for _, name := range sortedKeys(v) {
+			p := path + "/" + escape(name)
+			if prop := s.Properties[name]; prop != nil {
+				prop.validate(p, v[name], errs)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				*errs = append(*errs, &Error{Path: p, Message: "is not allowed"})
+			}
+		}
+	case []any:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			fail("has %d items, want at least %d", len(v), *s.MinItems)
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			fail("has %d items, want at most %d", len(v), *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(path+"/"+strconv.Itoa(i), item, errs)
+			}
+		}
+	case string:
+		n := utf8.RuneCountInString(v)
+		if s.MinLength != nil && n < *s.MinLength {
+			fail("has %d characters, want at least %d", n, *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			fail("has %d characters, want at most %d", n, *s.MaxLength)
+		}
+	case json.Number:
+		f, _ := v.Float64()
+		if s.Minimum != nil && f < *s.Minimum {
+			fail("is %s, want at least %v", v, *s.Minimum)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			fail("is %s, want at most %v", v, *s.Maximum)
+		}
+	}
+}
+
+// typeOf returns the type of a decoded value, as the type keyword names
+// it.
+func typeOf(v any) string {
+	switch v := v.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		if isInteger(v) {
+			return "integer"
+		}
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return "null"
+}
+
+// hasType reports whether v is of type typ.
+func hasType(v any, typ string) bool {
+	got := typeOf(v)
+	return got == typ || (got == "integer" && typ == "number")
+}
+
+// isInteger reports whether n has no fraction. A number too large for a
+// float64 is taken as an integer, as it has no fraction worth keeping.
+func isInteger(n json.Number) bool {
+	f, err := n.Float64()
+	return err != nil || f == math.Trunc(f)
+}
+
+// contains reports whether v equals one of values, comparing numbers by
+// value, so that 2 and 2.0 are equal.
+func contains(values []any, v any) bool {
+	for _, x := range values {
+		if equal(x, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func equal(a, b any) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, _ := a.Float64()
+		y, _ := b.Float64()
+		return x == y
+	case map[string]any, []any:
+		return encode(a) == encode(b)
+	}
+	return a == b
+}
+
+// encode returns v as compact JSON, for messages. Objects encode with
+// their keys sorted.
+func encode(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
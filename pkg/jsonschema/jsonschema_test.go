@@ -0,0 +1,129 @@
+package jsonschema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var order = MustParse(`{
+	"type": "object",
+	"required": ["customer", "lines"],
+	"additionalProperties": false,
+	"properties": {
+		"customer": {"type": "string", "minLength": 1, "maxLength": 5},
+		"priority": {"enum": ["low", "high", 3]},
+		"lines": {
+			"type": "array",
+			"minItems": 1,
+			"maxItems": 3,
+			"items": {
+				"type": "object",
+				"required": ["sku", "qty"],
+				"properties": {
+					"sku": {"type": "string"},
+					"qty": {"type": "integer", "minimum": 1, "maximum": 99},
+					"price": {"type": "number", "minimum": 0}
+				}
+			}
+		}
+	}
+}`)
+
+// messages returns the messages of Errors, or fails.
+func messages(t *testing.T, err error) []string {
+	t.Helper()
+	var errs Errors
+	require.True(t, errors.As(err, &errs), "%v is not Errors", err)
+	var out []string
+	for _, e := range errs {
+		out = append(out, e.Error())
+	}
+	return out
+}
+
+func TestValid(t *testing.T) {
+	valid := []string{
+		`{"customer": "ann", "lines": [{"sku": "a", "qty": 1}]}`,
+		`{"customer": "bø", "lines": [{"sku": "a", "qty": 2.0, "price": 9.5}], "priority": 3.0}`,
+		`{"customer": "ann", "priority": "high", "lines": [{"sku": "a", "qty": 99, "extra": true}]}`,
+	}
+	for _, doc := range valid {
+		assert.NoError(t, order.Validate([]byte(doc)), doc)
+	}
+	assert.NoError(t, (&Schema{}).Validate([]byte(`[1, "two", null]`)), "the zero Schema accepts anything")
+}
+
+func TestInvalid(t *testing.T) {
+	tests := []struct {
+		doc  string
+		want []string
+	}{
+		{`[]`, []string{"(document): is array, want object"}},
+		{`{}`, []string{"/customer: is required", "/lines: is required"}},
+		{`{"customer": "", "lines": [], "coupon": "x"}`, []string{
+			"/coupon: is not allowed",
+			"/customer: has 0 characters, want at least 1",
+			"/lines: has 0 items, want at least 1",
+		}},
+		{`{"customer": "ébène", "lines": [{"sku": "a", "qty": 1}], "priority": "urgent"}`, []string{
+			`/priority: is "urgent", want one of ["low","high",3]`,
+		}},
+		{`{"customer": "annabel", "lines": [{"sku": 7, "qty": 1.5}, {"qty": 0, "price": -1}]}`, []string{
+			"/customer: has 7 characters, want at most 5",
+			"/lines/0/qty: is number, want integer",
+			"/lines/0/sku: is integer, want string",
+			"/lines/1/sku: is required",
+			"/lines/1/price: is -1, want at least 0",
+			"/lines/1/qty: is 0, want at least 1",
+		}},
+		{`{"customer": null, "lines": [1, 2, 3, 4]}`, []string{
+			"/customer: is null, want string",
+			"/lines: has 4 items, want at most 3",
+			"/lines/0: is integer, want object",
+			"/lines/1: is integer, want object",
+			"/lines/2: is integer, want object",
+			"/lines/3: is integer, want object",
+		}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, messages(t, order.Validate([]byte(tt.doc))), tt.doc)
+	}
+}
+
+func TestEscapedPaths(t *testing.T) {
+	s := MustParse(`{"properties": {"a/b": {"type": "string"}, "c~d": {"type": "string"}}}`)
+	assert.Equal(t, []string{"/a~1b: is boolean, want string", "/c~0d: is null, want string"},
+		messages(t, s.Validate([]byte(`{"a/b": true, "c~d": null}`))))
+}
+
+func TestNotJSON(t *testing.T) {
+	for _, doc := range []string{``, `{"customer": `, `{} {}`, `{"a": 1,}`} {
+		err := order.Validate([]byte(doc))
+		require.Error(t, err, doc)
+		assert.ErrorContains(t, err, "invalid JSON", doc)
+		var errs Errors
+		assert.False(t, errors.As(err, &errs), "%q is not a document to validate", doc)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		`{"type": "text"}`: `(document): unknown type "text"`,
+		`{"properties": {"age": {"type": "integer", "minimun": 0}}}`: `unknown field "minimun"`,
+		`{"properties": {"age": {"minimum": 10, "maximum": 1}}}`:     "/properties/age: minimum 10 is above maximum 1",
+		`{"items": {"type": "int"}}`:                                 `/items: unknown type "int"`,
+		`{"required": ["id"], "additionalProperties": false}`:        `"id" is required but not allowed`,
+		`{"properties": {"id": null}}`:                               "/properties/id: no schema",
+		`{"type": "object"} {}`:                                      "data after the schema",
+		`{"type": ["string", "null"]}`:                               "cannot unmarshal array",
+		`{"type": "object"`:                                          "unexpected EOF",
+	}
+	for schema, want := range tests {
+		_, err := Parse([]byte(schema))
+		assert.ErrorContains(t, err, want, schema)
+	}
+	assert.Panics(t, func() { MustParse(`{"type": 1}`) })
+}
@@ -0,0 +1,147 @@
+// Package leaktest fails a test that leaves goroutines running: a worker
+// still blocked on a channel, a timer loop without a way to stop, or an
+// HTTP connection whose response body was never closed.
+//
+//	func TestCrawl(t *testing.T) {
+//		leaktest.Check(t)
+//		srv := httptest.NewServer(site)
+//		t.Cleanup(srv.Close)
+//		...
+//
+// Check lists the goroutines when it is called, and again when the test
+// ends, after the cleanups registered after it have run. Goroutines
+// winding down get Timeout to finish. Call it first, so that closing
+// servers and clients comes before the check, and not in a parallel
+// test, whose neighbours start goroutines of their own.
+//
+// NoDeadlock catches the worst leak of all, a test that never ends:
+//
+//	leaktest.NoDeadlock(t, time.Second, func() {
+//		assert.Equal(t, want, Merge(a, b))
+//	})
+//
+// It fails the test if the function has not returned in time, and lists
+// the goroutines blocked with it, rather than leave the whole package to
+// go test's -timeout. The runtime's own "all goroutines are asleep"
+// never fires in a test, whose timers keep it awake.
+package leaktest
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Timeout is how long Check waits for goroutines that are still finishing.
+var Timeout = 2 * time.Second
+
+// Check fails tb if goroutines started during the test are still running
+// when it ends, and lists their stacks.
+func Check(tb testing.TB) {
+	tb.Helper()
+	before := Goroutines()
+	tb.Cleanup(func() {
+		var leaked []string
+		deadline := time.Now().Add(Timeout)
+		for {
+			leaked = leaked[:0]
+			for id, stack := range Goroutines() {
+				if _, ok := before[id]; !ok {
+					leaked = append(leaked, stack)
+				}
+			}
+			if len(leaked) == 0 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if len(leaked) > 0 {
+			sort.Strings(leaked)
+			tb.Errorf("leaktest: %d goroutines still running after the test:\n\n%s", len(leaked), strings.Join(leaked, "\n\n"))
+		}
+	})
+}
+
+// NoDeadlock calls f in a goroutine of its own, and fails tb if f has
+// not returned after timeout, listing the stacks of the goroutines
+// started since NoDeadlock was called, f's among them. f is left blocked.
+// A panic in f fails tb as well, instead of ending the test binary. f
+// must not call tb.FailNow or tb.Fatal, which only the test's goroutine
+// may call. NoDeadlock reports whether f returned.
+func NoDeadlock(tb testing.TB, timeout time.Duration, f func()) bool {
+	tb.Helper()
+	before := Goroutines()
+	done := make(chan string, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Sprintf("%v\n\n%s", r, debug.Stack())
+			}
+			close(done)
+		}()
+		f()
+	}()
+	select {
+	case p, ok := <-done:
+		if ok {
+			tb.Errorf("leaktest: panic: %s", p)
+		}
+		return !ok
+	case <-time.After(timeout):
+	}
+	var stuck []string
+	for id, stack := range Goroutines() {
+		if _, ok := before[id]; !ok {
+			stuck = append(stuck, stack)
+		}
+	}
+	sort.Strings(stuck)
+	tb.Errorf("leaktest: deadlock: not done after %v, with %d goroutines blocked:\n\n%s", timeout, len(stuck), strings.Join(stuck, "\n\n"))
+	return false
+}
+
+// Goroutines returns the stack of every goroutine but the caller's, by
+// goroutine ID. Goroutines of the runtime and of package testing are left
+// out.
+func Goroutines() map[string]string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	stacks := make(map[string]string)
+	for i, g := range strings.Split(string(buf), "\n\n") {
+		if i == 0 || ignored(g) { // the first is the caller's
+			continue
+		}
+		// "goroutine 17 [chan receive]:\n..."
+		id, _, _ := strings.Cut(strings.TrimPrefix(g, "goroutine "), " ")
+		stacks[id] = g
+	}
+	return stacks
+}
+
+// ignored reports whether a stack belongs to the runtime or to package
+// testing, which start and stop goroutines of their own: other tests and
+// subtests among them.
+func ignored(stack string) bool {
+	for _, frame := range []string{
+		"testing.tRunner(",
+		"testing.(*M).",
+		"os/signal.signal_recv(",
+		"runtime.ensureSigM(",
+	} {
+		if strings.Contains(stack, frame) {
+			return true
+		}
+	}
+	return false
+}
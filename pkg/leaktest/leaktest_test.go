@@ -0,0 +1,110 @@
+package leaktest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a testing.TB that records errors and cleanups, and runs the
+// cleanups when finish is called.
+type recorder struct {
+	testing.TB
+	errors   []string
+	cleanups []func()
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Cleanup(f func()) { r.cleanups = append(r.cleanups, f) }
+
+func (r *recorder) finish() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+func shortTimeout(t *testing.T) {
+	old := Timeout
+	Timeout = 100 * time.Millisecond
+	t.Cleanup(func() { Timeout = old })
+}
+
+func blocked(stop chan struct{}) { <-stop }
+
+func TestCheckLeak(t *testing.T) {
+	shortTimeout(t)
+	stop := make(chan struct{})
+	defer close(stop)
+
+	r := &recorder{TB: t}
+	Check(r)
+	go blocked(stop)
+	r.finish()
+	require.Len(t, r.errors, 1)
+	assert.Contains(t, r.errors[0], "1 goroutines still running")
+	assert.Contains(t, r.errors[0], "leaktest.blocked(", "the stack of the leaked goroutine")
+}
+
+func TestCheckNoLeak(t *testing.T) {
+	shortTimeout(t)
+	stop := make(chan struct{})
+
+	r := &recorder{TB: t}
+	Check(r)
+	go blocked(stop)
+	r.Cleanup(func() { close(stop) })
+	r.finish()
+	assert.Empty(t, r.errors, "the cleanup registered after Check stopped the goroutine")
+}
+
+func TestCheckWaitsForGoroutinesFinishing(t *testing.T) {
+	r := &recorder{TB: t}
+	Check(r)
+	go time.Sleep(50 * time.Millisecond)
+	r.finish()
+	assert.Empty(t, r.errors)
+}
+
+func TestGoroutinesLeavesOutCaller(t *testing.T) {
+	for id, stack := range Goroutines() {
+		assert.False(t, strings.Contains(stack, "TestGoroutinesLeavesOutCaller"), "goroutine %s is the caller", id)
+	}
+}
+
+func TestNoDeadlock(t *testing.T) {
+	r := &recorder{TB: t}
+	assert.True(t, NoDeadlock(r, time.Second, func() {}))
+	assert.Empty(t, r.errors)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	assert.False(t, NoDeadlock(r, 50*time.Millisecond, func() {
+		go blocked(stop)
+		blocked(stop)
+	}))
+	require.Len(t, r.errors, 1)
+	assert.Contains(t, r.errors[0], "deadlock: not done after 50ms, with 2 goroutines blocked")
+	assert.Contains(t, r.errors[0], "[chan receive]")
+	assert.Contains(t, r.errors[0], "leaktest.blocked(")
+}
+
+func TestNoDeadlockPanic(t *testing.T) {
+	r := &recorder{TB: t}
+	assert.False(t, NoDeadlock(r, time.Second, func() {
+		ch := make(chan int)
+		close(ch)
+		close(ch)
+	}))
+	require.Len(t, r.errors, 1)
+	assert.Contains(t, r.errors[0], "leaktest: panic: close of closed channel")
+	assert.Contains(t, r.errors[0], "TestNoDeadlockPanic", "the stack of the panic")
+}
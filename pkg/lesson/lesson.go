@@ -0,0 +1,210 @@
+// Package lesson reads and runs a module's lessons: Markdown pages with
+// runnable Go cells between the prose, a gentler companion to the
+// example files.
+//
+// Lessons are the lessons/*.md files of a module; "interfaces.md" in
+// modules/02-types-interfaces is the lesson 02-types-interfaces/interfaces.
+// The first "# " heading is the lesson's title. Fenced code blocks with the
+// info string go are cells:
+//
+//	# Interfaces
+//
+//	A type satisfies an interface by having its methods:
+//
+//	```go
+//	type celsius float64
+//
+//	func (c celsius) String() string { return fmt.Sprintf("%.1f°C", float64(c)) }
+//	```
+//
+//	```go
+//	var s fmt.Stringer = celsius(21.5)
+//	fmt.Println(s)
+//	```
+//
+// The cells run in order in one repl.Session, so a cell sees what the
+// cells before it declared. Each cell is one REPL input: declarations,
+// statements, or an expression, whose value is printed. Mark a cell
+// "go norun" to only show it, e.g. code that needs packages from outside
+// the standard library; other code blocks are shown as they are.
+//
+// HTML renders the prose for the dashboard; it knows the common part of
+// Markdown: headings, paragraphs, lists, block quotes, code, emphasis, and
+// links.
+package lesson
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/repl"
+)
+
+// Dir is the directory of a module that holds its lessons.
+const Dir = "lessons"
+
+// Kind is what a Block of a lesson is.
+type Kind int
+
+const (
+	Prose Kind = iota // Markdown
+	Code              // a code block to show
+	Cell              // Go code to run
+)
+
+// Lesson is one lesson file.
+type Lesson struct {
+	ID     string // "<module>/<name>", e.g. "02-types-interfaces/interfaces"
+	Module string // module ID
+	Name   string // file name without ".md"
+	Title  string // from the first "# " heading; Name if there is none
+	Blocks []*Block
+}
+
+// Block is a stretch of prose or a code block.
+type Block struct {
+	Kind Kind
+	Info string // info string of a code block, e.g. "go norun"
+	Text string // Markdown for Prose, the code otherwise
+
+	// Set by Run, for cells.
+	Ran    bool
+	Output string // what the cell printed
+	Err    string // why the cell failed, if it did
+}
+
+// Parse splits a lesson's Markdown into blocks.
+func Parse(src string) *Lesson {
+	l := &Lesson{}
+	var prose []string
+	flush := func() {
+		text := strings.Trim(strings.Join(prose, "\n"), "\n")
+		if strings.TrimSpace(text) != "" {
+			l.Blocks = append(l.Blocks, &Block{Kind: Prose, Text: text})
+		}
+		prose = nil
+	}
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if l.Title == "" && strings.HasPrefix(line, "# ") {
+			l.Title = strings.TrimSpace(line[2:])
+		}
+		fence, info, ok := openFence(line)
+		if !ok {
+			prose = append(prose, line)
+			continue
+		}
+		flush()
+		var code []string
+		for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+			code = append(code, lines[i])
+		}
+		b := &Block{Kind: Code, Info: info, Text: strings.Join(code, "\n")}
+		if info == "go" {
+			b.Kind = Cell
+		}
+		l.Blocks = append(l.Blocks, b)
+	}
+	flush()
+	return l
+}
+
+// openFence reports whether line opens a fenced code block, returning
+// the fence (``` or ~~~, possibly longer) and the info string.
+func openFence(line string) (fence, info string, ok bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return "", "", false
+	}
+	for _, c := range []string{"`", "~"} {
+		n := len(trimmed) - len(strings.TrimLeft(trimmed, c))
+		if n >= 3 {
+			return trimmed[:n], strings.TrimSpace(trimmed[n:]), true
+		}
+	}
+	return "", "", false
+}
+
+// Load reads a lesson file.
+func Load(path string) (*Lesson, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	l := Parse(string(src))
+	l.Name = strings.TrimSuffix(filepath.Base(path), ".md")
+	if l.Title == "" {
+		l.Title = l.Name
+	}
+	return l, nil
+}
+
+// LoadModule reads the lessons of a module, in name order. A module
+// without lessons has none and no error.
+func LoadModule(m *course.Module) ([]*Lesson, error) {
+	paths, err := filepath.Glob(filepath.Join(m.Dir, Dir, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	var lessons []*Lesson
+	for _, p := range paths {
+		l, err := Load(p)
+		if err != nil {
+			return nil, err
+		}
+		l.Module = m.ID
+		l.ID = m.ID + "/" + l.Name
+		lessons = append(lessons, l)
+	}
+	return lessons, nil
+}
+
+// Find looks up a lesson by "<module>/<name>", where the module may be
+// abbreviated as for course.Course.Module, e.g. "02/interfaces".
+func Find(c *course.Course, ref string) (*Lesson, error) {
+	mod, name, ok := strings.Cut(ref, "/")
+	if !ok || name == "" {
+		return nil, fmt.Errorf("lesson %q: want <module>/<lesson>", ref)
+	}
+	m, err := c.Module(mod)
+	if err != nil {
+		return nil, err
+	}
+	lessons, err := LoadModule(m)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lessons {
+		if l.Name == name {
+			return l, nil
+		}
+	}
+	return nil, fmt.Errorf("lesson %s/%s: %w", m.ID, name, course.ErrNotFound)
+}
+
+// Run runs the lesson's cells in order in s.
+func (l *Lesson) Run(ctx context.Context, s *repl.Session) {
+	for _, b := range l.Blocks {
+		if b.Kind == Cell {
+			b.Run(ctx, s)
+		}
+	}
+}
+
+// Run runs a cell in s, recording what it printed and whether it failed.
+func (b *Block) Run(ctx context.Context, s *repl.Session) {
+	var out bytes.Buffer
+	err := s.Eval(ctx, b.Text, &out, &out) // Eval serializes the writes of the two streams
+	b.Ran, b.Output, b.Err = true, out.String(), ""
+	if err != nil {
+		b.Err = err.Error()
+	}
+}
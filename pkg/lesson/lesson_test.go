@@ -0,0 +1,90 @@
+package lesson
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/repl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sample = "# Slices\n\nA slice grows with `append`:\n\n" +
+	"```go\ns := []int{1}\ns = append(s, 2)\n```\n\n" +
+	"```go\nlen(s)\n```\n\n" +
+	"Never compiles:\n\n~~~go norun\ns.Push(3)\n~~~\n\n" +
+	"```text\noutput\n```\n"
+
+func TestParse(t *testing.T) {
+	l := Parse(sample)
+	assert.Equal(t, "Slices", l.Title)
+	require.Len(t, l.Blocks, 6)
+	assert.Equal(t, &Block{Kind: Prose, Text: "# Slices\n\nA slice grows with `append`:"}, l.Blocks[0])
+	assert.Equal(t, &Block{Kind: Cell, Info: "go", Text: "s := []int{1}\ns = append(s, 2)"}, l.Blocks[1])
+	assert.Equal(t, Cell, l.Blocks[2].Kind)
+	assert.Equal(t, Prose, l.Blocks[3].Kind)
+	assert.Equal(t, &Block{Kind: Code, Info: "go norun", Text: "s.Push(3)"}, l.Blocks[4])
+	assert.Equal(t, &Block{Kind: Code, Info: "text", Text: "output"}, l.Blocks[5])
+}
+
+func testCourse(t *testing.T) *course.Course {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":                             "module example.com/course\n\ngo 1.21\n",
+		"modules/01-slices/README.md":        "# Module 01: Slices\n",
+		"modules/01-slices/lessons/grow.md":  sample,
+		"modules/01-slices/lessons/notes.md": "No title here.\n",
+		"modules/01-slices/lessons/skip.txt": "not a lesson",
+	} {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	c, err := course.Load(root)
+	require.NoError(t, err)
+	return c
+}
+
+func TestLoadModule(t *testing.T) {
+	c := testCourse(t)
+	lessons, err := LoadModule(c.Modules[0])
+	require.NoError(t, err)
+	require.Len(t, lessons, 2)
+	assert.Equal(t, "01-slices/grow", lessons[0].ID)
+	assert.Equal(t, "01-slices", lessons[0].Module)
+	assert.Equal(t, "Slices", lessons[0].Title)
+	assert.Equal(t, "notes", lessons[1].Title, "no heading")
+
+	l, err := Find(c, "01/grow")
+	require.NoError(t, err)
+	assert.Equal(t, "01-slices/grow", l.ID)
+	_, err = Find(c, "01/missing")
+	assert.ErrorIs(t, err, course.ErrNotFound)
+	_, err = Find(c, "01")
+	assert.Error(t, err)
+}
+
+func TestRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go build")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	s, err := repl.New()
+	require.NoError(t, err)
+	defer s.Close()
+
+	l := Parse(sample + "\n```go\ns.Push(3)\n```\n")
+	l.Run(context.Background(), s)
+	assert.True(t, l.Blocks[1].Ran)
+	assert.Empty(t, l.Blocks[1].Output)
+	assert.Equal(t, "2\n", l.Blocks[2].Output, "sees s from the cell before")
+	assert.False(t, l.Blocks[4].Ran, "norun")
+	assert.Contains(t, l.Blocks[6].Err, "s.Push undefined")
+}
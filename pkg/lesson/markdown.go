@@ -0,0 +1,161 @@
+package lesson
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// HTML renders the Markdown of a Prose block. Text is escaped; links only
+// keep http, https, and relative URLs.
+func HTML(markdown string) string {
+	var b strings.Builder
+	for _, para := range paragraphs(markdown) {
+		first := para[0]
+		switch {
+		case heading.MatchString(first) && len(para) == 1:
+			m := heading.FindStringSubmatch(first)
+			level := strconv.Itoa(len(m[1]))
+			b.WriteString("<h" + level + ">" + inline(m[2]) + "</h" + level + ">\n")
+		case allLines(para, isIndented):
+			b.WriteString("<pre><code>")
+			for _, line := range para {
+				b.WriteString(html.EscapeString(strings.TrimPrefix(strings.TrimPrefix(line, "\t"), "    ")) + "\n")
+			}
+			b.WriteString("</code></pre>\n")
+		case strings.HasPrefix(first, ">"):
+			for i, line := range para {
+				para[i] = strings.TrimPrefix(strings.TrimPrefix(line, ">"), " ")
+			}
+			b.WriteString("<blockquote>\n" + HTML(strings.Join(para, "\n")) + "</blockquote>\n")
+		case bullet.MatchString(first):
+			list(&b, "ul", para, bullet)
+		case numbered.MatchString(first):
+			list(&b, "ol", para, numbered)
+		default:
+			b.WriteString("<p>" + inline(strings.Join(para, "\n")) + "</p>\n")
+		}
+	}
+	return b.String()
+}
+
+var (
+	heading  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	bullet   = regexp.MustCompile(`^[-*+]\s+`)
+	numbered = regexp.MustCompile(`^\d+[.)]\s+`)
+)
+
+// paragraphs splits Markdown into its blocks of lines. Headings are
+// blocks of their own even without blank lines around them.
+func paragraphs(markdown string) [][]string {
+	var paras [][]string
+	var cur []string
+	flush := func() {
+		if len(cur) > 0 {
+			paras = append(paras, cur)
+		}
+		cur = nil
+	}
+	for _, line := range strings.Split(markdown, "\n") {
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+		case heading.MatchString(line):
+			flush()
+			paras = append(paras, []string{line})
+		default:
+			cur = append(cur, line)
+		}
+	}
+	flush()
+	return paras
+}
+
+func isIndented(line string) bool {
+	return strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+}
+
+func allLines(lines []string, f func(string) bool) bool {
+	for _, line := range lines {
+		if !f(line) {
+			return false
+		}
+	}
+	return true
+}
+
+// list writes the items of a list; lines that do not start an item
+// continue the one before.
+func list(b *strings.Builder, tag string, lines []string, marker *regexp.Regexp) {
+	var items []string
+	for _, line := range lines {
+		if loc := marker.FindStringIndex(line); loc != nil {
+			items = append(items, line[loc[1]:])
+		} else if len(items) > 0 {
+			items[len(items)-1] += "\n" + strings.TrimSpace(line)
+		}
+	}
+	b.WriteString("<" + tag + ">\n")
+	for _, item := range items {
+		b.WriteString("<li>" + inline(item) + "</li>\n")
+	}
+	b.WriteString("</" + tag + ">\n")
+}
+
+var (
+	codeSpan = regexp.MustCompile("`+")
+	link     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	strong   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	emphasis = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+)
+
+// inline renders the spans of a block: code, links, and emphasis.
+func inline(text string) string {
+	var b strings.Builder
+	for text != "" {
+		loc := codeSpan.FindStringIndex(text)
+		if loc == nil {
+			b.WriteString(spans(text))
+			break
+		}
+		fence := text[loc[0]:loc[1]]
+		end := strings.Index(text[loc[1]:], fence)
+		if end < 0 {
+			b.WriteString(spans(text))
+			break
+		}
+		b.WriteString(spans(text[:loc[0]]))
+		code := strings.TrimSpace(text[loc[1] : loc[1]+end])
+		b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+		text = text[loc[1]+end+len(fence):]
+	}
+	return b.String()
+}
+
+// spans renders links and emphasis in text without code spans.
+func spans(text string) string {
+	text = html.EscapeString(text)
+	text = link.ReplaceAllStringFunc(text, func(s string) string {
+		m := link.FindStringSubmatch(s)
+		url := html.UnescapeString(m[2])
+		if !safeURL(url) {
+			return m[1]
+		}
+		return `<a href="` + html.EscapeString(url) + `">` + m[1] + "</a>"
+	})
+	text = strong.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = emphasis.ReplaceAllString(text, "<em>$1$2</em>")
+	return text
+}
+
+// safeURL reports whether a link may point at url: no javascript: and
+// the like.
+func safeURL(url string) bool {
+	scheme, _, ok := strings.Cut(url, ":")
+	if !ok || strings.ContainsAny(scheme, "/?#") {
+		return true // relative
+	}
+	scheme = strings.ToLower(scheme)
+	return scheme == "http" || scheme == "https" || scheme == "mailto"
+}
@@ -0,0 +1,27 @@
+package lesson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTML(t *testing.T) {
+	for _, tt := range []struct{ markdown, want string }{
+		{"# Title", "<h1>Title</h1>\n"},
+		{"## Sub ##\ntext", "<h2>Sub</h2>\n<p>text</p>\n"},
+		{"one\ntwo\n\nthree", "<p>one\ntwo</p>\n<p>three</p>\n"},
+		{"Use `a<b` and **bold** or *em*.", "<p>Use <code>a&lt;b</code> and <strong>bold</strong> or <em>em</em>.</p>\n"},
+		{"snake_case_names stay", "<p>snake_case_names stay</p>\n"},
+		{"- a\n- b\n  more", "<ul>\n<li>a</li>\n<li>b\nmore</li>\n</ul>\n"},
+		{"1. a\n2. b", "<ol>\n<li>a</li>\n<li>b</li>\n</ol>\n"},
+		{"> quoted", "<blockquote>\n<p>quoted</p>\n</blockquote>\n"},
+		{"    x := <1>", "<pre><code>x := &lt;1&gt;\n</code></pre>\n"},
+		{"[Go](https://go.dev/?a=1&b=2)", `<p><a href="https://go.dev/?a=1&amp;b=2">Go</a></p>` + "\n"},
+		{"[docs](../README.md)", `<p><a href="../README.md">docs</a></p>` + "\n"},
+		{"[click](javascript:alert%281%29)", "<p>click</p>\n"},
+		{"<script>", "<p>&lt;script&gt;</p>\n"},
+	} {
+		assert.Equal(t, tt.want, HTML(tt.markdown), tt.markdown)
+	}
+}
@@ -0,0 +1,124 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// An affine transform of the plane is a 3×3 matrix in homogeneous
+// coordinates: the point (x, y) is the column (x, y, 1), and the bottom
+// row of the matrix is 0 0 1. Multiplying matrices composes transforms,
+// right to left: (A × B) applies B first, then A.
+
+// ErrNotPreserved is returned, wrapped, when a transform would turn a
+// shape into a figure of another kind: a circle into an ellipse, or an
+// axis-aligned rectangle into a rotated one.
+var ErrNotPreserved = errors.New("transform does not preserve the shape")
+
+// Translation returns the transform that moves points by dx, dy.
+func Translation(dx, dy float64) Matrix[float64] {
+	return affine(1, 0, dx, 0, 1, dy)
+}
+
+// Scaling returns the transform that scales by sx horizontally and sy
+// vertically about the origin.
+func Scaling(sx, sy float64) Matrix[float64] {
+	return affine(sx, 0, 0, 0, sy, 0)
+}
+
+// Rotation returns the transform that rotates by theta radians
+// counterclockwise about the origin, as vec.Rotate does.
+func Rotation(theta float64) Matrix[float64] {
+	sin, cos := math.Sincos(theta)
+	return affine(cos, -sin, 0, sin, cos, 0)
+}
+
+// affine returns the matrix with top rows a b c and d e f.
+func affine(a, b, c, d, e, f float64) Matrix[float64] {
+	return Matrix[float64]{rows: 3, cols: 3, data: []float64{a, b, c, d, e, f, 0, 0, 1}}
+}
+
+// Compose returns the transform that applies ts in order, first to last:
+// the product of ts, last to first. With no transforms it returns the
+// identity.
+func Compose(ts ...Matrix[float64]) (Matrix[float64], error) {
+	m := Identity[float64](3)
+	for i, t := range ts {
+		if err := checkAffine(t); err != nil {
+			return Matrix[float64]{}, fmt.Errorf("transform %d: %w", i, err)
+		}
+		m, _ = t.Mul(m)
+	}
+	return m, nil
+}
+
+// checkAffine returns an error if m is not a 3×3 affine matrix.
+func checkAffine(m Matrix[float64]) error {
+	if m.rows != 3 || m.cols != 3 {
+		return fmt.Errorf("%w: an affine transform is 3x3, not %dx%d", ErrShape, m.rows, m.cols)
+	}
+	if m.data[6] != 0 || m.data[7] != 0 || m.data[8] != 1 {
+		return fmt.Errorf("bottom row is %v, not [0 0 1]: not an affine transform", m.data[6:])
+	}
+	return nil
+}
+
+// Apply returns p transformed by m.
+func Apply(m Matrix[float64], p geometry.Point) (geometry.Point, error) {
+	if err := checkAffine(m); err != nil {
+		return geometry.Point{}, err
+	}
+	return apply(m, p), nil
+}
+
+func apply(m Matrix[float64], p geometry.Point) geometry.Point {
+	d := m.data
+	return geometry.Point{X: d[0]*p.X + d[1]*p.Y + d[2], Y: d[3]*p.X + d[4]*p.Y + d[5]}
+}
+
+// tolerance is how far from zero, relative to the largest element of its
+// linear part, a matrix element counts as zero. Rotation(math.Pi/2) has
+// cos(π/2) ≈ 6e-17 where 0 was meant.
+const tolerance = 1e-12
+
+// Transform returns s transformed by m. A triangle stays a triangle under
+// any affine transform. A circle stays a circle only under rotations,
+// reflections, uniform scaling, and translations, and an axis-aligned
+// rectangle only if its sides stay parallel to the axes; otherwise
+// Transform returns an error wrapping ErrNotPreserved.
+func Transform(s geometry.Shape, m Matrix[float64]) (geometry.Shape, error) {
+	if err := checkAffine(m); err != nil {
+		return nil, err
+	}
+	a, b, c, d := m.data[0], m.data[1], m.data[3], m.data[4]
+	scale := math.Max(math.Max(math.Abs(a), math.Abs(b)), math.Max(math.Abs(c), math.Abs(d)))
+	zero := func(v float64) bool { return math.Abs(v) <= tolerance*scale }
+
+	switch s := s.(type) {
+	case geometry.Triangle:
+		return geometry.Triangle{A: apply(m, s.A), B: apply(m, s.B), C: apply(m, s.C)}, nil
+	case geometry.Circle:
+		// The linear part maps the unit circle to a circle when its columns
+		// are perpendicular and of equal length.
+		perpendicular := math.Abs(a*b+c*d) <= tolerance*scale*scale
+		if !perpendicular || !zero(math.Hypot(a, c)-math.Hypot(b, d)) {
+			return nil, fmt.Errorf("%w: a circle would become an ellipse", ErrNotPreserved)
+		}
+		return geometry.Circle{Center: apply(m, s.Center), Radius: s.Radius * math.Hypot(a, c)}, nil
+	case geometry.Rectangle:
+		if !(zero(b) && zero(c)) && !(zero(a) && zero(d)) {
+			return nil, fmt.Errorf("%w: a rectangle's sides would no longer be parallel to the axes", ErrNotPreserved)
+		}
+		p := apply(m, s.Origin)
+		q := apply(m, geometry.Point{X: s.Origin.X + s.Width, Y: s.Origin.Y + s.Height})
+		return geometry.Rectangle{
+			Origin: geometry.Point{X: math.Min(p.X, q.X), Y: math.Min(p.Y, q.Y)},
+			Width:  math.Abs(q.X - p.X),
+			Height: math.Abs(q.Y - p.Y),
+		}, nil
+	}
+	return nil, fmt.Errorf("cannot transform unknown shape %T", s)
+}
@@ -0,0 +1,120 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/vec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tol = 1e-9
+
+func xy(x, y float64) geometry.Point { return geometry.Point{X: x, Y: y} }
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Matrix[float64]
+		p    geometry.Point
+		want geometry.Point
+	}{
+		{"identity", Identity[float64](3), xy(3, -2), xy(3, -2)},
+		{"translation", Translation(1, -5), xy(3, -2), xy(4, -7)},
+		{"scaling", Scaling(2, -0.5), xy(3, -2), xy(6, 1)},
+		{"quarter turn", Rotation(math.Pi / 2), xy(3, -2), xy(2, 3)},
+		{"like vec.Rotate", Rotation(0.7), xy(3, -2), vec.Rotate(xy(3, -2), 0.7)},
+	}
+	for _, tt := range tests {
+		got, err := Apply(tt.m, tt.p)
+		require.NoError(t, err, tt.name)
+		assert.True(t, vec.Near(tt.want, got, tol), "%s: got %v, want %v", tt.name, got, tt.want)
+	}
+
+	_, err := Apply(Identity[float64](2), xy(1, 1))
+	assert.ErrorIs(t, err, ErrShape)
+	projective := Identity[float64](3)
+	projective.Set(2, 0, 1)
+	_, err = Apply(projective, xy(1, 1))
+	assert.ErrorContains(t, err, "not an affine transform")
+}
+
+func TestCompose(t *testing.T) {
+	// Rotating about (1, 1): move it to the origin, rotate, move back.
+	m, err := Compose(Translation(-1, -1), Rotation(math.Pi/2), Translation(1, 1))
+	require.NoError(t, err)
+	p, err := Apply(m, xy(2, 1))
+	require.NoError(t, err)
+	assert.True(t, vec.Near(xy(1, 2), p, tol), "got %v", p)
+	assert.True(t, vec.Near(vec.RotateAbout(xy(5, -3), xy(1, 1), math.Pi/2), apply(m, xy(5, -3)), tol))
+
+	// Order matters: scaling then moving is not moving then scaling.
+	scaleFirst, err := Compose(Scaling(2, 2), Translation(1, 0))
+	require.NoError(t, err)
+	moveFirst, err := Compose(Translation(1, 0), Scaling(2, 2))
+	require.NoError(t, err)
+	assert.Equal(t, xy(3, 0), apply(scaleFirst, xy(1, 0)))
+	assert.Equal(t, xy(4, 0), apply(moveFirst, xy(1, 0)))
+
+	id, err := Compose()
+	require.NoError(t, err)
+	assert.Equal(t, Identity[float64](3), id)
+
+	_, err = Compose(Translation(1, 1), New[float64](2, 2))
+	assert.ErrorIs(t, err, ErrShape)
+	assert.ErrorContains(t, err, "transform 1: ")
+}
+
+func TestTransformTriangle(t *testing.T) {
+	tri := geometry.Triangle{A: xy(0, 0), B: xy(3, 0), C: xy(0, 4)}
+	shear := affine(1, 1, 0, 0, 1, 0)
+	got, err := Transform(tri, shear)
+	require.NoError(t, err)
+	assert.Equal(t, geometry.Triangle{A: xy(0, 0), B: xy(3, 0), C: xy(4, 4)}, got)
+	assert.InDelta(t, tri.Area(), got.Area(), tol, "a shear keeps areas")
+
+	got, err = Transform(tri, Scaling(2, 3))
+	require.NoError(t, err)
+	assert.InDelta(t, 6*tri.Area(), got.Area(), tol)
+}
+
+func TestTransformCircle(t *testing.T) {
+	c := geometry.Circle{Center: xy(1, 0), Radius: 2}
+	m, err := Compose(Scaling(3, 3), Rotation(math.Pi/2), Translation(0, 1))
+	require.NoError(t, err)
+	got, err := Transform(c, m)
+	require.NoError(t, err)
+	gc := got.(geometry.Circle)
+	assert.True(t, vec.Near(xy(0, 4), gc.Center, tol), "got %v", gc.Center)
+	assert.InDelta(t, 6, gc.Radius, tol)
+
+	got, err = Transform(c, Scaling(-1, 1))
+	require.NoError(t, err, "a reflection keeps a circle round")
+	assert.Equal(t, geometry.Circle{Center: xy(-1, 0), Radius: 2}, got)
+
+	_, err = Transform(c, Scaling(1, 2))
+	assert.ErrorIs(t, err, ErrNotPreserved)
+	_, err = Transform(c, affine(1, 1, 0, 0, 1, 0))
+	assert.ErrorIs(t, err, ErrNotPreserved)
+}
+
+func TestTransformRectangle(t *testing.T) {
+	r := geometry.Rectangle{Origin: xy(1, 1), Width: 4, Height: 2}
+	got, err := Transform(r, Scaling(-1, 2))
+	require.NoError(t, err)
+	assert.Equal(t, geometry.Rectangle{Origin: xy(-5, 2), Width: 4, Height: 4}, got)
+
+	got, err = Transform(r, Rotation(math.Pi/2))
+	require.NoError(t, err, "a quarter turn keeps the sides on the axes")
+	gr := got.(geometry.Rectangle)
+	assert.True(t, vec.Near(xy(-3, 1), gr.Origin, tol), "got %v", gr.Origin)
+	assert.InDelta(t, 2, gr.Width, tol)
+	assert.InDelta(t, 4, gr.Height, tol)
+
+	_, err = Transform(r, Rotation(math.Pi/4))
+	assert.ErrorIs(t, err, ErrNotPreserved)
+	_, err = Transform(r, New[float64](3, 2))
+	assert.ErrorIs(t, err, ErrShape)
+}
@@ -0,0 +1,148 @@
+// Package matrix is a small generic matrix type for the course's linear
+// algebra: products, transposes, and identities, and the 3×3 affine
+// matrices that move, rotate, and scale shapes.
+//
+// Operations on matrices whose dimensions do not fit together return an
+// error wrapping ErrShape rather than panicking, since the dimensions
+// often come from input. Indexing outside a matrix with At or Set is a
+// programming mistake, and panics like indexing a slice.
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Float is the constraint for the elements of a Matrix: float32, float64,
+// and types defined on them. It is golang.org/x/exp/constraints.Float,
+// which the course does not depend on.
+type Float interface {
+	~float32 | ~float64
+}
+
+// ErrShape is returned, wrapped, when the dimensions of matrices do not
+// fit together, or rows have different lengths.
+var ErrShape = errors.New("dimension mismatch")
+
+// Matrix is a rows×cols matrix of T, stored row by row. Matrices are
+// values that share their elements when copied, like slices; the
+// operations return new ones.
+type Matrix[T Float] struct {
+	rows, cols int
+	data       []T
+}
+
+// New returns a rows×cols matrix of zeros. It panics if either dimension
+// is negative.
+func New[T Float](rows, cols int) Matrix[T] {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Sprintf("matrix: negative dimensions %dx%d", rows, cols))
+	}
+	return Matrix[T]{rows: rows, cols: cols, data: make([]T, rows*cols)}
+}
+
+// FromRows returns the matrix with the given rows, which must all be the
+// same length. The elements are copied.
+func FromRows[T Float](rows ...[]T) (Matrix[T], error) {
+	if len(rows) == 0 {
+		return Matrix[T]{}, nil
+	}
+	m := New[T](len(rows), len(rows[0]))
+	for i, row := range rows {
+		if len(row) != m.cols {
+			return Matrix[T]{}, fmt.Errorf("%w: row %d has %d elements, row 0 has %d", ErrShape, i, len(row), m.cols)
+		}
+		copy(m.data[i*m.cols:], row)
+	}
+	return m, nil
+}
+
+// Identity returns the n×n identity matrix.
+func Identity[T Float](n int) Matrix[T] {
+	m := New[T](n, n)
+	for i := 0; i < n; i++ {
+		m.data[i*n+i] = 1
+	}
+	return m
+}
+
+// Rows returns the number of rows.
+func (m Matrix[T]) Rows() int { return m.rows }
+
+// Cols returns the number of columns.
+func (m Matrix[T]) Cols() int { return m.cols }
+
+// At returns the element in row i and column j, counting from 0.
+func (m Matrix[T]) At(i, j int) T {
+	m.check(i, j)
+	return m.data[i*m.cols+j]
+}
+
+// Set sets the element in row i and column j. Copies of m see the change.
+func (m Matrix[T]) Set(i, j int, v T) {
+	m.check(i, j)
+	m.data[i*m.cols+j] = v
+}
+
+func (m Matrix[T]) check(i, j int) {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("matrix: index [%d, %d] out of range for %dx%d matrix", i, j, m.rows, m.cols))
+	}
+}
+
+// Mul returns the product m × n. m must have as many columns as n has
+// rows.
+func (m Matrix[T]) Mul(n Matrix[T]) (Matrix[T], error) {
+	if m.cols != n.rows {
+		return Matrix[T]{}, fmt.Errorf("%w: cannot multiply %dx%d by %dx%d", ErrShape, m.rows, m.cols, n.rows, n.cols)
+	}
+	p := New[T](m.rows, n.cols)
+	for i := 0; i < m.rows; i++ {
+		for k := 0; k < m.cols; k++ {
+			a := m.data[i*m.cols+k]
+			for j := 0; j < n.cols; j++ {
+				p.data[i*p.cols+j] += a * n.data[k*n.cols+j]
+			}
+		}
+	}
+	return p, nil
+}
+
+// Transpose returns m with its rows as columns.
+func (m Matrix[T]) Transpose() Matrix[T] {
+	t := New[T](m.cols, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			t.data[j*t.cols+i] = m.data[i*m.cols+j]
+		}
+	}
+	return t
+}
+
+// Near reports whether m and n have the same dimensions and elements
+// that differ by at most tol.
+func (m Matrix[T]) Near(n Matrix[T], tol T) bool {
+	if m.rows != n.rows || m.cols != n.cols {
+		return false
+	}
+	for i, v := range m.data {
+		if math.Abs(float64(v-n.data[i])) > float64(tol) {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats m one row per line, as in "[1 0]\n[0 1]".
+func (m Matrix[T]) String() string {
+	var b strings.Builder
+	for i := 0; i < m.rows; i++ {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprint(&b, m.data[i*m.cols:(i+1)*m.cols])
+	}
+	return b.String()
+}
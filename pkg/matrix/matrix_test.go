@@ -0,0 +1,110 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustRows[T Float](t *testing.T, rows ...[]T) Matrix[T] {
+	t.Helper()
+	m, err := FromRows(rows...)
+	require.NoError(t, err)
+	return m
+}
+
+func TestFromRows(t *testing.T) {
+	row := []float64{1, 2, 3}
+	m := mustRows(t, row, []float64{4, 5, 6})
+	assert.Equal(t, 2, m.Rows())
+	assert.Equal(t, 3, m.Cols())
+	assert.Equal(t, 6.0, m.At(1, 2))
+	row[0] = 9
+	assert.Equal(t, 1.0, m.At(0, 0), "the rows are copied")
+
+	_, err := FromRows([]float64{1, 2}, []float64{3})
+	assert.ErrorIs(t, err, ErrShape)
+	assert.EqualError(t, err, "dimension mismatch: row 1 has 1 elements, row 0 has 2")
+
+	empty, err := FromRows[float32]()
+	require.NoError(t, err)
+	assert.Zero(t, empty.Rows())
+	assert.Zero(t, empty.Cols())
+}
+
+func TestNewAndSet(t *testing.T) {
+	m := New[float32](2, 3)
+	assert.Equal(t, "[0 0 0]\n[0 0 0]", m.String())
+	copied := m
+	m.Set(0, 1, 2.5)
+	assert.Equal(t, float32(2.5), copied.At(0, 1), "copies share elements")
+
+	assert.Panics(t, func() { m.At(2, 0) })
+	assert.Panics(t, func() { m.Set(0, -1, 1) })
+	assert.Panics(t, func() { New[float64](-1, 2) })
+}
+
+func TestIdentity(t *testing.T) {
+	assert.Equal(t, "[1 0 0]\n[0 1 0]\n[0 0 1]", Identity[float64](3).String())
+	m := mustRows(t, []float64{1, 2}, []float64{3, 4}, []float64{5, 6})
+	left, err := Identity[float64](3).Mul(m)
+	require.NoError(t, err)
+	right, err := m.Mul(Identity[float64](2))
+	require.NoError(t, err)
+	assert.Equal(t, m, left)
+	assert.Equal(t, m, right)
+}
+
+func TestMul(t *testing.T) {
+	a := mustRows(t, []float64{1, 2, 3}, []float64{4, 5, 6})
+	b := mustRows(t, []float64{7, 8}, []float64{9, 10}, []float64{11, 12})
+	p, err := a.Mul(b)
+	require.NoError(t, err)
+	assert.Equal(t, mustRows(t, []float64{58, 64}, []float64{139, 154}), p)
+
+	p, err = b.Mul(a)
+	require.NoError(t, err)
+	assert.Equal(t, 3, p.Rows())
+	assert.Equal(t, 3, p.Cols())
+	assert.Equal(t, 39.0, p.At(0, 0))
+
+	_, err = a.Mul(a)
+	assert.ErrorIs(t, err, ErrShape)
+	assert.EqualError(t, err, "dimension mismatch: cannot multiply 2x3 by 2x3")
+
+	row := mustRows(t, []float64{1, 2, 3})
+	dot, err := row.Mul(row.Transpose())
+	require.NoError(t, err)
+	assert.Equal(t, "[14]", dot.String(), "a row times a column is their dot product")
+}
+
+func TestTranspose(t *testing.T) {
+	m := mustRows(t, []float64{1, 2, 3}, []float64{4, 5, 6})
+	assert.Equal(t, mustRows(t, []float64{1, 4}, []float64{2, 5}, []float64{3, 6}), m.Transpose())
+	assert.Equal(t, m, m.Transpose().Transpose())
+
+	// (AB)ᵀ = BᵀAᵀ
+	b := mustRows(t, []float64{1, 0}, []float64{2, -1}, []float64{0, 3})
+	ab, err := m.Mul(b)
+	require.NoError(t, err)
+	btat, err := b.Transpose().Mul(m.Transpose())
+	require.NoError(t, err)
+	assert.Equal(t, ab.Transpose(), btat)
+}
+
+func TestNear(t *testing.T) {
+	m := mustRows(t, []float64{1, 2})
+	assert.True(t, m.Near(mustRows(t, []float64{1.05, 1.95}), 0.1))
+	assert.False(t, m.Near(mustRows(t, []float64{1.2, 2}), 0.1))
+	assert.False(t, m.Near(m.Transpose(), 1), "different dimensions")
+}
+
+type celsius float32
+
+func TestDefinedFloatTypes(t *testing.T) {
+	m := mustRows(t, []celsius{20.5, 21})
+	s, err := m.Mul(mustRows(t, []celsius{2}, []celsius{0}))
+	require.NoError(t, err)
+	assert.Equal(t, celsius(41), s.At(0, 0))
+}
@@ -0,0 +1,378 @@
+// Package progress records how a learner is doing on each exercise in a
+// small JSON file, so every learngo command sees the same history.
+package progress
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DefaultPath returns where the store lives for a course root unless
+// LEARNGO_PROGRESS points somewhere else.
+func DefaultPath(root string) string {
+	if p := os.Getenv("LEARNGO_PROGRESS"); p != "" {
+		return p
+	}
+	return filepath.Join(root, ".learngo", "progress.json")
+}
+
+// SessionGap is the longest pause between two attempts that still counts
+// as one working session.
+const SessionGap = 30 * time.Minute
+
+// Exercise is the history of one exercise.
+type Exercise struct {
+	Attempts     int       `json:"attempts"`
+	Passed       bool      `json:"passed"`
+	FirstAttempt time.Time `json:"first_attempt"`
+	LastAttempt  time.Time `json:"last_attempt"`
+	CompletedAt  time.Time `json:"completed_at"`
+	HintsUsed    int       `json:"hints_used,omitempty"`
+
+	// FailedRuns counts failing runs before the first pass, or so far if
+	// the exercise never passed.
+	FailedRuns int `json:"failed_runs,omitempty"`
+	// Sessions counts working sessions: runs of attempts no more than
+	// SessionGap apart.
+	Sessions int `json:"sessions,omitempty"`
+	// ActiveTime is the wall-clock time between attempts within sessions,
+	// leaving out the breaks between them.
+	ActiveTime time.Duration `json:"active_time,omitempty"`
+	// Mistakes counts the kinds of mistakes seen in failed runs, by the
+	// IDs package recommend classifies them with.
+	Mistakes map[string]Mistake `json:"mistakes,omitempty"`
+}
+
+// Mistake is how often one kind of mistake made an exercise fail.
+type Mistake struct {
+	Count int       `json:"count"`
+	Last  time.Time `json:"last"`
+}
+
+// TimeSpent is the time from the first attempt until the exercise was
+// completed, or until the latest attempt if it is still open.
+func (e Exercise) TimeSpent() time.Duration {
+	if e.FirstAttempt.IsZero() {
+		return 0
+	}
+	end := e.LastAttempt
+	if !e.CompletedAt.IsZero() {
+		end = e.CompletedAt
+	}
+	return end.Sub(e.FirstAttempt)
+}
+
+// Run summarizes the latest test run of a module.
+type Run struct {
+	At          time.Time     `json:"at"`
+	Duration    time.Duration `json:"duration"`
+	Passed      []string      `json:"passed"` // exercise IDs
+	Failed      []string      `json:"failed"`
+	BuildFailed bool          `json:"build_failed,omitempty"`
+	Race        bool          `json:"race,omitempty"` // run with the race detector
+}
+
+// Total returns the number of exercises in the run.
+func (r Run) Total() int { return len(r.Passed) + len(r.Failed) }
+
+// Score is one quiz result.
+type Score struct {
+	Correct int       `json:"correct"`
+	Total   int       `json:"total"`
+	At      time.Time `json:"at"`
+}
+
+// Percent returns the share of correct answers, rounded down.
+func (s Score) Percent() int {
+	if s.Total == 0 {
+		return 0
+	}
+	return s.Correct * 100 / s.Total
+}
+
+// Quiz is the quiz history of a module.
+type Quiz struct {
+	Attempts int   `json:"attempts"`
+	Last     Score `json:"last"`
+	Best     Score `json:"best"`
+}
+
+// Store holds the progress of every exercise, keyed by exercise ID
+// ("01-basics/exercise1_fix_bugs"), the latest run and quiz history of
+// each module, keyed by module ID, when each achievement was unlocked, the
+// days the learner practiced, and the learner's seed for exercise test
+// data. The zero value is an empty store that is not backed by a file.
+type Store struct {
+	path         string
+	Seed         int64                `json:"seed,omitempty"`
+	Exercises    map[string]*Exercise `json:"exercises"`
+	Runs         map[string]*Run      `json:"runs,omitempty"`
+	Quizzes      map[string]*Quiz     `json:"quizzes,omitempty"`
+	Achievements map[string]time.Time `json:"achievements,omitempty"`
+
+	// Days lists the dates ("2006-01-02", in the learner's time zone) with
+	// at least one exercise attempt or quiz, in order.
+	Days []string `json:"days,omitempty"`
+}
+
+// Open reads the store at path. A missing file is an empty store.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, Exercises: make(map[string]*Exercise)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if s.Exercises == nil {
+		s.Exercises = make(map[string]*Exercise)
+	}
+	return s, nil
+}
+
+// Path returns the file the store is saved to.
+func (s *Store) Path() string { return s.path }
+
+// Save writes the store back to its file, creating the directory if
+// needed. It writes a temporary file and renames it, so a crash never
+// leaves a half-written store behind.
+func (s *Store) Save() error {
+	if s.path == "" {
+		return errors.New("progress: store has no file")
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Get returns the progress of an exercise; the zero Exercise if it was
+// never attempted.
+func (s *Store) Get(id string) Exercise {
+	if e, ok := s.Exercises[id]; ok {
+		return *e
+	}
+	return Exercise{}
+}
+
+func (s *Store) entry(id string) *Exercise {
+	if s.Exercises == nil {
+		s.Exercises = make(map[string]*Exercise)
+	}
+	e, ok := s.Exercises[id]
+	if !ok {
+		e = &Exercise{}
+		s.Exercises[id] = e
+	}
+	return e
+}
+
+// RecordAttempt records a test run of an exercise at time at. The first
+// passing run marks the exercise completed; a later failure (say, after
+// editing a finished exercise) clears Passed but keeps CompletedAt.
+// Attempts within SessionGap of the previous one extend its session and
+// add the time between them to ActiveTime.
+func (s *Store) RecordAttempt(id string, passed bool, at time.Time) {
+	e := s.entry(id)
+	e.Attempts++
+	if e.FirstAttempt.IsZero() {
+		e.FirstAttempt = at
+	}
+	if gap := at.Sub(e.LastAttempt); !e.LastAttempt.IsZero() && gap >= 0 && gap <= SessionGap {
+		e.ActiveTime += gap
+	} else {
+		e.Sessions++
+	}
+	e.LastAttempt = at
+	e.Passed = passed
+	s.RecordDay(at)
+	if e.CompletedAt.IsZero() {
+		if passed {
+			e.CompletedAt = at
+		} else {
+			e.FailedRuns++
+		}
+	}
+}
+
+// RecordMistake counts a kind of mistake in a failed run of an exercise
+// at time at.
+func (s *Store) RecordMistake(id, kind string, at time.Time) {
+	e := s.entry(id)
+	if e.Mistakes == nil {
+		e.Mistakes = make(map[string]Mistake)
+	}
+	m := e.Mistakes[kind]
+	m.Count++
+	if at.After(m.Last) {
+		m.Last = at
+	}
+	e.Mistakes[kind] = m
+}
+
+// RecordHint counts a hint shown for an exercise.
+func (s *Store) RecordHint(id string) {
+	s.entry(id).HintsUsed++
+}
+
+// RecordRun replaces the latest run of a module.
+func (s *Store) RecordRun(module string, r Run) {
+	if s.Runs == nil {
+		s.Runs = make(map[string]*Run)
+	}
+	s.Runs[module] = &r
+}
+
+// LastRun returns the latest run of a module, if there is one.
+func (s *Store) LastRun(module string) (Run, bool) {
+	r, ok := s.Runs[module]
+	if !ok {
+		return Run{}, false
+	}
+	return *r, true
+}
+
+// RecordQuiz records a quiz result for a module. Best keeps the highest
+// percentage, and the earliest result among equals.
+func (s *Store) RecordQuiz(module string, score Score) {
+	if s.Quizzes == nil {
+		s.Quizzes = make(map[string]*Quiz)
+	}
+	q, ok := s.Quizzes[module]
+	if !ok {
+		q = &Quiz{}
+		s.Quizzes[module] = q
+	}
+	q.Attempts++
+	q.Last = score
+	s.RecordDay(score.At)
+	if q.Attempts == 1 || score.Percent() > q.Best.Percent() {
+		q.Best = score
+	}
+}
+
+// Quiz returns the quiz history of a module; the zero Quiz if it was never
+// taken.
+func (s *Store) Quiz(module string) Quiz {
+	if q, ok := s.Quizzes[module]; ok {
+		return *q
+	}
+	return Quiz{}
+}
+
+// dateLayout is the format of Store.Days.
+const dateLayout = "2006-01-02"
+
+// RecordDay records practice on the date of at, in at's time zone.
+// RecordAttempt and RecordQuiz call it.
+func (s *Store) RecordDay(at time.Time) {
+	if at.IsZero() {
+		return
+	}
+	day := at.Format(dateLayout)
+	i := sort.SearchStrings(s.Days, day)
+	if i < len(s.Days) && s.Days[i] == day {
+		return
+	}
+	s.Days = append(s.Days, "")
+	copy(s.Days[i+1:], s.Days[i:])
+	s.Days[i] = day
+}
+
+// Streak is a run of consecutive days of practice.
+type Streak struct {
+	Current int  // days in the streak that is still going, 0 if none
+	Longest int  // days in the longest streak so far
+	Today   bool // practiced today; if not, Current ends tonight
+}
+
+// Streak returns the learner's streaks as of now. A streak is still going
+// if the learner practiced today or yesterday: the day is not over yet.
+func (s *Store) Streak(now time.Time) Streak {
+	var st Streak
+	run := 0
+	var prev time.Time
+	for _, d := range s.Days {
+		day, err := time.ParseInLocation(dateLayout, d, now.Location())
+		if err != nil {
+			continue
+		}
+		if !prev.IsZero() && prev.AddDate(0, 0, 1).Equal(day) {
+			run++
+		} else {
+			run = 1
+		}
+		st.Longest = max(st.Longest, run)
+		prev = day
+	}
+	today := now.Format(dateLayout)
+	yesterday := now.AddDate(0, 0, -1).Format(dateLayout)
+	switch last := prev.Format(dateLayout); {
+	case prev.IsZero():
+	case last == today:
+		st.Current, st.Today = run, true
+	case last == yesterday:
+		st.Current = run
+	}
+	return st
+}
+
+// Unlock records an achievement as unlocked at time at. It reports false,
+// leaving the store unchanged, if it was already unlocked.
+func (s *Store) Unlock(achievement string, at time.Time) bool {
+	if _, ok := s.Achievements[achievement]; ok {
+		return false
+	}
+	if s.Achievements == nil {
+		s.Achievements = make(map[string]time.Time)
+	}
+	s.Achievements[achievement] = at
+	return true
+}
+
+// Unlocked returns when an achievement was unlocked.
+func (s *Store) Unlocked(achievement string) (time.Time, bool) {
+	at, ok := s.Achievements[achievement]
+	return at, ok
+}
+
+// LearnerSeed returns the seed that varies this learner's exercise test
+// data, choosing one with newSeed the first time. It reports whether the
+// seed is new, in which case the store needs saving.
+func (s *Store) LearnerSeed(newSeed func() int64) (seed int64, created bool) {
+	if s.Seed != 0 {
+		return s.Seed, false
+	}
+	for s.Seed == 0 {
+		s.Seed = newSeed()
+	}
+	return s.Seed, true
+}
+
+// IDs returns the IDs of every exercise with recorded progress, sorted.
+func (s *Store) IDs() []string {
+	ids := make([]string, 0, len(s.Exercises))
+	for id := range s.Exercises {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,189 @@
+package progress
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var t0 = time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+
+func TestOpenMissingFile(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "progress.json"))
+	require.NoError(t, err)
+	assert.Empty(t, s.IDs())
+	assert.Equal(t, Exercise{}, s.Get("01-basics/exercise1_fix_bugs"))
+}
+
+func TestOpenCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o644))
+	_, err := Open(path)
+	assert.ErrorContains(t, err, path)
+}
+
+func TestRecordAttempt(t *testing.T) {
+	var s Store
+	const id = "01-basics/exercise1_fix_bugs"
+
+	s.RecordAttempt(id, false, t0)
+	s.RecordAttempt(id, false, t0.Add(10*time.Minute))
+	e := s.Get(id)
+	assert.Equal(t, 2, e.Attempts)
+	assert.False(t, e.Passed)
+	assert.True(t, e.CompletedAt.IsZero())
+	assert.Equal(t, 10*time.Minute, e.TimeSpent())
+
+	s.RecordAttempt(id, true, t0.Add(25*time.Minute))
+	s.RecordAttempt(id, false, t0.Add(time.Hour))
+	e = s.Get(id)
+	assert.Equal(t, 4, e.Attempts)
+	assert.False(t, e.Passed, "latest run failed")
+	assert.Equal(t, t0.Add(25*time.Minute), e.CompletedAt, "completion time is kept")
+	assert.Equal(t, 25*time.Minute, e.TimeSpent())
+	assert.Equal(t, 2, e.FailedRuns, "failures after completion are not counted")
+}
+
+func TestRecordAttemptSessions(t *testing.T) {
+	var s Store
+	const id = "01-basics/exercise1_fix_bugs"
+
+	s.RecordAttempt(id, false, t0)
+	s.RecordAttempt(id, false, t0.Add(20*time.Minute))
+	s.RecordAttempt(id, false, t0.Add(45*time.Minute))
+	// Back the next day.
+	s.RecordAttempt(id, false, t0.Add(24*time.Hour))
+	s.RecordAttempt(id, true, t0.Add(24*time.Hour+SessionGap))
+
+	e := s.Get(id)
+	assert.Equal(t, 2, e.Sessions)
+	assert.Equal(t, 45*time.Minute+SessionGap, e.ActiveTime)
+	assert.Equal(t, 4, e.FailedRuns)
+	assert.Equal(t, 24*time.Hour+SessionGap, e.TimeSpent(), "TimeSpent includes the breaks")
+}
+
+func TestRecordMistake(t *testing.T) {
+	var s Store
+	const id = "02-types-interfaces/exercise1_shapes"
+
+	s.RecordMistake(id, "pointer-receiver", t0.Add(time.Hour))
+	s.RecordMistake(id, "pointer-receiver", t0)
+	s.RecordMistake(id, "nil-map", t0)
+	assert.Equal(t, map[string]Mistake{
+		"pointer-receiver": {Count: 2, Last: t0.Add(time.Hour)},
+		"nil-map":          {Count: 1, Last: t0},
+	}, s.Get(id).Mistakes)
+}
+
+func TestSaveAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "progress.json")
+	s, err := Open(path)
+	require.NoError(t, err)
+
+	s.RecordAttempt("01-basics/exercise1_fix_bugs", true, t0)
+	s.RecordHint("02-types/exercise1")
+	require.NoError(t, s.Save())
+
+	reopened, err := Open(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"01-basics/exercise1_fix_bugs", "02-types/exercise1"}, reopened.IDs())
+	assert.True(t, reopened.Get("01-basics/exercise1_fix_bugs").CompletedAt.Equal(t0))
+	assert.Equal(t, 1, reopened.Get("02-types/exercise1").HintsUsed)
+}
+
+func TestRecordRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	s, err := Open(path)
+	require.NoError(t, err)
+	_, ok := s.LastRun("01-basics")
+	assert.False(t, ok)
+
+	s.RecordRun("01-basics", Run{At: t0, Failed: []string{"01-basics/exercise1_fix_bugs"}})
+	s.RecordRun("01-basics", Run{At: t0.Add(time.Hour), Passed: []string{"01-basics/exercise1_fix_bugs"}})
+	require.NoError(t, s.Save())
+
+	reopened, err := Open(path)
+	require.NoError(t, err)
+	run, ok := reopened.LastRun("01-basics")
+	require.True(t, ok)
+	assert.True(t, run.At.Equal(t0.Add(time.Hour)), "the latest run replaces the previous one")
+	assert.Equal(t, 1, run.Total())
+	assert.Empty(t, run.Failed)
+}
+
+func TestRecordQuiz(t *testing.T) {
+	var s Store
+	assert.Equal(t, Quiz{}, s.Quiz("02-types-interfaces"))
+
+	s.RecordQuiz("02-types-interfaces", Score{Correct: 3, Total: 5, At: t0})
+	s.RecordQuiz("02-types-interfaces", Score{Correct: 5, Total: 5, At: t0.Add(time.Hour)})
+	s.RecordQuiz("02-types-interfaces", Score{Correct: 1, Total: 5, At: t0.Add(2 * time.Hour)})
+
+	q := s.Quiz("02-types-interfaces")
+	assert.Equal(t, 3, q.Attempts)
+	assert.Equal(t, 20, q.Last.Percent())
+	assert.Equal(t, 100, q.Best.Percent())
+	assert.Equal(t, t0.Add(time.Hour), q.Best.At)
+	assert.Equal(t, 0, Score{}.Percent())
+}
+
+func TestStreak(t *testing.T) {
+	var s Store
+	assert.Equal(t, Streak{}, s.Streak(t0))
+
+	day := func(n int) time.Time { return t0.AddDate(0, 0, n) }
+	s.RecordAttempt("01-basics/exercise1_fix_bugs", false, day(-10))
+	s.RecordAttempt("01-basics/exercise1_fix_bugs", false, day(-9))
+	s.RecordQuiz("01-basics", Score{Correct: 1, Total: 2, At: day(-8)})
+	s.RecordDay(day(-2))
+	s.RecordDay(day(-1).Add(13 * time.Hour)) // late at night, still day -1
+	s.RecordDay(day(-2))
+	assert.Equal(t, []string{"2025-12-23", "2025-12-24", "2025-12-25", "2025-12-31", "2026-01-01"}, s.Days, "sorted, no duplicates")
+
+	assert.Equal(t, Streak{Current: 2, Longest: 3}, s.Streak(t0), "practiced yesterday, across the new year: the streak ends tonight")
+	s.RecordDay(t0)
+	assert.Equal(t, Streak{Current: 3, Longest: 3, Today: true}, s.Streak(t0))
+	assert.Equal(t, Streak{Longest: 3}, s.Streak(day(2)), "a missed day ends the streak")
+}
+
+func TestUnlock(t *testing.T) {
+	var s Store
+	_, ok := s.Unlocked("first-green")
+	assert.False(t, ok)
+
+	assert.True(t, s.Unlock("first-green", t0))
+	assert.False(t, s.Unlock("first-green", t0.Add(time.Hour)), "already unlocked")
+	at, ok := s.Unlocked("first-green")
+	assert.True(t, ok)
+	assert.Equal(t, t0, at, "the first unlock time is kept")
+}
+
+func TestLearnerSeed(t *testing.T) {
+	var s Store
+	seeds := []int64{0, 42, 7}
+	next := func() int64 { n := seeds[0]; seeds = seeds[1:]; return n }
+
+	seed, created := s.LearnerSeed(next)
+	assert.True(t, created)
+	assert.Equal(t, int64(42), seed, "zero means unset, so another is drawn")
+
+	seed, created = s.LearnerSeed(next)
+	assert.False(t, created)
+	assert.Equal(t, int64(42), seed, "the seed is kept")
+}
+
+func TestSaveWithoutPath(t *testing.T) {
+	var s Store
+	assert.Error(t, s.Save())
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("LEARNGO_PROGRESS", "")
+	assert.Equal(t, filepath.Join("/course", ".learngo", "progress.json"), DefaultPath("/course"))
+	t.Setenv("LEARNGO_PROGRESS", "/tmp/mine.json")
+	assert.Equal(t, "/tmp/mine.json", DefaultPath("/course"))
+}
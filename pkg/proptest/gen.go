@@ -0,0 +1,256 @@
+package proptest
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Const always generates v, which does not shrink.
+func Const[T any](v T) Gen[T] {
+	return func(*rand.Rand, int) Tree[T] { return Tree[T]{Value: v} }
+}
+
+// OneOf generates one of values, and shrinks towards the first.
+func OneOf[T any](values ...T) Gen[T] {
+	if len(values) == 0 {
+		panic("proptest: OneOf with no values")
+	}
+	return func(r *rand.Rand, _ int) Tree[T] {
+		return indexTree(values, r.Intn(len(values)))
+	}
+}
+
+func indexTree[T any](values []T, i int) Tree[T] {
+	return Tree[T]{Value: values[i], shrink: func() []Tree[T] {
+		var out []Tree[T]
+		for _, j := range ShrinkInt(i, 0) {
+			out = append(out, indexTree(values, j))
+		}
+		return out
+	}}
+}
+
+// Bool generates true or false, and shrinks true to false.
+func Bool() Gen[bool] {
+	return func(r *rand.Rand, _ int) Tree[bool] {
+		return NewTree(r.Intn(2) == 1, func(b bool) []bool {
+			if b {
+				return []bool{false}
+			}
+			return nil
+		})
+	}
+}
+
+// Int generates ints in [lo, hi], no further from the value closest to 0
+// than the size, and shrinks towards that value.
+func Int(lo, hi int) Gen[int] {
+	if lo > hi {
+		panic("proptest: Int with lo > hi")
+	}
+	target := min(max(0, lo), hi)
+	return func(r *rand.Rand, size int) Tree[int] {
+		a, b := max(lo, target-size), min(hi, target+size)
+		v := a + r.Intn(b-a+1)
+		return NewTree(v, func(v int) []int { return ShrinkInt(v, target) })
+	}
+}
+
+// ShrinkInt returns the ints between target and v that v shrinks to,
+// closest to target first: target itself, then halfway, a quarter of the
+// way from v, and so on, up to v's neighbour. It never returns v.
+func ShrinkInt(v, target int) []int {
+	var out []int
+	for d := v - target; d != 0; d /= 2 {
+		out = append(out, v-d)
+	}
+	return out
+}
+
+// Float64 generates floats in [lo, hi], and shrinks towards the value
+// closest to 0, through whole numbers.
+func Float64(lo, hi float64) Gen[float64] {
+	if lo > hi {
+		panic("proptest: Float64 with lo > hi")
+	}
+	target := math.Min(math.Max(0, lo), hi)
+	return func(r *rand.Rand, size int) Tree[float64] {
+		a, b := math.Max(lo, target-float64(size)), math.Min(hi, target+float64(size))
+		v := a + r.Float64()*(b-a)
+		return NewTree(v, func(v float64) []float64 { return shrinkFloat(v, target, lo, hi) })
+	}
+}
+
+func shrinkFloat(v, target, lo, hi float64) []float64 {
+	if math.Abs(v-target) < 1e-6 {
+		return nil
+	}
+	out := []float64{target}
+	if t := math.Trunc(v); t != v && t != target && t >= lo && t <= hi {
+		out = append(out, t)
+	}
+	if mid := target + (v-target)/2; mid != v && mid != target {
+		out = append(out, mid)
+	}
+	return out
+}
+
+// Rune generates mostly lowercase ASCII letters, and now and then a rune
+// from elsewhere in Unicode, or a space; it shrinks towards 'a'.
+func Rune() Gen[rune] {
+	other := []rune(" AZ09é日ß́😀")
+	return func(r *rand.Rand, _ int) Tree[rune] {
+		v := 'a' + rune(r.Intn(26))
+		if r.Intn(5) == 0 {
+			v = other[r.Intn(len(other))]
+		}
+		return NewTree(v, func(v rune) []rune {
+			if v == 'a' {
+				return nil
+			}
+			if v > 'a' && v <= 'z' {
+				return ShrinkRune(v)
+			}
+			return []rune{'a'}
+		})
+	}
+}
+
+// ShrinkRune shrinks a lowercase letter towards 'a'.
+func ShrinkRune(v rune) []rune {
+	var out []rune
+	for _, n := range ShrinkInt(int(v-'a'), 0) {
+		out = append(out, 'a'+rune(n))
+	}
+	return out
+}
+
+// String generates strings of up to size runes from Rune, and shrinks
+// them by dropping runes and shrinking the rest.
+func String() Gen[string] {
+	return Map(SliceOf(Rune()), func(rs []rune) string { return string(rs) })
+}
+
+// SliceOf generates slices of up to size elements from g. A slice shrinks
+// to the empty slice, to either half, to itself without one element, and
+// to itself with one element shrunk.
+func SliceOf[T any](g Gen[T]) Gen[[]T] {
+	return func(r *rand.Rand, size int) Tree[[]T] {
+		elems := make([]Tree[T], r.Intn(size+1))
+		for i := range elems {
+			elems[i] = g(r, size)
+		}
+		return sliceTree(elems)
+	}
+}
+
+func sliceTree[T any](elems []Tree[T]) Tree[[]T] {
+	value := make([]T, len(elems))
+	for i, e := range elems {
+		value[i] = e.Value
+	}
+	return Tree[[]T]{Value: value, shrink: func() []Tree[[]T] {
+		n := len(elems)
+		var out []Tree[[]T]
+		if n == 0 {
+			return nil
+		}
+		out = append(out, sliceTree[T](nil))
+		if n > 2 {
+			out = append(out, sliceTree(elems[:n/2]), sliceTree(elems[n/2:]))
+		}
+		for i := range elems {
+			out = append(out, sliceTree(without(elems, i)))
+		}
+		for i, e := range elems {
+			for _, s := range e.Shrinks() {
+				shrunk := append([]Tree[T](nil), elems...)
+				shrunk[i] = s
+				out = append(out, sliceTree(shrunk))
+			}
+		}
+		return out
+	}}
+}
+
+func without[T any](s []T, i int) []T {
+	out := make([]T, 0, len(s)-1)
+	out = append(out, s[:i]...)
+	return append(out, s[i+1:]...)
+}
+
+// Map generates f(v) for the values v from g, and shrinks by shrinking v.
+func Map[T, U any](g Gen[T], f func(T) U) Gen[U] {
+	return func(r *rand.Rand, size int) Tree[U] {
+		return mapTree(g(r, size), f)
+	}
+}
+
+func mapTree[T, U any](t Tree[T], f func(T) U) Tree[U] {
+	return Tree[U]{Value: f(t.Value), shrink: func() []Tree[U] {
+		var out []Tree[U]
+		for _, s := range t.Shrinks() {
+			out = append(out, mapTree(s, f))
+		}
+		return out
+	}}
+}
+
+// Map2 generates f(a, b) from two generators: a struct from its fields,
+// for instance. It shrinks a first, then b.
+func Map2[A, B, T any](ga Gen[A], gb Gen[B], f func(A, B) T) Gen[T] {
+	return func(r *rand.Rand, size int) Tree[T] {
+		return map2Tree(ga(r, size), gb(r, size), f)
+	}
+}
+
+func map2Tree[A, B, T any](a Tree[A], b Tree[B], f func(A, B) T) Tree[T] {
+	return Tree[T]{Value: f(a.Value, b.Value), shrink: func() []Tree[T] {
+		var out []Tree[T]
+		for _, s := range a.Shrinks() {
+			out = append(out, map2Tree(s, b, f))
+		}
+		for _, s := range b.Shrinks() {
+			out = append(out, map2Tree(a, s, f))
+		}
+		return out
+	}}
+}
+
+type pair[A, B any] struct {
+	a A
+	b B
+}
+
+// Map3 is Map2 with three generators.
+func Map3[A, B, C, T any](ga Gen[A], gb Gen[B], gc Gen[C], f func(A, B, C) T) Gen[T] {
+	ab := Map2(ga, gb, func(a A, b B) pair[A, B] { return pair[A, B]{a, b} })
+	return Map2(ab, gc, func(p pair[A, B], c C) T { return f(p.a, p.b, c) })
+}
+
+// Filter generates the values from g that keep returns true for, trying
+// up to 100 values each time, and shrinks only to values it keeps. It
+// panics if none of 100 values is kept: generate valid values directly
+// instead.
+func Filter[T any](g Gen[T], keep func(T) bool) Gen[T] {
+	return func(r *rand.Rand, size int) Tree[T] {
+		for i := 0; i < 100; i++ {
+			if t := g(r, size); keep(t.Value) {
+				return filterTree(t, keep)
+			}
+		}
+		panic("proptest: Filter kept none of 100 values")
+	}
+}
+
+func filterTree[T any](t Tree[T], keep func(T) bool) Tree[T] {
+	return Tree[T]{Value: t.Value, shrink: func() []Tree[T] {
+		var out []Tree[T]
+		for _, s := range t.Shrinks() {
+			if keep(s.Value) {
+				out = append(out, filterTree(s, keep))
+			}
+		}
+		return out
+	}}
+}
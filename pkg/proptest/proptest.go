@@ -0,0 +1,165 @@
+// Package proptest is a small property-based testing library for the
+// course. A property is a function that must return true for every input;
+// a generator makes random inputs for it, and when one fails, shrinks it
+// to a simpler input that still fails, so that the test reports []int{0, -1}
+// rather than forty numbers.
+//
+//	proptest.Check(t, proptest.SliceOf(proptest.Int(-100, 100)), func(xs []int) bool {
+//		return slices.Equal(Reverse(Reverse(xs)), xs)
+//	})
+//
+// Shrinking is integrated: a generator returns a Tree whose children are
+// the simpler values, so generators built with Map, Map2, and Map3 shrink
+// without any extra code. Inputs come from seed.Rand, so each learner's
+// tests try different ones, and LEARNGO_SEED replays a failure.
+package proptest
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+)
+
+// Tree is a generated value and the simpler values it shrinks to.
+type Tree[T any] struct {
+	Value  T
+	shrink func() []Tree[T]
+}
+
+// Shrinks returns the trees of the values that v shrinks to, simplest
+// first. They are computed when asked for, since there are many.
+func (t Tree[T]) Shrinks() []Tree[T] {
+	if t.shrink == nil {
+		return nil
+	}
+	return t.shrink()
+}
+
+// NewTree returns the tree of v, whose children come from calling shrink
+// on v and on each of them in turn. shrink must return values simpler
+// than its argument, or shrinking does not end until Config.MaxShrinks.
+func NewTree[T any](v T, shrink func(T) []T) Tree[T] {
+	return Tree[T]{Value: v, shrink: func() []Tree[T] {
+		var out []Tree[T]
+		for _, s := range shrink(v) {
+			out = append(out, NewTree(s, shrink))
+		}
+		return out
+	}}
+}
+
+// Gen generates random values of T. size grows from 1 to Config.MaxSize
+// over the runs of a check, so that the first inputs are small; generators
+// use it to bound lengths and magnitudes.
+type Gen[T any] func(r *rand.Rand, size int) Tree[T]
+
+// Config tunes a check. Zero fields take the defaults.
+type Config struct {
+	Runs       int   // inputs to try: 100
+	MaxSize    int   // the size of the last input: 100
+	MaxShrinks int   // shrinking steps before giving up: 1000
+	Seed       int64 // the base passed to seed.Rand: 1
+}
+
+func (c Config) withDefaults() Config {
+	if c.Runs <= 0 {
+		c.Runs = 100
+	}
+	if c.MaxSize <= 0 {
+		c.MaxSize = 100
+	}
+	if c.MaxShrinks <= 0 {
+		c.MaxShrinks = 1000
+	}
+	if c.Seed == 0 {
+		c.Seed = 1
+	}
+	return c
+}
+
+// Failure is a counterexample to a property.
+type Failure[T any] struct {
+	Value    T   // the shrunk counterexample
+	Original T   // as first generated
+	Run      int // the run that found it, from 1
+	Shrinks  int // how many times it was shrunk
+	Panic    any // what the property panicked with on Value, if it did
+}
+
+// Find tries prop on cfg.Runs values from g, and returns the first that
+// fails, shrunk, and true; or false if prop held for all of them. A panic
+// in prop counts as failing.
+func Find[T any](r *rand.Rand, cfg Config, g Gen[T], prop func(T) bool) (Failure[T], bool) {
+	cfg = cfg.withDefaults()
+	for run := 1; run <= cfg.Runs; run++ {
+		size := 1 + (run-1)*(cfg.MaxSize-1)/max(cfg.Runs-1, 1)
+		tree := g(r, size)
+		if ok, _ := holds(prop, tree.Value); ok {
+			continue
+		}
+		f := Failure[T]{Original: tree.Value, Run: run}
+		tree, f.Shrinks = shrink(tree, prop, cfg.MaxShrinks)
+		f.Value = tree.Value
+		_, f.Panic = holds(prop, f.Value)
+		return f, true
+	}
+	return Failure[T]{}, false
+}
+
+// shrink descends from a failing tree to the first child that fails as
+// well, until none does or it has taken max steps.
+func shrink[T any](tree Tree[T], prop func(T) bool, max int) (Tree[T], int) {
+	steps := 0
+	for steps < max {
+		next, found := tree, false
+		for _, child := range tree.Shrinks() {
+			if ok, _ := holds(prop, child.Value); !ok {
+				next, found = child, true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+		tree = next
+		steps++
+	}
+	return tree, steps
+}
+
+// holds calls prop, and turns a panic into a failure.
+func holds[T any](prop func(T) bool, v T) (ok bool, panicked any) {
+	defer func() {
+		if p := recover(); p != nil {
+			ok, panicked = false, p
+		}
+	}()
+	return prop(v), nil
+}
+
+// Check fails the test with a shrunk counterexample if prop does not hold
+// for values from g.
+func Check[T any](tb testing.TB, g Gen[T], prop func(T) bool) {
+	tb.Helper()
+	CheckWith(tb, Config{}, g, prop)
+}
+
+// CheckWith is Check with a Config.
+func CheckWith[T any](tb testing.TB, cfg Config, g Gen[T], prop func(T) bool) {
+	tb.Helper()
+	cfg = cfg.withDefaults()
+	f, failed := Find(seed.Rand(tb, cfg.Seed), cfg, g, prop)
+	if !failed {
+		return
+	}
+	msg := fmt.Sprintf("property failed on run %d of %d, for\n\t%#v", f.Run, cfg.Runs, f.Value)
+	if f.Panic != nil {
+		msg += fmt.Sprintf("\nwhich panics: %v", f.Panic)
+	}
+	if f.Shrinks > 0 {
+		msg += fmt.Sprintf("\nshrunk %d times from\n\t%#v", f.Shrinks, f.Original)
+	}
+	tb.Error(msg)
+}
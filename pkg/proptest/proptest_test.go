@@ -0,0 +1,137 @@
+package proptest
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func find[T any](t *testing.T, g Gen[T], prop func(T) bool) Failure[T] {
+	t.Helper()
+	f, ok := Find(rand.New(rand.NewSource(1)), Config{}, g, prop)
+	require.True(t, ok, "no counterexample")
+	return f
+}
+
+func TestFindShrinksInts(t *testing.T) {
+	f := find(t, Int(-1000, 1000), func(n int) bool { return n < 10 })
+	assert.Equal(t, 10, f.Value, "the smallest counterexample")
+	assert.GreaterOrEqual(t, f.Original, 10)
+
+	f = find(t, Int(5, 1000), func(n int) bool { return n%7 != 0 })
+	assert.Equal(t, 7, f.Value, "shrinks towards lo, not below it")
+}
+
+func TestFindShrinksSlices(t *testing.T) {
+	f := find(t, SliceOf(Int(-100, 100)), func(xs []int) bool {
+		for _, x := range xs {
+			if x < 0 {
+				return false
+			}
+		}
+		return true
+	})
+	assert.Equal(t, []int{-1}, f.Value)
+
+	f = find(t, SliceOf(Int(-100, 100)), func(xs []int) bool { return !slices.Contains(xs, 3) || len(xs) < 2 })
+	assert.ElementsMatch(t, []int{0, 3}, f.Value, "one other element, shrunk to 0")
+}
+
+func TestFindShrinksStructs(t *testing.T) {
+	type point struct{ X, Y int }
+	g := Map2(Int(-50, 50), Int(-50, 50), func(x, y int) point { return point{x, y} })
+	f := find(t, g, func(p point) bool { return p.X+p.Y < 20 })
+	assert.Equal(t, 20, f.Value.X+f.Value.Y)
+	assert.True(t, f.Value.X == 0 || f.Value.Y == 0 || f.Value.X == 20-f.Value.Y, "fields shrink one at a time")
+
+	type box struct{ W, H, D int }
+	g3 := Map3(Int(0, 50), Int(0, 50), Int(0, 50), func(w, h, d int) box { return box{w, h, d} })
+	fb := find(t, g3, func(b box) bool { return b.D < 5 })
+	assert.Equal(t, box{0, 0, 5}, fb.Value)
+}
+
+func TestFindStrings(t *testing.T) {
+	f := find(t, String(), func(s string) bool { return !strings.ContainsRune(s, 'q') })
+	assert.Equal(t, "q", f.Value)
+}
+
+func TestFindPanics(t *testing.T) {
+	f := find(t, SliceOf(Int(0, 10)), func(xs []int) bool { return xs[0] >= 0 })
+	assert.Equal(t, []int{}, f.Value)
+	assert.NotNil(t, f.Panic)
+}
+
+func TestFindHolds(t *testing.T) {
+	_, failed := Find(rand.New(rand.NewSource(1)), Config{Runs: 50}, SliceOf(Int(-5, 5)), func(xs []int) bool {
+		return len(xs) <= 100
+	})
+	assert.False(t, failed)
+}
+
+func TestFindMaxShrinks(t *testing.T) {
+	// A shrinker that returns its argument never ends on its own.
+	g := func(r *rand.Rand, size int) Tree[int] {
+		return NewTree(r.Intn(10), func(v int) []int { return []int{v} })
+	}
+	f, ok := Find(rand.New(rand.NewSource(1)), Config{MaxShrinks: 25}, g, func(int) bool { return false })
+	require.True(t, ok)
+	assert.Equal(t, 25, f.Shrinks)
+}
+
+func TestFilter(t *testing.T) {
+	even := Filter(Int(0, 100), func(n int) bool { return n%2 == 0 })
+	f := find(t, even, func(n int) bool { return n < 9 })
+	assert.Zero(t, f.Value%2, "odd numbers are not generated, nor shrunk to")
+	assert.GreaterOrEqual(t, f.Value, 10)
+	assert.Panics(t, func() {
+		Filter(Int(0, 10), func(int) bool { return false })(rand.New(rand.NewSource(1)), 10)
+	})
+}
+
+func TestShrinkInt(t *testing.T) {
+	assert.Equal(t, []int{0, 5, 8, 9}, ShrinkInt(10, 0))
+	assert.Equal(t, []int{0, -4, -6}, ShrinkInt(-7, 0))
+	assert.Empty(t, ShrinkInt(3, 3))
+	assert.Equal(t, []rune{'a', 'c', 'd'}, ShrinkRune('e'))
+}
+
+func TestOneOf(t *testing.T) {
+	f := find(t, OneOf("x", "y", "z"), func(s string) bool { return s == "x" })
+	assert.Equal(t, "y", f.Value)
+}
+
+// recorder is a testing.TB that records failures instead of failing.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper()                   {}
+func (r *recorder) Logf(string, ...any)       {}
+func (r *recorder) Error(args ...any)         { r.errors = append(r.errors, fmt.Sprint(args...)) }
+func (r *recorder) Errorf(f string, a ...any) { r.errors = append(r.errors, fmt.Sprintf(f, a...)) }
+
+func TestCheck(t *testing.T) {
+	t.Setenv(seed.EnvVar, "")
+	rec := &recorder{TB: t}
+	Check(rec, SliceOf(Int(-100, 100)), func(xs []int) bool { return len(xs) < 3 })
+	require.Len(t, rec.errors, 1)
+	assert.Contains(t, rec.errors[0], "property failed on run")
+	assert.Contains(t, rec.errors[0], "[]int{0, 0, 0}")
+	assert.Contains(t, rec.errors[0], "shrunk")
+
+	rec = &recorder{TB: t}
+	Check(rec, Int(0, 10), func(n int) bool { return 10/n >= 1 })
+	require.Len(t, rec.errors, 1)
+	assert.Contains(t, rec.errors[0], "\t0\nwhich panics: runtime error: integer divide by zero")
+
+	rec = &recorder{TB: t}
+	Check(rec, Bool(), func(b bool) bool { return b || !b })
+	assert.Empty(t, rec.errors)
+}
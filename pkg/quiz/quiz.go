@@ -0,0 +1,211 @@
+// Package quiz loads a module's multiple-choice question bank and runs it
+// interactively.
+//
+// A bank is a quiz.json file in the module directory:
+//
+//	{
+//	  "questions": [
+//	    {
+//	      "id": "implicit-satisfaction",
+//	      "topic": "interfaces",
+//	      "question": "How does a type declare that it implements an interface?",
+//	      "choices": ["With an implements clause", "It doesn't; having the methods is enough"],
+//	      "answer": 1,
+//	      "explanation": "Interfaces are satisfied implicitly."
+//	    }
+//	  ]
+//	}
+//
+// answer is the zero-based index of the correct choice. code is an
+// optional snippet shown below the question.
+package quiz
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileName is the name of a module's question bank.
+const FileName = "quiz.json"
+
+// ErrNoQuiz is returned by LoadModule when a module has no question bank.
+var ErrNoQuiz = errors.New("module has no quiz")
+
+// Question is one multiple-choice question.
+type Question struct {
+	ID          string   `json:"id"`
+	Topic       string   `json:"topic"`
+	Question    string   `json:"question"`
+	Code        string   `json:"code,omitempty"`
+	Choices     []string `json:"choices"`
+	Answer      int      `json:"answer"`
+	Explanation string   `json:"explanation"`
+}
+
+// Bank is a module's question bank.
+type Bank struct {
+	Questions []Question `json:"questions"`
+}
+
+// Load reads and validates a question bank.
+func Load(path string) (*Bank, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b Bank
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := b.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &b, nil
+}
+
+// LoadModule reads the question bank in a module directory.
+func LoadModule(dir string) (*Bank, error) {
+	b, err := Load(filepath.Join(dir, FileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoQuiz
+	}
+	return b, err
+}
+
+// Validate checks that every question is answerable and IDs are unique.
+func (b *Bank) Validate() error {
+	if len(b.Questions) == 0 {
+		return errors.New("no questions")
+	}
+	seen := make(map[string]bool)
+	for i, q := range b.Questions {
+		switch {
+		case q.ID == "":
+			return fmt.Errorf("question %d: missing id", i+1)
+		case seen[q.ID]:
+			return fmt.Errorf("question %q: duplicate id", q.ID)
+		case strings.TrimSpace(q.Question) == "":
+			return fmt.Errorf("question %q: empty question", q.ID)
+		case len(q.Choices) < 2 || len(q.Choices) > 26:
+			return fmt.Errorf("question %q: need 2 to 26 choices, have %d", q.ID, len(q.Choices))
+		case q.Answer < 0 || q.Answer >= len(q.Choices):
+			return fmt.Errorf("question %q: answer %d out of range", q.ID, q.Answer)
+		}
+		seen[q.ID] = true
+	}
+	return nil
+}
+
+// Pick returns up to n questions in random order, each with its choices
+// shuffled and Answer updated to match. The bank is not modified.
+func (b *Bank) Pick(n int, rng *rand.Rand) []Question {
+	order := rng.Perm(len(b.Questions))
+	if n > 0 && n < len(order) {
+		order = order[:n]
+	}
+	picked := make([]Question, 0, len(order))
+	for _, i := range order {
+		q := b.Questions[i]
+		perm := rng.Perm(len(q.Choices))
+		choices := make([]string, len(q.Choices))
+		answer := q.Answer
+		for newPos, oldPos := range perm {
+			choices[newPos] = q.Choices[oldPos]
+			if oldPos == answer {
+				q.Answer = newPos
+			}
+		}
+		q.Choices = choices
+		picked = append(picked, q)
+	}
+	return picked
+}
+
+// Miss is a wrongly answered question.
+type Miss struct {
+	Question Question
+	Given    int // index of the chosen answer
+}
+
+// Result is the outcome of a quiz.
+type Result struct {
+	Correct int
+	Total   int
+	Misses  []Miss
+}
+
+// Run asks each question on w, reads answers (a letter per line) from r,
+// and explains each wrong answer right away. A blank line or an invalid
+// letter asks again; end of input stops the quiz early, counting only the
+// questions answered.
+func Run(questions []Question, r io.Reader, w io.Writer) (Result, error) {
+	var res Result
+	in := bufio.NewScanner(r)
+	for i, q := range questions {
+		fmt.Fprintf(w, "\nQuestion %d/%d", i+1, len(questions))
+		if q.Topic != "" {
+			fmt.Fprintf(w, " [%s]", q.Topic)
+		}
+		fmt.Fprintf(w, "\n%s\n", q.Question)
+		if q.Code != "" {
+			fmt.Fprintf(w, "\n%s\n", indent(q.Code))
+		}
+		fmt.Fprintln(w)
+		for j, c := range q.Choices {
+			fmt.Fprintf(w, "  %c) %s\n", 'a'+j, c)
+		}
+
+		given, ok, err := readChoice(in, w, len(q.Choices))
+		if err != nil {
+			return res, err
+		}
+		if !ok {
+			return res, nil // input ended
+		}
+		res.Total++
+		if given == q.Answer {
+			res.Correct++
+			fmt.Fprintln(w, "Correct!")
+			continue
+		}
+		res.Misses = append(res.Misses, Miss{Question: q, Given: given})
+		fmt.Fprintf(w, "Not quite. The answer is %c) %s\n", 'a'+q.Answer, q.Choices[q.Answer])
+		if q.Explanation != "" {
+			fmt.Fprintf(w, "%s\n", q.Explanation)
+		}
+	}
+	return res, nil
+}
+
+// readChoice prompts until it reads a valid letter. ok is false at end of
+// input.
+func readChoice(in *bufio.Scanner, w io.Writer, n int) (choice int, ok bool, err error) {
+	last := 'a' + rune(n-1)
+	for {
+		fmt.Fprintf(w, "Your answer (a-%c): ", last)
+		if !in.Scan() {
+			fmt.Fprintln(w)
+			return 0, false, in.Err()
+		}
+		answer := strings.ToLower(strings.TrimSpace(in.Text()))
+		if len(answer) == 1 && answer[0] >= 'a' && rune(answer[0]) <= last {
+			return int(answer[0] - 'a'), true, nil
+		}
+		fmt.Fprintf(w, "Please type a letter from a to %c.\n", last)
+	}
+}
+
+func indent(code string) string {
+	lines := strings.Split(strings.TrimRight(code, "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = "    " + l
+	}
+	return strings.Join(lines, "\n")
+}
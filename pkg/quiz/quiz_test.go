@@ -0,0 +1,120 @@
+package quiz
+
+import (
+	"bytes"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleBank() *Bank {
+	return &Bank{Questions: []Question{
+		{ID: "q1", Topic: "interfaces", Question: "One?", Choices: []string{"right", "wrong"}, Answer: 0, Explanation: "Because one."},
+		{ID: "q2", Question: "Two?", Choices: []string{"wrong", "wrong too", "right"}, Answer: 2},
+		{ID: "q3", Question: "Three?", Code: "x := 3", Choices: []string{"wrong", "right"}, Answer: 1},
+	}}
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, sampleBank().Validate())
+
+	tests := []struct {
+		name   string
+		mutate func(b *Bank)
+		want   string
+	}{
+		{"empty", func(b *Bank) { b.Questions = nil }, "no questions"},
+		{"missing id", func(b *Bank) { b.Questions[0].ID = "" }, "missing id"},
+		{"duplicate id", func(b *Bank) { b.Questions[1].ID = "q1" }, "duplicate id"},
+		{"empty question", func(b *Bank) { b.Questions[0].Question = " " }, "empty question"},
+		{"one choice", func(b *Bank) { b.Questions[0].Choices = []string{"only"} }, "need 2 to 26 choices"},
+		{"answer out of range", func(b *Bank) { b.Questions[1].Answer = 3 }, "out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := sampleBank()
+			tt.mutate(b)
+			assert.ErrorContains(t, b.Validate(), tt.want)
+		})
+	}
+}
+
+func TestLoadModule(t *testing.T) {
+	dir := t.TempDir()
+	_, err := LoadModule(dir)
+	assert.ErrorIs(t, err, ErrNoQuiz)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, FileName), []byte(`{"questions": [{"id": "a", "question": "?", "choices": ["x"], "answer": 0}]}`), 0o644))
+	_, err = LoadModule(dir)
+	assert.ErrorContains(t, err, "need 2 to 26 choices")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, FileName), []byte(`{"questions": [`), 0o644))
+	_, err = LoadModule(dir)
+	assert.ErrorContains(t, err, "parsing")
+}
+
+func TestPickKeepsAnswersCorrect(t *testing.T) {
+	b := sampleBank()
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 20; i++ {
+		picked := b.Pick(0, rng)
+		require.Len(t, picked, 3)
+		for _, q := range picked {
+			assert.Equal(t, "right", q.Choices[q.Answer], "question %s", q.ID)
+		}
+	}
+	assert.Len(t, b.Pick(2, rng), 2)
+	assert.Equal(t, []string{"right", "wrong"}, b.Questions[0].Choices, "bank is not modified")
+}
+
+func TestRun(t *testing.T) {
+	questions := sampleBank().Questions
+	var out bytes.Buffer
+	// q1 right, q2 wrong after an invalid answer, q3 right (uppercase)
+	res, err := Run(questions, strings.NewReader("a\nz\n\nb\nB\n"), &out)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Correct)
+	assert.Equal(t, 3, res.Total)
+	require.Len(t, res.Misses, 1)
+	assert.Equal(t, "q2", res.Misses[0].Question.ID)
+	assert.Equal(t, 1, res.Misses[0].Given)
+
+	text := out.String()
+	assert.Contains(t, text, "Question 1/3 [interfaces]\nOne?")
+	assert.Contains(t, text, "    x := 3")
+	assert.Contains(t, text, "Please type a letter from a to c.")
+	assert.Contains(t, text, "Not quite. The answer is c) right")
+}
+
+func TestRunStopsAtEndOfInput(t *testing.T) {
+	res, err := Run(sampleBank().Questions, strings.NewReader("b\n"), &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Total)
+	assert.Equal(t, 0, res.Correct)
+	require.Len(t, res.Misses, 1)
+	assert.Equal(t, "Because one.", res.Misses[0].Question.Explanation)
+}
+
+// TestCourseBanks validates every quiz.json in the repository.
+func TestCourseBanks(t *testing.T) {
+	root, err := course.FindRoot(".")
+	require.NoError(t, err)
+	c, err := course.Load(root)
+	require.NoError(t, err)
+	found := 0
+	for _, m := range c.Modules {
+		_, err := LoadModule(m.Dir)
+		if err == ErrNoQuiz {
+			continue
+		}
+		found++
+		assert.NoError(t, err, m.ID)
+	}
+	assert.NotZero(t, found)
+}
@@ -0,0 +1,276 @@
+// Package readme keeps the parts of a module's README that index its code
+// in step with the code. The README marks each generated section:
+//
+//	<!-- learngo:exercises -->
+//	(generated, replaced on every run)
+//	<!-- /learngo:exercises -->
+//
+// and Generate fills the sections in:
+//
+//   - objectives: the module's description, objectives, and estimated time
+//     from the course manifest
+//   - examples: each example file and the functions and types it declares
+//   - exercises: each exercise in course order, with the objective from
+//     its EXERCISE: comment, its concepts and difficulty, and its tests
+//
+// Everything outside the markers is left alone. A module without a README
+// gets one with its title and every section.
+package readme
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/cheatsheet"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+)
+
+// FileName is the name of a module's README.
+const FileName = "README.md"
+
+// Sections lists the generated sections, in the order a new README has
+// them.
+var Sections = []string{"objectives", "examples", "exercises"}
+
+var difficultyNames = map[int]string{
+	course.Easy:   "easy",
+	course.Medium: "medium",
+	course.Hard:   "hard",
+}
+
+// Generate returns the module's README as it is and as it should be. The
+// current one is nil if the module has none.
+func Generate(m *course.Module) (current, updated []byte, err error) {
+	path := filepath.Join(m.Dir, FileName)
+	current, err = os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, err
+	}
+	sections, err := build(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	if current == nil {
+		return nil, skeleton(m, sections), nil
+	}
+	updated, err = replace(current, sections)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return current, updated, nil
+}
+
+// build renders every section for m.
+func build(m *course.Module) (map[string]string, error) {
+	var objectives strings.Builder
+	if m.Description != "" {
+		fmt.Fprintf(&objectives, "%s\n\n", m.Description)
+	}
+	if len(m.Objectives) > 0 {
+		objectives.WriteString("By completing this module, you will:\n")
+		for _, o := range m.Objectives {
+			fmt.Fprintf(&objectives, "- %s\n", o)
+		}
+		objectives.WriteString("\n")
+	}
+	if m.Estimate > 0 {
+		fmt.Fprintf(&objectives, "Estimated time: %s.\n", formatEstimate(m.Estimate))
+	}
+
+	var examples strings.Builder
+	sheet, err := cheatsheet.Build(m)
+	switch {
+	case errors.Is(err, cheatsheet.ErrNoExamples):
+		examples.WriteString("No examples yet.\n")
+	case err != nil:
+		return nil, err
+	default:
+		for _, f := range sheet.Files {
+			var names []string
+			for _, d := range f.Decls {
+				if !strings.Contains(d.Name, ".") {
+					names = append(names, "`"+d.Name+"`")
+				}
+			}
+			fmt.Fprintf(&examples, "- **%s/%s**", cheatsheet.ExamplesDir, f.Name)
+			if len(names) > 0 {
+				fmt.Fprintf(&examples, ": %s", strings.Join(names, ", "))
+			}
+			examples.WriteString("\n")
+		}
+	}
+
+	var exercises strings.Builder
+	if len(m.Exercises) == 0 {
+		exercises.WriteString("No exercises yet.\n")
+	}
+	for i, e := range m.Exercises {
+		doc, err := e.Doc()
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&exercises, "%d. **%s.go**", i+1, e.Name)
+		if objective := objective(doc.Intro); objective != "" {
+			fmt.Fprintf(&exercises, " - %s", objective)
+		}
+		exercises.WriteString("\n")
+		if len(e.Concepts) > 0 {
+			fmt.Fprintf(&exercises, "   - Concepts: %s", strings.Join(e.Concepts, ", "))
+			if d, ok := difficultyNames[e.Difficulty]; ok {
+				fmt.Fprintf(&exercises, " (%s)", d)
+			}
+			exercises.WriteString("\n")
+		}
+		tests, fuzz, err := testNames(strings.TrimSuffix(e.File, ".go") + "_test.go")
+		if err != nil {
+			return nil, err
+		}
+		if len(tests) > 0 {
+			fmt.Fprintf(&exercises, "   - Tests: `%s`\n", strings.Join(tests, "`, `"))
+		}
+		if len(fuzz) > 0 {
+			fmt.Fprintf(&exercises, "   - Fuzz targets: `%s`\n", strings.Join(fuzz, "`, `"))
+		}
+		if len(e.Benchmarks) > 0 {
+			var benches []string
+			for _, b := range e.Benchmarks {
+				benches = append(benches, fmt.Sprintf("`%s` (%s)", b.Name, limits(b)))
+			}
+			fmt.Fprintf(&exercises, "   - Benchmarks: %s\n", strings.Join(benches, ", "))
+		}
+	}
+
+	return map[string]string{
+		"objectives": objectives.String(),
+		"examples":   examples.String(),
+		"exercises":  exercises.String(),
+	}, nil
+}
+
+// limits describes a benchmark's thresholds, such as "at most 1000 ns/op
+// and 0 allocs/op".
+func limits(b course.Benchmark) string {
+	var parts []string
+	if b.MaxNsPerOp > 0 {
+		parts = append(parts, fmt.Sprintf("%g ns/op", b.MaxNsPerOp))
+	}
+	if b.MaxAllocsPerOp >= 0 {
+		parts = append(parts, fmt.Sprintf("%d allocs/op", b.MaxAllocsPerOp))
+	}
+	return "at most " + strings.Join(parts, " and ")
+}
+
+// objective returns the first line of an exercise's EXERCISE: comment,
+// without the label.
+func objective(intro string) string {
+	line, _, _ := strings.Cut(intro, "\n")
+	for _, label := range []string{"EXERCISE:", "SOLUTION:"} {
+		line = strings.TrimPrefix(line, label)
+	}
+	return strings.TrimSpace(line)
+}
+
+// testNames returns the Test functions and fuzz targets in a test file,
+// in source order, or none if the file does not exist.
+func testNames(path string) (tests, fuzz []string, err error) {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil {
+			continue
+		}
+		switch name := fn.Name.Name; {
+		case strings.HasPrefix(name, "Test") && name != "TestMain":
+			tests = append(tests, name)
+		case strings.HasPrefix(name, "Fuzz"):
+			fuzz = append(fuzz, name)
+		}
+	}
+	return tests, fuzz, nil
+}
+
+// formatEstimate renders an estimate the way the manifest writes it:
+// "90m", "4h", "2h30m".
+func formatEstimate(d time.Duration) string {
+	s := strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+var markerRE = regexp.MustCompile(`<!-- /?learngo:(\S*) -->`)
+
+func marker(name string) (begin, end string) {
+	return "<!-- learngo:" + name + " -->", "<!-- /learngo:" + name + " -->"
+}
+
+// replace fills in the generated sections marked in readme.
+func replace(readme []byte, sections map[string]string) ([]byte, error) {
+	for _, m := range markerRE.FindAllSubmatch(readme, -1) {
+		if _, ok := sections[string(m[1])]; !ok {
+			return nil, fmt.Errorf("unknown generated section %q (want one of %s)", m[1], strings.Join(Sections, ", "))
+		}
+	}
+	out := readme
+	for _, name := range Sections {
+		begin, end := marker(name)
+		i := bytes.Index(out, []byte(begin))
+		if i < 0 {
+			continue
+		}
+		start := i + len(begin)
+		j := bytes.Index(out[start:], []byte(end))
+		if j < 0 {
+			return nil, fmt.Errorf("%s has no matching %s", begin, end)
+		}
+		var b bytes.Buffer
+		b.Write(out[:start])
+		b.WriteString("\n" + sections[name])
+		b.Write(out[start+j:])
+		out = b.Bytes()
+	}
+	return out, nil
+}
+
+// skeleton returns a README for a module that has none.
+func skeleton(m *course.Module, sections map[string]string) []byte {
+	headings := map[string]string{
+		"objectives": "## 🎯 Learning Objectives",
+		"examples":   "## 💡 Examples",
+		"exercises":  "## 🏋️ Exercises",
+	}
+	title := m.Title
+	if title == m.ID {
+		// No README to take a title from: "07-linked-lists" becomes
+		// "Linked Lists".
+		_, rest, _ := strings.Cut(m.ID, "-")
+		words := strings.Fields(strings.ReplaceAll(rest, "-", " "))
+		for i, w := range words {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+		title = strings.Join(words, " ")
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# Module %02d: %s\n", m.Number, title)
+	for _, name := range Sections {
+		begin, end := marker(name)
+		fmt.Fprintf(&b, "\n%s\n\n%s\n%s%s\n", headings[name], begin, sections[name], end)
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,137 @@
+package readme
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testModule(t *testing.T, files map[string]string) *course.Module {
+	t.Helper()
+	root := t.TempDir()
+	files["go.mod"] = "module example.com/course\n"
+	files["course.yaml"] = `modules:
+  - id: 07-stacks
+    description: Stacks on slices.
+    objectives: [Push, Pop]
+    estimated_time: 90m
+    exercises: [exercise2_queue, exercise1_stack]
+`
+	files["modules/07-stacks/module.json"] = `{"exercises": {"exercise1_stack": {"concepts": ["slices", "generics"], "difficulty": 2, "benchmarks": {"BenchmarkPush": {"max_ns_op": 50, "max_allocs_op": 0}}}}}`
+	files["modules/07-stacks/exercises/exercise1_stack.go"] = "// EXERCISE: Fix the stack.\n// It pops from the wrong end.\npackage exercises\n"
+	files["modules/07-stacks/exercises/exercise1_stack_test.go"] = "package exercises\n\nfunc TestMain(m *testing.M) {}\nfunc TestPush(t *testing.T) {}\nfunc helper() {}\nfunc TestPop(t *testing.T) {}\nfunc FuzzPush(f *testing.F) {}\n"
+	files["modules/07-stacks/exercises/exercise2_queue.go"] = "package exercises\n"
+	files["modules/07-stacks/examples/example1.go"] = "package examples\n\n// Stack is a stack.\ntype Stack struct{}\n\n// Push pushes.\nfunc (s *Stack) Push() {}\n\n// New returns a Stack.\nfunc New() *Stack { return nil }\n"
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	c, err := course.Load(root)
+	require.NoError(t, err)
+	m, err := c.Module("07")
+	require.NoError(t, err)
+	return m
+}
+
+const generatedExercises = `1. **exercise2_queue.go**
+2. **exercise1_stack.go** - Fix the stack.
+   - Concepts: slices, generics (medium)
+   - Tests: ` + "`TestPush`, `TestPop`" + `
+   - Fuzz targets: ` + "`FuzzPush`" + `
+   - Benchmarks: ` + "`BenchmarkPush`" + ` (at most 50 ns/op and 0 allocs/op)
+`
+
+func TestGenerate(t *testing.T) {
+	m := testModule(t, map[string]string{
+		"modules/07-stacks/README.md": `# Module 07: Stacks
+
+Handwritten intro.
+
+<!-- learngo:exercises -->
+1. **exercise9_gone.go** - Stale.
+<!-- /learngo:exercises -->
+
+Handwritten outro.
+`,
+	})
+	current, updated, err := Generate(m)
+	require.NoError(t, err)
+	assert.Contains(t, string(current), "exercise9_gone")
+	assert.Equal(t, `# Module 07: Stacks
+
+Handwritten intro.
+
+<!-- learngo:exercises -->
+`+generatedExercises+`<!-- /learngo:exercises -->
+
+Handwritten outro.
+`, string(updated))
+
+	require.NoError(t, os.WriteFile(filepath.Join(m.Dir, FileName), updated, 0o644))
+	current, updated, err = Generate(m)
+	require.NoError(t, err)
+	assert.Equal(t, current, updated, "up to date")
+}
+
+func TestGenerateSkeleton(t *testing.T) {
+	m := testModule(t, map[string]string{})
+	current, updated, err := Generate(m)
+	require.NoError(t, err)
+	assert.Nil(t, current)
+	assert.Equal(t, `# Module 07: Stacks
+
+## 🎯 Learning Objectives
+
+<!-- learngo:objectives -->
+Stacks on slices.
+
+By completing this module, you will:
+- Push
+- Pop
+
+Estimated time: 1h30m.
+<!-- /learngo:objectives -->
+
+## 💡 Examples
+
+<!-- learngo:examples -->
+- **examples/example1.go**: `+"`Stack`, `New`"+`
+<!-- /learngo:examples -->
+
+## 🏋️ Exercises
+
+<!-- learngo:exercises -->
+`+generatedExercises+`<!-- /learngo:exercises -->
+`, string(updated))
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown generated section \"exercise\"":           "<!-- learngo:exercise -->\n<!-- /learngo:exercise -->\n",
+		"<!-- learngo:examples --> has no matching <!-- /": "<!-- learngo:examples -->\n",
+	}
+	for want, readme := range tests {
+		m := testModule(t, map[string]string{"modules/07-stacks/README.md": readme})
+		_, _, err := Generate(m)
+		assert.ErrorContains(t, err, want)
+	}
+}
+
+// TestRepositoryReadmes checks that the generated sections of every
+// module's README match the code; run "learngo gen readme" to fix them.
+func TestRepositoryReadmes(t *testing.T) {
+	root, err := course.FindRoot(".")
+	require.NoError(t, err)
+	c, err := course.Load(root)
+	require.NoError(t, err)
+	for _, m := range c.Modules {
+		current, updated, err := Generate(m)
+		require.NoError(t, err)
+		assert.Equal(t, string(updated), string(current), "%s/%s is out of date: run \"learngo gen readme\"", m.ID, FileName)
+	}
+}
@@ -0,0 +1,282 @@
+package recommend
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/cheatsheet"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/grader"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+)
+
+// Mistake is a kind of mistake that can be told from the output of a
+// failed run: a compiler error, a panic, or a report from the race
+// detector.
+type Mistake struct {
+	ID       string   // stored in progress.Exercise.Mistakes
+	Name     string   // what went wrong, in a few words
+	Advice   string   // how to fix it
+	Concepts []string // concepts of the exercises that practice it
+
+	output *regexp.Regexp // matches the output it causes
+	reread *regexp.Regexp // matches example code worth rereading
+}
+
+// Mistakes are the mistakes Classify knows, roughly from compile time to
+// run time.
+var Mistakes = []*Mistake{
+	{
+		ID:       "pointer-receiver",
+		Name:     "a value used where only its pointer has the method",
+		Advice:   "A method with a pointer receiver belongs to *T, not T, so a T value does not satisfy the interface. Pass &v, or use a value receiver if the method does not change v.",
+		Concepts: []string{"methods", "pointers", "interfaces"},
+		output:   regexp.MustCompile(`has pointer receiver`),
+		reread:   regexp.MustCompile(`func \(\w+ \*\w+(\[[^]]*\])?\)`),
+	},
+	{
+		ID:       "type-mismatch",
+		Name:     "a value of the wrong type",
+		Advice:   "Go never converts between types for you, not even between int and int64. Convert explicitly, e.g. float64(n), and read the compiler's \"have\" and \"want\" carefully.",
+		Concepts: []string{"variables", "generics"},
+		output:   regexp.MustCompile(`cannot use .* as .* value|mismatched types|does not satisfy`),
+		reread:   regexp.MustCompile(`\b(float64|int|int64|uint|string|rune|byte)\(`),
+	},
+	{
+		ID:       "unused",
+		Name:     "an unused variable or import",
+		Advice:   "Go refuses to compile unused local variables and imports. Use the value, assign it to _ while you work, or remove it; goimports keeps imports in order.",
+		Concepts: []string{"variables"},
+		output:   regexp.MustCompile(`declared and not used|imported and not used`),
+		reread:   regexp.MustCompile(`\b_\s*(,\s*\w+\s*)?:?=`),
+	},
+	{
+		ID:       "missing-return",
+		Name:     "a function that can end without returning",
+		Advice:   "Every path through a function with results must end in a return (or a panic). Check the branches of if and switch statements, including the case where no loop iteration returns.",
+		Concepts: []string{"functions", "control flow"},
+		output:   regexp.MustCompile(`missing return`),
+		reread:   regexp.MustCompile(`\bdefault:|\belse\b`),
+	},
+	{
+		ID:       "nil-map",
+		Name:     "writing to a nil map",
+		Advice:   "A map declared with var m map[K]V, or a map field nobody set, is nil: reading it works, writing panics. Create it with make or a literal first.",
+		Concepts: []string{"maps"},
+		output:   regexp.MustCompile(`assignment to entry in nil map`),
+		reread:   regexp.MustCompile(`make\(map\[|map\[[^]]+\][\w.*]+\{`),
+	},
+	{
+		ID:       "nil-pointer",
+		Name:     "dereferencing a nil pointer",
+		Advice:   "A pointer, interface, or map value was nil when the code used it. Check where it should have been set, and handle the nil case, such as an empty tree or a missing map entry.",
+		Concepts: []string{"pointers", "trees"},
+		output:   regexp.MustCompile(`nil pointer dereference`),
+		reread:   regexp.MustCompile(`[!=]= nil`),
+	},
+	{
+		ID:       "out-of-range",
+		Name:     "an index or slice bound out of range",
+		Advice:   "Valid indexes run from 0 to len(s)-1, and s[i:j] needs 0 <= i <= j <= cap(s). Check loop bounds and the empty slice; a condition like i <= len(s) is one too many.",
+		Concepts: []string{"slices", "strings"},
+		output:   regexp.MustCompile(`index out of range|slice bounds out of range`),
+		reread:   regexp.MustCompile(`len\(\w+\)\s*-\s*1|\[\w*:\w*\]`),
+	},
+	{
+		ID:       "stack-overflow",
+		Name:     "recursion without an end",
+		Advice:   "A recursive function called itself until the stack ran out. Make sure there is a base case and that every call gets closer to it.",
+		Concepts: []string{"recursion"},
+		output:   regexp.MustCompile(`goroutine stack exceeds|stack overflow`),
+		reread:   regexp.MustCompile(`\w\(\w+ ?- ?1[,)]|\w\(\w+\.(left|right)\b`),
+	},
+	{
+		ID:       "data-race",
+		Name:     "a data race",
+		Advice:   "Two goroutines used the same variable at the same time and at least one wrote to it. Guard it with a sync.Mutex, use sync/atomic, or hand the value over on a channel.",
+		Concepts: []string{"data races", "mutexes", "atomics"},
+		output:   regexp.MustCompile(`WARNING: DATA RACE`),
+		reread:   regexp.MustCompile(`sync\.(RW)?Mutex|atomic\.`),
+	},
+	{
+		ID:       "deadlock",
+		Name:     "goroutines waiting on each other forever",
+		Advice:   "Every goroutine was blocked: a send nobody receives, a receive from a channel nobody closes, or a lock taken twice. Check who closes each channel and that WaitGroup.Add matches Done.",
+		Concepts: []string{"channels", "goroutines"},
+		output:   regexp.MustCompile(`all goroutines are asleep - deadlock`),
+		reread:   regexp.MustCompile(`make\(chan|close\(|sync\.WaitGroup`),
+	},
+	{
+		ID:       "closed-channel",
+		Name:     "using a closed channel",
+		Advice:   "Sending on a closed channel, or closing one twice, panics. Only the sender should close a channel, once, after its last send.",
+		Concepts: []string{"channels"},
+		output:   regexp.MustCompile(`send on closed channel|close of closed channel|close of nil channel`),
+		reread:   regexp.MustCompile(`close\(`),
+	},
+	{
+		ID:       "timeout",
+		Name:     "a test that never finished",
+		Advice:   "A test ran out of time: look for a loop whose condition never changes, a goroutine blocked on a channel, or an algorithm far slower than it needs to be.",
+		Concepts: []string{"control flow", "goroutines", "memoization"},
+		output:   regexp.MustCompile(`panic: test timed out`),
+		reread:   regexp.MustCompile(`\bfor\b.*\{|\bselect \{`),
+	},
+}
+
+// Classify returns the mistakes whose output shows up in output, in
+// Mistakes order.
+func Classify(output string) []*Mistake {
+	var out []*Mistake
+	for _, m := range Mistakes {
+		if m.output.MatchString(output) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// RecordMistakes classifies why the exercises of m failed in run and
+// counts each mistake in s. Compiler errors count for the exercise whose
+// file, or test file, they point at.
+func RecordMistakes(s *progress.Store, m *course.Module, run *grader.Run, at time.Time) {
+	for _, res := range run.Results {
+		if res.Passed {
+			continue
+		}
+		var out strings.Builder
+		for _, t := range res.Tests {
+			if !t.Passed && !t.Skipped {
+				out.WriteString(t.Output)
+			}
+		}
+		for _, e := range m.Exercises {
+			if e.ID == res.Exercise && run.BuildOutput != "" {
+				out.WriteString(buildLines(run.BuildOutput, e))
+			}
+		}
+		for _, k := range Classify(out.String()) {
+			s.RecordMistake(res.Exercise, k.ID, at)
+		}
+	}
+}
+
+// buildLines returns the lines of compiler output about e's files.
+func buildLines(output string, e *course.Exercise) string {
+	file := regexp.MustCompile(`(^|[\s/])` + regexp.QuoteMeta(e.Name) + `(_test)?\.go:`)
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(output, "\n") {
+		if file.MatchString(line) {
+			b.WriteString(line)
+		}
+	}
+	return b.String()
+}
+
+// Thresholds for Patterns.
+const (
+	// RecentMistakes is how far back Patterns looks.
+	RecentMistakes = 14 * 24 * time.Hour
+	// RepeatedMistake is how often a mistake must have come up to be a
+	// pattern.
+	RepeatedMistake = 2
+	// maxExamples caps the example files suggested per pattern.
+	maxExamples = 2
+)
+
+// Pattern is a mistake the learner keeps making.
+type Pattern struct {
+	Mistake *Mistake
+	Count   int       // failed runs it came up in
+	Last    time.Time // when it last came up
+	// Exercises are where it came up, most recent first.
+	Exercises []*course.Exercise
+	// Practice lists unfinished exercises on the mistake's concepts,
+	// easiest first.
+	Practice []*course.Exercise
+	// Examples are example files, relative to the course root, that show
+	// the code the mistake is about.
+	Examples []string
+}
+
+// Patterns finds the mistakes that came up at least RepeatedMistake times
+// in exercises attempted within RecentMistakes of now, most frequent
+// first, and what to practice or reread for each.
+func Patterns(c *course.Course, s *progress.Store, now time.Time) []Pattern {
+	type seen struct {
+		exercise *course.Exercise
+		last     time.Time
+	}
+	counts := make(map[string]int)
+	where := make(map[string][]seen)
+	for _, e := range c.Exercises() {
+		for id, m := range s.Get(e.ID).Mistakes {
+			if now.Sub(m.Last) > RecentMistakes {
+				continue
+			}
+			counts[id] += m.Count
+			where[id] = append(where[id], seen{e, m.Last})
+		}
+	}
+
+	var out []Pattern
+	for _, m := range Mistakes {
+		if counts[m.ID] < RepeatedMistake {
+			continue
+		}
+		p := Pattern{Mistake: m, Count: counts[m.ID]}
+		ws := where[m.ID]
+		sort.SliceStable(ws, func(i, j int) bool { return ws[i].last.After(ws[j].last) })
+		for _, w := range ws {
+			p.Exercises = append(p.Exercises, w.exercise)
+		}
+		p.Last = ws[0].last
+		p.Practice = practiceConcepts(c, s, m.Concepts, course.Unrated, p.Exercises...)
+		p.Examples = examples(c, m)
+		out = append(out, p)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Count > out[j].Count })
+	return out
+}
+
+// examples returns the example files with the most code matching m's
+// reread pattern.
+func examples(c *course.Course, m *Mistake) []string {
+	type file struct {
+		path    string
+		matches int
+	}
+	var files []file
+	for _, mod := range c.Modules {
+		paths, _ := filepath.Glob(filepath.Join(mod.Dir, cheatsheet.ExamplesDir, "*.go"))
+		for _, p := range paths {
+			if strings.HasSuffix(p, "_test.go") {
+				continue
+			}
+			src, err := os.ReadFile(p)
+			if err != nil {
+				continue
+			}
+			if n := len(m.reread.FindAllIndex(src, -1)); n > 0 {
+				files = append(files, file{p, n})
+			}
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].matches > files[j].matches })
+	var out []string
+	for _, f := range files {
+		if len(out) == maxExamples {
+			break
+		}
+		rel, err := filepath.Rel(c.Root, f.path)
+		if err != nil {
+			rel = f.path
+		}
+		out = append(out, filepath.ToSlash(rel))
+	}
+	return out
+}
@@ -0,0 +1,126 @@
+package recommend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/grader"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mistakeIDs(ms []*Mistake) []string {
+	var out []string
+	for _, m := range ms {
+		out = append(out, m.ID)
+	}
+	return out
+}
+
+func TestClassify(t *testing.T) {
+	for output, want := range map[string][]string{
+		"./shapes.go:31:20: cannot use c (variable of type Circle) as Shape value in variable declaration: Circle does not implement Shape (method Area has pointer receiver)": {"pointer-receiver", "type-mismatch"},
+		"panic: assignment to entry in nil map [recovered]":                                            {"nil-map"},
+		"panic: runtime error: index out of range [3] with length 3":                                   {"out-of-range"},
+		"runtime: goroutine stack exceeds 1000000000-byte limit\nfatal error: stack overflow":          {"stack-overflow"},
+		"==================\nWARNING: DATA RACE\nWrite at 0x00c000012345 by goroutine 8:":              {"data-race"},
+		"fatal error: all goroutines are asleep - deadlock!":                                           {"deadlock"},
+		"panic: test timed out after 10m0s":                                                            {"timeout"},
+		"    shapes_test.go:12: \n        Error: Not equal:\n        expected: 4\n        actual  : 5": nil,
+	} {
+		assert.Equal(t, want, mistakeIDs(Classify(output)), output)
+	}
+}
+
+func TestRecordMistakes(t *testing.T) {
+	m := &course.Module{ID: "02-types", Exercises: []*course.Exercise{
+		{ID: "02-types/shapes", Name: "shapes"},
+		{ID: "02-types/stack", Name: "stack"},
+		{ID: "02-types/maps", Name: "maps"},
+	}}
+	run := &grader.Run{
+		Module: "02-types",
+		Results: []*grader.Result{
+			{Exercise: "02-types/shapes"},
+			{Exercise: "02-types/stack"},
+			{Exercise: "02-types/maps", Tests: []grader.TestResult{
+				{Name: "TestCount", Output: "panic: assignment to entry in nil map"},
+				{Name: "TestSkipped", Skipped: true, Output: "index out of range"},
+			}},
+		},
+		BuildOutput: "# 02-types/exercises\n" +
+			"./shapes.go:9:2: declared and not used: x\n" +
+			"./stack_test.go:20:14: cannot use s (variable of type Stack) as Pusher value in argument to push: Stack does not implement Pusher (method Push has pointer receiver)\n",
+	}
+	var s progress.Store
+	RecordMistakes(&s, m, run, t0)
+
+	assert.Equal(t, map[string]progress.Mistake{"unused": {Count: 1, Last: t0}}, s.Get("02-types/shapes").Mistakes)
+	assert.Equal(t, map[string]progress.Mistake{
+		"pointer-receiver": {Count: 1, Last: t0},
+		"type-mismatch":    {Count: 1, Last: t0},
+	}, s.Get("02-types/stack").Mistakes, "test files count for their exercise")
+	assert.Equal(t, map[string]progress.Mistake{"nil-map": {Count: 1, Last: t0}}, s.Get("02-types/maps").Mistakes,
+		"skipped tests do not count")
+}
+
+func TestPatterns(t *testing.T) {
+	dir := t.TempDir()
+	examples := filepath.Join(dir, "01-basics", "examples")
+	require.NoError(t, os.MkdirAll(examples, 0o755))
+	for name, src := range map[string]string{
+		"example1_maps.go":      "package examples\n\nvar a = make(map[string]int)\nvar b = make(map[int]bool)\n",
+		"example2_more.go":      "package examples\n\nvar c = make(map[string]int)\n",
+		"example1_maps_test.go": "package examples\n\nvar d = make(map[string]int)\nvar e = make(map[string]int)\nvar f = make(map[string]int)\n",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(examples, name), []byte(src), 0o644))
+	}
+	c := testCourse()
+	c.Root = dir
+	c.Modules[0].Dir = filepath.Join(dir, "01-basics")
+	c.Modules[0].Exercises[1].Concepts = []string{"slices", "maps"}
+
+	var s progress.Store
+	now := t0.Add(time.Hour)
+	s.RecordMistake("01-basics/memo", "nil-map", t0)
+	s.RecordMistake("01-basics/hard_recursion", "nil-map", t0.Add(time.Minute))
+	s.RecordMistake("01-basics/hard_recursion", "stack-overflow", t0)
+	s.RecordMistake("03-algorithms/trees", "data-race", t0.Add(-RecentMistakes))
+	s.RecordMistake("03-algorithms/trees", "data-race", t0.Add(-RecentMistakes))
+
+	ps := Patterns(c, &s, now)
+	require.Len(t, ps, 1, "stack-overflow came up once, the data races too long ago")
+	p := ps[0]
+	assert.Equal(t, "nil-map", p.Mistake.ID)
+	assert.Equal(t, 2, p.Count)
+	assert.Equal(t, t0.Add(time.Minute), p.Last)
+	assert.Equal(t, []string{"01-basics/hard_recursion", "01-basics/memo"}, names(p.Exercises), "most recent first")
+	assert.Equal(t, []string{"01-basics/slices"}, names(p.Practice), "exercises on maps, other than where it came up")
+	assert.Equal(t, []string{"01-basics/examples/example1_maps.go", "01-basics/examples/example2_more.go"}, p.Examples,
+		"most matching code first, without tests")
+
+	s.RecordMistake("01-basics/hard_recursion", "stack-overflow", t0)
+	s.RecordMistake("01-basics/hard_recursion", "stack-overflow", t0)
+	ps = Patterns(c, &s, now)
+	require.Len(t, ps, 2)
+	assert.Equal(t, "stack-overflow", ps[0].Mistake.ID, "most frequent first")
+	assert.Empty(t, ps[0].Examples)
+}
+
+// The mistakes should point at code in the course's own examples. The
+// concurrency module has no example files yet.
+func TestMistakesHaveExamples(t *testing.T) {
+	c, err := course.Load(filepath.Join("..", ".."))
+	require.NoError(t, err)
+	for _, m := range Mistakes {
+		switch m.ID {
+		case "data-race", "deadlock", "closed-channel":
+			continue
+		}
+		assert.NotEmpty(t, examples(c, m), m.ID)
+	}
+}
@@ -0,0 +1,46 @@
+package recommend
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/quiz"
+)
+
+// Complete reports whether m is finished: every exercise is done, or, for a
+// module without exercises, its quiz is passed with PassingQuiz. A module
+// with neither has nothing to finish.
+func Complete(m *course.Module, s *progress.Store) bool {
+	if len(m.Exercises) == 0 {
+		return !HasQuiz(m) || s.Quiz(m.ID).Best.Percent() >= PassingQuiz
+	}
+	for _, e := range m.Exercises {
+		if s.Get(e.ID).CompletedAt.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// Unmet returns the prerequisites of m that are not complete. A module
+// with unmet prerequisites is locked.
+func Unmet(c *course.Course, s *progress.Store, m *course.Module) []*course.Module {
+	var unmet []*course.Module
+	for _, req := range c.Prerequisites(m) {
+		if !Complete(req, s) {
+			unmet = append(unmet, req)
+		}
+	}
+	return unmet
+}
+
+// HasQuiz reports whether m has a question bank.
+func HasQuiz(m *course.Module) bool {
+	if m.Dir == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(m.Dir, quiz.FileName))
+	return err == nil
+}
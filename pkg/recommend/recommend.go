@@ -0,0 +1,192 @@
+// Package recommend suggests what a learner should do next: go on to the
+// next exercise, review a module, or practice the concepts they are stuck
+// on first. It reads the same progress store as every learngo command and
+// the concept and difficulty tags from each module's course.MetaFile.
+package recommend
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+)
+
+// Action is the kind of advice.
+type Action string
+
+// Actions, from most to least urgent.
+const (
+	Practice Action = "practice" // stuck on an exercise: try easier ones on the same concepts
+	Review   Action = "review"   // weak quiz score or stuck without practice material: reread the module
+	Proceed  Action = "proceed"  // on track: work on the next exercise
+	TakeQuiz Action = "quiz"     // on track: finish a module that only has a quiz
+	Done     Action = "done"     // every module is complete
+)
+
+// Thresholds for the advice.
+const (
+	// StruggleFailedRuns is the number of failed runs on an unfinished
+	// exercise that counts as being stuck.
+	StruggleFailedRuns = 5
+	// StruggleHints is the number of hints on an unfinished exercise that
+	// counts as being stuck.
+	StruggleHints = 2
+	// PassingQuiz is the best quiz score, in percent, below which a module
+	// should be reviewed.
+	PassingQuiz = 70
+	// maxPractice caps the practice exercises suggested at once.
+	maxPractice = 3
+)
+
+// Advice is a recommendation.
+type Advice struct {
+	Action Action
+	Module *course.Module
+	// Exercise is the exercise to work on next for Proceed, and the one
+	// the learner is stuck on for Practice and Review.
+	Exercise *course.Exercise
+	// Practice lists unfinished exercises that share concepts with
+	// Exercise and are no harder, easiest first.
+	Practice []*course.Exercise
+	// Concepts are the concepts the advice is about.
+	Concepts []string
+	Reasons  []string
+}
+
+// Next works out the advice for the progress in s.
+func Next(c *course.Course, s *progress.Store) Advice {
+	if a, ok := stuck(c, s); ok {
+		return a
+	}
+	if a, ok := weakQuiz(c, s); ok {
+		return a
+	}
+	return proceed(c, s)
+}
+
+// stuck advises practice on the first unfinished exercise, in course
+// order, that the learner is struggling with.
+func stuck(c *course.Course, s *progress.Store) (Advice, bool) {
+	for _, m := range c.Modules {
+		for _, e := range m.Exercises {
+			p := s.Get(e.ID)
+			if !p.CompletedAt.IsZero() || (p.FailedRuns < StruggleFailedRuns && p.HintsUsed < StruggleHints) {
+				continue
+			}
+			a := Advice{Action: Practice, Module: m, Exercise: e, Concepts: e.Concepts}
+			if p.FailedRuns > 0 {
+				a.Reasons = append(a.Reasons, fmt.Sprintf("%s has failed %d runs so far", e.Name, p.FailedRuns))
+			}
+			if p.HintsUsed > 0 {
+				a.Reasons = append(a.Reasons, fmt.Sprintf("you have used %d hints on it", p.HintsUsed))
+			}
+			a.Practice = practice(c, s, e)
+			if len(a.Practice) == 0 {
+				a.Action = Review
+				a.Reasons = append(a.Reasons, "no easier exercise practices the same concepts, so reread the module's examples")
+			}
+			return a, true
+		}
+	}
+	return Advice{}, false
+}
+
+// practice returns unfinished exercises sharing a concept with e that are
+// no harder than it.
+func practice(c *course.Course, s *progress.Store, e *course.Exercise) []*course.Exercise {
+	return practiceConcepts(c, s, e.Concepts, e.Difficulty, e)
+}
+
+// practiceConcepts returns unfinished exercises, other than skip, on any
+// of the concepts and no harder than difficulty (any if Unrated), easiest
+// first.
+func practiceConcepts(c *course.Course, s *progress.Store, concepts []string, difficulty int, skip ...*course.Exercise) []*course.Exercise {
+	var out []*course.Exercise
+	for _, other := range c.Exercises() {
+		if slices.Contains(skip, other) || !s.Get(other.ID).CompletedAt.IsZero() {
+			continue
+		}
+		if difficulty != course.Unrated && other.Difficulty > difficulty {
+			continue
+		}
+		for _, concept := range concepts {
+			if other.HasConcept(concept) {
+				out = append(out, other)
+				break
+			}
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Difficulty < out[j].Difficulty })
+	if len(out) > maxPractice {
+		out = out[:maxPractice]
+	}
+	return out
+}
+
+// weakQuiz advises reviewing the first module whose best quiz score is
+// below PassingQuiz.
+func weakQuiz(c *course.Course, s *progress.Store) (Advice, bool) {
+	for _, m := range c.Modules {
+		q := s.Quiz(m.ID)
+		if q.Attempts == 0 || q.Best.Percent() >= PassingQuiz {
+			continue
+		}
+		return Advice{
+			Action: Review,
+			Module: m,
+			Reasons: []string{fmt.Sprintf("your best %s quiz score is %d%% (%d/%d); aim for %d%%",
+				m.ID, q.Best.Percent(), q.Best.Correct, q.Best.Total, PassingQuiz)},
+		}, true
+	}
+	return Advice{}, false
+}
+
+// proceed picks the next exercise: one already started, else the easiest
+// one not started in the first unfinished module whose prerequisites are
+// complete. A module with only a quiz is finished by passing the quiz.
+func proceed(c *course.Course, s *progress.Store) Advice {
+	order, err := c.Order()
+	if err != nil {
+		order = c.Modules
+	}
+	for _, m := range order {
+		if Complete(m, s) || len(Unmet(c, s, m)) > 0 {
+			continue
+		}
+		if len(m.Exercises) == 0 {
+			return Advice{Action: TakeQuiz, Module: m, Reasons: []string{
+				fmt.Sprintf("%s has no exercises; pass its quiz with %d%% to finish it", m.ID, PassingQuiz),
+			}}
+		}
+		var started, fresh []*course.Exercise
+		done := 0
+		for _, e := range m.Exercises {
+			p := s.Get(e.ID)
+			switch {
+			case !p.CompletedAt.IsZero():
+				done++
+			case p.Attempts > 0 || p.HintsUsed > 0:
+				started = append(started, e)
+			default:
+				fresh = append(fresh, e)
+			}
+		}
+		a := Advice{Action: Proceed, Module: m}
+		if len(started) > 0 {
+			a.Exercise = started[0]
+			a.Reasons = append(a.Reasons, fmt.Sprintf("you have started %s and are not stuck on it", a.Exercise.Name))
+		} else {
+			sort.SliceStable(fresh, func(i, j int) bool { return fresh[i].Difficulty < fresh[j].Difficulty })
+			a.Exercise = fresh[0]
+			a.Reasons = append(a.Reasons, fmt.Sprintf("it is the easiest exercise you have not started in %s", m.ID))
+		}
+		a.Concepts = a.Exercise.Concepts
+		a.Reasons = append(a.Reasons, fmt.Sprintf("%d/%d exercises done in %s", done, len(m.Exercises), m.ID))
+		return a
+	}
+	// Prerequisites form a DAG, so some unfinished module is always
+	// unlocked: getting here means everything is complete.
+	return Advice{Action: Done, Reasons: []string{"every module in the course is complete"}}
+}
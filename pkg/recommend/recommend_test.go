@@ -0,0 +1,176 @@
+package recommend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/course"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/quiz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var t0 = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func exercise(module, name string, difficulty int, concepts ...string) *course.Exercise {
+	return &course.Exercise{ID: module + "/" + name, Module: module, Name: name, Difficulty: difficulty, Concepts: concepts}
+}
+
+func testCourse() *course.Course {
+	return &course.Course{Modules: []*course.Module{
+		{ID: "01-basics", Exercises: []*course.Exercise{
+			exercise("01-basics", "hard_recursion", course.Hard, "recursion"),
+			exercise("01-basics", "slices", course.Easy, "slices"),
+			exercise("01-basics", "memo", course.Medium, "recursion", "maps"),
+		}},
+		{ID: "02-types"},
+		{ID: "03-algorithms", Exercises: []*course.Exercise{
+			exercise("03-algorithms", "trees", course.Easy, "recursion"),
+			exercise("03-algorithms", "graphs", course.Hard, "recursion", "graphs"),
+		}},
+	}}
+}
+
+func moduleIDs(ms []*course.Module) []string {
+	var out []string
+	for _, m := range ms {
+		out = append(out, m.ID)
+	}
+	return out
+}
+
+func names(es []*course.Exercise) []string {
+	var out []string
+	for _, e := range es {
+		out = append(out, e.ID)
+	}
+	return out
+}
+
+func fail(s *progress.Store, id string, n int) {
+	for i := 0; i < n; i++ {
+		s.RecordAttempt(id, false, t0.Add(time.Duration(i)*time.Minute))
+	}
+}
+
+func TestProceedPicksEasiestFreshExercise(t *testing.T) {
+	c := testCourse()
+	var s progress.Store
+	a := Next(c, &s)
+	assert.Equal(t, Proceed, a.Action)
+	assert.Equal(t, "01-basics/slices", a.Exercise.ID)
+	assert.Equal(t, []string{"slices"}, a.Concepts)
+	assert.Contains(t, a.Reasons, "0/3 exercises done in 01-basics")
+}
+
+func TestProceedPrefersStartedExercise(t *testing.T) {
+	c := testCourse()
+	var s progress.Store
+	fail(&s, "01-basics/memo", 2)
+	a := Next(c, &s)
+	assert.Equal(t, Proceed, a.Action)
+	assert.Equal(t, "01-basics/memo", a.Exercise.ID)
+}
+
+func TestPracticeWhenStuck(t *testing.T) {
+	c := testCourse()
+	var s progress.Store
+	fail(&s, "01-basics/hard_recursion", StruggleFailedRuns)
+
+	a := Next(c, &s)
+	assert.Equal(t, Practice, a.Action)
+	assert.Equal(t, "01-basics/hard_recursion", a.Exercise.ID)
+	assert.Equal(t, []string{"recursion"}, a.Concepts)
+	assert.Equal(t, []string{"03-algorithms/trees", "01-basics/memo", "03-algorithms/graphs"}, names(a.Practice),
+		"easiest first, across modules")
+	assert.Contains(t, a.Reasons, "hard_recursion has failed 5 runs so far")
+
+	s.RecordAttempt("03-algorithms/trees", true, t0)
+	a = Next(c, &s)
+	assert.Equal(t, []string{"01-basics/memo", "03-algorithms/graphs"}, names(a.Practice), "finished exercises are skipped")
+}
+
+func TestPracticeSkipsHarderExercises(t *testing.T) {
+	c := testCourse()
+	var s progress.Store
+	s.RecordHint("01-basics/memo")
+	s.RecordHint("01-basics/memo")
+
+	a := Next(c, &s)
+	assert.Equal(t, Practice, a.Action)
+	assert.Equal(t, "01-basics/memo", a.Exercise.ID)
+	assert.Equal(t, []string{"03-algorithms/trees"}, names(a.Practice))
+	assert.Contains(t, a.Reasons, "you have used 2 hints on it")
+}
+
+func TestReviewWhenNothingToPractice(t *testing.T) {
+	c := testCourse()
+	var s progress.Store
+	fail(&s, "01-basics/slices", StruggleFailedRuns+1)
+
+	a := Next(c, &s)
+	assert.Equal(t, Review, a.Action)
+	assert.Equal(t, "01-basics", a.Module.ID)
+	assert.Empty(t, a.Practice)
+}
+
+func TestReviewWeakQuiz(t *testing.T) {
+	c := testCourse()
+	var s progress.Store
+	s.RecordQuiz("02-types", progress.Score{Correct: 2, Total: 5, At: t0})
+	a := Next(c, &s)
+	assert.Equal(t, Review, a.Action)
+	assert.Equal(t, "02-types", a.Module.ID)
+	assert.Contains(t, a.Reasons[0], "40% (2/5)")
+
+	s.RecordQuiz("02-types", progress.Score{Correct: 4, Total: 5, At: t0})
+	assert.Equal(t, Proceed, Next(c, &s).Action, "the best score counts")
+}
+
+func TestDone(t *testing.T) {
+	c := testCourse()
+	var s progress.Store
+	for _, e := range c.Exercises() {
+		s.RecordAttempt(e.ID, true, t0)
+	}
+	a := Next(c, &s)
+	assert.Equal(t, Done, a.Action)
+	require.Len(t, a.Reasons, 1)
+}
+
+func TestProceedRespectsPrerequisites(t *testing.T) {
+	c := testCourse()
+	c.Modules[0].Requires = []string{"03-algorithms"}
+	var s progress.Store
+	a := Next(c, &s)
+	assert.Equal(t, Proceed, a.Action)
+	assert.Equal(t, "03-algorithms/trees", a.Exercise.ID, "01-basics is locked until 03-algorithms is complete")
+	assert.Equal(t, []string{"03-algorithms"}, moduleIDs(Unmet(c, &s, c.Modules[0])))
+
+	s.RecordAttempt("03-algorithms/trees", true, t0)
+	s.RecordAttempt("03-algorithms/graphs", true, t0)
+	assert.Empty(t, Unmet(c, &s, c.Modules[0]))
+	assert.Equal(t, "01-basics/slices", Next(c, &s).Exercise.ID)
+}
+
+func TestTakeQuiz(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, quiz.FileName), []byte(`{}`), 0o644))
+	c := testCourse()
+	c.Modules[1].Dir = dir
+	var s progress.Store
+	for _, e := range c.Modules[0].Exercises {
+		s.RecordAttempt(e.ID, true, t0)
+	}
+	a := Next(c, &s)
+	assert.Equal(t, TakeQuiz, a.Action)
+	assert.Equal(t, "02-types", a.Module.ID)
+	assert.False(t, Complete(c.Modules[1], &s))
+
+	s.RecordQuiz("02-types", progress.Score{Correct: 5, Total: 5, At: t0})
+	assert.True(t, Complete(c.Modules[1], &s))
+	assert.Equal(t, "03-algorithms", Next(c, &s).Module.ID)
+}
@@ -0,0 +1,677 @@
+// Package repl evaluates Go snippets one after another, for trying things
+// out without writing a scratch file.
+//
+// Every input becomes part of a program that the go command compiles and
+// runs:
+//
+//   - import declarations and top-level declarations (func, type, const,
+//     var) are kept for later inputs. Declaring a name again replaces it.
+//   - an expression is printed with %#v, or just run if it has no value or
+//     prints itself, like a call of fmt.Println.
+//   - anything else runs as statements in main. If they compile and run
+//     successfully, they are kept, so their variables carry over.
+//
+// Kept statements run again before each new input, with their output
+// hidden; that is how their variables get their values. Anything else they
+// do, such as writing a file, happens again too.
+//
+// Standard library packages are imported automatically when an input
+// uses them; see Packages.
+package repl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Packages maps package names to the standard library packages imported
+// automatically when an input uses them without importing them.
+var Packages = map[string]string{
+	"atomic":    "sync/atomic",
+	"base64":    "encoding/base64",
+	"big":       "math/big",
+	"bits":      "math/bits",
+	"bufio":     "bufio",
+	"bytes":     "bytes",
+	"cmp":       "cmp",
+	"context":   "context",
+	"errors":    "errors",
+	"filepath":  "path/filepath",
+	"fmt":       "fmt",
+	"heap":      "container/heap",
+	"hex":       "encoding/hex",
+	"io":        "io",
+	"json":      "encoding/json",
+	"list":      "container/list",
+	"maps":      "maps",
+	"math":      "math",
+	"os":        "os",
+	"rand":      "math/rand",
+	"reflect":   "reflect",
+	"regexp":    "regexp",
+	"runtime":   "runtime",
+	"sha256":    "crypto/sha256",
+	"slices":    "slices",
+	"sort":      "sort",
+	"strconv":   "strconv",
+	"strings":   "strings",
+	"sync":      "sync",
+	"tabwriter": "text/tabwriter",
+	"time":      "time",
+	"unicode":   "unicode",
+	"unsafe":    "unsafe",
+	"utf8":      "unicode/utf8",
+}
+
+// ErrTimeout is returned by Eval when the program runs longer than the
+// session's Timeout.
+var ErrTimeout = errors.New("timed out")
+
+// CompileError lists the compiler's errors about an input. Positions are
+// line:column in the input; errors elsewhere in the program have none.
+type CompileError struct {
+	Errors []string
+}
+
+func (e *CompileError) Error() string {
+	return strings.Join(e.Errors, "\n")
+}
+
+// importSpec is an import of the session, e.g. r "math/rand".
+type importSpec struct {
+	Name, Path string // Name is empty unless given
+}
+
+// decl is a kept top-level declaration.
+type decl struct {
+	names []string // what it declares; methods are Type.Method
+	src   string
+}
+
+// Session is a sequence of inputs, each seeing what the ones before it
+// declared. The zero value is not usable; call New.
+type Session struct {
+	// Timeout limits how long each input's program may run; zero means no
+	// limit. Compiling does not count.
+	Timeout time.Duration
+
+	dir     string // for building programs
+	imports []importSpec
+	decls   []decl
+	stmts   []string
+}
+
+// New starts an empty session. Close removes its temporary files.
+func New() (*Session, error) {
+	dir, err := os.MkdirTemp("", "learngo-repl-")
+	if err != nil {
+		return nil, err
+	}
+	return &Session{dir: dir}, nil
+}
+
+// Close removes the session's temporary files.
+func (s *Session) Close() error {
+	return os.RemoveAll(s.dir)
+}
+
+// Reset forgets all kept imports, declarations, and statements.
+func (s *Session) Reset() {
+	s.imports, s.decls, s.stmts = nil, nil, nil
+}
+
+// Source returns the program the kept inputs make up.
+func (s *Session) Source() string {
+	src, _, _ := s.program(s.imports, s.decls, s.stmts, "", "")
+	return strings.Replace(src, markerStmts, "", 1)
+}
+
+// Eval compiles and runs one input, writing what the program prints to
+// stdout and stderr, which may be the same writer. It returns a
+// *CompileError if the input does not compile, and an error if the
+// program fails, e.g. because it panics; in either case the input is not
+// kept.
+func (s *Session) Eval(ctx context.Context, input string, stdout, stderr io.Writer) error {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil
+	}
+
+	fset := token.NewFileSet()
+	if f, err := parser.ParseFile(fset, "", "package p\n"+input, 0); err == nil && len(f.Decls) > 0 {
+		return s.declare(ctx, f, input)
+	}
+
+	// An expression, or a list of them like "v, ok", becomes the arguments
+	// of a call that prints them.
+	if call, err := parser.ParseExpr(printPrefix + input + ",\n)"); err == nil && !printsItself(call.(*ast.CallExpr).Args) {
+		err := s.run(ctx, s.imports, s.decls, s.stmts, printPrefix+input+",\n)", stdout, stderr)
+		args := call.(*ast.CallExpr).Args
+		_, isCall := args[0].(*ast.CallExpr)
+		isCall = isCall && len(args) == 1
+		var cerr *CompileError
+		if isCall && errors.As(err, &cerr) && strings.Contains(cerr.Error(), "used as value") {
+			// A call without results: run it as a statement.
+		} else {
+			if err == nil && isCall {
+				// The call may change variables: keep it, results ignored.
+				s.stmts = append(s.stmts, "__repl_discard("+input+")")
+			}
+			return err
+		}
+	}
+
+	if _, err := parser.ParseFile(fset, "", "package p\nfunc _() {\n"+input+"\n}", 0); err != nil {
+		return syntaxError(err, 2, input)
+	}
+	if err := s.run(ctx, s.imports, s.decls, s.stmts, input, stdout, stderr); err != nil {
+		return err
+	}
+	s.stmts = append(s.stmts, input)
+	return nil
+}
+
+// declare keeps the imports or top-level declarations of an input if they
+// compile.
+func (s *Session) declare(ctx context.Context, f *ast.File, input string) error {
+	imports := s.imports
+	var names, paths []string
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch spec := spec.(type) {
+				case *ast.ImportSpec:
+					p, _ := strconv.Unquote(spec.Path.Value)
+					imp := importSpec{Path: p}
+					if spec.Name != nil {
+						imp.Name = spec.Name.Name
+					}
+					imports = addImport(imports, imp)
+					paths = append(paths, p)
+				case *ast.TypeSpec:
+					names = append(names, spec.Name.Name)
+				case *ast.ValueSpec:
+					for _, n := range spec.Names {
+						names = append(names, n.Name)
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			names = append(names, funcName(d))
+		}
+	}
+
+	decls := s.decls
+	current := ""
+	if len(names) > 0 {
+		current = input
+		decls = nil
+		for _, d := range s.decls {
+			if !overlaps(d.names, names) {
+				decls = append(decls, d)
+			}
+		}
+	}
+	// Imports are checked even though nothing uses them yet.
+	src, line, err := s.program(imports, decls, s.stmts, "", current, paths...)
+	if err != nil {
+		return err
+	}
+	if _, err := s.build(ctx, src, line, input); err != nil {
+		return err
+	}
+	s.imports = imports
+	if current != "" {
+		s.decls = append(decls, decl{names: names, src: input})
+	}
+	return nil
+}
+
+// run builds and runs the program of the kept inputs plus the statements
+// in current.
+func (s *Session) run(ctx context.Context, imports []importSpec, decls []decl, stmts []string, current string, stdout, stderr io.Writer) error {
+	src, line, err := s.program(imports, decls, stmts, current, "")
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(current, printPrefix) {
+		line++
+	}
+	bin, err := s.build(ctx, src, line, current)
+	if err != nil {
+		return err
+	}
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	cmd := exec.CommandContext(ctx, bin)
+	cmd.Dir = s.dir
+	// exec.Cmd serializes writes when Stdout and Stderr are the same
+	// writer, but two afterMarkers are two writers, so they share a lock.
+	var mu sync.Mutex
+	cmd.Stdout = &afterMarker{w: stdout, mu: &mu}
+	cmd.Stderr = &afterMarker{w: stderr, mu: &mu}
+	err = cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrTimeout
+	}
+	return err
+}
+
+// marker separates the output of the kept statements, which is hidden,
+// from the output of the current input.
+const marker = "\x00learngo-repl\x00"
+
+// markerStmts write marker to standard output and standard error.
+var markerStmts = fmt.Sprintf("__repl_os.Stdout.WriteString(%q)\n__repl_os.Stderr.WriteString(%q)\n", marker, marker)
+
+// afterMarker writes to w what is written to it after marker, holding mu.
+type afterMarker struct {
+	w     io.Writer
+	mu    *sync.Mutex
+	found bool
+	buf   []byte
+}
+
+func (m *afterMarker) Write(p []byte) (int, error) {
+	if m.found {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.w.Write(p)
+	}
+	m.buf = append(m.buf, p...)
+	i := bytes.Index(m.buf, []byte(marker))
+	if i < 0 {
+		if keep := len(marker) - 1; len(m.buf) > keep {
+			m.buf = append(m.buf[:0], m.buf[len(m.buf)-keep:]...)
+		}
+		return len(p), nil
+	}
+	m.found = true
+	rest := m.buf[i+len(marker):]
+	m.buf = nil
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, err := m.w.Write(rest); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// program writes out the Go program for the kept inputs plus either the
+// statements or the declaration in current. It returns the line current
+// starts on. Packages in check are imported even if unused.
+func (s *Session) program(imports []importSpec, decls []decl, stmts []string, currentStmts, currentDecl string, check ...string) (src string, line int, err error) {
+	var body strings.Builder
+	for _, d := range decls {
+		body.WriteString(d.src + "\n\n")
+	}
+	declLine := strings.Count(body.String(), "\n")
+	if currentDecl != "" {
+		body.WriteString(currentDecl + "\n\n")
+	}
+	body.WriteString("func main() {\n")
+	for _, st := range stmts {
+		body.WriteString(st + "\n")
+	}
+	body.WriteString(markerStmts)
+	stmtLine := strings.Count(body.String(), "\n")
+	if currentStmts != "" {
+		body.WriteString(currentStmts + "\n")
+	}
+	body.WriteString("}\n")
+
+	// Keep every variable main declares used, and import the packages the
+	// program uses.
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", "package main\n"+body.String(), 0)
+	if err != nil {
+		return "", 0, err
+	}
+	var unused []string
+	for _, d := range f.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok && fn.Name.Name == "main" && fn.Recv == nil {
+			unused = declared(fn.Body)
+		}
+	}
+	used := make(map[string]bool)
+	for _, id := range f.Unresolved {
+		used[id.Name] = true
+	}
+	var header strings.Builder
+	header.WriteString("package main\n\nimport (\n\t__repl_fmt \"fmt\"\n\t__repl_os \"os\"\n")
+	imported := make(map[string]bool)
+	for _, imp := range imports {
+		name := packageName(imp)
+		imported[name] = true
+		switch {
+		case imp.Name == "_" || imp.Name == ".":
+			fmt.Fprintf(&header, "\t%s %q\n", imp.Name, imp.Path)
+		case used[name] && imp.Name != "":
+			fmt.Fprintf(&header, "\t%s %q\n", imp.Name, imp.Path)
+		case used[name]:
+			fmt.Fprintf(&header, "\t%q\n", imp.Path)
+		}
+	}
+	var auto []string
+	for name := range used {
+		if p, ok := Packages[name]; ok && !imported[name] {
+			auto = append(auto, p)
+		}
+	}
+	sort.Strings(auto)
+	for _, p := range auto {
+		fmt.Fprintf(&header, "\t%q\n", p)
+	}
+	for _, p := range check {
+		fmt.Fprintf(&header, "\t_ %q\n", p)
+	}
+	header.WriteString(")\n\n")
+	offset := strings.Count(header.String(), "\n")
+
+	src = header.String() + body.String()
+	src = strings.TrimSuffix(src, "}\n")
+	for _, name := range unused {
+		src += "_ = " + name + "\n"
+	}
+	src += "}\n\n" + helpers
+	if currentDecl != "" {
+		return src, offset + declLine + 1, nil
+	}
+	return src, offset + stmtLine + 1, nil
+}
+
+// printPrefix starts the statement that prints an expression input, which
+// goes on the next line to keep the compiler's positions in it.
+const printPrefix = "__repl_print(\n"
+
+// helpers print and discard expression values for Eval.
+const helpers = `func __repl_print(v ...any) {
+	for i, x := range v {
+		if i > 0 {
+			__repl_fmt.Print(", ")
+		}
+		__repl_fmt.Printf("%#v", x)
+	}
+	__repl_fmt.Println()
+}
+
+func __repl_discard(...any) {}
+`
+
+// compilerError matches an error of the compiler, e.g.
+// "./main.go:12:5: undefined: x".
+var compilerError = regexp.MustCompile(`^\./main\.go:(\d+):(\d+): (.*)$`)
+
+// build compiles a program and returns the path of the binary. Errors on
+// the lines of input, which starts on line, are reported relative to it.
+func (s *Session) build(ctx context.Context, src string, line int, input string) (string, error) {
+	if err := os.WriteFile(filepath.Join(s.dir, "main.go"), []byte(src), 0o644); err != nil {
+		return "", err
+	}
+	bin := filepath.Join(s.dir, "main")
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", bin, "main.go")
+	cmd.Dir = s.dir
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		if err != nil {
+			return "", fmt.Errorf("running go build: %w", err)
+		}
+		return bin, nil
+	}
+
+	lines := strings.Count(input, "\n") + 1
+	cerr := &CompileError{}
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		text := sc.Text()
+		if strings.HasPrefix(text, "# ") {
+			continue
+		}
+		m := compilerError.FindStringSubmatch(text)
+		if m == nil {
+			cerr.Errors = append(cerr.Errors, text)
+			continue
+		}
+		n, _ := strconv.Atoi(m[1])
+		if n >= line && n < line+lines {
+			cerr.Errors = append(cerr.Errors, fmt.Sprintf("%d:%s: %s", n-line+1, m[2], m[3]))
+		} else {
+			cerr.Errors = append(cerr.Errors, m[3])
+		}
+	}
+	return "", cerr
+}
+
+// syntaxError turns the parse error of an input that was parsed after
+// the given number of other lines into a CompileError, with positions in
+// the input. Errors found after the input, where it ended too early, are
+// put at its end.
+func syntaxError(err error, before int, input string) error {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) {
+		return err
+	}
+	lines := strings.Split(input, "\n")
+	cerr := &CompileError{}
+	for _, e := range list {
+		line, col := e.Pos.Line-before, e.Pos.Column
+		switch {
+		case line < 1:
+			cerr.Errors = append(cerr.Errors, e.Msg)
+			continue
+		case line > len(lines):
+			line, col = len(lines), len(lines[len(lines)-1])+1
+		}
+		cerr.Errors = append(cerr.Errors, fmt.Sprintf("%d:%d: %s", line, col, e.Msg))
+	}
+	return cerr
+}
+
+// printsItself reports whether exprs is a call of one of the fmt.Print
+// functions, whose results are of no interest.
+func printsItself(exprs []ast.Expr) bool {
+	if len(exprs) != 1 {
+		return false
+	}
+	call, ok := exprs[0].(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "fmt" && strings.HasPrefix(sel.Sel.Name, "Print")
+}
+
+// declared returns the variables declared directly in a function body.
+func declared(body *ast.BlockStmt) []string {
+	var names []string
+	add := func(id *ast.Ident) {
+		if id.Name != "_" && !strings.HasPrefix(id.Name, "__repl_") {
+			names = append(names, id.Name)
+		}
+	}
+	for _, st := range body.List {
+		switch st := st.(type) {
+		case *ast.AssignStmt:
+			if st.Tok != token.DEFINE {
+				continue
+			}
+			for _, lhs := range st.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok {
+					add(id)
+				}
+			}
+		case *ast.DeclStmt:
+			gen, ok := st.Decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				for _, id := range spec.(*ast.ValueSpec).Names {
+					add(id)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// funcName names a function declaration for replacing it later: methods
+// are Type.Method.
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	t := fn.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	switch x := t.(type) {
+	case *ast.IndexExpr:
+		t = x.X
+	case *ast.IndexListExpr:
+		t = x.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
+
+// addImport adds an import, replacing one of the same package.
+func addImport(imports []importSpec, imp importSpec) []importSpec {
+	out := make([]importSpec, 0, len(imports)+1)
+	for _, old := range imports {
+		if old.Path != imp.Path {
+			out = append(out, old)
+		}
+	}
+	return append(out, imp)
+}
+
+// packageName is the name an import makes available: its own name, or
+// the last element of its path without a major version suffix.
+func packageName(imp importSpec) string {
+	if imp.Name != "" {
+		return imp.Name
+	}
+	dir, name := path.Split(imp.Path)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" && dir != "" {
+		name = path.Base(dir)
+	}
+	return name
+}
+
+func overlaps(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Complete reports whether src is a complete input rather than the start
+// of a longer one: it has no unclosed brackets, strings, or comments.
+func Complete(src string) bool {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(src))
+	unterminated := false
+	var sc scanner.Scanner
+	sc.Init(file, []byte(src), func(_ token.Position, msg string) {
+		if strings.HasSuffix(msg, "not terminated") {
+			unterminated = true
+		}
+	}, scanner.ScanComments)
+	depth := 0
+	for {
+		_, tok, _ := sc.Scan()
+		switch tok {
+		case token.LPAREN, token.LBRACK, token.LBRACE:
+			depth++
+		case token.RPAREN, token.RBRACK, token.RBRACE:
+			depth--
+		case token.EOF:
+			return depth <= 0 && !unterminated
+		}
+	}
+}
+
+// Run reads inputs from r, evaluates them in s, and writes the results to
+// w, until the input ends or it reads :quit. Inputs with unclosed brackets
+// continue on the next line. Errors go to ew, and lines starting with a
+// colon are commands; :help lists them.
+func Run(ctx context.Context, s *Session, r io.Reader, w, ew io.Writer) error {
+	in := bufio.NewScanner(r)
+	var input strings.Builder
+	for {
+		if input.Len() == 0 {
+			fmt.Fprint(w, ">>> ")
+		} else {
+			fmt.Fprint(w, "... ")
+		}
+		if !in.Scan() {
+			fmt.Fprintln(w)
+			return in.Err()
+		}
+		line := in.Text()
+		if cmd := strings.TrimSpace(line); input.Len() == 0 && strings.HasPrefix(cmd, ":") {
+			switch cmd {
+			case ":quit", ":q":
+				return nil
+			case ":reset":
+				s.Reset()
+			case ":source":
+				fmt.Fprint(w, s.Source())
+			case ":help":
+				fmt.Fprint(w, help)
+			default:
+				fmt.Fprintf(ew, "unknown command %s; :help lists them\n", cmd)
+			}
+			continue
+		}
+		input.WriteString(line + "\n")
+		if !Complete(input.String()) {
+			continue
+		}
+		err := s.Eval(ctx, input.String(), w, ew)
+		input.Reset()
+		if err != nil {
+			fmt.Fprintln(ew, err)
+		}
+	}
+}
+
+const help = `Enter Go declarations, statements, or expressions; expressions are printed.
+  :source  show the program your inputs make up
+  :reset   forget everything entered so far
+  :quit    leave (or end the input with Ctrl-D)
+`
@@ -0,0 +1,136 @@
+package repl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComplete(t *testing.T) {
+	assert.True(t, Complete("x := 1\n"))
+	assert.True(t, Complete("func f() {}\n"))
+	assert.False(t, Complete("func f() {\n"))
+	assert.False(t, Complete("s := []int{\n\t1,\n"))
+	assert.False(t, Complete("s := `raw\n"))
+	assert.False(t, Complete("/* comment\n"))
+	assert.True(t, Complete(`s := "{"`+"\n"), "brackets in strings do not count")
+}
+
+func newSession(t *testing.T) *Session {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("runs go build")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	s, err := New()
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestEval(t *testing.T) {
+	s := newSession(t)
+	ctx := context.Background()
+	eval := func(input string) (string, error) {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		err := s.Eval(ctx, input, &stdout, &stderr)
+		return stdout.String() + stderr.String(), err
+	}
+	mustEval := func(input, want string) {
+		t.Helper()
+		out, err := eval(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, out, input)
+	}
+
+	mustEval("x := []int{1, 2, 3}", "")
+	mustEval(`fmt.Println("appending")`, "appending\n")
+	mustEval("x = append(x, 4)", "")
+	mustEval("x", "[]int{1, 2, 3, 4}\n")
+	mustEval("len(x[1:])", "3\n")
+
+	mustEval("func double(n int) int { return n * 2 }", "")
+	mustEval("double(21)", "42\n")
+	mustEval("func double(n int) int { return n + n + 1 }", "")
+	mustEval("double(21)", "43\n")
+
+	mustEval(`var v any = "go"`, "")
+	mustEval("s, ok := v.(string)", "")
+	mustEval("s, ok", "\"go\", true\n")
+	mustEval("strings.ToUpper(s)", "\"GO\"\n")
+	mustEval("import r \"sort\"", "")
+	mustEval("r.IntsAreSorted(x)", "true\n")
+
+	_, err := eval("x + missing")
+	var cerr *CompileError
+	require.ErrorAs(t, err, &cerr)
+	assert.Equal(t, []string{"1:5: undefined: missing"}, cerr.Errors)
+
+	_, err = eval("y := 1 +")
+	require.ErrorAs(t, err, &cerr)
+	assert.Contains(t, cerr.Errors[0], "1:9: ")
+
+	_, err = eval(`import "no/such/package"`)
+	require.ErrorAs(t, err, &cerr)
+
+	out, err := eval(`panic("boom")`)
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Contains(t, out, "panic: boom")
+	mustEval("x", "[]int{1, 2, 3, 4}\n")
+
+	src := s.Source()
+	assert.Contains(t, src, "x = append(x, 4)")
+	assert.Contains(t, src, "n + n + 1")
+	assert.NotContains(t, src, "return n * 2", "replaced")
+	assert.NotContains(t, src, "boom", "failed inputs are not kept")
+	assert.NotContains(t, src, marker)
+
+	s.Reset()
+	_, err = eval("x")
+	require.ErrorAs(t, err, &cerr)
+}
+
+func TestEvalSameWriter(t *testing.T) {
+	s := newSession(t)
+	var out bytes.Buffer
+	input := `for i := 0; i < 100; i++ { fmt.Println("out"); fmt.Fprintln(os.Stderr, "err") }`
+	require.NoError(t, s.Eval(context.Background(), input, &out, &out))
+	assert.Equal(t, 100, strings.Count(out.String(), "out\n"))
+	assert.Equal(t, 100, strings.Count(out.String(), "err\n"))
+}
+
+func TestEvalTimeout(t *testing.T) {
+	s := newSession(t)
+	s.Timeout = 200 * time.Millisecond
+	err := s.Eval(context.Background(), "for {}", &bytes.Buffer{}, &bytes.Buffer{})
+	assert.True(t, errors.Is(err, ErrTimeout), "%v", err)
+}
+
+func TestRun(t *testing.T) {
+	s := newSession(t)
+	in := strings.Join([]string{
+		"type point struct {",
+		"\tx, y int",
+		"}",
+		"point{1, 2}",
+		":help",
+		":frobnicate",
+		":quit",
+		"point{3, 4}",
+	}, "\n")
+	var out, errOut bytes.Buffer
+	require.NoError(t, Run(context.Background(), s, strings.NewReader(in), &out, &errOut))
+	assert.Equal(t, ">>> ... ... >>> main.point{x:1, y:2}\n>>> "+help+">>> >>> ", out.String())
+	assert.Equal(t, "unknown command :frobnicate; :help lists them\n", errOut.String())
+}
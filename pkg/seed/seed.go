@@ -0,0 +1,60 @@
+// Package seed gives exercise tests per-learner randomness. learngo keeps
+// a random seed for each learner in the progress store and passes it to
+// the tests in LEARNGO_SEED, so every learner gets their own shape sizes,
+// thresholds, and random inputs: a copied solution that only works for one
+// set of numbers fails for everyone else, while a correct one passes for
+// all of them.
+//
+// Without LEARNGO_SEED, as under a plain "go test", tests get the fixed
+// streams they always had.
+package seed
+
+import (
+	"math/rand"
+	"os"
+	"strconv"
+	"testing"
+)
+
+// EnvVar is the environment variable holding the learner's seed.
+const EnvVar = "LEARNGO_SEED"
+
+// Value returns the learner's seed, and false if EnvVar is unset or not a
+// number.
+func Value() (int64, bool) {
+	n, err := strconv.ParseInt(os.Getenv(EnvVar), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// Rand returns a random source for one test. base picks the stream, so
+// tests in a package do not share numbers: with no learner seed it is the
+// seed itself, and otherwise it is mixed with the learner's. The learner's
+// seed is logged so a failure can be reproduced with
+// LEARNGO_SEED=<n> go test.
+func Rand(tb testing.TB, base int64) *rand.Rand {
+	tb.Helper()
+	learner, ok := Value()
+	if !ok {
+		return rand.New(rand.NewSource(base))
+	}
+	tb.Logf("%s=%d", EnvVar, learner)
+	return rand.New(rand.NewSource(mix(learner, base)))
+}
+
+// mix combines two seeds with the splitmix64 finalizer, so nearby inputs
+// give unrelated outputs.
+func mix(a, b int64) int64 {
+	z := uint64(a) ^ (uint64(b) * 0x9e3779b97f4a7c15)
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
+
+// Between returns a number in [lo, hi] from r, for varying constants such
+// as a rectangle's width.
+func Between(r *rand.Rand, lo, hi int) int {
+	return lo + r.Intn(hi-lo+1)
+}
@@ -0,0 +1,45 @@
+package seed
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandWithoutSeedIsFixed(t *testing.T) {
+	t.Setenv(EnvVar, "")
+	_, ok := Value()
+	assert.False(t, ok)
+	assert.Equal(t, rand.New(rand.NewSource(42)).Int63(), Rand(t, 42).Int63(), "same stream as before seeds existed")
+}
+
+func TestRandWithSeed(t *testing.T) {
+	t.Setenv(EnvVar, "1234")
+	n, ok := Value()
+	assert.True(t, ok)
+	assert.Equal(t, int64(1234), n)
+
+	a, b := Rand(t, 42).Int63(), Rand(t, 42).Int63()
+	assert.Equal(t, a, b, "reproducible")
+	assert.NotEqual(t, rand.New(rand.NewSource(42)).Int63(), a, "differs from the unseeded stream")
+	assert.NotEqual(t, a, Rand(t, 43).Int63(), "bases give different streams")
+
+	t.Setenv(EnvVar, "1235")
+	assert.NotEqual(t, a, Rand(t, 42).Int63(), "learners get different streams")
+
+	t.Setenv(EnvVar, "not a number")
+	_, ok = Value()
+	assert.False(t, ok)
+}
+
+func TestBetween(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	seen := make(map[int]bool)
+	for i := 0; i < 1000; i++ {
+		n := Between(r, 3, 6)
+		assert.True(t, n >= 3 && n <= 6, "%d", n)
+		seen[n] = true
+	}
+	assert.Len(t, seen, 4, "both ends are reachable")
+}
@@ -0,0 +1,286 @@
+// Package shadow finds variables that shadow another of the same name and
+// type, declared in an enclosing scope and used after the shadowing
+// declaration. That is the mark of the shadowing bug: an err := inside an
+// if, for, or case block, meant to set the err outside it, which is then
+// checked and found still nil.
+//
+//	var err error
+//	for _, r := range records {
+//		n, err := w.Write(r) // declares a new err
+//		if err != nil {
+//			break
+//		}
+//		total += n
+//	}
+//	return total, err // always nil
+//
+// It is the check of go vet's shadow analyzer, which vet does not run by
+// default, built on go/types alone so that the course needs no other
+// module. Like the analyzer, it leaves out declarations of a different
+// type, which declare a different thing, redeclarations such as v := v,
+// which copy on purpose, and outer variables that are never used again,
+// which nothing can have been meant for.
+//
+// Run it on a package with "go run ./cmd/shadow dir", or from a test with
+// shadow.Check(t, "file.go"), which fails the test for each finding in
+// the named files.
+package shadow
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// Finding is a declaration that shadows another.
+type Finding struct {
+	Pos      token.Position // where the shadowing variable is declared
+	Name     string         // the variable's name
+	Shadowed token.Position // where the variable it shadows is declared
+}
+
+// String formats f as go vet does.
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: declaration of %q shadows declaration at line %d", f.Pos, f.Name, f.Shadowed.Line)
+}
+
+// Dir checks the Go package in dir, leaving out its test files and files
+// excluded by build constraints.
+func Dir(dir string) ([]Finding, error) {
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range append(pkg.GoFiles, pkg.CgoFiles...) {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return Files(fset, files)
+}
+
+// Files checks files, which make up one package, and returns the findings
+// in the order of their positions. The package has to type-check, cgo
+// aside: the types of the variables decide whether one shadows another.
+func Files(fset *token.FileSet, files []*ast.File) ([]Finding, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+	info := &types.Info{
+		Defs: make(map[*ast.Ident]types.Object),
+		Uses: make(map[*ast.Ident]types.Object),
+	}
+	var errs []error
+	conf := types.Config{
+		Importer:    sourceImporter,
+		FakeImportC: true,
+		Error:       func(err error) { errs = append(errs, err) },
+	}
+	_, _ = conf.Check(files[0].Name.Name, fset, files, info)
+	// With import "C" taken on trust, everything from C has an invalid
+	// type, and its uses are type errors. Only a package without cgo has to
+	// type-check.
+	if len(errs) > 0 && !importsC(files) {
+		return nil, errs[0]
+	}
+
+	c := checker{fset: fset, info: info, spans: spans(info)}
+	for _, f := range files {
+		ast.Inspect(f, c.visit)
+	}
+	sort.Slice(c.findings, func(i, j int) bool {
+		a, b := c.findings[i].Pos, c.findings[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return c.findings, nil
+}
+
+// sourceImporter type-checks imports from their source, which is slower
+// than the compiler's export data but finds every package go/build can,
+// the module's own included. It is shared, so that each package is loaded
+// once however many are checked.
+var sourceImporter = &lockedImporter{imp: importer.ForCompiler(token.NewFileSet(), "source", nil)}
+
+// lockedImporter makes an importer safe for concurrent use.
+type lockedImporter struct {
+	mu  sync.Mutex
+	imp types.Importer
+}
+
+func (l *lockedImporter) Import(path string) (*types.Package, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.imp.Import(path)
+}
+
+// importsC reports whether any of files uses cgo.
+func importsC(files []*ast.File) bool {
+	for _, f := range files {
+		for _, imp := range f.Imports {
+			if imp.Path.Value == `"C"` {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Check fails tb for each finding in the package in the current directory,
+// which is the package's own directory when go test runs it. With names,
+// it reports only the findings in the files of those names.
+func Check(tb testing.TB, names ...string) {
+	tb.Helper()
+	findings, err := Dir(".")
+	if err != nil {
+		tb.Fatalf("shadow: %v", err)
+	}
+	for _, f := range findings {
+		if len(names) == 0 || contains(names, filepath.Base(f.Pos.Filename)) {
+			tb.Errorf("shadow: %v", f)
+		}
+	}
+}
+
+// contains reports whether names holds name.
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// span is the stretch of source from an object's declaration to its last
+// use.
+type span struct {
+	min, max token.Pos
+}
+
+// contains reports whether pos is inside s.
+func (s span) contains(pos token.Pos) bool {
+	return s.min <= pos && pos < s.max
+}
+
+// spans returns the span of every object info defines or uses.
+func spans(info *types.Info) map[types.Object]span {
+	spans := make(map[types.Object]span)
+	grow := func(id *ast.Ident, obj types.Object) {
+		if obj == nil {
+			return
+		}
+		s, ok := spans[obj]
+		if !ok {
+			s = span{min: id.Pos(), max: id.End()}
+		}
+		s.min = min(s.min, id.Pos())
+		s.max = max(s.max, id.End())
+		spans[obj] = s
+	}
+	for id, obj := range info.Defs {
+		grow(id, obj)
+	}
+	for id, obj := range info.Uses {
+		grow(id, obj)
+	}
+	return spans
+}
+
+// checker walks the files of a package, collecting findings.
+type checker struct {
+	fset     *token.FileSet
+	info     *types.Info
+	spans    map[types.Object]span
+	findings []Finding
+}
+
+// visit checks the variables declared by n, if it declares any.
+func (c *checker) visit(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		if n.Tok != token.DEFINE || redeclares(n.Lhs, n.Rhs) {
+			return true
+		}
+		for _, lhs := range n.Lhs {
+			if id, ok := lhs.(*ast.Ident); ok {
+				c.check(id)
+			}
+		}
+	case *ast.GenDecl:
+		if n.Tok != token.VAR {
+			return true
+		}
+		for _, spec := range n.Specs {
+			vs := spec.(*ast.ValueSpec)
+			lhs := make([]ast.Expr, len(vs.Names))
+			for i, id := range vs.Names {
+				lhs[i] = id
+			}
+			if redeclares(lhs, vs.Values) {
+				continue
+			}
+			for _, id := range vs.Names {
+				c.check(id)
+			}
+		}
+	}
+	return true
+}
+
+// redeclares reports whether an assignment is the idiom x, y := x, y,
+// which declares copies of variables on purpose, as for a closure.
+func redeclares(lhs, rhs []ast.Expr) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i := range lhs {
+		l, ok := lhs[i].(*ast.Ident)
+		r, ok2 := rhs[i].(*ast.Ident)
+		if !ok || !ok2 || l.Name != r.Name {
+			return false
+		}
+	}
+	return true
+}
+
+// check records a finding if the variable id declares shadows another.
+func (c *checker) check(id *ast.Ident) {
+	if id.Name == "_" {
+		return
+	}
+	obj, ok := c.info.Defs[id].(*types.Var)
+	if !ok {
+		return // not a declaration, but an assignment to a variable of the same scope
+	}
+	_, outer := obj.Parent().Parent().LookupParent(id.Name, id.Pos())
+	shadowed, ok := outer.(*types.Var)
+	if !ok || shadowed.Parent() == types.Universe {
+		return
+	}
+	if !c.spans[shadowed].contains(id.Pos()) {
+		return // declared later, or never used after id
+	}
+	if !types.Identical(obj.Type(), shadowed.Type()) {
+		return
+	}
+	c.findings = append(c.findings, Finding{
+		Pos:      c.fset.Position(id.Pos()),
+		Name:     id.Name,
+		Shadowed: c.fset.Position(shadowed.Pos()),
+	})
+}
@@ -0,0 +1,195 @@
+package shadow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePackage writes files into a new directory, and returns it.
+func writePackage(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, src := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644))
+	}
+	return dir
+}
+
+// findings returns the findings of Dir as strings, without the directory.
+func findings(t *testing.T, dir string) []string {
+	t.Helper()
+	fs, err := Dir(dir)
+	require.NoError(t, err)
+	var lines []string
+	for _, f := range fs {
+		f.Pos.Filename = filepath.Base(f.Pos.Filename)
+		lines = append(lines, f.String())
+	}
+	return lines
+}
+
+const shadowing = `package records
+
+import (
+	"io"
+	"strconv"
+)
+
+var Verbose bool
+
+// Sum breaks out of the loop with an err that is not the one it returns.
+func Sum(xs []string) (int, error) {
+	var err error
+	total := 0
+	for _, x := range xs {
+		n, err := strconv.Atoi(x)
+		if err != nil {
+			break
+		}
+		total += n
+	}
+	return total, err
+}
+
+// Configure sets a local Verbose, not the package's.
+func Configure(v string) error {
+	Verbose, err := strconv.ParseBool(v)
+	if err != nil {
+		return err
+	}
+	_ = Verbose
+	return nil
+}
+
+// IsVerbose reads the package's Verbose.
+func IsVerbose() bool { return Verbose }
+
+// Copy declares with var, in an if block.
+func Copy(w io.Writer, data []byte) (n int, err error) {
+	if len(data) > 0 {
+		var n int
+		n, err = w.Write(data)
+		_ = n
+	}
+	return n, err
+}
+`
+
+func TestDir(t *testing.T) {
+	dir := writePackage(t, map[string]string{"records.go": shadowing})
+	assert.Equal(t, []string{
+		`records.go:15:6: declaration of "err" shadows declaration at line 12`,
+		`records.go:26:2: declaration of "Verbose" shadows declaration at line 8`,
+		`records.go:40:7: declaration of "n" shadows declaration at line 38`,
+	}, findings(t, dir))
+}
+
+const fine = `package records
+
+import "strconv"
+
+// Handlers copies i for each closure, on purpose.
+func Handlers(n int) []func() int {
+	var hs []func() int
+	for i := 0; i < n; i++ {
+		i := i
+		hs = append(hs, func() int { return i })
+	}
+	return hs
+}
+
+// Parse shadows err only where the outer one is not used again.
+func Parse(xs []string) ([]int, error) {
+	ns, err := make([]int, 0, len(xs)), error(nil)
+	if len(xs) == 0 {
+		return ns, err
+	}
+	for _, x := range xs {
+		n, err := strconv.Atoi(x)
+		if err != nil {
+			return nil, err
+		}
+		ns = append(ns, n)
+	}
+	return ns, nil
+}
+
+// Lengths declares a string v in the scope of an int v: another thing.
+func Lengths(v int, words []string) int {
+	total := v
+	for _, w := range words {
+		v := w + "!"
+		total += len(v)
+	}
+	return total + v
+}
+
+// Later declares x before the x it would shadow.
+func Later() int {
+	{
+		x := 1
+		_ = x
+	}
+	x := 2
+	return x
+}
+`
+
+func TestDirNoFindings(t *testing.T) {
+	dir := writePackage(t, map[string]string{"records.go": fine})
+	assert.Empty(t, findings(t, dir))
+}
+
+func TestDirSkipsTests(t *testing.T) {
+	dir := writePackage(t, map[string]string{
+		"records.go":      "package records\n\nvar Verbose bool\n",
+		"records_test.go": "package records\n\nfunc init() {\n\tVerbose := true\n\t_ = Verbose\n}\n\nvar _ = Verbose\n",
+	})
+	assert.Empty(t, findings(t, dir))
+}
+
+func TestDirTypeError(t *testing.T) {
+	dir := writePackage(t, map[string]string{"records.go": "package records\n\nvar x int = \"one\"\n"})
+	_, err := Dir(dir)
+	assert.ErrorContains(t, err, "cannot use")
+}
+
+// recorder is a testing.TB that records errors.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestCheck(t *testing.T) {
+	dir := writePackage(t, map[string]string{
+		"records.go": shadowing,
+		"fine.go":    "package records\n\nfunc Fine() int { return 1 }\n",
+	})
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	r := &recorder{TB: t}
+	Check(r, "fine.go")
+	assert.Empty(t, r.errors)
+
+	Check(r, "records.go")
+	require.Len(t, r.errors, 3)
+	assert.Equal(t, `shadow: records.go:15:6: declaration of "err" shadows declaration at line 12`, r.errors[0])
+
+	r.errors = nil
+	Check(r)
+	assert.Len(t, r.errors, 3, "every file")
+}
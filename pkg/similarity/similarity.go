@@ -0,0 +1,231 @@
+// Package similarity flags suspiciously similar exercise submissions in a
+// cohort.
+//
+// Parse turns a Go file into a Fingerprint. It walks the syntax tree in
+// order and writes one token per node: the kind of node and its operator,
+// but for identifiers and literals only that they are one, so renaming
+// variables, rewording strings, or reformatting does not hide a copy.
+// Every run of K tokens is hashed, and winnowing keeps the smallest hash
+// in every window of W of them (the algorithm of Schleimer, Wilkerson, and
+// Aiken's MOSS), so a copied function is found wherever it was pasted.
+//
+// Every learner starts from the same exercise file, so Compare leaves out
+// whatever the starting file already contains and only compares what the
+// learners wrote themselves. A fix that changes a line or two is too small
+// to tell a copy from two people finding the same answer; submissions with
+// fewer than MinOwn fingerprints of their own are not compared.
+package similarity
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"hash/fnv"
+	"sort"
+)
+
+// Winnowing parameters: K tokens per hash, and one fingerprint kept per
+// window of W hashes. Matches shorter than K tokens are never found; any
+// match of K+W-1 tokens or more always is.
+const (
+	K = 20
+	W = 8
+)
+
+// MinOwn is the number of fingerprints a submission needs beyond those of
+// its starting file to be compared.
+const MinOwn = 8
+
+// Fingerprint is the set of winnowed hashes of one file.
+type Fingerprint struct {
+	hashes map[uint64]bool
+}
+
+// Len returns the number of hashes in f.
+func (f *Fingerprint) Len() int { return len(f.hashes) }
+
+// Parse fingerprints Go source code.
+func Parse(src []byte) (*Fingerprint, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	return &Fingerprint{hashes: winnow(kgrams(tokens(f)))}, nil
+}
+
+// tokens returns the identifier-normalized preorder walk of f, with a ")"
+// when each node ends so that nesting counts.
+func tokens(f *ast.File) []string {
+	var toks []string
+	for _, decl := range f.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			continue
+		}
+		ast.Inspect(decl, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case nil:
+				toks = append(toks, ")")
+				return true
+			case *ast.CommentGroup, *ast.Comment:
+				return false
+			case *ast.Ident:
+				// Predeclared names (int, len, nil, ...) say something
+				// about the code; the learner's own names do not.
+				if types.Universe.Lookup(n.Name) != nil {
+					toks = append(toks, n.Name)
+				} else {
+					toks = append(toks, "ident")
+				}
+			case *ast.BasicLit:
+				toks = append(toks, "lit:"+n.Kind.String())
+			case *ast.BinaryExpr:
+				toks = append(toks, "binary:"+n.Op.String())
+			case *ast.UnaryExpr:
+				toks = append(toks, "unary:"+n.Op.String())
+			case *ast.AssignStmt:
+				toks = append(toks, "assign:"+n.Tok.String())
+			case *ast.IncDecStmt:
+				toks = append(toks, "incdec:"+n.Tok.String())
+			case *ast.BranchStmt:
+				toks = append(toks, "branch:"+n.Tok.String())
+			default:
+				toks = append(toks, fmt.Sprintf("%T", n))
+			}
+			return true
+		})
+	}
+	return toks
+}
+
+// kgrams hashes every run of K tokens.
+func kgrams(toks []string) []uint64 {
+	if len(toks) < K {
+		return nil
+	}
+	hashes := make([]uint64, 0, len(toks)-K+1)
+	for i := 0; i+K <= len(toks); i++ {
+		h := fnv.New64a()
+		for _, tok := range toks[i : i+K] {
+			h.Write([]byte(tok))
+			h.Write([]byte{0})
+		}
+		hashes = append(hashes, h.Sum64())
+	}
+	return hashes
+}
+
+// winnow keeps the smallest hash of every window of W, the rightmost one
+// on ties. A file too short for a whole window keeps its smallest hash.
+func winnow(hashes []uint64) map[uint64]bool {
+	kept := make(map[uint64]bool)
+	if len(hashes) == 0 {
+		return kept
+	}
+	w := min(W, len(hashes))
+	for i := 0; i+w <= len(hashes); i++ {
+		m := i
+		for j := i; j < i+w; j++ {
+			if hashes[j] <= hashes[m] {
+				m = j
+			}
+		}
+		kept[hashes[m]] = true
+	}
+	return kept
+}
+
+// Compare returns the Jaccard similarity of a and b, leaving out the
+// fingerprints of base, the file both started from; base may be nil. It
+// reports false if either has fewer than MinOwn fingerprints of its own.
+func Compare(a, b, base *Fingerprint) (score float64, ok bool) {
+	own := func(f *Fingerprint) map[uint64]bool {
+		out := make(map[uint64]bool, len(f.hashes))
+		for h := range f.hashes {
+			if base == nil || !base.hashes[h] {
+				out[h] = true
+			}
+		}
+		return out
+	}
+	oa, ob := own(a), own(b)
+	if len(oa) < MinOwn || len(ob) < MinOwn {
+		return 0, false
+	}
+	shared := 0
+	for h := range oa {
+		if ob[h] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(oa)+len(ob)-shared), true
+}
+
+// Submission is one learner's file for one exercise.
+type Submission struct {
+	Learner  string
+	Exercise string // exercise ID, e.g. "01-basics/exercise1_fix_bugs"
+	Source   []byte
+}
+
+// Match is a pair of submissions for the same exercise at least as similar
+// as the threshold Detect was given.
+type Match struct {
+	Exercise string
+	A, B     string // learners, in name order
+	Score    float64
+}
+
+// Detect compares the submissions for each exercise pairwise and returns
+// the matches scoring threshold or more, most similar first. bases holds
+// each exercise's starting file by exercise ID. Submissions that do not
+// parse are skipped: they are not finished, so there is nothing to copy.
+func Detect(subs []Submission, bases map[string][]byte, threshold float64) []Match {
+	type entry struct {
+		learner string
+		f       *Fingerprint
+	}
+	byExercise := make(map[string][]entry)
+	for _, s := range subs {
+		f, err := Parse(s.Source)
+		if err != nil {
+			continue
+		}
+		byExercise[s.Exercise] = append(byExercise[s.Exercise], entry{s.Learner, f})
+	}
+
+	var matches []Match
+	for exercise, entries := range byExercise {
+		var base *Fingerprint
+		if src, ok := bases[exercise]; ok {
+			base, _ = Parse(src)
+		}
+		for i := range entries {
+			for j := i + 1; j < len(entries); j++ {
+				score, ok := Compare(entries[i].f, entries[j].f, base)
+				if !ok || score < threshold {
+					continue
+				}
+				a, b := entries[i].learner, entries[j].learner
+				if b < a {
+					a, b = b, a
+				}
+				matches = append(matches, Match{Exercise: exercise, A: a, B: b, Score: score})
+			}
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		x, y := matches[i], matches[j]
+		switch {
+		case x.Score != y.Score:
+			return x.Score > y.Score
+		case x.Exercise != y.Exercise:
+			return x.Exercise < y.Exercise
+		case x.A != y.A:
+			return x.A < y.A
+		}
+		return x.B < y.B
+	})
+	return matches
+}
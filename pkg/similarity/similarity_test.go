@@ -0,0 +1,176 @@
+package similarity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// starter is the exercise file every learner begins with.
+const starter = `package exercises
+
+// WordFrequency counts how often each word occurs.
+func WordFrequency(text string) map[string]int {
+	// BUG: counts characters, not words
+	counts := make(map[string]int)
+	for _, r := range text {
+		counts[string(r)]++
+	}
+	return counts
+}
+`
+
+// ada rewrote the function around a tokenizer of their own.
+const ada = `package exercises
+
+import "unicode"
+
+// WordFrequency counts how often each word occurs.
+func WordFrequency(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, w := range splitWords(text) {
+		counts[w]++
+	}
+	return counts
+}
+
+func splitWords(text string) []string {
+	var words []string
+	start := -1
+	for i, r := range text {
+		isLetter := unicode.IsLetter(r) || r == '\''
+		if isLetter && start < 0 {
+			start = i
+		}
+		if !isLetter && start >= 0 {
+			words = append(words, text[start:i])
+			start = -1
+		}
+	}
+	if start >= 0 {
+		words = append(words, text[start:])
+	}
+	return words
+}
+`
+
+// bo copied ada's solution, renamed everything, and changed the comments.
+const bo = `package exercises
+
+import "unicode"
+
+// WordFrequency returns word counts.
+func WordFrequency(s string) map[string]int {
+	freq := make(map[string]int)
+	for _, word := range tokenize(s) {
+		freq[word]++ // count it
+	}
+	return freq
+}
+
+// tokenize splits s into words.
+func tokenize(s string) []string {
+	var out []string
+	begin := -1
+	for pos, ch := range s {
+		letter := unicode.IsLetter(ch) || ch == '-'
+		if letter && begin < 0 {
+			begin = pos
+		}
+		if !letter && begin >= 0 {
+			out = append(out, s[begin:pos])
+			begin = -1
+		}
+	}
+	if begin >= 0 {
+		out = append(out, s[begin:])
+	}
+	return out
+}
+`
+
+// cy solved it independently with the standard library.
+const cy = `package exercises
+
+import (
+	"sort"
+	"strings"
+)
+
+// WordFrequency counts how often each word occurs.
+func WordFrequency(text string) map[string]int {
+	counts := map[string]int{}
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !('a' <= r && r <= 'z')
+	})
+	sort.Strings(fields)
+	for i := 0; i < len(fields); i++ {
+		counts[fields[i]] = counts[fields[i]] + 1
+	}
+	return counts
+}
+`
+
+// dee only fixed the bug in place, like most of the cohort will.
+const dee = `package exercises
+
+import "strings"
+
+// WordFrequency counts how often each word occurs.
+func WordFrequency(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, w := range strings.Fields(text) {
+		counts[w]++
+	}
+	return counts
+}
+`
+
+func parse(t *testing.T, src string) *Fingerprint {
+	t.Helper()
+	f, err := Parse([]byte(src))
+	require.NoError(t, err)
+	return f
+}
+
+func TestCompare(t *testing.T) {
+	base := parse(t, starter)
+	score, ok := Compare(parse(t, ada), parse(t, bo), base)
+	require.True(t, ok)
+	assert.Greater(t, score, 0.8, "renaming and new comments do not hide a copy")
+
+	score, ok = Compare(parse(t, ada), parse(t, cy), base)
+	require.True(t, ok)
+	assert.Less(t, score, 0.2)
+
+	_, ok = Compare(parse(t, ada), parse(t, dee), base)
+	assert.False(t, ok, "a fix in place is too small to judge")
+
+	score, ok = Compare(parse(t, ada), parse(t, ada), nil)
+	require.True(t, ok)
+	assert.Equal(t, 1.0, score)
+}
+
+func TestParse(t *testing.T) {
+	assert.Equal(t, parse(t, starter).hashes, parse(t, "// Reformatted.\npackage exercises\nfunc F(s string) map[string]int { c := make(map[string]int); for _, x := range s { c[string(x)]++ }; return c }\n").hashes)
+	_, err := Parse([]byte("package exercises\nfunc {"))
+	assert.Error(t, err)
+	assert.Zero(t, parse(t, "package exercises\n").Len())
+}
+
+func TestDetect(t *testing.T) {
+	subs := []Submission{
+		{Learner: "bo", Exercise: "01/words", Source: []byte(bo)},
+		{Learner: "ada", Exercise: "01/words", Source: []byte(ada)},
+		{Learner: "cy", Exercise: "01/words", Source: []byte(cy)},
+		{Learner: "dee", Exercise: "01/words", Source: []byte(dee)},
+		{Learner: "eve", Exercise: "01/words", Source: []byte("package exercises\nfunc {")},
+		{Learner: "cy", Exercise: "02/other", Source: []byte(ada)},
+	}
+	matches := Detect(subs, map[string][]byte{"01/words": []byte(starter)}, 0.5)
+	require.Len(t, matches, 1, "ada's file under another exercise is not compared with hers")
+	assert.Equal(t, "01/words", matches[0].Exercise)
+	assert.Equal(t, "ada", matches[0].A)
+	assert.Equal(t, "bo", matches[0].B)
+}
@@ -0,0 +1,271 @@
+// Package smtptest runs an SMTP server in the test process, on loopback,
+// for tests of code that sends mail. It speaks as much of RFC 5321 as
+// net/smtp uses: EHLO and HELO, AUTH PLAIN, MAIL, RCPT, DATA, RSET, NOOP,
+// and QUIT. Nothing is delivered; the server keeps every message it
+// accepts. Module 30 tests its mailers with it:
+//
+//	srv := smtptest.Start(t, nil)
+//	err := smtp.SendMail(srv.Addr, nil, "app@example.com", []string{"ada@example.com"}, msg)
+//	require.NoError(t, err)
+//	got := srv.Messages()[0]
+//
+// Like a real server, it refuses a message with a line longer than
+// MaxLineLength, and asks for a password when it was given users.
+package smtptest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// MaxLineLength is the longest line RFC 5322 allows in a message, without
+// its CRLF.
+const MaxLineLength = 998
+
+// Message is a message the server accepted.
+type Message struct {
+	From string   // the address of MAIL FROM
+	To   []string // the addresses of RCPT TO, in order
+	User string   // who logged in with AUTH, if anyone did
+	Data []byte   // the message, with CRLF line endings and dots unstuffed
+}
+
+// Server is an SMTP server listening on loopback.
+type Server struct {
+	// Addr is the host:port to give net/smtp.
+	Addr string
+
+	ln    net.Listener
+	users map[string]string
+	wg    sync.WaitGroup
+
+	mu       sync.Mutex
+	messages []Message
+	conns    map[net.Conn]bool
+}
+
+// NewServer starts a server on 127.0.0.1. When users is not nil, it maps
+// user names to passwords, and clients must log in with one of them
+// before they send.
+func NewServer(users map[string]string) (*Server, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{Addr: ln.Addr().String(), ln: ln, users: users, conns: make(map[net.Conn]bool)}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Start starts a server for a test, and closes it when the test ends.
+func Start(t testing.TB, users map[string]string) *Server {
+	t.Helper()
+	s, err := NewServer(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// Messages returns the messages accepted so far, oldest first.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// Close stops the server, and closes the connections it still has.
+func (s *Server) Close() error {
+	err := s.ln.Close()
+	s.mu.Lock()
+	for c := range s.conns {
+		c.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		c, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[c] = true
+		s.mu.Unlock()
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(c)
+			s.mu.Lock()
+			delete(s.conns, c)
+			s.mu.Unlock()
+			c.Close()
+		}()
+	}
+}
+
+// session is the state of one connection.
+type session struct {
+	*textproto.Conn
+	user string
+	mail *Message // nil before MAIL FROM
+}
+
+func (s *Server) handle(c net.Conn) {
+	conn := &session{Conn: textproto.NewConn(c)}
+	conn.PrintfLine("220 smtptest ready")
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			if s.users != nil {
+				conn.PrintfLine("250-smtptest")
+				conn.PrintfLine("250 AUTH PLAIN")
+			} else {
+				conn.PrintfLine("250 smtptest")
+			}
+		case "HELO":
+			conn.PrintfLine("250 smtptest")
+		case "AUTH":
+			s.auth(conn, arg)
+		case "MAIL":
+			from, ok := address(arg, "FROM:")
+			switch {
+			case !ok:
+				conn.PrintfLine("501 5.5.4 Syntax: MAIL FROM:<address>")
+			case s.users != nil && conn.user == "":
+				conn.PrintfLine("530 5.7.0 Authentication required")
+			default:
+				conn.mail = &Message{From: from, User: conn.user}
+				conn.PrintfLine("250 2.1.0 OK")
+			}
+		case "RCPT":
+			to, ok := address(arg, "TO:")
+			switch {
+			case conn.mail == nil:
+				conn.PrintfLine("503 5.5.1 MAIL first")
+			case !ok || to == "":
+				conn.PrintfLine("501 5.5.4 Syntax: RCPT TO:<address>")
+			default:
+				conn.mail.To = append(conn.mail.To, to)
+				conn.PrintfLine("250 2.1.5 OK")
+			}
+		case "DATA":
+			if conn.mail == nil || len(conn.mail.To) == 0 {
+				conn.PrintfLine("503 5.5.1 RCPT first")
+				continue
+			}
+			conn.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := readData(conn.R)
+			switch {
+			case errors.Is(err, errLineTooLong):
+				conn.PrintfLine("552 5.3.4 %v", err)
+			case err != nil:
+				return
+			default:
+				conn.mail.Data = data
+				s.mu.Lock()
+				s.messages = append(s.messages, *conn.mail)
+				s.mu.Unlock()
+				conn.PrintfLine("250 2.0.0 OK: queued")
+			}
+			conn.mail = nil
+		case "RSET":
+			conn.mail = nil
+			conn.PrintfLine("250 2.0.0 OK")
+		case "NOOP":
+			conn.PrintfLine("250 2.0.0 OK")
+		case "QUIT":
+			conn.PrintfLine("221 2.0.0 Bye")
+			return
+		default:
+			conn.PrintfLine("502 5.5.2 Command not recognized")
+		}
+	}
+}
+
+// auth handles AUTH PLAIN, with the credentials on the command line as
+// net/smtp sends them.
+func (s *Server) auth(conn *session, arg string) {
+	mech, resp, _ := strings.Cut(arg, " ")
+	if s.users == nil || !strings.EqualFold(mech, "PLAIN") {
+		conn.PrintfLine("504 5.5.4 Unrecognized authentication type")
+		return
+	}
+	b, err := base64.StdEncoding.DecodeString(resp)
+	if err != nil {
+		conn.PrintfLine("501 5.5.2 Cannot decode response")
+		return
+	}
+	// identity NUL user NUL password
+	parts := bytes.Split(b, []byte{0})
+	if len(parts) != 3 {
+		conn.PrintfLine("501 5.5.2 Malformed response")
+		return
+	}
+	user, password := string(parts[1]), string(parts[2])
+	if want, ok := s.users[user]; !ok || want != password {
+		conn.PrintfLine("535 5.7.8 Authentication credentials invalid")
+		return
+	}
+	conn.user = user
+	conn.PrintfLine("235 2.7.0 Authentication successful")
+}
+
+// address returns the address in "FROM:<a@b>" or "TO:<a@b>", ignoring
+// any parameters after it.
+func address(arg, prefix string) (string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", false
+	}
+	rest := strings.TrimSpace(arg[len(prefix):])
+	if !strings.HasPrefix(rest, "<") {
+		return "", false
+	}
+	addr, _, ok := strings.Cut(rest[1:], ">")
+	return addr, ok
+}
+
+var errLineTooLong = fmt.Errorf("line longer than %d characters", MaxLineLength)
+
+// readData reads a message up to the line with a single dot, unstuffing
+// dots. It reads to the end even when a line is too long, so the
+// connection can go on.
+func readData(r *bufio.Reader) ([]byte, error) {
+	var data bytes.Buffer
+	var tooLong error
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			return nil, err
+		}
+		content := bytes.TrimSuffix(bytes.TrimSuffix(line, []byte("\n")), []byte("\r"))
+		if string(content) == "." {
+			return data.Bytes(), tooLong
+		}
+		if len(content) > MaxLineLength {
+			tooLong = errLineTooLong
+		}
+		content = bytes.TrimPrefix(content, []byte("."))
+		data.Write(content)
+		data.WriteString("\r\n")
+	}
+}
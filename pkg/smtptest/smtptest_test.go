@@ -0,0 +1,69 @@
+package smtptest
+
+import (
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendMail(t *testing.T) {
+	srv := Start(t, nil)
+	msg := "Subject: Hi\r\n\r\nHello.\r\n.leading dot\r\n"
+	err := smtp.SendMail(srv.Addr, nil, "app@example.com", []string{"ada@example.com", "grace@example.com"}, []byte(msg))
+	require.NoError(t, err)
+
+	got := srv.Messages()
+	require.Len(t, got, 1)
+	assert.Equal(t, "app@example.com", got[0].From)
+	assert.Equal(t, []string{"ada@example.com", "grace@example.com"}, got[0].To)
+	assert.Empty(t, got[0].User)
+	assert.Equal(t, msg, string(got[0].Data), "dots unstuffed")
+}
+
+func TestLineTooLong(t *testing.T) {
+	srv := Start(t, nil)
+	send := func(line string) error {
+		return smtp.SendMail(srv.Addr, nil, "app@example.com", []string{"ada@example.com"},
+			[]byte("Subject: Hi\r\n\r\n"+line+"\r\n"))
+	}
+	require.NoError(t, send(strings.Repeat("x", MaxLineLength)))
+	err := send(strings.Repeat("x", MaxLineLength+1))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "552")
+	assert.Len(t, srv.Messages(), 1)
+}
+
+func TestAuth(t *testing.T) {
+	srv := Start(t, map[string]string{"app": "s3cret"})
+	to := []string{"ada@example.com"}
+	msg := []byte("Subject: Hi\r\n\r\nHello.\r\n")
+
+	err := smtp.SendMail(srv.Addr, nil, "app@example.com", to, msg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "530")
+
+	// net/smtp sends a password without TLS only to localhost.
+	err = smtp.SendMail(srv.Addr, smtp.PlainAuth("", "app", "wrong", "127.0.0.1"), "app@example.com", to, msg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "535")
+
+	err = smtp.SendMail(srv.Addr, smtp.PlainAuth("", "app", "s3cret", "127.0.0.1"), "app@example.com", to, msg)
+	require.NoError(t, err)
+	got := srv.Messages()
+	require.Len(t, got, 1)
+	assert.Equal(t, "app", got[0].User)
+}
+
+func TestClose(t *testing.T) {
+	srv, err := NewServer(nil)
+	require.NoError(t, err)
+	c, err := smtp.Dial(srv.Addr)
+	require.NoError(t, err)
+	defer c.Close()
+	// An open connection does not keep Close waiting.
+	require.NoError(t, srv.Close())
+	assert.Error(t, c.Noop())
+}
@@ -0,0 +1,199 @@
+// Package stats summarizes a stream of float64 samples in constant
+// memory: count, mean, variance, minimum, maximum, and quantiles.
+//
+// An Accumulator never keeps the samples themselves. The mean and
+// variance are updated with Welford's method, which stays accurate where
+// a running sum of squares cancels catastrophically; quantiles come from
+// a sketch of at most SketchSize weighted centroids, exact until it fills
+// and within about a percent of rank after that. The grader uses it for
+// the runs of a benchmark, and learngo stats for the effort on exercises.
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// SketchSize is the most centroids an Accumulator keeps for quantiles.
+// Quantiles are exact for up to SketchSize samples.
+const SketchSize = 128
+
+// centroid stands for count samples whose mean is mean.
+type centroid struct {
+	mean  float64
+	count float64
+}
+
+// Accumulator summarizes the samples added to it. The zero value is an
+// empty Accumulator ready to use. It is not safe for concurrent use; to
+// spread the work over goroutines, give each its own and Merge them.
+type Accumulator struct {
+	n        int
+	mean, m2 float64 // Welford's running mean and sum of squared deviations
+	min, max float64
+	sketch   []centroid // sorted by mean
+}
+
+// Add adds the sample x. NaN has no place in an order, and is ignored.
+func (a *Accumulator) Add(x float64) {
+	if math.IsNaN(x) {
+		return
+	}
+	a.n++
+	if a.n == 1 {
+		a.min, a.max = x, x
+	} else {
+		a.min, a.max = math.Min(a.min, x), math.Max(a.max, x)
+	}
+	d := x - a.mean
+	a.mean += d / float64(a.n)
+	a.m2 += d * (x - a.mean)
+	a.insert(centroid{mean: x, count: 1})
+}
+
+// AddAll adds each of xs.
+func (a *Accumulator) AddAll(xs ...float64) {
+	for _, x := range xs {
+		a.Add(x)
+	}
+}
+
+// AddChan adds the samples received from ch until it is closed.
+func (a *Accumulator) AddChan(ch <-chan float64) {
+	for x := range ch {
+		a.Add(x)
+	}
+}
+
+// AddSeq adds the samples seq yields. seq has the shape of an
+// iter.Seq[float64], so a push iterator written for range-over-func
+// works here unchanged.
+func (a *Accumulator) AddSeq(seq func(yield func(float64) bool)) {
+	seq(func(x float64) bool {
+		a.Add(x)
+		return true
+	})
+}
+
+// Merge adds the samples summarized by b, as if each had been added to a.
+// The mean and variance are exact; quantiles are as accurate as the two
+// sketches were.
+func (a *Accumulator) Merge(b *Accumulator) {
+	if b.n == 0 {
+		return
+	}
+	if a.n == 0 {
+		*a = Accumulator{n: b.n, mean: b.mean, m2: b.m2, min: b.min, max: b.max}
+		a.sketch = append(a.sketch, b.sketch...)
+		return
+	}
+	n := a.n + b.n
+	d := b.mean - a.mean
+	a.m2 += b.m2 + d*d*float64(a.n)*float64(b.n)/float64(n)
+	a.mean += d * float64(b.n) / float64(n)
+	a.n = n
+	a.min, a.max = math.Min(a.min, b.min), math.Max(a.max, b.max)
+	for _, c := range b.sketch {
+		a.insert(c)
+	}
+}
+
+// Count returns the number of samples added.
+func (a *Accumulator) Count() int { return a.n }
+
+// Mean returns the mean of the samples, or NaN if there are none.
+func (a *Accumulator) Mean() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.mean
+}
+
+// Variance returns the sample variance, which divides by n-1, or 0 for
+// fewer than two samples.
+func (a *Accumulator) Variance() float64 {
+	if a.n < 2 {
+		return 0
+	}
+	return a.m2 / float64(a.n-1)
+}
+
+// StdDev returns the sample standard deviation.
+func (a *Accumulator) StdDev() float64 { return math.Sqrt(a.Variance()) }
+
+// Min returns the smallest sample, or NaN if there are none.
+func (a *Accumulator) Min() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.min
+}
+
+// Max returns the largest sample, or NaN if there are none.
+func (a *Accumulator) Max() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.max
+}
+
+// Quantile returns an estimate of the q-quantile of the samples, for q
+// between 0 and 1: Quantile(0.5) is the median, Quantile(0) the minimum,
+// and Quantile(1) the maximum. Between two samples it interpolates, so
+// the median of an even number of samples is the mean of the middle two.
+// It returns NaN if there are no samples, and panics if q is outside
+// [0, 1].
+func (a *Accumulator) Quantile(q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic("stats: quantile outside [0, 1]")
+	}
+	if a.n == 0 {
+		return math.NaN()
+	}
+	// Each centroid sits at the middle of the ranks it stands for, and
+	// the minimum and maximum at the two ends; interpolate between the
+	// two points either side of rank q·n.
+	rank := q * float64(a.n)
+	prevRank, prevValue := 0.0, a.min
+	var seen float64
+	for _, c := range a.sketch {
+		mid := seen + c.count/2
+		if rank <= mid {
+			return lerp(prevRank, prevValue, mid, c.mean, rank)
+		}
+		prevRank, prevValue = mid, c.mean
+		seen += c.count
+	}
+	return lerp(prevRank, prevValue, float64(a.n), a.max, rank)
+}
+
+// lerp returns the value at x on the line through (x0, y0) and (x1, y1).
+func lerp(x0, y0, x1, y1, x float64) float64 {
+	if x1 <= x0 {
+		return y1
+	}
+	return y0 + (y1-y0)*(x-x0)/(x1-x0)
+}
+
+// insert adds c to the sketch, and merges the closest two centroids if
+// that leaves too many. The distance between two centroids is weighted by
+// their counts, so the sparse tails keep finer centroids than the middle.
+func (a *Accumulator) insert(c centroid) {
+	i := sort.Search(len(a.sketch), func(i int) bool { return a.sketch[i].mean > c.mean })
+	a.sketch = append(a.sketch, centroid{})
+	copy(a.sketch[i+1:], a.sketch[i:])
+	a.sketch[i] = c
+	for len(a.sketch) > SketchSize {
+		best, bestCost := 0, math.Inf(1)
+		for j := 0; j+1 < len(a.sketch); j++ {
+			l, r := a.sketch[j], a.sketch[j+1]
+			if cost := (r.mean - l.mean) * (l.count + r.count); cost < bestCost {
+				best, bestCost = j, cost
+			}
+		}
+		l, r := a.sketch[best], a.sketch[best+1]
+		count := l.count + r.count
+		a.sketch[best] = centroid{mean: l.mean + (r.mean-l.mean)*r.count/count, count: count}
+		a.sketch = append(a.sketch[:best+1], a.sketch[best+2:]...)
+	}
+}
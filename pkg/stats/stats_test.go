@@ -0,0 +1,192 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/proptest"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/seed"
+	"github.com/stretchr/testify/assert"
+)
+
+func of(xs ...float64) *Accumulator {
+	var a Accumulator
+	a.AddAll(xs...)
+	return &a
+}
+
+// exactQuantile is the q-quantile of sorted, interpolated the way
+// Quantile does: sample i sits at rank i+0.5.
+func exactQuantile(sorted []float64, q float64) float64 {
+	pos := q*float64(len(sorted)) - 0.5
+	if pos <= 0 {
+		return sorted[0]
+	}
+	if pos >= float64(len(sorted)-1) {
+		return sorted[len(sorted)-1]
+	}
+	i := int(pos)
+	return sorted[i] + (sorted[i+1]-sorted[i])*(pos-float64(i))
+}
+
+func TestSummary(t *testing.T) {
+	a := of(2, 4, 4, 4, 5, 5, 7, 9)
+	assert.Equal(t, 8, a.Count())
+	assert.Equal(t, 5.0, a.Mean())
+	assert.InDelta(t, 32.0/7, a.Variance(), 1e-12, "divides by n-1")
+	assert.InDelta(t, math.Sqrt(32.0/7), a.StdDev(), 1e-12)
+	assert.Equal(t, 2.0, a.Min())
+	assert.Equal(t, 9.0, a.Max())
+	assert.Equal(t, 2.0, a.Quantile(0))
+	assert.Equal(t, 4.5, a.Quantile(0.5), "the mean of the middle two")
+	assert.Equal(t, 9.0, a.Quantile(1))
+
+	a.Add(math.NaN())
+	assert.Equal(t, 8, a.Count(), "NaN is ignored")
+
+	one := of(-3)
+	assert.Equal(t, 0.0, one.Variance())
+	assert.Equal(t, -3.0, one.Quantile(0.9))
+}
+
+func TestEmpty(t *testing.T) {
+	var a Accumulator
+	assert.Equal(t, 0, a.Count())
+	assert.True(t, math.IsNaN(a.Mean()))
+	assert.True(t, math.IsNaN(a.Min()))
+	assert.True(t, math.IsNaN(a.Max()))
+	assert.True(t, math.IsNaN(a.Quantile(0.5)))
+	assert.Equal(t, 0.0, a.Variance())
+	assert.Panics(t, func() { a.Quantile(1.5) })
+	assert.Panics(t, func() { a.Quantile(math.NaN()) })
+}
+
+// quantileCase is a few samples and a quantile to ask of them.
+type quantileCase struct {
+	xs []float64
+	q  float64
+}
+
+func TestExactWhileSmall(t *testing.T) {
+	cases := proptest.Map2(proptest.SliceOf(proptest.Float64(-1e6, 1e6)), proptest.Float64(0, 1),
+		func(xs []float64, q float64) quantileCase {
+			if len(xs) > SketchSize {
+				xs = xs[:SketchSize]
+			}
+			return quantileCase{xs, q}
+		})
+	proptest.Check(t, cases, func(c quantileCase) bool {
+		if len(c.xs) == 0 {
+			return true
+		}
+		sorted := append([]float64(nil), c.xs...)
+		sort.Float64s(sorted)
+		want := exactQuantile(sorted, c.q)
+		return math.Abs(of(c.xs...).Quantile(c.q)-want) <= 1e-9*math.Max(1, math.Abs(want))
+	})
+}
+
+func TestQuantileAccuracy(t *testing.T) {
+	r := seed.Rand(t, 80)
+	dists := map[string]func() float64{
+		"uniform":     r.Float64,
+		"normal":      r.NormFloat64,
+		"exponential": r.ExpFloat64,
+		"bimodal": func() float64 {
+			if r.Intn(4) == 0 {
+				return 100 + r.NormFloat64()
+			}
+			return r.NormFloat64()
+		},
+	}
+	const n = 50000
+	for name, sample := range dists {
+		var a Accumulator
+		xs := make([]float64, n)
+		for i := range xs {
+			xs[i] = sample()
+			a.Add(xs[i])
+		}
+		sort.Float64s(xs)
+		for _, q := range []float64{0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99} {
+			got := a.Quantile(q)
+			rank := float64(sort.SearchFloat64s(xs, got)) / n
+			assert.InDelta(t, q, rank, 0.005, "%s: Quantile(%v) = %v, which has rank %.4f", name, q, got, rank)
+		}
+		assert.Equal(t, xs[0], a.Quantile(0), name)
+		assert.Equal(t, xs[n-1], a.Quantile(1), name)
+	}
+}
+
+func TestMeanAndVarianceAccuracy(t *testing.T) {
+	// A large offset wipes out a naive sum of squares: E[x²] - E[x]² is
+	// the difference of two numbers near 1e18.
+	r := seed.Rand(t, 80)
+	var a Accumulator
+	var sum float64
+	xs := make([]float64, 10000)
+	for i := range xs {
+		xs[i] = 1e9 + r.NormFloat64()
+		a.Add(xs[i])
+		sum += xs[i] - 1e9
+	}
+	mean := 1e9 + sum/float64(len(xs))
+	var ss float64
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+	assert.InDelta(t, mean, a.Mean(), 1e-6)
+	assert.InDelta(t, ss/float64(len(xs)-1), a.Variance(), 1e-6)
+}
+
+func TestMerge(t *testing.T) {
+	r := seed.Rand(t, 80)
+	var whole, left, right, empty Accumulator
+	for i := 0; i < 5000; i++ {
+		x := r.NormFloat64()*10 + 3
+		whole.Add(x)
+		if i%3 == 0 {
+			left.Add(x)
+		} else {
+			right.Add(x)
+		}
+	}
+	empty.Merge(&left)
+	empty.Merge(&right)
+	merged := empty
+	assert.Equal(t, whole.Count(), merged.Count())
+	assert.InDelta(t, whole.Mean(), merged.Mean(), 1e-9)
+	assert.InDelta(t, whole.Variance(), merged.Variance(), 1e-9)
+	assert.Equal(t, whole.Min(), merged.Min())
+	assert.Equal(t, whole.Max(), merged.Max())
+	for _, q := range []float64{0.1, 0.5, 0.9} {
+		assert.InDelta(t, whole.Quantile(q), merged.Quantile(q), 0.5, "Quantile(%v)", q)
+	}
+	assert.Equal(t, 1667, left.Count(), "merging does not change b")
+}
+
+func TestSources(t *testing.T) {
+	ch := make(chan float64)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= 10; i++ {
+			ch <- float64(i)
+		}
+	}()
+	var fromChan Accumulator
+	fromChan.AddChan(ch)
+	assert.Equal(t, 10, fromChan.Count())
+	assert.Equal(t, 5.5, fromChan.Mean())
+
+	seq := func(yield func(float64) bool) {
+		for i := 1; i <= 10; i++ {
+			if !yield(float64(i)) {
+				return
+			}
+		}
+	}
+	var fromSeq Accumulator
+	fromSeq.AddSeq(seq)
+	assert.Equal(t, fromChan, fromSeq)
+}
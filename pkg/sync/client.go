@@ -0,0 +1,86 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+)
+
+// Client talks to a sync server on behalf of one learner.
+type Client struct {
+	BaseURL string       // e.g. "https://sync.example.com"
+	Learner string       // the learner's name on the server
+	Token   string       // bearer token, if the server requires one
+	HTTP    *http.Client // http.DefaultClient if nil
+}
+
+// Pull returns the learner's progress on the server.
+func (c *Client) Pull(ctx context.Context) (*progress.Store, error) {
+	return c.do(ctx, http.MethodGet, nil)
+}
+
+// Push merges local into the learner's progress on the server and returns
+// the merged store.
+func (c *Client) Push(ctx context.Context, local *progress.Store) (*progress.Store, error) {
+	body, err := json.Marshal(local)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, http.MethodPost, body)
+}
+
+// Sync pushes local to the server and merges the result back into local,
+// leaving both sides with the same progress. The caller saves local.
+func (c *Client) Sync(ctx context.Context, local *progress.Store) error {
+	merged, err := c.Push(ctx, local)
+	if err != nil {
+		return err
+	}
+	Merge(local, merged)
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method string, body []byte) (*progress.Store, error) {
+	if !validLearner.MatchString(c.Learner) {
+		return nil, fmt.Errorf("invalid learner name %q: use letters, digits, '.', '_' and '-'", c.Learner)
+	}
+	u := strings.TrimSuffix(c.BaseURL, "/") + APIPath + url.PathEscape(c.Learner)
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	hc := c.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("sync server: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	var s progress.Store
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return nil, fmt.Errorf("sync server: bad response: %w", err)
+	}
+	if s.Exercises == nil {
+		s.Exercises = make(map[string]*progress.Exercise)
+	}
+	return &s, nil
+}
@@ -0,0 +1,121 @@
+// Package sync copies a learner's progress store between machines through
+// a small REST server, so work done on a laptop shows up on a desktop.
+//
+// Progress on two machines is combined with Merge, which never loses work:
+// an exercise completed on either machine is completed, counters keep the
+// higher value, timestamps keep the earliest start and the latest
+// activity, and a day practiced on either machine counts toward streaks.
+// Merging is idempotent and order-independent, so syncing twice or from
+// several machines in any order gives the same result.
+//
+// The API, served by NewServer and used by Client:
+//
+//	GET  /v1/progress/{learner}  the learner's store on the server
+//	POST /v1/progress/{learner}  merge the body into it and return the result
+package sync
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+)
+
+// Merge merges the progress in src into dst.
+func Merge(dst, src *progress.Store) {
+	if dst.Exercises == nil {
+		dst.Exercises = make(map[string]*progress.Exercise)
+	}
+	for id, e := range src.Exercises {
+		if d, ok := dst.Exercises[id]; ok {
+			mergeExercise(d, *e)
+		} else {
+			e := *e
+			e.Mistakes = maps.Clone(e.Mistakes)
+			dst.Exercises[id] = &e
+		}
+	}
+
+	for module, r := range src.Runs {
+		if d, ok := dst.LastRun(module); !ok || r.At.After(d.At) {
+			dst.RecordRun(module, *r)
+		}
+	}
+
+	for module, q := range src.Quizzes {
+		if dst.Quizzes == nil {
+			dst.Quizzes = make(map[string]*progress.Quiz)
+		}
+		d, ok := dst.Quizzes[module]
+		if !ok {
+			q := *q
+			dst.Quizzes[module] = &q
+			continue
+		}
+		d.Attempts = max(d.Attempts, q.Attempts)
+		if q.Last.At.After(d.Last.At) {
+			d.Last = q.Last
+		}
+		if p, dp := q.Best.Percent(), d.Best.Percent(); p > dp || p == dp && earlier(q.Best.At, d.Best.At) {
+			d.Best = q.Best
+		}
+	}
+
+	for id, at := range src.Achievements {
+		if d, ok := dst.Unlocked(id); !ok || at.Before(d) {
+			delete(dst.Achievements, id)
+			dst.Unlock(id, at)
+		}
+	}
+
+	for _, day := range src.Days {
+		if !slices.Contains(dst.Days, day) {
+			dst.Days = append(dst.Days, day)
+		}
+	}
+	slices.Sort(dst.Days)
+
+	// Exercise test data must not change from one machine to the next, so
+	// both sides settle on the same seed: the lower one.
+	if dst.Seed == 0 || src.Seed != 0 && src.Seed < dst.Seed {
+		dst.Seed = src.Seed
+	}
+}
+
+func mergeExercise(d *progress.Exercise, s progress.Exercise) {
+	if s.LastAttempt.After(d.LastAttempt) {
+		d.Passed = s.Passed // the latest run decides
+		d.LastAttempt = s.LastAttempt
+	} else if s.LastAttempt.Equal(d.LastAttempt) && s.Passed {
+		d.Passed = true
+	}
+	if earlier(s.FirstAttempt, d.FirstAttempt) {
+		d.FirstAttempt = s.FirstAttempt
+	}
+	if earlier(s.CompletedAt, d.CompletedAt) {
+		d.CompletedAt = s.CompletedAt
+	}
+	d.Attempts = max(d.Attempts, s.Attempts)
+	d.HintsUsed = max(d.HintsUsed, s.HintsUsed)
+	d.FailedRuns = max(d.FailedRuns, s.FailedRuns)
+	d.Sessions = max(d.Sessions, s.Sessions)
+	d.ActiveTime = max(d.ActiveTime, s.ActiveTime)
+	for kind, m := range s.Mistakes {
+		if d.Mistakes == nil {
+			d.Mistakes = make(map[string]progress.Mistake)
+		}
+		dm := d.Mistakes[kind]
+		dm.Count = max(dm.Count, m.Count)
+		if m.Last.After(dm.Last) {
+			dm.Last = m.Last
+		}
+		d.Mistakes[kind] = dm
+	}
+}
+
+// earlier reports whether a is set and before b, treating an unset b as
+// later than anything.
+func earlier(a, b time.Time) bool {
+	return !a.IsZero() && (b.IsZero() || a.Before(b))
+}
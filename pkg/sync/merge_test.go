@@ -0,0 +1,109 @@
+package sync
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var t0 = time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// laptop and desktop return stores for the same learner who worked on
+// different exercises, and on one in common, on two machines.
+func laptop() *progress.Store {
+	s := &progress.Store{Seed: 42}
+	s.RecordAttempt("01-basics/ex1", false, t0)
+	s.RecordAttempt("01-basics/ex1", true, t0.Add(10*time.Minute))
+	s.RecordAttempt("01-basics/shared", false, t0.Add(time.Hour))
+	s.RecordMistake("01-basics/shared", "nil-map", t0.Add(time.Hour))
+	s.RecordRun("01-basics", progress.Run{At: t0.Add(time.Hour), Failed: []string{"01-basics/shared"}})
+	s.RecordQuiz("01-basics", progress.Score{Correct: 4, Total: 5, At: t0})
+	s.Unlock("first-green", t0.Add(10*time.Minute))
+	return s
+}
+
+func desktop() *progress.Store {
+	s := &progress.Store{Seed: 7}
+	s.RecordAttempt("01-basics/shared", false, t0.Add(-time.Hour))
+	s.RecordMistake("01-basics/shared", "nil-map", t0.Add(-time.Hour))
+	s.RecordMistake("01-basics/shared", "nil-map", t0.Add(-time.Hour))
+	s.RecordAttempt("01-basics/shared", true, t0.Add(2*time.Hour))
+	s.RecordHint("01-basics/shared")
+	s.RecordAttempt("02-types/ex1", false, t0.Add(3*time.Hour))
+	s.RecordRun("01-basics", progress.Run{At: t0.Add(2 * time.Hour), Passed: []string{"01-basics/shared"}})
+	s.RecordQuiz("01-basics", progress.Score{Correct: 2, Total: 5, At: t0.Add(time.Hour)})
+	s.RecordQuiz("01-basics", progress.Score{Correct: 3, Total: 5, At: t0.Add(2 * time.Hour)})
+	s.Unlock("first-green", t0.Add(2*time.Hour))
+	s.RecordDay(t0.AddDate(0, 0, -1))
+	return s
+}
+
+func TestMerge(t *testing.T) {
+	s := laptop()
+	Merge(s, desktop())
+
+	assert.Equal(t, []string{"01-basics/ex1", "01-basics/shared", "02-types/ex1"}, s.IDs(), "union of exercises")
+	assert.True(t, s.Get("01-basics/ex1").Passed)
+
+	shared := s.Get("01-basics/shared")
+	assert.True(t, shared.Passed, "the latest run passed")
+	assert.Equal(t, t0.Add(-time.Hour), shared.FirstAttempt, "earliest start")
+	assert.Equal(t, t0.Add(2*time.Hour), shared.LastAttempt)
+	assert.Equal(t, t0.Add(2*time.Hour), shared.CompletedAt, "completed on the desktop")
+	assert.Equal(t, 2, shared.Attempts)
+	assert.Equal(t, 1, shared.HintsUsed)
+	assert.Equal(t, map[string]progress.Mistake{"nil-map": {Count: 2, Last: t0.Add(time.Hour)}}, shared.Mistakes,
+		"the higher count and the latest time")
+
+	run, ok := s.LastRun("01-basics")
+	require.True(t, ok)
+	assert.Equal(t, t0.Add(2*time.Hour), run.At, "latest run")
+
+	q := s.Quiz("01-basics")
+	assert.Equal(t, 2, q.Attempts)
+	assert.Equal(t, 60, q.Last.Percent(), "latest result")
+	assert.Equal(t, 80, q.Best.Percent(), "best result")
+
+	at, ok := s.Unlocked("first-green")
+	require.True(t, ok)
+	assert.Equal(t, t0.Add(10*time.Minute), at, "earliest unlock")
+	assert.Equal(t, int64(7), s.Seed)
+	assert.Equal(t, []string{"2026-02-28", "2026-03-01"}, s.Days, "days practiced on either machine")
+}
+
+// stateOf renders a store for comparison.
+func stateOf(t *testing.T, s *progress.Store) string {
+	t.Helper()
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestMergeIsOrderIndependentAndIdempotent(t *testing.T) {
+	ab := laptop()
+	Merge(ab, desktop())
+	ba := desktop()
+	Merge(ba, laptop())
+	assert.Equal(t, stateOf(t, ab), stateOf(t, ba))
+
+	again := stateOf(t, ab)
+	Merge(ab, desktop())
+	Merge(ab, ab)
+	assert.Equal(t, again, stateOf(t, ab))
+}
+
+func TestMergeIntoEmpty(t *testing.T) {
+	var s progress.Store
+	src := laptop()
+	Merge(&s, src)
+	assert.Equal(t, stateOf(t, src), stateOf(t, &s))
+
+	s.Exercises["01-basics/ex1"].Attempts = 99
+	assert.Equal(t, 2, src.Get("01-basics/ex1").Attempts, "exercises are copied, not shared")
+	s.RecordMistake("01-basics/shared", "nil-map", t0)
+	assert.Equal(t, 1, src.Get("01-basics/shared").Mistakes["nil-map"].Count)
+}
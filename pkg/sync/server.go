@@ -0,0 +1,85 @@
+package sync
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+	gosync "sync"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+)
+
+// APIPath is the prefix of the progress API.
+const APIPath = "/v1/progress/"
+
+// maxBody bounds an uploaded store.
+const maxBody = 4 << 20
+
+// validLearner keeps learner names safe to use as file names.
+var validLearner = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// Server keeps one progress store per learner in a directory.
+type Server struct {
+	dir   string
+	token string // required bearer token; none if empty
+
+	mu gosync.Mutex // serializes read-merge-save
+}
+
+// NewServer returns a server storing progress in dir. If token is not
+// empty, requests must send it as a bearer token.
+func NewServer(dir, token string) *Server {
+	return &Server{dir: dir, token: token}
+}
+
+// authorized reports whether r sends the server's token, comparing in
+// constant time so that response times do not give it away.
+func (s *Server) authorized(r *http.Request) bool {
+	want := "Bearer " + s.token
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(want)) == 1
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	learner, ok := strings.CutPrefix(r.URL.Path, APIPath)
+	if !ok || !validLearner.MatchString(learner) {
+		http.NotFound(w, r)
+		return
+	}
+	if s.token != "" && !s.authorized(r) {
+		http.Error(w, "wrong or missing sync token", http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, err := progress.Open(filepath.Join(s.dir, learner+".json"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var incoming progress.Store
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxBody)).Decode(&incoming); err != nil {
+			http.Error(w, fmt.Sprintf("bad progress store: %v", err), http.StatusBadRequest)
+			return
+		}
+		Merge(stored, &incoming)
+		if err := stored.Save(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stored)
+}
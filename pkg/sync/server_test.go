@@ -0,0 +1,84 @@
+package sync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientServer(t *testing.T) {
+	dir := t.TempDir()
+	srv := httptest.NewServer(NewServer(dir, "s3cret"))
+	defer srv.Close()
+	client := func(learner string) *Client {
+		return &Client{BaseURL: srv.URL, Learner: learner, Token: "s3cret", HTTP: srv.Client()}
+	}
+	ctx := context.Background()
+
+	empty, err := client("ada").Pull(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, empty.Exercises, "nothing pushed yet")
+
+	// The laptop syncs first, then the desktop, then the laptop again.
+	lap, desk := laptop(), desktop()
+	require.NoError(t, client("ada").Sync(ctx, lap))
+	require.NoError(t, client("ada").Sync(ctx, desk))
+	require.NoError(t, client("ada").Sync(ctx, lap))
+	assert.Equal(t, stateOf(t, lap), stateOf(t, desk), "both machines converge")
+	assert.True(t, lap.Get("01-basics/shared").Passed)
+
+	onServer, err := progress.Open(filepath.Join(dir, "ada.json"))
+	require.NoError(t, err)
+	assert.Equal(t, lap.IDs(), onServer.IDs(), "the server saved the merge")
+
+	other, err := client("bo").Pull(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, other.Exercises, "learners are kept apart")
+}
+
+func TestServerRejects(t *testing.T) {
+	srv := httptest.NewServer(NewServer(t.TempDir(), "s3cret"))
+	defer srv.Close()
+	ctx := context.Background()
+
+	_, err := (&Client{BaseURL: srv.URL, Learner: "ada", HTTP: srv.Client()}).Pull(ctx)
+	assert.ErrorContains(t, err, "401 Unauthorized: wrong or missing sync token")
+	for _, token := range []string{"s3cre", "s3creT", "s3cret2"} {
+		_, err = (&Client{BaseURL: srv.URL, Learner: "ada", Token: token, HTTP: srv.Client()}).Pull(ctx)
+		assert.ErrorContains(t, err, "401 Unauthorized", token)
+	}
+
+	_, err = (&Client{BaseURL: srv.URL, Learner: "../etc/passwd", Token: "s3cret"}).Pull(ctx)
+	assert.ErrorContains(t, err, "invalid learner name")
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+APIPath+"..%2Fada", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	req, err = http.NewRequest(http.MethodPost, srv.URL+APIPath+"ada", strings.NewReader("not json"))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	resp, err = srv.Client().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	req, err = http.NewRequest(http.MethodDelete, srv.URL+APIPath+"ada", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	resp, err = srv.Client().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
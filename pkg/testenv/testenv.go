@@ -0,0 +1,79 @@
+// Package testenv holds the course's convention for integration tests:
+// exercise tests that need more than Go and the machine's CPU, such as the
+// network, a database like SQLite, or another program like the go command
+// or a C compiler. Classroom and office machines often lack one or the
+// other, so each such test starts by saying what it needs:
+//
+//	func TestBuildsEverywhere(t *testing.T) {
+//		testenv.MustHaveGoBuild(t)
+//		...
+//
+// and is skipped, with the reason, under go test -short, or where the
+// machine lacks it. "learngo check" runs the unit tests alone, with
+// -short, and "learngo check -integration" runs the full suite, with
+// -tags integration as well. That tag is for test files that do not even
+// compile everywhere, such as one importing a SQLite driver: they carry
+// //go:build integration and are named exerciseN_name_integration_test.go,
+// so that the grader knows which exercise they test.
+package testenv
+
+import (
+	"context"
+	"net"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Integration skips the test under go test -short, saying why it is an
+// integration test, e.g. "runs go build".
+func Integration(tb testing.TB, reason string) {
+	tb.Helper()
+	if testing.Short() {
+		tb.Skipf("integration test (%s); run learngo check -integration to run it", reason)
+	}
+}
+
+// MustHaveExec skips the test unless it may run other programs, and the
+// named one is installed.
+func MustHaveExec(tb testing.TB, name string) {
+	tb.Helper()
+	Integration(tb, "runs "+name)
+	if _, err := exec.LookPath(name); err != nil {
+		tb.Skipf("%s is not installed", name)
+	}
+}
+
+// MustHaveGoBuild skips the test unless it may run the go command.
+func MustHaveGoBuild(tb testing.TB) {
+	tb.Helper()
+	MustHaveExec(tb, "go")
+}
+
+// MustHaveCGO skips the test unless it may run the go command, and the C
+// compiler that cgo uses is installed.
+func MustHaveCGO(tb testing.TB) {
+	tb.Helper()
+	MustHaveGoBuild(tb)
+	out, err := exec.Command("go", "env", "CGO_ENABLED", "CC").Output()
+	fields := strings.Fields(string(out))
+	if err != nil || len(fields) < 2 || fields[0] != "1" {
+		tb.Skip("cgo is disabled")
+	}
+	if _, err := exec.LookPath(fields[1]); err != nil {
+		tb.Skipf("the C compiler %s is not installed", fields[1])
+	}
+}
+
+// MustHaveNetwork skips the test unless it may use the network, and can
+// resolve a public host name.
+func MustHaveNetwork(tb testing.TB) {
+	tb.Helper()
+	Integration(tb, "uses the network")
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if _, err := net.DefaultResolver.LookupHost(ctx, "go.dev"); err != nil {
+		tb.Skipf("no network: %v", err)
+	}
+}
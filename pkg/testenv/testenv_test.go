@@ -0,0 +1,51 @@
+package testenv
+
+import (
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder is a testing.TB that records a skip instead of skipping.
+type recorder struct {
+	testing.TB
+	skipped string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Skip(args ...any) { r.skipped = fmt.Sprint(args...) }
+
+func (r *recorder) Skipf(format string, args ...any) {
+	if r.skipped == "" {
+		r.skipped = fmt.Sprintf(format, args...)
+	}
+}
+
+func TestIntegration(t *testing.T) {
+	r := &recorder{TB: t}
+	Integration(r, "runs go build")
+	if testing.Short() {
+		assert.Equal(t, "integration test (runs go build); run learngo check -integration to run it", r.skipped)
+	} else {
+		assert.Empty(t, r.skipped)
+	}
+}
+
+func TestMustHaveExec(t *testing.T) {
+	r := &recorder{TB: t}
+	MustHaveExec(r, "learngo-no-such-program")
+	assert.NotEmpty(t, r.skipped)
+	if !testing.Short() {
+		assert.Equal(t, "learngo-no-such-program is not installed", r.skipped)
+	}
+}
+
+func TestMustHaveGoBuild(t *testing.T) {
+	r := &recorder{TB: t}
+	MustHaveGoBuild(r)
+	_, err := exec.LookPath("go")
+	assert.Equal(t, testing.Short() || err != nil, r.skipped != "", r.skipped)
+}
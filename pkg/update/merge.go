@@ -0,0 +1,133 @@
+package update
+
+import "bytes"
+
+// Merge3 merges two edits of base line by line, the way diff3 does: a
+// region changed on one side only takes that side's lines, a region
+// changed the same way on both sides is taken once, and a region changed
+// differently on both sides becomes a conflict between markers
+//
+//	<<<<<<< oursLabel
+//	...
+//	=======
+//	...
+//	>>>>>>> theirsLabel
+//
+// It returns the merged text and the number of conflicts.
+func Merge3(base, ours, theirs []byte, oursLabel, theirsLabel string) (merged []byte, conflicts int) {
+	o, a, b := splitLines(base), splitLines(ours), splitLines(theirs)
+	ma, mb := matchLines(o, a), matchLines(o, b)
+
+	var out bytes.Buffer
+	resolve := func(o, a, b [][]byte) {
+		switch {
+		case equalLines(a, o):
+			writeLines(&out, b)
+		case equalLines(b, o), equalLines(a, b):
+			writeLines(&out, a)
+		default:
+			conflicts++
+			out.WriteString("<<<<<<< " + oursLabel + "\n")
+			writeSection(&out, a)
+			out.WriteString("=======\n")
+			writeSection(&out, b)
+			out.WriteString(">>>>>>> " + theirsLabel + "\n")
+		}
+	}
+
+	i, j, k := 0, 0, 0
+	for {
+		// Find the next base line both sides kept: the end of a chunk.
+		next := i
+		for next < len(o) && (ma[next] < 0 || mb[next] < 0) {
+			next++
+		}
+		if next == len(o) {
+			resolve(o[i:], a[j:], b[k:])
+			return out.Bytes(), conflicts
+		}
+		if next > i || ma[next] > j || mb[next] > k {
+			resolve(o[i:next], a[j:ma[next]], b[k:mb[next]])
+		}
+		out.Write(o[next])
+		i, j, k = next+1, ma[next]+1, mb[next]+1
+	}
+}
+
+// splitLines splits text after each newline, keeping the newlines so that
+// a missing one at the end survives the merge.
+func splitLines(text []byte) [][]byte {
+	var lines [][]byte
+	for len(text) > 0 {
+		n := bytes.IndexByte(text, '\n') + 1
+		if n == 0 {
+			n = len(text)
+		}
+		lines = append(lines, text[:n])
+		text = text[n:]
+	}
+	return lines
+}
+
+// matchLines pairs the lines of a longest common subsequence of x and y:
+// m[i] is the index in y of the line matched with x[i], or -1.
+func matchLines(x, y [][]byte) []int {
+	m := make([]int, len(x))
+	for i := range m {
+		m[i] = -1
+	}
+	// lcs[i][j] is the LCS length of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if bytes.Equal(x[i], y[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	for i, j := 0, 0; i < len(x) && j < len(y); {
+		switch {
+		case bytes.Equal(x[i], y[j]):
+			m[i] = j
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return m
+}
+
+func equalLines(x, y [][]byte) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if !bytes.Equal(x[i], y[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func writeLines(w *bytes.Buffer, lines [][]byte) {
+	for _, l := range lines {
+		w.Write(l)
+	}
+}
+
+// writeSection writes one side of a conflict, ending it with a newline so
+// the marker after it starts on a line of its own.
+func writeSection(w *bytes.Buffer, lines [][]byte) {
+	writeLines(w, lines)
+	if n := len(lines); n > 0 && !bytes.HasSuffix(lines[n-1], []byte("\n")) {
+		w.WriteByte('\n')
+	}
+}
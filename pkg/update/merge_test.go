@@ -0,0 +1,75 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge3(t *testing.T) {
+	const base = "package p\n\n// Sum adds up nums.\nfunc Sum(nums []int) int {\n\treturn 0\n}\n"
+	tests := []struct {
+		name          string
+		ours, theirs  string
+		want          string
+		wantConflicts int
+	}{
+		{
+			name:   "only ours",
+			ours:   "package p\n\n// Sum adds up nums.\nfunc Sum(nums []int) int {\n\ttotal := 0\n\tfor _, n := range nums {\n\t\ttotal += n\n\t}\n\treturn total\n}\n",
+			theirs: base,
+			want:   "package p\n\n// Sum adds up nums.\nfunc Sum(nums []int) int {\n\ttotal := 0\n\tfor _, n := range nums {\n\t\ttotal += n\n\t}\n\treturn total\n}\n",
+		},
+		{
+			name:   "only theirs",
+			ours:   base,
+			theirs: "package p\n\n// Sum returns the sum of nums.\nfunc Sum(nums []int) int {\n\treturn 0\n}\n",
+			want:   "package p\n\n// Sum returns the sum of nums.\nfunc Sum(nums []int) int {\n\treturn 0\n}\n",
+		},
+		{
+			name:   "both, apart",
+			ours:   "package p\n\n// Sum adds up nums.\nfunc Sum(nums []int) int {\n\treturn len(nums)\n}\n",
+			theirs: "package p\n\n// Sum returns the sum of nums.\nfunc Sum(nums []int) int {\n\treturn 0\n}\n",
+			want:   "package p\n\n// Sum returns the sum of nums.\nfunc Sum(nums []int) int {\n\treturn len(nums)\n}\n",
+		},
+		{
+			name:   "both, the same",
+			ours:   "package p\n\n// Sum returns the sum of nums.\nfunc Sum(nums []int) int {\n\treturn 0\n}\n",
+			theirs: "package p\n\n// Sum returns the sum of nums.\nfunc Sum(nums []int) int {\n\treturn 0\n}\n",
+			want:   "package p\n\n// Sum returns the sum of nums.\nfunc Sum(nums []int) int {\n\treturn 0\n}\n",
+		},
+		{
+			name:          "both, the same lines",
+			ours:          "package p\n\n// Sum adds up nums.\nfunc Sum(nums []int) int {\n\treturn 1\n}\n",
+			theirs:        "package p\n\n// Sum adds up nums.\nfunc Sum(nums []int) int {\n\treturn 2\n}\n",
+			want:          "package p\n\n// Sum adds up nums.\nfunc Sum(nums []int) int {\n<<<<<<< ours\n\treturn 1\n=======\n\treturn 2\n>>>>>>> theirs\n}\n",
+			wantConflicts: 1,
+		},
+		{
+			name:   "appended on both ends",
+			ours:   base + "\nfunc Mean() {}\n",
+			theirs: "// Package p sums.\n" + base,
+			want:   "// Package p sums.\n" + base + "\nfunc Mean() {}\n",
+		},
+		{
+			name:          "no final newline",
+			ours:          "package p\n\n// Sum adds up nums.\nfunc Sum(nums []int) int {\n\treturn 0\n}",
+			theirs:        "package p\n\n// Sum adds up nums.\nfunc Sum(nums []int) int {\n\treturn 0\n}\n// end",
+			want:          "package p\n\n// Sum adds up nums.\nfunc Sum(nums []int) int {\n\treturn 0\n<<<<<<< ours\n}\n=======\n}\n// end\n>>>>>>> theirs\n",
+			wantConflicts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts := Merge3([]byte(base), []byte(tt.ours), []byte(tt.theirs), "ours", "theirs")
+			assert.Equal(t, tt.want, string(got))
+			assert.Equal(t, tt.wantConflicts, conflicts)
+		})
+	}
+}
+
+func TestMerge3NoBase(t *testing.T) {
+	got, conflicts := Merge3(nil, []byte("a\n"), []byte("b\n"), "ours", "theirs")
+	assert.Equal(t, "<<<<<<< ours\na\n=======\nb\n>>>>>>> theirs\n", string(got))
+	assert.Equal(t, 1, conflicts)
+}
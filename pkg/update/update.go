@@ -0,0 +1,214 @@
+// Package update brings a course workspace created by "learngo init" up to
+// date with the course's latest release, without losing the learner's
+// work.
+//
+// A release endpoint is a tree of static files, as written by Publish:
+//
+//	<server>/<channel>.json     the channel's current Release
+//	<server>/<version>/<path>   every file of every published version
+//
+// A workspace remembers the release it was last brought to, and keeps a
+// pristine copy of its files, under StateDir. Updating replaces the files
+// the learner has not touched and merges the new release into the ones
+// they have, so a fixed typo in an exercise's doc comment arrives without
+// wiping out a half-finished solution below it.
+package update
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Channels are the release channels a workspace can follow: stable gets
+// releases once they have been tried, beta gets them first.
+var Channels = []string{"stable", "beta"}
+
+// Release describes one published version of the course content.
+type Release struct {
+	Version string `json:"version"`
+	Channel string `json:"channel"`
+	// Files maps each slash-separated path in the release to the hex
+	// SHA-256 of its contents.
+	Files map[string]string `json:"files"`
+}
+
+// validVersion keeps versions usable as a single URL path segment and
+// directory name.
+var validVersion = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]*$`)
+
+// Validate reports whether r is well-formed.
+func (r *Release) Validate() error {
+	if !validVersion.MatchString(r.Version) {
+		return fmt.Errorf("invalid version %q", r.Version)
+	}
+	if err := validChannel(r.Channel); err != nil {
+		return err
+	}
+	for p, sum := range r.Files {
+		if !validPath(p) {
+			return fmt.Errorf("invalid path %q", p)
+		}
+		if b, err := hex.DecodeString(sum); err != nil || len(b) != sha256.Size {
+			return fmt.Errorf("%s: invalid checksum %q", p, sum)
+		}
+	}
+	return nil
+}
+
+func validChannel(channel string) error {
+	for _, c := range Channels {
+		if channel == c {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown channel %q: use %s", channel, strings.Join(Channels, " or "))
+}
+
+// validPath reports whether p stays inside the workspace, and out of the
+// directory where the workspace keeps its own state.
+func validPath(p string) bool {
+	return fs.ValidPath(p) && p != "." && !strings.HasPrefix(p, ".learngo/")
+}
+
+// Checksum returns the hex SHA-256 of data, as used in Release.Files.
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Client fetches releases from a release endpoint.
+type Client struct {
+	BaseURL string       // e.g. "https://example.com/learngo"
+	HTTP    *http.Client // http.DefaultClient if nil
+}
+
+// Latest returns the channel's current release.
+func (c *Client) Latest(ctx context.Context, channel string) (*Release, error) {
+	if err := validChannel(channel); err != nil {
+		return nil, err
+	}
+	data, err := c.get(ctx, channel+".json")
+	if err != nil {
+		return nil, err
+	}
+	var r Release
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("release server: bad %s.json: %w", channel, err)
+	}
+	if err := r.Validate(); err != nil {
+		return nil, fmt.Errorf("release server: %s.json: %w", channel, err)
+	}
+	return &r, nil
+}
+
+// Download fetches every file of r and checks it against its checksum. It
+// returns an error rather than a partial release if any file is missing or
+// does not match.
+func (c *Client) Download(ctx context.Context, r *Release) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(r.Files))
+	for p, sum := range r.Files {
+		data, err := c.get(ctx, r.Version+"/"+p)
+		if err != nil {
+			return nil, err
+		}
+		if got := Checksum(data); got != sum {
+			return nil, fmt.Errorf("release %s: %s: checksum mismatch: got %s, want %s", r.Version, p, got, sum)
+		}
+		files[p] = data
+	}
+	return files, nil
+}
+
+func (c *Client) get(ctx context.Context, p string) ([]byte, error) {
+	u := strings.TrimSuffix(c.BaseURL, "/") + "/" + (&url.URL{Path: p}).EscapedPath()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	hc := c.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("release server: %s: %s", p, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Files reads every regular file in fsys, keyed by slash-separated path.
+func Files(fsys fs.FS) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, p)
+		files[p] = data
+		return err
+	})
+	return files, err
+}
+
+// Publish writes files as version of the course to the release endpoint
+// tree at dir and makes it the channel's current release. Earlier versions
+// stay in place, for workspaces on the other channel.
+func Publish(dir, channel, version string, files map[string][]byte) (*Release, error) {
+	r := &Release{Version: version, Channel: channel, Files: make(map[string]string, len(files))}
+	for p, data := range files {
+		r.Files[p] = Checksum(data)
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	for p, data := range files {
+		target := filepath.Join(dir, version, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(target, data, 0o644); err != nil {
+			return nil, err
+		}
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return r, writeAtomic(filepath.Join(dir, channel+".json"), append(data, '\n'))
+}
+
+// writeAtomic replaces the file at name with data through a temporary file,
+// so readers never see half of it.
+func writeAtomic(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
@@ -0,0 +1,160 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var v1 = map[string][]byte{
+	"go.mod":                           []byte("module example.com/course\n"),
+	"modules/01-basics/README.md":      []byte("# Basics\n"),
+	"modules/01-basics/exercises/a.go": []byte("package exercises\n\n// A returns 1.\nfunc A() int {\n\treturn 0\n}\n"),
+	"modules/01-basics/exercises/b.go": []byte("package exercises\n\nfunc B() {}\n"),
+	"modules/01-basics/exercises/c.go": []byte("package exercises\n\nfunc C() {}\n"),
+	"modules/01-basics/exercises/d.go": []byte("package exercises\n\nfunc D() {}\n"),
+}
+
+// releaseServer publishes v1 on both channels and v2 on beta, and serves
+// them.
+func releaseServer(t *testing.T, v2 map[string][]byte) (*Client, string) {
+	dir := t.TempDir()
+	for _, ch := range Channels {
+		_, err := Publish(dir, ch, "1.0.0", v1)
+		require.NoError(t, err)
+	}
+	_, err := Publish(dir, "beta", "1.1.0-beta", v2)
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	t.Cleanup(srv.Close)
+	return &Client{BaseURL: srv.URL}, dir
+}
+
+// workspace creates a workspace from v1.
+func workspace(t *testing.T) *Workspace {
+	root := t.TempDir()
+	for p, data := range v1 {
+		writeFile(t, root, p, string(data))
+	}
+	w, err := Create(root, State{Version: "1.0.0"}, v1)
+	require.NoError(t, err)
+	return w
+}
+
+func writeFile(t *testing.T, root, p, data string) {
+	t.Helper()
+	name := filepath.Join(root, filepath.FromSlash(p))
+	require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+	require.NoError(t, os.WriteFile(name, []byte(data), 0o644))
+}
+
+func readFile(t *testing.T, root, p string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(p)))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestUpdate(t *testing.T) {
+	v2 := map[string][]byte{
+		"go.mod":                      v1["go.mod"],
+		"modules/01-basics/README.md": []byte("# Go Basics\n"),
+		// The doc comment is fixed; the learner has filled in the body.
+		"modules/01-basics/exercises/a.go": []byte("package exercises\n\n// A returns 1, always.\nfunc A() int {\n\treturn 0\n}\n"),
+		// Both change the same line.
+		"modules/01-basics/exercises/b.go": []byte("package exercises\n\nfunc B() int { return 0 }\n"),
+		// c.go and d.go are dropped; the learner changed c.go.
+		"modules/02-types/README.md": []byte("# Types\n"),
+	}
+	client, _ := releaseServer(t, v2)
+	ctx := context.Background()
+
+	stable, err := client.Latest(ctx, "stable")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", stable.Version)
+	r, err := client.Latest(ctx, "beta")
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0-beta", r.Version)
+	files, err := client.Download(ctx, r)
+	require.NoError(t, err)
+	assert.Equal(t, v2, files)
+
+	w := workspace(t)
+	writeFile(t, w.Root, "modules/01-basics/exercises/a.go", "package exercises\n\n// A returns 1.\nfunc A() int {\n\treturn 1\n}\n")
+	writeFile(t, w.Root, "modules/01-basics/exercises/b.go", "package exercises\n\nfunc B() string { return \"\" }\n")
+	writeFile(t, w.Root, "modules/01-basics/exercises/c.go", "package exercises\n\nfunc C() int { return 3 }\n")
+
+	changes, err := w.Apply(r, files)
+	require.NoError(t, err)
+	assert.Equal(t, []Change{
+		{"modules/01-basics/README.md", Updated},
+		{"modules/01-basics/exercises/a.go", Merged},
+		{"modules/01-basics/exercises/b.go", Conflicted},
+		{"modules/01-basics/exercises/c.go", Kept},
+		{"modules/01-basics/exercises/d.go", Removed},
+		{"modules/02-types/README.md", Added},
+	}, changes)
+
+	assert.Equal(t, "# Go Basics\n", readFile(t, w.Root, "modules/01-basics/README.md"))
+	assert.Equal(t, "package exercises\n\n// A returns 1, always.\nfunc A() int {\n\treturn 1\n}\n", readFile(t, w.Root, "modules/01-basics/exercises/a.go"))
+	assert.Equal(t, "package exercises\n\n<<<<<<< your version\nfunc B() string { return \"\" }\n=======\nfunc B() int { return 0 }\n>>>>>>> release 1.1.0-beta\n",
+		readFile(t, w.Root, "modules/01-basics/exercises/b.go"))
+	assert.Equal(t, "package exercises\n\nfunc C() int { return 3 }\n", readFile(t, w.Root, "modules/01-basics/exercises/c.go"))
+	assert.NoFileExists(t, filepath.Join(w.Root, "modules/01-basics/exercises/d.go"))
+	assert.Equal(t, "# Types\n", readFile(t, w.Root, "modules/02-types/README.md"))
+
+	reopened, err := Open(w.Root)
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0-beta", reopened.State.Version)
+	assert.Equal(t, "beta", reopened.State.Channel)
+	assert.Len(t, reopened.State.Files, len(v2))
+
+	// Applying the same release again changes nothing; c.go is the
+	// learner's own file now.
+	changes, err = reopened.Apply(r, files)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.FileExists(t, filepath.Join(w.Root, "modules/01-basics/exercises/c.go"))
+}
+
+func TestUpdateKeepsDeletedFiles(t *testing.T) {
+	w := workspace(t)
+	require.NoError(t, os.Remove(filepath.Join(w.Root, "modules/01-basics/README.md")))
+	v2 := map[string][]byte{"modules/01-basics/README.md": []byte("# Go Basics\n")}
+	changes, err := w.Apply(&Release{Version: "2", Channel: "stable"}, v2)
+	require.NoError(t, err)
+	assert.Equal(t, []Change{{"go.mod", Removed}, {"modules/01-basics/exercises/a.go", Removed},
+		{"modules/01-basics/exercises/b.go", Removed}, {"modules/01-basics/exercises/c.go", Removed},
+		{"modules/01-basics/exercises/d.go", Removed}}, changes)
+	assert.NoFileExists(t, filepath.Join(w.Root, "modules/01-basics/README.md"))
+}
+
+func TestDownloadChecksumMismatch(t *testing.T) {
+	client, dir := releaseServer(t, v1)
+	writeFile(t, dir, "1.1.0-beta/go.mod", "module example.com/evil\n")
+	r, err := client.Latest(context.Background(), "beta")
+	require.NoError(t, err)
+	_, err = client.Download(context.Background(), r)
+	assert.ErrorContains(t, err, "go.mod: checksum mismatch")
+}
+
+func TestLatestErrors(t *testing.T) {
+	client, dir := releaseServer(t, v1)
+	_, err := client.Latest(context.Background(), "nightly")
+	assert.ErrorContains(t, err, `unknown channel "nightly"`)
+
+	writeFile(t, dir, "stable.json", `{"version": "2", "channel": "stable", "files": {"../escape": "00"}}`)
+	_, err = client.Latest(context.Background(), "stable")
+	assert.ErrorContains(t, err, `invalid path "../escape"`)
+}
+
+func TestOpenNotWorkspace(t *testing.T) {
+	_, err := Open(t.TempDir())
+	assert.ErrorIs(t, err, ErrNotWorkspace)
+}
@@ -0,0 +1,255 @@
+package update
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// StateDir is where a workspace keeps track of its release, relative to
+// the course root: State in release.json, and the release's files as they
+// were published under base/, to tell the learner's edits apart from
+// upstream changes.
+const StateDir = ".learngo/release"
+
+// ErrNotWorkspace is returned by Open for a course that was not created by
+// "learngo init", such as a clone of the repository.
+var ErrNotWorkspace = errors.New("not a course workspace created by learngo init")
+
+// State is what a workspace remembers about its release.
+type State struct {
+	Version string `json:"version"`
+	Channel string `json:"channel,omitempty"` // the channel to follow; "stable" if empty
+	Server  string `json:"server,omitempty"`  // the release endpoint last updated from
+	// Files maps each file of the release to its checksum.
+	Files map[string]string `json:"files"`
+}
+
+// Workspace is a course workspace that can be updated.
+type Workspace struct {
+	Root  string
+	State State
+}
+
+// Create starts tracking the workspace at root, whose files were created
+// from the release described by st. st.Files is filled in from files; the
+// files themselves must already be in place.
+func Create(root string, st State, files map[string][]byte) (*Workspace, error) {
+	w := &Workspace{Root: root, State: st}
+	if err := w.writeBase(files); err != nil {
+		return nil, err
+	}
+	return w, w.Save()
+}
+
+// Open returns the workspace at root. It returns an error wrapping
+// ErrNotWorkspace if root has no release state.
+func Open(root string) (*Workspace, error) {
+	data, err := os.ReadFile(filepath.Join(root, StateDir, "release.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", root, ErrNotWorkspace)
+	}
+	if err != nil {
+		return nil, err
+	}
+	w := &Workspace{Root: root}
+	if err := json.Unmarshal(data, &w.State); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filepath.Join(StateDir, "release.json"), err)
+	}
+	return w, nil
+}
+
+// Save writes the workspace's state.
+func (w *Workspace) Save() error {
+	data, err := json.MarshalIndent(w.State, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeAtomic(filepath.Join(w.Root, StateDir, "release.json"), append(data, '\n'))
+}
+
+// Action is what Apply did with one file.
+type Action int
+
+const (
+	Added      Action = iota // new in the release
+	Updated                  // replaced; the learner had not changed it
+	Merged                   // the release's changes merged into the learner's
+	Conflicted               // merged, with conflicts left for the learner
+	Removed                  // dropped from the course; the learner had not changed it
+	Kept                     // dropped from the course, but kept for the learner's changes
+)
+
+func (a Action) String() string {
+	switch a {
+	case Added:
+		return "added"
+	case Updated:
+		return "updated"
+	case Merged:
+		return "merged"
+	case Conflicted:
+		return "conflict"
+	case Removed:
+		return "removed"
+	case Kept:
+		return "kept"
+	}
+	return fmt.Sprintf("Action(%d)", int(a))
+}
+
+// Change is one file Apply touched.
+type Change struct {
+	Path   string
+	Action Action
+}
+
+// Apply brings the workspace to release r, whose files have been
+// downloaded. For each file it compares three versions: the one the
+// workspace was on (the base), the learner's, and r's.
+//
+//   - A file the learner has not changed is replaced, added, or removed.
+//   - A file only the learner changed is left alone.
+//   - A file both changed gets a three-way merge with Merge3; conflicts are
+//     written with markers for the learner to resolve.
+//   - A file the learner deleted stays deleted, and one dropped from the
+//     course that the learner changed stays in place.
+//
+// Apply returns the changes in path order and saves the new state.
+func (w *Workspace) Apply(r *Release, files map[string][]byte) ([]Change, error) {
+	paths := make(map[string]bool)
+	for p := range w.State.Files {
+		paths[p] = true
+	}
+	for p := range files {
+		paths[p] = true
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		if !validPath(p) {
+			return nil, fmt.Errorf("invalid path %q", p)
+		}
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	// Work out every change before writing anything, so an unreadable file
+	// does not leave the workspace half updated.
+	type write struct {
+		Change
+		data []byte
+	}
+	var writes []write
+	var kept []Change
+	for _, p := range sorted {
+		base, inBase, err := w.base(p)
+		if err != nil {
+			return nil, err
+		}
+		local, err := os.ReadFile(w.path(p))
+		exists := err == nil
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		next, inNext := files[p]
+
+		switch {
+		case !inNext:
+			if !inBase || !exists {
+				continue
+			}
+			if bytes.Equal(local, base) {
+				writes = append(writes, write{Change{p, Removed}, nil})
+			} else {
+				kept = append(kept, Change{p, Kept})
+			}
+		case !exists:
+			if !inBase {
+				writes = append(writes, write{Change{p, Added}, next})
+			}
+		case bytes.Equal(local, next), inBase && bytes.Equal(base, next):
+			// Nothing new for this file.
+		case inBase && bytes.Equal(local, base):
+			writes = append(writes, write{Change{p, Updated}, next})
+		default:
+			merged, conflicts := Merge3(base, local, next, "your version", "release "+r.Version)
+			action := Merged
+			if conflicts > 0 {
+				action = Conflicted
+			}
+			writes = append(writes, write{Change{p, action}, merged})
+		}
+	}
+
+	changes := make([]Change, 0, len(writes)+len(kept))
+	for _, wr := range writes {
+		target := w.path(wr.Path)
+		var err error
+		if wr.Action == Removed {
+			err = os.Remove(target)
+		} else if err = os.MkdirAll(filepath.Dir(target), 0o755); err == nil {
+			err = os.WriteFile(target, wr.data, 0o644)
+		}
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, wr.Change)
+	}
+	changes = append(changes, kept...)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+
+	if err := w.writeBase(files); err != nil {
+		return nil, err
+	}
+	w.State.Version = r.Version
+	w.State.Channel = r.Channel
+	return changes, w.Save()
+}
+
+func (w *Workspace) path(p string) string {
+	return filepath.Join(w.Root, filepath.FromSlash(p))
+}
+
+func (w *Workspace) basePath(p string) string {
+	return filepath.Join(w.Root, StateDir, "base", filepath.FromSlash(p))
+}
+
+// base returns the pristine copy of p from the workspace's release.
+func (w *Workspace) base(p string) (data []byte, ok bool, err error) {
+	if _, ok := w.State.Files[p]; !ok {
+		return nil, false, nil
+	}
+	data, err = os.ReadFile(w.basePath(p))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	return data, err == nil, err
+}
+
+// writeBase replaces the pristine copies with files and records their
+// checksums.
+func (w *Workspace) writeBase(files map[string][]byte) error {
+	if err := os.RemoveAll(filepath.Join(w.Root, StateDir, "base")); err != nil {
+		return err
+	}
+	w.State.Files = make(map[string]string, len(files))
+	for p, data := range files {
+		if !validPath(p) {
+			return fmt.Errorf("invalid path %q", p)
+		}
+		target := w.basePath(p)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, data, 0o644); err != nil {
+			return err
+		}
+		w.State.Files[p] = Checksum(data)
+	}
+	return nil
+}
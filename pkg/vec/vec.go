@@ -0,0 +1,71 @@
+// Package vec is 2D vector arithmetic on geometry.Point, read as the
+// vector from the origin to the point.
+//
+// The course moves, scales, and rotates shapes, and asks which side of an
+// edge a pixel lies on, in several modules; these functions are the one
+// place that arithmetic is written out. Results are floats, so compare
+// them with a tolerance, as Near does, rather than with ==.
+package vec
+
+import (
+	"math"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+)
+
+// Add returns p + q.
+func Add(p, q geometry.Point) geometry.Point {
+	return geometry.Point{X: p.X + q.X, Y: p.Y + q.Y}
+}
+
+// Sub returns p - q, the vector from q to p.
+func Sub(p, q geometry.Point) geometry.Point {
+	return geometry.Point{X: p.X - q.X, Y: p.Y - q.Y}
+}
+
+// Scale returns p multiplied by k.
+func Scale(p geometry.Point, k float64) geometry.Point {
+	return geometry.Point{X: p.X * k, Y: p.Y * k}
+}
+
+// Dot returns the dot product of p and q: positive when they point the
+// same way, 0 when they are perpendicular, negative when opposite.
+func Dot(p, q geometry.Point) float64 {
+	return p.X*q.X + p.Y*q.Y
+}
+
+// Cross returns the z component of p × q: positive when q turns
+// counterclockwise from p, negative when clockwise, and 0 when they are
+// parallel. Its magnitude is the area of the parallelogram they span.
+func Cross(p, q geometry.Point) float64 {
+	return p.X*q.Y - p.Y*q.X
+}
+
+// Norm returns the length of p.
+func Norm(p geometry.Point) float64 {
+	return math.Hypot(p.X, p.Y)
+}
+
+// Rotate returns p rotated by theta radians counterclockwise about the
+// origin, with the y axis pointing up. In image coordinates, where y
+// points down, the rotation is clockwise.
+func Rotate(p geometry.Point, theta float64) geometry.Point {
+	sin, cos := math.Sincos(theta)
+	return geometry.Point{X: p.X*cos - p.Y*sin, Y: p.X*sin + p.Y*cos}
+}
+
+// RotateAbout returns p rotated by theta radians about center.
+func RotateAbout(p, center geometry.Point, theta float64) geometry.Point {
+	return Add(center, Rotate(Sub(p, center), theta))
+}
+
+// Lerp returns the point a fraction t of the way from p to q: p at 0, q
+// at 1, and beyond them for t outside [0, 1].
+func Lerp(p, q geometry.Point, t float64) geometry.Point {
+	return geometry.Point{X: p.X + t*(q.X-p.X), Y: p.Y + t*(q.Y-p.Y)}
+}
+
+// Near reports whether p and q are at most tol apart in each coordinate.
+func Near(p, q geometry.Point, tol float64) bool {
+	return math.Abs(p.X-q.X) <= tol && math.Abs(p.Y-q.Y) <= tol
+}
@@ -0,0 +1,157 @@
+package vec
+
+import (
+	"math"
+	"testing"
+
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/geometry"
+	"github.com/TheAnarchoX/LearningGoTheHardWay/pkg/proptest"
+	"github.com/stretchr/testify/assert"
+)
+
+const tol = 1e-9
+
+type pt = geometry.Point
+
+func xy(x, y float64) pt { return geometry.Point{X: x, Y: y} }
+
+// assertNear fails unless got is within tol of want in each coordinate.
+func assertNear(t *testing.T, want, got pt, msgAndArgs ...any) {
+	t.Helper()
+	assert.InDelta(t, want.X, got.X, tol, msgAndArgs...)
+	assert.InDelta(t, want.Y, got.Y, tol, msgAndArgs...)
+}
+
+func TestArithmetic(t *testing.T) {
+	tests := []struct {
+		p, q     pt
+		sum, dif pt
+		dot      float64
+		cross    float64
+	}{
+		{pt{}, pt{}, pt{}, pt{}, 0, 0},
+		{xy(1, 0), xy(0, 1), xy(1, 1), xy(1, -1), 0, 1},
+		{xy(0, 1), xy(1, 0), xy(1, 1), xy(-1, 1), 0, -1},
+		{xy(3, 4), xy(3, 4), xy(6, 8), pt{}, 25, 0},
+		{xy(2, -1), xy(-4, 2), xy(-2, 1), xy(6, -3), -10, 0},
+		{xy(1.5, 2.5), xy(-0.5, 4), xy(1, 6.5), xy(2, -1.5), 9.25, 7.25},
+	}
+	for _, tt := range tests {
+		assertNear(t, tt.sum, Add(tt.p, tt.q), "Add(%v, %v)", tt.p, tt.q)
+		assertNear(t, tt.dif, Sub(tt.p, tt.q), "Sub(%v, %v)", tt.p, tt.q)
+		assert.InDelta(t, tt.dot, Dot(tt.p, tt.q), tol, "Dot(%v, %v)", tt.p, tt.q)
+		assert.InDelta(t, tt.cross, Cross(tt.p, tt.q), tol, "Cross(%v, %v)", tt.p, tt.q)
+	}
+}
+
+func TestScaleAndNorm(t *testing.T) {
+	assert.Equal(t, xy(6, -8), Scale(xy(3, -4), 2))
+	assert.Equal(t, xy(-1.5, 2), Scale(xy(3, -4), -0.5))
+	assert.Equal(t, pt{}, Scale(xy(3, -4), 0))
+	assert.Equal(t, 5.0, Norm(xy(3, -4)))
+	assert.Equal(t, 0.0, Norm(pt{}))
+	assert.InDelta(t, math.Sqrt2, Norm(xy(1, 1)), tol)
+	assert.Equal(t, 1e200*math.Sqrt2, Norm(xy(1e200, 1e200)), "no overflow on the way")
+}
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		p     pt
+		theta float64
+		want  pt
+	}{
+		{xy(1, 0), 0, xy(1, 0)},
+		{xy(1, 0), math.Pi / 2, xy(0, 1)},
+		{xy(1, 0), math.Pi, xy(-1, 0)},
+		{xy(1, 0), -math.Pi / 2, xy(0, -1)},
+		{xy(1, 0), 2 * math.Pi, xy(1, 0)},
+		{xy(0, 2), math.Pi / 2, xy(-2, 0)},
+		{xy(1, 1), math.Pi / 4, xy(0, math.Sqrt2)},
+		{xy(3, 4), math.Pi / 3, xy(1.5-2*math.Sqrt(3), 1.5*math.Sqrt(3)+2)},
+		{pt{}, 1, pt{}},
+	}
+	for _, tt := range tests {
+		assertNear(t, tt.want, Rotate(tt.p, tt.theta), "Rotate(%v, %g)", tt.p, tt.theta)
+	}
+
+	assertNear(t, xy(-1, 2), RotateAbout(xy(3, 2), xy(1, 0), math.Pi/2))
+	assertNear(t, xy(5, 5), RotateAbout(xy(5, 5), xy(5, 5), 1), "the center stays put")
+}
+
+func TestLerp(t *testing.T) {
+	p, q := xy(-2, 4), xy(6, 0)
+	assert.Equal(t, p, Lerp(p, q, 0))
+	assert.Equal(t, q, Lerp(p, q, 1))
+	assertNear(t, xy(2, 2), Lerp(p, q, 0.5))
+	assertNear(t, xy(0, 3), Lerp(p, q, 0.25))
+	assertNear(t, xy(10, -2), Lerp(p, q, 1.5), "extrapolates past q")
+	assertNear(t, xy(-6, 6), Lerp(p, q, -0.5), "and before p")
+	assert.Equal(t, p, Lerp(p, p, 0.7))
+}
+
+func TestNear(t *testing.T) {
+	assert.True(t, Near(xy(1, 2), xy(1, 2), 0))
+	assert.True(t, Near(xy(1, 2), xy(1.05, 1.95), 0.1))
+	assert.False(t, Near(xy(1, 2), xy(1.2, 2), 0.1))
+	assert.False(t, Near(xy(1, 2), xy(1, 1.8), 0.1))
+}
+
+// points generates points with coordinates in [-1000, 1000].
+func points() proptest.Gen[pt] {
+	c := proptest.Float64(-1000, 1000)
+	return proptest.Map2(c, c, xy)
+}
+
+// move is two points and a number: an angle, a factor, or a fraction.
+type move struct {
+	p, q pt
+	k    float64
+}
+
+func moves(k proptest.Gen[float64]) proptest.Gen[move] {
+	return proptest.Map3(points(), points(), k, func(p, q pt, k float64) move { return move{p, q, k} })
+}
+
+// near reports whether a and b are equal up to rounding errors in values
+// as large as scale.
+func near(a, b, scale float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, scale)
+}
+
+func TestArithmeticProperties(t *testing.T) {
+	proptest.Check(t, moves(proptest.Float64(-10, 10)), func(m move) bool {
+		p, q, k := m.p, m.q, m.k
+		return Add(p, q) == Add(q, p) &&
+			Near(Sub(Add(p, q), q), p, 1e-9*2000) &&
+			Dot(p, q) == Dot(q, p) &&
+			Cross(p, q) == -Cross(q, p) &&
+			Cross(p, p) == 0 &&
+			near(Norm(p)*Norm(p), Dot(p, p), Dot(p, p)) &&
+			near(Norm(Scale(p, k)), math.Abs(k)*Norm(p), math.Abs(k)*Norm(p)) &&
+			near(Dot(Scale(p, k), q), k*Dot(p, q), math.Abs(k)*Norm(p)*Norm(q))
+	})
+}
+
+func TestRotateProperties(t *testing.T) {
+	proptest.Check(t, moves(proptest.Float64(-2*math.Pi, 2*math.Pi)), func(m move) bool {
+		p, q, theta := m.p, m.q, m.k
+		rp, rq := Rotate(p, theta), Rotate(q, theta)
+		scale := math.Max(1, Norm(p)) * math.Max(1, Norm(q))
+		return near(Norm(rp), Norm(p), Norm(p)) &&
+			Near(Rotate(rp, -theta), p, 1e-9*math.Max(1, Norm(p))) &&
+			near(Dot(rp, rq), Dot(p, q), scale) &&
+			near(Cross(rp, rq), Cross(p, q), scale) &&
+			near(RotateAbout(p, q, theta).Distance(q), p.Distance(q), scale)
+	})
+}
+
+func TestLerpProperties(t *testing.T) {
+	proptest.Check(t, moves(proptest.Float64(0, 1)), func(m move) bool {
+		p, q, frac := m.p, m.q, m.k
+		l := Lerp(p, q, frac)
+		d := p.Distance(q)
+		return near(p.Distance(l), frac*d, d) &&
+			near(p.Distance(l)+l.Distance(q), d, d) &&
+			near(Cross(Sub(l, p), Sub(q, p)), 0, d*d)
+	})
+}
@@ -0,0 +1,133 @@
+package vet
+
+import "strings"
+
+// explanation is the course's take on an analyzer.
+type explanation struct {
+	text   string
+	module string
+}
+
+// explanations covers the go vet analyzers learners run into most.
+var explanations = map[string]explanation{
+	"copylocks": {
+		"You copied a value that contains a sync.Mutex (or another lock). The copy has its own, separate lock, so the original data is no longer protected. Use a pointer receiver, or pass and store a pointer.",
+		"03-concurrency-fundamentals",
+	},
+	"atomic": {
+		"x = atomic.AddInt64(&x, 1) writes x twice: once atomically, then again with a plain assignment that races. Call atomic.AddInt64(&x, 1) on its own, or use atomic.Int64.",
+		"03-concurrency-fundamentals",
+	},
+	"loopclosure": {
+		"A goroutine or deferred function refers to a loop variable. Before Go 1.22 every iteration shared one variable, so the closure sees whatever value it has when it runs. Pass the value as an argument.",
+		"03-concurrency-fundamentals",
+	},
+	"lostcancel": {
+		"The cancel function from context.WithCancel/WithTimeout is not called on every path, so the context and its timer leak until the parent is done. defer cancel() right after creating it.",
+		"07-advanced-concurrency",
+	},
+	"testinggoroutine": {
+		"t.Fatal and t.FailNow stop the goroutine that calls them, not the test. Called from a goroutine you started, they let the test carry on. Send the error back to the test goroutine, or use t.Error.",
+		"05-testing",
+	},
+	"tests": {
+		"A Test, Benchmark, Example, or Fuzz function has the wrong name or signature, so go test silently ignores it. Check the capital letter after the prefix and the parameter type.",
+		"05-testing",
+	},
+	"printf": {
+		"The format verbs do not match the arguments: a %d given a string, a missing argument, or a Println with formatting directives. fmt only notices at run time and prints %!d(string=...) instead.",
+		"01-basics",
+	},
+	"stringintconv": {
+		"string(i) on an integer makes the character with that code point, not the decimal number: string(65) is \"A\". Use strconv.Itoa for digits, or string(rune(i)) if you do want the character.",
+		"01-basics",
+	},
+	"assign": {
+		"A variable is assigned to itself (x = x), which does nothing. Usually the left or right side names the wrong variable, such as a field versus a parameter.",
+		"01-basics",
+	},
+	"unusedresult": {
+		"The result of a function with no side effects is thrown away, for example strings.TrimSpace(s) without s = ... Strings are immutable: these functions return a new value.",
+		"01-basics",
+	},
+	"bools": {
+		"A boolean expression is redundant or always has the same value, like x == 1 || x == 1 or x != 1 || x != 2. Check the operators and operands.",
+		"01-basics",
+	},
+	"nilfunc": {
+		"A function is compared to nil, which is always false for a declared function. You probably meant to call it.",
+		"01-basics",
+	},
+	"unreachable": {
+		"This code can never run because of a return, panic, or infinite loop before it. It is often a leftover from debugging or a misplaced return.",
+		"01-basics",
+	},
+	"shift": {
+		"A shift is at least as wide as the value's type, so the result is always 0 (or -1). Check the shift amount and the operand's type.",
+		"11-bit-manipulation",
+	},
+	"ifaceassert": {
+		"This type assertion can never succeed: the two interfaces have a method with the same name but different signatures, so no type implements both.",
+		"02-types-interfaces",
+	},
+	"stdmethods": {
+		"A method has the name of a well-known interface method (String, Error, MarshalJSON, ...) but the wrong signature, so the type does not implement that interface and fmt, errors, or encoding/json ignore it.",
+		"02-types-interfaces",
+	},
+	"composites": {
+		"A struct literal from another package uses positional fields. Adding a field to that struct will break this code; name the fields.",
+		"02-types-interfaces",
+	},
+	"structtag": {
+		"A struct tag is malformed, for example json: \"name\" with a space or unbalanced quotes. encoding/json ignores a broken tag, so the field is encoded under its Go name.",
+		"02-types-interfaces",
+	},
+	"errorsas": {
+		"The second argument of errors.As must be a pointer to an interface or to a type that implements error. Pass &target, where target is the error type you are looking for.",
+		"04-error-handling",
+	},
+	"httpresponse": {
+		"resp.Body is used (or closed) before the error from the HTTP call is checked. When err is non-nil resp may be nil, and this panics. Check err first.",
+		"04-error-handling",
+	},
+	"defers": {
+		"defer evaluates the arguments of the deferred call immediately, so time.Since(start) is computed when the defer statement runs, not when the function returns. Wrap it in a func() { ... }().",
+		"01-basics",
+	},
+}
+
+// staticcheckCategories explains staticcheck codes by prefix when there
+// is no explanation for the code itself.
+var staticcheckCategories = []struct{ prefix, text string }{
+	{"SA", "staticcheck found a likely bug."},
+	{"S1", "staticcheck found code that can be written more simply."},
+	{"ST", "staticcheck found a style issue; idiomatic Go reads more easily for other Gophers."},
+	{"QF", "staticcheck suggests a quick fix."},
+}
+
+var staticcheckExplanations = map[string]explanation{
+	"SA4006": {"A value is assigned but overwritten or never read. Often an error from a call is assigned to err and then not checked.", "04-error-handling"},
+	"SA5011": {"A pointer is dereferenced even though the code checks it for nil elsewhere, so one of the two is wrong.", "01-basics"},
+	"SA2002": {"t.FailNow or t.Fatal is called from a goroutine other than the test's, so it does not stop the test.", "05-testing"},
+	"ST1005": {"Error strings should not be capitalized or end with punctuation, because they are often wrapped: \"open config: file not found\".", "04-error-handling"},
+	"S1005":  {"A blank identifier is not needed here, as in for x, _ := range s.", "01-basics"},
+}
+
+// Explain fills in f's Explanation and Module when the course has an
+// explanation for its analyzer.
+func Explain(f *Finding) {
+	if e, ok := explanations[f.Analyzer]; ok {
+		f.Explanation, f.Module = e.text, e.module
+		return
+	}
+	if e, ok := staticcheckExplanations[f.Analyzer]; ok {
+		f.Explanation, f.Module = e.text, e.module
+		return
+	}
+	for _, c := range staticcheckCategories {
+		if strings.HasPrefix(f.Analyzer, c.prefix) {
+			f.Explanation = c.text + " See https://staticcheck.dev/docs/checks/#" + f.Analyzer
+			return
+		}
+	}
+}
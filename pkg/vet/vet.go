@@ -0,0 +1,193 @@
+// Package vet runs go vet, and staticcheck when asked, on exercise code
+// and explains each finding the way the course would: what went wrong,
+// why it matters, and which module covers it.
+package vet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Finding is one diagnostic from an analyzer.
+type Finding struct {
+	Analyzer string // go vet analyzer ("copylocks") or staticcheck code ("SA4006")
+	File     string // relative to the directory that was checked
+	Line     int
+	Column   int
+	Message  string // the tool's own message
+	// Explanation says what the message means for a learner; empty for
+	// analyzers the course has no explanation for.
+	Explanation string
+	// Module is the ID of the module that teaches the topic, if any.
+	Module string
+}
+
+// Options selects the tools Run uses.
+type Options struct {
+	// Staticcheck also runs staticcheck, which must be installed.
+	Staticcheck bool
+}
+
+// ErrNoStaticcheck is returned by Run when Options.Staticcheck is set but
+// staticcheck is not in PATH.
+var ErrNoStaticcheck = errors.New("staticcheck not found in PATH (go install honnef.co/go/tools/cmd/staticcheck@latest)")
+
+// Run checks the package in dir, tests included, and returns the findings
+// sorted by position. Code that does not compile is an error, not a
+// finding.
+func Run(ctx context.Context, dir string, opts Options) ([]Finding, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "go", "vet", "-json", ".")
+	cmd.Dir = dir
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("running go vet: %w", err)
+		}
+		return nil, fmt.Errorf("go vet: %s", strings.TrimSpace(stderr.String()))
+	}
+	findings, err := ParseVet(&stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Staticcheck {
+		if _, err := exec.LookPath("staticcheck"); err != nil {
+			return nil, ErrNoStaticcheck
+		}
+		stdout.Reset()
+		stderr.Reset()
+		cmd := exec.CommandContext(ctx, "staticcheck", "-f", "json", ".")
+		cmd.Dir = dir
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		// staticcheck exits 1 when it finds something; only missing output
+		// means it failed.
+		if err := cmd.Run(); err != nil && stdout.Len() == 0 {
+			return nil, fmt.Errorf("staticcheck: %v: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		more, err := ParseStaticcheck(&stdout)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, more...)
+	}
+
+	for i := range findings {
+		f := &findings[i]
+		if rel, err := filepath.Rel(dir, f.File); err == nil && !strings.HasPrefix(rel, "..") {
+			f.File = rel
+		}
+		Explain(f)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return findings, nil
+}
+
+// ParseVet reads the output of `go vet -json`: a stream of objects mapping
+// package to analyzer to diagnostics. A package and its test variant
+// report the same diagnostic twice; duplicates are dropped.
+func ParseVet(r io.Reader) ([]Finding, error) {
+	dec := json.NewDecoder(r)
+	var findings []Finding
+	seen := make(map[Finding]bool)
+	for {
+		var pkgs map[string]map[string]json.RawMessage
+		if err := dec.Decode(&pkgs); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("parsing go vet output: %w", err)
+		}
+		for _, analyzers := range pkgs {
+			for analyzer, raw := range analyzers {
+				var diags []struct {
+					Posn    string `json:"posn"`
+					Message string `json:"message"`
+				}
+				if err := json.Unmarshal(raw, &diags); err != nil {
+					continue // {"error": ...} when the analyzer itself failed
+				}
+				for _, d := range diags {
+					f := Finding{Analyzer: analyzer, Message: d.Message}
+					f.File, f.Line, f.Column = splitPosition(d.Posn)
+					if !seen[f] {
+						seen[f] = true
+						findings = append(findings, f)
+					}
+				}
+			}
+		}
+	}
+	return findings, nil
+}
+
+// ParseStaticcheck reads the output of `staticcheck -f json`: one object
+// per line.
+func ParseStaticcheck(r io.Reader) ([]Finding, error) {
+	dec := json.NewDecoder(r)
+	var findings []Finding
+	for {
+		var d struct {
+			Code     string `json:"code"`
+			Location struct {
+				File   string `json:"file"`
+				Line   int    `json:"line"`
+				Column int    `json:"column"`
+			} `json:"location"`
+			Message string `json:"message"`
+		}
+		if err := dec.Decode(&d); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("parsing staticcheck output: %w", err)
+		}
+		if d.Code == "compile" {
+			return nil, fmt.Errorf("staticcheck: %s:%d: %s", d.Location.File, d.Location.Line, d.Message)
+		}
+		findings = append(findings, Finding{
+			Analyzer: d.Code,
+			File:     d.Location.File,
+			Line:     d.Location.Line,
+			Column:   d.Location.Column,
+			Message:  d.Message,
+		})
+	}
+	return findings, nil
+}
+
+// splitPosition splits "file.go:12:3" into its parts.
+func splitPosition(posn string) (file string, line, col int) {
+	file = posn
+	if i := strings.LastIndexByte(file, ':'); i > 0 {
+		if n, err := strconv.Atoi(file[i+1:]); err == nil {
+			file, col = file[:i], n
+		}
+	}
+	if i := strings.LastIndexByte(file, ':'); i > 0 {
+		if n, err := strconv.Atoi(file[i+1:]); err == nil {
+			file, line = file[:i], n
+		}
+	}
+	if line == 0 { // "file.go:12" only
+		line, col = col, 0
+	}
+	return file, line, col
+}
@@ -0,0 +1,144 @@
+package vet
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vetOutput is what go vet -json prints on standard output for a package
+// and its test variant.
+const vetOutput = `{
+	"example.com/course/exercises": {
+		"copylocks": [
+			{"posn": "/src/exercises/counter.go:13:9", "end": "/src/exercises/counter.go:13:10", "message": "Get passes lock by value: exercises.C contains sync.Mutex"}
+		],
+		"printf": {"error": "analysis skipped"}
+	}
+}
+{
+	"example.com/course/exercises [example.com/course/exercises.test]": {
+		"copylocks": [
+			{"posn": "/src/exercises/counter.go:13:9", "message": "Get passes lock by value: exercises.C contains sync.Mutex"}
+		],
+		"testinggoroutine": [
+			{"posn": "/src/exercises/counter_test.go:5:40", "message": "call to (*testing.T).Fatal from a non-test goroutine"}
+		]
+	}
+}
+`
+
+func TestParseVet(t *testing.T) {
+	findings, err := ParseVet(strings.NewReader(vetOutput))
+	require.NoError(t, err)
+	require.Len(t, findings, 2, "the test variant's duplicate is dropped")
+
+	byAnalyzer := map[string]Finding{}
+	for _, f := range findings {
+		byAnalyzer[f.Analyzer] = f
+	}
+	assert.Equal(t, Finding{
+		Analyzer: "copylocks",
+		File:     "/src/exercises/counter.go",
+		Line:     13,
+		Column:   9,
+		Message:  "Get passes lock by value: exercises.C contains sync.Mutex",
+	}, byAnalyzer["copylocks"])
+	assert.Equal(t, 5, byAnalyzer["testinggoroutine"].Line)
+
+	_, err = ParseVet(strings.NewReader("{"))
+	assert.ErrorContains(t, err, "parsing go vet output")
+}
+
+func TestParseStaticcheck(t *testing.T) {
+	out := `{"code":"SA4006","severity":"error","location":{"file":"/src/a.go","line":7,"column":2},"end":{"file":"/src/a.go","line":7,"column":5},"message":"this value of err is never used"}
+{"code":"ST1005","severity":"error","location":{"file":"/src/a.go","line":9,"column":9},"message":"error strings should not be capitalized"}
+`
+	findings, err := ParseStaticcheck(strings.NewReader(out))
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	assert.Equal(t, Finding{Analyzer: "SA4006", File: "/src/a.go", Line: 7, Column: 2, Message: "this value of err is never used"}, findings[0])
+
+	_, err = ParseStaticcheck(strings.NewReader(`{"code":"compile","location":{"file":"a.go","line":1},"message":"undefined: x"}`))
+	assert.ErrorContains(t, err, "undefined: x")
+}
+
+func TestSplitPosition(t *testing.T) {
+	tests := []struct {
+		posn      string
+		file      string
+		line, col int
+	}{
+		{"/src/a.go:12:3", "/src/a.go", 12, 3},
+		{"/src/a.go:12", "/src/a.go", 12, 0},
+		{`C:\src\a.go:4:1`, `C:\src\a.go`, 4, 1},
+		{"a.go", "a.go", 0, 0},
+	}
+	for _, tt := range tests {
+		file, line, col := splitPosition(tt.posn)
+		assert.Equal(t, []any{tt.file, tt.line, tt.col}, []any{file, line, col}, tt.posn)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	f := Finding{Analyzer: "copylocks"}
+	Explain(&f)
+	assert.Contains(t, f.Explanation, "sync.Mutex")
+	assert.Equal(t, "03-concurrency-fundamentals", f.Module)
+
+	f = Finding{Analyzer: "SA9999"}
+	Explain(&f)
+	assert.Contains(t, f.Explanation, "likely bug")
+	assert.Contains(t, f.Explanation, "https://staticcheck.dev/docs/checks/#SA9999")
+	assert.Empty(t, f.Module)
+
+	f = Finding{Analyzer: "somethingnew"}
+	Explain(&f)
+	assert.Empty(t, f.Explanation)
+}
+
+func writePackage(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files["go.mod"] = "module example.com/vetme\n\ngo 1.21\n"
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go vet")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+	dir := writePackage(t, map[string]string{
+		"counter.go":      "package vetme\n\nimport \"sync\"\n\ntype C struct {\n\tmu sync.Mutex\n\tn  int\n}\n\nfunc (c C) Get() int { return c.n }\n",
+		"counter_test.go": "package vetme\n\nimport \"testing\"\n\nfunc TestC(t *testing.T) {\n\tdone := make(chan bool)\n\tgo func() { t.Fatal(\"no\"); done <- true }()\n\t<-done\n}\n",
+	})
+	findings, err := Run(context.Background(), dir, Options{})
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	assert.Equal(t, "copylocks", findings[0].Analyzer)
+	assert.Equal(t, "counter.go", findings[0].File, "paths are relative to dir")
+	assert.Equal(t, 10, findings[0].Line)
+	assert.NotEmpty(t, findings[0].Explanation)
+	assert.Equal(t, "testinggoroutine", findings[1].Analyzer)
+
+	clean := writePackage(t, map[string]string{"ok.go": "package vetme\n\nfunc OK() int { return 1 }\n"})
+	findings, err = Run(context.Background(), clean, Options{})
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+
+	broken := writePackage(t, map[string]string{"bad.go": "package vetme\n\nfunc Bad() { undefined() }\n"})
+	_, err = Run(context.Background(), broken, Options{})
+	assert.ErrorContains(t, err, "undefined: undefined")
+}
@@ -0,0 +1,217 @@
+// Package watch reports changes to files and directory trees by polling
+// them: it lists the tree every Interval and compares each file's size and
+// modification time with the last listing. Polling needs no inotify,
+// kqueue, or ReadDirectoryChangesW, so it works the same on every system
+// and file system, network mounts and containers included, at the cost of
+// noticing a change up to one Interval late.
+//
+//	w := &watch.Watcher{Paths: []string{"exercises"}, Skip: watch.SkipHidden}
+//	for {
+//		events, err := w.Wait(ctx)
+//		if err != nil {
+//			return err
+//		}
+//		rebuild(events)
+//	}
+//
+// Editors save a file in several steps, and "go generate" writes many, so
+// Wait returns only once the tree has been quiet for Debounce, with the
+// net change since it was called: a file written twice is one Write, and
+// one created and removed again is nothing at all.
+package watch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Op is what happened to a file.
+type Op int
+
+const (
+	Create Op = iota + 1
+	Write
+	Remove
+)
+
+func (op Op) String() string {
+	switch op {
+	case Create:
+		return "CREATE"
+	case Write:
+		return "WRITE"
+	case Remove:
+		return "REMOVE"
+	}
+	return "Op(" + strconv.Itoa(int(op)) + ")"
+}
+
+// Event is a change to one file or directory.
+type Event struct {
+	Path string // as found under one of the Watcher's Paths
+	Op   Op
+}
+
+func (e Event) String() string { return e.Op.String() + " " + e.Path }
+
+// DefaultInterval is the time between polls of a Watcher without an
+// Interval.
+const DefaultInterval = 250 * time.Millisecond
+
+// A Watcher watches files, and directories with everything below them.
+// Set its fields before the first call to Poll or Wait, and do not call
+// its methods from more than one goroutine at a time.
+type Watcher struct {
+	// Paths are the files and directories to watch. A path that does not
+	// exist yet is watched too, and reported when it is created.
+	Paths []string
+	// Interval is the time between polls; DefaultInterval if zero.
+	Interval time.Duration
+	// Debounce is how long the tree must stay unchanged before Wait
+	// returns. Zero returns after the first poll that sees a change.
+	Debounce time.Duration
+	// Skip, if not nil, leaves out the files and directories it returns
+	// true for, and everything below such a directory. It is not called
+	// for Paths themselves.
+	Skip func(path string, d fs.DirEntry) bool
+
+	files map[string]file // the last listing; nil before the first
+}
+
+// file is what a listing records of each path.
+type file struct {
+	size    int64
+	modTime time.Time
+	dir     bool
+}
+
+// SkipHidden skips the files and directories whose names start with a
+// dot, such as .git and editors' swap files.
+func SkipHidden(path string, d fs.DirEntry) bool {
+	return strings.HasPrefix(d.Name(), ".")
+}
+
+// Poll lists the watched paths and returns the changes since the last
+// listing, sorted by path. The first call only takes the listing, and
+// returns none: call it before making changes that Wait should see.
+func (w *Watcher) Poll() ([]Event, error) {
+	files, err := w.list()
+	if err != nil {
+		return nil, err
+	}
+	if w.files == nil {
+		w.files = files
+		return nil, nil
+	}
+	events := diff(w.files, files)
+	w.files = files
+	return events, nil
+}
+
+// Wait polls until the watched paths change and then stay unchanged for
+// Debounce, and returns the net change since it was called. It returns
+// ctx's error if ctx is done first, and the error of a listing that
+// fails.
+func (w *Watcher) Wait(ctx context.Context) ([]Event, error) {
+	if w.files == nil {
+		if _, err := w.Poll(); err != nil {
+			return nil, err
+		}
+	}
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	before := w.files
+	var changed time.Time // of the last poll that saw a change; zero if none has
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+		events, err := w.Poll()
+		if err != nil {
+			return nil, err
+		}
+		if len(events) > 0 {
+			changed = time.Now()
+		}
+		if changed.IsZero() || time.Since(changed) < w.Debounce {
+			continue
+		}
+		if events := diff(before, w.files); len(events) > 0 {
+			return events, nil
+		}
+		changed = time.Time{} // changes that undid themselves
+	}
+}
+
+// list walks the watched paths. Files that disappear during the walk are
+// left out, as if they had gone before it.
+func (w *Watcher) list() (map[string]file, error) {
+	files := make(map[string]file)
+	for _, root := range w.Paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if path != root && w.Skip != nil && w.Skip(path, d) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			info, err := d.Info()
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			files[path] = file{size: info.Size(), modTime: info.ModTime(), dir: d.IsDir()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// diff returns the changes from the listing old to the listing new,
+// sorted by path. A directory's size and time change with its entries,
+// which are reported themselves, so a directory is only ever created or
+// removed.
+func diff(old, new map[string]file) []Event {
+	var events []Event
+	for path, f := range new {
+		o, ok := old[path]
+		switch {
+		case !ok:
+			events = append(events, Event{path, Create})
+		case o.dir != f.dir:
+			events = append(events, Event{path, Remove}, Event{path, Create})
+		case !f.dir && (o.size != f.size || !o.modTime.Equal(f.modTime)):
+			events = append(events, Event{path, Write})
+		}
+	}
+	for path := range old {
+		if _, ok := new[path]; !ok {
+			events = append(events, Event{path, Remove})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Path < events[j].Path })
+	return events
+}
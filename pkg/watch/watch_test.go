@@ -0,0 +1,145 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// write creates or replaces the file at path, and sets its modification
+// time to at, so that tests do not depend on the file system's clock
+// resolution.
+func write(t *testing.T, path, data string, at time.Time) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+	require.NoError(t, os.Chtimes(path, at, at))
+}
+
+var t0 = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestPoll(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.go")
+	write(t, a, "package a", t0)
+	w := &Watcher{Paths: []string{dir}}
+
+	events, err := w.Poll()
+	require.NoError(t, err)
+	assert.Empty(t, events, "the first poll takes the listing")
+
+	b := filepath.Join(dir, "sub", "b.go")
+	write(t, b, "package b", t0)
+	write(t, a, "package a // same size", t0)
+	events, err = w.Poll()
+	require.NoError(t, err)
+	assert.Equal(t, []Event{{a, Write}, {filepath.Dir(b), Create}, {b, Create}}, events)
+
+	write(t, a, "package a // same size", t0.Add(time.Second))
+	require.NoError(t, os.RemoveAll(filepath.Dir(b)))
+	events, err = w.Poll()
+	require.NoError(t, err)
+	assert.Equal(t, []Event{{a, Write}, {filepath.Dir(b), Remove}, {b, Remove}}, events)
+
+	events, err = w.Poll()
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestPollMissingPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	w := &Watcher{Paths: []string{path}}
+	_, err := w.Poll()
+	require.NoError(t, err, "a path that does not exist yet")
+
+	write(t, path, "{}", t0)
+	events, err := w.Poll()
+	require.NoError(t, err)
+	assert.Equal(t, []Event{{path, Create}}, events)
+
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, os.Mkdir(path, 0o755))
+	events, err = w.Poll()
+	require.NoError(t, err)
+	assert.Equal(t, []Event{{path, Remove}, {path, Create}}, events, "a file replaced by a directory")
+}
+
+func TestSkipHidden(t *testing.T) {
+	dir := t.TempDir()
+	w := &Watcher{Paths: []string{dir}, Skip: SkipHidden}
+	_, err := w.Poll()
+	require.NoError(t, err)
+
+	write(t, filepath.Join(dir, ".git", "index"), "x", t0)
+	write(t, filepath.Join(dir, ".main.go.swp"), "x", t0)
+	write(t, filepath.Join(dir, "main.go"), "x", t0)
+	events, err := w.Poll()
+	require.NoError(t, err)
+	assert.Equal(t, []Event{{filepath.Join(dir, "main.go"), Create}}, events)
+}
+
+func TestWaitDebounces(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.go")
+	write(t, a, "package a", t0)
+	w := &Watcher{Paths: []string{dir}, Interval: 5 * time.Millisecond, Debounce: 50 * time.Millisecond}
+	_, err := w.Poll()
+	require.NoError(t, err)
+
+	go func() {
+		// A save in steps, as an editor makes it, each seen by a poll.
+		tmp := filepath.Join(dir, "a.go~")
+		for i, step := range []func(){
+			func() { os.WriteFile(tmp, []byte("package a // new"), 0o644) },
+			func() { os.Rename(tmp, a) },
+			func() { os.Chtimes(a, t0.Add(time.Second), t0.Add(time.Second)) },
+		} {
+			time.Sleep(time.Duration(i+1) * 10 * time.Millisecond)
+			step()
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events, err := w.Wait(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Event{{a, Write}}, events, "one net change, after the save")
+}
+
+func TestWaitIgnoresUndoneChanges(t *testing.T) {
+	dir := t.TempDir()
+	w := &Watcher{Paths: []string{dir}, Interval: 5 * time.Millisecond, Debounce: 30 * time.Millisecond}
+	_, err := w.Poll()
+	require.NoError(t, err)
+
+	tmp := filepath.Join(dir, "tmp")
+	write(t, tmp, "x", t0)
+	go func() {
+		time.Sleep(15 * time.Millisecond)
+		os.Remove(tmp)
+		time.Sleep(100 * time.Millisecond)
+		os.WriteFile(filepath.Join(dir, "b.go"), []byte("package b"), 0o644)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events, err := w.Wait(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Event{{filepath.Join(dir, "b.go"), Create}}, events)
+}
+
+func TestWaitStops(t *testing.T) {
+	w := &Watcher{Paths: []string{t.TempDir()}, Interval: time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := w.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestOpString(t *testing.T) {
+	assert.Equal(t, "WRITE main.go", Event{"main.go", Write}.String())
+	assert.Equal(t, "Op(7)", Op(7).String())
+}